@@ -0,0 +1,27 @@
+// Command ledgerctl is the ledger's operations CLI: it backs up and restores the ledger with
+// integrity checks and rebuilds account balances from the raw entries to recover from failed
+// reconciliation at scale. It runs against the ledger database named by DB_URL:
+//
+//	ledgerctl snapshot [-keep N]                   record every balance summed from its entries
+//	ledgerctl snapshots [-limit N]                 list snapshots, latest first
+//	ledgerctl verify [-snapshot ID|latest] [-all]  replay balances and report drift
+//	ledgerctl repair [-snapshot ID|latest]         replay and reset drifted cached balances
+//	ledgerctl chain [-account ID]                  verify the entry hash chains
+//	ledgerctl backup -file PATH                    dump users, accounts and entries to a new file
+//	ledgerctl restore -file PATH [-check]          load a dump into a freshly migrated database
+//
+// verify exits with status 2 when any cached balance differs from its entries, and chain when any
+// entry was changed, removed or reordered after it was posted. Replaying from a
+// snapshot only sums the entries posted after it; snapshots are summed from the entries too, so
+// take them when verify is clean.
+//
+// A backup is read as of one moment while postings carry on. restore checks the dump's checksum,
+// rechains every entry and reconciles every balance in the transaction that loads it, so a dump
+// that fails leaves the database untouched; -check only verifies the file's checksum.
+package main
+
+import "github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/ledgerctl"
+
+func main() {
+	ledgerctl.Main("ledgerctl")
+}
@@ -0,0 +1,9 @@
+// Command ledgertool is the earlier name of ledgerctl, kept so existing scripts and cron jobs keep
+// working. It runs the same commands against the ledger database named by DB_URL; see ledgerctl.
+package main
+
+import "github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/ledgerctl"
+
+func main() {
+	ledgerctl.Main("ledgertool")
+}
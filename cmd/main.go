@@ -3,17 +3,49 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
+	"crypto/tls"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net"
 	"net/http"
 	"os"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	_ "github.com/PaulBabatuyi/Double-Entry-Bank-Go/docs"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/alert"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
 	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/api"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/archive"
 	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/db"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/flutterwave"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/geo"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/inbound"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/jobs"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/metrics"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/nip"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/notify"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/password"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/paystack"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/pii"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/realtime"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/receipt"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/risk"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/sanctions"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/secrets"
 	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/stripe"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/treasury"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/webhooks"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
@@ -22,7 +54,9 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/rs/zerolog"
 	zlog "github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"golang.org/x/crypto/acme/autocert"
 )
 
 func initLogger() {
@@ -42,40 +76,126 @@ func initLogger() {
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token
 
+// defaultCORSOrigins serve the hosted frontend and local development servers.
+var defaultCORSOrigins = []string{
+	"https://golangbank.app",
+	"http://localhost:3000",
+	"http://127.0.0.1:3000",
+	"http://localhost:5173",
+	"http://127.0.0.1:5173",
+}
+
+// envList reads a comma-separated list from key, falling back to defaults when it is unset or empty.
+func envList(key string, defaults []string) []string {
+	parts := strings.Split(os.Getenv(key), ",")
+	values := make([]string, 0, len(parts))
+	for _, part := range parts {
+		// Normalize each value to avoid accidental whitespace mismatches.
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			values = append(values, trimmed)
+		}
+	}
+	if len(values) == 0 {
+		return defaults
+	}
+	return values
+}
+
 func parseAllowedOrigins() []string {
 	// Allow explicit runtime configuration; defaults are safe for hosted frontend + local dev.
-	origins := os.Getenv("CORS_ALLOWED_ORIGINS")
-	if strings.TrimSpace(origins) == "" {
-		return []string{
-			"https://golangbank.app",
-			"http://localhost:3000",
-			"http://127.0.0.1:3000",
-			"http://localhost:5173",
-			"http://127.0.0.1:5173",
+	return envList("CORS_ALLOWED_ORIGINS", defaultCORSOrigins)
+}
+
+// corsOptions builds the browser access policy from CORS_* settings. Preflight requests are
+// answered by the middleware before routing, so protected routes need no OPTIONS handlers.
+func corsOptions(allowedOrigins []string) (cors.Options, error) {
+	opts := cors.Options{
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: envList("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
+		// If-None-Match revalidates account reads and Last-Event-ID resumes entry streams.
+		AllowedHeaders: envList("CORS_ALLOWED_HEADERS", []string{"Accept", "Authorization", "Content-Type", "If-None-Match", "Last-Event-ID"}),
+		// Content-Disposition names downloaded statements and exports.
+		ExposedHeaders:   envList("CORS_EXPOSED_HEADERS", []string{"Link", "ETag", "Content-Disposition", "X-Request-Id"}),
+		AllowCredentials: true,
+		MaxAge:           300,
+	}
+	if v := strings.TrimSpace(os.Getenv("CORS_ALLOW_CREDENTIALS")); v != "" {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return cors.Options{}, fmt.Errorf("CORS_ALLOW_CREDENTIALS must be true or false: %w", err)
 		}
+		opts.AllowCredentials = allow
 	}
-
-	parts := strings.Split(origins, ",")
-	allowed := make([]string, 0, len(parts))
-	for _, origin := range parts {
-		// Normalize each origin to avoid accidental whitespace mismatches.
-		trimmed := strings.TrimSpace(origin)
-		if trimmed != "" {
-			allowed = append(allowed, trimmed)
+	if v := strings.TrimSpace(os.Getenv("CORS_MAX_AGE")); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds < 0 {
+			return cors.Options{}, errors.New("CORS_MAX_AGE must be a non-negative number of seconds")
 		}
+		opts.MaxAge = seconds
+	}
+	// A wildcard origin with credentials would let any site act with a signed-in browser's session.
+	if opts.AllowCredentials && slices.Contains(allowedOrigins, "*") {
+		return cors.Options{}, errors.New("CORS_ALLOWED_ORIGINS=* requires CORS_ALLOW_CREDENTIALS=false")
 	}
+	return opts, nil
+}
 
-	if len(allowed) == 0 {
-		return []string{
-			"https://golangbank.app",
-			"http://localhost:3000",
-			"http://127.0.0.1:3000",
-			"http://localhost:5173",
-			"http://127.0.0.1:5173",
-		}
+// envDuration reads a Go duration such as 10s from key, falling back to def when it is unset.
+func envDuration(key string, def time.Duration) time.Duration {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return def
 	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		zlog.Fatal().Str(key, v).Msg("Invalid duration; use a positive value such as 10s")
+	}
+	return d
+}
+
+// newSecretLoader connects to the secret store named by SECRETS_PROVIDER (vault, aws or gcp),
+// caching values for SECRETS_CACHE_TTL. It returns nil when secrets come from the environment.
+func newSecretLoader(ctx context.Context) (*secrets.Loader, error) {
+	var (
+		provider secrets.Provider
+		err      error
+	)
+	switch p := strings.ToLower(strings.TrimSpace(os.Getenv("SECRETS_PROVIDER"))); p {
+	case "":
+		return nil, nil
+	case "vault":
+		provider, err = secrets.NewVault(os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN"), os.Getenv("VAULT_KV_MOUNT"))
+	case "aws":
+		provider, err = secrets.NewAWSSecretsManager(ctx, os.Getenv("AWS_REGION"))
+	case "gcp":
+		provider, err = secrets.NewGCPSecretManager(os.Getenv("GCP_PROJECT"), os.Getenv("GCP_ACCESS_TOKEN"))
+	default:
+		return nil, fmt.Errorf("unknown SECRETS_PROVIDER %q", p)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secrets.NewLoader(provider, envDuration("SECRETS_CACHE_TTL", secrets.DefaultTTL)), nil
+}
 
-	return allowed
+// secretEnv reads key from the secret store when key_REF names a secret there (e.g.
+// JWT_SECRET_REF=prod/ledger#jwt_secret), and from the environment otherwise. A reference that
+// cannot be loaded is fatal rather than falling back to the environment.
+func secretEnv(loader *secrets.Loader, key string) string {
+	ref := strings.TrimSpace(os.Getenv(key + "_REF"))
+	if ref == "" {
+		return os.Getenv(key)
+	}
+	if loader == nil {
+		zlog.Fatal().Str("variable", key+"_REF").Msg("SECRETS_PROVIDER is required for secret references")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	value, err := loader.Get(ctx, ref)
+	if err != nil {
+		zlog.Fatal().Err(err).Str("variable", key+"_REF").Msg("Failed to load secret")
+	}
+	return value
 }
 
 func resolveDBURL() string {
@@ -125,6 +245,69 @@ func resolveDBURL() string {
 	return connStr
 }
 
+func newEmailSender(ctx context.Context) (notify.EmailSender, error) {
+	// Default to logging so local development never needs a mail relay.
+	from := os.Getenv("NOTIFY_FROM_EMAIL")
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("NOTIFY_EMAIL_PROVIDER"))) {
+	case "smtp":
+		return notify.NewSMTPSender(notify.SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     os.Getenv("SMTP_PORT"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     from,
+		})
+	case "ses":
+		return notify.NewSESSender(ctx, os.Getenv("AWS_REGION"), from)
+	default:
+		return notify.LogEmailSender{}, nil
+	}
+}
+
+func newSMSSender() (notify.SMSSender, error) {
+	// SMS is opt-in per deployment; nil disables the channel entirely.
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("NOTIFY_SMS_PROVIDER"))) {
+	case "twilio":
+		return notify.NewTwilioSender(os.Getenv("TWILIO_ACCOUNT_SID"), os.Getenv("TWILIO_AUTH_TOKEN"), os.Getenv("TWILIO_FROM_NUMBER"))
+	case "termii":
+		return notify.NewTermiiSender(os.Getenv("TERMII_API_KEY"), os.Getenv("TERMII_SENDER_ID"))
+	case "log":
+		return notify.LogSMSSender{}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func newAlertSender() (alert.Sender, error) {
+	// Every configured channel gets each alert; with none, integrity alerts are only logged.
+	var senders alert.Multi
+	if key := os.Getenv("ALERT_PAGERDUTY_ROUTING_KEY"); key != "" {
+		s, err := alert.NewPagerDutySender(key)
+		if err != nil {
+			return nil, err
+		}
+		senders = append(senders, s)
+	}
+	if url := os.Getenv("ALERT_SLACK_WEBHOOK_URL"); url != "" {
+		s, err := alert.NewSlackSender(url)
+		if err != nil {
+			return nil, err
+		}
+		senders = append(senders, s)
+	}
+	if url := os.Getenv("ALERT_WEBHOOK_URL"); url != "" {
+		s, err := alert.NewWebhookSender(url, os.Getenv("ALERT_WEBHOOK_SECRET"))
+		if err != nil {
+			return nil, err
+		}
+		senders = append(senders, s)
+	}
+	if len(senders) == 0 {
+		return alert.LogSender{}, nil
+	}
+	return senders, nil
+}
+
 func main() {
 	// Capture startup time so health endpoint can report uptime.
 	startTime := time.Now()
@@ -135,18 +318,42 @@ func main() {
 		zlog.Warn().Err(err).Msg("No .env file found – using system env")
 	}
 
-	if err := api.InitTokenAuthFromEnv(); err != nil {
-		zlog.Fatal().Err(err).Msg("Failed to initialize JWT auth")
+	// SECRETS_PROVIDER loads JWT_SECRET, DB_URL and the PII keys from a secret store when their
+	// *_REF variable names a secret; without it, or without a reference, they come from the environment.
+	secretLoader, err := newSecretLoader(context.Background())
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to configure secret store")
 	}
 
-	// Build DB connection string and validate connectivity before serving traffic.
-	connStr := resolveDBURL()
-	if strings.Contains(connStr, "@localhost:") || strings.Contains(connStr, "@127.0.0.1:") || strings.Contains(connStr, "@[::1]:") {
-		zlog.Warn().Msg("Using localhost DB_URL; this is only valid for local development")
+	// JWT_SIGNING_KEY (RSA or Ed25519, PEM) signs tokens others can verify through the JWKS;
+	// JWT_VERIFICATION_KEYS keeps the previous key's tokens valid after a rotation, and JWT_SECRET,
+	// if still set, keeps HS256 tokens valid until they expire. Without it tokens use JWT_SECRET.
+	if signingKey := secretEnv(secretLoader, "JWT_SIGNING_KEY"); signingKey != "" {
+		if err := api.InitTokenKeys(signingKey, secretEnv(secretLoader, "JWT_VERIFICATION_KEYS"), secretEnv(secretLoader, "JWT_SECRET")); err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to initialize JWT signing keys")
+		}
+	} else if err := api.InitTokenAuth(secretEnv(secretLoader, "JWT_SECRET")); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to initialize JWT auth")
 	}
-	dbConn, err := sql.Open("postgres", connStr)
-	if err != nil {
-		zlog.Fatal().Err(err).Msg("Failed to open DB connection")
+
+	// Build DB connection string and validate connectivity before serving traffic. A DB_URL held in
+	// the secret store is re-read for new connections, so rotated credentials need no restart.
+	var dbConn *sql.DB
+	if ref := strings.TrimSpace(os.Getenv("DB_URL_REF")); ref != "" {
+		// Resolve once up front so a bad reference fails at startup.
+		secretEnv(secretLoader, "DB_URL")
+		dbConn = sql.OpenDB(db.NewRotatingConnector(func(ctx context.Context) (string, error) {
+			return secretLoader.Get(ctx, ref)
+		}))
+		dbConn.SetConnMaxLifetime(30 * time.Minute)
+	} else {
+		connStr := resolveDBURL()
+		if strings.Contains(connStr, "@localhost:") || strings.Contains(connStr, "@127.0.0.1:") || strings.Contains(connStr, "@[::1]:") {
+			zlog.Warn().Msg("Using localhost DB_URL; this is only valid for local development")
+		}
+		if dbConn, err = sql.Open("postgres", connStr); err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to open DB connection")
+		}
 	}
 
 	pingCtx, pingCancel := context.WithTimeout(context.Background(), 15*time.Second)
@@ -162,26 +369,507 @@ func main() {
 		}
 	}()
 
-	store := db.NewStore(dbConn)
-	ledgerSvc := service.NewLedgerService(store)
+	// DB_TX_TIMEOUT bounds each ledger transaction attempt, including in background jobs.
+	store := db.NewStore(dbConn, db.WithTxTimeout(envDuration("DB_TX_TIMEOUT", 30*time.Second)))
+
+	// PII_KEYS seals phones and KYC document details at rest: comma-separated id:base64 master keys,
+	// the first of which seals new values. PII_INDEX_KEY keys the blind index phones are found by.
+	var piiKeys *pii.Keyring
+	if spec := strings.TrimSpace(secretEnv(secretLoader, "PII_KEYS")); spec != "" {
+		keyring, err := pii.ParseKeyring(spec, secretEnv(secretLoader, "PII_INDEX_KEY"))
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Invalid PII_KEYS or PII_INDEX_KEY")
+		}
+		piiKeys = keyring
+	}
+
+	// Committed ledger events fan out to notification channels after each commit.
+	bus := events.NewBus()
+	emailSender, err := newEmailSender(context.Background())
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to configure email sender")
+	}
+	notifyOpts := []notify.Option{notify.WithPII(piiKeys)}
+	smsSender, err := newSMSSender()
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to configure SMS sender")
+	}
+	if smsSender != nil {
+		notifyOpts = append(notifyOpts, notify.WithSMS(smsSender))
+	}
+	notifier := notify.NewNotifier(store, emailSender, notifyOpts...)
+	bus.Subscribe("email", notifier.HandleEvent)
+
+	// Live WebSocket clients receive the same committed events.
+	hub := realtime.NewHub()
+	bus.Subscribe("realtime", hub.HandleEvent)
+
+	// Organization webhook endpoints get a signed delivery per event, sent by the job runner below.
+	webhookDispatcher := webhooks.NewDispatcher(store)
+	bus.Subscribe("webhooks", webhookDispatcher.HandleEvent)
+
+	// Exchange rates: FX_PROVIDER pulls hourly for each FX_BASE_CURRENCIES base; admins can override pairs either way.
+	var ratesOpts []rates.Option
+	switch provider := strings.TrimSpace(os.Getenv("FX_PROVIDER")); provider {
+	case "":
+	case "open-er-api":
+		bases := []string{"USD"}
+		if v := strings.TrimSpace(os.Getenv("FX_BASE_CURRENCIES")); v != "" {
+			bases = strings.Split(v, ",")
+		}
+		ratesOpts = append(ratesOpts, rates.WithProvider(rates.NewOpenERAPI(), bases...))
+	default:
+		zlog.Fatal().Str("provider", provider).Msg("Unknown FX_PROVIDER")
+	}
+	ratesSvc := rates.NewService(store, ratesOpts...)
+
+	// Conversions price off ratesSvc; FX_SPREAD_BPS is the spread on pairs without one set by an admin.
+	var spreadBps int32
+	if v := strings.TrimSpace(os.Getenv("FX_SPREAD_BPS")); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n < 0 || n > 5000 {
+			zlog.Fatal().Str("FX_SPREAD_BPS", v).Msg("FX_SPREAD_BPS must be an integer from 0 to 5000")
+		}
+		spreadBps = int32(n)
+	}
+
+	ledgerOpts := []service.Option{service.WithPublisher(bus), service.WithFX(ratesSvc, spreadBps), service.WithPII(piiKeys)}
+	if os.Getenv("KYC_ENFORCED") == "true" {
+		// Withdrawals and bank payouts are capped by the owner's approved KYC level.
+		ledgerOpts = append(ledgerOpts, service.WithKYCLimits(service.DefaultKYCLimits()))
+	}
+	// AML_REPORTING_THRESHOLD turns on AML screening with the built-in rules tuned around that amount;
+	// AML_RULE_ACTIONS overrides what each rule does, e.g. "structuring=block,round_amount_burst=hold".
+	if v := os.Getenv("AML_REPORTING_THRESHOLD"); v != "" {
+		threshold, err := decimal.NewFromString(v)
+		if err != nil || !threshold.IsPositive() {
+			zlog.Fatal().Str("value", v).Msg("Invalid AML_REPORTING_THRESHOLD")
+		}
+		rules, err := aml.ApplyActions(aml.DefaultRules(threshold), os.Getenv("AML_RULE_ACTIONS"))
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Invalid AML_RULE_ACTIONS")
+		}
+		ledgerOpts = append(ledgerOpts, service.WithAMLRules(aml.NewEngine(rules...)))
+	}
+	// RISK_SCORING=true scores withdrawals, transfers and payouts for fraud. Scores of
+	// RISK_CHALLENGE_SCORE need a request signed with an account key; RISK_REVIEW_SCORE a manual review.
+	if os.Getenv("RISK_SCORING") == "true" {
+		cfg := risk.DefaultConfig()
+		for name, score := range map[string]*int{"RISK_CHALLENGE_SCORE": &cfg.ChallengeAt, "RISK_REVIEW_SCORE": &cfg.ReviewAt} {
+			if v := os.Getenv(name); v != "" {
+				n, err := strconv.Atoi(v)
+				if err != nil || n < 1 || n > 101 {
+					zlog.Fatal().Str(name, v).Msg(name + " must be an integer from 1 to 101")
+				}
+				*score = n
+			}
+		}
+		ledgerOpts = append(ledgerOpts, service.WithRiskScoring(risk.NewScorer(cfg)))
+	}
+	// FEE_REVERSAL_POLICY decides whether reversing a transaction refunds its fees: refund (default) or retain.
+	feePolicy, err := service.ParseFeeReversalPolicy(strings.TrimSpace(os.Getenv("FEE_REVERSAL_POLICY")))
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Invalid FEE_REVERSAL_POLICY")
+	}
+	ledgerOpts = append(ledgerOpts, service.WithFeeReversal(feePolicy))
+	// ADJUSTMENT_DUAL_CONTROL holds manual adjustments until a second admin approves them.
+	if os.Getenv("ADJUSTMENT_DUAL_CONTROL") == "true" {
+		ledgerOpts = append(ledgerOpts, service.WithAdjustmentApproval(true))
+	}
+	// ERASURE_GRACE_PERIOD is how long erasure requests can be cancelled before users are anonymized.
+	ledgerOpts = append(ledgerOpts, service.WithErasureGracePeriod(envDuration("ERASURE_GRACE_PERIOD", service.DefaultErasureGracePeriod)))
+	// ENTRY_ARCHIVE_BUCKET turns on archival of entries older than ENTRY_ARCHIVE_AFTER_MONTHS whole
+	// months to an S3 bucket with Object Lock, each segment locked for ENTRY_ARCHIVE_RETAIN_YEARS.
+	// ENTRY_ARCHIVE_ENDPOINT points at an S3-compatible store instead of AWS. ENTRY_RETENTION_MONTHS
+	// prunes archived entries from Postgres once they are that many whole months old.
+	if bucket := os.Getenv("ENTRY_ARCHIVE_BUCKET"); bucket != "" {
+		archiveStore, err := archive.NewS3Store(context.Background(), archive.S3Config{
+			Bucket:    bucket,
+			Region:    os.Getenv("AWS_REGION"),
+			Endpoint:  os.Getenv("ENTRY_ARCHIVE_ENDPOINT"),
+			PathStyle: os.Getenv("ENTRY_ARCHIVE_PATH_STYLE") == "true",
+		})
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to initialize entry archive")
+		}
+		policy := service.ArchivePolicy{AfterMonths: 24, RetainYears: 7}
+		for name, n := range map[string]*int{"ENTRY_ARCHIVE_AFTER_MONTHS": &policy.AfterMonths, "ENTRY_ARCHIVE_RETAIN_YEARS": &policy.RetainYears} {
+			if v := os.Getenv(name); v != "" {
+				if *n, err = strconv.Atoi(v); err != nil || *n < 1 {
+					zlog.Fatal().Str(name, v).Msg(name + " must be a positive integer")
+				}
+			}
+		}
+		if v := os.Getenv("ENTRY_RETENTION_MONTHS"); v != "" {
+			if policy.PruneAfterMonths, err = strconv.Atoi(v); err != nil || policy.PruneAfterMonths < policy.AfterMonths {
+				zlog.Fatal().Str("ENTRY_RETENTION_MONTHS", v).Msg("ENTRY_RETENTION_MONTHS must be an integer no less than ENTRY_ARCHIVE_AFTER_MONTHS")
+			}
+		}
+		ledgerOpts = append(ledgerOpts, service.WithArchive(archiveStore, policy))
+	}
+	// ALERT_PAGERDUTY_ROUTING_KEY, ALERT_SLACK_WEBHOOK_URL and ALERT_WEBHOOK_URL choose where
+	// balance mismatches found by reconciliation and the projection check are sent.
+	alertSender, err := newAlertSender()
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to configure integrity alerts")
+	}
+	ledgerOpts = append(ledgerOpts, service.WithIntegrityAlerts(alertSender))
+	ledgerSvc := service.NewLedgerService(store, ledgerOpts...)
+	// SETTLEMENT_SHARDS splits the settlement account into that many shards, so concurrent
+	// deposits and withdrawals lock different rows. Lowering it later keeps the shards already made.
+	if v := os.Getenv("SETTLEMENT_SHARDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > service.MaxSettlementShards {
+			zlog.Fatal().Str("SETTLEMENT_SHARDS", v).Msgf("SETTLEMENT_SHARDS must be an integer from 1 to %d", service.MaxSettlementShards)
+		}
+		if err := ledgerSvc.EnsureSettlementShards(context.Background(), n); err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to create settlement shards")
+		}
+	}
+
+	// Cash pools zero member accounts into their master through the ledger's transfer postings.
+	treasurySvc := treasury.NewService(store, ledgerSvc)
 
 	// Wire HTTP handlers with service and persistence dependencies.
-	h := api.NewHandler(ledgerSvc, store)
+	allowedOrigins := parseAllowedOrigins()
+	handlerOpts := []api.Option{api.WithRealtime(hub, allowedOrigins), api.WithRates(ratesSvc), api.WithTreasury(treasurySvc), api.WithPII(piiKeys), api.WithSecurityAlerts(emailSender)}
+	// SUPPORTED_CURRENCIES lists the ISO 4217 currencies accounts are opened in. Unset, it is the
+	// currencies with settlement and payout clearing accounts, so deposits and withdrawals can post.
+	settled, err := store.ListSettlementCurrencies(context.Background())
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to list settlement currencies")
+	}
+	codes := envList("SUPPORTED_CURRENCIES", nil)
+	for i, code := range codes {
+		normalized, err := rates.NormalizeCurrency(code)
+		if err != nil {
+			zlog.Fatal().Err(err).Str("currency", code).Msg("Invalid SUPPORTED_CURRENCIES")
+		}
+		codes[i] = normalized
+		if !slices.Contains(settled, normalized) {
+			zlog.Warn().Str("currency", normalized).Msg("SUPPORTED_CURRENCIES includes a currency without settlement and payout clearing accounts; its deposits and withdrawals will fail")
+		}
+	}
+	if len(codes) == 0 {
+		if len(settled) == 0 {
+			zlog.Fatal().Msg("No currency has settlement and payout clearing accounts; set SUPPORTED_CURRENCIES")
+		}
+		codes = settled
+	}
+	handlerOpts = append(handlerOpts, api.WithCurrencies(codes))
+	// LOGIN_MAX_FAILURES wrong passwords lock a user out for LOGIN_LOCKOUT; LOGIN_IP_MAX_FAILURES
+	// failures from one address within LOGIN_IP_WINDOW turn it away. Unset ones keep the defaults.
+	loginPolicy := api.LoginPolicy{
+		LockDuration: envDuration("LOGIN_LOCKOUT", api.DefaultLoginPolicy.LockDuration),
+		IPWindow:     envDuration("LOGIN_IP_WINDOW", api.DefaultLoginPolicy.IPWindow),
+	}
+	loginPolicy.MaxFailures, _ = strconv.Atoi(os.Getenv("LOGIN_MAX_FAILURES"))
+	loginPolicy.IPMaxFailures, _ = strconv.Atoi(os.Getenv("LOGIN_IP_MAX_FAILURES"))
+	handlerOpts = append(handlerOpts, api.WithLoginPolicy(loginPolicy))
+	// NAME_ENQUIRY_MAX enquiries by one user, or NAME_ENQUIRY_IP_MAX from one address, within
+	// NAME_ENQUIRY_WINDOW turn further ones away. Unset ones keep the defaults.
+	enquiryPolicy := api.NameEnquiryPolicy{Window: envDuration("NAME_ENQUIRY_WINDOW", api.DefaultNameEnquiryPolicy.Window)}
+	enquiryPolicy.MaxPerUser, _ = strconv.Atoi(os.Getenv("NAME_ENQUIRY_MAX"))
+	enquiryPolicy.MaxPerIP, _ = strconv.Atoi(os.Getenv("NAME_ENQUIRY_IP_MAX"))
+	handlerOpts = append(handlerOpts, api.WithNameEnquiryPolicy(enquiryPolicy))
+	// PASSWORD_MIN_LENGTH and PASSWORD_REQUIRE (e.g. upper,lower,digit,symbol) set the password
+	// policy; PASSWORD_BREACH_CHECK=true also refuses passwords listed by Have I Been Pwned.
+	passwordPolicy := password.DefaultPolicy()
+	if v, err := strconv.Atoi(os.Getenv("PASSWORD_MIN_LENGTH")); err == nil && v > 0 {
+		passwordPolicy.MinLength = v
+	}
+	if passwordPolicy.Require, err = password.ParseClasses(os.Getenv("PASSWORD_REQUIRE")); err != nil {
+		zlog.Fatal().Err(err).Msg("Invalid PASSWORD_REQUIRE")
+	}
+	if os.Getenv("PASSWORD_BREACH_CHECK") == "true" {
+		passwordPolicy.Breaches = password.NewPwnedPasswords()
+	}
+	handlerOpts = append(handlerOpts, api.WithPasswordPolicy(passwordPolicy))
+	// PASSWORD_HASH=argon2id hashes new passwords with argon2id, tuned by ARGON2_MEMORY (KiB),
+	// ARGON2_ITERATIONS and ARGON2_PARALLELISM; bcrypt hashes are replaced as users log in.
+	argon2Params := password.DefaultArgon2Params()
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_MEMORY"), 10, 32); err == nil {
+		argon2Params.Memory = uint32(v)
+	}
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_ITERATIONS"), 10, 32); err == nil {
+		argon2Params.Iterations = uint32(v)
+	}
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_PARALLELISM"), 10, 8); err == nil {
+		argon2Params.Parallelism = uint8(v)
+	}
+	passwordHasher, err := password.NewHasher(os.Getenv("PASSWORD_HASH"), argon2Params)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Invalid password hash settings")
+	}
+	handlerOpts = append(handlerOpts, api.WithPasswordHasher(passwordHasher))
+	// SIGNED_TRANSFER_THRESHOLD requires transfers above that amount to be signed with a key
+	// registered on the source account, on top of the bearer token.
+	if v := os.Getenv("SIGNED_TRANSFER_THRESHOLD"); v != "" {
+		threshold, err := decimal.NewFromString(v)
+		if err != nil || threshold.IsNegative() {
+			zlog.Fatal().Str("value", v).Msg("Invalid SIGNED_TRANSFER_THRESHOLD")
+		}
+		handlerOpts = append(handlerOpts, api.WithSignedTransfers(threshold))
+	}
+	// SANCTIONS_LIST_FILE screens registering users and external transfer beneficiaries against a
+	// "list,name" CSV; matches scoring SANCTIONS_MIN_SCORE or more wait for compliance review.
+	if path := strings.TrimSpace(os.Getenv("SANCTIONS_LIST_FILE")); path != "" {
+		minScore := sanctions.DefaultMinScore
+		if v := os.Getenv("SANCTIONS_MIN_SCORE"); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil || f <= 0 || f > 1 {
+				zlog.Fatal().Str("value", v).Msg("SANCTIONS_MIN_SCORE must be a number in (0, 1]")
+			}
+			minScore = f
+		}
+		list, err := sanctions.LoadList(path, minScore)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to load SANCTIONS_LIST_FILE")
+		}
+		zlog.Info().Int("entries", list.Len()).Msg("Sanctions screening enabled")
+		handlerOpts = append(handlerOpts, api.WithSanctionsScreening(list, envDuration("SANCTIONS_CACHE_TTL", api.DefaultSanctionsCacheTTL)))
+	}
+	// GEO_COUNTRY_HEADER names a country header set by a trusted proxy (e.g. CF-IPCountry);
+	// GEO_IP_RANGES_FILE is a CSV of "first_ip,last_ip,country" rows to look client addresses up in
+	// instead. Either tracks the countries users act from: a new one within GEO_ANOMALY_WINDOW of
+	// activity elsewhere is emailed to the user and scored as a fraud risk signal.
+	var locator geo.Locator
+	if header := strings.TrimSpace(os.Getenv("GEO_COUNTRY_HEADER")); header != "" {
+		locator = geo.HeaderLocator(header)
+	} else if path := os.Getenv("GEO_IP_RANGES_FILE"); path != "" {
+		ranges, err := geo.LoadRanges(path)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to load GEO_IP_RANGES_FILE")
+		}
+		zlog.Info().Int("ranges", ranges.Len()).Msg("IP geolocation enabled")
+		locator = ranges
+	}
+	if locator != nil {
+		handlerOpts = append(handlerOpts, api.WithGeoLocation(locator, envDuration("GEO_ANOMALY_WINDOW", api.DefaultGeoAnomalyWindow)))
+	}
+	// LEGACY_LIST_RESPONSES=true keeps paged list endpoints answering with bare arrays for old clients.
+	if os.Getenv("LEGACY_LIST_RESPONSES") == "true" {
+		handlerOpts = append(handlerOpts, api.WithBareLists())
+	}
+
+	// Background jobs: features register kinds and schedules here; the runner persists and retries them.
+	jobRunner := jobs.NewRunner(store, 2)
+	jobRunner.Register(jobs.KindPurgeSucceeded, jobs.PurgeSucceeded(store, 7*24*time.Hour))
+	if err := jobRunner.Schedule("purge-succeeded-jobs", "@daily", jobs.KindPurgeSucceeded, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule job purge")
+	}
+	jobRunner.Register(webhooks.KindDispatch, webhookDispatcher.Dispatch)
+	if err := jobRunner.Schedule("webhook-dispatch", "@every 15s", webhooks.KindDispatch, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule webhook dispatch")
+	}
+
+	// RECEIPT_BRAND_NAME and RECEIPT_BRAND_COLOR (#RRGGBB) brand the PDF transaction receipts.
+	brand := receipt.DefaultBrand
+	if name := strings.TrimSpace(os.Getenv("RECEIPT_BRAND_NAME")); name != "" {
+		brand.Name = name
+	}
+	if color := strings.TrimSpace(os.Getenv("RECEIPT_BRAND_COLOR")); color != "" {
+		c, err := receipt.ParseColor(color)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Invalid RECEIPT_BRAND_COLOR")
+		}
+		brand.Color = c
+	}
+	handlerOpts = append(handlerOpts, api.WithReceiptBrand(brand))
+
+	// Payment request links are absolute under PUBLIC_BASE_URL when it is set.
+	if baseURL := strings.TrimSpace(os.Getenv("PUBLIC_BASE_URL")); baseURL != "" {
+		handlerOpts = append(handlerOpts, api.WithPaymentLinks(baseURL))
+	}
+
+	// Last month's statements go out at 06:00 UTC on the 1st; STATEMENT_LINK_SECRET enables signed download links.
+	var statementOpts []notify.StatementOption
+	if secret := strings.TrimSpace(os.Getenv("STATEMENT_LINK_SECRET")); secret != "" {
+		baseURL := strings.TrimSpace(os.Getenv("PUBLIC_BASE_URL"))
+		if baseURL == "" {
+			zlog.Fatal().Msg("PUBLIC_BASE_URL is required when STATEMENT_LINK_SECRET is set")
+		}
+		statementOpts = append(statementOpts, notify.WithStatementLinks(baseURL, []byte(secret)))
+		handlerOpts = append(handlerOpts, api.WithStatementLinks([]byte(secret)))
+	}
+	// STATEMENT_SIGNING_KEY (RSA or Ed25519, PEM) signs every camt.053 statement served, so
+	// recipients can check it against /.well-known/statement-keys.json; STATEMENT_VERIFICATION_KEYS
+	// keeps statements signed with a retired key verifiable.
+	if signingKey := secretEnv(secretLoader, "STATEMENT_SIGNING_KEY"); signingKey != "" {
+		signer, err := api.NewStatementSigner(signingKey, secretEnv(secretLoader, "STATEMENT_VERIFICATION_KEYS"))
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to initialize statement signing")
+		}
+		handlerOpts = append(handlerOpts, api.WithStatementSigner(signer))
+	}
+	statementMailer := notify.NewStatementMailer(store, emailSender, statementOpts...)
+	jobRunner.Register(notify.KindMonthlyStatements, statementMailer.SendMonthly)
+	if err := jobRunner.Schedule("monthly-statements", "0 6 1 * *", notify.KindMonthlyStatements, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule monthly statements")
+	}
+
+	jobRunner.Register(rates.KindRefresh, ratesSvc.Refresh)
+	if err := jobRunner.Schedule("refresh-fx-rates", "@hourly", rates.KindRefresh, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule exchange rate refresh")
+	}
+
+	// Interest for the previous month is paid once it has closed.
+	jobRunner.Register(service.KindPayInterest, ledgerSvc.PayInterest)
+	if err := jobRunner.Schedule("pay-interest", "0 2 1 * *", service.KindPayInterest, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule interest payments")
+	}
+
+	// Savings goal contributions fall due at the time of day the rule was set up.
+	jobRunner.Register(service.KindSavingsContributions, ledgerSvc.ContributeSavings)
+	if err := jobRunner.Schedule("savings-contributions", "@hourly", service.KindSavingsContributions, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule savings contributions")
+	}
+
+	// Sweep rules fall due at midnight UTC; the hourly run catches up after downtime.
+	jobRunner.Register(service.KindSweeps, ledgerSvc.RunSweeps)
+	if err := jobRunner.Schedule("sweeps", "@hourly", service.KindSweeps, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule sweeps")
+	}
+
+	// Cash pool members are zeroed into their master at 23:55 UTC, so each day ends at their targets.
+	jobRunner.Register(treasury.KindZeroBalance, treasurySvc.ZeroBalances)
+	if err := jobRunner.Schedule("cash-pool-zero-balance", "55 23 * * *", treasury.KindZeroBalance, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule cash pool zero balancing")
+	}
+
+	// Merkle roots over the entries posted since the last one are published hourly.
+	jobRunner.Register(service.KindMerkleRoots, ledgerSvc.PublishMerkleRoots)
+	if err := jobRunner.Schedule("merkle-roots", "@hourly", service.KindMerkleRoots, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule Merkle roots")
+	}
+
+	// Aged entries are archived monthly; a failed month is retried on the next run.
+	jobRunner.Register(service.KindArchiveEntries, ledgerSvc.ArchiveEntries)
+	if err := jobRunner.Schedule("archive-entries", "0 3 2 * *", service.KindArchiveEntries, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule entry archival")
+	}
+	// Archived months are pruned after archival has had its run; a blocked month waits a month.
+	jobRunner.Register(service.KindPruneEntries, ledgerSvc.PruneEntries)
+	if err := jobRunner.Schedule("prune-entries", "0 5 2 * *", service.KindPruneEntries, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule entry pruning")
+	}
+
+	// Loan delinquency ages by whole days past due, so once a day is enough.
+	jobRunner.Register(service.KindLoanDelinquency, ledgerSvc.UpdateLoanDelinquency)
+	if err := jobRunner.Schedule("loan-delinquency", "0 1 * * *", service.KindLoanDelinquency, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule loan delinquency updates")
+	}
+
+	// Reconciliation runs are queued by admins; the job only needs registering.
+	jobRunner.Register(service.KindReconcileAll, ledgerSvc.ReconcileAll)
+
+	// Users whose erasure grace period has ended are anonymized overnight.
+	jobRunner.Register(service.KindUserErasures, ledgerSvc.ProcessErasures)
+	if err := jobRunner.Schedule("user-erasures", "0 3 * * *", service.KindUserErasures, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule user erasures")
+	}
+
+	// Sessions are kept for a week past expiry, and login attempts and name enquiries for 90 days,
+	// for inspection.
+	jobRunner.Register(api.KindPruneSessions, api.PruneSessions(store, 7*24*time.Hour))
+	if err := jobRunner.Schedule("prune-sessions", "@daily", api.KindPruneSessions, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule session pruning")
+	}
+	jobRunner.Register(api.KindPruneLoginAttempts, api.PruneLoginAttempts(store, 90*24*time.Hour))
+	if err := jobRunner.Schedule("prune-login-attempts", "@daily", api.KindPruneLoginAttempts, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule login attempt pruning")
+	}
+	jobRunner.Register(api.KindPruneNameEnquiries, api.PruneNameEnquiries(store, 90*24*time.Hour))
+	if err := jobRunner.Schedule("prune-name-enquiries", "@daily", api.KindPruneNameEnquiries, nil); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to schedule name enquiry pruning")
+	}
+
+	// With PII_KEYS set, plaintext values and values under retired master keys are re-sealed daily
+	// and once at startup, so a newly added key takes effect without waiting a day.
+	if piiKeys != nil {
+		jobRunner.Register(pii.KindRekey, pii.NewRekeyer(store, piiKeys).Rekey)
+		if err := jobRunner.Schedule("pii-rekey", "@daily", pii.KindRekey, nil); err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to schedule PII re-sealing")
+		}
+		if _, err := jobRunner.Enqueue(context.Background(), pii.KindRekey, nil, time.Time{}); err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to queue PII re-sealing")
+		}
+	}
+	go jobRunner.Run(context.Background(), 15*time.Second)
+
+	// Transfers sent with ?async=true are posted by this pool; it also polls for jobs left by a restart.
+	transferWorkerCount := 4
+	if v, err := strconv.Atoi(os.Getenv("TRANSFER_WORKERS")); err == nil && v > 0 {
+		transferWorkerCount = v
+	}
+	transferWorkers := service.NewTransferWorkers(ledgerSvc, transferWorkerCount)
+	go transferWorkers.Run(context.Background(), 5*time.Second)
+	handlerOpts = append(handlerOpts, api.WithAsyncTransfers(transferWorkers))
+	if secret := strings.TrimSpace(os.Getenv("PAYSTACK_SECRET_KEY")); secret != "" {
+		// With Paystack configured, deposits are only credited after a verified payment webhook.
+		paystackClient, err := paystack.NewClient(secret)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to configure Paystack")
+		}
+		handlerOpts = append(handlerOpts, api.WithPaystack(paystackClient, os.Getenv("PAYSTACK_CALLBACK_URL")))
+	} else {
+		zlog.Warn().Msg("PAYSTACK_SECRET_KEY not set; deposits are credited immediately (mock funding)")
+	}
+	if secret := strings.TrimSpace(os.Getenv("FLUTTERWAVE_SECRET_KEY")); secret != "" {
+		// With Flutterwave configured, withdrawals are held until the payout webhook settles or reverses them.
+		flutterwaveClient, err := flutterwave.NewClient(secret, os.Getenv("FLUTTERWAVE_SECRET_HASH"))
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to configure Flutterwave")
+		}
+		handlerOpts = append(handlerOpts, api.WithFlutterwave(flutterwaveClient, os.Getenv("FLUTTERWAVE_CALLBACK_URL")))
+	} else {
+		zlog.Warn().Msg("FLUTTERWAVE_SECRET_KEY not set; withdrawals are debited immediately (mock payout)")
+	}
+	if secret := strings.TrimSpace(os.Getenv("STRIPE_SECRET_KEY")); secret != "" {
+		stripeClient, err := stripe.NewClient(secret, os.Getenv("STRIPE_WEBHOOK_SECRET"))
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to configure Stripe")
+		}
+		handlerOpts = append(handlerOpts, api.WithStripe(stripeClient))
+	}
+	if secret := strings.TrimSpace(os.Getenv("INBOUND_WEBHOOK_SECRET")); secret != "" {
+		// Providers push bank transfers to virtual accounts on /webhooks/payments.
+		verifier, err := inbound.NewVerifier(secret)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to configure inbound payments")
+		}
+		handlerOpts = append(handlerOpts, api.WithInboundPayments(verifier))
+	}
+	switch connector := strings.TrimSpace(os.Getenv("NIP_CONNECTOR")); connector {
+	case "":
+	case "simulator":
+		// Interbank transfers go through the in-memory NIP simulator; pending ones are requeried in the background.
+		sim := nip.NewSimulator()
+		handlerOpts = append(handlerOpts, api.WithNIP(sim))
+		go nip.NewRequeryer(sim, store, ledgerSvc).Run(context.Background(), 30*time.Second)
+		zlog.Warn().Msg("NIP_CONNECTOR=simulator; interbank transfers are simulated")
+	default:
+		zlog.Fatal().Str("connector", connector).Msg("Unsupported NIP_CONNECTOR")
+	}
+	h := api.NewHandler(ledgerSvc, store, handlerOpts...)
 
 	r := chi.NewRouter()
+	// RequestID runs first so the access log and the ledger's logs and rows share one ID.
+	r.Use(middleware.RequestID)
+	// Stream routes accept ?jwt=; it is masked before the access log writes the URI.
+	r.Use(api.RedactQueryTokens)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.RequestID)
+	r.Use(api.PropagateRequestID)
+	// Error messages follow Accept-Language, or the caller's saved locale once authenticated.
+	r.Use(api.Localize)
 
 	// CORS middleware for separate frontend deployments and local development.
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   parseAllowedOrigins(),
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}))
+	corsOpts, err := corsOptions(allowedOrigins)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Invalid CORS configuration")
+	}
+	r.Use(cors.Handler(corsOpts))
 
 	r.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -192,53 +880,342 @@ func main() {
 		})
 	})
 
+	// REQUEST_TIMEOUT bounds every request except the long-lived streams; it stays below the
+	// server's write timeout so clients get a 504 rather than a dropped connection.
+	requestTimeout := api.Timeout(envDuration("REQUEST_TIMEOUT", 10*time.Second))
+
 	// Public routes
-	r.Post("/register", h.Register)
-	r.Post("/login", h.Login)
-	r.Get("/health", func(w http.ResponseWriter, _ *http.Request) {
-		// Health returns service liveness plus lightweight runtime metadata.
-		zlog.Info().Msg("Health check requested")
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(map[string]string{
-			"status":  "healthy",
-			"version": "0.1.0",
-			"uptime":  time.Since(startTime).String(),
-		}); err != nil {
-			zlog.Error().Err(err).Msg("Failed to encode health check response")
-		}
+	r.Group(func(r chi.Router) {
+		r.Use(requestTimeout)
+		r.Post("/register", h.Register)
+		r.Post("/login", h.Login)
+		r.Get("/.well-known/jwks.json", h.JWKS)
+		r.Get("/.well-known/statement-keys.json", h.StatementKeys)
+		r.Post("/statements/verify", h.VerifyStatement)
+		r.Post("/password/change", h.ChangePassword)
+		r.Post("/webhooks/paystack", h.PaystackWebhook)
+		r.Post("/webhooks/flutterwave", h.FlutterwaveWebhook)
+		r.Post("/webhooks/stripe", h.StripeWebhook)
+		r.Post("/webhooks/payments", h.PaymentsWebhook)
+		// Signed, expiring links emailed with monthly statements; the signature replaces the bearer token.
+		r.Get("/statements/{account_id}/{period}", h.DownloadMonthlyStatement)
+		r.Get("/health", func(w http.ResponseWriter, _ *http.Request) {
+			// Health returns service liveness plus lightweight runtime metadata.
+			zlog.Info().Msg("Health check requested")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(map[string]string{
+				"status":  "healthy",
+				"version": "0.1.0",
+				"uptime":  time.Since(startTime).String(),
+			}); err != nil {
+				zlog.Error().Err(err).Msg("Failed to encode health check response")
+			}
+		})
+
+		r.Get("/swagger/*", httpSwagger.Handler(
+			httpSwagger.URL("/swagger/doc.json"),
+			httpSwagger.DeepLinking(true),
+		))
+	})
+
+	// Streaming routes: browsers cannot set headers on WebSocket/EventSource, so also accept ?jwt=.
+	r.Group(func(r chi.Router) {
+		r.Use(api.Verify(jwtauth.TokenFromHeader, jwtauth.TokenFromQuery))
+		r.Use(api.Authenticator)
+		r.Use(h.RequireSession)
+		r.Use(h.PreferUserLocale)
+		r.Use(h.TrackLocation)
+		r.Get("/ws", h.WebSocket)
+		r.Get("/accounts/{id}/entries/stream", h.StreamEntries)
 	})
 
-	r.Get("/swagger/*", httpSwagger.Handler(
-		httpSwagger.URL("/swagger/doc.json"),
-		httpSwagger.DeepLinking(true),
-	))
 	// Protected routes
 	r.Group(func(r chi.Router) {
+		r.Use(requestTimeout)
 		// Apply JWT verification only to protected business endpoints.
-		r.Use(jwtauth.Verifier(api.TokenAuth))
-		r.Use(jwtauth.Authenticator(api.TokenAuth))
+		r.Use(api.Verifier)
+		r.Use(api.Authenticator)
+		r.Use(h.RequireSession)
+		r.Use(h.PreferUserLocale)
+		r.Use(h.TrackLocation)
 
 		r.Post("/accounts", h.CreateAccount)
 		r.Get("/accounts", h.ListAccounts)
+		r.Get("/accounts/enquiry", h.EnquireAccount)
 		r.Get("/accounts/{id}", h.GetAccount)
 		r.Post("/accounts/{id}/deposit", h.Deposit)
+		r.Post("/accounts/{id}/deposits/card", h.CreateCardDeposit)
 		r.Post("/accounts/{id}/withdraw", h.Withdraw)
+		r.Post("/accounts/{id}/wallets", h.CreateSubWallet)
+		r.Get("/accounts/{id}/wallets", h.ListSubWallets)
+		r.Post("/accounts/{id}/wallets/moves", h.MoveBetweenWallets)
+		r.Post("/accounts/{id}/goal", h.CreateSavingsGoal)
+		r.Get("/accounts/{id}/goal", h.GetSavingsGoal)
+		r.Put("/accounts/{id}/goal", h.UpdateSavingsGoal)
+		r.Delete("/accounts/{id}/goal", h.DeleteSavingsGoal)
+		r.Get("/goals", h.ListSavingsGoals)
+		r.Post("/accounts/{id}/sweeps", h.CreateSweepRule)
+		r.Get("/accounts/{id}/sweeps", h.ListSweepRules)
+		r.Delete("/accounts/{id}/sweeps/{ruleId}", h.DeleteSweepRule)
+		r.Get("/accounts/{id}/sweeps/{ruleId}/executions", h.ListSweepExecutions)
+		r.Post("/treasury/pools", h.CreateCashPool)
+		r.Get("/treasury/pools", h.ListCashPools)
+		r.Get("/treasury/pools/{id}", h.GetCashPool)
+		r.Post("/treasury/pools/{id}/members", h.AddCashPoolMember)
+		r.Delete("/treasury/pools/{id}/members/{accountId}", h.RemoveCashPoolMember)
+		r.Post("/treasury/pools/{id}/fundings", h.FundCashPoolMember)
+		r.Get("/treasury/pools/{id}/movements", h.ListCashPoolMovements)
+		r.Get("/accounts/{id}/loans", h.ListAccountLoans)
+		r.Get("/loans/schedule", h.PreviewLoanSchedule)
+		r.Get("/loans/{id}", h.GetLoan)
+		r.Post("/loans/{id}/repayments", h.RepayLoan)
+		r.Get("/accounts/{id}/owners", h.ListAccountOwners)
+		r.Post("/accounts/{id}/owners", h.AddAccountOwner)
+		r.Delete("/accounts/{id}/owners/{user_id}", h.RemoveAccountOwner)
+		r.Post("/accounts/{id}/ownership-transfers", h.RequestAccountOwnershipTransfer)
 		r.Post("/transfers", h.Transfer)
+		r.Post("/recipients/lookup", h.LookupRecipient)
+		r.Get("/recipients/recent", h.ListRecentRecipients)
+		r.Post("/accounts/{id}/splits", h.PaySplit)
+		r.Get("/transfers/{id}", h.GetTransferJob)
+		r.Post("/accounts/{id}/payout-batches", h.CreatePayoutBatch)
+		r.Get("/payout-batches/{id}", h.GetPayoutBatch)
+		r.Get("/payout-batches/{id}/results.csv", h.DownloadPayoutBatchResults)
+		r.Post("/accounts/{id}/transfers/external", h.ExternalTransfer)
+		r.Post("/accounts/{id}/signing-keys", h.CreateSigningKey)
+		r.Get("/accounts/{id}/signing-keys", h.ListSigningKeys)
+		r.Delete("/accounts/{id}/signing-keys/{keyId}", h.RevokeSigningKey)
+		r.Post("/banks/name-enquiry", h.NameEnquiry)
 		r.Get("/accounts/{id}/entries", h.GetEntries)
+		r.Put("/entries/{id}/category", h.SetEntryCategory)
+		r.Delete("/entries/{id}/category", h.ClearEntryCategory)
+		r.Get("/entries/{id}/proof", h.GetEntryProof)
+		r.Get("/ledger/merkle-roots", h.ListMerkleRoots)
+		r.Get("/ledger/merkle-roots/{id}", h.GetMerkleRoot)
+		r.Post("/categories", h.CreateCategory)
+		r.Get("/categories", h.ListCategories)
+		r.Delete("/categories/{id}", h.DeleteCategory)
+		r.Post("/categories/rules", h.CreateCategoryRule)
+		r.Get("/categories/rules", h.ListCategoryRules)
+		r.Delete("/categories/rules/{id}", h.DeleteCategoryRule)
+		r.Get("/accounts/{id}/analytics/spending", h.GetSpendingAnalytics)
+		r.Get("/accounts/{id}/summary", h.GetAccountSummary)
 		r.Get("/accounts/{id}/reconcile", h.ReconcileAccount)
 		r.Get("/transactions/{id}", h.GetTransactions)
+		r.Get("/transactions/{id}/status", h.GetTransactionStatus)
+		r.Get("/transactions/{id}/receipt", h.GetTransactionReceipt)
+		r.Post("/transactions/{id}/annotations", h.CreateTransactionAnnotation)
+		r.Get("/transactions/{id}/annotations", h.ListTransactionAnnotations)
+		r.Post("/transactions/{id}/disputes", h.OpenDispute)
+		r.Get("/accounts/{id}/disputes", h.ListAccountDisputes)
+		r.Post("/accounts/{id}/escrows", h.CreateEscrow)
+		r.Get("/accounts/{id}/escrows", h.ListAccountEscrows)
+		r.Get("/escrows/{id}", h.GetEscrow)
+		r.Post("/escrows/{id}/release", h.ReleaseEscrow)
+		r.Post("/escrows/{id}/refund", h.RefundEscrow)
+		r.Post("/accounts/{id}/payment-requests", h.CreatePaymentRequest)
+		r.Get("/accounts/{id}/payment-requests", h.ListAccountPaymentRequests)
+		r.Get("/payment-requests/{token}", h.GetPaymentRequest)
+		r.Post("/payment-requests/{token}/pay", h.PayPaymentRequest)
+		r.Post("/payment-requests/{token}/cancel", h.CancelPaymentRequest)
+		r.Get("/accounts/{id}/qr", h.GetAccountQR)
+		r.Post("/qr/decode", h.DecodeQR)
+		r.Post("/qr/pay", h.PayQR)
+		r.Get("/payouts/{reference}", h.GetPayout)
+		r.Get("/accounts/{id}/statements/camt053", h.ExportCAMT053)
+		r.Get("/accounts/{id}/notifications/statements", h.GetStatementPreference)
+		r.Put("/accounts/{id}/notifications/statements", h.UpdateStatementPreference)
+		r.Get("/rates", h.GetRate)
+		r.Get("/rates/quote", h.QuoteConversion)
+		r.Get("/products", h.ListProducts)
+		r.Get("/currencies", h.ListCurrencies)
+		r.Post("/accounts/{id}/conversions", h.ConvertCurrency)
+
+		r.Get("/me/notifications", h.GetNotificationPreferences)
+		r.Get("/me/profile", h.GetProfile)
+		r.Put("/me/profile", h.UpdateProfile)
+		r.Put("/me/locale", h.SetLocale)
+		r.Put("/me/default-account", h.SetDefaultAccount)
+		r.Get("/me/sessions", h.ListSessions)
+		r.Delete("/me/sessions/{id}", h.RevokeSession)
+		r.Get("/security/logins", h.ListMyLogins)
+		r.Post("/logout", h.Logout)
+		r.Post("/me/erasure", h.RequestMyErasure)
+		r.Get("/me/erasure", h.GetMyErasure)
+		r.Delete("/me/erasure", h.CancelMyErasure)
+		r.Post("/me/kyc", h.SubmitKYC)
+		r.Get("/me/kyc", h.GetKYC)
+		r.Put("/me/notifications", h.UpdateNotificationPreferences)
 	})
 
+	// Admin routes
+	r.Group(func(r chi.Router) {
+		r.Use(requestTimeout)
+		r.Use(api.Verifier)
+		r.Use(api.Authenticator)
+		r.Use(h.RequireSession)
+		r.Use(h.PreferUserLocale)
+		r.Use(api.RequireRole(api.RoleAdmin))
+
+		r.Post("/admin/reconciliations", h.ImportBankStatement)
+		r.Get("/admin/reconciliations", h.ListReconciliations)
+		r.Get("/admin/reconciliations/{id}", h.GetReconciliation)
+		r.Get("/admin/inbound-payments", h.ListInboundPayments)
+		r.Post("/admin/inbound-payments/{id}/resolve", h.ResolveInboundPayment)
+		r.Get("/admin/kyc", h.ListKYC)
+		r.Post("/admin/kyc/{user_id}/review", h.ReviewKYC)
+		r.Get("/admin/accounts", h.ListAllAccounts)
+		r.Get("/admin/users", h.ListUsers)
+		r.Post("/admin/users/{id}/lock", h.LockUser)
+		r.Post("/admin/users/{id}/unlock", h.UnlockUser)
+		r.Post("/admin/users/{id}/password-reset", h.ResetUserPassword)
+		r.Put("/admin/users/{id}/role", h.SetUserRole)
+		r.Get("/admin/login-attempts", h.ListLoginAttempts)
+		r.Post("/admin/users/{id}/erasure", h.RequestUserErasure)
+		r.Delete("/admin/users/{id}/erasure", h.CancelUserErasure)
+		r.Get("/admin/erasures", h.ListErasures)
+		r.Get("/admin/ownership-transfers", h.ListOwnershipTransfers)
+		r.Post("/admin/ownership-transfers", h.CreateOwnershipTransfer)
+		r.Post("/admin/ownership-transfers/{id}/decision", h.DecideOwnershipTransfer)
+		r.Post("/admin/adjustments", h.CreateAdjustment)
+		r.Get("/admin/adjustments", h.ListAdjustments)
+		r.Get("/admin/adjustments/{id}", h.GetAdjustment)
+		r.Post("/admin/adjustments/{id}/decision", h.DecideAdjustment)
+		r.Post("/admin/accounts/{id}/balance-repairs", h.ProposeBalanceRepair)
+		r.Get("/admin/balance-repairs", h.ListBalanceRepairs)
+		r.Get("/admin/balance-repairs/{id}", h.GetBalanceRepair)
+		r.Post("/admin/balance-repairs/{id}/decision", h.DecideBalanceRepair)
+		r.Post("/admin/reconcile", h.StartReconciliationRun)
+		r.Get("/admin/reconcile", h.ListReconciliationRuns)
+		r.Get("/admin/reconcile/{id}", h.GetReconciliationRun)
+		r.Get("/admin/reconcile/{id}/results", h.ListReconciliationResults)
+		r.Get("/admin/payout-suspense", h.ListPayoutSuspenseCases)
+		r.Get("/admin/payout-suspense/{id}", h.GetPayoutSuspenseCase)
+		r.Post("/admin/payout-suspense/{id}/resolution", h.ResolvePayoutSuspenseCase)
+		r.Get("/admin/transactions", h.ListTransactions)
+		r.Post("/admin/transactions/{id}/reverse", h.ReverseTransaction)
+		r.Post("/admin/transactions/{id}/refunds", h.RefundTransaction)
+		r.Get("/admin/transactions/{id}/refunds", h.ListTransactionRefunds)
+		r.Get("/admin/transactions/{id}/events", h.ListTransactionEvents)
+		r.Get("/admin/ledger/projections", h.CheckLedgerProjections)
+		r.Post("/admin/ledger/projections/rebuild", h.RebuildLedgerProjections)
+		r.Get("/admin/ledger/chain", h.VerifyEntryChain)
+		r.Get("/admin/gl-mappings", h.ListGLMappings)
+		r.Put("/admin/gl-mappings/accounts/{id}", h.SetGLAccountCode)
+		r.Put("/admin/gl-mappings/customers/{currency}", h.SetGLCustomerCode)
+		r.Get("/admin/gl-export", h.ExportGeneralLedger)
+		r.Get("/admin/jobs", h.ListJobs)
+		r.Get("/admin/disputes", h.ListDisputes)
+		r.Post("/admin/disputes/{id}/resolve", h.ResolveDispute)
+		r.Get("/admin/rates/overrides", h.ListRateOverrides)
+		r.Put("/admin/rates/overrides", h.SetRateOverride)
+		r.Delete("/admin/rates/overrides/{base}/{quote}", h.ClearRateOverride)
+		r.Get("/admin/rates/spreads", h.ListFXSpreads)
+		r.Put("/admin/rates/spreads", h.SetFXSpread)
+		r.Get("/admin/account-products", h.ListAccountProducts)
+		r.Put("/admin/account-products", h.SetAccountProduct)
+		r.Put("/admin/products", h.SetProduct)
+		r.Get("/admin/tax-rules", h.ListTaxRules)
+		r.Put("/admin/tax-rules", h.SetTaxRule)
+		r.Get("/admin/tax-report", h.GetTaxReport)
+		r.Get("/admin/settlement-report", h.GetSettlementReport)
+		r.Get("/admin/limits", h.ListTransactionLimits)
+		r.Put("/admin/limits", h.SetTransactionLimit)
+		r.Delete("/admin/limits/{currency}/{operation}/{level}", h.ClearTransactionLimit)
+		r.Get("/admin/products/{code}/interest-tiers", h.ListInterestTiers)
+		r.Put("/admin/products/{code}/interest-tiers", h.SetInterestTiers)
+		r.Post("/admin/organizations", h.CreateOrganization)
+		r.Get("/admin/organizations", h.ListOrganizations)
+		r.Post("/admin/organizations/{id}/admins", h.AssignOrganizationAdmin)
+	})
+
+	// Organization admin routes, scoped to the org_id claim of the caller.
+	r.Group(func(r chi.Router) {
+		r.Use(requestTimeout)
+		r.Use(api.Verifier)
+		r.Use(api.Authenticator)
+		r.Use(h.RequireSession)
+		r.Use(h.PreferUserLocale)
+		r.Use(api.RequireRole(api.RoleOrgAdmin))
+
+		r.Get("/org/users", h.ListOrgUsers)
+		r.Get("/org/accounts", h.ListOrgAccounts)
+		r.Put("/org/users/{id}/role", h.SetOrgUserRole)
+		r.Post("/org/loans", h.OriginateLoan)
+		r.Get("/org/loans", h.ListOrgLoans)
+		r.Post("/org/webhooks", h.CreateWebhookEndpoint)
+		r.Get("/org/webhooks", h.ListWebhookEndpoints)
+		r.Post("/org/webhooks/{id}/rotate-secret", h.RotateWebhookSecret)
+		r.Get("/org/webhooks/deliveries", h.ListWebhookDeliveries)
+		r.Post("/org/webhooks/deliveries/{id}/redeliver", h.RedeliverWebhook)
+	})
+
+	// Maker-checker: staff request transfers, a different approver posts them.
+	r.Group(func(r chi.Router) {
+		r.Use(requestTimeout)
+		r.Use(api.Verifier)
+		r.Use(api.Authenticator)
+		r.Use(h.RequireSession)
+		r.Use(h.PreferUserLocale)
+		r.Use(api.RequireRole(api.RoleOrgAdmin, api.RoleApprover))
+
+		r.Post("/org/transfer-requests", h.CreateTransferRequest)
+		r.Get("/org/transfer-requests", h.ListTransferRequests)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(requestTimeout)
+		r.Use(api.Verifier)
+		r.Use(api.Authenticator)
+		r.Use(h.RequireSession)
+		r.Use(h.PreferUserLocale)
+		r.Use(api.RequireRole(api.RoleApprover))
+
+		r.Post("/org/transfer-requests/{id}/decision", h.DecideTransferRequest)
+	})
+
+	// Compliance review queues: AML alerts on money movements and sanctions screenings of names.
+	r.Group(func(r chi.Router) {
+		r.Use(requestTimeout)
+		r.Use(api.Verifier)
+		r.Use(api.Authenticator)
+		r.Use(h.RequireSession)
+		r.Use(h.PreferUserLocale)
+		r.Use(api.RequireRole(api.RoleCompliance))
+
+		r.Get("/org/aml/alerts", h.ListAMLAlerts)
+		r.Post("/org/aml/alerts/{id}/decision", h.ReviewAMLAlert)
+		r.Get("/org/screenings", h.ListSanctionsScreenings)
+		r.Post("/org/screenings/{id}/decision", h.ReviewSanctionsScreening)
+	})
+
+	// DEBUG_PORT serves pprof profiles and expvar counters on a port of their own, to admins only,
+	// so a CPU or allocation profile can be taken from a running server, and the ledger's business
+	// metrics at /metrics, which a Prometheus scraper may fetch with METRICS_TOKEN as its bearer
+	// token instead of an admin's. Unset leaves them all off.
+	if debugPort := strings.TrimSpace(os.Getenv("DEBUG_PORT")); debugPort != "" {
+		expvar.Publish("db", expvar.Func(func() any { return dbConn.Stats() }))
+		expvar.Publish("goroutines", expvar.Func(func() any { return runtime.NumGoroutine() }))
+		admin := chi.Chain(api.Verifier, api.Authenticator, h.RequireSession, api.RequireRole(api.RoleAdmin))
+		dr := chi.NewRouter()
+		dr.Use(middleware.Recoverer)
+		dr.Mount("/debug", admin.Handler(middleware.Profiler()))
+		metricsHandler := admin.Handler(metrics.Handler())
+		if token := strings.TrimSpace(os.Getenv("METRICS_TOKEN")); token != "" {
+			metricsHandler = scrapeToken(token, metricsHandler)
+		}
+		dr.Handle("/metrics", metricsHandler)
+		go serveDebug(dr, debugPort)
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		// Default port for local development when PORT is not injected.
 		port = "8080"
 	}
 
-	// Configure HTTP server with timeouts for security
+	// Configure HTTP server with timeouts for security; serve picks the address.
 	srv := &http.Server{
-		Addr:              ":" + port,
 		Handler:           r,
 		ReadTimeout:       15 * time.Second,
 		WriteTimeout:      15 * time.Second,
@@ -246,8 +1223,131 @@ func main() {
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
-	zlog.Info().Str("port", port).Msg("Starting server")
-	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	if err := serve(srv, port); err != nil && err != http.ErrServerClosed {
 		zlog.Fatal().Err(err).Msg("Server failed to start")
 	}
 }
+
+// serveDebug serves the debug router on port. Its write timeout leaves room for CPU profiles and
+// execution traces, which take ?seconds=N (default 30) to record.
+func serveDebug(handler http.Handler, port string) {
+	srv := &http.Server{
+		Addr:              ":" + port,
+		Handler:           handler,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      2 * time.Minute,
+		IdleTimeout:       60 * time.Second,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	zlog.Info().Str("port", port).Msg("Serving debug endpoints")
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		zlog.Fatal().Err(err).Msg("Debug server failed")
+	}
+}
+
+// scrapeToken serves the business metrics to a request bearing token and hands any other to next.
+func scrapeToken(token string, next http.Handler) http.Handler {
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) == 1 {
+			metrics.Handler().ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// serve runs srv. With TLS_CERT_FILE and TLS_KEY_FILE, or TLS_AUTOCERT_DOMAINS for Let's Encrypt,
+// it serves HTTPS on TLS_PORT (default 443) and redirects plain HTTP on HTTP_REDIRECT_PORT
+// (default 80, "off" to disable) to it; otherwise it serves plain HTTP on port.
+func serve(srv *http.Server, port string) error {
+	certFile := strings.TrimSpace(os.Getenv("TLS_CERT_FILE"))
+	keyFile := strings.TrimSpace(os.Getenv("TLS_KEY_FILE"))
+	domains := envList("TLS_AUTOCERT_DOMAINS", nil)
+	if certFile == "" && keyFile == "" && len(domains) == 0 {
+		srv.Addr = ":" + port
+		zlog.Info().Str("port", port).Msg("Starting server")
+		return srv.ListenAndServe()
+	}
+	if (certFile == "") != (keyFile == "") {
+		return errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if certFile != "" && len(domains) > 0 {
+		return errors.New("set either TLS_CERT_FILE/TLS_KEY_FILE or TLS_AUTOCERT_DOMAINS, not both")
+	}
+
+	tlsPort := strings.TrimSpace(os.Getenv("TLS_PORT"))
+	if tlsPort == "" {
+		tlsPort = "443"
+	}
+	redirectPort := strings.TrimSpace(os.Getenv("HTTP_REDIRECT_PORT"))
+	if redirectPort == "" {
+		redirectPort = "80"
+	}
+	srv.Addr = ":" + tlsPort
+	srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	srv.Handler = hsts(srv.Handler)
+
+	// Plain HTTP only redirects; with autocert it also answers Let's Encrypt's HTTP-01 challenges.
+	var redirect http.Handler = httpsRedirect(tlsPort)
+	if len(domains) > 0 {
+		cacheDir := strings.TrimSpace(os.Getenv("TLS_AUTOCERT_CACHE_DIR"))
+		if cacheDir == "" {
+			cacheDir = "autocert-cache"
+		}
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(domains...),
+			Cache:      autocert.DirCache(cacheDir),
+			Email:      strings.TrimSpace(os.Getenv("TLS_AUTOCERT_EMAIL")),
+		}
+		srv.TLSConfig = manager.TLSConfig()
+		srv.TLSConfig.MinVersion = tls.VersionTLS12
+		redirect = manager.HTTPHandler(redirect)
+		if redirectPort == "off" {
+			// TLS-ALPN-01 on the HTTPS port still validates without port 80.
+			zlog.Warn().Msg("HTTP_REDIRECT_PORT=off: Let's Encrypt can only validate over TLS-ALPN on port 443")
+		}
+	}
+	if redirectPort != "off" {
+		redirectSrv := &http.Server{
+			Addr:              ":" + redirectPort,
+			Handler:           redirect,
+			ReadTimeout:       5 * time.Second,
+			WriteTimeout:      5 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+		go func() {
+			zlog.Info().Str("port", redirectPort).Msg("Redirecting HTTP to HTTPS")
+			if err := redirectSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				zlog.Fatal().Err(err).Msg("HTTP redirect server failed")
+			}
+		}()
+	}
+
+	zlog.Info().Str("port", tlsPort).Bool("autocert", len(domains) > 0).Msg("Starting HTTPS server")
+	return srv.ListenAndServeTLS(certFile, keyFile)
+}
+
+// httpsRedirect permanently redirects requests to the same host and path over HTTPS on tlsPort,
+// keeping the method so POSTs are not replayed as GETs.
+func httpsRedirect(tlsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if tlsPort != "443" {
+			host = net.JoinHostPort(host, tlsPort)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
+
+// hsts tells browsers to keep using HTTPS for a year once they have reached the API over it.
+func hsts(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Strict-Transport-Security", "max-age=31536000")
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,201 @@
+// Package alert pages operators when the ledger finds it disagrees with itself, such as a cached
+// balance that no longer matches the entries behind it. Alerts go out through PagerDuty, Slack or
+// a signed JSON webhook, or only to the log when none is configured.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/sdk/webhook"
+)
+
+// Kinds of integrity failure.
+const (
+	// KindBalanceMismatch is an account whose stored balance differs from the sum of its entries.
+	KindBalanceMismatch = "balance_mismatch"
+	// KindProjectionDrift is an account whose stored balance or entries differ from the balance
+	// replayed from the ledger event log.
+	KindProjectionDrift = "projection_drift"
+)
+
+// Alert describes one integrity failure.
+type Alert struct {
+	Kind      string    `json:"kind"`
+	AccountID uuid.UUID `json:"account_id"`
+	Currency  string    `json:"currency,omitempty"`
+	// Stored is the balance the account row holds and Expected the one the ledger says it should.
+	Stored   string `json:"stored_balance"`
+	Expected string `json:"expected_balance"`
+	// Delta is Expected minus Stored.
+	Delta      string    `json:"delta"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// Summary is a one-line description of a, for channels that show plain text.
+func (a Alert) Summary() string {
+	return fmt.Sprintf("Ledger %s on account %s: stored %s, expected %s (delta %s)",
+		strings.ReplaceAll(a.Kind, "_", " "), a.AccountID, a.Stored, a.Expected, a.Delta)
+}
+
+// Sender delivers alerts to a channel.
+type Sender interface {
+	Send(ctx context.Context, a Alert) error
+}
+
+// defaultHTTPClient bounds channel calls so a hung API cannot pin the goroutine raising the alert.
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// PagerDutySender triggers incidents through the PagerDuty Events API v2.
+type PagerDutySender struct {
+	client     *http.Client
+	routingKey string
+	baseURL    string
+}
+
+// NewPagerDutySender constructs a PagerDutySender for a service's integration routing key.
+func NewPagerDutySender(routingKey string) (*PagerDutySender, error) {
+	if routingKey == "" {
+		return nil, errors.New("pagerduty routing key is required")
+	}
+	return &PagerDutySender{client: defaultHTTPClient, routingKey: routingKey, baseURL: "https://events.pagerduty.com"}, nil
+}
+
+// Send implements Sender. Alerts for the same kind and account share a dedup key, so repeated
+// checks update one open incident instead of paging again.
+func (s *PagerDutySender) Send(ctx context.Context, a Alert) error {
+	return postJSON(ctx, s.client, s.baseURL+"/v2/enqueue", "pagerduty", nil, map[string]any{
+		"routing_key":  s.routingKey,
+		"event_action": "trigger",
+		"dedup_key":    a.Kind + ":" + a.AccountID.String(),
+		"payload": map[string]any{
+			"summary":        a.Summary(),
+			"source":         "ledger",
+			"severity":       "critical",
+			"component":      "balances",
+			"class":          a.Kind,
+			"timestamp":      a.DetectedAt.UTC().Format(time.RFC3339),
+			"custom_details": a,
+		},
+	})
+}
+
+// SlackSender posts alerts to a Slack incoming webhook.
+type SlackSender struct {
+	client *http.Client
+	url    string
+}
+
+// NewSlackSender constructs a SlackSender for an incoming webhook URL.
+func NewSlackSender(webhookURL string) (*SlackSender, error) {
+	if webhookURL == "" {
+		return nil, errors.New("slack webhook URL is required")
+	}
+	return &SlackSender{client: defaultHTTPClient, url: webhookURL}, nil
+}
+
+// Send implements Sender.
+func (s *SlackSender) Send(ctx context.Context, a Alert) error {
+	return postJSON(ctx, s.client, s.url, "slack", nil, map[string]string{"text": ":rotating_light: " + a.Summary()})
+}
+
+// WebhookSender posts each alert as JSON to a URL. With a secret the body is signed as ledger
+// webhooks are, so receivers verify it with the sdk/webhook package.
+type WebhookSender struct {
+	client *http.Client
+	url    string
+	secret string
+}
+
+// NewWebhookSender constructs a WebhookSender; secret may be empty to send unsigned alerts.
+func NewWebhookSender(url, secret string) (*WebhookSender, error) {
+	if url == "" {
+		return nil, errors.New("alert webhook URL is required")
+	}
+	return &WebhookSender{client: defaultHTTPClient, url: url, secret: secret}, nil
+}
+
+// Send implements Sender.
+func (s *WebhookSender) Send(ctx context.Context, a Alert) error {
+	var sign func(http.Header, []byte)
+	if s.secret != "" {
+		sign = func(h http.Header, body []byte) {
+			now := time.Now().Unix()
+			h.Set(webhook.TimestampHeader, fmt.Sprint(now))
+			h.Set(webhook.SignatureHeader, webhook.Sign(s.secret, now, body))
+		}
+	}
+	return postJSON(ctx, s.client, s.url, "alert webhook", sign, a)
+}
+
+// Multi sends each alert to every sender in turn, returning their errors joined.
+type Multi []Sender
+
+// Send implements Sender.
+func (m Multi) Send(ctx context.Context, a Alert) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Send(ctx, a); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LogSender writes alerts to the log instead of sending them; the default when no channel is set.
+type LogSender struct{}
+
+// Send implements Sender.
+func (LogSender) Send(_ context.Context, a Alert) error {
+	log.Error().
+		Str("kind", a.Kind).
+		Str("account_id", a.AccountID.String()).
+		Str("stored_balance", a.Stored).
+		Str("expected_balance", a.Expected).
+		Str("delta", a.Delta).
+		Msg("Ledger integrity alert (log sender)")
+	return nil
+}
+
+// postJSON posts payload as JSON to url, letting sign add headers over the encoded body, and
+// converts non-2xx responses into errors.
+func postJSON(ctx context.Context, client *http.Client, url, channel string, sign func(http.Header, []byte), payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if sign != nil {
+		sign(req.Header, body)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", channel, err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Warn().Err(closeErr).Str("channel", channel).Msg("Failed to close alert response body")
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned %d: %s", channel, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
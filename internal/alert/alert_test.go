@@ -0,0 +1,86 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/sdk/webhook"
+)
+
+func testAlert() Alert {
+	return Alert{
+		Kind:       KindBalanceMismatch,
+		AccountID:  uuid.New(),
+		Stored:     "100.0000",
+		Expected:   "90.0000",
+		Delta:      "-10.0000",
+		DetectedAt: time.Now(),
+	}
+}
+
+func TestPagerDutySender_TriggersDedupedIncident(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/enqueue", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	s, err := NewPagerDutySender("routing-key")
+	require.NoError(t, err)
+	s.baseURL = srv.URL
+	a := testAlert()
+	require.NoError(t, s.Send(context.Background(), a))
+
+	assert.Equal(t, "routing-key", got["routing_key"])
+	assert.Equal(t, "trigger", got["event_action"])
+	assert.Equal(t, KindBalanceMismatch+":"+a.AccountID.String(), got["dedup_key"])
+	payload := got["payload"].(map[string]any)
+	assert.Equal(t, "critical", payload["severity"])
+	assert.Contains(t, payload["summary"], a.AccountID.String())
+	assert.Equal(t, "-10.0000", payload["custom_details"].(map[string]any)["delta"])
+}
+
+func TestWebhookSender_SignsBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := webhook.Verify(r.Header, body, 0, "secret"); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	signed, err := NewWebhookSender(srv.URL, "secret")
+	require.NoError(t, err)
+	assert.NoError(t, signed.Send(context.Background(), testAlert()))
+
+	unsigned, err := NewWebhookSender(srv.URL, "")
+	require.NoError(t, err)
+	assert.ErrorContains(t, unsigned.Send(context.Background(), testAlert()), "401")
+}
+
+type failingSender struct{ calls int }
+
+func (f *failingSender) Send(context.Context, Alert) error {
+	f.calls++
+	return errors.New("down")
+}
+
+func TestMulti_SendsToEveryChannel(t *testing.T) {
+	first, second := &failingSender{}, &failingSender{}
+	err := Multi{first, LogSender{}, second}.Send(context.Background(), testAlert())
+	assert.Error(t, err)
+	assert.Equal(t, 1, first.calls)
+	assert.Equal(t, 1, second.calls)
+}
@@ -0,0 +1,215 @@
+// Package aml screens money movements against anti-money-laundering rules. Each Rule looks at one
+// operation and the account's recent activity and may ask for the operation to be flagged for
+// review, held until compliance staff release it, or blocked outright. The Engine runs every rule
+// and keeps the strictest action.
+package aml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Action is what a rule asks for. Later actions are stricter.
+type Action string
+
+// Actions in increasing severity; ActionAllow is the zero value.
+const (
+	ActionAllow Action = ""
+	ActionFlag  Action = "flag"
+	ActionHold  Action = "hold"
+	ActionBlock Action = "block"
+)
+
+// severity orders actions so the strictest hit wins.
+func (a Action) severity() int {
+	switch a {
+	case ActionFlag:
+		return 1
+	case ActionHold:
+		return 2
+	case ActionBlock:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// ParseAction reads flag, hold or block.
+func ParseAction(s string) (Action, error) {
+	switch a := Action(strings.ToLower(strings.TrimSpace(s))); a {
+	case ActionFlag, ActionHold, ActionBlock:
+		return a, nil
+	}
+	return ActionAllow, fmt.Errorf("aml: action %q must be flag, hold or block", s)
+}
+
+// Direction says whether money enters or leaves the screened account.
+type Direction string
+
+// Directions of an operation or past movement.
+const (
+	Inbound  Direction = "in"
+	Outbound Direction = "out"
+)
+
+// Operation is a money movement about to be posted, seen from AccountID.
+type Operation struct {
+	// Kind names the ledger operation, e.g. deposit, withdrawal, transfer or payout.
+	Kind      string
+	AccountID uuid.UUID
+	Direction Direction
+	Amount    decimal.Decimal
+	Currency  string
+	At        time.Time
+}
+
+// Activity is one past movement on the screened account.
+type Activity struct {
+	Direction Direction
+	Amount    decimal.Decimal
+	At        time.Time
+}
+
+// Hit is one rule's finding.
+type Hit struct {
+	Rule   string
+	Action Action
+	Reason string
+}
+
+// Rule is one screening heuristic. Implementations must be safe for concurrent use.
+type Rule interface {
+	// Name identifies the rule in alerts and configuration.
+	Name() string
+	// Window is how much of the account's history Check needs to see.
+	Window() time.Duration
+	// Check reports whether op, given the account's activity within Window, breaks the rule.
+	Check(op Operation, history []Activity) (Hit, bool)
+}
+
+// Verdict is the outcome of screening one operation.
+type Verdict struct {
+	// Action is the strictest action of Hits, or ActionAllow.
+	Action Action
+	Hits   []Hit
+}
+
+// Rules lists the names of the rules that hit.
+func (v Verdict) Rules() []string {
+	names := make([]string, 0, len(v.Hits))
+	for _, h := range v.Hits {
+		names = append(names, h.Rule)
+	}
+	return names
+}
+
+// Reason joins the hits' reasons for a reviewer.
+func (v Verdict) Reason() string {
+	reasons := make([]string, 0, len(v.Hits))
+	for _, h := range v.Hits {
+		reasons = append(reasons, h.Reason)
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// Engine evaluates a fixed set of rules.
+type Engine struct {
+	rules []Rule
+}
+
+// NewEngine returns an engine running rules in order.
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{rules: rules}
+}
+
+// Lookback is the longest window any rule needs, i.e. how much history to load for Evaluate.
+func (e *Engine) Lookback() time.Duration {
+	var d time.Duration
+	for _, r := range e.rules {
+		d = max(d, r.Window())
+	}
+	return d
+}
+
+// Evaluate runs every rule on op, handing each only the history within its window.
+func (e *Engine) Evaluate(op Operation, history []Activity) Verdict {
+	var v Verdict
+	for _, r := range e.rules {
+		since := op.At.Add(-r.Window())
+		recent := make([]Activity, 0, len(history))
+		for _, a := range history {
+			if !a.At.Before(since) && !a.At.After(op.At) {
+				recent = append(recent, a)
+			}
+		}
+		hit, ok := r.Check(op, recent)
+		if !ok {
+			continue
+		}
+		hit.Rule = r.Name()
+		v.Hits = append(v.Hits, hit)
+		if hit.Action.severity() > v.Action.severity() {
+			v.Action = hit.Action
+		}
+	}
+	return v
+}
+
+// WithAction returns r with every hit's action replaced by a.
+func WithAction(r Rule, a Action) Rule {
+	return actionOverride{Rule: r, action: a}
+}
+
+type actionOverride struct {
+	Rule
+	action Action
+}
+
+func (o actionOverride) Check(op Operation, history []Activity) (Hit, bool) {
+	hit, ok := o.Rule.Check(op, history)
+	hit.Action = o.action
+	return hit, ok
+}
+
+// ApplyActions overrides the actions of rules from a spec such as
+// "structuring=block,round_amount_burst=hold". Unknown rule names are an error.
+func ApplyActions(rules []Rule, spec string) ([]Rule, error) {
+	actions := map[string]Action{}
+	for _, part := range strings.Split(spec, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("aml: %q must look like rule=action", part)
+		}
+		a, err := ParseAction(value)
+		if err != nil {
+			return nil, err
+		}
+		actions[strings.TrimSpace(name)] = a
+	}
+
+	out := make([]Rule, len(rules))
+	for i, r := range rules {
+		out[i] = r
+		if a, ok := actions[r.Name()]; ok {
+			out[i] = WithAction(r, a)
+			delete(actions, r.Name())
+		}
+	}
+	if len(actions) > 0 {
+		unknown := make([]string, 0, len(actions))
+		for name := range actions {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("aml: unknown rules %s", strings.Join(unknown, ", "))
+	}
+	return out, nil
+}
@@ -0,0 +1,93 @@
+package aml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func op(dir Direction, amount string) Operation {
+	return Operation{Kind: "transfer", AccountID: uuid.New(), Direction: dir, Amount: decimal.RequireFromString(amount), Currency: "USD", At: now}
+}
+
+func past(dir Direction, amount string, ago time.Duration) Activity {
+	return Activity{Direction: dir, Amount: decimal.RequireFromString(amount), At: now.Add(-ago)}
+}
+
+func TestStructuring(t *testing.T) {
+	// The third deposit just under the threshold in a day is held; older or smaller ones don't count.
+	e := NewEngine(DefaultRules(decimal.NewFromInt(10_000))...)
+	history := []Activity{
+		past(Inbound, "9500", time.Hour),
+		past(Inbound, "9900", 2*time.Hour),
+	}
+	v := e.Evaluate(op(Inbound, "9800"), history)
+	assert.Equal(t, ActionHold, v.Action)
+	assert.Equal(t, []string{"structuring"}, v.Rules())
+
+	history[1].At = now.Add(-48 * time.Hour)
+	assert.Equal(t, ActionAllow, e.Evaluate(op(Inbound, "9800"), history).Action)
+	assert.Equal(t, ActionAllow, e.Evaluate(op(Inbound, "10000"), []Activity{past(Inbound, "9500", time.Hour), past(Inbound, "9900", time.Hour)}).Action)
+}
+
+func TestRapidMovement(t *testing.T) {
+	// Sending on nearly everything that just arrived is held; keeping most of it is not.
+	e := NewEngine(DefaultRules(decimal.NewFromInt(10_000))...)
+	history := []Activity{past(Inbound, "8000", 30*time.Minute)}
+
+	v := e.Evaluate(op(Outbound, "7300"), history)
+	assert.Equal(t, ActionHold, v.Action)
+	assert.Equal(t, []string{"rapid_movement"}, v.Rules())
+	assert.Contains(t, v.Reason(), "7300.00 out against 8000.00 in")
+
+	assert.Equal(t, ActionAllow, e.Evaluate(op(Outbound, "2000"), history).Action)
+	assert.Equal(t, ActionAllow, e.Evaluate(op(Outbound, "300"), []Activity{past(Inbound, "300", time.Minute)}).Action)
+}
+
+func TestRoundAmountBurst(t *testing.T) {
+	// Five round amounts within an hour are flagged.
+	e := NewEngine(DefaultRules(decimal.NewFromInt(10_000))...)
+	var history []Activity
+	for i := range 4 {
+		history = append(history, past(Outbound, "2000", time.Duration(i+1)*time.Minute))
+	}
+	v := e.Evaluate(op(Inbound, "1000"), history)
+	assert.Equal(t, ActionFlag, v.Action)
+	assert.Equal(t, []string{"round_amount_burst"}, v.Rules())
+
+	assert.Equal(t, ActionAllow, e.Evaluate(op(Inbound, "1001"), history).Action)
+}
+
+func TestEvaluate_StrictestWins(t *testing.T) {
+	// When several rules hit, the strictest action is kept and every hit is reported.
+	rules, err := ApplyActions(DefaultRules(decimal.NewFromInt(10_000)), "round_amount_burst=block")
+	require.NoError(t, err)
+	e := NewEngine(rules...)
+	history := []Activity{past(Inbound, "9000", time.Minute), past(Inbound, "9000", 2*time.Minute), past(Outbound, "1000", 3*time.Minute), past(Outbound, "1000", 4*time.Minute)}
+
+	v := e.Evaluate(op(Inbound, "9000"), history)
+	assert.Equal(t, ActionBlock, v.Action)
+	assert.Equal(t, []string{"structuring", "round_amount_burst"}, v.Rules())
+	assert.Equal(t, 24*time.Hour, e.Lookback())
+}
+
+func TestApplyActions(t *testing.T) {
+	// Malformed specs, bad actions and unknown rules are refused.
+	rules := DefaultRules(decimal.NewFromInt(10_000))
+	_, err := ApplyActions(rules, "structuring")
+	assert.Error(t, err)
+	_, err = ApplyActions(rules, "structuring=ignore")
+	assert.Error(t, err)
+	_, err = ApplyActions(rules, "velocity=flag")
+	assert.ErrorContains(t, err, "velocity")
+
+	same, err := ApplyActions(rules, "")
+	require.NoError(t, err)
+	assert.Equal(t, rules, same)
+}
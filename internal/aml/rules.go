@@ -0,0 +1,143 @@
+package aml
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultRules are the built-in rules tuned around a reporting threshold, the amount above which
+// transactions must be reported and below which launderers therefore like to stay.
+func DefaultRules(threshold decimal.Decimal) []Rule {
+	return []Rule{
+		Structuring{
+			Threshold: threshold,
+			Margin:    decimal.NewFromFloat(0.1),
+			Count:     3,
+			Period:    24 * time.Hour,
+			Action:    ActionHold,
+		},
+		RapidMovement{
+			MinAmount: threshold.Div(decimal.NewFromInt(2)),
+			Ratio:     decimal.NewFromFloat(0.9),
+			Period:    24 * time.Hour,
+			Action:    ActionHold,
+		},
+		RoundAmountBurst{
+			Unit:   threshold.Div(decimal.NewFromInt(10)),
+			Count:  5,
+			Period: time.Hour,
+			Action: ActionFlag,
+		},
+	}
+}
+
+// Structuring catches amounts split to stay just under Threshold: Count or more movements in the
+// same direction within Period, each between Threshold less Margin (a fraction) and Threshold.
+type Structuring struct {
+	Threshold decimal.Decimal
+	Margin    decimal.Decimal
+	Count     int
+	Period    time.Duration
+	Action    Action
+}
+
+// Name implements Rule.
+func (Structuring) Name() string { return "structuring" }
+
+// Window implements Rule.
+func (r Structuring) Window() time.Duration { return r.Period }
+
+// Check implements Rule.
+func (r Structuring) Check(op Operation, history []Activity) (Hit, bool) {
+	floor := r.Threshold.Sub(r.Threshold.Mul(r.Margin))
+	justUnder := func(d decimal.Decimal) bool {
+		return d.GreaterThanOrEqual(floor) && d.LessThan(r.Threshold)
+	}
+	if !justUnder(op.Amount) {
+		return Hit{}, false
+	}
+	n := 1
+	for _, a := range history {
+		if a.Direction == op.Direction && justUnder(a.Amount) {
+			n++
+		}
+	}
+	if n < r.Count {
+		return Hit{}, false
+	}
+	return Hit{Action: r.Action, Reason: fmt.Sprintf("%d movements just under %s within %s", n, r.Threshold, r.Period)}, true
+}
+
+// RapidMovement catches pass-through accounts: money leaving that amounts to at least Ratio of
+// what came in within Period, when the inflow was at least MinAmount.
+type RapidMovement struct {
+	MinAmount decimal.Decimal
+	Ratio     decimal.Decimal
+	Period    time.Duration
+	Action    Action
+}
+
+// Name implements Rule.
+func (RapidMovement) Name() string { return "rapid_movement" }
+
+// Window implements Rule.
+func (r RapidMovement) Window() time.Duration { return r.Period }
+
+// Check implements Rule.
+func (r RapidMovement) Check(op Operation, history []Activity) (Hit, bool) {
+	if op.Direction != Outbound {
+		return Hit{}, false
+	}
+	in, out := decimal.Zero, op.Amount
+	for _, a := range history {
+		if a.Direction == Inbound {
+			in = in.Add(a.Amount)
+		} else {
+			out = out.Add(a.Amount)
+		}
+	}
+	if in.LessThan(r.MinAmount) || out.LessThan(in.Mul(r.Ratio)) {
+		return Hit{}, false
+	}
+	return Hit{Action: r.Action, Reason: fmt.Sprintf("%s out against %s in within %s", out.StringFixed(2), in.StringFixed(2), r.Period)}, true
+}
+
+// RoundAmountBurst catches bursts of round amounts: Count or more movements within Period that
+// are whole multiples of Unit.
+type RoundAmountBurst struct {
+	Unit   decimal.Decimal
+	Count  int
+	Period time.Duration
+	Action Action
+}
+
+// Name implements Rule.
+func (RoundAmountBurst) Name() string { return "round_amount_burst" }
+
+// Window implements Rule.
+func (r RoundAmountBurst) Window() time.Duration { return r.Period }
+
+// Check implements Rule.
+func (r RoundAmountBurst) Check(op Operation, history []Activity) (Hit, bool) {
+	if !r.Unit.IsPositive() {
+		return Hit{}, false
+	}
+	round := func(d decimal.Decimal) bool {
+		return d.IsPositive() && d.Mod(r.Unit).IsZero()
+	}
+	if !round(op.Amount) {
+		return Hit{}, false
+	}
+	n := 1
+	for _, a := range history {
+		if round(a.Amount) {
+			n++
+		}
+	}
+	if n < r.Count {
+		return Hit{}, false
+	}
+	return Hit{Action: r.Action, Reason: fmt.Sprintf("%d multiples of %s within %s", n, r.Unit, r.Period)}, true
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// adjustmentStatus maps adjustment errors to an HTTP status.
+func adjustmentStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAdjustmentNotFound), errors.Is(err, service.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAdjustmentNotPending), errors.Is(err, service.ErrAdjustmentIsRepair):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrAdjustmentSelfApproval):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidAdjustment), errors.Is(err, service.ErrAmountPrecision), errors.Is(err, service.ErrInsufficientFunds):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondAdjustmentError writes err with its adjustment status, hiding internal failures.
+func respondAdjustmentError(w http.ResponseWriter, err error, msg string) {
+	status := adjustmentStatus(err)
+	if status == http.StatusInternalServerError {
+		log.Error().Err(err).Msg(msg)
+		respondError(w, status, msg)
+		return
+	}
+	respondLedgerError(w, status, err)
+}
+
+// CreateAdjustment godoc
+// @Summary      Post a manual adjustment
+// @Description  Records a corrective journal of balanced debit and credit lines (2 to 50, each with exactly one of debit or credit, JSON number or string, balanced per currency) under a reason_code: error_correction, reconciliation, fee_waiver, write_off, goodwill or other. It posts as an adjustment transaction at once, or, when the server requires dual control, stays pending (202) until a different admin approves it. Customer balances may not end below their floor either way. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{reason_code=string,memo=string,lines=[]object{account_id=string,debit=string,credit=string,description=string}}  true  "Journal"
+// @Success      201   {object}  AdjustmentResponse
+// @Success      202   {object}  AdjustmentResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/adjustments [post]
+// @Security     Bearer
+func (h *Handler) CreateAdjustment(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate the requesting admin and decode the journal.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		ReasonCode string `json:"reason_code"`
+		Memo       string `json:"memo"`
+		Lines      []struct {
+			Debit       interface{} `json:"debit"`
+			Credit      interface{} `json:"credit"`
+			AccountID   string      `json:"account_id"`
+			Description string      `json:"description"`
+		} `json:"lines"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	memo := strings.TrimSpace(input.Memo)
+	if memo == "" || len(memo) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "memo required (at most 500 characters)")
+		return
+	}
+	lines := make([]service.AdjustmentLine, 0, len(input.Lines))
+	for _, l := range input.Lines {
+		accountID, err := uuid.Parse(l.AccountID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid account_id")
+			return
+		}
+		line := service.AdjustmentLine{AccountID: accountID, Description: strings.TrimSpace(l.Description)}
+		if len(line.Description) > maxDecisionNote {
+			respondError(w, http.StatusBadRequest, "line description must be at most 500 characters")
+			return
+		}
+		if l.Debit != nil {
+			if line.Debit, err = normalizeAmountInput(l.Debit); err != nil {
+				respondError(w, http.StatusBadRequest, "invalid debit")
+				return
+			}
+		}
+		if l.Credit != nil {
+			if line.Credit, err = normalizeAmountInput(l.Credit); err != nil {
+				respondError(w, http.StatusBadRequest, "invalid credit")
+				return
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	// Step 2: Record it, posting at once unless dual control holds it for a checker.
+	adj, err := h.ledger.CreateAdjustment(r.Context(), service.AdjustmentInput{
+		ReasonCode:  strings.TrimSpace(input.ReasonCode),
+		Memo:        memo,
+		Lines:       lines,
+		RequestedBy: userID,
+	})
+	if err != nil {
+		respondAdjustmentError(w, err, "failed to create adjustment")
+		return
+	}
+
+	status := http.StatusCreated
+	if adj.Status == service.AdjustmentPending {
+		status = http.StatusAccepted
+	}
+	respondJSON(w, status, toAdjustmentResponse(adj.Adjustment, adj.Lines))
+}
+
+// ListAdjustments godoc
+// @Summary      List adjustments
+// @Description  Returns a page of adjustments in one status, oldest first, wrapped in {data, page}, without their lines. The default status "pending" is the dual control approval queue. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        status    query     string  false  "pending (default), posted or rejected"
+// @Param        limit     query     int     false  "Limit (default 20, max 100)"
+// @Param        offset    query     int     false  "Offset (default 0)"
+// @Param        envelope  query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200       {object}  PagedResponse{data=[]AdjustmentResponse}
+// @Failure      400       {object}  ErrorResponse
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
+// @Router       /admin/adjustments [get]
+// @Security     Bearer
+func (h *Handler) ListAdjustments(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.AdjustmentPending
+	case service.AdjustmentPending, service.AdjustmentPosted, service.AdjustmentRejected:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be pending, posted or rejected")
+		return
+	}
+	limit, offset := parsePage(r)
+
+	// Step 2: Fetch the page and the size of the whole list.
+	rows, err := h.store.ListAdjustmentsByStatus(r.Context(), sqlc.ListAdjustmentsByStatusParams{
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		Offset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to list adjustments")
+		respondError(w, http.StatusInternalServerError, "failed to list adjustments")
+		return
+	}
+	total, err := h.store.CountAdjustmentsByStatus(r.Context(), status)
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to count adjustments")
+		respondError(w, http.StatusInternalServerError, "failed to list adjustments")
+		return
+	}
+
+	resp := make([]AdjustmentResponse, 0, len(rows))
+	for _, adj := range rows {
+		resp = append(resp, toAdjustmentResponse(adj, nil))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// GetAdjustment godoc
+// @Summary      Get an adjustment
+// @Description  Returns an adjustment with its journal lines, who requested and decided it, and the transaction it posted. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Adjustment ID"
+// @Success      200  {object}  AdjustmentResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/adjustments/{id} [get]
+// @Security     Bearer
+func (h *Handler) GetAdjustment(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid adjustment ID")
+		return
+	}
+	adj, err := h.store.GetAdjustment(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, service.ErrAdjustmentNotFound.Error())
+			return
+		}
+		log.Error().Err(err).Str("adjustment_id", id.String()).Msg("Failed to get adjustment")
+		respondError(w, http.StatusInternalServerError, "failed to get adjustment")
+		return
+	}
+	lines, err := h.store.ListAdjustmentLines(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Str("adjustment_id", id.String()).Msg("Failed to list adjustment lines")
+		respondError(w, http.StatusInternalServerError, "failed to get adjustment")
+		return
+	}
+	respondJSON(w, http.StatusOK, toAdjustmentResponse(adj, lines))
+}
+
+// DecideAdjustment godoc
+// @Summary      Approve or reject an adjustment (dual control)
+// @Description  Approval posts the pending journal and records the approver atomically; rejection closes it without posting. The admin who requested an adjustment can never decide it, and a balance repair's adjustment is decided through the repair (409). Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                               true  "Adjustment ID"
+// @Param        body  body      object{decision=string,note=string}  true  "decision: approve or reject; note is required when rejecting"
+// @Success      200   {object}  AdjustmentResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/adjustments/{id}/decision [post]
+// @Security     Bearer
+func (h *Handler) DecideAdjustment(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate the checker and parse input.
+	approverID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid adjustment ID")
+		return
+	}
+	var input struct {
+		Decision string `json:"decision"`
+		Note     string `json:"note"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	note := strings.TrimSpace(input.Note)
+	if len(note) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "note must be at most 500 characters")
+		return
+	}
+
+	// Step 2: Decide under the adjustment's row lock.
+	var adj service.Adjustment
+	switch input.Decision {
+	case "approve":
+		adj, err = h.ledger.ApproveAdjustment(r.Context(), id, approverID, note)
+	case "reject":
+		if note == "" {
+			respondError(w, http.StatusBadRequest, "note required when rejecting")
+			return
+		}
+		adj, err = h.ledger.RejectAdjustment(r.Context(), id, approverID, note)
+	default:
+		respondError(w, http.StatusBadRequest, "decision must be approve or reject")
+		return
+	}
+	if err != nil {
+		respondAdjustmentError(w, err, "failed to decide adjustment")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toAdjustmentResponse(adj.Adjustment, adj.Lines))
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestAdjustmentStatus(t *testing.T) {
+	// Self-approval is forbidden, decided adjustments conflict, malformed journals are 400.
+	assert.Equal(t, http.StatusForbidden, adjustmentStatus(service.ErrAdjustmentSelfApproval))
+	assert.Equal(t, http.StatusConflict, adjustmentStatus(service.ErrAdjustmentNotPending))
+	assert.Equal(t, http.StatusNotFound, adjustmentStatus(service.ErrAccountNotFound))
+	assert.Equal(t, http.StatusBadRequest, adjustmentStatus(fmt.Errorf("%w: USD debits do not balance", service.ErrInvalidAdjustment)))
+	assert.Equal(t, http.StatusInternalServerError, adjustmentStatus(errors.New("connection reset")))
+}
+
+func TestListAdjustments_RejectsUnknownStatus(t *testing.T) {
+	// Only the three adjustment statuses can be listed.
+	rw := httptest.NewRecorder()
+	(&Handler{}).ListAdjustments(rw, httptest.NewRequest(http.MethodGet, "/admin/adjustments?status=approved", nil))
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// parseAccountFilters reads the admin account browser's optional filters. Unset ones stay null
+// and match every account.
+func parseAccountFilters(r *http.Request) (sqlc.CountSearchAccountsParams, error) {
+	q := r.URL.Query()
+	var f sqlc.CountSearchAccountsParams
+	if v := strings.TrimSpace(q.Get("owner_email")); v != "" {
+		f.OwnerEmail = sql.NullString{String: v, Valid: true}
+	}
+	if v := q.Get("currency"); v != "" {
+		currency, err := rates.NormalizeCurrency(v)
+		if err != nil {
+			return f, err
+		}
+		f.Currency = sql.NullString{String: currency, Valid: true}
+	}
+	var bounds [2]*decimal.Decimal
+	for i, key := range []string{"min_balance", "max_balance"} {
+		v := q.Get(key)
+		if v == "" {
+			continue
+		}
+		d, err := decimal.NewFromString(v)
+		if err != nil {
+			return f, errors.New(key + " must be a decimal amount")
+		}
+		bounds[i] = &d
+	}
+	if bounds[0] != nil && bounds[1] != nil && bounds[0].GreaterThan(*bounds[1]) {
+		return f, errors.New("min_balance must not exceed max_balance")
+	}
+	if bounds[0] != nil {
+		f.MinBalance = sql.NullString{String: bounds[0].String(), Valid: true}
+	}
+	if bounds[1] != nil {
+		f.MaxBalance = sql.NullString{String: bounds[1].String(), Valid: true}
+	}
+	if v := q.Get("is_system"); v != "" {
+		isSystem, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, errors.New("is_system must be true or false")
+		}
+		f.IsSystem = sql.NullBool{Bool: isSystem, Valid: true}
+	}
+	if v := strings.TrimSpace(q.Get("product")); v != "" {
+		f.Product = sql.NullString{String: v, Valid: true}
+	}
+	if v := q.Get("org_id"); v != "" {
+		orgID, err := uuid.Parse(v)
+		if err != nil {
+			return f, errors.New("invalid org_id")
+		}
+		f.OrgID = uuid.NullUUID{UUID: orgID, Valid: true}
+	}
+	return f, nil
+}
+
+// ListAllAccounts godoc
+// @Summary      Browse accounts
+// @Description  Returns a page of accounts across every organization, newest first unless sorted, wrapped in {data, page}, each with its primary owner's email. Every filter is optional: owner_email matches the primary owner case-insensitively, and min_balance and max_balance are inclusive. Accounts have no lifecycle status to filter on. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        owner_email  query     string  false  "Primary owner's email"
+// @Param        currency     query     string  false  "Currency"
+// @Param        min_balance  query     string  false  "Lowest balance"
+// @Param        max_balance  query     string  false  "Highest balance"
+// @Param        is_system    query     bool    false  "System (true) or customer (false) accounts"
+// @Param        product      query     string  false  "Account product"
+// @Param        org_id       query     string  false  "Organization ID"
+// @Param        sort         query     string  false  "created_at, -created_at (default), name, -name, balance or -balance"
+// @Param        limit        query     int     false  "Limit (default 20, max 100)"
+// @Param        offset       query     int     false  "Offset (default 0)"
+// @Param        envelope     query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200          {object}  PagedResponse{data=[]AdminAccountResponse}
+// @Failure      400          {object}  ErrorResponse
+// @Failure      401          {object}  ErrorResponse
+// @Failure      403          {object}  ErrorResponse
+// @Failure      500          {object}  ErrorResponse
+// @Router       /admin/accounts [get]
+// @Security     Bearer
+func (h *Handler) ListAllAccounts(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters, sort and page.
+	filters, err := parseAccountFilters(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	sort, err := parseSort(r, accountSorts)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, offset := parsePage(r)
+
+	// Step 2: Fetch the page and the size of the whole result.
+	rows, err := h.store.SearchAccounts(r.Context(), sqlc.SearchAccountsParams{
+		OwnerEmail: filters.OwnerEmail,
+		Currency:   filters.Currency,
+		MinBalance: filters.MinBalance,
+		MaxBalance: filters.MaxBalance,
+		IsSystem:   filters.IsSystem,
+		Product:    filters.Product,
+		OrgID:      filters.OrgID,
+		Sort:       sort,
+		RowLimit:   int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		RowOffset:  int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to search accounts")
+		respondError(w, http.StatusInternalServerError, "failed to list accounts")
+		return
+	}
+	total, err := h.store.CountSearchAccounts(r.Context(), filters)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count accounts")
+		respondError(w, http.StatusInternalServerError, "failed to list accounts")
+		return
+	}
+
+	catalog := h.productCatalog(r.Context())
+	resp := make([]AdminAccountResponse, 0, len(rows))
+	for _, row := range rows {
+		// A split system account shows the total of its shards.
+		row.Account.Balance = row.TotalBalance
+		resp = append(resp, AdminAccountResponse{
+			AccountResponse: toAccountResponse(row.Account, catalog),
+			OwnerEmail:      row.OwnerEmail.String,
+		})
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccountFilters(t *testing.T) {
+	// Given filters are normalized; the rest stay null so they match everything.
+	req := httptest.NewRequest(http.MethodGet, "/admin/accounts?owner_email=+Ada@Example.com&currency=usd&min_balance=-50&max_balance=100.5&is_system=false", nil)
+	f, err := parseAccountFilters(req)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada@Example.com", f.OwnerEmail.String)
+	assert.Equal(t, "USD", f.Currency.String)
+	assert.Equal(t, "-50", f.MinBalance.String)
+	assert.Equal(t, "100.5", f.MaxBalance.String)
+	assert.True(t, f.IsSystem.Valid)
+	assert.False(t, f.IsSystem.Bool)
+	assert.False(t, f.Product.Valid)
+	assert.False(t, f.OrgID.Valid)
+}
+
+func TestListAllAccounts_ValidatesQuery(t *testing.T) {
+	// Malformed filters and sorts are refused before the database is read.
+	h := &Handler{}
+	for _, query := range []string{
+		"currency=US",
+		"min_balance=ten",
+		"min_balance=10&max_balance=5",
+		"is_system=maybe",
+		"org_id=acme",
+		"sort=owner_email",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/accounts?"+query, nil)
+		rw := httptest.NewRecorder()
+		h.ListAllAccounts(rw, req)
+		assert.Equal(t, http.StatusBadRequest, rw.Code, query)
+	}
+}
@@ -0,0 +1,319 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/password"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// userRoles are every role an admin can give a user.
+var userRoles = []string{RoleCustomer, RoleOrgAdmin, RoleApprover, RoleCompliance, RoleAdmin}
+
+// parseUserFilters reads the admin user list's optional filters. Unset ones stay null and match
+// every user.
+func parseUserFilters(r *http.Request) (sqlc.CountUsersParams, error) {
+	q := r.URL.Query()
+	var f sqlc.CountUsersParams
+	if v := q.Get("org_id"); v != "" {
+		orgID, err := uuid.Parse(v)
+		if err != nil {
+			return f, errors.New("invalid org_id")
+		}
+		f.OrgID = uuid.NullUUID{UUID: orgID, Valid: true}
+	}
+	if v := strings.TrimSpace(q.Get("email")); v != "" {
+		f.Email = sql.NullString{String: v, Valid: true}
+	}
+	if v := q.Get("role"); v != "" {
+		if !slices.Contains(userRoles, v) {
+			return f, errors.New("role must be customer, org_admin, approver, compliance or admin")
+		}
+		f.Role = sql.NullString{String: v, Valid: true}
+	}
+	if v := q.Get("locked"); v != "" {
+		locked, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, errors.New("locked must be true or false")
+		}
+		f.Locked = sql.NullBool{Bool: locked, Valid: true}
+	}
+	return f, nil
+}
+
+// temporaryPassword returns a random password for an admin reset that also meets policy's length
+// and character class rules, at least 20 characters long.
+func temporaryPassword(policy password.Policy) (string, error) {
+	policy.Breaches = nil
+	b := make([]byte, max(15, (policy.MinLength*3+3)/4))
+	for range 100 {
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		temp := base64.RawURLEncoding.EncodeToString(b)
+		if len(policy.Check(context.Background(), temp, "")) == 0 {
+			return temp, nil
+		}
+	}
+	return "", errors.New("no random password met the password policy")
+}
+
+// adminUserTarget parses the user ID path parameter, refusing the caller's own ID so an admin
+// cannot lock, reset or demote themselves.
+func adminUserTarget(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	callerID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	if userID == callerID {
+		respondError(w, http.StatusBadRequest, "cannot change your own login or role")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return callerID, userID, true
+}
+
+// respondAdminUser writes the updated user, or the error that kept it from being updated.
+func respondAdminUser(w http.ResponseWriter, userID uuid.UUID, user sqlc.User, err error, action string) {
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to " + action)
+		respondError(w, http.StatusInternalServerError, "failed to "+action)
+		return
+	}
+	respondJSON(w, http.StatusOK, toAdminUserResponse(user))
+}
+
+// ListUsers godoc
+// @Summary      List users
+// @Description  Returns a page of users across every organization, oldest first, wrapped in {data, page}, with whether their login is locked or awaiting a password change. Every filter is optional; email matches any part of the address case-insensitively. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        org_id    query     string  false  "Organization ID"
+// @Param        email     query     string  false  "Part of the email"
+// @Param        role      query     string  false  "customer, org_admin, approver, compliance or admin"
+// @Param        locked    query     bool    false  "Only locked (true) or unlocked (false) users"
+// @Param        limit     query     int     false  "Limit (default 20, max 100)"
+// @Param        offset    query     int     false  "Offset (default 0)"
+// @Param        envelope  query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200       {object}  PagedResponse{data=[]AdminUserResponse}
+// @Failure      400       {object}  ErrorResponse
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
+// @Router       /admin/users [get]
+// @Security     Bearer
+func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters and page.
+	filters, err := parseUserFilters(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, offset := parsePage(r)
+
+	// Step 2: Fetch the page and the size of the whole result.
+	rows, err := h.store.ListUsers(r.Context(), sqlc.ListUsersParams{
+		OrgID:     filters.OrgID,
+		Email:     filters.Email,
+		Role:      filters.Role,
+		Locked:    filters.Locked,
+		RowLimit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		RowOffset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list users")
+		respondError(w, http.StatusInternalServerError, "failed to list users")
+		return
+	}
+	total, err := h.store.CountUsers(r.Context(), filters)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count users")
+		respondError(w, http.StatusInternalServerError, "failed to list users")
+		return
+	}
+
+	resp := make([]AdminUserResponse, 0, len(rows))
+	for _, u := range rows {
+		resp = append(resp, toAdminUserResponse(u))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// LockUser godoc
+// @Summary      Lock a user's login
+// @Description  Refuses the user's logins and password changes (403, code "account_locked") until they are unlocked, and logs out every session they have open. Admins cannot lock themselves. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                 true  "User ID"
+// @Param        body  body      object{reason=string}  true  "Why the login is locked"
+// @Success      200   {object}  AdminUserResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/users/{id}/lock [post]
+// @Security     Bearer
+func (h *Handler) LockUser(w http.ResponseWriter, r *http.Request) {
+	callerID, userID, ok := adminUserTarget(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		Reason string `json:"reason"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" || len(reason) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "reason required (at most 500 characters)")
+		return
+	}
+
+	user, err := h.store.LockUser(r.Context(), sqlc.LockUserParams{ID: userID, LockedReason: reason})
+	if err == nil {
+		log.Info().Str("user_id", userID.String()).Str("locked_by", callerID.String()).Str("reason", reason).Msg("User login locked")
+		h.revokeUserSessions(r.Context(), userID, "user locked")
+	}
+	respondAdminUser(w, userID, user, err, "lock user")
+}
+
+// UnlockUser godoc
+// @Summary      Unlock a user's login
+// @Description  Lets a locked user log in again, also lifting a temporary lockout from failed logins. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  AdminUserResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/users/{id}/unlock [post]
+// @Security     Bearer
+func (h *Handler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	callerID, userID, ok := adminUserTarget(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.store.UnlockUser(r.Context(), userID)
+	if err == nil {
+		log.Info().Str("user_id", userID.String()).Str("unlocked_by", callerID.String()).Msg("User login unlocked")
+	}
+	respondAdminUser(w, userID, user, err, "unlock user")
+}
+
+// ResetUserPassword godoc
+// @Summary      Force a password reset
+// @Description  Replaces the user's password with a random temporary one, returned only in this response for the admin to pass on. The user's next login is refused (403, code "password_reset_required") until they choose a new password with POST /password/change, and their open sessions are logged out. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  PasswordResetResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/users/{id}/password-reset [post]
+// @Security     Bearer
+func (h *Handler) ResetUserPassword(w http.ResponseWriter, r *http.Request) {
+	callerID, userID, ok := adminUserTarget(w, r)
+	if !ok {
+		return
+	}
+
+	temp, err := temporaryPassword(h.passwordPolicy)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate temporary password")
+		respondError(w, http.StatusInternalServerError, "failed to reset password")
+		return
+	}
+	hashed, err := h.passwords.Hash(temp)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to hash password")
+		respondError(w, http.StatusInternalServerError, "failed to reset password")
+		return
+	}
+	if _, err := h.store.SetUserPassword(r.Context(), sqlc.SetUserPasswordParams{
+		ID:                    userID,
+		HashedPassword:        hashed,
+		PasswordResetRequired: true,
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to reset password")
+		respondError(w, http.StatusInternalServerError, "failed to reset password")
+		return
+	}
+
+	log.Info().Str("user_id", userID.String()).Str("reset_by", callerID.String()).Msg("User password reset")
+	h.revokeUserSessions(r.Context(), userID, "password reset")
+	respondJSON(w, http.StatusOK, PasswordResetResponse{UserID: userID.String(), TemporaryPassword: temp})
+}
+
+// SetUserRole godoc
+// @Summary      Change a user's role
+// @Description  Sets any user to customer, org_admin, approver, compliance or admin. The user's open sessions are logged out, so the new role takes effect when they log in again. Admins cannot change their own role. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string               true  "User ID"
+// @Param        body  body      object{role=string}  true  "customer, org_admin, approver, compliance or admin"
+// @Success      200   {object}  AdminUserResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/users/{id}/role [put]
+// @Security     Bearer
+func (h *Handler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	callerID, userID, ok := adminUserTarget(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		Role string `json:"role"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	if !slices.Contains(userRoles, input.Role) {
+		respondError(w, http.StatusBadRequest, "role must be customer, org_admin, approver, compliance or admin")
+		return
+	}
+
+	user, err := h.store.SetUserRole(r.Context(), sqlc.SetUserRoleParams{ID: userID, Role: input.Role})
+	if err == nil {
+		log.Info().Str("user_id", userID.String()).Str("changed_by", callerID.String()).Str("role", input.Role).Msg("User role changed")
+		h.revokeUserSessions(r.Context(), userID, "role changed")
+	}
+	respondAdminUser(w, userID, user, err, "set role")
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/password"
+)
+
+func TestParseUserFilters(t *testing.T) {
+	// Known roles and booleans are accepted; anything else is refused before the database is read.
+	f, err := parseUserFilters(httptest.NewRequest(http.MethodGet, "/admin/users?email=ada&role=approver&locked=true", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "ada", f.Email.String)
+	assert.Equal(t, RoleApprover, f.Role.String)
+	assert.True(t, f.Locked.Bool)
+	assert.False(t, f.OrgID.Valid)
+
+	for _, query := range []string{"role=root", "locked=maybe", "org_id=acme"} {
+		_, err := parseUserFilters(httptest.NewRequest(http.MethodGet, "/admin/users?"+query, nil))
+		assert.Error(t, err, query)
+	}
+}
+
+func TestAdminUserEndpoints_RejectSelf(t *testing.T) {
+	// An admin cannot lock, reset or change the role of their own user.
+	require.NoError(t, InitTokenAuth("fV7sliKV3qn657I60wEFtw/Auk/0bNU9zdp30wFzfDg="))
+	userID := uuid.New()
+	token, err := GenerateToken(userID, uuid.New(), RoleAdmin)
+	require.NoError(t, err)
+
+	h := &Handler{}
+	router := chi.NewRouter()
+	router.Group(func(r chi.Router) {
+		r.Use(Verifier, Authenticator)
+		r.Post("/admin/users/{id}/lock", h.LockUser)
+		r.Post("/admin/users/{id}/password-reset", h.ResetUserPassword)
+		r.Put("/admin/users/{id}/role", h.SetUserRole)
+	})
+
+	for _, tc := range []struct{ method, path, body string }{
+		{http.MethodPost, "/lock", `{"reason":"fraud"}`},
+		{http.MethodPost, "/password-reset", ``},
+		{http.MethodPut, "/role", `{"role":"customer"}`},
+	} {
+		req := httptest.NewRequest(tc.method, "/admin/users/"+userID.String()+tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusBadRequest, rw.Code, tc.path)
+		assert.Contains(t, rw.Body.String(), "your own", tc.path)
+	}
+}
+
+func TestTemporaryPassword(t *testing.T) {
+	// Temporary passwords are random, long enough to resist guessing and meet the policy.
+	a, err := temporaryPassword(password.DefaultPolicy())
+	require.NoError(t, err)
+	b, err := temporaryPassword(password.DefaultPolicy())
+	require.NoError(t, err)
+	assert.Len(t, a, 20)
+	assert.NotEqual(t, a, b)
+
+	strict := password.Policy{MinLength: 32, Require: password.Classes}
+	c, err := temporaryPassword(strict)
+	require.NoError(t, err)
+	assert.Empty(t, strict.Check(context.Background(), c, ""))
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// respondScreening answers an operation AML screening or fraud risk scoring stopped: 202 with code
+// pending_review when it is held for compliance review, 403 with code transaction_declined when it
+// was blocked, 401 with code step_up_required when it must be retried signed with a key registered
+// on the account. It reports whether it wrote a response.
+func respondScreening(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrStepUpRequired):
+		respondLedgerError(w, http.StatusUnauthorized, err)
+	case errors.Is(err, service.ErrHeldForReview):
+		respondLedgerError(w, http.StatusAccepted, err)
+	case errors.Is(err, service.ErrScreeningBlocked):
+		respondLedgerError(w, http.StatusForbidden, err)
+	default:
+		return false
+	}
+	return true
+}
+
+// amlAlertStatus maps AML review errors to an HTTP status.
+func amlAlertStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAMLAlertNotFound), errors.Is(err, service.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAMLAlertNotOpen), errors.Is(err, service.ErrAMLDecisionNotAllowed):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrMinimumBalance),
+		errors.Is(err, service.ErrDebitLimitExceeded), errors.Is(err, service.ErrOperationNotAllowed), errors.Is(err, service.ErrSavingsGoalLocked),
+		errors.Is(err, service.ErrKYCRequired), errors.Is(err, service.ErrKYCLimitExceeded),
+		errors.Is(err, service.ErrCurrencyMismatch), errors.Is(err, service.ErrCrossOrgTransfer):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ListAMLAlerts godoc
+// @Summary      List AML alerts
+// @Description  Returns the caller's organization's AML alerts by status, oldest first. The default status "open" is the review queue: flagged operations that posted, held operations waiting to be released, and blocked ones. Compliance staff only.
+// @Tags         compliance
+// @Produce      json
+// @Param        status  query     string  false  "open (default), released, rejected, cleared or reported"
+// @Param        limit   query     int     false  "Limit (default 20)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   AMLAlertResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /org/aml/alerts [get]
+// @Security     Bearer
+func (h *Handler) ListAMLAlerts(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.AMLAlertOpen
+	case service.AMLAlertOpen, service.AMLAlertReleased, service.AMLAlertRejected, service.AMLAlertCleared, service.AMLAlertReported:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be open, released, rejected, cleared or reported")
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	// Step 2: Fetch the page.
+	rows, err := h.store.ListAMLAlertsByStatus(r.Context(), sqlc.ListAMLAlertsByStatusParams{
+		OrgID:  orgID,
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Str("status", status).Msg("Failed to list AML alerts")
+		respondError(w, http.StatusInternalServerError, "failed to list AML alerts")
+		return
+	}
+
+	resp := make([]AMLAlertResponse, 0, len(rows))
+	for _, a := range rows {
+		resp = append(resp, toAMLAlertResponse(a))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ReviewAMLAlert godoc
+// @Summary      Review an AML alert
+// @Description  Closes an open alert. A held operation is released, which posts it as requested after the usual balance checks, or rejected. A flagged or blocked one is cleared, or reported for a suspicious activity report. Compliance staff only.
+// @Tags         compliance
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                               true  "Alert ID"
+// @Param        body  body      object{decision=string,note=string}  true  "decision: release, reject, clear or report; note is required except when releasing or clearing"
+// @Success      200   {object}  AMLAlertResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /org/aml/alerts/{id}/decision [post]
+// @Security     Bearer
+func (h *Handler) ReviewAMLAlert(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate the reviewer (role is enforced by RequireRole) and parse input.
+	reviewerID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	alertID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid alert ID")
+		return
+	}
+	var input struct {
+		Decision string `json:"decision"`
+		Note     string `json:"note"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	note := strings.TrimSpace(input.Note)
+	if len(note) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "note must be at most 500 characters")
+		return
+	}
+
+	// Step 2: Decide under the alert's row lock.
+	var alert sqlc.AmlAlert
+	switch input.Decision {
+	case "release":
+		alert, err = h.ledger.ReleaseAMLAlert(r.Context(), orgID, alertID, reviewerID, note)
+	case "clear":
+		alert, err = h.ledger.ReviewAMLAlert(r.Context(), orgID, alertID, reviewerID, service.AMLAlertCleared, note)
+	case "reject", "report":
+		if note == "" {
+			respondError(w, http.StatusBadRequest, "note required when rejecting or reporting")
+			return
+		}
+		status := service.AMLAlertRejected
+		if input.Decision == "report" {
+			status = service.AMLAlertReported
+		}
+		alert, err = h.ledger.ReviewAMLAlert(r.Context(), orgID, alertID, reviewerID, status, note)
+	default:
+		respondError(w, http.StatusBadRequest, "decision must be release, reject, clear or report")
+		return
+	}
+	if err != nil {
+		status := amlAlertStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Error().Err(err).Str("alert_id", alertID.String()).Msg("Failed to review AML alert")
+			respondError(w, status, "failed to review AML alert")
+			return
+		}
+		respondLedgerError(w, status, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toAMLAlertResponse(alert))
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestRespondScreening(t *testing.T) {
+	// Held operations answer 202 and blocked ones 403, each with a code; other errors are left alone.
+	rw := httptest.NewRecorder()
+	assert.True(t, respondScreening(rw, service.ErrHeldForReview))
+	assert.Equal(t, http.StatusAccepted, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"code":"pending_review"`)
+
+	rw = httptest.NewRecorder()
+	assert.True(t, respondScreening(rw, service.ErrScreeningBlocked))
+	assert.Equal(t, http.StatusForbidden, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"code":"transaction_declined"`)
+
+	assert.False(t, respondScreening(httptest.NewRecorder(), nil))
+	assert.False(t, respondScreening(httptest.NewRecorder(), service.ErrInsufficientFunds))
+}
+
+func TestAMLAlertStatus(t *testing.T) {
+	// Reviewed alerts and mismatched decisions conflict; a release that cannot post is 400.
+	assert.Equal(t, http.StatusConflict, amlAlertStatus(service.ErrAMLAlertNotOpen))
+	assert.Equal(t, http.StatusConflict, amlAlertStatus(service.ErrAMLDecisionNotAllowed))
+	assert.Equal(t, http.StatusNotFound, amlAlertStatus(fmt.Errorf("lock: %w", service.ErrAMLAlertNotFound)))
+	assert.Equal(t, http.StatusBadRequest, amlAlertStatus(service.ErrInsufficientFunds))
+	assert.Equal(t, http.StatusInternalServerError, amlAlertStatus(errors.New("connection reset")))
+}
+
+func TestRespondScreening_StepUp(t *testing.T) {
+	// Operations challenged for fraud risk ask for a signed retry.
+	rw := httptest.NewRecorder()
+	assert.True(t, respondScreening(rw, service.ErrStepUpRequired))
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"code":"step_up_required"`)
+}
@@ -32,17 +32,19 @@ func normalizeAmountInput(value interface{}) (string, error) {
 	}
 }
 
-func decodeAmountFromBody(r *http.Request) (string, error) {
+// decodeAmountFromBody reads a body holding only an amount, writing a 400 and returning false
+// when it is malformed.
+func decodeAmountFromBody(w http.ResponseWriter, r *http.Request) (string, bool) {
 	var input struct {
 		Amount interface{} `json:"amount"`
 	}
-
-	// UseNumber prevents automatic conversion into float64.
-	dec := json.NewDecoder(r.Body)
-	dec.UseNumber()
-	if err := dec.Decode(&input); err != nil {
-		return "", err
+	if !decodeJSON(w, r, &input) {
+		return "", false
 	}
-
-	return normalizeAmountInput(input.Amount)
+	amount, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+	return amount, true
 }
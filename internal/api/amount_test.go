@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,6 +18,8 @@ func TestNormalizeAmountInput(t *testing.T) {
 func TestDecodeAmountFromBody_Invalid(t *testing.T) {
 	// Empty body should fail JSON decoding.
 	req := &http.Request{Body: http.NoBody}
-	_, err := decodeAmountFromBody(req)
-	assert.Error(t, err)
+	rw := httptest.NewRecorder()
+	_, ok := decodeAmountFromBody(rw, req)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
 }
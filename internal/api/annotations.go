@@ -0,0 +1,152 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// maxAnnotationNote caps a transaction note.
+const maxAnnotationNote = 500
+
+// CreateTransactionAnnotation godoc
+// @Summary      Add a note to a transaction
+// @Description  Attaches a note to a transaction the caller can see ("this was for March rent"). Notes are personal to the caller and append-only: a note cannot be edited or removed, only followed by another.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string               true  "Transaction ID"
+// @Param        body  body      object{note=string}  true  "Note (at most 500 characters)"
+// @Success      201   {object}  AnnotationResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /transactions/{id}/annotations [post]
+// @Security     Bearer
+func (h *Handler) CreateTransactionAnnotation(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and decode payload.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	transactionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+	var input struct {
+		Note string `json:"note"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	note := strings.TrimSpace(input.Note)
+	if note == "" || len(note) > maxAnnotationNote {
+		respondError(w, http.StatusBadRequest, "note required (at most 500 characters)")
+		return
+	}
+
+	// Step 2: Only transactions the caller can see take their notes.
+	if _, ok := h.visibleTransactionEntries(w, r, userID, transactionID); !ok {
+		return
+	}
+
+	// Step 3: Append the note.
+	annotation, err := h.store.CreateTransactionAnnotation(r.Context(), sqlc.CreateTransactionAnnotationParams{
+		TransactionID: transactionID,
+		UserID:        userID,
+		Note:          note,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Str("user_id", userID.String()).Msg("Failed to annotate transaction")
+		respondError(w, http.StatusInternalServerError, "failed to annotate transaction")
+		return
+	}
+	respondJSON(w, http.StatusCreated, toAnnotationResponse(annotation))
+}
+
+// ListTransactionAnnotations godoc
+// @Summary      List a transaction's notes
+// @Description  Returns the notes the caller attached to a transaction they can see, oldest first. GET /transactions/{id}?annotations=true returns them with the entries.
+// @Tags         accounts
+// @Produce      json
+// @Param        id   path      string  true  "Transaction ID"
+// @Success      200  {array}   AnnotationResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /transactions/{id}/annotations [get]
+// @Security     Bearer
+func (h *Handler) ListTransactionAnnotations(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	transactionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+	if _, ok := h.visibleTransactionEntries(w, r, userID, transactionID); !ok {
+		return
+	}
+	resp, ok := h.transactionAnnotations(w, r, userID, transactionID)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// visibleTransactionEntries loads a transaction's entries when the caller can see it, writing the
+// error response and returning false when it does not exist or they cannot.
+func (h *Handler) visibleTransactionEntries(w http.ResponseWriter, r *http.Request, userID, transactionID uuid.UUID) ([]sqlc.Entry, bool) {
+	entries, err := h.store.ListEntriesByTransaction(r.Context(), transactionID)
+	if err != nil {
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to fetch transaction")
+		respondError(w, http.StatusInternalServerError, "failed to fetch transaction")
+		return nil, false
+	}
+	if len(entries) == 0 {
+		respondError(w, http.StatusNotFound, "transaction not found")
+		return nil, false
+	}
+	authorized, err := h.transactionVisible(r.Context(), userID, entries)
+	if err != nil {
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to authorize transaction")
+		respondError(w, http.StatusInternalServerError, "failed to authorize transaction")
+		return nil, false
+	}
+	if !authorized {
+		respondError(w, http.StatusForbidden, "access denied")
+		return nil, false
+	}
+	return entries, true
+}
+
+// transactionAnnotations returns the caller's notes on a transaction, writing a 500 and returning
+// false when they cannot be read.
+func (h *Handler) transactionAnnotations(w http.ResponseWriter, r *http.Request, userID, transactionID uuid.UUID) ([]AnnotationResponse, bool) {
+	annotations, err := h.store.ListTransactionAnnotations(r.Context(), sqlc.ListTransactionAnnotationsParams{
+		TransactionID: transactionID,
+		UserID:        userID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to list transaction annotations")
+		respondError(w, http.StatusInternalServerError, "failed to list transaction annotations")
+		return nil, false
+	}
+	resp := make([]AnnotationResponse, 0, len(annotations))
+	for _, a := range annotations {
+		resp = append(resp, toAnnotationResponse(a))
+	}
+	return resp, true
+}
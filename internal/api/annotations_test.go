@@ -0,0 +1,27 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestToAnnotationResponse(t *testing.T) {
+	// The author is the caller, so only the note and what it is attached to are returned.
+	a := sqlc.TransactionAnnotation{
+		ID:            uuid.New(),
+		TransactionID: uuid.New(),
+		UserID:        uuid.New(),
+		Note:          "this was for March rent",
+		CreatedAt:     time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC),
+	}
+	resp := toAnnotationResponse(a)
+	assert.Equal(t, a.ID.String(), resp.ID)
+	assert.Equal(t, a.TransactionID.String(), resp.TransactionID)
+	assert.Equal(t, "this was for March rent", resp.Note)
+	assert.Equal(t, a.CreatedAt, resp.CreatedAt)
+}
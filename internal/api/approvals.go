@@ -0,0 +1,250 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// maxDecisionNote bounds reasons and approval notes.
+const maxDecisionNote = 500
+
+// transferRequestStatus maps maker-checker errors to an HTTP status.
+func transferRequestStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTransferRequestNotFound), errors.Is(err, service.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrTransferRequestNotPending):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrSelfApproval):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrMinimumBalance), errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountPrecision),
+		errors.Is(err, service.ErrDebitLimitExceeded), errors.Is(err, service.ErrOperationNotAllowed), errors.Is(err, service.ErrSavingsGoalLocked),
+		errors.Is(err, service.ErrCurrencyMismatch), errors.Is(err, service.ErrSameAccountTransfer),
+		errors.Is(err, service.ErrCrossOrgTransfer):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CreateTransferRequest godoc
+// @Summary      Request a transfer (maker)
+// @Description  Queues a transfer between two accounts of the caller's organization. Nothing is posted until a different user with the approver role approves it. Organization admins and approvers only.
+// @Tags         approvals
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{from_id=string,to_id=string,amount=string,reason=string}  true  "Transfer to approve"
+// @Success      201   {object}  TransferRequestResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /org/transfer-requests [post]
+// @Security     Bearer
+func (h *Handler) CreateTransferRequest(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate the maker.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	// Step 2: Decode payload.
+	var input struct {
+		Amount interface{} `json:"amount"`
+		FromID string      `json:"from_id"`
+		ToID   string      `json:"to_id"`
+		Reason string      `json:"reason"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	fromID, err := uuid.Parse(input.FromID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid from_id")
+		return
+	}
+	toID, err := uuid.Parse(input.ToID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid to_id")
+		return
+	}
+	amount, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid amount")
+		return
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" || len(reason) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "reason required (at most 500 characters)")
+		return
+	}
+
+	// Step 3: Queue for a checker.
+	req, err := h.ledger.CreateTransferRequest(r.Context(), service.TransferRequestInput{
+		OrgID:       orgID,
+		FromID:      fromID,
+		ToID:        toID,
+		Amount:      amount,
+		Reason:      reason,
+		RequestedBy: userID,
+	})
+	if err != nil {
+		status := transferRequestStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to create transfer request")
+			respondError(w, status, "failed to create transfer request")
+			return
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toTransferRequestResponse(req))
+}
+
+// ListTransferRequests godoc
+// @Summary      List transfer requests
+// @Description  Returns the caller's organization's transfer requests by status, oldest first. The default status "pending" is the approval queue. Organization admins and approvers only.
+// @Tags         approvals
+// @Produce      json
+// @Param        status  query     string  false  "pending (default), approved or rejected"
+// @Param        limit   query     int     false  "Limit (default 20)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   TransferRequestResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /org/transfer-requests [get]
+// @Security     Bearer
+func (h *Handler) ListTransferRequests(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.TransferRequestPending
+	case service.TransferRequestPending, service.TransferRequestApproved, service.TransferRequestRejected:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be pending, approved or rejected")
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	// Step 2: Fetch the page.
+	rows, err := h.store.ListTransferRequestsByStatus(r.Context(), sqlc.ListTransferRequestsByStatusParams{
+		OrgID:  orgID,
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Str("status", status).Msg("Failed to list transfer requests")
+		respondError(w, http.StatusInternalServerError, "failed to list transfer requests")
+		return
+	}
+
+	resp := make([]TransferRequestResponse, 0, len(rows))
+	for _, req := range rows {
+		resp = append(resp, toTransferRequestResponse(req))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// DecideTransferRequest godoc
+// @Summary      Approve or reject a transfer request (checker)
+// @Description  Approval posts the transfer and records the approver atomically; rejection closes the request without posting. The requester can never decide their own request. Approvers only.
+// @Tags         approvals
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                               true  "Transfer request ID"
+// @Param        body  body      object{decision=string,note=string}  true  "decision: approve or reject; note is required when rejecting"
+// @Success      200   {object}  TransferRequestResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /org/transfer-requests/{id}/decision [post]
+// @Security     Bearer
+func (h *Handler) DecideTransferRequest(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate the checker (role is enforced by RequireRole) and parse input.
+	approverID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	requestID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid transfer request ID")
+		return
+	}
+	var input struct {
+		Decision string `json:"decision"`
+		Note     string `json:"note"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	note := strings.TrimSpace(input.Note)
+	if len(note) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "note must be at most 500 characters")
+		return
+	}
+
+	// Step 2: Decide under the request's row lock.
+	var req sqlc.TransferRequest
+	switch input.Decision {
+	case "approve":
+		req, err = h.ledger.ApproveTransferRequest(r.Context(), orgID, requestID, approverID, note)
+	case "reject":
+		if note == "" {
+			respondError(w, http.StatusBadRequest, "note required when rejecting")
+			return
+		}
+		req, err = h.ledger.RejectTransferRequest(r.Context(), orgID, requestID, approverID, note)
+	default:
+		respondError(w, http.StatusBadRequest, "decision must be approve or reject")
+		return
+	}
+	if err != nil {
+		status := transferRequestStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Error().Err(err).Str("request_id", requestID.String()).Msg("Failed to decide transfer request")
+			respondError(w, status, "failed to decide transfer request")
+			return
+		}
+		respondLedgerError(w, status, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toTransferRequestResponse(req))
+}
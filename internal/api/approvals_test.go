@@ -0,0 +1,21 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestTransferRequestStatus(t *testing.T) {
+	// Self-approval is forbidden, decided requests conflict, business rule failures are 400.
+	assert.Equal(t, http.StatusForbidden, transferRequestStatus(service.ErrSelfApproval))
+	assert.Equal(t, http.StatusConflict, transferRequestStatus(service.ErrTransferRequestNotPending))
+	assert.Equal(t, http.StatusNotFound, transferRequestStatus(fmt.Errorf("lock: %w", service.ErrTransferRequestNotFound)))
+	assert.Equal(t, http.StatusBadRequest, transferRequestStatus(service.ErrInsufficientFunds))
+	assert.Equal(t, http.StatusInternalServerError, transferRequestStatus(errors.New("connection reset")))
+}
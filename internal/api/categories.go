@@ -0,0 +1,453 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+const (
+	// maxCategoryName bounds a category's name.
+	maxCategoryName = 50
+	// maxSpendingRange bounds how far apart from and to may be in a spending query.
+	maxSpendingRange = 2 * 366 * 24 * time.Hour
+)
+
+// categoryStatus maps categorization errors to an HTTP status.
+func categoryStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCategoryNotFound), errors.Is(err, service.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidCategoryRule), errors.Is(err, service.ErrInvalidSpendPeriod):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondCategoryError writes err with its categorization status, hiding internal failures.
+func respondCategoryError(w http.ResponseWriter, err error, msg string) {
+	status := categoryStatus(err)
+	if status == http.StatusInternalServerError {
+		log.Error().Err(err).Msg("Categorization failed")
+		respondError(w, status, msg)
+		return
+	}
+	respondError(w, status, err.Error())
+}
+
+// CreateCategory godoc
+// @Summary      Create a spending category
+// @Description  Adds a category to the caller's own set. Names are unique per user, ignoring case.
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{name=string}  true  "Category name"
+// @Success      201   {object}  CategoryResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Router       /categories [post]
+// @Security     Bearer
+func (h *Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		Name string `json:"name"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > maxCategoryName {
+		respondError(w, http.StatusBadRequest, "name required (at most 50 characters)")
+		return
+	}
+
+	category, err := h.store.CreateCategory(r.Context(), sqlc.CreateCategoryParams{UserID: userID, Name: name})
+	if err != nil {
+		// The only constraint left to trip is the unique name per user.
+		log.Error().Err(err).Str("user_id", userID.String()).Str("name", name).Msg("Failed to create category")
+		respondError(w, http.StatusConflict, "category name already in use or creation failed")
+		return
+	}
+	respondJSON(w, http.StatusCreated, toCategoryResponse(category))
+}
+
+// ListCategories godoc
+// @Summary      List spending categories
+// @Description  Returns the caller's categories by name
+// @Tags         analytics
+// @Produce      json
+// @Success      200  {array}   CategoryResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /categories [get]
+// @Security     Bearer
+func (h *Handler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	categories, err := h.store.ListCategories(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list categories")
+		respondError(w, http.StatusInternalServerError, "failed to list categories")
+		return
+	}
+	resp := make([]CategoryResponse, 0, len(categories))
+	for _, c := range categories {
+		resp = append(resp, toCategoryResponse(c))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// DeleteCategory godoc
+// @Summary      Delete a spending category
+// @Description  Removes the category with its rules and manual assignments; its entries become uncategorized unless another rule matches
+// @Tags         analytics
+// @Param        id  path  string  true  "Category ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /categories/{id} [delete]
+// @Security     Bearer
+func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	categoryID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+	if err := h.ledger.DeleteCategory(r.Context(), userID, categoryID); err != nil {
+		respondCategoryError(w, err, "failed to delete category")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateCategoryRule godoc
+// @Summary      Add a categorization rule
+// @Description  Files the caller's entries under a category automatically: either when the entry description contains description_contains (case-insensitive) or when the other side of the transaction is counterparty_account_id. Rules apply to past and future entries alike, lowest priority first; a manual assignment always wins.
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{category_id=string,description_contains=string,counterparty_account_id=string,priority=int}  true  "Rule"
+// @Success      201   {object}  CategoryRuleResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /categories/rules [post]
+// @Security     Bearer
+func (h *Handler) CreateCategoryRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		CategoryID            string `json:"category_id"`
+		DescriptionContains   string `json:"description_contains"`
+		CounterpartyAccountID string `json:"counterparty_account_id"`
+		Priority              int32  `json:"priority"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	categoryID, err := uuid.Parse(input.CategoryID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid category_id")
+		return
+	}
+	var counterpartyID uuid.UUID
+	if input.CounterpartyAccountID != "" {
+		if counterpartyID, err = uuid.Parse(input.CounterpartyAccountID); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid counterparty_account_id")
+			return
+		}
+	}
+
+	rule, err := h.ledger.CreateCategoryRule(r.Context(), service.CategoryRuleRequest{
+		UserID:                userID,
+		OrgID:                 orgID,
+		CategoryID:            categoryID,
+		DescriptionContains:   input.DescriptionContains,
+		CounterpartyAccountID: counterpartyID,
+		Priority:              input.Priority,
+	})
+	if err != nil {
+		respondCategoryError(w, err, "failed to create rule")
+		return
+	}
+	respondJSON(w, http.StatusCreated, toCategoryRuleResponse(rule))
+}
+
+// ListCategoryRules godoc
+// @Summary      List categorization rules
+// @Description  Returns the caller's rules in the order they are tried
+// @Tags         analytics
+// @Produce      json
+// @Success      200  {array}   CategoryRuleResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /categories/rules [get]
+// @Security     Bearer
+func (h *Handler) ListCategoryRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	rules, err := h.store.ListCategoryRules(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list category rules")
+		respondError(w, http.StatusInternalServerError, "failed to list rules")
+		return
+	}
+	resp := make([]CategoryRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, toCategoryRuleResponse(rule))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// DeleteCategoryRule godoc
+// @Summary      Delete a categorization rule
+// @Tags         analytics
+// @Param        id  path  string  true  "Rule ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /categories/rules/{id} [delete]
+// @Security     Bearer
+func (h *Handler) DeleteCategoryRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	ruleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid rule ID")
+		return
+	}
+	found, err := h.ledger.DeleteCategoryRule(r.Context(), userID, ruleID)
+	if err != nil {
+		respondCategoryError(w, err, "failed to delete rule")
+		return
+	}
+	if !found {
+		respondError(w, http.StatusNotFound, "rule not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetEntryCategory godoc
+// @Summary      Categorize an entry
+// @Description  Files one entry of an account the caller can see under one of their categories, overriding rules. Other holders of a joint account keep their own categories.
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                      true  "Entry ID"
+// @Param        body  body      object{category_id=string}  true  "Category"
+// @Success      200   {object}  EntryCategoryResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /entries/{id}/category [put]
+// @Security     Bearer
+func (h *Handler) SetEntryCategory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		CategoryID string `json:"category_id"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	categoryID, err := uuid.Parse(input.CategoryID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid category_id")
+		return
+	}
+	entry, ok := h.visibleEntry(w, r, userID)
+	if !ok {
+		return
+	}
+
+	assigned, err := h.ledger.SetEntryCategory(r.Context(), userID, entry.ID, categoryID)
+	if err != nil {
+		respondCategoryError(w, err, "failed to categorize entry")
+		return
+	}
+	respondJSON(w, http.StatusOK, EntryCategoryResponse{
+		EntryID:    assigned.EntryID.String(),
+		CategoryID: assigned.CategoryID.String(),
+	})
+}
+
+// ClearEntryCategory godoc
+// @Summary      Remove an entry's manual category
+// @Description  Drops the caller's manual assignment so rules apply to the entry again
+// @Tags         analytics
+// @Param        id  path  string  true  "Entry ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /entries/{id}/category [delete]
+// @Security     Bearer
+func (h *Handler) ClearEntryCategory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	entry, ok := h.visibleEntry(w, r, userID)
+	if !ok {
+		return
+	}
+	if err := h.ledger.ClearEntryCategory(r.Context(), userID, entry.ID); err != nil {
+		respondCategoryError(w, err, "failed to clear entry category")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// visibleEntry loads the {id} entry if the caller can see its account.
+func (h *Handler) visibleEntry(w http.ResponseWriter, r *http.Request, userID uuid.UUID) (sqlc.Entry, bool) {
+	entryID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid entry ID")
+		return sqlc.Entry{}, false
+	}
+	entry, err := h.store.GetEntry(r.Context(), entryID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "entry not found")
+		return sqlc.Entry{}, false
+	}
+	if _, ok := h.visibleAccount(w, r, userID, entry.AccountID); !ok {
+		return sqlc.Entry{}, false
+	}
+	return entry, true
+}
+
+// GetSpendingAnalytics godoc
+// @Summary      Spending per category per period
+// @Description  Totals the account's debits (transfers out, withdrawals, payments and fees) per UTC day, week or month and per category as the caller has categorized them: manual assignment first, then the first matching rule, otherwise uncategorized (category_id omitted). from and to are inclusive UTC dates at most two years apart; the default is the last twelve calendar months by month.
+// @Tags         analytics
+// @Produce      json
+// @Param        id      path      string  true   "Account ID"
+// @Param        period  query     string  false  "day, week or month (default month)"
+// @Param        from    query     string  false  "First day (YYYY-MM-DD)"
+// @Param        to      query     string  false  "Last day (YYYY-MM-DD, default today)"
+// @Success      200     {object}  SpendingResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      404     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /accounts/{id}/analytics/spending [get]
+// @Security     Bearer
+func (h *Handler) GetSpendingAnalytics(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and enforce visibility.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	acc, ok := h.visibleAccount(w, r, userID, accountID)
+	if !ok {
+		return
+	}
+
+	// Step 2: Resolve the range; to is inclusive, so the query runs up to the next midnight.
+	period, from, to, err := spendingRange(r, time.Now().UTC())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Step 3: Aggregate from the entries.
+	rows, err := h.ledger.SpendByCategory(r.Context(), userID, accountID, period, from, to)
+	if err != nil {
+		respondCategoryError(w, err, "failed to compute spending")
+		return
+	}
+
+	buckets := make([]SpendingBucketResponse, 0, len(rows))
+	for _, row := range rows {
+		buckets = append(buckets, toSpendingBucketResponse(row))
+	}
+	respondJSON(w, http.StatusOK, SpendingResponse{
+		AccountID: accountID.String(),
+		Currency:  acc.Currency,
+		Period:    period,
+		From:      from.Format("2006-01-02"),
+		To:        to.AddDate(0, 0, -1).Format("2006-01-02"),
+		Buckets:   buckets,
+	})
+}
+
+// spendingRange parses period, from and to, returning the half-open [from, to) to query.
+func spendingRange(r *http.Request, now time.Time) (string, time.Time, time.Time, error) {
+	q := r.URL.Query()
+	period := q.Get("period")
+	if period == "" {
+		period = "month"
+	}
+	if period != "day" && period != "week" && period != "month" {
+		return "", time.Time{}, time.Time{}, service.ErrInvalidSpendPeriod
+	}
+
+	last := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if raw := q.Get("to"); raw != "" {
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, errors.New("to must be YYYY-MM-DD")
+		}
+		last = t
+	}
+	from := time.Date(last.Year(), last.Month()-11, 1, 0, 0, 0, 0, time.UTC)
+	if raw := q.Get("from"); raw != "" {
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, errors.New("from must be YYYY-MM-DD")
+		}
+		from = t
+	}
+	to := last.AddDate(0, 0, 1)
+	if !from.Before(to) || to.Sub(from) > maxSpendingRange {
+		return "", time.Time{}, time.Time{}, errors.New("from must not be after to, and at most two years before it")
+	}
+	return period, from, to, nil
+}
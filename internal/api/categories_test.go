@@ -0,0 +1,86 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestCategoryStatus(t *testing.T) {
+	// Foreign categories and accounts read as missing; malformed rules and periods are client errors.
+	assert.Equal(t, http.StatusNotFound, categoryStatus(service.ErrCategoryNotFound))
+	assert.Equal(t, http.StatusNotFound, categoryStatus(service.ErrAccountNotFound))
+	assert.Equal(t, http.StatusBadRequest, categoryStatus(service.ErrInvalidCategoryRule))
+	assert.Equal(t, http.StatusBadRequest, categoryStatus(service.ErrInvalidSpendPeriod))
+	assert.Equal(t, http.StatusInternalServerError, categoryStatus(errors.New("boom")))
+}
+
+func TestSpendingRange(t *testing.T) {
+	// Defaults to the last twelve calendar months by month, with to inclusive.
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	period, from, to, err := spendingRange(httptest.NewRequest(http.MethodGet, "/", nil), now)
+	require.NoError(t, err)
+	assert.Equal(t, "month", period)
+	assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), to)
+
+	period, from, to, err = spendingRange(httptest.NewRequest(http.MethodGet, "/?period=week&from=2026-01-01&to=2026-01-31", nil), now)
+	require.NoError(t, err)
+	assert.Equal(t, "week", period)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), to)
+
+	for _, q := range []string{"period=year", "from=01-01-2026", "to=tomorrow", "from=2026-02-01&to=2026-01-01", "from=2020-01-01&to=2026-01-01"} {
+		_, _, _, err := spendingRange(httptest.NewRequest(http.MethodGet, "/?"+q, nil), now)
+		assert.Error(t, err, q)
+	}
+}
+
+func TestToSpendingBucketResponse(t *testing.T) {
+	// Rows without a category are labelled Uncategorized and carry no category_id.
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	resp := toSpendingBucketResponse(sqlc.SpendByCategoryRow{PeriodStart: start, Total: "12.5000", EntryCount: 2})
+	assert.Nil(t, resp.CategoryID)
+	assert.Equal(t, "Uncategorized", resp.CategoryName)
+
+	resp = toSpendingBucketResponse(sqlc.SpendByCategoryRow{PeriodStart: start, CategoryID: "c1", CategoryName: "Groceries", Total: "40.0000", EntryCount: 3})
+	require.NotNil(t, resp.CategoryID)
+	assert.Equal(t, "c1", *resp.CategoryID)
+	assert.Equal(t, "Groceries", resp.CategoryName)
+	assert.Equal(t, "40.0000", resp.Total)
+}
+
+func TestToHistoryEntryResponse(t *testing.T) {
+	// The read model row maps to a statement line in the currency's decimal places; only the
+	// primary owner sees their category.
+	row := sqlc.AccountHistory{
+		EntryID:               uuid.New(),
+		Debit:                 "25.0000",
+		Credit:                "0.0000",
+		BalanceAfter:          "75.0000",
+		OperationType:         "transfer",
+		CounterpartyAccountID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		CounterpartyName:      "Ada Obi",
+		CategoryID:            uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		CategoryName:          "Groceries",
+	}
+	resp := toHistoryEntryResponse(row, "NGN", true)
+	assert.Equal(t, "25.00", resp.Debit)
+	assert.Equal(t, "75.00", resp.BalanceAfter)
+	assert.Equal(t, "Ada Obi", resp.CounterpartyName)
+	assert.Equal(t, row.CounterpartyAccountID.UUID.String(), resp.CounterpartyAccountID)
+	assert.Equal(t, "Groceries", resp.Category)
+
+	resp = toHistoryEntryResponse(row, "NGN", false)
+	assert.Empty(t, resp.Category)
+	assert.Empty(t, resp.CategoryID)
+}
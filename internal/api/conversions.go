@@ -0,0 +1,225 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// maxSpreadBps caps a configured spread at 50%, matching the fx_spreads check constraint.
+const maxSpreadBps = 5000
+
+// conversionStatus maps errors from pricing or posting a conversion to an HTTP status.
+func conversionStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAccountNotFound), errors.Is(err, service.ErrCrossOrgTransfer):
+		// Accounts of other tenants are indistinguishable from missing ones.
+		return http.StatusNotFound
+	case errors.Is(err, rates.ErrRateNotFound):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrConversionUnavailable), errors.Is(err, service.ErrStaleRate):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountPrecision), errors.Is(err, service.ErrInsufficientFunds),
+		errors.Is(err, service.ErrSameAccountTransfer), errors.Is(err, service.ErrSameCurrencyConversion),
+		errors.Is(err, service.ErrCurrencyMismatch), errors.Is(err, rates.ErrInvalidCurrency), errors.Is(err, rates.ErrUnknownCurrency),
+		errors.Is(err, service.ErrSavingsGoalLocked):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondConversionError writes err with its conversion status, hiding internal failures.
+func respondConversionError(w http.ResponseWriter, err error) {
+	status := conversionStatus(err)
+	switch status {
+	case http.StatusNotFound:
+		respondError(w, status, "to account not found")
+	case http.StatusInternalServerError:
+		log.Error().Err(err).Msg("Currency conversion failed")
+		respondError(w, status, "failed to convert currency")
+	default:
+		respondError(w, status, err.Error())
+	}
+}
+
+// ConvertCurrency godoc
+// @Summary      Convert between currencies
+// @Description  Sells amount from the account for the currency of to_account_id at the mid rate less the pair's spread. The spread is posted to the bank's FX Income account as its own ledger leg. Both accounts must belong to the same organization.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                                       true  "Source account ID"
+// @Param        body  body      object{to_account_id=string,amount=string}  true  "Target account and amount to sell"
+// @Success      201   {object}  ConversionResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      422   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Failure      503   {object}  ErrorResponse
+// @Router       /accounts/{id}/conversions [post]
+// @Security     Bearer
+func (h *Handler) ConvertCurrency(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and authorize both accounts.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	fromID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	var input struct {
+		Amount      interface{} `json:"amount"`
+		ToAccountID string      `json:"to_account_id"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	toID, err := uuid.Parse(input.ToAccountID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid to_account_id")
+		return
+	}
+	amount, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid amount")
+		return
+	}
+	if _, ok := h.ownedAccount(w, r, userID, fromID); !ok {
+		return
+	}
+	if _, ok := h.visibleAccount(w, r, userID, toID); !ok {
+		return
+	}
+
+	// Step 2: Price and post atomically; the service re-checks balance and currencies under lock.
+	conversion, err := h.ledger.Convert(r.Context(), fromID, toID, userID, amount)
+	if respondScreening(w, err) {
+		return
+	}
+	if err != nil {
+		respondConversionError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toConversionResponse(conversion))
+}
+
+// QuoteConversion godoc
+// @Summary      Quote a currency conversion
+// @Description  Prices selling amount of sell for buy at the current mid rate less the pair's spread, without moving money.
+// @Tags         rates
+// @Produce      json
+// @Param        sell    query     string  true  "Currency sold (ISO 4217)"
+// @Param        buy     query     string  true  "Currency bought (ISO 4217)"
+// @Param        amount  query     string  true  "Amount of sell"
+// @Success      200     {object}  ConversionQuoteResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      422     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Failure      503     {object}  ErrorResponse
+// @Router       /rates/quote [get]
+// @Security     Bearer
+func (h *Handler) QuoteConversion(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	quote, err := h.ledger.QuoteConversion(r.Context(), q.Get("sell"), q.Get("buy"), q.Get("amount"))
+	if err != nil {
+		respondConversionError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, toConversionQuoteResponse(quote))
+}
+
+// SetFXSpread godoc
+// @Summary      Set a currency pair's spread
+// @Description  Sets the spread in basis points charged when selling base for quote (0 to 5000). Pairs without one use FX_SPREAD_BPS. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{base=string,quote=string,spread_bps=int}  true  "Pair and spread in basis points"
+// @Success      200   {object}  FXSpreadResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/rates/spreads [put]
+// @Security     Bearer
+func (h *Handler) SetFXSpread(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and validate the pair.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		SpreadBps *int32 `json:"spread_bps"`
+		Base      string `json:"base"`
+		Quote     string `json:"quote"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	base, errBase := rates.NormalizeCurrency(input.Base)
+	quote, errQuote := rates.NormalizeCurrency(input.Quote)
+	if errBase != nil || errQuote != nil || base == quote {
+		respondError(w, http.StatusBadRequest, "base and quote must be different ISO 4217 currency codes")
+		return
+	}
+	if input.SpreadBps == nil || *input.SpreadBps < 0 || *input.SpreadBps > maxSpreadBps {
+		respondError(w, http.StatusBadRequest, "spread_bps must be between 0 and 5000")
+		return
+	}
+
+	// Step 2: Persist.
+	spread, err := h.store.UpsertFXSpread(r.Context(), sqlc.UpsertFXSpreadParams{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		SpreadBps:     *input.SpreadBps,
+		UpdatedBy:     userID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("pair", base+"/"+quote).Msg("Failed to set FX spread")
+		respondError(w, http.StatusInternalServerError, "failed to set spread")
+		return
+	}
+
+	log.Info().Str("pair", base+"/"+quote).Int32("spread_bps", spread.SpreadBps).Str("set_by", userID.String()).Msg("FX spread set")
+	respondJSON(w, http.StatusOK, toFXSpreadResponse(spread))
+}
+
+// ListFXSpreads godoc
+// @Summary      List currency pair spreads
+// @Description  Returns every configured pair spread. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   FXSpreadResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/rates/spreads [get]
+// @Security     Bearer
+func (h *Handler) ListFXSpreads(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.store.ListFXSpreads(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list FX spreads")
+		respondError(w, http.StatusInternalServerError, "failed to list spreads")
+		return
+	}
+
+	resp := make([]FXSpreadResponse, 0, len(rows))
+	for _, s := range rows {
+		resp = append(resp, toFXSpreadResponse(s))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
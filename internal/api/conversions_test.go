@@ -0,0 +1,24 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestConversionStatus(t *testing.T) {
+	// Missing pricing is 503 or 422; bad requests are 400 and other tenants' accounts look missing.
+	assert.Equal(t, http.StatusNotFound, conversionStatus(service.ErrCrossOrgTransfer))
+	assert.Equal(t, http.StatusServiceUnavailable, conversionStatus(service.ErrConversionUnavailable))
+	assert.Equal(t, http.StatusServiceUnavailable, conversionStatus(service.ErrStaleRate))
+	assert.Equal(t, http.StatusUnprocessableEntity, conversionStatus(rates.ErrRateNotFound))
+	assert.Equal(t, http.StatusBadRequest, conversionStatus(service.ErrSameCurrencyConversion))
+	assert.Equal(t, http.StatusBadRequest, conversionStatus(service.ErrInsufficientFunds))
+	assert.Equal(t, http.StatusBadRequest, conversionStatus(rates.ErrInvalidCurrency))
+	assert.Equal(t, http.StatusInternalServerError, conversionStatus(errors.New("connection reset")))
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+)
+
+// defaultAccountCurrency is the currency of accounts opened without one, where it is supported.
+const defaultAccountCurrency = "USD"
+
+// WithCurrencies limits the currencies accounts can be opened in to codes, which must be upper-case
+// ISO 4217 codes. Without it every ISO 4217 currency is allowed, so deployments pass the currencies
+// their settlement and payout clearing accounts are in.
+func WithCurrencies(codes []string) Option {
+	return func(h *Handler) {
+		h.currencies = codes
+	}
+}
+
+// currencySupported reports whether accounts can be opened in the ISO 4217 currency code.
+func (h *Handler) currencySupported(code string) bool {
+	return len(h.currencies) == 0 || slices.Contains(h.currencies, code)
+}
+
+// defaultCurrency is the currency of accounts opened without one: USD, or the first supported
+// currency of a deployment that does not open USD accounts.
+func (h *Handler) defaultCurrency() string {
+	if h.currencySupported(defaultAccountCurrency) {
+		return defaultAccountCurrency
+	}
+	return h.currencies[0]
+}
+
+// ListCurrencies godoc
+// @Summary      List supported currencies
+// @Description  Returns the ISO 4217 currencies this deployment opens accounts in, ordered by code, with the number of decimal places their amounts carry. Other endpoints taking a currency accept any ISO 4217 code and reject unknown ones.
+// @Tags         accounts
+// @Produce      json
+// @Success      200  {array}   CurrencyResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /currencies [get]
+// @Security     Bearer
+func (h *Handler) ListCurrencies(w http.ResponseWriter, _ *http.Request) {
+	resp := make([]CurrencyResponse, 0)
+	for _, c := range currencies.All() {
+		if h.currencySupported(c.Code) {
+			resp = append(resp, CurrencyResponse{Code: c.Code, Name: c.Name, Numeric: c.Numeric, Exponent: c.Exponent})
+		}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
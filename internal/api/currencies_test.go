@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCurrencies(t *testing.T) {
+	// A deployment limited to a few currencies lists only those, in code order, with their exponents.
+	h := NewHandler(nil, nil, WithCurrencies([]string{"NGN", "JPY", "KWD"}))
+	rw := httptest.NewRecorder()
+	h.ListCurrencies(rw, httptest.NewRequest(http.MethodGet, "/currencies", nil))
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	var resp []CurrencyResponse
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+	assert.Equal(t, []CurrencyResponse{
+		{Code: "JPY", Name: "Yen", Numeric: "392", Exponent: 0},
+		{Code: "KWD", Name: "Kuwaiti Dinar", Numeric: "414", Exponent: 3},
+		{Code: "NGN", Name: "Naira", Numeric: "566", Exponent: 2},
+	}, resp)
+	// Without USD, accounts default to the first currency configured.
+	assert.Equal(t, "NGN", h.defaultCurrency())
+}
+
+func TestCurrencySupported(t *testing.T) {
+	// Without a list every ISO 4217 currency is open, and USD is the default.
+	h := &Handler{}
+	assert.True(t, h.currencySupported("NGN"))
+	assert.Equal(t, "USD", h.defaultCurrency())
+
+	h = &Handler{currencies: []string{"USD", "NGN"}}
+	assert.True(t, h.currencySupported("NGN"))
+	assert.False(t, h.currencySupported("EUR"))
+	assert.Equal(t, "USD", h.defaultCurrency())
+}
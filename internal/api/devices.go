@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/i18n"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/notify"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// userAgent is the request's user agent, cut to maxUserAgent bytes for storage.
+func userAgent(r *http.Request) string {
+	ua := r.UserAgent()
+	if len(ua) > maxUserAgent {
+		ua = ua[:maxUserAgent]
+	}
+	return ua
+}
+
+// deviceFingerprint identifies the client software a request came from by hashing the headers a
+// browser or app sends unchanged between visits. The address is left out so a device stays the
+// same device across networks. It is a heuristic for spotting unfamiliar logins, not a secret.
+func deviceFingerprint(r *http.Request) string {
+	sum := sha256.Sum256([]byte(r.UserAgent() + "\n" + r.Header.Get("Accept-Language")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// rememberDevice records that user logged in from the attempt's device and reports whether it was
+// new to them. When it was and the user has logged in from other devices before, they are
+// alerted; a user's first device is never reported, so accounts from before devices were
+// remembered do not all get an alert. Failures are logged and count as a known device.
+func (h *Handler) rememberDevice(ctx context.Context, user sqlc.User, attempt sqlc.CreateLoginAttemptParams) bool {
+	seen, err := h.store.RememberDevice(ctx, sqlc.RememberDeviceParams{
+		UserID:    user.ID,
+		DeviceID:  attempt.DeviceID,
+		UserAgent: attempt.UserAgent,
+		IpAddress: attempt.IpAddress,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to remember login device")
+		return false
+	}
+	if !seen.NewDevice || !seen.OtherDevices {
+		return seen.NewDevice
+	}
+
+	log.Info().Str("user_id", user.ID.String()).Str("device_id", attempt.DeviceID).Str("ip_address", attempt.IpAddress).Msg("Login from new device")
+	if h.securityAlerts == nil {
+		return true
+	}
+	device := attempt.UserAgent
+	if device == "" {
+		device = i18n.T(user.Locale, "an unidentified client")
+	}
+	if err := h.securityAlerts.SendEmail(ctx, notify.EmailMessage{
+		To:      user.Email,
+		Subject: i18n.T(user.Locale, "New sign-in to your account"),
+		Body: i18n.Sprintf(user.Locale, "Your account was signed in to from a new device at %s UTC.\n\nDevice: %s\nAddress: %s\n\nIf this was you, there is nothing to do. If it wasn't, change your password now; that also logs out every device.",
+			time.Now().UTC().Format("2006-01-02 15:04"), device, attempt.IpAddress),
+	}); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to send new device alert")
+	}
+	return true
+}
+
+// ListMyLogins godoc
+// @Summary      List my logins
+// @Description  Returns a page of the caller's recorded login and password change attempts, newest first, wrapped in {data, page}, so they can spot activity that wasn't theirs: when, from which address and user agent, whether it succeeded and why not. device_id fingerprints the client; new_device marks a successful login from a device not seen before, which is also emailed to the user.
+// @Tags         profile
+// @Produce      json
+// @Param        ip         query     string  false  "Client IP address"
+// @Param        succeeded  query     bool    false  "Only successful or only failed attempts"
+// @Param        limit      query     int     false  "Limit (default 20, max 100)"
+// @Param        offset     query     int     false  "Offset (default 0)"
+// @Success      200        {object}  PagedResponse{data=[]LoginAttemptResponse}
+// @Failure      400        {object}  ErrorResponse
+// @Failure      401        {object}  ErrorResponse
+// @Failure      500        {object}  ErrorResponse
+// @Router       /security/logins [get]
+// @Security     Bearer
+func (h *Handler) ListMyLogins(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	filters, ok := parseLoginAttemptFilters(w, r)
+	if !ok {
+		return
+	}
+	// Callers only ever see their own attempts, whatever user_id they pass.
+	filters.UserID = uuid.NullUUID{UUID: userID, Valid: true}
+	h.respondLoginAttempts(w, r, filters)
+}
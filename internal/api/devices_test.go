@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceFingerprint(t *testing.T) {
+	// The same client on another network is the same device; another browser is not.
+	req := func(ua, addr string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/login", nil)
+		r.Header.Set("User-Agent", ua)
+		r.Header.Set("Accept-Language", "en-GB")
+		r.RemoteAddr = addr
+		return r
+	}
+	home := deviceFingerprint(req("Firefox/130.0", "192.0.2.1:1234"))
+	assert.Len(t, home, 32)
+	assert.Equal(t, home, deviceFingerprint(req("Firefox/130.0", "198.51.100.7:4321")))
+	assert.NotEqual(t, home, deviceFingerprint(req("Chrome/129.0", "192.0.2.1:1234")))
+}
+
+func TestUserAgent(t *testing.T) {
+	// Oversized user agents are cut before they are stored.
+	r := httptest.NewRequest(http.MethodPost, "/login", nil)
+	r.Header.Set("User-Agent", strings.Repeat("x", 1000))
+	assert.Len(t, userAgent(r), maxUserAgent)
+}
+
+func TestListMyLogins_RejectsBadFilters(t *testing.T) {
+	// Malformed filters are refused before the store is queried.
+	require.NoError(t, InitTokenAuth(testJWTSecret))
+	token, err := GenerateToken(uuid.New(), uuid.New(), RoleCustomer)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/security/logins?succeeded=maybe", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rw := httptest.NewRecorder()
+	Verifier(Authenticator(http.HandlerFunc((&Handler{}).ListMyLogins))).ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assert.Contains(t, rw.Body.String(), "succeeded must be")
+}
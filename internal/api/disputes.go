@@ -0,0 +1,262 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// disputeStatus maps dispute errors to an HTTP status.
+func disputeStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrDisputeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrDisputeNotOpen), errors.Is(err, service.ErrAlreadyDisputed):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrTransactionNotDisputable), errors.Is(err, service.ErrCurrencyMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrInsufficientFunds):
+		// The credited account no longer holds the money; operations must recover it offline.
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// OpenDispute godoc
+// @Summary      Dispute a transaction
+// @Description  Opens a dispute on a transaction that debited an account the caller owns. The disputed amount is moved from the credited account into the Disputes Holding account until an admin refunds or rejects it.
+// @Tags         disputes
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                 true  "Transaction ID"
+// @Param        body  body      object{reason=string}  true  "Why the transaction is disputed"
+// @Success      201   {object}  DisputeResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      422   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /transactions/{id}/disputes [post]
+// @Security     Bearer
+func (h *Handler) OpenDispute(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and parse input.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	transactionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+	var input struct {
+		Reason string `json:"reason"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" || len(reason) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "reason required (at most 500 characters)")
+		return
+	}
+
+	// Step 2: Only an owner of the debited account may dispute.
+	acc, err := h.ledger.DisputedAccount(r.Context(), transactionID)
+	if err != nil {
+		if errors.Is(err, service.ErrTransactionNotDisputable) {
+			respondError(w, http.StatusNotFound, "transaction not found or not disputable")
+			return
+		}
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to load disputed transaction")
+		respondError(w, http.StatusInternalServerError, "failed to open dispute")
+		return
+	}
+	if !h.hasAccountRole(r.Context(), userID, acc, AccountRoleOwner) {
+		// Do not reveal transactions of other customers.
+		respondError(w, http.StatusNotFound, "transaction not found or not disputable")
+		return
+	}
+
+	// Step 3: Freeze the amount in holding.
+	dispute, err := h.ledger.OpenDispute(r.Context(), transactionID, userID, reason)
+	if err != nil {
+		status := disputeStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to open dispute")
+			respondError(w, status, "failed to open dispute")
+			return
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toDisputeResponse(dispute))
+}
+
+// ListAccountDisputes godoc
+// @Summary      List account disputes
+// @Description  Returns disputes opened on transactions that debited the account, newest first
+// @Tags         disputes
+// @Produce      json
+// @Param        id   path      string  true  "Account ID"
+// @Success      200  {array}   DisputeResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/disputes [get]
+// @Security     Bearer
+func (h *Handler) ListAccountDisputes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.visibleAccount(w, r, userID, accountID); !ok {
+		return
+	}
+
+	rows, err := h.store.ListDisputesByAccount(r.Context(), accountID)
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to list disputes")
+		respondError(w, http.StatusInternalServerError, "failed to list disputes")
+		return
+	}
+
+	resp := make([]DisputeResponse, 0, len(rows))
+	for _, d := range rows {
+		resp = append(resp, toDisputeResponse(d))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ListDisputes godoc
+// @Summary      List disputes
+// @Description  Returns disputes by status, oldest first. The default status "open" is the work queue. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        status  query     string  false  "open (default), refunded or rejected"
+// @Param        limit   query     int     false  "Limit (default 20)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   DisputeResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /admin/disputes [get]
+// @Security     Bearer
+func (h *Handler) ListDisputes(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.DisputeOpen
+	case service.DisputeOpen, service.DisputeRefunded, service.DisputeRejected:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be open, refunded or rejected")
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	// Step 2: Fetch the page.
+	rows, err := h.store.ListDisputesByStatus(r.Context(), sqlc.ListDisputesByStatusParams{
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to list disputes")
+		respondError(w, http.StatusInternalServerError, "failed to list disputes")
+		return
+	}
+
+	resp := make([]DisputeResponse, 0, len(rows))
+	for _, d := range rows {
+		resp = append(resp, toDisputeResponse(d))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ResolveDispute godoc
+// @Summary      Resolve a dispute
+// @Description  Refund credits the held amount to the disputing account; reject returns it to the account originally credited. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                               true  "Dispute ID"
+// @Param        body  body      object{decision=string,note=string}  true  "decision: refund or reject"
+// @Success      200   {object}  DisputeResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/disputes/{id}/resolve [post]
+// @Security     Bearer
+func (h *Handler) ResolveDispute(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and parse input.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	disputeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid dispute ID")
+		return
+	}
+	var input struct {
+		Decision string `json:"decision"`
+		Note     string `json:"note"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	if input.Decision != "refund" && input.Decision != "reject" {
+		respondError(w, http.StatusBadRequest, "decision must be refund or reject")
+		return
+	}
+	note := strings.TrimSpace(input.Note)
+	if len(note) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "note must be at most 500 characters")
+		return
+	}
+
+	// Step 2: Release the hold exactly once.
+	dispute, err := h.ledger.ResolveDispute(r.Context(), disputeID, userID, input.Decision == "refund", note)
+	if err != nil {
+		status := disputeStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Error().Err(err).Str("dispute_id", disputeID.String()).Msg("Failed to resolve dispute")
+			respondError(w, status, "failed to resolve dispute")
+			return
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toDisputeResponse(dispute))
+}
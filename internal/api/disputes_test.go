@@ -0,0 +1,21 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestDisputeStatus(t *testing.T) {
+	// Duplicate and closed disputes conflict; an emptied counterparty is unprocessable.
+	assert.Equal(t, http.StatusConflict, disputeStatus(service.ErrAlreadyDisputed))
+	assert.Equal(t, http.StatusConflict, disputeStatus(service.ErrDisputeNotOpen))
+	assert.Equal(t, http.StatusNotFound, disputeStatus(service.ErrDisputeNotFound))
+	assert.Equal(t, http.StatusBadRequest, disputeStatus(service.ErrTransactionNotDisputable))
+	assert.Equal(t, http.StatusUnprocessableEntity, disputeStatus(service.ErrInsufficientFunds))
+	assert.Equal(t, http.StatusInternalServerError, disputeStatus(errors.New("connection reset")))
+}
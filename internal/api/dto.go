@@ -1,6 +1,11 @@
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/sanctions"
+)
 
 // AccountResponse represents an account returned by the API.
 //
@@ -12,7 +17,25 @@ type AccountResponse struct {
 	Currency  string    `json:"currency"`
 	OwnerID   *string   `json:"owner_id,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
-	IsSystem  bool      `json:"is_system"`
+	// VirtualAccountNumber receives bank transfers that are credited to this account.
+	VirtualAccountNumber string `json:"virtual_account_number"`
+	// ParentAccountID is set on sub-wallets.
+	ParentAccountID *string `json:"parent_account_id,omitempty"`
+	// Product decides account rules such as the minimum balance, overdraft, fees and interest.
+	Product string `json:"product"`
+	// MinBalance must remain after withdrawals and transfers, the balance may go as far as
+	// OverdraftLimit below zero, and AvailableBalance is what can be spent.
+	MinBalance       string `json:"min_balance,omitempty"`
+	OverdraftLimit   string `json:"overdraft_limit,omitempty"`
+	AvailableBalance string `json:"available_balance,omitempty"`
+	IsSystem         bool   `json:"is_system"`
+}
+
+// AdminAccountResponse is an account in the admin account browser.
+type AdminAccountResponse struct {
+	// OwnerEmail is the primary owner's email; system accounts have none.
+	OwnerEmail string `json:"owner_email,omitempty"`
+	AccountResponse
 }
 
 // EntryResponse represents a ledger entry returned by the API.
@@ -27,11 +50,72 @@ type EntryResponse struct {
 	Description   string    `json:"description,omitempty"`
 }
 
+// TransactionDetailResponse is a transaction's entries with the caller's notes on it.
+type TransactionDetailResponse struct {
+	Entries     []EntryResponse      `json:"entries"`
+	Annotations []AnnotationResponse `json:"annotations"`
+}
+
+// AnnotationResponse is a note the caller attached to a transaction.
+type AnnotationResponse struct {
+	ID            string    `json:"id"`
+	TransactionID string    `json:"transaction_id"`
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// HistoryEntryResponse is an entry as the account's statement shows it: the balance once it
+// posted, who was on the other side and, for the account's primary owner, its category.
+type HistoryEntryResponse struct {
+	EntryResponse
+	BalanceAfter          string `json:"balance_after"`
+	CounterpartyAccountID string `json:"counterparty_account_id,omitempty"`
+	CounterpartyName      string `json:"counterparty_name,omitempty"`
+	CategoryID            string `json:"category_id,omitempty"`
+	Category              string `json:"category,omitempty"`
+}
+
+// SplitPaymentResponse reports a split payment's transaction with every leg it posted.
+type SplitPaymentResponse struct {
+	TransactionID string          `json:"transaction_id"`
+	Amount        string          `json:"amount"`
+	Fee           string          `json:"fee"`
+	Currency      string          `json:"currency"`
+	Entries       []EntryResponse `json:"entries"`
+}
+
 // RegisterResponse is returned after successful registration.
 type RegisterResponse struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
-	Token  string `json:"token"`
+	Token  string `json:"token,omitempty"`
+	// ScreeningStatus is pending_review when sanctions screening matched the user's name; no token is
+	// issued and the user cannot log in until compliance staff clear them.
+	ScreeningStatus string `json:"screening_status,omitempty"`
+}
+
+// ProfileResponse is the authenticated user's personal details.
+type ProfileResponse struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Phone     string `json:"phone,omitempty"`
+	// DateOfBirth is formatted YYYY-MM-DD.
+	DateOfBirth string          `json:"date_of_birth,omitempty"`
+	Address     AddressResponse `json:"address"`
+	// Locale is the language errors and notifications are written in; empty follows Accept-Language.
+	Locale string `json:"locale"`
+}
+
+// AddressResponse is a postal address.
+type AddressResponse struct {
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
 }
 
 // TokenResponse contains a signed JWT.
@@ -47,6 +131,18 @@ type MessageResponse struct {
 // ErrorResponse contains an API error message.
 type ErrorResponse struct {
 	Error string `json:"error"`
+	// Code is a stable identifier for errors clients are expected to handle, e.g. minimum_balance_required.
+	Code string `json:"code,omitempty"`
+	// RequestID identifies the request in server logs; set on timeouts.
+	RequestID string `json:"request_id,omitempty"`
+	// Violations lists every password rule a rejected password broke (code weak_password).
+	Violations []PasswordViolation `json:"violations,omitempty"`
+}
+
+// PasswordViolation is one password rule, e.g. min_length, upper or breached, and why it failed.
+type PasswordViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
 }
 
 // ReconcileResponse reports whether stored and computed balances match.
@@ -54,3 +150,1181 @@ type ReconcileResponse struct {
 	Message string `json:"message"`
 	Matched bool   `json:"matched"`
 }
+
+// NotificationPreferencesResponse describes which alert channels a user receives.
+type NotificationPreferencesResponse struct {
+	Phone        string `json:"phone,omitempty"`
+	EmailEnabled bool   `json:"email_enabled"`
+	SMSEnabled   bool   `json:"sms_enabled"`
+}
+
+// StatementPreferenceResponse is how an account's monthly statement is delivered.
+type StatementPreferenceResponse struct {
+	AccountID string `json:"account_id"`
+	// Delivery is email, link or off.
+	Delivery string `json:"delivery"`
+}
+
+// StreamMessage is one frame pushed over live account streams.
+// Type is "snapshot" for the initial balance of each account, then "entry" per new ledger entry.
+// Balance is omitted on replayed entries, where only the live path knows the post-entry balance.
+type StreamMessage struct {
+	Entry     *EntryResponse `json:"entry,omitempty"`
+	Type      string         `json:"type"`
+	AccountID string         `json:"account_id"`
+	Balance   string         `json:"balance,omitempty"`
+}
+
+// ReconciliationSummaryResponse describes one imported settlement-bank statement.
+type ReconciliationSummaryResponse struct {
+	PeriodStart     time.Time `json:"period_start"`
+	PeriodEnd       time.Time `json:"period_end"`
+	CreatedAt       time.Time `json:"created_at"`
+	ImportedBy      *string   `json:"imported_by,omitempty"`
+	ID              string    `json:"id"`
+	SourceFormat    string    `json:"source_format"`
+	Filename        string    `json:"filename,omitempty"`
+	LineCount       int32     `json:"line_count"`
+	MatchedCount    int32     `json:"matched_count"`
+	MissingCount    int32     `json:"missing_count"`
+	UnexpectedCount int32     `json:"unexpected_count"`
+}
+
+// ReconciliationResponse is a statement import together with its full matching report.
+type ReconciliationResponse struct {
+	Report json.RawMessage `json:"report" swaggertype:"object"`
+	ReconciliationSummaryResponse
+}
+
+// DepositInitResponse points the customer at the payment provider's checkout.
+type DepositInitResponse struct {
+	Reference        string `json:"reference"`
+	AuthorizationURL string `json:"authorization_url"`
+	AccessCode       string `json:"access_code"`
+	Status           string `json:"status"`
+}
+
+// PayoutResponse reports a bank payout whose funds are held pending the rail's result.
+type PayoutResponse struct {
+	CreatedAt       time.Time `json:"created_at"`
+	Reference       string    `json:"reference"`
+	AccountID       string    `json:"account_id"`
+	Amount          string    `json:"amount"`
+	Currency        string    `json:"currency"`
+	BankCode        string    `json:"bank_code"`
+	AccountNumber   string    `json:"account_number"`
+	BeneficiaryName string    `json:"beneficiary_name,omitempty"`
+	Status          string    `json:"status"`
+	FailureReason   string    `json:"failure_reason,omitempty"`
+}
+
+// PayoutSuspenseCaseResponse is a failed payout whose funds wait in Payout Suspense, and how an
+// admin resolved it.
+type PayoutSuspenseCaseResponse struct {
+	CreatedAt      time.Time       `json:"created_at"`
+	ResolvedAt     *time.Time      `json:"resolved_at,omitempty"`
+	ID             string          `json:"id"`
+	PayoutID       string          `json:"payout_id"`
+	AccountID      string          `json:"account_id"`
+	Amount         string          `json:"amount"`
+	Currency       string          `json:"currency"`
+	Reason         string          `json:"reason,omitempty"`
+	Status         string          `json:"status"`
+	ResolvedBy     *string         `json:"resolved_by,omitempty"`
+	ResolutionNote string          `json:"resolution_note,omitempty"`
+	Payout         *PayoutResponse `json:"payout,omitempty"`
+	RetryPayout    *PayoutResponse `json:"retry_payout,omitempty"`
+}
+
+// CardDepositResponse carries what the client needs to confirm a Stripe card payment.
+type CardDepositResponse struct {
+	Reference       string `json:"reference"`
+	PaymentIntentID string `json:"payment_intent_id"`
+	ClientSecret    string `json:"client_secret"`
+	Status          string `json:"status"`
+}
+
+// NameEnquiryResponse confirms who owns a destination bank account.
+type NameEnquiryResponse struct {
+	BankCode      string `json:"bank_code"`
+	AccountNumber string `json:"account_number"`
+	AccountName   string `json:"account_name"`
+	SessionID     string `json:"session_id"`
+}
+
+// InboundPaymentResponse reports a provider-notified credit and where it was posted.
+type InboundPaymentResponse struct {
+	CreatedAt            time.Time  `json:"created_at"`
+	ResolvedAt           *time.Time `json:"resolved_at,omitempty"`
+	AccountID            *string    `json:"account_id,omitempty"`
+	ID                   string     `json:"id"`
+	Provider             string     `json:"provider"`
+	EventID              string     `json:"event_id"`
+	VirtualAccountNumber string     `json:"virtual_account_number"`
+	Amount               string     `json:"amount"`
+	Currency             string     `json:"currency"`
+	PayerName            string     `json:"payer_name,omitempty"`
+	PayerReference       string     `json:"payer_reference,omitempty"`
+	Status               string     `json:"status"`
+	TransactionID        string     `json:"transaction_id"`
+}
+
+// KYCResponse reports a user's identity verification. IDNumber is masked to its last four characters.
+type KYCResponse struct {
+	SubmittedAt       time.Time  `json:"submitted_at"`
+	ReviewedAt        *time.Time `json:"reviewed_at,omitempty"`
+	UserID            string     `json:"user_id"`
+	IDType            string     `json:"id_type"`
+	IDNumber          string     `json:"id_number"`
+	DocumentReference string     `json:"document_reference"`
+	Status            string     `json:"status"`
+	RejectionReason   string     `json:"rejection_reason,omitempty"`
+	Level             int16      `json:"level"`
+}
+
+// OrganizationResponse is a tenant; users register into it by slug.
+type OrganizationResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+}
+
+// OrgUserResponse is a user as seen by an organization admin.
+type OrgUserResponse struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	ID        string     `json:"id"`
+	Email     string     `json:"email"`
+	FirstName string     `json:"first_name"`
+	LastName  string     `json:"last_name"`
+	Role      string     `json:"role"`
+}
+
+// AdminUserResponse is a user in the admin user list, with the login controls admins set.
+type AdminUserResponse struct {
+	LockedAt              *time.Time `json:"locked_at,omitempty"`
+	ErasedAt              *time.Time `json:"erased_at,omitempty"`
+	LoginLockedUntil      *time.Time `json:"login_locked_until,omitempty"`
+	OrgID                 string     `json:"org_id"`
+	LockedReason          string     `json:"locked_reason,omitempty"`
+	FailedLogins          int32      `json:"failed_logins"`
+	PasswordResetRequired bool       `json:"password_reset_required"`
+	OrgUserResponse
+}
+
+// PasswordResetResponse carries the temporary password of an admin reset. It is shown only once.
+type PasswordResetResponse struct {
+	UserID            string `json:"user_id"`
+	TemporaryPassword string `json:"temporary_password"`
+}
+
+// SubWalletsResponse is a parent account with its sub-wallets and their combined balance.
+type SubWalletsResponse struct {
+	Parent  AccountResponse   `json:"parent"`
+	Wallets []AccountResponse `json:"wallets"`
+	// TotalBalance is the parent balance plus every sub-wallet balance.
+	TotalBalance string `json:"total_balance"`
+}
+
+// AccountOwnerResponse is one user with access to a joint account.
+type AccountOwnerResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+	AddedBy   *string   `json:"added_by,omitempty"`
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	// Role is owner or viewer.
+	Role string `json:"role"`
+	// Primary marks the user who opened the account; they cannot be removed.
+	Primary bool `json:"primary"`
+}
+
+// TransferRequestResponse is a maker-checker transfer and both actors' audit trail.
+type TransferRequestResponse struct {
+	RequestedAt   time.Time  `json:"requested_at"`
+	DecidedAt     *time.Time `json:"decided_at,omitempty"`
+	DecidedBy     *string    `json:"decided_by,omitempty"`
+	TransactionID *string    `json:"transaction_id,omitempty"`
+	ID            string     `json:"id"`
+	FromAccountID string     `json:"from_account_id"`
+	ToAccountID   string     `json:"to_account_id"`
+	Amount        string     `json:"amount"`
+	Currency      string     `json:"currency"`
+	Reason        string     `json:"reason"`
+	Status        string     `json:"status"`
+	RequestedBy   string     `json:"requested_by"`
+	DecisionNote  string     `json:"decision_note,omitempty"`
+}
+
+// AdjustmentResponse is an admin's corrective journal and both admins' audit trail.
+type AdjustmentResponse struct {
+	RequestedAt   time.Time                `json:"requested_at"`
+	DecidedAt     *time.Time               `json:"decided_at,omitempty"`
+	DecidedBy     *string                  `json:"decided_by,omitempty"`
+	TransactionID *string                  `json:"transaction_id,omitempty"`
+	ID            string                   `json:"id"`
+	ReasonCode    string                   `json:"reason_code"`
+	Memo          string                   `json:"memo"`
+	Status        string                   `json:"status"`
+	RequestedBy   string                   `json:"requested_by"`
+	DecisionNote  string                   `json:"decision_note,omitempty"`
+	Lines         []AdjustmentLineResponse `json:"lines,omitempty"`
+}
+
+// AdjustmentLineResponse is one line of an adjustment journal.
+type AdjustmentLineResponse struct {
+	AccountID   string `json:"account_id"`
+	Debit       string `json:"debit"`
+	Credit      string `json:"credit"`
+	Description string `json:"description,omitempty"`
+	LineNo      int32  `json:"line_no"`
+}
+
+// BalanceRepairResponse is an assisted repair of an account whose stored balance disagrees with
+// its entries: the mismatch found, the adjustment proposed to book it and the repair's audit log.
+type BalanceRepairResponse struct {
+	CreatedAt         time.Time                    `json:"created_at"`
+	ID                string                       `json:"id"`
+	AccountID         string                       `json:"account_id"`
+	StoredBalance     string                       `json:"stored_balance"`
+	CalculatedBalance string                       `json:"calculated_balance"`
+	Delta             string                       `json:"delta"`
+	Status            string                       `json:"status"`
+	Adjustment        *AdjustmentResponse          `json:"adjustment,omitempty"`
+	Events            []BalanceRepairEventResponse `json:"events,omitempty"`
+}
+
+// BalanceRepairEventResponse is one entry of a balance repair's audit log.
+type BalanceRepairEventResponse struct {
+	CreatedAt time.Time         `json:"created_at"`
+	ActorID   *string           `json:"actor_id,omitempty"`
+	Detail    map[string]string `json:"detail"`
+	Action    string            `json:"action"`
+}
+
+// ReconciliationRunResponse is a check of every account's stored balance against its entries
+// and how far it has got.
+type ReconciliationRunResponse struct {
+	StartedAt          time.Time  `json:"started_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	FinishedAt         *time.Time `json:"finished_at,omitempty"`
+	ID                 string     `json:"id"`
+	Status             string     `json:"status"`
+	RequestedBy        string     `json:"requested_by"`
+	TotalAccounts      int32      `json:"total_accounts"`
+	CheckedAccounts    int32      `json:"checked_accounts"`
+	MismatchedAccounts int32      `json:"mismatched_accounts"`
+}
+
+// ReconciliationResultResponse is one account's result in a reconciliation run.
+type ReconciliationResultResponse struct {
+	CheckedAt         time.Time `json:"checked_at"`
+	AccountID         string    `json:"account_id"`
+	Currency          string    `json:"currency"`
+	StoredBalance     string    `json:"stored_balance"`
+	CalculatedBalance string    `json:"calculated_balance"`
+	Matched           bool      `json:"matched"`
+}
+
+// OwnershipTransferResponse is a request to move an account to a new primary owner and its audit trail.
+type OwnershipTransferResponse struct {
+	RequestedAt  time.Time  `json:"requested_at"`
+	DecidedAt    *time.Time `json:"decided_at,omitempty"`
+	DecidedBy    *string    `json:"decided_by,omitempty"`
+	ID           string     `json:"id"`
+	AccountID    string     `json:"account_id"`
+	FromUserID   string     `json:"from_user_id"`
+	ToUserID     string     `json:"to_user_id"`
+	Reason       string     `json:"reason"`
+	Status       string     `json:"status"`
+	RequestedBy  string     `json:"requested_by"`
+	DecisionNote string     `json:"decision_note,omitempty"`
+}
+
+// ErasureResponse is a request to erase a user's personal data and its audit trail.
+type ErasureResponse struct {
+	RequestedAt time.Time  `json:"requested_at"`
+	EraseAfter  time.Time  `json:"erase_after"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+	CancelledBy *string    `json:"cancelled_by,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Status      string     `json:"status"`
+	Reason      string     `json:"reason,omitempty"`
+	RequestedBy string     `json:"requested_by"`
+}
+
+// SessionResponse is one login of the caller, identified by its token's jti claim.
+type SessionResponse struct {
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	ID        string     `json:"id"`
+	UserAgent string     `json:"user_agent"`
+	IPAddress string     `json:"ip_address"`
+	Current   bool       `json:"current"`
+}
+
+// LoginAttemptResponse is one recorded login or password change attempt.
+type LoginAttemptResponse struct {
+	CreatedAt     time.Time `json:"created_at"`
+	OrgID         *string   `json:"org_id,omitempty"`
+	UserID        *string   `json:"user_id,omitempty"`
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	IPAddress     string    `json:"ip_address"`
+	UserAgent     string    `json:"user_agent"`
+	DeviceID      string    `json:"device_id"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	Succeeded     bool      `json:"succeeded"`
+	// NewDevice marks a successful login from a device the user had not logged in from before.
+	NewDevice bool `json:"new_device"`
+}
+
+// SigningKeyResponse is an Ed25519 public key that may sign transfers from an account.
+type SigningKeyResponse struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	ID         string     `json:"id"`
+	AccountID  string     `json:"account_id"`
+	Name       string     `json:"name"`
+	// PublicKey is the base64 of the key's 32 bytes.
+	PublicKey string `json:"public_key"`
+}
+
+// AMLAlertResponse is an operation AML screening flagged, held or blocked, and its review.
+type AMLAlertResponse struct {
+	CreatedAt      time.Time  `json:"created_at"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	ReviewedBy     *string    `json:"reviewed_by,omitempty"`
+	CounterpartyID *string    `json:"counterparty_id,omitempty"`
+	// TransactionID is the posted operation: set at once when flagged, on release when held.
+	TransactionID *string `json:"transaction_id,omitempty"`
+	ID            string  `json:"id"`
+	AccountID     string  `json:"account_id"`
+	// Operation is deposit, withdrawal, transfer or payout; Direction is in or out of the account.
+	Operation string `json:"operation"`
+	Direction string `json:"direction"`
+	Amount    string `json:"amount"`
+	Currency  string `json:"currency"`
+	// Action is flag, hold or block.
+	Action     string   `json:"action"`
+	Rules      []string `json:"rules"`
+	Reason     string   `json:"reason"`
+	Status     string   `json:"status"`
+	ReviewNote string   `json:"review_note,omitempty"`
+}
+
+// SanctionsScreeningResponse is a registering user's or external beneficiary's name checked
+// against sanctions lists, and its review.
+type SanctionsScreeningResponse struct {
+	ScreenedAt time.Time  `json:"screened_at"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	ReviewedBy *string    `json:"reviewed_by,omitempty"`
+	UserID     *string    `json:"user_id,omitempty"`
+	ID         string     `json:"id"`
+	// SubjectType is user or beneficiary; beneficiaries carry their bank code and account number.
+	SubjectType   string            `json:"subject_type"`
+	Name          string            `json:"name"`
+	BankCode      string            `json:"bank_code,omitempty"`
+	AccountNumber string            `json:"account_number,omitempty"`
+	Provider      string            `json:"provider"`
+	Matches       []sanctions.Match `json:"matches"`
+	// Error is why the provider could not screen the name, which is reviewed like a match.
+	Error string `json:"error,omitempty"`
+	// Status is clear, pending_review, cleared (a false match) or confirmed.
+	Status     string `json:"status"`
+	ReviewNote string `json:"review_note,omitempty"`
+}
+
+// TransactionLimitResponse is an admin-configured cap on one operation in a currency at a KYC level.
+type TransactionLimitResponse struct {
+	Currency  string    `json:"currency"`
+	Operation string    `json:"operation"`
+	KYCLevel  int16     `json:"kyc_level"`
+	MaxAmount string    `json:"max_amount"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DisputeResponse reports a disputed transaction and where its held funds went.
+type DisputeResponse struct {
+	OpenedAt                time.Time  `json:"opened_at"`
+	ResolvedAt              *time.Time `json:"resolved_at,omitempty"`
+	ResolvedBy              *string    `json:"resolved_by,omitempty"`
+	ResolutionTransactionID *string    `json:"resolution_transaction_id,omitempty"`
+	ID                      string     `json:"id"`
+	TransactionID           string     `json:"transaction_id"`
+	AccountID               string     `json:"account_id"`
+	CounterpartyAccountID   string     `json:"counterparty_account_id"`
+	Amount                  string     `json:"amount"`
+	Currency                string     `json:"currency"`
+	Reason                  string     `json:"reason"`
+	// Status is open, refunded or rejected.
+	Status            string `json:"status"`
+	OpenedBy          string `json:"opened_by"`
+	HoldTransactionID string `json:"hold_transaction_id"`
+	ResolutionNote    string `json:"resolution_note,omitempty"`
+}
+
+// EscrowResponse reports money a buyer has put aside for a seller and how it was settled.
+type EscrowResponse struct {
+	CreatedAt               time.Time  `json:"created_at"`
+	SettledAt               *time.Time `json:"settled_at,omitempty"`
+	SettledBy               *string    `json:"settled_by,omitempty"`
+	SettlementTransactionID *string    `json:"settlement_transaction_id,omitempty"`
+	ID                      string     `json:"id"`
+	BuyerAccountID          string     `json:"buyer_account_id"`
+	SellerAccountID         string     `json:"seller_account_id"`
+	Amount                  string     `json:"amount"`
+	Currency                string     `json:"currency"`
+	Description             string     `json:"description"`
+	// Status is funded, released or refunded.
+	Status               string `json:"status"`
+	CreatedBy            string `json:"created_by"`
+	FundingTransactionID string `json:"funding_transaction_id"`
+	SettlementNote       string `json:"settlement_note,omitempty"`
+}
+
+// PaymentRequestResponse represents a request for payment and its shareable link.
+type PaymentRequestResponse struct {
+	ExpiresAt      time.Time  `json:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+	PayerAccountID *string    `json:"payer_account_id,omitempty"`
+	TransactionID  *string    `json:"transaction_id,omitempty"`
+	Token          string     `json:"token"`
+	Link           string     `json:"link"`
+	AccountID      string     `json:"account_id"`
+	Amount         string     `json:"amount"`
+	Currency       string     `json:"currency"`
+	Memo           string     `json:"memo,omitempty"`
+	// Status is pending, paid, cancelled or expired.
+	Status string `json:"status"`
+}
+
+// QRCodeResponse is a receiving QR payload and the fields it encodes.
+type QRCodeResponse struct {
+	// Payload is the EMV QR string to render as a QR code.
+	Payload       string `json:"payload"`
+	AccountNumber string `json:"account_number"`
+	Currency      string `json:"currency"`
+	// Amount is set for dynamic codes only.
+	Amount    string `json:"amount,omitempty"`
+	Reference string `json:"reference,omitempty"`
+}
+
+// QRDecodeResponse tells a payer who a scanned code pays.
+type QRDecodeResponse struct {
+	AccountID     string `json:"account_id"`
+	AccountNumber string `json:"account_number"`
+	MerchantName  string `json:"merchant_name"`
+	Currency      string `json:"currency"`
+	// Amount is set for dynamic codes only; otherwise the payer chooses it.
+	Amount    string `json:"amount,omitempty"`
+	Reference string `json:"reference,omitempty"`
+}
+
+// QRPaymentResponse reports a posted QR payment.
+type QRPaymentResponse struct {
+	TransactionID string `json:"transaction_id"`
+	ToAccountID   string `json:"to_account_id"`
+	Amount        string `json:"amount"`
+	Currency      string `json:"currency"`
+}
+
+// RecipientResponse lets a sender confirm who an email or phone pays without revealing their account.
+type RecipientResponse struct {
+	// DisplayName is the first name and last initial, or a masked email.
+	DisplayName string `json:"display_name"`
+	Currency    string `json:"currency"`
+}
+
+// AccountEnquiryResponse names who owns an account number, so the sender can confirm before paying.
+type AccountEnquiryResponse struct {
+	AccountID     string `json:"account_id"`
+	AccountNumber string `json:"account_number"`
+	// DisplayName is the owner's first name and last initial, or a masked email.
+	DisplayName string `json:"display_name"`
+	Currency    string `json:"currency"`
+}
+
+// RecentRecipientResponse is an account the caller has transferred to, for quick-send.
+type RecentRecipientResponse struct {
+	AccountID     string `json:"account_id"`
+	AccountNumber string `json:"account_number"`
+	// DisplayName is the owner's first name and last initial, or a masked email.
+	DisplayName   string    `json:"display_name"`
+	Currency      string    `json:"currency"`
+	LastSentAt    time.Time `json:"last_sent_at"`
+	TransferCount int64     `json:"transfer_count"`
+}
+
+// CategoryResponse is one of the caller's spending categories.
+type CategoryResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CategoryRuleResponse files entries under a category; exactly one matcher is set.
+type CategoryRuleResponse struct {
+	ID                    string    `json:"id"`
+	CategoryID            string    `json:"category_id"`
+	DescriptionContains   *string   `json:"description_contains,omitempty"`
+	CounterpartyAccountID *string   `json:"counterparty_account_id,omitempty"`
+	Priority              int32     `json:"priority"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+// EntryCategoryResponse is a manual category assignment.
+type EntryCategoryResponse struct {
+	EntryID    string `json:"entry_id"`
+	CategoryID string `json:"category_id"`
+}
+
+// SpendingResponse is an account's spending per period and category.
+type SpendingResponse struct {
+	AccountID string                   `json:"account_id"`
+	Currency  string                   `json:"currency"`
+	Period    string                   `json:"period"`
+	From      string                   `json:"from"`
+	To        string                   `json:"to"`
+	Buckets   []SpendingBucketResponse `json:"buckets"`
+}
+
+// SpendingBucketResponse is the spend in one category during one period, largest first within a period.
+type SpendingBucketResponse struct {
+	PeriodStart time.Time `json:"period_start"`
+	// CategoryID is omitted for uncategorized spend.
+	CategoryID   *string `json:"category_id,omitempty"`
+	CategoryName string  `json:"category_name"`
+	Total        string  `json:"total"`
+	EntryCount   int64   `json:"entry_count"`
+}
+
+// AccountSummaryResponse is one month of money in and money out for an account.
+type AccountSummaryResponse struct {
+	AccountID    string `json:"account_id"`
+	Currency     string `json:"currency"`
+	Month        string `json:"month"`
+	TotalCredits string `json:"total_credits"`
+	TotalDebits  string `json:"total_debits"`
+	// NetChange is total_credits minus total_debits.
+	NetChange string                       `json:"net_change"`
+	Largest   []SummaryTransactionResponse `json:"largest_transactions"`
+	Days      []SummaryDayResponse         `json:"days"`
+}
+
+// SummaryTransactionResponse is one of the month's largest transactions on the account.
+type SummaryTransactionResponse struct {
+	TransactionID string `json:"transaction_id"`
+	// NetChange is positive for money in and negative for money out, fees included.
+	NetChange   string    `json:"net_change"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SummaryDayResponse is one UTC day of an account summary.
+type SummaryDayResponse struct {
+	Date       string `json:"date"`
+	Credits    string `json:"credits"`
+	Debits     string `json:"debits"`
+	NetChange  string `json:"net_change"`
+	EntryCount int64  `json:"entry_count"`
+}
+
+// SavingsGoalResponse is a sub-wallet's savings goal with its progress.
+type SavingsGoalResponse struct {
+	ID           string  `json:"id"`
+	WalletID     string  `json:"wallet_id"`
+	Name         string  `json:"name"`
+	Currency     string  `json:"currency"`
+	TargetAmount string  `json:"target_amount"`
+	TargetDate   *string `json:"target_date,omitempty"`
+	LockedUntil  *string `json:"locked_until,omitempty"`
+	// Locked reports whether debits of the wallet are currently refused.
+	Locked          bool                         `json:"locked"`
+	Saved           string                       `json:"saved"`
+	Remaining       string                       `json:"remaining"`
+	ProgressPercent string                       `json:"progress_percent"`
+	Reached         bool                         `json:"reached"`
+	Contribution    *SavingsContributionResponse `json:"contribution,omitempty"`
+	CreatedAt       time.Time                    `json:"created_at"`
+}
+
+// SavingsContributionResponse is a goal's automatic contribution from the parent account.
+type SavingsContributionResponse struct {
+	Amount   string     `json:"amount"`
+	Interval string     `json:"interval"`
+	NextAt   *time.Time `json:"next_at,omitempty"`
+	// LastError explains the most recent skipped contribution, if any.
+	LastError string `json:"last_error,omitempty"`
+}
+
+// SweepRuleResponse is a rule moving an account's balance above threshold to another account.
+type SweepRuleResponse struct {
+	ID              string `json:"id"`
+	AccountID       string `json:"account_id"`
+	TargetAccountID string `json:"target_account_id"`
+	Currency        string `json:"currency"`
+	Threshold       string `json:"threshold"`
+	// Frequency is daily or weekly; runs happen at midnight UTC.
+	Frequency string    `json:"frequency"`
+	NextRunAt time.Time `json:"next_run_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SweepExecutionResponse is one run of a sweep rule.
+type SweepExecutionResponse struct {
+	ID     string `json:"id"`
+	RuleID string `json:"rule_id"`
+	// Status is swept, skipped or failed.
+	Status        string  `json:"status"`
+	Amount        string  `json:"amount"`
+	TransactionID *string `json:"transaction_id,omitempty"`
+	// Reason explains a skipped or failed run.
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CashPoolResponse is a cash pool and, on the detail view, its master balance and members.
+type CashPoolResponse struct {
+	ID              string                   `json:"id"`
+	MasterAccountID string                   `json:"master_account_id"`
+	Name            string                   `json:"name"`
+	Currency        string                   `json:"currency,omitempty"`
+	MasterBalance   string                   `json:"master_balance,omitempty"`
+	Members         []CashPoolMemberResponse `json:"members,omitempty"`
+	CreatedAt       time.Time                `json:"created_at"`
+}
+
+// CashPoolMemberResponse is an account zeroed into a pool's master at end of day.
+type CashPoolMemberResponse struct {
+	AccountID string `json:"account_id"`
+	Name      string `json:"name"`
+	Balance   string `json:"balance"`
+	// TargetBalance is what the member is brought back to at end of day.
+	TargetBalance string    `json:"target_balance"`
+	AddedAt       time.Time `json:"added_at"`
+}
+
+// CashPoolMovementResponse is one posting between a pool's master and a member.
+type CashPoolMovementResponse struct {
+	ID        string `json:"id"`
+	AccountID string `json:"account_id"`
+	// Kind is sweep (member to master), cover (master to member) or fund (master to member on request).
+	Kind          string    `json:"kind"`
+	Amount        string    `json:"amount"`
+	TransactionID string    `json:"transaction_id"`
+	CreatedBy     *string   `json:"created_by,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// LoanResponse is a loan with, on the detail view, its schedule and repayments.
+type LoanResponse struct {
+	ID                        string                    `json:"id"`
+	AccountID                 string                    `json:"account_id"`
+	Principal                 string                    `json:"principal"`
+	Currency                  string                    `json:"currency"`
+	AnnualRateBps             int32                     `json:"annual_rate_bps"`
+	TermMonths                int32                     `json:"term_months"`
+	Status                    string                    `json:"status"`
+	Delinquency               string                    `json:"delinquency"`
+	DaysPastDue               int32                     `json:"days_past_due"`
+	DisbursementTransactionID string                    `json:"disbursement_transaction_id"`
+	Installments              []LoanInstallmentResponse `json:"installments,omitempty"`
+	Repayments                []RepaymentResponse       `json:"repayments,omitempty"`
+	CreatedAt                 time.Time                 `json:"created_at"`
+	PaidOffAt                 *time.Time                `json:"paid_off_at,omitempty"`
+}
+
+// LoanInstallmentResponse is one row of an amortization schedule. Balance is only set on a
+// preview, the paid amounts only on an existing loan.
+type LoanInstallmentResponse struct {
+	Number        int32      `json:"number"`
+	DueDate       string     `json:"due_date"`
+	PrincipalDue  string     `json:"principal_due"`
+	InterestDue   string     `json:"interest_due"`
+	Balance       string     `json:"balance,omitempty"`
+	PrincipalPaid string     `json:"principal_paid,omitempty"`
+	InterestPaid  string     `json:"interest_paid,omitempty"`
+	PaidAt        *time.Time `json:"paid_at,omitempty"`
+}
+
+// RepaymentResponse is a posted loan repayment and how it was split.
+type RepaymentResponse struct {
+	ID            string    `json:"id"`
+	TransactionID string    `json:"transaction_id"`
+	Amount        string    `json:"amount"`
+	Principal     string    `json:"principal"`
+	Interest      string    `json:"interest"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// LoanRepaymentResponse is a new repayment and the loan's standing after it.
+type LoanRepaymentResponse struct {
+	Repayment   RepaymentResponse `json:"repayment"`
+	Status      string            `json:"status"`
+	Delinquency string            `json:"delinquency"`
+}
+
+// ReversalResponse links a reversed transaction to the posting that undid it.
+type ReversalResponse struct {
+	TransactionID         string `json:"transaction_id"`
+	ReversalTransactionID string `json:"reversal_transaction_id"`
+	// FeeAmount is what the original charged in fees; FeesRefunded says whether it was returned.
+	FeeAmount    string    `json:"fee_amount"`
+	FeesRefunded bool      `json:"fees_refunded"`
+	Reason       string    `json:"reason"`
+	ReversedBy   string    `json:"reversed_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// RefundSummaryResponse is a transaction's amount, fees excluded, and the partial refunds made
+// against it. Remaining is what may still be refunded.
+type RefundSummaryResponse struct {
+	TransactionID string                      `json:"transaction_id"`
+	Currency      string                      `json:"currency"`
+	Amount        string                      `json:"amount"`
+	Refunded      string                      `json:"refunded"`
+	Remaining     string                      `json:"remaining"`
+	Refunds       []TransactionRefundResponse `json:"refunds"`
+}
+
+// TransactionRefundResponse links part of a transaction to the posting that refunded it.
+type TransactionRefundResponse struct {
+	ID                  string    `json:"id"`
+	RefundTransactionID string    `json:"refund_transaction_id"`
+	Amount              string    `json:"amount"`
+	Reason              string    `json:"reason"`
+	RefundedBy          string    `json:"refunded_by"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// TaxRuleResponse is a tax withheld from customer credits of one operation.
+type TaxRuleResponse struct {
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	OperationType string    `json:"operation_type"`
+	RateBps       int32     `json:"rate_bps"`
+	Active        bool      `json:"active"`
+	UpdatedBy     string    `json:"updated_by"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// TaxReportResponse totals withheld taxes over an inclusive range of UTC days.
+type TaxReportResponse struct {
+	From  string                  `json:"from"`
+	To    string                  `json:"to"`
+	Lines []TaxReportLineResponse `json:"lines"`
+}
+
+// TaxReportLineResponse is what one rule withheld in one currency.
+type TaxReportLineResponse struct {
+	RuleCode string `json:"rule_code"`
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+	// Postings counts the taxed credits; BaseAmount is their gross total.
+	Postings   int64  `json:"postings"`
+	BaseAmount string `json:"base_amount"`
+	Amount     string `json:"amount"`
+}
+
+// SettlementReportResponse summarizes the settlement account over an inclusive range of UTC days
+// as the settlement bank sees it: positions are what the bank should hold, inflows money arriving.
+type SettlementReportResponse struct {
+	From                string                        `json:"from"`
+	To                  string                        `json:"to"`
+	Currency            string                        `json:"currency"`
+	OpeningPosition     string                        `json:"opening_position"`
+	ClosingPosition     string                        `json:"closing_position"`
+	NetMovement         string                        `json:"net_movement"`
+	ExpectedBankBalance string                        `json:"expected_bank_balance"`
+	BankBalance         *string                       `json:"bank_balance,omitempty"`
+	Difference          *string                       `json:"difference,omitempty"`
+	Matched             *bool                         `json:"matched,omitempty"`
+	ByOperation         []SettlementOperationResponse `json:"by_operation"`
+	ByDay               []SettlementDayResponse       `json:"by_day"`
+}
+
+// SettlementOperationResponse totals the settlement activity of one operation type.
+type SettlementOperationResponse struct {
+	OperationType string `json:"operation_type"`
+	Entries       int64  `json:"entries"`
+	Inflow        string `json:"inflow"`
+	Outflow       string `json:"outflow"`
+	Net           string `json:"net"`
+}
+
+// SettlementDayResponse totals one UTC day's settlement activity and the position it closed at.
+type SettlementDayResponse struct {
+	Date            string `json:"date"`
+	Entries         int64  `json:"entries"`
+	Inflow          string `json:"inflow"`
+	Outflow         string `json:"outflow"`
+	Net             string `json:"net"`
+	ClosingPosition string `json:"closing_position"`
+}
+
+// GLMappingsResponse lists where each system account and each currency's customer accounts export to.
+type GLMappingsResponse struct {
+	Accounts  []GLAccountCodeResponse  `json:"accounts"`
+	Customers []GLCustomerCodeResponse `json:"customers"`
+}
+
+// GLAccountCodeResponse is a system account's external GL account; GLCode is empty when unmapped.
+type GLAccountCodeResponse struct {
+	AccountID string `json:"account_id"`
+	Name      string `json:"name"`
+	Currency  string `json:"currency"`
+	GLCode    string `json:"gl_code"`
+	GLName    string `json:"gl_name"`
+}
+
+// GLCustomerCodeResponse is the GL account customer accounts of one currency export to.
+type GLCustomerCodeResponse struct {
+	Currency string `json:"currency"`
+	GLCode   string `json:"gl_code"`
+	GLName   string `json:"gl_name"`
+}
+
+// PagedResponse wraps one page of a list endpoint.
+type PagedResponse struct {
+	Data interface{} `json:"data"`
+	Page PageInfo    `json:"page"`
+}
+
+// PageInfo locates a page within the whole list. NextOffset is omitted on the last page.
+type PageInfo struct {
+	Limit      int   `json:"limit"`
+	Offset     int   `json:"offset"`
+	NextOffset *int  `json:"next_offset,omitempty"`
+	Total      int64 `json:"total"`
+}
+
+// TransactionResponse reports a ledger transaction's lifecycle status.
+type TransactionResponse struct {
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	ID            string    `json:"id"`
+	OperationType string    `json:"operation_type"`
+	// Status is pending, posted, failed or reversed.
+	Status string `json:"status"`
+	// RequestID is the X-Request-Id of the request that created the transaction, if any.
+	RequestID string `json:"request_id,omitempty"`
+	// Risk is the fraud risk assessment of the operation, shown to admins only when it was scored.
+	Risk *TransactionRiskResponse `json:"risk,omitempty"`
+}
+
+// TransactionRiskResponse is the fraud risk score (0 to 100) of the operation behind a transaction,
+// the signals that raised it and the decision: allow, or challenge when the customer stepped up.
+type TransactionRiskResponse struct {
+	Score    int16    `json:"score"`
+	Decision string   `json:"decision"`
+	Factors  []string `json:"factors"`
+}
+
+// LedgerEventResponse is one entry of the ledger's append-only event log. type is
+// transaction_posted, whose payload holds the operation type, initial status and legs, or
+// transaction_status_changed, whose payload holds from and to.
+type LedgerEventResponse struct {
+	CreatedAt     time.Time       `json:"created_at"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Type          string          `json:"type"`
+	TransactionID string          `json:"transaction_id"`
+	RequestID     string          `json:"request_id,omitempty"`
+	Seq           int64           `json:"seq"`
+}
+
+// LedgerProjectionResponse reports whether cached balances and entries match the balances
+// replayed from the event log, listing the accounts that do not.
+type LedgerProjectionResponse struct {
+	Drift      []LedgerDriftResponse `json:"drift"`
+	Consistent bool                  `json:"consistent"`
+}
+
+// LedgerDriftResponse is an account whose projections disagree with the event log.
+type LedgerDriftResponse struct {
+	AccountID       string `json:"account_id"`
+	Currency        string `json:"currency"`
+	StoredBalance   string `json:"stored_balance"`
+	EntriesBalance  string `json:"entries_balance"`
+	ReplayedBalance string `json:"replayed_balance"`
+}
+
+// EntryChainResponse reports a check of the entries' hash chains. intact is true when no entry was
+// changed, removed or reordered after it was posted.
+type EntryChainResponse struct {
+	Breaks   []EntryChainBreakResponse `json:"breaks"`
+	Entries  int64                     `json:"entries"`
+	Accounts int                       `json:"accounts"`
+	Intact   bool                      `json:"intact"`
+}
+
+// EntryChainBreakResponse is where an account's chain does not hold. Problems are sequence_gap,
+// prev_hash_mismatch, hash_mismatch or head_mismatch; entry_id is omitted when no entry is left.
+type EntryChainBreakResponse struct {
+	AccountID string   `json:"account_id"`
+	EntryID   string   `json:"entry_id,omitempty"`
+	Problems  []string `json:"problems"`
+	Seq       int64    `json:"seq"`
+}
+
+// MerkleRootResponse is a published Merkle root over leaf_count entries. Hashes are hex.
+type MerkleRootResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	RootHash  string    `json:"root_hash"`
+	LeafCount int32     `json:"leaf_count"`
+}
+
+// MerkleProofResponse shows an entry is under a published root: leaf_hash, hashed up through path
+// from leaf_index, gives root.root_hash. Hashes are hex.
+type MerkleProofResponse struct {
+	Entry     ChainedEntryResponse `json:"entry"`
+	Root      MerkleRootResponse   `json:"root"`
+	LeafHash  string               `json:"leaf_hash"`
+	Path      []string             `json:"path"`
+	LeafIndex int                  `json:"leaf_index"`
+}
+
+// ChainedEntryResponse is an entry with everything its chain hash covers. description is null,
+// not empty, when the entry has none, since the hash tells the two apart.
+type ChainedEntryResponse struct {
+	CreatedAt     time.Time `json:"created_at"`
+	Description   *string   `json:"description"`
+	ID            string    `json:"id"`
+	AccountID     string    `json:"account_id"`
+	TransactionID string    `json:"transaction_id"`
+	Debit         string    `json:"debit"`
+	Credit        string    `json:"credit"`
+	OperationType string    `json:"operation_type"`
+	PrevHash      string    `json:"prev_hash,omitempty"`
+	Hash          string    `json:"hash"`
+	ChainSeq      int64     `json:"chain_seq"`
+}
+
+// StatementVerificationResponse reports whether a statement's signature holds under the
+// published statement keys, and which key signed it.
+type StatementVerificationResponse struct {
+	KeyID  string `json:"key_id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	Valid  bool   `json:"valid"`
+}
+
+// TransferJobResponse reports an async transfer. ID is the ledger transaction ID once posted.
+type TransferJobResponse struct {
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	ID            string    `json:"id"`
+	FromAccountID string    `json:"from_account_id"`
+	ToAccountID   string    `json:"to_account_id"`
+	Amount        string    `json:"amount"`
+	// Status is pending, posted or failed.
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+// PayoutBatchResponse reports a bulk payout and the progress of its rows.
+type PayoutBatchResponse struct {
+	CreatedAt       time.Time `json:"created_at"`
+	ID              string    `json:"id"`
+	SourceAccountID string    `json:"source_account_id"`
+	Filename        string    `json:"filename,omitempty"`
+	TotalAmount     string    `json:"total_amount"`
+	Currency        string    `json:"currency"`
+	// Status is processing while any row is pending, then completed.
+	Status   string                   `json:"status"`
+	Rows     []PayoutBatchRowResponse `json:"rows"`
+	RowCount int32                    `json:"row_count"`
+	Pending  int32                    `json:"pending"`
+	Posted   int32                    `json:"posted"`
+	Failed   int32                    `json:"failed"`
+}
+
+// PayoutBatchRowResponse is one line of a payout file and its outcome.
+type PayoutBatchRowResponse struct {
+	AccountID string `json:"account_id"`
+	Amount    string `json:"amount"`
+	Narration string `json:"narration,omitempty"`
+	// Status is pending, posted or failed.
+	Status string `json:"status"`
+	// TransactionID is set once the row is posted.
+	TransactionID string `json:"transaction_id,omitempty"`
+	FailureReason string `json:"failure_reason,omitempty"`
+	Line          int32  `json:"line"`
+}
+
+// PayoutBatchErrorResponse lists every rejected line of a payout file.
+type PayoutBatchErrorResponse struct {
+	Error string                   `json:"error"`
+	Rows  []PayoutRowErrorResponse `json:"rows"`
+}
+
+// PayoutRowErrorResponse explains why one line of a payout file was rejected.
+type PayoutRowErrorResponse struct {
+	Error string `json:"error"`
+	Line  int    `json:"line"`
+}
+
+// JobResponse reports a background job and its last error.
+type JobResponse struct {
+	RunAt       time.Time       `json:"run_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+	ID          string          `json:"id"`
+	Kind        string          `json:"kind"`
+	Status      string          `json:"status"`
+	LastError   string          `json:"last_error,omitempty"`
+	Attempts    int32           `json:"attempts"`
+	MaxAttempts int32           `json:"max_attempts"`
+}
+
+// WebhookEndpointResponse is an organization webhook endpoint and its health.
+type WebhookEndpointResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	// PreviousSecretExpiresAt is when the secret replaced by the last rotation stops signing
+	// deliveries; absent if the secret was never rotated.
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+	// Secret signs deliveries. It is only returned when the endpoint is created or its secret rotated.
+	Secret              string `json:"secret,omitempty"`
+	ConsecutiveFailures int32  `json:"consecutive_failures"`
+	// Healthy turns false after repeated consecutive failures and back on the next success.
+	Healthy bool `json:"healthy"`
+}
+
+// WebhookDeliveryResponse is one event queued for an endpoint.
+type WebhookDeliveryResponse struct {
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	ID            string          `json:"id"`
+	EndpointID    string          `json:"endpoint_id"`
+	EventType     string          `json:"event_type"`
+	TransactionID string          `json:"transaction_id"`
+	// Status is pending, succeeded or dead.
+	Status    string `json:"status"`
+	LastError string `json:"last_error,omitempty"`
+	Attempts  int32  `json:"attempts"`
+}
+
+// RateResponse is the price of one unit of Base in Quote.
+type RateResponse struct {
+	AsOf  time.Time `json:"as_of"`
+	Base  string    `json:"base"`
+	Quote string    `json:"quote"`
+	Rate  string    `json:"rate"`
+	// Source is provider, manual or identity (base equals quote).
+	Source string `json:"source"`
+	// Inverted is true when the rate was derived from the stored quote/base pair.
+	Inverted bool `json:"inverted"`
+	// Stale is true when the provider rate is older than the freshness window.
+	Stale bool `json:"stale"`
+}
+
+// RateOverrideResponse is a manual exchange rate set by an admin.
+type RateOverrideResponse struct {
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Base      string     `json:"base"`
+	Quote     string     `json:"quote"`
+	Rate      string     `json:"rate"`
+	Reason    string     `json:"reason"`
+	SetBy     string     `json:"set_by"`
+}
+
+// ConversionQuoteResponse prices selling SellAmount of SellCurrency for BuyCurrency.
+type ConversionQuoteResponse struct {
+	AsOf         time.Time `json:"as_of"`
+	SellCurrency string    `json:"sell_currency"`
+	SellAmount   string    `json:"sell_amount"`
+	BuyCurrency  string    `json:"buy_currency"`
+	BuyAmount    string    `json:"buy_amount"`
+	MidRate      string    `json:"mid_rate"`
+	// CustomerRate is the mid rate less the spread; BuyAmount is SellAmount at this rate.
+	CustomerRate string `json:"customer_rate"`
+	// Margin is the bank's spread income in BuyCurrency.
+	Margin     string `json:"margin"`
+	RateSource string `json:"rate_source"`
+	SpreadBps  int32  `json:"spread_bps"`
+}
+
+// ConversionResponse is a posted currency conversion.
+type ConversionResponse struct {
+	CreatedAt     time.Time `json:"created_at"`
+	RateAsOf      time.Time `json:"rate_as_of"`
+	TransactionID string    `json:"transaction_id"`
+	FromAccountID string    `json:"from_account_id"`
+	ToAccountID   string    `json:"to_account_id"`
+	SellCurrency  string    `json:"sell_currency"`
+	SellAmount    string    `json:"sell_amount"`
+	BuyCurrency   string    `json:"buy_currency"`
+	BuyAmount     string    `json:"buy_amount"`
+	MidRate       string    `json:"mid_rate"`
+	CustomerRate  string    `json:"customer_rate"`
+	Margin        string    `json:"margin"`
+	RateSource    string    `json:"rate_source"`
+	SpreadBps     int32     `json:"spread_bps"`
+}
+
+// FXSpreadResponse is the spread charged when selling Base for Quote.
+type FXSpreadResponse struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	Base      string    `json:"base"`
+	Quote     string    `json:"quote"`
+	UpdatedBy string    `json:"updated_by"`
+	SpreadBps int32     `json:"spread_bps"`
+}
+
+// AccountProductResponse is a product's rules in one currency. MaxDebit is omitted when uncapped.
+type AccountProductResponse struct {
+	UpdatedAt      time.Time `json:"updated_at"`
+	MaxDebit       *string   `json:"max_debit,omitempty"`
+	Product        string    `json:"product"`
+	Currency       string    `json:"currency"`
+	MinBalance     string    `json:"min_balance"`
+	OverdraftLimit string    `json:"overdraft_limit"`
+	TransferFee    string    `json:"transfer_fee"`
+	WithdrawalFee  string    `json:"withdrawal_fee"`
+	UpdatedBy      string    `json:"updated_by"`
+}
+
+// ProductResponse is an account type with its per-currency rules.
+type ProductResponse struct {
+	UpdatedAt time.Time                `json:"updated_at"`
+	Code      string                   `json:"code"`
+	Name      string                   `json:"name"`
+	Rules     []AccountProductResponse `json:"rules"`
+	// InterestTiers are the balance bands above the base interest_rate_bps, per currency.
+	InterestTiers      []InterestTierResponse `json:"interest_tiers,omitempty"`
+	InterestRateBps    int32                  `json:"interest_rate_bps"`
+	WithdrawalsEnabled bool                   `json:"withdrawals_enabled"`
+	TransfersEnabled   bool                   `json:"transfers_enabled"`
+}
+
+// InterestTierResponse is a balance band of a product's interest rate in one currency: the part
+// of a balance from min_balance up to the next band earns rate_bps a year.
+type InterestTierResponse struct {
+	UpdatedAt  time.Time `json:"updated_at"`
+	Product    string    `json:"product"`
+	Currency   string    `json:"currency"`
+	MinBalance string    `json:"min_balance"`
+	RateBps    int32     `json:"rate_bps"`
+}
+
+// CurrencyResponse is an ISO 4217 currency accounts can be opened in.
+type CurrencyResponse struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Numeric string `json:"numeric"`
+	// Exponent is how many decimal places amounts in the currency carry.
+	Exponent int32 `json:"exponent"`
+}
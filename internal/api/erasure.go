@@ -0,0 +1,245 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// erasureStatus maps user erasure errors to an HTTP status.
+func erasureStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrErasurePending), errors.Is(err, service.ErrErasureNotPending),
+		errors.Is(err, service.ErrErasureBalance), errors.Is(err, service.ErrUserErased):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondErasureError writes err with its erasure status, hiding internal failures.
+func respondErasureError(w http.ResponseWriter, err error, msg string) {
+	status := erasureStatus(err)
+	if status == http.StatusInternalServerError {
+		log.Error().Err(err).Msg(msg)
+		respondError(w, status, msg)
+		return
+	}
+	respondError(w, status, err.Error())
+}
+
+// requestErasure decodes the reason, optional unless reasonRequired, and schedules userID's erasure.
+func (h *Handler) requestErasure(w http.ResponseWriter, r *http.Request, userID, requestedBy uuid.UUID, reasonRequired bool) {
+	var input struct {
+		Reason string `json:"reason"`
+	}
+	if !decodeOptionalJSON(w, r, &input) {
+		return
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reasonRequired && reason == "" {
+		respondError(w, http.StatusBadRequest, "reason required")
+		return
+	}
+	if len(reason) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "reason must be at most 500 characters")
+		return
+	}
+
+	erasure, err := h.ledger.RequestErasure(r.Context(), userID, requestedBy, reason)
+	if err != nil {
+		respondErasureError(w, err, "failed to request erasure")
+		return
+	}
+	respondJSON(w, http.StatusAccepted, toErasureResponse(erasure))
+}
+
+// RequestMyErasure godoc
+// @Summary      Ask for your personal data to be erased
+// @Description  Schedules the caller's profile, login, KYC document details and statement addresses to be anonymized once the grace period (30 days unless configured) ends; until then DELETE /me/erasure cancels it. Accounts, transactions and entries are kept for accounting retention. Every account the caller owns must have a zero balance, both now and when the erasure runs. After it runs the caller can no longer log in.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{reason=string}  false  "Optional reason"
+// @Success      202   {object}  ErasureResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /me/erasure [post]
+// @Security     Bearer
+func (h *Handler) RequestMyErasure(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	h.requestErasure(w, r, userID, userID, false)
+}
+
+// GetMyErasure godoc
+// @Summary      Get your latest erasure request
+// @Description  Returns the caller's most recent erasure request, with when it will run while it is pending.
+// @Tags         profile
+// @Produce      json
+// @Success      200  {object}  ErasureResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /me/erasure [get]
+// @Security     Bearer
+func (h *Handler) GetMyErasure(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	erasure, err := h.store.GetLatestUserErasure(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "no erasure request")
+			return
+		}
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get erasure request")
+		respondError(w, http.StatusInternalServerError, "failed to get erasure request")
+		return
+	}
+	respondJSON(w, http.StatusOK, toErasureResponse(erasure))
+}
+
+// CancelMyErasure godoc
+// @Summary      Cancel your erasure request
+// @Description  Withdraws the caller's pending erasure request during its grace period.
+// @Tags         profile
+// @Produce      json
+// @Success      200  {object}  ErasureResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /me/erasure [delete]
+// @Security     Bearer
+func (h *Handler) CancelMyErasure(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	erasure, err := h.ledger.CancelErasure(r.Context(), userID, userID)
+	if err != nil {
+		respondErasureError(w, err, "failed to cancel erasure")
+		return
+	}
+	respondJSON(w, http.StatusOK, toErasureResponse(erasure))
+}
+
+// RequestUserErasure godoc
+// @Summary      Schedule a user's erasure
+// @Description  Schedules a user's personal data to be anonymized after the grace period, e.g. for a request received by email. The same rules as POST /me/erasure apply, and a reason is required. Admins cannot target their own user. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                 true  "User ID"
+// @Param        body  body      object{reason=string}  true  "Why"
+// @Success      202   {object}  ErasureResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/users/{id}/erasure [post]
+// @Security     Bearer
+func (h *Handler) RequestUserErasure(w http.ResponseWriter, r *http.Request) {
+	callerID, userID, ok := adminUserTarget(w, r)
+	if !ok {
+		return
+	}
+	h.requestErasure(w, r, userID, callerID, true)
+}
+
+// CancelUserErasure godoc
+// @Summary      Cancel a user's erasure
+// @Description  Withdraws a user's pending erasure request during its grace period. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  ErasureResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/users/{id}/erasure [delete]
+// @Security     Bearer
+func (h *Handler) CancelUserErasure(w http.ResponseWriter, r *http.Request) {
+	callerID, userID, ok := adminUserTarget(w, r)
+	if !ok {
+		return
+	}
+	erasure, err := h.ledger.CancelErasure(r.Context(), userID, callerID)
+	if err != nil {
+		respondErasureError(w, err, "failed to cancel erasure")
+		return
+	}
+	respondJSON(w, http.StatusOK, toErasureResponse(erasure))
+}
+
+// ListErasures godoc
+// @Summary      List user erasure requests
+// @Description  Returns a page of erasure requests in one status, oldest first, wrapped in {data, page}. Pending ones are waiting out their grace period; completed and cancelled ones are the audit trail. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        status    query     string  false  "pending (default), cancelled or completed"
+// @Param        limit     query     int     false  "Limit (default 20, max 100)"
+// @Param        offset    query     int     false  "Offset (default 0)"
+// @Param        envelope  query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200       {object}  PagedResponse{data=[]ErasureResponse}
+// @Failure      400       {object}  ErrorResponse
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
+// @Router       /admin/erasures [get]
+// @Security     Bearer
+func (h *Handler) ListErasures(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.ErasurePending
+	case service.ErasurePending, service.ErasureCancelled, service.ErasureCompleted:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be pending, cancelled or completed")
+		return
+	}
+	limit, offset := parsePage(r)
+
+	// Step 2: Fetch the page and the size of the whole list.
+	rows, err := h.store.ListUserErasuresByStatus(r.Context(), sqlc.ListUserErasuresByStatusParams{
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		Offset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to list erasures")
+		respondError(w, http.StatusInternalServerError, "failed to list erasures")
+		return
+	}
+	total, err := h.store.CountUserErasuresByStatus(r.Context(), status)
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to count erasures")
+		respondError(w, http.StatusInternalServerError, "failed to list erasures")
+		return
+	}
+
+	resp := make([]ErasureResponse, 0, len(rows))
+	for _, e := range rows {
+		resp = append(resp, toErasureResponse(e))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestErasureStatus(t *testing.T) {
+	// Each erasure error maps to the status clients rely on.
+	assert.Equal(t, http.StatusNotFound, erasureStatus(service.ErrUserNotFound))
+	assert.Equal(t, http.StatusConflict, erasureStatus(service.ErrErasurePending))
+	assert.Equal(t, http.StatusConflict, erasureStatus(service.ErrErasureNotPending))
+	assert.Equal(t, http.StatusConflict, erasureStatus(service.ErrErasureBalance))
+	assert.Equal(t, http.StatusConflict, erasureStatus(service.ErrUserErased))
+	assert.Equal(t, http.StatusInternalServerError, erasureStatus(errors.New("boom")))
+}
+
+func TestErasureEndpoints_RejectBadInput(t *testing.T) {
+	// Admin requests need a reason, cannot target the admin and list only known statuses.
+	require.NoError(t, InitTokenAuth("fV7sliKV3qn657I60wEFtw/Auk/0bNU9zdp30wFzfDg="))
+	adminID := uuid.New()
+	token, err := GenerateToken(adminID, uuid.New(), RoleAdmin)
+	require.NoError(t, err)
+
+	h := &Handler{}
+	router := chi.NewRouter()
+	router.Group(func(r chi.Router) {
+		r.Use(Verifier, Authenticator)
+		r.Post("/me/erasure", h.RequestMyErasure)
+		r.Post("/admin/users/{id}/erasure", h.RequestUserErasure)
+		r.Get("/admin/erasures", h.ListErasures)
+	})
+
+	for _, tc := range []struct{ method, path, body, want string }{
+		{http.MethodPost, "/me/erasure", `{"reason":"` + strings.Repeat("x", 501) + `"}`, "at most 500"},
+		{http.MethodPost, "/admin/users/" + uuid.NewString() + "/erasure", ``, "reason required"},
+		{http.MethodPost, "/admin/users/" + adminID.String() + "/erasure", `{"reason":"email request"}`, "your own"},
+		{http.MethodGet, "/admin/erasures?status=done", ``, "status must be"},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusBadRequest, rw.Code, tc.path)
+		assert.Contains(t, rw.Body.String(), tc.want, tc.path)
+	}
+}
@@ -0,0 +1,348 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// escrowStatus maps escrow errors to an HTTP status.
+func escrowStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrEscrowNotFound), errors.Is(err, service.ErrAccountNotFound),
+		errors.Is(err, service.ErrCrossOrgTransfer):
+		// Accounts of other tenants are indistinguishable from missing ones.
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrEscrowNotFunded):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrMinimumBalance),
+		errors.Is(err, service.ErrDebitLimitExceeded), errors.Is(err, service.ErrOperationNotAllowed), errors.Is(err, service.ErrSavingsGoalLocked),
+		errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountPrecision), errors.Is(err, service.ErrCurrencyMismatch),
+		errors.Is(err, service.ErrSameAccountTransfer):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondEscrowError writes err with its escrow status, hiding internal failures.
+func respondEscrowError(w http.ResponseWriter, err error, msg string) {
+	status := escrowStatus(err)
+	switch status {
+	case http.StatusNotFound:
+		respondError(w, status, "escrow or account not found")
+	case http.StatusInternalServerError:
+		log.Error().Err(err).Msg("Escrow operation failed")
+		respondError(w, status, msg)
+	default:
+		respondLedgerError(w, status, err)
+	}
+}
+
+// escrowParty reports whether the caller may act for accountID on e: an owner of that account,
+// or an organization admin of the escrow's organization acting as arbiter.
+func (h *Handler) escrowParty(r *http.Request, userID, orgID uuid.UUID, e sqlc.Escrow, accountID uuid.UUID, role string) bool {
+	if authenticatedRole(r) == RoleOrgAdmin && orgID == e.OrgID {
+		return true
+	}
+	acc, err := h.store.GetAccount(r.Context(), accountID)
+	if err != nil {
+		return false
+	}
+	return h.hasAccountRole(r.Context(), userID, acc, role)
+}
+
+// CreateEscrow godoc
+// @Summary      Fund an escrow
+// @Description  Moves amount from an account the caller owns into escrow for seller_account_id, an account of the same organization and currency. The funds sit in the Escrow Holding account until the buyer (or an organization admin) releases them to the seller, or the seller (or an organization admin) refunds the buyer. With SIGNED_TRANSFER_THRESHOLD set, escrows above it must be funded with a signed request like POST /transfers. The amount field accepts JSON number or string.
+// @Tags         escrows
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                                                             true  "Buyer account ID"
+// @Param        body  body      object{seller_account_id=string,amount=string,description=string}  true  "Escrow details"
+// @Success      201   {object}  EscrowResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/escrows [post]
+// @Security     Bearer
+func (h *Handler) CreateEscrow(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and authorize the buyer account.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	buyerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.ownedAccount(w, r, userID, buyerID); !ok {
+		return
+	}
+
+	// Step 2: Decode payload.
+	body := h.captureSignedBody(r)
+	var input struct {
+		Amount          interface{} `json:"amount"`
+		SellerAccountID string      `json:"seller_account_id"`
+		Description     string      `json:"description"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	sellerID, err := uuid.Parse(input.SellerAccountID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid seller_account_id")
+		return
+	}
+	amount, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid amount")
+		return
+	}
+	ctx, ok := h.checkRequestSignature(w, r, body, buyerID, amount)
+	if !ok {
+		return
+	}
+	r = r.WithContext(ctx)
+	description := strings.TrimSpace(input.Description)
+	if description == "" || len(description) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "description required (at most 500 characters)")
+		return
+	}
+
+	// Step 3: Fund; the service checks the seller's organization and currency under lock.
+	escrow, err := h.ledger.CreateEscrow(r.Context(), service.EscrowRequest{
+		BuyerAccountID:  buyerID,
+		SellerAccountID: sellerID,
+		Amount:          amount,
+		Description:     description,
+		CreatedBy:       userID,
+	})
+	if respondScreening(w, err) {
+		return
+	}
+	if err != nil {
+		respondEscrowError(w, err, "failed to fund escrow")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toEscrowResponse(escrow))
+}
+
+// ListAccountEscrows godoc
+// @Summary      List account escrows
+// @Description  Returns escrows where the account is the buyer or the seller, newest first
+// @Tags         escrows
+// @Produce      json
+// @Param        id      path      string  true   "Account ID"
+// @Param        limit   query     int     false  "Limit (default 20)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   EscrowResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      404     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /accounts/{id}/escrows [get]
+// @Security     Bearer
+func (h *Handler) ListAccountEscrows(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.visibleAccount(w, r, userID, accountID); !ok {
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	rows, err := h.store.ListEscrowsByAccount(r.Context(), sqlc.ListEscrowsByAccountParams{
+		AccountID: accountID,
+		RowLimit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		RowOffset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to list escrows")
+		respondError(w, http.StatusInternalServerError, "failed to list escrows")
+		return
+	}
+
+	resp := make([]EscrowResponse, 0, len(rows))
+	for _, e := range rows {
+		resp = append(resp, toEscrowResponse(e))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetEscrow godoc
+// @Summary      Get an escrow
+// @Description  Returns an escrow the caller can see as buyer, seller or organization admin
+// @Tags         escrows
+// @Produce      json
+// @Param        id   path      string  true  "Escrow ID"
+// @Success      200  {object}  EscrowResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /escrows/{id} [get]
+// @Security     Bearer
+func (h *Handler) GetEscrow(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	escrow, ok := h.loadEscrow(w, r)
+	if !ok {
+		return
+	}
+	if !h.escrowParty(r, userID, orgID, escrow, escrow.BuyerAccountID, AccountRoleViewer) &&
+		!h.escrowParty(r, userID, orgID, escrow, escrow.SellerAccountID, AccountRoleViewer) {
+		respondError(w, http.StatusNotFound, "escrow not found")
+		return
+	}
+	respondJSON(w, http.StatusOK, toEscrowResponse(escrow))
+}
+
+// ReleaseEscrow godoc
+// @Summary      Release an escrow to the seller
+// @Description  Pays a funded escrow to the seller's account. Owners of the buyer account and organization admins only.
+// @Tags         escrows
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string              true  "Escrow ID"
+// @Param        body  body      object{note=string}  false  "Optional note"
+// @Success      200   {object}  EscrowResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /escrows/{id}/release [post]
+// @Security     Bearer
+func (h *Handler) ReleaseEscrow(w http.ResponseWriter, r *http.Request) {
+	h.settleEscrow(w, r, true)
+}
+
+// RefundEscrow godoc
+// @Summary      Refund an escrow to the buyer
+// @Description  Returns a funded escrow to the buyer's account. Owners of the seller account and organization admins only.
+// @Tags         escrows
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string              true  "Escrow ID"
+// @Param        body  body      object{note=string}  false  "Optional note"
+// @Success      200   {object}  EscrowResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /escrows/{id}/refund [post]
+// @Security     Bearer
+func (h *Handler) RefundEscrow(w http.ResponseWriter, r *http.Request) {
+	h.settleEscrow(w, r, false)
+}
+
+// settleEscrow releases (to the seller) or refunds (to the buyer). Each side can only give up
+// its own claim: the buyer releases, the seller refunds, and an org admin can do either.
+func (h *Handler) settleEscrow(w http.ResponseWriter, r *http.Request, release bool) {
+	// Step 1: Authenticate caller and load the escrow.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	escrow, ok := h.loadEscrow(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		Note string `json:"note"`
+	}
+	// The body is optional.
+	if !decodeOptionalJSON(w, r, &input) {
+		return
+	}
+	note := strings.TrimSpace(input.Note)
+	if len(note) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "note must be at most 500 characters")
+		return
+	}
+
+	// Step 2: Authorize the side giving up its claim.
+	party := escrow.SellerAccountID
+	if release {
+		party = escrow.BuyerAccountID
+	}
+	if !h.escrowParty(r, userID, orgID, escrow, party, AccountRoleOwner) {
+		if !h.escrowParty(r, userID, orgID, escrow, escrow.BuyerAccountID, AccountRoleViewer) &&
+			!h.escrowParty(r, userID, orgID, escrow, escrow.SellerAccountID, AccountRoleViewer) {
+			respondError(w, http.StatusNotFound, "escrow not found")
+			return
+		}
+		respondError(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	// Step 3: Pay out of holding exactly once.
+	escrow, err := h.ledger.SettleEscrow(r.Context(), escrow.ID, userID, release, note)
+	if err != nil {
+		respondEscrowError(w, err, "failed to settle escrow")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toEscrowResponse(escrow))
+}
+
+// loadEscrow parses the escrow ID from the path and loads it, writing 400 or 404 on failure.
+func (h *Handler) loadEscrow(w http.ResponseWriter, r *http.Request) (sqlc.Escrow, bool) {
+	escrowID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid escrow ID")
+		return sqlc.Escrow{}, false
+	}
+	escrow, err := h.store.GetEscrow(r.Context(), escrowID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "escrow not found")
+			return sqlc.Escrow{}, false
+		}
+		log.Error().Err(err).Str("escrow_id", escrowID.String()).Msg("Failed to load escrow")
+		respondError(w, http.StatusInternalServerError, "failed to load escrow")
+		return sqlc.Escrow{}, false
+	}
+	return escrow, true
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestEscrowStatus(t *testing.T) {
+	// Settled escrows conflict, other tenants' accounts look missing, and ledger refusals are client errors.
+	assert.Equal(t, http.StatusConflict, escrowStatus(service.ErrEscrowNotFunded))
+	assert.Equal(t, http.StatusNotFound, escrowStatus(service.ErrEscrowNotFound))
+	assert.Equal(t, http.StatusNotFound, escrowStatus(fmt.Errorf("fund: %w", service.ErrCrossOrgTransfer)))
+	assert.Equal(t, http.StatusBadRequest, escrowStatus(service.ErrMinimumBalance))
+	assert.Equal(t, http.StatusBadRequest, escrowStatus(service.ErrSameAccountTransfer))
+	assert.Equal(t, http.StatusInternalServerError, escrowStatus(errors.New("connection reset")))
+}
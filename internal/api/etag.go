@@ -0,0 +1,48 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// accountsETag is a weak validator for a representation of accounts. updated_at moves with
+// every change to an account row, balance included; the product-derived fields of resps cover
+// rule changes made elsewhere, and extra anything else in the body, such as a page's total.
+func accountsETag(accounts []sqlc.Account, resps []AccountResponse, extra string) string {
+	h := sha256.New()
+	for i, acc := range accounts {
+		fmt.Fprintf(h, "%s|%s|%d|%s|%s|%s\n", acc.ID, acc.Balance, acc.UpdatedAt.UnixNano(),
+			resps[i].MinBalance, resps[i].OverdraftLimit, resps[i].AvailableBalance)
+	}
+	fmt.Fprint(h, extra)
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header names etag, comparing weakly as RFC 9110
+// requires for GET.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified sets etag on the response and answers 304 when the client already holds that
+// version, reporting whether it did. Responses are private to the caller and must be revalidated.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if header := r.Header.Get("If-None-Match"); header != "" && etagMatches(header, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+	return false
+}
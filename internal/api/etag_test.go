@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestAccountsETag_ChangesWithAccount(t *testing.T) {
+	// A new balance, a newer updated_at or different product rules each change the tag.
+	acc := sqlc.Account{ID: uuid.New(), Balance: "10.0000", UpdatedAt: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)}
+	resp := []AccountResponse{{MinBalance: "0.0000"}}
+	base := accountsETag([]sqlc.Account{acc}, resp, "")
+	assert.Equal(t, base, accountsETag([]sqlc.Account{acc}, resp, ""))
+
+	moved := acc
+	moved.Balance = "12.0000"
+	assert.NotEqual(t, base, accountsETag([]sqlc.Account{moved}, resp, ""))
+	touched := acc
+	touched.UpdatedAt = acc.UpdatedAt.Add(time.Microsecond)
+	assert.NotEqual(t, base, accountsETag([]sqlc.Account{touched}, resp, ""))
+	assert.NotEqual(t, base, accountsETag([]sqlc.Account{acc}, []AccountResponse{{MinBalance: "5.0000"}}, ""))
+	assert.NotEqual(t, base, accountsETag([]sqlc.Account{acc}, resp, "page"))
+}
+
+func TestNotModified(t *testing.T) {
+	// Matching If-None-Match values, weak or strong, in a list or "*", answer 304.
+	etag := `W/"abc"`
+	for header, want := range map[string]bool{
+		"":               false,
+		`W/"abc"`:        true,
+		`"abc"`:          true,
+		`"xyz", W/"abc"`: true,
+		"*":              true,
+		`W/"abd"`:        false,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+		if header != "" {
+			req.Header.Set("If-None-Match", header)
+		}
+		rw := httptest.NewRecorder()
+		assert.Equal(t, want, notModified(rw, req, etag), header)
+		assert.Equal(t, etag, rw.Header().Get("ETag"))
+		if want {
+			assert.Equal(t, http.StatusNotModified, rw.Code)
+		}
+	}
+}
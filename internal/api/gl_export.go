@@ -0,0 +1,266 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/glexport"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// maxGLExportRange bounds one general ledger export.
+const maxGLExportRange = 366 * 24 * time.Hour
+
+// decodeGLCode reads a GL code and name; the code is what Xero imports by, the name what
+// QuickBooks matches.
+func decodeGLCode(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	var input struct {
+		GLCode string `json:"gl_code"`
+		GLName string `json:"gl_name"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return "", "", false
+	}
+	code, name := strings.TrimSpace(input.GLCode), strings.TrimSpace(input.GLName)
+	if code == "" || len(code) > 32 || name == "" || len(name) > maxProfileField {
+		respondError(w, http.StatusBadRequest, "gl_code (at most 32 characters) and gl_name (at most 100) required")
+		return "", "", false
+	}
+	return code, name, true
+}
+
+// ListGLMappings godoc
+// @Summary      List general ledger mappings
+// @Description  Returns every system account with the external GL code it exports to (empty when unmapped), and the customer deposits GL code of each currency. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  GLMappingsResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/gl-mappings [get]
+// @Security     Bearer
+func (h *Handler) ListGLMappings(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.store.ListSystemAccountGLCodes(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list GL account codes")
+		respondError(w, http.StatusInternalServerError, "failed to list GL mappings")
+		return
+	}
+	customers, err := h.store.ListGLCustomerCodes(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list GL customer codes")
+		respondError(w, http.StatusInternalServerError, "failed to list GL mappings")
+		return
+	}
+
+	resp := GLMappingsResponse{
+		Accounts:  make([]GLAccountCodeResponse, 0, len(accounts)),
+		Customers: make([]GLCustomerCodeResponse, 0, len(customers)),
+	}
+	for _, a := range accounts {
+		resp.Accounts = append(resp.Accounts, GLAccountCodeResponse{
+			AccountID: a.ID.String(),
+			Name:      a.Name,
+			Currency:  a.Currency,
+			GLCode:    a.GlCode,
+			GLName:    a.GlName,
+		})
+	}
+	for _, c := range customers {
+		resp.Customers = append(resp.Customers, GLCustomerCodeResponse{Currency: c.Currency, GLCode: c.GlCode, GLName: c.GlName})
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SetGLAccountCode godoc
+// @Summary      Map a system account to a GL code
+// @Description  Sets the external general ledger account a system account (settlement, fee income, interest expense, ...) exports to. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                           true  "System account ID"
+// @Param        body  body      object{gl_code=string,gl_name=string}  true  "GL account"
+// @Success      200   {object}  GLAccountCodeResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/gl-mappings/accounts/{id} [put]
+// @Security     Bearer
+func (h *Handler) SetGLAccountCode(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	code, name, ok := decodeGLCode(w, r)
+	if !ok {
+		return
+	}
+	acc, err := h.store.GetAccount(r.Context(), accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "account not found")
+			return
+		}
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to load account")
+		respondError(w, http.StatusInternalServerError, "failed to set GL code")
+		return
+	}
+	if !acc.IsSystem {
+		respondError(w, http.StatusBadRequest, "customer accounts export under their currency's customer deposits code")
+		return
+	}
+
+	if _, err := h.store.UpsertGLAccountCode(r.Context(), sqlc.UpsertGLAccountCodeParams{
+		AccountID: acc.ID,
+		GlCode:    code,
+		GlName:    name,
+		UpdatedBy: userID,
+	}); err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to set GL account code")
+		respondError(w, http.StatusInternalServerError, "failed to set GL code")
+		return
+	}
+
+	log.Info().Str("account_id", acc.ID.String()).Str("gl_code", code).Str("set_by", userID.String()).Msg("GL account code set")
+	respondJSON(w, http.StatusOK, GLAccountCodeResponse{AccountID: acc.ID.String(), Name: acc.Name, Currency: acc.Currency, GLCode: code, GLName: name})
+}
+
+// SetGLCustomerCode godoc
+// @Summary      Map customer accounts to a GL code
+// @Description  Sets the external general ledger account (typically a customer deposits liability) that all customer accounts in currency export to, netted per day. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        currency  path      string                           true  "Currency"
+// @Param        body      body      object{gl_code=string,gl_name=string}  true  "GL account"
+// @Success      200       {object}  GLCustomerCodeResponse
+// @Failure      400       {object}  ErrorResponse
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
+// @Router       /admin/gl-mappings/customers/{currency} [put]
+// @Security     Bearer
+func (h *Handler) SetGLCustomerCode(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	currency, err := rates.NormalizeCurrency(chi.URLParam(r, "currency"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	code, name, ok := decodeGLCode(w, r)
+	if !ok {
+		return
+	}
+
+	c, err := h.store.UpsertGLCustomerCode(r.Context(), sqlc.UpsertGLCustomerCodeParams{
+		Currency:  currency,
+		GlCode:    code,
+		GlName:    name,
+		UpdatedBy: userID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("currency", currency).Msg("Failed to set GL customer code")
+		respondError(w, http.StatusInternalServerError, "failed to set GL code")
+		return
+	}
+
+	log.Info().Str("currency", currency).Str("gl_code", code).Str("set_by", userID.String()).Msg("GL customer code set")
+	respondJSON(w, http.StatusOK, GLCustomerCodeResponse{Currency: c.Currency, GLCode: c.GlCode, GLName: c.GlName})
+}
+
+// ExportGeneralLedger godoc
+// @Summary      Export general ledger journals
+// @Description  Downloads the ledger's activity in currency between from and to (inclusive UTC dates, default the current month to date, at most a year) as one journal per day: the net movement of each mapped GL account, rounded to cents with any difference on the day's largest line. format xero (default) is Xero's manual journal CSV import; iif is a QuickBooks Desktop general journal file. Fails with 409 and the account names when activity touches an account without a GL code. Admin only.
+// @Tags         admin
+// @Produce      text/csv
+// @Produce      text/plain
+// @Param        currency  query     string  true   "Currency"
+// @Param        from      query     string  false  "First day (YYYY-MM-DD)"
+// @Param        to        query     string  false  "Last day (YYYY-MM-DD)"
+// @Param        format    query     string  false  "xero (default) or iif"
+// @Success      200       {string}  string  "Journal file"
+// @Failure      400       {object}  ErrorResponse
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      409       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
+// @Router       /admin/gl-export [get]
+// @Security     Bearer
+func (h *Handler) ExportGeneralLedger(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Validate the currency, range and format.
+	currency, err := rates.NormalizeCurrency(r.URL.Query().Get("currency"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	from, to, err := reportRange(r, time.Now().UTC())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if to.Sub(from) > maxGLExportRange {
+		respondError(w, http.StatusBadRequest, "export at most one year at a time")
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = glexport.FormatXero
+	}
+	if format != glexport.FormatXero && format != glexport.FormatIIF {
+		respondError(w, http.StatusBadRequest, glexport.ErrUnknownFormat.Error())
+		return
+	}
+
+	// Step 2: Net the day's activity per GL account; every account must be mapped.
+	rows, err := h.store.GLDailyActivity(r.Context(), sqlc.GLDailyActivityParams{Currency: currency, FromTime: from, ToTime: to})
+	if err != nil {
+		log.Error().Err(err).Str("currency", currency).Msg("Failed to load GL activity")
+		respondError(w, http.StatusInternalServerError, "failed to export general ledger")
+		return
+	}
+	journals, err := glexport.Journals(rows, currency)
+	if err != nil {
+		var unmapped *glexport.UnmappedError
+		if errors.As(err, &unmapped) {
+			respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		log.Error().Err(err).Str("currency", currency).Msg("Failed to build GL journals")
+		respondError(w, http.StatusInternalServerError, "failed to export general ledger")
+		return
+	}
+
+	// Step 3: Render before writing headers so a failure can still answer with an error.
+	var buf bytes.Buffer
+	if err := glexport.Write(&buf, format, journals); err != nil {
+		log.Error().Err(err).Str("currency", currency).Msg("Failed to render GL export")
+		respondError(w, http.StatusInternalServerError, "failed to export general ledger")
+		return
+	}
+	filename := fmt.Sprintf("gl-%s-%s-%s.%s", currency, from.Format("20060102"), to.AddDate(0, 0, -1).Format("20060102"), glexport.Extension(format))
+	w.Header().Set("Content-Type", glexport.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportGeneralLedger_ValidatesQuery(t *testing.T) {
+	// Currency, range and format are checked before the ledger is read.
+	h := &Handler{}
+	for _, query := range []string{
+		"",
+		"currency=US",
+		"currency=USD&from=2026-03-01&to=2026-02-01",
+		"currency=USD&from=2024-01-01&to=2026-01-01",
+		"currency=USD&format=ofx",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/gl-export?"+query, nil)
+		rw := httptest.NewRecorder()
+		h.ExportGeneralLedger(rw, req)
+		assert.Equal(t, http.StatusBadRequest, rw.Code, query)
+	}
+}
@@ -2,42 +2,212 @@
 package api
 
 import (
-	"encoding/json"
+	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/jwtauth/v5"
 	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog/log"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/shopspring/decimal"
 
 	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/db"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/flutterwave"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/geo"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/inbound"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/nip"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/notify"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/password"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/paystack"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/pii"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/realtime"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/receipt"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/sanctions"
 	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/stripe"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/treasury"
 	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
 )
 
 // Handler serves HTTP requests backed by the ledger and store layers.
 type Handler struct {
-	ledger *service.LedgerService
-	store  *db.Store
+	ledger      *service.LedgerService
+	store       *db.Store
+	realtime    *realtime.Hub
+	paystack    *paystack.Client
+	flutterwave *flutterwave.Client
+	stripe      *stripe.Client
+	nip         nip.Connector
+	inbound     *inbound.Verifier
+	upgrader    websocket.Upgrader
+	// paystackCallbackURL is where Paystack sends the customer after checkout.
+	paystackCallbackURL string
+	// flutterwaveCallbackURL receives transfer results when not set on the dashboard.
+	flutterwaveCallbackURL string
+	// transferWorkers posts transfers accepted with ?async=true; nil disables async mode.
+	transferWorkers *service.TransferWorkers
+	// rates answers exchange rate lookups; nil disables GET /rates.
+	rates *rates.Service
+	// treasury runs cash pools; nil disables /treasury.
+	treasury *treasury.Service
+	// statementLinkSecret verifies emailed statement download links; empty rejects every link.
+	statementLinkSecret []byte
+	// statementSigner signs served camt.053 statements; nil serves them unsigned.
+	statementSigner *StatementSigner
+	// receiptBrand heads transaction receipts; the zero value uses receipt.DefaultBrand.
+	receiptBrand receipt.Brand
+	// paymentLinkBase prefixes shareable payment request links; empty yields relative links.
+	paymentLinkBase string
+	// bareLists answers paged list endpoints with bare arrays instead of PagedResponse.
+	bareLists bool
+	// pii seals phones and KYC document details; nil stores them in plaintext.
+	pii *pii.Keyring
+	// loginPolicy bounds failed logins per user and per client address.
+	loginPolicy LoginPolicy
+	// securityAlerts emails users about locked logins, new devices and new countries; nil only logs them.
+	securityAlerts notify.EmailSender
+	// passwordPolicy is what passwords users choose must satisfy.
+	passwordPolicy password.Policy
+	// passwords hashes and verifies passwords; nil uses bcrypt.
+	passwords *password.Hasher
+	// signedTransfersAbove is the amount above which transfers must be signed; nil never requires it.
+	signedTransfersAbove *decimal.Decimal
+	// sanctions screens new users and external beneficiaries; nil disables screening.
+	sanctions sanctions.Provider
+	// sanctionsCacheTTL is how long a clean screening of a beneficiary is reused.
+	sanctionsCacheTTL time.Duration
+	// geo locates requests by country; nil disables location tracking.
+	geo geo.Locator
+	// geoAnomalyWindow is how recent activity elsewhere makes a new country anomalous.
+	geoAnomalyWindow time.Duration
+	// currencies are the ISO 4217 codes accounts may be opened in; empty allows every one.
+	currencies []string
+	// nameEnquiryPolicy bounds account number lookups per user and per client address.
+	nameEnquiryPolicy NameEnquiryPolicy
+}
+
+// Option customizes optional Handler collaborators.
+type Option func(*Handler)
+
+// WithRealtime enables live streaming endpoints backed by hub.
+// allowedOrigins restricts which browser origins may open WebSocket connections.
+func WithRealtime(hub *realtime.Hub, allowedOrigins []string) Option {
+	return func(h *Handler) {
+		h.realtime = hub
+		h.upgrader = websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     originChecker(allowedOrigins),
+		}
+	}
+}
+
+// WithPaystack routes deposits through Paystack checkout instead of crediting immediately.
+// The ledger is credited only when the signed charge.success webhook arrives.
+func WithPaystack(client *paystack.Client, callbackURL string) Option {
+	return func(h *Handler) {
+		h.paystack = client
+		h.paystackCallbackURL = callbackURL
+	}
+}
+
+// WithFlutterwave routes withdrawals through Flutterwave bank transfers.
+// Funds are held in clearing until the transfer webhook settles or reverses them.
+func WithFlutterwave(client *flutterwave.Client, callbackURL string) Option {
+	return func(h *Handler) {
+		h.flutterwave = client
+		h.flutterwaveCallbackURL = callbackURL
+	}
+}
+
+// WithStripe enables card funding through Stripe PaymentIntents.
+func WithStripe(client *stripe.Client) Option {
+	return func(h *Handler) {
+		h.stripe = client
+	}
+}
+
+// WithNIP enables name enquiry and outbound transfers to other Nigerian banks.
+func WithNIP(connector nip.Connector) Option {
+	return func(h *Handler) {
+		h.nip = connector
+	}
+}
+
+// WithInboundPayments accepts signed credit notifications for customer virtual accounts.
+func WithInboundPayments(verifier *inbound.Verifier) Option {
+	return func(h *Handler) {
+		h.inbound = verifier
+	}
+}
+
+// WithAsyncTransfers lets POST /transfers?async=true queue transfers for workers and return 202.
+func WithAsyncTransfers(workers *service.TransferWorkers) Option {
+	return func(h *Handler) {
+		h.transferWorkers = workers
+	}
+}
+
+// WithRates enables exchange rate lookups.
+func WithRates(svc *rates.Service) Option {
+	return func(h *Handler) {
+		h.rates = svc
+	}
+}
+
+// WithTreasury enables cash pools.
+func WithTreasury(svc *treasury.Service) Option {
+	return func(h *Handler) {
+		h.treasury = svc
+	}
+}
+
+// WithStatementLinks serves monthly statements from links signed with secret.
+func WithStatementLinks(secret []byte) Option {
+	return func(h *Handler) {
+		h.statementLinkSecret = secret
+	}
+}
+
+// WithPaymentLinks makes payment request links absolute URLs under baseURL.
+func WithPaymentLinks(baseURL string) Option {
+	return func(h *Handler) {
+		h.paymentLinkBase = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithPII seals phones and KYC document details with keyring before they are stored.
+func WithPII(keyring *pii.Keyring) Option {
+	return func(h *Handler) {
+		h.pii = keyring
+	}
 }
 
 // NewHandler constructs a Handler with the required service and persistence dependencies.
-func NewHandler(ledger *service.LedgerService, store *db.Store) *Handler {
-	return &Handler{ledger: ledger, store: store}
+func NewHandler(ledger *service.LedgerService, store *db.Store, opts ...Option) *Handler {
+	h := &Handler{ledger: ledger, store: store, loginPolicy: DefaultLoginPolicy, nameEnquiryPolicy: DefaultNameEnquiryPolicy, passwordPolicy: password.DefaultPolicy()}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // Register godoc
 // @Summary      Register a new user
-// @Description  Creates a new user with email and hashed password, returns user details and JWT token
+// @Description  Creates a new user with email and hashed password (first and last name optional) in the organization named by its slug ("default" when omitted), returns user details and JWT token. Complete the profile with PUT /me/profile. A password that breaks the password policy answers 400 with code "weak_password" and every rule it broke in violations. When sanctions screening is on and the name matches a list entry, the user is created pending review: 202 without a token, and logins answer 403 with code "account_under_review" until compliance staff clear them.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
-// @Param        body    body      object{email=string,password=string}  true  "User registration details"
+// @Param        body    body      object{email=string,password=string,first_name=string,last_name=string,org=string}  true  "User registration details"
 // @Success      201     {object}  RegisterResponse
+// @Success      202     {object}  RegisterResponse
 // @Failure      400     {object}  ErrorResponse
 // @Failure      409     {object}  ErrorResponse
 // @Failure      500     {object}  ErrorResponse
@@ -45,12 +215,13 @@ func NewHandler(ledger *service.LedgerService, store *db.Store) *Handler {
 func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	// Step 1: Decode registration payload.
 	var input struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
+		Email     string `json:"email"`
+		Password  string `json:"password"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+		Org       string `json:"org"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		log.Warn().Err(err).Msg("Failed to decode register request")
-		respondError(w, http.StatusBadRequest, "invalid input")
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
@@ -58,27 +229,80 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, "email and password required")
 		return
 	}
+	if !h.checkPassword(w, r, input.Password, input.Email) {
+		return
+	}
+	input.FirstName, input.LastName = strings.TrimSpace(input.FirstName), strings.TrimSpace(input.LastName)
+	if len(input.FirstName) > maxProfileField || len(input.LastName) > maxProfileField {
+		respondError(w, http.StatusBadRequest, "names must be at most 100 characters")
+		return
+	}
+	org, err := h.organizationBySlug(r.Context(), input.Org)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusBadRequest, "unknown organization")
+			return
+		}
+		log.Error().Err(err).Str("org", input.Org).Msg("Failed to load organization")
+		respondError(w, http.StatusInternalServerError, "failed to register")
+		return
+	}
 
 	// Step 2: Hash password before persisting user credentials.
-	hashed, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	hashed, err := h.passwords.Hash(input.Password)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to hash password")
 		respondError(w, http.StatusInternalServerError, "failed to hash password")
 		return
 	}
 
-	// Step 3: Persist user record and then mint JWT for immediate login.
-	user, err := h.store.CreateUser(r.Context(), sqlc.CreateUserParams{
-		Email:          input.Email,
-		HashedPassword: string(hashed),
+	// Step 3: Screen the user's name when sanctions screening is on; a match is recorded with the user.
+	var screening *sqlc.CreateSanctionsScreeningParams
+	if name := strings.TrimSpace(input.FirstName + " " + input.LastName); h.sanctions != nil && name != "" {
+		s := h.screenName(r.Context(), sqlc.CreateSanctionsScreeningParams{OrgID: org.ID, SubjectType: "user", Name: name})
+		screening = &s
+	}
+
+	// Step 4: Persist user record and then mint JWT for immediate login.
+	var (
+		user    sqlc.CreateUserRow
+		pending bool
+	)
+	err = h.store.ExecTx(r.Context(), func(q *sqlc.Queries) error {
+		var err error
+		user, err = q.CreateUser(r.Context(), sqlc.CreateUserParams{
+			OrgID:          org.ID,
+			Email:          input.Email,
+			HashedPassword: hashed,
+			FirstName:      input.FirstName,
+			LastName:       input.LastName,
+		})
+		if err != nil || screening == nil {
+			return err
+		}
+		screening.SubjectKey = "user:" + user.ID.String()
+		screening.UserID = uuid.NullUUID{UUID: user.ID, Valid: true}
+		if _, err := q.CreateSanctionsScreening(r.Context(), *screening); err != nil {
+			return err
+		}
+		if screening.Status != screeningPending {
+			return nil
+		}
+		pending = true
+		return q.SetUserScreeningStatus(r.Context(), sqlc.SetUserScreeningStatusParams{ID: user.ID, ScreeningStatus: userScreeningPending})
 	})
 	if err != nil {
 		log.Error().Err(err).Str("email", input.Email).Msg("Failed to create user")
 		respondError(w, http.StatusConflict, "user already exists or failed")
 		return
 	}
+	if pending {
+		log.Warn().Str("user_id", user.ID.String()).Msg("User registered pending sanctions review")
+		respondJSON(w, http.StatusAccepted, RegisterResponse{UserID: user.ID.String(), Email: user.Email, ScreeningStatus: userScreeningPending})
+		return
+	}
 
-	token, err := GenerateToken(user.ID)
+	token, err := h.issueToken(r, user.ID, user.OrgID, RoleCustomer)
 	if err != nil {
 		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to generate token")
 		respondError(w, http.StatusInternalServerError, "failed to generate token")
@@ -95,14 +319,16 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 
 // Login godoc
 // @Summary      Login user
-// @Description  Authenticates user with email/password and returns JWT token
+// @Description  Authenticates user with email/password within an organization ("default" when org is omitted) and returns JWT token. A user an admin locked gets 403 with code "account_locked", and one pending sanctions review or confirmed as a match gets 403 with code "account_under_review"; one whose password an admin reset gets 403 with code "password_reset_required" and must choose a new password with POST /password/change. After too many wrong passwords the login is locked for a while (429, code "login_temporarily_locked", with Retry-After), and an address with too many recent failures is turned away (429, code "too_many_attempts").
 // @Tags         auth
 // @Accept       json
 // @Produce      json
-// @Param        body    body      object{email=string,password=string}  true  "User login details"
+// @Param        body    body      object{email=string,password=string,org=string}  true  "User login details"
 // @Success      200     {object}  TokenResponse
 // @Failure      400     {object}  ErrorResponse
 // @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      429     {object}  ErrorResponse
 // @Failure      500     {object}  ErrorResponse
 // @Router       /login [post]
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
@@ -110,46 +336,179 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	var input struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
+		Org      string `json:"org"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		log.Warn().Err(err).Msg("Failed to decode login request")
-		respondError(w, http.StatusBadRequest, "invalid input")
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	// Step 2: Check the password, then the admin's login controls.
+	user, ok := h.checkCredentials(w, r, input.Org, input.Email, input.Password)
+	if !ok {
+		return
+	}
+	if user.PasswordResetRequired {
+		log.Warn().Str("user_id", user.ID.String()).Msg("Login refused - password reset required")
+		respondJSON(w, http.StatusForbidden, ErrorResponse{Error: "password reset required; choose a new password with POST /password/change", Code: "password_reset_required"})
 		return
 	}
 
-	// Step 2: Load user by email and compare bcrypt password hash.
-	user, err := h.store.GetUserByEmail(r.Context(), input.Email)
+	// Step 3: Open a session and return its JWT.
+	token, err := h.issueToken(r, user.ID, user.OrgID, user.Role)
 	if err != nil {
-		log.Warn().Err(err).Str("email", input.Email).Msg("Login failed - user not found")
-		respondError(w, http.StatusUnauthorized, "invalid credentials")
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to generate token")
+		respondError(w, http.StatusInternalServerError, "failed to generate token")
 		return
 	}
 
-	if compareErr := bcrypt.CompareHashAndPassword([]byte(user.HashedPassword), []byte(input.Password)); compareErr != nil {
-		log.Warn().Str("email", input.Email).Msg("Login failed - invalid password")
+	log.Info().Str("user_id", user.ID.String()).Str("email", user.Email).Msg("User logged in successfully")
+	respondJSON(w, http.StatusOK, TokenResponse{Token: token})
+}
+
+// checkCredentials loads the user by organization and email and compares the password hash,
+// answering 401 for any mismatch and 403 for a locked user. The lock is only revealed to someone
+// who knows the password. Addresses and users with too many recent failures get 429 before any
+// password is compared, and every attempt is recorded. A hash made with an outdated algorithm or
+// parameters is replaced on success.
+func (h *Handler) checkCredentials(w http.ResponseWriter, r *http.Request, orgSlug, email, pw string) (sqlc.User, bool) {
+	attempt := sqlc.CreateLoginAttemptParams{Email: email, IpAddress: clientIP(r), UserAgent: userAgent(r), DeviceID: deviceFingerprint(r)}
+	if h.loginThrottled(w, r, attempt) {
+		return sqlc.User{}, false
+	}
+
+	org, err := h.organizationBySlug(r.Context(), orgSlug)
+	if err != nil {
+		log.Warn().Err(err).Str("org", orgSlug).Msg("Login failed - organization not found")
+		h.recordLoginAttempt(r.Context(), attempt, loginInvalidCredentials)
+		respondError(w, http.StatusUnauthorized, "invalid credentials")
+		return sqlc.User{}, false
+	}
+	attempt.OrgID = uuid.NullUUID{UUID: org.ID, Valid: true}
+	user, err := h.store.GetUserByEmail(r.Context(), sqlc.GetUserByEmailParams{OrgID: org.ID, Email: email})
+	if err != nil {
+		log.Warn().Err(err).Str("email", email).Msg("Login failed - user not found")
+		h.recordLoginAttempt(r.Context(), attempt, loginInvalidCredentials)
+		respondError(w, http.StatusUnauthorized, "invalid credentials")
+		return sqlc.User{}, false
+	}
+	attempt.UserID = uuid.NullUUID{UUID: user.ID, Valid: true}
+
+	// While locked out, passwords are not even compared, so guessing cannot continue.
+	if until := user.LoginLockedUntil; until.Valid && until.Time.After(time.Now()) {
+		log.Warn().Str("user_id", user.ID.String()).Str("ip_address", attempt.IpAddress).Msg("Login refused - temporarily locked")
+		h.recordLoginAttempt(r.Context(), attempt, loginTemporarilyLocked)
+		respondTooManyAttempts(w, time.Until(until.Time), "too many failed logins; try again later", "login_temporarily_locked")
+		return sqlc.User{}, false
+	}
+	rehash, compareErr := h.passwords.Verify(user.HashedPassword, pw)
+	if compareErr != nil {
+		if !errors.Is(compareErr, password.ErrMismatch) {
+			log.Error().Err(compareErr).Str("user_id", user.ID.String()).Msg("Stored password hash is unreadable")
+		}
+		log.Warn().Str("email", email).Msg("Login failed - invalid password")
+		h.recordWrongPassword(r.Context(), user, attempt)
 		respondError(w, http.StatusUnauthorized, "invalid credentials")
+		return sqlc.User{}, false
+	}
+	if user.LockedAt.Valid {
+		log.Warn().Str("user_id", user.ID.String()).Msg("Login refused - user locked")
+		h.recordLoginAttempt(r.Context(), attempt, loginAccountLocked)
+		respondJSON(w, http.StatusForbidden, ErrorResponse{Error: "login locked; contact support", Code: "account_locked"})
+		return sqlc.User{}, false
+	}
+	if user.ScreeningStatus != userScreeningClear {
+		log.Warn().Str("user_id", user.ID.String()).Str("screening_status", user.ScreeningStatus).Msg("Login refused - sanctions screening")
+		h.recordLoginAttempt(r.Context(), attempt, loginUnderReview)
+		respondJSON(w, http.StatusForbidden, ErrorResponse{Error: "account under review; contact support", Code: "account_under_review"})
+		return sqlc.User{}, false
+	}
+
+	if rehash {
+		h.rehashPassword(r.Context(), user, pw)
+	}
+	if user.FailedLogins > 0 || user.LoginLockedUntil.Valid {
+		if err := h.store.ResetFailedLogins(r.Context(), user.ID); err != nil {
+			log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to reset failed logins")
+		}
+	}
+	attempt.NewDevice = h.rememberDevice(r.Context(), user, attempt)
+	h.recordLoginAttempt(r.Context(), attempt, "")
+	return user, true
+}
+
+// ChangePassword godoc
+// @Summary      Change password
+// @Description  Replaces the caller's password after checking the current one, logs out every existing session and returns a JWT for a new one. This is how a user whose password an admin reset logs in again, using the temporary password as current_password. Locked users cannot change their password.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body    body      object{email=string,org=string,current_password=string,new_password=string}  true  "Credentials and new password"
+// @Success      200     {object}  TokenResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      429     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /password/change [post]
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Decode payload.
+	var input struct {
+		Email           string `json:"email"`
+		Org             string `json:"org"`
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	if input.NewPassword == "" || input.NewPassword == input.CurrentPassword {
+		respondError(w, http.StatusBadRequest, "new_password required and must differ from current_password")
+		return
+	}
+	if !h.checkPassword(w, r, input.NewPassword, input.Email) {
+		return
+	}
+
+	// Step 2: Authenticate with the current password.
+	user, ok := h.checkCredentials(w, r, input.Org, input.Email, input.CurrentPassword)
+	if !ok {
 		return
 	}
 
-	// Step 3: Return a fresh JWT on successful authentication.
-	token, err := GenerateToken(user.ID)
+	// Step 3: Store the new hash, clearing any admin reset, and log the user in.
+	hashed, err := h.passwords.Hash(input.NewPassword)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to hash password")
+		respondError(w, http.StatusInternalServerError, "failed to hash password")
+		return
+	}
+	if _, err := h.store.SetUserPassword(r.Context(), sqlc.SetUserPasswordParams{
+		ID:             user.ID,
+		HashedPassword: hashed,
+	}); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to change password")
+		respondError(w, http.StatusInternalServerError, "failed to change password")
+		return
+	}
+	h.revokeUserSessions(r.Context(), user.ID, "password changed")
+	token, err := h.issueToken(r, user.ID, user.OrgID, user.Role)
 	if err != nil {
 		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to generate token")
 		respondError(w, http.StatusInternalServerError, "failed to generate token")
 		return
 	}
 
-	log.Info().Str("user_id", user.ID.String()).Str("email", user.Email).Msg("User logged in successfully")
+	log.Info().Str("user_id", user.ID.String()).Bool("after_reset", user.PasswordResetRequired).Msg("Password changed")
 	respondJSON(w, http.StatusOK, TokenResponse{Token: token})
 }
 
 // CreateAccount godoc
 // @Summary      Create a new account
-// @Description  Creates a new user-owned account with name and currency
+// @Description  Creates a new user-owned account with name and currency. currency is one of GET /currencies and defaults to USD, or to the deployment's first configured currency where USD is not offered. product is one of GET /products and defaults to "current"; it sets the account's interest, fees, limits and overdraft.
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
-// @Param        body    body      object{name=string}  true  "Account details"
+// @Param        body    body      object{name=string,currency=string,product=string}  true  "Account details"
 // @Success      201     {object}  AccountResponse
 // @Failure      400     {object}  ErrorResponse
 // @Failure      401     {object}  ErrorResponse
@@ -176,22 +535,72 @@ func (h *Handler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusUnauthorized, "invalid token")
 		return
 	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
 
-	// Step 2: Decode request payload.
+	// Step 2: Decode request payload; currency and product are optional and must be supported.
 	var input struct {
-		Name string `json:"name"`
+		Name     string `json:"name"`
+		Currency string `json:"currency"`
+		Product  string `json:"product"`
 	}
-	if decodeErr := json.NewDecoder(r.Body).Decode(&input); decodeErr != nil || input.Name == "" {
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	if input.Name == "" {
 		respondError(w, http.StatusBadRequest, "name required")
 		return
 	}
+	currency := h.defaultCurrency()
+	if strings.TrimSpace(input.Currency) != "" {
+		if currency, err = rates.NormalizeCurrency(input.Currency); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if !h.currencySupported(currency) {
+		respondError(w, http.StatusBadRequest, "currency not supported; GET /currencies lists the supported ones")
+		return
+	}
+	product := strings.TrimSpace(input.Product)
+	if product == "" {
+		product = service.DefaultProduct
+	}
+	overdraft, err := h.productOverdraft(r.Context(), product, currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusBadRequest, "unknown product")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("product", product).Msg("Failed to look up account product")
+		respondError(w, http.StatusInternalServerError, "failed to create account")
+		return
+	}
 
-	// Step 3: Create a user-owned account in default currency.
-	acc, err := h.store.CreateAccount(r.Context(), sqlc.CreateAccountParams{
-		OwnerID:  uuid.NullUUID{UUID: userID, Valid: true},
-		Name:     input.Name,
-		Currency: "USD",
-		IsSystem: false,
+	// Step 3: Create a user-owned account with its primary owner row.
+	var acc sqlc.Account
+	err = h.store.ExecTx(r.Context(), func(q *sqlc.Queries) error {
+		var createErr error
+		acc, createErr = q.CreateAccount(r.Context(), sqlc.CreateAccountParams{
+			OwnerID:        uuid.NullUUID{UUID: userID, Valid: true},
+			Name:           input.Name,
+			Currency:       currency,
+			IsSystem:       false,
+			OrgID:          uuid.NullUUID{UUID: orgID, Valid: true},
+			Product:        product,
+			OverdraftLimit: overdraft,
+		})
+		if createErr != nil {
+			return createErr
+		}
+		_, createErr = q.AddAccountOwner(r.Context(), sqlc.AddAccountOwnerParams{
+			AccountID: acc.ID,
+			UserID:    userID,
+			Role:      AccountRoleOwner,
+		})
+		return createErr
 	})
 	if err != nil {
 		log.Error().Err(err).Str("user_id", userID.String()).Str("name", input.Name).Msg("Failed to create account")
@@ -200,15 +609,22 @@ func (h *Handler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Info().Str("account_id", acc.ID.String()).Str("user_id", userID.String()).Str("name", acc.Name).Msg("Account created")
-	respondJSON(w, http.StatusCreated, toAccountResponse(acc))
+	respondJSON(w, http.StatusCreated, toAccountResponse(acc, h.productCatalog(r.Context())))
 }
 
 // ListAccounts godoc
 // @Summary      List user accounts
-// @Description  Returns list of accounts owned by authenticated user
+// @Description  Returns the accounts the authenticated user owns or co-owns, including sub-wallets, newest first unless sort says otherwise, wrapped in {data, page}. With bare list responses (or ?envelope=false) the whole list is returned as an array and limit and offset are ignored. Carries an ETag; send it back in If-None-Match to get 304 while nothing changed.
 // @Tags         accounts
 // @Produce      json
-// @Success      200     {array}   AccountResponse
+// @Param        limit     query     int     false  "Limit (default 20, max 100)"
+// @Param        offset    query     int     false  "Offset (default 0)"
+// @Param        sort      query     string  false  "created_at, -created_at (default), name, -name, balance or -balance"
+// @Param        envelope  query     bool    false  "Wrap in {data, page} (default per server)"
+// @Param        If-None-Match  header  string  false  "ETag of the copy the client holds"
+// @Success      200     {object}  PagedResponse{data=[]AccountResponse}
+// @Success      304     "Not modified"
+// @Failure      400     {object}  ErrorResponse
 // @Failure      401     {object}  ErrorResponse
 // @Failure      500     {object}  ErrorResponse
 // @Router       /accounts [get]
@@ -234,29 +650,48 @@ func (h *Handler) ListAccounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Step 2: Fetch only accounts owned by the authenticated user.
-	accounts, err := h.store.ListAccountsByOwner(r.Context(), uuid.NullUUID{UUID: userID, Valid: true})
+	// Step 2: Fetch only accounts the authenticated user owns or co-owns, in the requested order.
+	sort, err := parseSort(r, accountSorts)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	accounts, err := h.store.ListAccountsForUser(r.Context(), sqlc.ListAccountsForUserParams{UserID: userID, Sort: sort})
 	if err != nil {
 		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list accounts")
 		respondError(w, http.StatusInternalServerError, "failed to list accounts")
 		return
 	}
 
+	// Step 3: Page in memory; a user holds few accounts, and old clients expect them all.
+	total := int64(len(accounts))
+	limit, offset := parsePage(r)
+	if h.wantsEnvelope(r) {
+		accounts = accounts[min(offset, len(accounts)):min(offset+limit, len(accounts))]
+	}
+
+	catalog := h.productCatalog(r.Context())
 	response := make([]AccountResponse, len(accounts))
 	for i, acc := range accounts {
-		response[i] = toAccountResponse(acc)
+		response[i] = toAccountResponse(acc, catalog)
 	}
 
-	respondJSON(w, http.StatusOK, response)
+	// Step 4: Answer 304 when the caller's copy of this page is current.
+	if notModified(w, r, accountsETag(accounts, response, fmt.Sprintf("%t|%d|%d|%d", h.wantsEnvelope(r), limit, offset, total))) {
+		return
+	}
+	h.respondPage(w, r, response, limit, offset, total)
 }
 
 // GetAccount godoc
 // @Summary      Get account details
-// @Description  Returns details of a specific account
+// @Description  Returns details of a specific account. Carries an ETag that changes with the balance or any other field; send it back in If-None-Match to get 304 while nothing changed.
 // @Tags         accounts
 // @Produce      json
-// @Param        id   path      string  true  "Account ID"
+// @Param        id             path    string  true   "Account ID"
+// @Param        If-None-Match  header  string  false  "ETag of the copy the client holds"
 // @Success      200  {object}  AccountResponse
+// @Success      304  "Not modified"
 // @Failure      400  {object}  ErrorResponse
 // @Failure      401  {object}  ErrorResponse
 // @Failure      403  {object}  ErrorResponse
@@ -299,24 +734,30 @@ func (h *Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if acc.OwnerID.Valid && acc.OwnerID.UUID != userID {
+	if !h.hasAccountRole(r.Context(), userID, acc, AccountRoleViewer) {
 		log.Warn().Str("account_id", accountID.String()).Str("user_id", userID.String()).Str("owner_id", acc.OwnerID.UUID.String()).Msg("Access denied to account")
 		respondError(w, http.StatusForbidden, "access denied")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, toAccountResponse(acc))
+	// Step 3: Answer 304 when the caller's copy is current.
+	resp := toAccountResponse(acc, h.productCatalog(r.Context()))
+	if notModified(w, r, accountsETag([]sqlc.Account{acc}, []AccountResponse{resp}, "")) {
+		return
+	}
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // Deposit godoc
 // @Summary      Deposit money into account
-// @Description  Deposits fiat amount (mock) with double-entry ledger update
+// @Description  When Paystack is configured, starts a Paystack checkout and returns 202 with the authorization URL; the ledger is credited only after the verified payment webhook. Otherwise deposits immediately (local development mock).
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
 // @Param        id      path      string  true   "Account ID"
 // @Param        body    body      object{amount=string}  true  "Deposit amount (e.g., 1000.0000)"
 // @Success      200     {object}  MessageResponse
+// @Success      202     {object}  DepositInitResponse
 // @Failure      400     {object}  ErrorResponse
 // @Failure      401     {object}  ErrorResponse
 // @Failure      403     {object}  ErrorResponse
@@ -358,28 +799,37 @@ func (h *Handler) Deposit(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusNotFound, "account not found")
 		return
 	}
-	if acc.OwnerID.Valid && acc.OwnerID.UUID != userID {
+	if !h.hasAccountRole(r.Context(), userID, acc, AccountRoleOwner) {
 		log.Warn().Str("account_id", accountID.String()).Str("user_id", userID.String()).Msg("Deposit denied - access forbidden")
 		respondError(w, http.StatusForbidden, "access denied")
 		return
 	}
 
 	// Step 3: Decode amount and invoke service-level double-entry logic.
-	amount, err := decodeAmountFromBody(r)
-	if err != nil {
-		log.Warn().Err(err).Msg("Failed to decode deposit request")
-		respondError(w, http.StatusBadRequest, "invalid input")
+	amount, ok := decodeAmountFromBody(w, r)
+	if !ok {
+		return
+	}
+
+	if h.paystack != nil {
+		h.initiatePaystackDeposit(w, r, userID, acc, amount)
 		return
 	}
 
 	err = h.ledger.Deposit(r.Context(), accountID, amount)
+	if respondScreening(w, err) {
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Str("account_id", accountID.String()).Str("amount", amount).Msg("Deposit failed")
 		code := http.StatusInternalServerError
-		if errors.Is(err, service.ErrInvalidAmount) || errors.Is(err, service.ErrCurrencyMismatch) {
+		switch {
+		case errors.Is(err, service.ErrInvalidAmount) || errors.Is(err, service.ErrAmountPrecision) || errors.Is(err, service.ErrCurrencyMismatch):
 			code = http.StatusBadRequest
+		case errors.Is(err, service.ErrKYCRequired) || errors.Is(err, service.ErrKYCLimitExceeded):
+			code = http.StatusForbidden
 		}
-		respondError(w, code, err.Error())
+		respondLedgerError(w, code, err)
 		return
 	}
 
@@ -389,13 +839,14 @@ func (h *Handler) Deposit(w http.ResponseWriter, r *http.Request) {
 
 // Withdraw godoc
 // @Summary      Withdraw money from account
-// @Description  Withdraws fiat amount (mock) with double-entry ledger update
+// @Description  When Flutterwave is configured, holds the amount and queues a bank payout (bank_code and account_number required); the hold is settled or reversed by the payout webhook. Otherwise withdraws immediately (local development mock). A withdrawal scored risky for fraud answers 401 with code step_up_required until retried signed with a key registered on the account; with SIGNED_TRANSFER_THRESHOLD set, withdrawals above it must be signed like POST /transfers.
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
 // @Param        id      path      string  true   "Account ID"
-// @Param        body    body      object{amount=string}  true  "Withdraw amount (e.g., 500.0000)"
+// @Param        body    body      object{amount=string,bank_code=string,account_number=string,narration=string}  true  "Withdraw amount (e.g., 500.0000) and destination bank account"
 // @Success      200     {object}  MessageResponse
+// @Success      202     {object}  PayoutResponse
 // @Failure      400     {object}  ErrorResponse
 // @Failure      401     {object}  ErrorResponse
 // @Failure      403     {object}  ErrorResponse
@@ -437,28 +888,38 @@ func (h *Handler) Withdraw(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusNotFound, "account not found")
 		return
 	}
-	if acc.OwnerID.Valid && acc.OwnerID.UUID != userID {
+	if !h.hasAccountRole(r.Context(), userID, acc, AccountRoleOwner) {
 		log.Warn().Str("account_id", accountID.String()).Str("user_id", userID.String()).Msg("Withdrawal denied - access forbidden")
 		respondError(w, http.StatusForbidden, "access denied")
 		return
 	}
+	// A request signed with one of the account's keys steps up withdrawals challenged for fraud risk,
+	// and is required above the signing threshold.
+	body := h.captureSignedBody(r)
+
+	if h.flutterwave != nil {
+		h.initiateFlutterwavePayout(w, r, userID, accountID, body)
+		return
+	}
 
 	// Step 3: Decode amount and delegate business checks to service layer.
-	amount, err := decodeAmountFromBody(r)
-	if err != nil {
-		log.Warn().Err(err).Msg("Failed to decode withdrawal request")
-		respondError(w, http.StatusBadRequest, "invalid input")
+	amount, ok := decodeAmountFromBody(w, r)
+	if !ok {
 		return
 	}
+	ctx, ok := h.checkRequestSignature(w, r, body, accountID, amount)
+	if !ok {
+		return
+	}
+	r = r.WithContext(ctx)
 
 	err = h.ledger.Withdraw(r.Context(), accountID, amount)
+	if respondScreening(w, err) {
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Str("account_id", accountID.String()).Str("amount", amount).Msg("Withdrawal failed")
-		code := http.StatusInternalServerError
-		if errors.Is(err, service.ErrInsufficientFunds) || errors.Is(err, service.ErrInvalidAmount) || errors.Is(err, service.ErrCurrencyMismatch) {
-			code = http.StatusBadRequest
-		}
-		respondError(w, code, err.Error())
+		respondLedgerError(w, outboundDebitStatus(err), err)
 		return
 	}
 
@@ -468,16 +929,19 @@ func (h *Handler) Withdraw(w http.ResponseWriter, r *http.Request) {
 
 // Transfer godoc
 // @Summary      Transfer money between accounts
-// @Description  Transfers funds between accounts with atomic double-entry updates. The amount field accepts JSON number or string. from_id/to_id are preferred; from_account_id/to_account_id are supported as legacy aliases.
+// @Description  Transfers funds between accounts of the same organization with atomic double-entry updates. The amount field accepts JSON number or string. from_id/to_id are preferred; from_account_id/to_account_id are supported as legacy aliases. Instead of to_id, to_email or to_phone pays that user's default account; confirm the recipient first with POST /recipients/lookup. A transfer scored risky for fraud answers 401 with code step_up_required until retried signed with a key registered on the account.
 // @Tags         accounts
 // @Accept       json
 // @Produce      json
-// @Param        body    body      object{from_id=string,to_id=string,amount=string}  true  "Transfer details"
+// @Param        body    body      object{from_id=string,to_id=string,to_email=string,to_phone=string,amount=string}  true  "Transfer details"
+// @Param        async   query     bool      false  "Queue the transfer and return 202 with its transaction ID; poll GET /transfers/{id}"
 // @Success      200     {object}  MessageResponse
+// @Success      202     {object}  TransferJobResponse
 // @Failure      400     {object}  ErrorResponse
 // @Failure      401     {object}  ErrorResponse
 // @Failure      403     {object}  ErrorResponse
 // @Failure      404     {object}  ErrorResponse
+// @Failure      503     {object}  ErrorResponse
 // @Router       /transfers [post]
 // @Security     Bearer
 func (h *Handler) Transfer(w http.ResponseWriter, r *http.Request) {
@@ -502,18 +966,17 @@ func (h *Handler) Transfer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Step 2: Decode payload with support for current and legacy field names.
+	body := h.captureSignedBody(r)
 	var input struct {
 		Amount        interface{} `json:"amount"`
 		FromID        string      `json:"from_id"`
 		ToID          string      `json:"to_id"`
 		FromAccountID string      `json:"from_account_id"`
 		ToAccountID   string      `json:"to_account_id"`
+		ToEmail       string      `json:"to_email"`
+		ToPhone       string      `json:"to_phone"`
 	}
-	dec := json.NewDecoder(r.Body)
-	dec.UseNumber()
-	if decodeErr := dec.Decode(&input); decodeErr != nil {
-		log.Warn().Err(decodeErr).Msg("Failed to decode transfer request")
-		respondError(w, http.StatusBadRequest, "invalid input")
+	if !decodeJSON(w, r, &input) {
 		return
 	}
 
@@ -526,6 +989,19 @@ func (h *Handler) Transfer(w http.ResponseWriter, r *http.Request) {
 	if toIDRaw == "" {
 		toIDRaw = strings.TrimSpace(input.ToAccountID)
 	}
+	toEmail, toPhone := strings.TrimSpace(input.ToEmail), strings.TrimSpace(input.ToPhone)
+	if toEmail != "" || toPhone != "" {
+		if toIDRaw != "" {
+			respondError(w, http.StatusBadRequest, "give to_id, to_email or to_phone, not several")
+			return
+		}
+		// Email and phone address the recipient's default account.
+		toID, ok := h.recipientAccount(w, r, toEmail, toPhone)
+		if !ok {
+			return
+		}
+		toIDRaw = toID.String()
+	}
 
 	log.Info().Str("from_id", fromIDRaw).Str("to_id", toIDRaw).Interface("amount", input.Amount).Msg("Transfer request received")
 
@@ -574,17 +1050,41 @@ func (h *Handler) Transfer(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusNotFound, "from account not found")
 		return
 	}
-	if fromAcc.OwnerID.Valid && fromAcc.OwnerID.UUID != userID {
+	if !h.hasAccountRole(r.Context(), userID, fromAcc, AccountRoleOwner) {
 		log.Warn().Str("from_id", fromID.String()).Str("user_id", userID.String()).Msg("Transfer denied - access forbidden")
 		respondError(w, http.StatusForbidden, "access denied")
 		return
 	}
+	ctx, ok := h.checkRequestSignature(w, r, body, fromID, amount)
+	if !ok {
+		return
+	}
+	r = r.WithContext(ctx)
+
+	// Async mode returns as soon as the job is durable; a worker posts it.
+	if r.URL.Query().Get("async") == "true" {
+		h.enqueueTransfer(w, r, userID, fromID, toID, amount)
+		return
+	}
 
 	// Step 5: Run transfer through service layer (atomic double-entry write).
 	err = h.ledger.Transfer(r.Context(), fromID, toID, amount)
+	if respondScreening(w, err) {
+		return
+	}
+	if errors.Is(err, service.ErrCrossOrgTransfer) {
+		// Accounts of other tenants are indistinguishable from missing ones.
+		log.Warn().Str("from_id", fromID.String()).Str("to_id", toID.String()).Msg("Transfer denied - destination in another organization")
+		respondError(w, http.StatusNotFound, "to account not found")
+		return
+	}
+	if errors.Is(err, service.ErrKYCRequired) || errors.Is(err, service.ErrKYCLimitExceeded) {
+		respondLedgerError(w, http.StatusForbidden, err)
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Str("from_id", fromID.String()).Str("to_id", toID.String()).Str("amount", amount).Msg("Transfer failed")
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondLedgerError(w, http.StatusBadRequest, err)
 		return
 	}
 
@@ -594,13 +1094,15 @@ func (h *Handler) Transfer(w http.ResponseWriter, r *http.Request) {
 
 // GetEntries godoc
 // @Summary      Get account entries
-// @Description  Returns list of ledger entries for an account (immutable history)
+// @Description  Returns a page of the account's ledger entries (immutable history), newest first unless sort says otherwise, wrapped in {data, page}, statement style: each with the account's balance_after it posted, the counterparty on the other side of the transaction and, for the account's primary owner, the category they file it under. amount sorts by the entry's debit or credit. Months archived and pruned from the database are read back from the archive, with the counterparty and category they had when archived
 // @Tags         accounts
 // @Produce      json
-// @Param        id      path      string  true   "Account ID"
-// @Param        limit   query     int     false  "Limit (default 20)"
-// @Param        offset  query     int     false  "Offset (default 0)"
-// @Success      200     {array}   EntryResponse
+// @Param        id        path      string  true   "Account ID"
+// @Param        limit     query     int     false  "Limit (default 20, max 100)"
+// @Param        offset    query     int     false  "Offset (default 0)"
+// @Param        sort      query     string  false  "created_at, -created_at (default), amount or -amount"
+// @Param        envelope  query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200     {object}  PagedResponse{data=[]HistoryEntryResponse}
 // @Failure      400     {object}  ErrorResponse
 // @Failure      401     {object}  ErrorResponse
 // @Failure      403     {object}  ErrorResponse
@@ -642,59 +1144,46 @@ func (h *Handler) GetEntries(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusNotFound, "account not found")
 		return
 	}
-	if acc.OwnerID.Valid && acc.OwnerID.UUID != userID {
+	if !h.hasAccountRole(r.Context(), userID, acc, AccountRoleViewer) {
 		log.Warn().Str("account_id", accountID.String()).Str("user_id", userID.String()).Msg("Get entries denied - access forbidden")
 		respondError(w, http.StatusForbidden, "access denied")
 		return
 	}
 
-	// Step 3: Parse pagination with safe defaults and caps.
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
-
-	limit := 20
-	offset := 0
-
-	if v, parseErr := strconv.Atoi(limitStr); parseErr == nil && v > 0 {
-		limit = min(v, 100)
-	}
-	if v, parseErr := strconv.Atoi(offsetStr); parseErr == nil && v >= 0 {
-		offset = v
-	}
-
-	// Validate conversions to int32 to prevent overflow
-	if limit > 2147483647 || offset > 2147483647 {
-		respondError(w, http.StatusBadRequest, "limit or offset too large")
+	// Step 3: Parse pagination and sort with safe defaults and caps.
+	limit, offset := parsePage(r)
+	sort, err := parseSort(r, entrySorts)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Step 4: Fetch immutable ledger entries for the account.
-	entries, err := h.store.ListEntriesByAccount(r.Context(), sqlc.ListEntriesByAccountParams{
-		AccountID: accountID,
-		Limit:     int32(limit),
-		Offset:    int32(offset),
-	})
+	// Step 4: Fetch the account's history from the read model the posting path maintains,
+	// merged with any months archived and pruned from it.
+	entries, total, err := h.ledger.AccountHistory(r.Context(), accountID, sort, limit, offset)
 	if err != nil {
 		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to fetch entries")
 		respondError(w, http.StatusInternalServerError, "failed to fetch entries")
 		return
 	}
 
-	response := make([]EntryResponse, len(entries))
+	primary := acc.OwnerID.Valid && acc.OwnerID.UUID == userID
+	response := make([]HistoryEntryResponse, len(entries))
 	for i, entry := range entries {
-		response[i] = toEntryResponse(entry)
+		response[i] = toHistoryEntryResponse(entry, acc.Currency, primary)
 	}
 
-	respondJSON(w, http.StatusOK, response)
+	h.respondPage(w, r, response, limit, offset, total)
 }
 
 // GetTransactions godoc
 // @Summary      Get transaction details
-// @Description  Returns both entries (debit and credit) for a complete transaction view
+// @Description  Returns both entries (debit and credit) for a complete transaction view. With annotations=true, returns {entries, annotations} with the caller's notes on it instead.
 // @Tags         accounts
 // @Produce      json
-// @Param        id   path      string  true  "Transaction ID"
-// @Success      200  {array}   EntryResponse
+// @Param        id           path      string  true   "Transaction ID"
+// @Param        annotations  query     bool    false  "Include the caller's notes"
+// @Success      200          {array}   EntryResponse
 // @Failure      400  {object}  ErrorResponse
 // @Failure      401  {object}  ErrorResponse
 // @Failure      403  {object}  ErrorResponse
@@ -746,19 +1235,11 @@ func (h *Handler) GetTransactions(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Step 3: Authorize if user owns at least one account in this transaction.
-	authorized := false
-	for _, entry := range entries {
-		acc, err := h.store.GetAccount(r.Context(), entry.AccountID)
-		if err != nil {
-			log.Error().Err(err).Str("account_id", entry.AccountID.String()).Msg("Failed to authorize transaction")
-			respondError(w, http.StatusInternalServerError, "failed to authorize transaction")
-			return
-		}
-
-		if acc.OwnerID.Valid && acc.OwnerID.UUID == userID {
-			authorized = true
-			break
-		}
+	authorized, err := h.transactionVisible(r.Context(), userID, entries)
+	if err != nil {
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to authorize transaction")
+		respondError(w, http.StatusInternalServerError, "failed to authorize transaction")
+		return
 	}
 
 	if !authorized {
@@ -767,9 +1248,20 @@ func (h *Handler) GetTransactions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	currencyOf := h.entryCurrencies(r.Context(), entries)
 	response := make([]EntryResponse, len(entries))
 	for i, entry := range entries {
-		response[i] = toEntryResponse(entry)
+		response[i] = toEntryResponse(entry, currencyOf[entry.AccountID])
+	}
+
+	// Step 4: Notes come alongside the entries only when asked for, keeping the plain list as is.
+	if withNotes, _ := strconv.ParseBool(r.URL.Query().Get("annotations")); withNotes {
+		annotations, ok := h.transactionAnnotations(w, r, userID, transactionID)
+		if !ok {
+			return
+		}
+		respondJSON(w, http.StatusOK, TransactionDetailResponse{Entries: response, Annotations: annotations})
+		return
 	}
 
 	respondJSON(w, http.StatusOK, response)
@@ -823,7 +1315,7 @@ func (h *Handler) ReconcileAccount(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusNotFound, "account not found")
 		return
 	}
-	if acc.OwnerID.Valid && acc.OwnerID.UUID != userID {
+	if !h.hasAccountRole(r.Context(), userID, acc, AccountRoleViewer) {
 		log.Warn().Str("account_id", accountID.String()).Str("user_id", userID.String()).Msg("Reconcile denied - access forbidden")
 		respondError(w, http.StatusForbidden, "access denied")
 		return
@@ -843,3 +1335,29 @@ func (h *Handler) ReconcileAccount(w http.ResponseWriter, r *http.Request) {
 		Message: "Account reconciled successfully",
 	})
 }
+
+// ownedAccount loads accountID and verifies the caller may act on it as an owner.
+// It writes a 404/403 response and returns false when access is not allowed.
+func (h *Handler) ownedAccount(w http.ResponseWriter, r *http.Request, userID, accountID uuid.UUID) (sqlc.Account, bool) {
+	return h.accountWithRole(w, r, userID, accountID, AccountRoleOwner)
+}
+
+// visibleAccount is ownedAccount for read-only access, which viewers also have.
+func (h *Handler) visibleAccount(w http.ResponseWriter, r *http.Request, userID, accountID uuid.UUID) (sqlc.Account, bool) {
+	return h.accountWithRole(w, r, userID, accountID, AccountRoleViewer)
+}
+
+func (h *Handler) accountWithRole(w http.ResponseWriter, r *http.Request, userID, accountID uuid.UUID, role string) (sqlc.Account, bool) {
+	acc, err := h.store.GetAccount(r.Context(), accountID)
+	if err != nil {
+		log.Warn().Err(err).Str("account_id", accountID.String()).Msg("Account not found")
+		respondError(w, http.StatusNotFound, "account not found")
+		return sqlc.Account{}, false
+	}
+	if !h.hasAccountRole(r.Context(), userID, acc, role) {
+		log.Warn().Str("account_id", accountID.String()).Str("user_id", userID.String()).Msg("Access denied to account")
+		respondError(w, http.StatusForbidden, "access denied")
+		return sqlc.Account{}, false
+	}
+	return acc, true
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/inbound"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// PaymentsWebhook godoc
+// @Summary      Inbound payment webhook
+// @Description  Receives credit notifications from external providers. The X-Payment-Signature header (t=<unix>,v1=<HMAC-SHA256 of "t.body">) is verified with a 5 minute tolerance, and each provider event ID is posted at most once. Credits to a known virtual account are deposited to it; anything else is parked in the Unapplied Receipts suspense account.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        X-Payment-Signature  header    string  true  "Webhook signature"
+// @Success      200                  {object}  InboundPaymentResponse
+// @Failure      400                  {object}  ErrorResponse
+// @Failure      401                  {object}  ErrorResponse
+// @Failure      404                  {object}  ErrorResponse
+// @Failure      422                  {object}  ErrorResponse
+// @Failure      500                  {object}  ErrorResponse
+// @Router       /webhooks/payments [post]
+func (h *Handler) PaymentsWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.inbound == nil {
+		respondError(w, http.StatusNotFound, "inbound payments are not configured")
+		return
+	}
+
+	// Step 1: Authenticate the sender and reject stale (replayed) deliveries.
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	if err := h.inbound.Verify(body, r.Header.Get(inbound.SignatureHeader), inbound.DefaultTolerance); err != nil {
+		log.Warn().Err(err).Str("remote_addr", r.RemoteAddr).Msg("Inbound payment webhook rejected")
+		respondError(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+
+	n, err := inbound.Parse(body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Step 2: Post once per event; the event ID makes redelivery a no-op.
+	payment, replay, err := h.ledger.ReceiveInboundPayment(r.Context(), service.InboundCredit{
+		Provider:             n.Provider,
+		EventID:              n.EventID,
+		VirtualAccountNumber: n.VirtualAccountNumber,
+		Amount:               n.Amount.String(),
+		Currency:             n.Currency,
+		PayerName:            n.PayerName,
+		PayerReference:       n.PayerReference,
+	})
+	switch {
+	case errors.Is(err, service.ErrCurrencyMismatch):
+		// Nothing here can hold this currency; keep the provider retrying until an operator steps in.
+		log.Error().Str("provider", n.Provider).Str("event_id", n.EventID).Str("currency", n.Currency).Msg("Inbound payment in unsupported currency")
+		respondError(w, http.StatusUnprocessableEntity, "unsupported currency")
+		return
+	case err != nil:
+		log.Error().Err(err).Str("provider", n.Provider).Str("event_id", n.EventID).Msg("Failed to post inbound payment")
+		respondError(w, http.StatusInternalServerError, "failed to post payment")
+		return
+	}
+
+	if replay {
+		log.Info().Str("provider", n.Provider).Str("event_id", n.EventID).Msg("Inbound payment replay ignored")
+	}
+	respondJSON(w, http.StatusOK, toInboundPaymentResponse(payment))
+}
+
+// ListInboundPayments godoc
+// @Summary      List inbound payments
+// @Description  Returns provider-notified credits by status, oldest first. The default status "unmatched" is the suspense queue awaiting resolution. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        status  query     string  false  "credited, unmatched (default) or resolved"
+// @Param        limit   query     int     false  "Limit (default 20)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   InboundPaymentResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /admin/inbound-payments [get]
+// @Security     Bearer
+func (h *Handler) ListInboundPayments(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.InboundUnmatched
+	case service.InboundCredited, service.InboundUnmatched, service.InboundResolved:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be credited, unmatched or resolved")
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	// Step 2: Fetch the page.
+	rows, err := h.store.ListInboundPaymentsByStatus(r.Context(), sqlc.ListInboundPaymentsByStatusParams{
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to list inbound payments")
+		respondError(w, http.StatusInternalServerError, "failed to list inbound payments")
+		return
+	}
+
+	resp := make([]InboundPaymentResponse, 0, len(rows))
+	for _, p := range rows {
+		resp = append(resp, toInboundPaymentResponse(p))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ResolveInboundPayment godoc
+// @Summary      Resolve an unmatched inbound payment
+// @Description  Moves a suspense credit from Unapplied Receipts to the customer account it belongs to. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                    true  "Inbound payment ID"
+// @Param        body  body      object{account_id=string}  true  "Customer account to credit"
+// @Success      200   {object}  InboundPaymentResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/inbound-payments/{id}/resolve [post]
+// @Security     Bearer
+func (h *Handler) ResolveInboundPayment(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and parse input.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	paymentID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid inbound payment ID")
+		return
+	}
+	var input struct {
+		AccountID string `json:"account_id"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	accountID, err := uuid.Parse(input.AccountID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	// Step 2: Post suspense -> customer exactly once.
+	payment, err := h.ledger.ResolveInboundPayment(r.Context(), paymentID, accountID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInboundPaymentNotFound), errors.Is(err, service.ErrAccountNotFound):
+			respondError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrInboundPaymentNotUnmatched):
+			respondError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrCurrencyMismatch):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			log.Error().Err(err).Str("inbound_payment_id", paymentID.String()).Msg("Failed to resolve inbound payment")
+			respondError(w, http.StatusInternalServerError, "failed to resolve inbound payment")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toInboundPaymentResponse(payment))
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/jobs"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// ListJobs godoc
+// @Summary      List background jobs
+// @Description  Returns background jobs by status, most recent first. The default status "failed" lists jobs that exhausted their retries. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        status  query     string  false  "failed (default), pending, running or succeeded"
+// @Param        limit   query     int     false  "Limit (default 20)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   JobResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /admin/jobs [get]
+// @Security     Bearer
+func (h *Handler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = jobs.StatusFailed
+	case jobs.StatusPending, jobs.StatusRunning, jobs.StatusSucceeded, jobs.StatusFailed:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be pending, running, succeeded or failed")
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	// Step 2: Fetch the page.
+	rows, err := h.store.ListJobsByStatus(r.Context(), sqlc.ListJobsByStatusParams{
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to list jobs")
+		respondError(w, http.StatusInternalServerError, "failed to list jobs")
+		return
+	}
+
+	resp := make([]JobResponse, 0, len(rows))
+	for _, job := range rows {
+		resp = append(resp, toJobResponse(job))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
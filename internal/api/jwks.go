@@ -0,0 +1,258 @@
+package api
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jws"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+)
+
+// minRSABits is the smallest RSA modulus accepted for signing or verifying tokens.
+const minRSABits = 2048
+
+// tokenKeys signs new tokens with one key and accepts tokens signed by any key it holds, so tokens
+// signed before a key rotation stay valid until they expire.
+type tokenKeys struct {
+	signer jwk.Key // the active private key, or the shared secret in HS256 mode
+	verify jwk.Set // every key a token may be signed with
+	public jwk.Set // the public asymmetric keys, served as the JWKS
+}
+
+// InitTokenKeys signs tokens with signingKeyPEM, an RSA (RS256) or Ed25519 (EdDSA) private key in
+// PEM form, and publishes its public half at /.well-known/jwks.json under its RFC 7638 thumbprint.
+// verificationKeysPEM holds further PEM keys that are accepted and published but never sign: the
+// previous key after a rotation, or the next one ahead of it. A non-empty legacySecret keeps HS256
+// tokens issued with JWT_SECRET valid while clients move over; it is never published.
+func InitTokenKeys(signingKeyPEM, verificationKeysPEM, legacySecret string) error {
+	keys, err := parsePEMKeys(signingKeyPEM)
+	if err != nil {
+		return fmt.Errorf("JWT_SIGNING_KEY: %w", err)
+	}
+	if len(keys) != 1 {
+		return errors.New("JWT_SIGNING_KEY must hold exactly one key")
+	}
+	signer, err := signingJWK(keys[0])
+	if err != nil {
+		return fmt.Errorf("JWT_SIGNING_KEY: %w", err)
+	}
+
+	k := &tokenKeys{signer: signer, verify: jwk.NewSet(), public: jwk.NewSet()}
+	if err := k.addPublic(signer); err != nil {
+		return err
+	}
+	others, err := parsePEMKeys(verificationKeysPEM)
+	if err != nil {
+		return fmt.Errorf("JWT_VERIFICATION_KEYS: %w", err)
+	}
+	for _, raw := range others {
+		key, err := signingJWK(raw)
+		if err != nil {
+			return fmt.Errorf("JWT_VERIFICATION_KEYS: %w", err)
+		}
+		if err := k.addPublic(key); err != nil {
+			return err
+		}
+	}
+	if legacySecret != "" {
+		secret, err := secretJWK(legacySecret)
+		if err != nil {
+			return err
+		}
+		if err := k.verify.AddKey(secret); err != nil {
+			return err
+		}
+	}
+
+	tokenAuth = k
+	return nil
+}
+
+// addPublic accepts and publishes the public half of key.
+func (k *tokenKeys) addPublic(key jwk.Key) error {
+	pub, err := key.PublicKey()
+	if err != nil {
+		return err
+	}
+	if err := pub.Set(jwk.KeyUsageKey, jwk.ForSignature); err != nil {
+		return err
+	}
+	if kid, _ := pub.KeyID(); k.hasKeyID(kid) {
+		return nil
+	}
+	if err := k.verify.AddKey(pub); err != nil {
+		return err
+	}
+	return k.public.AddKey(pub)
+}
+
+// hasKeyID reports whether a key with kid is already published, so a key listed twice is harmless.
+func (k *tokenKeys) hasKeyID(kid string) bool {
+	_, ok := k.public.LookupKeyID(kid)
+	return ok
+}
+
+// secretJWK wraps an HS256 shared secret.
+func secretJWK(secret string) (jwk.Key, error) {
+	if len(secret) < 32 {
+		return nil, errors.New("JWT_SECRET must be at least 32 characters")
+	}
+	key, err := jwk.Import([]byte(secret))
+	if err != nil {
+		return nil, err
+	}
+	if err := key.Set(jwk.AlgorithmKey, jwa.HS256()); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// signingJWK imports an RSA or Ed25519 key, private or public, naming it by its thumbprint and
+// pinning the one algorithm it may be used with.
+func signingJWK(raw interface{}) (jwk.Key, error) {
+	var alg jwa.SignatureAlgorithm
+	switch v := raw.(type) {
+	case *rsa.PrivateKey:
+		alg = jwa.RS256()
+		if v.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA keys must be at least %d bits", minRSABits)
+		}
+	case *rsa.PublicKey:
+		alg = jwa.RS256()
+		if v.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA keys must be at least %d bits", minRSABits)
+		}
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		alg = jwa.EdDSA()
+	default:
+		return nil, fmt.Errorf("unsupported key type %T; use RSA or Ed25519", raw)
+	}
+
+	key, err := jwk.Import(raw)
+	if err != nil {
+		return nil, err
+	}
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	if err := key.Set(jwk.KeyIDKey, base64.RawURLEncoding.EncodeToString(thumbprint)); err != nil {
+		return nil, err
+	}
+	if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// parsePEMKeys reads every key in a PEM bundle: PKCS#8 or PKCS#1 private keys and PKIX public keys.
+// Environment variables cannot always hold newlines, so a literal \n is read as one.
+func parsePEMKeys(bundle string) ([]interface{}, error) {
+	rest := []byte(strings.ReplaceAll(strings.TrimSpace(bundle), `\n`, "\n"))
+	var keys []interface{}
+	for len(strings.TrimSpace(string(rest))) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("not a PEM-encoded key")
+		}
+		var (
+			key interface{}
+			err error
+		)
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		default:
+			return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Verify looks for a token with findTokenFns, in order, and verifies it against every key the
+// server accepts, leaving the token and any error in the context for Authenticator and the claim
+// helpers, as jwtauth.Verify does.
+func Verify(findTokenFns ...func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, err := verifyRequest(r, findTokenFns...)
+			next.ServeHTTP(w, r.WithContext(jwtauth.NewContext(r.Context(), token, err)))
+		})
+	}
+}
+
+// Verifier verifies a token from the Authorization header or the jwt cookie.
+func Verifier(next http.Handler) http.Handler {
+	return Verify(jwtauth.TokenFromHeader, jwtauth.TokenFromCookie)(next)
+}
+
+// Authenticator rejects requests whose token Verify could not verify with a 401.
+func Authenticator(next http.Handler) http.Handler {
+	return jwtauth.Authenticator(nil)(next)
+}
+
+// verifyRequest finds and verifies the request's token.
+func verifyRequest(r *http.Request, findTokenFns ...func(r *http.Request) string) (jwt.Token, error) {
+	var tokenString string
+	for _, fn := range findTokenFns {
+		if tokenString = fn(r); tokenString != "" {
+			break
+		}
+	}
+	if tokenString == "" {
+		return nil, jwtauth.ErrNoTokenFound
+	}
+	return verifyToken(tokenString)
+}
+
+// verifyToken checks the token's signature against every accepted key (each pinned to its own
+// algorithm, and tried even when the token names no kid, as HS256 tokens do) and then its claims.
+func verifyToken(tokenString string) (jwt.Token, error) {
+	if tokenAuth == nil {
+		return nil, jwtauth.ErrUnauthorized
+	}
+	token, err := jwt.Parse([]byte(tokenString), jwt.WithKeySet(tokenAuth.verify, jws.WithRequireKid(false)), jwt.WithValidate(false))
+	if err != nil {
+		return nil, jwtauth.ErrorReason(err)
+	}
+	if err := jwt.Validate(token); err != nil {
+		return token, jwtauth.ErrorReason(err)
+	}
+	return token, nil
+}
+
+// JWKS godoc
+// @Summary      Get the token signing keys
+// @Description  Returns the public keys access tokens are signed with as a JSON Web Key Set, so other services can verify tokens without a shared secret. Tokens name their key in the kid header. Besides the active key the set holds any key still accepted after a rotation or published ahead of one. Empty while the server signs with an HS256 shared secret.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  object{keys=[]object}
+// @Router       /.well-known/jwks.json [get]
+func (h *Handler) JWKS(w http.ResponseWriter, _ *http.Request) {
+	set := jwk.NewSet()
+	if tokenAuth != nil {
+		set = tokenAuth.public
+	}
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	respondJSON(w, http.StatusOK, set)
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJWTSecret = "fV7sliKV3qn657I60wEFtw/Auk/0bNU9zdp30wFzfDg="
+
+// privatePEM encodes key as a PKCS#8 PEM block.
+func privatePEM(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+// publicPEM encodes key as a PKIX PEM block.
+func publicPEM(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// verifiesToken reports whether the protected routes accept token.
+func verifiesToken(token string) int {
+	req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rw := httptest.NewRecorder()
+	Verifier(Authenticator(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))).ServeHTTP(rw, req)
+	return rw.Code
+}
+
+func TestInitTokenKeys_SignsWithKid(t *testing.T) {
+	// RSA and Ed25519 keys sign with their own algorithm and name themselves by thumbprint.
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for alg, key := range map[string]interface{}{"RS256": rsaKey, "EdDSA": edKey} {
+		require.NoError(t, InitTokenKeys(privatePEM(t, key), "", ""), alg)
+		token, err := GenerateToken(uuid.New(), uuid.New(), RoleCustomer)
+		require.NoError(t, err)
+
+		var header struct {
+			Alg string `json:"alg"`
+			Kid string `json:"kid"`
+		}
+		raw, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[0])
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(raw, &header))
+		assert.Equal(t, alg, header.Alg)
+		assert.True(t, tokenAuth.hasKeyID(header.Kid), alg)
+		assert.Equal(t, http.StatusNoContent, verifiesToken(token), alg)
+	}
+}
+
+func TestInitTokenKeys_Rotation(t *testing.T) {
+	// After a rotation tokens of the previous key stay valid while it is listed, and only then.
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	require.NoError(t, InitTokenKeys(privatePEM(t, oldKey), "", ""))
+	oldToken, err := GenerateToken(uuid.New(), uuid.New(), RoleCustomer)
+	require.NoError(t, err)
+
+	require.NoError(t, InitTokenKeys(privatePEM(t, newKey), publicPEM(t, oldKey.Public()), ""))
+	assert.Equal(t, http.StatusNoContent, verifiesToken(oldToken))
+	assert.Equal(t, 2, tokenAuth.public.Len())
+
+	require.NoError(t, InitTokenKeys(privatePEM(t, newKey), "", ""))
+	assert.Equal(t, http.StatusUnauthorized, verifiesToken(oldToken))
+}
+
+func TestInitTokenKeys_LegacySecret(t *testing.T) {
+	// HS256 tokens stay valid while JWT_SECRET is still set, but the secret is never published.
+	require.NoError(t, InitTokenAuth(testJWTSecret))
+	legacy, err := GenerateToken(uuid.New(), uuid.New(), RoleCustomer)
+	require.NoError(t, err)
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, InitTokenKeys(privatePEM(t, key), "", testJWTSecret))
+	assert.Equal(t, http.StatusNoContent, verifiesToken(legacy))
+	assert.Equal(t, 1, tokenAuth.public.Len())
+
+	require.NoError(t, InitTokenKeys(privatePEM(t, key), "", ""))
+	assert.Equal(t, http.StatusUnauthorized, verifiesToken(legacy))
+}
+
+func TestVerifyToken_RejectsAlgorithmConfusion(t *testing.T) {
+	// A token HMAC-signed with the published public key must not pass as an RS256 token.
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	require.NoError(t, InitTokenKeys(privatePEM(t, rsaKey), "", ""))
+
+	tok := jwt.New()
+	require.NoError(t, tok.Set("user_id", uuid.NewString()))
+	require.NoError(t, tok.Set("exp", time.Now().Add(time.Hour).Unix()))
+	forged, err := jwt.Sign(tok, jwt.WithKey(jwa.HS256(), []byte(publicPEM(t, &rsaKey.PublicKey))))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, verifiesToken(string(forged)))
+}
+
+func TestInitTokenKeys_RejectsWeakKeys(t *testing.T) {
+	// Short RSA keys, unsupported key types and garbage fail at startup.
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	assert.Error(t, InitTokenKeys(privatePEM(t, weak), "", ""))
+	assert.Error(t, InitTokenKeys("not a key", "", ""))
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	assert.Error(t, InitTokenKeys(privatePEM(t, key), "", "too short"))
+}
+
+func TestJWKS(t *testing.T) {
+	// The JWKS lists only public keys, with their kid, alg and use.
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, InitTokenKeys(strings.ReplaceAll(privatePEM(t, key), "\n", `\n`), "", testJWTSecret))
+
+	rw := httptest.NewRecorder()
+	(&Handler{}).JWKS(rw, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	set, err := jwk.Parse(rw.Body.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, 1, set.Len())
+	pub, _ := set.Key(0)
+	kid, _ := pub.KeyID()
+	alg, _ := pub.Algorithm()
+	use, _ := pub.KeyUsage()
+	assert.True(t, tokenAuth.hasKeyID(kid))
+	assert.Equal(t, "EdDSA", alg.String())
+	assert.Equal(t, "sig", use)
+	assert.NotContains(t, rw.Body.String(), `"d"`)
+}
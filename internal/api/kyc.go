@@ -0,0 +1,287 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// kycIDTypes lists the identity documents accepted for verification.
+var kycIDTypes = map[string]bool{
+	"passport": true, "national_id": true, "drivers_license": true, "voters_card": true, "nin": true, "bvn": true,
+}
+
+// kycIDNumberPattern accepts document numbers without spaces, e.g. A12345678 or 12345678901.
+var kycIDNumberPattern = regexp.MustCompile(`^[A-Za-z0-9-]{4,32}$`)
+
+// outboundDebitStatus maps withdrawal and payout errors to an HTTP status.
+func outboundDebitStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrKYCRequired), errors.Is(err, service.ErrKYCLimitExceeded):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrMinimumBalance),
+		errors.Is(err, service.ErrDebitLimitExceeded), errors.Is(err, service.ErrOperationNotAllowed), errors.Is(err, service.ErrSavingsGoalLocked),
+		errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountPrecision), errors.Is(err, service.ErrCurrencyMismatch):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// SubmitKYC godoc
+// @Summary      Submit identity verification
+// @Description  Submits (or resubmits) the authenticated user's identity document for review. document_reference points at the uploaded document in storage. A resubmission keeps any previously approved level until it is reviewed.
+// @Tags         kyc
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{id_type=string,id_number=string,document_reference=string}  true  "id_type: passport, national_id, drivers_license, voters_card, nin or bvn"
+// @Success      201   {object}  KYCResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /me/kyc [post]
+// @Security     Bearer
+func (h *Handler) SubmitKYC(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	// Step 2: Validate the submission.
+	var input struct {
+		IDType            string `json:"id_type"`
+		IDNumber          string `json:"id_number"`
+		DocumentReference string `json:"document_reference"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	idType := strings.ToLower(strings.TrimSpace(input.IDType))
+	if !kycIDTypes[idType] {
+		respondError(w, http.StatusBadRequest, "id_type must be passport, national_id, drivers_license, voters_card, nin or bvn")
+		return
+	}
+	idNumber := strings.TrimSpace(input.IDNumber)
+	if !kycIDNumberPattern.MatchString(idNumber) {
+		respondError(w, http.StatusBadRequest, "invalid id_number")
+		return
+	}
+	docRef := strings.TrimSpace(input.DocumentReference)
+	if docRef == "" || len(docRef) > 512 {
+		respondError(w, http.StatusBadRequest, "document_reference required")
+		return
+	}
+
+	// Step 3: Seal the document details, store and queue for review.
+	sealedIDNumber, err := h.pii.Seal(idNumber)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to seal KYC submission")
+		respondError(w, http.StatusInternalServerError, "failed to submit verification")
+		return
+	}
+	sealedDocRef, err := h.pii.Seal(docRef)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to seal KYC submission")
+		respondError(w, http.StatusInternalServerError, "failed to submit verification")
+		return
+	}
+	rec, err := h.store.UpsertKYCSubmission(r.Context(), sqlc.UpsertKYCSubmissionParams{
+		UserID:            userID,
+		IDType:            idType,
+		IDNumber:          sealedIDNumber,
+		DocumentReference: sealedDocRef,
+	})
+	if err == nil {
+		rec, err = h.openKYC(rec)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to store KYC submission")
+		respondError(w, http.StatusInternalServerError, "failed to submit verification")
+		return
+	}
+
+	log.Info().Str("user_id", userID.String()).Str("id_type", idType).Msg("KYC submitted")
+	respondJSON(w, http.StatusCreated, toKYCResponse(rec))
+}
+
+// GetKYC godoc
+// @Summary      Get identity verification status
+// @Description  Returns the authenticated user's KYC record, including the approved level that sets withdrawal limits
+// @Tags         kyc
+// @Produce      json
+// @Success      200  {object}  KYCResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /me/kyc [get]
+// @Security     Bearer
+func (h *Handler) GetKYC(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	rec, err := h.store.GetKYCRecordByUser(r.Context(), userID)
+	if err == nil {
+		rec, err = h.openKYC(rec)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "no verification submitted")
+			return
+		}
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to load KYC record")
+		respondError(w, http.StatusInternalServerError, "failed to load verification")
+		return
+	}
+	respondJSON(w, http.StatusOK, toKYCResponse(rec))
+}
+
+// ListKYC godoc
+// @Summary      List KYC submissions
+// @Description  Returns KYC records by status, oldest submission first. The default status "pending" is the review queue. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        status  query     string  false  "pending (default), approved or rejected"
+// @Param        limit   query     int     false  "Limit (default 20)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   KYCResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /admin/kyc [get]
+// @Security     Bearer
+func (h *Handler) ListKYC(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.KYCPending
+	case service.KYCPending, service.KYCApproved, service.KYCRejected:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be pending, approved or rejected")
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	// Step 2: Fetch the page.
+	rows, err := h.store.ListKYCRecordsByStatus(r.Context(), sqlc.ListKYCRecordsByStatusParams{
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to list KYC records")
+		respondError(w, http.StatusInternalServerError, "failed to list verifications")
+		return
+	}
+
+	resp := make([]KYCResponse, 0, len(rows))
+	for _, rec := range rows {
+		opened, err := h.openKYC(rec)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", rec.UserID.String()).Msg("Failed to open KYC record")
+			respondError(w, http.StatusInternalServerError, "failed to list verifications")
+			return
+		}
+		resp = append(resp, toKYCResponse(opened))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ReviewKYC godoc
+// @Summary      Review a KYC submission
+// @Description  Approves a pending submission at level 1-3 or rejects it with a reason. Rejection keeps any previously approved level. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        user_id  path      string  true  "User ID"
+// @Param        body     body      object{decision=string,level=int,reason=string}  true  "decision: approve or reject"
+// @Success      200      {object}  KYCResponse
+// @Failure      400      {object}  ErrorResponse
+// @Failure      401      {object}  ErrorResponse
+// @Failure      403      {object}  ErrorResponse
+// @Failure      409      {object}  ErrorResponse
+// @Failure      500      {object}  ErrorResponse
+// @Router       /admin/kyc/{user_id}/review [post]
+// @Security     Bearer
+func (h *Handler) ReviewKYC(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate reviewer (role is enforced by RequireRole) and parse input.
+	reviewerID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	userID, err := uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	var input struct {
+		Decision string `json:"decision"`
+		Reason   string `json:"reason"`
+		Level    int16  `json:"level"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	params := sqlc.ReviewKYCRecordParams{
+		UserID:     userID,
+		ReviewedBy: uuid.NullUUID{UUID: reviewerID, Valid: true},
+	}
+	switch input.Decision {
+	case "approve":
+		if input.Level < 1 || input.Level > 3 {
+			respondError(w, http.StatusBadRequest, "level must be between 1 and 3")
+			return
+		}
+		params.Status, params.Level = service.KYCApproved, input.Level
+	case "reject":
+		reason := strings.TrimSpace(input.Reason)
+		if reason == "" {
+			respondError(w, http.StatusBadRequest, "reason required when rejecting")
+			return
+		}
+		params.Status = service.KYCRejected
+		params.RejectionReason = sql.NullString{String: reason, Valid: true}
+	default:
+		respondError(w, http.StatusBadRequest, "decision must be approve or reject")
+		return
+	}
+
+	// Step 2: Only pending submissions can be reviewed.
+	rec, err := h.store.ReviewKYCRecord(r.Context(), params)
+	if err == nil {
+		rec, err = h.openKYC(rec)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusConflict, "no pending verification for this user")
+			return
+		}
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to review KYC record")
+		respondError(w, http.StatusInternalServerError, "failed to review verification")
+		return
+	}
+
+	log.Info().Str("user_id", userID.String()).Str("reviewer_id", reviewerID.String()).Str("status", rec.Status).Int16("level", rec.Level).Msg("KYC reviewed")
+	respondJSON(w, http.StatusOK, toKYCResponse(rec))
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestMaskIDNumber(t *testing.T) {
+	// Only the last four characters of an identity number are ever returned.
+	assert.Equal(t, "*******6789", maskIDNumber("12345676789"))
+	assert.Equal(t, "****", maskIDNumber("A123"))
+}
+
+func TestOutboundDebitStatus(t *testing.T) {
+	// KYC refusals are 403, business rule failures 400, everything else 500.
+	assert.Equal(t, http.StatusForbidden, outboundDebitStatus(service.ErrKYCRequired))
+	assert.Equal(t, http.StatusForbidden, outboundDebitStatus(fmt.Errorf("hold: %w", service.ErrKYCLimitExceeded)))
+	assert.Equal(t, http.StatusBadRequest, outboundDebitStatus(service.ErrInsufficientFunds))
+	assert.Equal(t, http.StatusInternalServerError, outboundDebitStatus(errors.New("connection reset")))
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ListTransactionEvents godoc
+// @Summary      List a transaction's ledger events
+// @Description  Returns the ledger event log's record of a transaction, oldest first: the transaction_posted event with its legs, then any status changes. The log is append-only; the transaction, its entries and account balances are projections of it. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Transaction ID"
+// @Success      200  {array}   LedgerEventResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/transactions/{id}/events [get]
+// @Security     Bearer
+func (h *Handler) ListTransactionEvents(w http.ResponseWriter, r *http.Request) {
+	transactionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+	events, err := h.ledger.ListTransactionEvents(r.Context(), transactionID)
+	if err != nil {
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to list ledger events")
+		respondError(w, http.StatusInternalServerError, "failed to list ledger events")
+		return
+	}
+	if len(events) == 0 {
+		respondError(w, http.StatusNotFound, "transaction not found")
+		return
+	}
+
+	resp := make([]LedgerEventResponse, 0, len(events))
+	for _, e := range events {
+		resp = append(resp, toLedgerEventResponse(e))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// CheckLedgerProjections godoc
+// @Summary      Check ledger projections
+// @Description  Replays the ledger event log and compares every account's cached balance and the sum of its entries with the replayed balance. consistent is true when they all match; drift lists the accounts that do not. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  LedgerProjectionResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/ledger/projections [get]
+// @Security     Bearer
+func (h *Handler) CheckLedgerProjections(w http.ResponseWriter, r *http.Request) {
+	drift, err := h.ledger.CheckLedgerProjections(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to check ledger projections")
+		respondError(w, http.StatusInternalServerError, "failed to check ledger projections")
+		return
+	}
+	respondJSON(w, http.StatusOK, toLedgerProjectionResponse(drift))
+}
+
+// RebuildLedgerProjections godoc
+// @Summary      Rebuild cached balances from the ledger event log
+// @Description  Resets every account's cached balance to the balance replayed from the ledger event log, holding postings back while it runs. Returns the drift found before the rebuild; entries that disagree with the log are reported, not rewritten. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  LedgerProjectionResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/ledger/projections/rebuild [post]
+// @Security     Bearer
+func (h *Handler) RebuildLedgerProjections(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	drift, err := h.ledger.RebuildBalances(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to rebuild ledger projections")
+		respondError(w, http.StatusInternalServerError, "failed to rebuild ledger projections")
+		return
+	}
+	log.Info().Str("admin_id", userID.String()).Int("drifted_accounts", len(drift)).Msg("Ledger projections rebuilt")
+	respondJSON(w, http.StatusOK, toLedgerProjectionResponse(drift))
+}
+
+// VerifyEntryChain godoc
+// @Summary      Verify the entry hash chains
+// @Description  Recomputes the hash chain of every account's entries, or only account_id's, and lists each place where an entry was changed, removed or reordered after it was posted. intact is true when there is none. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        account_id  query     string  false  "Only verify this account"
+// @Success      200         {object}  EntryChainResponse
+// @Failure      400         {object}  ErrorResponse
+// @Failure      401         {object}  ErrorResponse
+// @Failure      403         {object}  ErrorResponse
+// @Failure      500         {object}  ErrorResponse
+// @Router       /admin/ledger/chain [get]
+// @Security     Bearer
+func (h *Handler) VerifyEntryChain(w http.ResponseWriter, r *http.Request) {
+	var accountID uuid.NullUUID
+	if v := r.URL.Query().Get("account_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid account_id")
+			return
+		}
+		accountID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	result, err := h.ledger.VerifyEntryChain(r.Context(), accountID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to verify entry hash chain")
+		respondError(w, http.StatusInternalServerError, "failed to verify entry hash chain")
+		return
+	}
+	respondJSON(w, http.StatusOK, toEntryChainResponse(result))
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestToLedgerProjectionResponse(t *testing.T) {
+	// No drift is consistent and still answers an empty list; drifted accounts are listed.
+	resp := toLedgerProjectionResponse(nil)
+	assert.True(t, resp.Consistent)
+	assert.NotNil(t, resp.Drift)
+
+	id := uuid.New()
+	resp = toLedgerProjectionResponse([]sqlc.ListLedgerProjectionDriftRow{{AccountID: id, Currency: "NGN", StoredBalance: "10.0000", EntriesBalance: "5.0000", ReplayedBalance: "5.0000"}})
+	assert.False(t, resp.Consistent)
+	assert.Equal(t, []LedgerDriftResponse{{AccountID: id.String(), Currency: "NGN", StoredBalance: "10.0000", EntriesBalance: "5.0000", ReplayedBalance: "5.0000"}}, resp.Drift)
+}
+
+func TestToLedgerEventResponse(t *testing.T) {
+	// The payload is passed through as JSON.
+	e := sqlc.LedgerEvent{Seq: 7, EventType: "transaction_status_changed", TransactionID: uuid.New(), Payload: json.RawMessage(`{"from":"posted","to":"reversed"}`)}
+	resp := toLedgerEventResponse(e)
+	assert.Equal(t, int64(7), resp.Seq)
+	assert.Equal(t, "transaction_status_changed", resp.Type)
+	raw, err := json.Marshal(resp)
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), `"payload":{"from":"posted","to":"reversed"}`)
+}
+
+func TestToEntryChainResponse(t *testing.T) {
+	// An intact chain answers an empty list; a head break with no entry left omits entry_id.
+	resp := toEntryChainResponse(service.ChainVerification{Accounts: 2, Entries: 5})
+	assert.True(t, resp.Intact)
+	assert.NotNil(t, resp.Breaks)
+
+	accountID := uuid.New()
+	resp = toEntryChainResponse(service.ChainVerification{Breaks: []service.ChainBreak{{AccountID: accountID, Seq: 4, Problems: []string{service.ChainProblemHead}}}})
+	assert.False(t, resp.Intact)
+	assert.Equal(t, []EntryChainBreakResponse{{AccountID: accountID.String(), Seq: 4, Problems: []string{"head_mismatch"}}}, resp.Breaks)
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// SetTransactionLimit godoc
+// @Summary      Set a transaction limit
+// @Description  Caps a single deposit, withdrawal, transfer or payout in currency for account owners at kyc_level (0 to 3). A max_amount of 0 blocks the operation at that level until the owner verifies further. Limits apply to the next operation, without a redeploy; withdrawals and payouts without one fall back to the built-in KYC limits when KYC_ENFORCED is on. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{currency=string,operation=string,kyc_level=int,max_amount=string}  true  "Transaction limit"
+// @Success      200   {object}  TransactionLimitResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/limits [put]
+// @Security     Bearer
+func (h *Handler) SetTransactionLimit(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and validate the limit.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		MaxAmount interface{} `json:"max_amount"`
+		KYCLevel  *int16      `json:"kyc_level"`
+		Currency  string      `json:"currency"`
+		Operation string      `json:"operation"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	currency, err := rates.NormalizeCurrency(input.Currency)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if input.KYCLevel == nil {
+		respondError(w, http.StatusBadRequest, "kyc_level required")
+		return
+	}
+	raw, err := normalizeAmountInput(input.MaxAmount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "max_amount: "+err.Error())
+		return
+	}
+	maxAmount, err := service.ValidateTransactionLimit(input.Operation, *input.KYCLevel, raw)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Step 2: Persist; the ledger reads limits on every operation.
+	limit, err := h.store.UpsertTransactionLimit(r.Context(), sqlc.UpsertTransactionLimitParams{
+		Currency:  currency,
+		Operation: input.Operation,
+		KycLevel:  *input.KYCLevel,
+		MaxAmount: maxAmount.StringFixed(4),
+		UpdatedBy: userID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("currency", currency).Str("operation", input.Operation).Msg("Failed to set transaction limit")
+		respondError(w, http.StatusInternalServerError, "failed to set transaction limit")
+		return
+	}
+
+	log.Info().Str("currency", currency).Str("operation", limit.Operation).Int16("kyc_level", limit.KycLevel).Str("max_amount", limit.MaxAmount).Str("set_by", userID.String()).Msg("Transaction limit set")
+	respondJSON(w, http.StatusOK, toTransactionLimitResponse(limit))
+}
+
+// ListTransactionLimits godoc
+// @Summary      List transaction limits
+// @Description  Returns every configured transaction limit by currency, operation and KYC level. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   TransactionLimitResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/limits [get]
+// @Security     Bearer
+func (h *Handler) ListTransactionLimits(w http.ResponseWriter, r *http.Request) {
+	limits, err := h.store.ListTransactionLimits(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list transaction limits")
+		respondError(w, http.StatusInternalServerError, "failed to list transaction limits")
+		return
+	}
+	resp := make([]TransactionLimitResponse, 0, len(limits))
+	for _, l := range limits {
+		resp = append(resp, toTransactionLimitResponse(l))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ClearTransactionLimit godoc
+// @Summary      Clear a transaction limit
+// @Description  Removes a configured limit, so the operation falls back to the built-in KYC limits (withdrawals and payouts) or is uncapped. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        currency   path      string  true  "Currency"
+// @Param        operation  path      string  true  "deposit, withdrawal, transfer or payout"
+// @Param        level      path      int     true  "KYC level"
+// @Success      200        {object}  MessageResponse
+// @Failure      400        {object}  ErrorResponse
+// @Failure      401        {object}  ErrorResponse
+// @Failure      403        {object}  ErrorResponse
+// @Failure      404        {object}  ErrorResponse
+// @Failure      500        {object}  ErrorResponse
+// @Router       /admin/limits/{currency}/{operation}/{level} [delete]
+// @Security     Bearer
+func (h *Handler) ClearTransactionLimit(w http.ResponseWriter, r *http.Request) {
+	currency, err := rates.NormalizeCurrency(chi.URLParam(r, "currency"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	level, err := strconv.ParseInt(chi.URLParam(r, "level"), 10, 16)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid KYC level")
+		return
+	}
+	operation := chi.URLParam(r, "operation")
+
+	removed, err := h.store.DeleteTransactionLimit(r.Context(), sqlc.DeleteTransactionLimitParams{
+		Currency:  currency,
+		Operation: operation,
+		KycLevel:  int16(level), // #nosec G115 -- parsed as 16 bits above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("currency", currency).Str("operation", operation).Msg("Failed to clear transaction limit")
+		respondError(w, http.StatusInternalServerError, "failed to clear transaction limit")
+		return
+	}
+	if removed == 0 {
+		respondError(w, http.StatusNotFound, "no limit for this currency, operation and level")
+		return
+	}
+
+	log.Info().Str("currency", currency).Str("operation", operation).Int64("kyc_level", level).Msg("Transaction limit cleared")
+	respondJSON(w, http.StatusOK, MessageResponse{Message: "limit cleared"})
+}
@@ -0,0 +1,414 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// loanStatus maps loan errors to an HTTP status.
+func loanStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrLoanNotFound), errors.Is(err, service.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrLoanPaidOff):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountPrecision), errors.Is(err, service.ErrInvalidLoanTerms),
+		errors.Is(err, service.ErrLoanOverpayment), errors.Is(err, service.ErrCurrencyMismatch),
+		errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrMinimumBalance),
+		errors.Is(err, service.ErrDebitLimitExceeded), errors.Is(err, service.ErrOperationNotAllowed),
+		errors.Is(err, service.ErrSavingsGoalLocked):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondLoanError writes err with its loan status, hiding internal failures.
+func respondLoanError(w http.ResponseWriter, err error, msg string) {
+	status := loanStatus(err)
+	switch status {
+	case http.StatusNotFound:
+		if errors.Is(err, service.ErrAccountNotFound) {
+			respondError(w, status, "account not found")
+			return
+		}
+		respondError(w, status, "loan not found")
+	case http.StatusInternalServerError:
+		log.Error().Err(err).Msg("Loan operation failed")
+		respondError(w, status, msg)
+	default:
+		respondLedgerError(w, status, err)
+	}
+}
+
+// PreviewLoanSchedule godoc
+// @Summary      Preview an amortization schedule
+// @Description  Computes the equal monthly installments for a loan of principal at annual_rate_bps (basis points a year) over term_months, first due one month from today, without creating anything. Each installment splits into principal and interest, rounded to the decimal places of currency (cents when omitted); the last absorbs rounding.
+// @Tags         loans
+// @Produce      json
+// @Param        principal        query     string  true  "Principal"
+// @Param        annual_rate_bps  query     int     true  "Annual rate in basis points (0-10000)"
+// @Param        term_months      query     int     true  "Term in months (1-360)"
+// @Param        currency         query     string  false "ISO 4217 currency of the loan"
+// @Success      200              {array}   LoanInstallmentResponse
+// @Failure      400              {object}  ErrorResponse
+// @Failure      401              {object}  ErrorResponse
+// @Router       /loans/schedule [get]
+// @Security     Bearer
+func (h *Handler) PreviewLoanSchedule(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	principal, err := decimal.NewFromString(q.Get("principal"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid principal")
+		return
+	}
+	rate, err := strconv.ParseInt(q.Get("annual_rate_bps"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid annual_rate_bps")
+		return
+	}
+	term, err := strconv.ParseInt(q.Get("term_months"), 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid term_months")
+		return
+	}
+	var currency string
+	if v := q.Get("currency"); v != "" {
+		if currency, err = rates.NormalizeCurrency(v); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	schedule, err := service.AmortizationSchedule(principal, int32(rate), int32(term), time.Now(), currency) // #nosec G115 -- parsed as 32-bit above
+	if err != nil {
+		respondLoanError(w, err, "failed to compute schedule")
+		return
+	}
+	resp := make([]LoanInstallmentResponse, 0, len(schedule))
+	for _, inst := range schedule {
+		resp = append(resp, LoanInstallmentResponse{
+			Number:       inst.Number,
+			DueDate:      inst.DueDate.Format("2006-01-02"),
+			PrincipalDue: currencies.Format(currency, inst.Principal),
+			InterestDue:  currencies.Format(currency, inst.Interest),
+			Balance:      currencies.Format(currency, inst.Balance),
+		})
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// OriginateLoan godoc
+// @Summary      Disburse a loan
+// @Description  Organization admins lend principal to an account of their organization at annual_rate_bps over term_months. The disbursement is one ledger transaction debiting the Loans Receivable system account of the currency and crediting the borrower, and the amortization schedule is fixed. Principal accepts JSON number or string.
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{account_id=string,principal=string,annual_rate_bps=int,term_months=int}  true  "Loan terms"
+// @Success      201   {object}  LoanResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /org/loans [post]
+// @Security     Bearer
+func (h *Handler) OriginateLoan(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and decode payload.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		Principal     interface{} `json:"principal"`
+		AccountID     string      `json:"account_id"`
+		AnnualRateBps int32       `json:"annual_rate_bps"`
+		TermMonths    int32       `json:"term_months"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	accountID, err := uuid.Parse(input.AccountID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account_id")
+		return
+	}
+	principal, err := normalizeAmountInput(input.Principal)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid principal")
+		return
+	}
+
+	// Step 2: Disburse; the service keeps the borrower inside the caller's organization.
+	loan, installments, err := h.ledger.OriginateLoan(r.Context(), service.LoanRequest{
+		OrgID:         orgID,
+		AccountID:     accountID,
+		Principal:     principal,
+		AnnualRateBps: input.AnnualRateBps,
+		TermMonths:    input.TermMonths,
+		CreatedBy:     userID,
+	})
+	if respondScreening(w, err) {
+		return
+	}
+	if err != nil {
+		respondLoanError(w, err, "failed to disburse loan")
+		return
+	}
+
+	log.Info().Str("loan_id", loan.ID.String()).Str("admin_id", userID.String()).Msg("Loan originated")
+	respondJSON(w, http.StatusCreated, toLoanResponse(loan, installments, nil))
+}
+
+// ListOrgLoans godoc
+// @Summary      List the organization's loans
+// @Description  Returns loans of the caller's organization, newest first, optionally only those with one delinquency (current, late, delinquent or defaulted)
+// @Tags         loans
+// @Produce      json
+// @Param        delinquency  query     string  false  "Delinquency filter"
+// @Param        limit        query     int     false  "Limit (default 20)"
+// @Param        offset       query     int     false  "Offset (default 0)"
+// @Success      200          {array}   LoanResponse
+// @Failure      400          {object}  ErrorResponse
+// @Failure      401          {object}  ErrorResponse
+// @Failure      403          {object}  ErrorResponse
+// @Failure      500          {object}  ErrorResponse
+// @Router       /org/loans [get]
+// @Security     Bearer
+func (h *Handler) ListOrgLoans(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	delinquency := r.URL.Query().Get("delinquency")
+	switch delinquency {
+	case "", service.LoanCurrent, service.LoanLate, service.LoanDelinquent, service.LoanDefaulted:
+	default:
+		respondError(w, http.StatusBadRequest, "delinquency must be current, late, delinquent or defaulted")
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	loans, err := h.store.ListOrgLoans(r.Context(), sqlc.ListOrgLoansParams{
+		OrgID:       orgID,
+		Delinquency: delinquency,
+		RowLimit:    int32(limit),  // #nosec G115 -- capped at 100 above
+		RowOffset:   int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to list loans")
+		respondError(w, http.StatusInternalServerError, "failed to list loans")
+		return
+	}
+	resp := make([]LoanResponse, 0, len(loans))
+	for _, loan := range loans {
+		resp = append(resp, toLoanResponse(loan, nil, nil))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ListAccountLoans godoc
+// @Summary      List an account's loans
+// @Description  Returns the loans disbursed into the account, newest first, with their status and delinquency
+// @Tags         loans
+// @Produce      json
+// @Param        id   path      string  true  "Account ID"
+// @Success      200  {array}   LoanResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/loans [get]
+// @Security     Bearer
+func (h *Handler) ListAccountLoans(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.visibleAccount(w, r, userID, accountID); !ok {
+		return
+	}
+	loans, err := h.store.ListLoansByAccount(r.Context(), accountID)
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to list loans")
+		respondError(w, http.StatusInternalServerError, "failed to list loans")
+		return
+	}
+	resp := make([]LoanResponse, 0, len(loans))
+	for _, loan := range loans {
+		resp = append(resp, toLoanResponse(loan, nil, nil))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetLoan godoc
+// @Summary      Get a loan
+// @Description  Returns the loan with its amortization schedule, what has been paid against each installment, and its repayments. Visible to the borrower account's holders and the organization's admins.
+// @Tags         loans
+// @Produce      json
+// @Param        id   path      string  true  "Loan ID"
+// @Success      200  {object}  LoanResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /loans/{id} [get]
+// @Security     Bearer
+func (h *Handler) GetLoan(w http.ResponseWriter, r *http.Request) {
+	loan, ok := h.loadLoan(w, r)
+	if !ok {
+		return
+	}
+	installments, err := h.store.ListLoanInstallments(r.Context(), loan.ID)
+	if err != nil {
+		log.Error().Err(err).Str("loan_id", loan.ID.String()).Msg("Failed to load loan schedule")
+		respondError(w, http.StatusInternalServerError, "failed to load loan")
+		return
+	}
+	repayments, err := h.store.ListLoanRepayments(r.Context(), loan.ID)
+	if err != nil {
+		log.Error().Err(err).Str("loan_id", loan.ID.String()).Msg("Failed to load loan repayments")
+		respondError(w, http.StatusInternalServerError, "failed to load loan")
+		return
+	}
+	respondJSON(w, http.StatusOK, toLoanResponse(loan, installments, repayments))
+}
+
+// RepayLoan godoc
+// @Summary      Repay a loan
+// @Description  Pays amount towards the loan from account_id (default: the borrower account), which the caller must own. The amount fills installments oldest first, interest before principal, and is posted as one transaction: a debit of the payer, a credit of the principal part to Loans Receivable and of the interest part to Interest Income. Paying early reduces later installments; paying more than is owed is refused. The amount field accepts JSON number or string.
+// @Tags         loans
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                                true  "Loan ID"
+// @Param        body  body      object{amount=string,account_id=string}  true  "Repayment"
+// @Success      201   {object}  LoanRepaymentResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /loans/{id}/repayments [post]
+// @Security     Bearer
+func (h *Handler) RepayLoan(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and decode payload.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	loan, ok := h.loadLoan(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		Amount    interface{} `json:"amount"`
+		AccountID string      `json:"account_id"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	amount, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid amount")
+		return
+	}
+	fromID := loan.AccountID
+	if input.AccountID != "" {
+		if fromID, err = uuid.Parse(input.AccountID); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid account_id")
+			return
+		}
+	}
+
+	// Step 2: Authorize the paying account.
+	if _, ok := h.ownedAccount(w, r, userID, fromID); !ok {
+		return
+	}
+
+	// Step 3: Post the repayment.
+	repayment, loan, err := h.ledger.RepayLoan(r.Context(), service.LoanRepaymentRequest{
+		LoanID:        loan.ID,
+		FromAccountID: fromID,
+		Amount:        amount,
+		CreatedBy:     userID,
+	})
+	if respondScreening(w, err) {
+		return
+	}
+	if err != nil {
+		respondLoanError(w, err, "failed to repay loan")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, LoanRepaymentResponse{
+		Repayment:   toRepaymentResponse(repayment, loan.Currency),
+		Status:      loan.Status,
+		Delinquency: loan.Delinquency,
+	})
+}
+
+// loadLoan loads the {id} loan if the caller holds the borrower account or administers its
+// organization; anyone else gets the same 404 as for a missing loan.
+func (h *Handler) loadLoan(w http.ResponseWriter, r *http.Request) (sqlc.Loan, bool) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return sqlc.Loan{}, false
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return sqlc.Loan{}, false
+	}
+	loanID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid loan ID")
+		return sqlc.Loan{}, false
+	}
+	loan, err := h.store.GetLoan(r.Context(), loanID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "loan not found")
+			return sqlc.Loan{}, false
+		}
+		log.Error().Err(err).Str("loan_id", loanID.String()).Msg("Failed to load loan")
+		respondError(w, http.StatusInternalServerError, "failed to load loan")
+		return sqlc.Loan{}, false
+	}
+	if authenticatedRole(r) == RoleOrgAdmin && orgID == loan.OrgID {
+		return loan, true
+	}
+	acc, err := h.store.GetAccount(r.Context(), loan.AccountID)
+	if err != nil || !h.hasAccountRole(r.Context(), userID, acc, AccountRoleViewer) {
+		respondError(w, http.StatusNotFound, "loan not found")
+		return sqlc.Loan{}, false
+	}
+	return loan, true
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestLoanStatus(t *testing.T) {
+	// Repaying a settled loan conflicts; bad terms and overpayments are client errors.
+	assert.Equal(t, http.StatusNotFound, loanStatus(service.ErrLoanNotFound))
+	assert.Equal(t, http.StatusNotFound, loanStatus(service.ErrAccountNotFound))
+	assert.Equal(t, http.StatusConflict, loanStatus(service.ErrLoanPaidOff))
+	assert.Equal(t, http.StatusBadRequest, loanStatus(fmt.Errorf("%w (12.00)", service.ErrLoanOverpayment)))
+	assert.Equal(t, http.StatusBadRequest, loanStatus(service.ErrInvalidLoanTerms))
+	assert.Equal(t, http.StatusBadRequest, loanStatus(service.ErrInsufficientFunds))
+	assert.Equal(t, http.StatusInternalServerError, loanStatus(errors.New("boom")))
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/i18n"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// localeWriter carries the language a request's error messages are written in.
+type localeWriter struct {
+	http.ResponseWriter
+	locale string
+	// preferred loads the caller's saved language, "" when they have none. It runs at most once,
+	// when the first error is written, so requests that succeed never pay for it.
+	preferred func() string
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (lw *localeWriter) Unwrap() http.ResponseWriter {
+	return lw.ResponseWriter
+}
+
+// Hijack hands the connection to the WebSocket upgrader, which asserts http.Hijacker directly.
+func (lw *localeWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(lw.ResponseWriter).Hijack()
+}
+
+// Localize writes error messages in the language the Accept-Language header prefers among
+// i18n.Locales, falling back to English. Messages without a translation stay in English.
+func Localize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(&localeWriter{ResponseWriter: w, locale: i18n.Match(r.Header.Get("Accept-Language"))}, r)
+	})
+}
+
+// PreferUserLocale lets the language an authenticated caller saved with PUT /me/locale override
+// Accept-Language. It must run after Localize and Authenticator.
+func (h *Handler) PreferUserLocale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lw := findLocaleWriter(w)
+		_, claims, err := jwtauth.FromContext(r.Context())
+		if lw == nil || err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		userIDStr, _ := claims["user_id"].(string)
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := r.Context()
+		lw.preferred = func() string {
+			user, err := h.store.GetUser(ctx, userID)
+			if err != nil {
+				log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to load preferred locale")
+				return ""
+			}
+			return user.Locale
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// findLocaleWriter returns the localeWriter w wraps, or nil outside Localize.
+func findLocaleWriter(w http.ResponseWriter) *localeWriter {
+	for {
+		if lw, ok := w.(*localeWriter); ok {
+			return lw
+		}
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return nil
+		}
+		w = u.Unwrap()
+	}
+}
+
+// localize translates msg into the language of the request w answers, naming it in the
+// Content-Language header.
+func localize(w http.ResponseWriter, msg string) string {
+	lw := findLocaleWriter(w)
+	if lw == nil {
+		return msg
+	}
+	if lw.preferred != nil {
+		if locale := lw.preferred(); locale != "" {
+			lw.locale = locale
+		}
+		lw.preferred = nil
+	}
+	w.Header().Set("Content-Language", lw.locale)
+	return i18n.T(lw.locale, msg)
+}
+
+// SetLocale godoc
+// @Summary      Set preferred language
+// @Description  Saves the language API errors and notifications are written in: en, yo, ha, ig or fr. It overrides Accept-Language on authenticated requests; an empty locale clears it, so errors follow Accept-Language again and notifications are sent in English. Messages without a translation stay in English.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{locale=string}  true  "Locale"
+// @Success      200   {object}  ProfileResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /me/locale [put]
+// @Security     Bearer
+func (h *Handler) SetLocale(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		Locale string `json:"locale"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	locale := strings.ToLower(strings.TrimSpace(input.Locale))
+	if locale != "" && !i18n.Supported(locale) {
+		respondError(w, http.StatusBadRequest, "locale must be one of "+strings.Join(i18n.Locales, ", "))
+		return
+	}
+
+	user, err := h.store.SetUserLocale(r.Context(), sqlc.SetUserLocaleParams{ID: userID, Locale: locale})
+	if err == nil {
+		user, err = h.openUser(user)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to set locale")
+		respondError(w, http.StatusInternalServerError, "failed to set locale")
+		return
+	}
+	log.Info().Str("user_id", userID.String()).Str("locale", locale).Msg("Preferred locale set")
+	respondJSON(w, http.StatusOK, toProfileResponse(user))
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestLocalize(t *testing.T) {
+	// Error messages follow Accept-Language, through other middleware wrapping the writer, and
+	// untranslated ones stay in English.
+	handler := Localize(Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ledger":
+			respondLedgerError(w, http.StatusUnprocessableEntity, service.ErrInsufficientFunds)
+		case "/untranslated":
+			respondError(w, http.StatusBadRequest, "invalid savings goal ID")
+		default:
+			respondError(w, http.StatusNotFound, "account not found")
+		}
+	})))
+
+	cases := []struct {
+		path, acceptLanguage, want, language string
+	}{
+		{"/", "", "account not found", "en"},
+		{"/", "fr-FR,fr;q=0.9,en;q=0.8", "compte introuvable", "fr"},
+		{"/", "de, yo;q=0.5", "a kò rí àkáǹtì náà", "yo"},
+		{"/ledger", "ha", "kuɗi bai isa ba", "ha"},
+		{"/untranslated", "ig", "invalid savings goal ID", "ig"},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, c.path, nil)
+		req.Header.Set("Accept-Language", c.acceptLanguage)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		var body ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, c.want, body.Error, c.acceptLanguage)
+		assert.Equal(t, c.language, rec.Header().Get("Content-Language"), c.acceptLanguage)
+	}
+}
+
+func TestLocalize_PreferredLocaleWins(t *testing.T) {
+	// A saved preference overrides the header, and is only loaded once.
+	loads := 0
+	handler := Localize(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		findLocaleWriter(w).preferred = func() string {
+			loads++
+			return "ig"
+		}
+		respondError(w, http.StatusForbidden, "access denied")
+		assert.Equal(t, "ego ezughị", localize(w, "insufficient funds"))
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "fr")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var body ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "a jụrụ ohere", body.Error)
+	assert.Equal(t, 1, loads)
+}
+
+func TestLocalize_OutsideMiddleware(t *testing.T) {
+	rec := httptest.NewRecorder()
+	respondError(rec, http.StatusNotFound, "account not found")
+	assert.Contains(t, rec.Body.String(), "account not found")
+	assert.Empty(t, rec.Header().Get("Content-Language"))
+}
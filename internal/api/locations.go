@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/geo"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/i18n"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/notify"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// DefaultGeoAnomalyWindow is how soon after activity in one country a request from a new country
+// counts as anomalous by default, and for how long money operations from it stay under suspicion.
+const DefaultGeoAnomalyWindow = 12 * time.Hour
+
+// WithGeoLocation records the country of each authenticated request with locator. A request from
+// a country the user has not been in before, within window of activity in another, is emailed to
+// the user, and money operations from that country are scored as a geo anomaly for window after it
+// first appeared.
+func WithGeoLocation(locator geo.Locator, window time.Duration) Option {
+	return func(h *Handler) {
+		h.geo = locator
+		h.geoAnomalyWindow = window
+	}
+}
+
+// TrackLocation is middleware recording where authenticated requests come from, after
+// RequireSession. Requests from a country that appeared suddenly carry the anomaly to fraud risk
+// scoring. Without geolocation it does nothing.
+func (h *Handler) TrackLocation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.geo == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		userID, ok := authenticatedUserID(w, r)
+		if !ok {
+			return
+		}
+		if detail := h.rememberLocation(r.Context(), userID, h.geo.Country(r), clientIP(r)); detail != "" {
+			r = r.WithContext(service.WithGeoAnomaly(r.Context(), detail))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rememberLocation records that userID made a request from country and describes the anomaly when
+// the country first appeared within the window of activity elsewhere and is still that recent.
+// The first request from such a country is logged and emailed to the user. Unknown countries and
+// failures are logged and count as no anomaly.
+func (h *Handler) rememberLocation(ctx context.Context, userID uuid.UUID, country, ip string) string {
+	if country == "" {
+		return ""
+	}
+	seen, err := h.store.RememberLocation(ctx, sqlc.RememberLocationParams{
+		UserID:        userID,
+		Country:       country,
+		IpAddress:     ip,
+		WindowSeconds: int32(h.geoAnomalyWindow / time.Second), // #nosec G115 -- configured window, well below 68 years
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to remember request location")
+		return ""
+	}
+	if seen.OtherCountry == "" || time.Since(seen.FirstSeenAt) >= h.geoAnomalyWindow {
+		return ""
+	}
+	detail := fmt.Sprintf("request from %s within %s of activity in %s", country, h.geoAnomalyWindow, seen.OtherCountry)
+	if seen.NewCountry {
+		log.Warn().Str("user_id", userID.String()).Str("country", country).Str("previous_country", seen.OtherCountry).
+			Str("ip_address", ip).Msg("Request from new country shortly after activity elsewhere")
+		h.sendLocationAlert(ctx, userID, country, seen.OtherCountry, ip)
+	}
+	return detail
+}
+
+// sendLocationAlert emails the user about activity from a new country. Failures are only logged.
+func (h *Handler) sendLocationAlert(ctx context.Context, userID uuid.UUID, country, previous, ip string) {
+	if h.securityAlerts == nil {
+		return
+	}
+	user, err := h.store.GetUser(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to load user for new country alert")
+		return
+	}
+	if err := h.securityAlerts.SendEmail(ctx, notify.EmailMessage{
+		To:      user.Email,
+		Subject: i18n.T(user.Locale, "Your account was used from a new country"),
+		Body: i18n.Sprintf(user.Locale, "Your account was used from %s at %s UTC, shortly after activity from %s.\n\nAddress: %s\n\nIf this was you, there is nothing to do, though payments may ask you to confirm them for a while. If it wasn't, change your password now; that also logs out every device.",
+			country, time.Now().UTC().Format("2006-01-02 15:04"), previous, ip),
+	}); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to send new country alert")
+	}
+}
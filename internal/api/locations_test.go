@@ -0,0 +1,30 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/geo"
+)
+
+func TestTrackLocation_Disabled(t *testing.T) {
+	// Without geolocation requests pass through untouched, authenticated or not.
+	h := &Handler{}
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true })
+	rec := httptest.NewRecorder()
+	h.TrackLocation(next).ServeHTTP(rec, httptest.NewRequest("GET", "/accounts", nil))
+	assert.True(t, called)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRememberLocation_UnknownCountry(t *testing.T) {
+	// Requests that cannot be located are not recorded and carry no anomaly.
+	h := &Handler{geo: geo.HeaderLocator("CF-IPCountry"), geoAnomalyWindow: DefaultGeoAnomalyWindow}
+	assert.Equal(t, "", h.rememberLocation(context.Background(), uuid.New(), "", "203.0.113.7"))
+}
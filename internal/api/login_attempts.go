@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/i18n"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/jobs"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/notify"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// Why a recorded login attempt failed.
+const (
+	loginInvalidCredentials = "invalid_credentials"
+	loginTemporarilyLocked  = "temporarily_locked"
+	loginAccountLocked      = "account_locked"
+	loginUnderReview        = "under_review"
+	loginThrottled          = "throttled"
+)
+
+// KindPruneLoginAttempts deletes login attempts older than the configured retention.
+const KindPruneLoginAttempts = "login_attempts.prune"
+
+// LoginPolicy bounds password guessing, per user and per client address.
+type LoginPolicy struct {
+	// MaxFailures consecutive wrong passwords lock the user's login for LockDuration.
+	MaxFailures  int
+	LockDuration time.Duration
+	// IPMaxFailures failed attempts from one address within IPWindow turn it away until they age out.
+	IPMaxFailures int
+	IPWindow      time.Duration
+}
+
+// DefaultLoginPolicy locks a user for 15 minutes after 5 wrong passwords and throttles an address
+// after 20 failures in 15 minutes.
+var DefaultLoginPolicy = LoginPolicy{MaxFailures: 5, LockDuration: 15 * time.Minute, IPMaxFailures: 20, IPWindow: 15 * time.Minute}
+
+// WithLoginPolicy replaces the default lockout and throttling limits; zero fields keep their default.
+func WithLoginPolicy(p LoginPolicy) Option {
+	return func(h *Handler) {
+		if p.MaxFailures > 0 {
+			h.loginPolicy.MaxFailures = p.MaxFailures
+		}
+		if p.LockDuration > 0 {
+			h.loginPolicy.LockDuration = p.LockDuration
+		}
+		if p.IPMaxFailures > 0 {
+			h.loginPolicy.IPMaxFailures = p.IPMaxFailures
+		}
+		if p.IPWindow > 0 {
+			h.loginPolicy.IPWindow = p.IPWindow
+		}
+	}
+}
+
+// WithSecurityAlerts emails users through sender when repeated failed logins lock them out.
+func WithSecurityAlerts(sender notify.EmailSender) Option {
+	return func(h *Handler) {
+		h.securityAlerts = sender
+	}
+}
+
+// clientIP is the address the request came from, without its port.
+func clientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
+
+// respondTooManyAttempts answers 429 with how long the client should wait.
+func respondTooManyAttempts(w http.ResponseWriter, retryAfter time.Duration, msg, code string) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Round(time.Second).Seconds())))
+	respondJSON(w, http.StatusTooManyRequests, ErrorResponse{Error: msg, Code: code})
+}
+
+// recordLoginAttempt stores the attempt for throttling and the audit trail. Failing to record it
+// is logged rather than refusing the login.
+func (h *Handler) recordLoginAttempt(ctx context.Context, attempt sqlc.CreateLoginAttemptParams, failureReason string) {
+	attempt.Succeeded = failureReason == ""
+	attempt.FailureReason = failureReason
+	if err := h.store.CreateLoginAttempt(ctx, attempt); err != nil {
+		log.Error().Err(err).Str("ip_address", attempt.IpAddress).Msg("Failed to record login attempt")
+	}
+}
+
+// loginThrottled reports whether the address has failed too often lately, answering 429 if so.
+func (h *Handler) loginThrottled(w http.ResponseWriter, r *http.Request, attempt sqlc.CreateLoginAttemptParams) bool {
+	p := h.loginPolicy
+	failures, err := h.store.CountFailedLoginsByIP(r.Context(), sqlc.CountFailedLoginsByIPParams{
+		IpAddress: attempt.IpAddress,
+		CreatedAt: time.Now().Add(-p.IPWindow),
+	})
+	if err != nil {
+		// Throttling is a defence in depth; the per-user lockout still applies.
+		log.Error().Err(err).Str("ip_address", attempt.IpAddress).Msg("Failed to count failed logins")
+		return false
+	}
+	if failures < int64(p.IPMaxFailures) {
+		return false
+	}
+	h.recordLoginAttempt(r.Context(), attempt, loginThrottled)
+	log.Warn().Str("ip_address", attempt.IpAddress).Int64("failures", failures).Msg("Login throttled - too many failures from address")
+	respondTooManyAttempts(w, p.IPWindow, "too many failed logins from this address; try again later", "too_many_attempts")
+	return true
+}
+
+// recordWrongPassword counts a wrong password against user, locking their login once the policy's
+// limit is reached and alerting them that it was.
+func (h *Handler) recordWrongPassword(ctx context.Context, user sqlc.User, attempt sqlc.CreateLoginAttemptParams) {
+	h.recordLoginAttempt(ctx, attempt, loginInvalidCredentials)
+	p := h.loginPolicy
+	updated, err := h.store.RecordFailedLogin(ctx, sqlc.RecordFailedLoginParams{
+		ID:          user.ID,
+		MaxFailures: int32(p.MaxFailures), // #nosec G115 -- a small configured count
+		LockedUntil: time.Now().Add(p.LockDuration),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to record failed login")
+		return
+	}
+	if updated.FailedLogins != 0 || !updated.LoginLockedUntil.Valid || !updated.LoginLockedUntil.Time.After(time.Now()) {
+		return
+	}
+
+	log.Warn().Str("user_id", user.ID.String()).Str("ip_address", attempt.IpAddress).Time("locked_until", updated.LoginLockedUntil.Time).Int("failures", p.MaxFailures).Msg("Login temporarily locked after repeated failures")
+	if h.securityAlerts == nil {
+		return
+	}
+	if err := h.securityAlerts.SendEmail(ctx, notify.EmailMessage{
+		To:      user.Email,
+		Subject: i18n.T(user.Locale, "Your login was temporarily locked"),
+		Body: i18n.Sprintf(user.Locale, "We locked your login until %s UTC after %d failed sign-in attempts, the last from %s.\n\nIf this wasn't you, someone may be trying to guess your password. Consider changing it once the lock lifts, and contact support if you need help.",
+			updated.LoginLockedUntil.Time.UTC().Format("2006-01-02 15:04"), p.MaxFailures, attempt.IpAddress),
+	}); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to send login lock alert")
+	}
+}
+
+// ListLoginAttempts godoc
+// @Summary      List login attempts
+// @Description  Returns a page of recorded login and password change attempts, newest first, wrapped in {data, page}: who and which address tried, whether it succeeded and why not (invalid_credentials, temporarily_locked, account_locked or throttled). Attempts for unknown emails have no user_id. Every filter is optional. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        user_id    query     string  false  "User ID"
+// @Param        ip         query     string  false  "Client IP address"
+// @Param        succeeded  query     bool    false  "Only successful or only failed attempts"
+// @Param        limit      query     int     false  "Limit (default 20, max 100)"
+// @Param        offset     query     int     false  "Offset (default 0)"
+// @Success      200        {object}  PagedResponse{data=[]LoginAttemptResponse}
+// @Failure      400        {object}  ErrorResponse
+// @Failure      401        {object}  ErrorResponse
+// @Failure      403        {object}  ErrorResponse
+// @Failure      500        {object}  ErrorResponse
+// @Router       /admin/login-attempts [get]
+// @Security     Bearer
+func (h *Handler) ListLoginAttempts(w http.ResponseWriter, r *http.Request) {
+	filters, ok := parseLoginAttemptFilters(w, r)
+	if !ok {
+		return
+	}
+	h.respondLoginAttempts(w, r, filters)
+}
+
+// parseLoginAttemptFilters reads the user_id, ip and succeeded filters, answering 400 for malformed ones.
+func parseLoginAttemptFilters(w http.ResponseWriter, r *http.Request) (sqlc.CountLoginAttemptsParams, bool) {
+	q := r.URL.Query()
+	var filters sqlc.CountLoginAttemptsParams
+	if v := q.Get("user_id"); v != "" {
+		userID, err := uuid.Parse(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid user_id")
+			return filters, false
+		}
+		filters.UserID = uuid.NullUUID{UUID: userID, Valid: true}
+	}
+	if v := q.Get("ip"); v != "" {
+		filters.IpAddress = sql.NullString{String: v, Valid: true}
+	}
+	if v := q.Get("succeeded"); v != "" {
+		succeeded, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "succeeded must be true or false")
+			return filters, false
+		}
+		filters.Succeeded = sql.NullBool{Bool: succeeded, Valid: true}
+	}
+	return filters, true
+}
+
+// respondLoginAttempts answers with the page of login attempts matching filters.
+func (h *Handler) respondLoginAttempts(w http.ResponseWriter, r *http.Request, filters sqlc.CountLoginAttemptsParams) {
+	limit, offset := parsePage(r)
+	rows, err := h.store.ListLoginAttempts(r.Context(), sqlc.ListLoginAttemptsParams{
+		UserID:    filters.UserID,
+		IpAddress: filters.IpAddress,
+		Succeeded: filters.Succeeded,
+		RowLimit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		RowOffset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list login attempts")
+		respondError(w, http.StatusInternalServerError, "failed to list login attempts")
+		return
+	}
+	total, err := h.store.CountLoginAttempts(r.Context(), filters)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count login attempts")
+		respondError(w, http.StatusInternalServerError, "failed to list login attempts")
+		return
+	}
+
+	resp := make([]LoginAttemptResponse, 0, len(rows))
+	for _, a := range rows {
+		resp = append(resp, toLoginAttemptResponse(a))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// LoginAttemptPruner deletes old login attempts. *db.Store satisfies it.
+type LoginAttemptPruner interface {
+	DeleteLoginAttemptsBefore(ctx context.Context, createdAt time.Time) (int64, error)
+}
+
+// PruneLoginAttempts returns a handler that keeps the login audit trail to retention.
+func PruneLoginAttempts(store LoginAttemptPruner, retention time.Duration) jobs.HandlerFunc {
+	return func(ctx context.Context, _ json.RawMessage) error {
+		n, err := store.DeleteLoginAttemptsBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+		log.Info().Int64("attempts", n).Msg("Pruned login attempts")
+		return nil
+	}
+}
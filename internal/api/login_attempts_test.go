@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestWithLoginPolicy(t *testing.T) {
+	// Only the limits that are set replace the defaults.
+	h := &Handler{loginPolicy: DefaultLoginPolicy}
+	WithLoginPolicy(LoginPolicy{MaxFailures: 3, IPWindow: time.Hour})(h)
+	assert.Equal(t, LoginPolicy{MaxFailures: 3, LockDuration: 15 * time.Minute, IPMaxFailures: 20, IPWindow: time.Hour}, h.loginPolicy)
+}
+
+func TestRespondTooManyAttempts(t *testing.T) {
+	// Refused logins say when to retry, in whole seconds.
+	rw := httptest.NewRecorder()
+	respondTooManyAttempts(rw, 90*time.Second+300*time.Millisecond, "slow down", "too_many_attempts")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "90", rw.Header().Get("Retry-After"))
+	assert.Contains(t, rw.Body.String(), `"code":"too_many_attempts"`)
+}
+
+func TestClientIP(t *testing.T) {
+	// Attempts are counted per address, whatever the client port.
+	req := httptest.NewRequest(http.MethodPost, "/login", nil)
+	req.RemoteAddr = "192.0.2.1:51234"
+	assert.Equal(t, "192.0.2.1", clientIP(req))
+	req.RemoteAddr = "[2001:db8::1]:443"
+	assert.Equal(t, "2001:db8::1", clientIP(req))
+	req.RemoteAddr = "192.0.2.1"
+	assert.Equal(t, "192.0.2.1", clientIP(req))
+}
+
+func TestToLoginAttemptResponse(t *testing.T) {
+	// Attempts for unknown emails carry no user.
+	resp := toLoginAttemptResponse(sqlc.LoginAttempt{ID: uuid.New(), Email: "nobody@example.com", FailureReason: loginInvalidCredentials})
+	assert.Nil(t, resp.UserID)
+	assert.False(t, resp.Succeeded)
+	assert.Equal(t, "invalid_credentials", resp.FailureReason)
+}
+
+func TestListLoginAttempts_RejectsBadFilters(t *testing.T) {
+	// Malformed filters are refused before the store is queried.
+	h := &Handler{}
+	for query, want := range map[string]string{"user_id=nope": "invalid user_id", "succeeded=maybe": "succeeded must be"} {
+		rw := httptest.NewRecorder()
+		h.ListLoginAttempts(rw, httptest.NewRequest(http.MethodGet, "/admin/login-attempts?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rw.Code, query)
+		assert.Contains(t, rw.Body.String(), want, query)
+	}
+}
+
+type fakeLoginAttemptPruner struct{ cutoff time.Time }
+
+func (f *fakeLoginAttemptPruner) DeleteLoginAttemptsBefore(_ context.Context, createdAt time.Time) (int64, error) {
+	f.cutoff = createdAt
+	return 0, nil
+}
+
+func TestPruneLoginAttempts(t *testing.T) {
+	// Attempts older than the retention are deleted.
+	store := &fakeLoginAttemptPruner{}
+	require.NoError(t, PruneLoginAttempts(store, 90*24*time.Hour)(context.Background(), nil))
+	assert.WithinDuration(t, time.Now().Add(-90*24*time.Hour), store.cutoff, time.Minute)
+}
@@ -1,8 +1,25 @@
 package api
 
-import "github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
 
-func toAccountResponse(acc sqlc.Account) AccountResponse {
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/sanctions"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// toAccountResponse maps acc; catalog supplies its product's rules, and nil omits the minimum,
+// overdraft and available balance.
+func toAccountResponse(acc sqlc.Account, catalog *productCatalog) AccountResponse {
 	var ownerID *string
 	if acc.OwnerID.Valid {
 		// Convert nullable UUID into pointer so omitempty works in JSON output.
@@ -10,18 +27,40 @@ func toAccountResponse(acc sqlc.Account) AccountResponse {
 		ownerID = &s
 	}
 
-	return AccountResponse{
+	var parentID *string
+	if acc.ParentAccountID.Valid {
+		s := acc.ParentAccountID.UUID.String()
+		parentID = &s
+	}
+
+	resp := AccountResponse{
 		ID:        acc.ID.String(),
 		OwnerID:   ownerID,
 		Name:      acc.Name,
-		Balance:   acc.Balance,
+		Balance:   currencies.FormatString(acc.Currency, acc.Balance),
 		Currency:  acc.Currency,
+		Product:   acc.Product,
 		IsSystem:  acc.IsSystem,
 		CreatedAt: acc.CreatedAt.Time,
+		// Payers send bank transfers here; see ReceiveInboundPayment.
+		VirtualAccountNumber: acc.VirtualAccountNumber,
+		ParentAccountID:      parentID,
+	}
+	if catalog != nil {
+		rules, err := service.RulesFor(context.Background(), catalog, acc)
+		balance, balanceErr := decimal.NewFromString(acc.Balance)
+		if err == nil && balanceErr == nil {
+			resp.MinBalance = currencies.Format(acc.Currency, rules.MinBalance)
+			resp.OverdraftLimit = currencies.Format(acc.Currency, rules.OverdraftLimit)
+			resp.AvailableBalance = currencies.Format(acc.Currency, rules.AvailableBalance(balance))
+		}
 	}
+	return resp
 }
 
-func toEntryResponse(entry sqlc.Entry) EntryResponse {
+// toEntryResponse maps an entry of an account in currency, whose decimal places the amounts are
+// written with; an empty currency leaves them as stored.
+func toEntryResponse(entry sqlc.Entry, currency string) EntryResponse {
 	var description string
 	if entry.Description.Valid {
 		// Preserve optional descriptions only when present in DB rows.
@@ -30,11 +69,15 @@ func toEntryResponse(entry sqlc.Entry) EntryResponse {
 
 	operationType := operationTypeToString(entry.OperationType)
 
+	debit, credit := entry.Debit, entry.Credit
+	if currency != "" {
+		debit, credit = currencies.FormatString(currency, debit), currencies.FormatString(currency, credit)
+	}
 	return EntryResponse{
 		ID:            entry.ID.String(),
 		AccountID:     entry.AccountID.String(),
-		Debit:         entry.Debit,
-		Credit:        entry.Credit,
+		Debit:         debit,
+		Credit:        credit,
 		TransactionID: entry.TransactionID.String(),
 		OperationType: operationType,
 		Description:   description,
@@ -42,6 +85,33 @@ func toEntryResponse(entry sqlc.Entry) EntryResponse {
 	}
 }
 
+// toHistoryEntryResponse maps a read model row of an account in currency; withCategory is false
+// for callers other than the account's primary owner, whose categories the row holds.
+func toHistoryEntryResponse(row sqlc.AccountHistory, currency string, withCategory bool) HistoryEntryResponse {
+	resp := HistoryEntryResponse{
+		EntryResponse: EntryResponse{
+			ID:            row.EntryID.String(),
+			AccountID:     row.AccountID.String(),
+			Debit:         currencies.FormatString(currency, row.Debit),
+			Credit:        currencies.FormatString(currency, row.Credit),
+			TransactionID: row.TransactionID.String(),
+			OperationType: row.OperationType,
+			Description:   row.Description,
+			CreatedAt:     row.CreatedAt,
+		},
+		BalanceAfter:     currencies.FormatString(currency, row.BalanceAfter),
+		CounterpartyName: row.CounterpartyName,
+	}
+	if row.CounterpartyAccountID.Valid {
+		resp.CounterpartyAccountID = row.CounterpartyAccountID.UUID.String()
+	}
+	if withCategory && row.CategoryID.Valid {
+		resp.CategoryID = row.CategoryID.UUID.String()
+		resp.Category = row.CategoryName
+	}
+	return resp
+}
+
 func operationTypeToString(v interface{}) string {
 	// sqlc enum decoding can arrive as string or []byte depending on driver path.
 	switch t := v.(type) {
@@ -55,3 +125,1193 @@ func operationTypeToString(v interface{}) string {
 		return ""
 	}
 }
+
+func toReconciliationSummary(rec sqlc.ListBankStatementImportsRow) ReconciliationSummaryResponse {
+	var importedBy *string
+	if rec.ImportedBy.Valid {
+		s := rec.ImportedBy.UUID.String()
+		importedBy = &s
+	}
+
+	return ReconciliationSummaryResponse{
+		ID:              rec.ID.String(),
+		SourceFormat:    rec.SourceFormat,
+		Filename:        rec.Filename,
+		PeriodStart:     rec.PeriodStart,
+		PeriodEnd:       rec.PeriodEnd,
+		LineCount:       rec.LineCount,
+		MatchedCount:    rec.MatchedCount,
+		MissingCount:    rec.MissingCount,
+		UnexpectedCount: rec.UnexpectedCount,
+		ImportedBy:      importedBy,
+		CreatedAt:       rec.CreatedAt,
+	}
+}
+
+func toReconciliationResponse(rec sqlc.BankStatementImport) ReconciliationResponse {
+	return ReconciliationResponse{
+		ReconciliationSummaryResponse: toReconciliationSummary(sqlc.ListBankStatementImportsRow{
+			ID:              rec.ID,
+			SourceFormat:    rec.SourceFormat,
+			Filename:        rec.Filename,
+			PeriodStart:     rec.PeriodStart,
+			PeriodEnd:       rec.PeriodEnd,
+			LineCount:       rec.LineCount,
+			MatchedCount:    rec.MatchedCount,
+			MissingCount:    rec.MissingCount,
+			UnexpectedCount: rec.UnexpectedCount,
+			ImportedBy:      rec.ImportedBy,
+			CreatedAt:       rec.CreatedAt,
+		}),
+		Report: rec.Report,
+	}
+}
+
+func toPayoutResponse(p sqlc.Payout) PayoutResponse {
+	return PayoutResponse{
+		Reference:       p.Reference,
+		AccountID:       p.AccountID.String(),
+		Amount:          p.Amount,
+		Currency:        p.Currency,
+		BankCode:        p.BankCode,
+		AccountNumber:   p.AccountNumber,
+		BeneficiaryName: p.BeneficiaryName,
+		Status:          p.Status,
+		FailureReason:   p.FailureReason.String,
+		CreatedAt:       p.CreatedAt,
+	}
+}
+
+func toPayoutSuspenseCaseResponse(c sqlc.PayoutSuspenseCase) PayoutSuspenseCaseResponse {
+	resp := PayoutSuspenseCaseResponse{
+		ID:             c.ID.String(),
+		PayoutID:       c.PayoutID.String(),
+		AccountID:      c.AccountID.String(),
+		Amount:         c.Amount,
+		Currency:       c.Currency,
+		Reason:         c.Reason,
+		Status:         c.Status,
+		ResolutionNote: c.ResolutionNote,
+		CreatedAt:      c.CreatedAt,
+	}
+	if c.ResolvedBy.Valid {
+		s := c.ResolvedBy.UUID.String()
+		resp.ResolvedBy = &s
+	}
+	if c.ResolvedAt.Valid {
+		resp.ResolvedAt = &c.ResolvedAt.Time
+	}
+	return resp
+}
+
+func toInboundPaymentResponse(p sqlc.InboundPayment) InboundPaymentResponse {
+	resp := InboundPaymentResponse{
+		ID:                   p.ID.String(),
+		Provider:             p.Provider,
+		EventID:              p.EventID,
+		VirtualAccountNumber: p.VirtualAccountNumber,
+		Amount:               p.Amount,
+		Currency:             p.Currency,
+		PayerName:            p.PayerName,
+		PayerReference:       p.PayerReference,
+		Status:               p.Status,
+		TransactionID:        p.TransactionID.String(),
+		CreatedAt:            p.CreatedAt,
+	}
+	if p.AccountID.Valid {
+		id := p.AccountID.UUID.String()
+		resp.AccountID = &id
+	}
+	if p.ResolvedAt.Valid {
+		resp.ResolvedAt = &p.ResolvedAt.Time
+	}
+	return resp
+}
+
+func toKYCResponse(rec sqlc.KycRecord) KYCResponse {
+	resp := KYCResponse{
+		UserID:            rec.UserID.String(),
+		IDType:            rec.IDType,
+		IDNumber:          maskIDNumber(rec.IDNumber),
+		DocumentReference: rec.DocumentReference,
+		Status:            rec.Status,
+		RejectionReason:   rec.RejectionReason.String,
+		Level:             rec.Level,
+		SubmittedAt:       rec.SubmittedAt,
+	}
+	if rec.ReviewedAt.Valid {
+		resp.ReviewedAt = &rec.ReviewedAt.Time
+	}
+	return resp
+}
+
+// maskIDNumber keeps only the last four characters of an identity document number.
+func maskIDNumber(n string) string {
+	if len(n) <= 4 {
+		return strings.Repeat("*", len(n))
+	}
+	return strings.Repeat("*", len(n)-4) + n[len(n)-4:]
+}
+
+func toProfileResponse(u sqlc.User) ProfileResponse {
+	resp := ProfileResponse{
+		UserID:    u.ID.String(),
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Phone:     u.Phone.String,
+		Address: AddressResponse{
+			Line1:      u.AddressLine1,
+			Line2:      u.AddressLine2,
+			City:       u.City,
+			State:      u.State,
+			PostalCode: u.PostalCode,
+			Country:    u.Country,
+		},
+		Locale: u.Locale,
+	}
+	if u.DateOfBirth.Valid {
+		resp.DateOfBirth = u.DateOfBirth.Time.Format(dateOfBirthLayout)
+	}
+	return resp
+}
+
+func toOrganizationResponse(org sqlc.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:        org.ID.String(),
+		Name:      org.Name,
+		Slug:      org.Slug,
+		CreatedAt: org.CreatedAt,
+	}
+}
+
+func toOrgUserResponse(u sqlc.User) OrgUserResponse {
+	resp := OrgUserResponse{
+		ID:        u.ID.String(),
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Role:      u.Role,
+	}
+	if u.CreatedAt.Valid {
+		resp.CreatedAt = &u.CreatedAt.Time
+	}
+	return resp
+}
+
+func toAdminUserResponse(u sqlc.User) AdminUserResponse {
+	resp := AdminUserResponse{
+		OrgUserResponse:       toOrgUserResponse(u),
+		OrgID:                 u.OrgID.String(),
+		LockedReason:          u.LockedReason,
+		FailedLogins:          u.FailedLogins,
+		PasswordResetRequired: u.PasswordResetRequired,
+	}
+	if u.LoginLockedUntil.Valid && u.LoginLockedUntil.Time.After(time.Now()) {
+		resp.LoginLockedUntil = &u.LoginLockedUntil.Time
+	}
+	if u.LockedAt.Valid {
+		resp.LockedAt = &u.LockedAt.Time
+	}
+	if u.ErasedAt.Valid {
+		resp.ErasedAt = &u.ErasedAt.Time
+	}
+	return resp
+}
+
+func toAccountOwnerResponse(row sqlc.ListAccountOwnersRow, acc sqlc.Account) AccountOwnerResponse {
+	resp := AccountOwnerResponse{
+		UserID:    row.UserID.String(),
+		Email:     row.Email,
+		FirstName: row.FirstName,
+		LastName:  row.LastName,
+		Role:      row.Role,
+		Primary:   acc.OwnerID.Valid && acc.OwnerID.UUID == row.UserID,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.AddedBy.Valid {
+		s := row.AddedBy.UUID.String()
+		resp.AddedBy = &s
+	}
+	return resp
+}
+
+func toTransferRequestResponse(req sqlc.TransferRequest) TransferRequestResponse {
+	resp := TransferRequestResponse{
+		ID:            req.ID.String(),
+		FromAccountID: req.FromAccountID.String(),
+		ToAccountID:   req.ToAccountID.String(),
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		Reason:        req.Reason,
+		Status:        req.Status,
+		RequestedBy:   req.RequestedBy.String(),
+		RequestedAt:   req.RequestedAt,
+		DecisionNote:  req.DecisionNote,
+	}
+	if req.DecidedBy.Valid {
+		s := req.DecidedBy.UUID.String()
+		resp.DecidedBy = &s
+	}
+	if req.DecidedAt.Valid {
+		resp.DecidedAt = &req.DecidedAt.Time
+	}
+	if req.TransactionID.Valid {
+		s := req.TransactionID.UUID.String()
+		resp.TransactionID = &s
+	}
+	return resp
+}
+
+func toAdjustmentResponse(adj sqlc.Adjustment, lines []sqlc.AdjustmentLine) AdjustmentResponse {
+	resp := AdjustmentResponse{
+		ID:           adj.ID.String(),
+		ReasonCode:   adj.ReasonCode,
+		Memo:         adj.Memo,
+		Status:       adj.Status,
+		RequestedBy:  adj.RequestedBy.String(),
+		RequestedAt:  adj.RequestedAt,
+		DecisionNote: adj.DecisionNote,
+	}
+	if adj.DecidedBy.Valid {
+		s := adj.DecidedBy.UUID.String()
+		resp.DecidedBy = &s
+	}
+	if adj.DecidedAt.Valid {
+		resp.DecidedAt = &adj.DecidedAt.Time
+	}
+	if adj.TransactionID.Valid {
+		s := adj.TransactionID.UUID.String()
+		resp.TransactionID = &s
+	}
+	for _, l := range lines {
+		resp.Lines = append(resp.Lines, AdjustmentLineResponse{
+			LineNo:      l.LineNo,
+			AccountID:   l.AccountID.String(),
+			Debit:       l.Debit,
+			Credit:      l.Credit,
+			Description: l.Description,
+		})
+	}
+	return resp
+}
+
+func toBalanceRepairResponse(repair sqlc.BalanceRepair) BalanceRepairResponse {
+	return BalanceRepairResponse{
+		ID:                repair.ID.String(),
+		AccountID:         repair.AccountID.String(),
+		StoredBalance:     repair.StoredBalance,
+		CalculatedBalance: repair.CalculatedBalance,
+		Delta:             repair.Delta,
+		Status:            repair.Status,
+		CreatedAt:         repair.CreatedAt,
+	}
+}
+
+// toBalanceRepairDetailResponse adds a repair's adjustment and audit log.
+func toBalanceRepairDetailResponse(repair service.BalanceRepair) BalanceRepairResponse {
+	resp := toBalanceRepairResponse(repair.BalanceRepair)
+	adj := toAdjustmentResponse(repair.Adjustment.Adjustment, repair.Adjustment.Lines)
+	resp.Adjustment = &adj
+	resp.Events = make([]BalanceRepairEventResponse, 0, len(repair.Events))
+	for _, e := range repair.Events {
+		event := BalanceRepairEventResponse{Action: e.Action, CreatedAt: e.CreatedAt}
+		if e.ActorID.Valid {
+			s := e.ActorID.UUID.String()
+			event.ActorID = &s
+		}
+		if err := json.Unmarshal(e.Detail, &event.Detail); err != nil {
+			event.Detail = map[string]string{}
+		}
+		resp.Events = append(resp.Events, event)
+	}
+	return resp
+}
+
+func toReconciliationRunResponse(run sqlc.BalanceReconciliationRun) ReconciliationRunResponse {
+	resp := ReconciliationRunResponse{
+		ID:                 run.ID.String(),
+		Status:             run.Status,
+		RequestedBy:        run.RequestedBy.String(),
+		TotalAccounts:      run.TotalAccounts,
+		CheckedAccounts:    run.CheckedAccounts,
+		MismatchedAccounts: run.MismatchedAccounts,
+		StartedAt:          run.StartedAt,
+		UpdatedAt:          run.UpdatedAt,
+	}
+	if run.FinishedAt.Valid {
+		resp.FinishedAt = &run.FinishedAt.Time
+	}
+	return resp
+}
+
+func toReconciliationResultResponse(result sqlc.BalanceReconciliationResult) ReconciliationResultResponse {
+	return ReconciliationResultResponse{
+		AccountID:         result.AccountID.String(),
+		Currency:          result.Currency,
+		StoredBalance:     result.StoredBalance,
+		CalculatedBalance: result.CalculatedBalance,
+		Matched:           result.Matched,
+		CheckedAt:         result.CheckedAt,
+	}
+}
+
+func toOwnershipTransferResponse(t sqlc.OwnershipTransfer) OwnershipTransferResponse {
+	resp := OwnershipTransferResponse{
+		ID:           t.ID.String(),
+		AccountID:    t.AccountID.String(),
+		FromUserID:   t.FromUserID.String(),
+		ToUserID:     t.ToUserID.String(),
+		Reason:       t.Reason,
+		Status:       t.Status,
+		RequestedBy:  t.RequestedBy.String(),
+		RequestedAt:  t.RequestedAt,
+		DecisionNote: t.DecisionNote,
+	}
+	if t.DecidedBy.Valid {
+		s := t.DecidedBy.UUID.String()
+		resp.DecidedBy = &s
+	}
+	if t.DecidedAt.Valid {
+		resp.DecidedAt = &t.DecidedAt.Time
+	}
+	return resp
+}
+
+func toErasureResponse(e sqlc.UserErasure) ErasureResponse {
+	resp := ErasureResponse{
+		ID:          e.ID.String(),
+		UserID:      e.UserID.String(),
+		Status:      e.Status,
+		Reason:      e.Reason,
+		RequestedBy: e.RequestedBy.String(),
+		RequestedAt: e.RequestedAt,
+		EraseAfter:  e.EraseAfter,
+	}
+	if e.CancelledBy.Valid {
+		s := e.CancelledBy.UUID.String()
+		resp.CancelledBy = &s
+	}
+	if e.CancelledAt.Valid {
+		resp.CancelledAt = &e.CancelledAt.Time
+	}
+	if e.CompletedAt.Valid {
+		resp.CompletedAt = &e.CompletedAt.Time
+	}
+	return resp
+}
+
+// toSessionResponse marks the session current when it is the one the caller's token names.
+func toSessionResponse(s sqlc.Session, current uuid.UUID) SessionResponse {
+	resp := SessionResponse{
+		ID:        s.ID.String(),
+		UserAgent: s.UserAgent,
+		IPAddress: s.IpAddress,
+		CreatedAt: s.CreatedAt,
+		ExpiresAt: s.ExpiresAt,
+		Current:   s.ID == current,
+	}
+	if s.RevokedAt.Valid {
+		resp.RevokedAt = &s.RevokedAt.Time
+	}
+	return resp
+}
+
+func toLoginAttemptResponse(a sqlc.LoginAttempt) LoginAttemptResponse {
+	resp := LoginAttemptResponse{
+		ID:            a.ID.String(),
+		Email:         a.Email,
+		IPAddress:     a.IpAddress,
+		UserAgent:     a.UserAgent,
+		DeviceID:      a.DeviceID,
+		Succeeded:     a.Succeeded,
+		NewDevice:     a.NewDevice,
+		FailureReason: a.FailureReason,
+		CreatedAt:     a.CreatedAt,
+	}
+	if a.OrgID.Valid {
+		s := a.OrgID.UUID.String()
+		resp.OrgID = &s
+	}
+	if a.UserID.Valid {
+		s := a.UserID.UUID.String()
+		resp.UserID = &s
+	}
+	return resp
+}
+
+func toSigningKeyResponse(k sqlc.AccountSigningKey) SigningKeyResponse {
+	resp := SigningKeyResponse{
+		ID:        k.ID.String(),
+		AccountID: k.AccountID.String(),
+		Name:      k.Name,
+		PublicKey: k.PublicKey,
+		CreatedAt: k.CreatedAt,
+	}
+	if k.LastUsedAt.Valid {
+		resp.LastUsedAt = &k.LastUsedAt.Time
+	}
+	if k.RevokedAt.Valid {
+		resp.RevokedAt = &k.RevokedAt.Time
+	}
+	return resp
+}
+
+func toAMLAlertResponse(a sqlc.AmlAlert) AMLAlertResponse {
+	resp := AMLAlertResponse{
+		ID:         a.ID.String(),
+		AccountID:  a.AccountID.String(),
+		Operation:  a.Operation,
+		Direction:  a.Direction,
+		Amount:     a.Amount,
+		Currency:   a.Currency,
+		Action:     a.Action,
+		Rules:      a.Rules,
+		Reason:     a.Reason,
+		Status:     a.Status,
+		ReviewNote: a.ReviewNote,
+		CreatedAt:  a.CreatedAt,
+	}
+	if a.CounterpartyID.Valid {
+		s := a.CounterpartyID.UUID.String()
+		resp.CounterpartyID = &s
+	}
+	if a.TransactionID.Valid {
+		s := a.TransactionID.UUID.String()
+		resp.TransactionID = &s
+	}
+	if a.ReviewedBy.Valid {
+		s := a.ReviewedBy.UUID.String()
+		resp.ReviewedBy = &s
+	}
+	if a.ReviewedAt.Valid {
+		resp.ReviewedAt = &a.ReviewedAt.Time
+	}
+	return resp
+}
+
+func toSanctionsScreeningResponse(s sqlc.SanctionsScreening) SanctionsScreeningResponse {
+	resp := SanctionsScreeningResponse{
+		ID:            s.ID.String(),
+		SubjectType:   s.SubjectType,
+		Name:          s.Name,
+		BankCode:      s.BankCode,
+		AccountNumber: s.AccountNumber,
+		Provider:      s.Provider,
+		Matches:       []sanctions.Match{},
+		Error:         s.Error,
+		Status:        s.Status,
+		ReviewNote:    s.ReviewNote,
+		ScreenedAt:    s.ScreenedAt,
+	}
+	// Matches are always written by screenName as a JSON array.
+	_ = json.Unmarshal(s.Matches, &resp.Matches)
+	if s.UserID.Valid {
+		id := s.UserID.UUID.String()
+		resp.UserID = &id
+	}
+	if s.ReviewedBy.Valid {
+		id := s.ReviewedBy.UUID.String()
+		resp.ReviewedBy = &id
+	}
+	if s.ReviewedAt.Valid {
+		resp.ReviewedAt = &s.ReviewedAt.Time
+	}
+	return resp
+}
+
+func toTransactionLimitResponse(l sqlc.TransactionLimit) TransactionLimitResponse {
+	return TransactionLimitResponse{
+		Currency:  l.Currency,
+		Operation: l.Operation,
+		KYCLevel:  l.KycLevel,
+		MaxAmount: l.MaxAmount,
+		UpdatedBy: l.UpdatedBy.String(),
+		UpdatedAt: l.UpdatedAt,
+	}
+}
+
+func toDisputeResponse(d sqlc.Dispute) DisputeResponse {
+	resp := DisputeResponse{
+		ID:                    d.ID.String(),
+		TransactionID:         d.TransactionID.String(),
+		AccountID:             d.AccountID.String(),
+		CounterpartyAccountID: d.CounterpartyAccountID.String(),
+		Amount:                d.Amount,
+		Currency:              d.Currency,
+		Reason:                d.Reason,
+		Status:                d.Status,
+		OpenedBy:              d.OpenedBy.String(),
+		OpenedAt:              d.OpenedAt,
+		HoldTransactionID:     d.HoldTransactionID.String(),
+		ResolutionNote:        d.ResolutionNote,
+	}
+	if d.ResolvedBy.Valid {
+		s := d.ResolvedBy.UUID.String()
+		resp.ResolvedBy = &s
+	}
+	if d.ResolvedAt.Valid {
+		resp.ResolvedAt = &d.ResolvedAt.Time
+	}
+	if d.ResolutionTransactionID.Valid {
+		s := d.ResolutionTransactionID.UUID.String()
+		resp.ResolutionTransactionID = &s
+	}
+	return resp
+}
+
+func toSplitPaymentResponse(p service.SplitPayment) SplitPaymentResponse {
+	entries := make([]EntryResponse, 0, len(p.Entries))
+	for _, e := range p.Entries {
+		entries = append(entries, toEntryResponse(e, p.Currency))
+	}
+	return SplitPaymentResponse{
+		TransactionID: p.TransactionID.String(),
+		Amount:        currencies.Format(p.Currency, p.Total),
+		Fee:           currencies.Format(p.Currency, p.Fee),
+		Currency:      p.Currency,
+		Entries:       entries,
+	}
+}
+
+func toEscrowResponse(e sqlc.Escrow) EscrowResponse {
+	resp := EscrowResponse{
+		ID:                   e.ID.String(),
+		BuyerAccountID:       e.BuyerAccountID.String(),
+		SellerAccountID:      e.SellerAccountID.String(),
+		Amount:               e.Amount,
+		Currency:             e.Currency,
+		Description:          e.Description,
+		Status:               e.Status,
+		CreatedBy:            e.CreatedBy.String(),
+		CreatedAt:            e.CreatedAt,
+		FundingTransactionID: e.FundingTransactionID.String(),
+		SettlementNote:       e.SettlementNote,
+	}
+	if e.SettledBy.Valid {
+		s := e.SettledBy.UUID.String()
+		resp.SettledBy = &s
+	}
+	if e.SettledAt.Valid {
+		resp.SettledAt = &e.SettledAt.Time
+	}
+	if e.SettlementTransactionID.Valid {
+		s := e.SettlementTransactionID.UUID.String()
+		resp.SettlementTransactionID = &s
+	}
+	return resp
+}
+
+// toPaymentRequestResponse reports pr with its status as of now, so pending requests past expiry read as expired.
+func toPaymentRequestResponse(pr sqlc.PaymentRequest, link string, now time.Time) PaymentRequestResponse {
+	resp := PaymentRequestResponse{
+		Token:     pr.Token,
+		Link:      link,
+		AccountID: pr.AccountID.String(),
+		Amount:    pr.Amount,
+		Currency:  pr.Currency,
+		Memo:      pr.Memo,
+		Status:    service.PaymentRequestStatus(pr, now),
+		ExpiresAt: pr.ExpiresAt,
+		CreatedAt: pr.CreatedAt,
+	}
+	if pr.PaidAt.Valid {
+		resp.PaidAt = &pr.PaidAt.Time
+	}
+	if pr.PayerAccountID.Valid {
+		s := pr.PayerAccountID.UUID.String()
+		resp.PayerAccountID = &s
+	}
+	if pr.TransactionID.Valid {
+		s := pr.TransactionID.UUID.String()
+		resp.TransactionID = &s
+	}
+	return resp
+}
+
+func toTransactionResponse(tx sqlc.Transaction) TransactionResponse {
+	return TransactionResponse{
+		ID:            tx.ID.String(),
+		OperationType: tx.OperationType,
+		Status:        tx.Status,
+		RequestID:     tx.RequestID.String,
+		CreatedAt:     tx.CreatedAt,
+		UpdatedAt:     tx.UpdatedAt,
+	}
+}
+
+// toAdminTransactionResponse adds the fraud risk assessment, which customers are not shown.
+func toAdminTransactionResponse(tx sqlc.Transaction) TransactionResponse {
+	resp := toTransactionResponse(tx)
+	if tx.RiskScore.Valid {
+		resp.Risk = &TransactionRiskResponse{
+			Score:    tx.RiskScore.Int16,
+			Decision: tx.RiskDecision.String,
+			Factors:  tx.RiskFactors,
+		}
+	}
+	return resp
+}
+
+func toLedgerEventResponse(e sqlc.LedgerEvent) LedgerEventResponse {
+	return LedgerEventResponse{
+		Seq:           e.Seq,
+		Type:          e.EventType,
+		TransactionID: e.TransactionID.String(),
+		Payload:       e.Payload,
+		RequestID:     e.RequestID.String,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+func toLedgerProjectionResponse(drift []sqlc.ListLedgerProjectionDriftRow) LedgerProjectionResponse {
+	resp := LedgerProjectionResponse{Consistent: len(drift) == 0, Drift: make([]LedgerDriftResponse, 0, len(drift))}
+	for _, d := range drift {
+		resp.Drift = append(resp.Drift, LedgerDriftResponse{
+			AccountID:       d.AccountID.String(),
+			Currency:        d.Currency,
+			StoredBalance:   d.StoredBalance,
+			EntriesBalance:  d.EntriesBalance,
+			ReplayedBalance: d.ReplayedBalance,
+		})
+	}
+	return resp
+}
+
+func toEntryChainResponse(v service.ChainVerification) EntryChainResponse {
+	resp := EntryChainResponse{
+		Intact:   v.Intact(),
+		Accounts: v.Accounts,
+		Entries:  v.Entries,
+		Breaks:   make([]EntryChainBreakResponse, 0, len(v.Breaks)),
+	}
+	for _, b := range v.Breaks {
+		br := EntryChainBreakResponse{AccountID: b.AccountID.String(), Seq: b.Seq, Problems: b.Problems}
+		if b.EntryID.Valid {
+			br.EntryID = b.EntryID.UUID.String()
+		}
+		resp.Breaks = append(resp.Breaks, br)
+	}
+	return resp
+}
+
+func toMerkleRootResponse(root sqlc.MerkleRoot) MerkleRootResponse {
+	return MerkleRootResponse{
+		ID:        root.ID.String(),
+		RootHash:  hex.EncodeToString(root.RootHash),
+		LeafCount: root.LeafCount,
+		CreatedAt: root.CreatedAt,
+	}
+}
+
+func toMerkleProofResponse(entry sqlc.Entry, proof service.MerkleProof) MerkleProofResponse {
+	resp := MerkleProofResponse{
+		Entry: ChainedEntryResponse{
+			ID:            entry.ID.String(),
+			AccountID:     entry.AccountID.String(),
+			TransactionID: entry.TransactionID.String(),
+			ChainSeq:      entry.ChainSeq,
+			Debit:         entry.Debit,
+			Credit:        entry.Credit,
+			OperationType: entry.OperationType,
+			CreatedAt:     entry.CreatedAt.Time,
+			PrevHash:      hex.EncodeToString(entry.PrevHash),
+			Hash:          hex.EncodeToString(entry.Hash),
+		},
+		Root:      toMerkleRootResponse(proof.Root),
+		LeafIndex: proof.LeafIndex,
+		LeafHash:  hex.EncodeToString(proof.Leaf),
+		Path:      make([]string, 0, len(proof.Path)),
+	}
+	if entry.Description.Valid {
+		resp.Entry.Description = &entry.Description.String
+	}
+	for _, p := range proof.Path {
+		resp.Path = append(resp.Path, hex.EncodeToString(p))
+	}
+	return resp
+}
+
+func toTransferJobResponse(job sqlc.TransferJob) TransferJobResponse {
+	resp := TransferJobResponse{
+		ID:            job.ID.String(),
+		FromAccountID: job.FromAccountID.String(),
+		ToAccountID:   job.ToAccountID.String(),
+		Amount:        job.Amount,
+		Status:        job.Status,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+	}
+	// Transient reasons are overwritten on retry; only a failed job's reason is final.
+	if job.Status == service.TransferJobFailed {
+		resp.FailureReason = job.FailureReason
+	}
+	return resp
+}
+
+// toPayoutBatchResponse summarizes a batch from its rows' transfer jobs.
+func toPayoutBatchResponse(batch sqlc.PayoutBatch, jobs []sqlc.TransferJob) PayoutBatchResponse {
+	resp := PayoutBatchResponse{
+		ID:              batch.ID.String(),
+		SourceAccountID: batch.SourceAccountID.String(),
+		Filename:        batch.Filename,
+		TotalAmount:     batch.TotalAmount,
+		Currency:        batch.Currency,
+		RowCount:        batch.RowCount,
+		CreatedAt:       batch.CreatedAt,
+		Rows:            make([]PayoutBatchRowResponse, 0, len(jobs)),
+	}
+	for _, job := range jobs {
+		row := toPayoutBatchRowResponse(job)
+		switch row.Status {
+		case service.TransferJobPosted:
+			resp.Posted++
+		case service.TransferJobFailed:
+			resp.Failed++
+		default:
+			resp.Pending++
+		}
+		resp.Rows = append(resp.Rows, row)
+	}
+	resp.Status = "completed"
+	if resp.Pending > 0 {
+		resp.Status = "processing"
+	}
+	return resp
+}
+
+func toPayoutBatchRowResponse(job sqlc.TransferJob) PayoutBatchRowResponse {
+	t := toTransferJobResponse(job)
+	row := PayoutBatchRowResponse{
+		Line:          job.BatchRow.Int32,
+		AccountID:     t.ToAccountID,
+		Amount:        t.Amount,
+		Narration:     job.Narration,
+		Status:        t.Status,
+		FailureReason: t.FailureReason,
+	}
+	if job.Status == service.TransferJobPosted {
+		row.TransactionID = t.ID
+	}
+	return row
+}
+
+func toJobResponse(job sqlc.Job) JobResponse {
+	return JobResponse{
+		ID:          job.ID.String(),
+		Kind:        job.Kind,
+		Payload:     job.Payload,
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+		LastError:   job.LastError,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+	}
+}
+
+func toWebhookEndpointResponse(ep sqlc.WebhookEndpoint) WebhookEndpointResponse {
+	resp := WebhookEndpointResponse{
+		ID:                  ep.ID.String(),
+		URL:                 ep.Url,
+		Healthy:             ep.Healthy,
+		ConsecutiveFailures: ep.ConsecutiveFailures,
+		CreatedAt:           ep.CreatedAt,
+	}
+	if ep.PreviousSecretExpiresAt.Valid {
+		resp.PreviousSecretExpiresAt = &ep.PreviousSecretExpiresAt.Time
+	}
+	return resp
+}
+
+func toWebhookDeliveryResponse(d sqlc.WebhookDelivery) WebhookDeliveryResponse {
+	resp := WebhookDeliveryResponse{
+		ID:            d.ID.String(),
+		EndpointID:    d.EndpointID.String(),
+		EventType:     d.EventType,
+		TransactionID: d.TransactionID.String(),
+		Payload:       d.Payload,
+		Status:        d.Status,
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		LastError:     d.LastError,
+		CreatedAt:     d.CreatedAt,
+	}
+	if d.DeliveredAt.Valid {
+		resp.DeliveredAt = &d.DeliveredAt.Time
+	}
+	return resp
+}
+
+func toRateResponse(r rates.Rate) RateResponse {
+	return RateResponse{
+		Base:     r.Base,
+		Quote:    r.Quote,
+		Rate:     r.Rate.String(),
+		Source:   r.Source,
+		AsOf:     r.AsOf,
+		Inverted: r.Inverted,
+		Stale:    r.Stale,
+	}
+}
+
+func toRateOverrideResponse(o sqlc.FxRateOverride) RateOverrideResponse {
+	resp := RateOverrideResponse{
+		Base:      o.BaseCurrency,
+		Quote:     o.QuoteCurrency,
+		Rate:      o.Rate,
+		Reason:    o.Reason,
+		SetBy:     o.SetBy.String(),
+		CreatedAt: o.CreatedAt,
+	}
+	if o.ExpiresAt.Valid {
+		resp.ExpiresAt = &o.ExpiresAt.Time
+	}
+	return resp
+}
+
+func toConversionQuoteResponse(q service.ConversionQuote) ConversionQuoteResponse {
+	return ConversionQuoteResponse{
+		SellCurrency: q.SellCurrency,
+		SellAmount:   currencies.Format(q.SellCurrency, q.SellAmount),
+		BuyCurrency:  q.BuyCurrency,
+		BuyAmount:    currencies.Format(q.BuyCurrency, q.BuyAmount),
+		MidRate:      q.MidRate.String(),
+		CustomerRate: q.CustomerRate.String(),
+		Margin:       currencies.Format(q.BuyCurrency, q.Margin),
+		SpreadBps:    q.SpreadBps,
+		RateSource:   q.RateSource,
+		AsOf:         q.AsOf,
+	}
+}
+
+func toConversionResponse(c sqlc.FxConversion) ConversionResponse {
+	return ConversionResponse{
+		TransactionID: c.TransactionID.String(),
+		FromAccountID: c.FromAccountID.String(),
+		ToAccountID:   c.ToAccountID.String(),
+		SellCurrency:  c.SellCurrency,
+		SellAmount:    c.SellAmount,
+		BuyCurrency:   c.BuyCurrency,
+		BuyAmount:     c.BuyAmount,
+		MidRate:       c.MidRate,
+		CustomerRate:  c.CustomerRate,
+		Margin:        c.Margin,
+		SpreadBps:     c.SpreadBps,
+		RateSource:    c.RateSource,
+		RateAsOf:      c.RateAsOf,
+		CreatedAt:     c.CreatedAt,
+	}
+}
+
+func toFXSpreadResponse(s sqlc.FxSpread) FXSpreadResponse {
+	return FXSpreadResponse{
+		Base:      s.BaseCurrency,
+		Quote:     s.QuoteCurrency,
+		SpreadBps: s.SpreadBps,
+		UpdatedBy: s.UpdatedBy.String(),
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func toAccountProductResponse(p sqlc.AccountProduct) AccountProductResponse {
+	var maxDebit *string
+	if p.MaxDebit.Valid {
+		maxDebit = &p.MaxDebit.String
+	}
+	return AccountProductResponse{
+		Product:        p.Product,
+		Currency:       p.Currency,
+		MinBalance:     p.MinBalance,
+		OverdraftLimit: p.OverdraftLimit,
+		TransferFee:    p.TransferFee,
+		WithdrawalFee:  p.WithdrawalFee,
+		MaxDebit:       maxDebit,
+		UpdatedBy:      p.UpdatedBy.String(),
+		UpdatedAt:      p.UpdatedAt,
+	}
+}
+
+func toInterestTierResponse(t sqlc.InterestTier) InterestTierResponse {
+	return InterestTierResponse{
+		Product:    t.Product,
+		Currency:   t.Currency,
+		MinBalance: t.MinBalance,
+		RateBps:    t.RateBps,
+		UpdatedAt:  t.UpdatedAt,
+	}
+}
+
+// toProductResponse maps p with its per-currency rules; rules may be nil.
+func toProductResponse(p sqlc.Product, rules []AccountProductResponse) ProductResponse {
+	if rules == nil {
+		rules = []AccountProductResponse{}
+	}
+	return ProductResponse{
+		Code:               p.Code,
+		Name:               p.Name,
+		InterestRateBps:    p.InterestRateBps,
+		WithdrawalsEnabled: p.WithdrawalsEnabled,
+		TransfersEnabled:   p.TransfersEnabled,
+		Rules:              rules,
+		UpdatedAt:          p.UpdatedAt,
+	}
+}
+
+// toRecentRecipientResponse maps a recent recipient, masking the owner's name.
+func toRecentRecipientResponse(r sqlc.ListRecentRecipientsRow) RecentRecipientResponse {
+	return RecentRecipientResponse{
+		AccountID:     r.ID.String(),
+		AccountNumber: r.VirtualAccountNumber,
+		DisplayName:   service.MaskedName(sqlc.User{FirstName: r.FirstName, LastName: r.LastName, Email: r.Email}),
+		Currency:      r.Currency,
+		LastSentAt:    r.LastSentAt,
+		TransferCount: r.TransferCount,
+	}
+}
+
+func toAnnotationResponse(a sqlc.TransactionAnnotation) AnnotationResponse {
+	return AnnotationResponse{
+		ID:            a.ID.String(),
+		TransactionID: a.TransactionID.String(),
+		Note:          a.Note,
+		CreatedAt:     a.CreatedAt,
+	}
+}
+
+func toCategoryResponse(c sqlc.Category) CategoryResponse {
+	return CategoryResponse{ID: c.ID.String(), Name: c.Name, CreatedAt: c.CreatedAt}
+}
+
+func toCategoryRuleResponse(r sqlc.CategoryRule) CategoryRuleResponse {
+	resp := CategoryRuleResponse{
+		ID:         r.ID.String(),
+		CategoryID: r.CategoryID.String(),
+		Priority:   r.Priority,
+		CreatedAt:  r.CreatedAt,
+	}
+	if r.DescriptionPattern.Valid {
+		resp.DescriptionContains = &r.DescriptionPattern.String
+	}
+	if r.CounterpartyAccountID.Valid {
+		id := r.CounterpartyAccountID.UUID.String()
+		resp.CounterpartyAccountID = &id
+	}
+	return resp
+}
+
+// toSpendingBucketResponse maps a spending row; rows without a category are uncategorized.
+func toSpendingBucketResponse(r sqlc.SpendByCategoryRow) SpendingBucketResponse {
+	resp := SpendingBucketResponse{
+		PeriodStart:  r.PeriodStart.UTC(),
+		CategoryName: r.CategoryName,
+		Total:        r.Total,
+		EntryCount:   r.EntryCount,
+	}
+	if r.CategoryID == "" {
+		resp.CategoryName = "Uncategorized"
+	} else {
+		resp.CategoryID = &r.CategoryID
+	}
+	return resp
+}
+
+// toSavingsGoalResponse reports goal with progress against balance and its lock as of now.
+func toSavingsGoalResponse(goal sqlc.SavingsGoal, balance, currency string, now time.Time) (SavingsGoalResponse, error) {
+	progress, err := service.GoalProgress(goal, balance)
+	if err != nil {
+		return SavingsGoalResponse{}, err
+	}
+	resp := SavingsGoalResponse{
+		ID:              goal.ID.String(),
+		WalletID:        goal.WalletID.String(),
+		Name:            goal.Name,
+		Currency:        currency,
+		TargetAmount:    goal.TargetAmount,
+		Locked:          service.GoalLocked(goal, now),
+		Saved:           progress.Saved.StringFixed(4),
+		Remaining:       progress.Remaining.StringFixed(4),
+		ProgressPercent: progress.Percent.StringFixed(2),
+		Reached:         progress.Reached,
+		CreatedAt:       goal.CreatedAt,
+	}
+	if goal.TargetDate.Valid {
+		d := goal.TargetDate.Time.Format(service.GoalDateLayout)
+		resp.TargetDate = &d
+	}
+	if goal.LockedUntil.Valid {
+		d := goal.LockedUntil.Time.Format(service.GoalDateLayout)
+		resp.LockedUntil = &d
+	}
+	if goal.ContributionAmount.Valid {
+		resp.Contribution = &SavingsContributionResponse{
+			Amount:    goal.ContributionAmount.String,
+			Interval:  goal.ContributionInterval.String,
+			LastError: goal.LastContributionError,
+		}
+		if goal.NextContributionAt.Valid {
+			resp.Contribution.NextAt = &goal.NextContributionAt.Time
+		}
+	}
+	return resp, nil
+}
+
+func toSweepRuleResponse(rule sqlc.SweepRule, currency string) SweepRuleResponse {
+	return SweepRuleResponse{
+		ID:              rule.ID.String(),
+		AccountID:       rule.AccountID.String(),
+		TargetAccountID: rule.TargetAccountID.String(),
+		Currency:        currency,
+		Threshold:       currencies.FormatString(currency, rule.Threshold),
+		Frequency:       rule.Frequency,
+		NextRunAt:       rule.NextRunAt,
+		CreatedAt:       rule.CreatedAt,
+	}
+}
+
+func toSweepExecutionResponse(e sqlc.SweepExecution, currency string) SweepExecutionResponse {
+	resp := SweepExecutionResponse{
+		ID:        e.ID.String(),
+		RuleID:    e.RuleID.String(),
+		Status:    e.Status,
+		Amount:    currencies.FormatString(currency, e.Amount),
+		Reason:    e.Reason,
+		CreatedAt: e.CreatedAt,
+	}
+	if e.TransactionID.Valid {
+		id := e.TransactionID.UUID.String()
+		resp.TransactionID = &id
+	}
+	return resp
+}
+
+func toCashPoolResponse(pool sqlc.CashPool, currency string) CashPoolResponse {
+	return CashPoolResponse{
+		ID:              pool.ID.String(),
+		MasterAccountID: pool.MasterAccountID.String(),
+		Name:            pool.Name,
+		Currency:        currency,
+		CreatedAt:       pool.CreatedAt,
+	}
+}
+
+func toCashPoolMemberResponse(m sqlc.CashPoolMember, acc sqlc.Account) CashPoolMemberResponse {
+	return CashPoolMemberResponse{
+		AccountID:     m.AccountID.String(),
+		Name:          acc.Name,
+		Balance:       currencies.FormatString(acc.Currency, acc.Balance),
+		TargetBalance: currencies.FormatString(acc.Currency, m.TargetBalance),
+		AddedAt:       m.CreatedAt,
+	}
+}
+
+func toCashPoolMovementResponse(m sqlc.CashPoolMovement, currency string) CashPoolMovementResponse {
+	resp := CashPoolMovementResponse{
+		ID:            m.ID.String(),
+		AccountID:     m.AccountID.String(),
+		Kind:          m.Kind,
+		Amount:        currencies.FormatString(currency, m.Amount),
+		TransactionID: m.TransactionID.String(),
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.CreatedBy.Valid {
+		id := m.CreatedBy.UUID.String()
+		resp.CreatedBy = &id
+	}
+	return resp
+}
+
+func toLoanResponse(loan sqlc.Loan, installments []sqlc.LoanInstallment, repayments []sqlc.LoanRepayment) LoanResponse {
+	resp := LoanResponse{
+		ID:                        loan.ID.String(),
+		AccountID:                 loan.AccountID.String(),
+		Principal:                 currencies.FormatString(loan.Currency, loan.Principal),
+		Currency:                  loan.Currency,
+		AnnualRateBps:             loan.AnnualRateBps,
+		TermMonths:                loan.TermMonths,
+		Status:                    loan.Status,
+		Delinquency:               loan.Delinquency,
+		DaysPastDue:               loan.DaysPastDue,
+		DisbursementTransactionID: loan.DisbursementTransactionID.String(),
+		CreatedAt:                 loan.CreatedAt,
+	}
+	if loan.PaidOffAt.Valid {
+		resp.PaidOffAt = &loan.PaidOffAt.Time
+	}
+	for _, inst := range installments {
+		row := LoanInstallmentResponse{
+			Number:        inst.Number,
+			DueDate:       inst.DueDate.Format("2006-01-02"),
+			PrincipalDue:  currencies.FormatString(loan.Currency, inst.PrincipalDue),
+			InterestDue:   currencies.FormatString(loan.Currency, inst.InterestDue),
+			PrincipalPaid: currencies.FormatString(loan.Currency, inst.PrincipalPaid),
+			InterestPaid:  currencies.FormatString(loan.Currency, inst.InterestPaid),
+		}
+		if inst.PaidAt.Valid {
+			row.PaidAt = &inst.PaidAt.Time
+		}
+		resp.Installments = append(resp.Installments, row)
+	}
+	for _, rp := range repayments {
+		resp.Repayments = append(resp.Repayments, toRepaymentResponse(rp, loan.Currency))
+	}
+	return resp
+}
+
+func toRepaymentResponse(rp sqlc.LoanRepayment, currency string) RepaymentResponse {
+	return RepaymentResponse{
+		ID:            rp.ID.String(),
+		TransactionID: rp.TransactionID.String(),
+		Amount:        currencies.FormatString(currency, rp.Amount),
+		Principal:     currencies.FormatString(currency, rp.Principal),
+		Interest:      currencies.FormatString(currency, rp.Interest),
+		CreatedAt:     rp.CreatedAt,
+	}
+}
+
+func toReversalResponse(r sqlc.TransactionReversal) ReversalResponse {
+	return ReversalResponse{
+		TransactionID:         r.TransactionID.String(),
+		ReversalTransactionID: r.ReversalTransactionID.String(),
+		FeeAmount:             r.FeeAmount,
+		FeesRefunded:          r.FeesRefunded,
+		Reason:                r.Reason,
+		ReversedBy:            r.ReversedBy.String(),
+		CreatedAt:             r.CreatedAt,
+	}
+}
+
+func toRefundSummaryResponse(s service.RefundSummary) RefundSummaryResponse {
+	refunds := make([]TransactionRefundResponse, 0, len(s.Refunds))
+	for _, r := range s.Refunds {
+		refunds = append(refunds, TransactionRefundResponse{
+			ID:                  r.ID.String(),
+			RefundTransactionID: r.RefundTransactionID.String(),
+			Amount:              r.Amount,
+			Reason:              r.Reason,
+			RefundedBy:          r.RefundedBy.String(),
+			CreatedAt:           r.CreatedAt,
+		})
+	}
+	return RefundSummaryResponse{
+		TransactionID: s.TransactionID.String(),
+		Currency:      s.Currency,
+		Amount:        s.Amount.StringFixed(4),
+		Refunded:      s.Refunded.StringFixed(4),
+		Remaining:     s.Remaining().StringFixed(4),
+		Refunds:       refunds,
+	}
+}
+
+func toTaxRuleResponse(r sqlc.TaxRule) TaxRuleResponse {
+	return TaxRuleResponse{
+		Code:          r.Code,
+		Name:          r.Name,
+		OperationType: r.OperationType,
+		RateBps:       r.RateBps,
+		Active:        r.Active,
+		UpdatedBy:     r.UpdatedBy.String(),
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
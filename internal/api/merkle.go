@@ -0,0 +1,118 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// GetEntryProof godoc
+// @Summary      Prove an entry is under a published Merkle root
+// @Description  Returns the entry's chained content, its Merkle root and the audit path between them, so the entry can be checked against the published root without trusting this API: hash the entry as internal/hashchain does to get hash, take SHA-256(0x00 || hash) as the leaf, then hash up the path per RFC 6962 (SHA-256(0x01 || left || right)) to reach root_hash. Roots are published hourly over the entries posted since the last one; 404 until the entry's root is out.
+// @Tags         statements
+// @Produce      json
+// @Param        id   path      string  true  "Entry ID"
+// @Success      200  {object}  MerkleProofResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /entries/{id}/proof [get]
+// @Security     Bearer
+func (h *Handler) GetEntryProof(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	entry, ok := h.visibleEntry(w, r, userID)
+	if !ok {
+		return
+	}
+	proof, err := h.ledger.MerkleProof(r.Context(), entry.ID)
+	if errors.Is(err, service.ErrEntryNotRooted) {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("entry_id", entry.ID.String()).Msg("Failed to build Merkle proof")
+		respondError(w, http.StatusInternalServerError, "failed to build merkle proof")
+		return
+	}
+	respondJSON(w, http.StatusOK, toMerkleProofResponse(entry, proof))
+}
+
+// ListMerkleRoots godoc
+// @Summary      List published Merkle roots
+// @Description  Returns a page of the Merkle roots published over the entries, newest first, wrapped in {data, page}. Each covers the entries posted since the one before it.
+// @Tags         statements
+// @Produce      json
+// @Param        limit     query     int     false  "Limit (default 20, max 100)"
+// @Param        offset    query     int     false  "Offset (default 0)"
+// @Param        envelope  query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200       {object}  PagedResponse{data=[]MerkleRootResponse}
+// @Failure      401       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
+// @Router       /ledger/merkle-roots [get]
+// @Security     Bearer
+func (h *Handler) ListMerkleRoots(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePage(r)
+	roots, err := h.store.ListMerkleRoots(r.Context(), sqlc.ListMerkleRootsParams{
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		Offset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list Merkle roots")
+		respondError(w, http.StatusInternalServerError, "failed to list merkle roots")
+		return
+	}
+	total, err := h.store.CountMerkleRoots(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count Merkle roots")
+		respondError(w, http.StatusInternalServerError, "failed to list merkle roots")
+		return
+	}
+
+	resp := make([]MerkleRootResponse, 0, len(roots))
+	for _, root := range roots {
+		resp = append(resp, toMerkleRootResponse(root))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// GetMerkleRoot godoc
+// @Summary      Get a published Merkle root
+// @Tags         statements
+// @Produce      json
+// @Param        id   path      string  true  "Merkle root ID"
+// @Success      200  {object}  MerkleRootResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /ledger/merkle-roots/{id} [get]
+// @Security     Bearer
+func (h *Handler) GetMerkleRoot(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid merkle root ID")
+		return
+	}
+	root, err := h.ledger.GetMerkleRoot(r.Context(), id)
+	if errors.Is(err, service.ErrMerkleRootNotFound) {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("root_id", id.String()).Msg("Failed to get Merkle root")
+		respondError(w, http.StatusInternalServerError, "failed to get merkle root")
+		return
+	}
+	respondJSON(w, http.StatusOK, toMerkleRootResponse(root))
+}
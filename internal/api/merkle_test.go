@@ -0,0 +1,64 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/hashchain"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/merkle"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestToMerkleProofResponse(t *testing.T) {
+	// An auditor can recompute the entry's hash and walk the path to the root from the response alone.
+	entry := sqlc.Entry{
+		ID:            uuid.New(),
+		AccountID:     uuid.New(),
+		TransactionID: uuid.New(),
+		Debit:         "0.0000",
+		Credit:        "25.0000",
+		OperationType: "deposit",
+		CreatedAt:     sql.NullTime{Time: time.Date(2026, 3, 4, 5, 6, 7, 8000, time.UTC), Valid: true},
+		ChainSeq:      1,
+	}
+	entry.Hash = hashchain.Hash(nil, hashchain.Entry{
+		ID: entry.ID, AccountID: entry.AccountID, TransactionID: entry.TransactionID, Seq: entry.ChainSeq,
+		Debit: entry.Debit, Credit: entry.Credit, OperationType: entry.OperationType, CreatedAt: entry.CreatedAt,
+	})
+	leaves := [][]byte{merkle.LeafHash([]byte("other")), merkle.LeafHash(entry.Hash), merkle.LeafHash([]byte("another"))}
+	root := sqlc.MerkleRoot{ID: uuid.New(), RootHash: merkle.Root(leaves), LeafCount: 3}
+
+	resp := toMerkleProofResponse(entry, service.MerkleProof{Root: root, Leaf: leaves[1], Path: merkle.Proof(leaves, 1), LeafIndex: 1})
+	assert.Nil(t, resp.Entry.Description)
+	assert.Empty(t, resp.Entry.PrevHash)
+
+	id, err := uuid.Parse(resp.Entry.ID)
+	require.NoError(t, err)
+	accountID, err := uuid.Parse(resp.Entry.AccountID)
+	require.NoError(t, err)
+	transactionID, err := uuid.Parse(resp.Entry.TransactionID)
+	require.NoError(t, err)
+	hash := hashchain.Hash(nil, hashchain.Entry{
+		ID: id, AccountID: accountID, TransactionID: transactionID, Seq: resp.Entry.ChainSeq,
+		Debit: resp.Entry.Debit, Credit: resp.Entry.Credit, OperationType: resp.Entry.OperationType,
+		CreatedAt: sql.NullTime{Time: resp.Entry.CreatedAt, Valid: true},
+	})
+	assert.Equal(t, resp.Entry.Hash, hex.EncodeToString(hash))
+
+	path := make([][]byte, 0, len(resp.Path))
+	for _, p := range resp.Path {
+		b, err := hex.DecodeString(p)
+		require.NoError(t, err)
+		path = append(path, b)
+	}
+	rootHash, err := hex.DecodeString(resp.Root.RootHash)
+	require.NoError(t, err)
+	assert.True(t, merkle.Verify(merkle.LeafHash(hash), resp.LeafIndex, int(resp.Root.LeafCount), path, rootHash))
+}
@@ -1,19 +1,42 @@
 package api
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/url"
 	"os"
+	"slices"
 	"time"
 
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/jwtauth/v5"
 	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
 )
 
-var (
-	// TokenAuth holds the JWT authenticator used by the API package.
-	TokenAuth *jwtauth.JWTAuth
+// tokenAuth holds the keys the API package signs and verifies JWTs with.
+var tokenAuth *tokenKeys
+
+// User roles carried in the JWT "role" claim. RoleAdmin operates the whole
+// deployment; RoleOrgAdmin manages the users and accounts of one organization;
+// RoleApprover is the checker who approves transfers that staff requested;
+// RoleCompliance reviews the operations AML screening flagged, held or blocked.
+const (
+	RoleCustomer   = "customer"
+	RoleOrgAdmin   = "org_admin"
+	RoleApprover   = "approver"
+	RoleCompliance = "compliance"
+	RoleAdmin      = "admin"
 )
 
+// DefaultOrgSlug names the organization used when register or login does not pick one.
+const DefaultOrgSlug = "default"
+
 // InitTokenAuthFromEnv initializes JWT auth using the JWT_SECRET environment variable.
 func InitTokenAuthFromEnv() error {
 	// Keep bootstrap simple: this function is called once from main().
@@ -21,32 +44,217 @@ func InitTokenAuthFromEnv() error {
 	return InitTokenAuth(secret)
 }
 
-// InitTokenAuth initializes JWT auth with the provided secret.
+// InitTokenAuth initializes JWT auth with an HS256 shared secret. InitTokenKeys replaces it with
+// asymmetric keys other services can verify through the JWKS.
 func InitTokenAuth(secret string) error {
 	// Fail fast if JWT configuration is insecure or missing.
 	if secret == "" {
 		return errors.New("JWT_SECRET environment variable is required")
 	}
-
-	if len(secret) < 32 {
-		return errors.New("JWT_SECRET must be at least 32 characters")
+	key, err := secretJWK(secret)
+	if err != nil {
+		return err
+	}
+	verify := jwk.NewSet()
+	if err := verify.AddKey(key); err != nil {
+		return err
 	}
 
-	TokenAuth = jwtauth.New("HS256", []byte(secret), nil)
+	tokenAuth = &tokenKeys{signer: key, verify: verify, public: jwk.NewSet()}
 	return nil
 }
 
-// GenerateToken creates a signed JWT for the given user ID.
-func GenerateToken(userID uuid.UUID) (string, error) {
-	if TokenAuth == nil {
+// GenerateToken creates a signed JWT for the given user, their organization and role.
+func GenerateToken(userID, orgID uuid.UUID, role string) (string, error) {
+	// Include user identity, tenant and expiry in signed JWT claims.
+	return signToken(map[string]interface{}{
+		"user_id": userID.String(),
+		"org_id":  orgID.String(),
+		"role":    role,
+		"exp":     time.Now().Add(tokenTTL).Unix(),
+	})
+}
+
+// signToken signs claims with the active key; asymmetric keys name themselves in the kid header.
+func signToken(claims map[string]interface{}) (string, error) {
+	if tokenAuth == nil {
 		return "", errors.New("token auth is not initialized")
 	}
+	t := jwt.New()
+	for k, v := range claims {
+		if err := t.Set(k, v); err != nil {
+			return "", err
+		}
+	}
+	alg, _ := tokenAuth.signer.Algorithm()
+	signed, err := jwt.Sign(t, jwt.WithKey(alg, tokenAuth.signer))
+	if err != nil {
+		return "", err
+	}
+	return string(signed), nil
+}
+
+// authenticatedUserID extracts the caller's user ID from JWT claims.
+// It writes a 401 response and returns false when the token is unusable.
+func authenticatedUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	_, claims, err := jwtauth.FromContext(r.Context())
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to extract JWT from context")
+		respondError(w, http.StatusUnauthorized, "invalid token")
+		return uuid.Nil, false
+	}
+	userIDStr, ok := claims["user_id"].(string)
+	if !ok {
+		log.Warn().Msg("user_id claim missing or invalid in JWT")
+		respondError(w, http.StatusUnauthorized, "invalid token")
+		return uuid.Nil, false
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		log.Error().Err(err).Str("user_id_str", userIDStr).Msg("Invalid user_id UUID in token")
+		respondError(w, http.StatusUnauthorized, "invalid token")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
 
-	// Include user identity and expiry in signed JWT claims.
-	claims := map[string]interface{}{
-		"user_id": userID.String(),
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
+// authenticatedOrgID extracts the caller's organization from JWT claims.
+// Tokens issued before organizations existed carry none; those callers must log in again.
+func authenticatedOrgID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	_, claims, err := jwtauth.FromContext(r.Context())
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to extract JWT from context")
+		respondError(w, http.StatusUnauthorized, "invalid token")
+		return uuid.Nil, false
 	}
-	_, tokenString, err := TokenAuth.Encode(claims)
-	return tokenString, err
+	orgIDStr, _ := claims["org_id"].(string)
+	orgID, err := uuid.Parse(orgIDStr)
+	if err != nil {
+		log.Warn().Interface("user_id", claims["user_id"]).Msg("org_id claim missing or invalid in JWT")
+		respondError(w, http.StatusUnauthorized, "invalid token")
+		return uuid.Nil, false
+	}
+	return orgID, true
+}
+
+// authenticatedRole returns the caller's role claim, or "" when there is none.
+func authenticatedRole(r *http.Request) string {
+	_, claims, err := jwtauth.FromContext(r.Context())
+	if err != nil {
+		return ""
+	}
+	role, _ := claims["role"].(string)
+	return role
+}
+
+// RequireRole rejects requests whose JWT does not carry one of the given roles.
+// It must run after jwtauth.Verifier and jwtauth.Authenticator.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, claims, err := jwtauth.FromContext(r.Context())
+			if err != nil {
+				respondError(w, http.StatusUnauthorized, "invalid token")
+				return
+			}
+			// Tokens issued before roles existed carry no claim and are treated as customers.
+			got, _ := claims["role"].(string)
+			if !slices.Contains(roles, got) {
+				log.Warn().Interface("user_id", claims["user_id"]).Strs("required_roles", roles).Msg("Forbidden: missing role")
+				respondError(w, http.StatusForbidden, "forbidden")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// PropagateRequestID hands the request ID set by middleware.RequestID to the ledger service, which
+// tags its logs and the transactions it records with it, and echoes it in the X-Request-Id
+// response header so a user can quote it to support.
+func PropagateRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := middleware.GetReqID(r.Context())
+		if requestID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("X-Request-Id", requestID)
+		next.ServeHTTP(w, r.WithContext(service.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+// RedactQueryTokens masks a ?jwt= token in r.RequestURI, which the access log writes, so stream
+// connections do not leave bearer tokens in the logs. r.URL keeps the token for the verifier.
+// It must run before middleware.Logger.
+func RedactQueryTokens(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if !q.Has("jwt") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		q.Set("jwt", "REDACTED")
+		r2 := r.Clone(r.Context())
+		r2.RequestURI = (&url.URL{Path: r.URL.Path, RawPath: r.URL.RawPath, RawQuery: q.Encode()}).RequestURI()
+		next.ServeHTTP(w, r2)
+	})
+}
+
+// Timeout bounds each request's context by d, which cancels the database calls and transactions
+// it makes. When the deadline passes before the handler answers, or the handler then answers with
+// a server error, the client gets 504 with the request ID to quote. Long-lived streams must not
+// run behind it.
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			tw := &timeoutWriter{ResponseWriter: w, ctx: ctx}
+			next.ServeHTTP(tw, r.WithContext(ctx))
+			if !tw.wroteHeader && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				tw.WriteHeader(http.StatusGatewayTimeout)
+			}
+		})
+	}
+}
+
+// timeoutWriter turns a server error written after the request deadline into a 504 and drops the
+// handler's own body for it.
+type timeoutWriter struct {
+	http.ResponseWriter
+	ctx         context.Context
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	if tw.wroteHeader {
+		return
+	}
+	tw.wroteHeader = true
+	if code < http.StatusInternalServerError || !errors.Is(tw.ctx.Err(), context.DeadlineExceeded) {
+		tw.ResponseWriter.WriteHeader(code)
+		return
+	}
+	tw.timedOut = true
+	requestID := middleware.GetReqID(tw.ctx)
+	log.Warn().Str("request_id", requestID).Msg("Request timed out")
+	tw.Header().Set("X-Request-Id", requestID)
+	respondJSON(tw.ResponseWriter, http.StatusGatewayTimeout, ErrorResponse{Error: "request timed out", RequestID: requestID})
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	if !tw.wroteHeader {
+		tw.WriteHeader(http.StatusOK)
+	}
+	if tw.timedOut {
+		return len(b), nil
+	}
+	return tw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
 }
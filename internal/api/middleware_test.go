@@ -1,10 +1,20 @@
 package api
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
 )
 
 func TestInitTokenAuthFromEnv_MissingSecret(t *testing.T) {
@@ -20,3 +30,113 @@ func TestInitTokenAuth_Success(t *testing.T) {
 	err := InitTokenAuth(secret)
 	assert.NoError(t, err)
 }
+
+func TestRequireRole(t *testing.T) {
+	// Only tokens carrying the required role reach the wrapped handler.
+	require.NoError(t, InitTokenAuth("fV7sliKV3qn657I60wEFtw/Auk/0bNU9zdp30wFzfDg="))
+	protected := Verifier(Authenticator(
+		RequireRole(RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})),
+	))
+
+	for role, want := range map[string]int{RoleAdmin: http.StatusNoContent, RoleCustomer: http.StatusForbidden, "": http.StatusForbidden} {
+		token, err := GenerateToken(uuid.New(), uuid.New(), role)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/admin/reconciliations", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rw := httptest.NewRecorder()
+		protected.ServeHTTP(rw, req)
+		assert.Equal(t, want, rw.Code, "role %q", role)
+	}
+}
+
+func TestRequireRole_AnyOf(t *testing.T) {
+	// Maker routes accept any of several roles; other roles are still refused.
+	require.NoError(t, InitTokenAuth("fV7sliKV3qn657I60wEFtw/Auk/0bNU9zdp30wFzfDg="))
+	protected := Verifier(Authenticator(
+		RequireRole(RoleOrgAdmin, RoleApprover)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})),
+	))
+
+	for role, want := range map[string]int{RoleOrgAdmin: http.StatusNoContent, RoleApprover: http.StatusNoContent, RoleCustomer: http.StatusForbidden} {
+		token, err := GenerateToken(uuid.New(), uuid.New(), role)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/org/transfer-requests", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rw := httptest.NewRecorder()
+		protected.ServeHTTP(rw, req)
+		assert.Equal(t, want, rw.Code, "role %q", role)
+	}
+}
+
+func TestTimeout_AnswersGatewayTimeout(t *testing.T) {
+	// A handler that gives up on its expired context, or never answers, yields 504 with the request ID.
+	failing := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		respondError(w, http.StatusInternalServerError, "failed to load account")
+	})
+	silent := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	// Ledger errors map to 400, but one caused by the deadline is still a timeout.
+	ledger := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		respondLedgerError(w, http.StatusBadRequest, fmt.Errorf("lock account: %w", r.Context().Err()))
+	})
+	for _, next := range []http.Handler{failing, silent, ledger} {
+		handler := middleware.RequestID(Timeout(10 * time.Millisecond)(next))
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/accounts", nil))
+		assert.Equal(t, http.StatusGatewayTimeout, rw.Code)
+		var resp ErrorResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+		assert.Equal(t, "request timed out", resp.Error)
+		assert.NotEmpty(t, resp.RequestID)
+		assert.Equal(t, resp.RequestID, rw.Header().Get("X-Request-Id"))
+	}
+}
+
+func TestTimeout_PassesTimelyResponses(t *testing.T) {
+	// Responses written before the deadline, errors included, are untouched.
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		respondError(w, http.StatusInternalServerError, "failed")
+	}))
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/accounts", nil))
+	assert.Equal(t, http.StatusInternalServerError, rw.Code)
+	assert.Contains(t, rw.Body.String(), "failed")
+}
+
+func TestPropagateRequestID(t *testing.T) {
+	// The request ID reaches the service through the context and is echoed to the client.
+	var got string
+	handler := middleware.RequestID(PropagateRequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = service.RequestIDFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	req := httptest.NewRequest(http.MethodPost, "/transfers", nil)
+	req.Header.Set("X-Request-Id", "support-ticket-123")
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+
+	assert.Equal(t, "support-ticket-123", got)
+	assert.Equal(t, "support-ticket-123", rw.Header().Get("X-Request-Id"))
+}
+
+func TestRedactQueryTokens(t *testing.T) {
+	// The logged URI loses the token; the verifier still sees it on r.URL.
+	var logged, token string
+	h := RedactQueryTokens(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		logged, token = r.RequestURI, r.URL.Query().Get("jwt")
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/accounts/1/events?jwt=secret.token&last_event_id=5", nil))
+	assert.NotContains(t, logged, "secret.token")
+	assert.Contains(t, logged, "jwt=REDACTED")
+	assert.Contains(t, logged, "last_event_id=5")
+	assert.Equal(t, "secret.token", token)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ws?x=1", nil))
+	assert.Equal(t, "/ws?x=1", logged)
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/jobs"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// KindPruneNameEnquiries deletes name enquiries older than the configured retention.
+const KindPruneNameEnquiries = "name_enquiries.prune"
+
+// accountNumberPattern matches the 10-digit numbers accounts are given.
+var accountNumberPattern = regexp.MustCompile(`^[0-9]{10}$`)
+
+// NameEnquiryPolicy bounds how many account numbers can be looked up, so owners cannot be
+// enumerated by walking the number space.
+type NameEnquiryPolicy struct {
+	// MaxPerUser enquiries by one user, or MaxPerIP from one address, within Window turn further
+	// ones away until they age out.
+	MaxPerUser int
+	MaxPerIP   int
+	Window     time.Duration
+}
+
+// DefaultNameEnquiryPolicy allows a user 20 enquiries, and an address 60, every 15 minutes.
+var DefaultNameEnquiryPolicy = NameEnquiryPolicy{MaxPerUser: 20, MaxPerIP: 60, Window: 15 * time.Minute}
+
+// WithNameEnquiryPolicy replaces the default enquiry limits; zero fields keep their default.
+func WithNameEnquiryPolicy(p NameEnquiryPolicy) Option {
+	return func(h *Handler) {
+		if p.MaxPerUser > 0 {
+			h.nameEnquiryPolicy.MaxPerUser = p.MaxPerUser
+		}
+		if p.MaxPerIP > 0 {
+			h.nameEnquiryPolicy.MaxPerIP = p.MaxPerIP
+		}
+		if p.Window > 0 {
+			h.nameEnquiryPolicy.Window = p.Window
+		}
+	}
+}
+
+// EnquireAccount godoc
+// @Summary      Confirm who owns an account number
+// @Description  Returns the masked owner name (first name and last initial, or a masked email), currency and ID of the customer account in the caller's organization with the given 10-digit number, so the sender can confirm who they are paying before POST /transfers. Unknown numbers and system accounts answer 404. Every enquiry is recorded; a user or address making too many within the window is turned away (429, code "too_many_enquiries", with Retry-After).
+// @Tags         accounts
+// @Produce      json
+// @Param        number  query     string  true  "Account number"
+// @Success      200     {object}  AccountEnquiryResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      404     {object}  ErrorResponse
+// @Failure      429     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /accounts/enquiry [get]
+// @Security     Bearer
+func (h *Handler) EnquireAccount(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and validate the number.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	number := strings.TrimSpace(r.URL.Query().Get("number"))
+	if !accountNumberPattern.MatchString(number) {
+		respondError(w, http.StatusBadRequest, "number must be a 10-digit account number")
+		return
+	}
+
+	// Step 2: Turn away callers who have enquired too often lately.
+	enquiry := sqlc.CreateNameEnquiryParams{UserID: userID, IpAddress: clientIP(r), AccountNumber: number}
+	if h.nameEnquiryThrottled(w, r, enquiry) {
+		return
+	}
+
+	// Step 3: Look the number up in the caller's organization, recording the enquiry either way.
+	acc, err := h.store.GetAccountByVirtualNumber(r.Context(), number)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to look up account number")
+		respondError(w, http.StatusInternalServerError, "failed to look up account")
+		return
+	}
+	enquiry.Found = err == nil && acc.OrgID.UUID == orgID && acc.OwnerID.Valid
+	if err := h.store.CreateNameEnquiry(r.Context(), enquiry); err != nil {
+		// Without a record the enquiry would escape the limit, so it is refused.
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to record name enquiry")
+		respondError(w, http.StatusInternalServerError, "failed to look up account")
+		return
+	}
+	if !enquiry.Found {
+		respondError(w, http.StatusNotFound, "account not found")
+		return
+	}
+
+	// Step 4: Name the owner without revealing their full name.
+	owner, err := h.store.GetUser(r.Context(), acc.OwnerID.UUID)
+	if err != nil {
+		log.Error().Err(err).Str("account_id", acc.ID.String()).Msg("Failed to load account owner")
+		respondError(w, http.StatusInternalServerError, "failed to look up account")
+		return
+	}
+	respondJSON(w, http.StatusOK, AccountEnquiryResponse{
+		AccountID:     acc.ID.String(),
+		AccountNumber: acc.VirtualAccountNumber,
+		DisplayName:   service.MaskedName(owner),
+		Currency:      acc.Currency,
+	})
+}
+
+// nameEnquiryThrottled reports whether the caller or their address has reached the enquiry limit,
+// answering 429 if so. Throttled enquiries are not recorded, so the window ends once they stop.
+func (h *Handler) nameEnquiryThrottled(w http.ResponseWriter, r *http.Request, enquiry sqlc.CreateNameEnquiryParams) bool {
+	p := h.nameEnquiryPolicy
+	counts, err := h.store.CountRecentNameEnquiries(r.Context(), sqlc.CountRecentNameEnquiriesParams{
+		UserID:    enquiry.UserID,
+		IpAddress: enquiry.IpAddress,
+		Since:     time.Now().Add(-p.Window),
+	})
+	if err != nil {
+		// The limit is the only thing standing between a caller and enumeration, so fail closed.
+		log.Error().Err(err).Str("user_id", enquiry.UserID.String()).Msg("Failed to count name enquiries")
+		respondError(w, http.StatusInternalServerError, "failed to look up account")
+		return true
+	}
+	if !p.exceeded(counts) {
+		return false
+	}
+	log.Warn().Str("user_id", enquiry.UserID.String()).Str("ip_address", enquiry.IpAddress).
+		Int64("by_user", counts.ByUser).Int64("by_ip", counts.ByIp).Msg("Name enquiry throttled")
+	respondTooManyAttempts(w, p.Window, "too many account enquiries; try again later", "too_many_enquiries")
+	return true
+}
+
+// exceeded reports whether either count has reached its limit.
+func (p NameEnquiryPolicy) exceeded(counts sqlc.CountRecentNameEnquiriesRow) bool {
+	return counts.ByUser >= int64(p.MaxPerUser) || counts.ByIp >= int64(p.MaxPerIP)
+}
+
+// NameEnquiryPruner deletes old name enquiries. *db.Store satisfies it.
+type NameEnquiryPruner interface {
+	DeleteNameEnquiriesBefore(ctx context.Context, createdAt time.Time) (int64, error)
+}
+
+// PruneNameEnquiries returns a handler that keeps the name enquiry trail to retention.
+func PruneNameEnquiries(store NameEnquiryPruner, retention time.Duration) jobs.HandlerFunc {
+	return func(ctx context.Context, _ json.RawMessage) error {
+		n, err := store.DeleteNameEnquiriesBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+		log.Info().Int64("enquiries", n).Msg("Pruned name enquiries")
+		return nil
+	}
+}
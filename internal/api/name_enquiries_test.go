@@ -0,0 +1,32 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestWithNameEnquiryPolicy(t *testing.T) {
+	// Only the limits that are set replace the defaults.
+	h := NewHandler(nil, nil, WithNameEnquiryPolicy(NameEnquiryPolicy{MaxPerUser: 5}))
+	assert.Equal(t, NameEnquiryPolicy{MaxPerUser: 5, MaxPerIP: 60, Window: 15 * time.Minute}, h.nameEnquiryPolicy)
+}
+
+func TestNameEnquiryPolicy_Exceeded(t *testing.T) {
+	p := NameEnquiryPolicy{MaxPerUser: 3, MaxPerIP: 10}
+	assert.False(t, p.exceeded(sqlc.CountRecentNameEnquiriesRow{ByUser: 2, ByIp: 9}))
+	assert.True(t, p.exceeded(sqlc.CountRecentNameEnquiriesRow{ByUser: 3, ByIp: 3}))
+	// Several users behind one address share its limit.
+	assert.True(t, p.exceeded(sqlc.CountRecentNameEnquiriesRow{ByUser: 0, ByIp: 10}))
+}
+
+func TestAccountNumberPattern(t *testing.T) {
+	// Only well-formed numbers are looked up and counted against the limit.
+	assert.True(t, accountNumberPattern.MatchString("9000000012"))
+	for _, number := range []string{"", "12345", "90000000123", "90000000ab", " 9000000012"} {
+		assert.False(t, accountNumberPattern.MatchString(number), number)
+	}
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/nip"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// NameEnquiry godoc
+// @Summary      Resolve a Nigerian bank account name
+// @Description  Performs a NIP name enquiry so the user can confirm the beneficiary before sending money
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{bank_code=string,account_number=string}  true  "Destination bank account"
+// @Success      200   {object}  NameEnquiryResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      422   {object}  ErrorResponse
+// @Failure      502   {object}  ErrorResponse
+// @Failure      503   {object}  ErrorResponse
+// @Router       /banks/name-enquiry [post]
+// @Security     Bearer
+func (h *Handler) NameEnquiry(w http.ResponseWriter, r *http.Request) {
+	if h.nip == nil {
+		respondError(w, http.StatusServiceUnavailable, "interbank transfers are not configured")
+		return
+	}
+	if _, ok := authenticatedUserID(w, r); !ok {
+		return
+	}
+
+	var input struct {
+		BankCode      string `json:"bank_code"`
+		AccountNumber string `json:"account_number"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	ne, ok := h.resolveBeneficiary(w, r, input.BankCode, input.AccountNumber)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, NameEnquiryResponse{
+		BankCode:      ne.DestinationInstitutionCode,
+		AccountNumber: ne.AccountNumber,
+		AccountName:   ne.AccountName,
+		SessionID:     ne.SessionID,
+	})
+}
+
+// ExternalTransfer godoc
+// @Summary      Transfer to another Nigerian bank (NIP)
+// @Description  Confirms the beneficiary by name enquiry, holds the amount, and sends a NIP funds transfer. Approved transfers settle to the settlement account; declined ones move to Payout Suspense for an admin to refund or retry; timeouts stay pending until a status requery resolves them. When sanctions screening is on, a beneficiary whose name matches a list entry is held for compliance review and the transfer answers 403 with code "beneficiary_under_review".
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "Source account ID"
+// @Param        body  body      object{amount=string,bank_code=string,account_number=string,narration=string}  true  "Transfer details"
+// @Success      200   {object}  PayoutResponse  "Transfer settled or reversed"
+// @Success      202   {object}  PayoutResponse  "Transfer pending requery"
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      422   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Failure      503   {object}  ErrorResponse
+// @Router       /accounts/{id}/transfers/external [post]
+// @Security     Bearer
+func (h *Handler) ExternalTransfer(w http.ResponseWriter, r *http.Request) {
+	if h.nip == nil {
+		respondError(w, http.StatusServiceUnavailable, "interbank transfers are not configured")
+		return
+	}
+
+	// Step 1: Authenticate caller and enforce ownership of the source account.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	account, ok := h.ownedAccount(w, r, userID, accountID)
+	if !ok {
+		return
+	}
+
+	body := h.captureSignedBody(r)
+	var input struct {
+		Amount        interface{} `json:"amount"`
+		BankCode      string      `json:"bank_code"`
+		AccountNumber string      `json:"account_number"`
+		Narration     string      `json:"narration"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	amount, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctx, ok := h.checkRequestSignature(w, r, body, accountID, amount)
+	if !ok {
+		return
+	}
+	r = r.WithContext(ctx)
+
+	// Step 2: Name enquiry server-side, so the beneficiary name on record is the bank's, not the client's.
+	ne, ok := h.resolveBeneficiary(w, r, input.BankCode, input.AccountNumber)
+	if !ok {
+		return
+	}
+	if !h.screenBeneficiary(w, r, account, ne) {
+		return
+	}
+
+	// Step 3: Hold funds before the transfer leaves the bank.
+	payout, err := h.ledger.HoldPayout(r.Context(), service.PayoutRequest{
+		AccountID:       accountID,
+		UserID:          userID,
+		Provider:        nip.Provider,
+		Reference:       newNIPReference(),
+		Amount:          amount,
+		BankCode:        ne.DestinationInstitutionCode,
+		AccountNumber:   ne.AccountNumber,
+		Narration:       strings.TrimSpace(input.Narration),
+		BeneficiaryName: ne.AccountName,
+	})
+	if respondScreening(w, err) {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Str("amount", amount).Msg("NIP hold failed")
+		respondError(w, outboundDebitStatus(err), err.Error())
+		return
+	}
+
+	// Step 4: Send the transfer and act on the response code.
+	payout = h.sendNIPTransfer(r.Context(), payout, ne.SessionID)
+
+	log.Info().Str("reference", payout.Reference).Str("status", payout.Status).Str("account_id", accountID.String()).Msg("NIP transfer processed")
+	status := http.StatusOK
+	if payout.Status == service.PayoutPending {
+		status = http.StatusAccepted
+	}
+	respondJSON(w, status, toPayoutResponse(payout))
+}
+
+// sendNIPTransfer sends a held payout over NIP under a name enquiry session and completes it when
+// the response code is final: settled on approval, failed to suspense on a decline. Otherwise the
+// hold stays pending for the requery worker.
+func (h *Handler) sendNIPTransfer(ctx context.Context, payout sqlc.Payout, nameEnquiryRef string) sqlc.Payout {
+	held, _ := decimal.NewFromString(payout.Amount)
+	ft, err := h.nip.FundsTransfer(ctx, nip.FundsTransferRequest{
+		Amount:                     held,
+		NameEnquiryRef:             nameEnquiryRef,
+		DestinationInstitutionCode: payout.BankCode,
+		BeneficiaryAccountNumber:   payout.AccountNumber,
+		BeneficiaryAccountName:     payout.BeneficiaryName,
+		Narration:                  payout.Narration,
+		PaymentReference:           payout.Reference,
+	})
+	outcome := nip.OutcomePending
+	if err != nil {
+		log.Warn().Err(err).Str("reference", payout.Reference).Msg("NIP funds transfer outcome unknown; awaiting requery")
+	} else {
+		outcome = nip.Classify(ft.ResponseCode)
+		if ft.SessionID != "" {
+			if setErr := h.store.SetPayoutProviderTransferID(ctx, sqlc.SetPayoutProviderTransferIDParams{
+				ProviderTransferID: sql.NullString{String: ft.SessionID, Valid: true},
+				Reference:          payout.Reference,
+			}); setErr != nil {
+				log.Warn().Err(setErr).Str("reference", payout.Reference).Msg("Failed to store NIP session ID")
+			}
+		}
+	}
+
+	if outcome != nip.OutcomePending {
+		reason := ""
+		if outcome == nip.OutcomeFailed {
+			reason = "NIP response " + ft.ResponseCode
+		}
+		completed, err := h.ledger.CompletePayout(ctx, payout.Reference, outcome == nip.OutcomeSucceeded, reason)
+		if err != nil {
+			// The hold stays in clearing; the requery worker will finish it.
+			log.Error().Err(err).Str("reference", payout.Reference).Msg("Failed to complete NIP payout")
+		} else {
+			payout = completed
+		}
+	}
+	return payout
+}
+
+// resolveBeneficiary runs a name enquiry and writes the error response when it fails.
+func (h *Handler) resolveBeneficiary(w http.ResponseWriter, r *http.Request, bankCode, accountNumber string) (nip.NameEnquiryResponse, bool) {
+	bankCode, accountNumber = strings.TrimSpace(bankCode), strings.TrimSpace(accountNumber)
+	if bankCode == "" {
+		respondError(w, http.StatusBadRequest, "bank_code required")
+		return nip.NameEnquiryResponse{}, false
+	}
+	if err := nip.ValidateNUBAN(accountNumber); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return nip.NameEnquiryResponse{}, false
+	}
+
+	ne, err := h.nip.NameEnquiry(r.Context(), nip.NameEnquiryRequest{
+		DestinationInstitutionCode: bankCode,
+		AccountNumber:              accountNumber,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("bank_code", bankCode).Msg("NIP name enquiry failed")
+		respondError(w, http.StatusBadGateway, "name enquiry failed")
+		return nip.NameEnquiryResponse{}, false
+	}
+	if ne.ResponseCode != nip.CodeApproved || ne.AccountName == "" {
+		respondError(w, http.StatusUnprocessableEntity, "beneficiary account could not be verified")
+		return nip.NameEnquiryResponse{}, false
+	}
+	return ne, true
+}
+
+// newNIPReference returns a unique payment reference for an outbound NIP transfer.
+func newNIPReference() string {
+	return "nip_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+}
@@ -0,0 +1,256 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/notify"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// e164Pattern accepts international numbers such as +2348012345678.
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// GetNotificationPreferences godoc
+// @Summary      Get notification preferences
+// @Description  Returns which transaction alert channels (email, SMS) are enabled for the authenticated user
+// @Tags         notifications
+// @Produce      json
+// @Success      200  {object}  NotificationPreferencesResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /me/notifications [get]
+// @Security     Bearer
+func (h *Handler) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	// Step 2: Load user phone and stored preferences (defaults apply when unset).
+	user, err := h.store.GetUser(r.Context(), userID)
+	if err == nil {
+		user, err = h.openUser(user)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to load user")
+		respondError(w, http.StatusInternalServerError, "failed to load preferences")
+		return
+	}
+
+	response := NotificationPreferencesResponse{EmailEnabled: true, Phone: user.Phone.String}
+	prefs, err := h.store.GetNotificationPreferences(r.Context(), userID)
+	switch {
+	case err == nil:
+		response.EmailEnabled = prefs.EmailEnabled
+		response.SMSEnabled = prefs.SmsEnabled
+	case !errors.Is(err, sql.ErrNoRows):
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to load notification preferences")
+		respondError(w, http.StatusInternalServerError, "failed to load preferences")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// UpdateNotificationPreferences godoc
+// @Summary      Update notification preferences
+// @Description  Enables or disables email and SMS transaction alerts. SMS requires an E.164 phone number (e.g., +2348012345678).
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{email_enabled=bool,sms_enabled=bool,phone=string}  true  "Preferences"
+// @Success      200   {object}  NotificationPreferencesResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /me/notifications [put]
+// @Security     Bearer
+func (h *Handler) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and decode payload.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Phone        *string `json:"phone"`
+		EmailEnabled bool    `json:"email_enabled"`
+		SMSEnabled   bool    `json:"sms_enabled"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	user, err := h.store.GetUser(r.Context(), userID)
+	if err == nil {
+		user, err = h.openUser(user)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to load user")
+		respondError(w, http.StatusInternalServerError, "failed to update preferences")
+		return
+	}
+
+	// Step 2: Validate phone; SMS alerts are useless without a deliverable number.
+	phone := user.Phone.String
+	if input.Phone != nil {
+		phone = strings.TrimSpace(*input.Phone)
+		if phone != "" && !e164Pattern.MatchString(phone) {
+			respondError(w, http.StatusBadRequest, "phone must be in E.164 format, e.g. +2348012345678")
+			return
+		}
+	}
+	if input.SMSEnabled && phone == "" {
+		respondError(w, http.StatusBadRequest, "phone is required to enable SMS alerts")
+		return
+	}
+
+	// Step 3: Persist the sealed phone and preferences together.
+	var prefs sqlc.NotificationPreference
+	err = h.store.ExecTx(r.Context(), func(q *sqlc.Queries) error {
+		if input.Phone != nil {
+			sealed, index, err := h.sealPhone(phone)
+			if err != nil {
+				return err
+			}
+			if err := q.UpdateUserPhone(r.Context(), sqlc.UpdateUserPhoneParams{
+				ID:         userID,
+				Phone:      sealed,
+				PhoneIndex: index,
+			}); err != nil {
+				return err
+			}
+		}
+		var upsertErr error
+		prefs, upsertErr = q.UpsertNotificationPreferences(r.Context(), sqlc.UpsertNotificationPreferencesParams{
+			UserID:       userID,
+			EmailEnabled: input.EmailEnabled,
+			SmsEnabled:   input.SMSEnabled,
+		})
+		return upsertErr
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to update notification preferences")
+		respondError(w, http.StatusInternalServerError, "failed to update preferences")
+		return
+	}
+
+	log.Info().Str("user_id", userID.String()).Bool("email", prefs.EmailEnabled).Bool("sms", prefs.SmsEnabled).Msg("Notification preferences updated")
+	respondJSON(w, http.StatusOK, NotificationPreferencesResponse{
+		Phone:        phone,
+		EmailEnabled: prefs.EmailEnabled,
+		SMSEnabled:   prefs.SmsEnabled,
+	})
+}
+
+// GetStatementPreference godoc
+// @Summary      Get monthly statement delivery
+// @Description  Returns how the account's monthly statement reaches its primary owner: "email" (default, statement in the email body), "link" (signed download link) or "off"
+// @Tags         notifications
+// @Produce      json
+// @Param        id   path      string  true  "Account ID"
+// @Success      200  {object}  StatementPreferenceResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/notifications/statements [get]
+// @Security     Bearer
+func (h *Handler) GetStatementPreference(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.visibleAccount(w, r, userID, accountID); !ok {
+		return
+	}
+
+	response := StatementPreferenceResponse{AccountID: accountID.String(), Delivery: notify.StatementEmail}
+	pref, err := h.store.GetStatementPreference(r.Context(), accountID)
+	switch {
+	case err == nil:
+		response.Delivery = pref.Delivery
+	case !errors.Is(err, sql.ErrNoRows):
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to load statement preference")
+		respondError(w, http.StatusInternalServerError, "failed to load preferences")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// UpdateStatementPreference godoc
+// @Summary      Set monthly statement delivery
+// @Description  Chooses how the account's monthly statement is delivered: "email", "link" or "off". Owners only.
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                   true  "Account ID"
+// @Param        body  body      object{delivery=string}  true  "email, link or off"
+// @Success      200   {object}  StatementPreferenceResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/notifications/statements [put]
+// @Security     Bearer
+func (h *Handler) UpdateStatementPreference(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and require owner access.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.ownedAccount(w, r, userID, accountID); !ok {
+		return
+	}
+
+	// Step 2: Validate the choice.
+	var input struct {
+		Delivery string `json:"delivery"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	switch input.Delivery {
+	case notify.StatementEmail, notify.StatementLink, notify.StatementOff:
+	default:
+		respondError(w, http.StatusBadRequest, "delivery must be email, link or off")
+		return
+	}
+
+	// Step 3: Persist.
+	pref, err := h.store.UpsertStatementPreference(r.Context(), sqlc.UpsertStatementPreferenceParams{
+		AccountID: accountID,
+		Delivery:  input.Delivery,
+		UpdatedBy: userID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to update statement preference")
+		respondError(w, http.StatusInternalServerError, "failed to update preferences")
+		return
+	}
+
+	log.Info().Str("account_id", accountID.String()).Str("delivery", pref.Delivery).Msg("Statement preference updated")
+	respondJSON(w, http.StatusOK, StatementPreferenceResponse{AccountID: accountID.String(), Delivery: pref.Delivery})
+}
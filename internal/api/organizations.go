@@ -0,0 +1,306 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// orgSlugPattern mirrors the organizations.slug CHECK constraint.
+var orgSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}$`)
+
+// organizationBySlug resolves a register/login organization, defaulting to DefaultOrgSlug.
+func (h *Handler) organizationBySlug(ctx context.Context, slug string) (sqlc.Organization, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		slug = DefaultOrgSlug
+	}
+	return h.store.GetOrganizationBySlug(ctx, slug)
+}
+
+// CreateOrganization godoc
+// @Summary      Create an organization
+// @Description  Creates a tenant. Users register into it by passing its slug as "org". Platform admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{name=string,slug=string}  true  "Organization; slug is 2-63 lowercase letters, digits or hyphens"
+// @Success      201   {object}  OrganizationResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Router       /admin/organizations [post]
+// @Security     Bearer
+func (h *Handler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name string `json:"name"`
+		Slug string `json:"slug"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	name := strings.TrimSpace(input.Name)
+	slug := strings.ToLower(strings.TrimSpace(input.Slug))
+	if name == "" || len(name) > maxProfileField {
+		respondError(w, http.StatusBadRequest, "name required (at most 100 characters)")
+		return
+	}
+	if !orgSlugPattern.MatchString(slug) {
+		respondError(w, http.StatusBadRequest, "slug must be 2-63 lowercase letters, digits or hyphens")
+		return
+	}
+
+	org, err := h.store.CreateOrganization(r.Context(), sqlc.CreateOrganizationParams{Name: name, Slug: slug})
+	if err != nil {
+		log.Error().Err(err).Str("slug", slug).Msg("Failed to create organization")
+		respondError(w, http.StatusConflict, "organization already exists or failed")
+		return
+	}
+
+	log.Info().Str("org_id", org.ID.String()).Str("slug", org.Slug).Msg("Organization created")
+	respondJSON(w, http.StatusCreated, toOrganizationResponse(org))
+}
+
+// ListOrganizations godoc
+// @Summary      List organizations
+// @Description  Returns every tenant on this deployment, oldest first. Platform admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        limit   query     int  false  "Limit (default 20)"
+// @Param        offset  query     int  false  "Offset (default 0)"
+// @Success      200     {array}   OrganizationResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /admin/organizations [get]
+// @Security     Bearer
+func (h *Handler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	rows, err := h.store.ListOrganizations(r.Context(), sqlc.ListOrganizationsParams{
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list organizations")
+		respondError(w, http.StatusInternalServerError, "failed to list organizations")
+		return
+	}
+
+	resp := make([]OrganizationResponse, 0, len(rows))
+	for _, org := range rows {
+		resp = append(resp, toOrganizationResponse(org))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// AssignOrganizationAdmin godoc
+// @Summary      Make a user an organization admin
+// @Description  Grants org_admin to a user of the organization so they can manage its users and accounts. Platform admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                  true  "Organization ID"
+// @Param        body  body      object{user_id=string}  true  "User in that organization"
+// @Success      200   {object}  OrgUserResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/organizations/{id}/admins [post]
+// @Security     Bearer
+func (h *Handler) AssignOrganizationAdmin(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+	var input struct {
+		UserID string `json:"user_id"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	userID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	h.setOrgUserRole(w, r, orgID, userID, RoleOrgAdmin)
+}
+
+// ListOrgUsers godoc
+// @Summary      List organization users
+// @Description  Returns the users of the caller's organization, oldest first. Organization admin only.
+// @Tags         organization
+// @Produce      json
+// @Param        limit   query     int  false  "Limit (default 20)"
+// @Param        offset  query     int  false  "Offset (default 0)"
+// @Success      200     {array}   OrgUserResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /org/users [get]
+// @Security     Bearer
+func (h *Handler) ListOrgUsers(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	rows, err := h.store.ListUsersByOrg(r.Context(), sqlc.ListUsersByOrgParams{
+		OrgID:  orgID,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to list organization users")
+		respondError(w, http.StatusInternalServerError, "failed to list users")
+		return
+	}
+
+	resp := make([]OrgUserResponse, 0, len(rows))
+	for _, u := range rows {
+		resp = append(resp, toOrgUserResponse(u))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ListOrgAccounts godoc
+// @Summary      List organization accounts
+// @Description  Returns a page of the customer accounts of the caller's organization, oldest first, wrapped in {data, page}. Organization admin only.
+// @Tags         organization
+// @Produce      json
+// @Param        limit     query     int   false  "Limit (default 20, max 100)"
+// @Param        offset    query     int   false  "Offset (default 0)"
+// @Param        envelope  query     bool  false  "Wrap in {data, page} (default per server)"
+// @Success      200     {object}  PagedResponse{data=[]AccountResponse}
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /org/accounts [get]
+// @Security     Bearer
+func (h *Handler) ListOrgAccounts(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	limit, offset := parsePage(r)
+
+	org := uuid.NullUUID{UUID: orgID, Valid: true}
+	rows, err := h.store.ListAccountsByOrg(r.Context(), sqlc.ListAccountsByOrgParams{
+		OrgID:  org,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		Offset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to list organization accounts")
+		respondError(w, http.StatusInternalServerError, "failed to list accounts")
+		return
+	}
+	total, err := h.store.CountAccountsByOrg(r.Context(), org)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to count organization accounts")
+		respondError(w, http.StatusInternalServerError, "failed to list accounts")
+		return
+	}
+
+	catalog := h.productCatalog(r.Context())
+	resp := make([]AccountResponse, 0, len(rows))
+	for _, acc := range rows {
+		resp = append(resp, toAccountResponse(acc, catalog))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// SetOrgUserRole godoc
+// @Summary      Change a user's organization role
+// @Description  Sets a user of the caller's organization to customer, org_admin, approver (the checker for transfer requests) or compliance (the reviewer of AML alerts). Organization admin only.
+// @Tags         organization
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string               true  "User ID"
+// @Param        body  body      object{role=string}  true  "customer, org_admin, approver or compliance"
+// @Success      200   {object}  OrgUserResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /org/users/{id}/role [put]
+// @Security     Bearer
+func (h *Handler) SetOrgUserRole(w http.ResponseWriter, r *http.Request) {
+	callerID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	var input struct {
+		Role string `json:"role"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	if input.Role != RoleCustomer && input.Role != RoleOrgAdmin && input.Role != RoleApprover && input.Role != RoleCompliance {
+		respondError(w, http.StatusBadRequest, "role must be customer, org_admin, approver or compliance")
+		return
+	}
+	// An organization must not be able to lock itself out by demoting its last admin through this call.
+	if userID == callerID && input.Role != RoleOrgAdmin {
+		respondError(w, http.StatusBadRequest, "cannot remove your own admin role")
+		return
+	}
+
+	h.setOrgUserRole(w, r, orgID, userID, input.Role)
+}
+
+// setOrgUserRole updates a role scoped to orgID; users of other organizations are reported as not found.
+func (h *Handler) setOrgUserRole(w http.ResponseWriter, r *http.Request, orgID, userID uuid.UUID, role string) {
+	user, err := h.store.SetUserRoleInOrg(r.Context(), sqlc.SetUserRoleInOrgParams{Role: role, ID: userID, OrgID: orgID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "user not found in organization")
+			return
+		}
+		log.Error().Err(err).Str("org_id", orgID.String()).Str("user_id", userID.String()).Msg("Failed to set user role")
+		respondError(w, http.StatusInternalServerError, "failed to set role")
+		return
+	}
+
+	log.Info().Str("org_id", orgID.String()).Str("user_id", userID.String()).Str("role", role).Msg("User role changed; takes effect at next login")
+	respondJSON(w, http.StatusOK, toOrgUserResponse(user))
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgSlugPattern(t *testing.T) {
+	// Slugs follow the organizations.slug CHECK constraint.
+	for _, slug := range []string{"default", "acme", "acme-2", "a1"} {
+		assert.True(t, orgSlugPattern.MatchString(slug), slug)
+	}
+	for _, slug := range []string{"", "a", "-acme", "Acme", "acme corp", strings.Repeat("a", 64)} {
+		assert.False(t, orgSlugPattern.MatchString(slug), slug)
+	}
+}
+
+func TestAuthenticatedOrgID(t *testing.T) {
+	// The org_id claim minted at login is what scopes org admin routes.
+	require.NoError(t, InitTokenAuth("fV7sliKV3qn657I60wEFtw/Auk/0bNU9zdp30wFzfDg="))
+	orgID := uuid.New()
+	var got uuid.UUID
+	handler := Verifier(Authenticator(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id, ok := authenticatedOrgID(w, r); ok {
+				got = id
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}),
+	))
+
+	token, err := GenerateToken(uuid.New(), orgID, RoleOrgAdmin)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/org/users", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+	assert.Equal(t, orgID, got)
+
+	// Tokens without an org (issued before organizations existed) must be refreshed.
+	legacy, err := signToken(map[string]interface{}{"user_id": uuid.NewString()})
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodGet, "/org/users", nil)
+	req.Header.Set("Authorization", "Bearer "+legacy)
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+}
+
+func TestSetOrgUserRole_RejectsSelfDemotion(t *testing.T) {
+	// An org admin cannot demote themselves and leave the organization unmanaged.
+	require.NoError(t, InitTokenAuth("fV7sliKV3qn657I60wEFtw/Auk/0bNU9zdp30wFzfDg="))
+	userID := uuid.New()
+	token, err := GenerateToken(userID, uuid.New(), RoleOrgAdmin)
+	require.NoError(t, err)
+
+	h := &Handler{}
+	router := chi.NewRouter()
+	router.With(Verifier, Authenticator).Put("/org/users/{id}/role", h.SetOrgUserRole)
+
+	req := httptest.NewRequest(http.MethodPut, "/org/users/"+userID.String()+"/role", strings.NewReader(`{"role":"customer"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assert.Contains(t, rw.Body.String(), "own admin role")
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// Account access roles stored on account_owners.
+const (
+	// AccountRoleOwner moves money and manages co-owners.
+	AccountRoleOwner = "owner"
+	// AccountRoleViewer reads balances, entries and statements only.
+	AccountRoleViewer = "viewer"
+)
+
+// hasAccountRole reports whether userID holds role (or owner, which implies viewer) on acc.
+// Sub-wallets resolve to their parent's owners. Lookup errors deny access.
+func (h *Handler) hasAccountRole(ctx context.Context, userID uuid.UUID, acc sqlc.Account, role string) bool {
+	// System and unowned accounts belong to the ledger, not to any customer.
+	if acc.IsSystem || !acc.OwnerID.Valid {
+		return false
+	}
+	got, err := h.store.GetAccountAccessRole(ctx, sqlc.GetAccountAccessRoleParams{UserID: userID, AccountID: acc.ID})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Error().Err(err).Str("account_id", acc.ID.String()).Str("user_id", userID.String()).Msg("Failed to load account role")
+		}
+		return false
+	}
+	return got == AccountRoleOwner || got == role
+}
+
+// ListAccountOwners godoc
+// @Summary      List account owners
+// @Description  Returns everyone with access to the account and their role. The primary owner is the user who opened it.
+// @Tags         accounts
+// @Produce      json
+// @Param        id   path      string  true  "Account ID"
+// @Success      200  {array}   AccountOwnerResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/owners [get]
+// @Security     Bearer
+func (h *Handler) ListAccountOwners(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	acc, ok := h.visibleAccount(w, r, userID, accountID)
+	if !ok {
+		return
+	}
+	if !jointAccountEligible(w, acc) {
+		return
+	}
+
+	rows, err := h.store.ListAccountOwners(r.Context(), accountID)
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to list account owners")
+		respondError(w, http.StatusInternalServerError, "failed to list owners")
+		return
+	}
+
+	resp := make([]AccountOwnerResponse, 0, len(rows))
+	for _, row := range rows {
+		resp = append(resp, toAccountOwnerResponse(row, acc))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// AddAccountOwner godoc
+// @Summary      Add a co-owner
+// @Description  Gives another user of the caller's organization access to the account as owner (can move money) or viewer (read only). Adding an existing co-owner changes their role. Owners only.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                          true  "Account ID"
+// @Param        body  body      object{email=string,role=string}  true  "role: owner or viewer"
+// @Success      201   {object}  AccountOwnerResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/owners [post]
+// @Security     Bearer
+func (h *Handler) AddAccountOwner(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and require owner access.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	acc, ok := h.ownedAccount(w, r, userID, accountID)
+	if !ok {
+		return
+	}
+	if !jointAccountEligible(w, acc) {
+		return
+	}
+
+	// Step 2: Validate input and resolve the invitee within the caller's organization.
+	var input struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	if input.Role != AccountRoleOwner && input.Role != AccountRoleViewer {
+		respondError(w, http.StatusBadRequest, "role must be owner or viewer")
+		return
+	}
+	invitee, err := h.store.GetUserByEmail(r.Context(), sqlc.GetUserByEmailParams{OrgID: orgID, Email: strings.TrimSpace(input.Email)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "user not found in organization")
+			return
+		}
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to load co-owner")
+		respondError(w, http.StatusInternalServerError, "failed to add owner")
+		return
+	}
+	if invitee.ID == acc.OwnerID.UUID && input.Role != AccountRoleOwner {
+		respondError(w, http.StatusBadRequest, "the primary owner cannot be made a viewer")
+		return
+	}
+
+	// Step 3: Grant (or change) access.
+	owner, err := h.store.AddAccountOwner(r.Context(), sqlc.AddAccountOwnerParams{
+		AccountID: accountID,
+		UserID:    invitee.ID,
+		Role:      input.Role,
+		AddedBy:   uuid.NullUUID{UUID: userID, Valid: true},
+	})
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Str("co_owner_id", invitee.ID.String()).Msg("Failed to add account owner")
+		respondError(w, http.StatusInternalServerError, "failed to add owner")
+		return
+	}
+
+	log.Info().Str("account_id", accountID.String()).Str("co_owner_id", invitee.ID.String()).Str("role", owner.Role).Str("added_by", userID.String()).Msg("Account co-owner added")
+	respondJSON(w, http.StatusCreated, toAccountOwnerResponse(sqlc.ListAccountOwnersRow{
+		AccountID: owner.AccountID,
+		UserID:    owner.UserID,
+		Role:      owner.Role,
+		AddedBy:   owner.AddedBy,
+		CreatedAt: owner.CreatedAt,
+		Email:     invitee.Email,
+		FirstName: invitee.FirstName,
+		LastName:  invitee.LastName,
+	}, acc))
+}
+
+// RemoveAccountOwner godoc
+// @Summary      Remove a co-owner
+// @Description  Revokes a user's access to the account. Owners may remove anyone but the primary owner; any co-owner may remove themselves.
+// @Tags         accounts
+// @Produce      json
+// @Param        id       path      string  true  "Account ID"
+// @Param        user_id  path      string  true  "User to remove"
+// @Success      200      {object}  MessageResponse
+// @Failure      400      {object}  ErrorResponse
+// @Failure      401      {object}  ErrorResponse
+// @Failure      403      {object}  ErrorResponse
+// @Failure      404      {object}  ErrorResponse
+// @Failure      500      {object}  ErrorResponse
+// @Router       /accounts/{id}/owners/{user_id} [delete]
+// @Security     Bearer
+func (h *Handler) RemoveAccountOwner(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller; leaving needs only access, removing others needs owner.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	targetID, err := uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	var acc sqlc.Account
+	if targetID == userID {
+		acc, ok = h.visibleAccount(w, r, userID, accountID)
+	} else {
+		acc, ok = h.ownedAccount(w, r, userID, accountID)
+	}
+	if !ok {
+		return
+	}
+	if !jointAccountEligible(w, acc) {
+		return
+	}
+	if targetID == acc.OwnerID.UUID {
+		respondError(w, http.StatusBadRequest, "the primary owner cannot be removed")
+		return
+	}
+
+	// Step 2: Revoke.
+	removed, err := h.store.RemoveAccountOwner(r.Context(), sqlc.RemoveAccountOwnerParams{AccountID: accountID, UserID: targetID})
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Str("co_owner_id", targetID.String()).Msg("Failed to remove account owner")
+		respondError(w, http.StatusInternalServerError, "failed to remove owner")
+		return
+	}
+	if removed == 0 {
+		respondError(w, http.StatusNotFound, "user is not an owner of this account")
+		return
+	}
+
+	log.Info().Str("account_id", accountID.String()).Str("co_owner_id", targetID.String()).Str("removed_by", userID.String()).Msg("Account co-owner removed")
+	respondJSON(w, http.StatusOK, MessageResponse{Message: "owner removed"})
+}
+
+// jointAccountEligible rejects system accounts and sub-wallets, whose access comes from their parent.
+func jointAccountEligible(w http.ResponseWriter, acc sqlc.Account) bool {
+	if acc.IsSystem || !acc.OwnerID.Valid {
+		respondError(w, http.StatusBadRequest, "system accounts have no owners")
+		return false
+	}
+	if acc.ParentAccountID.Valid {
+		respondError(w, http.StatusBadRequest, "sub-wallets share the owners of their parent account")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestHasAccountRole_UnownedAccount(t *testing.T) {
+	// System accounts have no owner rows; nobody reaches them through customer endpoints, and no lookup is made.
+	h := &Handler{}
+	assert.False(t, h.hasAccountRole(context.Background(), uuid.New(), sqlc.Account{ID: uuid.New(), IsSystem: true}, AccountRoleOwner))
+	assert.False(t, h.hasAccountRole(context.Background(), uuid.New(), sqlc.Account{ID: uuid.New()}, AccountRoleViewer))
+}
+
+func TestJointAccountEligible(t *testing.T) {
+	// Co-owners are managed on top-level customer accounts only.
+	owner := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	cases := map[string]struct {
+		acc  sqlc.Account
+		want bool
+	}{
+		"customer":   {sqlc.Account{OwnerID: owner}, true},
+		"system":     {sqlc.Account{IsSystem: true}, false},
+		"sub-wallet": {sqlc.Account{OwnerID: owner, ParentAccountID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}, false},
+	}
+	for name, tc := range cases {
+		rw := httptest.NewRecorder()
+		assert.Equal(t, tc.want, jointAccountEligible(rw, tc.acc), name)
+		if !tc.want {
+			assert.Equal(t, http.StatusBadRequest, rw.Code, name)
+		}
+	}
+}
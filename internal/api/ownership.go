@@ -0,0 +1,263 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// ownershipTransferStatus maps ownership transfer errors to an HTTP status.
+func ownershipTransferStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrOwnershipTransferNotFound), errors.Is(err, service.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrOwnershipTransferPending), errors.Is(err, service.ErrOwnershipTransferNotPending):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrOwnershipTransferSelfApproval):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidOwnershipTransfer):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondOwnershipTransferError writes err with its ownership transfer status, hiding internal failures.
+func respondOwnershipTransferError(w http.ResponseWriter, err error, msg string) {
+	status := ownershipTransferStatus(err)
+	if status == http.StatusInternalServerError {
+		log.Error().Err(err).Msg(msg)
+		respondError(w, status, msg)
+		return
+	}
+	respondError(w, status, err.Error())
+}
+
+// requestOwnershipTransfer decodes the new owner and reason and queues the transfer of accountID.
+func (h *Handler) requestOwnershipTransfer(w http.ResponseWriter, r *http.Request, requestedBy uuid.UUID, accountID *uuid.UUID) {
+	var input struct {
+		AccountID string `json:"account_id"`
+		ToEmail   string `json:"to_email"`
+		Reason    string `json:"reason"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	if accountID == nil {
+		id, err := uuid.Parse(input.AccountID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid account_id")
+			return
+		}
+		accountID = &id
+	}
+	toEmail := strings.TrimSpace(input.ToEmail)
+	if !strings.Contains(toEmail, "@") {
+		respondError(w, http.StatusBadRequest, "invalid to_email")
+		return
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" || len(reason) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "reason required (at most 500 characters)")
+		return
+	}
+
+	transfer, err := h.ledger.RequestOwnershipTransfer(r.Context(), service.OwnershipTransferInput{
+		AccountID:   *accountID,
+		ToEmail:     toEmail,
+		Reason:      reason,
+		RequestedBy: requestedBy,
+	})
+	if err != nil {
+		respondOwnershipTransferError(w, err, "failed to request ownership transfer")
+		return
+	}
+	respondJSON(w, http.StatusAccepted, toOwnershipTransferResponse(transfer))
+}
+
+// RequestAccountOwnershipTransfer godoc
+// @Summary      Hand an account over to another user
+// @Description  Asks for the account, with its sub-wallets and history, to move to the user of the same organization with to_email, e.g. on a company handover. Only the primary owner can ask, and nothing changes until an admin approves. The new owner replaces the current one; other co-owners keep their access.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                               true  "Account ID"
+// @Param        body  body      object{to_email=string,reason=string}  true  "New owner and why"
+// @Success      202   {object}  OwnershipTransferResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/ownership-transfers [post]
+// @Security     Bearer
+func (h *Handler) RequestAccountOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	acc, ok := h.ownedAccount(w, r, userID, accountID)
+	if !ok {
+		return
+	}
+	if acc.OwnerID.UUID != userID {
+		respondError(w, http.StatusForbidden, "only the primary owner can hand the account over")
+		return
+	}
+	h.requestOwnershipTransfer(w, r, userID, &acc.ID)
+}
+
+// CreateOwnershipTransfer godoc
+// @Summary      Request an account ownership transfer
+// @Description  Asks, on the owner's behalf (e.g. for an estate), for the account with its sub-wallets and history to move to the user of the account's organization with to_email. A different admin must approve it. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{account_id=string,to_email=string,reason=string}  true  "Account, new owner and why"
+// @Success      202   {object}  OwnershipTransferResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/ownership-transfers [post]
+// @Security     Bearer
+func (h *Handler) CreateOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	h.requestOwnershipTransfer(w, r, userID, nil)
+}
+
+// ListOwnershipTransfers godoc
+// @Summary      List account ownership transfers
+// @Description  Returns a page of ownership transfers in one status, oldest first, wrapped in {data, page}. The default status "pending" is the approval queue; approved and rejected ones are the audit trail. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        status    query     string  false  "pending (default), approved or rejected"
+// @Param        limit     query     int     false  "Limit (default 20, max 100)"
+// @Param        offset    query     int     false  "Offset (default 0)"
+// @Param        envelope  query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200       {object}  PagedResponse{data=[]OwnershipTransferResponse}
+// @Failure      400       {object}  ErrorResponse
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
+// @Router       /admin/ownership-transfers [get]
+// @Security     Bearer
+func (h *Handler) ListOwnershipTransfers(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.OwnershipTransferPending
+	case service.OwnershipTransferPending, service.OwnershipTransferApproved, service.OwnershipTransferRejected:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be pending, approved or rejected")
+		return
+	}
+	limit, offset := parsePage(r)
+
+	// Step 2: Fetch the page and the size of the whole list.
+	rows, err := h.store.ListOwnershipTransfersByStatus(r.Context(), sqlc.ListOwnershipTransfersByStatusParams{
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		Offset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to list ownership transfers")
+		respondError(w, http.StatusInternalServerError, "failed to list ownership transfers")
+		return
+	}
+	total, err := h.store.CountOwnershipTransfersByStatus(r.Context(), status)
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to count ownership transfers")
+		respondError(w, http.StatusInternalServerError, "failed to list ownership transfers")
+		return
+	}
+
+	resp := make([]OwnershipTransferResponse, 0, len(rows))
+	for _, t := range rows {
+		resp = append(resp, toOwnershipTransferResponse(t))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// DecideOwnershipTransfer godoc
+// @Summary      Approve or reject an account ownership transfer
+// @Description  Approval makes the new user the primary owner of the account and its sub-wallets, removes the previous owner's access and records the approver atomically; rejection closes the request. The requester can never decide their own request. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                               true  "Ownership transfer ID"
+// @Param        body  body      object{decision=string,note=string}  true  "decision: approve or reject; note is required when rejecting"
+// @Success      200   {object}  OwnershipTransferResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/ownership-transfers/{id}/decision [post]
+// @Security     Bearer
+func (h *Handler) DecideOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate the checker and parse input.
+	approverID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid ownership transfer ID")
+		return
+	}
+	var input struct {
+		Decision string `json:"decision"`
+		Note     string `json:"note"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	note := strings.TrimSpace(input.Note)
+	if len(note) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "note must be at most 500 characters")
+		return
+	}
+
+	// Step 2: Decide under the request's row lock.
+	var transfer sqlc.OwnershipTransfer
+	switch input.Decision {
+	case "approve":
+		transfer, err = h.ledger.ApproveOwnershipTransfer(r.Context(), id, approverID, note)
+	case "reject":
+		if note == "" {
+			respondError(w, http.StatusBadRequest, "note required when rejecting")
+			return
+		}
+		transfer, err = h.ledger.RejectOwnershipTransfer(r.Context(), id, approverID, note)
+	default:
+		respondError(w, http.StatusBadRequest, "decision must be approve or reject")
+		return
+	}
+	if err != nil {
+		respondOwnershipTransferError(w, err, "failed to decide ownership transfer")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toOwnershipTransferResponse(transfer))
+}
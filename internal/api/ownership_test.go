@@ -0,0 +1,60 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestOwnershipTransferStatus(t *testing.T) {
+	// Each ownership transfer error maps to the status clients rely on.
+	assert.Equal(t, http.StatusNotFound, ownershipTransferStatus(service.ErrOwnershipTransferNotFound))
+	assert.Equal(t, http.StatusNotFound, ownershipTransferStatus(service.ErrAccountNotFound))
+	assert.Equal(t, http.StatusConflict, ownershipTransferStatus(service.ErrOwnershipTransferPending))
+	assert.Equal(t, http.StatusConflict, ownershipTransferStatus(service.ErrOwnershipTransferNotPending))
+	assert.Equal(t, http.StatusForbidden, ownershipTransferStatus(service.ErrOwnershipTransferSelfApproval))
+	assert.Equal(t, http.StatusBadRequest, ownershipTransferStatus(service.ErrInvalidOwnershipTransfer))
+	assert.Equal(t, http.StatusInternalServerError, ownershipTransferStatus(errors.New("boom")))
+}
+
+func TestOwnershipTransferEndpoints_RejectBadInput(t *testing.T) {
+	// Malformed requests are refused before the ledger is touched.
+	require.NoError(t, InitTokenAuth("fV7sliKV3qn657I60wEFtw/Auk/0bNU9zdp30wFzfDg="))
+	token, err := GenerateToken(uuid.New(), uuid.New(), RoleAdmin)
+	require.NoError(t, err)
+
+	h := &Handler{}
+	router := chi.NewRouter()
+	router.Group(func(r chi.Router) {
+		r.Use(Verifier, Authenticator)
+		r.Get("/admin/ownership-transfers", h.ListOwnershipTransfers)
+		r.Post("/admin/ownership-transfers", h.CreateOwnershipTransfer)
+		r.Post("/admin/ownership-transfers/{id}/decision", h.DecideOwnershipTransfer)
+	})
+
+	id := uuid.New().String()
+	for _, tc := range []struct{ method, path, body, want string }{
+		{http.MethodGet, "/admin/ownership-transfers?status=done", ``, "status must be"},
+		{http.MethodPost, "/admin/ownership-transfers", `{"account_id":"x","to_email":"a@b.c","reason":"estate"}`, "invalid account_id"},
+		{http.MethodPost, "/admin/ownership-transfers", `{"account_id":"` + id + `","to_email":"nobody","reason":"estate"}`, "invalid to_email"},
+		{http.MethodPost, "/admin/ownership-transfers", `{"account_id":"` + id + `","to_email":"a@b.c"}`, "reason required"},
+		{http.MethodPost, "/admin/ownership-transfers/" + id + "/decision", `{"decision":"reject"}`, "note required"},
+		{http.MethodPost, "/admin/ownership-transfers/" + id + "/decision", `{"decision":"maybe"}`, "decision must be"},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusBadRequest, rw.Code, tc.path)
+		assert.Contains(t, rw.Body.String(), tc.want, tc.path)
+	}
+}
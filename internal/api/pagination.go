@@ -0,0 +1,85 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Page bounds shared by the paged list endpoints.
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+	maxPageOffset    = 2147483647
+)
+
+// parsePage reads limit and offset with safe defaults and caps; invalid values fall back to the defaults.
+func parsePage(r *http.Request) (int, int) {
+	limit, offset := defaultPageLimit, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, maxPageLimit)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= maxPageOffset {
+		offset = v
+	}
+	return limit, offset
+}
+
+// Sort keys each list accepts; a leading "-" sorts descending. The list queries order by
+// these exact values, so only whitelisted ones may reach them.
+var (
+	accountSorts = []string{"created_at", "-created_at", "name", "-name", "balance", "-balance"}
+	entrySorts   = []string{"created_at", "-created_at", "amount", "-amount"}
+)
+
+// parseSort reads the sort query parameter, defaulting to newest first. It fails for keys
+// outside allowed.
+func parseSort(r *http.Request, allowed []string) (string, error) {
+	sort := r.URL.Query().Get("sort")
+	if sort == "" {
+		return "-created_at", nil
+	}
+	if !slices.Contains(allowed, sort) {
+		return "", fmt.Errorf("sort must be one of %s", strings.Join(allowed, ", "))
+	}
+	return sort, nil
+}
+
+// WithBareLists makes the paged list endpoints answer with a bare JSON array, as before the
+// {data, page} envelope, for clients that have not moved over. ?envelope=true still asks for it.
+func WithBareLists() Option {
+	return func(h *Handler) {
+		h.bareLists = true
+	}
+}
+
+// wantsEnvelope reports whether r gets a list wrapped in PagedResponse: ?envelope=true|false
+// decides, otherwise the server default.
+func (h *Handler) wantsEnvelope(r *http.Request) bool {
+	if v, err := strconv.ParseBool(r.URL.Query().Get("envelope")); err == nil {
+		return v
+	}
+	return !h.bareLists
+}
+
+// pageInfo describes the page at offset of a list of total items. NextOffset is set while
+// items remain past this page.
+func pageInfo(limit, offset int, total int64) PageInfo {
+	page := PageInfo{Limit: limit, Offset: offset, Total: total}
+	if int64(offset)+int64(limit) < total {
+		next := offset + limit
+		page.NextOffset = &next
+	}
+	return page
+}
+
+// respondPage writes one page of a list, in the envelope or as a bare array per wantsEnvelope.
+func (h *Handler) respondPage(w http.ResponseWriter, r *http.Request, data interface{}, limit, offset int, total int64) {
+	if !h.wantsEnvelope(r) {
+		respondJSON(w, http.StatusOK, data)
+		return
+	}
+	respondJSON(w, http.StatusOK, PagedResponse{Data: data, Page: pageInfo(limit, offset, total)})
+}
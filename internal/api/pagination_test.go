@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePage(t *testing.T) {
+	// Missing or invalid values fall back to the defaults and the limit is capped.
+	limit, offset := parsePage(httptest.NewRequest(http.MethodGet, "/accounts", nil))
+	assert.Equal(t, 20, limit)
+	assert.Equal(t, 0, offset)
+
+	limit, offset = parsePage(httptest.NewRequest(http.MethodGet, "/accounts?limit=500&offset=40", nil))
+	assert.Equal(t, 100, limit)
+	assert.Equal(t, 40, offset)
+
+	limit, offset = parsePage(httptest.NewRequest(http.MethodGet, "/accounts?limit=-1&offset=99999999999", nil))
+	assert.Equal(t, 20, limit)
+	assert.Equal(t, 0, offset)
+}
+
+func TestPageInfo_NextOffset(t *testing.T) {
+	// next_offset points past this page only while items remain.
+	page := pageInfo(20, 0, 45)
+	require.NotNil(t, page.NextOffset)
+	assert.Equal(t, 20, *page.NextOffset)
+	assert.Nil(t, pageInfo(20, 40, 45).NextOffset)
+	assert.Nil(t, pageInfo(20, 0, 20).NextOffset)
+}
+
+func TestRespondPage_Envelope(t *testing.T) {
+	// The envelope is the default; bare lists and ?envelope= switch between the two shapes.
+	data := []string{"a", "b"}
+	cases := []struct {
+		handler  *Handler
+		query    string
+		envelope bool
+	}{
+		{&Handler{}, "", true},
+		{&Handler{}, "?envelope=false", false},
+		{&Handler{bareLists: true}, "", false},
+		{&Handler{bareLists: true}, "?envelope=true", true},
+	}
+	for _, c := range cases {
+		rw := httptest.NewRecorder()
+		c.handler.respondPage(rw, httptest.NewRequest(http.MethodGet, "/accounts"+c.query, nil), data, 2, 0, 5)
+		require.Equal(t, http.StatusOK, rw.Code)
+		if c.envelope {
+			var body struct {
+				Data []string `json:"data"`
+				Page PageInfo `json:"page"`
+			}
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body), c.query)
+			assert.Equal(t, data, body.Data)
+			assert.Equal(t, int64(5), body.Page.Total)
+			continue
+		}
+		var body []string
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body), c.query)
+		assert.Equal(t, data, body)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	// Only whitelisted keys reach the queries; no sort means newest first.
+	sort, err := parseSort(httptest.NewRequest(http.MethodGet, "/accounts", nil), accountSorts)
+	require.NoError(t, err)
+	assert.Equal(t, "-created_at", sort)
+
+	sort, err = parseSort(httptest.NewRequest(http.MethodGet, "/accounts/x/entries?sort=-amount", nil), entrySorts)
+	require.NoError(t, err)
+	assert.Equal(t, "-amount", sort)
+
+	_, err = parseSort(httptest.NewRequest(http.MethodGet, "/accounts?sort=amount", nil), accountSorts)
+	assert.Error(t, err)
+	_, err = parseSort(httptest.NewRequest(http.MethodGet, "/accounts?sort=id", nil), accountSorts)
+	assert.Error(t, err)
+}
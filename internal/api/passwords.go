@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/password"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// WithPasswordPolicy replaces the default rules for passwords users choose.
+func WithPasswordPolicy(p password.Policy) Option {
+	return func(h *Handler) {
+		h.passwordPolicy = p
+	}
+}
+
+// checkPassword enforces the password policy on a password the user chose for email, answering
+// 400 with code weak_password and every rule it breaks.
+func (h *Handler) checkPassword(w http.ResponseWriter, r *http.Request, pw, email string) bool {
+	violations := h.passwordPolicy.Check(r.Context(), pw, email)
+	if len(violations) == 0 {
+		return true
+	}
+	resp := ErrorResponse{Error: "password does not meet the password policy", Code: "weak_password"}
+	for _, v := range violations {
+		resp.Violations = append(resp.Violations, PasswordViolation{Rule: v.Rule, Message: v.Message})
+	}
+	respondJSON(w, http.StatusBadRequest, resp)
+	return false
+}
+
+// WithPasswordHasher replaces bcrypt as the hash for new passwords. Existing hashes keep working
+// and are replaced as their users log in.
+func WithPasswordHasher(hasher *password.Hasher) Option {
+	return func(h *Handler) {
+		h.passwords = hasher
+	}
+}
+
+// rehashPassword replaces user's stored hash with one from the configured hasher after pw was
+// verified against it. Failures are only logged: the login has already succeeded and the next
+// one will try again.
+func (h *Handler) rehashPassword(ctx context.Context, user sqlc.User, pw string) {
+	hashed, err := h.passwords.Hash(pw)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to rehash password")
+		return
+	}
+	if _, err := h.store.RehashUserPassword(ctx, sqlc.RehashUserPasswordParams{
+		ID:      user.ID,
+		NewHash: hashed,
+		OldHash: user.HashedPassword,
+	}); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to store rehashed password")
+		return
+	}
+	log.Info().Str("user_id", user.ID.String()).Msg("Password rehashed")
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/password"
+)
+
+func TestPasswordPolicy_RegisterAndChange(t *testing.T) {
+	// Weak passwords are refused with every broken rule before any account is touched.
+	h := NewHandler(nil, nil, WithPasswordPolicy(password.Policy{MinLength: 12, Require: []string{password.ClassDigit}}))
+
+	for _, tc := range []struct {
+		handler http.HandlerFunc
+		body    string
+		rules   []string
+	}{
+		{h.Register, `{"email":"ada@example.com","password":"x"}`, []string{"min_length", "digit"}},
+		{h.ChangePassword, `{"email":"ada@example.com","current_password":"old","new_password":"ada-was-here-1"}`, []string{"contains_email"}},
+	} {
+		rw := httptest.NewRecorder()
+		tc.handler(rw, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body)))
+		require.Equal(t, http.StatusBadRequest, rw.Code, tc.body)
+
+		var resp ErrorResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+		assert.Equal(t, "weak_password", resp.Code)
+		var got []string
+		for _, v := range resp.Violations {
+			got = append(got, v.Rule)
+		}
+		assert.Equal(t, tc.rules, got)
+	}
+}
@@ -0,0 +1,358 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+const (
+	// defaultPaymentRequestTTL applies when a request is created without expires_at.
+	defaultPaymentRequestTTL = 7 * 24 * time.Hour
+	// maxPaymentRequestTTL bounds how long a payment link stays payable.
+	maxPaymentRequestTTL = 90 * 24 * time.Hour
+	// maxPaymentMemo bounds the memo, which is appended to both transfer entries.
+	maxPaymentMemo = 140
+)
+
+// paymentRequestStatus maps payment request errors to an HTTP status.
+func paymentRequestStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrPaymentRequestNotFound), errors.Is(err, service.ErrAccountNotFound),
+		errors.Is(err, service.ErrCrossOrgTransfer):
+		// Requests into other tenants' accounts are indistinguishable from missing ones.
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrPaymentRequestClosed), errors.Is(err, service.ErrPaymentRequestExpired):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrKYCRequired), errors.Is(err, service.ErrKYCLimitExceeded):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrMinimumBalance),
+		errors.Is(err, service.ErrDebitLimitExceeded), errors.Is(err, service.ErrOperationNotAllowed), errors.Is(err, service.ErrSavingsGoalLocked),
+		errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountPrecision), errors.Is(err, service.ErrCurrencyMismatch),
+		errors.Is(err, service.ErrSameAccountTransfer):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondPaymentRequestError writes err with its payment request status, hiding internal failures.
+func respondPaymentRequestError(w http.ResponseWriter, err error, msg string) {
+	status := paymentRequestStatus(err)
+	switch status {
+	case http.StatusNotFound:
+		respondError(w, status, "payment request or account not found")
+	case http.StatusInternalServerError:
+		log.Error().Err(err).Msg("Payment request operation failed")
+		respondError(w, status, msg)
+	default:
+		respondLedgerError(w, status, err)
+	}
+}
+
+// paymentRequestExpiry resolves the requested expires_at against now, applying the default and cap.
+func paymentRequestExpiry(raw string, now time.Time) (time.Time, error) {
+	if strings.TrimSpace(raw) == "" {
+		return now.Add(defaultPaymentRequestTTL), nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, errors.New("expires_at must be an RFC 3339 timestamp")
+	}
+	if !expiresAt.After(now) || expiresAt.After(now.Add(maxPaymentRequestTTL)) {
+		return time.Time{}, errors.New("expires_at must be in the future and within 90 days")
+	}
+	return expiresAt, nil
+}
+
+// paymentLink is the shareable URL a payer opens to see and pay the request.
+func (h *Handler) paymentLink(token string) string {
+	return h.paymentLinkBase + "/payment-requests/" + token
+}
+
+// CreatePaymentRequest godoc
+// @Summary      Request a payment
+// @Description  Creates a request for amount into an account the caller owns and returns a shareable link. Another ledger user of the same organization and currency pays it with POST /payment-requests/{token}/pay before expires_at (default 7 days, at most 90). The amount field accepts JSON number or string.
+// @Tags         payment-requests
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                                           true  "Account to be paid into"
+// @Param        body  body      object{amount=string,memo=string,expires_at=string}  true  "Request details"
+// @Success      201   {object}  PaymentRequestResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/payment-requests [post]
+// @Security     Bearer
+func (h *Handler) CreatePaymentRequest(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and authorize the receiving account.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.ownedAccount(w, r, userID, accountID); !ok {
+		return
+	}
+
+	// Step 2: Decode payload.
+	var input struct {
+		Amount    interface{} `json:"amount"`
+		Memo      string      `json:"memo"`
+		ExpiresAt string      `json:"expires_at"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	amount, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid amount")
+		return
+	}
+	memo := strings.TrimSpace(input.Memo)
+	if len(memo) > maxPaymentMemo {
+		respondError(w, http.StatusBadRequest, "memo must be at most 140 characters")
+		return
+	}
+	now := time.Now()
+	expiresAt, err := paymentRequestExpiry(input.ExpiresAt, now)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Step 3: Record the request.
+	pr, err := h.ledger.CreatePaymentRequest(r.Context(), service.PaymentRequestInput{
+		AccountID: accountID,
+		CreatedBy: userID,
+		Amount:    amount,
+		Memo:      memo,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		respondPaymentRequestError(w, err, "failed to create payment request")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toPaymentRequestResponse(pr, h.paymentLink(pr.Token), now))
+}
+
+// ListAccountPaymentRequests godoc
+// @Summary      List account payment requests
+// @Description  Returns payment requests into the account, newest first, with their links and status (pending, paid, cancelled or expired)
+// @Tags         payment-requests
+// @Produce      json
+// @Param        id      path      string  true   "Account ID"
+// @Param        limit   query     int     false  "Limit (default 20)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   PaymentRequestResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      404     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /accounts/{id}/payment-requests [get]
+// @Security     Bearer
+func (h *Handler) ListAccountPaymentRequests(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.visibleAccount(w, r, userID, accountID); !ok {
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	rows, err := h.store.ListPaymentRequestsByAccount(r.Context(), sqlc.ListPaymentRequestsByAccountParams{
+		AccountID: accountID,
+		Limit:     int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset:    int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to list payment requests")
+		respondError(w, http.StatusInternalServerError, "failed to list payment requests")
+		return
+	}
+
+	now := time.Now()
+	resp := make([]PaymentRequestResponse, 0, len(rows))
+	for _, pr := range rows {
+		resp = append(resp, toPaymentRequestResponse(pr, h.paymentLink(pr.Token), now))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetPaymentRequest godoc
+// @Summary      View a payment request
+// @Description  Returns the request behind a payment link so the payer can check the amount, memo and expiry before paying. Any authenticated user holding the link may view it; who paid is only shown to the requester's account holders and the payer.
+// @Tags         payment-requests
+// @Produce      json
+// @Param        token  path      string  true  "Token from the payment link"
+// @Success      200    {object}  PaymentRequestResponse
+// @Failure      401    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      500    {object}  ErrorResponse
+// @Router       /payment-requests/{token} [get]
+// @Security     Bearer
+func (h *Handler) GetPaymentRequest(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	pr, ok := h.loadPaymentRequest(w, r)
+	if !ok {
+		return
+	}
+
+	resp := toPaymentRequestResponse(pr, h.paymentLink(pr.Token), time.Now())
+	if pr.PaidBy.UUID != userID && !h.canViewPaymentRequestAccount(r, userID, pr) {
+		resp.PayerAccountID, resp.TransactionID = nil, nil
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// PayPaymentRequest godoc
+// @Summary      Pay a payment request
+// @Description  Transfers the requested amount from an account the caller owns into the requester's account and marks the request paid. The payer's product rules and transfer fee apply, and both sides receive a payment_request alert. With SIGNED_TRANSFER_THRESHOLD set, requests above it must be paid with a signed request like POST /transfers. Fails with 409 once the request is paid, cancelled or expired.
+// @Tags         payment-requests
+// @Accept       json
+// @Produce      json
+// @Param        token  path      string                     true  "Token from the payment link"
+// @Param        body   body      object{account_id=string}  true  "Account to pay from"
+// @Success      200    {object}  PaymentRequestResponse
+// @Failure      400    {object}  ErrorResponse
+// @Failure      401    {object}  ErrorResponse
+// @Failure      403    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      409    {object}  ErrorResponse
+// @Failure      500    {object}  ErrorResponse
+// @Router       /payment-requests/{token}/pay [post]
+// @Security     Bearer
+func (h *Handler) PayPaymentRequest(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and authorize the paying account.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	body := h.captureSignedBody(r)
+	var input struct {
+		AccountID string `json:"account_id"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	payerID, err := uuid.Parse(input.AccountID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account_id")
+		return
+	}
+	if _, ok := h.ownedAccount(w, r, userID, payerID); !ok {
+		return
+	}
+	pr, ok := h.loadPaymentRequest(w, r)
+	if !ok {
+		return
+	}
+	ctx, ok := h.checkRequestSignature(w, r, body, payerID, pr.Amount)
+	if !ok {
+		return
+	}
+	r = r.WithContext(ctx)
+
+	// Step 2: Pay; the service re-checks status, expiry, organization and funds under lock.
+	pr, err = h.ledger.PayPaymentRequest(r.Context(), pr.Token, payerID, userID)
+	if respondScreening(w, err) {
+		return
+	}
+	if err != nil {
+		respondPaymentRequestError(w, err, "failed to pay payment request")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toPaymentRequestResponse(pr, h.paymentLink(pr.Token), time.Now()))
+}
+
+// CancelPaymentRequest godoc
+// @Summary      Cancel a payment request
+// @Description  Withdraws a pending request so its link can no longer be paid. Only owners of the receiving account may cancel.
+// @Tags         payment-requests
+// @Produce      json
+// @Param        token  path      string  true  "Token from the payment link"
+// @Success      200    {object}  PaymentRequestResponse
+// @Failure      401    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      409    {object}  ErrorResponse
+// @Failure      500    {object}  ErrorResponse
+// @Router       /payment-requests/{token}/cancel [post]
+// @Security     Bearer
+func (h *Handler) CancelPaymentRequest(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	pr, ok := h.loadPaymentRequest(w, r)
+	if !ok {
+		return
+	}
+	acc, err := h.store.GetAccount(r.Context(), pr.AccountID)
+	if err != nil || !h.hasAccountRole(r.Context(), userID, acc, AccountRoleOwner) {
+		respondError(w, http.StatusNotFound, service.ErrPaymentRequestNotFound.Error())
+		return
+	}
+
+	pr, err = h.ledger.CancelPaymentRequest(r.Context(), pr.ID)
+	if err != nil {
+		respondPaymentRequestError(w, err, "failed to cancel payment request")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toPaymentRequestResponse(pr, h.paymentLink(pr.Token), time.Now()))
+}
+
+// loadPaymentRequest fetches the request named by the token in the URL, answering 404 when missing.
+func (h *Handler) loadPaymentRequest(w http.ResponseWriter, r *http.Request) (sqlc.PaymentRequest, bool) {
+	pr, err := h.store.GetPaymentRequestByToken(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, service.ErrPaymentRequestNotFound.Error())
+			return sqlc.PaymentRequest{}, false
+		}
+		log.Error().Err(err).Msg("Failed to fetch payment request")
+		respondError(w, http.StatusInternalServerError, "failed to fetch payment request")
+		return sqlc.PaymentRequest{}, false
+	}
+	return pr, true
+}
+
+// canViewPaymentRequestAccount reports whether the caller can see the account being paid into.
+func (h *Handler) canViewPaymentRequestAccount(r *http.Request, userID uuid.UUID, pr sqlc.PaymentRequest) bool {
+	acc, err := h.store.GetAccount(r.Context(), pr.AccountID)
+	return err == nil && h.hasAccountRole(r.Context(), userID, acc, AccountRoleViewer)
+}
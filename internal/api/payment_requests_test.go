@@ -0,0 +1,66 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestPaymentRequestStatus(t *testing.T) {
+	// Closed and expired requests conflict; ledger refusals are client errors.
+	assert.Equal(t, http.StatusNotFound, paymentRequestStatus(service.ErrPaymentRequestNotFound))
+	assert.Equal(t, http.StatusNotFound, paymentRequestStatus(service.ErrCrossOrgTransfer))
+	assert.Equal(t, http.StatusConflict, paymentRequestStatus(service.ErrPaymentRequestClosed))
+	assert.Equal(t, http.StatusConflict, paymentRequestStatus(service.ErrPaymentRequestExpired))
+	assert.Equal(t, http.StatusForbidden, paymentRequestStatus(service.ErrKYCLimitExceeded))
+	assert.Equal(t, http.StatusBadRequest, paymentRequestStatus(service.ErrInsufficientFunds))
+	assert.Equal(t, http.StatusInternalServerError, paymentRequestStatus(errors.New("connection reset")))
+}
+
+func TestPaymentRequestExpiry(t *testing.T) {
+	// Expiry defaults to a week and must fall within the next 90 days.
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	got, err := paymentRequestExpiry("", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(7*24*time.Hour), got)
+
+	got, err = paymentRequestExpiry("2026-03-02T12:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(24*time.Hour), got)
+
+	for _, raw := range []string{"tomorrow", "2026-03-01T11:00:00Z", "2026-06-30T12:00:00Z"} {
+		_, err := paymentRequestExpiry(raw, now)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestToPaymentRequestResponse_ReportsExpiry(t *testing.T) {
+	// A pending request past its expiry reads as expired; paid requests expose the payer.
+	now := time.Now()
+	pr := sqlc.PaymentRequest{Token: "pr_abc", AccountID: uuid.New(), Status: service.PaymentRequestPending, ExpiresAt: now.Add(-time.Minute)}
+	h := &Handler{paymentLinkBase: "https://bank.example.com"}
+
+	resp := toPaymentRequestResponse(pr, h.paymentLink(pr.Token), now)
+	assert.Equal(t, service.PaymentRequestExpired, resp.Status)
+	assert.Equal(t, "https://bank.example.com/payment-requests/pr_abc", resp.Link)
+	assert.Nil(t, resp.PayerAccountID)
+
+	payer := uuid.New()
+	pr.Status = service.PaymentRequestPaid
+	pr.PayerAccountID = uuid.NullUUID{UUID: payer, Valid: true}
+	pr.PaidAt = sql.NullTime{Time: now, Valid: true}
+	resp = toPaymentRequestResponse(pr, h.paymentLink(pr.Token), now)
+	assert.Equal(t, service.PaymentRequestPaid, resp.Status)
+	require.NotNil(t, resp.PayerAccountID)
+	assert.Equal(t, payer.String(), *resp.PayerAccountID)
+}
@@ -0,0 +1,314 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/paystack"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/stripe"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// maxWebhookBody bounds webhook payloads; provider events are a few KB.
+const maxWebhookBody = 1 << 20
+
+// initiatePaystackDeposit records a pending charge and starts Paystack checkout for it.
+func (h *Handler) initiatePaystackDeposit(w http.ResponseWriter, r *http.Request, userID uuid.UUID, acc sqlc.Account, amountStr string) {
+	amount, err := decimal.NewFromString(amountStr)
+	if err != nil || !amount.IsPositive() {
+		respondError(w, http.StatusBadRequest, service.ErrInvalidAmount.Error())
+		return
+	}
+	subunits, err := paystack.ToSubunits(amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.store.GetUser(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to load user for deposit")
+		respondError(w, http.StatusInternalServerError, "failed to start deposit")
+		return
+	}
+
+	// Persist the charge before calling Paystack so a webhook can never arrive for an unknown reference.
+	charge, err := h.store.CreatePaymentCharge(r.Context(), sqlc.CreatePaymentChargeParams{
+		Provider:  "paystack",
+		Reference: paystack.NewReference(),
+		AccountID: acc.ID,
+		UserID:    userID,
+		Amount:    amount.StringFixed(4),
+		Currency:  acc.Currency,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("account_id", acc.ID.String()).Msg("Failed to record payment charge")
+		respondError(w, http.StatusInternalServerError, "failed to start deposit")
+		return
+	}
+
+	checkout, err := h.paystack.Initialize(r.Context(), paystack.InitializeRequest{
+		Email:       user.Email,
+		Amount:      subunits,
+		Currency:    acc.Currency,
+		Reference:   charge.Reference,
+		CallbackURL: h.paystackCallbackURL,
+		Metadata:    map[string]string{"account_id": acc.ID.String()},
+	})
+	if err != nil {
+		log.Error().Err(err).Str("reference", charge.Reference).Msg("Paystack initialize failed")
+		if markErr := h.store.MarkPaymentChargeFailed(r.Context(), charge.Reference); markErr != nil {
+			log.Error().Err(markErr).Str("reference", charge.Reference).Msg("Failed to mark payment charge failed")
+		}
+		respondError(w, http.StatusBadGateway, "payment provider unavailable")
+		return
+	}
+
+	log.Info().Str("reference", charge.Reference).Str("account_id", acc.ID.String()).Str("amount", charge.Amount).Msg("Paystack deposit initialized")
+	respondJSON(w, http.StatusAccepted, DepositInitResponse{
+		Reference:        charge.Reference,
+		AuthorizationURL: checkout.AuthorizationURL,
+		AccessCode:       checkout.AccessCode,
+		Status:           charge.Status,
+	})
+}
+
+// PaystackWebhook godoc
+// @Summary      Paystack payment webhook
+// @Description  Receives Paystack events. The X-Paystack-Signature HMAC is verified before anything is read; charge.success posts the pending deposit exactly once per reference.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        X-Paystack-Signature  header    string  true  "HMAC-SHA512 of the body"
+// @Success      200                   {object}  MessageResponse
+// @Failure      400                   {object}  ErrorResponse
+// @Failure      401                   {object}  ErrorResponse
+// @Failure      404                   {object}  ErrorResponse
+// @Failure      500                   {object}  ErrorResponse
+// @Router       /webhooks/paystack [post]
+func (h *Handler) PaystackWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.paystack == nil {
+		respondError(w, http.StatusNotFound, "paystack is not configured")
+		return
+	}
+
+	// Step 1: Authenticate the sender by signature over the raw body.
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	if !h.paystack.VerifySignature(body, r.Header.Get(paystack.SignatureHeader)) {
+		log.Warn().Str("remote_addr", r.RemoteAddr).Msg("Paystack webhook rejected - bad signature")
+		respondError(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+
+	// Step 2: Acknowledge events we do not act on so Paystack stops retrying them.
+	evt, err := paystack.ParseWebhook(body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	if evt.Event != paystack.EventChargeSuccess || evt.Data.Status != "success" {
+		respondJSON(w, http.StatusOK, MessageResponse{Message: "ignored"})
+		return
+	}
+
+	// Step 3: Settle idempotently; redelivery of the same reference is a no-op.
+	amount := paystack.FromSubunits(evt.Data.Amount)
+	charge, err := h.ledger.SettleCharge(r.Context(), evt.Data.Reference, amount.String(), evt.Data.Currency)
+	switch {
+	case errors.Is(err, service.ErrChargeNotFound):
+		log.Warn().Str("reference", evt.Data.Reference).Msg("Paystack webhook for unknown reference")
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, service.ErrChargeMismatch), errors.Is(err, service.ErrChargeOverLimit):
+		// Acknowledge: retrying will not change the amount; the charge is flagged for manual review.
+		respondJSON(w, http.StatusOK, MessageResponse{Message: "charge flagged for review"})
+		return
+	case err != nil:
+		// Non-2xx makes Paystack retry later, which is what we want for transient failures.
+		log.Error().Err(err).Str("reference", evt.Data.Reference).Msg("Failed to settle Paystack charge")
+		respondError(w, http.StatusInternalServerError, "failed to settle charge")
+		return
+	}
+
+	log.Info().Str("reference", charge.Reference).Str("status", charge.Status).Msg("Paystack charge settled")
+	respondJSON(w, http.StatusOK, MessageResponse{Message: "ok"})
+}
+
+// CreateCardDeposit godoc
+// @Summary      Fund account by card (Stripe)
+// @Description  Creates a Stripe PaymentIntent for the amount and returns its client secret for Stripe.js / mobile SDK confirmation. The account is credited through the normal deposit path only when the signed payment_intent.succeeded webhook arrives.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                 true  "Account ID"
+// @Param        body  body      object{amount=string}  true  "Deposit amount (e.g., 50.00)"
+// @Success      201   {object}  CardDepositResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      502   {object}  ErrorResponse
+// @Failure      503   {object}  ErrorResponse
+// @Router       /accounts/{id}/deposits/card [post]
+// @Security     Bearer
+func (h *Handler) CreateCardDeposit(w http.ResponseWriter, r *http.Request) {
+	if h.stripe == nil {
+		respondError(w, http.StatusServiceUnavailable, "card funding is not configured")
+		return
+	}
+
+	// Step 1: Authenticate caller and enforce ownership.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	acc, ok := h.ownedAccount(w, r, userID, accountID)
+	if !ok {
+		return
+	}
+
+	// Step 2: Validate amount against the currency's minor unit.
+	amountStr, ok := decodeAmountFromBody(w, r)
+	if !ok {
+		return
+	}
+	amount, err := decimal.NewFromString(amountStr)
+	if err != nil || !amount.IsPositive() {
+		respondError(w, http.StatusBadRequest, service.ErrInvalidAmount.Error())
+		return
+	}
+	minor, err := stripe.ToMinorUnits(amount, acc.Currency)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Step 3: Record the pending charge, then create the PaymentIntent keyed by it.
+	charge, err := h.store.CreatePaymentCharge(r.Context(), sqlc.CreatePaymentChargeParams{
+		Provider:  "stripe",
+		Reference: paystack.NewReference(),
+		AccountID: acc.ID,
+		UserID:    userID,
+		Amount:    amount.StringFixed(4),
+		Currency:  acc.Currency,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("account_id", acc.ID.String()).Msg("Failed to record payment charge")
+		respondError(w, http.StatusInternalServerError, "failed to start deposit")
+		return
+	}
+
+	intent, err := h.stripe.CreatePaymentIntent(r.Context(), stripe.PaymentIntentRequest{
+		Amount:         minor,
+		Currency:       acc.Currency,
+		Description:    "Account funding",
+		IdempotencyKey: charge.Reference,
+		Metadata:       map[string]string{"reference": charge.Reference, "account_id": acc.ID.String()},
+	})
+	if err != nil {
+		log.Error().Err(err).Str("reference", charge.Reference).Msg("Stripe PaymentIntent creation failed")
+		if markErr := h.store.MarkPaymentChargeFailed(r.Context(), charge.Reference); markErr != nil {
+			log.Error().Err(markErr).Str("reference", charge.Reference).Msg("Failed to mark payment charge failed")
+		}
+		respondError(w, http.StatusBadGateway, "payment provider unavailable")
+		return
+	}
+	if err := h.store.SetPaymentChargeProviderReference(r.Context(), sqlc.SetPaymentChargeProviderReferenceParams{
+		ProviderReference: sql.NullString{String: intent.ID, Valid: true},
+		Reference:         charge.Reference,
+	}); err != nil {
+		log.Warn().Err(err).Str("reference", charge.Reference).Msg("Failed to store PaymentIntent ID")
+	}
+
+	log.Info().Str("reference", charge.Reference).Str("payment_intent", intent.ID).Str("amount", charge.Amount).Msg("Card deposit initialized")
+	respondJSON(w, http.StatusCreated, CardDepositResponse{
+		Reference:       charge.Reference,
+		PaymentIntentID: intent.ID,
+		ClientSecret:    intent.ClientSecret,
+		Status:          charge.Status,
+	})
+}
+
+// StripeWebhook godoc
+// @Summary      Stripe payment webhook
+// @Description  Receives Stripe events. The Stripe-Signature header is verified (HMAC-SHA256, 5 minute tolerance); payment_intent.succeeded posts the pending card deposit exactly once per reference.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        Stripe-Signature  header    string  true  "Stripe webhook signature"
+// @Success      200               {object}  MessageResponse
+// @Failure      400               {object}  ErrorResponse
+// @Failure      401               {object}  ErrorResponse
+// @Failure      404               {object}  ErrorResponse
+// @Failure      500               {object}  ErrorResponse
+// @Router       /webhooks/stripe [post]
+func (h *Handler) StripeWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.stripe == nil {
+		respondError(w, http.StatusNotFound, "stripe is not configured")
+		return
+	}
+
+	// Step 1: Authenticate the sender by signature over the raw body.
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	if err := h.stripe.VerifySignature(body, r.Header.Get(stripe.SignatureHeader), stripe.DefaultTolerance); err != nil {
+		log.Warn().Err(err).Str("remote_addr", r.RemoteAddr).Msg("Stripe webhook rejected")
+		respondError(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+
+	// Step 2: Acknowledge events we do not act on so Stripe stops retrying them.
+	evt, err := stripe.ParseEvent(body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+	intent := evt.Data.Object
+	reference := intent.Metadata["reference"]
+	if evt.Type != stripe.EventPaymentIntentSucceeded || reference == "" {
+		respondJSON(w, http.StatusOK, MessageResponse{Message: "ignored"})
+		return
+	}
+
+	// Step 3: Credit through the shared deposit path; redelivery of the same intent is a no-op.
+	currency := strings.ToUpper(intent.Currency)
+	amount := stripe.FromMinorUnits(intent.AmountReceived, currency)
+	charge, err := h.ledger.SettleCharge(r.Context(), reference, amount.String(), currency)
+	switch {
+	case errors.Is(err, service.ErrChargeNotFound):
+		log.Warn().Str("reference", reference).Str("payment_intent", intent.ID).Msg("Stripe webhook for unknown reference")
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, service.ErrChargeMismatch), errors.Is(err, service.ErrChargeOverLimit):
+		respondJSON(w, http.StatusOK, MessageResponse{Message: "charge flagged for review"})
+		return
+	case err != nil:
+		log.Error().Err(err).Str("reference", reference).Msg("Failed to settle Stripe charge")
+		respondError(w, http.StatusInternalServerError, "failed to settle charge")
+		return
+	}
+
+	log.Info().Str("reference", charge.Reference).Str("payment_intent", intent.ID).Str("status", charge.Status).Msg("Stripe charge settled")
+	respondJSON(w, http.StatusOK, MessageResponse{Message: "ok"})
+}
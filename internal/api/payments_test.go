@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/flutterwave"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/inbound"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/paystack"
+)
+
+func TestPaystackWebhook_RejectsBadSignature(t *testing.T) {
+	// Unsigned webhooks are rejected before any ledger work happens.
+	client, err := paystack.NewClient("sk_test_secret")
+	require.NoError(t, err)
+	h := NewHandler(nil, nil, WithPaystack(client, ""))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/paystack", strings.NewReader(`{"event":"charge.success"}`))
+	req.Header.Set(paystack.SignatureHeader, "deadbeef")
+	rw := httptest.NewRecorder()
+	h.PaystackWebhook(rw, req)
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+}
+
+func TestFlutterwaveWebhook_RejectsBadHash(t *testing.T) {
+	// Webhooks without the dashboard secret hash never reach the ledger.
+	client, err := flutterwave.NewClient("FLWSECK_TEST-x", "my-hash")
+	require.NoError(t, err)
+	h := NewHandler(nil, nil, WithFlutterwave(client, ""))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/flutterwave", strings.NewReader(`{"event":"transfer.completed"}`))
+	req.Header.Set(flutterwave.HashHeader, "wrong")
+	rw := httptest.NewRecorder()
+	h.FlutterwaveWebhook(rw, req)
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+}
+
+func TestPaymentsWebhook_RejectsStaleAndUnsigned(t *testing.T) {
+	// Deliveries signed outside the tolerance window are treated as replays.
+	verifier, err := inbound.NewVerifier("whsec_inbound")
+	require.NoError(t, err)
+	h := NewHandler(nil, nil, WithInboundPayments(verifier))
+	body := `{"event_id":"evt_1","provider":"bank","virtual_account_number":"9000000001","amount":"10.00","currency":"USD"}`
+
+	for _, sig := range []string{"", verifier.Sign([]byte(body), time.Now().Add(-time.Hour))} {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/payments", strings.NewReader(body))
+		req.Header.Set(inbound.SignatureHeader, sig)
+		rw := httptest.NewRecorder()
+		h.PaymentsWebhook(rw, req)
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	}
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// maxPayoutUpload caps payout files; 1000 rows with full narrations fit well inside it.
+const maxPayoutUpload = 1 << 20
+
+// respondPayoutBatchError answers 400 with every rejected line, or maps other errors to a status.
+func respondPayoutBatchError(w http.ResponseWriter, err error, sourceID uuid.UUID) {
+	var (
+		rowsErr  *service.PayoutBatchError
+		tooLarge *http.MaxBytesError
+	)
+	switch {
+	case errors.As(err, &rowsErr):
+		resp := PayoutBatchErrorResponse{Error: service.ErrInvalidPayoutBatch.Error(), Rows: make([]PayoutRowErrorResponse, 0, len(rowsErr.Rows))}
+		for _, row := range rowsErr.Rows {
+			resp.Rows = append(resp.Rows, PayoutRowErrorResponse{Line: row.Line, Error: row.Message})
+		}
+		respondJSON(w, http.StatusBadRequest, resp)
+	case errors.As(err, &tooLarge):
+		respondError(w, http.StatusRequestEntityTooLarge, "payout file too large")
+	case errors.Is(err, service.ErrInvalidPayoutBatch):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrAccountNotFound):
+		respondError(w, http.StatusNotFound, "account not found")
+	default:
+		log.Error().Err(err).Str("source_id", sourceID.String()).Msg("Failed to queue payout batch")
+		respondError(w, http.StatusInternalServerError, "failed to queue payout batch")
+	}
+}
+
+// CreatePayoutBatch godoc
+// @Summary      Upload a bulk payout file
+// @Description  Accepts a CSV of account,amount,narration rows (header optional, narration up to 140 characters, at most 1000 rows) and queues one transfer per row from an account the caller owns. Every row is validated first; if any is invalid nothing is queued and the response lists each rejected line. Queued rows post or fail independently; poll GET /payout-batches/{id} for progress. Accepts multipart/form-data (field "file") or a raw text/csv body. Requires async transfers to be enabled. With SIGNED_TRANSFER_THRESHOLD set, a file whose amounts add up to more must be uploaded in a signed request like POST /transfers.
+// @Tags         accounts
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id    path      string  true   "Source account ID"
+// @Param        file  formData  file    false  "Payout CSV"
+// @Success      202   {object}  PayoutBatchResponse
+// @Failure      400   {object}  PayoutBatchErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      413   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Failure      503   {object}  ErrorResponse
+// @Router       /accounts/{id}/payout-batches [post]
+// @Security     Bearer
+func (h *Handler) CreatePayoutBatch(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and authorize the source account.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	sourceID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.ownedAccount(w, r, userID, sourceID); !ok {
+		return
+	}
+	if h.transferWorkers == nil {
+		respondError(w, http.StatusServiceUnavailable, "async transfers are not enabled")
+		return
+	}
+
+	// Step 2: Read the file from a multipart field or the raw body.
+	signedBody := h.captureSignedBody(r)
+	r.Body = http.MaxBytesReader(w, r.Body, maxPayoutUpload)
+	var (
+		body     io.Reader = r.Body
+		filename string
+	)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				respondError(w, http.StatusRequestEntityTooLarge, "payout file too large")
+				return
+			}
+			respondError(w, http.StatusBadRequest, "file field required")
+			return
+		}
+		defer func() { _ = file.Close() }()
+		body, filename = file, header.Filename
+	}
+
+	// Step 3: Validate every row, then queue them all as one batch.
+	rows, err := service.ParsePayoutCSV(body)
+	if err != nil {
+		respondPayoutBatchError(w, err, sourceID)
+		return
+	}
+	// The batch as a whole is what leaves the account, so its total meets the signing threshold.
+	total := decimal.Zero
+	for _, row := range rows {
+		total = total.Add(row.Amount)
+	}
+	ctx, ok := h.checkRequestSignature(w, r, signedBody, sourceID, total.String())
+	if !ok {
+		return
+	}
+	r = r.WithContext(ctx)
+	batch, jobs, err := h.ledger.CreatePayoutBatch(r.Context(), service.PayoutBatchRequest{
+		SourceAccountID: sourceID,
+		CreatedBy:       userID,
+		Filename:        filename,
+		Rows:            rows,
+	})
+	if err != nil {
+		respondPayoutBatchError(w, err, sourceID)
+		return
+	}
+	h.transferWorkers.Notify()
+
+	w.Header().Set("Location", "/payout-batches/"+batch.ID.String())
+	respondJSON(w, http.StatusAccepted, toPayoutBatchResponse(batch, jobs))
+}
+
+// GetPayoutBatch godoc
+// @Summary      Get a bulk payout
+// @Description  Returns a payout batch with pending, posted and failed counts and each row's outcome. Posted rows carry their ledger transaction ID; failed rows carry the reason. Visible to the uploader and anyone who can view the source account.
+// @Tags         accounts
+// @Produce      json
+// @Param        id   path      string  true  "Payout batch ID"
+// @Success      200  {object}  PayoutBatchResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /payout-batches/{id} [get]
+// @Security     Bearer
+func (h *Handler) GetPayoutBatch(w http.ResponseWriter, r *http.Request) {
+	batch, jobs, ok := h.loadPayoutBatch(w, r)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, toPayoutBatchResponse(batch, jobs))
+}
+
+// DownloadPayoutBatchResults godoc
+// @Summary      Download bulk payout results
+// @Description  Returns the batch's rows as CSV with columns line, account_id, amount, narration, status, transaction_id and failure_reason, for reconciling against the uploaded file. Rows still pending are included with an empty outcome.
+// @Tags         accounts
+// @Produce      text/csv
+// @Param        id   path      string  true  "Payout batch ID"
+// @Success      200  {string}  string  "CSV results"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /payout-batches/{id}/results.csv [get]
+// @Security     Bearer
+func (h *Handler) DownloadPayoutBatchResults(w http.ResponseWriter, r *http.Request) {
+	batch, jobs, ok := h.loadPayoutBatch(w, r)
+	if !ok {
+		return
+	}
+
+	// Render into a buffer so failures still return JSON errors.
+	var buf bytes.Buffer
+	if err := writePayoutResults(&buf, jobs); err != nil {
+		log.Error().Err(err).Str("batch_id", batch.ID.String()).Msg("Failed to render payout results")
+		respondError(w, http.StatusInternalServerError, "failed to render payout results")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "payout-results-"+batch.ID.String()+".csv"))
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Error().Err(err).Msg("Failed to write payout results response")
+	}
+}
+
+// loadPayoutBatch fetches the batch named in the URL and its rows, answering 404 to callers
+// who neither uploaded it nor can view its source account.
+func (h *Handler) loadPayoutBatch(w http.ResponseWriter, r *http.Request) (sqlc.PayoutBatch, []sqlc.TransferJob, bool) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return sqlc.PayoutBatch{}, nil, false
+	}
+	batchID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payout batch ID")
+		return sqlc.PayoutBatch{}, nil, false
+	}
+
+	batch, err := h.store.GetPayoutBatch(r.Context(), batchID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, service.ErrPayoutBatchNotFound.Error())
+			return sqlc.PayoutBatch{}, nil, false
+		}
+		log.Error().Err(err).Str("batch_id", batchID.String()).Msg("Failed to fetch payout batch")
+		respondError(w, http.StatusInternalServerError, "failed to fetch payout batch")
+		return sqlc.PayoutBatch{}, nil, false
+	}
+	if batch.CreatedBy != userID {
+		source, err := h.store.GetAccount(r.Context(), batch.SourceAccountID)
+		if err != nil || !h.hasAccountRole(r.Context(), userID, source, AccountRoleViewer) {
+			respondError(w, http.StatusNotFound, service.ErrPayoutBatchNotFound.Error())
+			return sqlc.PayoutBatch{}, nil, false
+		}
+	}
+
+	jobs, err := h.store.ListPayoutBatchRows(r.Context(), uuid.NullUUID{UUID: batch.ID, Valid: true})
+	if err != nil {
+		log.Error().Err(err).Str("batch_id", batchID.String()).Msg("Failed to list payout batch rows")
+		respondError(w, http.StatusInternalServerError, "failed to fetch payout batch")
+		return sqlc.PayoutBatch{}, nil, false
+	}
+	return batch, jobs, true
+}
+
+// writePayoutResults renders one CSV line per batch row with its outcome.
+func writePayoutResults(w io.Writer, jobs []sqlc.TransferJob) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"line", "account_id", "amount", "narration", "status", "transaction_id", "failure_reason"}); err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		row := toPayoutBatchRowResponse(job)
+		record := []string{strconv.Itoa(int(row.Line)), row.AccountID, row.Amount, row.Narration, row.Status, row.TransactionID, row.FailureReason}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
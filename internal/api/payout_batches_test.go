@@ -0,0 +1,56 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestRespondPayoutBatchError_ListsRows(t *testing.T) {
+	// A rejected file answers 400 with each bad line so the uploader can fix them all at once.
+	w := httptest.NewRecorder()
+	respondPayoutBatchError(w, &service.PayoutBatchError{Rows: []service.PayoutRowError{{Line: 3, Message: "invalid account ID"}}}, uuid.New())
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var resp PayoutBatchErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []PayoutRowErrorResponse{{Line: 3, Error: "invalid account ID"}}, resp.Rows)
+}
+
+func TestToPayoutBatchResponse_CountsRowOutcomes(t *testing.T) {
+	// A batch is processing until no row is pending; only posted rows expose a transaction ID.
+	posted := sqlc.TransferJob{ID: uuid.New(), Status: service.TransferJobPosted, BatchRow: sql.NullInt32{Int32: 2, Valid: true}}
+	failed := sqlc.TransferJob{ID: uuid.New(), Status: service.TransferJobFailed, FailureReason: "insufficient funds", BatchRow: sql.NullInt32{Int32: 3, Valid: true}}
+	pending := sqlc.TransferJob{ID: uuid.New(), Status: service.TransferJobPending, BatchRow: sql.NullInt32{Int32: 4, Valid: true}}
+
+	resp := toPayoutBatchResponse(sqlc.PayoutBatch{ID: uuid.New(), RowCount: 3}, []sqlc.TransferJob{posted, failed, pending})
+	assert.Equal(t, "processing", resp.Status)
+	assert.Equal(t, [3]int32{1, 1, 1}, [3]int32{resp.Posted, resp.Failed, resp.Pending})
+	assert.Equal(t, posted.ID.String(), resp.Rows[0].TransactionID)
+	assert.Empty(t, resp.Rows[1].TransactionID)
+	assert.Equal(t, "insufficient funds", resp.Rows[1].FailureReason)
+
+	resp = toPayoutBatchResponse(sqlc.PayoutBatch{ID: uuid.New(), RowCount: 2}, []sqlc.TransferJob{posted, failed})
+	assert.Equal(t, "completed", resp.Status)
+}
+
+func TestWritePayoutResults(t *testing.T) {
+	// The results file has a header and one line per row with its outcome.
+	to := uuid.New()
+	job := sqlc.TransferJob{ID: uuid.New(), ToAccountID: to, Amount: "10.0000", Narration: "Bonus, March", Status: service.TransferJobFailed, FailureReason: "insufficient funds", BatchRow: sql.NullInt32{Int32: 2, Valid: true}}
+
+	var buf bytes.Buffer
+	require.NoError(t, writePayoutResults(&buf, []sqlc.TransferJob{job}))
+	assert.Equal(t, "line,account_id,amount,narration,status,transaction_id,failure_reason\n"+
+		"2,"+to.String()+",10.0000,\"Bonus, March\",failed,,insufficient funds\n", buf.String())
+}
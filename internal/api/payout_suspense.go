@@ -0,0 +1,259 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/flutterwave"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/nip"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// respondSuspenseCaseError maps suspense case errors to an HTTP status, hiding internal failures.
+func respondSuspenseCaseError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrSuspenseCaseNotFound):
+		respondLedgerError(w, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrSuspenseCaseResolved):
+		respondLedgerError(w, http.StatusConflict, err)
+	default:
+		log.Error().Err(err).Msg(msg)
+		respondError(w, http.StatusInternalServerError, msg)
+	}
+}
+
+// ListPayoutSuspenseCases godoc
+// @Summary      List payout suspense cases
+// @Description  Returns a page of failed payouts whose funds moved to the Payout Suspense account, in one status, oldest first, wrapped in {data, page}. The default status "open" is the queue awaiting a refund or retry. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        status    query     string  false  "open (default), refunded or retried"
+// @Param        limit     query     int     false  "Limit (default 20, max 100)"
+// @Param        offset    query     int     false  "Offset (default 0)"
+// @Param        envelope  query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200       {object}  PagedResponse{data=[]PayoutSuspenseCaseResponse}
+// @Failure      400       {object}  ErrorResponse
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
+// @Router       /admin/payout-suspense [get]
+// @Security     Bearer
+func (h *Handler) ListPayoutSuspenseCases(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.SuspenseCaseOpen
+	case service.SuspenseCaseOpen, service.SuspenseCaseRefunded, service.SuspenseCaseRetried:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be open, refunded or retried")
+		return
+	}
+	limit, offset := parsePage(r)
+
+	// Step 2: Fetch the page and the size of the whole list.
+	rows, err := h.store.ListPayoutSuspenseCasesByStatus(r.Context(), sqlc.ListPayoutSuspenseCasesByStatusParams{
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		Offset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to list payout suspense cases")
+		respondError(w, http.StatusInternalServerError, "failed to list payout suspense cases")
+		return
+	}
+	total, err := h.store.CountPayoutSuspenseCasesByStatus(r.Context(), status)
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to count payout suspense cases")
+		respondError(w, http.StatusInternalServerError, "failed to list payout suspense cases")
+		return
+	}
+
+	resp := make([]PayoutSuspenseCaseResponse, 0, len(rows))
+	for _, c := range rows {
+		resp = append(resp, toPayoutSuspenseCaseResponse(c))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// GetPayoutSuspenseCase godoc
+// @Summary      Get a payout suspense case
+// @Description  Returns a payout suspense case with the payout that failed and, once retried, the payout that replaced it. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Suspense case ID"
+// @Success      200  {object}  PayoutSuspenseCaseResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/payout-suspense/{id} [get]
+// @Security     Bearer
+func (h *Handler) GetPayoutSuspenseCase(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid suspense case ID")
+		return
+	}
+	sc, err := h.ledger.GetSuspenseCase(r.Context(), id)
+	if err != nil {
+		respondSuspenseCaseError(w, err, "failed to get payout suspense case")
+		return
+	}
+	resp, err := h.suspenseCaseDetail(r, sc)
+	if err != nil {
+		respondSuspenseCaseError(w, err, "failed to get payout suspense case")
+		return
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ResolvePayoutSuspenseCase godoc
+// @Summary      Refund or retry a failed payout
+// @Description  Resolves an open payout suspense case. refund credits the funds back to the customer's account as a reversal. retry holds them in payouts clearing again as a new payout to the same beneficiary on the same rail, under a new reference, and sends it; NIP payouts repeat the name enquiry first. A retried payout that fails again opens a case of its own. 409 when the case is already resolved or its rail is not configured. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                             true  "Suspense case ID"
+// @Param        body  body      object{action=string,note=string}  true  "action: refund or retry"
+// @Success      200   {object}  PayoutSuspenseCaseResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      422   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Failure      502   {object}  ErrorResponse
+// @Router       /admin/payout-suspense/{id}/resolution [post]
+// @Security     Bearer
+func (h *Handler) ResolvePayoutSuspenseCase(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate the admin and parse input.
+	adminID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid suspense case ID")
+		return
+	}
+	var input struct {
+		Action string `json:"action"`
+		Note   string `json:"note"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	note := strings.TrimSpace(input.Note)
+	if len(note) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "note must be at most 500 characters")
+		return
+	}
+
+	// Step 2: Refund or retry under the case's row lock.
+	var sc sqlc.PayoutSuspenseCase
+	switch input.Action {
+	case "refund":
+		sc, err = h.ledger.RefundSuspenseCase(r.Context(), id, adminID, note)
+	case "retry":
+		var retried bool
+		sc, retried = h.retrySuspenseCase(w, r, id, adminID, note)
+		if !retried {
+			return
+		}
+	default:
+		respondError(w, http.StatusBadRequest, "action must be refund or retry")
+		return
+	}
+	if err != nil {
+		respondSuspenseCaseError(w, err, "failed to resolve payout suspense case")
+		return
+	}
+
+	log.Info().Str("case_id", sc.ID.String()).Str("admin_id", adminID.String()).Str("action", input.Action).Msg("Payout suspense case resolved")
+	resp, err := h.suspenseCaseDetail(r, sc)
+	if err != nil {
+		respondSuspenseCaseError(w, err, "failed to load payout suspense case")
+		return
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// retrySuspenseCase re-holds a failed payout's funds as a new payout on its rail and sends it,
+// writing the error response and reporting false when it cannot.
+func (h *Handler) retrySuspenseCase(w http.ResponseWriter, r *http.Request, id, adminID uuid.UUID, note string) (sqlc.PayoutSuspenseCase, bool) {
+	// Step 1: Find the failed payout's rail and check it can send again.
+	sc, err := h.ledger.GetSuspenseCase(r.Context(), id)
+	if err == nil && sc.Status != service.SuspenseCaseOpen {
+		err = service.ErrSuspenseCaseResolved
+	}
+	if err != nil {
+		respondSuspenseCaseError(w, err, "failed to retry payout")
+		return sqlc.PayoutSuspenseCase{}, false
+	}
+	failed, err := h.store.GetPayout(r.Context(), sc.PayoutID)
+	if err != nil {
+		respondSuspenseCaseError(w, err, "failed to retry payout")
+		return sqlc.PayoutSuspenseCase{}, false
+	}
+	var (
+		reference string
+		ne        nip.NameEnquiryResponse
+	)
+	switch {
+	case failed.Provider == "flutterwave" && h.flutterwave != nil:
+		reference = flutterwave.NewReference()
+	case failed.Provider == nip.Provider && h.nip != nil:
+		// A NIP transfer needs a fresh name enquiry session for the beneficiary.
+		var ok bool
+		if ne, ok = h.resolveBeneficiary(w, r, failed.BankCode, failed.AccountNumber); !ok {
+			return sqlc.PayoutSuspenseCase{}, false
+		}
+		reference = newNIPReference()
+	default:
+		respondError(w, http.StatusConflict, "payout provider "+failed.Provider+" is not configured")
+		return sqlc.PayoutSuspenseCase{}, false
+	}
+
+	// Step 2: Hold the funds as a new payout, then send it as the original was sent.
+	sc, retry, err := h.ledger.RetrySuspenseCase(r.Context(), id, adminID, note, reference)
+	if err != nil {
+		respondSuspenseCaseError(w, err, "failed to retry payout")
+		return sqlc.PayoutSuspenseCase{}, false
+	}
+	if failed.Provider == nip.Provider {
+		retry = h.sendNIPTransfer(r.Context(), retry, ne.SessionID)
+	} else {
+		retry, _ = h.sendFlutterwaveTransfer(r.Context(), retry)
+	}
+	log.Info().Str("case_id", sc.ID.String()).Str("reference", retry.Reference).Str("status", retry.Status).Msg("Failed payout retried")
+	return sc, true
+}
+
+// suspenseCaseDetail adds a case's failed payout and, once retried, its replacement.
+func (h *Handler) suspenseCaseDetail(r *http.Request, sc sqlc.PayoutSuspenseCase) (PayoutSuspenseCaseResponse, error) {
+	resp := toPayoutSuspenseCaseResponse(sc)
+	payout, err := h.store.GetPayout(r.Context(), sc.PayoutID)
+	if err != nil {
+		return resp, err
+	}
+	p := toPayoutResponse(payout)
+	resp.Payout = &p
+	if sc.RetryPayoutID.Valid {
+		retry, err := h.store.GetPayout(r.Context(), sc.RetryPayoutID.UUID)
+		if err != nil {
+			return resp, err
+		}
+		rp := toPayoutResponse(retry)
+		resp.RetryPayout = &rp
+	}
+	return resp, nil
+}
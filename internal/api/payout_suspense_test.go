@@ -0,0 +1,31 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestRespondSuspenseCaseError(t *testing.T) {
+	// Unknown cases are 404, resolved ones conflict, anything else is hidden behind a 500.
+	for err, want := range map[error]int{
+		service.ErrSuspenseCaseNotFound: http.StatusNotFound,
+		service.ErrSuspenseCaseResolved: http.StatusConflict,
+		errors.New("connection reset"):  http.StatusInternalServerError,
+	} {
+		rw := httptest.NewRecorder()
+		respondSuspenseCaseError(rw, err, "failed")
+		assert.Equal(t, want, rw.Code, err.Error())
+	}
+}
+
+func TestListPayoutSuspenseCases_RejectsUnknownStatus(t *testing.T) {
+	rw := httptest.NewRecorder()
+	(&Handler{}).ListPayoutSuspenseCases(rw, httptest.NewRequest(http.MethodGet, "/admin/payout-suspense?status=failed", nil))
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+}
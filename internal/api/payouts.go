@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/flutterwave"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// bankAccountPattern accepts 10-digit NUBANs and the longer alphanumeric formats other rails use.
+var bankAccountPattern = regexp.MustCompile(`^[A-Za-z0-9]{6,34}$`)
+
+// initiateFlutterwavePayout holds the withdrawal amount and queues the bank transfer. body is the
+// request body captured for its signature.
+func (h *Handler) initiateFlutterwavePayout(w http.ResponseWriter, r *http.Request, userID, accountID uuid.UUID, body []byte) {
+	// Step 3: Decode amount and destination bank account.
+	var input struct {
+		Amount        interface{} `json:"amount"`
+		BankCode      string      `json:"bank_code"`
+		AccountNumber string      `json:"account_number"`
+		Narration     string      `json:"narration"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	amount, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	input.BankCode = strings.TrimSpace(input.BankCode)
+	input.AccountNumber = strings.TrimSpace(input.AccountNumber)
+	if input.BankCode == "" || !bankAccountPattern.MatchString(input.AccountNumber) {
+		respondError(w, http.StatusBadRequest, "bank_code and a valid account_number are required")
+		return
+	}
+	ctx, ok := h.checkRequestSignature(w, r, body, accountID, amount)
+	if !ok {
+		return
+	}
+	r = r.WithContext(ctx)
+
+	// Step 4: Hold funds in clearing before asking the rail to move real money.
+	payout, err := h.ledger.HoldPayout(r.Context(), service.PayoutRequest{
+		AccountID:     accountID,
+		UserID:        userID,
+		Provider:      "flutterwave",
+		Reference:     flutterwave.NewReference(),
+		Amount:        amount,
+		BankCode:      input.BankCode,
+		AccountNumber: input.AccountNumber,
+		Narration:     strings.TrimSpace(input.Narration),
+	})
+	if respondScreening(w, err) {
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Str("amount", amount).Msg("Payout hold failed")
+		respondLedgerError(w, outboundDebitStatus(err), err)
+		return
+	}
+
+	// Step 5: Queue the transfer; its final status arrives on the webhook.
+	payout, rejected := h.sendFlutterwaveTransfer(r.Context(), payout)
+	if rejected {
+		respondError(w, http.StatusBadGateway, "payout rejected by provider")
+		return
+	}
+
+	log.Info().Str("reference", payout.Reference).Str("account_id", accountID.String()).Str("amount", payout.Amount).Msg("Payout queued")
+	respondJSON(w, http.StatusAccepted, toPayoutResponse(payout))
+}
+
+// sendFlutterwaveTransfer queues a held payout's transfer with Flutterwave and reports whether
+// Flutterwave rejected it. A rejection fails the payout at once, moving its funds to suspense;
+// an unknown outcome leaves the hold pending for the webhook to decide.
+func (h *Handler) sendFlutterwaveTransfer(ctx context.Context, payout sqlc.Payout) (sqlc.Payout, bool) {
+	transfer, err := h.flutterwave.CreateTransfer(ctx, flutterwave.TransferRequest{
+		AccountBank:   payout.BankCode,
+		AccountNumber: payout.AccountNumber,
+		Currency:      payout.Currency,
+		Narration:     payout.Narration,
+		Reference:     payout.Reference,
+		CallbackURL:   h.flutterwaveCallbackURL,
+		Amount:        json.Number(payout.Amount),
+	})
+	var rejected *flutterwave.APIError
+	switch {
+	case errors.As(err, &rejected):
+		// Nothing was queued, so fail the payout right away rather than wait for a webhook.
+		log.Error().Err(err).Str("reference", payout.Reference).Msg("Flutterwave rejected payout")
+		failed, failErr := h.ledger.CompletePayout(ctx, payout.Reference, false, rejected.Message)
+		if failErr != nil {
+			log.Error().Err(failErr).Str("reference", payout.Reference).Msg("Failed to fail rejected payout")
+			return payout, true
+		}
+		return failed, true
+	case err != nil:
+		// The transfer may exist; keep the hold and let the webhook decide.
+		log.Warn().Err(err).Str("reference", payout.Reference).Msg("Flutterwave payout outcome unknown; leaving hold pending")
+	default:
+		if setErr := h.store.SetPayoutProviderTransferID(ctx, sqlc.SetPayoutProviderTransferIDParams{
+			ProviderTransferID: sql.NullString{String: strconv.FormatInt(transfer.ID, 10), Valid: true},
+			Reference:          payout.Reference,
+		}); setErr != nil {
+			log.Warn().Err(setErr).Str("reference", payout.Reference).Msg("Failed to store provider transfer ID")
+		}
+	}
+	return payout, false
+}
+
+// GetPayout godoc
+// @Summary      Get payout status
+// @Description  Returns a bank payout started by the authenticated user, including whether it settled or was reversed
+// @Tags         accounts
+// @Produce      json
+// @Param        reference  path      string  true  "Payout reference"
+// @Success      200        {object}  PayoutResponse
+// @Failure      401        {object}  ErrorResponse
+// @Failure      404        {object}  ErrorResponse
+// @Failure      500        {object}  ErrorResponse
+// @Router       /payouts/{reference} [get]
+// @Security     Bearer
+func (h *Handler) GetPayout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	payout, err := h.store.GetPayoutByReference(r.Context(), chi.URLParam(r, "reference"))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "payout not found")
+			return
+		}
+		log.Error().Err(err).Msg("Failed to load payout")
+		respondError(w, http.StatusInternalServerError, "failed to load payout")
+		return
+	}
+	// Report someone else's payout as missing rather than forbidden to avoid leaking references.
+	if payout.UserID != userID {
+		respondError(w, http.StatusNotFound, "payout not found")
+		return
+	}
+	respondJSON(w, http.StatusOK, toPayoutResponse(payout))
+}
+
+// FlutterwaveWebhook godoc
+// @Summary      Flutterwave transfer webhook
+// @Description  Receives transfer.completed events authenticated by the verif-hash header. SUCCESSFUL settles the held payout; FAILED moves it to Payout Suspense and opens a case to refund or retry. Redeliveries are no-ops.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        verif-hash  header    string  true  "Secret hash configured on the Flutterwave dashboard"
+// @Success      200         {object}  MessageResponse
+// @Failure      400         {object}  ErrorResponse
+// @Failure      401         {object}  ErrorResponse
+// @Failure      404         {object}  ErrorResponse
+// @Failure      500         {object}  ErrorResponse
+// @Router       /webhooks/flutterwave [post]
+func (h *Handler) FlutterwaveWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.flutterwave == nil {
+		respondError(w, http.StatusNotFound, "flutterwave is not configured")
+		return
+	}
+
+	// Step 1: Authenticate the sender before reading anything else.
+	if !h.flutterwave.VerifyHash(r.Header.Get(flutterwave.HashHeader)) {
+		log.Warn().Str("remote_addr", r.RemoteAddr).Msg("Flutterwave webhook rejected - bad hash")
+		respondError(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	evt, err := flutterwave.ParseWebhook(body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	// Step 2: Only final transfer outcomes move money; acknowledge everything else.
+	var succeeded bool
+	switch {
+	case evt.Event != flutterwave.EventTransferCompleted:
+		respondJSON(w, http.StatusOK, MessageResponse{Message: "ignored"})
+		return
+	case evt.Data.Status == flutterwave.StatusSuccessful:
+		succeeded = true
+	case evt.Data.Status == flutterwave.StatusFailed:
+		succeeded = false
+	default:
+		respondJSON(w, http.StatusOK, MessageResponse{Message: "ignored"})
+		return
+	}
+
+	// Step 3: Settle or reverse the hold exactly once.
+	payout, err := h.ledger.CompletePayout(r.Context(), evt.Data.Reference, succeeded, evt.Data.CompleteMessage)
+	switch {
+	case errors.Is(err, service.ErrPayoutNotFound):
+		log.Warn().Str("reference", evt.Data.Reference).Msg("Flutterwave webhook for unknown reference")
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		log.Error().Err(err).Str("reference", evt.Data.Reference).Msg("Failed to complete payout")
+		respondError(w, http.StatusInternalServerError, "failed to complete payout")
+		return
+	}
+
+	log.Info().Str("reference", payout.Reference).Str("status", payout.Status).Msg("Flutterwave payout completed")
+	respondJSON(w, http.StatusOK, MessageResponse{Message: "ok"})
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"database/sql"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// sealPhone encrypts phone for storage together with its blind index; an empty phone clears both.
+func (h *Handler) sealPhone(phone string) (sql.NullString, sql.NullString, error) {
+	if phone == "" {
+		return sql.NullString{}, sql.NullString{}, nil
+	}
+	sealed, err := h.pii.Seal(phone)
+	if err != nil {
+		return sql.NullString{}, sql.NullString{}, err
+	}
+	index := h.pii.Index(phone)
+	return sql.NullString{String: sealed, Valid: true}, sql.NullString{String: index, Valid: index != ""}, nil
+}
+
+// openUser decrypts the sealed fields of u.
+func (h *Handler) openUser(u sqlc.User) (sqlc.User, error) {
+	if !u.Phone.Valid {
+		return u, nil
+	}
+	phone, err := h.pii.Open(u.Phone.String)
+	if err != nil {
+		return sqlc.User{}, err
+	}
+	u.Phone.String = phone
+	return u, nil
+}
+
+// openKYC decrypts the document details of rec.
+func (h *Handler) openKYC(rec sqlc.KycRecord) (sqlc.KycRecord, error) {
+	var err error
+	if rec.IDNumber, err = h.pii.Open(rec.IDNumber); err != nil {
+		return sqlc.KycRecord{}, err
+	}
+	if rec.DocumentReference, err = h.pii.Open(rec.DocumentReference); err != nil {
+		return sqlc.KycRecord{}, err
+	}
+	return rec, nil
+}
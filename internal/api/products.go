@@ -0,0 +1,515 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/db"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// maxInterestRateBps caps a product's annual rate at 100%, matching the products check constraint.
+const maxInterestRateBps = 10000
+
+// productCatalog holds every product and its per-currency rules so account lists need two
+// queries. It satisfies service.ProductReader.
+type productCatalog struct {
+	products map[string]sqlc.Product
+	rules    map[[2]string]sqlc.AccountProduct
+}
+
+func (c *productCatalog) GetProduct(_ context.Context, code string) (sqlc.Product, error) {
+	p, ok := c.products[code]
+	if !ok {
+		return sqlc.Product{}, sql.ErrNoRows
+	}
+	return p, nil
+}
+
+func (c *productCatalog) GetAccountProduct(_ context.Context, arg sqlc.GetAccountProductParams) (sqlc.AccountProduct, error) {
+	p, ok := c.rules[[2]string{arg.Product, arg.Currency}]
+	if !ok {
+		return sqlc.AccountProduct{}, sql.ErrNoRows
+	}
+	return p, nil
+}
+
+// productCatalog loads the products for account responses. On failure it returns nil, which
+// leaves the product rules and available balance out rather than showing a wrong figure.
+func (h *Handler) productCatalog(ctx context.Context) *productCatalog {
+	products, err := h.store.ListProducts(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load products")
+		return nil
+	}
+	rules, err := h.store.ListAccountProducts(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load account products")
+		return nil
+	}
+	c := &productCatalog{
+		products: make(map[string]sqlc.Product, len(products)),
+		rules:    make(map[[2]string]sqlc.AccountProduct, len(rules)),
+	}
+	for _, p := range products {
+		c.products[p.Code] = p
+	}
+	for _, p := range rules {
+		c.rules[[2]string{p.Product, p.Currency}] = p
+	}
+	return c
+}
+
+// parseRuleAmount reads a non-negative amount with no more decimal places than currency allows;
+// empty means zero.
+func parseRuleAmount(s, currency string) (decimal.Decimal, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return decimal.Zero, true
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil || d.IsNegative() || !currencies.Fits(currency, d) {
+		return decimal.Decimal{}, false
+	}
+	return d, true
+}
+
+// ListProducts godoc
+// @Summary      List account products
+// @Description  Returns the account types that can be opened (for example current, savings, escrow and merchant) with their interest rate and balance bands, allowed operations and per-currency rules.
+// @Tags         accounts
+// @Produce      json
+// @Success      200  {array}   ProductResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /products [get]
+// @Security     Bearer
+func (h *Handler) ListProducts(w http.ResponseWriter, r *http.Request) {
+	products, err := h.store.ListProducts(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list products")
+		respondError(w, http.StatusInternalServerError, "failed to list products")
+		return
+	}
+	rules, err := h.store.ListAccountProducts(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list account products")
+		respondError(w, http.StatusInternalServerError, "failed to list products")
+		return
+	}
+
+	tiers, err := h.store.ListAllInterestTiers(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list interest tiers")
+		respondError(w, http.StatusInternalServerError, "failed to list products")
+		return
+	}
+
+	byProduct := make(map[string][]AccountProductResponse, len(products))
+	for _, p := range rules {
+		byProduct[p.Product] = append(byProduct[p.Product], toAccountProductResponse(p))
+	}
+	tiersByProduct := make(map[string][]InterestTierResponse, len(products))
+	for _, t := range tiers {
+		tiersByProduct[t.Product] = append(tiersByProduct[t.Product], toInterestTierResponse(t))
+	}
+	resp := make([]ProductResponse, 0, len(products))
+	for _, p := range products {
+		product := toProductResponse(p, byProduct[p.Code])
+		product.InterestTiers = tiersByProduct[p.Code]
+		resp = append(resp, product)
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SetProduct godoc
+// @Summary      Create or update a product
+// @Description  Sets a product's name, annual interest rate in basis points (0 to 10000, paid monthly on positive balances) and whether withdrawals and transfers are allowed. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{code=string,name=string,interest_rate_bps=int,withdrawals_enabled=bool,transfers_enabled=bool}  true  "Product"
+// @Success      200   {object}  ProductResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/products [put]
+// @Security     Bearer
+func (h *Handler) SetProduct(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and validate the product.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		InterestRateBps    *int32 `json:"interest_rate_bps"`
+		WithdrawalsEnabled *bool  `json:"withdrawals_enabled"`
+		TransfersEnabled   *bool  `json:"transfers_enabled"`
+		Code               string `json:"code"`
+		Name               string `json:"name"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	code := strings.TrimSpace(input.Code)
+	if err := service.ValidateProduct(code); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > maxProfileField {
+		respondError(w, http.StatusBadRequest, "name required (at most 100 characters)")
+		return
+	}
+	var rateBps int32
+	if input.InterestRateBps != nil {
+		rateBps = *input.InterestRateBps
+	}
+	if rateBps < 0 || rateBps > maxInterestRateBps {
+		respondError(w, http.StatusBadRequest, "interest_rate_bps must be between 0 and 10000")
+		return
+	}
+	// Operations are allowed unless switched off explicitly.
+	withdrawals := input.WithdrawalsEnabled == nil || *input.WithdrawalsEnabled
+	transfers := input.TransfersEnabled == nil || *input.TransfersEnabled
+
+	// Step 2: Persist; the rate applies from the next interest run.
+	p, err := h.store.UpsertProduct(r.Context(), sqlc.UpsertProductParams{
+		Code:               code,
+		Name:               name,
+		InterestRateBps:    rateBps,
+		WithdrawalsEnabled: withdrawals,
+		TransfersEnabled:   transfers,
+		UpdatedBy:          uuid.NullUUID{UUID: userID, Valid: true},
+	})
+	if err != nil {
+		log.Error().Err(err).Str("product", code).Msg("Failed to set product")
+		respondError(w, http.StatusInternalServerError, "failed to set product")
+		return
+	}
+
+	log.Info().Str("product", code).Int32("interest_rate_bps", rateBps).Bool("withdrawals", withdrawals).Bool("transfers", transfers).Str("set_by", userID.String()).Msg("Product set")
+	respondJSON(w, http.StatusOK, toProductResponse(p, nil))
+}
+
+// SetAccountProduct godoc
+// @Summary      Set a product's rules for a currency
+// @Description  Sets the minimum balance or overdraft limit, the transfer and withdrawal fees, and the optional per-transaction cap for accounts of product in currency. A product cannot have both a minimum balance and an overdraft. Lowering an overdraft fails while an account is overdrawn beyond the new limit. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{product=string,currency=string,min_balance=string,overdraft_limit=string,transfer_fee=string,withdrawal_fee=string,max_debit=string}  true  "Product rule"
+// @Success      200   {object}  AccountProductResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/account-products [put]
+// @Security     Bearer
+func (h *Handler) SetAccountProduct(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and validate the rule.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		Product        string `json:"product"`
+		Currency       string `json:"currency"`
+		MinBalance     string `json:"min_balance"`
+		OverdraftLimit string `json:"overdraft_limit"`
+		TransferFee    string `json:"transfer_fee"`
+		WithdrawalFee  string `json:"withdrawal_fee"`
+		MaxDebit       string `json:"max_debit"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	product := strings.TrimSpace(input.Product)
+	if err := service.ValidateProduct(product); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	currency, err := rates.NormalizeCurrency(input.Currency)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	amounts := make(map[string]decimal.Decimal, 4)
+	for field, value := range map[string]string{
+		"min_balance":     input.MinBalance,
+		"overdraft_limit": input.OverdraftLimit,
+		"transfer_fee":    input.TransferFee,
+		"withdrawal_fee":  input.WithdrawalFee,
+	} {
+		d, ok := parseRuleAmount(value, currency)
+		if !ok {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("%s must be a non-negative amount with at most %d decimal places", field, currencies.Exponent(currency)))
+			return
+		}
+		amounts[field] = d
+	}
+	if amounts["min_balance"].IsPositive() && amounts["overdraft_limit"].IsPositive() {
+		respondError(w, http.StatusBadRequest, "a product cannot have both a minimum balance and an overdraft")
+		return
+	}
+	var maxDebit sql.NullString
+	if strings.TrimSpace(input.MaxDebit) != "" {
+		d, ok := parseRuleAmount(input.MaxDebit, currency)
+		if !ok || !d.IsPositive() {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("max_debit must be a positive amount with at most %d decimal places", currencies.Exponent(currency)))
+			return
+		}
+		maxDebit = sql.NullString{String: d.StringFixed(4), Valid: true}
+	}
+
+	// Step 2: Persist and apply the overdraft to existing accounts together.
+	var p sqlc.AccountProduct
+	err = h.store.ExecTx(r.Context(), func(q *sqlc.Queries) error {
+		if _, err := q.GetProduct(r.Context(), product); err != nil {
+			return err
+		}
+		var err error
+		p, err = q.UpsertAccountProduct(r.Context(), sqlc.UpsertAccountProductParams{
+			Product:        product,
+			Currency:       currency,
+			MinBalance:     amounts["min_balance"].StringFixed(4),
+			OverdraftLimit: amounts["overdraft_limit"].StringFixed(4),
+			TransferFee:    amounts["transfer_fee"].StringFixed(4),
+			WithdrawalFee:  amounts["withdrawal_fee"].StringFixed(4),
+			MaxDebit:       maxDebit,
+			UpdatedBy:      userID,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = q.SyncAccountOverdrafts(r.Context(), sqlc.SyncAccountOverdraftsParams{
+			OverdraftLimit: p.OverdraftLimit,
+			Product:        product,
+			Currency:       currency,
+		})
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondError(w, http.StatusNotFound, "product not found")
+		case db.IsCheckViolation(err, db.CustomerBalanceConstraint):
+			respondError(w, http.StatusConflict, "an account is overdrawn beyond the new overdraft limit")
+		default:
+			log.Error().Err(err).Str("product", product).Str("currency", currency).Msg("Failed to set account product")
+			respondError(w, http.StatusInternalServerError, "failed to set account product")
+		}
+		return
+	}
+
+	log.Info().Str("product", product).Str("currency", currency).Str("min_balance", p.MinBalance).Str("overdraft_limit", p.OverdraftLimit).Str("set_by", userID.String()).Msg("Account product rules set")
+	respondJSON(w, http.StatusOK, toAccountProductResponse(p))
+}
+
+// ListAccountProducts godoc
+// @Summary      List product rules
+// @Description  Returns every product's per-currency rules. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   AccountProductResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/account-products [get]
+// @Security     Bearer
+func (h *Handler) ListAccountProducts(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.store.ListAccountProducts(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list account products")
+		respondError(w, http.StatusInternalServerError, "failed to list account products")
+		return
+	}
+
+	resp := make([]AccountProductResponse, 0, len(rows))
+	for _, p := range rows {
+		resp = append(resp, toAccountProductResponse(p))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// maxInterestTiers bounds how many balance bands a product has in one currency.
+const maxInterestTiers = 10
+
+// interestTierInput is one balance band in a SetInterestTiers request.
+type interestTierInput struct {
+	MinBalance string `json:"min_balance"`
+	RateBps    int32  `json:"rate_bps"`
+}
+
+// parseInterestTiers validates bands of currency and sorts them by minimum balance. Each starts at a
+// distinct positive amount; below the first, the product's base rate applies.
+func parseInterestTiers(input []interestTierInput, currency string) ([]service.InterestBand, string) {
+	if len(input) > maxInterestTiers {
+		return nil, "at most 10 tiers per currency"
+	}
+	bands := make([]service.InterestBand, 0, len(input))
+	seen := make(map[string]bool, len(input))
+	for _, t := range input {
+		from, ok := parseRuleAmount(t.MinBalance, currency)
+		if !ok || !from.IsPositive() {
+			return nil, fmt.Sprintf("min_balance must be a positive amount with at most %d decimal places", currencies.Exponent(currency))
+		}
+		if seen[from.StringFixed(4)] {
+			return nil, "tiers must have distinct min_balance"
+		}
+		seen[from.StringFixed(4)] = true
+		if t.RateBps < 0 || t.RateBps > maxInterestRateBps {
+			return nil, "rate_bps must be between 0 and 10000"
+		}
+		bands = append(bands, service.InterestBand{From: from, RateBps: t.RateBps})
+	}
+	sort.Slice(bands, func(i, j int) bool { return bands[i].From.LessThan(bands[j].From) })
+	return bands, ""
+}
+
+// SetInterestTiers godoc
+// @Summary      Set a product's interest bands for a currency
+// @Description  Replaces the balance bands of product's interest rate in currency. The part of a balance from a band's min_balance up to the next band's earns that band's rate_bps a year, and the part below the lowest band earns the product's interest_rate_bps; for example interest_rate_bps 200 with a band at 100000 and rate_bps 400 pays 2% on the first 100,000 and 4% on the rest. An empty list removes the bands. Applies from the next interest run. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        code  path      string  true  "Product code"
+// @Param        body  body      object{currency=string,tiers=[]object{min_balance=string,rate_bps=int}}  true  "Bands"
+// @Success      200   {array}   InterestTierResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/products/{code}/interest-tiers [put]
+// @Security     Bearer
+func (h *Handler) SetInterestTiers(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and validate the bands.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	product := chi.URLParam(r, "code")
+	if err := service.ValidateProduct(product); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var input struct {
+		Currency string              `json:"currency"`
+		Tiers    []interestTierInput `json:"tiers"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	currency, err := rates.NormalizeCurrency(input.Currency)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	bands, msg := parseInterestTiers(input.Tiers, currency)
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	// Step 2: Replace the currency's bands together so an interest run never sees half a set.
+	tiers := make([]sqlc.InterestTier, 0, len(bands))
+	err = h.store.ExecTx(r.Context(), func(q *sqlc.Queries) error {
+		if _, err := q.GetProduct(r.Context(), product); err != nil {
+			return err
+		}
+		if err := q.DeleteInterestTiers(r.Context(), sqlc.DeleteInterestTiersParams{Product: product, Currency: currency}); err != nil {
+			return err
+		}
+		for _, band := range bands {
+			tier, err := q.CreateInterestTier(r.Context(), sqlc.CreateInterestTierParams{
+				Product:    product,
+				Currency:   currency,
+				MinBalance: band.From.StringFixed(4),
+				RateBps:    band.RateBps,
+				UpdatedBy:  userID,
+			})
+			if err != nil {
+				return err
+			}
+			tiers = append(tiers, tier)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "product not found")
+			return
+		}
+		log.Error().Err(err).Str("product", product).Str("currency", currency).Msg("Failed to set interest tiers")
+		respondError(w, http.StatusInternalServerError, "failed to set interest tiers")
+		return
+	}
+
+	log.Info().Str("product", product).Str("currency", currency).Int("tiers", len(tiers)).Str("set_by", userID.String()).Msg("Interest tiers set")
+	resp := make([]InterestTierResponse, 0, len(tiers))
+	for _, t := range tiers {
+		resp = append(resp, toInterestTierResponse(t))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ListInterestTiers godoc
+// @Summary      List a product's interest bands
+// @Description  Returns the balance bands of product's interest rate in every currency, by currency and minimum balance. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        code  path      string  true  "Product code"
+// @Success      200   {array}   InterestTierResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/products/{code}/interest-tiers [get]
+// @Security     Bearer
+func (h *Handler) ListInterestTiers(w http.ResponseWriter, r *http.Request) {
+	product := chi.URLParam(r, "code")
+	tiers, err := h.store.ListProductInterestTiers(r.Context(), product)
+	if err != nil {
+		log.Error().Err(err).Str("product", product).Msg("Failed to list interest tiers")
+		respondError(w, http.StatusInternalServerError, "failed to list interest tiers")
+		return
+	}
+
+	resp := make([]InterestTierResponse, 0, len(tiers))
+	for _, t := range tiers {
+		resp = append(resp, toInterestTierResponse(t))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// productOverdraft returns the overdraft limit a new account of product in currency starts
+// with, or ErrNoRows if the product does not exist.
+func (h *Handler) productOverdraft(ctx context.Context, product, currency string) (string, error) {
+	if _, err := h.store.GetProduct(ctx, product); err != nil {
+		return "", err
+	}
+	rule, err := h.store.GetAccountProduct(ctx, sqlc.GetAccountProductParams{Product: product, Currency: currency})
+	if errors.Is(err, sql.ErrNoRows) {
+		return decimal.Zero.StringFixed(4), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return rule.OverdraftLimit, nil
+}
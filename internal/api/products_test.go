@@ -0,0 +1,102 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestToAccountResponse_AvailableBalance(t *testing.T) {
+	// Available balance is what remains above the product minimum or within the overdraft, and never
+	// negative. Amounts carry the currency's decimal places.
+	catalog := &productCatalog{
+		products: map[string]sqlc.Product{
+			"savings": {Code: "savings", WithdrawalsEnabled: true, TransfersEnabled: true},
+			"current": {Code: "current", WithdrawalsEnabled: true, TransfersEnabled: true},
+		},
+		rules: map[[2]string]sqlc.AccountProduct{
+			{"savings", "NGN"}: {Product: "savings", Currency: "NGN", MinBalance: "500.0000", OverdraftLimit: "0.0000", TransferFee: "0", WithdrawalFee: "0"},
+			{"current", "NGN"}: {Product: "current", Currency: "NGN", MinBalance: "0.0000", OverdraftLimit: "200.0000", TransferFee: "0", WithdrawalFee: "0"},
+		},
+	}
+	acc := sqlc.Account{ID: uuid.New(), Balance: "1200.0000", Currency: "NGN", Product: "savings"}
+
+	resp := toAccountResponse(acc, catalog)
+	assert.Equal(t, "1200.00", resp.Balance)
+	assert.Equal(t, "500.00", resp.MinBalance)
+	assert.Equal(t, "700.00", resp.AvailableBalance)
+
+	acc.Balance = "300.0000"
+	assert.Equal(t, "0.00", toAccountResponse(acc, catalog).AvailableBalance)
+
+	overdrawn := sqlc.Account{ID: uuid.New(), Balance: "-50.0000", Currency: "NGN", Product: "current"}
+	resp = toAccountResponse(overdrawn, catalog)
+	assert.Equal(t, "200.00", resp.OverdraftLimit)
+	assert.Equal(t, "150.00", resp.AvailableBalance)
+
+	// Without product rules the figures are left out rather than guessed.
+	resp = toAccountResponse(acc, nil)
+	assert.Empty(t, resp.MinBalance)
+	assert.Empty(t, resp.AvailableBalance)
+}
+
+func TestParseRuleAmount(t *testing.T) {
+	// Rule amounts are optional, non-negative and fit the currency's decimal places.
+	d, ok := parseRuleAmount("", "NGN")
+	assert.True(t, ok)
+	assert.True(t, d.IsZero())
+	d, ok = parseRuleAmount(" 12.5 ", "NGN")
+	assert.True(t, ok)
+	assert.Equal(t, "12.5", d.String())
+	_, ok = parseRuleAmount("-1", "NGN")
+	assert.False(t, ok)
+	_, ok = parseRuleAmount("0.001", "NGN")
+	assert.False(t, ok)
+	_, ok = parseRuleAmount("12.5", "JPY")
+	assert.False(t, ok)
+	_, ok = parseRuleAmount("0.125", "KWD")
+	assert.True(t, ok)
+}
+
+func TestErrorCode(t *testing.T) {
+	// Clients can tell a minimum-balance refusal from plain insufficient funds, even when wrapped.
+	assert.Equal(t, "minimum_balance_required", errorCode(fmt.Errorf("withdraw: %w", service.ErrMinimumBalance)))
+	assert.Equal(t, "insufficient_funds", errorCode(service.ErrInsufficientFunds))
+	assert.Equal(t, "debit_limit_exceeded", errorCode(service.ErrDebitLimitExceeded))
+	assert.Equal(t, "operation_not_allowed", errorCode(service.ErrOperationNotAllowed))
+	assert.Equal(t, "limit_exceeded", errorCode(service.ErrKYCLimitExceeded))
+	assert.Empty(t, errorCode(service.ErrInvalidAmount))
+}
+
+func TestParseInterestTiers(t *testing.T) {
+	// Bands start at distinct positive balances, carry a valid rate and come back sorted.
+	bands, msg := parseInterestTiers([]interestTierInput{
+		{MinBalance: "500000", RateBps: 500},
+		{MinBalance: "100000", RateBps: 400},
+	}, "NGN")
+	assert.Empty(t, msg)
+	assert.Len(t, bands, 2)
+	assert.Equal(t, "100000", bands[0].From.String())
+	assert.Equal(t, int32(500), bands[1].RateBps)
+
+	bands, msg = parseInterestTiers(nil, "NGN")
+	assert.Empty(t, msg)
+	assert.Empty(t, bands)
+
+	for _, input := range [][]interestTierInput{
+		{{MinBalance: "0", RateBps: 100}},
+		{{MinBalance: "", RateBps: 100}},
+		{{MinBalance: "1.001", RateBps: 100}},
+		{{MinBalance: "100", RateBps: 10001}},
+		{{MinBalance: "100", RateBps: -1}},
+		{{MinBalance: "100", RateBps: 100}, {MinBalance: "100.00", RateBps: 200}},
+	} {
+		_, msg := parseInterestTiers(input, "NGN")
+		assert.NotEmpty(t, msg, "%+v", input)
+	}
+}
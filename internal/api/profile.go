@@ -0,0 +1,194 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// dateOfBirthLayout is the only accepted date_of_birth format (ISO 8601 calendar date).
+const dateOfBirthLayout = "2006-01-02"
+
+// maxProfileField bounds free-text profile fields.
+const maxProfileField = 100
+
+// countryPattern accepts ISO 3166-1 alpha-2 codes such as NG or US.
+var countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// ProfileInput is a partial profile update; omitted (null) fields are left unchanged.
+type ProfileInput struct {
+	FirstName   *string       `json:"first_name"`
+	LastName    *string       `json:"last_name"`
+	Phone       *string       `json:"phone"`
+	DateOfBirth *string       `json:"date_of_birth"`
+	Address     *AddressInput `json:"address"`
+}
+
+// AddressInput is a postal address; it replaces the stored address as a whole.
+type AddressInput struct {
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+// GetProfile godoc
+// @Summary      Get profile
+// @Description  Returns the authenticated user's personal details
+// @Tags         profile
+// @Produce      json
+// @Success      200  {object}  ProfileResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /me/profile [get]
+// @Security     Bearer
+func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.store.GetUser(r.Context(), userID)
+	if err == nil {
+		user, err = h.openUser(user)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to load user")
+		respondError(w, http.StatusInternalServerError, "failed to load profile")
+		return
+	}
+	respondJSON(w, http.StatusOK, toProfileResponse(user))
+}
+
+// UpdateProfile godoc
+// @Summary      Update profile
+// @Description  Updates name, phone (E.164), date of birth (YYYY-MM-DD) and address. Omitted fields are unchanged; an address replaces the stored one as a whole.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Param        body  body      ProfileInput  true  "Profile fields to change"
+// @Success      200   {object}  ProfileResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /me/profile [put]
+// @Security     Bearer
+func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and decode payload.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input ProfileInput
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	user, err := h.store.GetUser(r.Context(), userID)
+	if err == nil {
+		user, err = h.openUser(user)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to load user")
+		respondError(w, http.StatusInternalServerError, "failed to update profile")
+		return
+	}
+
+	// Step 2: Merge onto the stored profile and validate the result.
+	params, err := mergeProfile(user, input, time.Now().UTC())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Step 3: Seal the phone and persist.
+	params.Phone, params.PhoneIndex, err = h.sealPhone(params.Phone.String)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to seal phone")
+		respondError(w, http.StatusInternalServerError, "failed to update profile")
+		return
+	}
+	updated, err := h.store.UpdateUserProfile(r.Context(), params)
+	if err == nil {
+		updated, err = h.openUser(updated)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to update profile")
+		respondError(w, http.StatusInternalServerError, "failed to update profile")
+		return
+	}
+
+	log.Info().Str("user_id", userID.String()).Msg("Profile updated")
+	respondJSON(w, http.StatusOK, toProfileResponse(updated))
+}
+
+// mergeProfile applies input to the stored user and validates every field.
+func mergeProfile(user sqlc.User, input ProfileInput, now time.Time) (sqlc.UpdateUserProfileParams, error) {
+	params := sqlc.UpdateUserProfileParams{
+		ID:           user.ID,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		Phone:        user.Phone,
+		DateOfBirth:  user.DateOfBirth,
+		AddressLine1: user.AddressLine1,
+		AddressLine2: user.AddressLine2,
+		City:         user.City,
+		State:        user.State,
+		PostalCode:   user.PostalCode,
+		Country:      user.Country,
+	}
+
+	if input.FirstName != nil {
+		params.FirstName = strings.TrimSpace(*input.FirstName)
+	}
+	if input.LastName != nil {
+		params.LastName = strings.TrimSpace(*input.LastName)
+	}
+	if input.Phone != nil {
+		phone := strings.TrimSpace(*input.Phone)
+		if phone != "" && !e164Pattern.MatchString(phone) {
+			return params, errors.New("phone must be in E.164 format, e.g. +2348012345678")
+		}
+		params.Phone = sql.NullString{String: phone, Valid: phone != ""}
+	}
+	if input.DateOfBirth != nil {
+		params.DateOfBirth = sql.NullTime{}
+		if v := strings.TrimSpace(*input.DateOfBirth); v != "" {
+			dob, err := time.Parse(dateOfBirthLayout, v)
+			if err != nil {
+				return params, errors.New("date_of_birth must be YYYY-MM-DD")
+			}
+			if !dob.Before(now) || dob.Year() < 1900 {
+				return params, errors.New("date_of_birth must be a past date after 1900")
+			}
+			params.DateOfBirth = sql.NullTime{Time: dob, Valid: true}
+		}
+	}
+	if a := input.Address; a != nil {
+		params.AddressLine1 = strings.TrimSpace(a.Line1)
+		params.AddressLine2 = strings.TrimSpace(a.Line2)
+		params.City = strings.TrimSpace(a.City)
+		params.State = strings.TrimSpace(a.State)
+		params.PostalCode = strings.TrimSpace(a.PostalCode)
+		params.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+		if params.Country != "" && !countryPattern.MatchString(params.Country) {
+			return params, errors.New("country must be an ISO 3166-1 alpha-2 code, e.g. NG")
+		}
+	}
+
+	for _, f := range []string{params.FirstName, params.LastName, params.AddressLine1, params.AddressLine2, params.City, params.State, params.PostalCode} {
+		if len(f) > maxProfileField {
+			return params, errors.New("profile fields must be at most 100 characters")
+		}
+	}
+	return params, nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestMergeProfile(t *testing.T) {
+	// Omitted fields keep stored values; provided ones are trimmed and validated.
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := sqlc.User{ID: uuid.New(), FirstName: "Ada", Phone: sql.NullString{String: "+2348012345678", Valid: true}, City: "Lagos"}
+	str := func(s string) *string { return &s }
+
+	params, err := mergeProfile(user, ProfileInput{
+		LastName:    str(" Obi "),
+		DateOfBirth: str("1990-05-17"),
+	}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", params.FirstName)
+	assert.Equal(t, "Obi", params.LastName)
+	assert.Equal(t, "+2348012345678", params.Phone.String)
+	assert.Equal(t, "Lagos", params.City)
+	assert.Equal(t, "1990-05-17", params.DateOfBirth.Time.Format(dateOfBirthLayout))
+
+	params, err = mergeProfile(user, ProfileInput{Address: &AddressInput{Line1: "12 Marina", Country: "ng"}, Phone: str("")}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "NG", params.Country)
+	assert.Empty(t, params.City)
+	assert.False(t, params.Phone.Valid)
+
+	for _, in := range []ProfileInput{
+		{Phone: str("08012345678")},
+		{DateOfBirth: str("17/05/1990")},
+		{DateOfBirth: str("2030-01-01")},
+		{Address: &AddressInput{Country: "Nigeria"}},
+	} {
+		_, err := mergeProfile(user, in, now)
+		assert.Error(t, err)
+	}
+}
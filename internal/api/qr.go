@@ -0,0 +1,225 @@
+package api
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/qr"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+// qrStatus maps QR code errors to an HTTP status.
+func qrStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAccountNotFound), errors.Is(err, service.ErrCrossOrgTransfer):
+		// Accounts of other tenants are indistinguishable from missing ones.
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrKYCRequired), errors.Is(err, service.ErrKYCLimitExceeded):
+		return http.StatusForbidden
+	case errors.Is(err, qr.ErrInvalidPayload), errors.Is(err, qr.ErrChecksum), errors.Is(err, qr.ErrUnsupportedCurrency),
+		errors.Is(err, service.ErrQRAmountMismatch), errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountPrecision),
+		errors.Is(err, service.ErrSameAccountTransfer), errors.Is(err, service.ErrCurrencyMismatch),
+		errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrMinimumBalance),
+		errors.Is(err, service.ErrDebitLimitExceeded), errors.Is(err, service.ErrOperationNotAllowed), errors.Is(err, service.ErrSavingsGoalLocked):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondQRError writes err with its QR status, hiding internal failures.
+func respondQRError(w http.ResponseWriter, err error, msg string) {
+	status := qrStatus(err)
+	switch status {
+	case http.StatusNotFound:
+		respondError(w, status, "account not found")
+	case http.StatusInternalServerError:
+		log.Error().Err(err).Msg("QR operation failed")
+		respondError(w, status, msg)
+	default:
+		respondLedgerError(w, status, err)
+	}
+}
+
+// GetAccountQR godoc
+// @Summary      Get a receiving QR code
+// @Description  Returns an EMV merchant-presented QR payload for the account's account number, to be rendered as a QR code. With amount the code is dynamic and fixes the amount (two decimals); without it the code is static and the payer enters the amount. reference (up to 25 characters, e.g. an order number) is carried into the payment narration.
+// @Tags         qr
+// @Produce      json
+// @Param        id         path      string  true   "Receiving account ID"
+// @Param        amount     query     string  false  "Fixed amount"
+// @Param        reference  query     string  false  "Payment reference"
+// @Success      200        {object}  QRCodeResponse
+// @Failure      400        {object}  ErrorResponse
+// @Failure      401        {object}  ErrorResponse
+// @Failure      403        {object}  ErrorResponse
+// @Failure      404        {object}  ErrorResponse
+// @Failure      500        {object}  ErrorResponse
+// @Router       /accounts/{id}/qr [get]
+// @Security     Bearer
+func (h *Handler) GetAccountQR(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	acc, ok := h.visibleAccount(w, r, userID, accountID)
+	if !ok {
+		return
+	}
+
+	amount := strings.TrimSpace(r.URL.Query().Get("amount"))
+	reference := strings.TrimSpace(r.URL.Query().Get("reference"))
+	fields, payload, err := service.ReceivingQR(acc, amount, reference)
+	if err != nil {
+		respondQRError(w, err, "failed to build QR code")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, QRCodeResponse{
+		Payload:       payload,
+		AccountNumber: fields.AccountNumber,
+		Currency:      fields.Currency,
+		Amount:        fields.Amount,
+		Reference:     fields.Reference,
+	})
+}
+
+// DecodeQR godoc
+// @Summary      Decode a scanned QR code
+// @Description  Verifies a scanned payload's checksum and returns who it pays, in which currency and, for a dynamic code, how much, so the payer can confirm before calling POST /qr/pay. Only codes for accounts in the caller's organization resolve.
+// @Tags         qr
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{payload=string}  true  "Scanned payload"
+// @Success      200   {object}  QRDecodeResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /qr/decode [post]
+// @Security     Bearer
+func (h *Handler) DecodeQR(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		Payload string `json:"payload"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	payload, err := qr.Decode(input.Payload)
+	if err != nil {
+		respondQRError(w, err, "failed to decode QR code")
+		return
+	}
+	acc, err := h.ledger.ResolveQR(r.Context(), payload)
+	if err == nil && acc.OrgID.UUID != orgID {
+		err = service.ErrCrossOrgTransfer
+	}
+	if err != nil {
+		respondQRError(w, err, "failed to decode QR code")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, QRDecodeResponse{
+		AccountID:     acc.ID.String(),
+		AccountNumber: payload.AccountNumber,
+		MerchantName:  acc.Name,
+		Currency:      payload.Currency,
+		Amount:        payload.Amount,
+		Reference:     payload.Reference,
+	})
+}
+
+// PayQR godoc
+// @Summary      Pay a scanned QR code
+// @Description  Transfers from an account the caller owns to the account in the payload. A dynamic code fixes the amount (amount may be omitted or must match); a static code needs amount. The payer's product rules and transfer fee apply. With SIGNED_TRANSFER_THRESHOLD set, payments above it must be signed like POST /transfers. The amount field accepts JSON number or string.
+// @Tags         qr
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{payload=string,account_id=string,amount=string}  true  "Payload and paying account"
+// @Success      201   {object}  QRPaymentResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /qr/pay [post]
+// @Security     Bearer
+func (h *Handler) PayQR(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and decode payload.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	body := h.captureSignedBody(r)
+	var input struct {
+		Amount    interface{} `json:"amount"`
+		Payload   string      `json:"payload"`
+		AccountID string      `json:"account_id"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	fromID, err := uuid.Parse(input.AccountID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account_id")
+		return
+	}
+	var amount string
+	if input.Amount != nil {
+		if amount, err = normalizeAmountInput(input.Amount); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid amount")
+			return
+		}
+	}
+	payload, err := qr.Decode(input.Payload)
+	if err != nil {
+		respondQRError(w, err, "failed to pay QR code")
+		return
+	}
+
+	// Step 2: Authorize the paying account.
+	if _, ok := h.ownedAccount(w, r, userID, fromID); !ok {
+		return
+	}
+	// A dynamic code's own amount is what moves; a static code without one fails in the service.
+	if signed := cmp.Or(payload.Amount, amount); signed != "" {
+		ctx, ok := h.checkRequestSignature(w, r, body, fromID, signed)
+		if !ok {
+			return
+		}
+		r = r.WithContext(ctx)
+	}
+
+	// Step 3: Pay; the service checks organization, currency and funds under lock.
+	payment, err := h.ledger.PayQR(r.Context(), fromID, payload, amount)
+	if respondScreening(w, err) {
+		return
+	}
+	if err != nil {
+		respondQRError(w, err, "failed to pay QR code")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, QRPaymentResponse{
+		TransactionID: payment.TransactionID.String(),
+		ToAccountID:   payment.ToAccountID.String(),
+		Amount:        currencies.Format(payment.Currency, payment.Amount),
+		Currency:      payment.Currency,
+	})
+}
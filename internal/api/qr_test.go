@@ -0,0 +1,21 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/qr"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestQRStatus(t *testing.T) {
+	// Bad or tampered codes and ledger refusals are client errors; other tenants' accounts look missing.
+	assert.Equal(t, http.StatusBadRequest, qrStatus(qr.ErrChecksum))
+	assert.Equal(t, http.StatusBadRequest, qrStatus(service.ErrQRAmountMismatch))
+	assert.Equal(t, http.StatusBadRequest, qrStatus(service.ErrInsufficientFunds))
+	assert.Equal(t, http.StatusNotFound, qrStatus(service.ErrCrossOrgTransfer))
+	assert.Equal(t, http.StatusInternalServerError, qrStatus(errors.New("connection reset")))
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// GetRate godoc
+// @Summary      Get an exchange rate
+// @Description  Returns how many units of quote one unit of base buys. Manual overrides take precedence over provider rates; stale is set when the provider rate is older than 24 hours.
+// @Tags         rates
+// @Produce      json
+// @Param        base   query     string  true  "Base currency (ISO 4217)"
+// @Param        quote  query     string  true  "Quote currency (ISO 4217)"
+// @Success      200    {object}  RateResponse
+// @Failure      400    {object}  ErrorResponse
+// @Failure      401    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      500    {object}  ErrorResponse
+// @Failure      503    {object}  ErrorResponse
+// @Router       /rates [get]
+// @Security     Bearer
+func (h *Handler) GetRate(w http.ResponseWriter, r *http.Request) {
+	if h.rates == nil {
+		respondError(w, http.StatusServiceUnavailable, "exchange rates are not configured")
+		return
+	}
+
+	rate, err := h.rates.Get(r.Context(), r.URL.Query().Get("base"), r.URL.Query().Get("quote"))
+	if err != nil {
+		switch {
+		case errors.Is(err, rates.ErrInvalidCurrency), errors.Is(err, rates.ErrUnknownCurrency):
+			respondError(w, http.StatusBadRequest, "base and quote must be ISO 4217 currency codes")
+		case errors.Is(err, rates.ErrRateNotFound):
+			respondError(w, http.StatusNotFound, err.Error())
+		default:
+			log.Error().Err(err).Msg("Failed to look up exchange rate")
+			respondError(w, http.StatusInternalServerError, "failed to look up exchange rate")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toRateResponse(rate))
+}
+
+// SetRateOverride godoc
+// @Summary      Override an exchange rate
+// @Description  Pins the rate for a currency pair (and its inverse) until expires_at, or until cleared when omitted. Provider refreshes do not replace it. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{base=string,quote=string,rate=string,reason=string,expires_at=string}  true  "1 base = rate quote; expires_at is RFC 3339"
+// @Success      200   {object}  RateOverrideResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/rates/overrides [put]
+// @Security     Bearer
+func (h *Handler) SetRateOverride(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and decode payload.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		ExpiresAt *time.Time `json:"expires_at"`
+		Base      string     `json:"base"`
+		Quote     string     `json:"quote"`
+		Rate      string     `json:"rate"`
+		Reason    string     `json:"reason"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+
+	// Step 2: Validate the pair, the rate and the reason.
+	base, errBase := rates.NormalizeCurrency(input.Base)
+	quote, errQuote := rates.NormalizeCurrency(input.Quote)
+	if errBase != nil || errQuote != nil || base == quote {
+		respondError(w, http.StatusBadRequest, "base and quote must be different ISO 4217 currency codes")
+		return
+	}
+	rate, err := decimal.NewFromString(strings.TrimSpace(input.Rate))
+	if err != nil || !rate.IsPositive() || rate.Exponent() < -10 {
+		respondError(w, http.StatusBadRequest, "rate must be a positive decimal with at most 10 decimal places")
+		return
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" || len(reason) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "reason required (at most 500 characters)")
+		return
+	}
+	expiresAt := sql.NullTime{}
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(time.Now()) {
+			respondError(w, http.StatusBadRequest, "expires_at must be in the future")
+			return
+		}
+		expiresAt = sql.NullTime{Time: *input.ExpiresAt, Valid: true}
+	}
+
+	// Step 3: Persist.
+	override, err := h.store.UpsertFXRateOverride(r.Context(), sqlc.UpsertFXRateOverrideParams{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          rate.String(),
+		Reason:        reason,
+		SetBy:         userID,
+		ExpiresAt:     expiresAt,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("pair", base+"/"+quote).Msg("Failed to set rate override")
+		respondError(w, http.StatusInternalServerError, "failed to set rate override")
+		return
+	}
+
+	log.Info().Str("pair", base+"/"+quote).Str("rate", override.Rate).Str("set_by", userID.String()).Msg("Exchange rate override set")
+	respondJSON(w, http.StatusOK, toRateOverrideResponse(override))
+}
+
+// ListRateOverrides godoc
+// @Summary      List exchange rate overrides
+// @Description  Returns every manual rate override, including expired ones. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   RateOverrideResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/rates/overrides [get]
+// @Security     Bearer
+func (h *Handler) ListRateOverrides(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.store.ListFXRateOverrides(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list rate overrides")
+		respondError(w, http.StatusInternalServerError, "failed to list rate overrides")
+		return
+	}
+
+	resp := make([]RateOverrideResponse, 0, len(rows))
+	for _, o := range rows {
+		resp = append(resp, toRateOverrideResponse(o))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ClearRateOverride godoc
+// @Summary      Clear an exchange rate override
+// @Description  Removes the manual rate for a pair so provider rates apply again. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        base   path      string  true  "Base currency"
+// @Param        quote  path      string  true  "Quote currency"
+// @Success      200    {object}  MessageResponse
+// @Failure      400    {object}  ErrorResponse
+// @Failure      401    {object}  ErrorResponse
+// @Failure      403    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      500    {object}  ErrorResponse
+// @Router       /admin/rates/overrides/{base}/{quote} [delete]
+// @Security     Bearer
+func (h *Handler) ClearRateOverride(w http.ResponseWriter, r *http.Request) {
+	base, errBase := rates.NormalizeCurrency(chi.URLParam(r, "base"))
+	quote, errQuote := rates.NormalizeCurrency(chi.URLParam(r, "quote"))
+	if errBase != nil || errQuote != nil {
+		respondError(w, http.StatusBadRequest, "base and quote must be ISO 4217 currency codes")
+		return
+	}
+
+	removed, err := h.store.DeleteFXRateOverride(r.Context(), sqlc.DeleteFXRateOverrideParams{BaseCurrency: base, QuoteCurrency: quote})
+	if err != nil {
+		log.Error().Err(err).Str("pair", base+"/"+quote).Msg("Failed to clear rate override")
+		respondError(w, http.StatusInternalServerError, "failed to clear rate override")
+		return
+	}
+	if removed == 0 {
+		respondError(w, http.StatusNotFound, "no override for this pair")
+		return
+	}
+
+	log.Info().Str("pair", base+"/"+quote).Msg("Exchange rate override cleared")
+	respondJSON(w, http.StatusOK, MessageResponse{Message: "override cleared"})
+}
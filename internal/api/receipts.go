@@ -0,0 +1,196 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/receipt"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// errNoReceiptLegs is returned for postings without a debit and a credit to name as the parties.
+var errNoReceiptLegs = errors.New("transaction has no debit and credit to put on a receipt")
+
+// WithReceiptBrand heads transaction receipts with brand instead of receipt.DefaultBrand.
+func WithReceiptBrand(brand receipt.Brand) Option {
+	return func(h *Handler) {
+		h.receiptBrand = brand
+	}
+}
+
+// GetTransactionReceipt godoc
+// @Summary      Download a transaction receipt
+// @Description  Renders a branded one-page PDF receipt for a transaction the caller can see, to share as proof of payment: the amount, its status, the reference (the transaction ID), when it was posted, who paid and who was paid, any fee charged on top and, for conversions, what the payee received.
+// @Tags         accounts
+// @Produce      application/pdf
+// @Param        id   path      string  true  "Transaction ID"
+// @Success      200  {file}    binary  "PDF receipt"
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /transactions/{id}/receipt [get]
+// @Security     Bearer
+func (h *Handler) GetTransactionReceipt(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and check they can see the transaction.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	transactionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+	entries, ok := h.visibleTransactionEntries(w, r, userID, transactionID)
+	if !ok {
+		return
+	}
+
+	// Step 2: Work out who paid whom, and what.
+	rc, err := h.buildReceipt(r, transactionID, entries)
+	if err != nil {
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to build receipt")
+		respondError(w, http.StatusInternalServerError, "failed to build receipt")
+		return
+	}
+
+	// Step 3: Render it.
+	var buf bytes.Buffer
+	if err := receipt.WritePDF(&buf, h.receiptBrand, rc); err != nil {
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to render receipt")
+		respondError(w, http.StatusInternalServerError, "failed to render receipt")
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "receipt-"+transactionID.String()+".pdf"))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Error().Err(err).Msg("Failed to write receipt response")
+	}
+}
+
+// buildReceipt loads what a receipt states about a transaction beyond its entries: its status,
+// its fees and the names of the accounts on each side.
+func (h *Handler) buildReceipt(r *http.Request, transactionID uuid.UUID, entries []sqlc.Entry) (receipt.Receipt, error) {
+	tx, err := h.store.GetTransaction(r.Context(), transactionID)
+	if err != nil {
+		return receipt.Receipt{}, err
+	}
+	feeIDs, err := h.store.ListFeeEntryIDs(r.Context(), transactionID)
+	if err != nil {
+		return receipt.Receipt{}, err
+	}
+	fees := make(map[uuid.UUID]bool, len(feeIDs))
+	for _, id := range feeIDs {
+		fees[id] = true
+	}
+	payer, payee, fee, err := receiptLegs(entries, fees)
+	if err != nil {
+		return receipt.Receipt{}, err
+	}
+	from, fromAcc, err := h.receiptParty(r, payer.AccountID)
+	if err != nil {
+		return receipt.Receipt{}, err
+	}
+	to, toAcc, err := h.receiptParty(r, payee.AccountID)
+	if err != nil {
+		return receipt.Receipt{}, err
+	}
+
+	amount, err := decimal.NewFromString(payer.Debit)
+	if err != nil {
+		return receipt.Receipt{}, err
+	}
+	rc := receipt.Receipt{
+		Reference:     tx.ID.String(),
+		OperationType: tx.OperationType,
+		Status:        tx.Status,
+		Amount:        amount,
+		Currency:      fromAcc.Currency,
+		Fee:           fee,
+		From:          from,
+		To:            to,
+		CreatedAt:     tx.CreatedAt,
+		GeneratedAt:   time.Now(),
+	}
+	if payer.Description.Valid {
+		rc.Description = payer.Description.String
+	}
+	if toAcc.Currency != fromAcc.Currency {
+		received, err := decimal.NewFromString(payee.Credit)
+		if err != nil {
+			return receipt.Receipt{}, err
+		}
+		rc.Received, rc.ReceivedCurrency = &received, toAcc.Currency
+	}
+	return rc, nil
+}
+
+// receiptParty names an account as its owner, falling back to the account's name, with its
+// account number masked.
+func (h *Handler) receiptParty(r *http.Request, accountID uuid.UUID) (receipt.Party, sqlc.Account, error) {
+	acc, err := h.store.GetAccount(r.Context(), accountID)
+	if err != nil {
+		return receipt.Party{}, sqlc.Account{}, err
+	}
+	p := receipt.Party{Name: acc.Name, Account: maskAccountNumber(acc.VirtualAccountNumber)}
+	if acc.OwnerID.Valid && !acc.IsSystem {
+		owner, err := h.store.GetUser(r.Context(), acc.OwnerID.UUID)
+		if err != nil {
+			return receipt.Party{}, sqlc.Account{}, err
+		}
+		if name := strings.TrimSpace(owner.FirstName + " " + owner.LastName); name != "" {
+			p.Name = name
+		}
+	}
+	return p, acc, nil
+}
+
+// receiptLegs picks the payer and payee of a posting, the largest debit and credit outside its
+// fees, and totals the fees charged with it.
+func receiptLegs(entries []sqlc.Entry, fees map[uuid.UUID]bool) (payer, payee sqlc.Entry, fee decimal.Decimal, err error) {
+	var largestDebit, largestCredit decimal.Decimal
+	for _, e := range entries {
+		debit, err := decimal.NewFromString(e.Debit)
+		if err != nil {
+			return sqlc.Entry{}, sqlc.Entry{}, decimal.Zero, fmt.Errorf("invalid debit on entry %s: %w", e.ID, err)
+		}
+		credit, err := decimal.NewFromString(e.Credit)
+		if err != nil {
+			return sqlc.Entry{}, sqlc.Entry{}, decimal.Zero, fmt.Errorf("invalid credit on entry %s: %w", e.ID, err)
+		}
+		if fees[e.ID] {
+			fee = fee.Add(debit)
+			continue
+		}
+		if debit.GreaterThan(largestDebit) {
+			payer, largestDebit = e, debit
+		}
+		if credit.GreaterThan(largestCredit) {
+			payee, largestCredit = e, credit
+		}
+	}
+	if payer.ID == uuid.Nil || payee.ID == uuid.Nil {
+		return sqlc.Entry{}, sqlc.Entry{}, decimal.Zero, errNoReceiptLegs
+	}
+	return payer, payee, fee, nil
+}
+
+// maskAccountNumber shows only the last four digits of an account number.
+func maskAccountNumber(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+	return strings.Repeat("*", len(number)-4) + number[len(number)-4:]
+}
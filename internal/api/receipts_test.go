@@ -0,0 +1,37 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestReceiptLegs(t *testing.T) {
+	// The payer and payee are the operation's legs; fee legs only add up to the fee.
+	payer, payee, income := uuid.New(), uuid.New(), uuid.New()
+	entries := []sqlc.Entry{
+		{ID: uuid.New(), AccountID: payer, Debit: "10000.0000", Credit: "0.0000"},
+		{ID: uuid.New(), AccountID: payee, Debit: "0.0000", Credit: "10000.0000"},
+		{ID: uuid.New(), AccountID: payer, Debit: "25.0000", Credit: "0.0000"},
+		{ID: uuid.New(), AccountID: income, Debit: "0.0000", Credit: "25.0000"},
+	}
+	fees := map[uuid.UUID]bool{entries[2].ID: true, entries[3].ID: true}
+
+	from, to, fee, err := receiptLegs(entries, fees)
+	require.NoError(t, err)
+	assert.Equal(t, entries[0].ID, from.ID)
+	assert.Equal(t, entries[1].ID, to.ID)
+	assert.Equal(t, "25", fee.String())
+
+	_, _, _, err = receiptLegs(entries[:1], nil)
+	assert.ErrorIs(t, err, errNoReceiptLegs)
+}
+
+func TestMaskAccountNumber(t *testing.T) {
+	assert.Equal(t, "******7890", maskAccountNumber("1234567890"))
+	assert.Equal(t, "", maskAccountNumber(""))
+}
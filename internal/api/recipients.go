@@ -0,0 +1,183 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// findRecipient resolves an email or phone in the caller's organization, answering 400 for a
+// malformed address and 404 when nobody (or more than one user) matches.
+func (h *Handler) findRecipient(w http.ResponseWriter, r *http.Request, email, phone string) (service.Recipient, bool) {
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return service.Recipient{}, false
+	}
+	if (email == "") == (phone == "") {
+		respondError(w, http.StatusBadRequest, "give exactly one of email or phone")
+		return service.Recipient{}, false
+	}
+	if phone != "" && !e164Pattern.MatchString(phone) {
+		respondError(w, http.StatusBadRequest, "phone must be in E.164 format, e.g. +2348012345678")
+		return service.Recipient{}, false
+	}
+	if email != "" && !strings.Contains(email, "@") {
+		respondError(w, http.StatusBadRequest, "invalid email")
+		return service.Recipient{}, false
+	}
+
+	recipient, err := h.ledger.FindRecipient(r.Context(), orgID, email, phone)
+	if err != nil {
+		if errors.Is(err, service.ErrRecipientNotFound) {
+			respondError(w, http.StatusNotFound, err.Error())
+			return service.Recipient{}, false
+		}
+		log.Error().Err(err).Msg("Recipient lookup failed")
+		respondError(w, http.StatusInternalServerError, "failed to look up recipient")
+		return service.Recipient{}, false
+	}
+	return recipient, true
+}
+
+// recipientAccount is findRecipient for callers that only need the receiving account.
+func (h *Handler) recipientAccount(w http.ResponseWriter, r *http.Request, email, phone string) (uuid.UUID, bool) {
+	recipient, ok := h.findRecipient(w, r, email, phone)
+	if !ok {
+		return uuid.Nil, false
+	}
+	return recipient.Account.ID, true
+}
+
+// LookupRecipient godoc
+// @Summary      Confirm a recipient by email or phone
+// @Description  Finds the user in the caller's organization with the given email or E.164 phone and returns a masked name (first name and last initial) and the currency of the account that would receive, so the sender can confirm before POST /transfers with to_email or to_phone. The account itself is not revealed. A phone shared by several users matches nobody.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{email=string,phone=string}  true  "Exactly one of email or phone"
+// @Success      200   {object}  RecipientResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /recipients/lookup [post]
+// @Security     Bearer
+func (h *Handler) LookupRecipient(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Email string `json:"email"`
+		Phone string `json:"phone"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	recipient, ok := h.findRecipient(w, r, strings.TrimSpace(input.Email), strings.TrimSpace(input.Phone))
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, RecipientResponse{
+		DisplayName: service.MaskedName(recipient.User),
+		Currency:    recipient.Account.Currency,
+	})
+}
+
+// ListRecentRecipients godoc
+// @Summary      List recent recipients
+// @Description  Returns the accounts the caller most recently transferred to from any account they own or co-own, newest first, with a masked owner name, the last transfer time and how many transfers they have received. Derived from the ledger's transfer entries; the caller's own accounts and fee accounts are left out.
+// @Tags         accounts
+// @Produce      json
+// @Param        limit  query     int  false  "Limit (default 20, max 100)"
+// @Success      200    {array}   RecentRecipientResponse
+// @Failure      401    {object}  ErrorResponse
+// @Failure      500    {object}  ErrorResponse
+// @Router       /recipients/recent [get]
+// @Security     Bearer
+func (h *Handler) ListRecentRecipients(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	limit := 20
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+
+	rows, err := h.store.ListRecentRecipients(r.Context(), sqlc.ListRecentRecipientsParams{
+		UserID:   userID,
+		RowLimit: int32(limit), // #nosec G115 -- capped at 100 above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list recent recipients")
+		respondError(w, http.StatusInternalServerError, "failed to list recent recipients")
+		return
+	}
+
+	resp := make([]RecentRecipientResponse, 0, len(rows))
+	for _, row := range rows {
+		resp = append(resp, toRecentRecipientResponse(row))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// SetDefaultAccount godoc
+// @Summary      Set default receiving account
+// @Description  Chooses which of the caller's accounts receives transfers addressed to their email or phone. It must be a top-level account the caller is the primary owner of. Until one is set, the oldest such account is used.
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{account_id=string}  true  "Account ID"
+// @Success      200   {object}  AccountResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /me/default-account [put]
+// @Security     Bearer
+func (h *Handler) SetDefaultAccount(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and decode payload.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		AccountID string `json:"account_id"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	accountID, err := uuid.Parse(input.AccountID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account_id")
+		return
+	}
+
+	// Step 2: Only the primary owner's own top-level accounts receive by email or phone.
+	acc, ok := h.ownedAccount(w, r, userID, accountID)
+	if !ok {
+		return
+	}
+	if acc.OwnerID.UUID != userID || acc.ParentAccountID.Valid {
+		respondError(w, http.StatusBadRequest, "default account must be a top-level account you are the primary owner of")
+		return
+	}
+
+	// Step 3: Record the choice.
+	if err := h.store.SetDefaultAccount(r.Context(), sqlc.SetDefaultAccountParams{
+		ID:               userID,
+		DefaultAccountID: uuid.NullUUID{UUID: acc.ID, Valid: true},
+	}); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to set default account")
+		respondError(w, http.StatusInternalServerError, "failed to set default account")
+		return
+	}
+
+	log.Info().Str("user_id", userID.String()).Str("account_id", acc.ID.String()).Msg("Default account set")
+	respondJSON(w, http.StatusOK, toAccountResponse(acc, h.productCatalog(r.Context())))
+}
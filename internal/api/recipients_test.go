@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestLookupRecipient_ValidatesAddress(t *testing.T) {
+	// Exactly one well-formed email or E.164 phone is required before any lookup.
+	require.NoError(t, InitTokenAuth("fV7sliKV3qn657I60wEFtw/Auk/0bNU9zdp30wFzfDg="))
+	h := &Handler{}
+	handler := Verifier(Authenticator(http.HandlerFunc(h.LookupRecipient)))
+	token, err := GenerateToken(uuid.New(), uuid.New(), RoleCustomer)
+	require.NoError(t, err)
+
+	for _, body := range []string{`{}`, `{"email":"a@example.com","phone":"+2348012345678"}`, `{"phone":"08012345678"}`, `{"email":"nobody"}`} {
+		req := httptest.NewRequest(http.MethodPost, "/recipients/lookup", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusBadRequest, rw.Code, body)
+	}
+}
+
+func TestToRecentRecipientResponse_MasksOwner(t *testing.T) {
+	// Quick-send entries never expose the recipient's full name or email.
+	id := uuid.New()
+	sent := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	resp := toRecentRecipientResponse(sqlc.ListRecentRecipientsRow{
+		ID: id, VirtualAccountNumber: "1234567890", Currency: "NGN",
+		FirstName: "Grace", LastName: "Hopper", Email: "grace@example.com",
+		LastSentAt: sent, TransferCount: 3,
+	})
+	assert.Equal(t, RecentRecipientResponse{
+		AccountID: id.String(), AccountNumber: "1234567890", DisplayName: "Grace H.",
+		Currency: "NGN", LastSentAt: sent, TransferCount: 3,
+	}, resp)
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// StartReconciliationRun godoc
+// @Summary      Reconcile every account
+// @Description  Queues a background job that checks every account's stored balance against its entries, 500 accounts to a transaction, recording each account's result. Only one run goes at a time: while one is running it is returned instead and its job queued again, so a run whose job gave up resumes after the last batch it finished. Mismatches are counted and alerted as GET /accounts/{id}/reconcile does. Poll the run for progress. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Success      202  {object}  ReconciliationRunResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/reconcile [post]
+// @Security     Bearer
+func (h *Handler) StartReconciliationRun(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	run, resumed, err := h.ledger.StartReconciliationRun(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to start reconciliation run")
+		respondError(w, http.StatusInternalServerError, "failed to start reconciliation run")
+		return
+	}
+	log.Info().Str("run_id", run.ID.String()).Str("user_id", userID.String()).Bool("resumed", resumed).Msg("Reconciliation run requested")
+	respondJSON(w, http.StatusAccepted, toReconciliationRunResponse(run))
+}
+
+// ListReconciliationRuns godoc
+// @Summary      List reconciliation runs
+// @Description  Returns a page of reconciliation runs with their progress, newest first, wrapped in {data, page}. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        limit     query     int     false  "Limit (default 20, max 100)"
+// @Param        offset    query     int     false  "Offset (default 0)"
+// @Param        envelope  query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200       {object}  PagedResponse{data=[]ReconciliationRunResponse}
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
+// @Router       /admin/reconcile [get]
+// @Security     Bearer
+func (h *Handler) ListReconciliationRuns(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePage(r)
+	rows, err := h.store.ListReconciliationRuns(r.Context(), sqlc.ListReconciliationRunsParams{
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		Offset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list reconciliation runs")
+		respondError(w, http.StatusInternalServerError, "failed to list reconciliation runs")
+		return
+	}
+	total, err := h.store.CountReconciliationRuns(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count reconciliation runs")
+		respondError(w, http.StatusInternalServerError, "failed to list reconciliation runs")
+		return
+	}
+
+	resp := make([]ReconciliationRunResponse, 0, len(rows))
+	for _, run := range rows {
+		resp = append(resp, toReconciliationRunResponse(run))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// GetReconciliationRun godoc
+// @Summary      Get a reconciliation run
+// @Description  Returns a reconciliation run's progress: how many accounts it has checked of those there were when it started, and how many of them mismatched. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Reconciliation run ID"
+// @Success      200  {object}  ReconciliationRunResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/reconcile/{id} [get]
+// @Security     Bearer
+func (h *Handler) GetReconciliationRun(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid reconciliation run ID")
+		return
+	}
+	run, err := h.ledger.GetReconciliationRun(r.Context(), id)
+	if errors.Is(err, service.ErrReconciliationRunNotFound) {
+		respondLedgerError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("run_id", id.String()).Msg("Failed to get reconciliation run")
+		respondError(w, http.StatusInternalServerError, "failed to get reconciliation run")
+		return
+	}
+	respondJSON(w, http.StatusOK, toReconciliationRunResponse(run))
+}
+
+// ListReconciliationResults godoc
+// @Summary      List a reconciliation run's results
+// @Description  Returns a page of the per-account results a run has recorded so far, in account ID order, wrapped in {data, page}: each account's stored and calculated balances and whether they matched. mismatched=true returns only the accounts that did not. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        id          path      string  true   "Reconciliation run ID"
+// @Param        mismatched  query     bool    false  "Only mismatched accounts"
+// @Param        limit       query     int     false  "Limit (default 20, max 100)"
+// @Param        offset      query     int     false  "Offset (default 0)"
+// @Param        envelope    query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200         {object}  PagedResponse{data=[]ReconciliationResultResponse}
+// @Failure      400         {object}  ErrorResponse
+// @Failure      401         {object}  ErrorResponse
+// @Failure      403         {object}  ErrorResponse
+// @Failure      404         {object}  ErrorResponse
+// @Failure      500         {object}  ErrorResponse
+// @Router       /admin/reconcile/{id}/results [get]
+// @Security     Bearer
+func (h *Handler) ListReconciliationResults(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse the run and filters with safe defaults and caps.
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid reconciliation run ID")
+		return
+	}
+	var mismatchedOnly bool
+	if v := r.URL.Query().Get("mismatched"); v != "" {
+		if mismatchedOnly, err = strconv.ParseBool(v); err != nil {
+			respondError(w, http.StatusBadRequest, "mismatched must be true or false")
+			return
+		}
+	}
+	limit, offset := parsePage(r)
+
+	// Step 2: Check the run exists, so an unknown one is 404 rather than an empty page.
+	if _, err := h.ledger.GetReconciliationRun(r.Context(), id); err != nil {
+		if errors.Is(err, service.ErrReconciliationRunNotFound) {
+			respondLedgerError(w, http.StatusNotFound, err)
+			return
+		}
+		log.Error().Err(err).Str("run_id", id.String()).Msg("Failed to get reconciliation run")
+		respondError(w, http.StatusInternalServerError, "failed to list reconciliation results")
+		return
+	}
+
+	// Step 3: Fetch the page and the size of the whole list.
+	rows, err := h.store.ListReconciliationResults(r.Context(), sqlc.ListReconciliationResultsParams{
+		RunID:          id,
+		MismatchedOnly: mismatchedOnly,
+		RowLimit:       int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		RowOffset:      int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Str("run_id", id.String()).Msg("Failed to list reconciliation results")
+		respondError(w, http.StatusInternalServerError, "failed to list reconciliation results")
+		return
+	}
+	total, err := h.store.CountReconciliationResults(r.Context(), sqlc.CountReconciliationResultsParams{
+		RunID:          id,
+		MismatchedOnly: mismatchedOnly,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("run_id", id.String()).Msg("Failed to count reconciliation results")
+		respondError(w, http.StatusInternalServerError, "failed to list reconciliation results")
+		return
+	}
+
+	resp := make([]ReconciliationResultResponse, 0, len(rows))
+	for _, result := range rows {
+		resp = append(resp, toReconciliationResultResponse(result))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
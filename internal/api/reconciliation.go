@@ -0,0 +1,183 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/reconcile"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// maxStatementUpload caps statement files; a month of settlement activity fits comfortably.
+const maxStatementUpload = 10 << 20
+
+// ImportBankStatement godoc
+// @Summary      Import settlement bank statement
+// @Description  Uploads a CSV or OFX statement from the settlement bank, matches its lines against settlement-account entries by reference, amount and date, and stores a report of matched, missing and unexpected items. Accepts multipart/form-data (field "file") or a raw request body. Admin only.
+// @Tags         admin
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        format  query     string  false  "Statement format (csv or ofx); inferred from the file extension when omitted"
+// @Param        file    formData  file    false  "Statement file"
+// @Success      201     {object}  ReconciliationResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      413     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /admin/reconciliations [post]
+// @Security     Bearer
+func (h *Handler) ImportBankStatement(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole).
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	// Step 2: Read the statement from a multipart field or the raw body.
+	r.Body = http.MaxBytesReader(w, r.Body, maxStatementUpload)
+	var (
+		body     io.Reader = r.Body
+		filename string
+	)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				respondError(w, http.StatusRequestEntityTooLarge, "statement file too large")
+				return
+			}
+			respondError(w, http.StatusBadRequest, "file field required")
+			return
+		}
+		defer func() { _ = file.Close() }()
+		body, filename = file, header.Filename
+	}
+
+	format := reconcile.Format(strings.ToLower(r.URL.Query().Get("format")))
+	if format == "" {
+		format = reconcile.Format(strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), "."))
+	}
+	if format != reconcile.FormatCSV && format != reconcile.FormatOFX {
+		respondError(w, http.StatusBadRequest, "format must be csv or ofx")
+		return
+	}
+
+	// Step 3: Parse, reconcile and persist the report.
+	rec, _, err := reconcile.Import(r.Context(), h.store, reconcile.ImportRequest{
+		Body:       body,
+		Format:     format,
+		Filename:   filename,
+		ImportedBy: userID,
+	})
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			respondError(w, http.StatusRequestEntityTooLarge, "statement file too large")
+		case errors.Is(err, reconcile.ErrEmptyStatement), errors.Is(err, reconcile.ErrInvalidStatement):
+			log.Warn().Err(err).Str("format", string(format)).Msg("Statement import rejected")
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			log.Error().Err(err).Msg("Statement import failed")
+			respondError(w, http.StatusInternalServerError, "failed to import statement")
+		}
+		return
+	}
+
+	service.ReconciliationMismatchesTotal.Add(float64(rec.MissingCount+rec.UnexpectedCount), "bank_statement")
+	log.Info().
+		Str("import_id", rec.ID.String()).
+		Str("user_id", userID.String()).
+		Int32("matched", rec.MatchedCount).
+		Int32("missing", rec.MissingCount).
+		Int32("unexpected", rec.UnexpectedCount).
+		Msg("Bank statement reconciled")
+	respondJSON(w, http.StatusCreated, toReconciliationResponse(rec))
+}
+
+// ListReconciliations godoc
+// @Summary      List statement reconciliations
+// @Description  Returns imported settlement-bank statements with match counts, newest first. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        limit   query     int  false  "Limit (default 20)"
+// @Param        offset  query     int  false  "Offset (default 0)"
+// @Success      200     {array}   ReconciliationSummaryResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /admin/reconciliations [get]
+// @Security     Bearer
+func (h *Handler) ListReconciliations(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse pagination with safe defaults and caps.
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	// Step 2: Fetch summaries without the (potentially large) report bodies.
+	rows, err := h.store.ListBankStatementImports(r.Context(), sqlc.ListBankStatementImportsParams{
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list statement imports")
+		respondError(w, http.StatusInternalServerError, "failed to list reconciliations")
+		return
+	}
+
+	response := make([]ReconciliationSummaryResponse, 0, len(rows))
+	for _, row := range rows {
+		response = append(response, toReconciliationSummary(row))
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// GetReconciliation godoc
+// @Summary      Get statement reconciliation report
+// @Description  Returns one imported statement with its matched, missing and unexpected items. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Import ID"
+// @Success      200  {object}  ReconciliationResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/reconciliations/{id} [get]
+// @Security     Bearer
+func (h *Handler) GetReconciliation(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid reconciliation ID")
+		return
+	}
+
+	rec, err := h.store.GetBankStatementImport(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "reconciliation not found")
+			return
+		}
+		log.Error().Err(err).Str("import_id", id.String()).Msg("Failed to load statement import")
+		respondError(w, http.StatusInternalServerError, "failed to load reconciliation")
+		return
+	}
+	respondJSON(w, http.StatusOK, toReconciliationResponse(rec))
+}
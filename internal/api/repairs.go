@@ -0,0 +1,237 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// balanceRepairStatus maps balance repair errors to an HTTP status.
+func balanceRepairStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrBalanceRepairNotFound), errors.Is(err, service.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrNoBalanceMismatch), errors.Is(err, service.ErrBalanceRepairPending),
+		errors.Is(err, service.ErrBalanceRepairNotPending), errors.Is(err, service.ErrBalanceRepairStale),
+		errors.Is(err, service.ErrEntriesDisagreeWithLog), errors.Is(err, service.ErrAdjustmentNotPending):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrAdjustmentSelfApproval):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidAdjustment), errors.Is(err, service.ErrInsufficientFunds):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondBalanceRepairError writes err with its repair status, hiding internal failures.
+func respondBalanceRepairError(w http.ResponseWriter, err error, msg string) {
+	status := balanceRepairStatus(err)
+	if status == http.StatusInternalServerError {
+		log.Error().Err(err).Msg(msg)
+		respondError(w, status, msg)
+		return
+	}
+	respondLedgerError(w, status, err)
+}
+
+// ProposeBalanceRepair godoc
+// @Summary      Propose a balance repair
+// @Description  Checks the account's stored balance against its entries and, if they differ, proposes a reconciliation adjustment booking the delta (stored less calculated): an entry on the account that leaves its stored balance as it is, against the Reconciliation Suspense account in its currency. The adjustment waits for a different admin to approve the repair. 409 when the balances match, when a repair is already pending, or when the entries themselves disagree with the ledger event log. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string              true  "Account ID"
+// @Param        body  body      object{memo=string}  true  "Why the stored balance is the one to keep"
+// @Success      201   {object}  BalanceRepairResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/accounts/{id}/balance-repairs [post]
+// @Security     Bearer
+func (h *Handler) ProposeBalanceRepair(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate the proposing admin and parse input.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	var input struct {
+		Memo string `json:"memo"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	memo := strings.TrimSpace(input.Memo)
+	if memo == "" || len(memo) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "memo required (at most 500 characters)")
+		return
+	}
+
+	// Step 2: Compute the mismatch and propose the adjustment that books it.
+	repair, err := h.ledger.ProposeBalanceRepair(r.Context(), accountID, userID, memo)
+	if err != nil {
+		respondBalanceRepairError(w, err, "failed to propose balance repair")
+		return
+	}
+	respondJSON(w, http.StatusCreated, toBalanceRepairDetailResponse(repair))
+}
+
+// ListBalanceRepairs godoc
+// @Summary      List balance repairs
+// @Description  Returns a page of balance repairs in one status, oldest first, wrapped in {data, page}, without their adjustments or audit logs. The default status "pending" is the approval queue. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        status    query     string  false  "pending (default), posted or rejected"
+// @Param        limit     query     int     false  "Limit (default 20, max 100)"
+// @Param        offset    query     int     false  "Offset (default 0)"
+// @Param        envelope  query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200       {object}  PagedResponse{data=[]BalanceRepairResponse}
+// @Failure      400       {object}  ErrorResponse
+// @Failure      401       {object}  ErrorResponse
+// @Failure      403       {object}  ErrorResponse
+// @Failure      500       {object}  ErrorResponse
+// @Router       /admin/balance-repairs [get]
+// @Security     Bearer
+func (h *Handler) ListBalanceRepairs(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.BalanceRepairPending
+	case service.BalanceRepairPending, service.BalanceRepairPosted, service.BalanceRepairRejected:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be pending, posted or rejected")
+		return
+	}
+	limit, offset := parsePage(r)
+
+	// Step 2: Fetch the page and the size of the whole list.
+	rows, err := h.store.ListBalanceRepairsByStatus(r.Context(), sqlc.ListBalanceRepairsByStatusParams{
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		Offset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to list balance repairs")
+		respondError(w, http.StatusInternalServerError, "failed to list balance repairs")
+		return
+	}
+	total, err := h.store.CountBalanceRepairsByStatus(r.Context(), status)
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to count balance repairs")
+		respondError(w, http.StatusInternalServerError, "failed to list balance repairs")
+		return
+	}
+
+	resp := make([]BalanceRepairResponse, 0, len(rows))
+	for _, repair := range rows {
+		resp = append(resp, toBalanceRepairResponse(repair))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// GetBalanceRepair godoc
+// @Summary      Get a balance repair
+// @Description  Returns a balance repair with its proposed adjustment and its audit log: who proposed it and the balances they saw, who approved or rejected it, and the balances verified once it was posted. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Balance repair ID"
+// @Success      200  {object}  BalanceRepairResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/balance-repairs/{id} [get]
+// @Security     Bearer
+func (h *Handler) GetBalanceRepair(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid balance repair ID")
+		return
+	}
+	repair, err := h.ledger.GetBalanceRepair(r.Context(), id)
+	if err != nil {
+		respondBalanceRepairError(w, err, "failed to get balance repair")
+		return
+	}
+	respondJSON(w, http.StatusOK, toBalanceRepairDetailResponse(repair))
+}
+
+// DecideBalanceRepair godoc
+// @Summary      Approve or reject a balance repair
+// @Description  Approval posts the repair's adjustment and checks the account matches its entries before committing; it is refused with 409 if the account's mismatch changed since the proposal. Rejection closes the repair and its adjustment without posting. The admin who proposed a repair can never decide it. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                               true  "Balance repair ID"
+// @Param        body  body      object{decision=string,note=string}  true  "decision: approve or reject; note is required when rejecting"
+// @Success      200   {object}  BalanceRepairResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/balance-repairs/{id}/decision [post]
+// @Security     Bearer
+func (h *Handler) DecideBalanceRepair(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate the checker and parse input.
+	approverID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid balance repair ID")
+		return
+	}
+	var input struct {
+		Decision string `json:"decision"`
+		Note     string `json:"note"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	note := strings.TrimSpace(input.Note)
+	if len(note) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "note must be at most 500 characters")
+		return
+	}
+
+	// Step 2: Decide under the repair's row lock.
+	var repair service.BalanceRepair
+	switch input.Decision {
+	case "approve":
+		repair, err = h.ledger.ApproveBalanceRepair(r.Context(), id, approverID, note)
+	case "reject":
+		if note == "" {
+			respondError(w, http.StatusBadRequest, "note required when rejecting")
+			return
+		}
+		repair, err = h.ledger.RejectBalanceRepair(r.Context(), id, approverID, note)
+	default:
+		respondError(w, http.StatusBadRequest, "decision must be approve or reject")
+		return
+	}
+	if err != nil {
+		respondBalanceRepairError(w, err, "failed to decide balance repair")
+		return
+	}
+	respondJSON(w, http.StatusOK, toBalanceRepairDetailResponse(repair))
+}
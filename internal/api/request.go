@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// maxJSONBody bounds JSON request bodies; the largest, such as a product's interest tiers or a
+// split's recipients, are a few KB.
+const maxJSONBody = 1 << 20
+
+// decodeJSON strictly decodes r's body into dst, writing a 400 that names the offending field
+// (or 413 for an oversized body) and returning false when it cannot. Unknown fields are refused
+// so a typo like "ammount" is not silently read as a zero value, and numbers stay json.Number so
+// amounts keep their exact decimal digits.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	return decodeBody(w, r, dst, false)
+}
+
+// decodeOptionalJSON is decodeJSON for endpoints whose body may be omitted entirely.
+func decodeOptionalJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	return decodeBody(w, r, dst, true)
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, dst interface{}, optional bool) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBody))
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+	err := dec.Decode(dst)
+	if errors.Is(err, io.EOF) && optional {
+		return true
+	}
+	if err == nil && dec.Decode(&struct{}{}) != io.EOF {
+		err = errTrailingJSON
+	}
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must be at most %d bytes", tooLarge.Limit))
+			return false
+		}
+		respondError(w, http.StatusBadRequest, decodeErrorMessage(err))
+		return false
+	}
+	return true
+}
+
+// errTrailingJSON is reported for a body holding more than one JSON value.
+var errTrailingJSON = errors.New("request body must contain a single JSON object")
+
+// decodeErrorMessage turns a decoding failure into a client-facing message naming the field.
+func decodeErrorMessage(err error) string {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.Is(err, io.EOF):
+		return "request body required"
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return "malformed JSON: unexpected end of body"
+	case errors.As(err, &syntaxErr):
+		return fmt.Sprintf("malformed JSON at byte %d", syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return "request body must be a JSON object"
+		}
+		return fmt.Sprintf("field %q must be %s", typeErr.Field, jsonKind(typeErr.Type))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no typed error for unknown fields.
+		return "unknown field " + strings.TrimPrefix(err.Error(), "json: unknown field ")
+	case errors.Is(err, errTrailingJSON):
+		return err.Error()
+	default:
+		return "invalid input"
+	}
+}
+
+// jsonKind describes the JSON value a Go type decodes from.
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Ptr:
+		return jsonKind(t.Elem())
+	default:
+		return "an object"
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeJSON_NamesOffendingField(t *testing.T) {
+	// Typos, wrong types, malformed and trailing JSON are refused with a message a client can act on.
+	type payload struct {
+		Amount interface{} `json:"amount"`
+		Note   string      `json:"note"`
+		Days   int         `json:"days"`
+	}
+	cases := map[string]string{
+		`{"ammount":"10"}`:         `unknown field "ammount"`,
+		`{"note":5}`:               `field "note" must be a string`,
+		`{"days":"7"}`:             `field "days" must be an integer`,
+		`{"note":"a"`:              "malformed JSON: unexpected end of body",
+		`{"note":"a"}{"note":"b"}`: "request body must contain a single JSON object",
+		`[1]`:                      "request body must be a JSON object",
+		``:                         "request body required",
+	}
+	for body, msg := range cases {
+		rw := httptest.NewRecorder()
+		var dst payload
+		ok := decodeJSON(rw, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), &dst)
+		assert.False(t, ok, body)
+		assert.Equal(t, http.StatusBadRequest, rw.Code, body)
+		var resp ErrorResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+		assert.Equal(t, msg, resp.Error, body)
+	}
+}
+
+func TestDecodeJSON_KeepsAmountDigits(t *testing.T) {
+	// Numbers decode as json.Number so amounts are not rounded through float64.
+	var dst struct {
+		Amount interface{} `json:"amount"`
+	}
+	rw := httptest.NewRecorder()
+	require.True(t, decodeJSON(rw, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"amount":0.1000000000000000055}`)), &dst))
+	assert.Equal(t, json.Number("0.1000000000000000055"), dst.Amount)
+}
+
+func TestDecodeJSON_BodyLimit(t *testing.T) {
+	// Bodies past the limit are refused with 413.
+	var dst struct {
+		Note string `json:"note"`
+	}
+	body := `{"note":"` + strings.Repeat("a", maxJSONBody) + `"}`
+	rw := httptest.NewRecorder()
+	assert.False(t, decodeJSON(rw, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), &dst))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+}
+
+func TestDecodeOptionalJSON_AllowsEmptyBody(t *testing.T) {
+	// An omitted body leaves the defaults; a present one is still decoded strictly.
+	var dst struct {
+		Note string `json:"note"`
+	}
+	assert.True(t, decodeOptionalJSON(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", http.NoBody), &dst))
+	assert.False(t, decodeOptionalJSON(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"nope":1}`)), &dst))
+}
@@ -2,9 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/db"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
 )
 
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -18,5 +22,45 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 
 func respondError(w http.ResponseWriter, status int, msg string) {
 	// Keep API error shape consistent across every endpoint.
-	respondJSON(w, status, ErrorResponse{Error: msg})
+	respondJSON(w, status, ErrorResponse{Error: localize(w, msg)})
+}
+
+// errorCode returns the stable code for ledger errors clients branch on, or "".
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, service.ErrMinimumBalance):
+		return "minimum_balance_required"
+	case errors.Is(err, service.ErrInsufficientFunds):
+		return "insufficient_funds"
+	case errors.Is(err, service.ErrAmountPrecision):
+		return "invalid_amount_precision"
+	case errors.Is(err, service.ErrDebitLimitExceeded):
+		return "debit_limit_exceeded"
+	case errors.Is(err, service.ErrOperationNotAllowed):
+		return "operation_not_allowed"
+	case errors.Is(err, service.ErrSavingsGoalLocked):
+		return "savings_goal_locked"
+	case errors.Is(err, service.ErrKYCRequired):
+		return "kyc_required"
+	case errors.Is(err, service.ErrKYCLimitExceeded):
+		return "limit_exceeded"
+	case errors.Is(err, service.ErrHeldForReview):
+		return "pending_review"
+	case errors.Is(err, service.ErrScreeningBlocked):
+		return "transaction_declined"
+	case errors.Is(err, service.ErrStepUpRequired):
+		return "step_up_required"
+	default:
+		return ""
+	}
+}
+
+// respondLedgerError writes a client-facing ledger error with its code. A ledger call cut off by
+// the request deadline answers 504 whatever status the caller mapped it to.
+func respondLedgerError(w http.ResponseWriter, status int, err error) {
+	if db.IsTimeout(err) {
+		respondError(w, http.StatusGatewayTimeout, "request timed out")
+		return
+	}
+	respondJSON(w, status, ErrorResponse{Error: localize(w, err.Error()), Code: errorCode(err)})
 }
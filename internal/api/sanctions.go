@@ -0,0 +1,267 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/nip"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/sanctions"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// DefaultSanctionsCacheTTL is how long a clean screening of a beneficiary is reused by default.
+const DefaultSanctionsCacheTTL = 24 * time.Hour
+
+// Screening statuses stored on the sanctions_screenings table. Pending screenings are the review queue.
+const (
+	screeningClear     = "clear"
+	screeningPending   = "pending_review"
+	screeningCleared   = "cleared"
+	screeningConfirmed = "confirmed"
+)
+
+// User screening statuses stored on users.screening_status. Only clear users can log in.
+const (
+	userScreeningClear   = "clear"
+	userScreeningPending = "pending_review"
+	userScreeningBlocked = "blocked"
+)
+
+// WithSanctionsScreening screens the names of users registering and of external transfer
+// beneficiaries with provider, reusing a beneficiary's clean result for cacheTTL.
+func WithSanctionsScreening(provider sanctions.Provider, cacheTTL time.Duration) Option {
+	return func(h *Handler) {
+		h.sanctions = provider
+		h.sanctionsCacheTTL = cacheTTL
+	}
+}
+
+// screenName asks the provider about name and returns the screening to record for subject. A hit,
+// or a provider failure, leaves it pending review.
+func (h *Handler) screenName(ctx context.Context, subject sqlc.CreateSanctionsScreeningParams) sqlc.CreateSanctionsScreeningParams {
+	subject.Provider = h.sanctions.Name()
+	subject.Status = screeningClear
+	subject.Matches = json.RawMessage("[]")
+	matches, err := h.sanctions.Screen(ctx, subject.Name)
+	if err != nil {
+		log.Error().Err(err).Str("subject_type", subject.SubjectType).Msg("Sanctions screening failed; holding for review")
+		subject.Error = err.Error()
+		subject.Status = screeningPending
+		return subject
+	}
+	if len(matches) > 0 {
+		subject.Status = screeningPending
+		if raw, err := json.Marshal(matches); err == nil {
+			subject.Matches = raw
+		}
+	}
+	return subject
+}
+
+// screenBeneficiary lets an external transfer from account to the beneficiary confirmed by name
+// enquiry through unless screening has the beneficiary pending review or confirmed as a match,
+// answering 403 with code beneficiary_under_review then.
+func (h *Handler) screenBeneficiary(w http.ResponseWriter, r *http.Request, account sqlc.Account, ne nip.NameEnquiryResponse) bool {
+	if h.sanctions == nil || !account.OrgID.Valid {
+		return true
+	}
+	orgID := account.OrgID.UUID
+	key := "beneficiary:" + ne.DestinationInstitutionCode + ":" + ne.AccountNumber + ":" + sanctions.Normalize(ne.AccountName)
+
+	// Step 1: Reuse a review, a pending review or a recent clean result for the same beneficiary.
+	screening, err := h.store.GetCachedScreening(r.Context(), sqlc.GetCachedScreeningParams{
+		OrgID:      orgID,
+		SubjectKey: key,
+		Since:      time.Now().Add(-h.sanctionsCacheTTL),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// Step 2: Screen afresh and record the result.
+		screening, err = h.store.CreateSanctionsScreening(r.Context(), h.screenName(r.Context(), sqlc.CreateSanctionsScreeningParams{
+			OrgID:         orgID,
+			SubjectType:   "beneficiary",
+			SubjectKey:    key,
+			Name:          ne.AccountName,
+			BankCode:      ne.DestinationInstitutionCode,
+			AccountNumber: ne.AccountNumber,
+		}))
+	}
+	if err != nil {
+		log.Error().Err(err).Str("account_id", account.ID.String()).Msg("Failed to screen beneficiary")
+		respondError(w, http.StatusInternalServerError, "failed to screen beneficiary")
+		return false
+	}
+
+	if screening.Status == screeningPending || screening.Status == screeningConfirmed {
+		log.Warn().Str("screening_id", screening.ID.String()).Str("account_id", account.ID.String()).Str("status", screening.Status).Msg("External transfer refused - beneficiary screening")
+		respondJSON(w, http.StatusForbidden, ErrorResponse{Error: "this beneficiary cannot be paid at the moment; contact support", Code: "beneficiary_under_review"})
+		return false
+	}
+	return true
+}
+
+// ListSanctionsScreenings godoc
+// @Summary      List sanctions screenings
+// @Description  Returns the caller's organization's sanctions screenings of registering users and external beneficiaries by status, oldest first. The default status "pending_review" is the review queue: names that matched a list entry or could not be screened. Compliance staff only.
+// @Tags         compliance
+// @Produce      json
+// @Param        status  query     string  false  "pending_review (default), clear, cleared or confirmed"
+// @Param        limit   query     int     false  "Limit (default 20)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   SanctionsScreeningResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /org/screenings [get]
+// @Security     Bearer
+func (h *Handler) ListSanctionsScreenings(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = screeningPending
+	case screeningPending, screeningClear, screeningCleared, screeningConfirmed:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be pending_review, clear, cleared or confirmed")
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	// Step 2: Fetch the page.
+	rows, err := h.store.ListSanctionsScreeningsByStatus(r.Context(), sqlc.ListSanctionsScreeningsByStatusParams{
+		OrgID:  orgID,
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Str("status", status).Msg("Failed to list sanctions screenings")
+		respondError(w, http.StatusInternalServerError, "failed to list sanctions screenings")
+		return
+	}
+
+	resp := make([]SanctionsScreeningResponse, 0, len(rows))
+	for _, s := range rows {
+		resp = append(resp, toSanctionsScreeningResponse(s))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ReviewSanctionsScreening godoc
+// @Summary      Review a sanctions screening
+// @Description  Decides a screening pending review. clear marks the match as false: a registered user can log in and transfers to the beneficiary go ahead. confirm keeps the user from logging in and the beneficiary from being paid, and needs a note. Compliance staff only.
+// @Tags         compliance
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                               true  "Screening ID"
+// @Param        body  body      object{decision=string,note=string}  true  "decision: clear or confirm"
+// @Success      200   {object}  SanctionsScreeningResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /org/screenings/{id}/decision [post]
+// @Security     Bearer
+func (h *Handler) ReviewSanctionsScreening(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate the reviewer (role is enforced by RequireRole) and parse input.
+	reviewerID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	screeningID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid screening ID")
+		return
+	}
+	var input struct {
+		Decision string `json:"decision"`
+		Note     string `json:"note"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	note := strings.TrimSpace(input.Note)
+	if len(note) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "note must be at most 500 characters")
+		return
+	}
+	status, userStatus := screeningCleared, userScreeningClear
+	switch input.Decision {
+	case "clear":
+	case "confirm":
+		if note == "" {
+			respondError(w, http.StatusBadRequest, "note required when confirming")
+			return
+		}
+		status, userStatus = screeningConfirmed, userScreeningBlocked
+	default:
+		respondError(w, http.StatusBadRequest, "decision must be clear or confirm")
+		return
+	}
+
+	// Step 2: Decide under the screening's row lock, and release or block the user with it.
+	var screening sqlc.SanctionsScreening
+	errNotPending := errors.New("screening is not pending review")
+	err = h.store.ExecTx(r.Context(), func(q *sqlc.Queries) error {
+		var err error
+		screening, err = q.GetSanctionsScreeningForUpdate(r.Context(), sqlc.GetSanctionsScreeningForUpdateParams{ID: screeningID, OrgID: orgID})
+		if err != nil {
+			return err
+		}
+		if screening.Status != screeningPending {
+			return errNotPending
+		}
+		screening, err = q.ReviewSanctionsScreening(r.Context(), sqlc.ReviewSanctionsScreeningParams{
+			Status:     status,
+			ReviewedBy: uuid.NullUUID{UUID: reviewerID, Valid: true},
+			ReviewNote: note,
+			ID:         screening.ID,
+		})
+		if err != nil || !screening.UserID.Valid {
+			return err
+		}
+		return q.SetUserScreeningStatus(r.Context(), sqlc.SetUserScreeningStatusParams{ID: screening.UserID.UUID, ScreeningStatus: userStatus})
+	})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		respondError(w, http.StatusNotFound, "screening not found")
+		return
+	case errors.Is(err, errNotPending):
+		respondError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		log.Error().Err(err).Str("screening_id", screeningID.String()).Msg("Failed to review sanctions screening")
+		respondError(w, http.StatusInternalServerError, "failed to review sanctions screening")
+		return
+	}
+
+	log.Info().Str("screening_id", screening.ID.String()).Str("reviewed_by", reviewerID.String()).Str("status", screening.Status).Msg("Sanctions screening reviewed")
+	respondJSON(w, http.StatusOK, toSanctionsScreeningResponse(screening))
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/nip"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/sanctions"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+type failingProvider struct{}
+
+func (failingProvider) Name() string { return "failing" }
+
+func (failingProvider) Screen(context.Context, string) ([]sanctions.Match, error) {
+	return nil, errors.New("provider unavailable")
+}
+
+func TestScreenName(t *testing.T) {
+	// Clean names pass, hits and provider failures wait for review.
+	h := &Handler{sanctions: sanctions.NewListProvider([]sanctions.Entry{{List: "OFAC SDN", Name: "Ivan Sidorov"}}, 0)}
+
+	clean := h.screenName(context.Background(), sqlc.CreateSanctionsScreeningParams{Name: "Ada Obi"})
+	assert.Equal(t, screeningClear, clean.Status)
+	assert.Equal(t, "list", clean.Provider)
+	assert.JSONEq(t, "[]", string(clean.Matches))
+
+	hit := h.screenName(context.Background(), sqlc.CreateSanctionsScreeningParams{Name: "Sidorov Ivan"})
+	assert.Equal(t, screeningPending, hit.Status)
+	var matches []sanctions.Match
+	require.NoError(t, json.Unmarshal(hit.Matches, &matches))
+	require.Len(t, matches, 1)
+	assert.Equal(t, "OFAC SDN", matches[0].List)
+
+	h.sanctions = failingProvider{}
+	failed := h.screenName(context.Background(), sqlc.CreateSanctionsScreeningParams{Name: "Ada Obi"})
+	assert.Equal(t, screeningPending, failed.Status)
+	assert.Equal(t, "provider unavailable", failed.Error)
+}
+
+func TestScreenBeneficiary_Disabled(t *testing.T) {
+	// Without a provider every beneficiary goes through untouched.
+	h := &Handler{}
+	rw := httptest.NewRecorder()
+	ok := h.screenBeneficiary(rw, httptest.NewRequest("POST", "/", nil), sqlc.Account{}, nip.NameEnquiryResponse{AccountName: "Ivan Sidorov"})
+	assert.True(t, ok)
+	assert.Zero(t, rw.Body.Len())
+}
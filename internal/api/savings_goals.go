@@ -0,0 +1,283 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// savingsGoalStatus maps savings goal errors to an HTTP status.
+func savingsGoalStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrSavingsGoalNotFound), errors.Is(err, service.ErrAccountNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrSavingsGoalExists), errors.Is(err, service.ErrSavingsGoalLocked):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidSavingsGoal):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondSavingsGoalError writes err with its savings goal status, hiding internal failures.
+func respondSavingsGoalError(w http.ResponseWriter, err error, msg string) {
+	status := savingsGoalStatus(err)
+	if status == http.StatusInternalServerError {
+		log.Error().Err(err).Msg("Savings goal operation failed")
+		respondError(w, status, msg)
+		return
+	}
+	respondLedgerError(w, status, err)
+}
+
+// savingsGoalInput is the body of goal create and replace requests.
+type savingsGoalInput struct {
+	Name         string      `json:"name"`
+	TargetAmount interface{} `json:"target_amount"`
+	TargetDate   string      `json:"target_date"`
+	LockedUntil  string      `json:"locked_until"`
+	Contribution *struct {
+		Amount   interface{} `json:"amount"`
+		Interval string      `json:"interval"`
+	} `json:"contribution"`
+}
+
+// decodeSavingsGoal reads a goal body for walletID, answering 400 for malformed fields.
+func decodeSavingsGoal(w http.ResponseWriter, r *http.Request, walletID, userID uuid.UUID) (service.SavingsGoalRequest, bool) {
+	var input savingsGoalInput
+	if !decodeJSON(w, r, &input) {
+		return service.SavingsGoalRequest{}, false
+	}
+	target, err := normalizeAmountInput(input.TargetAmount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid target_amount")
+		return service.SavingsGoalRequest{}, false
+	}
+	req := service.SavingsGoalRequest{
+		WalletID:     walletID,
+		Name:         input.Name,
+		TargetAmount: target,
+		CreatedBy:    userID,
+	}
+	for _, d := range []struct {
+		raw   string
+		dst   *time.Time
+		field string
+	}{
+		{input.TargetDate, &req.TargetDate, "target_date"},
+		{input.LockedUntil, &req.LockedUntil, "locked_until"},
+	} {
+		if d.raw == "" {
+			continue
+		}
+		if *d.dst, err = time.Parse(service.GoalDateLayout, d.raw); err != nil {
+			respondError(w, http.StatusBadRequest, d.field+" must be YYYY-MM-DD")
+			return service.SavingsGoalRequest{}, false
+		}
+	}
+	if input.Contribution != nil {
+		if req.ContributionAmount, err = normalizeAmountInput(input.Contribution.Amount); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid contribution amount")
+			return service.SavingsGoalRequest{}, false
+		}
+		req.ContributionInterval = input.Contribution.Interval
+	}
+	return req, true
+}
+
+// walletParam parses the {id} wallet and checks the caller's role on it.
+func (h *Handler) walletParam(w http.ResponseWriter, r *http.Request, role string) (uuid.UUID, sqlc.Account, bool) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return uuid.Nil, sqlc.Account{}, false
+	}
+	walletID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return uuid.Nil, sqlc.Account{}, false
+	}
+	wallet, ok := h.accountWithRole(w, r, userID, walletID, role)
+	if !ok {
+		return uuid.Nil, sqlc.Account{}, false
+	}
+	return userID, wallet, true
+}
+
+// CreateSavingsGoal godoc
+// @Summary      Set a savings goal on a sub-wallet
+// @Description  Gives a sub-wallet a target amount and optional target date. Progress is the wallet's balance. With locked_until, LedgerService refuses every debit of the wallet (withdrawals, transfers, payouts, conversions and moves back to the parent) until that UTC date. With contribution, amount moves from the parent account weekly or monthly, starting on the next contributions run; a contribution the parent cannot cover is skipped and reported on the goal. Amounts accept JSON number or string.
+// @Tags         wallets
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "Sub-wallet ID"
+// @Param        body  body      object{name=string,target_amount=string,target_date=string,locked_until=string,contribution=object{amount=string,interval=string}}  true  "Goal"
+// @Success      201   {object}  SavingsGoalResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/goal [post]
+// @Security     Bearer
+func (h *Handler) CreateSavingsGoal(w http.ResponseWriter, r *http.Request) {
+	userID, wallet, ok := h.walletParam(w, r, AccountRoleOwner)
+	if !ok {
+		return
+	}
+	req, ok := decodeSavingsGoal(w, r, wallet.ID, userID)
+	if !ok {
+		return
+	}
+	goal, err := h.ledger.CreateSavingsGoal(r.Context(), req)
+	if err != nil {
+		respondSavingsGoalError(w, err, "failed to create savings goal")
+		return
+	}
+	h.respondSavingsGoal(w, http.StatusCreated, goal, wallet)
+}
+
+// GetSavingsGoal godoc
+// @Summary      Get a wallet's savings goal
+// @Description  Returns the goal with its progress, lock and contribution status
+// @Tags         wallets
+// @Produce      json
+// @Param        id   path      string  true  "Sub-wallet ID"
+// @Success      200  {object}  SavingsGoalResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/goal [get]
+// @Security     Bearer
+func (h *Handler) GetSavingsGoal(w http.ResponseWriter, r *http.Request) {
+	_, wallet, ok := h.walletParam(w, r, AccountRoleViewer)
+	if !ok {
+		return
+	}
+	goal, err := h.store.GetSavingsGoalByWallet(r.Context(), wallet.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = service.ErrSavingsGoalNotFound
+	}
+	if err != nil {
+		respondSavingsGoalError(w, err, "failed to load savings goal")
+		return
+	}
+	h.respondSavingsGoal(w, http.StatusOK, goal, wallet)
+}
+
+// UpdateSavingsGoal godoc
+// @Summary      Replace a wallet's savings goal
+// @Description  Replaces every field of the goal; omitted optional fields are cleared. While locked, locked_until may only move later. Changing the contribution restarts it on the next run.
+// @Tags         wallets
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "Sub-wallet ID"
+// @Param        body  body      object{name=string,target_amount=string,target_date=string,locked_until=string,contribution=object{amount=string,interval=string}}  true  "Goal"
+// @Success      200   {object}  SavingsGoalResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/goal [put]
+// @Security     Bearer
+func (h *Handler) UpdateSavingsGoal(w http.ResponseWriter, r *http.Request) {
+	userID, wallet, ok := h.walletParam(w, r, AccountRoleOwner)
+	if !ok {
+		return
+	}
+	req, ok := decodeSavingsGoal(w, r, wallet.ID, userID)
+	if !ok {
+		return
+	}
+	goal, err := h.ledger.UpdateSavingsGoal(r.Context(), req)
+	if err != nil {
+		respondSavingsGoalError(w, err, "failed to update savings goal")
+		return
+	}
+	h.respondSavingsGoal(w, http.StatusOK, goal, wallet)
+}
+
+// DeleteSavingsGoal godoc
+// @Summary      Remove a wallet's savings goal
+// @Description  Removes the goal and its contribution rule once it is no longer locked. The money stays in the wallet.
+// @Tags         wallets
+// @Param        id  path  string  true  "Sub-wallet ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/goal [delete]
+// @Security     Bearer
+func (h *Handler) DeleteSavingsGoal(w http.ResponseWriter, r *http.Request) {
+	_, wallet, ok := h.walletParam(w, r, AccountRoleOwner)
+	if !ok {
+		return
+	}
+	if err := h.ledger.DeleteSavingsGoal(r.Context(), wallet.ID); err != nil {
+		respondSavingsGoalError(w, err, "failed to delete savings goal")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSavingsGoals godoc
+// @Summary      List my savings goals
+// @Description  Returns the goals on every wallet the caller owns or co-owns, with progress
+// @Tags         wallets
+// @Produce      json
+// @Success      200  {array}   SavingsGoalResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /goals [get]
+// @Security     Bearer
+func (h *Handler) ListSavingsGoals(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	rows, err := h.store.ListSavingsGoalsForUser(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list savings goals")
+		respondError(w, http.StatusInternalServerError, "failed to list savings goals")
+		return
+	}
+	now := time.Now()
+	resp := make([]SavingsGoalResponse, 0, len(rows))
+	for _, row := range rows {
+		goal, err := toSavingsGoalResponse(row.SavingsGoal, row.Balance, row.Currency, now)
+		if err != nil {
+			log.Error().Err(err).Str("goal_id", row.SavingsGoal.ID.String()).Msg("Invalid savings goal amounts")
+			respondError(w, http.StatusInternalServerError, "failed to list savings goals")
+			return
+		}
+		resp = append(resp, goal)
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// respondSavingsGoal writes goal with progress against the wallet's current balance.
+func (h *Handler) respondSavingsGoal(w http.ResponseWriter, status int, goal sqlc.SavingsGoal, wallet sqlc.Account) {
+	resp, err := toSavingsGoalResponse(goal, wallet.Balance, wallet.Currency, time.Now())
+	if err != nil {
+		log.Error().Err(err).Str("goal_id", goal.ID.String()).Msg("Invalid savings goal amounts")
+		respondError(w, http.StatusInternalServerError, "failed to load savings goal")
+		return
+	}
+	respondJSON(w, status, resp)
+}
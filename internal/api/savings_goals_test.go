@@ -0,0 +1,57 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestSavingsGoalStatus(t *testing.T) {
+	// A locked goal or a second goal conflicts; invalid goals are client errors.
+	assert.Equal(t, http.StatusNotFound, savingsGoalStatus(service.ErrSavingsGoalNotFound))
+	assert.Equal(t, http.StatusConflict, savingsGoalStatus(service.ErrSavingsGoalExists))
+	assert.Equal(t, http.StatusConflict, savingsGoalStatus(fmt.Errorf("%w until 2026-06-01", service.ErrSavingsGoalLocked)))
+	assert.Equal(t, http.StatusBadRequest, savingsGoalStatus(fmt.Errorf("%w: bad", service.ErrInvalidSavingsGoal)))
+	assert.Equal(t, http.StatusInternalServerError, savingsGoalStatus(errors.New("boom")))
+	assert.Equal(t, "savings_goal_locked", errorCode(service.ErrSavingsGoalLocked))
+}
+
+func TestToSavingsGoalResponse(t *testing.T) {
+	// Dates, lock state and the contribution rule are reported alongside progress.
+	now := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	next := now.Add(time.Hour)
+	goal := sqlc.SavingsGoal{
+		ID: uuid.New(), WalletID: uuid.New(), Name: "Car", TargetAmount: "2000.0000",
+		TargetDate:           sql.NullTime{Time: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+		LockedUntil:          sql.NullTime{Time: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		ContributionAmount:   sql.NullString{String: "100.0000", Valid: true},
+		ContributionInterval: sql.NullString{String: service.ContributionMonthly, Valid: true},
+		NextContributionAt:   sql.NullTime{Time: next, Valid: true},
+	}
+	resp, err := toSavingsGoalResponse(goal, "500.0000", "NGN", now)
+	require.NoError(t, err)
+	assert.True(t, resp.Locked)
+	assert.Equal(t, "2026-12-31", *resp.TargetDate)
+	assert.Equal(t, "2026-06-01", *resp.LockedUntil)
+	assert.Equal(t, "25.00", resp.ProgressPercent)
+	assert.Equal(t, "1500.0000", resp.Remaining)
+	require.NotNil(t, resp.Contribution)
+	assert.Equal(t, "monthly", resp.Contribution.Interval)
+	assert.Equal(t, next, *resp.Contribution.NextAt)
+
+	resp, err = toSavingsGoalResponse(sqlc.SavingsGoal{TargetAmount: "10.0000"}, "0.0000", "NGN", now)
+	require.NoError(t, err)
+	assert.False(t, resp.Locked)
+	assert.Nil(t, resp.TargetDate)
+	assert.Nil(t, resp.Contribution)
+}
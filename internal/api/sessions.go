@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/jobs"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// tokenTTL is how long an access token, and the session it belongs to, lasts.
+const tokenTTL = 24 * time.Hour
+
+// maxUserAgent bounds the user agent recorded on a session or login attempt.
+const maxUserAgent = 255
+
+// KindPruneSessions deletes sessions that expired longer ago than the configured retention.
+const KindPruneSessions = "sessions.prune"
+
+// issueToken opens a session for the user and returns a token that names it in the jti claim, so
+// the session can be revoked before the token expires.
+func (h *Handler) issueToken(r *http.Request, userID, orgID uuid.UUID, role string) (string, error) {
+	session, err := h.store.CreateSession(r.Context(), sqlc.CreateSessionParams{
+		ID:        uuid.New(),
+		UserID:    userID,
+		UserAgent: userAgent(r),
+		IpAddress: clientIP(r),
+		ExpiresAt: time.Now().Add(tokenTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return signToken(map[string]interface{}{
+		"user_id": userID.String(),
+		"org_id":  orgID.String(),
+		"role":    role,
+		"jti":     session.ID.String(),
+		"exp":     session.ExpiresAt.Unix(),
+	})
+}
+
+// sessionID returns the session named by the caller's token, or false for tokens issued before
+// sessions existed.
+func sessionID(r *http.Request) (uuid.UUID, bool, error) {
+	_, claims, err := jwtauth.FromContext(r.Context())
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return uuid.Nil, false, nil
+	}
+	id, err := uuid.Parse(jti)
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+	return id, true, nil
+}
+
+// RequireSession refuses tokens whose session was revoked by logout, by a password change or by
+// an admin. It runs after Authenticator. Tokens without a jti were issued before sessions existed
+// and are accepted until they expire.
+func (h *Handler) RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok, err := sessionID(r)
+		if err != nil {
+			log.Warn().Err(err).Msg("Invalid jti claim in JWT")
+			respondError(w, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		session, err := h.store.GetSession(r.Context(), id)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			log.Error().Err(err).Str("session_id", id.String()).Msg("Failed to load session")
+			respondError(w, http.StatusInternalServerError, "failed to check session")
+			return
+		}
+		if err != nil || session.RevokedAt.Valid {
+			respondJSON(w, http.StatusUnauthorized, ErrorResponse{Error: "session revoked; log in again", Code: "session_revoked"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// revokeUserSessions ends every open session of userID, logging rather than failing so the admin
+// action or password change that triggered it still completes.
+func (h *Handler) revokeUserSessions(ctx context.Context, userID uuid.UUID, why string) {
+	n, err := h.store.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("reason", why).Msg("Failed to revoke sessions")
+		return
+	}
+	if n > 0 {
+		log.Info().Str("user_id", userID.String()).Int64("sessions", n).Str("reason", why).Msg("Sessions revoked")
+	}
+}
+
+// Logout godoc
+// @Summary      Log out
+// @Description  Revokes the session of the token used to call it, so the token stops working before it expires. With all=true every session of the caller is revoked, logging out all devices. Tokens issued before sessions existed cannot be revoked and answer 400.
+// @Tags         auth
+// @Accept       json
+// @Param        body  body  object{all=bool}  false  "Log out everywhere"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /logout [post]
+// @Security     Bearer
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		All bool `json:"all"`
+	}
+	if !decodeOptionalJSON(w, r, &input) {
+		return
+	}
+
+	if input.All {
+		if _, err := h.store.RevokeUserSessions(r.Context(), userID); err != nil {
+			log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to revoke sessions")
+			respondError(w, http.StatusInternalServerError, "failed to log out")
+			return
+		}
+		log.Info().Str("user_id", userID.String()).Msg("User logged out of all sessions")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	id, ok, err := sessionID(r)
+	if err != nil || !ok {
+		respondError(w, http.StatusBadRequest, "token has no session; log in again to get one")
+		return
+	}
+	if _, err := h.store.RevokeSession(r.Context(), sqlc.RevokeSessionParams{ID: id, UserID: userID}); err != nil {
+		log.Error().Err(err).Str("session_id", id.String()).Msg("Failed to revoke session")
+		respondError(w, http.StatusInternalServerError, "failed to log out")
+		return
+	}
+	log.Info().Str("user_id", userID.String()).Str("session_id", id.String()).Msg("User logged out")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSessions godoc
+// @Summary      List my sessions
+// @Description  Returns the caller's sessions that are neither revoked nor expired, newest first, with the user agent and IP address they were opened from. current marks the session of the token used to call it.
+// @Tags         profile
+// @Produce      json
+// @Success      200  {array}   SessionResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /me/sessions [get]
+// @Security     Bearer
+func (h *Handler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	current, _, _ := sessionID(r)
+
+	sessions, err := h.store.ListActiveSessions(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list sessions")
+		respondError(w, http.StatusInternalServerError, "failed to list sessions")
+		return
+	}
+	resp := make([]SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		resp = append(resp, toSessionResponse(s, current))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// RevokeSession godoc
+// @Summary      Revoke one of my sessions
+// @Description  Logs out the device holding the session; its token stops working immediately. Revoking an already revoked session is a no-op.
+// @Tags         profile
+// @Produce      json
+// @Param        id   path      string  true  "Session ID"
+// @Success      200  {object}  SessionResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /me/sessions/{id} [delete]
+// @Security     Bearer
+func (h *Handler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid session ID")
+		return
+	}
+	current, _, _ := sessionID(r)
+
+	session, err := h.store.RevokeSession(r.Context(), sqlc.RevokeSessionParams{ID: id, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "session not found")
+			return
+		}
+		log.Error().Err(err).Str("session_id", id.String()).Msg("Failed to revoke session")
+		respondError(w, http.StatusInternalServerError, "failed to revoke session")
+		return
+	}
+	log.Info().Str("user_id", userID.String()).Str("session_id", id.String()).Msg("Session revoked")
+	respondJSON(w, http.StatusOK, toSessionResponse(session, current))
+}
+
+// PruneStore deletes expired sessions. *db.Store satisfies it.
+type PruneStore interface {
+	DeleteSessionsExpiredBefore(ctx context.Context, expiresAt time.Time) (int64, error)
+}
+
+// PruneSessions returns a handler that keeps the sessions table from growing without bound.
+// Expired sessions are kept for retention so recent logins stay inspectable.
+func PruneSessions(store PruneStore, retention time.Duration) jobs.HandlerFunc {
+	return func(ctx context.Context, _ json.RawMessage) error {
+		n, err := store.DeleteSessionsExpiredBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+		log.Info().Int64("sessions", n).Msg("Pruned expired sessions")
+		return nil
+	}
+}
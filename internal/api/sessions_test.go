@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestRequireSession_TokensWithoutSession(t *testing.T) {
+	// Tokens from before sessions pass untouched; a malformed jti is refused.
+	require.NoError(t, InitTokenAuth(testJWTSecret))
+	protected := Verifier(Authenticator((&Handler{}).RequireSession(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))))
+
+	legacy, err := GenerateToken(uuid.New(), uuid.New(), RoleCustomer)
+	require.NoError(t, err)
+	bad, err := signToken(map[string]interface{}{"user_id": uuid.NewString(), "jti": "not-a-uuid", "exp": time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+
+	for token, want := range map[string]int{legacy: http.StatusNoContent, bad: http.StatusUnauthorized} {
+		req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rw := httptest.NewRecorder()
+		protected.ServeHTTP(rw, req)
+		assert.Equal(t, want, rw.Code)
+	}
+}
+
+func TestSessionEndpoints_RejectBadInput(t *testing.T) {
+	// Logging out a token without a session and revoking a malformed id are client errors.
+	require.NoError(t, InitTokenAuth(testJWTSecret))
+	token, err := GenerateToken(uuid.New(), uuid.New(), RoleCustomer)
+	require.NoError(t, err)
+
+	h := &Handler{}
+	router := chi.NewRouter()
+	router.Group(func(r chi.Router) {
+		r.Use(Verifier, Authenticator)
+		r.Post("/logout", h.Logout)
+		r.Delete("/me/sessions/{id}", h.RevokeSession)
+	})
+
+	for _, tc := range []struct{ method, path, want string }{
+		{http.MethodPost, "/logout", "no session"},
+		{http.MethodDelete, "/me/sessions/nope", "invalid session ID"},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusBadRequest, rw.Code, tc.path)
+		assert.Contains(t, rw.Body.String(), tc.want, tc.path)
+	}
+}
+
+func TestToSessionResponse(t *testing.T) {
+	// Only the session the caller's token names is marked current.
+	s := sqlc.Session{ID: uuid.New(), UserAgent: "curl/8.0", IpAddress: "192.0.2.1"}
+	assert.True(t, toSessionResponse(s, s.ID).Current)
+	assert.False(t, toSessionResponse(s, uuid.New()).Current)
+	assert.Nil(t, toSessionResponse(s, s.ID).RevokedAt)
+}
+
+type fakePruneStore struct{ cutoff time.Time }
+
+func (f *fakePruneStore) DeleteSessionsExpiredBefore(_ context.Context, expiresAt time.Time) (int64, error) {
+	f.cutoff = expiresAt
+	return 3, nil
+}
+
+func TestPruneSessions(t *testing.T) {
+	// Sessions are deleted only once they have been expired for the whole retention.
+	store := &fakePruneStore{}
+	require.NoError(t, PruneSessions(store, 7*24*time.Hour)(context.Background(), nil))
+	assert.WithinDuration(t, time.Now().Add(-7*24*time.Hour), store.cutoff, time.Minute)
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// GetSettlementReport godoc
+// @Summary      Report the settlement account's position
+// @Description  Summarizes the settlement account, its shards included, between from and to (inclusive UTC dates, default the current month to date) as the settlement bank sees it: money the ledger says the bank holds, which is the settlement account's balance negated. Gives the opening and closing positions, inflows and outflows per operation type and per day with each day's closing position, and the bank balance expected at the end of to. With bank_balance, the bank's actual closing balance from its statement, also gives the difference (bank less expected). Months pruned to the archive count toward the positions but not the breakdowns. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        from          query     string  false  "First day (YYYY-MM-DD)"
+// @Param        to            query     string  false  "Last day (YYYY-MM-DD)"
+// @Param        bank_balance  query     string  false  "The settlement bank's closing balance for to"
+// @Success      200           {object}  SettlementReportResponse
+// @Failure      400           {object}  ErrorResponse
+// @Failure      401           {object}  ErrorResponse
+// @Failure      403           {object}  ErrorResponse
+// @Failure      404           {object}  ErrorResponse
+// @Failure      500           {object}  ErrorResponse
+// @Router       /admin/settlement-report [get]
+// @Security     Bearer
+func (h *Handler) GetSettlementReport(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse the range and the bank's balance to compare against.
+	from, to, err := reportRange(r, time.Now().UTC())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var bankBalance *decimal.Decimal
+	if raw := r.URL.Query().Get("bank_balance"); raw != "" {
+		d, err := decimal.NewFromString(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "bank_balance must be a decimal amount")
+			return
+		}
+		bankBalance = &d
+	}
+
+	// Step 2: Read the positions either side of the range and the activity within it.
+	opening, err := h.store.GetSettlementPositionBefore(r.Context(), from)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(w, http.StatusNotFound, "settlement account not found")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to read opening settlement position")
+		respondError(w, http.StatusInternalServerError, "failed to build settlement report")
+		return
+	}
+	closing, err := h.store.GetSettlementPositionBefore(r.Context(), to)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to read closing settlement position")
+		respondError(w, http.StatusInternalServerError, "failed to build settlement report")
+		return
+	}
+	rows, err := h.store.SettlementActivity(r.Context(), sqlc.SettlementActivityParams{FromTime: from, ToTime: to})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to total settlement activity")
+		respondError(w, http.StatusInternalServerError, "failed to build settlement report")
+		return
+	}
+
+	resp, err := buildSettlementReport(opening, closing, rows, bankBalance)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to build settlement report")
+		respondError(w, http.StatusInternalServerError, "failed to build settlement report")
+		return
+	}
+	resp.From = from.Format("2006-01-02")
+	resp.To = to.AddDate(0, 0, -1).Format("2006-01-02")
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// settlementTotals accumulates settlement activity in the bank's view.
+type settlementTotals struct {
+	entries         int64
+	inflow, outflow decimal.Decimal
+}
+
+// buildSettlementReport turns the settlement account's balances before and after the range, and
+// its activity within it, into the bank's view: positions are balances negated, inflows debits.
+func buildSettlementReport(opening, closing sqlc.GetSettlementPositionBeforeRow, rows []sqlc.SettlementActivityRow, bankBalance *decimal.Decimal) (SettlementReportResponse, error) {
+	openingBalance, err := decimal.NewFromString(opening.Balance)
+	if err != nil {
+		return SettlementReportResponse{}, err
+	}
+	closingBalance, err := decimal.NewFromString(closing.Balance)
+	if err != nil {
+		return SettlementReportResponse{}, err
+	}
+	openingPos, closingPos := openingBalance.Neg(), closingBalance.Neg()
+
+	// Rows come ordered by day, then operation type, so days arrive in order.
+	var days []string
+	byDay := map[string]*settlementTotals{}
+	byOp := map[string]*settlementTotals{}
+	for _, row := range rows {
+		in, err := decimal.NewFromString(row.Inflow)
+		if err != nil {
+			return SettlementReportResponse{}, err
+		}
+		out, err := decimal.NewFromString(row.Outflow)
+		if err != nil {
+			return SettlementReportResponse{}, err
+		}
+		if byDay[row.Day] == nil {
+			byDay[row.Day] = &settlementTotals{}
+			days = append(days, row.Day)
+		}
+		if byOp[row.OperationType] == nil {
+			byOp[row.OperationType] = &settlementTotals{}
+		}
+		for _, t := range []*settlementTotals{byDay[row.Day], byOp[row.OperationType]} {
+			t.entries += row.Entries
+			t.inflow = t.inflow.Add(in)
+			t.outflow = t.outflow.Add(out)
+		}
+	}
+
+	resp := SettlementReportResponse{
+		Currency:            opening.Currency,
+		OpeningPosition:     openingPos.StringFixed(4),
+		ClosingPosition:     closingPos.StringFixed(4),
+		NetMovement:         closingPos.Sub(openingPos).StringFixed(4),
+		ExpectedBankBalance: closingPos.StringFixed(4),
+		ByOperation:         make([]SettlementOperationResponse, 0, len(byOp)),
+		ByDay:               make([]SettlementDayResponse, 0, len(days)),
+	}
+	for _, op := range slices.Sorted(maps.Keys(byOp)) {
+		t := byOp[op]
+		resp.ByOperation = append(resp.ByOperation, SettlementOperationResponse{
+			OperationType: op,
+			Entries:       t.entries,
+			Inflow:        t.inflow.StringFixed(4),
+			Outflow:       t.outflow.StringFixed(4),
+			Net:           t.inflow.Sub(t.outflow).StringFixed(4),
+		})
+	}
+	position := openingPos
+	for _, day := range days {
+		t := byDay[day]
+		position = position.Add(t.inflow).Sub(t.outflow)
+		resp.ByDay = append(resp.ByDay, SettlementDayResponse{
+			Date:            day,
+			Entries:         t.entries,
+			Inflow:          t.inflow.StringFixed(4),
+			Outflow:         t.outflow.StringFixed(4),
+			Net:             t.inflow.Sub(t.outflow).StringFixed(4),
+			ClosingPosition: position.StringFixed(4),
+		})
+	}
+
+	// The bank's own closing balance, when given, is compared with the one the ledger expects.
+	if bankBalance != nil {
+		actual, diff := bankBalance.StringFixed(4), bankBalance.Sub(closingPos)
+		difference, matched := diff.StringFixed(4), diff.IsZero()
+		resp.BankBalance, resp.Difference, resp.Matched = &actual, &difference, &matched
+	}
+	return resp, nil
+}
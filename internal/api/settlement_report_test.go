@@ -0,0 +1,45 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestBuildSettlementReport(t *testing.T) {
+	opening := sqlc.GetSettlementPositionBeforeRow{Currency: "NGN", Balance: "-1000.0000"}
+	closing := sqlc.GetSettlementPositionBeforeRow{Currency: "NGN", Balance: "-1150.0000"}
+	rows := []sqlc.SettlementActivityRow{
+		{Day: "2026-10-01", OperationType: "deposit", Entries: 2, Inflow: "300.0000", Outflow: "0.0000"},
+		{Day: "2026-10-01", OperationType: "withdrawal", Entries: 1, Inflow: "0.0000", Outflow: "100.0000"},
+		{Day: "2026-10-02", OperationType: "deposit", Entries: 1, Inflow: "50.0000", Outflow: "0.0000"},
+		{Day: "2026-10-02", OperationType: "reversal", Entries: 1, Inflow: "0.0000", Outflow: "100.0000"},
+	}
+	bank := decimal.RequireFromString("1140")
+
+	resp, err := buildSettlementReport(opening, closing, rows, &bank)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1000.0000", resp.OpeningPosition)
+	assert.Equal(t, "1150.0000", resp.ClosingPosition)
+	assert.Equal(t, "150.0000", resp.NetMovement)
+	assert.Equal(t, "1150.0000", resp.ExpectedBankBalance)
+
+	require.Len(t, resp.ByOperation, 3)
+	assert.Equal(t, "deposit", resp.ByOperation[0].OperationType)
+	assert.Equal(t, int64(3), resp.ByOperation[0].Entries)
+	assert.Equal(t, "350.0000", resp.ByOperation[0].Net)
+	assert.Equal(t, "reversal", resp.ByOperation[1].OperationType)
+
+	require.Len(t, resp.ByDay, 2)
+	assert.Equal(t, "1200.0000", resp.ByDay[0].ClosingPosition)
+	assert.Equal(t, "1150.0000", resp.ByDay[1].ClosingPosition)
+
+	require.NotNil(t, resp.Difference)
+	assert.Equal(t, "-10.0000", *resp.Difference)
+	assert.False(t, *resp.Matched)
+}
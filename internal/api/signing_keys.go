@@ -0,0 +1,271 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/sdk/httpsig"
+)
+
+// WithSignedTransfers requires transfers of more than threshold, in the source account's currency,
+// to be signed with a key registered on the account as well as carry a bearer token.
+func WithSignedTransfers(threshold decimal.Decimal) Option {
+	return func(h *Handler) {
+		h.signedTransfersAbove = &threshold
+	}
+}
+
+// captureSignedBody reads the request body so its digest can be checked once the amount is known,
+// leaving it in place for decoding. It reads nothing when signed transfers are off and the request
+// is not signed.
+func (h *Handler) captureSignedBody(r *http.Request) []byte {
+	if (h.signedTransfersAbove == nil && r.Header.Get("Signature") == "") || r.Body == nil {
+		return nil
+	}
+	// One byte over the limit lets decodeJSON still refuse oversized bodies.
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxJSONBody+1))
+	if err != nil {
+		body = nil
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body
+}
+
+// checkRequestSignature lets transfers of amount from accountID through when they are below the
+// signing threshold or signed with one of the account's keys, answering 401 with code
+// signature_required or invalid_signature otherwise. See verifyRequestSignature for the context.
+func (h *Handler) checkRequestSignature(w http.ResponseWriter, r *http.Request, body []byte, accountID uuid.UUID, amount string) (context.Context, bool) {
+	required := false
+	if h.signedTransfersAbove != nil {
+		d, err := decimal.NewFromString(amount)
+		required = err != nil || d.GreaterThan(*h.signedTransfersAbove)
+	}
+	return h.verifyRequestSignature(w, r, body, accountID, required)
+}
+
+// verifyRequestSignature checks a signature made with one of accountID's keys, answering 401 with
+// code invalid_signature when it does not verify, or signature_required when it is missing and
+// required. A verified request is a step-up: the returned context lets operations challenged for
+// fraud risk through.
+func (h *Handler) verifyRequestSignature(w http.ResponseWriter, r *http.Request, body []byte, accountID uuid.UUID, required bool) (context.Context, bool) {
+	sig, err := httpsig.Parse(r)
+	if errors.Is(err, httpsig.ErrMissing) {
+		if !required {
+			return r.Context(), true
+		}
+		respondJSON(w, http.StatusUnauthorized, ErrorResponse{
+			Error: "transfers above " + h.signedTransfersAbove.String() + " must be signed with a key registered on the account",
+			Code:  "signature_required",
+		})
+		return nil, false
+	}
+	refuse := func(reason error) (context.Context, bool) {
+		log.Warn().Err(reason).Str("account_id", accountID.String()).Str("key_id", sig.KeyID).Msg("Request refused - invalid request signature")
+		respondJSON(w, http.StatusUnauthorized, ErrorResponse{Error: "invalid request signature", Code: "invalid_signature"})
+		return nil, false
+	}
+	if err != nil {
+		return refuse(err)
+	}
+	keyID, err := uuid.Parse(sig.KeyID)
+	if err != nil {
+		return refuse(err)
+	}
+	key, err := h.store.GetActiveAccountSigningKey(r.Context(), sqlc.GetActiveAccountSigningKeyParams{ID: keyID, AccountID: accountID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return refuse(errors.New("unknown or revoked key"))
+	}
+	if err != nil {
+		log.Error().Err(err).Str("key_id", keyID.String()).Msg("Failed to load signing key")
+		respondError(w, http.StatusInternalServerError, "failed to check request signature")
+		return nil, false
+	}
+	pub, err := httpsig.ParsePublicKey(key.PublicKey)
+	if err != nil {
+		return refuse(err)
+	}
+	if err := httpsig.Verify(r, body, sig, pub, time.Now()); err != nil {
+		return refuse(err)
+	}
+
+	if err := h.store.TouchAccountSigningKey(r.Context(), keyID); err != nil {
+		log.Warn().Err(err).Str("key_id", keyID.String()).Msg("Failed to record signing key use")
+	}
+	return service.WithStepUp(r.Context()), true
+}
+
+// CreateSigningKey godoc
+// @Summary      Register a request signing key
+// @Description  Registers an Ed25519 public key (base64 of its 32 bytes) on the account. When the server requires signed transfers, transfers from the account above the configured amount must carry Date, Digest and Signature headers made with one of its keys, in the HTTP Signatures style (see the sdk/httpsig package). Because a key is a second factor, the caller's current password is required. Owners only.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                                          true  "Account ID"
+// @Param        body  body      object{name=string,public_key=string,password=string}  true  "Key"
+// @Success      201   {object}  SigningKeyResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/signing-keys [post]
+// @Security     Bearer
+func (h *Handler) CreateSigningKey(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and validate the key.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	var input struct {
+		Name      string `json:"name"`
+		PublicKey string `json:"public_key"`
+		Password  string `json:"password"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	if _, err := httpsig.ParsePublicKey(input.PublicKey); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, ok := h.ownedAccount(w, r, userID, accountID); !ok {
+		return
+	}
+
+	// Step 2: Confirm the caller's password, so a stolen token cannot enroll its own key.
+	user, err := h.store.GetUser(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to load user")
+		respondError(w, http.StatusInternalServerError, "failed to register signing key")
+		return
+	}
+	if _, err := h.passwords.Verify(user.HashedPassword, input.Password); err != nil {
+		log.Warn().Str("user_id", userID.String()).Str("account_id", accountID.String()).Msg("Signing key refused - wrong password")
+		respondError(w, http.StatusUnauthorized, "password is incorrect")
+		return
+	}
+
+	// Step 3: Store the key.
+	key, err := h.store.CreateAccountSigningKey(r.Context(), sqlc.CreateAccountSigningKeyParams{
+		AccountID: accountID,
+		Name:      strings.TrimSpace(input.Name),
+		PublicKey: strings.TrimSpace(input.PublicKey),
+		CreatedBy: userID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to register signing key")
+		respondError(w, http.StatusInternalServerError, "failed to register signing key")
+		return
+	}
+
+	log.Info().Str("key_id", key.ID.String()).Str("account_id", accountID.String()).Str("user_id", userID.String()).Msg("Signing key registered")
+	respondJSON(w, http.StatusCreated, toSigningKeyResponse(key))
+}
+
+// ListSigningKeys godoc
+// @Summary      List request signing keys
+// @Description  Returns the account's signing keys that are not revoked, oldest first. Owners only.
+// @Tags         accounts
+// @Produce      json
+// @Param        id   path      string  true  "Account ID"
+// @Success      200  {array}   SigningKeyResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/signing-keys [get]
+// @Security     Bearer
+func (h *Handler) ListSigningKeys(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.ownedAccount(w, r, userID, accountID); !ok {
+		return
+	}
+
+	keys, err := h.store.ListAccountSigningKeys(r.Context(), accountID)
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to list signing keys")
+		respondError(w, http.StatusInternalServerError, "failed to list signing keys")
+		return
+	}
+	resp := make([]SigningKeyResponse, 0, len(keys))
+	for _, k := range keys {
+		resp = append(resp, toSigningKeyResponse(k))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// RevokeSigningKey godoc
+// @Summary      Revoke a request signing key
+// @Description  Stops the key from signing transfers from the account. Revoking an already revoked key is a no-op. Owners only.
+// @Tags         accounts
+// @Produce      json
+// @Param        id      path      string  true  "Account ID"
+// @Param        keyId   path      string  true  "Key ID"
+// @Success      200     {object}  SigningKeyResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      404     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /accounts/{id}/signing-keys/{keyId} [delete]
+// @Security     Bearer
+func (h *Handler) RevokeSigningKey(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	keyID, err := uuid.Parse(chi.URLParam(r, "keyId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid key ID")
+		return
+	}
+	if _, ok := h.ownedAccount(w, r, userID, accountID); !ok {
+		return
+	}
+
+	key, err := h.store.RevokeAccountSigningKey(r.Context(), sqlc.RevokeAccountSigningKeyParams{ID: keyID, AccountID: accountID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "signing key not found")
+			return
+		}
+		log.Error().Err(err).Str("key_id", keyID.String()).Msg("Failed to revoke signing key")
+		respondError(w, http.StatusInternalServerError, "failed to revoke signing key")
+		return
+	}
+
+	log.Info().Str("key_id", key.ID.String()).Str("account_id", accountID.String()).Str("user_id", userID.String()).Msg("Signing key revoked")
+	respondJSON(w, http.StatusOK, toSigningKeyResponse(key))
+}
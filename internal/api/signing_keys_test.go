@@ -0,0 +1,100 @@
+package api
+
+import (
+	"crypto/ed25519"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/sdk/httpsig"
+)
+
+func TestCaptureSignedBody(t *testing.T) {
+	// The captured body can still be decoded; nothing is read while signing is off.
+	r := httptest.NewRequest(http.MethodPost, "/transfers", strings.NewReader(`{"amount":"10"}`))
+	assert.Nil(t, (&Handler{}).captureSignedBody(r))
+
+	h := &Handler{}
+	WithSignedTransfers(decimal.NewFromInt(1000))(h)
+	body := h.captureSignedBody(r)
+	assert.Equal(t, `{"amount":"10"}`, string(body))
+	rest, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, rest)
+}
+
+func TestCheckRequestSignature(t *testing.T) {
+	// Transfers up to the threshold pass; larger ones need a well-formed signature.
+	h := &Handler{}
+	WithSignedTransfers(decimal.NewFromInt(1000))(h)
+	accountID := uuid.New()
+	body := []byte(`{"amount":"5000"}`)
+	check := func(rw http.ResponseWriter, r *http.Request, amount string) bool {
+		_, ok := h.checkRequestSignature(rw, r, body, accountID, amount)
+		return ok
+	}
+
+	rw := httptest.NewRecorder()
+	assert.True(t, check(rw, httptest.NewRequest(http.MethodPost, "/transfers", nil), "1000.0000"))
+
+	rw = httptest.NewRecorder()
+	assert.False(t, check(rw, httptest.NewRequest(http.MethodPost, "/transfers", nil), "5000"))
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"code":"signature_required"`)
+
+	// A key ID that is not a key is refused before any lookup, below the threshold too.
+	_, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	for _, amount := range []string{"5000", "10"} {
+		req := httptest.NewRequest(http.MethodPost, "/transfers", nil)
+		require.NoError(t, httpsig.Sign(req, body, "not-a-key", priv))
+		rw = httptest.NewRecorder()
+		assert.False(t, check(rw, req, amount))
+		assert.Contains(t, rw.Body.String(), `"code":"invalid_signature"`)
+	}
+}
+
+func TestVerifyRequestSignature_Optional(t *testing.T) {
+	// Unsigned requests pass when no signature is required, without stepping up.
+	h := &Handler{}
+	req := httptest.NewRequest(http.MethodPost, "/accounts/x/withdraw", nil)
+	ctx, ok := h.verifyRequestSignature(httptest.NewRecorder(), req, nil, uuid.New(), false)
+	assert.True(t, ok)
+	assert.Equal(t, req.Context(), ctx)
+}
+
+func TestCreateSigningKey_RejectsBadKey(t *testing.T) {
+	// Keys that are not 32-byte Ed25519 keys are refused before the account is loaded.
+	require.NoError(t, InitTokenAuth(testJWTSecret))
+	token, err := GenerateToken(uuid.New(), uuid.New(), RoleCustomer)
+	require.NoError(t, err)
+	router := chi.NewRouter()
+	router.Group(func(r chi.Router) {
+		r.Use(Verifier, Authenticator)
+		r.Post("/accounts/{id}/signing-keys", (&Handler{}).CreateSigningKey)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/accounts/"+uuid.NewString()+"/signing-keys", strings.NewReader(`{"public_key":"c2hvcnQ=","password":"x"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assert.Contains(t, rw.Body.String(), "Ed25519")
+}
+
+func TestToSigningKeyResponse(t *testing.T) {
+	// Unused, active keys carry no timestamps beyond creation.
+	resp := toSigningKeyResponse(sqlc.AccountSigningKey{ID: uuid.New(), AccountID: uuid.New(), PublicKey: "cHVi"})
+	assert.Nil(t, resp.LastUsedAt)
+	assert.Nil(t, resp.RevokedAt)
+	assert.Equal(t, "cHVi", resp.PublicKey)
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+// splitStatus maps split payment errors to an HTTP status.
+func splitStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAccountNotFound), errors.Is(err, service.ErrCrossOrgTransfer):
+		// Accounts of other tenants are indistinguishable from missing ones.
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidSplit), errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountPrecision),
+		errors.Is(err, service.ErrSameAccountTransfer), errors.Is(err, service.ErrCurrencyMismatch),
+		errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrMinimumBalance),
+		errors.Is(err, service.ErrDebitLimitExceeded), errors.Is(err, service.ErrOperationNotAllowed), errors.Is(err, service.ErrSavingsGoalLocked):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrKYCRequired), errors.Is(err, service.ErrKYCLimitExceeded):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// PaySplit godoc
+// @Summary      Pay several accounts at once
+// @Description  Debits amount from an account the caller owns and credits each split's account with its share under one ledger transaction, e.g. a marketplace sale settled to the merchant, the platform fee and tax. Shares must add up to amount; up to 20 distinct destinations in the caller's organization and currency. The transfer limit applies to amount, and fraud scoring applies to amount against each destination; a split needing step-up answers 401 and one needing review is refused (403), as it cannot wait. With SIGNED_TRANSFER_THRESHOLD set, a total above it must be signed like POST /transfers. Amount fields accept JSON number or string.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "Source account ID"
+// @Param        body  body      object{amount=string,description=string,splits=[]object{account_id=string,amount=string,description=string}}  true  "Total and shares"
+// @Success      201   {object}  SplitPaymentResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/splits [post]
+// @Security     Bearer
+func (h *Handler) PaySplit(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and authorize the source account.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	fromID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.ownedAccount(w, r, userID, fromID); !ok {
+		return
+	}
+
+	// Step 2: Decode the total and every share.
+	body := h.captureSignedBody(r)
+	var input struct {
+		Amount      interface{} `json:"amount"`
+		Description string      `json:"description"`
+		Splits      []struct {
+			Amount      interface{} `json:"amount"`
+			AccountID   string      `json:"account_id"`
+			Description string      `json:"description"`
+		} `json:"splits"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	total, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid amount")
+		return
+	}
+	ctx, ok := h.checkRequestSignature(w, r, body, fromID, total)
+	if !ok {
+		return
+	}
+	r = r.WithContext(ctx)
+	description := strings.TrimSpace(input.Description)
+	if len(description) > maxDecisionNote {
+		respondError(w, http.StatusBadRequest, "description must be at most 500 characters")
+		return
+	}
+	if description == "" {
+		description = "Split payment"
+	}
+	if len(input.Splits) == 0 || len(input.Splits) > service.MaxSplitDestinations {
+		respondError(w, http.StatusBadRequest, service.ErrInvalidSplit.Error())
+		return
+	}
+	shares := make([]service.SplitShare, 0, len(input.Splits))
+	for _, split := range input.Splits {
+		accountID, err := uuid.Parse(split.AccountID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid split account_id")
+			return
+		}
+		amount, err := normalizeAmountInput(split.Amount)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid split amount")
+			return
+		}
+		shareDescription := strings.TrimSpace(split.Description)
+		if len(shareDescription) > maxDecisionNote {
+			respondError(w, http.StatusBadRequest, "split description must be at most 500 characters")
+			return
+		}
+		shares = append(shares, service.SplitShare{AccountID: accountID, Amount: amount, Description: shareDescription})
+	}
+
+	// Step 3: Post atomically; the service re-checks organization, currency and funds under lock.
+	payment, err := h.ledger.PaySplit(r.Context(), fromID, total, description, shares)
+	if respondScreening(w, err) {
+		return
+	}
+	if err != nil {
+		status := splitStatus(err)
+		switch status {
+		case http.StatusNotFound:
+			respondError(w, status, "destination account not found")
+		case http.StatusInternalServerError:
+			log.Error().Err(err).Str("from_id", fromID.String()).Msg("Split payment failed")
+			respondError(w, status, "failed to post split payment")
+		default:
+			respondLedgerError(w, status, err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toSplitPaymentResponse(payment))
+}
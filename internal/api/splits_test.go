@@ -0,0 +1,20 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestSplitStatus(t *testing.T) {
+	// Unbalanced splits and ledger refusals are client errors; other tenants' accounts look missing.
+	assert.Equal(t, http.StatusBadRequest, splitStatus(service.ErrInvalidSplit))
+	assert.Equal(t, http.StatusBadRequest, splitStatus(service.ErrInsufficientFunds))
+	assert.Equal(t, http.StatusNotFound, splitStatus(service.ErrCrossOrgTransfer))
+	assert.Equal(t, http.StatusForbidden, splitStatus(service.ErrKYCLimitExceeded))
+	assert.Equal(t, http.StatusInternalServerError, splitStatus(errors.New("connection reset")))
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+const (
+	sseHeartbeat   = 15 * time.Second
+	sseReplayBatch = 100
+)
+
+// StreamEntries godoc
+// @Summary      Server-Sent Events feed of account entries
+// @Description  Streams new ledger entries for an account as text/event-stream. Each event id is the entry's position in the account's chain, which follows commit order; reconnecting with Last-Event-ID (header or last_event_id query) replays entries committed after it before switching to live delivery. An entry ID is also accepted as Last-Event-ID; one no longer stored, such as a pruned entry, resumes with live delivery only. Browsers may pass the JWT as ?jwt=.
+// @Tags         streaming
+// @Produce      text/event-stream
+// @Param        id             path      string  true   "Account ID"
+// @Param        Last-Event-ID  header    string  false  "Resume after this event ID"
+// @Param        last_event_id  query     string  false  "Resume after this event ID (EventSource fallback)"
+// @Success      200  {object}  StreamMessage
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      503  {object}  ErrorResponse
+// @Router       /accounts/{id}/entries/stream [get]
+// @Security     Bearer
+func (h *Handler) StreamEntries(w http.ResponseWriter, r *http.Request) {
+	if h.realtime == nil {
+		respondError(w, http.StatusServiceUnavailable, "live streaming is not enabled")
+		return
+	}
+
+	// Step 1: Authenticate caller and enforce ownership.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	acc, ok := h.visibleAccount(w, r, userID, accountID)
+	if !ok {
+		return
+	}
+
+	// Step 2: Resolve the resume point, if any, before committing to a stream.
+	lastID := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	if lastID == "" {
+		lastID = strings.TrimSpace(r.URL.Query().Get("last_event_id"))
+	}
+	afterSeq := int64(-1)
+	if lastID != "" {
+		if afterSeq, ok = h.resumePosition(w, r, accountID, lastID); !ok {
+			return
+		}
+	}
+
+	// Subscribe before replaying so entries committed mid-replay are not lost.
+	sub := h.realtime.Subscribe(accountID)
+	defer sub.Close()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(id, event string, payload interface{}) error {
+		data, marshalErr := json.Marshal(payload)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		// Long-lived stream: extend the server write deadline one frame at a time.
+		if deadlineErr := rc.SetWriteDeadline(time.Now().Add(wsWriteWait)); deadlineErr != nil {
+			return deadlineErr
+		}
+		if id != "" {
+			if _, writeErr := fmt.Fprintf(w, "id: %s\n", id); writeErr != nil {
+				return writeErr
+			}
+		}
+		if _, writeErr := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); writeErr != nil {
+			return writeErr
+		}
+		return rc.Flush()
+	}
+	if _, err := fmt.Fprint(w, "retry: 3000\n\n"); err != nil {
+		return
+	}
+
+	// Step 3: Replay history after the resume point in batches.
+	replayedThrough := afterSeq
+	for afterSeq >= 0 {
+		batch, listErr := h.store.ListEntriesByAccountAfter(r.Context(), sqlc.ListEntriesByAccountAfterParams{
+			AccountID: accountID,
+			AfterSeq:  afterSeq,
+			RowLimit:  sseReplayBatch,
+		})
+		if listErr != nil {
+			log.Error().Err(listErr).Str("account_id", accountID.String()).Msg("Failed to replay entries")
+			return
+		}
+		for _, entry := range batch {
+			entryResp := toEntryResponse(entry, acc.Currency)
+			if err := send(streamEventID(entry), "entry", StreamMessage{Type: "entry", AccountID: accountID.String(), Entry: &entryResp}); err != nil {
+				return
+			}
+			replayedThrough = entry.ChainSeq
+		}
+		if len(batch) < sseReplayBatch {
+			break
+		}
+		afterSeq = replayedThrough
+	}
+
+	// Step 4: Switch to live delivery with periodic heartbeats.
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case update, open := <-sub.C:
+			if !open {
+				// Lagged: the client reconnects with Last-Event-ID and catches up via replay.
+				return
+			}
+			if update.Entry.ChainSeq <= replayedThrough {
+				// Already sent by the replay.
+				continue
+			}
+			entryResp := toEntryResponse(update.Entry, acc.Currency)
+			if err := send(streamEventID(update.Entry), "entry", StreamMessage{
+				Type:      "entry",
+				AccountID: accountID.String(),
+				Balance:   update.Balance,
+				Entry:     &entryResp,
+			}); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := rc.SetWriteDeadline(time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// streamEventID is an entry's event ID: its position in the account's chain.
+func streamEventID(entry sqlc.Entry) string {
+	return strconv.FormatInt(entry.ChainSeq, 10)
+}
+
+// resumePosition turns a Last-Event-ID into the chain position to replay after, or -1 to skip the
+// replay. Entry IDs, which earlier streams used as event IDs, resolve to their entry's position; one
+// no longer stored, such as an entry pruned after archiving, resumes with live delivery only.
+func (h *Handler) resumePosition(w http.ResponseWriter, r *http.Request, accountID uuid.UUID, lastID string) (int64, bool) {
+	if seq, err := strconv.ParseInt(lastID, 10, 64); err == nil {
+		if seq < 0 {
+			respondError(w, http.StatusBadRequest, "invalid Last-Event-ID")
+			return 0, false
+		}
+		return seq, true
+	}
+	entryID, err := uuid.Parse(lastID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid Last-Event-ID")
+		return 0, false
+	}
+	anchor, err := h.store.GetEntry(r.Context(), entryID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		log.Info().Str("account_id", accountID.String()).Str("last_event_id", lastID).Msg("Stream anchor no longer stored; resuming live")
+		return -1, true
+	case err != nil:
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to load stream anchor")
+		respondError(w, http.StatusInternalServerError, "failed to resume stream")
+		return 0, false
+	case anchor.AccountID != accountID:
+		respondError(w, http.StatusBadRequest, "Last-Event-ID does not belong to this account")
+		return 0, false
+	}
+	return anchor.ChainSeq, true
+}
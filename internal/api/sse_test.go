@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestResumePosition(t *testing.T) {
+	// Event IDs are chain positions and resolve without a lookup; anything else but an entry ID is refused.
+	h := &Handler{}
+	r := httptest.NewRequest(http.MethodGet, "/accounts/x/entries/stream", nil)
+
+	seq, ok := h.resumePosition(httptest.NewRecorder(), r, uuid.New(), "42")
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), seq)
+
+	for _, bad := range []string{"-1", "not-an-id"} {
+		rw := httptest.NewRecorder()
+		_, ok = h.resumePosition(rw, r, uuid.New(), bad)
+		assert.False(t, ok, bad)
+		assert.Equal(t, http.StatusBadRequest, rw.Code, bad)
+	}
+}
+
+func TestStreamEventID(t *testing.T) {
+	assert.Equal(t, "17", streamEventID(sqlc.Entry{ID: uuid.New(), ChainSeq: 17}))
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/lestrrat-go/jwx/v3/jwk"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/statement"
+)
+
+// maxSignedStatement bounds a statement posted for verification.
+const maxSignedStatement = 10 << 20
+
+// StatementSigner signs served statements with the bank's key and publishes the public keys
+// recipients verify them with.
+type StatementSigner struct {
+	key    jwk.Key
+	public jwk.Set
+}
+
+// NewStatementSigner signs with signingKeyPEM, an RSA (RS256) or Ed25519 (EdDSA) private key in
+// PEM form, and publishes its public half at /.well-known/statement-keys.json under its RFC 7638
+// thumbprint. verificationKeysPEM holds further PEM keys that are published but never sign, so
+// statements signed before a key rotation still verify.
+func NewStatementSigner(signingKeyPEM, verificationKeysPEM string) (*StatementSigner, error) {
+	keys, err := parsePEMKeys(signingKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("STATEMENT_SIGNING_KEY: %w", err)
+	}
+	if len(keys) != 1 {
+		return nil, errors.New("STATEMENT_SIGNING_KEY must hold exactly one key")
+	}
+	key, err := signingJWK(keys[0])
+	if err != nil {
+		return nil, fmt.Errorf("STATEMENT_SIGNING_KEY: %w", err)
+	}
+	s := &StatementSigner{key: key, public: jwk.NewSet()}
+	if err := s.addPublic(key); err != nil {
+		return nil, err
+	}
+	others, err := parsePEMKeys(verificationKeysPEM)
+	if err != nil {
+		return nil, fmt.Errorf("STATEMENT_VERIFICATION_KEYS: %w", err)
+	}
+	for _, raw := range others {
+		other, err := signingJWK(raw)
+		if err != nil {
+			return nil, fmt.Errorf("STATEMENT_VERIFICATION_KEYS: %w", err)
+		}
+		if err := s.addPublic(other); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// addPublic publishes the public half of key.
+func (s *StatementSigner) addPublic(key jwk.Key) error {
+	pub, err := key.PublicKey()
+	if err != nil {
+		return err
+	}
+	if err := pub.Set(jwk.KeyUsageKey, jwk.ForSignature); err != nil {
+		return err
+	}
+	if kid, _ := pub.KeyID(); kid != "" {
+		if _, ok := s.public.LookupKeyID(kid); ok {
+			return nil
+		}
+	}
+	return s.public.AddKey(pub)
+}
+
+// WithStatementSigner signs every camt.053 statement served with signer.
+func WithStatementSigner(signer *StatementSigner) Option {
+	return func(h *Handler) {
+		h.statementSigner = signer
+	}
+}
+
+// StatementKeys godoc
+// @Summary      Get the statement signing keys
+// @Description  Returns the public keys statements are signed with as a JSON Web Key Set. A signed camt.053 statement ends with a <?statement-signature jws="..."?> processing instruction holding a detached JWS (RFC 7515 appendix F) over every byte before it, naming its key in the kid header. Besides the active key the set holds keys retired by a rotation. Empty while statements are not signed.
+// @Tags         statements
+// @Produce      json
+// @Success      200  {object}  object{keys=[]object}
+// @Router       /.well-known/statement-keys.json [get]
+func (h *Handler) StatementKeys(w http.ResponseWriter, _ *http.Request) {
+	set := jwk.NewSet()
+	if h.statementSigner != nil {
+		set = h.statementSigner.public
+	}
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	respondJSON(w, http.StatusOK, set)
+}
+
+// VerifyStatement godoc
+// @Summary      Verify a signed statement
+// @Description  Checks a signed camt.053 statement, posted as the request body exactly as it was received, against the published statement keys. valid is false, with the reason, when the statement is unsigned or was changed after it was signed. No authentication, so landlords, embassies and auditors can check a statement they were handed.
+// @Tags         statements
+// @Accept       xml
+// @Produce      json
+// @Success      200  {object}  StatementVerificationResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      413  {object}  ErrorResponse
+// @Router       /statements/verify [post]
+func (h *Handler) VerifyStatement(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSignedStatement))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondError(w, http.StatusRequestEntityTooLarge, "statement is too large")
+			return
+		}
+		respondError(w, http.StatusBadRequest, "failed to read statement")
+		return
+	}
+	keys := jwk.NewSet()
+	if h.statementSigner != nil {
+		keys = h.statementSigner.public
+	}
+	_, kid, err := statement.VerifySigned(body, keys)
+	resp := StatementVerificationResponse{Valid: err == nil, KeyID: kid}
+	if err != nil {
+		resp.Reason = err.Error()
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/statement"
+)
+
+func TestVerifyStatement(t *testing.T) {
+	// A statement signed by the active key verifies, and stops verifying once a figure is edited.
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := NewStatementSigner(privatePEM(t, key), privatePEM(t, oldKey))
+	require.NoError(t, err)
+	h := &Handler{}
+	WithStatementSigner(signer)(h)
+
+	rw := httptest.NewRecorder()
+	h.StatementKeys(rw, httptest.NewRequest(http.MethodGet, "/.well-known/statement-keys.json", nil))
+	require.Equal(t, http.StatusOK, rw.Code)
+	set, err := jwk.Parse(rw.Body.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 2, set.Len())
+	assert.NotContains(t, rw.Body.String(), `"d"`)
+
+	signed, err := statement.Sign([]byte("<Document><Amt>10.00</Amt></Document>\n"), signer.key)
+	require.NoError(t, err)
+	verify := func(body []byte) StatementVerificationResponse {
+		rw := httptest.NewRecorder()
+		h.VerifyStatement(rw, httptest.NewRequest(http.MethodPost, "/statements/verify", bytes.NewReader(body)))
+		require.Equal(t, http.StatusOK, rw.Code)
+		var resp StatementVerificationResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+		return resp
+	}
+
+	resp := verify(signed)
+	kid, _ := signer.key.KeyID()
+	assert.True(t, resp.Valid)
+	assert.Equal(t, kid, resp.KeyID)
+
+	resp = verify(bytes.Replace(signed, []byte("10.00"), []byte("90.00"), 1))
+	assert.False(t, resp.Valid)
+	assert.Equal(t, statement.ErrBadSignature.Error(), resp.Reason)
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/statement"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// summaryLargest is how many of the month's largest transactions a summary lists.
+const summaryLargest = 5
+
+// GetAccountSummary godoc
+// @Summary      Monthly money in and money out
+// @Description  Aggregates one UTC calendar month of the account's entries: total credits, total debits, net change, the largest transactions by net effect on the account, and a day-by-day series including quiet days. The current month runs up to today. Defaults to the current month.
+// @Tags         statements
+// @Produce      json
+// @Param        id     path      string  true   "Account ID"
+// @Param        month  query     string  false  "Month (YYYY-MM, UTC)"
+// @Success      200    {object}  AccountSummaryResponse
+// @Failure      400    {object}  ErrorResponse
+// @Failure      401    {object}  ErrorResponse
+// @Failure      403    {object}  ErrorResponse
+// @Failure      404    {object}  ErrorResponse
+// @Failure      500    {object}  ErrorResponse
+// @Router       /accounts/{id}/summary [get]
+// @Security     Bearer
+func (h *Handler) GetAccountSummary(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and enforce visibility.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	acc, ok := h.visibleAccount(w, r, userID, accountID)
+	if !ok {
+		return
+	}
+
+	// Step 2: Resolve the month; days that have not started yet are left out.
+	now := time.Now().UTC()
+	month := now
+	if raw := r.URL.Query().Get("month"); raw != "" {
+		month, err = time.Parse(statement.PeriodLayout, raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "month must be YYYY-MM")
+			return
+		}
+	}
+	from, to := statement.MonthBounds(month)
+	if from.After(now) {
+		respondError(w, http.StatusBadRequest, "month must not be in the future")
+		return
+	}
+	if _, tomorrow := statement.DayBounds(now); to.After(tomorrow) {
+		to = tomorrow
+	}
+
+	// Step 3: Aggregate in the database.
+	days, err := h.store.DailyTotalsBetween(r.Context(), sqlc.DailyTotalsBetweenParams{
+		FromDay:   from,
+		ToDay:     to,
+		AccountID: accountID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to total account days")
+		respondError(w, http.StatusInternalServerError, "failed to build summary")
+		return
+	}
+	largest, err := h.store.LargestTransactionsBetween(r.Context(), sqlc.LargestTransactionsBetweenParams{
+		AccountID: accountID,
+		FromTime:  from,
+		ToTime:    to,
+		RowLimit:  summaryLargest,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Failed to list largest transactions")
+		respondError(w, http.StatusInternalServerError, "failed to build summary")
+		return
+	}
+
+	summary, err := buildAccountSummary(acc, from, days, largest)
+	if err != nil {
+		log.Error().Err(err).Str("account_id", accountID.String()).Msg("Invalid amounts in summary")
+		respondError(w, http.StatusInternalServerError, "failed to build summary")
+		return
+	}
+	respondJSON(w, http.StatusOK, summary)
+}
+
+// buildAccountSummary totals the daily rows and signs each large transaction by its net effect.
+func buildAccountSummary(acc sqlc.Account, month time.Time, days []sqlc.DailyTotalsBetweenRow, largest []sqlc.LargestTransactionsBetweenRow) (AccountSummaryResponse, error) {
+	resp := AccountSummaryResponse{
+		AccountID: acc.ID.String(),
+		Currency:  acc.Currency,
+		Month:     month.Format(statement.PeriodLayout),
+		Largest:   make([]SummaryTransactionResponse, 0, len(largest)),
+		Days:      make([]SummaryDayResponse, 0, len(days)),
+	}
+	credits, debits := decimal.Zero, decimal.Zero
+	for _, d := range days {
+		c, err := decimal.NewFromString(d.Credits)
+		if err != nil {
+			return AccountSummaryResponse{}, err
+		}
+		db, err := decimal.NewFromString(d.Debits)
+		if err != nil {
+			return AccountSummaryResponse{}, err
+		}
+		credits, debits = credits.Add(c), debits.Add(db)
+		resp.Days = append(resp.Days, SummaryDayResponse{
+			Date:       d.Day.Format("2006-01-02"),
+			Credits:    currencies.Format(acc.Currency, c),
+			Debits:     currencies.Format(acc.Currency, db),
+			NetChange:  currencies.Format(acc.Currency, c.Sub(db)),
+			EntryCount: d.EntryCount,
+		})
+	}
+	resp.TotalCredits = currencies.Format(acc.Currency, credits)
+	resp.TotalDebits = currencies.Format(acc.Currency, debits)
+	resp.NetChange = currencies.Format(acc.Currency, credits.Sub(debits))
+
+	for _, t := range largest {
+		c, err := decimal.NewFromString(t.Credits)
+		if err != nil {
+			return AccountSummaryResponse{}, err
+		}
+		db, err := decimal.NewFromString(t.Debits)
+		if err != nil {
+			return AccountSummaryResponse{}, err
+		}
+		resp.Largest = append(resp.Largest, SummaryTransactionResponse{
+			TransactionID: t.TransactionID.String(),
+			NetChange:     currencies.Format(acc.Currency, c.Sub(db)),
+			Description:   t.Description,
+			CreatedAt:     t.CreatedAt.UTC(),
+		})
+	}
+	return resp, nil
+}
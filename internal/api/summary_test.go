@@ -0,0 +1,44 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestBuildAccountSummary(t *testing.T) {
+	// Totals come from the daily series and large transactions are signed by their net effect, in
+	// the currency's decimal places.
+	acc := sqlc.Account{ID: uuid.New(), Currency: "USD"}
+	month := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	txID := uuid.New()
+	days := []sqlc.DailyTotalsBetweenRow{
+		{Day: month, Credits: "100.0000", Debits: "0.0000", EntryCount: 1},
+		{Day: month.AddDate(0, 0, 1), Credits: "0.0000", Debits: "0.0000"},
+		{Day: month.AddDate(0, 0, 2), Credits: "5.5000", Debits: "40.2500", EntryCount: 3},
+	}
+	largest := []sqlc.LargestTransactionsBetweenRow{
+		{TransactionID: txID, Credits: "0.0000", Debits: "40.2500", Description: "Transfer to shop", CreatedAt: month.AddDate(0, 0, 2)},
+	}
+
+	resp, err := buildAccountSummary(acc, month, days, largest)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-02", resp.Month)
+	assert.Equal(t, "105.50", resp.TotalCredits)
+	assert.Equal(t, "40.25", resp.TotalDebits)
+	assert.Equal(t, "65.25", resp.NetChange)
+	require.Len(t, resp.Days, 3)
+	assert.Equal(t, SummaryDayResponse{Date: "2026-02-02", Credits: "0.00", Debits: "0.00", NetChange: "0.00"}, resp.Days[1])
+	assert.Equal(t, "-34.75", resp.Days[2].NetChange)
+	require.Len(t, resp.Largest, 1)
+	assert.Equal(t, "-40.25", resp.Largest[0].NetChange)
+	assert.Equal(t, txID.String(), resp.Largest[0].TransactionID)
+
+	_, err = buildAccountSummary(acc, month, []sqlc.DailyTotalsBetweenRow{{Credits: "x", Debits: "0"}}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// sweepStatus maps sweep rule errors to an HTTP status.
+func sweepStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrSweepRuleNotFound), errors.Is(err, service.ErrAccountNotFound),
+		errors.Is(err, service.ErrCrossOrgTransfer):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidSweepRule), errors.Is(err, service.ErrCurrencyMismatch),
+		errors.Is(err, service.ErrAmountPrecision):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondSweepError writes err with its sweep status, hiding internal failures.
+func respondSweepError(w http.ResponseWriter, err error, msg string) {
+	status := sweepStatus(err)
+	if status == http.StatusInternalServerError {
+		log.Error().Err(err).Msg("Sweep rule operation failed")
+		respondError(w, status, msg)
+		return
+	}
+	respondLedgerError(w, status, err)
+}
+
+// CreateSweepRule godoc
+// @Summary      Add a sweep rule to an account
+// @Description  Moves whatever the account holds above threshold, less the transfer fee, to target_account_id every night (daily) or once a week (weekly) at midnight UTC, starting the coming midnight. A threshold of 0 sweeps the whole balance. Each sweep is screened and checked against the product's rules and transfer limits like a transfer. The target must be another account the caller owns, in the same currency; a run fails while the caller no longer owns both accounts. Each run is recorded in the rule's execution history: swept with the amount and transaction, skipped when the balance was at or below threshold, or failed with the reason. Threshold accepts JSON number or string.
+// @Tags         accounts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "Account ID"
+// @Param        body  body      object{target_account_id=string,threshold=string,frequency=string}  true  "Sweep rule"
+// @Success      201   {object}  SweepRuleResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/sweeps [post]
+// @Security     Bearer
+func (h *Handler) CreateSweepRule(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and decode payload.
+	userID, acc, ok := h.walletParam(w, r, AccountRoleOwner)
+	if !ok {
+		return
+	}
+	var input struct {
+		TargetAccountID string      `json:"target_account_id"`
+		Threshold       interface{} `json:"threshold"`
+		Frequency       string      `json:"frequency"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	targetID, err := uuid.Parse(input.TargetAccountID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid target_account_id")
+		return
+	}
+	threshold, err := normalizeAmountInput(input.Threshold)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid threshold")
+		return
+	}
+
+	// Step 2: Money may only be swept into an account the caller also owns.
+	if _, ok := h.ownedAccount(w, r, userID, targetID); !ok {
+		return
+	}
+
+	// Step 3: Record the rule.
+	rule, err := h.ledger.CreateSweepRule(r.Context(), service.SweepRuleRequest{
+		AccountID:       acc.ID,
+		TargetAccountID: targetID,
+		Threshold:       threshold,
+		Frequency:       input.Frequency,
+		CreatedBy:       userID,
+	})
+	if err != nil {
+		respondSweepError(w, err, "failed to create sweep rule")
+		return
+	}
+	respondJSON(w, http.StatusCreated, toSweepRuleResponse(rule, acc.Currency))
+}
+
+// ListSweepRules godoc
+// @Summary      List an account's sweep rules
+// @Description  Returns the account's sweep rules, oldest first, with when each runs next
+// @Tags         accounts
+// @Produce      json
+// @Param        id   path      string  true  "Account ID"
+// @Success      200  {array}   SweepRuleResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/sweeps [get]
+// @Security     Bearer
+func (h *Handler) ListSweepRules(w http.ResponseWriter, r *http.Request) {
+	_, acc, ok := h.walletParam(w, r, AccountRoleViewer)
+	if !ok {
+		return
+	}
+	rules, err := h.store.ListSweepRulesByAccount(r.Context(), acc.ID)
+	if err != nil {
+		log.Error().Err(err).Str("account_id", acc.ID.String()).Msg("Failed to list sweep rules")
+		respondError(w, http.StatusInternalServerError, "failed to list sweep rules")
+		return
+	}
+	resp := make([]SweepRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, toSweepRuleResponse(rule, acc.Currency))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// DeleteSweepRule godoc
+// @Summary      Remove a sweep rule
+// @Description  Stops the rule and discards its execution history. Money already swept stays in the target account.
+// @Tags         accounts
+// @Param        id      path  string  true  "Account ID"
+// @Param        ruleId  path  string  true  "Sweep rule ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/sweeps/{ruleId} [delete]
+// @Security     Bearer
+func (h *Handler) DeleteSweepRule(w http.ResponseWriter, r *http.Request) {
+	_, acc, ok := h.walletParam(w, r, AccountRoleOwner)
+	if !ok {
+		return
+	}
+	ruleID, ok := sweepRuleParam(w, r)
+	if !ok {
+		return
+	}
+	if err := h.ledger.DeleteSweepRule(r.Context(), acc.ID, ruleID); err != nil {
+		respondSweepError(w, err, "failed to delete sweep rule")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSweepExecutions godoc
+// @Summary      List a sweep rule's execution history
+// @Description  Returns a page of the rule's runs, newest first: swept with the amount and transaction_id, skipped when the balance was at or below threshold, or failed with the reason. Paged responses are wrapped in {data, page}.
+// @Tags         accounts
+// @Produce      json
+// @Param        id      path      string  true   "Account ID"
+// @Param        ruleId  path      string  true   "Sweep rule ID"
+// @Param        limit   query     int     false  "Limit (default 20, max 100)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {object}  PagedResponse{data=[]SweepExecutionResponse}
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      404     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /accounts/{id}/sweeps/{ruleId}/executions [get]
+// @Security     Bearer
+func (h *Handler) ListSweepExecutions(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and resolve the rule on their account.
+	_, acc, ok := h.walletParam(w, r, AccountRoleViewer)
+	if !ok {
+		return
+	}
+	ruleID, ok := sweepRuleParam(w, r)
+	if !ok {
+		return
+	}
+	rule, err := h.store.GetAccountSweepRule(r.Context(), sqlc.GetAccountSweepRuleParams{ID: ruleID, AccountID: acc.ID})
+	if errors.Is(err, sql.ErrNoRows) {
+		err = service.ErrSweepRuleNotFound
+	}
+	if err != nil {
+		respondSweepError(w, err, "failed to list sweep executions")
+		return
+	}
+
+	// Step 2: Load the page of runs.
+	limit, offset := parsePage(r)
+	rows, err := h.store.ListSweepExecutions(r.Context(), sqlc.ListSweepExecutionsParams{
+		RuleID:    rule.ID,
+		RowLimit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		RowOffset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Str("rule_id", rule.ID.String()).Msg("Failed to list sweep executions")
+		respondError(w, http.StatusInternalServerError, "failed to list sweep executions")
+		return
+	}
+	total, err := h.store.CountSweepExecutions(r.Context(), rule.ID)
+	if err != nil {
+		log.Error().Err(err).Str("rule_id", rule.ID.String()).Msg("Failed to count sweep executions")
+		respondError(w, http.StatusInternalServerError, "failed to list sweep executions")
+		return
+	}
+
+	resp := make([]SweepExecutionResponse, 0, len(rows))
+	for _, e := range rows {
+		resp = append(resp, toSweepExecutionResponse(e, acc.Currency))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// sweepRuleParam parses the {ruleId} path parameter.
+func sweepRuleParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	ruleID, err := uuid.Parse(chi.URLParam(r, "ruleId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid sweep rule ID")
+		return uuid.Nil, false
+	}
+	return ruleID, true
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestSweepStatus(t *testing.T) {
+	// Accounts of other tenants look missing; bad rules are client errors.
+	assert.Equal(t, http.StatusNotFound, sweepStatus(service.ErrSweepRuleNotFound))
+	assert.Equal(t, http.StatusNotFound, sweepStatus(service.ErrCrossOrgTransfer))
+	assert.Equal(t, http.StatusBadRequest, sweepStatus(fmt.Errorf("%w: bad", service.ErrInvalidSweepRule)))
+	assert.Equal(t, http.StatusBadRequest, sweepStatus(service.ErrCurrencyMismatch))
+	assert.Equal(t, http.StatusInternalServerError, sweepStatus(errors.New("boom")))
+}
+
+func TestToSweepExecutionResponse(t *testing.T) {
+	// Amounts follow the account's currency; only sweeps carry a transaction.
+	txID := uuid.New()
+	resp := toSweepExecutionResponse(sqlc.SweepExecution{
+		ID: uuid.New(), RuleID: uuid.New(), Status: service.SweepSwept, Amount: "25000.5000",
+		TransactionID: uuid.NullUUID{UUID: txID, Valid: true},
+	}, "NGN")
+	assert.Equal(t, "25000.50", resp.Amount)
+	assert.Equal(t, txID.String(), *resp.TransactionID)
+
+	resp = toSweepExecutionResponse(sqlc.SweepExecution{Status: service.SweepSkipped, Amount: "0.0000", Reason: "balance at or below threshold"}, "JPY")
+	assert.Equal(t, "0", resp.Amount)
+	assert.Nil(t, resp.TransactionID)
+	assert.Equal(t, "balance at or below threshold", resp.Reason)
+}
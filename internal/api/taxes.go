@@ -0,0 +1,195 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// SetTaxRule godoc
+// @Summary      Create or update a tax rule
+// @Description  Sets a tax withheld from customer credits of operation_type (currently interest) at rate_bps, e.g. code wht_interest at 1000 for 10% withholding tax on interest. Each active rule posts its share of the credit from the customer to the per-currency Tax Payable system account under the same transaction. Inactive rules stop withholding; the active rules of one operation cannot add up to more than 100%. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{code=string,name=string,operation_type=string,rate_bps=int,active=bool}  true  "Tax rule"
+// @Success      200   {object}  TaxRuleResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/tax-rules [put]
+// @Security     Bearer
+func (h *Handler) SetTaxRule(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and validate the rule.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		Active        *bool  `json:"active"`
+		Code          string `json:"code"`
+		Name          string `json:"name"`
+		OperationType string `json:"operation_type"`
+		RateBps       int32  `json:"rate_bps"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	code := strings.TrimSpace(input.Code)
+	if err := service.ValidateTaxRule(code, input.OperationType, input.RateBps); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > maxProfileField {
+		respondError(w, http.StatusBadRequest, "name required (at most 100 characters)")
+		return
+	}
+	// Rules are active unless switched off explicitly.
+	active := input.Active == nil || *input.Active
+
+	// Step 2: Persist, keeping the operation's active rules within the whole credit.
+	var rule sqlc.TaxRule
+	err := h.store.ExecTx(r.Context(), func(q *sqlc.Queries) error {
+		var err error
+		rule, err = q.UpsertTaxRule(r.Context(), sqlc.UpsertTaxRuleParams{
+			Code:          code,
+			Name:          name,
+			OperationType: input.OperationType,
+			RateBps:       input.RateBps,
+			Active:        active,
+			UpdatedBy:     userID,
+		})
+		if err != nil {
+			return err
+		}
+		rules, err := q.ListActiveTaxRules(r.Context(), input.OperationType)
+		if err != nil {
+			return err
+		}
+		var total int32
+		for _, other := range rules {
+			total += other.RateBps
+		}
+		if total > maxInterestRateBps {
+			return service.ErrInvalidTaxRule
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTaxRule) {
+			respondError(w, http.StatusBadRequest, "active tax rules of an operation cannot withhold more than 100%")
+			return
+		}
+		log.Error().Err(err).Str("code", code).Msg("Failed to set tax rule")
+		respondError(w, http.StatusInternalServerError, "failed to set tax rule")
+		return
+	}
+
+	log.Info().Str("code", code).Str("operation_type", rule.OperationType).Int32("rate_bps", rule.RateBps).Bool("active", rule.Active).Str("set_by", userID.String()).Msg("Tax rule set")
+	respondJSON(w, http.StatusOK, toTaxRuleResponse(rule))
+}
+
+// ListTaxRules godoc
+// @Summary      List tax rules
+// @Description  Returns every tax rule, active or not. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   TaxRuleResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/tax-rules [get]
+// @Security     Bearer
+func (h *Handler) ListTaxRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.store.ListTaxRules(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list tax rules")
+		respondError(w, http.StatusInternalServerError, "failed to list tax rules")
+		return
+	}
+	resp := make([]TaxRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, toTaxRuleResponse(rule))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetTaxReport godoc
+// @Summary      Report withheld taxes
+// @Description  Totals what each tax rule withheld between from and to (inclusive UTC dates, default the current month to date), per currency: how many credits were taxed, their gross amount and the tax posted to Tax Payable. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        from  query     string  false  "First day (YYYY-MM-DD)"
+// @Param        to    query     string  false  "Last day (YYYY-MM-DD)"
+// @Success      200   {object}  TaxReportResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/tax-report [get]
+// @Security     Bearer
+func (h *Handler) GetTaxReport(w http.ResponseWriter, r *http.Request) {
+	from, to, err := reportRange(r, time.Now().UTC())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	rows, err := h.store.TaxReport(r.Context(), sqlc.TaxReportParams{FromTime: from, ToTime: to})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to build tax report")
+		respondError(w, http.StatusInternalServerError, "failed to build tax report")
+		return
+	}
+
+	resp := TaxReportResponse{
+		From:  from.Format("2006-01-02"),
+		To:    to.AddDate(0, 0, -1).Format("2006-01-02"),
+		Lines: make([]TaxReportLineResponse, 0, len(rows)),
+	}
+	for _, row := range rows {
+		resp.Lines = append(resp.Lines, TaxReportLineResponse{
+			RuleCode:   row.RuleCode,
+			Name:       row.Name,
+			Currency:   row.Currency,
+			Postings:   row.Postings,
+			BaseAmount: row.BaseAmount,
+			Amount:     row.Amount,
+		})
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// reportRange reads the inclusive from and to dates of a report and returns them as a
+// half-open range of UTC instants. It defaults to the current month up to today.
+func reportRange(r *http.Request, now time.Time) (time.Time, time.Time, error) {
+	q := r.URL.Query()
+	last := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if raw := q.Get("to"); raw != "" {
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to must be YYYY-MM-DD")
+		}
+		last = t
+	}
+	from := time.Date(last.Year(), last.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if raw := q.Get("from"); raw != "" {
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from must be YYYY-MM-DD")
+		}
+		from = t
+	}
+	to := last.AddDate(0, 0, 1)
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	return from, to, nil
+}
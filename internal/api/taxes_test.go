@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportRange(t *testing.T) {
+	// The report defaults to this month so far; to is inclusive.
+	now := time.Date(2026, 3, 17, 15, 4, 0, 0, time.UTC)
+
+	from, to, err := reportRange(httptest.NewRequest("GET", "/admin/tax-report", nil), now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC), to)
+
+	from, to, err = reportRange(httptest.NewRequest("GET", "/admin/tax-report?from=2025-01-01&to=2025-12-31", nil), now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), to)
+
+	_, _, err = reportRange(httptest.NewRequest("GET", "/admin/tax-report?from=2026-04-01", nil), now)
+	assert.Error(t, err)
+	_, _, err = reportRange(httptest.NewRequest("GET", "/admin/tax-report?to=March", nil), now)
+	assert.Error(t, err)
+}
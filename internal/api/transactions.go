@@ -0,0 +1,359 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// transactionVisible reports whether the caller can see at least one customer leg of a transaction.
+// Unowned (system) legs never authorize.
+func (h *Handler) transactionVisible(ctx context.Context, userID uuid.UUID, entries []sqlc.Entry) (bool, error) {
+	for _, entry := range entries {
+		acc, err := h.store.GetAccount(ctx, entry.AccountID)
+		if err != nil {
+			return false, err
+		}
+		if acc.OwnerID.Valid && h.hasAccountRole(ctx, userID, acc, AccountRoleViewer) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// entryCurrencies maps the accounts of entries to their currencies, for formatting amounts. An
+// account that fails to load is left out and its entries keep their stored scale.
+func (h *Handler) entryCurrencies(ctx context.Context, entries []sqlc.Entry) map[uuid.UUID]string {
+	currencyOf := make(map[uuid.UUID]string, len(entries))
+	for _, entry := range entries {
+		if _, seen := currencyOf[entry.AccountID]; seen {
+			continue
+		}
+		acc, err := h.store.GetAccount(ctx, entry.AccountID)
+		if err != nil {
+			log.Warn().Err(err).Str("account_id", entry.AccountID.String()).Msg("Failed to load entry currency")
+			continue
+		}
+		currencyOf[entry.AccountID] = acc.Currency
+	}
+	return currencyOf
+}
+
+// GetTransactionStatus godoc
+// @Summary      Get transaction status
+// @Description  Returns where a transaction is in its lifecycle: pending while an external rail decides, then posted or failed; posted transactions may later be reversed
+// @Tags         accounts
+// @Produce      json
+// @Param        id   path      string  true  "Transaction ID"
+// @Success      200  {object}  TransactionResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /transactions/{id}/status [get]
+// @Security     Bearer
+func (h *Handler) GetTransactionStatus(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and parse transaction ID.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	transactionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+
+	// Step 2: Load the transaction and its entries.
+	tx, err := h.store.GetTransaction(r.Context(), transactionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "transaction not found")
+			return
+		}
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to fetch transaction")
+		respondError(w, http.StatusInternalServerError, "failed to fetch transaction")
+		return
+	}
+	entries, err := h.store.ListEntriesByTransaction(r.Context(), transactionID)
+	if err != nil {
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to fetch transaction")
+		respondError(w, http.StatusInternalServerError, "failed to fetch transaction")
+		return
+	}
+
+	// Step 3: Same visibility rule as the entries view.
+	authorized, err := h.transactionVisible(r.Context(), userID, entries)
+	if err != nil {
+		log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to authorize transaction")
+		respondError(w, http.StatusInternalServerError, "failed to authorize transaction")
+		return
+	}
+	if !authorized {
+		respondError(w, http.StatusForbidden, "access denied")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toTransactionResponse(tx))
+}
+
+// ListTransactions godoc
+// @Summary      List transactions by status or request
+// @Description  Returns a page of the transactions in one status, oldest first, wrapped in {data, page}. The default status "pending" lists rail operations still awaiting an outcome. With request_id it instead lists every transaction created by that request (the X-Request-Id a user quotes), whatever its status. Operations scored for fraud carry risk: the score, the signals behind it and the decision (challenge means the customer stepped up). Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        status      query     string  false  "pending (default), posted, failed or reversed"
+// @Param        request_id  query     string  false  "X-Request-Id of the creating request"
+// @Param        limit       query     int     false  "Limit (default 20, max 100)"
+// @Param        offset      query     int     false  "Offset (default 0)"
+// @Param        envelope    query     bool    false  "Wrap in {data, page} (default per server)"
+// @Success      200         {object}  PagedResponse{data=[]TransactionResponse}
+// @Failure      400         {object}  ErrorResponse
+// @Failure      401         {object}  ErrorResponse
+// @Failure      403         {object}  ErrorResponse
+// @Failure      500         {object}  ErrorResponse
+// @Router       /admin/transactions [get]
+// @Security     Bearer
+func (h *Handler) ListTransactions(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse filters with safe defaults and caps.
+	requestID := strings.TrimSpace(r.URL.Query().Get("request_id"))
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = service.TransactionPending
+	case service.TransactionPending, service.TransactionPosted, service.TransactionFailed, service.TransactionReversed:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be pending, posted, failed or reversed")
+		return
+	}
+	limit, offset := parsePage(r)
+
+	// Step 2: Fetch the page and the size of the whole list.
+	var (
+		rows  []sqlc.Transaction
+		total int64
+		err   error
+	)
+	if requestID != "" {
+		id := sql.NullString{String: requestID, Valid: true}
+		rows, err = h.store.ListTransactionsByRequestID(r.Context(), sqlc.ListTransactionsByRequestIDParams{
+			RequestID: id,
+			Limit:     int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+			Offset:    int32(offset), // #nosec G115 -- bounded by parsePage
+		})
+		if err == nil {
+			total, err = h.store.CountTransactionsByRequestID(r.Context(), id)
+		}
+	} else {
+		rows, err = h.store.ListTransactionsByStatus(r.Context(), sqlc.ListTransactionsByStatusParams{
+			Status: status,
+			Limit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+			Offset: int32(offset), // #nosec G115 -- bounded by parsePage
+		})
+		if err == nil {
+			total, err = h.store.CountTransactionsByStatus(r.Context(), status)
+		}
+	}
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Str("request_id", requestID).Msg("Failed to list transactions")
+		respondError(w, http.StatusInternalServerError, "failed to list transactions")
+		return
+	}
+
+	resp := make([]TransactionResponse, 0, len(rows))
+	for _, tx := range rows {
+		resp = append(resp, toAdminTransactionResponse(tx))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// reversalStatus maps reversal and refund errors to an HTTP status.
+func reversalStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTransactionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrTransactionNotReversible), errors.Is(err, service.ErrTransactionNotRefundable):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrRefundExceedsRemaining):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrInvalidRefundAmount), errors.Is(err, service.ErrAmountPrecision):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ReverseTransaction godoc
+// @Summary      Reverse a transaction
+// @Description  Undoes a posted transfer, deposit or withdrawal with one reversal posting that mirrors its entries, and marks it reversed. Fees charged with it are refunded or kept according to the FEE_REVERSAL_POLICY setting (refund by default); refund_fees overrides it for this reversal. Fails when an account the transaction credited no longer holds the money, and for disputed transactions and bank payouts, which have their own flows. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                               true  "Transaction ID"
+// @Param        body  body      object{reason=string,refund_fees=bool}  true  "Reason and fee override"
+// @Success      201   {object}  ReversalResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/transactions/{id}/reverse [post]
+// @Security     Bearer
+func (h *Handler) ReverseTransaction(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and decode payload.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	transactionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+	var input struct {
+		RefundFees *bool  `json:"refund_fees"`
+		Reason     string `json:"reason"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" || len(reason) > 500 {
+		respondError(w, http.StatusBadRequest, "reason required (at most 500 characters)")
+		return
+	}
+
+	// Step 2: Reverse the transaction and, per policy, its fees.
+	reversal, err := h.ledger.ReverseTransaction(r.Context(), service.ReversalRequest{
+		TransactionID: transactionID,
+		ReversedBy:    userID,
+		Reason:        reason,
+		RefundFees:    input.RefundFees,
+	})
+	if err != nil {
+		status := reversalStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to reverse transaction")
+			respondError(w, status, "failed to reverse transaction")
+			return
+		}
+		respondLedgerError(w, status, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toReversalResponse(reversal))
+}
+
+// RefundTransaction godoc
+// @Summary      Refund part of a transaction
+// @Description  Returns amount of a posted two-leg transfer, deposit or withdrawal with one reversal posting from the account it credited to the account it debited, linked to the original. Refunds of a transaction may add up to its amount, fees excluded, which are never refunded this way; 422 when this one would go past it. The refund that reaches the full amount marks the original reversed. A partly refunded transaction can no longer be reversed or disputed. Fails when the credited account no longer holds the amount, and for disputed transactions and bank payouts. Admin only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                             true  "Transaction ID"
+// @Param        body  body      object{amount=string,reason=string}  true  "Amount and reason"
+// @Success      201   {object}  RefundSummaryResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      422   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /admin/transactions/{id}/refunds [post]
+// @Security     Bearer
+func (h *Handler) RefundTransaction(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and decode payload.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	transactionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+	var input struct {
+		Amount interface{} `json:"amount"`
+		Reason string      `json:"reason"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	amount, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" || len(reason) > 500 {
+		respondError(w, http.StatusBadRequest, "reason required (at most 500 characters)")
+		return
+	}
+
+	// Step 2: Refund the amount, within what is left of the original.
+	summary, err := h.ledger.RefundTransaction(r.Context(), service.RefundRequest{
+		TransactionID: transactionID,
+		Amount:        amount,
+		RefundedBy:    userID,
+		Reason:        reason,
+	})
+	if err != nil {
+		status := reversalStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to refund transaction")
+			respondError(w, status, "failed to refund transaction")
+			return
+		}
+		respondLedgerError(w, status, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toRefundSummaryResponse(summary))
+}
+
+// ListTransactionRefunds godoc
+// @Summary      List a transaction's partial refunds
+// @Description  Returns a transfer, deposit or withdrawal's amount, fees excluded, the partial refunds made against it, oldest first, and how much is refunded and left to refund. Admin only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Transaction ID"
+// @Success      200  {object}  RefundSummaryResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/transactions/{id}/refunds [get]
+// @Security     Bearer
+func (h *Handler) ListTransactionRefunds(w http.ResponseWriter, r *http.Request) {
+	transactionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+	summary, err := h.ledger.TransactionRefunds(r.Context(), transactionID)
+	if err != nil {
+		status := reversalStatus(err)
+		if status == http.StatusInternalServerError {
+			log.Error().Err(err).Str("transaction_id", transactionID.String()).Msg("Failed to list transaction refunds")
+			respondError(w, status, "failed to list transaction refunds")
+			return
+		}
+		respondLedgerError(w, status, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, toRefundSummaryResponse(summary))
+}
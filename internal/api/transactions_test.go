@@ -0,0 +1,44 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestReversalStatus(t *testing.T) {
+	// Transactions outside the reversal path conflict; a spent credit is a client error.
+	assert.Equal(t, http.StatusNotFound, reversalStatus(service.ErrTransactionNotFound))
+	assert.Equal(t, http.StatusConflict, reversalStatus(service.ErrTransactionNotReversible))
+	assert.Equal(t, http.StatusBadRequest, reversalStatus(service.ErrInsufficientFunds))
+	assert.Equal(t, http.StatusConflict, reversalStatus(service.ErrTransactionNotRefundable))
+	assert.Equal(t, http.StatusUnprocessableEntity, reversalStatus(service.ErrRefundExceedsRemaining))
+	assert.Equal(t, http.StatusBadRequest, reversalStatus(service.ErrInvalidRefundAmount))
+	assert.Equal(t, http.StatusInternalServerError, reversalStatus(errors.New("boom")))
+}
+
+func TestToAdminTransactionResponse(t *testing.T) {
+	// Admins see the risk assessment of scored operations; customers and unscored ones carry none.
+	tx := sqlc.Transaction{
+		ID:           uuid.New(),
+		RiskScore:    sql.NullInt16{Int16: 60, Valid: true},
+		RiskDecision: sql.NullString{String: "challenge", Valid: true},
+		RiskFactors:  []string{"new_beneficiary", "velocity"},
+	}
+	resp := toAdminTransactionResponse(tx)
+	require.NotNil(t, resp.Risk)
+	assert.Equal(t, int16(60), resp.Risk.Score)
+	assert.Equal(t, "challenge", resp.Risk.Decision)
+	assert.Nil(t, toTransactionResponse(tx).Risk)
+
+	tx.RiskScore = sql.NullInt16{}
+	assert.Nil(t, toAdminTransactionResponse(tx).Risk)
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+// enqueueStatus maps errors from queuing an async transfer to an HTTP status.
+func enqueueStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAccountNotFound), errors.Is(err, service.ErrCrossOrgTransfer):
+		// Accounts of other tenants are indistinguishable from missing ones.
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountPrecision), errors.Is(err, service.ErrSameAccountTransfer),
+		errors.Is(err, service.ErrCurrencyMismatch):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// enqueueTransfer queues an authorized transfer and answers 202 with the job to poll.
+func (h *Handler) enqueueTransfer(w http.ResponseWriter, r *http.Request, userID, fromID, toID uuid.UUID, amount string) {
+	if h.transferWorkers == nil {
+		respondError(w, http.StatusServiceUnavailable, "async transfers are not enabled")
+		return
+	}
+
+	job, err := h.ledger.EnqueueTransfer(r.Context(), fromID, toID, userID, amount)
+	if respondScreening(w, err) {
+		return
+	}
+	if err != nil {
+		status := enqueueStatus(err)
+		switch {
+		case status == http.StatusInternalServerError:
+			log.Error().Err(err).Str("from_id", fromID.String()).Str("to_id", toID.String()).Msg("Failed to queue transfer")
+			respondError(w, status, "failed to queue transfer")
+		case status == http.StatusNotFound:
+			respondError(w, status, "to account not found")
+		default:
+			respondError(w, status, err.Error())
+		}
+		return
+	}
+	h.transferWorkers.Notify()
+
+	w.Header().Set("Location", "/transfers/"+job.ID.String())
+	respondJSON(w, http.StatusAccepted, toTransferJobResponse(job))
+}
+
+// GetTransferJob godoc
+// @Summary      Poll an async transfer
+// @Description  Returns the status of a transfer queued with POST /transfers?async=true: pending until a worker runs it, then posted or failed with a reason. The ID is also the ledger transaction ID once posted.
+// @Tags         accounts
+// @Produce      json
+// @Param        id   path      string  true  "Transaction ID returned by the 202 response"
+// @Success      200  {object}  TransferJobResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /transfers/{id} [get]
+// @Security     Bearer
+func (h *Handler) GetTransferJob(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	jobID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid transfer ID")
+		return
+	}
+
+	job, err := h.store.GetTransferJob(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "transfer not found")
+			return
+		}
+		log.Error().Err(err).Str("transfer_id", jobID.String()).Msg("Failed to fetch transfer job")
+		respondError(w, http.StatusInternalServerError, "failed to fetch transfer")
+		return
+	}
+
+	// The requester and anyone who can see the source account may poll; others get 404.
+	if job.RequestedBy != userID {
+		fromAcc, err := h.store.GetAccount(r.Context(), job.FromAccountID)
+		if err != nil || !h.hasAccountRole(r.Context(), userID, fromAcc, AccountRoleViewer) {
+			respondError(w, http.StatusNotFound, "transfer not found")
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, toTransferJobResponse(job))
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+func TestEnqueueStatus(t *testing.T) {
+	// Other tenants' accounts look missing; invalid requests are 400 before anything is queued.
+	assert.Equal(t, http.StatusNotFound, enqueueStatus(service.ErrCrossOrgTransfer))
+	assert.Equal(t, http.StatusNotFound, enqueueStatus(service.ErrAccountNotFound))
+	assert.Equal(t, http.StatusBadRequest, enqueueStatus(service.ErrSameAccountTransfer))
+	assert.Equal(t, http.StatusBadRequest, enqueueStatus(service.ErrCurrencyMismatch))
+	assert.Equal(t, http.StatusInternalServerError, enqueueStatus(errors.New("connection reset")))
+}
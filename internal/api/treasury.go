@@ -0,0 +1,411 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/treasury"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// treasuryStatus maps cash pool errors to an HTTP status.
+func treasuryStatus(err error) int {
+	switch {
+	case errors.Is(err, treasury.ErrPoolNotFound), errors.Is(err, treasury.ErrMemberNotFound),
+		errors.Is(err, service.ErrAccountNotFound), errors.Is(err, service.ErrCrossOrgTransfer):
+		// Accounts of other tenants are indistinguishable from missing ones.
+		return http.StatusNotFound
+	case errors.Is(err, treasury.ErrAlreadyPooled):
+		return http.StatusConflict
+	case errors.Is(err, treasury.ErrMemberNotOwned), errors.Is(err, service.ErrKYCRequired), errors.Is(err, service.ErrKYCLimitExceeded):
+		return http.StatusForbidden
+	case errors.Is(err, treasury.ErrInvalidPool), errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrMinimumBalance),
+		errors.Is(err, service.ErrDebitLimitExceeded), errors.Is(err, service.ErrOperationNotAllowed), errors.Is(err, service.ErrSavingsGoalLocked),
+		errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountPrecision), errors.Is(err, service.ErrCurrencyMismatch):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondTreasuryError writes err with its cash pool status, hiding internal failures.
+func respondTreasuryError(w http.ResponseWriter, err error, msg string) {
+	status := treasuryStatus(err)
+	if status == http.StatusInternalServerError {
+		log.Error().Err(err).Msg("Cash pool operation failed")
+		respondError(w, status, msg)
+		return
+	}
+	respondLedgerError(w, status, err)
+}
+
+// CreateCashPool godoc
+// @Summary      Create a cash pool
+// @Description  Makes master_account_id the master of a new cash pool. Member accounts added to the pool are zeroed into the master at end of day (23:55 UTC): a member's balance above its target moves up to the master and a shortfall below it is covered from the master. Members can also be funded from the master on demand. An account belongs to at most one pool, as master or member (409 otherwise).
+// @Tags         treasury
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{master_account_id=string,name=string}  true  "Pool"
+// @Success      201   {object}  CashPoolResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Failure      503   {object}  ErrorResponse
+// @Router       /treasury/pools [post]
+// @Security     Bearer
+func (h *Handler) CreateCashPool(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and decode payload.
+	if !h.treasuryEnabled(w) {
+		return
+	}
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		MasterAccountID string `json:"master_account_id"`
+		Name            string `json:"name"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	masterID, err := uuid.Parse(input.MasterAccountID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid master_account_id")
+		return
+	}
+
+	// Step 2: Only the master's owner may pool it.
+	master, ok := h.ownedAccount(w, r, userID, masterID)
+	if !ok {
+		return
+	}
+
+	// Step 3: Create the pool.
+	pool, err := h.treasury.CreatePool(r.Context(), master.ID, input.Name, userID)
+	if err != nil {
+		respondTreasuryError(w, err, "failed to create cash pool")
+		return
+	}
+	respondJSON(w, http.StatusCreated, toCashPoolResponse(pool, master.Currency))
+}
+
+// ListCashPools godoc
+// @Summary      List cash pools
+// @Description  Returns the pools whose master account the caller owns or can view, oldest first
+// @Tags         treasury
+// @Produce      json
+// @Success      200  {array}   CashPoolResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Failure      503  {object}  ErrorResponse
+// @Router       /treasury/pools [get]
+// @Security     Bearer
+func (h *Handler) ListCashPools(w http.ResponseWriter, r *http.Request) {
+	if !h.treasuryEnabled(w) {
+		return
+	}
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	pools, err := h.store.ListCashPoolsForUser(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list cash pools")
+		respondError(w, http.StatusInternalServerError, "failed to list cash pools")
+		return
+	}
+	resp := make([]CashPoolResponse, 0, len(pools))
+	for _, pool := range pools {
+		resp = append(resp, toCashPoolResponse(pool, ""))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetCashPool godoc
+// @Summary      Get a cash pool
+// @Description  Returns the pool with its master's balance and each member's balance and target
+// @Tags         treasury
+// @Produce      json
+// @Param        id   path      string  true  "Cash pool ID"
+// @Success      200  {object}  CashPoolResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Failure      503  {object}  ErrorResponse
+// @Router       /treasury/pools/{id} [get]
+// @Security     Bearer
+func (h *Handler) GetCashPool(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and resolve the pool through its master.
+	_, pool, master, ok := h.cashPoolParam(w, r, AccountRoleViewer)
+	if !ok {
+		return
+	}
+
+	// Step 2: Load the members with their current balances.
+	members, err := h.store.ListCashPoolMembers(r.Context(), pool.ID)
+	if err != nil {
+		log.Error().Err(err).Str("pool_id", pool.ID.String()).Msg("Failed to list cash pool members")
+		respondError(w, http.StatusInternalServerError, "failed to get cash pool")
+		return
+	}
+	resp := toCashPoolResponse(pool, master.Currency)
+	resp.MasterBalance = currencies.FormatString(master.Currency, master.Balance)
+	resp.Members = make([]CashPoolMemberResponse, 0, len(members))
+	for _, m := range members {
+		acc, err := h.store.GetAccount(r.Context(), m.AccountID)
+		if err != nil {
+			log.Error().Err(err).Str("account_id", m.AccountID.String()).Msg("Failed to load cash pool member")
+			respondError(w, http.StatusInternalServerError, "failed to get cash pool")
+			return
+		}
+		resp.Members = append(resp.Members, toCashPoolMemberResponse(m, acc))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// AddCashPoolMember godoc
+// @Summary      Add an account to a cash pool
+// @Description  Adds an account the caller owns, in the master's currency, to the pool. Its balance is moved only while the caller still owns both it and the master. At end of day the member is brought back to target_balance (default 0) against the master. Target accepts JSON number or string.
+// @Tags         treasury
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "Cash pool ID"
+// @Param        body  body      object{account_id=string,target_balance=string}  true  "Member"
+// @Success      201   {object}  CashPoolMemberResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Failure      503   {object}  ErrorResponse
+// @Router       /treasury/pools/{id}/members [post]
+// @Security     Bearer
+func (h *Handler) AddCashPoolMember(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and decode payload.
+	userID, pool, _, ok := h.cashPoolParam(w, r, AccountRoleOwner)
+	if !ok {
+		return
+	}
+	var input struct {
+		AccountID     string      `json:"account_id"`
+		TargetBalance interface{} `json:"target_balance"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	accountID, err := uuid.Parse(input.AccountID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account_id")
+		return
+	}
+	target := ""
+	if input.TargetBalance != nil {
+		if target, err = normalizeAmountInput(input.TargetBalance); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid target_balance")
+			return
+		}
+	}
+
+	// Step 2: Money is zeroed out of members, so the caller must own them too.
+	acc, ok := h.ownedAccount(w, r, userID, accountID)
+	if !ok {
+		return
+	}
+
+	// Step 3: Add the member.
+	m, err := h.treasury.AddMember(r.Context(), treasury.MemberRequest{
+		PoolID:        pool.ID,
+		AccountID:     acc.ID,
+		TargetBalance: target,
+		AddedBy:       userID,
+	})
+	if err != nil {
+		respondTreasuryError(w, err, "failed to add cash pool member")
+		return
+	}
+	respondJSON(w, http.StatusCreated, toCashPoolMemberResponse(m, acc))
+}
+
+// RemoveCashPoolMember godoc
+// @Summary      Remove an account from a cash pool
+// @Description  Stops zeroing the account into the master. Its balance and the pool's past movements stay as they are.
+// @Tags         treasury
+// @Param        id         path  string  true  "Cash pool ID"
+// @Param        accountId  path  string  true  "Member account ID"
+// @Success      204
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Failure      503  {object}  ErrorResponse
+// @Router       /treasury/pools/{id}/members/{accountId} [delete]
+// @Security     Bearer
+func (h *Handler) RemoveCashPoolMember(w http.ResponseWriter, r *http.Request) {
+	_, pool, _, ok := h.cashPoolParam(w, r, AccountRoleOwner)
+	if !ok {
+		return
+	}
+	accountID, err := uuid.Parse(chi.URLParam(r, "accountId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if err := h.treasury.RemoveMember(r.Context(), pool.ID, accountID); err != nil {
+		respondTreasuryError(w, err, "failed to remove cash pool member")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// FundCashPoolMember godoc
+// @Summary      Fund a pool member from the master
+// @Description  Moves amount from the pool's master to one of its members now, rather than waiting for the end-of-day cover. The funding is screened and checked against the master's product rules and transfer limits like a transfer. Amount accepts JSON number or string.
+// @Tags         treasury
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "Cash pool ID"
+// @Param        body  body      object{account_id=string,amount=string}  true  "Funding"
+// @Success      201   {object}  CashPoolMovementResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Failure      503   {object}  ErrorResponse
+// @Router       /treasury/pools/{id}/fundings [post]
+// @Security     Bearer
+func (h *Handler) FundCashPoolMember(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and decode payload.
+	userID, pool, master, ok := h.cashPoolParam(w, r, AccountRoleOwner)
+	if !ok {
+		return
+	}
+	var input struct {
+		AccountID string      `json:"account_id"`
+		Amount    interface{} `json:"amount"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	accountID, err := uuid.Parse(input.AccountID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account_id")
+		return
+	}
+	amount, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid amount")
+		return
+	}
+
+	// Step 2: Post the funding.
+	movement, err := h.treasury.Fund(r.Context(), pool.ID, accountID, amount, userID)
+	if respondScreening(w, err) {
+		return
+	}
+	if err != nil {
+		respondTreasuryError(w, err, "failed to fund cash pool member")
+		return
+	}
+	respondJSON(w, http.StatusCreated, toCashPoolMovementResponse(movement, master.Currency))
+}
+
+// ListCashPoolMovements godoc
+// @Summary      List a cash pool's movements
+// @Description  Returns a page of the pool's postings, newest first: sweep (member to master at end of day), cover (master to member at end of day) or fund (master to member on request). Paged responses are wrapped in {data, page}.
+// @Tags         treasury
+// @Produce      json
+// @Param        id      path      string  true   "Cash pool ID"
+// @Param        limit   query     int     false  "Limit (default 20, max 100)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {object}  PagedResponse{data=[]CashPoolMovementResponse}
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      404     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Failure      503     {object}  ErrorResponse
+// @Router       /treasury/pools/{id}/movements [get]
+// @Security     Bearer
+func (h *Handler) ListCashPoolMovements(w http.ResponseWriter, r *http.Request) {
+	_, pool, master, ok := h.cashPoolParam(w, r, AccountRoleViewer)
+	if !ok {
+		return
+	}
+	limit, offset := parsePage(r)
+	rows, err := h.store.ListCashPoolMovements(r.Context(), sqlc.ListCashPoolMovementsParams{
+		PoolID:    pool.ID,
+		RowLimit:  int32(limit),  // #nosec G115 -- capped at 100 by parsePage
+		RowOffset: int32(offset), // #nosec G115 -- bounded by parsePage
+	})
+	if err != nil {
+		log.Error().Err(err).Str("pool_id", pool.ID.String()).Msg("Failed to list cash pool movements")
+		respondError(w, http.StatusInternalServerError, "failed to list cash pool movements")
+		return
+	}
+	total, err := h.store.CountCashPoolMovements(r.Context(), pool.ID)
+	if err != nil {
+		log.Error().Err(err).Str("pool_id", pool.ID.String()).Msg("Failed to count cash pool movements")
+		respondError(w, http.StatusInternalServerError, "failed to list cash pool movements")
+		return
+	}
+
+	resp := make([]CashPoolMovementResponse, 0, len(rows))
+	for _, m := range rows {
+		resp = append(resp, toCashPoolMovementResponse(m, master.Currency))
+	}
+	h.respondPage(w, r, resp, limit, offset, total)
+}
+
+// treasuryEnabled answers 503 when cash pools are not configured.
+func (h *Handler) treasuryEnabled(w http.ResponseWriter) bool {
+	if h.treasury == nil {
+		respondError(w, http.StatusServiceUnavailable, "cash pooling is not configured")
+		return false
+	}
+	return true
+}
+
+// cashPoolParam resolves the {id} pool for the caller, who needs role on its master account.
+func (h *Handler) cashPoolParam(w http.ResponseWriter, r *http.Request, role string) (uuid.UUID, sqlc.CashPool, sqlc.Account, bool) {
+	if !h.treasuryEnabled(w) {
+		return uuid.Nil, sqlc.CashPool{}, sqlc.Account{}, false
+	}
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return uuid.Nil, sqlc.CashPool{}, sqlc.Account{}, false
+	}
+	poolID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid cash pool ID")
+		return uuid.Nil, sqlc.CashPool{}, sqlc.Account{}, false
+	}
+	pool, err := h.store.GetCashPool(r.Context(), poolID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = treasury.ErrPoolNotFound
+	}
+	if err != nil {
+		respondTreasuryError(w, err, "failed to get cash pool")
+		return uuid.Nil, sqlc.CashPool{}, sqlc.Account{}, false
+	}
+	master, ok := h.accountWithRole(w, r, userID, pool.MasterAccountID, role)
+	if !ok {
+		return uuid.Nil, sqlc.CashPool{}, sqlc.Account{}, false
+	}
+	return userID, pool, master, true
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/treasury"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestTreasuryStatus(t *testing.T) {
+	// Accounts of other tenants look missing; pooling a pooled account conflicts.
+	assert.Equal(t, http.StatusNotFound, treasuryStatus(treasury.ErrPoolNotFound))
+	assert.Equal(t, http.StatusNotFound, treasuryStatus(treasury.ErrMemberNotFound))
+	assert.Equal(t, http.StatusNotFound, treasuryStatus(service.ErrCrossOrgTransfer))
+	assert.Equal(t, http.StatusConflict, treasuryStatus(treasury.ErrAlreadyPooled))
+	assert.Equal(t, http.StatusBadRequest, treasuryStatus(fmt.Errorf("%w: bad", treasury.ErrInvalidPool)))
+	assert.Equal(t, http.StatusBadRequest, treasuryStatus(service.ErrInsufficientFunds))
+	assert.Equal(t, http.StatusInternalServerError, treasuryStatus(errors.New("boom")))
+}
+
+func TestToCashPoolMovementResponse(t *testing.T) {
+	// Amounts follow the master's currency; only fundings name who asked for them.
+	userID := uuid.New()
+	resp := toCashPoolMovementResponse(sqlc.CashPoolMovement{
+		ID: uuid.New(), Kind: treasury.MoveFund, Amount: "5000.5000",
+		TransactionID: uuid.New(), CreatedBy: uuid.NullUUID{UUID: userID, Valid: true},
+	}, "NGN")
+	assert.Equal(t, "5000.50", resp.Amount)
+	assert.Equal(t, userID.String(), *resp.CreatedBy)
+
+	resp = toCashPoolMovementResponse(sqlc.CashPoolMovement{Kind: treasury.MoveSweep, Amount: "120.0000"}, "JPY")
+	assert.Equal(t, "120", resp.Amount)
+	assert.Nil(t, resp.CreatedBy)
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+)
+
+// CreateSubWallet godoc
+// @Summary      Create a sub-wallet
+// @Description  Opens a named sub-wallet ("pocket") under an account the caller owns. Sub-wallets share the parent's owner and currency and cannot have sub-wallets of their own.
+// @Tags         wallets
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string               true  "Parent account ID"
+// @Param        body  body      object{name=string}  true  "Wallet name, unique under the parent"
+// @Success      201   {object}  AccountResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/wallets [post]
+// @Security     Bearer
+func (h *Handler) CreateSubWallet(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and authorize the parent account.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	parentID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	if _, ok := h.ownedAccount(w, r, userID, parentID); !ok {
+		return
+	}
+
+	// Step 2: Validate the wallet name.
+	var input struct {
+		Name string `json:"name"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > maxProfileField {
+		respondError(w, http.StatusBadRequest, "name required (at most 100 characters)")
+		return
+	}
+
+	// Step 3: Create under the locked parent.
+	wallet, err := h.ledger.CreateSubWallet(r.Context(), parentID, name)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSubWalletNesting):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrSubWalletLimit):
+			respondError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrAccountNotFound):
+			respondError(w, http.StatusNotFound, "account not found")
+		default:
+			// The only constraint left to trip is the unique name under the parent.
+			log.Error().Err(err).Str("parent_id", parentID.String()).Str("name", name).Msg("Failed to create sub-wallet")
+			respondError(w, http.StatusConflict, "wallet name already in use or creation failed")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toAccountResponse(wallet, h.productCatalog(r.Context())))
+}
+
+// ListSubWallets godoc
+// @Summary      List sub-wallets
+// @Description  Returns an account the caller owns with its sub-wallets and the rolled-up balance across all of them
+// @Tags         wallets
+// @Produce      json
+// @Param        id   path      string  true  "Parent account ID"
+// @Success      200  {object}  SubWalletsResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /accounts/{id}/wallets [get]
+// @Security     Bearer
+func (h *Handler) ListSubWallets(w http.ResponseWriter, r *http.Request) {
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	parentID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	parent, ok := h.visibleAccount(w, r, userID, parentID)
+	if !ok {
+		return
+	}
+
+	wallets, err := h.store.ListSubWallets(r.Context(), uuid.NullUUID{UUID: parentID, Valid: true})
+	if err != nil {
+		log.Error().Err(err).Str("parent_id", parentID.String()).Msg("Failed to list sub-wallets")
+		respondError(w, http.StatusInternalServerError, "failed to list wallets")
+		return
+	}
+	total, err := h.store.GetRolledUpBalance(r.Context(), parentID)
+	if err != nil {
+		log.Error().Err(err).Str("parent_id", parentID.String()).Msg("Failed to compute rolled-up balance")
+		respondError(w, http.StatusInternalServerError, "failed to list wallets")
+		return
+	}
+
+	catalog := h.productCatalog(r.Context())
+	resp := SubWalletsResponse{
+		Parent:       toAccountResponse(parent, catalog),
+		Wallets:      make([]AccountResponse, 0, len(wallets)),
+		TotalBalance: total,
+	}
+	for _, wallet := range wallets {
+		resp.Wallets = append(resp.Wallets, toAccountResponse(wallet, catalog))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// MoveBetweenWallets godoc
+// @Summary      Move money between wallets
+// @Description  Instantly moves funds between an account and its sub-wallets, or between two sub-wallets of the same parent. The amount field accepts JSON number or string.
+// @Tags         wallets
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                                             true  "Parent account ID"
+// @Param        body  body      object{from_id=string,to_id=string,amount=string}  true  "Move details"
+// @Success      200   {object}  SubWalletsResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /accounts/{id}/wallets/moves [post]
+// @Security     Bearer
+func (h *Handler) MoveBetweenWallets(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller and authorize the parent account.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	parentID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	parent, ok := h.ownedAccount(w, r, userID, parentID)
+	if !ok {
+		return
+	}
+	if parent.IsSystem || parent.ParentAccountID.Valid {
+		respondError(w, http.StatusBadRequest, "not a parent account")
+		return
+	}
+
+	// Step 2: Decode payload.
+	var input struct {
+		Amount interface{} `json:"amount"`
+		FromID string      `json:"from_id"`
+		ToID   string      `json:"to_id"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	fromID, err := uuid.Parse(input.FromID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid from_id")
+		return
+	}
+	toID, err := uuid.Parse(input.ToID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid to_id")
+		return
+	}
+	amount, err := normalizeAmountInput(input.Amount)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid amount")
+		return
+	}
+
+	// Step 3: Both sides must be the parent or one of its wallets; the service re-checks under lock.
+	for _, id := range []uuid.UUID{fromID, toID} {
+		if id == parentID {
+			continue
+		}
+		acc, err := h.store.GetAccount(r.Context(), id)
+		if err != nil || !acc.ParentAccountID.Valid || acc.ParentAccountID.UUID != parentID {
+			respondError(w, http.StatusNotFound, "wallet not found under this account")
+			return
+		}
+	}
+	if err := h.ledger.MoveBetweenWallets(r.Context(), fromID, toID, amount); err != nil {
+		if respondScreening(w, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, service.ErrInsufficientFunds), errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountPrecision), errors.Is(err, service.ErrSameAccountTransfer),
+			errors.Is(err, service.ErrSavingsGoalLocked):
+			respondError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrNotSameWalletGroup), errors.Is(err, service.ErrAccountNotFound):
+			respondError(w, http.StatusNotFound, "wallet not found under this account")
+		default:
+			log.Error().Err(err).Str("from_id", fromID.String()).Str("to_id", toID.String()).Msg("Wallet move failed")
+			respondError(w, http.StatusInternalServerError, "failed to move funds")
+		}
+		return
+	}
+
+	h.ListSubWallets(w, r)
+}
@@ -0,0 +1,290 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/webhooks"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// CreateWebhookEndpoint godoc
+// @Summary      Register a webhook endpoint
+// @Description  Registers an HTTPS URL that receives every ledger event touching the organization's accounts. Deliveries are signed with the returned secret, which is shown only once. Organization admins only.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        body  body      object{url=string}  true  "HTTPS endpoint URL"
+// @Success      201   {object}  WebhookEndpointResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      409   {object}  ErrorResponse
+// @Router       /org/webhooks [post]
+// @Security     Bearer
+func (h *Handler) CreateWebhookEndpoint(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Authenticate caller (role is enforced by RequireRole) and validate the URL.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	var input struct {
+		URL string `json:"url"`
+	}
+	if !decodeJSON(w, r, &input) {
+		return
+	}
+	endpointURL := strings.TrimSpace(input.URL)
+	if err := webhooks.ValidateURL(endpointURL); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Step 2: Generate the signing secret and store the endpoint.
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate webhook secret")
+		respondError(w, http.StatusInternalServerError, "failed to create webhook endpoint")
+		return
+	}
+	ep, err := h.store.CreateWebhookEndpoint(r.Context(), sqlc.CreateWebhookEndpointParams{
+		OrgID:     orgID,
+		Url:       endpointURL,
+		Secret:    secret,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		// The only constraint left to trip is the unique URL per organization.
+		log.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to create webhook endpoint")
+		respondError(w, http.StatusConflict, "url already registered or creation failed")
+		return
+	}
+
+	resp := toWebhookEndpointResponse(ep)
+	resp.Secret = ep.Secret
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// ListWebhookEndpoints godoc
+// @Summary      List webhook endpoints
+// @Description  Returns the organization's webhook endpoints with their health. Secrets are never returned. Organization admins only.
+// @Tags         webhooks
+// @Produce      json
+// @Success      200  {array}   WebhookEndpointResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /org/webhooks [get]
+// @Security     Bearer
+func (h *Handler) ListWebhookEndpoints(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := h.store.ListWebhookEndpointsByOrg(r.Context(), orgID)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to list webhook endpoints")
+		respondError(w, http.StatusInternalServerError, "failed to list webhook endpoints")
+		return
+	}
+
+	resp := make([]WebhookEndpointResponse, 0, len(rows))
+	for _, ep := range rows {
+		resp = append(resp, toWebhookEndpointResponse(ep))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// webhookSecretGrace is how long a rotated-out secret keeps signing deliveries by default, and
+// maxWebhookSecretGrace the longest a caller may ask for.
+const (
+	webhookSecretGrace    = 24 * time.Hour
+	maxWebhookSecretGrace = 7 * 24 * time.Hour
+)
+
+// RotateWebhookSecret godoc
+// @Summary      Rotate a webhook endpoint's secret
+// @Description  Replaces the endpoint's signing secret and returns the new one, shown only once. For grace_hours (default 24, at most 168) deliveries carry a signature made with each secret, comma-separated in X-Webhook-Signature, so receivers can switch to the new secret at any point in that window. grace_hours=0 retires the old secret at once, for a leaked secret. Organization admins only.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                   true   "Endpoint ID"
+// @Param        body  body      object{grace_hours=int}  false  "How long the old secret keeps signing"
+// @Success      200   {object}  WebhookEndpointResponse
+// @Failure      400   {object}  ErrorResponse
+// @Failure      401   {object}  ErrorResponse
+// @Failure      403   {object}  ErrorResponse
+// @Failure      404   {object}  ErrorResponse
+// @Failure      500   {object}  ErrorResponse
+// @Router       /org/webhooks/{id}/rotate-secret [post]
+// @Security     Bearer
+func (h *Handler) RotateWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Parse the endpoint and grace period.
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	endpointID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid endpoint ID")
+		return
+	}
+	var input struct {
+		GraceHours *int `json:"grace_hours"`
+	}
+	if !decodeOptionalJSON(w, r, &input) {
+		return
+	}
+	grace := webhookSecretGrace
+	if input.GraceHours != nil {
+		if *input.GraceHours < 0 || *input.GraceHours > int(maxWebhookSecretGrace/time.Hour) {
+			respondError(w, http.StatusBadRequest, "grace_hours must be between 0 and 168")
+			return
+		}
+		grace = time.Duration(*input.GraceHours) * time.Hour
+	}
+
+	// Step 2: Swap in a new secret, keeping the old one for the grace period.
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate webhook secret")
+		respondError(w, http.StatusInternalServerError, "failed to rotate webhook secret")
+		return
+	}
+	ep, err := h.store.RotateWebhookEndpointSecret(r.Context(), sqlc.RotateWebhookEndpointSecretParams{
+		ID:                endpointID,
+		OrgID:             orgID,
+		Secret:            secret,
+		PreviousExpiresAt: time.Now().Add(grace),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondError(w, http.StatusNotFound, "webhook endpoint not found")
+			return
+		}
+		log.Error().Err(err).Str("endpoint_id", endpointID.String()).Msg("Failed to rotate webhook secret")
+		respondError(w, http.StatusInternalServerError, "failed to rotate webhook secret")
+		return
+	}
+
+	log.Info().Str("endpoint_id", ep.ID.String()).Str("org_id", orgID.String()).Dur("grace", grace).Msg("Webhook secret rotated")
+	resp := toWebhookEndpointResponse(ep)
+	resp.Secret = ep.Secret
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  Returns the organization's deliveries by status, newest first. The default status "dead" is the dead-letter queue: deliveries that exhausted their retries. Organization admins only.
+// @Tags         webhooks
+// @Produce      json
+// @Param        status  query     string  false  "dead (default), pending or succeeded"
+// @Param        limit   query     int     false  "Limit (default 20)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   WebhookDeliveryResponse
+// @Failure      400     {object}  ErrorResponse
+// @Failure      401     {object}  ErrorResponse
+// @Failure      403     {object}  ErrorResponse
+// @Failure      500     {object}  ErrorResponse
+// @Router       /org/webhooks/deliveries [get]
+// @Security     Bearer
+func (h *Handler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+
+	// Step 1: Parse filters with safe defaults and caps.
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = webhooks.StatusDead
+	case webhooks.StatusDead, webhooks.StatusPending, webhooks.StatusSucceeded:
+	default:
+		respondError(w, http.StatusBadRequest, "status must be dead, pending or succeeded")
+		return
+	}
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 && v <= 2147483647 {
+		offset = v
+	}
+
+	// Step 2: Fetch the page.
+	rows, err := h.store.ListWebhookDeliveriesByOrg(r.Context(), sqlc.ListWebhookDeliveriesByOrgParams{
+		OrgID:  orgID,
+		Status: status,
+		Limit:  int32(limit),  // #nosec G115 -- capped at 100 above
+		Offset: int32(offset), // #nosec G115 -- bounded above
+	})
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Str("status", status).Msg("Failed to list webhook deliveries")
+		respondError(w, http.StatusInternalServerError, "failed to list webhook deliveries")
+		return
+	}
+
+	resp := make([]WebhookDeliveryResponse, 0, len(rows))
+	for _, d := range rows {
+		resp = append(resp, toWebhookDeliveryResponse(d))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// RedeliverWebhook godoc
+// @Summary      Redeliver a webhook
+// @Description  Re-queues a dead or delivered webhook for an immediate attempt with a fresh retry budget. Receivers should deduplicate on the X-Webhook-Delivery header. Organization admins only.
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Delivery ID"
+// @Success      202  {object}  WebhookDeliveryResponse
+// @Failure      400  {object}  ErrorResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      403  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /org/webhooks/deliveries/{id}/redeliver [post]
+// @Security     Bearer
+func (h *Handler) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	orgID, ok := authenticatedOrgID(w, r)
+	if !ok {
+		return
+	}
+	deliveryID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid delivery ID")
+		return
+	}
+
+	d, err := h.store.RedeliverWebhookDelivery(r.Context(), sqlc.RedeliverWebhookDeliveryParams{
+		ID:    deliveryID,
+		OrgID: orgID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Other organizations' deliveries and ones still being retried look the same.
+			respondError(w, http.StatusNotFound, "delivery not found or still pending")
+			return
+		}
+		log.Error().Err(err).Str("delivery_id", deliveryID.String()).Msg("Failed to redeliver webhook")
+		respondError(w, http.StatusInternalServerError, "failed to redeliver webhook")
+		return
+	}
+
+	log.Info().Str("delivery_id", d.ID.String()).Str("org_id", orgID.String()).Msg("Webhook delivery re-queued")
+	respondJSON(w, http.StatusAccepted, toWebhookDeliveryResponse(d))
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestRotateWebhookSecret_RejectsBadInput(t *testing.T) {
+	// Malformed endpoint IDs and grace periods beyond a week are refused before the store is touched.
+	require.NoError(t, InitTokenAuth(testJWTSecret))
+	token, err := GenerateToken(uuid.New(), uuid.New(), RoleAdmin)
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	router.Group(func(r chi.Router) {
+		r.Use(Verifier, Authenticator)
+		r.Post("/org/webhooks/{id}/rotate-secret", (&Handler{}).RotateWebhookSecret)
+	})
+
+	for _, tc := range []struct{ path, body, want string }{
+		{"/org/webhooks/nope/rotate-secret", "", "invalid endpoint ID"},
+		{"/org/webhooks/" + uuid.NewString() + "/rotate-secret", `{"grace_hours":169}`, "grace_hours must be"},
+		{"/org/webhooks/" + uuid.NewString() + "/rotate-secret", `{"grace_hours":-1}`, "grace_hours must be"},
+	} {
+		req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusBadRequest, rw.Code, tc.body)
+		assert.Contains(t, rw.Body.String(), tc.want, tc.body)
+	}
+}
+
+func TestToWebhookEndpointResponse(t *testing.T) {
+	// Secrets never leak through the mapper; a rotation's grace period does show.
+	expires := time.Now().Add(time.Hour)
+	ep := sqlc.WebhookEndpoint{ID: uuid.New(), Secret: "whsec_new", PreviousSecret: "whsec_old"}
+	assert.Nil(t, toWebhookEndpointResponse(ep).PreviousSecretExpiresAt)
+
+	ep.PreviousSecretExpiresAt.Time, ep.PreviousSecretExpiresAt.Valid = expires, true
+	resp := toWebhookEndpointResponse(ep)
+	require.NotNil(t, resp.PreviousSecretExpiresAt)
+	assert.Equal(t, expires, *resp.PreviousSecretExpiresAt)
+	assert.Empty(t, resp.Secret)
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+const (
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = (wsPongWait * 9) / 10
+)
+
+// originChecker allows non-browser clients (no Origin header), same-host pages and configured origins.
+func originChecker(allowed []string) func(r *http.Request) bool {
+	set := make(map[string]struct{}, len(allowed))
+	for _, origin := range allowed {
+		set[strings.TrimRight(origin, "/")] = struct{}{}
+	}
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if _, ok := set[origin]; ok {
+			return true
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
+
+// WebSocket godoc
+// @Summary      Live balance and entry stream
+// @Description  Upgrades to a WebSocket that first sends a "snapshot" frame per owned or co-owned account, then an "entry" frame (with the balance after posting) for every new ledger entry. Browsers may pass the JWT as the ?jwt= query parameter. Accounts created after connecting require a reconnect.
+// @Tags         streaming
+// @Success      101  {object}  StreamMessage
+// @Failure      401  {object}  ErrorResponse
+// @Failure      503  {object}  ErrorResponse
+// @Router       /ws [get]
+// @Security     Bearer
+func (h *Handler) WebSocket(w http.ResponseWriter, r *http.Request) {
+	if h.realtime == nil {
+		respondError(w, http.StatusServiceUnavailable, "live streaming is not enabled")
+		return
+	}
+
+	// Step 1: Authenticate caller and resolve the accounts they may watch.
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	accounts, err := h.store.ListAccountsForUser(r.Context(), sqlc.ListAccountsForUserParams{UserID: userID})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list accounts for stream")
+		respondError(w, http.StatusInternalServerError, "failed to list accounts")
+		return
+	}
+
+	// Step 2: Upgrade; the upgrader writes its own error response on failure.
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("WebSocket upgrade failed")
+		return
+	}
+	defer func() {
+		if closeErr := conn.Close(); closeErr != nil {
+			log.Debug().Err(closeErr).Msg("WebSocket close failed")
+		}
+	}()
+
+	// Subscribe before sending snapshots so no entry can slip between the two.
+	ids := make([]uuid.UUID, len(accounts))
+	currencyOf := make(map[uuid.UUID]string, len(accounts))
+	for i, acc := range accounts {
+		ids[i] = acc.ID
+		currencyOf[acc.ID] = acc.Currency
+	}
+	sub := h.realtime.Subscribe(ids...)
+	defer sub.Close()
+
+	// Step 3: Drain client frames so pongs and close messages are processed.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn.SetReadLimit(512)
+		_ = conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		})
+		for {
+			if _, _, readErr := conn.ReadMessage(); readErr != nil {
+				return
+			}
+		}
+	}()
+
+	for _, acc := range accounts {
+		if err := writeWS(conn, StreamMessage{Type: "snapshot", AccountID: acc.ID.String(), Balance: currencies.FormatString(acc.Currency, acc.Balance)}); err != nil {
+			return
+		}
+	}
+	log.Info().Str("user_id", userID.String()).Int("accounts", len(accounts)).Msg("WebSocket stream opened")
+
+	// Step 4: Push updates until the client leaves or falls too far behind.
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case update, open := <-sub.C:
+			if !open {
+				msg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "stream lagged; reconnect to resync")
+				_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteWait))
+				return
+			}
+			entry := toEntryResponse(update.Entry, currencyOf[update.AccountID])
+			if err := writeWS(conn, StreamMessage{
+				Type:      "entry",
+				AccountID: update.AccountID.String(),
+				Balance:   update.Balance,
+				Entry:     &entry,
+			}); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		case <-done:
+			log.Info().Str("user_id", userID.String()).Msg("WebSocket stream closed")
+			return
+		}
+	}
+}
+
+func writeWS(conn *websocket.Conn, msg StreamMessage) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(wsWriteWait)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(msg)
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOriginChecker(t *testing.T) {
+	// Configured and same-host origins pass; unknown browser origins do not.
+	check := originChecker([]string{"https://golangbank.app/"})
+
+	req := httptest.NewRequest("GET", "http://api.golangbank.app/ws", nil)
+	assert.True(t, check(req), "non-browser clients send no Origin")
+
+	req.Header.Set("Origin", "https://golangbank.app")
+	assert.True(t, check(req))
+
+	req.Header.Set("Origin", "http://api.golangbank.app")
+	assert.True(t, check(req))
+
+	req.Header.Set("Origin", "https://evil.example")
+	assert.False(t, check(req))
+}
@@ -0,0 +1,136 @@
+// Package archive writes aged ledger entries to write-once object storage and reads them back.
+// A segment holds one account's entries for one calendar month (UTC) as gzip-compressed JSON
+// Lines: a header line naming the format, account, month and entry count, then one Record per
+// entry in (created_at, id) order. A segment's SHA-256 is recorded beside it in the database and
+// checked on every read, and the store is asked to verify it on upload.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Format names the segment layout in its header; readers reject any other.
+const Format = "ledger-entries/v1"
+
+// ErrChecksum is returned by Decode for a segment whose bytes do not hash to the recorded sum.
+var ErrChecksum = errors.New("archive segment checksum mismatch")
+
+// Store keeps segments in object storage. Put must never replace an existing object.
+type Store interface {
+	// Put stores body under key, locked against deletion and overwrite until retainUntil. sum is
+	// the SHA-256 of body.
+	Put(ctx context.Context, key string, body, sum []byte, retainUntil time.Time) error
+	// Get returns the object stored under key.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// Record is an archived entry: the ledger entry with its chain hashes, and the statement fields
+// GET /accounts/{id}/entries shows beside it as they were when the segment was written.
+type Record struct {
+	ID                    uuid.UUID     `json:"id"`
+	AccountID             uuid.UUID     `json:"account_id"`
+	TransactionID         uuid.UUID     `json:"transaction_id"`
+	OperationType         string        `json:"operation_type"`
+	Debit                 string        `json:"debit"`
+	Credit                string        `json:"credit"`
+	Description           *string       `json:"description"`
+	CreatedAt             time.Time     `json:"created_at"`
+	ChainSeq              int64         `json:"chain_seq"`
+	PrevHash              []byte        `json:"prev_hash"`
+	Hash                  []byte        `json:"hash"`
+	BalanceAfter          string        `json:"balance_after"`
+	CounterpartyAccountID uuid.NullUUID `json:"counterparty_account_id"`
+	CounterpartyName      string        `json:"counterparty_name"`
+	CategoryID            uuid.NullUUID `json:"category_id"`
+	CategoryName          string        `json:"category_name"`
+}
+
+// header is a segment's first line.
+type header struct {
+	Format    string    `json:"format"`
+	AccountID uuid.UUID `json:"account_id"`
+	Period    string    `json:"period"`
+	Count     int       `json:"count"`
+}
+
+// Key is the object key of a segment: account and month, then the segment's SHA-256, so a
+// segment rewritten with different content never collides with the one already stored.
+func Key(accountID uuid.UUID, period time.Time, sum []byte) string {
+	return fmt.Sprintf("entries/%s/%s/%x.jsonl.gz", accountID, period.UTC().Format("2006-01"), sum)
+}
+
+// Encode writes the segment of accountID's entries for the month starting at period and returns
+// it with its SHA-256.
+func Encode(accountID uuid.UUID, period time.Time, records []Record) ([]byte, []byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	if err := enc.Encode(header{Format: Format, AccountID: accountID, Period: period.UTC().Format("2006-01"), Count: len(records)}); err != nil {
+		return nil, nil, err
+	}
+	for _, rec := range records {
+		if rec.AccountID != accountID {
+			return nil, nil, fmt.Errorf("entry %s belongs to account %s", rec.ID, rec.AccountID)
+		}
+		if err := enc.Encode(rec); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, nil, err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return buf.Bytes(), sum[:], nil
+}
+
+// Decode checks body against sum and returns its records.
+func Decode(body, sum []byte) ([]Record, error) {
+	got := sha256.Sum256(body)
+	if subtle.ConstantTimeCompare(got[:], sum) != 1 {
+		return nil, ErrChecksum
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("read archive segment: %w", err)
+	}
+	defer zr.Close()
+
+	sc := bufio.NewScanner(zr)
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	if !sc.Scan() {
+		return nil, errors.New("archive segment has no header")
+	}
+	var h header
+	if err := json.Unmarshal(sc.Bytes(), &h); err != nil {
+		return nil, fmt.Errorf("read archive segment header: %w", err)
+	}
+	if h.Format != Format {
+		return nil, fmt.Errorf("unknown archive segment format %q", h.Format)
+	}
+	records := make([]Record, 0, h.Count)
+	for sc.Scan() {
+		var rec Record
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("read archive segment: %w", err)
+		}
+		records = append(records, rec)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read archive segment: %w", err)
+	}
+	if len(records) != h.Count {
+		return nil, fmt.Errorf("archive segment holds %d entries, header says %d", len(records), h.Count)
+	}
+	return records, nil
+}
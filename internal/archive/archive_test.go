@@ -0,0 +1,41 @@
+package archive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	// A segment reads back as written and is refused once a byte of it changes.
+	accountID := uuid.New()
+	period := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	desc := "rent"
+	records := []Record{
+		{ID: uuid.New(), AccountID: accountID, TransactionID: uuid.New(), OperationType: "deposit", Debit: "0.0000", Credit: "100.0000", CreatedAt: period.Add(time.Hour), ChainSeq: 1, Hash: []byte{1}, BalanceAfter: "100.0000"},
+		{ID: uuid.New(), AccountID: accountID, TransactionID: uuid.New(), OperationType: "transfer", Debit: "40.0000", Credit: "0.0000", Description: &desc, CreatedAt: period.Add(2 * time.Hour), ChainSeq: 2, PrevHash: []byte{1}, Hash: []byte{2}, BalanceAfter: "60.0000", CounterpartyAccountID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, CounterpartyName: "Landlord"},
+	}
+
+	body, sum, err := Encode(accountID, period, records)
+	require.NoError(t, err)
+	want := sha256.Sum256(body)
+	assert.Equal(t, want[:], sum)
+	assert.Equal(t, "entries/"+accountID.String()+"/2025-03/", Key(accountID, period, sum)[:len("entries/")+36+9])
+
+	got, err := Decode(body, sum)
+	require.NoError(t, err)
+	assert.Equal(t, records, got)
+
+	tampered := bytes.Clone(body)
+	tampered[len(tampered)/2] ^= 0xff
+	_, err = Decode(tampered, sum)
+	assert.ErrorIs(t, err, ErrChecksum)
+
+	_, _, err = Encode(uuid.New(), period, records)
+	assert.Error(t, err)
+}
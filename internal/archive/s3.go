@@ -0,0 +1,87 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// S3Config locates the bucket segments are written to.
+type S3Config struct {
+	Bucket string
+	Region string
+	// Endpoint points the client at an S3-compatible store such as MinIO; empty means AWS.
+	Endpoint string
+	// PathStyle addresses the bucket in the path instead of the host name, as most
+	// S3-compatible stores expect.
+	PathStyle bool
+}
+
+// S3Store keeps segments in an S3 bucket with Object Lock enabled. Objects are written in
+// compliance mode, so not even the bucket owner can delete or overwrite one before its
+// retention ends.
+type S3Store struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewS3Store loads AWS credentials from the default chain and constructs an S3Store.
+func NewS3Store(ctx context.Context, cfg S3Config) (*S3Store, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("archive bucket is required")
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, fmt.Errorf("load AWS config: %w", err)
+	}
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.PathStyle
+	})
+	return &S3Store{client: client, bucket: cfg.Bucket}, nil
+}
+
+// Put implements Store. The store checks body against sum before accepting it. An object
+// already under key is left alone: keys carry the content's hash, so it holds the same bytes.
+func (s *S3Store) Put(ctx context.Context, key string, body, sum []byte, retainUntil time.Time) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:                    aws.String(s.bucket),
+		Key:                       aws.String(key),
+		Body:                      bytes.NewReader(body),
+		ContentType:               aws.String("application/gzip"),
+		ChecksumSHA256:            aws.String(base64.StdEncoding.EncodeToString(sum)),
+		IfNoneMatch:               aws.String("*"),
+		ObjectLockMode:            s3types.ObjectLockModeCompliance,
+		ObjectLockRetainUntilDate: aws.Time(retainUntil),
+	})
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "PreconditionFailed" {
+		return nil
+	}
+	return err
+}
+
+// Get implements Store.
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
@@ -0,0 +1,202 @@
+// Package backup writes and reads logical dumps of the ledger's core tables. A dump is
+// gzip-compressed JSON Lines: a header line naming the format and the ledger event it was taken
+// through, one line per row naming its table, table after table in Tables order, then a trailer
+// line with every table's row count and the SHA-256 of all the lines before it. Rows are the
+// tables' own columns as Postgres renders them to JSON, so a dump restores into the same schema.
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"slices"
+	"time"
+)
+
+// Format names the dump layout in its header; readers reject any other.
+const Format = "ledger-backup/v1"
+
+// ErrChecksum is returned by Read for a dump whose lines do not hash to the sum in its trailer,
+// or whose row counts differ from the trailer's.
+var ErrChecksum = errors.New("backup checksum mismatch")
+
+// Tables are the tables a dump holds, in the order they are written and restored: each one's
+// references point at tables before it, except users' default accounts and sub-wallets' parents,
+// which a restore sets once the accounts are in.
+var Tables = []string{
+	"organizations",
+	"users",
+	"products",
+	"accounts",
+	"account_shards",
+	"transactions",
+	"entry_archives",
+	"entries",
+	"entry_chain_heads",
+	"account_history",
+	"ledger_events",
+}
+
+// Header is a dump's first line.
+type Header struct {
+	Format string `json:"format"`
+	// TakenAt is when the dump's snapshot of the database was taken.
+	TakenAt time.Time `json:"taken_at"`
+	// ThroughSeq is the last ledger event the dump holds.
+	ThroughSeq int64 `json:"through_seq"`
+}
+
+// line is one line of a dump after the header: a row, or the trailer.
+type line struct {
+	Table  string           `json:"table,omitempty"`
+	Row    json.RawMessage  `json:"row,omitempty"`
+	Counts map[string]int64 `json:"counts,omitempty"`
+	SHA256 string           `json:"sha256,omitempty"`
+}
+
+// Writer writes a dump.
+type Writer struct {
+	zw     *gzip.Writer
+	sum    hash.Hash
+	enc    *json.Encoder
+	counts map[string]int64
+	// table is the index in Tables of the table being written.
+	table int
+}
+
+// NewWriter starts a dump on w with header h; h.Format is filled in.
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	zw := gzip.NewWriter(w)
+	sum := sha256.New()
+	bw := &Writer{zw: zw, sum: sum, enc: json.NewEncoder(io.MultiWriter(zw, sum)), counts: make(map[string]int64, len(Tables))}
+	h.Format = Format
+	if err := bw.enc.Encode(h); err != nil {
+		return nil, err
+	}
+	return bw, nil
+}
+
+// Write adds row, a JSON object of table's columns. Tables must be written in Tables order.
+func (w *Writer) Write(table string, row json.RawMessage) error {
+	i := slices.Index(Tables, table)
+	if i < 0 {
+		return fmt.Errorf("table %q is not backed up", table)
+	}
+	if i < w.table {
+		return fmt.Errorf("table %s written after %s", table, Tables[w.table])
+	}
+	w.table = i
+	w.counts[table]++
+	return w.enc.Encode(line{Table: table, Row: row})
+}
+
+// Counts returns how many rows of each table were written.
+func (w *Writer) Counts() map[string]int64 {
+	return w.counts
+}
+
+// Close writes the trailer and flushes the dump. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	trailer := line{Counts: w.counts, SHA256: hex.EncodeToString(w.sum.Sum(nil))}
+	if err := json.NewEncoder(w.zw).Encode(trailer); err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+// Read reads the dump on r, calling fn with each table's rows in batches of at most n, in Tables
+// order; with a nil fn it only checks the dump. The trailer is checked once every row has been
+// passed to fn, so a caller loading the rows must be able to undo them when Read fails, as a
+// database transaction can.
+func Read(r io.Reader, n int, fn func(table string, rows []json.RawMessage) error) (Header, map[string]int64, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return Header{}, nil, fmt.Errorf("read backup: %w", err)
+	}
+	defer zr.Close()
+	br := bufio.NewReaderSize(zr, 64*1024)
+	sum := sha256.New()
+
+	raw, err := br.ReadBytes('\n')
+	if err != nil {
+		return Header{}, nil, fmt.Errorf("read backup header: %w", err)
+	}
+	sum.Write(raw)
+	var h Header
+	if err := json.Unmarshal(raw, &h); err != nil {
+		return Header{}, nil, fmt.Errorf("read backup header: %w", err)
+	}
+	if h.Format != Format {
+		return Header{}, nil, fmt.Errorf("unknown backup format %q", h.Format)
+	}
+
+	var (
+		counts = make(map[string]int64, len(Tables))
+		table  = -1
+		batch  []json.RawMessage
+	)
+	flush := func() error {
+		if len(batch) == 0 || fn == nil {
+			batch = nil
+			return nil
+		}
+		err := fn(Tables[table], batch)
+		batch = nil
+		return err
+	}
+	for {
+		raw, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return Header{}, nil, errors.New("backup is truncated: no trailer")
+		}
+		if err != nil {
+			return Header{}, nil, fmt.Errorf("read backup: %w", err)
+		}
+		var l line
+		if err := json.Unmarshal(raw, &l); err != nil {
+			return Header{}, nil, fmt.Errorf("read backup: %w", err)
+		}
+		if l.SHA256 != "" {
+			if err := flush(); err != nil {
+				return Header{}, nil, err
+			}
+			if rest, _ := br.Peek(1); len(rest) > 0 {
+				return Header{}, nil, errors.New("backup has data after its trailer")
+			}
+			want, err := hex.DecodeString(l.SHA256)
+			if err != nil || subtle.ConstantTimeCompare(sum.Sum(nil), want) != 1 {
+				return Header{}, nil, ErrChecksum
+			}
+			for _, t := range Tables {
+				if counts[t] != l.Counts[t] {
+					return Header{}, nil, fmt.Errorf("%w: %d %s rows, trailer says %d", ErrChecksum, counts[t], t, l.Counts[t])
+				}
+			}
+			return h, counts, nil
+		}
+		sum.Write(raw)
+
+		i := slices.Index(Tables, l.Table)
+		if i < 0 {
+			return Header{}, nil, fmt.Errorf("backup holds rows of unknown table %q", l.Table)
+		}
+		if i < table {
+			return Header{}, nil, fmt.Errorf("backup holds %s rows after %s", l.Table, Tables[table])
+		}
+		if i != table || len(batch) >= n {
+			if err := flush(); err != nil {
+				return Header{}, nil, err
+			}
+			table = i
+		}
+		batch = append(batch, l.Row)
+		counts[l.Table]++
+	}
+}
@@ -0,0 +1,79 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDump(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Header{TakenAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), ThroughSeq: 7})
+	require.NoError(t, err)
+	require.NoError(t, w.Write("users", json.RawMessage(`{"id":"u1"}`)))
+	for _, id := range []string{"a1", "a2", "a3"} {
+		require.NoError(t, w.Write("accounts", json.RawMessage(`{"id":"`+id+`","balance":10.0000}`)))
+	}
+	require.NoError(t, w.Write("entries", json.RawMessage(`{"id":"e1"}`)))
+	assert.Error(t, w.Write("users", json.RawMessage(`{"id":"u2"}`)))
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// gunzipped returns the dump's lines uncompressed, for tampering with.
+func gunzipped(t *testing.T, dump []byte) []byte {
+	t.Helper()
+	zr, err := gzip.NewReader(bytes.NewReader(dump))
+	require.NoError(t, err)
+	raw, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	return raw
+}
+
+func gzipped(t *testing.T, raw []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(raw)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestRead_Batches(t *testing.T) {
+	// Rows come back table by table in batches of at most n, with the header and counts.
+	type batch struct {
+		table string
+		rows  int
+	}
+	var got []batch
+	h, counts, err := Read(bytes.NewReader(testDump(t)), 2, func(table string, rows []json.RawMessage) error {
+		got = append(got, batch{table, len(rows)})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Format, h.Format)
+	assert.Equal(t, int64(7), h.ThroughSeq)
+	assert.Equal(t, []batch{{"users", 1}, {"accounts", 2}, {"accounts", 1}, {"entries", 1}}, got)
+	assert.Equal(t, map[string]int64{"users": 1, "accounts": 3, "entries": 1}, counts)
+}
+
+func TestRead_RejectsTampering(t *testing.T) {
+	// An edited row fails the checksum and a dump cut short has no trailer.
+	raw := gunzipped(t, testDump(t))
+
+	edited := bytes.Replace(raw, []byte(`"balance":10.0000`), []byte(`"balance":90.0000`), 1)
+	_, _, err := Read(bytes.NewReader(gzipped(t, edited)), 10, nil)
+	assert.ErrorIs(t, err, ErrChecksum)
+
+	truncated := raw[:bytes.LastIndexByte(raw[:len(raw)-1], '\n')+1]
+	_, _, err = Read(bytes.NewReader(gzipped(t, truncated)), 10, nil)
+	assert.ErrorContains(t, err, "truncated")
+}
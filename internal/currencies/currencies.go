@@ -0,0 +1,135 @@
+// Package currencies is the registry of currencies the ledger knows, read from an embedded ISO 4217
+// table. Each carries its exponent: the number of decimal places its amounts carry (ISO 4217 minor
+// units), such as 0 for JPY, 2 for NGN and USD and 3 for KWD. Amounts are validated, stored and
+// formatted at their currency's exponent.
+package currencies
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxExponent is the scale the ledger's NUMERIC(19,4) amount columns store.
+const MaxExponent = 4
+
+// DefaultExponent applies to codes missing from the table, as most currencies have cents.
+const DefaultExponent = 2
+
+// Currency is one row of the ISO 4217 table.
+type Currency struct {
+	Code     string
+	Numeric  string
+	Exponent int32
+	Name     string
+}
+
+// iso4217 lists the active ISO 4217 currencies, leaving out precious metals and testing codes
+// (XAU, XTS, XXX and the like) that no account holds.
+//
+//go:embed iso4217.csv
+var iso4217 []byte
+
+// table indexes iso4217 by code.
+var table = func() map[string]Currency {
+	rows, err := csv.NewReader(bytes.NewReader(iso4217)).ReadAll()
+	if err != nil {
+		panic("currencies: invalid iso4217.csv: " + err.Error())
+	}
+	t := make(map[string]Currency, len(rows))
+	for _, row := range rows[1:] {
+		exp, err := strconv.ParseInt(row[2], 10, 32)
+		if err != nil {
+			panic("currencies: invalid minor units for " + row[0])
+		}
+		t[row[0]] = Currency{Code: row[0], Numeric: row[1], Exponent: min(int32(exp), MaxExponent), Name: row[3]}
+	}
+	return t
+}()
+
+// Lookup returns the currency with the upper-case code, and whether ISO 4217 defines it.
+func Lookup(code string) (Currency, bool) {
+	c, ok := table[code]
+	return c, ok
+}
+
+// Known reports whether ISO 4217 defines the upper-case code.
+func Known(code string) bool {
+	_, ok := table[code]
+	return ok
+}
+
+// All returns every currency in the table, ordered by code.
+func All() []Currency {
+	all := make([]Currency, 0, len(table))
+	for _, c := range table {
+		all = append(all, c)
+	}
+	slices.SortFunc(all, func(a, b Currency) int { return strings.Compare(a.Code, b.Code) })
+	return all
+}
+
+// Exponent returns the number of decimal places code's amounts carry.
+func Exponent(code string) int32 {
+	if c, ok := table[code]; ok {
+		return c.Exponent
+	}
+	return DefaultExponent
+}
+
+// Fits reports whether amount has no more decimal places than code allows, ignoring trailing
+// zeros: 100.00 fits JPY, 100.5 does not.
+func Fits(code string, amount decimal.Decimal) bool {
+	return amount.Equal(amount.Truncate(Exponent(code)))
+}
+
+// Round rounds amount half away from zero to code's exponent.
+func Round(code string, amount decimal.Decimal) decimal.Decimal {
+	return amount.Round(Exponent(code))
+}
+
+// RoundDown truncates amount to code's exponent.
+func RoundDown(code string, amount decimal.Decimal) decimal.Decimal {
+	return amount.Truncate(Exponent(code))
+}
+
+// Format writes amount with exactly code's exponent of decimal places.
+func Format(code string, amount decimal.Decimal) string {
+	return amount.StringFixed(Exponent(code))
+}
+
+// FormatString is Format for an amount read from the database, returning it unchanged when it
+// is not a number.
+func FormatString(code, amount string) string {
+	d, err := decimal.NewFromString(amount)
+	if err != nil {
+		return amount
+	}
+	return Format(code, d)
+}
+
+// Display writes amount as customers read it: thousands grouped with commas and code's exponent
+// of decimal places, such as 1,234,567.89 NGN or 1,234,568 JPY.
+func Display(code string, amount decimal.Decimal) string {
+	sign := ""
+	if amount.IsNegative() {
+		sign = "-"
+	}
+	whole, frac, _ := strings.Cut(Format(code, amount.Abs()), ".")
+	var b strings.Builder
+	for i, ch := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(ch)
+	}
+	if frac != "" {
+		return sign + b.String() + "." + frac
+	}
+	return sign + b.String()
+}
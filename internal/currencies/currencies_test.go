@@ -0,0 +1,82 @@
+package currencies
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponent(t *testing.T) {
+	assert.Equal(t, int32(0), Exponent("JPY"))
+	assert.Equal(t, int32(2), Exponent("NGN"))
+	assert.Equal(t, int32(3), Exponent("KWD"))
+	assert.Equal(t, int32(4), Exponent("CLF"))
+	assert.Equal(t, int32(DefaultExponent), Exponent("XYZ"))
+}
+
+func TestLookup(t *testing.T) {
+	c, ok := Lookup("NGN")
+	assert.True(t, ok)
+	assert.Equal(t, Currency{Code: "NGN", Numeric: "566", Exponent: 2, Name: "Naira"}, c)
+	assert.True(t, Known("JPY"))
+	// Unknown, lower-case and non-monetary codes are not in the table.
+	assert.False(t, Known("XYZ"))
+	assert.False(t, Known("usd"))
+	assert.False(t, Known("XAU"))
+}
+
+func TestAll(t *testing.T) {
+	// The table is well formed: sorted by code, three-digit numeric codes, exponents within storage.
+	all := All()
+	assert.Greater(t, len(all), 150)
+	numeric := make(map[string]string, len(all))
+	for i, c := range all {
+		assert.Regexp(t, `^[A-Z]{3}$`, c.Code)
+		assert.Regexp(t, `^[0-9]{3}$`, c.Numeric, c.Code)
+		assert.NotEmpty(t, c.Name, c.Code)
+		assert.LessOrEqual(t, c.Exponent, int32(MaxExponent), c.Code)
+		if i > 0 {
+			assert.Less(t, all[i-1].Code, c.Code)
+		}
+		if other, dup := numeric[c.Numeric]; dup {
+			t.Errorf("%s and %s share numeric code %s", other, c.Code, c.Numeric)
+		}
+		numeric[c.Numeric] = c.Code
+	}
+}
+
+func TestFits(t *testing.T) {
+	cases := []struct {
+		code, amount string
+		fits         bool
+	}{
+		{"JPY", "100", true},
+		{"JPY", "100.00", true},
+		{"JPY", "100.5", false},
+		{"NGN", "100.25", true},
+		{"NGN", "100.2500", true},
+		{"NGN", "100.255", false},
+		{"KWD", "1.125", true},
+		{"KWD", "1.1255", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.fits, Fits(c.code, decimal.RequireFromString(c.amount)), c.code+" "+c.amount)
+	}
+}
+
+func TestRoundAndFormat(t *testing.T) {
+	assert.Equal(t, "101", Round("JPY", decimal.RequireFromString("100.5")).String())
+	assert.Equal(t, "100", RoundDown("JPY", decimal.RequireFromString("100.9")).String())
+	assert.Equal(t, "1250", Format("JPY", decimal.RequireFromString("1250.0000")))
+	assert.Equal(t, "1250.50", FormatString("NGN", "1250.5000"))
+	assert.Equal(t, "1.125", FormatString("KWD", "1.1250"))
+	assert.Equal(t, "n/a", FormatString("USD", "n/a"))
+}
+
+func TestDisplay(t *testing.T) {
+	assert.Equal(t, "1,234,567.89", Display("NGN", decimal.RequireFromString("1234567.891")))
+	assert.Equal(t, "1,234,568", Display("JPY", decimal.RequireFromString("1234567.891")))
+	assert.Equal(t, "-1,000.000", Display("KWD", decimal.RequireFromString("-1000")))
+	assert.Equal(t, "999.00", Display("USD", decimal.RequireFromString("999")))
+}
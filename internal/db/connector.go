@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/lib/pq"
+)
+
+// rotatingConnector opens each new connection with the DSN current at that moment, so database
+// credentials rotated in a secret store are used without a restart.
+type rotatingConnector struct {
+	dsn func(ctx context.Context) (string, error)
+}
+
+// NewRotatingConnector returns a connector for sql.OpenDB that asks dsn for the connection
+// string whenever the pool opens a connection. Pair it with SetConnMaxLifetime so connections
+// opened with old credentials are retired.
+func NewRotatingConnector(dsn func(ctx context.Context) (string, error)) driver.Connector {
+	return rotatingConnector{dsn: dsn}
+}
+
+// Connect implements driver.Connector.
+func (c rotatingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dsn, err := c.dsn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+// Driver implements driver.Connector.
+func (rotatingConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
@@ -0,0 +1,24 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingConnector_ResolvesDSNPerConnection(t *testing.T) {
+	// Every connection asks for the current DSN, and a failure to resolve it fails the connection.
+	var calls int
+	connector := NewRotatingConnector(func(context.Context) (string, error) {
+		calls++
+		return "", errors.New("secret store down")
+	})
+	for i := 0; i < 2; i++ {
+		_, err := connector.Connect(context.Background())
+		assert.ErrorContains(t, err, "secret store down")
+	}
+	assert.Equal(t, 2, calls)
+	assert.NotNil(t, connector.Driver())
+}
@@ -17,14 +17,32 @@ import (
 type Store struct {
 	*sqlc.Queries
 	db *sql.DB
+	// txTimeout bounds each ExecTx attempt; zero leaves only the caller's deadline.
+	txTimeout time.Duration
+}
+
+// StoreOption customizes a Store.
+type StoreOption func(*Store)
+
+// WithTxTimeout bounds every transaction attempt by d, so a stuck serializable transaction is
+// rolled back and its connection returned even when the caller set no deadline, as background
+// jobs do.
+func WithTxTimeout(d time.Duration) StoreOption {
+	return func(store *Store) {
+		store.txTimeout = d
+	}
 }
 
 // NewStore constructs a Store backed by the given database connection.
-func NewStore(db *sql.DB) *Store {
-	return &Store{
+func NewStore(db *sql.DB, opts ...StoreOption) *Store {
+	store := &Store{
 		Queries: sqlc.New(db),
 		db:      db,
 	}
+	for _, opt := range opts {
+		opt(store)
+	}
+	return store
 }
 
 // isSerializationError reports whether err is a PostgreSQL serialization failure.
@@ -33,6 +51,30 @@ func isSerializationError(err error) bool {
 	return errors.As(err, &pqErr) && pqErr.Code == "40001"
 }
 
+// CustomerBalanceConstraint is the CHECK that keeps non-system account balances within their overdraft limit.
+const CustomerBalanceConstraint = "accounts_customer_balance_within_overdraft"
+
+// BalancedTransactionConstraint is the deferred trigger that refuses to commit a transaction
+// whose entries do not net to zero in each currency.
+const BalancedTransactionConstraint = "entries_transaction_balanced"
+
+// ErrUnbalancedTransaction is returned by ExecTx when the commit was refused because a
+// transaction's debits and credits differ.
+var ErrUnbalancedTransaction = errors.New("ledger transaction does not balance")
+
+// IsCheckViolation reports whether err is a PostgreSQL check violation (SQLSTATE 23514) of constraint.
+func IsCheckViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23514" && pqErr.Constraint == constraint
+}
+
+// IsTimeout reports whether err is a database call cut off by its deadline: the context error, or
+// PostgreSQL cancelling the statement that was running when it passed (SQLSTATE 57014).
+func IsTimeout(err error) bool {
+	var pqErr *pq.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &pqErr) && pqErr.Code == "57014")
+}
+
 // ExecTx runs fn inside a transaction and handles rollback on error.
 // Serialization failures (SQLSTATE 40001) are automatically retried up to maxAttempts times.
 func (store *Store) ExecTx(ctx context.Context, fn func(q *sqlc.Queries) error) error {
@@ -59,6 +101,13 @@ func (store *Store) ExecTx(ctx context.Context, fn func(q *sqlc.Queries) error)
 }
 
 func (store *Store) execTxOnce(ctx context.Context, fn func(q *sqlc.Queries) error) error {
+	// database/sql rolls the transaction back once ctx is done, so the deadline frees the connection.
+	if store.txTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, store.txTimeout)
+		defer cancel()
+	}
+
 	// Use serializable isolation to protect balance-changing flows from race anomalies.
 	tx, err := store.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
@@ -75,13 +124,38 @@ func (store *Store) execTxOnce(ctx context.Context, fn func(q *sqlc.Queries) err
 		return err
 	}
 
+	// Deferred constraints, such as the per-transaction balance check, run here.
 	if err := tx.Commit(); err != nil {
+		if IsCheckViolation(err, BalancedTransactionConstraint) {
+			return fmt.Errorf("%w: %v", ErrUnbalancedTransaction, err)
+		}
 		return fmt.Errorf("commit failed: %w", err)
 	}
 
 	return nil
 }
 
+// ReadTx runs fn inside a read-only repeatable-read transaction, so every query fn makes sees the
+// database as of the same moment while writers carry on. It is never retried.
+func (store *Store) ReadTx(ctx context.Context, fn func(q *sqlc.Queries) error) error {
+	if store.txTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, store.txTimeout)
+		defer cancel()
+	}
+	tx, err := store.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	if err := fn(sqlc.New(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx failed: %w, rollback failed: %v", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
 // retryWait returns a capped exponential backoff duration for the given attempt (0-based).
 func retryWait(attempt int) time.Duration {
 	// Exponential backoff: 50ms, 100ms, 200ms ... capped at 1s.
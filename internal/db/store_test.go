@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -21,6 +22,15 @@ func TestIsSerializationError(t *testing.T) {
 	assert.False(t, isSerializationError(errors.New("some other error")))
 }
 
+func TestIsCheckViolation(t *testing.T) {
+	// Only SQLSTATE 23514 on the named constraint matches, including when wrapped.
+	pqErr := &pq.Error{Code: "23514", Constraint: CustomerBalanceConstraint}
+	assert.True(t, IsCheckViolation(pqErr, CustomerBalanceConstraint))
+	assert.True(t, IsCheckViolation(fmt.Errorf("update balance: %w", pqErr), CustomerBalanceConstraint))
+	assert.False(t, IsCheckViolation(&pq.Error{Code: "23514", Constraint: "other"}, CustomerBalanceConstraint))
+	assert.False(t, IsCheckViolation(nil, CustomerBalanceConstraint))
+}
+
 func TestRetryWait(t *testing.T) {
 	// Backoff should grow exponentially and cap at one second.
 	assert.Equal(t, 50*time.Millisecond, retryWait(0))
@@ -42,3 +52,10 @@ func TestNewStore(t *testing.T) {
 	store := NewStore(db)
 	assert.NotNil(t, store)
 }
+
+func TestIsTimeout(t *testing.T) {
+	assert.True(t, IsTimeout(fmt.Errorf("lock account: %w", context.DeadlineExceeded)))
+	assert.True(t, IsTimeout(&pq.Error{Code: "57014"}))
+	assert.False(t, IsTimeout(context.Canceled))
+	assert.False(t, IsTimeout(errors.New("insufficient funds")))
+}
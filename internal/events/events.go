@@ -0,0 +1,123 @@
+// Package events carries committed ledger changes to out-of-band consumers.
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// Type identifies the business operation that produced an event.
+type Type string
+
+const (
+	// TypeDeposit is published after an external deposit commits.
+	TypeDeposit Type = "deposit"
+	// TypeWithdrawal is published after an external withdrawal commits.
+	TypeWithdrawal Type = "withdrawal"
+	// TypeTransfer is published after an internal transfer commits.
+	TypeTransfer Type = "transfer"
+	// TypeReversal is published when a held withdrawal is returned to the customer.
+	TypeReversal Type = "reversal"
+	// TypeDispute is published when disputed funds move into or out of the holding account.
+	TypeDispute Type = "dispute"
+	// TypeConversion is published after money is converted between currencies.
+	TypeConversion Type = "conversion"
+	// TypeInterest is published after monthly interest is paid into an account.
+	TypeInterest Type = "interest"
+	// TypeEscrow is published when an escrow is funded, released or refunded.
+	TypeEscrow Type = "escrow"
+	// TypePaymentRequest is published when a payer settles a payment request.
+	TypePaymentRequest Type = "payment_request"
+	// TypeLoan is published when a loan is disbursed or a repayment is posted.
+	TypeLoan Type = "loan"
+	// TypeAdjustment is published when an admin's corrective journal is posted.
+	TypeAdjustment Type = "adjustment"
+)
+
+// Event describes one committed ledger transaction.
+//
+//nolint:govet // Field order mirrors how consumers read the event.
+type Event struct {
+	Type          Type
+	TransactionID uuid.UUID
+	Amount        string
+	Currency      string
+	// Entries holds every leg written by the transaction.
+	Entries []sqlc.Entry
+	// Balances maps each touched account to its cached balance after commit.
+	Balances   map[uuid.UUID]string
+	OccurredAt time.Time
+}
+
+// Handler consumes a committed event. Returned errors are logged, never retried.
+type Handler func(ctx context.Context, evt Event) error
+
+// Publisher accepts events once the producing transaction has committed.
+type Publisher interface {
+	Publish(ctx context.Context, evt Event)
+}
+
+type subscription struct {
+	handle Handler
+	name   string
+}
+
+// Bus fans events out to subscribers asynchronously so slow consumers
+// (email, SMS, sockets) never extend the request that moved the money.
+type Bus struct {
+	subs []subscription
+	wg   sync.WaitGroup
+	mu   sync.RWMutex
+}
+
+// NewBus constructs an empty event bus.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a named handler for every published event.
+func (b *Bus) Subscribe(name string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = append(b.subs, subscription{name: name, handle: h})
+}
+
+// Publish delivers evt to every subscriber in its own goroutine.
+func (b *Bus) Publish(ctx context.Context, evt Event) {
+	b.mu.RLock()
+	subs := make([]subscription, len(b.subs))
+	copy(subs, b.subs)
+	b.mu.RUnlock()
+
+	// Detach from request cancellation: the money already moved, consumers must still run.
+	ctx = context.WithoutCancel(ctx)
+	for _, sub := range subs {
+		b.wg.Add(1)
+		go func(sub subscription) {
+			defer b.wg.Done()
+			defer func() {
+				if rec := recover(); rec != nil {
+					log.Error().Interface("panic", rec).Str("subscriber", sub.name).Msg("Event subscriber panicked")
+				}
+			}()
+			if err := sub.handle(ctx, evt); err != nil {
+				log.Error().Err(err).
+					Str("subscriber", sub.name).
+					Str("tx_id", evt.TransactionID.String()).
+					Str("type", string(evt.Type)).
+					Msg("Event subscriber failed")
+			}
+		}(sub)
+	}
+}
+
+// Wait blocks until all in-flight deliveries finish. Used on shutdown and in tests.
+func (b *Bus) Wait() {
+	b.wg.Wait()
+}
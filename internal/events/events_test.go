@@ -0,0 +1,49 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_PublishDeliversToAllSubscribers(t *testing.T) {
+	// Every subscriber sees the event, even when a sibling fails or panics.
+	bus := NewBus()
+	var delivered atomic.Int32
+	bus.Subscribe("ok", func(_ context.Context, _ Event) error {
+		delivered.Add(1)
+		return nil
+	})
+	bus.Subscribe("failing", func(_ context.Context, _ Event) error {
+		delivered.Add(1)
+		return errors.New("boom")
+	})
+	bus.Subscribe("panicking", func(_ context.Context, _ Event) error {
+		delivered.Add(1)
+		panic("boom")
+	})
+
+	bus.Publish(context.Background(), Event{Type: TypeDeposit, TransactionID: uuid.New()})
+	bus.Wait()
+	assert.Equal(t, int32(3), delivered.Load())
+}
+
+func TestBus_PublishIgnoresRequestCancellation(t *testing.T) {
+	// Consumers run after the HTTP request is gone, so they get a live context.
+	bus := NewBus()
+	var ctxErr error
+	bus.Subscribe("ctx", func(ctx context.Context, _ Event) error {
+		ctxErr = ctx.Err()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bus.Publish(ctx, Event{Type: TypeTransfer})
+	bus.Wait()
+	assert.NoError(t, ctxErr)
+}
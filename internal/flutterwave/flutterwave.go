@@ -0,0 +1,147 @@
+// Package flutterwave is a minimal client for Flutterwave bank transfers (payouts)
+// and their asynchronous result webhooks.
+package flutterwave
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// HashHeader carries the secret hash configured on the Flutterwave dashboard.
+const HashHeader = "verif-hash"
+
+// EventTransferCompleted is sent when a transfer reaches a final state.
+const EventTransferCompleted = "transfer.completed"
+
+// Final transfer statuses reported by Flutterwave.
+const (
+	StatusSuccessful = "SUCCESSFUL"
+	StatusFailed     = "FAILED"
+)
+
+// APIError is a definite rejection from Flutterwave: the transfer was not queued.
+// Transport errors are returned as-is because the transfer may or may not exist.
+type APIError struct {
+	Message    string
+	StatusCode int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("flutterwave returned %d: %s", e.StatusCode, e.Message)
+}
+
+// Client calls the Flutterwave v3 API.
+type Client struct {
+	httpClient *http.Client
+	secretKey  string
+	secretHash string
+	baseURL    string
+}
+
+// NewClient constructs a Client. secretHash authenticates incoming webhooks.
+func NewClient(secretKey, secretHash string) (*Client, error) {
+	if secretKey == "" || secretHash == "" {
+		return nil, errors.New("flutterwave secret key and webhook secret hash are required")
+	}
+	return &Client{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		secretKey:  secretKey,
+		secretHash: secretHash,
+		baseURL:    "https://api.flutterwave.com/v3",
+	}, nil
+}
+
+// TransferRequest is a payout to a bank account. Amount is in major units (e.g., 5000.50);
+// json.Number keeps it exact on the wire.
+type TransferRequest struct {
+	AccountBank   string      `json:"account_bank"`
+	AccountNumber string      `json:"account_number"`
+	Currency      string      `json:"currency"`
+	Narration     string      `json:"narration,omitempty"`
+	Reference     string      `json:"reference"`
+	CallbackURL   string      `json:"callback_url,omitempty"`
+	Amount        json.Number `json:"amount"`
+}
+
+// Transfer is Flutterwave's view of a queued or completed transfer.
+type Transfer struct {
+	Reference       string      `json:"reference"`
+	Status          string      `json:"status"`
+	Currency        string      `json:"currency"`
+	CompleteMessage string      `json:"complete_message"`
+	Amount          json.Number `json:"amount"`
+	ID              int64       `json:"id"`
+}
+
+// CreateTransfer queues a payout. The final outcome arrives via webhook.
+func (c *Client) CreateTransfer(ctx context.Context, in TransferRequest) (Transfer, error) {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return Transfer{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/transfers", bytes.NewReader(payload))
+	if err != nil {
+		return Transfer{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.secretKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Transfer{}, fmt.Errorf("flutterwave request failed: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Warn().Err(closeErr).Msg("Failed to close flutterwave response body")
+		}
+	}()
+
+	var body struct {
+		Status  string   `json:"status"`
+		Message string   `json:"message"`
+		Data    Transfer `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return Transfer{}, fmt.Errorf("flutterwave returned %d with unreadable body: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || body.Status != "success" {
+		return Transfer{}, &APIError{StatusCode: resp.StatusCode, Message: body.Message}
+	}
+	return body.Data, nil
+}
+
+// VerifyHash reports whether the webhook's verif-hash header matches the configured secret.
+func (c *Client) VerifyHash(header string) bool {
+	return header != "" && subtle.ConstantTimeCompare([]byte(header), []byte(c.secretHash)) == 1
+}
+
+// WebhookEvent is a Flutterwave webhook notification.
+type WebhookEvent struct {
+	Event string   `json:"event"`
+	Data  Transfer `json:"data"`
+}
+
+// ParseWebhook decodes a webhook body. Call VerifyHash first.
+func ParseWebhook(body []byte) (WebhookEvent, error) {
+	var evt WebhookEvent
+	if err := json.Unmarshal(body, &evt); err != nil {
+		return WebhookEvent{}, fmt.Errorf("decode flutterwave webhook: %w", err)
+	}
+	return evt, nil
+}
+
+// NewReference returns a unique transfer reference for a payout.
+func NewReference() string {
+	return "wdr_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+}
@@ -0,0 +1,65 @@
+package flutterwave
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyHash(t *testing.T) {
+	// Only the exact configured secret hash authenticates a webhook.
+	c, err := NewClient("FLWSECK_TEST-x", "my-hash")
+	require.NoError(t, err)
+	assert.True(t, c.VerifyHash("my-hash"))
+	assert.False(t, c.VerifyHash("my-has"))
+	assert.False(t, c.VerifyHash(""))
+}
+
+func TestCreateTransfer(t *testing.T) {
+	// Accepted transfers return the queued transfer; rejections surface as APIError.
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/transfers", r.URL.Path)
+		assert.Equal(t, "Bearer FLWSECK_TEST-x", r.Header.Get("Authorization"))
+		var in map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		if calls == 1 {
+			assert.Equal(t, 5000.5, in["amount"])
+			_, _ = w.Write([]byte(`{"status":"success","message":"Transfer Queued Successfully","data":{"id":42,"reference":"wdr_1","status":"NEW","amount":5000.5,"currency":"NGN"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":"error","message":"Insufficient balance"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient("FLWSECK_TEST-x", "my-hash")
+	require.NoError(t, err)
+	c.baseURL = srv.URL
+
+	req := TransferRequest{AccountBank: "044", AccountNumber: "0690000040", Currency: "NGN", Reference: "wdr_1", Amount: json.Number("5000.5000")}
+	tr, err := c.CreateTransfer(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), tr.ID)
+
+	_, err = c.CreateTransfer(context.Background(), req)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "Insufficient balance", apiErr.Message)
+}
+
+func TestParseWebhook(t *testing.T) {
+	// transfer.completed carries the reference and final status.
+	evt, err := ParseWebhook([]byte(`{"event":"transfer.completed","data":{"id":42,"reference":"wdr_1","status":"FAILED","complete_message":"Account resolution failed","amount":100,"currency":"NGN"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, EventTransferCompleted, evt.Event)
+	assert.Equal(t, StatusFailed, evt.Data.Status)
+	assert.Equal(t, "Account resolution failed", evt.Data.CompleteMessage)
+}
@@ -0,0 +1,114 @@
+// Package geo works out which country a request came from. A Locator either trusts a country
+// header set by the CDN or proxy in front of the API, or looks the client address up in a table of
+// IP ranges loaded from a file, e.g. one of the free country databases exported to CSV.
+package geo
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Locator returns the ISO 3166-1 alpha-2 country of a request, or "" when it cannot tell.
+type Locator interface {
+	Country(r *http.Request) string
+}
+
+// normalizeCountry upper-cases a two-letter code, dropping the placeholders CDNs use for unknown
+// addresses (XX) and Tor (T1).
+func normalizeCountry(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 2 || code == "XX" || code == "T1" || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return ""
+	}
+	return code
+}
+
+// HeaderLocator reads the country from a header set by a trusted proxy, e.g. CF-IPCountry. Only
+// use it when clients cannot reach the API without passing through that proxy.
+type HeaderLocator string
+
+// Country implements Locator.
+func (h HeaderLocator) Country(r *http.Request) string {
+	return normalizeCountry(r.Header.Get(string(h)))
+}
+
+type ipRange struct {
+	start, end netip.Addr
+	country    string
+}
+
+// RangeLocator looks the client address up in sorted, non-overlapping IP ranges.
+type RangeLocator struct {
+	ranges []ipRange
+}
+
+// LoadRanges reads a CSV file of "first_ip,last_ip,country" rows, IPv4 or IPv6. Blank lines and
+// lines starting with # are skipped.
+func LoadRanges(path string) (*RangeLocator, error) {
+	f, err := os.Open(path) // #nosec G304 -- path comes from operator configuration
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = 3
+	r.TrimLeadingSpace = true
+	l := &RangeLocator{}
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("geo: %s: %w", path, err)
+		}
+		start, errStart := netip.ParseAddr(strings.TrimSpace(rec[0]))
+		end, errEnd := netip.ParseAddr(strings.TrimSpace(rec[1]))
+		if errStart != nil || errEnd != nil || start.Is4() != end.Is4() || end.Less(start) {
+			line, _ := r.FieldPos(0)
+			return nil, fmt.Errorf("geo: %s:%d: invalid range %s-%s", path, line, rec[0], rec[1])
+		}
+		if country := normalizeCountry(rec[2]); country != "" {
+			l.ranges = append(l.ranges, ipRange{start: start, end: end, country: country})
+		}
+	}
+	sort.Slice(l.ranges, func(i, j int) bool { return l.ranges[i].start.Less(l.ranges[j].start) })
+	return l, nil
+}
+
+// Len is the number of ranges loaded.
+func (l *RangeLocator) Len() int { return len(l.ranges) }
+
+// Lookup returns the country of addr, or "" when no range holds it.
+func (l *RangeLocator) Lookup(addr netip.Addr) string {
+	addr = addr.Unmap()
+	// The last range starting at or before addr is the only one that can hold it.
+	i := sort.Search(len(l.ranges), func(i int) bool { return addr.Less(l.ranges[i].start) }) - 1
+	if i < 0 || l.ranges[i].start.Is4() != addr.Is4() || l.ranges[i].end.Less(addr) {
+		return ""
+	}
+	return l.ranges[i].country
+}
+
+// Country implements Locator with the address the request came from.
+func (l *RangeLocator) Country(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return ""
+	}
+	return l.Lookup(addr)
+}
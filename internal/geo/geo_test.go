@@ -0,0 +1,49 @@
+package geo
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderLocator(t *testing.T) {
+	// The proxy's header is trusted once normalized; unknown and Tor placeholders are not countries.
+	r := httptest.NewRequest("GET", "/", nil)
+	loc := HeaderLocator("CF-IPCountry")
+	assert.Equal(t, "", loc.Country(r))
+	r.Header.Set("CF-IPCountry", "ng")
+	assert.Equal(t, "NG", loc.Country(r))
+	for _, v := range []string{"XX", "T1", "NGA", "1A"} {
+		r.Header.Set("CF-IPCountry", v)
+		assert.Equal(t, "", loc.Country(r), v)
+	}
+}
+
+func TestRangeLocator(t *testing.T) {
+	// Addresses resolve to the range holding them, IPv4-mapped IPv6 included; gaps resolve to nothing.
+	path := filepath.Join(t.TempDir(), "ranges.csv")
+	require.NoError(t, os.WriteFile(path, []byte("# country ranges\n102.88.0.0,102.91.255.255,NG\n81.2.69.0,81.2.69.255,gb\n2a00:1450::,2a00:1450:ffff:ffff:ffff:ffff:ffff:ffff,IE\n"), 0o600))
+	l, err := LoadRanges(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, l.Len())
+
+	assert.Equal(t, "NG", l.Lookup(netip.MustParseAddr("102.89.1.1")))
+	assert.Equal(t, "GB", l.Lookup(netip.MustParseAddr("81.2.69.160")))
+	assert.Equal(t, "GB", l.Lookup(netip.MustParseAddr("::ffff:81.2.69.160")))
+	assert.Equal(t, "IE", l.Lookup(netip.MustParseAddr("2a00:1450::1")))
+	assert.Equal(t, "", l.Lookup(netip.MustParseAddr("81.2.70.1")))
+	assert.Equal(t, "", l.Lookup(netip.MustParseAddr("1.1.1.1")))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "102.88.0.7:51234"
+	assert.Equal(t, "NG", l.Country(r))
+
+	require.NoError(t, os.WriteFile(path, []byte("10.0.0.9,10.0.0.1,NG\n"), 0o600))
+	_, err = LoadRanges(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,203 @@
+// Package glexport renders ledger activity as general ledger journals for the corporate books:
+// Xero's manual journal CSV import and QuickBooks Desktop IIF. Each UTC day of one currency
+// becomes one journal whose lines are the net movement of each external GL account.
+package glexport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// Export formats.
+const (
+	FormatXero = "xero"
+	FormatIIF  = "iif"
+)
+
+// xeroTaxRate marks journal lines as outside VAT/GST; ledger movements carry no tax of their own.
+const xeroTaxRate = "Tax Exempt"
+
+// ErrUnknownFormat is returned for a format other than xero or iif.
+var ErrUnknownFormat = errors.New("format must be xero or iif")
+
+// UnmappedError lists the accounts with activity but no GL code, which would leave a journal
+// unbalanced in the books.
+type UnmappedError struct {
+	Names []string
+}
+
+func (e *UnmappedError) Error() string {
+	return "accounts without a GL code: " + strings.Join(e.Names, ", ")
+}
+
+// Line is one GL account's net movement: positive is a debit, negative a credit.
+type Line struct {
+	Code   string
+	Name   string
+	Amount decimal.Decimal
+}
+
+// Journal is one day's balanced activity in one currency.
+type Journal struct {
+	Date     time.Time
+	Currency string
+	Lines    []Line
+}
+
+// Memo describes the journal in the target system.
+func (j Journal) Memo() string {
+	return fmt.Sprintf("Ledger activity %s (%s)", j.Date.Format("2006-01-02"), j.Currency)
+}
+
+// Journals groups daily activity rows, as GLDailyActivity returns them, into journals with
+// amounts rounded to cents. Rounding differences go to the day's largest line so every journal
+// still balances. Days that net to nothing are left out.
+func Journals(rows []sqlc.GLDailyActivityRow, currency string) ([]Journal, error) {
+	var (
+		journals []Journal
+		unmapped = map[string]bool{}
+	)
+	for _, row := range rows {
+		if row.GlCode == "" {
+			unmapped[row.GlName] = true
+			continue
+		}
+		amount, err := decimal.NewFromString(row.Net)
+		if err != nil {
+			return nil, fmt.Errorf("invalid net amount for %s on %s: %w", row.GlCode, row.Day.Format("2006-01-02"), err)
+		}
+		if n := len(journals); n == 0 || !journals[n-1].Date.Equal(row.Day) {
+			journals = append(journals, Journal{Date: row.Day, Currency: currency})
+		}
+		j := &journals[len(journals)-1]
+		j.Lines = append(j.Lines, Line{Code: row.GlCode, Name: row.GlName, Amount: amount})
+	}
+	if len(unmapped) > 0 {
+		names := make([]string, 0, len(unmapped))
+		for name := range unmapped {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, &UnmappedError{Names: names}
+	}
+
+	out := journals[:0]
+	for _, j := range journals {
+		j.Lines = roundLines(j.Lines)
+		if len(j.Lines) > 0 {
+			out = append(out, j)
+		}
+	}
+	return out, nil
+}
+
+// roundLines rounds each line to cents, moves the rounding difference onto the largest line
+// and drops lines that round to zero.
+func roundLines(lines []Line) []Line {
+	total := decimal.Zero
+	largest := -1
+	rounded := make([]Line, 0, len(lines))
+	for _, l := range lines {
+		l.Amount = l.Amount.Round(2)
+		if l.Amount.IsZero() {
+			continue
+		}
+		total = total.Add(l.Amount)
+		rounded = append(rounded, l)
+		if largest < 0 || l.Amount.Abs().GreaterThan(rounded[largest].Amount.Abs()) {
+			largest = len(rounded) - 1
+		}
+	}
+	if largest >= 0 && !total.IsZero() {
+		rounded[largest].Amount = rounded[largest].Amount.Sub(total)
+	}
+	return rounded
+}
+
+// Write renders journals in format.
+func Write(w io.Writer, format string, journals []Journal) error {
+	switch format {
+	case FormatXero:
+		return writeXero(w, journals)
+	case FormatIIF:
+		return writeIIF(w, journals)
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+// ContentType is the MIME type of a format's file.
+func ContentType(format string) string {
+	if format == FormatIIF {
+		return "text/plain; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Extension is the file extension of format.
+func Extension(format string) string {
+	if format == FormatIIF {
+		return "iif"
+	}
+	return "csv"
+}
+
+// writeXero writes Xero's manual journal import: one row per line, journals grouped by
+// narration and date (DD/MM/YYYY), debits positive and credits negative.
+func writeXero(w io.Writer, journals []Journal) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"*Narration", "*Date", "Description", "*AccountCode", "*TaxRate", "*Amount"}); err != nil {
+		return err
+	}
+	for _, j := range journals {
+		for _, l := range j.Lines {
+			if err := cw.Write([]string{j.Memo(), j.Date.Format("02/01/2006"), l.Name, l.Code, xeroTaxRate, l.Amount.StringFixed(2)}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeIIF writes QuickBooks Desktop general journal entries: a TRNS line, SPL lines for the
+// rest and ENDTRNS, tab separated, dates MM/DD/YYYY. QuickBooks matches ACCNT by account name.
+func writeIIF(w io.Writer, journals []Journal) error {
+	header := "!TRNS\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO\n" +
+		"!SPL\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO\n" +
+		"!ENDTRNS\n"
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+	for _, j := range journals {
+		date := j.Date.Format("01/02/2006")
+		docnum := "GL" + j.Date.Format("20060102") + j.Currency
+		for i, l := range j.Lines {
+			kind := "SPL"
+			if i == 0 {
+				kind = "TRNS"
+			}
+			if _, err := fmt.Fprintf(w, "%s\tGENERAL JOURNAL\t%s\t%s\t%s\t%s\t%s\n", kind, date, iifField(l.Name), l.Amount.StringFixed(2), docnum, iifField(j.Memo())); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, "ENDTRNS\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iifField keeps a value on its own tab-separated field.
+func iifField(s string) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ", `"`, "'").Replace(s)
+}
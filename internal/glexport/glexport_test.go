@@ -0,0 +1,90 @@
+package glexport
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	day1 = time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	day2 = time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+)
+
+func TestJournals(t *testing.T) {
+	// Rows become one balanced journal per day, rounded to cents.
+	rows := []sqlc.GLDailyActivityRow{
+		{Day: day1, GlCode: "1000", GlName: "Bank", Net: "100.0000"},
+		{Day: day1, GlCode: "2100", GlName: "Customer deposits", Net: "-100.0000"},
+		{Day: day2, GlCode: "2100", GlName: "Customer deposits", Net: "-33.3333"},
+		{Day: day2, GlCode: "5100", GlName: "Interest expense", Net: "33.3333"},
+		{Day: day2, GlCode: "2100", GlName: "Customer deposits", Net: "0.0000"},
+	}
+	journals, err := Journals(rows, "NGN")
+	require.NoError(t, err)
+	require.Len(t, journals, 2)
+	assert.Equal(t, "Ledger activity 2026-03-14 (NGN)", journals[0].Memo())
+	assert.Len(t, journals[1].Lines, 2)
+	assert.Equal(t, "33.33", journals[1].Lines[1].Amount.StringFixed(2))
+}
+
+func TestJournals_Unmapped(t *testing.T) {
+	// Accounts without a GL code are reported instead of exported.
+	_, err := Journals([]sqlc.GLDailyActivityRow{
+		{Day: day1, GlCode: "1000", GlName: "Bank", Net: "5"},
+		{Day: day1, GlName: "Fee Income", Net: "-5"},
+		{Day: day2, GlName: "Customer accounts", Net: "1"},
+	}, "NGN")
+	var unmapped *UnmappedError
+	require.True(t, errors.As(err, &unmapped))
+	assert.Equal(t, []string{"Customer accounts", "Fee Income"}, unmapped.Names)
+}
+
+func TestRoundLines(t *testing.T) {
+	// Cents rounding leaves the journal balanced by adjusting its largest line.
+	lines := roundLines([]Line{
+		{Code: "a", Amount: decimal.RequireFromString("10.005")},
+		{Code: "b", Amount: decimal.RequireFromString("10.005")},
+		{Code: "c", Amount: decimal.RequireFromString("-20.01")},
+		{Code: "d", Amount: decimal.RequireFromString("0.001")},
+		{Code: "e", Amount: decimal.RequireFromString("-0.001")},
+	})
+	require.Len(t, lines, 3)
+	total := decimal.Zero
+	for _, l := range lines {
+		total = total.Add(l.Amount)
+	}
+	assert.True(t, total.IsZero())
+}
+
+func TestWrite(t *testing.T) {
+	// Xero rows carry narration, DD/MM/YYYY date and signed amounts; IIF wraps each journal in TRNS/SPL/ENDTRNS.
+	journals := []Journal{{Date: day1, Currency: "USD", Lines: []Line{
+		{Code: "1000", Name: "Bank", Amount: decimal.RequireFromString("12.50")},
+		{Code: "2100", Name: "Customer\tdeposits", Amount: decimal.RequireFromString("-12.50")},
+	}}}
+
+	var xero bytes.Buffer
+	require.NoError(t, Write(&xero, FormatXero, journals))
+	assert.Equal(t, "*Narration,*Date,Description,*AccountCode,*TaxRate,*Amount\n"+
+		"Ledger activity 2026-03-14 (USD),14/03/2026,Bank,1000,Tax Exempt,12.50\n"+
+		"Ledger activity 2026-03-14 (USD),14/03/2026,Customer\tdeposits,2100,Tax Exempt,-12.50\n", xero.String())
+
+	var iif bytes.Buffer
+	require.NoError(t, Write(&iif, FormatIIF, journals))
+	lines := strings.Split(strings.TrimSpace(iif.String()), "\n")
+	require.Len(t, lines, 6)
+	assert.Equal(t, "TRNS\tGENERAL JOURNAL\t03/14/2026\tBank\t12.50\tGL20260314USD\tLedger activity 2026-03-14 (USD)", lines[3])
+	assert.True(t, strings.HasPrefix(lines[4], "SPL\tGENERAL JOURNAL\t03/14/2026\tCustomer deposits\t-12.50"))
+	assert.Equal(t, "ENDTRNS", lines[5])
+
+	assert.ErrorIs(t, Write(&iif, "pdf", journals), ErrUnknownFormat)
+}
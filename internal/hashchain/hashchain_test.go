@@ -0,0 +1,69 @@
+package hashchain
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEntry() Entry {
+	return Entry{
+		ID:            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		AccountID:     uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+		TransactionID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+		Seq:           1,
+		Debit:         "0.0000",
+		Credit:        "10.0000",
+		OperationType: "deposit",
+		Description:   sql.NullString{String: "External deposit", Valid: true},
+		CreatedAt:     sql.NullTime{Time: time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC), Valid: true},
+	}
+}
+
+func TestHash(t *testing.T) {
+	// The hash is stable, and every field, the previous hash and field boundaries change it.
+	e := testEntry()
+	h := Hash(nil, e)
+	assert.Len(t, h, 32)
+	assert.Equal(t, hex.EncodeToString(h), hex.EncodeToString(Hash(nil, e)))
+
+	assert.NotEqual(t, h, Hash(h, e))
+	changed := e
+	changed.Credit = "10.0001"
+	assert.NotEqual(t, h, Hash(nil, changed))
+	null, empty := e, e
+	null.Description, empty.Description = sql.NullString{}, sql.NullString{Valid: true}
+	assert.NotEqual(t, Hash(nil, null), Hash(nil, empty), "empty and NULL descriptions differ")
+
+	a, b := e, e
+	a.OperationType, a.Description.String = "deposit", "x"
+	b.OperationType, b.Description.String = "deposi", "tx"
+	assert.NotEqual(t, Hash(nil, a), Hash(nil, b))
+}
+
+func TestCheck(t *testing.T) {
+	// An intact chain passes; edits, gaps and relinks are each reported.
+	first := testEntry()
+	firstHash := Hash(nil, first)
+	second := testEntry()
+	second.ID, second.Seq = uuid.New(), 2
+	secondHash := Hash(firstHash, second)
+
+	assert.Empty(t, Check(first, nil, firstHash, nil))
+	link := &Link{Seq: 1, Hash: firstHash}
+	assert.Empty(t, Check(second, firstHash, secondHash, link))
+
+	edited := second
+	edited.Credit = "99.0000"
+	assert.Equal(t, []string{ProblemHash}, Check(edited, firstHash, secondHash, link))
+
+	gap := second
+	gap.Seq = 3
+	assert.Contains(t, Check(gap, firstHash, Hash(firstHash, gap), link), ProblemSequence)
+
+	assert.Equal(t, []string{ProblemPrevHash}, Check(second, nil, Hash(nil, second), link))
+}
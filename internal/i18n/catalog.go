@@ -0,0 +1,9 @@
+package i18n
+
+// catalogs maps each locale to its translations, keyed by the English text. English needs none.
+var catalogs = map[string]map[string]string{
+	"yo": yoruba,
+	"ha": hausa,
+	"ig": igbo,
+	"fr": french,
+}
@@ -0,0 +1,69 @@
+package i18n
+
+var french = map[string]string{
+	// API errors.
+	"invalid token":          "jeton invalide",
+	"access denied":          "accès refusé",
+	"forbidden":              "interdit",
+	"invalid account ID":     "identifiant de compte invalide",
+	"invalid transaction ID": "identifiant de transaction invalide",
+	"account not found":      "compte introuvable",
+	"transaction not found":  "transaction introuvable",
+	"invalid amount":         "montant invalide",
+	"invalid credentials":    "identifiants invalides",
+	"user not found":         "utilisateur introuvable",
+
+	// Ledger errors.
+	"insufficient funds":                                      "fonds insuffisants",
+	"cannot transfer to the same account":                     "impossible de virer vers le même compte",
+	"amount must be positive":                                 "le montant doit être positif",
+	"amount has more decimal places than the currency allows": "le montant a plus de décimales que la devise n'en autorise",
+	"currency mismatch":                                       "les devises ne correspondent pas",
+	"cannot transfer between organizations":                   "impossible de virer entre organisations",
+	"identity verification required":                          "vérification d'identité requise",
+	"amount exceeds the limit for your verification level":    "le montant dépasse la limite de votre niveau de vérification",
+	"transaction is pending review":                           "la transaction est en attente d'examen",
+	"transaction declined":                                    "transaction refusée",
+	"additional verification required":                        "vérification supplémentaire requise",
+	"savings goal is locked":                                  "l'objectif d'épargne est bloqué",
+	"amount exceeds available balance: the account's minimum balance must remain": "le montant dépasse le solde disponible : le solde minimum du compte doit être conservé",
+	"amount exceeds the account's per-transaction limit":                          "le montant dépasse la limite par transaction du compte",
+	"operation not allowed for this account type":                                 "opération non autorisée pour ce type de compte",
+
+	// Transaction alerts.
+	"Credit alert: %s %s on %s":                                                     "Alerte crédit : %s %s sur %s",
+	"Debit alert: %s %s on %s":                                                      "Alerte débit : %s %s sur %s",
+	"Hello,\n\nA credit of %s %s has posted to your account %q.\n\n":                "Bonjour,\n\nUn crédit de %s %s a été enregistré sur votre compte %q.\n\n",
+	"Hello,\n\nA debit of %s %s has posted to your account %q.\n\n":                 "Bonjour,\n\nUn débit de %s %s a été enregistré sur votre compte %q.\n\n",
+	"Transaction: %s\nType: %s\nAvailable balance: %s %s\n\n":                       "Transaction : %s\nType : %s\nSolde disponible : %s %s\n\n",
+	"If you did not expect this transaction, please contact support immediately.\n": "Si vous n'attendiez pas cette transaction, contactez immédiatement le support.\n",
+
+	// Statements.
+	"Your %s statement for %s":                                             "Votre relevé de %s pour %s",
+	"Hello,\n\nYour statement for %q covering %s is ready.\n\n":            "Bonjour,\n\nVotre relevé pour %q couvrant %s est disponible.\n\n",
+	"Opening balance: %s %s\nClosing balance: %s %s\n\n":                   "Solde d'ouverture : %s %s\nSolde de clôture : %s %s\n\n",
+	"Download it (ISO 20022 camt.053) until %s:\n%s/statements/%s/%s?%s\n": "Téléchargez-le (ISO 20022 camt.053) jusqu'au %s :\n%s/statements/%s/%s?%s\n",
+
+	// Security alerts.
+	"Your account was used from a new country": "Votre compte a été utilisé depuis un nouveau pays",
+	"Your account was used from %s at %s UTC, shortly after activity from %s.\n\nAddress: %s\n\nIf this was you, there is nothing to do, though payments may ask you to confirm them for a while. If it wasn't, change your password now; that also logs out every device.": "Votre compte a été utilisé depuis %s le %s UTC, peu après une activité depuis %s.\n\nAdresse : %s\n\nSi c'était vous, vous n'avez rien à faire, mais les paiements pourront vous demander une confirmation pendant un certain temps. Sinon, changez votre mot de passe maintenant ; cela déconnecte aussi tous les appareils.",
+	"Your login was temporarily locked": "Votre connexion a été temporairement bloquée",
+	"We locked your login until %s UTC after %d failed sign-in attempts, the last from %s.\n\nIf this wasn't you, someone may be trying to guess your password. Consider changing it once the lock lifts, and contact support if you need help.": "Nous avons bloqué votre connexion jusqu'au %s UTC après %d tentatives de connexion échouées, la dernière depuis %s.\n\nSi ce n'était pas vous, quelqu'un essaie peut-être de deviner votre mot de passe. Pensez à le changer une fois le blocage levé, et contactez le support si vous avez besoin d'aide.",
+	"New sign-in to your account": "Nouvelle connexion à votre compte",
+	"Your account was signed in to from a new device at %s UTC.\n\nDevice: %s\nAddress: %s\n\nIf this was you, there is nothing to do. If it wasn't, change your password now; that also logs out every device.": "Une connexion à votre compte a eu lieu depuis un nouvel appareil le %s UTC.\n\nAppareil : %s\nAdresse : %s\n\nSi c'était vous, vous n'avez rien à faire. Sinon, changez votre mot de passe maintenant ; cela déconnecte aussi tous les appareils.",
+	"an unidentified client": "un client non identifié",
+
+	// Months.
+	"January":   "janvier",
+	"February":  "février",
+	"March":     "mars",
+	"April":     "avril",
+	"May":       "mai",
+	"June":      "juin",
+	"July":      "juillet",
+	"August":    "août",
+	"September": "septembre",
+	"October":   "octobre",
+	"November":  "novembre",
+	"December":  "décembre",
+}
@@ -0,0 +1,69 @@
+package i18n
+
+var hausa = map[string]string{
+	// API errors.
+	"invalid token":          "alamar shiga ba ta da inganci",
+	"access denied":          "an hana shiga",
+	"forbidden":              "an haramta",
+	"invalid account ID":     "lambar asusu ba ta da inganci",
+	"invalid transaction ID": "lambar ma'amala ba ta da inganci",
+	"account not found":      "ba a sami asusun ba",
+	"transaction not found":  "ba a sami ma'amalar ba",
+	"invalid amount":         "adadin kuɗi ba shi da inganci",
+	"invalid credentials":    "bayanan shiga ba daidai ba ne",
+	"user not found":         "ba a sami mai amfanin ba",
+
+	// Ledger errors.
+	"insufficient funds":                                      "kuɗi bai isa ba",
+	"cannot transfer to the same account":                     "ba za a iya tura kuɗi zuwa asusu ɗaya ba",
+	"amount must be positive":                                 "dole adadin kuɗi ya fi sifili",
+	"amount has more decimal places than the currency allows": "adadin kuɗi yana da lambobin goma fiye da yadda kuɗin ya yarda",
+	"currency mismatch":                                       "nau'in kuɗi bai dace ba",
+	"cannot transfer between organizations":                   "ba za a iya tura kuɗi tsakanin ƙungiyoyi ba",
+	"identity verification required":                          "ana buƙatar tabbatar da shaidarku",
+	"amount exceeds the limit for your verification level":    "adadin ya wuce iyakar matakin tabbatarwarku",
+	"transaction is pending review":                           "ma'amalar tana jiran dubawa",
+	"transaction declined":                                    "an ƙi ma'amalar",
+	"additional verification required":                        "ana buƙatar ƙarin tabbatarwa",
+	"savings goal is locked":                                  "an kulle burin ajiya",
+	"amount exceeds available balance: the account's minimum balance must remain": "adadin ya wuce kuɗin da ke akwai: dole mafi ƙarancin kuɗin asusun ya ragu a ciki",
+	"amount exceeds the account's per-transaction limit":                          "adadin ya wuce iyakar asusun na kowace ma'amala",
+	"operation not allowed for this account type":                                 "ba a yarda da wannan aiki ga irin wannan asusu ba",
+
+	// Transaction alerts.
+	"Credit alert: %s %s on %s":                                                     "Sanarwar shigar kuɗi: %s %s a %s",
+	"Debit alert: %s %s on %s":                                                      "Sanarwar fitar kuɗi: %s %s a %s",
+	"Hello,\n\nA credit of %s %s has posted to your account %q.\n\n":                "Sannu,\n\nAn saka %s %s a asusunku %q.\n\n",
+	"Hello,\n\nA debit of %s %s has posted to your account %q.\n\n":                 "Sannu,\n\nAn cire %s %s daga asusunku %q.\n\n",
+	"Transaction: %s\nType: %s\nAvailable balance: %s %s\n\n":                       "Ma'amala: %s\nNau'i: %s\nKuɗin da ke akwai: %s %s\n\n",
+	"If you did not expect this transaction, please contact support immediately.\n": "Idan ba ku yi tsammanin wannan ma'amalar ba, ku tuntuɓi sashen tallafi nan take.\n",
+
+	// Statements.
+	"Your %s statement for %s":                                             "Bayanin asusunku na %s don %s",
+	"Hello,\n\nYour statement for %q covering %s is ready.\n\n":            "Sannu,\n\nBayanin asusunku na %q na %s ya shirya.\n\n",
+	"Opening balance: %s %s\nClosing balance: %s %s\n\n":                   "Kuɗin farko: %s %s\nKuɗin ƙarshe: %s %s\n\n",
+	"Download it (ISO 20022 camt.053) until %s:\n%s/statements/%s/%s?%s\n": "Ku sauke shi (ISO 20022 camt.053) kafin %s:\n%s/statements/%s/%s?%s\n",
+
+	// Security alerts.
+	"Your account was used from a new country": "An yi amfani da asusunku daga sabuwar ƙasa",
+	"Your account was used from %s at %s UTC, shortly after activity from %s.\n\nAddress: %s\n\nIf this was you, there is nothing to do, though payments may ask you to confirm them for a while. If it wasn't, change your password now; that also logs out every device.": "An yi amfani da asusunku daga %s a %s UTC, jim kaɗan bayan amfani da shi daga %s.\n\nAdireshi: %s\n\nIdan ku ne, babu abin da za ku yi, sai dai biyan kuɗi na iya neman ku tabbatar da su na ɗan lokaci. Idan ba ku ba ne, ku canza kalmar sirrinku yanzu; hakan zai fitar da duk na'urori.",
+	"Your login was temporarily locked": "An kulle shigarku na ɗan lokaci",
+	"We locked your login until %s UTC after %d failed sign-in attempts, the last from %s.\n\nIf this wasn't you, someone may be trying to guess your password. Consider changing it once the lock lifts, and contact support if you need help.": "Mun kulle shigarku har zuwa %s UTC bayan yunƙurin shiga %d da ba su yi nasara ba, na ƙarshe daga %s.\n\nIdan ba ku ba ne, wani na iya ƙoƙarin gano kalmar sirrinku. Ku yi la'akari da canza ta idan an buɗe, kuma ku tuntuɓi sashen tallafi idan kuna buƙatar taimako.",
+	"New sign-in to your account": "Sabuwar shiga a asusunku",
+	"Your account was signed in to from a new device at %s UTC.\n\nDevice: %s\nAddress: %s\n\nIf this was you, there is nothing to do. If it wasn't, change your password now; that also logs out every device.": "An shiga asusunku daga sabuwar na'ura a %s UTC.\n\nNa'ura: %s\nAdireshi: %s\n\nIdan ku ne, babu abin da za ku yi. Idan ba ku ba ne, ku canza kalmar sirrinku yanzu; hakan zai fitar da duk na'urori.",
+	"an unidentified client": "na'urar da ba a sani ba",
+
+	// Months.
+	"January":   "Janairu",
+	"February":  "Fabrairu",
+	"March":     "Maris",
+	"April":     "Afrilu",
+	"May":       "Mayu",
+	"June":      "Yuni",
+	"July":      "Yuli",
+	"August":    "Agusta",
+	"September": "Satumba",
+	"October":   "Oktoba",
+	"November":  "Nuwamba",
+	"December":  "Disamba",
+}
@@ -0,0 +1,78 @@
+// Package i18n translates the text customers read: API error messages and notification emails.
+// Messages are keyed by their English text, so code keeps writing English and a missing
+// translation falls back to it.
+package i18n
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default is the language messages are written in and fall back to.
+const Default = "en"
+
+// Locales are the languages messages can be read in: English, Yoruba, Hausa, Igbo and French.
+var Locales = []string{"en", "yo", "ha", "ig", "fr"}
+
+// Supported reports whether locale is one of Locales.
+func Supported(locale string) bool {
+	return slices.Contains(Locales, locale)
+}
+
+// Match picks the supported locale an Accept-Language header prefers most, ignoring regions
+// ("fr-CA" reads French), or Default when it names none.
+func Match(acceptLanguage string) string {
+	type pref struct {
+		locale string
+		q      float64
+	}
+	var prefs []pref
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if q <= 0 || !Supported(base) {
+			continue
+		}
+		prefs = append(prefs, pref{base, q})
+	}
+	if len(prefs) == 0 {
+		return Default
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+	return prefs[0].locale
+}
+
+// T translates msg into locale, returning msg itself when there is no translation.
+func T(locale, msg string) string {
+	if t, ok := catalogs[locale][msg]; ok {
+		return t
+	}
+	return msg
+}
+
+// Sprintf translates format into locale and formats it with args.
+func Sprintf(locale, format string, args ...interface{}) string {
+	return fmt.Sprintf(T(locale, format), args...)
+}
+
+// FormatMonth renders t's month and year, e.g. "September 2026" or "septembre 2026".
+func FormatMonth(locale string, t time.Time) string {
+	return T(locale, t.Month().String()) + " " + strconv.Itoa(t.Year())
+}
+
+// FormatDate renders t's day, month and year, e.g. "2 September 2026".
+func FormatDate(locale string, t time.Time) string {
+	return strconv.Itoa(t.Day()) + " " + FormatMonth(locale, t)
+}
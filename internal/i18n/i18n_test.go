@@ -0,0 +1,68 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	cases := map[string]string{
+		"":                           "en",
+		"fr":                         "fr",
+		"fr-CA,fr;q=0.9":             "fr",
+		"de-DE,de;q=0.9":             "en",
+		"de;q=1, yo;q=0.8, ha;q=0.9": "ha",
+		"en-GB;q=0.5, ig":            "ig",
+		"yo;q=0, fr;q=0.1":           "fr",
+		"*":                          "en",
+		"HA-ng":                      "ha",
+		"fr;q=abc, yo":               "yo",
+	}
+	for header, want := range cases {
+		assert.Equal(t, want, Match(header), header)
+	}
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "fonds insuffisants", T("fr", "insufficient funds"))
+	assert.Equal(t, "owó kò tó", T("yo", "insufficient funds"))
+	assert.Equal(t, "insufficient funds", T("en", "insufficient funds"))
+	// Untranslated messages and unknown locales fall back to English.
+	assert.Equal(t, "invalid savings goal ID", T("fr", "invalid savings goal ID"))
+	assert.Equal(t, "insufficient funds", T("de", "insufficient funds"))
+}
+
+func TestSprintf(t *testing.T) {
+	assert.Equal(t, "Alerte crédit : NGN 500.0000 sur Main", Sprintf("fr", "Credit alert: %s %s on %s", "NGN", "500.0000", "Main"))
+	assert.Equal(t, "Credit alert: NGN 500.0000 on Main", Sprintf("en", "Credit alert: %s %s on %s", "NGN", "500.0000", "Main"))
+}
+
+func TestFormatDate(t *testing.T) {
+	d := time.Date(2026, 9, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2 September 2026", FormatDate("en", d))
+	assert.Equal(t, "2 septembre 2026", FormatDate("fr", d))
+	assert.Equal(t, "Satumba 2026", FormatMonth("ha", d))
+}
+
+func TestCatalogsKeepVerbs(t *testing.T) {
+	// A translation must take the same arguments in the same order as its English text.
+	verbs := regexp.MustCompile(`%[sdqv]`)
+	for locale, catalog := range catalogs {
+		assert.True(t, Supported(locale), locale)
+		for msg, translated := range catalog {
+			assert.Equal(t, verbs.FindAllString(msg, -1), verbs.FindAllString(translated, -1), "%s: %q", locale, msg)
+		}
+	}
+}
+
+func TestCatalogsCoverTheSameMessages(t *testing.T) {
+	for locale, catalog := range catalogs {
+		assert.Len(t, catalog, len(french), locale)
+		for msg := range french {
+			assert.Contains(t, catalog, msg, locale)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package i18n
+
+var igbo = map[string]string{
+	// API errors.
+	"invalid token":          "akara nbanye adịghị mma",
+	"access denied":          "a jụrụ ohere",
+	"forbidden":              "amachibidoro ya",
+	"invalid account ID":     "nọmba akaụntụ adịghị mma",
+	"invalid transaction ID": "nọmba azụmahịa adịghị mma",
+	"account not found":      "ahụghị akaụntụ ahụ",
+	"transaction not found":  "ahụghị azụmahịa ahụ",
+	"invalid amount":         "ego e dere adịghị mma",
+	"invalid credentials":    "nkọwa nbanye ezighi ezi",
+	"user not found":         "ahụghị onye ọrụ ahụ",
+
+	// Ledger errors.
+	"insufficient funds":                                      "ego ezughị",
+	"cannot transfer to the same account":                     "enweghị ike izipu ego n'otu akaụntụ ahụ",
+	"amount must be positive":                                 "ego ga-akarịrị efu",
+	"amount has more decimal places than the currency allows": "ego ahụ nwere ọnụọgụ ntụpọ karịa ka ego ahụ na-anabata",
+	"currency mismatch":                                       "ụdị ego adabaghị",
+	"cannot transfer between organizations":                   "enweghị ike izipu ego n'etiti otu dị iche iche",
+	"identity verification required":                          "achọrọ nkwenye njirimara",
+	"amount exceeds the limit for your verification level":    "ego ahụ karịrị oke ọkwa nkwenye gị",
+	"transaction is pending review":                           "azụmahịa a na-eche nyocha",
+	"transaction declined":                                    "a jụrụ azụmahịa ahụ",
+	"additional verification required":                        "achọrọ nkwenye ọzọ",
+	"savings goal is locked":                                  "emechiri ebumnuche nchekwa ego",
+	"amount exceeds available balance: the account's minimum balance must remain": "ego ahụ karịrị ego dị n'akaụntụ: ego kacha nta akaụntụ ga-enwerịrị ga-anọgide",
+	"amount exceeds the account's per-transaction limit":                          "ego ahụ karịrị oke akaụntụ maka otu azụmahịa",
+	"operation not allowed for this account type":                                 "anaghị ekwe ka e mee ihe a n'ụdị akaụntụ a",
+
+	// Transaction alerts.
+	"Credit alert: %s %s on %s":                                                     "Ọkwa ego batara: %s %s na %s",
+	"Debit alert: %s %s on %s":                                                      "Ọkwa ego pụrụ: %s %s na %s",
+	"Hello,\n\nA credit of %s %s has posted to your account %q.\n\n":                "Ndewo,\n\nEgo %s %s abatala n'akaụntụ gị %q.\n\n",
+	"Hello,\n\nA debit of %s %s has posted to your account %q.\n\n":                 "Ndewo,\n\nEgo %s %s esila n'akaụntụ gị %q pụọ.\n\n",
+	"Transaction: %s\nType: %s\nAvailable balance: %s %s\n\n":                       "Azụmahịa: %s\nỤdị: %s\nEgo dị: %s %s\n\n",
+	"If you did not expect this transaction, please contact support immediately.\n": "Ọ bụrụ na ị tụghị anya azụmahịa a, kpọtụrụ ndị nkwado ozugbo.\n",
+
+	// Statements.
+	"Your %s statement for %s":                                             "Nkwupụta %s gị maka %s",
+	"Hello,\n\nYour statement for %q covering %s is ready.\n\n":            "Ndewo,\n\nNkwupụta gị maka %q nke %s adịla njikere.\n\n",
+	"Opening balance: %s %s\nClosing balance: %s %s\n\n":                   "Ego mmalite: %s %s\nEgo njedebe: %s %s\n\n",
+	"Download it (ISO 20022 camt.053) until %s:\n%s/statements/%s/%s?%s\n": "Budata ya (ISO 20022 camt.053) tupu %s:\n%s/statements/%s/%s?%s\n",
+
+	// Security alerts.
+	"Your account was used from a new country": "E jiri akaụntụ gị mee ihe site na mba ọhụrụ",
+	"Your account was used from %s at %s UTC, shortly after activity from %s.\n\nAddress: %s\n\nIf this was you, there is nothing to do, though payments may ask you to confirm them for a while. If it wasn't, change your password now; that also logs out every device.": "E jiri akaụntụ gị mee ihe site na %s n'oge %s UTC, obere oge ka e jiri ya mee ihe site na %s.\n\nAdreesị: %s\n\nỌ bụrụ na ọ bụ gị, ọ dịghị ihe ị ga-eme, mana ịkwụ ụgwọ nwere ike ịrịọ gị ka ị kwado ha ruo oge ụfọdụ. Ọ bụrụ na ọ bụghị gị, gbanwee paswọọdụ gị ugbu a; nke ahụ ga-ewepụkwa ngwaọrụ niile.",
+	"Your login was temporarily locked": "Emechiri nbanye gị nwa oge",
+	"We locked your login until %s UTC after %d failed sign-in attempts, the last from %s.\n\nIf this wasn't you, someone may be trying to guess your password. Consider changing it once the lock lifts, and contact support if you need help.": "Anyị emechiela nbanye gị ruo %s UTC mgbe mgbalị nbanye %d dara, nke ikpeazụ si %s.\n\nỌ bụrụ na ọ bụghị gị, mmadụ nwere ike na-agbalị ịkọ paswọọdụ gị. Tụlee ịgbanwe ya ozugbo e meghere ya, ma kpọtụrụ ndị nkwado ma ọ bụrụ na ịchọrọ enyemaka.",
+	"New sign-in to your account": "Nbanye ọhụrụ n'akaụntụ gị",
+	"Your account was signed in to from a new device at %s UTC.\n\nDevice: %s\nAddress: %s\n\nIf this was you, there is nothing to do. If it wasn't, change your password now; that also logs out every device.": "Abanyere n'akaụntụ gị site na ngwaọrụ ọhụrụ n'oge %s UTC.\n\nNgwaọrụ: %s\nAdreesị: %s\n\nỌ bụrụ na ọ bụ gị, ọ dịghị ihe ị ga-eme. Ọ bụrụ na ọ bụghị gị, gbanwee paswọọdụ gị ugbu a; nke ahụ ga-ewepụkwa ngwaọrụ niile.",
+	"an unidentified client": "ngwaọrụ a na-amaghị",
+
+	// Months.
+	"January":   "Jenụwarị",
+	"February":  "Febrụwarị",
+	"March":     "Maachị",
+	"April":     "Eprel",
+	"May":       "Mee",
+	"June":      "Jun",
+	"July":      "Julaị",
+	"August":    "Ọgọst",
+	"September": "Septemba",
+	"October":   "Ọktoba",
+	"November":  "Novemba",
+	"December":  "Disemba",
+}
@@ -0,0 +1,69 @@
+package i18n
+
+var yoruba = map[string]string{
+	// API errors.
+	"invalid token":          "àmì ìwọlé kò wúlò",
+	"access denied":          "a kọ̀ ìwọlé",
+	"forbidden":              "a kò gbà á láàyè",
+	"invalid account ID":     "nọ́mbà ìdánimọ̀ àkáǹtì kò wúlò",
+	"invalid transaction ID": "nọ́mbà ìdánimọ̀ ìdúnàádúrà kò wúlò",
+	"account not found":      "a kò rí àkáǹtì náà",
+	"transaction not found":  "a kò rí ìdúnàádúrà náà",
+	"invalid amount":         "iye owó kò wúlò",
+	"invalid credentials":    "àwọn ẹ̀rí ìwọlé kò tọ̀nà",
+	"user not found":         "a kò rí oníṣe náà",
+
+	// Ledger errors.
+	"insufficient funds":                                      "owó kò tó",
+	"cannot transfer to the same account":                     "a kò lè fi owó ránṣẹ́ sí àkáǹtì kan náà",
+	"amount must be positive":                                 "iye owó gbọ́dọ̀ ju òdo lọ",
+	"amount has more decimal places than the currency allows": "iye owó ní ààmì ìdá-mẹ́wàá ju bí owó náà ṣe gbà lọ",
+	"currency mismatch":                                       "irú owó kò bá ara wọn mu",
+	"cannot transfer between organizations":                   "a kò lè fi owó ránṣẹ́ láàárín àwọn àjọ",
+	"identity verification required":                          "a nílò ìjẹ́rìísí ìdánimọ̀",
+	"amount exceeds the limit for your verification level":    "iye owó ju òpin ìpele ìjẹ́rìísí yín lọ",
+	"transaction is pending review":                           "ìdúnàádúrà ń dúró de àyẹ̀wò",
+	"transaction declined":                                    "a kọ ìdúnàádúrà náà",
+	"additional verification required":                        "a nílò ìjẹ́rìísí àfikún",
+	"savings goal is locked":                                  "àfojúsùn ìfowópamọ́ ti wà ní títì",
+	"amount exceeds available balance: the account's minimum balance must remain": "iye owó ju owó tó wà lọ: owó tó kéré jù lọ tí àkáǹtì gbọ́dọ̀ ní gbọ́dọ̀ wà níbẹ̀",
+	"amount exceeds the account's per-transaction limit":                          "iye owó ju òpin àkáǹtì fún ìdúnàádúrà kan lọ",
+	"operation not allowed for this account type":                                 "a kò gba iṣẹ́ yìí láàyè fún irú àkáǹtì yìí",
+
+	// Transaction alerts.
+	"Credit alert: %s %s on %s":                                                     "Ìkìlọ̀ owó wọlé: %s %s lórí %s",
+	"Debit alert: %s %s on %s":                                                      "Ìkìlọ̀ owó jáde: %s %s lórí %s",
+	"Hello,\n\nA credit of %s %s has posted to your account %q.\n\n":                "Ẹ n lẹ́,\n\nOwó %s %s ti wọ inú àkáǹtì yín %q.\n\n",
+	"Hello,\n\nA debit of %s %s has posted to your account %q.\n\n":                 "Ẹ n lẹ́,\n\nOwó %s %s ti jáde láti inú àkáǹtì yín %q.\n\n",
+	"Transaction: %s\nType: %s\nAvailable balance: %s %s\n\n":                       "Ìdúnàádúrà: %s\nIrú: %s\nOwó tó wà: %s %s\n\n",
+	"If you did not expect this transaction, please contact support immediately.\n": "Tí ẹ kò bá retí ìdúnàádúrà yìí, ẹ kàn sí ẹ̀ka ìrànlọ́wọ́ lẹ́sẹ̀kẹsẹ̀.\n",
+
+	// Statements.
+	"Your %s statement for %s":                                             "Ìwé àkọsílẹ̀ %s yín fún %s",
+	"Hello,\n\nYour statement for %q covering %s is ready.\n\n":            "Ẹ n lẹ́,\n\nÌwé àkọsílẹ̀ yín fún %q tí ó bo %s ti ṣetán.\n\n",
+	"Opening balance: %s %s\nClosing balance: %s %s\n\n":                   "Owó ìbẹ̀rẹ̀: %s %s\nOwó ìparí: %s %s\n\n",
+	"Download it (ISO 20022 camt.053) until %s:\n%s/statements/%s/%s?%s\n": "Ẹ ṣe ìgbàsílẹ̀ rẹ̀ (ISO 20022 camt.053) títí di %s:\n%s/statements/%s/%s?%s\n",
+
+	// Security alerts.
+	"Your account was used from a new country": "A lo àkáǹtì yín láti orílẹ̀-èdè tuntun",
+	"Your account was used from %s at %s UTC, shortly after activity from %s.\n\nAddress: %s\n\nIf this was you, there is nothing to do, though payments may ask you to confirm them for a while. If it wasn't, change your password now; that also logs out every device.": "A lo àkáǹtì yín láti %s ní %s UTC, láìpẹ́ lẹ́yìn ìgbòkègbodò láti %s.\n\nÀdírẹ́sì: %s\n\nTí ẹ̀yin ni, kò sí ohun tí ẹ ní láti ṣe, ṣùgbọ́n àwọn ìsanwó lè ní kí ẹ fìdí wọn múlẹ̀ fún ìgbà díẹ̀. Tí kì í bá ṣe ẹ̀yin, ẹ yí ọ̀rọ̀ aṣínà yín padà báyìí; ìyẹn yóò tún mú gbogbo ẹ̀rọ jáde.",
+	"Your login was temporarily locked": "A ti ìwọlé yín pa fún ìgbà díẹ̀",
+	"We locked your login until %s UTC after %d failed sign-in attempts, the last from %s.\n\nIf this wasn't you, someone may be trying to guess your password. Consider changing it once the lock lifts, and contact support if you need help.": "A ti ìwọlé yín pa títí di %s UTC lẹ́yìn ìgbìyànjú ìwọlé %d tí kò yọrí sí rere, èyí tó kẹ́yìn láti %s.\n\nTí kì í bá ṣe ẹ̀yin, ẹnìkan lè máa gbìyànjú láti méfò ọ̀rọ̀ aṣínà yín. Ẹ ronú láti yí i padà nígbà tí a bá ṣí i, kí ẹ sì kàn sí ẹ̀ka ìrànlọ́wọ́ tí ẹ bá nílò ìrànlọ́wọ́.",
+	"New sign-in to your account": "Ìwọlé tuntun sí àkáǹtì yín",
+	"Your account was signed in to from a new device at %s UTC.\n\nDevice: %s\nAddress: %s\n\nIf this was you, there is nothing to do. If it wasn't, change your password now; that also logs out every device.": "A wọlé sí àkáǹtì yín láti ẹ̀rọ tuntun ní %s UTC.\n\nẸ̀rọ: %s\nÀdírẹ́sì: %s\n\nTí ẹ̀yin ni, kò sí ohun tí ẹ ní láti ṣe. Tí kì í bá ṣe ẹ̀yin, ẹ yí ọ̀rọ̀ aṣínà yín padà báyìí; ìyẹn yóò tún mú gbogbo ẹ̀rọ jáde.",
+	"an unidentified client": "ẹ̀rọ tí a kò mọ̀",
+
+	// Months.
+	"January":   "Ṣẹ́rẹ́",
+	"February":  "Èrèlé",
+	"March":     "Ẹrẹ̀nà",
+	"April":     "Ìgbé",
+	"May":       "Ẹ̀bibi",
+	"June":      "Òkúdu",
+	"July":      "Agẹmọ",
+	"August":    "Ògún",
+	"September": "Owewe",
+	"October":   "Ọ̀wàrà",
+	"November":  "Bélú",
+	"December":  "Ọ̀pẹ̀",
+}
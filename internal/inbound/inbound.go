@@ -0,0 +1,135 @@
+// Package inbound authenticates and decodes credit notifications that external
+// payment providers push when money arrives for a customer's virtual account.
+package inbound
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// SignatureHeader carries "t=<unix>,v1=<hex hmac>" where the HMAC-SHA256 covers "<t>.<body>".
+const SignatureHeader = "X-Payment-Signature"
+
+// DefaultTolerance bounds how old a signed notification may be before it is treated as a replay.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	// ErrInvalidSignature is returned when no v1 signature matches the payload.
+	ErrInvalidSignature = errors.New("invalid payment notification signature")
+	// ErrSignatureExpired is returned when the signed timestamp is outside the tolerance.
+	ErrSignatureExpired = errors.New("payment notification timestamp outside tolerance")
+	// ErrInvalidNotification is returned when a verified body is missing required fields.
+	ErrInvalidNotification = errors.New("invalid payment notification")
+)
+
+// Verifier checks notification signatures with the shared webhook secret.
+type Verifier struct {
+	now    func() time.Time
+	secret string
+}
+
+// NewVerifier constructs a Verifier for the shared secret configured with the provider.
+func NewVerifier(secret string) (*Verifier, error) {
+	if secret == "" {
+		return nil, errors.New("inbound payment webhook secret is required")
+	}
+	return &Verifier{secret: secret, now: time.Now}, nil
+}
+
+// Sign returns a signature header for payload at time t. Providers and tests use it to sign deliveries.
+func (v *Verifier) Sign(payload []byte, t time.Time) string {
+	return fmt.Sprintf("t=%d,v1=%s", t.Unix(), hex.EncodeToString(v.mac(t.Unix(), payload)))
+}
+
+// Verify checks a signature header against payload and the tolerance window.
+func (v *Verifier) Verify(payload []byte, header string, tolerance time.Duration) error {
+	var (
+		timestamp  int64
+		signatures [][]byte
+	)
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			ts, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return ErrInvalidSignature
+			}
+			timestamp = ts
+		case "v1":
+			if sig, err := hex.DecodeString(value); err == nil {
+				signatures = append(signatures, sig)
+			}
+		}
+	}
+	if timestamp == 0 || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	expected := v.mac(timestamp, payload)
+	matched := false
+	for _, sig := range signatures {
+		if hmac.Equal(expected, sig) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return ErrInvalidSignature
+	}
+	if age := v.now().Sub(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+func (v *Verifier) mac(timestamp int64, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(v.secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Notification is one credit to a virtual account, as reported by the provider.
+type Notification struct {
+	// EventID is unique per provider; redeliveries reuse it.
+	EventID              string      `json:"event_id"`
+	Provider             string      `json:"provider"`
+	VirtualAccountNumber string      `json:"virtual_account_number"`
+	Amount               json.Number `json:"amount"`
+	Currency             string      `json:"currency"`
+	PayerName            string      `json:"payer_name"`
+	PayerReference       string      `json:"payer_reference"`
+}
+
+// Parse decodes and validates a notification body. Call Verify first.
+func Parse(payload []byte) (Notification, error) {
+	var n Notification
+	if err := json.Unmarshal(payload, &n); err != nil {
+		return Notification{}, fmt.Errorf("%w: %w", ErrInvalidNotification, err)
+	}
+	n.EventID = strings.TrimSpace(n.EventID)
+	n.Provider = strings.ToLower(strings.TrimSpace(n.Provider))
+	n.VirtualAccountNumber = strings.TrimSpace(n.VirtualAccountNumber)
+	n.Currency = strings.ToUpper(strings.TrimSpace(n.Currency))
+	if n.EventID == "" || n.Provider == "" || n.VirtualAccountNumber == "" || n.Currency == "" {
+		return Notification{}, fmt.Errorf("%w: event_id, provider, virtual_account_number and currency are required", ErrInvalidNotification)
+	}
+	amount, err := decimal.NewFromString(n.Amount.String())
+	if err != nil || !amount.IsPositive() {
+		return Notification{}, fmt.Errorf("%w: amount must be a positive number", ErrInvalidNotification)
+	}
+	return n, nil
+}
@@ -0,0 +1,45 @@
+package inbound
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	// Only a fresh signature over the exact body with the shared secret is accepted.
+	v, err := NewVerifier("whsec_inbound")
+	require.NoError(t, err)
+	now := time.Unix(1_700_000_000, 0)
+	v.now = func() time.Time { return now }
+	body := []byte(`{"event_id":"evt_1"}`)
+
+	assert.NoError(t, v.Verify(body, v.Sign(body, now), DefaultTolerance))
+	assert.ErrorIs(t, v.Verify([]byte(`{"event_id":"evt_2"}`), v.Sign(body, now), DefaultTolerance), ErrInvalidSignature)
+	assert.ErrorIs(t, v.Verify(body, v.Sign(body, now.Add(-10*time.Minute)), DefaultTolerance), ErrSignatureExpired)
+	assert.ErrorIs(t, v.Verify(body, "t=abc,v1=00", DefaultTolerance), ErrInvalidSignature)
+	assert.ErrorIs(t, v.Verify(body, "", DefaultTolerance), ErrInvalidSignature)
+
+	other, err := NewVerifier("someone-else")
+	require.NoError(t, err)
+	assert.ErrorIs(t, v.Verify(body, other.Sign(body, now), DefaultTolerance), ErrInvalidSignature)
+}
+
+func TestParse(t *testing.T) {
+	// Notifications are normalised and must carry an event ID, destination and positive amount.
+	n, err := Parse([]byte(`{"event_id":" evt_1 ","provider":"Bank","virtual_account_number":"9000000001","amount":"25.50","currency":"usd","payer_name":"Ada"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "evt_1", n.EventID)
+	assert.Equal(t, "bank", n.Provider)
+	assert.Equal(t, "USD", n.Currency)
+	assert.Equal(t, "25.50", n.Amount.String())
+
+	_, err = Parse([]byte(`{"event_id":"evt_1","provider":"bank","virtual_account_number":"9000000001","amount":"-1","currency":"USD"}`))
+	assert.ErrorIs(t, err, ErrInvalidNotification)
+	_, err = Parse([]byte(`{"provider":"bank","virtual_account_number":"9000000001","amount":"1","currency":"USD"}`))
+	assert.ErrorIs(t, err, ErrInvalidNotification)
+	_, err = Parse([]byte(`not json`))
+	assert.ErrorIs(t, err, ErrInvalidNotification)
+}
@@ -0,0 +1,30 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// KindPurgeSucceeded deletes succeeded jobs older than the configured retention.
+const KindPurgeSucceeded = "jobs.purge_succeeded"
+
+// PurgeStore deletes finished jobs. *db.Store satisfies it.
+type PurgeStore interface {
+	DeleteSucceededJobsBefore(ctx context.Context, updatedAt time.Time) (int64, error)
+}
+
+// PurgeSucceeded returns a handler that keeps the jobs table from growing without bound.
+// Failed jobs are kept for inspection.
+func PurgeSucceeded(store PurgeStore, retention time.Duration) HandlerFunc {
+	return func(ctx context.Context, _ json.RawMessage) error {
+		n, err := store.DeleteSucceededJobsBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+		log.Info().Int64("jobs", n).Msg("Purged succeeded jobs")
+		return nil
+	}
+}
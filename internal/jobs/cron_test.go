@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpec_Every(t *testing.T) {
+	// @every adds a fixed interval to the previous run.
+	s, err := ParseSpec("@every 90s")
+	require.NoError(t, err)
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, at.Add(90*time.Second), s.Next(at))
+
+	_, err = ParseSpec("@every 10ms")
+	assert.Error(t, err)
+}
+
+func TestParseSpec_CronFields(t *testing.T) {
+	// Steps, ranges and shortcuts resolve to the next matching minute in UTC.
+	at := time.Date(2026, 3, 1, 10, 7, 30, 0, time.UTC) // a Sunday
+
+	s, err := ParseSpec("*/15 * * * *")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 1, 10, 15, 0, 0, time.UTC), s.Next(at))
+
+	s, err = ParseSpec("@daily")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), s.Next(at))
+
+	s, err = ParseSpec("30 9 * * 1-5")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC), s.Next(at))
+
+	s, err = ParseSpec("0 0 1 */3 *")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), s.Next(at))
+}
+
+func TestParseSpec_DayFieldsMatchEither(t *testing.T) {
+	// When both day fields are restricted, cron runs on either.
+	s, err := ParseSpec("0 0 15 * 7")
+	require.NoError(t, err)
+	at := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), s.Next(at))
+}
+
+func TestParseSpec_Invalid(t *testing.T) {
+	// Malformed or out-of-range fields are rejected; impossible dates never match.
+	for _, spec := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		_, err := ParseSpec(spec)
+		assert.Error(t, err, spec)
+	}
+	s, err := ParseSpec("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
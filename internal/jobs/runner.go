@@ -0,0 +1,303 @@
+// Package jobs runs persistent background work: jobs enqueued for immediate or deferred
+// execution, cron-style schedules that enqueue them, and a worker pool with retries.
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// Job statuses stored on the jobs table.
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// DefaultMaxAttempts is how many times a job runs before it is marked failed.
+const DefaultMaxAttempts = 5
+
+// Store persists jobs and schedules. *db.Store satisfies it.
+type Store interface {
+	EnqueueJob(ctx context.Context, arg sqlc.EnqueueJobParams) (sqlc.Job, error)
+	ClaimNextJob(ctx context.Context) (sqlc.Job, error)
+	CompleteJob(ctx context.Context, id uuid.UUID) error
+	RetryJob(ctx context.Context, arg sqlc.RetryJobParams) error
+	FailJob(ctx context.Context, arg sqlc.FailJobParams) error
+	RequeueStaleJobs(ctx context.Context, staleBefore time.Time) (int64, error)
+	UpsertJobSchedule(ctx context.Context, arg sqlc.UpsertJobScheduleParams) error
+	ClaimJobSchedule(ctx context.Context, arg sqlc.ClaimJobScheduleParams) (sqlc.JobSchedule, error)
+}
+
+// HandlerFunc runs one job. Returning an error retries it with backoff unless the error is Permanent.
+type HandlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+// permanentError marks a failure that retrying cannot fix.
+type permanentError struct{ err error }
+
+func (p permanentError) Error() string { return p.err.Error() }
+func (p permanentError) Unwrap() error { return p.err }
+
+// Permanent wraps err so the job fails immediately instead of being retried.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// schedule is a registered cron entry that enqueues kind with payload.
+type schedule struct {
+	next    Schedule
+	name    string
+	spec    string
+	kind    string
+	payload json.RawMessage
+}
+
+// Runner executes registered job kinds and enqueues scheduled occurrences.
+type Runner struct {
+	store     Store
+	now       func() time.Time
+	handlers  map[string]HandlerFunc
+	schedules []schedule
+	// staleAfter is how long a running job may hold its lock before it is assumed lost.
+	staleAfter time.Duration
+	workers    int
+	mu         sync.RWMutex
+}
+
+// NewRunner constructs a Runner with workers concurrent workers (at least one).
+func NewRunner(store Store, workers int) *Runner {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Runner{
+		store:      store,
+		now:        time.Now,
+		handlers:   make(map[string]HandlerFunc),
+		staleAfter: 10 * time.Minute,
+		workers:    workers,
+	}
+}
+
+// Register makes kind runnable. Registering a kind twice replaces its handler.
+func (r *Runner) Register(kind string, fn HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[kind] = fn
+}
+
+// Schedule enqueues kind with payload on every occurrence of spec (see ParseSpec).
+// name identifies the schedule across restarts and instances. Call it before Run.
+func (r *Runner) Schedule(name, spec, kind string, payload any) error {
+	next, err := ParseSpec(spec)
+	if err != nil {
+		return fmt.Errorf("schedule %s: %w", name, err)
+	}
+	raw, err := marshalPayload(payload)
+	if err != nil {
+		return fmt.Errorf("schedule %s: %w", name, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schedules = append(r.schedules, schedule{next: next, name: name, spec: spec, kind: kind, payload: raw})
+	return nil
+}
+
+// Enqueue persists a job of kind to run at runAt (now when zero).
+func (r *Runner) Enqueue(ctx context.Context, kind string, payload any, runAt time.Time) (sqlc.Job, error) {
+	raw, err := marshalPayload(payload)
+	if err != nil {
+		return sqlc.Job{}, err
+	}
+	if runAt.IsZero() {
+		runAt = r.now()
+	}
+	return r.store.EnqueueJob(ctx, sqlc.EnqueueJobParams{
+		Kind:        kind,
+		Payload:     raw,
+		RunAt:       runAt,
+		MaxAttempts: DefaultMaxAttempts,
+	})
+}
+
+func marshalPayload(payload any) (json.RawMessage, error) {
+	if payload == nil {
+		return json.RawMessage("{}"), nil
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal job payload: %w", err)
+	}
+	return raw, nil
+}
+
+// RunOnce claims and runs one due job. It reports false when nothing was due.
+func (r *Runner) RunOnce(ctx context.Context) (bool, error) {
+	job, err := r.store.ClaimNextJob(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	r.mu.RLock()
+	fn, ok := r.handlers[job.Kind]
+	r.mu.RUnlock()
+	if !ok {
+		// Another instance may know the kind, but retrying here cannot help.
+		err = Permanent(fmt.Errorf("no handler registered for job kind %q", job.Kind))
+	} else {
+		err = runHandler(ctx, fn, job.Payload)
+	}
+
+	if err == nil {
+		if err := r.store.CompleteJob(ctx, job.ID); err != nil {
+			return true, err
+		}
+		log.Info().Str("job_id", job.ID.String()).Str("kind", job.Kind).Int32("attempt", job.Attempts).Msg("Job succeeded")
+		return true, nil
+	}
+
+	var permanent permanentError
+	if errors.As(err, &permanent) || job.Attempts >= job.MaxAttempts {
+		log.Error().Err(err).Str("job_id", job.ID.String()).Str("kind", job.Kind).Int32("attempt", job.Attempts).Msg("Job failed")
+		return true, r.store.FailJob(ctx, sqlc.FailJobParams{LastError: err.Error(), ID: job.ID})
+	}
+	runAt := r.now().Add(backoff(job.Attempts))
+	log.Warn().Err(err).Str("job_id", job.ID.String()).Str("kind", job.Kind).Int32("attempt", job.Attempts).Time("retry_at", runAt).Msg("Job attempt failed")
+	return true, r.store.RetryJob(ctx, sqlc.RetryJobParams{RunAt: runAt, LastError: err.Error(), ID: job.ID})
+}
+
+// runHandler turns a handler panic into an ordinary failed attempt.
+func runHandler(ctx context.Context, fn HandlerFunc, payload json.RawMessage) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job panicked: %v", p)
+		}
+	}()
+	return fn(ctx, payload)
+}
+
+// backoff returns the delay before retrying after the given attempt (1-based):
+// 30s, 1m, 2m ... capped at 1h.
+func backoff(attempt int32) time.Duration {
+	d := 30 * time.Second
+	for i := int32(1); i < attempt; i++ {
+		d *= 2
+		if d >= time.Hour {
+			return time.Hour
+		}
+	}
+	return d
+}
+
+// Tick enqueues every schedule occurrence that is due and requeues jobs whose worker was lost.
+// Claiming a schedule and enqueuing its job are separate statements, so a crash between them
+// skips that occurrence; scheduled jobs should catch up on their next run.
+func (r *Runner) Tick(ctx context.Context) {
+	now := r.now()
+	r.mu.RLock()
+	schedules := r.schedules
+	r.mu.RUnlock()
+
+	for _, s := range schedules {
+		next := s.next.Next(now)
+		if next.IsZero() {
+			continue
+		}
+		if _, err := r.store.ClaimJobSchedule(ctx, sqlc.ClaimJobScheduleParams{NextRunAt: next, Name: s.name, Now: now}); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				log.Error().Err(err).Str("schedule", s.name).Msg("Failed to claim job schedule")
+			}
+			continue
+		}
+		if _, err := r.store.EnqueueJob(ctx, sqlc.EnqueueJobParams{
+			Kind:        s.kind,
+			Payload:     s.payload,
+			RunAt:       now,
+			MaxAttempts: DefaultMaxAttempts,
+		}); err != nil {
+			log.Error().Err(err).Str("schedule", s.name).Msg("Failed to enqueue scheduled job")
+		}
+	}
+
+	if n, err := r.store.RequeueStaleJobs(ctx, now.Add(-r.staleAfter)); err != nil {
+		log.Error().Err(err).Msg("Failed to requeue stale jobs")
+	} else if n > 0 {
+		log.Warn().Int64("jobs", n).Msg("Requeued jobs whose worker was lost")
+	}
+}
+
+// Run registers schedules, then runs the scheduler and workers every interval until ctx is cancelled.
+func (r *Runner) Run(ctx context.Context, interval time.Duration) {
+	r.mu.RLock()
+	schedules := r.schedules
+	r.mu.RUnlock()
+	for _, s := range schedules {
+		if err := r.store.UpsertJobSchedule(ctx, sqlc.UpsertJobScheduleParams{
+			Name:      s.name,
+			Spec:      s.spec,
+			NextRunAt: s.next.Next(r.now()),
+		}); err != nil {
+			log.Error().Err(err).Str("schedule", s.name).Msg("Failed to register job schedule")
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.Tick(ctx)
+			}
+		}
+	}()
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				r.drain(ctx)
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// drain runs due jobs until none are left, an error occurs, or ctx is cancelled.
+func (r *Runner) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		ran, err := r.RunOnce(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Job worker failed")
+			return
+		}
+		if !ran {
+			return
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// fakeStore keeps jobs in memory and records outcomes.
+type fakeStore struct {
+	claimable []sqlc.Job
+	enqueued  []sqlc.EnqueueJobParams
+	completed []uuid.UUID
+	retried   []sqlc.RetryJobParams
+	failed    []sqlc.FailJobParams
+	claimed   map[string]bool
+}
+
+func (f *fakeStore) EnqueueJob(_ context.Context, arg sqlc.EnqueueJobParams) (sqlc.Job, error) {
+	f.enqueued = append(f.enqueued, arg)
+	return sqlc.Job{ID: uuid.New(), Kind: arg.Kind, Payload: arg.Payload, RunAt: arg.RunAt, MaxAttempts: arg.MaxAttempts}, nil
+}
+
+func (f *fakeStore) ClaimNextJob(context.Context) (sqlc.Job, error) {
+	if len(f.claimable) == 0 {
+		return sqlc.Job{}, sql.ErrNoRows
+	}
+	job := f.claimable[0]
+	f.claimable = f.claimable[1:]
+	job.Attempts++
+	return job, nil
+}
+
+func (f *fakeStore) CompleteJob(_ context.Context, id uuid.UUID) error {
+	f.completed = append(f.completed, id)
+	return nil
+}
+
+func (f *fakeStore) RetryJob(_ context.Context, arg sqlc.RetryJobParams) error {
+	f.retried = append(f.retried, arg)
+	return nil
+}
+
+func (f *fakeStore) FailJob(_ context.Context, arg sqlc.FailJobParams) error {
+	f.failed = append(f.failed, arg)
+	return nil
+}
+
+func (f *fakeStore) RequeueStaleJobs(context.Context, time.Time) (int64, error) { return 0, nil }
+
+func (f *fakeStore) UpsertJobSchedule(context.Context, sqlc.UpsertJobScheduleParams) error {
+	return nil
+}
+
+func (f *fakeStore) ClaimJobSchedule(_ context.Context, arg sqlc.ClaimJobScheduleParams) (sqlc.JobSchedule, error) {
+	// Only the first claim of each schedule wins, as with a second instance racing this one.
+	if f.claimed[arg.Name] {
+		return sqlc.JobSchedule{}, sql.ErrNoRows
+	}
+	f.claimed[arg.Name] = true
+	return sqlc.JobSchedule{Name: arg.Name, NextRunAt: arg.NextRunAt}, nil
+}
+
+func newFakeStore(jobs ...sqlc.Job) *fakeStore {
+	return &fakeStore{claimable: jobs, claimed: map[string]bool{}}
+}
+
+func TestRunOnce_Succeeds(t *testing.T) {
+	// A registered handler receives the payload and the job is completed.
+	job := sqlc.Job{ID: uuid.New(), Kind: "echo", Payload: json.RawMessage(`{"n":1}`), MaxAttempts: 3}
+	store := newFakeStore(job)
+	r := NewRunner(store, 1)
+	var got json.RawMessage
+	r.Register("echo", func(_ context.Context, payload json.RawMessage) error {
+		got = payload
+		return nil
+	})
+
+	ran, err := r.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.JSONEq(t, `{"n":1}`, string(got))
+	assert.Equal(t, []uuid.UUID{job.ID}, store.completed)
+
+	ran, err = r.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.False(t, ran)
+}
+
+func TestRunOnce_RetriesWithBackoffThenFails(t *testing.T) {
+	// Errors retry with backoff until max attempts; the last attempt fails the job.
+	job := sqlc.Job{ID: uuid.New(), Kind: "flaky", MaxAttempts: 2}
+	store := newFakeStore(job)
+	r := NewRunner(store, 1)
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	r.Register("flaky", func(context.Context, json.RawMessage) error { return errors.New("timeout") })
+
+	_, err := r.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, store.retried, 1)
+	assert.Equal(t, now.Add(30*time.Second), store.retried[0].RunAt)
+
+	job.Attempts = 1
+	store.claimable = []sqlc.Job{job}
+	_, err = r.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, store.failed, 1)
+	assert.Equal(t, "timeout", store.failed[0].LastError)
+}
+
+func TestRunOnce_PermanentAndUnknownKindsFailImmediately(t *testing.T) {
+	// Permanent errors and unknown kinds never retry.
+	store := newFakeStore(
+		sqlc.Job{ID: uuid.New(), Kind: "bad", MaxAttempts: 5},
+		sqlc.Job{ID: uuid.New(), Kind: "missing", MaxAttempts: 5},
+	)
+	r := NewRunner(store, 1)
+	r.Register("bad", func(context.Context, json.RawMessage) error { return Permanent(errors.New("invalid payload")) })
+
+	for i := 0; i < 2; i++ {
+		_, err := r.RunOnce(context.Background())
+		require.NoError(t, err)
+	}
+	require.Len(t, store.failed, 2)
+	assert.Empty(t, store.retried)
+	assert.Contains(t, store.failed[1].LastError, `no handler registered for job kind "missing"`)
+}
+
+func TestRunOnce_RecoversPanics(t *testing.T) {
+	// A panicking handler counts as a failed attempt instead of killing the worker.
+	store := newFakeStore(sqlc.Job{ID: uuid.New(), Kind: "boom", MaxAttempts: 5})
+	r := NewRunner(store, 1)
+	r.Register("boom", func(context.Context, json.RawMessage) error { panic("nil map") })
+
+	_, err := r.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, store.retried, 1)
+	assert.Contains(t, store.retried[0].LastError, "job panicked: nil map")
+}
+
+func TestTick_EnqueuesClaimedSchedulesOnce(t *testing.T) {
+	// A due schedule enqueues its job; a schedule claimed elsewhere does not.
+	store := newFakeStore()
+	r := NewRunner(store, 1)
+	require.NoError(t, r.Schedule("nightly", "@daily", "report", map[string]string{"kind": "daily"}))
+	require.Error(t, r.Schedule("broken", "not a spec", "report", nil))
+
+	r.Tick(context.Background())
+	r.Tick(context.Background())
+	require.Len(t, store.enqueued, 1)
+	assert.Equal(t, "report", store.enqueued[0].Kind)
+	assert.JSONEq(t, `{"kind":"daily"}`, string(store.enqueued[0].Payload))
+}
+
+func TestBackoff(t *testing.T) {
+	// Delays double from 30s and cap at an hour.
+	assert.Equal(t, 30*time.Second, backoff(1))
+	assert.Equal(t, 2*time.Minute, backoff(3))
+	assert.Equal(t, time.Hour, backoff(20))
+}
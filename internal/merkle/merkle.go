@@ -0,0 +1,98 @@
+// Package merkle builds the Merkle trees published over ledger entries and the inclusion proofs
+// that let an auditor check an entry is under a published root without trusting the API. Trees
+// follow RFC 6962: leaves are hashed as SHA-256(0x00 || data) and interior nodes as
+// SHA-256(0x01 || left || right), with the left subtree holding the largest power of two leaves
+// smaller than the tree.
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+)
+
+// LeafHash is the hash of a leaf holding data.
+func LeafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x00})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x01})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// split is the size of the left subtree of a tree of n > 1 leaves.
+func split(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+// Root is the root of the tree over the given leaf hashes. An empty tree's root is the hash of
+// nothing.
+func Root(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		sum := sha256.Sum256(nil)
+		return sum[:]
+	case 1:
+		return leaves[0]
+	}
+	k := split(len(leaves))
+	return nodeHash(Root(leaves[:k]), Root(leaves[k:]))
+}
+
+// Proof is the audit path of leaf index in the tree over leaves: the sibling hashes from the
+// leaf up to the root. It is nil when index is out of range.
+func Proof(leaves [][]byte, index int) [][]byte {
+	if index < 0 || index >= len(leaves) {
+		return nil
+	}
+	return path(leaves, index)
+}
+
+func path(leaves [][]byte, index int) [][]byte {
+	if len(leaves) <= 1 {
+		return [][]byte{}
+	}
+	k := split(len(leaves))
+	if index < k {
+		return append(path(leaves[:k], index), Root(leaves[k:]))
+	}
+	return append(path(leaves[k:], index-k), Root(leaves[:k]))
+}
+
+// Verify reports whether proof places leaf (a leaf hash) at index in a tree of size leaves with
+// the given root.
+func Verify(leaf []byte, index, size int, proof [][]byte, root []byte) bool {
+	if index < 0 || index >= size {
+		return false
+	}
+	// RFC 9162 section 2.1.3.2: walk up from the leaf, consuming one sibling per level.
+	fn, sn := index, size-1
+	r := leaf
+	for _, p := range proof {
+		if sn == 0 {
+			return false
+		}
+		if fn&1 == 1 || fn == sn {
+			r = nodeHash(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = nodeHash(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	return sn == 0 && bytes.Equal(r, root)
+}
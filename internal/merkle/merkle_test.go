@@ -0,0 +1,43 @@
+package merkle
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaves[i] = LeafHash([]byte(fmt.Sprintf("entry-%d", i)))
+	}
+	return leaves
+}
+
+func TestRoot(t *testing.T) {
+	// RFC 6962 shapes: a lone leaf is the root, and an uneven tree splits at a power of two.
+	leaves := testLeaves(3)
+	assert.Equal(t, leaves[0], Root(leaves[:1]))
+	assert.Equal(t, nodeHash(nodeHash(leaves[0], leaves[1]), leaves[2]), Root(leaves))
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", hex.EncodeToString(Root(nil)))
+}
+
+func TestProofVerify(t *testing.T) {
+	// Every leaf of every tree size proves against its root, and nothing else does.
+	for size := 1; size <= 17; size++ {
+		leaves := testLeaves(size)
+		root := Root(leaves)
+		for i := range leaves {
+			proof := Proof(leaves, i)
+			assert.True(t, Verify(leaves[i], i, size, proof, root), "size %d leaf %d", size, i)
+			assert.False(t, Verify(LeafHash([]byte("forged")), i, size, proof, root))
+			if size > 1 {
+				assert.False(t, Verify(leaves[i], (i+1)%size, size, proof, root))
+			}
+		}
+	}
+	assert.Nil(t, Proof(testLeaves(2), 2))
+	assert.False(t, Verify(testLeaves(1)[0], 1, 1, nil, Root(testLeaves(1))))
+}
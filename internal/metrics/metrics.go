@@ -0,0 +1,179 @@
+// Package metrics keeps the ledger's business counters and gauges, the numbers on-call and product
+// watch, and writes them in the Prometheus text exposition format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Registry holds metric families and writes them out.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// Default is the registry NewCounter, NewGauge and Handler use.
+var Default = NewRegistry()
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// family is one named metric and its samples, one per combination of label values.
+type family struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+
+	mu      sync.Mutex
+	samples map[string]*sample
+}
+
+type sample struct {
+	labelValues []string
+	value       float64
+}
+
+// register adds a family, or returns the one already registered under name.
+func (r *Registry) register(name, help, kind string, labels []string) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.families[name]; ok {
+		if f.kind != kind || !slices.Equal(f.labels, labels) {
+			panic(fmt.Sprintf("metrics: %s registered twice with different kinds or labels", name))
+		}
+		return f
+	}
+	f := &family{name: name, help: help, kind: kind, labels: labels, samples: make(map[string]*sample)}
+	r.families[name] = f
+	return f
+}
+
+// update applies fn to the sample for labelValues, creating it at zero.
+func (f *family) update(labelValues []string, fn func(float64) float64) {
+	if len(labelValues) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s takes %d label values, got %d", f.name, len(f.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.samples[key]
+	if !ok {
+		s = &sample{labelValues: slices.Clone(labelValues)}
+		f.samples[key] = s
+	}
+	s.value = fn(s.value)
+}
+
+// Counter is a metric that only goes up, such as operations posted.
+type Counter struct{ f *family }
+
+// Counter registers a counter with the given label names.
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	return &Counter{f: r.register(name, help, "counter", labels)}
+}
+
+// NewCounter registers a counter in Default.
+func NewCounter(name, help string, labels ...string) *Counter {
+	return Default.Counter(name, help, labels...)
+}
+
+// Add increases the counter for labelValues by v; negative values are ignored.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+	c.f.update(labelValues, func(cur float64) float64 { return cur + v })
+}
+
+// Inc increases the counter for labelValues by one.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Gauge is a metric that is set to its current value, such as the size of a backlog.
+type Gauge struct{ f *family }
+
+// Gauge registers a gauge with the given label names.
+func (r *Registry) Gauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{f: r.register(name, help, "gauge", labels)}
+}
+
+// NewGauge registers a gauge in Default.
+func NewGauge(name, help string, labels ...string) *Gauge {
+	return Default.Gauge(name, help, labels...)
+}
+
+// Set sets the gauge for labelValues to v.
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	g.f.update(labelValues, func(float64) float64 { return v })
+}
+
+// WriteTo writes every family in the Prometheus text format, families by name and samples by
+// label values, so output is stable between scrapes.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.Unlock()
+	slices.SortFunc(families, func(a, b *family) int { return strings.Compare(a.name, b.name) })
+
+	var b strings.Builder
+	for _, f := range families {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", f.name, escapeHelp(f.help), f.name, f.kind)
+		f.mu.Lock()
+		samples := make([]sample, 0, len(f.samples))
+		for _, s := range f.samples {
+			samples = append(samples, *s)
+		}
+		f.mu.Unlock()
+		slices.SortFunc(samples, func(a, b sample) int { return slices.Compare(a.labelValues, b.labelValues) })
+		for _, s := range samples {
+			b.WriteString(f.name)
+			if len(f.labels) > 0 {
+				b.WriteByte('{')
+				for i, name := range f.labels {
+					if i > 0 {
+						b.WriteByte(',')
+					}
+					fmt.Fprintf(&b, "%s=\"%s\"", name, escapeLabel(s.labelValues[i]))
+				}
+				b.WriteByte('}')
+			}
+			fmt.Fprintf(&b, " %s\n", strconv.FormatFloat(s.value, 'g', -1, 64))
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// Handler serves the registry for Prometheus to scrape.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = r.WriteTo(w)
+	})
+}
+
+// Handler serves Default.
+func Handler() http.Handler {
+	return Default.Handler()
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
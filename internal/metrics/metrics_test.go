@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_WriteTo(t *testing.T) {
+	// Families come out by name and samples by label values, with label values escaped.
+	r := NewRegistry()
+	ops := r.Counter("ledger_operations_total", "Committed operations.", "operation", "currency")
+	ops.Inc("transfer", "NGN")
+	ops.Add(2, "deposit", "NGN")
+	ops.Add(-5, "deposit", "NGN")
+	ops.Inc("deposit", `U"SD`)
+	r.Gauge("backlog", "Jobs waiting.").Set(3.5)
+
+	var b strings.Builder
+	_, err := r.WriteTo(&b)
+	require.NoError(t, err)
+	assert.Equal(t, `# HELP backlog Jobs waiting.
+# TYPE backlog gauge
+backlog 3.5
+# HELP ledger_operations_total Committed operations.
+# TYPE ledger_operations_total counter
+ledger_operations_total{operation="deposit",currency="NGN"} 2
+ledger_operations_total{operation="deposit",currency="U\"SD"} 1
+ledger_operations_total{operation="transfer",currency="NGN"} 1
+`, b.String())
+}
+
+func TestRegistry_RegisterTwice(t *testing.T) {
+	// Registering a name again returns the same family; changing its shape is a programming error.
+	r := NewRegistry()
+	r.Counter("c", "help", "a").Inc("x")
+	r.Counter("c", "help", "a").Inc("x")
+	var b strings.Builder
+	_, err := r.WriteTo(&b)
+	require.NoError(t, err)
+	assert.Contains(t, b.String(), `c{a="x"} 2`)
+
+	assert.Panics(t, func() { r.Gauge("c", "help", "a") })
+	assert.Panics(t, func() { r.Counter("c", "help", "a").Inc() })
+}
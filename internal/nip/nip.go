@@ -0,0 +1,110 @@
+// Package nip models NIBSS Instant Payment (NIP) outbound transfers: name enquiry,
+// funds transfer and transaction status query, behind a mockable Connector.
+package nip
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"github.com/shopspring/decimal"
+)
+
+// Provider is the payouts.provider value for NIP transfers.
+const Provider = "nip"
+
+// NIP response codes referenced by the ledger; Classify decides what each means for held funds.
+const (
+	CodeApproved           = "00"
+	CodeInvalidAccount     = "07"
+	CodeInProgress         = "09"
+	CodeInsufficientFunds  = "51"
+	CodeBankUnavailable    = "91"
+	CodeSystemMalfunction  = "96"
+	CodeTimeout            = "97"
+	CodeDuplicateTransfer  = "26"
+	CodeTransactionUnknown = "25"
+)
+
+// ErrInvalidAccountNumber is returned for anything other than a 10-digit NUBAN.
+var ErrInvalidAccountNumber = errors.New("account number must be a 10-digit NUBAN")
+
+var nubanPattern = regexp.MustCompile(`^[0-9]{10}$`)
+
+// ValidateNUBAN checks the shape of a Nigerian account number.
+func ValidateNUBAN(accountNumber string) error {
+	if !nubanPattern.MatchString(accountNumber) {
+		return ErrInvalidAccountNumber
+	}
+	return nil
+}
+
+// Outcome is what a response code means for the held funds.
+type Outcome int
+
+const (
+	// OutcomeFailed means the beneficiary was not credited; release the hold.
+	OutcomeFailed Outcome = iota
+	// OutcomeSucceeded means the beneficiary bank accepted the credit.
+	OutcomeSucceeded
+	// OutcomePending means the result is unknown; requery before touching the hold.
+	OutcomePending
+)
+
+// Classify maps a NIP response code to an outcome. Timeouts and malfunctions are
+// pending, never failed: reversing a transfer that actually landed would pay twice.
+func Classify(code string) Outcome {
+	switch code {
+	case CodeApproved:
+		return OutcomeSucceeded
+	case CodeInProgress, CodeSystemMalfunction, CodeTimeout, "":
+		return OutcomePending
+	default:
+		return OutcomeFailed
+	}
+}
+
+// NameEnquiryRequest identifies a destination account at another bank.
+type NameEnquiryRequest struct {
+	DestinationInstitutionCode string
+	AccountNumber              string
+}
+
+// NameEnquiryResponse is the destination bank's answer to a name enquiry.
+type NameEnquiryResponse struct {
+	SessionID                  string
+	DestinationInstitutionCode string
+	AccountNumber              string
+	AccountName                string
+	BVN                        string
+	ResponseCode               string
+	KYCLevel                   int
+}
+
+// FundsTransferRequest credits a beneficiary previously confirmed by name enquiry.
+type FundsTransferRequest struct {
+	Amount                     decimal.Decimal
+	NameEnquiryRef             string
+	DestinationInstitutionCode string
+	BeneficiaryAccountNumber   string
+	BeneficiaryAccountName     string
+	OriginatorAccountName      string
+	Narration                  string
+	// PaymentReference is our payout reference; it must be unique per transfer.
+	PaymentReference string
+}
+
+// FundsTransferResponse reports the NIP session and response code for a transfer.
+type FundsTransferResponse struct {
+	SessionID    string
+	ResponseCode string
+}
+
+// Connector is the NIP interface used by the ledger. Implementations must be safe
+// for concurrent use. Transport errors on FundsTransfer leave the outcome unknown.
+type Connector interface {
+	NameEnquiry(ctx context.Context, req NameEnquiryRequest) (NameEnquiryResponse, error)
+	FundsTransfer(ctx context.Context, req FundsTransferRequest) (FundsTransferResponse, error)
+	// TransactionStatus requeries a transfer by the PaymentReference it was sent with.
+	TransactionStatus(ctx context.Context, paymentReference string) (string, error)
+}
@@ -0,0 +1,132 @@
+package nip
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestClassify(t *testing.T) {
+	// Only 00 succeeds; timeouts and malfunctions must never release the hold.
+	assert.Equal(t, OutcomeSucceeded, Classify(CodeApproved))
+	for _, code := range []string{CodeInProgress, CodeSystemMalfunction, CodeTimeout, ""} {
+		assert.Equal(t, OutcomePending, Classify(code), code)
+	}
+	for _, code := range []string{CodeInvalidAccount, CodeInsufficientFunds, CodeBankUnavailable, CodeDuplicateTransfer, CodeTransactionUnknown} {
+		assert.Equal(t, OutcomeFailed, Classify(code), code)
+	}
+}
+
+func TestValidateNUBAN(t *testing.T) {
+	// Account numbers must be exactly ten digits.
+	assert.NoError(t, ValidateNUBAN("0123456789"))
+	assert.ErrorIs(t, ValidateNUBAN("012345678"), ErrInvalidAccountNumber)
+	assert.ErrorIs(t, ValidateNUBAN("01234567ab"), ErrInvalidAccountNumber)
+}
+
+func TestSimulatorScenarios(t *testing.T) {
+	// The final account digit drives name enquiry, decline and timeout behaviour.
+	ctx := context.Background()
+	sim := NewSimulator()
+
+	ne, err := sim.NameEnquiry(ctx, NameEnquiryRequest{DestinationInstitutionCode: "058", AccountNumber: "0123456781"})
+	require.NoError(t, err)
+	assert.Equal(t, CodeApproved, ne.ResponseCode)
+	assert.NotEmpty(t, ne.AccountName)
+	assert.Len(t, ne.SessionID, 30)
+
+	ne, err = sim.NameEnquiry(ctx, NameEnquiryRequest{DestinationInstitutionCode: "058", AccountNumber: "0123456787"})
+	require.NoError(t, err)
+	assert.Equal(t, CodeInvalidAccount, ne.ResponseCode)
+
+	send := func(ref, account string) string {
+		resp, err := sim.FundsTransfer(ctx, FundsTransferRequest{
+			Amount:                     decimal.NewFromInt(100),
+			DestinationInstitutionCode: "058",
+			BeneficiaryAccountNumber:   account,
+			PaymentReference:           ref,
+		})
+		require.NoError(t, err)
+		return resp.ResponseCode
+	}
+	assert.Equal(t, CodeApproved, send("nip_ok", "0123456781"))
+	assert.Equal(t, CodeDuplicateTransfer, send("nip_ok", "0123456781"))
+	assert.Equal(t, CodeBankUnavailable, send("nip_declined", "0123456788"))
+	assert.Equal(t, CodeTimeout, send("nip_timeout", "0123456789"))
+
+	code, err := sim.TransactionStatus(ctx, "nip_timeout")
+	require.NoError(t, err)
+	assert.Equal(t, CodeApproved, code)
+	code, err = sim.TransactionStatus(ctx, "nip_missing")
+	require.NoError(t, err)
+	assert.Equal(t, CodeTransactionUnknown, code)
+}
+
+type fakePayoutStore struct {
+	pending []sqlc.Payout
+	touched []string
+}
+
+func (s *fakePayoutStore) ListPendingPayoutsByProvider(_ context.Context, arg sqlc.ListPendingPayoutsByProviderParams) ([]sqlc.Payout, error) {
+	if arg.Provider != Provider {
+		return nil, errors.New("unexpected provider")
+	}
+	return s.pending, nil
+}
+
+func (s *fakePayoutStore) TouchPayout(_ context.Context, reference string) error {
+	s.touched = append(s.touched, reference)
+	return nil
+}
+
+type fakeCompleter struct {
+	completed map[string]string
+}
+
+func (c *fakeCompleter) CompletePayout(_ context.Context, reference string, succeeded bool, reason string) (sqlc.Payout, error) {
+	if succeeded {
+		c.completed[reference] = "succeeded"
+	} else {
+		c.completed[reference] = reason
+	}
+	return sqlc.Payout{Reference: reference}, nil
+}
+
+type stubStatus map[string]string
+
+func (s stubStatus) NameEnquiry(context.Context, NameEnquiryRequest) (NameEnquiryResponse, error) {
+	return NameEnquiryResponse{}, nil
+}
+
+func (s stubStatus) FundsTransfer(context.Context, FundsTransferRequest) (FundsTransferResponse, error) {
+	return FundsTransferResponse{}, nil
+}
+
+func (s stubStatus) TransactionStatus(_ context.Context, reference string) (string, error) {
+	code, ok := s[reference]
+	if !ok {
+		return "", errors.New("switch unreachable")
+	}
+	return code, nil
+}
+
+func TestRequeryerRunOnce(t *testing.T) {
+	// Final codes complete the payout, pending ones are touched, and query errors are skipped.
+	store := &fakePayoutStore{pending: []sqlc.Payout{
+		{Reference: "nip_a"}, {Reference: "nip_b"}, {Reference: "nip_c"}, {Reference: "nip_d"},
+	}}
+	completer := &fakeCompleter{completed: map[string]string{}}
+	connector := stubStatus{"nip_a": CodeApproved, "nip_b": CodeBankUnavailable, "nip_c": CodeTimeout}
+
+	n, err := NewRequeryer(connector, store, completer).RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, map[string]string{"nip_a": "succeeded", "nip_b": "NIP response 91"}, completer.completed)
+	assert.Equal(t, []string{"nip_c"}, store.touched)
+}
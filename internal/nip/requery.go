@@ -0,0 +1,95 @@
+package nip
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// PayoutStore lists NIP payouts still awaiting a final status. *db.Store satisfies it.
+type PayoutStore interface {
+	ListPendingPayoutsByProvider(ctx context.Context, arg sqlc.ListPendingPayoutsByProviderParams) ([]sqlc.Payout, error)
+	TouchPayout(ctx context.Context, reference string) error
+}
+
+// PayoutCompleter settles or fails a held payout. *service.LedgerService satisfies it.
+type PayoutCompleter interface {
+	CompletePayout(ctx context.Context, reference string, succeeded bool, reason string) (sqlc.Payout, error)
+}
+
+// Requeryer resolves pending NIP transfers with transaction status queries (TSQ).
+type Requeryer struct {
+	connector Connector
+	store     PayoutStore
+	completer PayoutCompleter
+	// minAge gives NIP time to finish before we ask again.
+	minAge time.Duration
+	batch  int32
+}
+
+// NewRequeryer constructs a Requeryer that checks each pending payout at most once per minute.
+func NewRequeryer(connector Connector, store PayoutStore, completer PayoutCompleter) *Requeryer {
+	return &Requeryer{connector: connector, store: store, completer: completer, minAge: time.Minute, batch: 50}
+}
+
+// RunOnce requeries one batch and returns how many payouts reached a final status.
+func (r *Requeryer) RunOnce(ctx context.Context) (int, error) {
+	pending, err := r.store.ListPendingPayoutsByProvider(ctx, sqlc.ListPendingPayoutsByProviderParams{
+		Provider:      Provider,
+		UpdatedBefore: time.Now().Add(-r.minAge),
+		RowLimit:      r.batch,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	resolved := 0
+	for _, p := range pending {
+		code, err := r.connector.TransactionStatus(ctx, p.Reference)
+		if err != nil {
+			log.Warn().Err(err).Str("reference", p.Reference).Msg("NIP status query failed")
+			continue
+		}
+
+		outcome := Classify(code)
+		if outcome == OutcomePending {
+			// Push it to the back of the queue so one stuck transfer cannot starve the batch.
+			if err := r.store.TouchPayout(ctx, p.Reference); err != nil {
+				log.Warn().Err(err).Str("reference", p.Reference).Msg("Failed to touch pending payout")
+			}
+			continue
+		}
+
+		reason := ""
+		if outcome == OutcomeFailed {
+			reason = "NIP response " + code
+		}
+		if _, err := r.completer.CompletePayout(ctx, p.Reference, outcome == OutcomeSucceeded, reason); err != nil {
+			log.Error().Err(err).Str("reference", p.Reference).Msg("Failed to complete NIP payout")
+			continue
+		}
+		resolved++
+	}
+	return resolved, nil
+}
+
+// Run requeries on every tick until ctx is cancelled.
+func (r *Requeryer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := r.RunOnce(ctx); err != nil {
+				log.Error().Err(err).Msg("NIP requery batch failed")
+			} else if n > 0 {
+				log.Info().Int("resolved", n).Msg("NIP payouts resolved by requery")
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package nip
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Simulator is an in-memory Connector for development and tests.
+// The last digit of the beneficiary account selects the scenario:
+//
+//	'7' - name enquiry fails with invalid account (07)
+//	'8' - transfer is declined by the beneficiary bank (91)
+//	'9' - transfer times out (97) and settles as approved on requery
+//
+// Every other account is approved immediately.
+type Simulator struct {
+	transfers map[string]string
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+// NewSimulator constructs an empty Simulator.
+func NewSimulator() *Simulator {
+	return &Simulator{transfers: make(map[string]string), now: time.Now}
+}
+
+// NameEnquiry implements Connector.
+func (s *Simulator) NameEnquiry(_ context.Context, req NameEnquiryRequest) (NameEnquiryResponse, error) {
+	if err := ValidateNUBAN(req.AccountNumber); err != nil {
+		return NameEnquiryResponse{}, err
+	}
+	resp := NameEnquiryResponse{
+		SessionID:                  s.sessionID(req.DestinationInstitutionCode),
+		DestinationInstitutionCode: req.DestinationInstitutionCode,
+		AccountNumber:              req.AccountNumber,
+		ResponseCode:               CodeApproved,
+		KYCLevel:                   3,
+	}
+	if strings.HasSuffix(req.AccountNumber, "7") {
+		resp.ResponseCode = CodeInvalidAccount
+		return resp, nil
+	}
+	resp.AccountName = "SIMULATED BENEFICIARY " + req.AccountNumber[6:]
+	return resp, nil
+}
+
+// FundsTransfer implements Connector.
+func (s *Simulator) FundsTransfer(_ context.Context, req FundsTransferRequest) (FundsTransferResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, seen := s.transfers[req.PaymentReference]; seen {
+		return FundsTransferResponse{ResponseCode: CodeDuplicateTransfer}, nil
+	}
+	code := CodeApproved
+	final := CodeApproved
+	switch {
+	case strings.HasSuffix(req.BeneficiaryAccountNumber, "8"):
+		code, final = CodeBankUnavailable, CodeBankUnavailable
+	case strings.HasSuffix(req.BeneficiaryAccountNumber, "9"):
+		code = CodeTimeout
+	}
+	s.transfers[req.PaymentReference] = final
+	return FundsTransferResponse{SessionID: s.sessionID(req.DestinationInstitutionCode), ResponseCode: code}, nil
+}
+
+// TransactionStatus implements Connector.
+func (s *Simulator) TransactionStatus(_ context.Context, paymentReference string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code, ok := s.transfers[paymentReference]
+	if !ok {
+		return CodeTransactionUnknown, nil
+	}
+	return code, nil
+}
+
+// sessionID follows the 30-digit NIP layout: source code, yyMMddHHmmss, 12 random digits.
+func (s *Simulator) sessionID(institution string) string {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000_000_000))
+	if err != nil {
+		n = big.NewInt(s.now().UnixNano() % 1_000_000_000_000)
+	}
+	if len(institution) < 6 {
+		institution = strings.Repeat("0", 6-len(institution)) + institution
+	}
+	return fmt.Sprintf("%s%s%012d", institution[:6], s.now().UTC().Format("060102150405"), n)
+}
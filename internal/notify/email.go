@@ -0,0 +1,123 @@
+// Package notify delivers customer-facing alerts for committed ledger activity.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sestypes "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/rs/zerolog/log"
+)
+
+// EmailMessage is a plain-text email ready to be handed to a provider.
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// EmailSender abstracts the transport used to deliver email.
+type EmailSender interface {
+	SendEmail(ctx context.Context, msg EmailMessage) error
+}
+
+// SMTPConfig holds connection settings for a plain SMTP relay.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPSender delivers email through an SMTP relay using PLAIN auth.
+type SMTPSender struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPSender validates cfg and constructs an SMTPSender.
+func NewSMTPSender(cfg SMTPConfig) (*SMTPSender, error) {
+	if cfg.Host == "" || cfg.From == "" {
+		return nil, errors.New("SMTP host and from address are required")
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+	return &SMTPSender{cfg: cfg}, nil
+}
+
+// SendEmail implements EmailSender.
+func (s *SMTPSender) SendEmail(_ context.Context, msg EmailMessage) error {
+	addr := net.JoinHostPort(s.cfg.Host, s.cfg.Port)
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+	return smtp.SendMail(addr, auth, s.cfg.From, []string{msg.To}, buildMIME(s.cfg.From, msg))
+}
+
+// buildMIME renders a minimal RFC 5322 message with CRLF line endings.
+func buildMIME(from string, msg EmailMessage) []byte {
+	var b strings.Builder
+	// Strip CR/LF from header values so user data can never inject extra headers.
+	fmt.Fprintf(&b, "From: %s\r\n", sanitizeHeader(from))
+	fmt.Fprintf(&b, "To: %s\r\n", sanitizeHeader(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", sanitizeHeader(msg.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+func sanitizeHeader(v string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(v)
+}
+
+// SESSender delivers email through the Amazon SES v2 API.
+type SESSender struct {
+	client *sesv2.Client
+	from   string
+}
+
+// NewSESSender loads AWS credentials from the default chain and constructs an SESSender.
+func NewSESSender(ctx context.Context, region, from string) (*SESSender, error) {
+	if from == "" {
+		return nil, errors.New("SES from address is required")
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("load AWS config: %w", err)
+	}
+	return &SESSender{client: sesv2.NewFromConfig(cfg), from: from}, nil
+}
+
+// SendEmail implements EmailSender.
+func (s *SESSender) SendEmail(ctx context.Context, msg EmailMessage) error {
+	_, err := s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(s.from),
+		Destination:      &sestypes.Destination{ToAddresses: []string{msg.To}},
+		Content: &sestypes.EmailContent{
+			Simple: &sestypes.Message{
+				Subject: &sestypes.Content{Data: aws.String(msg.Subject)},
+				Body:    &sestypes.Body{Text: &sestypes.Content{Data: aws.String(msg.Body)}},
+			},
+		},
+	})
+	return err
+}
+
+// LogEmailSender writes emails to the log instead of sending them; the local-development default.
+type LogEmailSender struct{}
+
+// SendEmail implements EmailSender.
+func (LogEmailSender) SendEmail(_ context.Context, msg EmailMessage) error {
+	log.Info().Str("to", msg.To).Str("subject", msg.Subject).Msg("Email notification (log sender)")
+	return nil
+}
@@ -0,0 +1,176 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/i18n"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/pii"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// Directory resolves accounts, their owners and owner preferences. *db.Store satisfies it.
+type Directory interface {
+	GetAccount(ctx context.Context, id uuid.UUID) (sqlc.Account, error)
+	GetUser(ctx context.Context, id uuid.UUID) (sqlc.User, error)
+	GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (sqlc.NotificationPreference, error)
+}
+
+// Notifier turns committed ledger events into customer alerts.
+type Notifier struct {
+	directory Directory
+	email     EmailSender
+	sms       SMSSender
+	// pii opens sealed phones; nil means phones are stored in plaintext.
+	pii *pii.Keyring
+}
+
+// Option customizes optional Notifier channels.
+type Option func(*Notifier)
+
+// WithSMS enables debit/credit SMS alerts through sender.
+func WithSMS(sender SMSSender) Option {
+	return func(n *Notifier) {
+		n.sms = sender
+	}
+}
+
+// WithPII opens phones sealed by keyring before sending SMS alerts.
+func WithPII(keyring *pii.Keyring) Option {
+	return func(n *Notifier) {
+		n.pii = keyring
+	}
+}
+
+// NewNotifier constructs a Notifier that emails account owners via sender.
+func NewNotifier(directory Directory, sender EmailSender, opts ...Option) *Notifier {
+	n := &Notifier{directory: directory, email: sender}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// HandleEvent is an events.Handler that alerts the owner of every customer account touched by evt.
+func (n *Notifier) HandleEvent(ctx context.Context, evt events.Event) error {
+	var errs []error
+	for _, entry := range evt.Entries {
+		if err := n.notifyEntry(ctx, evt, entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// shouldEmail limits email alerts to money arriving, or leaving through a withdrawal or a paid
+// payment request. Outgoing transfer legs are initiated by the owner in-session and are not alerted.
+func shouldEmail(t events.Type, entry sqlc.Entry) bool {
+	if isPositive(entry.Credit) {
+		return true
+	}
+	return (t == events.TypeWithdrawal || t == events.TypePaymentRequest) && isPositive(entry.Debit)
+}
+
+func (n *Notifier) notifyEntry(ctx context.Context, evt events.Event, entry sqlc.Entry) error {
+	acc, err := n.directory.GetAccount(ctx, entry.AccountID)
+	if err != nil {
+		return fmt.Errorf("load account %s: %w", entry.AccountID, err)
+	}
+	// System accounts (settlement) have no owner to notify.
+	if acc.IsSystem || !acc.OwnerID.Valid {
+		return nil
+	}
+
+	user, err := n.directory.GetUser(ctx, acc.OwnerID.UUID)
+	if err != nil {
+		return fmt.Errorf("load owner of %s: %w", acc.ID, err)
+	}
+
+	prefs, err := n.preferences(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if prefs.EmailEnabled && shouldEmail(evt.Type, entry) {
+		msg := buildEmail(evt, acc, entry, user.Locale)
+		msg.To = user.Email
+		if err := n.email.SendEmail(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("send email for %s: %w", evt.TransactionID, err))
+		} else {
+			log.Info().
+				Str("tx_id", evt.TransactionID.String()).
+				Str("account_id", acc.ID.String()).
+				Msg("Transaction email sent")
+		}
+	}
+
+	// SMS alerts cover every debit and credit, matching what bank customers expect.
+	if n.sms != nil && prefs.SmsEnabled && user.Phone.Valid && user.Phone.String != "" {
+		body := buildSMS(evt, acc, entry)
+		phone, err := n.pii.Open(user.Phone.String)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("open phone of %s: %w", user.ID, err))
+		} else if err := n.sms.SendSMS(ctx, phone, body); err != nil {
+			errs = append(errs, fmt.Errorf("send sms for %s: %w", evt.TransactionID, err))
+		} else {
+			log.Info().
+				Str("tx_id", evt.TransactionID.String()).
+				Str("account_id", acc.ID.String()).
+				Msg("Transaction SMS sent")
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// preferences loads the owner's channel choices, defaulting to email-only when unset.
+func (n *Notifier) preferences(ctx context.Context, userID uuid.UUID) (sqlc.NotificationPreference, error) {
+	prefs, err := n.directory.GetNotificationPreferences(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.NotificationPreference{UserID: userID, EmailEnabled: true}, nil
+	}
+	if err != nil {
+		return sqlc.NotificationPreference{}, fmt.Errorf("load notification preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// buildSMS renders the short debit/credit alert for one entry. SMS alerts keep the CR/DR bank
+// alert format in every locale: customers recognise it and it fits one GSM-7 message.
+func buildSMS(evt events.Event, acc sqlc.Account, entry sqlc.Entry) string {
+	direction, amount := "CR", entry.Credit
+	if isPositive(entry.Debit) {
+		direction, amount = "DR", entry.Debit
+	}
+	msg := FormatSMSAlert(acc.ID.String(), direction, acc.Currency, amount, evt.Balances[acc.ID])
+	return fmt.Sprintf("%s Desc:%s Ref:%s", msg, evt.Type, lastN(evt.TransactionID.String(), 8))
+}
+
+// buildEmail renders the alert subject and body for one entry in the owner's locale.
+func buildEmail(evt events.Event, acc sqlc.Account, entry sqlc.Entry, locale string) EmailMessage {
+	subject, greeting, amount := "Credit alert: %s %s on %s", "Hello,\n\nA credit of %s %s has posted to your account %q.\n\n", entry.Credit
+	if isPositive(entry.Debit) {
+		subject, greeting, amount = "Debit alert: %s %s on %s", "Hello,\n\nA debit of %s %s has posted to your account %q.\n\n", entry.Debit
+	}
+
+	body := i18n.Sprintf(locale, greeting, acc.Currency, amount, acc.Name) +
+		i18n.Sprintf(locale, "Transaction: %s\nType: %s\nAvailable balance: %s %s\n\n", evt.TransactionID, evt.Type, acc.Currency, evt.Balances[acc.ID]) +
+		i18n.T(locale, "If you did not expect this transaction, please contact support immediately.\n")
+
+	return EmailMessage{
+		Subject: i18n.Sprintf(locale, subject, acc.Currency, amount, acc.Name),
+		Body:    body,
+	}
+}
+
+func isPositive(v string) bool {
+	d, err := decimal.NewFromString(v)
+	return err == nil && d.IsPositive()
+}
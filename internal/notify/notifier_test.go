@@ -0,0 +1,198 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+type fakeDirectory struct {
+	accounts map[uuid.UUID]sqlc.Account
+	users    map[uuid.UUID]sqlc.User
+	prefs    map[uuid.UUID]sqlc.NotificationPreference
+}
+
+func (f *fakeDirectory) GetNotificationPreferences(_ context.Context, userID uuid.UUID) (sqlc.NotificationPreference, error) {
+	p, ok := f.prefs[userID]
+	if !ok {
+		return sqlc.NotificationPreference{}, sql.ErrNoRows
+	}
+	return p, nil
+}
+
+func (f *fakeDirectory) GetAccount(_ context.Context, id uuid.UUID) (sqlc.Account, error) {
+	acc, ok := f.accounts[id]
+	if !ok {
+		return sqlc.Account{}, errors.New("not found")
+	}
+	return acc, nil
+}
+
+func (f *fakeDirectory) GetUser(_ context.Context, id uuid.UUID) (sqlc.User, error) {
+	user, ok := f.users[id]
+	if !ok {
+		return sqlc.User{}, errors.New("not found")
+	}
+	return user, nil
+}
+
+type captureSender struct {
+	sent []EmailMessage
+	sms  []string
+}
+
+func (c *captureSender) SendSMS(_ context.Context, _, body string) error {
+	c.sms = append(c.sms, body)
+	return nil
+}
+
+func (c *captureSender) SendEmail(_ context.Context, msg EmailMessage) error {
+	c.sent = append(c.sent, msg)
+	return nil
+}
+
+func newFixture() (*fakeDirectory, sqlc.Account, sqlc.Account, sqlc.Account) {
+	owner := sqlc.User{ID: uuid.New(), Email: "owner@example.com", Phone: sql.NullString{String: "+2348012345678", Valid: true}}
+	other := sqlc.User{ID: uuid.New(), Email: "other@example.com"}
+	userAcc := sqlc.Account{ID: uuid.New(), Name: "Main", Currency: "USD", OwnerID: uuid.NullUUID{UUID: owner.ID, Valid: true}}
+	otherAcc := sqlc.Account{ID: uuid.New(), Name: "Other", Currency: "USD", OwnerID: uuid.NullUUID{UUID: other.ID, Valid: true}}
+	settlement := sqlc.Account{ID: uuid.New(), Name: "Settlement Account", Currency: "USD", IsSystem: true}
+	dir := &fakeDirectory{
+		accounts: map[uuid.UUID]sqlc.Account{userAcc.ID: userAcc, otherAcc.ID: otherAcc, settlement.ID: settlement},
+		users:    map[uuid.UUID]sqlc.User{owner.ID: owner, other.ID: other},
+		prefs:    map[uuid.UUID]sqlc.NotificationPreference{},
+	}
+	return dir, userAcc, otherAcc, settlement
+}
+
+func TestHandleEvent_DepositEmailsOwnerOnly(t *testing.T) {
+	// Settlement leg must be skipped; only the customer receives an alert.
+	dir, userAcc, _, settlement := newFixture()
+	sender := &captureSender{}
+	n := NewNotifier(dir, sender)
+
+	err := n.HandleEvent(context.Background(), events.Event{
+		Type:          events.TypeDeposit,
+		TransactionID: uuid.New(),
+		Entries: []sqlc.Entry{
+			{AccountID: userAcc.ID, Debit: "0.0000", Credit: "100.0000"},
+			{AccountID: settlement.ID, Debit: "100.0000", Credit: "0.0000"},
+		},
+		Balances: map[uuid.UUID]string{userAcc.ID: "100.0000"},
+	})
+	require.NoError(t, err)
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "owner@example.com", sender.sent[0].To)
+	assert.Contains(t, sender.sent[0].Subject, "Credit alert")
+	assert.Contains(t, sender.sent[0].Body, "100.0000")
+}
+
+func TestHandleEvent_TransferAlertsRecipientOnly(t *testing.T) {
+	// Outgoing transfer legs are not alerted; incoming ones are.
+	dir, userAcc, otherAcc, _ := newFixture()
+	sender := &captureSender{}
+	n := NewNotifier(dir, sender)
+
+	err := n.HandleEvent(context.Background(), events.Event{
+		Type:          events.TypeTransfer,
+		TransactionID: uuid.New(),
+		Entries: []sqlc.Entry{
+			{AccountID: userAcc.ID, Debit: "25.0000", Credit: "0.0000"},
+			{AccountID: otherAcc.ID, Debit: "0.0000", Credit: "25.0000"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "other@example.com", sender.sent[0].To)
+}
+
+func TestHandleEvent_PaymentRequestAlertsBothSides(t *testing.T) {
+	// A paid payment request alerts the payer as well as the requester.
+	dir, userAcc, otherAcc, _ := newFixture()
+	sender := &captureSender{}
+	n := NewNotifier(dir, sender)
+
+	err := n.HandleEvent(context.Background(), events.Event{
+		Type:          events.TypePaymentRequest,
+		TransactionID: uuid.New(),
+		Entries: []sqlc.Entry{
+			{AccountID: userAcc.ID, Debit: "25.0000", Credit: "0.0000"},
+			{AccountID: otherAcc.ID, Debit: "0.0000", Credit: "25.0000"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, sender.sent, 2)
+	assert.Contains(t, sender.sent[0].Subject, "Debit alert")
+	assert.Equal(t, "other@example.com", sender.sent[1].To)
+}
+
+func TestHandleEvent_EmailsInOwnerLocale(t *testing.T) {
+	// Owners who chose a language get their alert in it.
+	dir, userAcc, _, settlement := newFixture()
+	owner := dir.users[userAcc.OwnerID.UUID]
+	owner.Locale = "fr"
+	dir.users[owner.ID] = owner
+	sender := &captureSender{}
+	n := NewNotifier(dir, sender)
+
+	err := n.HandleEvent(context.Background(), events.Event{
+		Type:          events.TypeDeposit,
+		TransactionID: uuid.New(),
+		Entries: []sqlc.Entry{
+			{AccountID: userAcc.ID, Debit: "0.0000", Credit: "100.0000"},
+			{AccountID: settlement.ID, Debit: "100.0000", Credit: "0.0000"},
+		},
+		Balances: map[uuid.UUID]string{userAcc.ID: "100.0000"},
+	})
+	require.NoError(t, err)
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "Alerte crédit : USD 100.0000 sur Main", sender.sent[0].Subject)
+	assert.Contains(t, sender.sent[0].Body, "Solde disponible : USD 100.0000")
+}
+
+func TestBuildMIME_StripsHeaderInjection(t *testing.T) {
+	// CR/LF in subject must not create new headers.
+	raw := string(buildMIME("bank@example.com", EmailMessage{To: "a@example.com", Subject: "hi\r\nBcc: evil@example.com", Body: "x"}))
+	assert.NotContains(t, raw, "\r\nBcc:")
+}
+
+func TestHandleEvent_SMSRespectsPreferences(t *testing.T) {
+	// SMS goes out for debits too, but only when the owner opted in.
+	dir, userAcc, otherAcc, _ := newFixture()
+	dir.prefs[userAcc.OwnerID.UUID] = sqlc.NotificationPreference{UserID: userAcc.OwnerID.UUID, EmailEnabled: false, SmsEnabled: true}
+	sender := &captureSender{}
+	n := NewNotifier(dir, sender, WithSMS(sender))
+
+	err := n.HandleEvent(context.Background(), events.Event{
+		Type:          events.TypeTransfer,
+		TransactionID: uuid.New(),
+		Entries: []sqlc.Entry{
+			{AccountID: userAcc.ID, Debit: "5000.0000", Credit: "0.0000"},
+			{AccountID: otherAcc.ID, Debit: "0.0000", Credit: "5000.0000"},
+		},
+		Balances: map[uuid.UUID]string{userAcc.ID: "20000.0000"},
+	})
+	require.NoError(t, err)
+	require.Len(t, sender.sms, 1)
+	assert.Contains(t, sender.sms[0], "DR USD5,000.00 Bal USD20,000.00")
+	// Recipient has no preferences row, so they get the default email only.
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "other@example.com", sender.sent[0].To)
+}
+
+func TestFormatSMSAlert(t *testing.T) {
+	// Classic bank alert format with masked account reference and grouped digits.
+	msg := FormatSMSAlert("0000-1234", "CR", "NGN", "5000", "20000.5")
+	assert.Equal(t, "Acct ...1234 CR NGN5,000.00 Bal NGN20,000.50", msg)
+	assert.Equal(t, "1,234,567.89", formatMoney("NGN", "1234567.891"))
+	assert.Equal(t, "999.00", formatMoney("USD", "999"))
+	assert.Equal(t, "1,500", formatMoney("JPY", "1500.0000"))
+}
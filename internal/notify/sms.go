@@ -0,0 +1,170 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+)
+
+// SMSSender abstracts the provider used to deliver text messages.
+type SMSSender interface {
+	SendSMS(ctx context.Context, to, body string) error
+}
+
+// defaultHTTPClient bounds provider calls so a hung API cannot pin event goroutines.
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// TwilioSender delivers SMS through the Twilio Messages API.
+type TwilioSender struct {
+	client     *http.Client
+	accountSID string
+	authToken  string
+	from       string
+	baseURL    string
+}
+
+// NewTwilioSender constructs a TwilioSender for the given account credentials.
+func NewTwilioSender(accountSID, authToken, from string) (*TwilioSender, error) {
+	if accountSID == "" || authToken == "" || from == "" {
+		return nil, errors.New("twilio account SID, auth token and sender are required")
+	}
+	return &TwilioSender{
+		client:     defaultHTTPClient,
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		baseURL:    "https://api.twilio.com",
+	}, nil
+}
+
+// SendSMS implements SMSSender.
+func (s *TwilioSender) SendSMS(ctx context.Context, to, body string) error {
+	form := url.Values{"To": {to}, "From": {s.from}, "Body": {body}}
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", s.baseURL, url.PathEscape(s.accountSID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doProviderRequest(s.client, req, "twilio")
+}
+
+// TermiiSender delivers SMS through the Termii messaging API, common for Nigerian numbers.
+type TermiiSender struct {
+	client   *http.Client
+	apiKey   string
+	senderID string
+	baseURL  string
+}
+
+// NewTermiiSender constructs a TermiiSender using the registered sender ID.
+func NewTermiiSender(apiKey, senderID string) (*TermiiSender, error) {
+	if apiKey == "" || senderID == "" {
+		return nil, errors.New("termii API key and sender ID are required")
+	}
+	return &TermiiSender{
+		client:   defaultHTTPClient,
+		apiKey:   apiKey,
+		senderID: senderID,
+		baseURL:  "https://api.ng.termii.com",
+	}, nil
+}
+
+// SendSMS implements SMSSender.
+func (s *TermiiSender) SendSMS(ctx context.Context, to, body string) error {
+	payload, err := json.Marshal(map[string]string{
+		"api_key": s.apiKey,
+		"to":      strings.TrimPrefix(to, "+"),
+		"from":    s.senderID,
+		"sms":     body,
+		"type":    "plain",
+		"channel": "dnd", // DND route still reaches numbers that opted out of promotional SMS.
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/api/sms/send", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return doProviderRequest(s.client, req, "termii")
+}
+
+// doProviderRequest executes req and converts non-2xx responses into errors.
+func doProviderRequest(client *http.Client, req *http.Request, provider string) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", provider, err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Warn().Err(closeErr).Str("provider", provider).Msg("Failed to close provider response body")
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned %d: %s", provider, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
+
+// LogSMSSender writes SMS to the log instead of sending them; the local-development default.
+type LogSMSSender struct{}
+
+// SendSMS implements SMSSender.
+func (LogSMSSender) SendSMS(_ context.Context, to, body string) error {
+	log.Info().Str("to", maskPhone(to)).Str("body", body).Msg("SMS notification (log sender)")
+	return nil
+}
+
+// FormatSMSAlert renders the classic bank alert, e.g.
+// "Acct ...1234 CR USD5,000.00 Bal USD20,000.00".
+func FormatSMSAlert(accountRef, direction, currency, amount, balance string) string {
+	msg := fmt.Sprintf("Acct ...%s %s %s%s", lastN(accountRef, 4), direction, currency, formatMoney(currency, amount))
+	if balance != "" {
+		msg += fmt.Sprintf(" Bal %s%s", currency, formatMoney(currency, balance))
+	}
+	return msg
+}
+
+// formatMoney renders a decimal string with thousands separators and the currency's decimals.
+func formatMoney(currency, v string) string {
+	d, err := decimal.NewFromString(v)
+	if err != nil {
+		return v
+	}
+	return currencies.Display(currency, d)
+}
+
+func lastN(s string, n int) string {
+	s = strings.ReplaceAll(s, "-", "")
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToUpper(s[len(s)-n:])
+}
+
+// maskPhone keeps only the last four digits for log output.
+func maskPhone(phone string) string {
+	if len(phone) <= 4 {
+		return "****"
+	}
+	return strings.Repeat("*", len(phone)-4) + phone[len(phone)-4:]
+}
@@ -0,0 +1,181 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/i18n"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/statement"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// Statement delivery choices stored on statement_preferences.
+const (
+	StatementEmail = "email"
+	StatementLink  = "link"
+	StatementOff   = "off"
+)
+
+// KindMonthlyStatements is the background job kind that sends last month's statements.
+const KindMonthlyStatements = "statements.monthly"
+
+// statementLinkTTL is how long a statement download link stays valid.
+const statementLinkTTL = 30 * 24 * time.Hour
+
+// StatementStore loads accounts due a statement and records sent ones. *db.Store satisfies it.
+type StatementStore interface {
+	statement.Source
+	GetUser(ctx context.Context, id uuid.UUID) (sqlc.User, error)
+	ListAccountsDueStatement(ctx context.Context, arg sqlc.ListAccountsDueStatementParams) ([]sqlc.ListAccountsDueStatementRow, error)
+	RecordMonthlyStatement(ctx context.Context, arg sqlc.RecordMonthlyStatementParams) error
+}
+
+// StatementMailer emails each account's monthly statement to its primary owner.
+type StatementMailer struct {
+	store StatementStore
+	email EmailSender
+	now   func() time.Time
+	// linkBaseURL and linkSecret enable "link" delivery; without them link preferences get the full email.
+	linkBaseURL string
+	linkSecret  []byte
+	batch       int32
+}
+
+// StatementOption customizes a StatementMailer.
+type StatementOption func(*StatementMailer)
+
+// WithStatementLinks lets owners choose a signed download link instead of the statement in the email.
+// baseURL is the public API origin, e.g. https://api.example.com.
+func WithStatementLinks(baseURL string, secret []byte) StatementOption {
+	return func(m *StatementMailer) {
+		m.linkBaseURL = strings.TrimRight(baseURL, "/")
+		m.linkSecret = secret
+	}
+}
+
+// NewStatementMailer constructs a StatementMailer that sends through sender.
+func NewStatementMailer(store StatementStore, sender EmailSender, opts ...StatementOption) *StatementMailer {
+	m := &StatementMailer{store: store, email: sender, now: time.Now, batch: 100}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// SendMonthly is a jobs.HandlerFunc that sends the statement for the last closed calendar month
+// to every account that has not received it yet. Accounts that fail are retried with the job.
+func (m *StatementMailer) SendMonthly(ctx context.Context, _ json.RawMessage) error {
+	from, to := statement.MonthBounds(m.now().UTC().AddDate(0, -1, 0))
+
+	var (
+		errs  []error
+		after uuid.UUID
+		sent  int
+	)
+	for {
+		rows, err := m.store.ListAccountsDueStatement(ctx, sqlc.ListAccountsDueStatementParams{
+			PeriodStart: from,
+			PeriodEnd:   to,
+			AfterID:     after,
+			RowLimit:    m.batch,
+		})
+		if err != nil {
+			return errors.Join(append(errs, fmt.Errorf("list accounts due statement: %w", err))...)
+		}
+		for _, row := range rows {
+			after = row.ID
+			if err := m.sendStatement(ctx, row, from, to); err != nil {
+				log.Error().Err(err).Str("account_id", row.ID.String()).Msg("Failed to send monthly statement")
+				errs = append(errs, err)
+				continue
+			}
+			sent++
+		}
+		if len(rows) < int(m.batch) {
+			break
+		}
+	}
+
+	log.Info().Str("period", from.Format(statement.PeriodLayout)).Int("sent", sent).Int("failed", len(errs)).Msg("Monthly statements sent")
+	return errors.Join(errs...)
+}
+
+// sendStatement builds, emails and records one account's statement for [from, to).
+func (m *StatementMailer) sendStatement(ctx context.Context, row sqlc.ListAccountsDueStatementRow, from, to time.Time) error {
+	if !row.OwnerID.Valid {
+		return nil
+	}
+	owner, err := m.store.GetUser(ctx, row.OwnerID.UUID)
+	if err != nil {
+		return fmt.Errorf("load owner of %s: %w", row.ID, err)
+	}
+	st, err := statement.Build(ctx, m.store, row.ID, from, to)
+	if err != nil {
+		return fmt.Errorf("build statement for %s: %w", row.ID, err)
+	}
+
+	name := strings.TrimSpace(owner.FirstName + " " + owner.LastName)
+	if name == "" {
+		name = owner.Email
+	}
+	st.Owner = &statement.Party{Name: name}
+
+	delivery := row.Delivery
+	if delivery == StatementLink && len(m.linkSecret) == 0 {
+		delivery = StatementEmail
+	}
+	msg, err := m.buildStatementEmail(st, delivery, owner.Locale)
+	if err != nil {
+		return err
+	}
+	msg.To = owner.Email
+	if err := m.email.SendEmail(ctx, msg); err != nil {
+		return fmt.Errorf("send statement for %s: %w", row.ID, err)
+	}
+
+	// Recorded after sending: a crash in between resends rather than silently skipping a statement.
+	return m.store.RecordMonthlyStatement(ctx, sqlc.RecordMonthlyStatementParams{
+		AccountID:   row.ID,
+		PeriodStart: from,
+		Delivery:    delivery,
+		SentTo:      owner.Email,
+	})
+}
+
+// buildStatementEmail renders the full statement, or a summary with a signed download link, in
+// the owner's locale. The statement itself is in English.
+func (m *StatementMailer) buildStatementEmail(st statement.Statement, delivery, locale string) (EmailMessage, error) {
+	period := i18n.FormatMonth(locale, st.From)
+	msg := EmailMessage{Subject: i18n.Sprintf(locale, "Your %s statement for %s", period, st.Account.Name)}
+
+	var b strings.Builder
+	b.WriteString(i18n.Sprintf(locale, "Hello,\n\nYour statement for %q covering %s is ready.\n\n", st.Account.Name, period))
+	b.WriteString(i18n.Sprintf(locale, "Opening balance: %s %s\nClosing balance: %s %s\n\n",
+		st.Account.Currency, currencies.Format(st.Account.Currency, st.OpeningBalance), st.Account.Currency, currencies.Format(st.Account.Currency, st.ClosingBalance)))
+
+	if delivery == StatementLink {
+		expires := m.now().Add(statementLinkTTL).Unix()
+		q := url.Values{}
+		q.Set("expires", fmt.Sprint(expires))
+		q.Set("sig", statement.LinkSignature(m.linkSecret, st.Account.ID, st.From, expires))
+		b.WriteString(i18n.Sprintf(locale, "Download it (ISO 20022 camt.053) until %s:\n%s/statements/%s/%s?%s\n",
+			i18n.FormatDate(locale, time.Unix(expires, 0).UTC()), m.linkBaseURL, st.Account.ID, st.From.Format(statement.PeriodLayout), q.Encode()))
+		msg.Body = b.String()
+		return msg, nil
+	}
+
+	if err := statement.WriteText(&b, st); err != nil {
+		return EmailMessage{}, fmt.Errorf("render statement for %s: %w", st.Account.ID, err)
+	}
+	msg.Body = b.String()
+	return msg, nil
+}
@@ -0,0 +1,144 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/statement"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// fakeStatementStore serves one page of due accounts and records sent statements.
+type fakeStatementStore struct {
+	*fakeDirectory
+	due      []sqlc.ListAccountsDueStatementRow
+	entries  []sqlc.Entry
+	listed   []sqlc.ListAccountsDueStatementParams
+	recorded []sqlc.RecordMonthlyStatementParams
+}
+
+func (f *fakeStatementStore) GetAccountBalanceBefore(context.Context, sqlc.GetAccountBalanceBeforeParams) (string, error) {
+	return "100.0000", nil
+}
+
+func (f *fakeStatementStore) ListEntriesByAccountBetween(context.Context, sqlc.ListEntriesByAccountBetweenParams) ([]sqlc.Entry, error) {
+	return f.entries, nil
+}
+
+func (f *fakeStatementStore) ListAccountsDueStatement(_ context.Context, arg sqlc.ListAccountsDueStatementParams) ([]sqlc.ListAccountsDueStatementRow, error) {
+	f.listed = append(f.listed, arg)
+	var page []sqlc.ListAccountsDueStatementRow
+	for _, row := range f.due {
+		if row.ID.String() > arg.AfterID.String() && int32(len(page)) < arg.RowLimit {
+			page = append(page, row)
+		}
+	}
+	return page, nil
+}
+
+func (f *fakeStatementStore) RecordMonthlyStatement(_ context.Context, arg sqlc.RecordMonthlyStatementParams) error {
+	f.recorded = append(f.recorded, arg)
+	return nil
+}
+
+type failingSender struct{}
+
+func (failingSender) SendEmail(context.Context, EmailMessage) error { return errors.New("smtp down") }
+
+func newStatementFixture(delivery string) (*fakeStatementStore, sqlc.Account) {
+	dir, userAcc, _, _ := newFixture()
+	store := &fakeStatementStore{
+		fakeDirectory: dir,
+		due:           []sqlc.ListAccountsDueStatementRow{{ID: userAcc.ID, OwnerID: userAcc.OwnerID, Delivery: delivery}},
+		entries: []sqlc.Entry{{
+			AccountID:     userAcc.ID,
+			Debit:         "0.0000",
+			Credit:        "25.5000",
+			OperationType: "deposit",
+			CreatedAt:     sql.NullTime{Time: time.Date(2026, 9, 3, 10, 0, 0, 0, time.UTC), Valid: true},
+		}},
+	}
+	return store, userAcc
+}
+
+func TestSendMonthly_EmailsLastMonthAndRecords(t *testing.T) {
+	// Running on 1 October sends September's statement inline and records it once sent.
+	store, userAcc := newStatementFixture(StatementEmail)
+	sender := &captureSender{}
+	m := NewStatementMailer(store, sender)
+	m.now = func() time.Time { return time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC) }
+
+	require.NoError(t, m.SendMonthly(context.Background(), nil))
+
+	september := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	require.NotEmpty(t, store.listed)
+	assert.Equal(t, september, store.listed[0].PeriodStart)
+	assert.Equal(t, september.AddDate(0, 1, 0), store.listed[0].PeriodEnd)
+
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "owner@example.com", sender.sent[0].To)
+	assert.Contains(t, sender.sent[0].Subject, "September 2026")
+	assert.Contains(t, sender.sent[0].Body, "Closing balance: USD 125.50")
+	assert.Contains(t, sender.sent[0].Body, "25.50")
+
+	require.Len(t, store.recorded, 1)
+	assert.Equal(t, sqlc.RecordMonthlyStatementParams{
+		AccountID:   userAcc.ID,
+		PeriodStart: september,
+		Delivery:    StatementEmail,
+		SentTo:      "owner@example.com",
+	}, store.recorded[0])
+}
+
+func TestSendMonthly_LinkDelivery(t *testing.T) {
+	// Link delivery emails a signed download link instead of the statement.
+	store, userAcc := newStatementFixture(StatementLink)
+	sender := &captureSender{}
+	secret := []byte("link-secret")
+	now := time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC)
+	m := NewStatementMailer(store, sender, WithStatementLinks("https://api.example.com/", secret))
+	m.now = func() time.Time { return now }
+
+	require.NoError(t, m.SendMonthly(context.Background(), nil))
+	require.Len(t, sender.sent, 1)
+	assert.NotContains(t, sender.sent[0].Body, "Period:")
+
+	link := regexp.MustCompile(`https://api\.example\.com/statements/\S+`).FindString(sender.sent[0].Body)
+	require.NotEmpty(t, link)
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "/statements/"+userAcc.ID.String()+"/2026-09", u.Path)
+	expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	require.NoError(t, err)
+	assert.True(t, statement.VerifyLink(secret, userAcc.ID, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), expires, u.Query().Get("sig"), now))
+	assert.Equal(t, StatementLink, store.recorded[0].Delivery)
+}
+
+func TestSendMonthly_LinkWithoutSecretFallsBackToEmail(t *testing.T) {
+	// Without link signing configured the owner still gets the statement.
+	store, _ := newStatementFixture(StatementLink)
+	sender := &captureSender{}
+
+	require.NoError(t, NewStatementMailer(store, sender).SendMonthly(context.Background(), nil))
+	require.Len(t, sender.sent, 1)
+	assert.NotContains(t, sender.sent[0].Body, "/statements/")
+	assert.Equal(t, StatementEmail, store.recorded[0].Delivery)
+}
+
+func TestSendMonthly_FailedSendIsNotRecorded(t *testing.T) {
+	// A failed send is returned so the job retries it, and nothing is recorded.
+	store, _ := newStatementFixture(StatementEmail)
+
+	err := NewStatementMailer(store, failingSender{}).SendMonthly(context.Background(), nil)
+	require.Error(t, err)
+	assert.Empty(t, store.recorded)
+}
@@ -0,0 +1,142 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Hash algorithms a Hasher can produce.
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// ErrMismatch is returned when a password does not match its hash.
+var ErrMismatch = errors.New("password does not match")
+
+// Argon2Params tunes argon2id. Memory is in KiB.
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params follows the OWASP baseline: 64 MiB, 3 passes, 2 lanes.
+func DefaultArgon2Params() Argon2Params {
+	return Argon2Params{Memory: 64 * 1024, Iterations: 3, Parallelism: 2, SaltLength: 16, KeyLength: 32}
+}
+
+// Hasher hashes new passwords with one algorithm and verifies hashes of either, reporting when a
+// stored hash should be replaced so old hashes migrate as users log in. A nil Hasher uses bcrypt
+// at its default cost.
+type Hasher struct {
+	algorithm  string
+	argon2     Argon2Params
+	bcryptCost int
+}
+
+// NewHasher hashes with algorithm, bcrypt or argon2id, using params for argon2id.
+func NewHasher(algorithm string, params Argon2Params) (*Hasher, error) {
+	switch algorithm {
+	case "", AlgorithmBcrypt:
+		return &Hasher{algorithm: AlgorithmBcrypt, bcryptCost: bcrypt.DefaultCost}, nil
+	case AlgorithmArgon2id:
+		if params.Memory < 8*uint32(params.Parallelism) || params.Iterations < 1 || params.Parallelism < 1 || params.SaltLength < 8 || params.KeyLength < 16 {
+			return nil, errors.New("argon2id needs iterations and parallelism of at least 1, memory of at least 8 KiB per lane, a salt of 8 bytes and a key of 16")
+		}
+		return &Hasher{algorithm: AlgorithmArgon2id, argon2: params, bcryptCost: bcrypt.DefaultCost}, nil
+	default:
+		return nil, fmt.Errorf("unknown password hash %q; use bcrypt or argon2id", algorithm)
+	}
+}
+
+// Hash returns the encoded hash of password.
+func (h *Hasher) Hash(password string) (string, error) {
+	if h == nil || h.algorithm == AlgorithmBcrypt {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.cost())
+		return string(hashed), err
+	}
+
+	salt := make([]byte, h.argon2.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	p := h.argon2
+	key := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	// PHC string format, as produced by the reference implementation.
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify checks password against hash, returning ErrMismatch when it does not match. rehash is
+// true when the hash matched but was made with another algorithm or weaker settings than the
+// Hasher's, and should be replaced with Hash(password).
+func (h *Hasher) Verify(hash, password string) (rehash bool, err error) {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, err
+		}
+		got := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key))) // #nosec G115 -- bounded by the decoded hash
+		if subtle.ConstantTimeCompare(got, key) != 1 {
+			return false, ErrMismatch
+		}
+		return h != nil && (h.algorithm != AlgorithmArgon2id || params.Memory != h.argon2.Memory ||
+			params.Iterations != h.argon2.Iterations || params.Parallelism != h.argon2.Parallelism), nil
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, ErrMismatch
+		}
+		return false, err
+	}
+	if h != nil && h.algorithm == AlgorithmArgon2id {
+		return true, nil
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err == nil && cost < h.cost(), nil
+}
+
+// cost is the bcrypt cost new hashes use.
+func (h *Hasher) cost() int {
+	if h == nil {
+		return bcrypt.DefaultCost
+	}
+	return h.bcryptCost
+}
+
+// decodeArgon2id parses a PHC-format argon2id hash.
+func decodeArgon2id(hash string) (Argon2Params, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return Argon2Params{}, nil, nil, errors.New("malformed argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Argon2Params{}, nil, nil, fmt.Errorf("unsupported argon2id version %q", parts[2])
+	}
+	var p Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("malformed argon2id parameters: %w", err)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("malformed argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Argon2Params{}, nil, nil, errors.New("malformed argon2id key")
+	}
+	p.SaltLength, p.KeyLength = uint32(len(salt)), uint32(len(key)) // #nosec G115 -- decoded from a short string
+	return p, salt, key, nil
+}
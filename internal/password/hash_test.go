@@ -0,0 +1,92 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fastArgon2 keeps tests quick; production uses DefaultArgon2Params.
+var fastArgon2 = Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHasher_Argon2id(t *testing.T) {
+	// argon2id hashes round-trip in PHC format and reject the wrong password.
+	h, err := NewHasher(AlgorithmArgon2id, fastArgon2)
+	require.NoError(t, err)
+
+	hash, err := h.Hash("correct horse")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"), hash)
+
+	rehash, err := h.Verify(hash, "correct horse")
+	require.NoError(t, err)
+	assert.False(t, rehash)
+
+	_, err = h.Verify(hash, "battery staple")
+	assert.ErrorIs(t, err, ErrMismatch)
+}
+
+func TestHasher_Rehash(t *testing.T) {
+	// Hashes from another algorithm or older parameters are flagged for replacement.
+	legacy, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	argon, err := NewHasher(AlgorithmArgon2id, fastArgon2)
+	require.NoError(t, err)
+	rehash, err := argon.Verify(string(legacy), "correct horse")
+	require.NoError(t, err)
+	assert.True(t, rehash, "bcrypt hash under argon2id")
+
+	stronger := fastArgon2
+	stronger.Iterations = 2
+	tuned, err := NewHasher(AlgorithmArgon2id, stronger)
+	require.NoError(t, err)
+	hash, err := argon.Hash("correct horse")
+	require.NoError(t, err)
+	rehash, err = tuned.Verify(hash, "correct horse")
+	require.NoError(t, err)
+	assert.True(t, rehash, "argon2id hash with old parameters")
+
+	// Switching back to bcrypt migrates argon2id hashes too; a nil Hasher still verifies them.
+	bc, err := NewHasher(AlgorithmBcrypt, Argon2Params{})
+	require.NoError(t, err)
+	rehash, err = bc.Verify(hash, "correct horse")
+	require.NoError(t, err)
+	assert.True(t, rehash)
+	var none *Hasher
+	rehash, err = none.Verify(hash, "correct horse")
+	require.NoError(t, err)
+	assert.False(t, rehash)
+}
+
+func TestHasher_Bcrypt(t *testing.T) {
+	// bcrypt hashes below the configured cost are upgraded.
+	h, err := NewHasher("", Argon2Params{})
+	require.NoError(t, err)
+	weak, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	rehash, err := h.Verify(string(weak), "correct horse")
+	require.NoError(t, err)
+	assert.True(t, rehash)
+	_, err = h.Verify(string(weak), "battery staple")
+	assert.ErrorIs(t, err, ErrMismatch)
+}
+
+func TestNewHasher_Rejects(t *testing.T) {
+	// Unknown algorithms and unsafe argon2id parameters are refused.
+	_, err := NewHasher("md5", Argon2Params{})
+	assert.Error(t, err)
+	_, err = NewHasher(AlgorithmArgon2id, Argon2Params{Memory: 64, Iterations: 0, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	assert.Error(t, err)
+}
+
+func TestVerify_MalformedArgon2id(t *testing.T) {
+	// Corrupt hashes are an error, not a mismatch.
+	_, err := (*Hasher)(nil).Verify("$argon2id$v=19$m=x$salt$key", "pw")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrMismatch)
+}
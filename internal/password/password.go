@@ -0,0 +1,132 @@
+// Package password enforces the rules user-chosen passwords must meet.
+package password
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MaxBytes is the longest password bcrypt can hash; longer ones would be silently truncated.
+const MaxBytes = 72
+
+// Character classes a policy can require.
+const (
+	ClassUpper  = "upper"
+	ClassLower  = "lower"
+	ClassDigit  = "digit"
+	ClassSymbol = "symbol"
+)
+
+// Classes are every character class a policy can require.
+var Classes = []string{ClassUpper, ClassLower, ClassDigit, ClassSymbol}
+
+// Violation is one rule a password breaks. Rule is stable for clients to match on.
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+// BreachChecker reports whether a password is known from a data breach.
+type BreachChecker interface {
+	Breached(ctx context.Context, password string) (bool, error)
+}
+
+// Policy is what a password must satisfy.
+type Policy struct {
+	// Breaches, when set, rejects passwords found in known data breaches.
+	Breaches BreachChecker
+	// Require lists the character classes a password must contain.
+	Require   []string
+	MinLength int
+}
+
+// DefaultPolicy asks for at least 10 characters and no particular character classes, favouring
+// length over composition rules.
+func DefaultPolicy() Policy {
+	return Policy{MinLength: 10}
+}
+
+// ParseClasses reads a comma-separated list of character classes.
+func ParseClasses(s string) ([]string, error) {
+	var classes []string
+	for _, c := range strings.Split(s, ",") {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" {
+			continue
+		}
+		known := false
+		for _, k := range Classes {
+			known = known || k == c
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown character class %q; use %s", c, strings.Join(Classes, ", "))
+		}
+		classes = append(classes, c)
+	}
+	return classes, nil
+}
+
+// Check returns every rule password breaks, none when it is acceptable. A password may not contain
+// the local part of the user's email. When the breach check itself fails the password is let
+// through and the failure logged, so an outage of the check does not block sign-ups.
+func (p Policy) Check(ctx context.Context, password, email string) []Violation {
+	var violations []Violation
+	if n := utf8.RuneCountInString(password); n < p.MinLength {
+		violations = append(violations, Violation{Rule: "min_length", Message: fmt.Sprintf("must be at least %d characters", p.MinLength)})
+	}
+	if len(password) > MaxBytes {
+		violations = append(violations, Violation{Rule: "max_length", Message: fmt.Sprintf("must be at most %d bytes", MaxBytes)})
+	}
+
+	has := map[string]bool{}
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			has[ClassUpper] = true
+		case unicode.IsLower(r):
+			has[ClassLower] = true
+		case unicode.IsDigit(r):
+			has[ClassDigit] = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			has[ClassSymbol] = true
+		}
+	}
+	for _, class := range p.Require {
+		if !has[class] {
+			violations = append(violations, Violation{Rule: class, Message: "must contain " + classDescription(class)})
+		}
+	}
+
+	if local, _, _ := strings.Cut(strings.ToLower(email), "@"); len(local) >= 3 && strings.Contains(strings.ToLower(password), local) {
+		violations = append(violations, Violation{Rule: "contains_email", Message: "must not contain your email address"})
+	}
+
+	if p.Breaches != nil && len(violations) == 0 {
+		breached, err := p.Breaches.Breached(ctx, password)
+		if err != nil {
+			log.Warn().Err(err).Msg("Breached password check failed; accepting password")
+		} else if breached {
+			violations = append(violations, Violation{Rule: "breached", Message: "appears in a known data breach; choose another"})
+		}
+	}
+	return violations
+}
+
+// classDescription names a character class in a violation message.
+func classDescription(class string) string {
+	switch class {
+	case ClassUpper:
+		return "an uppercase letter"
+	case ClassLower:
+		return "a lowercase letter"
+	case ClassDigit:
+		return "a digit"
+	default:
+		return "a symbol"
+	}
+}
@@ -0,0 +1,92 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rules lists the rules of violations, in order.
+func rules(violations []Violation) []string {
+	out := make([]string, 0, len(violations))
+	for _, v := range violations {
+		out = append(out, v.Rule)
+	}
+	return out
+}
+
+func TestPolicyCheck(t *testing.T) {
+	// Every broken rule is reported, so users can fix them all at once.
+	p := Policy{MinLength: 10, Require: Classes}
+	ctx := context.Background()
+
+	assert.Equal(t, []string{"min_length", "upper", "digit", "symbol"}, rules(p.Check(ctx, "a", "")))
+	assert.Empty(t, p.Check(ctx, "Correct-Horse-7", "ada@example.com"))
+	assert.Equal(t, []string{"contains_email"}, rules(p.Check(ctx, "Ada.Lovelace-1815", "ada.lovelace@example.com")))
+	assert.Equal(t, []string{"max_length"}, rules(p.Check(ctx, strings.Repeat("Aa1!", 19), "")))
+	// Length counts characters, not bytes.
+	assert.Empty(t, DefaultPolicy().Check(ctx, "ñññññññññ1", ""))
+}
+
+type fakeBreaches struct {
+	err      error
+	breached bool
+	calls    int
+}
+
+func (f *fakeBreaches) Breached(context.Context, string) (bool, error) {
+	f.calls++
+	return f.breached, f.err
+}
+
+func TestPolicyCheck_Breaches(t *testing.T) {
+	// Breached passwords are refused; a failing check lets the password through.
+	ctx := context.Background()
+	breaches := &fakeBreaches{breached: true}
+	p := Policy{MinLength: 8, Breaches: breaches}
+	assert.Equal(t, []string{"breached"}, rules(p.Check(ctx, "password1", "")))
+
+	// Passwords already refused by the local rules are not sent to the checker.
+	p.Check(ctx, "short", "")
+	assert.Equal(t, 1, breaches.calls)
+
+	breaches.err = errors.New("unreachable")
+	assert.Empty(t, p.Check(ctx, "password1", ""))
+}
+
+func TestParseClasses(t *testing.T) {
+	// Class lists are case-insensitive and refuse unknown classes.
+	classes, err := ParseClasses(" Upper, digit ,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{ClassUpper, ClassDigit}, classes)
+	_, err = ParseClasses("upper,emoji")
+	assert.Error(t, err)
+}
+
+func TestPwnedPasswords(t *testing.T) {
+	// Only the hash prefix is sent, and padding entries with a zero count never match.
+	var gotPath, gotPadding string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotPadding = r.URL.Path, r.Header.Get("Add-Padding")
+		// SHA-1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+		_, _ = w.Write([]byte("0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\r\n00000000000000000000000000000000000:0\r\n"))
+	}))
+	defer srv.Close()
+
+	p := &PwnedPasswords{httpClient: srv.Client(), baseURL: srv.URL}
+	breached, err := p.Breached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.True(t, breached)
+	assert.Equal(t, "/range/5BAA6", gotPath)
+	assert.Equal(t, "true", gotPadding)
+
+	breached, err = p.Breached(context.Background(), "a much better passphrase")
+	require.NoError(t, err)
+	assert.False(t, breached)
+}
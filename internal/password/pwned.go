@@ -0,0 +1,60 @@
+package password
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1" // #nosec G505 -- the Pwned Passwords range API is keyed by SHA-1
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PwnedPasswords checks passwords against the Have I Been Pwned Pwned Passwords range API. Only
+// the first five hex digits of the password's SHA-1 leave the server (k-anonymity), and responses
+// are padded so their size reveals nothing either.
+type PwnedPasswords struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewPwnedPasswords constructs a checker for the public API.
+func NewPwnedPasswords() *PwnedPasswords {
+	return &PwnedPasswords{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    "https://api.pwnedpasswords.com",
+	}
+}
+
+// Breached implements BreachChecker.
+func (p *PwnedPasswords) Breached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password)) // #nosec G401 -- required by the range API, not used for storage
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := digest[:5], digest[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Add-Padding", "true")
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("pwned passwords returned %d", resp.StatusCode)
+	}
+
+	// Each line is SUFFIX:COUNT; padding lines have a count of 0.
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, 1<<20))
+	for scanner.Scan() {
+		hash, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if ok && strings.EqualFold(hash, suffix) && count != "0" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,157 @@
+// Package paystack is a minimal client for the Paystack payments API:
+// transaction initialization and webhook signature verification.
+package paystack
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// SignatureHeader carries the HMAC-SHA512 of the webhook body keyed with the secret key.
+const SignatureHeader = "X-Paystack-Signature"
+
+// EventChargeSuccess is the webhook event sent once a charge is paid.
+const EventChargeSuccess = "charge.success"
+
+// ErrSubunitPrecision is returned for amounts finer than the currency's minor unit.
+var ErrSubunitPrecision = errors.New("amount must have at most 2 decimal places")
+
+// Client calls the Paystack API with a secret key.
+type Client struct {
+	httpClient *http.Client
+	secretKey  string
+	baseURL    string
+}
+
+// NewClient constructs a Client for the given secret key (sk_test_... or sk_live_...).
+func NewClient(secretKey string) (*Client, error) {
+	if secretKey == "" {
+		return nil, errors.New("paystack secret key is required")
+	}
+	return &Client{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		secretKey:  secretKey,
+		baseURL:    "https://api.paystack.co",
+	}, nil
+}
+
+// InitializeRequest describes a charge to start. Amount is in the currency's subunit (kobo, cents).
+type InitializeRequest struct {
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Email       string            `json:"email"`
+	Currency    string            `json:"currency"`
+	Reference   string            `json:"reference"`
+	CallbackURL string            `json:"callback_url,omitempty"`
+	Amount      int64             `json:"amount"`
+}
+
+// InitializeResponse is where the customer completes payment.
+type InitializeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	AccessCode       string `json:"access_code"`
+	Reference        string `json:"reference"`
+}
+
+// Initialize starts a transaction and returns the hosted checkout details.
+func (c *Client) Initialize(ctx context.Context, in InitializeRequest) (InitializeResponse, error) {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return InitializeResponse{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/transaction/initialize", bytes.NewReader(payload))
+	if err != nil {
+		return InitializeResponse{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.secretKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return InitializeResponse{}, fmt.Errorf("paystack request failed: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Warn().Err(closeErr).Msg("Failed to close paystack response body")
+		}
+	}()
+
+	var body struct {
+		Message string             `json:"message"`
+		Data    InitializeResponse `json:"data"`
+		Status  bool               `json:"status"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return InitializeResponse{}, fmt.Errorf("paystack returned %d with unreadable body: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || !body.Status {
+		return InitializeResponse{}, fmt.Errorf("paystack returned %d: %s", resp.StatusCode, body.Message)
+	}
+	return body.Data, nil
+}
+
+// VerifySignature reports whether signature is the hex HMAC-SHA512 of body under the secret key.
+func (c *Client) VerifySignature(body []byte, signature string) bool {
+	expected, err := hex.DecodeString(strings.TrimSpace(signature))
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+	mac := hmac.New(sha512.New, []byte(c.secretKey))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// ChargeData is the subset of a webhook charge payload the ledger needs.
+type ChargeData struct {
+	Reference string `json:"reference"`
+	Currency  string `json:"currency"`
+	Status    string `json:"status"`
+	Amount    int64  `json:"amount"`
+}
+
+// WebhookEvent is a Paystack webhook notification.
+type WebhookEvent struct {
+	Event string     `json:"event"`
+	Data  ChargeData `json:"data"`
+}
+
+// ParseWebhook decodes a webhook body. Call VerifySignature first.
+func ParseWebhook(body []byte) (WebhookEvent, error) {
+	var evt WebhookEvent
+	if err := json.Unmarshal(body, &evt); err != nil {
+		return WebhookEvent{}, fmt.Errorf("decode paystack webhook: %w", err)
+	}
+	return evt, nil
+}
+
+// ToSubunits converts a major-unit amount (e.g., 1500.50) to Paystack's integer subunits (150050).
+func ToSubunits(amount decimal.Decimal) (int64, error) {
+	sub := amount.Shift(2)
+	if !sub.Equal(sub.Truncate(0)) {
+		return 0, ErrSubunitPrecision
+	}
+	return sub.IntPart(), nil
+}
+
+// FromSubunits converts Paystack's integer subunits back to a major-unit amount.
+func FromSubunits(amount int64) decimal.Decimal {
+	return decimal.New(amount, -2)
+}
+
+// NewReference returns a unique transaction reference for a deposit.
+func NewReference() string {
+	return "dep_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+}
@@ -0,0 +1,71 @@
+package paystack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha512.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	// Only the HMAC of the exact body under our secret is accepted.
+	c, err := NewClient("sk_test_secret")
+	require.NoError(t, err)
+	body := []byte(`{"event":"charge.success","data":{"reference":"dep_1","amount":150050,"currency":"NGN","status":"success"}}`)
+
+	assert.True(t, c.VerifySignature(body, sign("sk_test_secret", body)))
+	assert.False(t, c.VerifySignature(body, sign("sk_test_other", body)))
+	assert.False(t, c.VerifySignature(append(body, ' '), sign("sk_test_secret", body)))
+	assert.False(t, c.VerifySignature(body, ""))
+
+	evt, err := ParseWebhook(body)
+	require.NoError(t, err)
+	assert.Equal(t, EventChargeSuccess, evt.Event)
+	assert.True(t, FromSubunits(evt.Data.Amount).Equal(decimal.RequireFromString("1500.50")))
+}
+
+func TestToSubunits(t *testing.T) {
+	// Subunit conversion is exact and refuses sub-kobo amounts.
+	n, err := ToSubunits(decimal.RequireFromString("1500.5000"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(150050), n)
+
+	_, err = ToSubunits(decimal.RequireFromString("10.005"))
+	assert.ErrorIs(t, err, ErrSubunitPrecision)
+}
+
+func TestInitialize(t *testing.T) {
+	// Initialize authenticates with the secret key and returns checkout details.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/transaction/initialize", r.URL.Path)
+		assert.Equal(t, "Bearer sk_test_secret", r.Header.Get("Authorization"))
+		var in InitializeRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		assert.Equal(t, int64(500000), in.Amount)
+		_, _ = w.Write([]byte(`{"status":true,"message":"ok","data":{"authorization_url":"https://checkout.paystack.com/abc","access_code":"abc","reference":"` + in.Reference + `"}}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient("sk_test_secret")
+	require.NoError(t, err)
+	c.baseURL = srv.URL
+
+	out, err := c.Initialize(context.Background(), InitializeRequest{Email: "a@b.co", Amount: 500000, Currency: "NGN", Reference: "dep_x"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://checkout.paystack.com/abc", out.AuthorizationURL)
+	assert.Equal(t, "dep_x", out.Reference)
+}
@@ -0,0 +1,208 @@
+// Package pii encrypts personal data at rest with envelope encryption. Every value is sealed
+// under its own random data key, which is wrapped by a named master key stored with the value,
+// so master keys can rotate while older values stay readable until they are re-sealed.
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// prefix marks a sealed value; anything without it is a plaintext value from before encryption.
+const prefix = "pii:v1:"
+
+var (
+	// ErrUnknownKey is returned for a value sealed under a master key the keyring does not hold.
+	ErrUnknownKey = errors.New("pii: value sealed under an unknown master key")
+	// ErrMalformed is returned for a sealed value that cannot be parsed or authenticated.
+	ErrMalformed = errors.New("pii: malformed sealed value")
+)
+
+// keyIDPattern keeps key IDs free of the separator used in sealed values.
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// encoding keeps sealed values compact and free of ':'.
+var encoding = base64.RawStdEncoding
+
+// Keyring seals and opens values with its master keys and computes blind indexes for lookups.
+// A nil *Keyring stores values in plaintext, so callers need not check whether encryption is on.
+type Keyring struct {
+	active   string
+	keys     map[string]cipher.AEAD
+	indexKey []byte
+}
+
+// ParseKeyring reads master keys from spec, a comma-separated list of id:base64 pairs whose
+// first entry seals new values, and the base64 key of the blind index. Every key is 32 bytes.
+// The index key cannot rotate without re-indexing every value, so it is kept apart.
+func ParseKeyring(spec, indexKey string) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string]cipher.AEAD)}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(part, ":")
+		if !ok || !keyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("pii: master key %q must be id:base64 with an id of letters, digits, _ or -", part)
+		}
+		if _, dup := k.keys[id]; dup {
+			return nil, fmt.Errorf("pii: master key %s is listed twice", id)
+		}
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("pii: master key %s: %w", id, err)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[id] = aead
+		if k.active == "" {
+			k.active = id
+		}
+	}
+	if k.active == "" {
+		return nil, errors.New("pii: no master keys")
+	}
+	index, err := decodeKey(indexKey)
+	if err != nil {
+		return nil, fmt.Errorf("pii: index key: %w", err)
+	}
+	k.indexKey = index
+	return k, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.New("not valid base64")
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext under aead with a fresh nonce, binding it to aad, as nonce||ciphertext.
+func seal(aead cipher.AEAD, plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open reverses seal.
+func open(aead cipher.AEAD, sealed, aad []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], aad)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	return plaintext, nil
+}
+
+// Seal encrypts value for storage under a new data key wrapped by the active master key. Empty
+// values stay empty.
+func (k *Keyring) Seal(value string) (string, error) {
+	if k == nil || value == "" {
+		return value, nil
+	}
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	wrapped, err := seal(k.keys[k.active], dataKey, []byte(k.active))
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	body, err := seal(aead, []byte(value), nil)
+	if err != nil {
+		return "", err
+	}
+	return prefix + k.active + ":" + encoding.EncodeToString(wrapped) + ":" + encoding.EncodeToString(body), nil
+}
+
+// Open decrypts a value written by Seal. Plaintext values stored before encryption was turned on
+// are returned as they are.
+func (k *Keyring) Open(stored string) (string, error) {
+	rest, sealed := strings.CutPrefix(stored, prefix)
+	if !sealed {
+		return stored, nil
+	}
+	parts := strings.Split(rest, ":")
+	if len(parts) != 3 {
+		return "", ErrMalformed
+	}
+	if k == nil {
+		return "", ErrUnknownKey
+	}
+	master, ok := k.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownKey, parts[0])
+	}
+	wrapped, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrMalformed
+	}
+	body, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrMalformed
+	}
+	dataKey, err := open(master, wrapped, []byte(parts[0]))
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", ErrMalformed
+	}
+	value, err := open(aead, body, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// Current reports whether stored needs no re-sealing: it is empty or sealed under the active
+// master key. Without a keyring every value is current.
+func (k *Keyring) Current(stored string) bool {
+	if k == nil || stored == "" {
+		return true
+	}
+	return strings.HasPrefix(stored, prefix+k.active+":")
+}
+
+// Index is the blind index of value: a keyed hash that lets equal values be found without
+// decrypting every row. It is empty without a keyring or for an empty value.
+func (k *Keyring) Index(value string) string {
+	if k == nil || value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(value))
+	return encoding.EncodeToString(mac.Sum(nil))
+}
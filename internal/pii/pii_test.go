@@ -0,0 +1,206 @@
+package pii
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+func testKeyring(t *testing.T, spec string) *Keyring {
+	t.Helper()
+	k, err := ParseKeyring(spec, testKey('i'))
+	require.NoError(t, err)
+	return k
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	// Sealed values round-trip, never contain the plaintext and differ on every seal.
+	k := testKeyring(t, "k1:"+testKey('a'))
+	a, err := k.Seal("+2348012345678")
+	require.NoError(t, err)
+	b, err := k.Seal("+2348012345678")
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+	assert.NotContains(t, a, "2348012345678")
+	assert.True(t, strings.HasPrefix(a, "pii:v1:k1:"))
+
+	got, err := k.Open(a)
+	require.NoError(t, err)
+	assert.Equal(t, "+2348012345678", got)
+
+	empty, err := k.Seal("")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestKeyring_OpenPlaintext(t *testing.T) {
+	// Values stored before encryption are read as they are, with or without a keyring.
+	k := testKeyring(t, "k1:"+testKey('a'))
+	got, err := k.Open("+2348012345678")
+	require.NoError(t, err)
+	assert.Equal(t, "+2348012345678", got)
+
+	var none *Keyring
+	got, err = none.Open("A1234567")
+	require.NoError(t, err)
+	assert.Equal(t, "A1234567", got)
+	sealed, err := none.Seal("A1234567")
+	require.NoError(t, err)
+	assert.Equal(t, "A1234567", sealed)
+	assert.Empty(t, none.Index("A1234567"))
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	// After a new key is put first, old values still open but are no longer current.
+	old := testKeyring(t, "k1:"+testKey('a'))
+	sealed, err := old.Seal("A1234567")
+	require.NoError(t, err)
+	assert.True(t, old.Current(sealed))
+
+	rotated := testKeyring(t, "k2:"+testKey('b')+",k1:"+testKey('a'))
+	got, err := rotated.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "A1234567", got)
+	assert.False(t, rotated.Current(sealed))
+	assert.False(t, rotated.Current("A1234567"))
+	assert.True(t, rotated.Current(""))
+
+	retired := testKeyring(t, "k2:"+testKey('b'))
+	_, err = retired.Open(sealed)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyring_OpenTampered(t *testing.T) {
+	// A value whose ciphertext or key ID was altered does not open.
+	k := testKeyring(t, "k1:"+testKey('a')+",k2:"+testKey('b'))
+	sealed, err := k.Seal("A1234567")
+	require.NoError(t, err)
+
+	flipped := []byte(sealed)
+	flipped[len(flipped)-2] ^= 1
+	_, err = k.Open(string(flipped))
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	_, err = k.Open(strings.Replace(sealed, "pii:v1:k1:", "pii:v1:k2:", 1))
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	_, err = k.Open("pii:v1:k1:nonsense")
+	assert.ErrorIs(t, err, ErrMalformed)
+}
+
+func TestKeyring_Index(t *testing.T) {
+	// The blind index is stable across master key rotation and differs per value.
+	a := testKeyring(t, "k1:"+testKey('a'))
+	b := testKeyring(t, "k2:"+testKey('b'))
+	assert.Equal(t, a.Index("+2348012345678"), b.Index("+2348012345678"))
+	assert.NotEqual(t, a.Index("+2348012345678"), a.Index("+2348012345679"))
+	assert.NotContains(t, a.Index("+2348012345678"), "2348012345678")
+}
+
+func TestParseKeyring_Invalid(t *testing.T) {
+	// Missing, short, duplicate or badly named keys are refused.
+	for _, tc := range []struct{ spec, index string }{
+		{"", testKey('i')},
+		{"k1:" + testKey('a'), ""},
+		{"k1:" + base64.StdEncoding.EncodeToString([]byte("short")), testKey('i')},
+		{"k1:" + testKey('a') + ",k1:" + testKey('b'), testKey('i')},
+		{"bad id:" + testKey('a'), testKey('i')},
+		{testKey('a'), testKey('i')},
+	} {
+		_, err := ParseKeyring(tc.spec, tc.index)
+		assert.Error(t, err, tc.spec)
+	}
+}
+
+// fakeStore holds sealed columns in memory.
+type fakeStore struct {
+	phones map[uuid.UUID]sqlc.ReplaceUserPhoneParams
+	kyc    map[uuid.UUID]sqlc.ListKYCDocumentsRow
+}
+
+func (f *fakeStore) ListUserPhones(_ context.Context, arg sqlc.ListUserPhonesParams) ([]sqlc.ListUserPhonesRow, error) {
+	var rows []sqlc.ListUserPhonesRow
+	for id, p := range f.phones {
+		if id.String() > arg.AfterID.String() {
+			rows = append(rows, sqlc.ListUserPhonesRow{ID: id, Phone: p.NewPhone})
+		}
+	}
+	return sortedPage(rows, func(r sqlc.ListUserPhonesRow) uuid.UUID { return r.ID }, arg.RowLimit), nil
+}
+
+func (f *fakeStore) ReplaceUserPhone(_ context.Context, arg sqlc.ReplaceUserPhoneParams) (int64, error) {
+	if f.phones[arg.ID].NewPhone != arg.Phone {
+		return 0, nil
+	}
+	f.phones[arg.ID] = arg
+	return 1, nil
+}
+
+func (f *fakeStore) ListKYCDocuments(_ context.Context, arg sqlc.ListKYCDocumentsParams) ([]sqlc.ListKYCDocumentsRow, error) {
+	var rows []sqlc.ListKYCDocumentsRow
+	for id, r := range f.kyc {
+		if id.String() > arg.AfterID.String() {
+			rows = append(rows, r)
+		}
+	}
+	return sortedPage(rows, func(r sqlc.ListKYCDocumentsRow) uuid.UUID { return r.ID }, arg.RowLimit), nil
+}
+
+func (f *fakeStore) ReplaceKYCDocument(_ context.Context, arg sqlc.ReplaceKYCDocumentParams) (int64, error) {
+	f.kyc[arg.ID] = sqlc.ListKYCDocumentsRow{ID: arg.ID, IDNumber: arg.NewIDNumber, DocumentReference: arg.NewDocumentReference}
+	return 1, nil
+}
+
+func sortedPage[T any](rows []T, id func(T) uuid.UUID, limit int32) []T {
+	slices.SortFunc(rows, func(a, b T) int { return strings.Compare(id(a).String(), id(b).String()) })
+	if len(rows) > int(limit) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+func TestRekeyer_Rekey(t *testing.T) {
+	// Plaintext and retired-key values end up sealed under the active key with a phone index.
+	old := testKeyring(t, "k1:"+testKey('a'))
+	rotated := testKeyring(t, "k2:"+testKey('b')+",k1:"+testKey('a'))
+	oldDoc, err := old.Seal("docs/passport.png")
+	require.NoError(t, err)
+
+	plainUser, erasedKYC, kycID := uuid.New(), uuid.New(), uuid.New()
+	store := &fakeStore{
+		phones: map[uuid.UUID]sqlc.ReplaceUserPhoneParams{
+			plainUser: {NewPhone: sql.NullString{String: "+2348012345678", Valid: true}},
+		},
+		kyc: map[uuid.UUID]sqlc.ListKYCDocumentsRow{
+			kycID:     {ID: kycID, IDNumber: "A1234567", DocumentReference: oldDoc},
+			erasedKYC: {ID: erasedKYC},
+		},
+	}
+	require.NoError(t, NewRekeyer(store, rotated).Rekey(context.Background(), nil))
+
+	phone := store.phones[plainUser]
+	assert.True(t, rotated.Current(phone.NewPhone.String))
+	assert.NotEqual(t, "+2348012345678", phone.NewPhone.String)
+	assert.Equal(t, rotated.Index("+2348012345678"), phone.PhoneIndex.String)
+
+	doc := store.kyc[kycID]
+	assert.True(t, rotated.Current(doc.IDNumber))
+	assert.True(t, rotated.Current(doc.DocumentReference))
+	got, err := rotated.Open(doc.DocumentReference)
+	require.NoError(t, err)
+	assert.Equal(t, "docs/passport.png", got)
+	assert.Empty(t, store.kyc[erasedKYC].IDNumber)
+}
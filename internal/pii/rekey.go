@@ -0,0 +1,140 @@
+package pii
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// KindRekey is the background job kind that seals plaintext values and re-seals values under
+// retired master keys.
+const KindRekey = "pii.rekey"
+
+// Store reads and replaces the sealed columns. *db.Store satisfies it.
+type Store interface {
+	ListUserPhones(ctx context.Context, arg sqlc.ListUserPhonesParams) ([]sqlc.ListUserPhonesRow, error)
+	ReplaceUserPhone(ctx context.Context, arg sqlc.ReplaceUserPhoneParams) (int64, error)
+	ListKYCDocuments(ctx context.Context, arg sqlc.ListKYCDocumentsParams) ([]sqlc.ListKYCDocumentsRow, error)
+	ReplaceKYCDocument(ctx context.Context, arg sqlc.ReplaceKYCDocumentParams) (int64, error)
+}
+
+// Rekeyer brings every stored value up to the keyring's active master key.
+type Rekeyer struct {
+	store   Store
+	keyring *Keyring
+}
+
+// NewRekeyer constructs a Rekeyer sealing values in store with keyring.
+func NewRekeyer(store Store, keyring *Keyring) *Rekeyer {
+	return &Rekeyer{store: store, keyring: keyring}
+}
+
+// rekeyBatch is how many rows each page of a run reads.
+const rekeyBatch = 200
+
+// Rekey is a jobs.HandlerFunc that seals plaintext phones and KYC document details and re-seals
+// those under retired master keys, so a retired key can be dropped once a run finishes cleanly.
+// A row changed since it was read is skipped; the next run picks it up.
+func (r *Rekeyer) Rekey(ctx context.Context, _ json.RawMessage) error {
+	phones, phoneErr := r.rekeyPhones(ctx)
+	docs, docErr := r.rekeyKYC(ctx)
+	log.Info().Int("phones", phones).Int("kyc_records", docs).Msg("PII re-sealed")
+	return errors.Join(phoneErr, docErr)
+}
+
+func (r *Rekeyer) rekeyPhones(ctx context.Context) (int, error) {
+	var (
+		after   uuid.UUID
+		changed int
+	)
+	for {
+		rows, err := r.store.ListUserPhones(ctx, sqlc.ListUserPhonesParams{AfterID: after, RowLimit: rekeyBatch})
+		if err != nil {
+			return changed, fmt.Errorf("list phones: %w", err)
+		}
+		for _, row := range rows {
+			after = row.ID
+			if r.keyring.Current(row.Phone.String) {
+				continue
+			}
+			phone, err := r.keyring.Open(row.Phone.String)
+			if err != nil {
+				return changed, fmt.Errorf("open phone of user %s: %w", row.ID, err)
+			}
+			sealed, err := r.keyring.Seal(phone)
+			if err != nil {
+				return changed, err
+			}
+			n, err := r.store.ReplaceUserPhone(ctx, sqlc.ReplaceUserPhoneParams{
+				NewPhone:   sql.NullString{String: sealed, Valid: true},
+				PhoneIndex: sql.NullString{String: r.keyring.Index(phone), Valid: true},
+				ID:         row.ID,
+				Phone:      row.Phone,
+			})
+			if err != nil {
+				return changed, fmt.Errorf("replace phone of user %s: %w", row.ID, err)
+			}
+			changed += int(n)
+		}
+		if len(rows) < rekeyBatch {
+			return changed, nil
+		}
+	}
+}
+
+func (r *Rekeyer) rekeyKYC(ctx context.Context) (int, error) {
+	var (
+		after   uuid.UUID
+		changed int
+	)
+	for {
+		rows, err := r.store.ListKYCDocuments(ctx, sqlc.ListKYCDocumentsParams{AfterID: after, RowLimit: rekeyBatch})
+		if err != nil {
+			return changed, fmt.Errorf("list kyc records: %w", err)
+		}
+		for _, row := range rows {
+			after = row.ID
+			if r.keyring.Current(row.IDNumber) && r.keyring.Current(row.DocumentReference) {
+				continue
+			}
+			idNumber, err := r.reseal(row.IDNumber)
+			if err != nil {
+				return changed, fmt.Errorf("re-seal kyc record %s: %w", row.ID, err)
+			}
+			docRef, err := r.reseal(row.DocumentReference)
+			if err != nil {
+				return changed, fmt.Errorf("re-seal kyc record %s: %w", row.ID, err)
+			}
+			n, err := r.store.ReplaceKYCDocument(ctx, sqlc.ReplaceKYCDocumentParams{
+				NewIDNumber:          idNumber,
+				NewDocumentReference: docRef,
+				ID:                   row.ID,
+				IDNumber:             row.IDNumber,
+				DocumentReference:    row.DocumentReference,
+			})
+			if err != nil {
+				return changed, fmt.Errorf("replace kyc record %s: %w", row.ID, err)
+			}
+			changed += int(n)
+		}
+		if len(rows) < rekeyBatch {
+			return changed, nil
+		}
+	}
+}
+
+// reseal opens stored and seals it again under the active master key.
+func (r *Rekeyer) reseal(stored string) (string, error) {
+	value, err := r.keyring.Open(stored)
+	if err != nil {
+		return "", err
+	}
+	return r.keyring.Seal(value)
+}
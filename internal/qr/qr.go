@@ -0,0 +1,209 @@
+// Package qr encodes and decodes merchant-presented QR payloads in the EMVCo MPM format:
+// ID/length/value fields ending in a CRC-16 checksum, which any EMV-capable app can render.
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AccountGUID identifies this ledger inside the merchant account template, so payloads
+// from other schemes are rejected rather than misread.
+const AccountGUID = "com.doubleentrybank"
+
+// Field IDs used by this package, from the EMVCo merchant-presented mode specification.
+const (
+	idFormat          = "00"
+	idInitiation      = "01"
+	idMerchantAccount = "26"
+	idCategory        = "52"
+	idCurrency        = "53"
+	idAmount          = "54"
+	idMerchantName    = "59"
+	idAdditional      = "62"
+	idCRC             = "63"
+
+	subGUID          = "00"
+	subAccountNumber = "01"
+	subReference     = "05"
+)
+
+// Point-of-initiation values: a static code is reused and the payer enters the amount; a
+// dynamic code carries the amount for one payment.
+const (
+	initiationStatic  = "11"
+	initiationDynamic = "12"
+)
+
+// Field limits from the specification.
+const (
+	maxNameLength      = 25
+	maxReferenceLength = 25
+)
+
+var (
+	// ErrInvalidPayload is returned for payloads that are malformed or not issued by this ledger.
+	ErrInvalidPayload = errors.New("invalid QR payload")
+	// ErrChecksum is returned when a payload's CRC does not match its contents.
+	ErrChecksum = errors.New("QR payload checksum mismatch")
+	// ErrUnsupportedCurrency is returned for currencies without an ISO 4217 numeric code here.
+	ErrUnsupportedCurrency = errors.New("currency not supported in QR payloads")
+)
+
+// numericCurrencies maps ISO 4217 alphabetic codes to the numeric codes the format carries.
+var numericCurrencies = map[string]string{
+	"USD": "840",
+	"EUR": "978",
+	"GBP": "826",
+	"NGN": "566",
+	"GHS": "936",
+	"KES": "404",
+	"ZAR": "710",
+	"CAD": "124",
+}
+
+// Payload is what a QR code tells the payer: which account to pay and, optionally, how much.
+type Payload struct {
+	AccountNumber string
+	Currency      string
+	// Amount is empty for a static code, where the payer chooses the amount.
+	Amount string
+	// MerchantName is shown to the payer before confirming.
+	MerchantName string
+	// Reference is an optional label, e.g. an order number, carried into the transfer narration.
+	Reference string
+}
+
+// Encode renders p as an EMV QR payload string.
+func Encode(p Payload) (string, error) {
+	numeric, ok := numericCurrencies[p.Currency]
+	if !ok {
+		return "", ErrUnsupportedCurrency
+	}
+	if p.AccountNumber == "" {
+		return "", fmt.Errorf("%w: account number required", ErrInvalidPayload)
+	}
+	if len(p.Reference) > maxReferenceLength {
+		return "", fmt.Errorf("%w: reference must be at most %d characters", ErrInvalidPayload, maxReferenceLength)
+	}
+
+	initiation := initiationStatic
+	if p.Amount != "" {
+		initiation = initiationDynamic
+	}
+	var b strings.Builder
+	writeField(&b, idFormat, "01")
+	writeField(&b, idInitiation, initiation)
+	writeField(&b, idMerchantAccount, field(subGUID, AccountGUID)+field(subAccountNumber, p.AccountNumber))
+	writeField(&b, idCategory, "0000")
+	writeField(&b, idCurrency, numeric)
+	if p.Amount != "" {
+		writeField(&b, idAmount, p.Amount)
+	}
+	if name := truncate(p.MerchantName, maxNameLength); name != "" {
+		writeField(&b, idMerchantName, name)
+	}
+	if p.Reference != "" {
+		writeField(&b, idAdditional, field(subReference, p.Reference))
+	}
+	b.WriteString(idCRC + "04")
+	b.WriteString(fmt.Sprintf("%04X", crc16(b.String())))
+	return b.String(), nil
+}
+
+// Decode parses and verifies a payload produced by Encode.
+func Decode(s string) (Payload, error) {
+	s = strings.TrimSpace(s)
+	// The checksum covers everything up to and including its own ID and length.
+	if len(s) < 8 || s[len(s)-8:len(s)-4] != idCRC+"04" {
+		return Payload{}, fmt.Errorf("%w: missing checksum", ErrInvalidPayload)
+	}
+	want, err := strconv.ParseUint(s[len(s)-4:], 16, 16)
+	if err != nil || uint16(want) != crc16(s[:len(s)-4]) {
+		return Payload{}, ErrChecksum
+	}
+
+	fields, err := parseFields(s[:len(s)-8])
+	if err != nil {
+		return Payload{}, err
+	}
+	if fields[idFormat] != "01" {
+		return Payload{}, fmt.Errorf("%w: unknown format", ErrInvalidPayload)
+	}
+	account, err := parseFields(fields[idMerchantAccount])
+	if err != nil || account[subGUID] != AccountGUID || account[subAccountNumber] == "" {
+		return Payload{}, fmt.Errorf("%w: not a payment code for this bank", ErrInvalidPayload)
+	}
+	p := Payload{
+		AccountNumber: account[subAccountNumber],
+		Amount:        fields[idAmount],
+		MerchantName:  fields[idMerchantName],
+	}
+	for alpha, numeric := range numericCurrencies {
+		if numeric == fields[idCurrency] {
+			p.Currency = alpha
+		}
+	}
+	if p.Currency == "" {
+		return Payload{}, ErrUnsupportedCurrency
+	}
+	if extra, ok := fields[idAdditional]; ok {
+		additional, err := parseFields(extra)
+		if err != nil {
+			return Payload{}, err
+		}
+		p.Reference = additional[subReference]
+	}
+	return p, nil
+}
+
+// parseFields splits s into its ID/length/value fields.
+func parseFields(s string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for len(s) > 0 {
+		if len(s) < 4 {
+			return nil, fmt.Errorf("%w: truncated field", ErrInvalidPayload)
+		}
+		n, err := strconv.Atoi(s[2:4])
+		if err != nil || len(s) < 4+n {
+			return nil, fmt.Errorf("%w: bad field length", ErrInvalidPayload)
+		}
+		fields[s[:2]] = s[4 : 4+n]
+		s = s[4+n:]
+	}
+	return fields, nil
+}
+
+func field(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+func writeField(b *strings.Builder, id, value string) {
+	b.WriteString(field(id, value))
+}
+
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) > n {
+		return strings.TrimSpace(s[:n])
+	}
+	return s
+}
+
+// crc16 is CRC-16/CCITT-FALSE (polynomial 0x1021, initial value 0xFFFF), as the format requires.
+func crc16(s string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,50 @@
+package qr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRC16(t *testing.T) {
+	// CRC-16/CCITT-FALSE check value for the standard test string.
+	assert.Equal(t, uint16(0x29B1), crc16("123456789"))
+}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	// A dynamic code carries amount and reference; a static one leaves the amount to the payer.
+	payload, err := Encode(Payload{AccountNumber: "9000000001", Currency: "NGN", Amount: "2500.00", MerchantName: "Mama Put Kitchen", Reference: "ORDER-77"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(payload, "000201010212"))
+
+	got, err := Decode(payload)
+	require.NoError(t, err)
+	assert.Equal(t, Payload{AccountNumber: "9000000001", Currency: "NGN", Amount: "2500.00", MerchantName: "Mama Put Kitchen", Reference: "ORDER-77"}, got)
+
+	static, err := Encode(Payload{AccountNumber: "9000000002", Currency: "USD"})
+	require.NoError(t, err)
+	got, err = Decode(static)
+	require.NoError(t, err)
+	assert.Empty(t, got.Amount)
+	assert.Equal(t, "USD", got.Currency)
+}
+
+func TestDecode_RejectsTamperedAndForeignPayloads(t *testing.T) {
+	// Changing any character breaks the checksum; other schemes' codes are refused.
+	payload, err := Encode(Payload{AccountNumber: "9000000001", Currency: "USD", Amount: "10.00"})
+	require.NoError(t, err)
+	tampered := strings.Replace(payload, "10.00", "90.00", 1)
+	_, err = Decode(tampered)
+	assert.ErrorIs(t, err, ErrChecksum)
+
+	foreign := "00020101021126190015com.example.pay5204000053038406304"
+	foreign += fmt.Sprintf("%04X", crc16(foreign))
+	_, err = Decode(foreign)
+	assert.ErrorIs(t, err, ErrInvalidPayload)
+
+	_, err = Encode(Payload{AccountNumber: "9000000001", Currency: "XYZ"})
+	assert.ErrorIs(t, err, ErrUnsupportedCurrency)
+}
@@ -0,0 +1,100 @@
+package rates
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Quotes are a provider's rates for one base currency at one point in time.
+type Quotes struct {
+	AsOf  time.Time
+	Rates map[string]decimal.Decimal
+	Base  string
+}
+
+// Provider fetches the latest rates quoted against a base currency.
+type Provider interface {
+	Name() string
+	Latest(ctx context.Context, base string) (Quotes, error)
+}
+
+// OpenERAPI is the keyless open.er-api.com feed, refreshed by the provider once a day.
+type OpenERAPI struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewOpenERAPI constructs an OpenERAPI provider.
+func NewOpenERAPI() *OpenERAPI {
+	return &OpenERAPI{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		baseURL:    "https://open.er-api.com/v6",
+	}
+}
+
+// Name implements Provider.
+func (p *OpenERAPI) Name() string { return "open.er-api.com" }
+
+// Latest implements Provider.
+func (p *OpenERAPI) Latest(ctx context.Context, base string) (Quotes, error) {
+	base, err := NormalizeCurrency(base)
+	if err != nil {
+		return Quotes{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/latest/"+url.PathEscape(base), nil)
+	if err != nil {
+		return Quotes{}, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return Quotes{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return Quotes{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Quotes{}, fmt.Errorf("rates provider returned %d", resp.StatusCode)
+	}
+
+	// Rates are decoded as json.Number so they reach decimal without float rounding.
+	var out struct {
+		Rates          map[string]json.Number `json:"rates"`
+		Result         string                 `json:"result"`
+		BaseCode       string                 `json:"base_code"`
+		ErrorType      string                 `json:"error-type"`
+		TimeLastUpdate int64                  `json:"time_last_update_unix"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&out); err != nil {
+		return Quotes{}, fmt.Errorf("decode rates: %w", err)
+	}
+	if out.Result != "success" {
+		return Quotes{}, fmt.Errorf("rates provider error: %s", out.ErrorType)
+	}
+	if out.BaseCode != base {
+		return Quotes{}, errors.New("rates provider answered for a different base currency")
+	}
+
+	quotes := Quotes{Base: base, AsOf: time.Unix(out.TimeLastUpdate, 0).UTC(), Rates: make(map[string]decimal.Decimal, len(out.Rates))}
+	for code, raw := range out.Rates {
+		v, err := decimal.NewFromString(raw.String())
+		if err != nil {
+			continue
+		}
+		quotes.Rates[code] = v
+	}
+	return quotes, nil
+}
@@ -0,0 +1,219 @@
+// Package rates keeps foreign exchange rates pulled from a provider, lets operators override
+// individual pairs, and answers conversions from the cached values.
+package rates
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrRateNotFound is returned when neither an override nor a provider rate exists for a pair.
+	ErrRateNotFound = errors.New("exchange rate not available")
+	// ErrInvalidCurrency is returned for codes that are not three ASCII letters.
+	ErrInvalidCurrency = errors.New("currency must be a 3-letter ISO 4217 code")
+	// ErrUnknownCurrency is returned for three-letter codes ISO 4217 does not define.
+	ErrUnknownCurrency = errors.New("currency is not an ISO 4217 code")
+)
+
+// Rate sources reported on a Rate.
+const (
+	SourceProvider = "provider"
+	SourceManual   = "manual"
+	SourceIdentity = "identity"
+)
+
+// KindRefresh is the background job kind that pulls the latest provider rates.
+const KindRefresh = "rates.refresh"
+
+// DefaultMaxAge is how old a provider rate may be before it is reported stale.
+const DefaultMaxAge = 24 * time.Hour
+
+// ratePrecision is the scale of stored and derived rates, matching NUMERIC(24, 10).
+const ratePrecision = 10
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// NormalizeCurrency upper-cases code and checks it against the ISO 4217 table.
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !currencyPattern.MatchString(code) {
+		return "", ErrInvalidCurrency
+	}
+	if !currencies.Known(code) {
+		return "", ErrUnknownCurrency
+	}
+	return code, nil
+}
+
+// Rate converts one unit of Base into Rate units of Quote.
+type Rate struct {
+	AsOf   time.Time
+	Rate   decimal.Decimal
+	Base   string
+	Quote  string
+	Source string
+	// Inverted is set when the rate was derived from the stored quote/base pair.
+	Inverted bool
+	// Stale is set when a provider rate is older than the service's maximum age.
+	Stale bool
+}
+
+// Store caches provider rates and overrides. *db.Store satisfies it.
+type Store interface {
+	UpsertFXRate(ctx context.Context, arg sqlc.UpsertFXRateParams) error
+	GetFXRate(ctx context.Context, arg sqlc.GetFXRateParams) (sqlc.FxRate, error)
+	GetActiveFXRateOverride(ctx context.Context, arg sqlc.GetActiveFXRateOverrideParams) (sqlc.FxRateOverride, error)
+}
+
+// Service answers rate lookups and refreshes the cache from a provider.
+type Service struct {
+	store    Store
+	provider Provider
+	now      func() time.Time
+	bases    []string
+	maxAge   time.Duration
+}
+
+// Option customizes a Service.
+type Option func(*Service)
+
+// WithProvider pulls rates quoted against each of bases from provider on Refresh.
+func WithProvider(provider Provider, bases ...string) Option {
+	return func(s *Service) {
+		s.provider = provider
+		s.bases = bases
+	}
+}
+
+// WithMaxAge overrides DefaultMaxAge.
+func WithMaxAge(d time.Duration) Option {
+	return func(s *Service) {
+		s.maxAge = d
+	}
+}
+
+// NewService constructs a Service. Without a provider only manual overrides are available.
+func NewService(store Store, opts ...Option) *Service {
+	s := &Service{store: store, now: time.Now, maxAge: DefaultMaxAge}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Get returns the rate for base/quote. Overrides win over provider rates, and a pair
+// stored only in the opposite direction is inverted.
+func (s *Service) Get(ctx context.Context, base, quote string) (Rate, error) {
+	base, err := NormalizeCurrency(base)
+	if err != nil {
+		return Rate{}, err
+	}
+	quote, err = NormalizeCurrency(quote)
+	if err != nil {
+		return Rate{}, err
+	}
+	if base == quote {
+		return Rate{Base: base, Quote: quote, Rate: decimal.NewFromInt(1), Source: SourceIdentity, AsOf: s.now().UTC()}, nil
+	}
+
+	// Step 1: An unexpired override in either direction.
+	for _, inverted := range []bool{false, true} {
+		b, q := base, quote
+		if inverted {
+			b, q = quote, base
+		}
+		o, err := s.store.GetActiveFXRateOverride(ctx, sqlc.GetActiveFXRateOverrideParams{BaseCurrency: b, QuoteCurrency: q})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return Rate{}, fmt.Errorf("load rate override: %w", err)
+		}
+		return s.rate(base, quote, o.Rate, SourceManual, o.CreatedAt, inverted)
+	}
+
+	// Step 2: The cached provider rate in either direction.
+	for _, inverted := range []bool{false, true} {
+		b, q := base, quote
+		if inverted {
+			b, q = quote, base
+		}
+		r, err := s.store.GetFXRate(ctx, sqlc.GetFXRateParams{BaseCurrency: b, QuoteCurrency: q})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return Rate{}, fmt.Errorf("load rate: %w", err)
+		}
+		return s.rate(base, quote, r.Rate, SourceProvider, r.FetchedAt, inverted)
+	}
+	return Rate{}, ErrRateNotFound
+}
+
+func (s *Service) rate(base, quote, stored, source string, asOf time.Time, inverted bool) (Rate, error) {
+	value, err := decimal.NewFromString(stored)
+	if err != nil || !value.IsPositive() {
+		return Rate{}, fmt.Errorf("invalid stored rate %q for %s/%s", stored, base, quote)
+	}
+	if inverted {
+		value = decimal.NewFromInt(1).DivRound(value, ratePrecision)
+	}
+	return Rate{
+		Base:     base,
+		Quote:    quote,
+		Rate:     value,
+		Source:   source,
+		AsOf:     asOf,
+		Inverted: inverted,
+		Stale:    source == SourceProvider && s.now().Sub(asOf) > s.maxAge,
+	}, nil
+}
+
+// Refresh is a jobs.HandlerFunc that stores the provider's latest rates for every configured base.
+// A base that fails does not stop the others; the job is retried if any failed.
+func (s *Service) Refresh(ctx context.Context, _ json.RawMessage) error {
+	if s.provider == nil {
+		return nil
+	}
+	var errs []error
+	for _, base := range s.bases {
+		quotes, err := s.provider.Latest(ctx, base)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fetch %s rates from %s: %w", base, s.provider.Name(), err))
+			continue
+		}
+		stored := 0
+		for quote, value := range quotes.Rates {
+			quote, err := NormalizeCurrency(quote)
+			if err != nil || quote == quotes.Base || !value.IsPositive() {
+				continue
+			}
+			if err := s.store.UpsertFXRate(ctx, sqlc.UpsertFXRateParams{
+				BaseCurrency:  quotes.Base,
+				QuoteCurrency: quote,
+				Rate:          value.Round(ratePrecision).String(),
+				Provider:      s.provider.Name(),
+				FetchedAt:     quotes.AsOf,
+			}); err != nil {
+				errs = append(errs, fmt.Errorf("store %s/%s: %w", quotes.Base, quote, err))
+				continue
+			}
+			stored++
+		}
+		log.Info().Str("base", quotes.Base).Int("rates", stored).Time("as_of", quotes.AsOf).Msg("Exchange rates refreshed")
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,166 @@
+package rates
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// fakeStore keeps rates and overrides keyed by "BASE/QUOTE".
+type fakeStore struct {
+	rates     map[string]sqlc.FxRate
+	overrides map[string]sqlc.FxRateOverride
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{rates: map[string]sqlc.FxRate{}, overrides: map[string]sqlc.FxRateOverride{}}
+}
+
+func (f *fakeStore) UpsertFXRate(_ context.Context, arg sqlc.UpsertFXRateParams) error {
+	f.rates[arg.BaseCurrency+"/"+arg.QuoteCurrency] = sqlc.FxRate{
+		BaseCurrency:  arg.BaseCurrency,
+		QuoteCurrency: arg.QuoteCurrency,
+		Rate:          arg.Rate,
+		Provider:      arg.Provider,
+		FetchedAt:     arg.FetchedAt,
+	}
+	return nil
+}
+
+func (f *fakeStore) GetFXRate(_ context.Context, arg sqlc.GetFXRateParams) (sqlc.FxRate, error) {
+	r, ok := f.rates[arg.BaseCurrency+"/"+arg.QuoteCurrency]
+	if !ok {
+		return sqlc.FxRate{}, sql.ErrNoRows
+	}
+	return r, nil
+}
+
+func (f *fakeStore) GetActiveFXRateOverride(_ context.Context, arg sqlc.GetActiveFXRateOverrideParams) (sqlc.FxRateOverride, error) {
+	o, ok := f.overrides[arg.BaseCurrency+"/"+arg.QuoteCurrency]
+	if !ok {
+		return sqlc.FxRateOverride{}, sql.ErrNoRows
+	}
+	return o, nil
+}
+
+type staticProvider struct {
+	quotes map[string]Quotes
+}
+
+func (staticProvider) Name() string { return "static" }
+
+func (p staticProvider) Latest(_ context.Context, base string) (Quotes, error) {
+	q, ok := p.quotes[base]
+	if !ok {
+		return Quotes{}, errors.New("unsupported base")
+	}
+	return q, nil
+}
+
+func TestGet_PrecedenceAndInversion(t *testing.T) {
+	// Overrides beat provider rates; a pair stored only the other way round is inverted.
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	store := newFakeStore()
+	store.rates["USD/NGN"] = sqlc.FxRate{Rate: "1600", FetchedAt: now.Add(-time.Hour)}
+	store.rates["USD/EUR"] = sqlc.FxRate{Rate: "0.9", FetchedAt: now.Add(-time.Hour)}
+	store.overrides["USD/EUR"] = sqlc.FxRateOverride{Rate: "0.95", CreatedAt: now.Add(-time.Minute)}
+	svc := NewService(store)
+	svc.now = func() time.Time { return now }
+
+	r, err := svc.Get(context.Background(), "usd", "ngn")
+	require.NoError(t, err)
+	assert.Equal(t, SourceProvider, r.Source)
+	assert.True(t, r.Rate.Equal(decimal.NewFromInt(1600)))
+	assert.False(t, r.Inverted)
+
+	r, err = svc.Get(context.Background(), "NGN", "USD")
+	require.NoError(t, err)
+	assert.True(t, r.Inverted)
+	assert.Equal(t, "0.000625", r.Rate.String())
+
+	r, err = svc.Get(context.Background(), "EUR", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, SourceManual, r.Source)
+	assert.True(t, r.Inverted)
+	assert.Equal(t, "1.0526315789", r.Rate.String())
+}
+
+func TestGet_IdentityMissingAndInvalid(t *testing.T) {
+	// Same currency is 1, unknown pairs and malformed codes are errors.
+	svc := NewService(newFakeStore())
+
+	r, err := svc.Get(context.Background(), "USD", "usd")
+	require.NoError(t, err)
+	assert.Equal(t, SourceIdentity, r.Source)
+	assert.True(t, r.Rate.Equal(decimal.NewFromInt(1)))
+
+	_, err = svc.Get(context.Background(), "USD", "GBP")
+	assert.ErrorIs(t, err, ErrRateNotFound)
+	_, err = svc.Get(context.Background(), "US", "GBP")
+	assert.ErrorIs(t, err, ErrInvalidCurrency)
+	_, err = svc.Get(context.Background(), "USD", "ABC")
+	assert.ErrorIs(t, err, ErrUnknownCurrency)
+}
+
+func TestGet_StaleProviderRate(t *testing.T) {
+	// Provider rates past the maximum age are still returned but flagged.
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	store := newFakeStore()
+	store.rates["USD/NGN"] = sqlc.FxRate{Rate: "1600", FetchedAt: now.Add(-25 * time.Hour)}
+	svc := NewService(store)
+	svc.now = func() time.Time { return now }
+
+	r, err := svc.Get(context.Background(), "USD", "NGN")
+	require.NoError(t, err)
+	assert.True(t, r.Stale)
+}
+
+func TestRefresh_StoresRatesAndContinuesPastFailures(t *testing.T) {
+	// Each base is fetched independently; invalid quotes are skipped and failures are reported.
+	asOf := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	store := newFakeStore()
+	provider := staticProvider{quotes: map[string]Quotes{
+		"USD": {Base: "USD", AsOf: asOf, Rates: map[string]decimal.Decimal{
+			"USD": decimal.NewFromInt(1),
+			"NGN": decimal.RequireFromString("1600.5"),
+			"EUR": decimal.RequireFromString("0.9123456789123"),
+			"BAD": decimal.Zero,
+		}},
+	}}
+	svc := NewService(store, WithProvider(provider, "USD", "GBP"))
+
+	err := svc.Refresh(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GBP")
+	assert.Len(t, store.rates, 2)
+	assert.Equal(t, "1600.5", store.rates["USD/NGN"].Rate)
+	assert.Equal(t, "0.9123456789", store.rates["USD/EUR"].Rate)
+	assert.Equal(t, asOf, store.rates["USD/NGN"].FetchedAt)
+}
+
+func TestOpenERAPI_Latest(t *testing.T) {
+	// Rates decode without float rounding and carry the provider's update time.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/latest/USD", r.URL.Path)
+		_, _ = w.Write([]byte(`{"result":"success","base_code":"USD","time_last_update_unix":1790000000,"rates":{"USD":1,"NGN":1600.123456789012}}`))
+	}))
+	defer srv.Close()
+	p := NewOpenERAPI()
+	p.baseURL = srv.URL
+
+	q, err := p.Latest(context.Background(), "usd")
+	require.NoError(t, err)
+	assert.Equal(t, "USD", q.Base)
+	assert.Equal(t, time.Unix(1790000000, 0).UTC(), q.AsOf)
+	assert.Equal(t, "1600.123456789012", q.Rates["NGN"].String())
+}
@@ -0,0 +1,110 @@
+// Package realtime fans committed ledger entries out to live client connections.
+package realtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// subscriptionBuffer bounds how far a slow client may lag before it is dropped.
+const subscriptionBuffer = 64
+
+// Update is one new entry on a watched account together with the balance after it posted.
+type Update struct {
+	Entry     sqlc.Entry
+	Type      events.Type
+	AccountID uuid.UUID
+	Balance   string
+}
+
+// Subscription receives updates for a fixed set of accounts until closed.
+// C is closed when the subscription ends, including when the client falls too far behind.
+type Subscription struct {
+	C        <-chan Update
+	ch       chan Update
+	accounts map[uuid.UUID]struct{}
+	hub      *Hub
+	once     sync.Once
+}
+
+// Close detaches the subscription from its hub. Safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.remove(s)
+}
+
+// Hub tracks live subscriptions and routes committed events to them.
+type Hub struct {
+	subs map[*Subscription]struct{}
+	mu   sync.RWMutex
+}
+
+// NewHub constructs an empty Hub.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscribe starts receiving updates for the given accounts.
+func (h *Hub) Subscribe(accountIDs ...uuid.UUID) *Subscription {
+	accounts := make(map[uuid.UUID]struct{}, len(accountIDs))
+	for _, id := range accountIDs {
+		accounts[id] = struct{}{}
+	}
+	ch := make(chan Update, subscriptionBuffer)
+	sub := &Subscription{C: ch, ch: ch, accounts: accounts, hub: h}
+
+	h.mu.Lock()
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	h.mu.Lock()
+	delete(h.subs, sub)
+	h.mu.Unlock()
+	sub.once.Do(func() { close(sub.ch) })
+}
+
+// HandleEvent is an events.Handler that pushes each entry to subscribers watching its account.
+func (h *Hub) HandleEvent(_ context.Context, evt events.Event) error {
+	var lagging []*Subscription
+
+	h.mu.RLock()
+	for sub := range h.subs {
+		for _, entry := range evt.Entries {
+			if _, ok := sub.accounts[entry.AccountID]; !ok {
+				continue
+			}
+			update := Update{
+				Entry:     entry,
+				Type:      evt.Type,
+				AccountID: entry.AccountID,
+				Balance:   evt.Balances[entry.AccountID],
+			}
+			select {
+			case sub.ch <- update:
+			default:
+				// Never block the bus on a slow client; drop it so it reconnects and resyncs.
+				lagging = append(lagging, sub)
+			}
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, sub := range lagging {
+		h.remove(sub)
+	}
+	return nil
+}
+
+// Len reports the number of live subscriptions.
+func (h *Hub) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs)
+}
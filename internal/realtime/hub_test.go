@@ -0,0 +1,56 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestHub_RoutesOnlyWatchedAccounts(t *testing.T) {
+	// A subscriber must never see entries for accounts it does not watch.
+	hub := NewHub()
+	mine, theirs := uuid.New(), uuid.New()
+	sub := hub.Subscribe(mine)
+	defer sub.Close()
+
+	err := hub.HandleEvent(context.Background(), events.Event{
+		Type: events.TypeTransfer,
+		Entries: []sqlc.Entry{
+			{AccountID: theirs, Debit: "10.0000", Credit: "0.0000"},
+			{AccountID: mine, Debit: "0.0000", Credit: "10.0000"},
+		},
+		Balances: map[uuid.UUID]string{mine: "10.0000"},
+	})
+	require.NoError(t, err)
+
+	update := <-sub.C
+	assert.Equal(t, mine, update.AccountID)
+	assert.Equal(t, "10.0000", update.Balance)
+	assert.Empty(t, sub.C)
+}
+
+func TestHub_DropsLaggingSubscriber(t *testing.T) {
+	// Overflowing the buffer closes the channel instead of blocking the bus.
+	hub := NewHub()
+	acc := uuid.New()
+	sub := hub.Subscribe(acc)
+
+	evt := events.Event{Entries: []sqlc.Entry{{AccountID: acc}}}
+	for i := 0; i <= subscriptionBuffer; i++ {
+		require.NoError(t, hub.HandleEvent(context.Background(), evt))
+	}
+	assert.Equal(t, 0, hub.Len())
+
+	drained := 0
+	for range sub.C {
+		drained++
+	}
+	assert.Equal(t, subscriptionBuffer, drained)
+	sub.Close() // idempotent after hub-side removal
+}
@@ -0,0 +1,215 @@
+// Package receipt renders transaction receipts as single-page PDF documents customers can share
+// as proof of payment. The PDF is written directly with the standard Helvetica fonts, which every
+// reader ships, so nothing is embedded and no PDF library is needed.
+package receipt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+)
+
+// DefaultBrandName heads receipts when no brand is configured.
+const DefaultBrandName = "Double-Entry Bank"
+
+// ErrInvalidColor is returned for a brand color that is not #RRGGBB.
+var ErrInvalidColor = errors.New("brand color must be #RRGGBB")
+
+// Brand is the name and accent color receipts are headed with.
+type Brand struct {
+	Name string
+	// Color fills the header band, as red, green and blue from 0 to 1.
+	Color [3]float64
+}
+
+// DefaultBrand is a dark blue band under DefaultBrandName.
+var DefaultBrand = Brand{Name: DefaultBrandName, Color: [3]float64{0.12, 0.31, 0.55}}
+
+// ParseColor reads a #RRGGBB hex color.
+func ParseColor(s string) ([3]float64, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) != 6 {
+		return [3]float64{}, ErrInvalidColor
+	}
+	var c [3]float64
+	for i := range c {
+		v, err := strconv.ParseUint(s[2*i:2*i+2], 16, 8)
+		if err != nil {
+			return [3]float64{}, ErrInvalidColor
+		}
+		c[i] = float64(v) / 255
+	}
+	return c, nil
+}
+
+// Party is one side of a transaction as the receipt names it.
+type Party struct {
+	Name string
+	// Account identifies the account, e.g. a masked account number; empty leaves it out.
+	Account string
+}
+
+// Receipt is what a receipt states about one transaction.
+type Receipt struct {
+	Reference     string
+	OperationType string
+	Status        string
+	Amount        decimal.Decimal
+	Currency      string
+	// Received is what the payee was credited when it differs in currency from Amount.
+	Received         *decimal.Decimal
+	ReceivedCurrency string
+	// Fee is what the payer was charged on top of Amount; zero leaves it out.
+	Fee         decimal.Decimal
+	Description string
+	From        Party
+	To          Party
+	CreatedAt   time.Time
+	GeneratedAt time.Time
+}
+
+// Page geometry in points: A4 with 50pt margins.
+const (
+	pageWidth  = 595
+	pageHeight = 842
+	margin     = 50
+	bandHeight = 90
+)
+
+// WritePDF renders r under brand as a one-page PDF.
+func WritePDF(w io.Writer, brand Brand, r Receipt) error {
+	if brand.Name == "" {
+		brand = DefaultBrand
+	}
+	return writeDocument(w, brand.Name+" receipt "+r.Reference, content(brand, r))
+}
+
+// content draws the page: a colored band with the brand, the amount, then one row per detail.
+func content(brand Brand, r Receipt) []byte {
+	var b bytes.Buffer
+	c := brand.Color
+	fmt.Fprintf(&b, "%s %s %s rg\n0 %d %d %d re f\n", num(c[0]), num(c[1]), num(c[2]), pageHeight-bandHeight, pageWidth, bandHeight)
+	text(&b, "F2", 22, [3]float64{1, 1, 1}, margin, pageHeight-50, brand.Name)
+	text(&b, "F1", 11, [3]float64{1, 1, 1}, margin, pageHeight-72, "Transaction receipt")
+
+	y := pageHeight - bandHeight - 50
+	text(&b, "F1", 10, gray, margin, y, "Amount")
+	text(&b, "F2", 26, black, margin, y-30, r.Currency+" "+currencies.Display(r.Currency, r.Amount))
+	text(&b, "F1", 11, gray, margin, y-50, strings.ToUpper(r.Status))
+	y -= 90
+
+	rows := [][2]string{
+		{"Reference", r.Reference},
+		{"Type", r.OperationType},
+		{"Date", r.CreatedAt.UTC().Format("2 January 2006, 15:04:05 MST")},
+		{"From", party(r.From)},
+		{"To", party(r.To)},
+	}
+	if r.Received != nil {
+		rows = append(rows, [2]string{"Received", r.ReceivedCurrency + " " + currencies.Display(r.ReceivedCurrency, *r.Received)})
+	}
+	if r.Fee.IsPositive() {
+		rows = append(rows, [2]string{"Fee", r.Currency + " " + currencies.Display(r.Currency, r.Fee)})
+	}
+	if r.Description != "" {
+		rows = append(rows, [2]string{"Description", r.Description})
+	}
+	for _, row := range rows {
+		fmt.Fprintf(&b, "0.85 0.85 0.85 RG 0.5 w %d %d m %d %d l S\n", margin, y+16, pageWidth-margin, y+16)
+		text(&b, "F1", 10, gray, margin, y, row[0])
+		text(&b, "F2", 11, black, margin+120, y, truncate(row[1], 60))
+		y -= 32
+	}
+
+	text(&b, "F1", 8, gray, margin, margin,
+		"Generated "+r.GeneratedAt.UTC().Format("2006-01-02 15:04:05 MST")+". This receipt reflects the ledger at that time; the status may change later.")
+	return b.Bytes()
+}
+
+var (
+	black = [3]float64{0, 0, 0}
+	gray  = [3]float64{0.4, 0.4, 0.4}
+)
+
+// text writes one line of s at (x, y) in font and size.
+func text(b *bytes.Buffer, font string, size int, color [3]float64, x, y int, s string) {
+	fmt.Fprintf(b, "BT %s %s %s rg /%s %d Tf %d %d Td (%s) Tj ET\n",
+		num(color[0]), num(color[1]), num(color[2]), font, size, x, y, escape(s))
+}
+
+func party(p Party) string {
+	if p.Account == "" {
+		return p.Name
+	}
+	return p.Name + " (" + p.Account + ")"
+}
+
+func num(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-3]) + "..."
+}
+
+// escape makes s a PDF literal string body in WinAnsiEncoding: Latin-1 characters map to
+// themselves, and anything the standard fonts cannot show becomes '?'.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x7f || (r >= 0xa0 && r <= 0xff):
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// writeDocument wraps a page's content stream in a minimal PDF: catalog, page tree, one page,
+// the two fonts it uses, the stream and an info dictionary, with a cross-reference table.
+func writeDocument(w io.Writer, title string, stream []byte) error {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", pageWidth, pageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream),
+		fmt.Sprintf("<< /Title (%s) >>", escape(title)),
+	}
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, len(objects), xref)
+	_, err := w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,86 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePDF(t *testing.T) {
+	// The receipt is a well-formed PDF whose cross-reference table points at each object.
+	var buf bytes.Buffer
+	err := WritePDF(&buf, Brand{}, Receipt{
+		Reference:     "1f0c5d3e-8b1a-4c55-9a7e-2d1c0e9f3b21",
+		OperationType: "transfer",
+		Status:        "posted",
+		Amount:        decimal.RequireFromString("10000"),
+		Currency:      "NGN",
+		Fee:           decimal.RequireFromString("25"),
+		Description:   "Rent (March)",
+		From:          Party{Name: "Ada Obi", Account: "******1234"},
+		To:            Party{Name: "Chidi Eze"},
+		CreatedAt:     time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		GeneratedAt:   time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	doc := buf.Bytes()
+
+	assert.True(t, bytes.HasPrefix(doc, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(doc, []byte("%%EOF\n")))
+	assert.Contains(t, buf.String(), "(Double-Entry Bank) Tj")
+	assert.Contains(t, buf.String(), "(NGN 10,000.00) Tj")
+	assert.Contains(t, buf.String(), "(Rent \\(March\\)) Tj")
+	assert.Contains(t, buf.String(), "(Ada Obi \\(******1234\\)) Tj")
+	assert.Contains(t, buf.String(), "(NGN 25.00) Tj")
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(doc)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(doc[xref:], []byte("xref\n0 8\n")))
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(doc[xref:], -1)
+	require.Len(t, offsets, 7)
+	for i, o := range offsets {
+		off, _ := strconv.Atoi(string(o[1]))
+		assert.True(t, bytes.HasPrefix(doc[off:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+
+	stream := regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)\nendstream`).FindSubmatch(doc)
+	require.NotNil(t, stream)
+	assert.Equal(t, string(stream[1]), strconv.Itoa(len(stream[2])))
+}
+
+func TestContent_AmountsInCurrencyScale(t *testing.T) {
+	// Amounts are grouped by thousands with the decimal places of their currency.
+	received := decimal.RequireFromString("2500.5")
+	page := string(content(DefaultBrand, Receipt{
+		Currency: "JPY", Amount: decimal.RequireFromString("1500"),
+		Received: &received, ReceivedCurrency: "NGN",
+	}))
+	assert.Contains(t, page, "(JPY 1,500)")
+	assert.Contains(t, page, "(NGN 2,500.50)")
+}
+
+func TestEscape(t *testing.T) {
+	// Delimiters are escaped, Latin-1 passes through as single bytes and the rest is replaced.
+	assert.Equal(t, `a\(b\)\\c`, escape(`a(b)\c`))
+	assert.Equal(t, "Jos\xe9", escape("José"))
+	assert.Equal(t, "? 5", escape("₦ 5"))
+}
+
+func TestParseColor(t *testing.T) {
+	c, err := ParseColor("#FF0080")
+	require.NoError(t, err)
+	assert.Equal(t, [3]float64{1, 0, 128.0 / 255}, c)
+	_, err = ParseColor("blue")
+	assert.ErrorIs(t, err, ErrInvalidColor)
+	_, err = ParseColor("#GG0000")
+	assert.ErrorIs(t, err, ErrInvalidColor)
+}
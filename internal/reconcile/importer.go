@@ -0,0 +1,107 @@
+package reconcile
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrEmptyStatement is returned when a statement file contains no transactions.
+	ErrEmptyStatement = errors.New("statement contains no transactions")
+	// ErrInvalidStatement wraps parse failures so callers can tell bad input from storage errors.
+	ErrInvalidStatement = errors.New("invalid statement")
+)
+
+// Store is the persistence an import needs. *db.Store satisfies it.
+type Store interface {
+	GetSettlementAccount(ctx context.Context) (sqlc.Account, error)
+	ListAccountShards(ctx context.Context, shardOf uuid.UUID) ([]uuid.UUID, error)
+	ListEntriesByAccountBetween(ctx context.Context, arg sqlc.ListEntriesByAccountBetweenParams) ([]sqlc.Entry, error)
+	CreateBankStatementImport(ctx context.Context, arg sqlc.CreateBankStatementImportParams) (sqlc.BankStatementImport, error)
+}
+
+// ImportRequest describes one uploaded settlement-bank statement.
+type ImportRequest struct {
+	Body       io.Reader
+	Format     Format
+	Filename   string
+	ImportedBy uuid.UUID
+}
+
+// Import parses a statement, reconciles it against the settlement account and stores the report.
+func Import(ctx context.Context, store Store, req ImportRequest) (sqlc.BankStatementImport, Report, error) {
+	lines, err := Parse(req.Format, req.Body)
+	if err != nil {
+		return sqlc.BankStatementImport{}, Report{}, fmt.Errorf("%w: %w", ErrInvalidStatement, err)
+	}
+	if len(lines) == 0 {
+		return sqlc.BankStatementImport{}, Report{}, ErrEmptyStatement
+	}
+
+	settlement, err := store.GetSettlementAccount(ctx)
+	if err != nil {
+		return sqlc.BankStatementImport{}, Report{}, fmt.Errorf("settlement account not found: %w", err)
+	}
+
+	// Load ledger activity for the statement period widened by the matching tolerance, from the
+	// settlement account and every shard it is split into.
+	shards, err := store.ListAccountShards(ctx, settlement.ID)
+	if err != nil {
+		return sqlc.BankStatementImport{}, Report{}, fmt.Errorf("load settlement shards: %w", err)
+	}
+	start, end := Period(lines)
+	var entries []sqlc.Entry
+	for _, accountID := range append([]uuid.UUID{settlement.ID}, shards...) {
+		rows, err := store.ListEntriesByAccountBetween(ctx, sqlc.ListEntriesByAccountBetweenParams{
+			AccountID: accountID,
+			FromTime:  sql.NullTime{Time: start.Add(-DefaultDateTolerance), Valid: true},
+			ToTime:    sql.NullTime{Time: end.Add(DefaultDateTolerance), Valid: true},
+		})
+		if err != nil {
+			return sqlc.BankStatementImport{}, Report{}, fmt.Errorf("load settlement entries: %w", err)
+		}
+		entries = append(entries, rows...)
+	}
+	// Put the shards' entries back in posting order, as one account's would be.
+	slices.SortStableFunc(entries, func(a, b sqlc.Entry) int { return a.CreatedAt.Time.Compare(b.CreatedAt.Time) })
+	items := make([]LedgerItem, 0, len(entries))
+	for _, e := range entries {
+		item, convErr := LedgerItemFromEntry(e)
+		if convErr != nil {
+			return sqlc.BankStatementImport{}, Report{}, fmt.Errorf("invalid entry %s: %w", e.ID, convErr)
+		}
+		items = append(items, item)
+	}
+
+	report := Reconcile(lines, items, start, end, DefaultDateTolerance)
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return sqlc.BankStatementImport{}, Report{}, err
+	}
+
+	rec, err := store.CreateBankStatementImport(ctx, sqlc.CreateBankStatementImportParams{
+		SourceFormat:    string(req.Format),
+		Filename:        req.Filename,
+		PeriodStart:     start,
+		PeriodEnd:       end,
+		LineCount:       int32(len(lines)), // #nosec G115 -- bounded by upload size limit
+		MatchedCount:    int32(len(report.Matched)),
+		MissingCount:    int32(len(report.Missing)),
+		UnexpectedCount: int32(len(report.Unexpected)),
+		Report:          raw,
+		ImportedBy:      uuid.NullUUID{UUID: req.ImportedBy, Valid: req.ImportedBy != uuid.Nil},
+	})
+	if err != nil {
+		return sqlc.BankStatementImport{}, Report{}, fmt.Errorf("store reconciliation report: %w", err)
+	}
+	return rec, report, nil
+}
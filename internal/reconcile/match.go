@@ -0,0 +1,168 @@
+package reconcile
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// DefaultDateTolerance is how far a bank booking date may drift from the ledger posting.
+const DefaultDateTolerance = 2 * 24 * time.Hour
+
+// LedgerItem is a settlement-account entry as it should appear on the bank statement.
+// Amount uses the bank's sign: a settlement debit (deposit) is money arriving at the bank.
+type LedgerItem struct {
+	PostedAt      time.Time       `json:"posted_at"`
+	Amount        decimal.Decimal `json:"amount"`
+	EntryID       string          `json:"entry_id"`
+	TransactionID string          `json:"transaction_id"`
+	OperationType string          `json:"operation_type"`
+	Description   string          `json:"description,omitempty"`
+}
+
+// Match pairs a bank line with the ledger entry it settles.
+type Match struct {
+	Line   Line       `json:"line"`
+	Ledger LedgerItem `json:"ledger"`
+	// Method is "reference" when the bank echoed our ID, otherwise "amount_date".
+	Method string `json:"method"`
+}
+
+// Report is the outcome of reconciling one statement.
+type Report struct {
+	PeriodStart time.Time    `json:"period_start"`
+	PeriodEnd   time.Time    `json:"period_end"`
+	Matched     []Match      `json:"matched"`
+	Missing     []LedgerItem `json:"missing"`
+	Unexpected  []Line       `json:"unexpected"`
+}
+
+// LedgerItemFromEntry converts a settlement entry into bank-signed form.
+func LedgerItemFromEntry(e sqlc.Entry) (LedgerItem, error) {
+	debit, err := decimal.NewFromString(e.Debit)
+	if err != nil {
+		return LedgerItem{}, err
+	}
+	credit, err := decimal.NewFromString(e.Credit)
+	if err != nil {
+		return LedgerItem{}, err
+	}
+	return LedgerItem{
+		PostedAt:      e.CreatedAt.Time.UTC(),
+		Amount:        debit.Sub(credit),
+		EntryID:       e.ID.String(),
+		TransactionID: e.TransactionID.String(),
+		OperationType: e.OperationType,
+		Description:   e.Description.String,
+	}, nil
+}
+
+// Period returns the day-aligned [start, end) range covered by lines.
+func Period(lines []Line) (time.Time, time.Time) {
+	if len(lines) == 0 {
+		return time.Time{}, time.Time{}
+	}
+	start, end := lines[0].Date, lines[0].Date
+	for _, l := range lines[1:] {
+		if l.Date.Before(start) {
+			start = l.Date
+		}
+		if l.Date.After(end) {
+			end = l.Date
+		}
+	}
+	y, m, d := start.Date()
+	start = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	y, m, d = end.Date()
+	end = time.Date(y, m, d, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	return start, end
+}
+
+// Reconcile matches lines to ledger items. Items should cover the statement period
+// widened by tolerance; only unmatched items inside [periodStart, periodEnd) count as missing.
+func Reconcile(lines []Line, items []LedgerItem, periodStart, periodEnd time.Time, tolerance time.Duration) Report {
+	report := Report{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Matched:     []Match{},
+		Missing:     []LedgerItem{},
+		Unexpected:  []Line{},
+	}
+	used := make([]bool, len(items))
+
+	// Pass 1: references are authoritative when the bank echoes our transaction or entry ID.
+	pending := make([]Line, 0, len(lines))
+	for _, line := range lines {
+		if idx := matchByReference(line, items, used); idx >= 0 {
+			used[idx] = true
+			report.Matched = append(report.Matched, Match{Line: line, Ledger: items[idx], Method: "reference"})
+			continue
+		}
+		pending = append(pending, line)
+	}
+
+	// Pass 2: exact amount within the date tolerance, closest posting first.
+	for _, line := range pending {
+		if idx := matchByAmountDate(line, items, used, tolerance); idx >= 0 {
+			used[idx] = true
+			report.Matched = append(report.Matched, Match{Line: line, Ledger: items[idx], Method: "amount_date"})
+			continue
+		}
+		report.Unexpected = append(report.Unexpected, line)
+	}
+
+	for i, item := range items {
+		if used[i] || item.PostedAt.Before(periodStart) || !item.PostedAt.Before(periodEnd) {
+			continue
+		}
+		report.Missing = append(report.Missing, item)
+	}
+
+	sort.Slice(report.Matched, func(i, j int) bool { return report.Matched[i].Line.Number < report.Matched[j].Line.Number })
+	return report
+}
+
+func matchByReference(line Line, items []LedgerItem, used []bool) int {
+	ref := strings.TrimSpace(line.Reference)
+	if ref == "" {
+		return -1
+	}
+	for i, item := range items {
+		if used[i] || !item.Amount.Equal(line.Amount) {
+			continue
+		}
+		if strings.EqualFold(ref, item.TransactionID) || strings.EqualFold(ref, item.EntryID) {
+			return i
+		}
+	}
+	return -1
+}
+
+func matchByAmountDate(line Line, items []LedgerItem, used []bool, tolerance time.Duration) int {
+	best, bestGap := -1, time.Duration(0)
+	for i, item := range items {
+		if used[i] || !item.Amount.Equal(line.Amount) {
+			continue
+		}
+		gap := absDuration(item.PostedAt.Sub(line.Date))
+		// Bank dates have day granularity, so allow the whole booking day on top of tolerance.
+		if gap > tolerance+24*time.Hour {
+			continue
+		}
+		if best < 0 || gap < bestGap {
+			best, bestGap = i, gap
+		}
+	}
+	return best
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,245 @@
+// Package reconcile matches external settlement-bank statements against ledger entries.
+package reconcile
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Format identifies a supported statement file format.
+type Format string
+
+const (
+	// FormatCSV is a header-based CSV export.
+	FormatCSV Format = "csv"
+	// FormatOFX is an Open Financial Exchange (SGML or XML) statement.
+	FormatOFX Format = "ofx"
+)
+
+// ErrUnsupportedFormat is returned for formats other than CSV and OFX.
+var ErrUnsupportedFormat = errors.New("unsupported statement format")
+
+// Line is one transaction on the external bank statement.
+// Amount is signed from the bank's view: positive means money arrived at the bank.
+type Line struct {
+	Date        time.Time       `json:"date"`
+	Amount      decimal.Decimal `json:"amount"`
+	Reference   string          `json:"reference,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Number      int             `json:"line"`
+}
+
+// Parse reads statement lines in the given format.
+func Parse(format Format, r io.Reader) ([]Line, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatOFX:
+		return parseOFX(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+var csvDateLayouts = []string{"2006-01-02", "2006-01-02T15:04:05Z07:00", "02/01/2006", "2006/01/02", "02-Jan-2006"}
+
+// parseCSV expects a header row with date plus either amount, or credit/debit columns.
+// Optional reference and description (or narration) columns are used when present.
+func parseCSV(r io.Reader) ([]Line, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	dateCol, ok := firstColumn(cols, "date", "booking date", "value date", "transaction date")
+	if !ok {
+		return nil, errors.New("CSV header must include a date column")
+	}
+	amountCol, hasAmount := firstColumn(cols, "amount")
+	creditCol, hasCredit := firstColumn(cols, "credit")
+	debitCol, hasDebit := firstColumn(cols, "debit")
+	if !hasAmount && !(hasCredit && hasDebit) {
+		return nil, errors.New("CSV header must include amount, or credit and debit columns")
+	}
+	refCol, hasRef := firstColumn(cols, "reference", "ref", "transaction id")
+	descCol, hasDesc := firstColumn(cols, "description", "narration", "details")
+
+	var lines []Line
+	for n := 2; ; n++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if isBlank(record) {
+			continue
+		}
+
+		date, err := parseDate(field(record, dateCol))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		var amount decimal.Decimal
+		if hasAmount {
+			amount, err = parseAmount(field(record, amountCol))
+		} else {
+			amount, err = creditMinusDebit(field(record, creditCol), field(record, debitCol))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		line := Line{Number: n, Date: date, Amount: amount}
+		if hasRef {
+			line.Reference = strings.TrimSpace(field(record, refCol))
+		}
+		if hasDesc {
+			line.Description = strings.TrimSpace(field(record, descCol))
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+var ofxTagPattern = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+
+// parseOFX extracts STMTTRN blocks from OFX 1.x (SGML, unclosed tags) or 2.x (XML).
+func parseOFX(r io.Reader) ([]Line, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []Line
+	for i, block := range splitOFXTransactions(string(raw)) {
+		tags := make(map[string]string)
+		for _, m := range ofxTagPattern.FindAllStringSubmatch(block, -1) {
+			tags[strings.ToUpper(m[1])] = strings.TrimSpace(m[2])
+		}
+
+		posted := tags["DTPOSTED"]
+		if len(posted) < 8 {
+			return nil, fmt.Errorf("transaction %d: missing DTPOSTED", i+1)
+		}
+		date, err := time.Parse("20060102", posted[:8])
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: invalid DTPOSTED %q", i+1, posted)
+		}
+		amount, err := parseAmount(tags["TRNAMT"])
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i+1, err)
+		}
+
+		desc := tags["NAME"]
+		if memo := tags["MEMO"]; memo != "" {
+			desc = strings.TrimSpace(desc + " " + memo)
+		}
+		ref := tags["REFNUM"]
+		if ref == "" {
+			ref = tags["FITID"]
+		}
+		lines = append(lines, Line{Number: i + 1, Date: date, Amount: amount, Reference: ref, Description: desc})
+	}
+	return lines, nil
+}
+
+// splitOFXTransactions returns the body of each STMTTRN element, closed or not.
+func splitOFXTransactions(doc string) []string {
+	upper := strings.ToUpper(doc)
+	var blocks []string
+	for {
+		start := strings.Index(upper, "<STMTTRN>")
+		if start < 0 {
+			return blocks
+		}
+		upper, doc = upper[start+len("<STMTTRN>"):], doc[start+len("<STMTTRN>"):]
+		end := len(upper)
+		for _, terminator := range []string{"</STMTTRN>", "<STMTTRN>", "</BANKTRANLIST>"} {
+			if idx := strings.Index(upper, terminator); idx >= 0 && idx < end {
+				end = idx
+			}
+		}
+		blocks = append(blocks, doc[:end])
+		upper, doc = upper[end:], doc[end:]
+	}
+}
+
+func parseDate(v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", v)
+}
+
+func parseAmount(v string) (decimal.Decimal, error) {
+	cleaned := strings.NewReplacer(",", "", " ", "").Replace(strings.TrimSpace(v))
+	if cleaned == "" {
+		return decimal.Zero, errors.New("amount required")
+	}
+	d, err := decimal.NewFromString(cleaned)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", v)
+	}
+	return d, nil
+}
+
+func creditMinusDebit(creditStr, debitStr string) (decimal.Decimal, error) {
+	credit, debit := decimal.Zero, decimal.Zero
+	var err error
+	if strings.TrimSpace(creditStr) != "" {
+		if credit, err = parseAmount(creditStr); err != nil {
+			return decimal.Zero, err
+		}
+	}
+	if strings.TrimSpace(debitStr) != "" {
+		if debit, err = parseAmount(debitStr); err != nil {
+			return decimal.Zero, err
+		}
+	}
+	return credit.Sub(debit.Abs()), nil
+}
+
+func firstColumn(cols map[string]int, names ...string) (int, bool) {
+	for _, name := range names {
+		if idx, ok := cols[name]; ok {
+			return idx, true
+		}
+	}
+	return 0, false
+}
+
+func field(record []string, idx int) string {
+	if idx < len(record) {
+		return record[idx]
+	}
+	return ""
+}
+
+func isBlank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package reconcile
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestParseCSV_AmountAndCreditDebitColumns(t *testing.T) {
+	// Both signed-amount and split credit/debit layouts are accepted.
+	lines, err := Parse(FormatCSV, strings.NewReader("Date,Amount,Reference,Narration\n2026-03-01,\"1,000.00\",abc,Deposit\n\n2026-03-02,-50,,Payout\n"))
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.True(t, lines[0].Amount.Equal(decimal.RequireFromString("1000")))
+	assert.Equal(t, "abc", lines[0].Reference)
+	assert.True(t, lines[1].Amount.Equal(decimal.RequireFromString("-50")))
+
+	lines, err = Parse(FormatCSV, strings.NewReader("date,credit,debit\n01/03/2026,,25.5\n"))
+	require.NoError(t, err)
+	assert.True(t, lines[0].Amount.Equal(decimal.RequireFromString("-25.5")))
+	assert.Equal(t, time.March, lines[0].Date.Month())
+}
+
+func TestParseOFX_SGML(t *testing.T) {
+	// OFX 1.x leaves leaf tags unclosed.
+	doc := `OFXHEADER:100
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20260301120000<TRNAMT>100.00<FITID>F1<NAME>ACME
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20260302<TRNAMT>-40.00<FITID>F2<MEMO>payout
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+	lines, err := Parse(FormatOFX, strings.NewReader(doc))
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "F1", lines[0].Reference)
+	assert.Equal(t, "ACME", lines[0].Description)
+	assert.True(t, lines[1].Amount.Equal(decimal.RequireFromString("-40")))
+}
+
+func TestReconcile_MatchedMissingUnexpected(t *testing.T) {
+	// Reference wins over amount/date; leftovers are classified on each side.
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	items := []LedgerItem{
+		{EntryID: "e1", TransactionID: "tx-1", Amount: decimal.RequireFromString("100"), PostedAt: day.Add(10 * time.Hour)},
+		{EntryID: "e2", TransactionID: "tx-2", Amount: decimal.RequireFromString("100"), PostedAt: day.Add(11 * time.Hour)},
+		{EntryID: "e3", TransactionID: "tx-3", Amount: decimal.RequireFromString("-40"), PostedAt: day.Add(36 * time.Hour)},
+		{EntryID: "e4", TransactionID: "tx-4", Amount: decimal.RequireFromString("7"), PostedAt: day.Add(12 * time.Hour)},
+	}
+	lines := []Line{
+		{Number: 1, Date: day, Amount: decimal.RequireFromString("100"), Reference: "TX-2"},
+		{Number: 2, Date: day, Amount: decimal.RequireFromString("100")},
+		{Number: 3, Date: day.AddDate(0, 0, 3), Amount: decimal.RequireFromString("-40")},
+		{Number: 4, Date: day, Amount: decimal.RequireFromString("999")},
+	}
+	start, end := Period(lines)
+	report := Reconcile(lines, items, start, end, DefaultDateTolerance)
+
+	require.Len(t, report.Matched, 3)
+	assert.Equal(t, "e2", report.Matched[0].Ledger.EntryID)
+	assert.Equal(t, "reference", report.Matched[0].Method)
+	assert.Equal(t, "e1", report.Matched[1].Ledger.EntryID)
+	assert.Equal(t, "e3", report.Matched[2].Ledger.EntryID)
+	require.Len(t, report.Unexpected, 1)
+	assert.Equal(t, 4, report.Unexpected[0].Number)
+	require.Len(t, report.Missing, 1)
+	assert.Equal(t, "e4", report.Missing[0].EntryID)
+}
+
+// shardedStore holds a settlement account split into shards, each with its own entries.
+type shardedStore struct {
+	settlement sqlc.Account
+	shards     []uuid.UUID
+	entries    map[uuid.UUID][]sqlc.Entry
+}
+
+func (s *shardedStore) GetSettlementAccount(context.Context) (sqlc.Account, error) {
+	return s.settlement, nil
+}
+
+func (s *shardedStore) ListAccountShards(context.Context, uuid.UUID) ([]uuid.UUID, error) {
+	return s.shards, nil
+}
+
+func (s *shardedStore) ListEntriesByAccountBetween(_ context.Context, arg sqlc.ListEntriesByAccountBetweenParams) ([]sqlc.Entry, error) {
+	return s.entries[arg.AccountID], nil
+}
+
+func (s *shardedStore) CreateBankStatementImport(context.Context, sqlc.CreateBankStatementImportParams) (sqlc.BankStatementImport, error) {
+	return sqlc.BankStatementImport{}, nil
+}
+
+func TestImport_MatchesAcrossSettlementShards(t *testing.T) {
+	// A deposit posted against a shard reconciles like one posted against the account itself.
+	settlement := sqlc.Account{ID: uuid.New(), Name: "Settlement Account"}
+	shard := uuid.New()
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(account uuid.UUID, debit string, at time.Time) sqlc.Entry {
+		return sqlc.Entry{ID: uuid.New(), AccountID: account, Debit: debit, Credit: "0.0000", TransactionID: uuid.New(), CreatedAt: sql.NullTime{Time: at, Valid: true}}
+	}
+	store := &shardedStore{
+		settlement: settlement,
+		shards:     []uuid.UUID{shard},
+		entries: map[uuid.UUID][]sqlc.Entry{
+			settlement.ID: {entry(settlement.ID, "100.0000", day.Add(10*time.Hour))},
+			shard:         {entry(shard, "40.0000", day.Add(9*time.Hour))},
+		},
+	}
+
+	_, report, err := Import(context.Background(), store, ImportRequest{
+		Body:   strings.NewReader("Date,Amount\n2026-03-01,100\n2026-03-01,40\n"),
+		Format: FormatCSV,
+	})
+	require.NoError(t, err)
+	assert.Len(t, report.Matched, 2)
+	assert.Empty(t, report.Missing)
+	assert.Empty(t, report.Unexpected)
+}
@@ -0,0 +1,185 @@
+// Package risk scores money movements for fraud. Each signal that looks out of character for the
+// account (a burst of payments, a first payment to a beneficiary, an amount far above its usual
+// ones, a request from a country the customer was not in moments ago) adds points; the total decides whether the operation goes ahead, needs the customer to
+// step up with a second factor, or waits for manual review.
+package risk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Decision is what a score asks for. Later decisions are stricter.
+type Decision string
+
+// Decisions in increasing severity.
+const (
+	Allow     Decision = "allow"
+	Challenge Decision = "challenge"
+	Review    Decision = "review"
+)
+
+// Factor names, stored with an assessment.
+const (
+	FactorVelocity       = "velocity"
+	FactorNewBeneficiary = "new_beneficiary"
+	FactorUnusualAmount  = "unusual_amount"
+	FactorGeoAnomaly     = "geo_anomaly"
+)
+
+// Operation is an outbound money movement about to be posted. Beneficiary identifies where the
+// money goes ("" when nowhere in particular); KnownBeneficiary says the account paid it before.
+// GeoAnomaly describes a sudden change of the requester's country, "" when there was none.
+type Operation struct {
+	Kind             string
+	Amount           decimal.Decimal
+	Beneficiary      string
+	KnownBeneficiary bool
+	GeoAnomaly       string
+	At               time.Time
+}
+
+// Activity is one past outbound movement of the account.
+type Activity struct {
+	Amount decimal.Decimal
+	At     time.Time
+}
+
+// Factor is one signal that added to a score.
+type Factor struct {
+	Name   string
+	Points int
+	Detail string
+}
+
+// Assessment is an operation's score out of 100, the signals behind it and the decision.
+type Assessment struct {
+	Score    int
+	Decision Decision
+	Factors  []Factor
+}
+
+// FactorNames lists the signals that contributed, in the order they were checked.
+func (a Assessment) FactorNames() []string {
+	names := make([]string, 0, len(a.Factors))
+	for _, f := range a.Factors {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// Config tunes the signals and the scores at which operations are challenged or reviewed.
+type Config struct {
+	// VelocityCount or more outbound movements within VelocityWindow before this one add VelocityPoints.
+	VelocityWindow time.Duration
+	VelocityCount  int
+	VelocityPoints int
+	// NewBeneficiaryPoints are added for the first payment to a beneficiary.
+	NewBeneficiaryPoints int
+	// An amount above UnusualMultiple times the average of the account's outbound movements within
+	// HistoryWindow adds UnusualPoints, once there are at least MinHistory of them to compare with.
+	HistoryWindow   time.Duration
+	MinHistory      int
+	UnusualMultiple decimal.Decimal
+	UnusualPoints   int
+	// GeoAnomalyPoints are added for an operation requested from a new country shortly after
+	// activity elsewhere.
+	GeoAnomalyPoints int
+	// Scores of ChallengeAt or more need a step-up, ReviewAt or more a manual review.
+	ChallengeAt int
+	ReviewAt    int
+}
+
+// DefaultConfig challenges operations showing two signals, or a sudden country change alone, and
+// reviews those showing three or a country change with an unusual amount.
+func DefaultConfig() Config {
+	return Config{
+		VelocityWindow:       time.Hour,
+		VelocityCount:        5,
+		VelocityPoints:       30,
+		NewBeneficiaryPoints: 30,
+		HistoryWindow:        30 * 24 * time.Hour,
+		MinHistory:           3,
+		UnusualMultiple:      decimal.NewFromInt(3),
+		UnusualPoints:        40,
+		GeoAnomalyPoints:     50,
+		ChallengeAt:          50,
+		ReviewAt:             90,
+	}
+}
+
+// Scorer assesses operations with a fixed Config.
+type Scorer struct {
+	cfg Config
+}
+
+// NewScorer returns a Scorer using cfg.
+func NewScorer(cfg Config) *Scorer {
+	return &Scorer{cfg: cfg}
+}
+
+// Lookback is how much history Assess needs.
+func (s *Scorer) Lookback() time.Duration {
+	return max(s.cfg.VelocityWindow, s.cfg.HistoryWindow)
+}
+
+// Assess scores op against the account's recent outbound history.
+func (s *Scorer) Assess(op Operation, history []Activity) Assessment {
+	var a Assessment
+
+	// A burst of payments is typical of a taken-over account being emptied.
+	recent, total, count := 0, decimal.Zero, 0
+	for _, h := range history {
+		age := op.At.Sub(h.At)
+		if age < 0 {
+			continue
+		}
+		if age <= s.cfg.VelocityWindow {
+			recent++
+		}
+		if age <= s.cfg.HistoryWindow {
+			total = total.Add(h.Amount)
+			count++
+		}
+	}
+	if s.cfg.VelocityCount > 0 && recent >= s.cfg.VelocityCount {
+		a.add(FactorVelocity, s.cfg.VelocityPoints, fmt.Sprintf("%d outbound movements in the last %s", recent, s.cfg.VelocityWindow))
+	}
+
+	if op.Beneficiary != "" && !op.KnownBeneficiary {
+		a.add(FactorNewBeneficiary, s.cfg.NewBeneficiaryPoints, "first payment to "+op.Beneficiary)
+	}
+
+	if count > 0 && count >= s.cfg.MinHistory {
+		average := total.Div(decimal.NewFromInt(int64(count)))
+		if average.IsPositive() && op.Amount.GreaterThan(average.Mul(s.cfg.UnusualMultiple)) {
+			a.add(FactorUnusualAmount, s.cfg.UnusualPoints, fmt.Sprintf("%s is %sx the average of %s over %d movements",
+				op.Amount.StringFixed(2), op.Amount.Div(average).StringFixed(1), average.StringFixed(2), count))
+		}
+	}
+
+	if op.GeoAnomaly != "" {
+		a.add(FactorGeoAnomaly, s.cfg.GeoAnomalyPoints, op.GeoAnomaly)
+	}
+
+	a.Score = min(a.Score, 100)
+	switch {
+	case a.Score >= s.cfg.ReviewAt:
+		a.Decision = Review
+	case a.Score >= s.cfg.ChallengeAt:
+		a.Decision = Challenge
+	default:
+		a.Decision = Allow
+	}
+	return a
+}
+
+func (a *Assessment) add(name string, points int, detail string) {
+	if points <= 0 {
+		return
+	}
+	a.Score += points
+	a.Factors = append(a.Factors, Factor{Name: name, Points: points, Detail: detail})
+}
@@ -0,0 +1,72 @@
+package risk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func history(now time.Time, amounts ...int64) []Activity {
+	out := make([]Activity, 0, len(amounts))
+	for i, amount := range amounts {
+		out = append(out, Activity{Amount: decimal.NewFromInt(amount), At: now.Add(-time.Duration(i+1) * 24 * time.Hour)})
+	}
+	return out
+}
+
+func TestAssess_Allow(t *testing.T) {
+	// A usual amount to a known beneficiary scores nothing.
+	now := time.Now()
+	a := NewScorer(DefaultConfig()).Assess(Operation{Amount: decimal.NewFromInt(100), Beneficiary: "account:x", KnownBeneficiary: true, At: now}, history(now, 100, 120, 80))
+	assert.Equal(t, 0, a.Score)
+	assert.Equal(t, Allow, a.Decision)
+	assert.Empty(t, a.Factors)
+}
+
+func TestAssess_SignalsAddUp(t *testing.T) {
+	// One signal is allowed, two are challenged and all three go to review.
+	now := time.Now()
+	s := NewScorer(DefaultConfig())
+
+	a := s.Assess(Operation{Amount: decimal.NewFromInt(100), Beneficiary: "account:x", At: now}, history(now, 100, 120, 80))
+	assert.Equal(t, Allow, a.Decision)
+	assert.Equal(t, []string{FactorNewBeneficiary}, a.FactorNames())
+
+	a = s.Assess(Operation{Amount: decimal.NewFromInt(1_000), Beneficiary: "account:x", At: now}, history(now, 100, 120, 80))
+	assert.Equal(t, 70, a.Score)
+	assert.Equal(t, Challenge, a.Decision)
+	assert.Equal(t, []string{FactorNewBeneficiary, FactorUnusualAmount}, a.FactorNames())
+
+	burst := history(now, 100, 120, 80)
+	for i := 0; i < 5; i++ {
+		burst = append(burst, Activity{Amount: decimal.NewFromInt(50), At: now.Add(-time.Duration(i+1) * time.Minute)})
+	}
+	a = s.Assess(Operation{Amount: decimal.NewFromInt(5_000), Beneficiary: "bank:058:0123456789", At: now}, burst)
+	assert.Equal(t, 100, a.Score)
+	assert.Equal(t, Review, a.Decision)
+	assert.Equal(t, []string{FactorVelocity, FactorNewBeneficiary, FactorUnusualAmount}, a.FactorNames())
+}
+
+func TestAssess_UnusualAmountNeedsHistory(t *testing.T) {
+	// Too little history to compare with is not a signal on its own.
+	now := time.Now()
+	a := NewScorer(DefaultConfig()).Assess(Operation{Amount: decimal.NewFromInt(10_000), At: now}, history(now, 10, 10))
+	assert.Equal(t, 0, a.Score)
+}
+
+func TestAssess_GeoAnomaly(t *testing.T) {
+	// A sudden country change is challenged on its own and reviewed with an unusual amount.
+	now := time.Now()
+	s := NewScorer(DefaultConfig())
+
+	a := s.Assess(Operation{Amount: decimal.NewFromInt(100), GeoAnomaly: "request from GB, 2h after activity in NG", At: now}, history(now, 100, 120, 80))
+	assert.Equal(t, 50, a.Score)
+	assert.Equal(t, Challenge, a.Decision)
+	assert.Equal(t, []string{FactorGeoAnomaly}, a.FactorNames())
+	assert.Equal(t, "request from GB, 2h after activity in NG", a.Factors[0].Detail)
+
+	a = s.Assess(Operation{Amount: decimal.NewFromInt(1_000), GeoAnomaly: "request from GB", At: now}, history(now, 100, 120, 80))
+	assert.Equal(t, Review, a.Decision)
+}
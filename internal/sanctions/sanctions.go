@@ -0,0 +1,169 @@
+// Package sanctions screens names against sanctions and watch lists. A Provider does the matching;
+// ListProvider matches against a list loaded from a file, and other providers (a vendor API, for
+// instance) plug in by implementing the interface.
+package sanctions
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultMinScore is the similarity at or above which ListProvider reports a match.
+const DefaultMinScore = 0.85
+
+// Match is one list entry a screened name resembles.
+type Match struct {
+	List  string  `json:"list"`
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+// Provider screens a name, returning the entries it matches, best first. An error means the name
+// could not be screened, not that it is clean.
+type Provider interface {
+	// Name identifies the provider on stored screening results.
+	Name() string
+	Screen(ctx context.Context, name string) ([]Match, error)
+}
+
+// Normalize folds a name for comparison: lower case, letters and digits only, tokens sorted, so
+// "SMITH, John" and "john smith" are the same name.
+func Normalize(name string) string {
+	tokens := tokenize(name)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+func tokenize(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Entry is one name on a list.
+type Entry struct {
+	List string
+	Name string
+}
+
+// ListProvider matches names against fixed entries.
+type ListProvider struct {
+	entries  []listEntry
+	minScore float64
+}
+
+type listEntry struct {
+	Entry
+	tokens []string
+}
+
+// NewListProvider matches against entries, reporting those scoring at least minScore (0 means
+// DefaultMinScore).
+func NewListProvider(entries []Entry, minScore float64) *ListProvider {
+	if minScore <= 0 {
+		minScore = DefaultMinScore
+	}
+	p := &ListProvider{minScore: minScore}
+	for _, e := range entries {
+		if tokens := tokenize(e.Name); len(tokens) > 0 {
+			p.entries = append(p.entries, listEntry{Entry: e, tokens: tokens})
+		}
+	}
+	return p
+}
+
+// LoadList reads a CSV file of "list,name" rows, e.g. exported from a consolidated sanctions list.
+// Blank lines and lines starting with # are skipped.
+func LoadList(path string, minScore float64) (*ListProvider, error) {
+	f, err := os.Open(path) // #nosec G304 -- path comes from operator configuration
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+	var entries []Entry
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("sanctions: %s: %w", path, err)
+		}
+		entries = append(entries, Entry{List: strings.TrimSpace(rec[0]), Name: strings.TrimSpace(rec[1])})
+	}
+	return NewListProvider(entries, minScore), nil
+}
+
+// Name implements Provider.
+func (p *ListProvider) Name() string { return "list" }
+
+// Len is the number of entries screened against.
+func (p *ListProvider) Len() int { return len(p.entries) }
+
+// Screen implements Provider. An entry's score is the average, over its name's words, of how
+// closely the best word of name spells it, so word order, extra middle names and small typos
+// still match.
+func (p *ListProvider) Screen(_ context.Context, name string) ([]Match, error) {
+	tokens := tokenize(name)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	var matches []Match
+	for _, e := range p.entries {
+		var total float64
+		for _, want := range e.tokens {
+			best := 0.0
+			for _, got := range tokens {
+				best = max(best, similarity(want, got))
+			}
+			total += best
+		}
+		if score := total / float64(len(e.tokens)); score >= p.minScore {
+			matches = append(matches, Match{List: e.List, Name: e.Name, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches, nil
+}
+
+// similarity is 1 less the edit distance between a and b over the longer length.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,57 @@
+package sanctions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	// Case, punctuation and word order do not matter.
+	assert.Equal(t, "john smith", Normalize("SMITH, John"))
+	assert.Equal(t, Normalize("Jean-Luc  Picard"), Normalize("picard jean luc"))
+	assert.Equal(t, "", Normalize(" - "))
+}
+
+func TestListProvider_Screen(t *testing.T) {
+	// Reordered names, extra middle names and small typos match; different names do not.
+	p := NewListProvider([]Entry{
+		{List: "OFAC SDN", Name: "Ivan Petrovich Sidorov"},
+		{List: "UN", Name: "Acme Shell Holdings"},
+	}, 0)
+
+	for _, name := range []string{"Sidorov Ivan Petrovich", "Ivan Petrovich Sidorov Jr", "Ivan Petrovitch Sidorov"} {
+		matches, err := p.Screen(context.Background(), name)
+		require.NoError(t, err)
+		require.Len(t, matches, 1, name)
+		assert.Equal(t, "OFAC SDN", matches[0].List)
+		assert.GreaterOrEqual(t, matches[0].Score, DefaultMinScore)
+	}
+
+	for _, name := range []string{"Ivan Smith", "Acme Holdings", ""} {
+		matches, err := p.Screen(context.Background(), name)
+		require.NoError(t, err)
+		assert.Empty(t, matches, name)
+	}
+}
+
+func TestLoadList(t *testing.T) {
+	// Comments are skipped and rows need exactly a list and a name.
+	path := filepath.Join(t.TempDir(), "list.csv")
+	require.NoError(t, os.WriteFile(path, []byte("# consolidated list\nOFAC SDN,Ivan Sidorov\nUN, \"Doe, Jane\"\n"), 0o600))
+	p, err := LoadList(path, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.Len())
+	matches, err := p.Screen(context.Background(), "Jane Doe")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "UN", matches[0].List)
+
+	require.NoError(t, os.WriteFile(path, []byte("OFAC SDN\n"), 0o600))
+	_, err = LoadList(path, 0)
+	assert.Error(t, err)
+}
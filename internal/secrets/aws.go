@@ -0,0 +1,43 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// AWSSecretsManager reads secrets from AWS Secrets Manager.
+type AWSSecretsManager struct {
+	client *secretsmanager.Client
+}
+
+// NewAWSSecretsManager loads AWS credentials from the default chain and constructs an
+// AWSSecretsManager for region.
+func NewAWSSecretsManager(ctx context.Context, region string) (*AWSSecretsManager, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("load AWS config: %w", err)
+	}
+	return &AWSSecretsManager{client: secretsmanager.NewFromConfig(cfg)}, nil
+}
+
+// GetSecret returns the current version (AWSCURRENT) of the secret with name or ARN name.
+func (a *AWSSecretsManager) GetSecret(ctx context.Context, name string) (string, error) {
+	out, err := a.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(name)})
+	if err != nil {
+		var notFound *smtypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return "", err
+	}
+	if out.SecretString != nil {
+		return *out.SecretString, nil
+	}
+	return string(out.SecretBinary), nil
+}
@@ -0,0 +1,141 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// metadataTokenURL hands out access tokens for the service account of the GCE, GKE or Cloud
+// Run instance the server runs on.
+const metadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// GCPSecretManager reads secrets from Google Cloud Secret Manager over its REST API.
+type GCPSecretManager struct {
+	httpClient *http.Client
+	project    string
+	baseURL    string
+	tokenURL   string
+	// staticToken, when set, is used instead of asking the metadata server.
+	staticToken string
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewGCPSecretManager constructs a GCPSecretManager for project. Access tokens come from the
+// instance metadata server unless accessToken is given.
+func NewGCPSecretManager(project, accessToken string) (*GCPSecretManager, error) {
+	if project == "" {
+		return nil, errors.New("GCP project is required")
+	}
+	return &GCPSecretManager{
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		project:     project,
+		baseURL:     "https://secretmanager.googleapis.com",
+		tokenURL:    metadataTokenURL,
+		staticToken: accessToken,
+	}, nil
+}
+
+// GetSecret returns the latest version of secret name, or the version named as name/versions/N.
+func (g *GCPSecretManager) GetSecret(ctx context.Context, name string) (string, error) {
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+	token, err := g.accessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	url := fmt.Sprintf("%s/v1/projects/%s/secrets/%s:access", g.baseURL, g.project, name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var body struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	status, err := g.getJSON(req, &body)
+	if err != nil {
+		return "", err
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	default:
+		return "", fmt.Errorf("secret manager returned %d", status)
+	}
+	data, err := base64.StdEncoding.DecodeString(body.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("secret manager returned an undecodable payload: %w", err)
+	}
+	return string(data), nil
+}
+
+// accessToken returns the static token or a metadata server token, reused until shortly
+// before it expires.
+func (g *GCPSecretManager) accessToken(ctx context.Context) (string, error) {
+	if g.staticToken != "" {
+		return g.staticToken, nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.token != "" && time.Now().Before(g.tokenExpiry) {
+		return g.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.tokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	status, err := g.getJSON(req, &body)
+	if err != nil {
+		return "", fmt.Errorf("metadata token: %w", err)
+	}
+	if status != http.StatusOK || body.AccessToken == "" {
+		return "", fmt.Errorf("metadata server returned %d", status)
+	}
+	g.token = body.AccessToken
+	g.tokenExpiry = time.Now().Add(time.Duration(body.ExpiresIn)*time.Second - time.Minute)
+	return g.token, nil
+}
+
+// getJSON sends req and decodes a successful JSON response into dst, returning the status.
+func (g *GCPSecretManager) getJSON(req *http.Request, dst any) (int, error) {
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Warn().Err(closeErr).Msg("Failed to close secret manager response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(dst); err != nil {
+		return resp.StatusCode, fmt.Errorf("unreadable body: %w", err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,108 @@
+// Package secrets loads credentials such as the JWT secret and database URL from a secret
+// store (HashiCorp Vault, AWS Secrets Manager or GCP Secret Manager) instead of the process
+// environment, caching each value so rotated secrets are picked up without hammering the store.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrNotFound is returned when a secret, or a field within it, does not exist.
+var ErrNotFound = errors.New("secret not found")
+
+// Provider fetches the current value of a named secret from a secret store.
+type Provider interface {
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// DefaultTTL is how long a loaded value is served before the store is asked again.
+const DefaultTTL = 5 * time.Minute
+
+// cached is a loaded value and when it was fetched.
+type cached struct {
+	value     string
+	fetchedAt time.Time
+}
+
+// Loader resolves secret references through a Provider and caches the results.
+type Loader struct {
+	provider Provider
+	ttl      time.Duration
+	now      func() time.Time
+	mu       sync.Mutex
+	cache    map[string]cached
+}
+
+// NewLoader constructs a Loader serving values from provider for ttl (DefaultTTL when not positive).
+func NewLoader(provider Provider, ttl time.Duration) *Loader {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Loader{provider: provider, ttl: ttl, now: time.Now, cache: make(map[string]cached)}
+}
+
+// Get resolves ref, a secret name optionally followed by #field to pick one field of a secret
+// that holds a JSON object, e.g. prod/ledger#jwt_secret. A cached value is served until its TTL
+// ends; if refreshing it then fails, the stale value is served and the failure logged, so a
+// store outage does not take down a running server.
+func (l *Loader) Get(ctx context.Context, ref string) (string, error) {
+	l.mu.Lock()
+	entry, ok := l.cache[ref]
+	l.mu.Unlock()
+	if ok && l.now().Sub(entry.fetchedAt) < l.ttl {
+		return entry.value, nil
+	}
+
+	value, err := l.fetch(ctx, ref)
+	if err != nil {
+		if ok {
+			log.Warn().Err(err).Str("secret", ref).Msg("Secret refresh failed; serving cached value")
+			return entry.value, nil
+		}
+		return "", err
+	}
+	l.mu.Lock()
+	l.cache[ref] = cached{value: value, fetchedAt: l.now()}
+	l.mu.Unlock()
+	return value, nil
+}
+
+func (l *Loader) fetch(ctx context.Context, ref string) (string, error) {
+	name, field, hasField := strings.Cut(ref, "#")
+	if name == "" {
+		return "", fmt.Errorf("secret reference %q has no name", ref)
+	}
+	raw, err := l.provider.GetSecret(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("load secret %s: %w", name, err)
+	}
+	if !hasField {
+		return raw, nil
+	}
+	return jsonField(raw, name, field)
+}
+
+// jsonField picks field out of raw, a JSON object of strings.
+func jsonField(raw, name, field string) (string, error) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object, so it has no field %s", name, field)
+	}
+	v, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("%w: %s#%s", ErrNotFound, name, field)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("field %s of secret %s is not a string", field, name)
+	}
+	return s, nil
+}
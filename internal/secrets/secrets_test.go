@@ -0,0 +1,155 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider serves values from a map and counts fetches.
+type fakeProvider struct {
+	values map[string]string
+	err    error
+	calls  int
+}
+
+func (f *fakeProvider) GetSecret(_ context.Context, name string) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	v, ok := f.values[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+func TestLoader_CachesUntilTTL(t *testing.T) {
+	// A value is fetched once per TTL, so a rotated secret shows up after the TTL ends.
+	provider := &fakeProvider{values: map[string]string{"jwt": "first"}}
+	loader := NewLoader(provider, time.Minute)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	loader.now = func() time.Time { return now }
+
+	v, err := loader.Get(context.Background(), "jwt")
+	require.NoError(t, err)
+	assert.Equal(t, "first", v)
+
+	provider.values["jwt"] = "second"
+	v, err = loader.Get(context.Background(), "jwt")
+	require.NoError(t, err)
+	assert.Equal(t, "first", v)
+	assert.Equal(t, 1, provider.calls)
+
+	now = now.Add(time.Minute)
+	v, err = loader.Get(context.Background(), "jwt")
+	require.NoError(t, err)
+	assert.Equal(t, "second", v)
+}
+
+func TestLoader_ServesStaleOnRefreshFailure(t *testing.T) {
+	// A store outage after the first load keeps serving the cached value; before it, it fails.
+	provider := &fakeProvider{values: map[string]string{"db": "postgres://a"}}
+	loader := NewLoader(provider, time.Minute)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	loader.now = func() time.Time { return now }
+
+	_, err := loader.Get(context.Background(), "db")
+	require.NoError(t, err)
+	provider.err = errors.New("store down")
+	now = now.Add(time.Hour)
+	v, err := loader.Get(context.Background(), "db")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://a", v)
+
+	_, err = loader.Get(context.Background(), "other")
+	assert.Error(t, err)
+}
+
+func TestLoader_JSONField(t *testing.T) {
+	// name#field picks one string field of a JSON secret.
+	provider := &fakeProvider{values: map[string]string{
+		"prod/ledger": `{"jwt_secret":"s3cret","port":5432}`,
+		"plain":       "not json",
+	}}
+	loader := NewLoader(provider, 0)
+
+	v, err := loader.Get(context.Background(), "prod/ledger#jwt_secret")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", v)
+
+	_, err = loader.Get(context.Background(), "prod/ledger#missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = loader.Get(context.Background(), "prod/ledger#port")
+	assert.Error(t, err)
+	_, err = loader.Get(context.Background(), "plain#field")
+	assert.Error(t, err)
+	_, err = loader.Get(context.Background(), "#field")
+	assert.Error(t, err)
+}
+
+func TestVault_GetSecret(t *testing.T) {
+	// KV v2 secrets are read with the token and returned as their JSON fields.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "tok" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/kv/data/prod/ledger" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"jwt_secret":"s3cret"},"metadata":{"version":3}}}`))
+	}))
+	defer srv.Close()
+
+	v, err := NewVault(srv.URL, "tok", "kv")
+	require.NoError(t, err)
+	loader := NewLoader(v, 0)
+	got, err := loader.Get(context.Background(), "prod/ledger#jwt_secret")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", got)
+
+	_, err = v.GetSecret(context.Background(), "prod/other")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = NewVault("", "tok", "")
+	assert.Error(t, err)
+}
+
+func TestGCPSecretManager_GetSecret(t *testing.T) {
+	// The latest version is read with a metadata server token, reused across calls.
+	var tokenCalls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			tokenCalls++
+			assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+			_, _ = w.Write([]byte(`{"access_token":"ya29","expires_in":3600}`))
+		case "/v1/projects/bank/secrets/jwt/versions/latest:access":
+			assert.Equal(t, "Bearer ya29", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"payload":{"data":"czNjcmV0"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	g, err := NewGCPSecretManager("bank", "")
+	require.NoError(t, err)
+	g.baseURL, g.tokenURL = srv.URL, srv.URL+"/token"
+
+	got, err := g.GetSecret(context.Background(), "jwt")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", got)
+	_, err = g.GetSecret(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 1, tokenCalls)
+}
@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Vault reads secrets from a HashiCorp Vault KV version 2 engine.
+type Vault struct {
+	httpClient *http.Client
+	addr       string
+	token      string
+	mount      string
+}
+
+// NewVault constructs a Vault provider for the server at addr (e.g. https://vault:8200),
+// authenticating with token, reading the KV v2 engine mounted at mount (secret when empty).
+func NewVault(addr, token, mount string) (*Vault, error) {
+	if addr == "" || token == "" {
+		return nil, errors.New("vault address and token are required")
+	}
+	if mount == "" {
+		mount = "secret"
+	}
+	return &Vault{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		addr:       strings.TrimSuffix(addr, "/"),
+		token:      token,
+		mount:      strings.Trim(mount, "/"),
+	}, nil
+}
+
+// GetSecret returns the latest version of the secret at path name as a JSON object of its
+// fields; pick one with a #field reference.
+func (v *Vault) GetSecret(ctx context.Context, name string) (string, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s", v.addr, v.mount, strings.Trim(name, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Warn().Err(closeErr).Msg("Failed to close vault response body")
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned %d", resp.StatusCode)
+	}
+	var body struct {
+		Data struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return "", fmt.Errorf("vault returned an unreadable body: %w", err)
+	}
+	if len(body.Data.Data) == 0 || string(body.Data.Data) == "null" {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return string(body.Data.Data), nil
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrInvalidAdjustment is returned for an adjustment journal that cannot be posted as given.
+	ErrInvalidAdjustment = errors.New("invalid adjustment")
+	// ErrAdjustmentNotFound is returned when an adjustment does not exist.
+	ErrAdjustmentNotFound = errors.New("adjustment not found")
+	// ErrAdjustmentNotPending is returned when deciding an adjustment that was already posted or rejected.
+	ErrAdjustmentNotPending = errors.New("adjustment is not pending")
+	// ErrAdjustmentSelfApproval is returned when the admin who requested an adjustment tries to decide it.
+	ErrAdjustmentSelfApproval = errors.New("an adjustment must be decided by an admin other than its requester")
+	// ErrAdjustmentIsRepair is returned when deciding a balance repair's adjustment as a plain one.
+	ErrAdjustmentIsRepair = errors.New("adjustment belongs to a balance repair; decide the repair instead")
+)
+
+// Adjustment statuses stored on the adjustments table.
+const (
+	AdjustmentPending  = "pending"
+	AdjustmentPosted   = "posted"
+	AdjustmentRejected = "rejected"
+)
+
+// AdjustmentReasonCodes are the reasons an adjustment may be posted for.
+var AdjustmentReasonCodes = []string{"error_correction", "reconciliation", "fee_waiver", "write_off", "goodwill", "other"}
+
+// maxAdjustmentLines bounds one journal.
+const maxAdjustmentLines = 50
+
+// WithAdjustmentApproval holds admin adjustments as pending until a second admin approves them.
+func WithAdjustmentApproval(required bool) Option {
+	return func(s *LedgerService) {
+		s.adjustmentApproval = required
+	}
+}
+
+// AdjustmentLine is one side of an adjustment journal. Exactly one of Debit or Credit is set.
+type AdjustmentLine struct {
+	AccountID   uuid.UUID
+	Debit       string
+	Credit      string
+	Description string
+}
+
+// AdjustmentInput is a corrective journal requested by an admin.
+type AdjustmentInput struct {
+	ReasonCode  string
+	Memo        string
+	Lines       []AdjustmentLine
+	RequestedBy uuid.UUID
+}
+
+// Adjustment is an adjustment together with its journal lines.
+type Adjustment struct {
+	sqlc.Adjustment
+	Lines []sqlc.AdjustmentLine
+}
+
+// adjustmentLineAmounts parses a line's debit and credit, exactly one of which must be positive.
+func adjustmentLineAmounts(i int, l AdjustmentLine) (decimal.Decimal, decimal.Decimal, error) {
+	amount := func(s string) (decimal.Decimal, error) {
+		if s == "" {
+			return decimal.Zero, nil
+		}
+		d, err := decimal.NewFromString(s)
+		if err != nil || d.IsNegative() || d.Exponent() < -4 {
+			return decimal.Zero, fmt.Errorf("%w: line %d amounts must be non-negative with at most 4 decimal places", ErrInvalidAdjustment, i+1)
+		}
+		return d, nil
+	}
+	debit, err := amount(l.Debit)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	credit, err := amount(l.Credit)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	if debit.IsPositive() == credit.IsPositive() {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("%w: line %d needs exactly one of debit or credit", ErrInvalidAdjustment, i+1)
+	}
+	return debit, credit, nil
+}
+
+// validateAdjustment checks everything about a journal that does not need the accounts.
+func validateAdjustment(in AdjustmentInput) error {
+	if !slices.Contains(AdjustmentReasonCodes, in.ReasonCode) {
+		return fmt.Errorf("%w: reason_code must be one of %v", ErrInvalidAdjustment, AdjustmentReasonCodes)
+	}
+	if len(in.Lines) < 2 || len(in.Lines) > maxAdjustmentLines {
+		return fmt.Errorf("%w: a journal needs between 2 and %d lines", ErrInvalidAdjustment, maxAdjustmentLines)
+	}
+	for i, l := range in.Lines {
+		if _, _, err := adjustmentLineAmounts(i, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateAdjustment records an admin's corrective journal. Under dual control it stays pending
+// until ApproveAdjustment runs for a different admin; otherwise it is posted at once.
+func (s *LedgerService) CreateAdjustment(ctx context.Context, in AdjustmentInput) (Adjustment, error) {
+	if err := validateAdjustment(in); err != nil {
+		return Adjustment{}, err
+	}
+
+	var (
+		adj Adjustment
+		evt events.Event
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Record the request and its lines as the audit trail.
+		var err error
+		if adj, err = recordAdjustment(ctx, q, in); err != nil {
+			return err
+		}
+
+		// Step 2: Check the journal posts even when it must wait, so a checker never approves one
+		// that cannot; without dual control, post it now.
+		if s.adjustmentApproval {
+			_, err = adjustmentLegs(ctx, q, adj, uuid.Nil)
+			return err
+		}
+		adj.Adjustment, evt, err = postAdjustment(ctx, q, adj, uuid.Nil, "", uuid.Nil)
+		return err
+	})
+	if err != nil {
+		return Adjustment{}, err
+	}
+
+	logger(ctx).Info().Str("adjustment_id", adj.ID.String()).Str("reason_code", adj.ReasonCode).Str("requested_by", in.RequestedBy.String()).Str("status", adj.Status).Msg("Adjustment requested")
+	if evt.TransactionID != uuid.Nil {
+		s.publish(ctx, evt)
+	}
+	return adj, nil
+}
+
+// recordAdjustment stores a validated journal as a pending adjustment with its lines.
+func recordAdjustment(ctx context.Context, q *sqlc.Queries, in AdjustmentInput) (Adjustment, error) {
+	var adj Adjustment
+	var err error
+	adj.Adjustment, err = q.CreateAdjustment(ctx, sqlc.CreateAdjustmentParams{
+		ReasonCode:  in.ReasonCode,
+		Memo:        in.Memo,
+		RequestedBy: in.RequestedBy,
+	})
+	if err != nil {
+		return Adjustment{}, err
+	}
+	for i, l := range in.Lines {
+		debit, credit, _ := adjustmentLineAmounts(i, l)
+		line, err := q.CreateAdjustmentLine(ctx, sqlc.CreateAdjustmentLineParams{
+			AdjustmentID: adj.ID,
+			LineNo:       int32(i + 1), // #nosec G115 -- at most maxAdjustmentLines
+			AccountID:    l.AccountID,
+			Debit:        debit.StringFixed(4),
+			Credit:       credit.StringFixed(4),
+			Description:  l.Description,
+		})
+		if err != nil {
+			return Adjustment{}, err
+		}
+		adj.Lines = append(adj.Lines, line)
+	}
+	return adj, nil
+}
+
+// ApproveAdjustment posts a pending adjustment and records the approver in the same
+// transaction, so an adjustment is never approved without its journal or posted twice.
+func (s *LedgerService) ApproveAdjustment(ctx context.Context, id, approverID uuid.UUID, note string) (Adjustment, error) {
+	var (
+		adj Adjustment
+		evt events.Event
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		if adj, err = lockPendingAdjustment(ctx, q, id, approverID); err != nil {
+			return err
+		}
+		if err := refuseRepairAdjustment(ctx, q, id); err != nil {
+			return err
+		}
+		adj.Adjustment, evt, err = postAdjustment(ctx, q, adj, approverID, note, uuid.Nil)
+		return err
+	})
+	if err != nil {
+		return Adjustment{}, err
+	}
+
+	logger(ctx).Info().Str("adjustment_id", adj.ID.String()).Str("requested_by", adj.RequestedBy.String()).Str("approved_by", approverID.String()).Str("tx_id", evt.TransactionID.String()).Msg("Adjustment approved")
+	s.publish(ctx, evt)
+	return adj, nil
+}
+
+// RejectAdjustment closes a pending adjustment without posting anything.
+func (s *LedgerService) RejectAdjustment(ctx context.Context, id, approverID uuid.UUID, note string) (Adjustment, error) {
+	var adj Adjustment
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		if adj, err = lockPendingAdjustment(ctx, q, id, approverID); err != nil {
+			return err
+		}
+		if err := refuseRepairAdjustment(ctx, q, id); err != nil {
+			return err
+		}
+		adj.Adjustment, err = q.DecideAdjustment(ctx, sqlc.DecideAdjustmentParams{
+			Status:       AdjustmentRejected,
+			DecidedBy:    uuid.NullUUID{UUID: approverID, Valid: true},
+			DecisionNote: note,
+			ID:           adj.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return Adjustment{}, err
+	}
+
+	logger(ctx).Info().Str("adjustment_id", adj.ID.String()).Str("requested_by", adj.RequestedBy.String()).Str("rejected_by", approverID.String()).Msg("Adjustment rejected")
+	return adj, nil
+}
+
+// lockPendingAdjustment locks an adjustment approverID may still decide, with its lines.
+func lockPendingAdjustment(ctx context.Context, q *sqlc.Queries, id, approverID uuid.UUID) (Adjustment, error) {
+	row, err := q.GetAdjustmentForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Adjustment{}, ErrAdjustmentNotFound
+		}
+		return Adjustment{}, err
+	}
+	if row.Status != AdjustmentPending {
+		return Adjustment{}, ErrAdjustmentNotPending
+	}
+	if row.RequestedBy == approverID {
+		return Adjustment{}, ErrAdjustmentSelfApproval
+	}
+	lines, err := q.ListAdjustmentLines(ctx, id)
+	if err != nil {
+		return Adjustment{}, err
+	}
+	return Adjustment{Adjustment: row, Lines: lines}, nil
+}
+
+// refuseRepairAdjustment returns ErrAdjustmentIsRepair if adjustment id was proposed by a balance
+// repair, which must be decided through the repair so its audit log is kept.
+func refuseRepairAdjustment(ctx context.Context, q *sqlc.Queries, id uuid.UUID) error {
+	n, err := q.CountBalanceRepairsForAdjustment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrAdjustmentIsRepair
+	}
+	return nil
+}
+
+// adjustmentLegs locks the journal's accounts and turns its lines into balanced legs. Lines on
+// booked, unless uuid.Nil, become booked legs: their entries are written without moving its balance.
+func adjustmentLegs(ctx context.Context, q *sqlc.Queries, adj Adjustment, booked uuid.UUID) ([]leg, error) {
+	ids := make([]uuid.UUID, 0, len(adj.Lines))
+	for _, l := range adj.Lines {
+		ids = append(ids, l.AccountID)
+	}
+	accounts, err := lockAccounts(ctx, q, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	legs := make([]leg, 0, len(adj.Lines))
+	debits := make(map[string]decimal.Decimal)
+	credits := make(map[string]decimal.Decimal)
+	for _, l := range adj.Lines {
+		acc := accounts[l.AccountID]
+		debit, err := decimal.NewFromString(l.Debit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid debit on adjustment line %d: %w", l.LineNo, err)
+		}
+		credit, err := decimal.NewFromString(l.Credit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credit on adjustment line %d: %w", l.LineNo, err)
+		}
+		description := l.Description
+		if description == "" {
+			description = fmt.Sprintf("Adjustment (%s)", adj.ReasonCode)
+		}
+		legs = append(legs, leg{account: acc, debit: debit, credit: credit, description: description, booked: booked != uuid.Nil && acc.ID == booked})
+		debits[acc.Currency] = debits[acc.Currency].Add(debit)
+		credits[acc.Currency] = credits[acc.Currency].Add(credit)
+	}
+	for ccy, debit := range debits {
+		if !debit.Equal(credits[ccy]) {
+			return nil, fmt.Errorf("%w: %s debits %s and credits %s do not balance", ErrInvalidAdjustment, ccy, debit.StringFixed(4), credits[ccy].StringFixed(4))
+		}
+	}
+	if err := checkCustomerBalances(legs); err != nil {
+		return nil, err
+	}
+	return legs, nil
+}
+
+// postAdjustment posts a pending adjustment's journal and marks it posted by approverID, or
+// without an approver when dual control is off. booked is as for adjustmentLegs.
+func postAdjustment(ctx context.Context, q *sqlc.Queries, adj Adjustment, approverID uuid.UUID, note string, booked uuid.UUID) (sqlc.Adjustment, events.Event, error) {
+	legs, err := adjustmentLegs(ctx, q, adj, booked)
+	if err != nil {
+		return sqlc.Adjustment{}, events.Event{}, err
+	}
+	txID := uuid.New()
+	entries, balances, err := postLegs(ctx, q, txID, "adjustment", legs...)
+	if err != nil {
+		return sqlc.Adjustment{}, events.Event{}, err
+	}
+	row, err := q.DecideAdjustment(ctx, sqlc.DecideAdjustmentParams{
+		Status:        AdjustmentPosted,
+		DecidedBy:     uuid.NullUUID{UUID: approverID, Valid: approverID != uuid.Nil},
+		DecisionNote:  note,
+		TransactionID: uuid.NullUUID{UUID: txID, Valid: true},
+		ID:            adj.ID,
+	})
+	if err != nil {
+		return sqlc.Adjustment{}, events.Event{}, err
+	}
+
+	var currency string
+	amount := decimal.Zero
+	for _, l := range legs {
+		amount, currency = amount.Add(l.debit), l.account.Currency
+	}
+	return row, events.Event{
+		Type:          events.TypeAdjustment,
+		TransactionID: txID,
+		Amount:        amount.StringFixed(4),
+		Currency:      currency,
+		Entries:       entries,
+		Balances:      balances,
+	}, nil
+}
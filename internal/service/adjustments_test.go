@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAdjustment_ValidatesBeforePosting(t *testing.T) {
+	// Unknown reasons, short journals and lines with both or neither side are refused before any database work.
+	s := &LedgerService{}
+	a, b := uuid.New(), uuid.New()
+	valid := []AdjustmentLine{{AccountID: a, Debit: "10"}, {AccountID: b, Credit: "10"}}
+
+	for _, in := range []AdjustmentInput{
+		{ReasonCode: "because", Lines: valid},
+		{ReasonCode: "write_off", Lines: valid[:1]},
+		{ReasonCode: "write_off", Lines: []AdjustmentLine{{AccountID: a, Debit: "10", Credit: "10"}, {AccountID: b, Credit: "10"}}},
+		{ReasonCode: "write_off", Lines: []AdjustmentLine{{AccountID: a}, {AccountID: b, Credit: "10"}}},
+		{ReasonCode: "write_off", Lines: []AdjustmentLine{{AccountID: a, Debit: "-10"}, {AccountID: b, Credit: "10"}}},
+		{ReasonCode: "write_off", Lines: []AdjustmentLine{{AccountID: a, Debit: "0.00001"}, {AccountID: b, Credit: "0.00001"}}},
+	} {
+		_, err := s.CreateAdjustment(context.Background(), in)
+		assert.ErrorIs(t, err, ErrInvalidAdjustment)
+	}
+	assert.NoError(t, validateAdjustment(AdjustmentInput{ReasonCode: "error_correction", Lines: valid}))
+}
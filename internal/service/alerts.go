@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/alert"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+const (
+	// maxDriftAlerts caps the alerts one projection check raises, so a ledger-wide fault pages
+	// once per account for the first few accounts rather than once for every account.
+	maxDriftAlerts = 20
+	// alertTimeout bounds delivering one check's alerts to every channel.
+	alertTimeout = 30 * time.Second
+)
+
+// WithIntegrityAlerts sends an alert through sender for every balance mismatch ReconcileAccount
+// or CheckLedgerProjections finds; without it they are only logged.
+func WithIntegrityAlerts(sender alert.Sender) Option {
+	return func(s *LedgerService) {
+		s.alerts = sender
+	}
+}
+
+// balanceAlert describes an account whose stored balance differs from expected.
+func balanceAlert(kind string, accountID uuid.UUID, currency string, stored, expected decimal.Decimal) alert.Alert {
+	return alert.Alert{
+		Kind:       kind,
+		AccountID:  accountID,
+		Currency:   currency,
+		Stored:     stored.StringFixed(4),
+		Expected:   expected.StringFixed(4),
+		Delta:      expected.Sub(stored).StringFixed(4),
+		DetectedAt: time.Now().UTC(),
+	}
+}
+
+// driftAlerts describes each drifting account, up to maxDriftAlerts, against the balance
+// replayed from the event log.
+func driftAlerts(drift []sqlc.ListLedgerProjectionDriftRow) []alert.Alert {
+	alerts := make([]alert.Alert, 0, min(len(drift), maxDriftAlerts))
+	for _, d := range drift[:min(len(drift), maxDriftAlerts)] {
+		stored, _ := decimal.NewFromString(d.StoredBalance)
+		replayed, _ := decimal.NewFromString(d.ReplayedBalance)
+		alerts = append(alerts, balanceAlert(alert.KindProjectionDrift, d.AccountID, d.Currency, stored, replayed))
+	}
+	return alerts
+}
+
+// raiseAlerts delivers alerts in the background, so a slow channel never holds up the check that
+// found them. Delivery failures are logged.
+func (s *LedgerService) raiseAlerts(ctx context.Context, alerts ...alert.Alert) {
+	if s.alerts == nil || len(alerts) == 0 {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, alertTimeout)
+		defer cancel()
+		for _, a := range alerts {
+			if err := s.alerts.Send(ctx, a); err != nil {
+				logger(ctx).Error().Err(err).
+					Str("kind", a.Kind).
+					Str("account_id", a.AccountID.String()).
+					Msg("Failed to deliver integrity alert")
+			}
+		}
+	}()
+}
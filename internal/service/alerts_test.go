@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/alert"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestDriftAlerts(t *testing.T) {
+	drift := make([]sqlc.ListLedgerProjectionDriftRow, maxDriftAlerts+5)
+	for i := range drift {
+		drift[i] = sqlc.ListLedgerProjectionDriftRow{AccountID: uuid.New(), Currency: "USD", StoredBalance: "100.0000", ReplayedBalance: "75.5000"}
+	}
+
+	alerts := driftAlerts(drift)
+	assert.Len(t, alerts, maxDriftAlerts)
+	assert.Equal(t, alert.KindProjectionDrift, alerts[0].Kind)
+	assert.Equal(t, drift[0].AccountID, alerts[0].AccountID)
+	assert.Equal(t, "75.5000", alerts[0].Expected)
+	assert.Equal(t, "-24.5000", alerts[0].Delta)
+}
+
+func TestMismatchAlerts(t *testing.T) {
+	results := []sqlc.BalanceReconciliationResult{
+		{AccountID: uuid.New(), Currency: "NGN", StoredBalance: "10.0000", CalculatedBalance: "12.2500"},
+	}
+
+	alerts := mismatchAlerts(results)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, alert.KindBalanceMismatch, alerts[0].Kind)
+	assert.Equal(t, "NGN", alerts[0].Currency)
+	assert.Equal(t, "2.2500", alerts[0].Delta)
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrHeldForReview is returned when AML screening held an operation until compliance staff release it.
+	// The message is deliberately vague so customers are not tipped off.
+	ErrHeldForReview = errors.New("transaction is pending review")
+	// ErrScreeningBlocked is returned when AML screening refused an operation.
+	ErrScreeningBlocked = errors.New("transaction declined")
+	// ErrAMLAlertNotFound is returned when an alert does not exist in the reviewer's organization.
+	ErrAMLAlertNotFound = errors.New("AML alert not found")
+	// ErrAMLAlertNotOpen is returned when reviewing an alert that was already reviewed.
+	ErrAMLAlertNotOpen = errors.New("AML alert was already reviewed")
+	// ErrAMLDecisionNotAllowed is returned for a decision that does not fit the alert's action.
+	ErrAMLDecisionNotAllowed = errors.New("release and reject apply to held operations; clear and report to flagged or blocked ones")
+)
+
+// AML alert statuses stored on the aml_alerts table. Open alerts are the review queue.
+const (
+	AMLAlertOpen     = "open"
+	AMLAlertReleased = "released"
+	AMLAlertRejected = "rejected"
+	AMLAlertCleared  = "cleared"
+	AMLAlertReported = "reported"
+)
+
+// WithAMLRules screens every money operation on customer accounts with engine before it posts.
+func WithAMLRules(engine *aml.Engine) Option {
+	return func(s *LedgerService) {
+		s.aml = engine
+	}
+}
+
+// screening is an operation that passed AML screening, possibly flagged.
+type screening struct {
+	op           aml.Operation
+	account      sqlc.Account
+	counterparty uuid.NullUUID
+	verdict      aml.Verdict
+}
+
+// screen runs the AML rules on a movement of amount into or out of accountID before it is posted.
+// A held operation is recorded for review and refused with ErrHeldForReview; a blocked one, or a
+// held one that cannot wait for review (holdable false), is recorded and refused with
+// ErrScreeningBlocked. Otherwise the caller posts the operation and passes the result to recordFlag.
+func (s *LedgerService) screen(ctx context.Context, kind string, accountID uuid.UUID, counterparty uuid.NullUUID, dir aml.Direction, amount decimal.Decimal, holdable bool) (screening, error) {
+	sc, err := s.evaluate(ctx, kind, accountID, counterparty, dir, amount)
+	if err != nil {
+		return screening{}, err
+	}
+	if sc.verdict.Action == aml.ActionHold && !holdable {
+		sc.verdict.Action = aml.ActionBlock
+	}
+	if sc.verdict.Action != aml.ActionHold && sc.verdict.Action != aml.ActionBlock {
+		return sc, nil
+	}
+
+	// Record held and blocked operations before refusing them.
+	alert, err := s.recordAlert(ctx, sc, uuid.NullUUID{})
+	if err != nil {
+		return screening{}, err
+	}
+	logger(ctx).Warn().Str("alert_id", alert.ID.String()).Str("account_id", accountID.String()).Str("operation", kind).
+		Str("action", alert.Action).Strs("rules", alert.Rules).Str("amount", alert.Amount).Msg("Operation stopped by AML screening")
+	if sc.verdict.Action == aml.ActionHold {
+		return screening{}, ErrHeldForReview
+	}
+	return screening{}, ErrScreeningBlocked
+}
+
+// evaluate runs the AML rules on a movement of amount into or out of accountID against its recent
+// history. Without rules, or for missing and system accounts, the operation passes.
+func (s *LedgerService) evaluate(ctx context.Context, kind string, accountID uuid.UUID, counterparty uuid.NullUUID, dir aml.Direction, amount decimal.Decimal) (screening, error) {
+	if s.aml == nil {
+		return screening{}, nil
+	}
+	// Step 1: Load the account and its recent history; missing accounts fail later as usual.
+	account, err := s.store.GetAccount(ctx, accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return screening{}, nil
+	}
+	if err != nil {
+		return screening{}, err
+	}
+	// System accounts are the bank's own and have no organization to review them.
+	if account.IsSystem || !account.OrgID.Valid {
+		return screening{}, nil
+	}
+	now := time.Now()
+	rows, err := s.store.ListAccountActivitySince(ctx, sqlc.ListAccountActivitySinceParams{
+		AccountID: accountID,
+		Since:     now.Add(-s.aml.Lookback()),
+	})
+	if err != nil {
+		return screening{}, err
+	}
+	history := make([]aml.Activity, 0, len(rows))
+	for _, row := range rows {
+		debit, err := decimal.NewFromString(row.Debit)
+		if err != nil {
+			return screening{}, fmt.Errorf("invalid entry debit: %w", err)
+		}
+		credit, err := decimal.NewFromString(row.Credit)
+		if err != nil {
+			return screening{}, fmt.Errorf("invalid entry credit: %w", err)
+		}
+		a := aml.Activity{Direction: aml.Inbound, Amount: credit, At: row.CreatedAt.Time}
+		if debit.IsPositive() {
+			a.Direction, a.Amount = aml.Outbound, debit
+		}
+		history = append(history, a)
+	}
+
+	// Step 2: Evaluate the operation against that history.
+	sc := screening{
+		op:           aml.Operation{Kind: kind, AccountID: accountID, Direction: dir, Amount: amount, Currency: account.Currency, At: now},
+		account:      account,
+		counterparty: counterparty,
+	}
+	sc.verdict = s.aml.Evaluate(sc.op, history)
+	return sc, nil
+}
+
+// screenReceived screens money that already reached the bank, such as a settled card charge or an
+// inbound bank transfer, before it is credited to accountID. The money cannot be turned away, so a
+// hold or block verdict is downgraded to a flag: the credit posts and the caller passes the result
+// to recordFlag for review. Nothing is written, so it is safe inside a retried transaction.
+func (s *LedgerService) screenReceived(ctx context.Context, kind string, accountID uuid.UUID, amount decimal.Decimal) (screening, error) {
+	sc, err := s.evaluate(ctx, kind, accountID, uuid.NullUUID{}, aml.Inbound, amount)
+	if err != nil {
+		return screening{}, err
+	}
+	if sc.verdict.Action == aml.ActionHold || sc.verdict.Action == aml.ActionBlock {
+		sc.verdict.Action = aml.ActionFlag
+	}
+	return sc, nil
+}
+
+// recordFlag queues a flagged operation for review once it posted as txID. Operations that passed
+// cleanly are ignored. The operation already committed, so failures are only logged.
+func (s *LedgerService) recordFlag(ctx context.Context, sc screening, txID uuid.UUID) {
+	if sc.verdict.Action != aml.ActionFlag {
+		return
+	}
+	alert, err := s.recordAlert(ctx, sc, uuid.NullUUID{UUID: txID, Valid: true})
+	if err != nil {
+		logger(ctx).Error().Err(err).Str("tx_id", txID.String()).Strs("rules", sc.verdict.Rules()).Msg("Failed to record AML alert")
+		return
+	}
+	logger(ctx).Warn().Str("alert_id", alert.ID.String()).Str("tx_id", txID.String()).Strs("rules", alert.Rules).Msg("Operation flagged by AML screening")
+}
+
+// recordAlert stores the verdict on sc for compliance review.
+func (s *LedgerService) recordAlert(ctx context.Context, sc screening, txID uuid.NullUUID) (sqlc.AmlAlert, error) {
+	return s.store.CreateAMLAlert(ctx, sqlc.CreateAMLAlertParams{
+		OrgID:          sc.account.OrgID.UUID,
+		AccountID:      sc.account.ID,
+		CounterpartyID: sc.counterparty,
+		Operation:      sc.op.Kind,
+		Direction:      string(sc.op.Direction),
+		Amount:         sc.op.Amount.StringFixed(4),
+		Currency:       sc.op.Currency,
+		Action:         string(sc.verdict.Action),
+		Rules:          sc.verdict.Rules(),
+		Reason:         sc.verdict.Reason(),
+		TransactionID:  txID,
+	})
+}
+
+// ReleaseAMLAlert posts a held operation and closes its alert in the same transaction, so an
+// operation is never released without posting or posted twice. The usual balance and product
+// checks apply again; screening does not.
+func (s *LedgerService) ReleaseAMLAlert(ctx context.Context, orgID, alertID, reviewerID uuid.UUID, note string) (sqlc.AmlAlert, error) {
+	var (
+		alert sqlc.AmlAlert
+		evt   events.Event
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the alert; only held operations can be released.
+		var err error
+		alert, err = lockOpenAMLAlert(ctx, q, orgID, alertID)
+		if err != nil {
+			return err
+		}
+		if alert.Action != string(aml.ActionHold) {
+			return ErrAMLDecisionNotAllowed
+		}
+		amount, err := decimal.NewFromString(alert.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid AML alert amount: %w", err)
+		}
+
+		// Step 2: Post the operation as it was requested and close the alert with it.
+		switch {
+		case alert.Operation == "deposit":
+			if err = checkDepositLimit(ctx, q, alert.AccountID, amount); err == nil {
+				evt, err = postDeposit(ctx, q, alert.AccountID, amount, "External deposit")
+			}
+		case alert.Operation == "withdrawal":
+			evt, err = s.postWithdrawal(ctx, q, alert.AccountID, amount)
+		case alert.Operation == "transfer" && alert.CounterpartyID.Valid:
+			evt, err = postTransfer(ctx, q, uuid.New(), alert.AccountID, alert.CounterpartyID.UUID, amount, "")
+		default:
+			err = fmt.Errorf("cannot release a held %s", alert.Operation)
+		}
+		if err != nil {
+			return err
+		}
+		alert, err = q.ReviewAMLAlert(ctx, sqlc.ReviewAMLAlertParams{
+			Status:        AMLAlertReleased,
+			ReviewedBy:    uuid.NullUUID{UUID: reviewerID, Valid: true},
+			ReviewNote:    note,
+			TransactionID: uuid.NullUUID{UUID: evt.TransactionID, Valid: true},
+			ID:            alert.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.AmlAlert{}, err
+	}
+
+	logger(ctx).Info().Str("alert_id", alert.ID.String()).Str("reviewed_by", reviewerID.String()).Str("tx_id", evt.TransactionID.String()).Msg("Held operation released")
+	s.publish(ctx, evt)
+	return alert, nil
+}
+
+// ReviewAMLAlert closes an open alert without posting anything: status AMLAlertRejected drops a
+// held operation, AMLAlertCleared or AMLAlertReported close a flagged or blocked one.
+func (s *LedgerService) ReviewAMLAlert(ctx context.Context, orgID, alertID, reviewerID uuid.UUID, status, note string) (sqlc.AmlAlert, error) {
+	var alert sqlc.AmlAlert
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		alert, err = lockOpenAMLAlert(ctx, q, orgID, alertID)
+		if err != nil {
+			return err
+		}
+		held := alert.Action == string(aml.ActionHold)
+		switch {
+		case status == AMLAlertRejected && held:
+		case (status == AMLAlertCleared || status == AMLAlertReported) && !held:
+		default:
+			return ErrAMLDecisionNotAllowed
+		}
+		alert, err = q.ReviewAMLAlert(ctx, sqlc.ReviewAMLAlertParams{
+			Status:     status,
+			ReviewedBy: uuid.NullUUID{UUID: reviewerID, Valid: true},
+			ReviewNote: note,
+			ID:         alert.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.AmlAlert{}, err
+	}
+
+	logger(ctx).Info().Str("alert_id", alert.ID.String()).Str("reviewed_by", reviewerID.String()).Str("status", status).Msg("AML alert reviewed")
+	return alert, nil
+}
+
+// lockOpenAMLAlert locks an alert of orgID that is still awaiting review.
+func lockOpenAMLAlert(ctx context.Context, q *sqlc.Queries, orgID, alertID uuid.UUID) (sqlc.AmlAlert, error) {
+	alert, err := q.GetAMLAlertForUpdate(ctx, sqlc.GetAMLAlertForUpdateParams{ID: alertID, OrgID: orgID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.AmlAlert{}, ErrAMLAlertNotFound
+		}
+		return sqlc.AmlAlert{}, err
+	}
+	if alert.Status != AMLAlertOpen {
+		return sqlc.AmlAlert{}, ErrAMLAlertNotOpen
+	}
+	return alert, nil
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+)
+
+func TestScreen_Disabled(t *testing.T) {
+	// Without rules every operation passes unflagged, received credits included.
+	s := &LedgerService{}
+	sc, err := s.screen(context.Background(), "split", uuid.New(), uuid.NullUUID{}, aml.Outbound, decimal.NewFromInt(1), false)
+	assert.NoError(t, err)
+	assert.Equal(t, aml.ActionAllow, sc.verdict.Action)
+
+	sc, err = s.screenReceived(context.Background(), "inbound_payment", uuid.New(), decimal.NewFromInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, aml.ActionAllow, sc.verdict.Action)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrTransferRequestNotFound is returned when a transfer request does not exist in the caller's organization.
+	ErrTransferRequestNotFound = errors.New("transfer request not found")
+	// ErrTransferRequestNotPending is returned when deciding a request that was already approved or rejected.
+	ErrTransferRequestNotPending = errors.New("transfer request is not pending")
+	// ErrSelfApproval is returned when the maker of a request tries to decide it.
+	ErrSelfApproval = errors.New("a transfer request must be decided by someone other than its requester")
+)
+
+// Transfer request statuses stored on the transfer_requests table.
+const (
+	TransferRequestPending  = "pending"
+	TransferRequestApproved = "approved"
+	TransferRequestRejected = "rejected"
+)
+
+// TransferRequestInput is a staff-initiated transfer awaiting a second user's approval.
+type TransferRequestInput struct {
+	OrgID       uuid.UUID
+	FromID      uuid.UUID
+	ToID        uuid.UUID
+	Amount      string
+	Reason      string
+	RequestedBy uuid.UUID
+}
+
+// CreateTransferRequest queues a transfer between two accounts of the maker's organization.
+// Nothing is posted until ApproveTransferRequest runs for a different user.
+func (s *LedgerService) CreateTransferRequest(ctx context.Context, in TransferRequestInput) (sqlc.TransferRequest, error) {
+	// Step 1: Validate the request the same way Transfer would, minus the balance check.
+	amount, err := validatePositiveAmount(in.Amount)
+	if err != nil {
+		return sqlc.TransferRequest{}, err
+	}
+	if in.FromID == in.ToID {
+		return sqlc.TransferRequest{}, ErrSameAccountTransfer
+	}
+	var currency string
+	for _, id := range []uuid.UUID{in.FromID, in.ToID} {
+		acc, err := s.store.GetAccount(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return sqlc.TransferRequest{}, ErrAccountNotFound
+			}
+			return sqlc.TransferRequest{}, err
+		}
+		// Accounts of other tenants (and platform system accounts) are reported as missing.
+		if !acc.OrgID.Valid || acc.OrgID.UUID != in.OrgID {
+			return sqlc.TransferRequest{}, ErrAccountNotFound
+		}
+		if currency != "" && acc.Currency != currency {
+			return sqlc.TransferRequest{}, ErrCurrencyMismatch
+		}
+		currency = acc.Currency
+	}
+
+	// Step 2: Queue it for a checker.
+	req, err := s.store.CreateTransferRequest(ctx, sqlc.CreateTransferRequestParams{
+		OrgID:         in.OrgID,
+		FromAccountID: in.FromID,
+		ToAccountID:   in.ToID,
+		Amount:        amount.StringFixed(4),
+		Currency:      currency,
+		Reason:        in.Reason,
+		RequestedBy:   in.RequestedBy,
+	})
+	if err != nil {
+		return sqlc.TransferRequest{}, err
+	}
+
+	logger(ctx).Info().Str("transfer_request_id", req.ID.String()).Str("requested_by", in.RequestedBy.String()).Str("amount", req.Amount).Msg("Transfer request created")
+	return req, nil
+}
+
+// ApproveTransferRequest posts a pending request and records the approver in the same
+// transaction, so a request is never approved without its transfer or posted twice.
+func (s *LedgerService) ApproveTransferRequest(ctx context.Context, orgID, requestID, approverID uuid.UUID, note string) (sqlc.TransferRequest, error) {
+	var (
+		req sqlc.TransferRequest
+		evt events.Event
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the request; the maker can never be the checker.
+		var err error
+		req, err = lockPendingTransferRequest(ctx, q, orgID, requestID, approverID)
+		if err != nil {
+			return err
+		}
+		amount, err := decimal.NewFromString(req.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid transfer request amount: %w", err)
+		}
+
+		// Step 2: Re-check the accounts under lock; they may have changed since the request.
+		fromAcc, toAcc, err := lockAccountPair(ctx, q, req.FromAccountID, req.ToAccountID)
+		if err != nil {
+			return err
+		}
+		if fromAcc.OrgID != toAcc.OrgID {
+			return ErrCrossOrgTransfer
+		}
+		if fromAcc.Currency != req.Currency || toAcc.Currency != req.Currency {
+			return ErrCurrencyMismatch
+		}
+		fee, err := checkSpendable(ctx, q, fromAcc, amount, debitTransfer)
+		if err != nil {
+			return err
+		}
+		if err := checkGoalLock(ctx, q, fromAcc); err != nil {
+			return err
+		}
+		fees, err := feeLegs(ctx, q, fromAcc, fee, "Transfer fee")
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Post and close the request together.
+		txID := uuid.New()
+		legs := []leg{
+			debitLeg(fromAcc, amount, fmt.Sprintf("Approved transfer to %s", toAcc.ID)),
+			creditLeg(toAcc, amount, fmt.Sprintf("Approved transfer from %s", fromAcc.ID)),
+		}
+		entries, balances, err := postLegs(ctx, q, txID, "transfer", append(legs, fees...)...)
+		if err != nil {
+			return err
+		}
+		req, err = q.DecideTransferRequest(ctx, sqlc.DecideTransferRequestParams{
+			Status:        TransferRequestApproved,
+			DecidedBy:     uuid.NullUUID{UUID: approverID, Valid: true},
+			DecisionNote:  note,
+			TransactionID: uuid.NullUUID{UUID: txID, Valid: true},
+			ID:            req.ID,
+		})
+		if err != nil {
+			return err
+		}
+		evt = events.Event{
+			Type:          events.TypeTransfer,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      req.Currency,
+			Entries:       entries,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.TransferRequest{}, err
+	}
+
+	logger(ctx).Info().Str("transfer_request_id", req.ID.String()).Str("requested_by", req.RequestedBy.String()).Str("approved_by", approverID.String()).Str("tx_id", evt.TransactionID.String()).Msg("Transfer request approved")
+	s.publish(ctx, evt)
+	return req, nil
+}
+
+// RejectTransferRequest closes a pending request without posting anything.
+func (s *LedgerService) RejectTransferRequest(ctx context.Context, orgID, requestID, approverID uuid.UUID, note string) (sqlc.TransferRequest, error) {
+	var req sqlc.TransferRequest
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		req, err = lockPendingTransferRequest(ctx, q, orgID, requestID, approverID)
+		if err != nil {
+			return err
+		}
+		req, err = q.DecideTransferRequest(ctx, sqlc.DecideTransferRequestParams{
+			Status:       TransferRequestRejected,
+			DecidedBy:    uuid.NullUUID{UUID: approverID, Valid: true},
+			DecisionNote: note,
+			ID:           req.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.TransferRequest{}, err
+	}
+
+	logger(ctx).Info().Str("transfer_request_id", req.ID.String()).Str("requested_by", req.RequestedBy.String()).Str("rejected_by", approverID.String()).Msg("Transfer request rejected")
+	return req, nil
+}
+
+// lockPendingTransferRequest locks a request of orgID that approverID may still decide.
+func lockPendingTransferRequest(ctx context.Context, q *sqlc.Queries, orgID, requestID, approverID uuid.UUID) (sqlc.TransferRequest, error) {
+	req, err := q.GetTransferRequestForUpdate(ctx, sqlc.GetTransferRequestForUpdateParams{ID: requestID, OrgID: orgID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.TransferRequest{}, ErrTransferRequestNotFound
+		}
+		return sqlc.TransferRequest{}, err
+	}
+	if req.Status != TransferRequestPending {
+		return sqlc.TransferRequest{}, ErrTransferRequestNotPending
+	}
+	if req.RequestedBy == approverID {
+		return sqlc.TransferRequest{}, ErrSelfApproval
+	}
+	return req, nil
+}
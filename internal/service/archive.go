@@ -0,0 +1,345 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/archive"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// KindArchiveEntries is the background job kind that copies aged entries to the archive.
+const KindArchiveEntries = "ledger.archive_entries"
+
+// archivePeriodsPerBatch is how many account months one listing of unarchived months returns.
+const archivePeriodsPerBatch = 500
+
+// ErrArchiveUnavailable is returned when an account's history includes pruned segments but no
+// archive store is configured to read them from.
+var ErrArchiveUnavailable = errors.New("archived history is unavailable")
+
+// ArchivePolicy decides which entries are archived and how long the archive keeps them.
+type ArchivePolicy struct {
+	// AfterMonths is how many whole months entries stay unarchived: with 12, entries posted before
+	// the first of the month a year ago are archived.
+	AfterMonths int
+	// RetainYears is how long after its month ends a segment is locked against deletion.
+	RetainYears int
+	// PruneAfterMonths is how many whole months entries stay in Postgres once archived; zero keeps
+	// them for good. It must not be less than AfterMonths.
+	PruneAfterMonths int
+}
+
+// WithArchive makes the ledger copy aged entries to store and read pruned history back from it.
+func WithArchive(store archive.Store, policy ArchivePolicy) Option {
+	return func(s *LedgerService) {
+		s.archive = store
+		s.archivePolicy = policy
+	}
+}
+
+// ArchiveEntries is a jobs.HandlerFunc that writes a segment for every account month older than
+// the policy allows that has none yet. Entries stay in Postgres; the segment is a copy.
+func (s *LedgerService) ArchiveEntries(ctx context.Context, _ json.RawMessage) error {
+	if s.archive == nil {
+		return nil
+	}
+	now := time.Now().UTC()
+	before := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -s.archivePolicy.AfterMonths, 0)
+
+	var (
+		errs     []error
+		archived int
+	)
+	for {
+		periods, err := s.store.ListUnarchivedPeriods(ctx, sqlc.ListUnarchivedPeriodsParams{Before: before, RowLimit: archivePeriodsPerBatch})
+		if err != nil {
+			return errors.Join(append(errs, fmt.Errorf("list unarchived periods: %w", err))...)
+		}
+		for _, p := range periods {
+			period := time.Date(p.Period.Year(), p.Period.Month(), 1, 0, 0, 0, 0, time.UTC)
+			if err := s.archivePeriod(ctx, p.AccountID, period); err != nil {
+				logger(ctx).Error().Err(err).Str("account_id", p.AccountID.String()).Str("period", period.Format("2006-01")).Msg("Failed to archive entries")
+				errs = append(errs, err)
+				continue
+			}
+			archived++
+		}
+		// A failed month is listed again, so stop rather than retry it until the next run.
+		if len(periods) < archivePeriodsPerBatch || len(errs) > 0 {
+			break
+		}
+	}
+
+	logger(ctx).Info().Int("segments", archived).Int("failed", len(errs)).Msg("Entries archived")
+	return errors.Join(errs...)
+}
+
+// archivePeriod writes the segment of the account's entries for the month starting at period and
+// records it.
+func (s *LedgerService) archivePeriod(ctx context.Context, accountID uuid.UUID, period time.Time) error {
+	end := period.AddDate(0, 1, 0)
+	rows, err := s.store.ListArchivableEntries(ctx, sqlc.ListArchivableEntriesParams{AccountID: accountID, PeriodStart: period, PeriodEnd: end})
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	records := make([]archive.Record, 0, len(rows))
+	net := decimal.Zero
+	for _, row := range rows {
+		// A segment stands in for the read model once pruned, so it must carry every row of it.
+		if !row.BalanceAfter.Valid {
+			return fmt.Errorf("entry %s has no account history row", row.ID)
+		}
+		rec := archive.Record{
+			ID:                    row.ID,
+			AccountID:             row.AccountID,
+			TransactionID:         row.TransactionID,
+			OperationType:         row.OperationType,
+			Debit:                 row.Debit,
+			Credit:                row.Credit,
+			CreatedAt:             row.CreatedAt.Time.UTC(),
+			ChainSeq:              row.ChainSeq,
+			PrevHash:              row.PrevHash,
+			Hash:                  row.Hash,
+			BalanceAfter:          row.BalanceAfter.String,
+			CounterpartyAccountID: row.CounterpartyAccountID,
+			CounterpartyName:      row.CounterpartyName.String,
+			CategoryID:            row.CategoryID,
+			CategoryName:          row.CategoryName.String,
+		}
+		if row.Description.Valid {
+			rec.Description = &row.Description.String
+		}
+		debit, err := decimal.NewFromString(row.Debit)
+		if err != nil {
+			return fmt.Errorf("invalid debit on entry %s: %w", row.ID, err)
+		}
+		credit, err := decimal.NewFromString(row.Credit)
+		if err != nil {
+			return fmt.Errorf("invalid credit on entry %s: %w", row.ID, err)
+		}
+		net = net.Add(credit).Sub(debit)
+		records = append(records, rec)
+	}
+	// The chain link pruning leaves behind is the highest one: a posting that started before the
+	// month ended can take its chain position after one that started in the next month.
+	link := records[0]
+	for _, rec := range records[1:] {
+		if rec.ChainSeq > link.ChainSeq {
+			link = rec
+		}
+	}
+
+	body, sum, err := archive.Encode(accountID, period, records)
+	if err != nil {
+		return err
+	}
+	key := archive.Key(accountID, period, sum)
+	if err := s.archive.Put(ctx, key, body, sum, end.AddDate(s.archivePolicy.RetainYears, 0, 0)); err != nil {
+		return fmt.Errorf("write archive segment: %w", err)
+	}
+	_, err = s.store.CreateEntryArchive(ctx, sqlc.CreateEntryArchiveParams{
+		AccountID:    accountID,
+		Period:       period,
+		ObjectKey:    key,
+		Sha256:       sum,
+		EntryCount:   int32(len(records)), // #nosec G115 -- one account's entries in a month
+		FirstEntryAt: records[0].CreatedAt,
+		LastEntryAt:  records[len(records)-1].CreatedAt,
+		RetainUntil:  end.AddDate(s.archivePolicy.RetainYears, 0, 0),
+		NetAmount:    net.StringFixed(4),
+		LastChainSeq: link.ChainSeq,
+		LastHash:     link.Hash,
+	})
+	return err
+}
+
+// AccountHistory returns a page of the account's history in sort order (created_at,
+// -created_at, amount or -amount; anything else is newest first) and its total length, serving
+// pruned months from their archive segments. Pruning removes whole months oldest first, so
+// archived history always precedes what is still in Postgres and time-ordered pages read only
+// the segments they reach; amount-ordered pages read every segment of the account.
+func (s *LedgerService) AccountHistory(ctx context.Context, accountID uuid.UUID, sort string, limit, offset int) ([]sqlc.AccountHistory, int64, error) {
+	live, err := s.store.CountEntriesByAccount(ctx, accountID)
+	if err != nil {
+		return nil, 0, err
+	}
+	segments, err := s.store.ListPrunedEntryArchives(ctx, accountID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(segments) == 0 {
+		rows, err := s.liveHistory(ctx, accountID, sort, limit, int64(offset))
+		return rows, live, err
+	}
+	if s.archive == nil {
+		return nil, 0, ErrArchiveUnavailable
+	}
+	var archived int64
+	for _, seg := range segments {
+		archived += int64(seg.EntryCount)
+	}
+	total := live + archived
+	start := int64(offset)
+
+	var rows []sqlc.AccountHistory
+	switch sort {
+	case "created_at":
+		if start < archived {
+			if rows, err = s.archivedHistory(ctx, segments, true, start, limit); err != nil {
+				return nil, 0, err
+			}
+		}
+		if len(rows) < limit {
+			more, err := s.liveHistory(ctx, accountID, sort, limit-len(rows), max(0, start-archived))
+			if err != nil {
+				return nil, 0, err
+			}
+			rows = append(rows, more...)
+		}
+	case "amount", "-amount":
+		all, err := s.archivedHistory(ctx, segments, true, 0, int(archived))
+		if err != nil {
+			return nil, 0, err
+		}
+		more, err := s.liveHistory(ctx, accountID, sort, int(min(start+int64(limit), live)), 0)
+		if err != nil {
+			return nil, 0, err
+		}
+		all = append(all, more...)
+		sortHistoryByAmount(all, sort == "-amount")
+		rows = all[min(start, int64(len(all))):min(start+int64(limit), int64(len(all)))]
+	default:
+		if start < live {
+			if rows, err = s.liveHistory(ctx, accountID, sort, limit, start); err != nil {
+				return nil, 0, err
+			}
+		}
+		if len(rows) < limit {
+			more, err := s.archivedHistory(ctx, segments, false, max(0, start-live), limit-len(rows))
+			if err != nil {
+				return nil, 0, err
+			}
+			rows = append(rows, more...)
+		}
+	}
+	return rows, total, nil
+}
+
+// liveHistory reads a page of the history still in Postgres.
+func (s *LedgerService) liveHistory(ctx context.Context, accountID uuid.UUID, sort string, limit int, offset int64) ([]sqlc.AccountHistory, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	return s.store.ListAccountHistory(ctx, sqlc.ListAccountHistoryParams{
+		AccountID: accountID,
+		Sort:      sort,
+		RowLimit:  int32(min(limit, 1<<31-1)),  // #nosec G115 -- clamped to int32
+		RowOffset: int32(min(offset, 1<<31-1)), // #nosec G115 -- clamped to int32
+	})
+}
+
+// archivedHistory reads n rows from offset of the history held in segments (oldest month first),
+// oldest first when ascending and newest first otherwise. Segments before offset are skipped
+// unread.
+func (s *LedgerService) archivedHistory(ctx context.Context, segments []sqlc.EntryArchive, ascending bool, offset int64, n int) ([]sqlc.AccountHistory, error) {
+	order := slices.Clone(segments)
+	if !ascending {
+		slices.Reverse(order)
+	}
+	rows := make([]sqlc.AccountHistory, 0, n)
+	for _, seg := range order {
+		if len(rows) >= n {
+			break
+		}
+		if offset >= int64(seg.EntryCount) {
+			offset -= int64(seg.EntryCount)
+			continue
+		}
+		records, err := s.readSegment(ctx, seg)
+		if err != nil {
+			return nil, err
+		}
+		if !ascending {
+			slices.Reverse(records)
+		}
+		for _, rec := range records[offset:] {
+			if len(rows) >= n {
+				break
+			}
+			rows = append(rows, historyFromRecord(rec))
+		}
+		offset = 0
+	}
+	return rows, nil
+}
+
+// readSegment fetches a segment and checks it against the digest recorded when it was written.
+func (s *LedgerService) readSegment(ctx context.Context, seg sqlc.EntryArchive) ([]archive.Record, error) {
+	body, err := s.archive.Get(ctx, seg.ObjectKey)
+	if err != nil {
+		return nil, fmt.Errorf("read archive segment %s: %w", seg.ObjectKey, err)
+	}
+	records, err := archive.Decode(body, seg.Sha256)
+	if err != nil {
+		return nil, fmt.Errorf("read archive segment %s: %w", seg.ObjectKey, err)
+	}
+	if len(records) != int(seg.EntryCount) {
+		return nil, fmt.Errorf("archive segment %s holds %d entries, %d recorded", seg.ObjectKey, len(records), seg.EntryCount)
+	}
+	return records, nil
+}
+
+// historyFromRecord is the read model row an archived entry stands in for.
+func historyFromRecord(rec archive.Record) sqlc.AccountHistory {
+	row := sqlc.AccountHistory{
+		EntryID:               rec.ID,
+		AccountID:             rec.AccountID,
+		TransactionID:         rec.TransactionID,
+		OperationType:         rec.OperationType,
+		Debit:                 rec.Debit,
+		Credit:                rec.Credit,
+		BalanceAfter:          rec.BalanceAfter,
+		CounterpartyAccountID: rec.CounterpartyAccountID,
+		CounterpartyName:      rec.CounterpartyName,
+		CategoryID:            rec.CategoryID,
+		CategoryName:          rec.CategoryName,
+		CreatedAt:             rec.CreatedAt,
+	}
+	if rec.Description != nil {
+		row.Description = *rec.Description
+	}
+	return row
+}
+
+// sortHistoryByAmount orders rows as ListAccountHistory does for amount and -amount: by
+// debit + credit, ties newest first.
+func sortHistoryByAmount(rows []sqlc.AccountHistory, desc bool) {
+	amount := func(h sqlc.AccountHistory) decimal.Decimal {
+		return decimal.RequireFromString(h.Debit).Add(decimal.RequireFromString(h.Credit))
+	}
+	slices.SortStableFunc(rows, func(a, b sqlc.AccountHistory) int {
+		c := amount(a).Cmp(amount(b))
+		if desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		if c = b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.EntryID.String(), a.EntryID.String())
+	})
+}
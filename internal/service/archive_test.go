@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/archive"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// memArchive is an in-memory archive.Store.
+type memArchive map[string][]byte
+
+func (m memArchive) Put(_ context.Context, key string, body, _ []byte, _ time.Time) error {
+	if _, ok := m[key]; !ok {
+		m[key] = body
+	}
+	return nil
+}
+
+func (m memArchive) Get(_ context.Context, key string) ([]byte, error) {
+	body, ok := m[key]
+	if !ok {
+		return nil, fmt.Errorf("no object %s", key)
+	}
+	return body, nil
+}
+
+// archiveMonths writes one segment per month from start, each holding perMonth deposits.
+func archiveMonths(t *testing.T, store memArchive, accountID uuid.UUID, start time.Time, months, perMonth int) []sqlc.EntryArchive {
+	t.Helper()
+	var segments []sqlc.EntryArchive
+	for m := 0; m < months; m++ {
+		period := start.AddDate(0, m, 0)
+		records := make([]archive.Record, 0, perMonth)
+		for i := 0; i < perMonth; i++ {
+			records = append(records, archive.Record{
+				ID: uuid.New(), AccountID: accountID, TransactionID: uuid.New(), OperationType: "deposit",
+				Debit: "0.0000", Credit: fmt.Sprintf("%d.0000", m*perMonth+i+1), CreatedAt: period.Add(time.Duration(i+1) * time.Hour),
+			})
+		}
+		body, sum, err := archive.Encode(accountID, period, records)
+		require.NoError(t, err)
+		key := archive.Key(accountID, period, sum)
+		require.NoError(t, store.Put(context.Background(), key, body, sum, time.Time{}))
+		segments = append(segments, sqlc.EntryArchive{AccountID: accountID, Period: period, ObjectKey: key, Sha256: sum, EntryCount: int32(perMonth)})
+	}
+	return segments
+}
+
+func TestArchivedHistory_Pages(t *testing.T) {
+	// Pages run across segment boundaries in either direction, skipping segments before the offset.
+	store := memArchive{}
+	accountID := uuid.New()
+	segments := archiveMonths(t, store, accountID, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 3, 4)
+	s := &LedgerService{archive: store}
+
+	credits := func(rows []sqlc.AccountHistory) []string {
+		out := make([]string, 0, len(rows))
+		for _, r := range rows {
+			out = append(out, r.Credit)
+		}
+		return out
+	}
+	rows, err := s.archivedHistory(context.Background(), segments, true, 3, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"4.0000", "5.0000", "6.0000"}, credits(rows))
+
+	rows, err = s.archivedHistory(context.Background(), segments, false, 2, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0000", "9.0000", "8.0000", "7.0000"}, credits(rows))
+
+	// A segment that no longer matches its recorded digest is refused.
+	store[segments[0].ObjectKey] = append([]byte(nil), store[segments[1].ObjectKey]...)
+	_, err = s.archivedHistory(context.Background(), segments, true, 0, 1)
+	assert.ErrorIs(t, err, archive.ErrChecksum)
+	_, err = s.archivedHistory(context.Background(), segments, true, 4, 1)
+	assert.NoError(t, err)
+}
+
+func TestSortHistoryByAmount(t *testing.T) {
+	// Amount ties fall back to newest first, as ListAccountHistory orders them.
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := []sqlc.AccountHistory{
+		{Credit: "5.0000", Debit: "0.0000", CreatedAt: at},
+		{Credit: "0.0000", Debit: "20.0000", CreatedAt: at},
+		{Credit: "5.0000", Debit: "0.0000", CreatedAt: at.Add(time.Hour)},
+	}
+	sortHistoryByAmount(rows, false)
+	assert.Equal(t, at.Add(time.Hour), rows[0].CreatedAt)
+	assert.Equal(t, "20.0000", rows[2].Debit)
+	sortHistoryByAmount(rows, true)
+	assert.Equal(t, "20.0000", rows[0].Debit)
+	assert.Equal(t, at.Add(time.Hour), rows[1].CreatedAt)
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/backup"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// backupPageSize is how many rows a backup reads per query and a restore writes per statement.
+const backupPageSize = 1000
+
+var (
+	// ErrRestoreTargetInUse is returned when restoring into a database that already holds users or
+	// entries; a restore needs a freshly migrated one.
+	ErrRestoreTargetInUse = errors.New("restore target already holds users or entries")
+	// ErrRestoreMismatch is returned when the restored ledger fails a check against the backup, as
+	// when an entry was edited in the dump or was already tampered with when it was taken.
+	ErrRestoreMismatch = errors.New("restored ledger does not match the backup")
+)
+
+// Backup writes a dump of the ledger's users, accounts, entries and the rows they depend on (see
+// backup.Tables) to w, all read as of one moment while postings carry on.
+func (s *LedgerService) Backup(ctx context.Context, w io.Writer) (backup.Header, map[string]int64, error) {
+	var (
+		header backup.Header
+		counts map[string]int64
+	)
+	err := s.store.ReadTx(ctx, func(q *sqlc.Queries) error {
+		seq, err := q.GetLatestLedgerEventSeq(ctx)
+		if err != nil {
+			return err
+		}
+		header = backup.Header{Format: backup.Format, TakenAt: time.Now().UTC(), ThroughSeq: seq}
+		bw, err := backup.NewWriter(w, header)
+		if err != nil {
+			return err
+		}
+		dumps := []func() error{
+			func() error {
+				return dumpTable(bw, "organizations", func(after uuid.UUID) ([]sqlc.DumpOrganizationsRow, error) {
+					return q.DumpOrganizations(ctx, sqlc.DumpOrganizationsParams{After: after, RowLimit: backupPageSize})
+				}, func(r sqlc.DumpOrganizationsRow) (uuid.UUID, string) { return r.ID, r.Data })
+			},
+			func() error {
+				return dumpTable(bw, "users", func(after uuid.UUID) ([]sqlc.DumpUsersRow, error) {
+					return q.DumpUsers(ctx, sqlc.DumpUsersParams{After: after, RowLimit: backupPageSize})
+				}, func(r sqlc.DumpUsersRow) (uuid.UUID, string) { return r.ID, r.Data })
+			},
+			func() error {
+				return dumpTable(bw, "products", func(after string) ([]sqlc.DumpProductsRow, error) {
+					return q.DumpProducts(ctx, sqlc.DumpProductsParams{After: after, RowLimit: backupPageSize})
+				}, func(r sqlc.DumpProductsRow) (string, string) { return r.Code, r.Data })
+			},
+			func() error {
+				return dumpTable(bw, "accounts", func(after uuid.UUID) ([]sqlc.DumpAccountsRow, error) {
+					return q.DumpAccounts(ctx, sqlc.DumpAccountsParams{After: after, RowLimit: backupPageSize})
+				}, func(r sqlc.DumpAccountsRow) (uuid.UUID, string) { return r.ID, r.Data })
+			},
+			func() error {
+				return dumpTable(bw, "account_shards", func(after uuid.UUID) ([]sqlc.DumpAccountShardsRow, error) {
+					return q.DumpAccountShards(ctx, sqlc.DumpAccountShardsParams{After: after, RowLimit: backupPageSize})
+				}, func(r sqlc.DumpAccountShardsRow) (uuid.UUID, string) { return r.AccountID, r.Data })
+			},
+			func() error {
+				return dumpTable(bw, "transactions", func(after uuid.UUID) ([]sqlc.DumpTransactionsRow, error) {
+					return q.DumpTransactions(ctx, sqlc.DumpTransactionsParams{After: after, RowLimit: backupPageSize})
+				}, func(r sqlc.DumpTransactionsRow) (uuid.UUID, string) { return r.ID, r.Data })
+			},
+			func() error {
+				return dumpTable(bw, "entry_archives", func(after uuid.UUID) ([]sqlc.DumpEntryArchivesRow, error) {
+					return q.DumpEntryArchives(ctx, sqlc.DumpEntryArchivesParams{After: after, RowLimit: backupPageSize})
+				}, func(r sqlc.DumpEntryArchivesRow) (uuid.UUID, string) { return r.ID, r.Data })
+			},
+			func() error {
+				return dumpTable(bw, "entries", func(after sqlc.DumpEntriesParams) ([]sqlc.DumpEntriesRow, error) {
+					after.RowLimit = backupPageSize
+					return q.DumpEntries(ctx, after)
+				}, func(r sqlc.DumpEntriesRow) (sqlc.DumpEntriesParams, string) {
+					return sqlc.DumpEntriesParams{AfterAccountID: r.AccountID, AfterSeq: r.ChainSeq}, r.Data
+				})
+			},
+			func() error {
+				return dumpTable(bw, "entry_chain_heads", func(after uuid.UUID) ([]sqlc.DumpEntryChainHeadsRow, error) {
+					return q.DumpEntryChainHeads(ctx, sqlc.DumpEntryChainHeadsParams{After: after, RowLimit: backupPageSize})
+				}, func(r sqlc.DumpEntryChainHeadsRow) (uuid.UUID, string) { return r.AccountID, r.Data })
+			},
+			func() error {
+				return dumpTable(bw, "account_history", func(after uuid.UUID) ([]sqlc.DumpAccountHistoryRow, error) {
+					return q.DumpAccountHistory(ctx, sqlc.DumpAccountHistoryParams{After: after, RowLimit: backupPageSize})
+				}, func(r sqlc.DumpAccountHistoryRow) (uuid.UUID, string) { return r.EntryID, r.Data })
+			},
+			func() error {
+				return dumpTable(bw, "ledger_events", func(after int64) ([]sqlc.DumpLedgerEventsRow, error) {
+					return q.DumpLedgerEvents(ctx, sqlc.DumpLedgerEventsParams{After: after, RowLimit: backupPageSize})
+				}, func(r sqlc.DumpLedgerEventsRow) (int64, string) { return r.Seq, r.Data })
+			},
+		}
+		for _, dump := range dumps {
+			if err := dump(); err != nil {
+				return err
+			}
+		}
+		counts = bw.Counts()
+		return bw.Close()
+	})
+	if err != nil {
+		return backup.Header{}, nil, err
+	}
+	logger(ctx).Info().Int64("through_seq", header.ThroughSeq).Int64("entries", counts["entries"]).Msg("Ledger backed up")
+	return header, counts, nil
+}
+
+// dumpTable writes every row of table to w a page at a time: page reads the rows after a key, and
+// next returns a row's key and JSON.
+func dumpTable[K, R any](w *backup.Writer, table string, page func(after K) ([]R, error), next func(R) (K, string)) error {
+	var after K
+	for {
+		rows, err := page(after)
+		if err != nil {
+			return fmt.Errorf("dump %s: %w", table, err)
+		}
+		for _, row := range rows {
+			var data string
+			after, data = next(row)
+			if err := w.Write(table, json.RawMessage(data)); err != nil {
+				return err
+			}
+		}
+		if len(rows) < backupPageSize {
+			return nil
+		}
+	}
+}
+
+// Restore loads the dump read from r into a freshly migrated database, one with no users or
+// entries yet, replacing the accounts and organization migrations seed. It all happens in one
+// transaction, so nothing is left behind unless every check passes: the dump's checksum and row
+// counts, each entry hashing as it did when dumped once chained afresh, each account's chain
+// ending at the head it had, every transaction balancing and every balance reconciling with its
+// entries.
+func (s *LedgerService) Restore(ctx context.Context, r io.ReadSeeker) (backup.Header, map[string]int64, error) {
+	var (
+		header backup.Header
+		counts map[string]int64
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		// Step 1: Refuse a database in use, then clear what migrations seed.
+		usage, err := q.GetRestoreTargetUsage(ctx)
+		if err != nil {
+			return err
+		}
+		if usage.Users > 0 || usage.Entries > 0 {
+			return ErrRestoreTargetInUse
+		}
+		if _, err := q.DeleteSeedAccounts(ctx); err != nil {
+			return fmt.Errorf("clear seeded accounts: %w", err)
+		}
+		if _, err := q.DeleteSeedOrganizations(ctx); err != nil {
+			return fmt.Errorf("clear seeded organizations: %w", err)
+		}
+
+		// Step 2: Load the tables in order, chaining the entries afresh.
+		var (
+			defaults, parents refs
+			seeded            bool
+		)
+		seed := func() error {
+			seeded = true
+			_, err := q.SeedRestoredEntryChainHeads(ctx)
+			return err
+		}
+		entriesAt := slices.Index(backup.Tables, "entries")
+		header, counts, err = backup.Read(r, backupPageSize, func(table string, batch []json.RawMessage) error {
+			if !seeded && slices.Index(backup.Tables, table) >= entriesAt {
+				if err := seed(); err != nil {
+					return err
+				}
+			}
+			doc, err := json.Marshal(batch)
+			if err != nil {
+				return err
+			}
+			n := int64(len(batch))
+			switch table {
+			case "organizations":
+				n, err = q.RestoreOrganizations(ctx, doc)
+			case "users":
+				if err = defaults.collect(batch, "default_account_id"); err == nil {
+					n, err = q.RestoreUsers(ctx, doc)
+				}
+			case "products":
+				n, err = q.RestoreProducts(ctx, doc)
+			case "accounts":
+				if err = parents.collect(batch, "parent_account_id"); err == nil {
+					n, err = q.RestoreAccounts(ctx, doc)
+				}
+			case "account_shards":
+				n, err = q.RestoreAccountShards(ctx, doc)
+			case "transactions":
+				n, err = q.RestoreTransactions(ctx, doc)
+			case "entry_archives":
+				n, err = q.RestoreEntryArchives(ctx, doc)
+			case "entries":
+				var bad []uuid.UUID
+				if bad, err = q.RestoreEntries(ctx, doc); err == nil && len(bad) > 0 {
+					return fmt.Errorf("%w: %d entries hash differently, first %s", ErrRestoreMismatch, len(bad), bad[0])
+				}
+			case "entry_chain_heads":
+				var bad []uuid.UUID
+				if bad, err = q.ListRestoredChainHeadMismatches(ctx, doc); err == nil && len(bad) > 0 {
+					return fmt.Errorf("%w: %d accounts' chains end elsewhere, first %s", ErrRestoreMismatch, len(bad), bad[0])
+				}
+			case "account_history":
+				n, err = q.RestoreAccountHistory(ctx, doc)
+			case "ledger_events":
+				n, err = q.RestoreLedgerEvents(ctx, doc)
+			}
+			if err != nil {
+				return fmt.Errorf("restore %s: %w", table, err)
+			}
+			if n != int64(len(batch)) {
+				return fmt.Errorf("restore %s: %d of %d rows written", table, n, len(batch))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if !seeded {
+			if err := seed(); err != nil {
+				return err
+			}
+		}
+
+		// Step 3: Put back the references loading had to leave out.
+		if len(defaults.ids) > 0 {
+			if _, err := q.SetRestoredDefaultAccounts(ctx, sqlc.SetRestoredDefaultAccountsParams{UserIds: defaults.ids, AccountIds: defaults.refs}); err != nil {
+				return fmt.Errorf("restore default accounts: %w", err)
+			}
+		}
+		if len(parents.ids) > 0 {
+			if _, err := q.SetRestoredAccountParents(ctx, sqlc.SetRestoredAccountParentsParams{AccountIds: parents.ids, ParentIds: parents.refs}); err != nil {
+				return fmt.Errorf("restore wallet parents: %w", err)
+			}
+		}
+		if err := q.ResetLedgerEventSeq(ctx); err != nil {
+			return err
+		}
+
+		// Step 4: Check the ledger as a whole: no chain head the dump lacked, and every balance
+		// reconciled. Unbalanced transactions fail the commit.
+		heads, err := q.CountEntryChainHeads(ctx)
+		if err != nil {
+			return err
+		}
+		if heads != counts["entry_chain_heads"] {
+			return fmt.Errorf("%w: %d chain heads restored, %d dumped", ErrRestoreMismatch, heads, counts["entry_chain_heads"])
+		}
+		drifted, err := q.ReplayBalances(ctx, sqlc.ReplayBalancesParams{DriftOnly: true})
+		if err != nil {
+			return err
+		}
+		if len(drifted) > 0 {
+			return fmt.Errorf("%w: %d balances differ from their entries, first %s", ErrRestoreMismatch, len(drifted), drifted[0].AccountID)
+		}
+		return nil
+	})
+	if err != nil {
+		return backup.Header{}, nil, err
+	}
+	logger(ctx).Info().Int64("through_seq", header.ThroughSeq).Int64("entries", counts["entries"]).Msg("Ledger restored")
+	return header, counts, nil
+}
+
+// refs collects the rows of a batch that reference another row through a column a restore sets
+// after loading.
+type refs struct {
+	ids, refs []uuid.UUID
+}
+
+func (r *refs) collect(batch []json.RawMessage, column string) error {
+	for _, raw := range batch {
+		var row map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &row); err != nil {
+			return err
+		}
+		var id uuid.UUID
+		var ref uuid.NullUUID
+		if err := json.Unmarshal(row["id"], &id); err != nil {
+			return fmt.Errorf("row without id: %w", err)
+		}
+		if v, ok := row[column]; ok {
+			if err := json.Unmarshal(v, &ref); err != nil {
+				return fmt.Errorf("invalid %s of %s: %w", column, id, err)
+			}
+		}
+		if ref.Valid {
+			r.ids = append(r.ids, id)
+			r.refs = append(r.refs, ref.UUID)
+		}
+	}
+	return nil
+}
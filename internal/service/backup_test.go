@@ -0,0 +1,26 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefsCollect(t *testing.T) {
+	// Only rows whose column is set are collected; a row without the column is skipped.
+	user, account := uuid.New(), uuid.New()
+	batch := []json.RawMessage{
+		json.RawMessage(`{"id":"` + user.String() + `","default_account_id":"` + account.String() + `"}`),
+		json.RawMessage(`{"id":"` + uuid.NewString() + `","default_account_id":null}`),
+		json.RawMessage(`{"id":"` + uuid.NewString() + `"}`),
+	}
+	var r refs
+	require.NoError(t, r.collect(batch, "default_account_id"))
+	assert.Equal(t, []uuid.UUID{user}, r.ids)
+	assert.Equal(t, []uuid.UUID{account}, r.refs)
+
+	assert.Error(t, r.collect([]json.RawMessage{json.RawMessage(`{"default_account_id":null}`)}, "default_account_id"))
+}
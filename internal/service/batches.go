@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+const (
+	// MaxPayoutBatchRows caps the payouts in one uploaded file.
+	MaxPayoutBatchRows = 1000
+	// maxPayoutNarration bounds a row's narration, which is appended to both entry descriptions.
+	maxPayoutNarration = 140
+)
+
+var (
+	// ErrInvalidPayoutBatch is returned (wrapped in *PayoutBatchError) when any row of a payout file is invalid.
+	ErrInvalidPayoutBatch = errors.New("invalid payout batch")
+	// ErrPayoutBatchNotFound is returned when a payout batch does not exist.
+	ErrPayoutBatchNotFound = errors.New("payout batch not found")
+)
+
+// PayoutRow is one parsed line of a payout file.
+type PayoutRow struct {
+	Amount    decimal.Decimal
+	Narration string
+	AccountID uuid.UUID
+	Line      int
+}
+
+// PayoutRowError explains why one line of a payout file was rejected.
+type PayoutRowError struct {
+	Message string
+	Line    int
+}
+
+// PayoutBatchError lists every rejected line of a payout file. Nothing is queued when it is returned.
+type PayoutBatchError struct {
+	Rows []PayoutRowError
+}
+
+func (e *PayoutBatchError) Error() string {
+	if len(e.Rows) == 1 {
+		return fmt.Sprintf("%s: line %d: %s", ErrInvalidPayoutBatch, e.Rows[0].Line, e.Rows[0].Message)
+	}
+	return fmt.Sprintf("%s: %d invalid rows", ErrInvalidPayoutBatch, len(e.Rows))
+}
+
+// Unwrap lets callers match the error with errors.Is(err, ErrInvalidPayoutBatch).
+func (e *PayoutBatchError) Unwrap() error { return ErrInvalidPayoutBatch }
+
+// PayoutBatchRequest queues every row as a transfer out of SourceAccountID.
+type PayoutBatchRequest struct {
+	Filename        string
+	Rows            []PayoutRow
+	SourceAccountID uuid.UUID
+	CreatedBy       uuid.UUID
+}
+
+// ParsePayoutCSV reads account,amount,narration rows. A header row is skipped when its first
+// column is not an account ID; narration is optional. Every line is checked, and all problems
+// are returned together as a *PayoutBatchError.
+func ParsePayoutCSV(r io.Reader) ([]PayoutRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	var (
+		rows    []PayoutRow
+		invalid []PayoutRowError
+	)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, &PayoutBatchError{Rows: append(invalid, PayoutRowError{Line: parseErr.Line, Message: parseErr.Err.Error()})}
+			}
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if first {
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+			if isPayoutHeader(record) {
+				continue
+			}
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(rows)+len(invalid) >= MaxPayoutBatchRows {
+			return nil, &PayoutBatchError{Rows: append(invalid, PayoutRowError{Line: line, Message: fmt.Sprintf("a batch holds at most %d rows", MaxPayoutBatchRows)})}
+		}
+
+		row, err := parsePayoutRecord(line, record)
+		if err != nil {
+			invalid = append(invalid, PayoutRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		rows = append(rows, row)
+	}
+	if len(invalid) > 0 {
+		return nil, &PayoutBatchError{Rows: invalid}
+	}
+	if len(rows) == 0 {
+		return nil, &PayoutBatchError{Rows: []PayoutRowError{{Line: 1, Message: "file has no payout rows"}}}
+	}
+	return rows, nil
+}
+
+// parsePayoutRecord checks one line's fields without touching the database.
+func parsePayoutRecord(line int, record []string) (PayoutRow, error) {
+	if len(record) < 2 || len(record) > 3 {
+		return PayoutRow{}, errors.New("expected account, amount and optional narration columns")
+	}
+	accountID, err := uuid.Parse(strings.TrimSpace(record[0]))
+	if err != nil {
+		return PayoutRow{}, errors.New("invalid account ID")
+	}
+	amount, err := validatePositiveAmount(strings.TrimSpace(record[1]))
+	if err != nil {
+		return PayoutRow{}, err
+	}
+	if amount.Exponent() < -4 {
+		return PayoutRow{}, errors.New("amount has more than 4 decimal places")
+	}
+	var narration string
+	if len(record) == 3 {
+		narration = strings.TrimSpace(record[2])
+	}
+	if len(narration) > maxPayoutNarration {
+		return PayoutRow{}, fmt.Errorf("narration must be at most %d characters", maxPayoutNarration)
+	}
+	return PayoutRow{Line: line, AccountID: accountID, Amount: amount, Narration: narration}, nil
+}
+
+// isPayoutHeader reports whether the first line names columns rather than a payout.
+func isPayoutHeader(record []string) bool {
+	_, err := uuid.Parse(strings.TrimSpace(record[0]))
+	return err != nil && strings.Contains(strings.ToLower(record[0]), "account")
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// CreatePayoutBatch checks every row against the source account and queues one transfer job per
+// row under a new batch. Rows are validated up front, so a bad file queues nothing; once queued,
+// each row posts or fails on its own, e.g. when the source runs out of funds part-way.
+func (s *LedgerService) CreatePayoutBatch(ctx context.Context, req PayoutBatchRequest) (sqlc.PayoutBatch, []sqlc.TransferJob, error) {
+	if len(req.Rows) == 0 || len(req.Rows) > MaxPayoutBatchRows {
+		return sqlc.PayoutBatch{}, nil, ErrInvalidPayoutBatch
+	}
+
+	// Step 1: Every destination must exist and be able to receive from the source.
+	source, err := s.store.GetAccount(ctx, req.SourceAccountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.PayoutBatch{}, nil, ErrAccountNotFound
+		}
+		return sqlc.PayoutBatch{}, nil, err
+	}
+	destinations := make(map[uuid.UUID]error)
+	var (
+		invalid []PayoutRowError
+		total   = decimal.Zero
+	)
+	for _, row := range req.Rows {
+		rowErr, checked := destinations[row.AccountID]
+		if !checked {
+			rowErr, err = s.payoutDestination(ctx, source, row.AccountID)
+			if err != nil {
+				return sqlc.PayoutBatch{}, nil, err
+			}
+			destinations[row.AccountID] = rowErr
+		}
+		if rowErr != nil {
+			invalid = append(invalid, PayoutRowError{Line: row.Line, Message: rowErr.Error()})
+		}
+		total = total.Add(row.Amount)
+	}
+	if len(invalid) > 0 {
+		return sqlc.PayoutBatch{}, nil, &PayoutBatchError{Rows: invalid}
+	}
+
+	// Step 2: Record the batch and its jobs together; balances are checked by the worker per row.
+	var (
+		batch sqlc.PayoutBatch
+		jobs  = make([]sqlc.TransferJob, 0, len(req.Rows))
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		jobs = jobs[:0]
+		var err error
+		batch, err = q.CreatePayoutBatch(ctx, sqlc.CreatePayoutBatchParams{
+			SourceAccountID: source.ID,
+			CreatedBy:       req.CreatedBy,
+			Filename:        req.Filename,
+			RowCount:        int32(len(req.Rows)), // #nosec G115 -- capped at MaxPayoutBatchRows above
+			TotalAmount:     total.StringFixed(4),
+			Currency:        source.Currency,
+		})
+		if err != nil {
+			return err
+		}
+		for _, row := range req.Rows {
+			job, err := q.CreateBatchTransferJob(ctx, sqlc.CreateBatchTransferJobParams{
+				ID:            uuid.New(),
+				FromAccountID: source.ID,
+				ToAccountID:   row.AccountID,
+				Amount:        row.Amount.StringFixed(4),
+				RequestedBy:   req.CreatedBy,
+				BatchID:       uuid.NullUUID{UUID: batch.ID, Valid: true},
+				BatchRow:      sql.NullInt32{Int32: int32(row.Line), Valid: true}, // #nosec G115 -- line numbers of an uploaded file fit in int32
+				Narration:     row.Narration,
+			})
+			if err != nil {
+				return err
+			}
+			jobs = append(jobs, job)
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.PayoutBatch{}, nil, err
+	}
+
+	logger(ctx).Info().Str("batch_id", batch.ID.String()).Str("source_id", source.ID.String()).Int32("rows", batch.RowCount).Str("total", batch.TotalAmount).Msg("Payout batch queued")
+	return batch, jobs, nil
+}
+
+// payoutDestination returns the row error for paying toID from source, or a non-nil err on lookup failure.
+func (s *LedgerService) payoutDestination(ctx context.Context, source sqlc.Account, toID uuid.UUID) (rowErr, err error) {
+	if toID == source.ID {
+		return ErrSameAccountTransfer, nil
+	}
+	to, err := s.store.GetAccount(ctx, toID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrAccountNotFound, nil
+		}
+		return nil, err
+	}
+	if to.OrgID != source.OrgID {
+		// Accounts of other tenants are indistinguishable from missing ones.
+		return ErrAccountNotFound, nil
+	}
+	if to.Currency != source.Currency {
+		return ErrCurrencyMismatch, nil
+	}
+	return nil, nil
+}
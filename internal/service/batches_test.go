@@ -0,0 +1,62 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePayoutCSV(t *testing.T) {
+	// The header is optional and skipped; narration may be left out.
+	first, second := uuid.New(), uuid.New()
+	file := "\ufeffaccount,amount,narration\n" +
+		first.String() + ",100.50,March salary\n" +
+		"\n" +
+		second.String() + ", 25\n"
+
+	rows, err := ParsePayoutCSV(strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, first, rows[0].AccountID)
+	assert.Equal(t, "100.5", rows[0].Amount.String())
+	assert.Equal(t, "March salary", rows[0].Narration)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, second, rows[1].AccountID)
+	assert.Empty(t, rows[1].Narration)
+	assert.Equal(t, 4, rows[1].Line)
+}
+
+func TestParsePayoutCSV_ReportsEveryBadRow(t *testing.T) {
+	// All invalid lines are reported together so the file can be fixed in one pass.
+	good := uuid.New().String()
+	file := good + ",10\n" +
+		"not-an-id,10\n" +
+		good + ",-5\n" +
+		good + ",1.00001\n" +
+		good + ",10," + strings.Repeat("x", 141) + "\n" +
+		good + "\n"
+
+	_, err := ParsePayoutCSV(strings.NewReader(file))
+	require.ErrorIs(t, err, ErrInvalidPayoutBatch)
+	var batchErr *PayoutBatchError
+	require.ErrorAs(t, err, &batchErr)
+	lines := make([]int, 0, len(batchErr.Rows))
+	for _, row := range batchErr.Rows {
+		lines = append(lines, row.Line)
+	}
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, lines)
+	assert.Equal(t, ErrInvalidAmount.Error(), batchErr.Rows[1].Message)
+}
+
+func TestParsePayoutCSV_RejectsEmptyAndOversizedFiles(t *testing.T) {
+	// A file needs at least one payout and at most MaxPayoutBatchRows.
+	_, err := ParsePayoutCSV(strings.NewReader("account,amount,narration\n"))
+	assert.ErrorIs(t, err, ErrInvalidPayoutBatch)
+
+	row := uuid.New().String() + ",1\n"
+	_, err = ParsePayoutCSV(strings.NewReader(strings.Repeat(row, MaxPayoutBatchRows+1)))
+	assert.ErrorIs(t, err, ErrInvalidPayoutBatch)
+}
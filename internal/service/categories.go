@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrCategoryNotFound is returned when a category does not exist or belongs to another user.
+	ErrCategoryNotFound = errors.New("category not found")
+	// ErrInvalidCategoryRule is returned when a rule does not name exactly one of a description
+	// pattern or a counterparty account.
+	ErrInvalidCategoryRule = errors.New("rule needs exactly one of description_contains or counterparty_account_id")
+	// ErrInvalidSpendPeriod is returned for an analytics period other than day, week or month.
+	ErrInvalidSpendPeriod = errors.New("period must be day, week or month")
+)
+
+// maxCategoryPattern bounds a rule's description pattern.
+const maxCategoryPattern = 100
+
+// CategoryRuleRequest files entries under CategoryID when their description contains
+// DescriptionContains or their transaction credits CounterpartyAccountID.
+type CategoryRuleRequest struct {
+	UserID                uuid.UUID
+	OrgID                 uuid.UUID
+	CategoryID            uuid.UUID
+	DescriptionContains   string
+	CounterpartyAccountID uuid.UUID
+	Priority              int32
+}
+
+// CreateCategoryRule validates and stores a rule. The category must be the user's own and the
+// counterparty, if any, an account of the user's organization.
+func (s *LedgerService) CreateCategoryRule(ctx context.Context, req CategoryRuleRequest) (sqlc.CategoryRule, error) {
+	pattern := strings.TrimSpace(req.DescriptionContains)
+	if (pattern == "") == (req.CounterpartyAccountID == uuid.Nil) || len(pattern) > maxCategoryPattern {
+		return sqlc.CategoryRule{}, ErrInvalidCategoryRule
+	}
+	if _, err := s.userCategory(ctx, req.UserID, req.CategoryID); err != nil {
+		return sqlc.CategoryRule{}, err
+	}
+
+	params := sqlc.CreateCategoryRuleParams{
+		UserID:     req.UserID,
+		CategoryID: req.CategoryID,
+		Priority:   req.Priority,
+	}
+	if pattern != "" {
+		params.DescriptionPattern = sql.NullString{String: pattern, Valid: true}
+	} else {
+		acc, err := s.store.GetAccount(ctx, req.CounterpartyAccountID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && acc.OrgID.UUID != req.OrgID) {
+			// Accounts of other tenants are indistinguishable from missing ones.
+			return sqlc.CategoryRule{}, ErrAccountNotFound
+		}
+		if err != nil {
+			return sqlc.CategoryRule{}, err
+		}
+		params.CounterpartyAccountID = uuid.NullUUID{UUID: acc.ID, Valid: true}
+	}
+	var rule sqlc.CategoryRule
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		if rule, err = q.CreateCategoryRule(ctx, params); err != nil {
+			return err
+		}
+		return q.RefreshUserHistoryCategories(ctx, req.UserID)
+	})
+	return rule, err
+}
+
+// DeleteCategoryRule removes one of the user's rules and reports whether it existed.
+func (s *LedgerService) DeleteCategoryRule(ctx context.Context, userID, ruleID uuid.UUID) (bool, error) {
+	var n int64
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		if n, err = q.DeleteCategoryRule(ctx, sqlc.DeleteCategoryRuleParams{ID: ruleID, UserID: userID}); err != nil || n == 0 {
+			return err
+		}
+		return q.RefreshUserHistoryCategories(ctx, userID)
+	})
+	return n > 0, err
+}
+
+// DeleteCategory removes one of the user's categories with its rules and assignments.
+func (s *LedgerService) DeleteCategory(ctx context.Context, userID, categoryID uuid.UUID) error {
+	return s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		n, err := q.DeleteCategory(ctx, sqlc.DeleteCategoryParams{ID: categoryID, UserID: userID})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrCategoryNotFound
+		}
+		return q.RefreshUserHistoryCategories(ctx, userID)
+	})
+}
+
+// SetEntryCategory files entryID under one of the user's categories, overriding any rule.
+// The caller checks the user can see the entry's account.
+func (s *LedgerService) SetEntryCategory(ctx context.Context, userID, entryID, categoryID uuid.UUID) (sqlc.EntryCategory, error) {
+	if _, err := s.userCategory(ctx, userID, categoryID); err != nil {
+		return sqlc.EntryCategory{}, err
+	}
+	var assigned sqlc.EntryCategory
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		assigned, err = q.SetEntryCategory(ctx, sqlc.SetEntryCategoryParams{
+			EntryID:    entryID,
+			UserID:     userID,
+			CategoryID: categoryID,
+		})
+		if err != nil {
+			return err
+		}
+		return q.RefreshEntryHistoryCategory(ctx, sqlc.RefreshEntryHistoryCategoryParams{EntryID: entryID, UserID: userID})
+	})
+	return assigned, err
+}
+
+// ClearEntryCategory drops the user's manual category for entryID, so rules apply to it again.
+func (s *LedgerService) ClearEntryCategory(ctx context.Context, userID, entryID uuid.UUID) error {
+	return s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		if _, err := q.ClearEntryCategory(ctx, sqlc.ClearEntryCategoryParams{EntryID: entryID, UserID: userID}); err != nil {
+			return err
+		}
+		return q.RefreshEntryHistoryCategory(ctx, sqlc.RefreshEntryHistoryCategoryParams{EntryID: entryID, UserID: userID})
+	})
+}
+
+// SpendByCategory totals the account's debits in [from, to) per UTC period and category as
+// seen by userID.
+func (s *LedgerService) SpendByCategory(ctx context.Context, userID, accountID uuid.UUID, period string, from, to time.Time) ([]sqlc.SpendByCategoryRow, error) {
+	switch period {
+	case "day", "week", "month":
+	default:
+		return nil, ErrInvalidSpendPeriod
+	}
+	return s.store.SpendByCategory(ctx, sqlc.SpendByCategoryParams{
+		Period:    period,
+		UserID:    userID,
+		AccountID: accountID,
+		FromTime:  from,
+		ToTime:    to,
+	})
+}
+
+// userCategory loads one of the user's categories.
+func (s *LedgerService) userCategory(ctx context.Context, userID, categoryID uuid.UUID) (sqlc.Category, error) {
+	category, err := s.store.GetCategory(ctx, sqlc.GetCategoryParams{ID: categoryID, UserID: userID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.Category{}, ErrCategoryNotFound
+	}
+	return category, err
+}
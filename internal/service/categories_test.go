@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCategoryRule_NeedsExactlyOneMatcher(t *testing.T) {
+	// A rule matches on a description pattern or a counterparty, never both or neither.
+	s := &LedgerService{}
+	for _, req := range []CategoryRuleRequest{
+		{CategoryID: uuid.New()},
+		{CategoryID: uuid.New(), DescriptionContains: "   "},
+		{CategoryID: uuid.New(), DescriptionContains: "uber", CounterpartyAccountID: uuid.New()},
+		{CategoryID: uuid.New(), DescriptionContains: strings.Repeat("a", maxCategoryPattern+1)},
+	} {
+		_, err := s.CreateCategoryRule(context.Background(), req)
+		assert.ErrorIs(t, err, ErrInvalidCategoryRule)
+	}
+}
+
+func TestSpendByCategory_RejectsUnknownPeriod(t *testing.T) {
+	// Only day, week and month reach date_trunc.
+	s := &LedgerService{}
+	_, err := s.SpendByCategory(context.Background(), uuid.New(), uuid.New(), "year", time.Now(), time.Now())
+	assert.ErrorIs(t, err, ErrInvalidSpendPeriod)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrChargeNotFound is returned when a provider confirms a reference we never issued.
+	ErrChargeNotFound = errors.New("payment charge not found")
+	// ErrChargeMismatch is returned when the confirmed amount or currency differs from the charge we created.
+	ErrChargeMismatch = errors.New("payment charge amount or currency mismatch")
+	// ErrChargeOverLimit is returned when crediting a confirmed charge would break the account's deposit limit.
+	ErrChargeOverLimit = errors.New("payment charge exceeds the account's deposit limit")
+)
+
+// SettleCharge posts the deposit for a provider-confirmed charge.
+// It is idempotent on reference: redelivered confirmations return the stored charge without posting again.
+// The deposit limit applies as for other deposits; a charge over it is marked failed for manual refund.
+// The money has already been collected, so AML screening can only flag the deposit for review.
+func (s *LedgerService) SettleCharge(ctx context.Context, reference, paidAmount, currency string) (sqlc.PaymentCharge, error) {
+	paid, err := decimal.NewFromString(paidAmount)
+	if err != nil {
+		return sqlc.PaymentCharge{}, ErrInvalidAmount
+	}
+
+	var (
+		charge    sqlc.PaymentCharge
+		evt       events.Event
+		sc        screening
+		posted    bool
+		mismatch  bool
+		overLimit bool
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the charge so concurrent webhook deliveries serialize here.
+		var err error
+		charge, err = q.GetPaymentChargeByReferenceForUpdate(ctx, reference)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrChargeNotFound
+			}
+			return err
+		}
+		posted, mismatch, overLimit = false, false, false
+		if charge.Status != "pending" {
+			return nil
+		}
+
+		// Step 2: Never credit more (or less, or in another currency) than the customer was asked to pay.
+		expected, err := decimal.NewFromString(charge.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid charge amount: %w", err)
+		}
+		if !expected.Equal(paid) || charge.Currency != currency {
+			mismatch = true
+			return q.MarkPaymentChargeFailed(ctx, reference)
+		}
+
+		err = checkDepositLimit(ctx, q, charge.AccountID, expected)
+		if errors.Is(err, ErrKYCRequired) || errors.Is(err, ErrKYCLimitExceeded) {
+			overLimit = true
+			return q.MarkPaymentChargeFailed(ctx, reference)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Post the deposit and link it to the charge in the same transaction.
+		sc, err = s.screenReceived(ctx, "deposit", charge.AccountID, expected)
+		if err != nil {
+			return err
+		}
+		evt, err = postDeposit(ctx, q, charge.AccountID, expected, fmt.Sprintf("Deposit via %s %s", charge.Provider, reference))
+		if err != nil {
+			return err
+		}
+		charge, err = q.MarkPaymentChargeSucceeded(ctx, sqlc.MarkPaymentChargeSucceededParams{
+			TransactionID: uuid.NullUUID{UUID: evt.TransactionID, Valid: true},
+			Reference:     reference,
+		})
+		posted = err == nil
+		return err
+	})
+	if err != nil {
+		return sqlc.PaymentCharge{}, err
+	}
+	if mismatch {
+		logger(ctx).Error().Str("reference", reference).Str("paid", paidAmount).Str("currency", currency).
+			Str("expected", charge.Amount).Str("expected_currency", charge.Currency).Msg("Payment charge mismatch; charge marked failed")
+		return charge, ErrChargeMismatch
+	}
+	if overLimit {
+		logger(ctx).Error().Str("reference", reference).Str("account_id", charge.AccountID.String()).
+			Str("amount", charge.Amount).Msg("Payment charge over deposit limit; charge marked failed")
+		return charge, ErrChargeOverLimit
+	}
+
+	if posted {
+		s.publish(ctx, evt)
+		s.recordFlag(ctx, sc, evt.TransactionID)
+	}
+	return charge, nil
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrDisputeNotFound is returned when a dispute does not exist.
+	ErrDisputeNotFound = errors.New("dispute not found")
+	// ErrDisputeNotOpen is returned when resolving a dispute that was already refunded or rejected.
+	ErrDisputeNotOpen = errors.New("dispute is not open")
+	// ErrAlreadyDisputed is returned when a transaction already has a dispute.
+	ErrAlreadyDisputed = errors.New("transaction already disputed")
+	// ErrTransactionNotDisputable is returned for transactions that are not a posted, single debit of a customer account.
+	ErrTransactionNotDisputable = errors.New("only a posted two-leg transaction that debited a customer account can be disputed")
+)
+
+// Dispute statuses stored on the disputes table.
+const (
+	DisputeOpen     = "open"
+	DisputeRefunded = "refunded"
+	DisputeRejected = "rejected"
+)
+
+// disputeLegs returns the debit and credit side of a disputable transaction.
+// Multi-leg postings and postings made by the dispute or reversal flows themselves are refused.
+func disputeLegs(entries []sqlc.Entry) (debit, credit sqlc.Entry, err error) {
+	if len(entries) != 2 {
+		return sqlc.Entry{}, sqlc.Entry{}, ErrTransactionNotDisputable
+	}
+	for _, e := range entries {
+		if e.OperationType == "dispute" || e.OperationType == "reversal" {
+			return sqlc.Entry{}, sqlc.Entry{}, ErrTransactionNotDisputable
+		}
+		if isPositive(e.Debit) {
+			debit = e
+		} else {
+			credit = e
+		}
+	}
+	if debit.ID == uuid.Nil || credit.ID == uuid.Nil {
+		return sqlc.Entry{}, sqlc.Entry{}, ErrTransactionNotDisputable
+	}
+	return debit, credit, nil
+}
+
+// isPositive reports whether a stored numeric string is greater than zero.
+func isPositive(v string) bool {
+	d, err := decimal.NewFromString(v)
+	return err == nil && d.IsPositive()
+}
+
+// DisputedAccount returns the account a transaction debited, which is the only one that may dispute it.
+func (s *LedgerService) DisputedAccount(ctx context.Context, transactionID uuid.UUID) (sqlc.Account, error) {
+	entries, err := s.store.ListEntriesByTransaction(ctx, transactionID)
+	if err != nil {
+		return sqlc.Account{}, err
+	}
+	if len(entries) == 0 {
+		return sqlc.Account{}, ErrTransactionNotDisputable
+	}
+	debit, _, err := disputeLegs(entries)
+	if err != nil {
+		return sqlc.Account{}, err
+	}
+	acc, err := s.store.GetAccount(ctx, debit.AccountID)
+	if err != nil {
+		return sqlc.Account{}, err
+	}
+	if acc.IsSystem {
+		return sqlc.Account{}, ErrTransactionNotDisputable
+	}
+	return acc, nil
+}
+
+// OpenDispute disputes a transaction on behalf of the account it debited and moves the
+// disputed amount from the credited account into the Disputes Holding account.
+func (s *LedgerService) OpenDispute(ctx context.Context, transactionID, openedBy uuid.UUID, reason string) (sqlc.Dispute, error) {
+	var (
+		dispute sqlc.Dispute
+		evt     events.Event
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: One dispute per transaction.
+		if _, err := q.GetDisputeByTransaction(ctx, transactionID); err == nil {
+			return ErrAlreadyDisputed
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		// Step 2: The transaction must be a posted, plain debit of a customer account.
+		tx, err := q.GetTransaction(ctx, transactionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrTransactionNotDisputable
+			}
+			return err
+		}
+		if tx.Status != TransactionPosted {
+			return ErrTransactionNotDisputable
+		}
+		// A dispute holds the whole amount, so money already refunded cannot be disputed again.
+		refunded, err := q.SumTransactionRefunds(ctx, transactionID)
+		if err != nil {
+			return err
+		}
+		if isPositive(refunded) {
+			return ErrTransactionNotDisputable
+		}
+		entries, err := q.ListEntriesByTransaction(ctx, transactionID)
+		if err != nil {
+			return err
+		}
+		debit, credit, err := disputeLegs(entries)
+		if err != nil {
+			return err
+		}
+		disputed, err := q.GetAccount(ctx, debit.AccountID)
+		if err != nil {
+			return err
+		}
+		if disputed.IsSystem {
+			return ErrTransactionNotDisputable
+		}
+		amount, err := decimal.NewFromString(debit.Debit)
+		if err != nil {
+			return fmt.Errorf("invalid disputed amount: %w", err)
+		}
+
+		// Step 3: Lock the credited account, then holding, and freeze the amount.
+		counterparty, err := q.GetAccountForUpdate(ctx, credit.AccountID)
+		if err != nil {
+			return err
+		}
+		holding, err := q.GetDisputesHoldingAccountForUpdate(ctx)
+		if err != nil {
+			return fmt.Errorf("disputes holding account not found: %w", err)
+		}
+		if counterparty.Currency != disputed.Currency || holding.Currency != disputed.Currency {
+			return ErrCurrencyMismatch
+		}
+		// Customer accounts never go negative; system accounts (e.g., settlement) may.
+		if !counterparty.IsSystem {
+			balance, err := decimal.NewFromString(counterparty.Balance)
+			if err != nil {
+				return errors.New("invalid counterparty balance")
+			}
+			if balance.LessThan(amount) {
+				return insufficientFunds("dispute")
+			}
+		}
+
+		holdTxID := uuid.New()
+		postings, balances, err := postLegs(ctx, q, holdTxID, "dispute",
+			debitLeg(counterparty, amount, fmt.Sprintf("Disputed transaction %s held", transactionID)),
+			creditLeg(holding, amount, fmt.Sprintf("Dispute hold for transaction %s", transactionID)),
+		)
+		if err != nil {
+			return err
+		}
+		dispute, err = q.CreateDispute(ctx, sqlc.CreateDisputeParams{
+			TransactionID:         transactionID,
+			AccountID:             disputed.ID,
+			CounterpartyAccountID: counterparty.ID,
+			Amount:                amount.StringFixed(4),
+			Currency:              disputed.Currency,
+			Reason:                reason,
+			OpenedBy:              openedBy,
+			HoldTransactionID:     holdTxID,
+		})
+		if err != nil {
+			return err
+		}
+		evt = events.Event{
+			Type:          events.TypeDispute,
+			TransactionID: holdTxID,
+			Amount:        amount.StringFixed(4),
+			Currency:      disputed.Currency,
+			Entries:       postings,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.Dispute{}, err
+	}
+
+	logger(ctx).Info().Str("dispute_id", dispute.ID.String()).Str("transaction_id", transactionID.String()).Str("opened_by", openedBy.String()).Str("amount", dispute.Amount).Msg("Dispute opened")
+	s.publish(ctx, evt)
+	return dispute, nil
+}
+
+// ResolveDispute releases held funds: a refund credits the disputing account, a rejection
+// returns them to the counterparty. Either way the dispute closes in the same transaction.
+func (s *LedgerService) ResolveDispute(ctx context.Context, disputeID, resolvedBy uuid.UUID, refund bool, note string) (sqlc.Dispute, error) {
+	var (
+		dispute sqlc.Dispute
+		evt     events.Event
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the dispute so two operators cannot resolve it twice.
+		var err error
+		dispute, err = q.GetDisputeForUpdate(ctx, disputeID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrDisputeNotFound
+			}
+			return err
+		}
+		if dispute.Status != DisputeOpen {
+			return ErrDisputeNotOpen
+		}
+		amount, err := decimal.NewFromString(dispute.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid dispute amount: %w", err)
+		}
+
+		// Step 2: Lock holding, then whoever receives the funds.
+		holding, err := q.GetDisputesHoldingAccountForUpdate(ctx)
+		if err != nil {
+			return fmt.Errorf("disputes holding account not found: %w", err)
+		}
+		status, targetID, description := DisputeRejected, dispute.CounterpartyAccountID, fmt.Sprintf("Dispute %s rejected", dispute.ID)
+		if refund {
+			status, targetID, description = DisputeRefunded, dispute.AccountID, fmt.Sprintf("Dispute %s refunded", dispute.ID)
+		}
+		target, err := q.GetAccountForUpdate(ctx, targetID)
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Release the hold and close the dispute.
+		txID := uuid.New()
+		postings, balances, err := postLegs(ctx, q, txID, "dispute",
+			debitLeg(holding, amount, fmt.Sprintf("Dispute %s released", dispute.ID)),
+			creditLeg(target, amount, description),
+		)
+		if err != nil {
+			return err
+		}
+		// A refund undoes the disputed transaction.
+		if refund {
+			if err := transitionTransaction(ctx, q, dispute.TransactionID, TransactionPosted, TransactionReversed); err != nil {
+				return err
+			}
+		}
+		dispute, err = q.ResolveDispute(ctx, sqlc.ResolveDisputeParams{
+			Status:                  status,
+			ResolvedBy:              uuid.NullUUID{UUID: resolvedBy, Valid: true},
+			ResolutionNote:          note,
+			ResolutionTransactionID: uuid.NullUUID{UUID: txID, Valid: true},
+			ID:                      dispute.ID,
+		})
+		if err != nil {
+			return err
+		}
+		evt = events.Event{
+			Type:          events.TypeDispute,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      dispute.Currency,
+			Entries:       postings,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.Dispute{}, err
+	}
+
+	logger(ctx).Info().Str("dispute_id", dispute.ID.String()).Str("status", dispute.Status).Str("resolved_by", resolvedBy.String()).Msg("Dispute resolved")
+	s.publish(ctx, evt)
+	return dispute, nil
+}
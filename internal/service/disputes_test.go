@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func disputeEntry(op, debit, credit string) sqlc.Entry {
+	return sqlc.Entry{ID: uuid.New(), AccountID: uuid.New(), OperationType: op, Debit: debit, Credit: credit}
+}
+
+func TestDisputeLegs(t *testing.T) {
+	// A plain two-leg transfer splits into its debit and credit sides.
+	out := disputeEntry("transfer", "25.0000", "0.0000")
+	in := disputeEntry("transfer", "0.0000", "25.0000")
+	debit, credit, err := disputeLegs([]sqlc.Entry{in, out})
+	require.NoError(t, err)
+	assert.Equal(t, out.ID, debit.ID)
+	assert.Equal(t, in.ID, credit.ID)
+}
+
+func TestDisputeLegs_RejectsNonDisputable(t *testing.T) {
+	// Multi-leg postings and the dispute flow's own postings cannot be disputed.
+	fee := disputeEntry("transfer", "1.0000", "0.0000")
+	_, _, err := disputeLegs([]sqlc.Entry{fee, fee, fee})
+	assert.ErrorIs(t, err, ErrTransactionNotDisputable)
+
+	_, _, err = disputeLegs([]sqlc.Entry{
+		disputeEntry("dispute", "25.0000", "0.0000"),
+		disputeEntry("dispute", "0.0000", "25.0000"),
+	})
+	assert.ErrorIs(t, err, ErrTransactionNotDisputable)
+
+	_, _, err = disputeLegs([]sqlc.Entry{
+		disputeEntry("transfer", "0.0000", "25.0000"),
+		disputeEntry("transfer", "0.0000", "25.0000"),
+	})
+	assert.ErrorIs(t, err, ErrTransactionNotDisputable)
+}
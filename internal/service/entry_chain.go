@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/hashchain"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// entryChainPageSize is how many chained entries VerifyEntryChain reads per query.
+const entryChainPageSize = 1000
+
+// ChainProblemHead is reported when an account's recorded chain head does not match its newest
+// entry, as when the latest entries were deleted.
+const ChainProblemHead = "head_mismatch"
+
+// ChainBreak is a place where an account's entry hash chain does not hold. EntryID is unset when
+// the account's head is wrong and no entry is left to blame.
+type ChainBreak struct {
+	AccountID uuid.UUID
+	EntryID   uuid.NullUUID
+	Seq       int64
+	Problems  []string
+}
+
+// ChainVerification reports a walk of the entry hash chains: how many accounts and entries were
+// checked and every break found.
+type ChainVerification struct {
+	Breaks   []ChainBreak
+	Accounts int
+	Entries  int64
+}
+
+// Intact reports whether no break was found.
+func (v ChainVerification) Intact() bool {
+	return len(v.Breaks) == 0
+}
+
+// VerifyEntryChain recomputes the hash chain of every account's entries, or only accountID's, and
+// reports each entry that was changed, removed or reordered after it was posted. An account whose
+// oldest months were pruned picks its chain up from the last link archived. Nothing is written and
+// postings carry on while it runs.
+func (s *LedgerService) VerifyEntryChain(ctx context.Context, accountID uuid.NullUUID) (ChainVerification, error) {
+	links, err := s.store.ListPrunedChainLinks(ctx, accountID)
+	if err != nil {
+		return ChainVerification{}, err
+	}
+	v := chainVerifier{pruned: make(map[uuid.UUID]hashchain.Link, len(links))}
+	for _, l := range links {
+		v.pruned[l.AccountID] = hashchain.Link{Seq: l.LastChainSeq, Hash: l.LastHash}
+	}
+	params := sqlc.ListEntryChainParams{AccountID: accountID, RowLimit: entryChainPageSize}
+	for {
+		rows, err := s.store.ListEntryChain(ctx, params)
+		if err != nil {
+			return ChainVerification{}, err
+		}
+		for _, row := range rows {
+			v.add(row)
+		}
+		if len(rows) < entryChainPageSize {
+			break
+		}
+		last := rows[len(rows)-1]
+		params.AfterAccountID, params.AfterSeq = last.AccountID, last.ChainSeq
+	}
+	v.closeAccount()
+
+	orphans, err := s.store.ListOrphanEntryChainHeads(ctx, accountID)
+	if err != nil {
+		return ChainVerification{}, err
+	}
+	for _, h := range orphans {
+		v.result.Accounts++
+		// Every entry of the account was pruned; its head must still be the last link archived.
+		if l, ok := v.pruned[h.AccountID]; ok && l.Seq == h.ChainSeq && bytes.Equal(l.Hash, h.Hash) {
+			continue
+		}
+		v.result.Breaks = append(v.result.Breaks, ChainBreak{AccountID: h.AccountID, Seq: h.ChainSeq, Problems: []string{ChainProblemHead}})
+	}
+
+	if !v.result.Intact() {
+		logger(ctx).Error().Int("breaks", len(v.result.Breaks)).Int64("entries", v.result.Entries).
+			Msg("Entry hash chain is broken")
+	}
+	return v.result, nil
+}
+
+// chainVerifier checks rows in chain order, one account after another.
+type chainVerifier struct {
+	result ChainVerification
+	// last is the current account's latest row, nil before the first.
+	last *sqlc.ListEntryChainRow
+	// pruned holds the last pruned link of each account with pruned entries, which the account's
+	// first row follows.
+	pruned map[uuid.UUID]hashchain.Link
+}
+
+func (v *chainVerifier) add(row sqlc.ListEntryChainRow) {
+	var prev *hashchain.Link
+	if v.last != nil && v.last.AccountID == row.AccountID {
+		prev = &hashchain.Link{Seq: v.last.ChainSeq, Hash: v.last.Hash}
+	} else {
+		v.closeAccount()
+		v.result.Accounts++
+		if l, ok := v.pruned[row.AccountID]; ok {
+			prev = &l
+		}
+	}
+	v.result.Entries++
+
+	entry := hashchain.Entry{
+		ID:            row.ID,
+		AccountID:     row.AccountID,
+		TransactionID: row.TransactionID,
+		Seq:           row.ChainSeq,
+		Debit:         row.Debit,
+		Credit:        row.Credit,
+		OperationType: row.OperationType,
+		Description:   row.Description,
+		CreatedAt:     row.CreatedAt,
+	}
+	if problems := hashchain.Check(entry, row.PrevHash, row.Hash, prev); len(problems) > 0 {
+		v.result.Breaks = append(v.result.Breaks, ChainBreak{
+			AccountID: row.AccountID,
+			EntryID:   uuid.NullUUID{UUID: row.ID, Valid: true},
+			Seq:       row.ChainSeq,
+			Problems:  problems,
+		})
+	}
+	v.last = &row
+}
+
+// closeAccount checks that the current account's head points at its newest entry.
+func (v *chainVerifier) closeAccount() {
+	last := v.last
+	v.last = nil
+	if last == nil {
+		return
+	}
+	if last.HeadSeq.Valid && last.HeadSeq.Int64 == last.ChainSeq && bytes.Equal(last.HeadHash, last.Hash) {
+		return
+	}
+	v.result.Breaks = append(v.result.Breaks, ChainBreak{
+		AccountID: last.AccountID,
+		EntryID:   uuid.NullUUID{UUID: last.ID, Valid: true},
+		Seq:       last.ChainSeq,
+		Problems:  []string{ChainProblemHead},
+	})
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/hashchain"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// chainRows builds n correctly chained rows for one account, with the head at the last.
+func chainRows(accountID uuid.UUID, n int) []sqlc.ListEntryChainRow {
+	rows := make([]sqlc.ListEntryChainRow, 0, n)
+	var prev []byte
+	for i := 1; i <= n; i++ {
+		row := sqlc.ListEntryChainRow{
+			ID:            uuid.New(),
+			AccountID:     accountID,
+			TransactionID: uuid.New(),
+			ChainSeq:      int64(i),
+			Debit:         "0.0000",
+			Credit:        "10.0000",
+			OperationType: "deposit",
+			CreatedAt:     sql.NullTime{Time: time.Now(), Valid: true},
+			PrevHash:      prev,
+		}
+		row.Hash = hashchain.Hash(prev, hashchain.Entry{
+			ID: row.ID, AccountID: accountID, TransactionID: row.TransactionID, Seq: row.ChainSeq,
+			Debit: row.Debit, Credit: row.Credit, OperationType: row.OperationType, CreatedAt: row.CreatedAt,
+		})
+		prev = row.Hash
+		rows = append(rows, row)
+	}
+	for i := range rows {
+		rows[i].HeadSeq = sql.NullInt64{Int64: int64(n), Valid: true}
+		rows[i].HeadHash = prev
+	}
+	return rows
+}
+
+func verifyRows(rows []sqlc.ListEntryChainRow) ChainVerification {
+	var v chainVerifier
+	for _, row := range rows {
+		v.add(row)
+	}
+	v.closeAccount()
+	return v.result
+}
+
+func TestChainVerifier(t *testing.T) {
+	// Intact chains pass; an edited entry and a dropped newest entry are each reported.
+	a, b := uuid.New(), uuid.New()
+	rows := append(chainRows(a, 3), chainRows(b, 2)...)
+	result := verifyRows(rows)
+	assert.True(t, result.Intact())
+	assert.Equal(t, 2, result.Accounts)
+	assert.Equal(t, int64(5), result.Entries)
+
+	edited := append([]sqlc.ListEntryChainRow(nil), rows...)
+	edited[1].Credit = "1000.0000"
+	result = verifyRows(edited)
+	require.Len(t, result.Breaks, 1)
+	assert.Equal(t, rows[1].ID, result.Breaks[0].EntryID.UUID)
+	assert.Equal(t, []string{hashchain.ProblemHash}, result.Breaks[0].Problems)
+
+	truncated := append(append([]sqlc.ListEntryChainRow(nil), rows[:2]...), rows[3:]...)
+	result = verifyRows(truncated)
+	require.Len(t, result.Breaks, 1)
+	assert.Equal(t, a, result.Breaks[0].AccountID)
+	assert.Equal(t, []string{ChainProblemHead}, result.Breaks[0].Problems)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// KindUserErasures is the background job kind that anonymizes users whose grace period ended.
+const KindUserErasures = "users.erasure"
+
+// DefaultErasureGracePeriod is how long an erasure request can be cancelled before it runs.
+const DefaultErasureGracePeriod = 30 * 24 * time.Hour
+
+// User erasure statuses stored on the user_erasures table.
+const (
+	ErasurePending   = "pending"
+	ErasureCancelled = "cancelled"
+	ErasureCompleted = "completed"
+)
+
+var (
+	// ErrUserNotFound is returned when a user does not exist.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrErasurePending is returned when the user already has an open erasure request.
+	ErrErasurePending = errors.New("user already has a pending erasure request")
+	// ErrErasureNotPending is returned when cancelling without an open erasure request.
+	ErrErasureNotPending = errors.New("user has no pending erasure request")
+	// ErrErasureBalance is returned while an account the user owns still holds money.
+	ErrErasureBalance = errors.New("accounts the user owns must have a zero balance before erasure")
+	// ErrUserErased is returned for a user whose personal data was already erased.
+	ErrUserErased = errors.New("user was already erased")
+)
+
+// WithErasureGracePeriod sets how long erasure requests wait, and can be cancelled, before they run.
+func WithErasureGracePeriod(d time.Duration) Option {
+	return func(s *LedgerService) {
+		s.erasureGrace = d
+	}
+}
+
+// RequestErasure schedules userID's personal data for erasure once the grace period ends.
+// requestedBy is the user themselves or an admin acting on their behalf.
+func (s *LedgerService) RequestErasure(ctx context.Context, userID, requestedBy uuid.UUID, reason string) (sqlc.UserErasure, error) {
+	grace := s.erasureGrace
+	if grace <= 0 {
+		grace = DefaultErasureGracePeriod
+	}
+
+	var erasure sqlc.UserErasure
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: The user must exist, not be erased yet and not owe or be owed money.
+		user, err := q.GetUser(ctx, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+		if user.ErasedAt.Valid {
+			return ErrUserErased
+		}
+		funded, err := q.HasOwnedBalance(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+		if err != nil {
+			return err
+		}
+		if funded {
+			return ErrErasureBalance
+		}
+
+		// Step 2: Queue it, one open request per user.
+		if _, err := q.GetPendingUserErasure(ctx, userID); err == nil {
+			return ErrErasurePending
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		erasure, err = q.CreateUserErasure(ctx, sqlc.CreateUserErasureParams{
+			UserID:      userID,
+			Reason:      reason,
+			RequestedBy: requestedBy,
+			EraseAfter:  time.Now().UTC().Add(grace),
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.UserErasure{}, err
+	}
+
+	logger(ctx).Info().Str("erasure_id", erasure.ID.String()).Str("user_id", userID.String()).Str("requested_by", requestedBy.String()).Time("erase_after", erasure.EraseAfter).Msg("User erasure requested")
+	return erasure, nil
+}
+
+// CancelErasure withdraws userID's pending erasure request during its grace period.
+func (s *LedgerService) CancelErasure(ctx context.Context, userID, cancelledBy uuid.UUID) (sqlc.UserErasure, error) {
+	erasure, err := s.store.CancelUserErasure(ctx, sqlc.CancelUserErasureParams{
+		CancelledBy: uuid.NullUUID{UUID: cancelledBy, Valid: true},
+		UserID:      userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.UserErasure{}, ErrErasureNotPending
+		}
+		return sqlc.UserErasure{}, err
+	}
+
+	logger(ctx).Info().Str("erasure_id", erasure.ID.String()).Str("user_id", userID.String()).Str("cancelled_by", cancelledBy.String()).Msg("User erasure cancelled")
+	return erasure, nil
+}
+
+// ProcessErasures is a jobs.HandlerFunc that erases every user whose grace period has ended.
+// Users who still hold money stay pending and are retried on the next run.
+func (s *LedgerService) ProcessErasures(ctx context.Context, _ json.RawMessage) error {
+	const batch = 100
+	var (
+		errs   []error
+		after  uuid.UUID
+		erased int
+	)
+	dueAt := time.Now().UTC()
+	for {
+		ids, err := s.store.ListDueUserErasureIDs(ctx, sqlc.ListDueUserErasureIDsParams{DueAt: dueAt, AfterID: after, RowLimit: batch})
+		if err != nil {
+			return errors.Join(append(errs, fmt.Errorf("list due erasures: %w", err))...)
+		}
+		for _, id := range ids {
+			after = id
+			erasure, err := s.eraseUser(ctx, id)
+			switch {
+			case errors.Is(err, ErrErasureBalance):
+				logger(ctx).Warn().Str("erasure_id", id.String()).Msg("User erasure postponed until the user's accounts are empty")
+			case err != nil:
+				logger(ctx).Error().Err(err).Str("erasure_id", id.String()).Msg("Failed to erase user")
+				errs = append(errs, err)
+			case erasure.Status == ErasureCompleted:
+				erased++
+				logger(ctx).Info().Str("erasure_id", id.String()).Str("user_id", erasure.UserID.String()).Msg("User erased")
+			}
+		}
+		if len(ids) < batch {
+			break
+		}
+	}
+
+	logger(ctx).Info().Int("erased", erased).Int("failed", len(errs)).Msg("User erasures processed")
+	return errors.Join(errs...)
+}
+
+// eraseUser anonymizes the user of a due erasure in one transaction: their profile, login, KYC
+// document details, statement recipients, their name in account history, remembered devices and
+// countries, transaction notes, and access to accounts other users own. Their own
+// accounts, transactions and entries are kept for accounting retention.
+func (s *LedgerService) eraseUser(ctx context.Context, id uuid.UUID) (sqlc.UserErasure, error) {
+	var erasure sqlc.UserErasure
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the request; a cancellation since it was listed wins.
+		var err error
+		if erasure, err = q.GetUserErasureForUpdate(ctx, id); err != nil {
+			return err
+		}
+		if erasure.Status != ErasurePending {
+			return nil
+		}
+		funded, err := q.HasOwnedBalance(ctx, uuid.NullUUID{UUID: erasure.UserID, Valid: true})
+		if err != nil {
+			return err
+		}
+		if funded {
+			return ErrErasureBalance
+		}
+
+		// Step 2: Replace the personal data; a user erased by an earlier attempt is left as is.
+		if _, err := q.AnonymizeUser(ctx, erasure.UserID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if err := q.RefreshCounterpartyNames(ctx, erasure.UserID); err != nil {
+			return err
+		}
+		if err := q.AnonymizeKYCRecord(ctx, erasure.UserID); err != nil {
+			return err
+		}
+		if err := q.AnonymizeStatementRecipients(ctx, uuid.NullUUID{UUID: erasure.UserID, Valid: true}); err != nil {
+			return err
+		}
+		if err := q.RemoveCoOwnerships(ctx, erasure.UserID); err != nil {
+			return err
+		}
+		if _, err := q.RevokeUserSessions(ctx, erasure.UserID); err != nil {
+			return err
+		}
+		if err := q.DeleteKnownDevices(ctx, erasure.UserID); err != nil {
+			return err
+		}
+		if err := q.DeleteUserLocations(ctx, erasure.UserID); err != nil {
+			return err
+		}
+		if err := q.DeleteUserAnnotations(ctx, erasure.UserID); err != nil {
+			return err
+		}
+
+		// Step 3: Close the request as the audit record of the erasure.
+		erasure, err = q.CompleteUserErasure(ctx, erasure.ID)
+		return err
+	})
+	return erasure, err
+}
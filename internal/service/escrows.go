@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrEscrowNotFound is returned when an escrow does not exist.
+	ErrEscrowNotFound = errors.New("escrow not found")
+	// ErrEscrowNotFunded is returned when settling an escrow that was already released or refunded.
+	ErrEscrowNotFunded = errors.New("escrow already released or refunded")
+)
+
+// Escrow statuses stored on the escrows table.
+const (
+	EscrowFunded   = "funded"
+	EscrowReleased = "released"
+	EscrowRefunded = "refunded"
+)
+
+// escrowHoldingAccount holds funded escrows. One exists per currency, created on first use.
+const escrowHoldingAccount = "Escrow Holding"
+
+// EscrowRequest is a buyer putting amount aside for a seller's account.
+type EscrowRequest struct {
+	Amount          string
+	Description     string
+	BuyerAccountID  uuid.UUID
+	SellerAccountID uuid.UUID
+	CreatedBy       uuid.UUID
+}
+
+// CreateEscrow moves the amount from the buyer into the Escrow Holding account of its
+// currency and records the escrow against that funding transaction. The funding is screened for
+// AML as an outbound payment to the seller.
+func (s *LedgerService) CreateEscrow(ctx context.Context, req EscrowRequest) (sqlc.Escrow, error) {
+	amount, err := validatePositiveAmount(req.Amount)
+	if err != nil {
+		return sqlc.Escrow{}, err
+	}
+	if req.BuyerAccountID == req.SellerAccountID {
+		return sqlc.Escrow{}, ErrSameAccountTransfer
+	}
+	// A held funding could only be released as a plain transfer, so it is refused instead.
+	sc, err := s.screen(ctx, "escrow", req.BuyerAccountID, uuid.NullUUID{UUID: req.SellerAccountID, Valid: true}, aml.Outbound, amount, false)
+	if err != nil {
+		return sqlc.Escrow{}, err
+	}
+
+	var (
+		escrow sqlc.Escrow
+		evt    events.Event
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock both parties and check they can trade with each other.
+		buyer, seller, err := lockAccountPair(ctx, q, req.BuyerAccountID, req.SellerAccountID)
+		if err != nil {
+			return err
+		}
+		if !buyer.OrgID.Valid || buyer.OrgID != seller.OrgID {
+			return ErrCrossOrgTransfer
+		}
+		if buyer.Currency != seller.Currency {
+			return ErrCurrencyMismatch
+		}
+		// Funding follows the buyer's transfer rules. A refund returns the full amount, so no fee is charged.
+		if _, err := checkSpendable(ctx, q, buyer, amount, debitTransfer); err != nil {
+			return err
+		}
+		if err := checkGoalLock(ctx, q, buyer); err != nil {
+			return err
+		}
+
+		// Step 2: Move the funds into holding and record the escrow.
+		holding, err := lockSystemAccount(ctx, q, escrowHoldingAccount, buyer.Currency)
+		if err != nil {
+			return err
+		}
+		txID := uuid.New()
+		postings, balances, err := postLegs(ctx, q, txID, "escrow",
+			debitLeg(buyer, amount, fmt.Sprintf("Escrow for %s: %s", seller.ID, req.Description)),
+			creditLeg(holding, amount, fmt.Sprintf("Escrow funded by %s", buyer.ID)),
+		)
+		if err != nil {
+			return err
+		}
+		escrow, err = q.CreateEscrow(ctx, sqlc.CreateEscrowParams{
+			OrgID:                buyer.OrgID.UUID,
+			BuyerAccountID:       buyer.ID,
+			SellerAccountID:      seller.ID,
+			Amount:               amount.StringFixed(4),
+			Currency:             buyer.Currency,
+			Description:          req.Description,
+			CreatedBy:            req.CreatedBy,
+			FundingTransactionID: txID,
+		})
+		if err != nil {
+			return err
+		}
+		evt = events.Event{
+			Type:          events.TypeEscrow,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      buyer.Currency,
+			Entries:       postings,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.Escrow{}, err
+	}
+
+	logger(ctx).Info().Str("escrow_id", escrow.ID.String()).Str("buyer", escrow.BuyerAccountID.String()).Str("seller", escrow.SellerAccountID.String()).Str("amount", escrow.Amount).Msg("Escrow funded")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, escrow.FundingTransactionID)
+	return escrow, nil
+}
+
+// SettleEscrow pays a funded escrow out of holding: a release credits the seller, a refund
+// credits the buyer. Either way the escrow closes in the same transaction.
+func (s *LedgerService) SettleEscrow(ctx context.Context, escrowID, settledBy uuid.UUID, release bool, note string) (sqlc.Escrow, error) {
+	var (
+		escrow sqlc.Escrow
+		evt    events.Event
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the escrow so it cannot be released and refunded at once.
+		var err error
+		escrow, err = q.GetEscrowForUpdate(ctx, escrowID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrEscrowNotFound
+			}
+			return err
+		}
+		if escrow.Status != EscrowFunded {
+			return ErrEscrowNotFunded
+		}
+		amount, err := decimal.NewFromString(escrow.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid escrow amount: %w", err)
+		}
+
+		// Step 2: Lock whoever receives the funds, then holding.
+		status, targetID, description := EscrowRefunded, escrow.BuyerAccountID, fmt.Sprintf("Escrow %s refunded", escrow.ID)
+		if release {
+			status, targetID, description = EscrowReleased, escrow.SellerAccountID, fmt.Sprintf("Escrow %s released: %s", escrow.ID, escrow.Description)
+		}
+		target, err := q.GetAccountForUpdate(ctx, targetID)
+		if err != nil {
+			return err
+		}
+		holding, err := lockSystemAccount(ctx, q, escrowHoldingAccount, escrow.Currency)
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Pay out of holding and close the escrow.
+		txID := uuid.New()
+		postings, balances, err := postLegs(ctx, q, txID, "escrow",
+			debitLeg(holding, amount, fmt.Sprintf("Escrow %s %s", escrow.ID, status)),
+			creditLeg(target, amount, description),
+		)
+		if err != nil {
+			return err
+		}
+		escrow, err = q.SettleEscrow(ctx, sqlc.SettleEscrowParams{
+			Status:                  status,
+			SettledBy:               uuid.NullUUID{UUID: settledBy, Valid: true},
+			SettlementNote:          note,
+			SettlementTransactionID: uuid.NullUUID{UUID: txID, Valid: true},
+			ID:                      escrow.ID,
+		})
+		if err != nil {
+			return err
+		}
+		evt = events.Event{
+			Type:          events.TypeEscrow,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      escrow.Currency,
+			Entries:       postings,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.Escrow{}, err
+	}
+
+	logger(ctx).Info().Str("escrow_id", escrow.ID.String()).Str("status", escrow.Status).Str("settled_by", settledBy.String()).Msg("Escrow settled")
+	s.publish(ctx, evt)
+	return escrow, nil
+}
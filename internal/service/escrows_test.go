@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateEscrow_ValidatesBeforePosting(t *testing.T) {
+	// Bad amounts and self-escrows are refused before any database work.
+	s := &LedgerService{}
+	id := uuid.New()
+
+	_, err := s.CreateEscrow(context.Background(), EscrowRequest{BuyerAccountID: id, SellerAccountID: uuid.New(), Amount: "-5"})
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = s.CreateEscrow(context.Background(), EscrowRequest{BuyerAccountID: id, SellerAccountID: id, Amount: "5"})
+	assert.ErrorIs(t, err, ErrSameAccountTransfer)
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/rates"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrConversionUnavailable is returned when no rate source is configured.
+	ErrConversionUnavailable = errors.New("currency conversion is not configured")
+	// ErrSameCurrencyConversion is returned when both accounts use the same currency; use a transfer.
+	ErrSameCurrencyConversion = errors.New("accounts share a currency; use a transfer")
+	// ErrStaleRate is returned when the only available rate is older than the freshness window.
+	ErrStaleRate = errors.New("exchange rate is stale")
+)
+
+// System account names for conversions. One of each exists per currency.
+const (
+	fxPositionAccount = "FX Position"
+	fxIncomeAccount   = "FX Income"
+)
+
+// RateSource prices currency pairs. *rates.Service satisfies it.
+type RateSource interface {
+	Get(ctx context.Context, base, quote string) (rates.Rate, error)
+}
+
+// WithFX enables currency conversion priced by src, charging defaultSpreadBps on pairs
+// without a configured spread.
+func WithFX(src RateSource, defaultSpreadBps int32) Option {
+	return func(s *LedgerService) {
+		s.rates = src
+		s.defaultSpreadBps = defaultSpreadBps
+	}
+}
+
+// ConversionQuote prices selling SellAmount of SellCurrency for BuyCurrency.
+// The customer receives BuyAmount at CustomerRate; Margin is the bank's spread income in BuyCurrency.
+type ConversionQuote struct {
+	AsOf         time.Time
+	SellAmount   decimal.Decimal
+	BuyAmount    decimal.Decimal
+	Margin       decimal.Decimal
+	MidRate      decimal.Decimal
+	CustomerRate decimal.Decimal
+	SellCurrency string
+	BuyCurrency  string
+	RateSource   string
+	SpreadBps    int32
+}
+
+// priceConversion applies a spread to the mid rate. The bank keeps the rounding: the
+// customer amount is rounded down to the decimal places of buyCurrency.
+func priceConversion(amount, mid decimal.Decimal, spreadBps int32, buyCurrency string) (customerRate, buy, margin decimal.Decimal) {
+	factor := decimal.NewFromInt(1).Sub(decimal.New(int64(spreadBps), -4))
+	customerRate = mid.Mul(factor).Round(10)
+	gross := currencies.Round(buyCurrency, amount.Mul(mid))
+	buy = currencies.RoundDown(buyCurrency, amount.Mul(mid).Mul(factor))
+	return customerRate, buy, gross.Sub(buy)
+}
+
+// QuoteConversion prices a conversion without posting it.
+func (s *LedgerService) QuoteConversion(ctx context.Context, sellCurrency, buyCurrency string, amountStr string) (ConversionQuote, error) {
+	amount, err := decimal.NewFromString(amountStr)
+	if err != nil || !amount.IsPositive() {
+		return ConversionQuote{}, ErrInvalidAmount
+	}
+	return s.quoteConversion(ctx, sellCurrency, buyCurrency, amount)
+}
+
+func (s *LedgerService) quoteConversion(ctx context.Context, sellCurrency, buyCurrency string, amount decimal.Decimal) (ConversionQuote, error) {
+	if s.rates == nil {
+		return ConversionQuote{}, ErrConversionUnavailable
+	}
+	rate, err := s.rates.Get(ctx, sellCurrency, buyCurrency)
+	if err != nil {
+		return ConversionQuote{}, err
+	}
+	if rate.Base == rate.Quote {
+		return ConversionQuote{}, ErrSameCurrencyConversion
+	}
+	if rate.Stale {
+		return ConversionQuote{}, ErrStaleRate
+	}
+	if !currencies.Fits(rate.Base, amount) {
+		return ConversionQuote{}, ErrAmountPrecision
+	}
+
+	spreadBps := s.defaultSpreadBps
+	spread, err := s.store.GetFXSpread(ctx, sqlc.GetFXSpreadParams{BaseCurrency: rate.Base, QuoteCurrency: rate.Quote})
+	switch {
+	case err == nil:
+		spreadBps = spread.SpreadBps
+	case !errors.Is(err, sql.ErrNoRows):
+		return ConversionQuote{}, fmt.Errorf("load spread: %w", err)
+	}
+
+	customerRate, buy, margin := priceConversion(amount, rate.Rate, spreadBps, rate.Quote)
+	if !buy.IsPositive() {
+		// Too small to be worth anything in the target currency.
+		return ConversionQuote{}, ErrInvalidAmount
+	}
+	return ConversionQuote{
+		SellCurrency: rate.Base,
+		BuyCurrency:  rate.Quote,
+		SellAmount:   amount,
+		BuyAmount:    buy,
+		Margin:       margin,
+		MidRate:      rate.Rate,
+		CustomerRate: customerRate,
+		SpreadBps:    spreadBps,
+		RateSource:   rate.Source,
+		AsOf:         rate.AsOf,
+	}, nil
+}
+
+// Convert sells amount from one account for the currency of another account in the same
+// organization. Each currency balances through the FX Position account, and the spread is
+// credited to FX Income as its own leg. The amount sold is screened for AML before it posts.
+func (s *LedgerService) Convert(ctx context.Context, fromID, toID, requestedBy uuid.UUID, amountStr string) (sqlc.FxConversion, error) {
+	amount, err := decimal.NewFromString(amountStr)
+	if err != nil || !amount.IsPositive() {
+		return sqlc.FxConversion{}, ErrInvalidAmount
+	}
+	if fromID == toID {
+		return sqlc.FxConversion{}, ErrSameAccountTransfer
+	}
+
+	// Step 1: Price against the current accounts before taking any locks.
+	fromAcc, err := s.store.GetAccount(ctx, fromID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.FxConversion{}, ErrAccountNotFound
+		}
+		return sqlc.FxConversion{}, err
+	}
+	toAcc, err := s.store.GetAccount(ctx, toID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.FxConversion{}, ErrAccountNotFound
+		}
+		return sqlc.FxConversion{}, err
+	}
+	if fromAcc.Currency == toAcc.Currency {
+		return sqlc.FxConversion{}, ErrSameCurrencyConversion
+	}
+	quote, err := s.quoteConversion(ctx, fromAcc.Currency, toAcc.Currency, amount)
+	if err != nil {
+		return sqlc.FxConversion{}, err
+	}
+	// The quote would be stale by the time a review released it, so a hold refuses the conversion.
+	sc, err := s.screen(ctx, "conversion", fromID, uuid.NullUUID{UUID: toID, Valid: true}, aml.Outbound, amount, false)
+	if err != nil {
+		return sqlc.FxConversion{}, err
+	}
+
+	var (
+		conversion sqlc.FxConversion
+		evt        events.Event
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 2: Lock both customer accounts and re-check what pricing relied on.
+		from, to, err := lockAccountPair(ctx, q, fromID, toID)
+		if err != nil {
+			return err
+		}
+		if from.OrgID != to.OrgID {
+			return ErrCrossOrgTransfer
+		}
+		if from.Currency != quote.SellCurrency || to.Currency != quote.BuyCurrency {
+			return ErrCurrencyMismatch
+		}
+		balance, err := decimal.NewFromString(from.Balance)
+		if err != nil {
+			return errors.New("invalid from balance")
+		}
+		if balance.LessThan(amount) {
+			return insufficientFunds("conversion")
+		}
+		if err := checkGoalLock(ctx, q, from); err != nil {
+			return err
+		}
+
+		// Step 3: Lock the FX system accounts in currency order, so opposite conversions
+		// cannot deadlock, creating them for a new currency.
+		positions := make(map[string]sqlc.Account, 2)
+		for _, ccy := range sortedPair(quote.SellCurrency, quote.BuyCurrency) {
+			positions[ccy], err = lockSystemAccount(ctx, q, fxPositionAccount, ccy)
+			if err != nil {
+				return err
+			}
+		}
+		sellPosition, buyPosition := positions[quote.SellCurrency], positions[quote.BuyCurrency]
+		legs := []leg{
+			debitLeg(from, amount, fmt.Sprintf("Converted to %s %s", quote.BuyCurrency, quote.BuyAmount.StringFixed(4))),
+			creditLeg(sellPosition, amount, fmt.Sprintf("FX bought from %s", from.ID)),
+			debitLeg(buyPosition, quote.BuyAmount.Add(quote.Margin), fmt.Sprintf("FX sold to %s", to.ID)),
+			creditLeg(to, quote.BuyAmount, fmt.Sprintf("Converted from %s %s at %s", quote.SellCurrency, amount.StringFixed(4), quote.CustomerRate)),
+		}
+		if quote.Margin.IsPositive() {
+			income, err := lockSystemAccount(ctx, q, fxIncomeAccount, quote.BuyCurrency)
+			if err != nil {
+				return err
+			}
+			legs = append(legs, creditLeg(income, quote.Margin, fmt.Sprintf("FX spread %d bps on %s/%s", quote.SpreadBps, quote.SellCurrency, quote.BuyCurrency)))
+		}
+
+		// Step 4: Post and record the pricing under the same transaction ID.
+		txID := uuid.New()
+		postings, balances, err := postLegs(ctx, q, txID, "conversion", legs...)
+		if err != nil {
+			return err
+		}
+		conversion, err = q.CreateFXConversion(ctx, sqlc.CreateFXConversionParams{
+			TransactionID: txID,
+			FromAccountID: from.ID,
+			ToAccountID:   to.ID,
+			SellCurrency:  quote.SellCurrency,
+			SellAmount:    amount.StringFixed(4),
+			BuyCurrency:   quote.BuyCurrency,
+			BuyAmount:     quote.BuyAmount.StringFixed(4),
+			MidRate:       quote.MidRate.String(),
+			CustomerRate:  quote.CustomerRate.String(),
+			SpreadBps:     quote.SpreadBps,
+			Margin:        quote.Margin.StringFixed(4),
+			RateSource:    quote.RateSource,
+			RateAsOf:      quote.AsOf,
+			RequestedBy:   requestedBy,
+		})
+		if err != nil {
+			return err
+		}
+		evt = events.Event{
+			Type:          events.TypeConversion,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      quote.SellCurrency,
+			Entries:       postings,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.FxConversion{}, err
+	}
+
+	logger(ctx).Info().
+		Str("tx_id", conversion.TransactionID.String()).
+		Str("sell", conversion.SellAmount+" "+conversion.SellCurrency).
+		Str("buy", conversion.BuyAmount+" "+conversion.BuyCurrency).
+		Str("margin", conversion.Margin).
+		Msg("Currency converted")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, conversion.TransactionID)
+	return conversion, nil
+}
+
+// lockSystemAccount locks the named system account for currency, creating it on first use.
+func lockSystemAccount(ctx context.Context, q *sqlc.Queries, name, currency string) (sqlc.Account, error) {
+	if err := q.EnsureSystemAccount(ctx, sqlc.EnsureSystemAccountParams{Name: name, Currency: currency}); err != nil {
+		return sqlc.Account{}, fmt.Errorf("create %s account for %s: %w", name, currency, err)
+	}
+	acc, err := q.GetSystemAccountForUpdate(ctx, sqlc.GetSystemAccountForUpdateParams{Name: name, Currency: currency})
+	if err != nil {
+		return sqlc.Account{}, fmt.Errorf("%s account for %s not found: %w", name, currency, err)
+	}
+	return acc, nil
+}
+
+// sortedPair orders two currency codes so locks are always taken in the same order.
+func sortedPair(a, b string) [2]string {
+	if a > b {
+		return [2]string{b, a}
+	}
+	return [2]string{a, b}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceConversion_SpreadGoesToMargin(t *testing.T) {
+	// 100 bps off a 1500 mid leaves the customer 1485 per unit; the difference is the bank's margin.
+	rate, buy, margin := priceConversion(decimal.RequireFromString("10"), decimal.RequireFromString("1500"), 100, "NGN")
+	assert.Equal(t, "1485", rate.String())
+	assert.Equal(t, "14850.00", buy.StringFixed(2))
+	assert.Equal(t, "150.00", margin.StringFixed(2))
+}
+
+func TestPriceConversion_RoundsInBanksFavour(t *testing.T) {
+	// Sub-cent remainders are kept by the bank, and buy plus margin always equals the gross amount.
+	amount, mid := decimal.RequireFromString("1000"), decimal.RequireFromString("0.00066667")
+	_, buy, margin := priceConversion(amount, mid, 25, "USD")
+	assert.Equal(t, "0.66", buy.String())
+	assert.Equal(t, amount.Mul(mid).Round(2).String(), buy.Add(margin).String())
+	assert.False(t, margin.IsNegative())
+
+	// Yen has no minor unit, so the customer gets whole yen.
+	_, buy, margin = priceConversion(decimal.RequireFromString("10"), decimal.RequireFromString("149.87"), 50, "JPY")
+	assert.Equal(t, "1491", buy.String())
+	assert.Equal(t, "8", margin.String())
+}
+
+func TestPriceConversion_ZeroSpread(t *testing.T) {
+	// Without a spread the customer gets the mid rate and no income leg is needed.
+	rate, buy, margin := priceConversion(decimal.RequireFromString("2.5"), decimal.RequireFromString("0.9"), 0, "EUR")
+	assert.Equal(t, "0.9", rate.String())
+	assert.Equal(t, "2.25", buy.String())
+	assert.True(t, margin.IsZero())
+}
+
+func TestQuoteConversion_RequiresRateSource(t *testing.T) {
+	// Without WithFX conversions are refused before touching the store.
+	s := &LedgerService{}
+	_, err := s.QuoteConversion(context.Background(), "USD", "NGN", "10")
+	assert.ErrorIs(t, err, ErrConversionUnavailable)
+	_, err = s.QuoteConversion(context.Background(), "USD", "NGN", "-1")
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrInboundPaymentNotFound is returned when a suspense item does not exist.
+	ErrInboundPaymentNotFound = errors.New("inbound payment not found")
+	// ErrInboundPaymentNotUnmatched is returned when resolving a payment that is not in suspense.
+	ErrInboundPaymentNotUnmatched = errors.New("inbound payment is not awaiting resolution")
+
+	// errInboundReplay rolls back a posting whose event was recorded by a concurrent delivery.
+	errInboundReplay = errors.New("inbound payment already recorded")
+)
+
+// Inbound payment statuses stored on the inbound_payments table.
+const (
+	InboundCredited  = "credited"
+	InboundUnmatched = "unmatched"
+	InboundResolved  = "resolved"
+)
+
+// InboundCredit is money a provider reports as received for a virtual account.
+type InboundCredit struct {
+	Provider             string
+	EventID              string
+	VirtualAccountNumber string
+	Amount               string
+	Currency             string
+	PayerName            string
+	PayerReference       string
+}
+
+// ReceiveInboundPayment posts a provider-reported credit exactly once per (provider, event ID).
+// Credits for a known virtual account go to that account; anything else is parked in the
+// Unapplied Receipts suspense account until an operator resolves it. The returned bool is
+// true when the event had already been recorded and nothing was posted. Credits to a customer are
+// screened for AML; the money has arrived, so screening can only flag them for review.
+func (s *LedgerService) ReceiveInboundPayment(ctx context.Context, credit InboundCredit) (sqlc.InboundPayment, bool, error) {
+	amount, err := validatePositiveAmount(credit.Amount)
+	if err != nil {
+		return sqlc.InboundPayment{}, false, err
+	}
+
+	var (
+		payment sqlc.InboundPayment
+		evt     events.Event
+		sc      screening
+		replay  bool
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Replays of a recorded event are acknowledged without posting.
+		existing, err := q.GetInboundPaymentByEvent(ctx, sqlc.GetInboundPaymentByEventParams{
+			Provider: credit.Provider,
+			EventID:  credit.EventID,
+		})
+		if err == nil {
+			payment, replay = existing, true
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		replay = false
+
+		// Step 2: Lock a settlement shard, then the matching customer account or suspense.
+		settlement, err := q.GetSettlementShardForUpdate(ctx)
+		if err != nil {
+			return fmt.Errorf("settlement account not found: %w", err)
+		}
+		status := InboundCredited
+		target, err := q.GetAccountByVirtualNumberForUpdate(ctx, credit.VirtualAccountNumber)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && target.Currency != credit.Currency) {
+			status = InboundUnmatched
+			target, err = q.GetSuspenseAccountForUpdate(ctx)
+			if err != nil {
+				return fmt.Errorf("suspense account not found: %w", err)
+			}
+		} else if err != nil {
+			return err
+		}
+		if target.Currency != credit.Currency || settlement.Currency != credit.Currency {
+			return ErrCurrencyMismatch
+		}
+
+		// The money has arrived, so screening can only flag a credit to a customer for review.
+		sc = screening{}
+		if status == InboundCredited {
+			if sc, err = s.screenReceived(ctx, "inbound_payment", target.ID, amount); err != nil {
+				return err
+			}
+		}
+
+		// Step 3: Record the event first; a concurrent delivery of the same event inserts nothing.
+		txID := uuid.New()
+		accountID := uuid.NullUUID{}
+		if status == InboundCredited {
+			accountID = uuid.NullUUID{UUID: target.ID, Valid: true}
+		}
+		payment, err = q.CreateInboundPayment(ctx, sqlc.CreateInboundPaymentParams{
+			Provider:             credit.Provider,
+			EventID:              credit.EventID,
+			VirtualAccountNumber: credit.VirtualAccountNumber,
+			Amount:               amount.StringFixed(4),
+			Currency:             credit.Currency,
+			PayerName:            credit.PayerName,
+			PayerReference:       credit.PayerReference,
+			Status:               status,
+			AccountID:            accountID,
+			TransactionID:        txID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return errInboundReplay
+		}
+		if err != nil {
+			return err
+		}
+
+		// Step 4: Money is now at the settlement bank; credit whoever it belongs to.
+		description := fmt.Sprintf("Bank transfer via %s %s", credit.Provider, credit.EventID)
+		if status == InboundUnmatched {
+			description = fmt.Sprintf("Unmatched bank transfer to %s via %s %s", credit.VirtualAccountNumber, credit.Provider, credit.EventID)
+		}
+		entries, balances, err := postLegs(ctx, q, txID, "deposit",
+			debitLeg(settlement, amount, fmt.Sprintf("Inbound transfer to %s", credit.VirtualAccountNumber)),
+			creditLeg(target, amount, description),
+		)
+		if err != nil {
+			return err
+		}
+		evt = events.Event{
+			Type:          events.TypeDeposit,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      credit.Currency,
+			Entries:       entries,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if errors.Is(err, errInboundReplay) {
+		// Lost the race to a concurrent delivery; its row is committed now.
+		payment, err = s.store.GetInboundPaymentByEvent(ctx, sqlc.GetInboundPaymentByEventParams{
+			Provider: credit.Provider,
+			EventID:  credit.EventID,
+		})
+		return payment, true, err
+	}
+	if err != nil {
+		return sqlc.InboundPayment{}, false, err
+	}
+
+	if !replay {
+		logger(ctx).Info().Str("provider", payment.Provider).Str("event_id", payment.EventID).Str("status", payment.Status).Msg("Inbound payment posted")
+		s.publish(ctx, evt)
+		s.recordFlag(ctx, sc, payment.TransactionID)
+	}
+	return payment, replay, nil
+}
+
+// ResolveInboundPayment moves an unmatched credit from suspense to the customer account it belongs
+// to, screening it for AML like a matched credit.
+func (s *LedgerService) ResolveInboundPayment(ctx context.Context, paymentID, accountID, resolvedBy uuid.UUID) (sqlc.InboundPayment, error) {
+	var (
+		payment sqlc.InboundPayment
+		evt     events.Event
+		sc      screening
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the suspense item so two operators cannot apply it twice.
+		var err error
+		payment, err = q.GetInboundPaymentForUpdate(ctx, paymentID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInboundPaymentNotFound
+			}
+			return err
+		}
+		if payment.Status != InboundUnmatched {
+			return ErrInboundPaymentNotUnmatched
+		}
+		amount, err := validatePositiveAmount(payment.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid inbound payment amount: %w", err)
+		}
+
+		// Step 2: Lock suspense, then the customer account.
+		suspense, err := q.GetSuspenseAccountForUpdate(ctx)
+		if err != nil {
+			return fmt.Errorf("suspense account not found: %w", err)
+		}
+		account, err := q.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+		if account.IsSystem {
+			return ErrAccountNotFound
+		}
+		if account.Currency != payment.Currency {
+			return ErrCurrencyMismatch
+		}
+		sc, err = s.screenReceived(ctx, "inbound_payment", account.ID, amount)
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Release suspense to the customer and close the item.
+		txID := uuid.New()
+		entries, balances, err := postLegs(ctx, q, txID, "deposit",
+			debitLeg(suspense, amount, fmt.Sprintf("Applied unmatched transfer %s", payment.EventID)),
+			creditLeg(account, amount, fmt.Sprintf("Bank transfer via %s %s", payment.Provider, payment.EventID)),
+		)
+		if err != nil {
+			return err
+		}
+		payment, err = q.ResolveInboundPayment(ctx, sqlc.ResolveInboundPaymentParams{
+			AccountID:               uuid.NullUUID{UUID: account.ID, Valid: true},
+			ResolutionTransactionID: uuid.NullUUID{UUID: txID, Valid: true},
+			ResolvedBy:              uuid.NullUUID{UUID: resolvedBy, Valid: true},
+			ID:                      paymentID,
+		})
+		if err != nil {
+			return err
+		}
+		evt = events.Event{
+			Type:          events.TypeDeposit,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      payment.Currency,
+			Entries:       entries,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.InboundPayment{}, err
+	}
+
+	logger(ctx).Info().Str("inbound_payment_id", payment.ID.String()).Str("account_id", accountID.String()).Str("resolved_by", resolvedBy.String()).Msg("Unmatched inbound payment resolved")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, payment.ResolutionTransactionID.UUID)
+	return payment, nil
+}
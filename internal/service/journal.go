@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// Ledger event types appended to the ledger_events log. Every ledger change is one of these,
+// appended in the same database transaction that applies it to the projections: the transactions
+// and entries tables and cached account balances.
+const (
+	// LedgerEventPosted records a balanced posting: the transaction and all its legs.
+	LedgerEventPosted = "transaction_posted"
+	// LedgerEventStatusChanged records a transaction moving from one status to the next.
+	LedgerEventStatusChanged = "transaction_status_changed"
+)
+
+// postedLeg is one leg of a posting as the log records it.
+type postedLeg struct {
+	EntryID     uuid.UUID `json:"entry_id"`
+	AccountID   uuid.UUID `json:"account_id"`
+	Debit       string    `json:"debit"`
+	Credit      string    `json:"credit"`
+	Description string    `json:"description"`
+	Fee         bool      `json:"fee"`
+	// Booked legs write their entry without moving the cached balance, which already holds it.
+	Booked bool `json:"booked,omitempty"`
+}
+
+// postedEvent is the payload of a transaction_posted event: everything the projections need.
+type postedEvent struct {
+	OperationType string      `json:"operation_type"`
+	Status        string      `json:"status"`
+	Legs          []postedLeg `json:"legs"`
+	RiskScore     *int16      `json:"risk_score,omitempty"`
+	RiskDecision  string      `json:"risk_decision,omitempty"`
+	RiskFactors   []string    `json:"risk_factors,omitempty"`
+}
+
+// statusChangedEvent is the payload of a transaction_status_changed event.
+type statusChangedEvent struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// newPostedEvent describes a posting of legs, giving each leg the ID its entry will have and
+// carrying the operation's fraud risk assessment from ctx, if scored.
+func newPostedEvent(ctx context.Context, operationType, status string, legs []leg) postedEvent {
+	p := postedEvent{OperationType: operationType, Status: status, Legs: make([]postedLeg, 0, len(legs))}
+	for _, l := range legs {
+		p.Legs = append(p.Legs, postedLeg{
+			EntryID:     uuid.New(),
+			AccountID:   l.account.ID,
+			Debit:       l.debit.StringFixed(4),
+			Credit:      l.credit.StringFixed(4),
+			Description: l.description,
+			Fee:         l.fee,
+			Booked:      l.booked,
+		})
+	}
+	if a, ok := riskFromContext(ctx); ok {
+		score := int16(a.Score) // #nosec G115 -- scores are 0 to 100
+		p.RiskScore, p.RiskDecision, p.RiskFactors = &score, string(a.Decision), a.FactorNames()
+	}
+	return p
+}
+
+// appendLedgerEvent appends an event about txID to the log inside an open transaction, stamped
+// with the ID of the request that caused it.
+func appendLedgerEvent(ctx context.Context, q *sqlc.Queries, eventType string, txID uuid.UUID, payload any) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode ledger event: %w", err)
+	}
+	requestID := RequestIDFromContext(ctx)
+	_, err = q.AppendLedgerEvent(ctx, sqlc.AppendLedgerEventParams{
+		EventType:     eventType,
+		TransactionID: txID,
+		Payload:       raw,
+		RequestID:     sql.NullString{String: requestID, Valid: requestID != ""},
+	})
+	return err
+}
+
+// projectPosting applies a transaction_posted event: the transaction row, one entry per leg, the
+// cached balance movements and the account history read model. Callers must already hold row locks on every account involved.
+func projectPosting(ctx context.Context, q *sqlc.Queries, txID uuid.UUID, p postedEvent) ([]sqlc.Entry, error) {
+	if err := recordTransaction(ctx, q, txID, p); err != nil {
+		return nil, err
+	}
+	entries, err := insertEntries(ctx, q, txID, p)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.ProjectAccountHistory(ctx, txID); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// insertEntries writes a posting's entries and moves its accounts' cached balances with one
+// statement each, however many legs it has, so a split or a large journal costs a few round trips
+// rather than two per leg. Entries come back in leg order.
+func insertEntries(ctx context.Context, q *sqlc.Queries, txID uuid.UUID, p postedEvent) ([]sqlc.Entry, error) {
+	params := sqlc.CreateEntriesParams{TransactionID: txID, OperationType: p.OperationType}
+	moved := make([]uuid.UUID, 0, len(p.Legs))
+	deltas := make([]string, 0, len(p.Legs))
+	for _, l := range p.Legs {
+		delta, err := l.delta()
+		if err != nil {
+			return nil, err
+		}
+		params.Ids = append(params.Ids, l.EntryID)
+		params.AccountIds = append(params.AccountIds, l.AccountID)
+		params.Debits = append(params.Debits, l.Debit)
+		params.Credits = append(params.Credits, l.Credit)
+		params.Descriptions = append(params.Descriptions, l.Description)
+		if !l.Booked {
+			moved = append(moved, l.AccountID)
+			deltas = append(deltas, delta.StringFixed(4))
+		}
+	}
+	entries, err := q.CreateEntries(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) != len(p.Legs) {
+		return nil, fmt.Errorf("%d of %d entries written", len(entries), len(p.Legs))
+	}
+	// RETURNING does not promise insertion order; put the entries back in leg order.
+	order := make(map[uuid.UUID]int, len(p.Legs))
+	for i, l := range p.Legs {
+		order[l.EntryID] = i
+	}
+	slices.SortFunc(entries, func(a, b sqlc.Entry) int { return order[a.ID] - order[b.ID] })
+
+	for _, l := range p.Legs {
+		if l.Fee {
+			if err := q.CreateFeeEntry(ctx, sqlc.CreateFeeEntryParams{EntryID: l.EntryID, TransactionID: txID}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := updateBalances(ctx, q, moved, deltas); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// delta is how the leg moves its account's balance.
+func (l postedLeg) delta() (decimal.Decimal, error) {
+	debit, err := decimal.NewFromString(l.Debit)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid leg debit: %w", err)
+	}
+	credit, err := decimal.NewFromString(l.Credit)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid leg credit: %w", err)
+	}
+	return credit.Sub(debit), nil
+}
+
+// CheckLedgerProjections replays the event log and lists the accounts whose cached balance or
+// entries disagree with it. An empty list means the projections match the log.
+func (s *LedgerService) CheckLedgerProjections(ctx context.Context) ([]sqlc.ListLedgerProjectionDriftRow, error) {
+	drift, err := s.store.ListLedgerProjectionDrift(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ReconciliationMismatchesTotal.Add(float64(len(drift)), "balance")
+	s.raiseAlerts(ctx, driftAlerts(drift)...)
+	return drift, nil
+}
+
+// RebuildBalances resets every cached balance to the one replayed from the event log and returns
+// the drift found beforehand. Postings wait while it runs, so none is lost between the replay and
+// the reset. Entries that disagree with the log are reported, not rewritten.
+func (s *LedgerService) RebuildBalances(ctx context.Context) ([]sqlc.ListLedgerProjectionDriftRow, error) {
+	var drift []sqlc.ListLedgerProjectionDriftRow
+	var rebuilt int64
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		if err := q.LockLedgerEvents(ctx); err != nil {
+			return err
+		}
+		var err error
+		if drift, err = q.ListLedgerProjectionDrift(ctx); err != nil {
+			return err
+		}
+		rebuilt, err = q.RebuildAccountBalances(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rebuilt > 0 {
+		logger(ctx).Warn().Int64("accounts", rebuilt).Msg("Cached balances rebuilt from the ledger event log")
+	}
+	return drift, nil
+}
+
+// ListTransactionEvents returns the logged history of a transaction, oldest first.
+func (s *LedgerService) ListTransactionEvents(ctx context.Context, txID uuid.UUID) ([]sqlc.LedgerEvent, error) {
+	return s.store.ListLedgerEventsByTransaction(ctx, txID)
+}
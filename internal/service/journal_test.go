@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/risk"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestNewPostedEvent(t *testing.T) {
+	// Each leg gets its own entry ID and the fee marker; the risk assessment rides along.
+	acc := sqlc.Account{ID: uuid.New()}
+	settlement := sqlc.Account{ID: uuid.New()}
+	fee := creditLeg(settlement, decimal.NewFromInt(1), "Transfer fee")
+	fee.fee = true
+	ctx := context.WithValue(context.Background(), riskKey{}, risk.Assessment{Score: 60, Decision: risk.Challenge, Factors: []risk.Factor{{Name: risk.FactorVelocity}}})
+
+	p := newPostedEvent(ctx, "transfer", TransactionPosted, []leg{
+		debitLeg(acc, decimal.NewFromInt(11), "Transfer"),
+		creditLeg(settlement, decimal.NewFromInt(10), "Transfer"),
+		fee,
+	})
+	require.Len(t, p.Legs, 3)
+	assert.NotEqual(t, p.Legs[0].EntryID, p.Legs[1].EntryID)
+	assert.Equal(t, "11.0000", p.Legs[0].Debit)
+	assert.Equal(t, "0.0000", p.Legs[0].Credit)
+	assert.True(t, p.Legs[2].Fee)
+	require.NotNil(t, p.RiskScore)
+	assert.Equal(t, int16(60), *p.RiskScore)
+	assert.Equal(t, "challenge", p.RiskDecision)
+	assert.Equal(t, []string{risk.FactorVelocity}, p.RiskFactors)
+
+	delta, err := p.Legs[0].delta()
+	require.NoError(t, err)
+	assert.Equal(t, "-11", delta.String())
+
+	assert.Nil(t, newPostedEvent(context.Background(), "deposit", TransactionPosted, nil).RiskScore)
+}
+
+// insertEntriesPerLeg is the leg-at-a-time path insertEntries replaced, kept to benchmark against.
+func insertEntriesPerLeg(ctx context.Context, q *sqlc.Queries, txID uuid.UUID, p postedEvent) ([]sqlc.Entry, error) {
+	entries := make([]sqlc.Entry, 0, len(p.Legs))
+	for _, l := range p.Legs {
+		entry, err := q.CreateEntry(ctx, sqlc.CreateEntryParams{
+			ID:            l.EntryID,
+			AccountID:     l.AccountID,
+			Debit:         l.Debit,
+			Credit:        l.Credit,
+			TransactionID: txID,
+			OperationType: p.OperationType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		delta, err := l.delta()
+		if err != nil {
+			return nil, err
+		}
+		if err := q.UpdateAccountBalance(ctx, sqlc.UpdateAccountBalanceParams{Balance: delta.StringFixed(4), ID: l.AccountID}); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// BenchmarkInsertEntries compares writing a posting's entries a leg at a time with insertEntries,
+// for postings of a few to a few hundred legs. It needs the test database:
+//
+//	go test ./internal/service -run '^$' -bench InsertEntries
+func BenchmarkInsertEntries(b *testing.B) {
+	ledger := setupTestLedger(b)
+	settlement, err := ledger.store.GetSettlementAccount(context.Background())
+	require.NoError(b, err)
+	account := createTestAccount(b, ledger, "0")
+
+	for _, n := range []int{2, 20, 200} {
+		p := postedEvent{OperationType: "deposit", Status: TransactionPosted}
+		for i := 0; i < n/2; i++ {
+			p.Legs = append(p.Legs,
+				postedLeg{AccountID: settlement.ID, Debit: "1.0000", Credit: "0.0000"},
+				postedLeg{AccountID: account, Debit: "0.0000", Credit: "1.0000"},
+			)
+		}
+		b.Run(fmt.Sprintf("per_leg/legs=%d", n), func(b *testing.B) {
+			benchmarkInsertEntries(b, ledger, p, insertEntriesPerLeg)
+		})
+		b.Run(fmt.Sprintf("bulk/legs=%d", n), func(b *testing.B) {
+			benchmarkInsertEntries(b, ledger, p, insertEntries)
+		})
+	}
+}
+
+// benchmarkInsertEntries times insert alone, each run in a transaction that is rolled back.
+func benchmarkInsertEntries(b *testing.B, ledger *LedgerService, p postedEvent, insert func(context.Context, *sqlc.Queries, uuid.UUID, postedEvent) ([]sqlc.Entry, error)) {
+	ctx := context.Background()
+	errRollback := errors.New("rollback")
+	b.StopTimer()
+	for i := 0; i < b.N; i++ {
+		err := ledger.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+			txID := uuid.New()
+			for j := range p.Legs {
+				p.Legs[j].EntryID = uuid.New()
+			}
+			if err := recordTransaction(ctx, q, txID, p); err != nil {
+				return err
+			}
+			b.StartTimer()
+			entries, err := insert(ctx, q, txID, p)
+			b.StopTimer()
+			if err != nil {
+				return err
+			}
+			if len(entries) != len(p.Legs) {
+				return fmt.Errorf("%d entries for %d legs", len(entries), len(p.Legs))
+			}
+			return errRollback
+		})
+		require.ErrorIs(b, err, errRollback)
+	}
+}
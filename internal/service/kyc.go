@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrKYCRequired is returned when the account owner must verify their identity before moving money out.
+	ErrKYCRequired = errors.New("identity verification required")
+	// ErrKYCLimitExceeded is returned when an outbound debit is above the owner's KYC level limit.
+	ErrKYCLimitExceeded = errors.New("amount exceeds the limit for your verification level")
+)
+
+// KYC record statuses stored on the kyc_records table.
+const (
+	KYCPending  = "pending"
+	KYCApproved = "approved"
+	KYCRejected = "rejected"
+)
+
+// KYCLimits caps a single operation by the owner's approved KYC level. Levels without an entry
+// are unlimited; a zero limit blocks the operation entirely. A nil KYCLimits caps nothing.
+type KYCLimits map[int16]decimal.Decimal
+
+// DefaultKYCLimits blocks unverified users, and caps level 1 at 1,000 and level 2 at 10,000 per debit.
+func DefaultKYCLimits() KYCLimits {
+	return KYCLimits{
+		0: decimal.Zero,
+		1: decimal.NewFromInt(1_000),
+		2: decimal.NewFromInt(10_000),
+	}
+}
+
+// allow reports whether a debit of amount is permitted at level.
+func (l KYCLimits) allow(level int16, amount decimal.Decimal) error {
+	limit, capped := l[level]
+	switch {
+	case !capped:
+		return nil
+	case limit.IsZero():
+		return ErrKYCRequired
+	case amount.GreaterThan(limit):
+		return ErrKYCLimitExceeded
+	}
+	return nil
+}
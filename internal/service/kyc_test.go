@@ -0,0 +1,18 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKYCLimitsAllow(t *testing.T) {
+	// Unverified users are blocked, capped levels stop at their limit, and uncapped levels pass.
+	limits := DefaultKYCLimits()
+	assert.ErrorIs(t, limits.allow(0, decimal.NewFromInt(1)), ErrKYCRequired)
+	assert.NoError(t, limits.allow(1, decimal.NewFromInt(1_000)))
+	assert.ErrorIs(t, limits.allow(1, decimal.RequireFromString("1000.01")), ErrKYCLimitExceeded)
+	assert.NoError(t, limits.allow(2, decimal.NewFromInt(10_000)))
+	assert.NoError(t, limits.allow(3, decimal.NewFromInt(1_000_000)))
+}
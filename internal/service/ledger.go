@@ -3,15 +3,21 @@ package service
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/alert"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/archive"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
 	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/db"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/pii"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/risk"
 	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
 )
 
@@ -22,20 +28,91 @@ var (
 	ErrSameAccountTransfer = errors.New("cannot transfer to the same account")
 	// ErrInvalidAmount is returned when the provided amount is zero or negative.
 	ErrInvalidAmount = errors.New("amount must be positive")
+	// ErrAmountPrecision is returned when an amount has more decimal places than its currency allows.
+	ErrAmountPrecision = errors.New("amount has more decimal places than the currency allows")
 	// ErrCurrencyMismatch is returned when accounts involved in an operation use different currencies.
 	ErrCurrencyMismatch = errors.New("currency mismatch")
 	// ErrAccountNotFound is returned when an expected account does not exist.
 	ErrAccountNotFound = errors.New("account not found")
+	// ErrCrossOrgTransfer is returned when a transfer would move money between organizations.
+	ErrCrossOrgTransfer = errors.New("cannot transfer between organizations")
 )
 
 // LedgerService coordinates double-entry operations on accounts.
 type LedgerService struct {
-	store *db.Store
+	store     *db.Store
+	publisher events.Publisher
+	// kycLimits caps withdrawals and payouts by the owner's KYC level where admins set no
+	// transaction limit; nil leaves them uncapped.
+	kycLimits KYCLimits
+	// rates prices conversions; nil disables them.
+	rates            RateSource
+	defaultSpreadBps int32
+	// feeReversal decides whether reversals refund fees by default; empty means refund.
+	feeReversal FeeReversalPolicy
+	// adjustmentApproval holds admin adjustments until a second admin approves them.
+	adjustmentApproval bool
+	// erasureGrace is how long an erasure request can be cancelled; zero means DefaultErasureGracePeriod.
+	erasureGrace time.Duration
+	// pii finds sealed phones by their blind index; nil means phones are stored in plaintext.
+	pii *pii.Keyring
+	// aml screens money movements before they post; nil disables screening.
+	aml *aml.Engine
+	// risk scores outbound money movements for fraud before they post; nil disables scoring.
+	risk *risk.Scorer
+	// archive holds segments of aged entries; nil disables archival.
+	archive       archive.Store
+	archivePolicy ArchivePolicy
+	// alerts is told about balance mismatches the integrity checks find; nil only logs them.
+	alerts alert.Sender
+}
+
+// Option customizes optional LedgerService collaborators.
+type Option func(*LedgerService)
+
+// WithPublisher makes the service announce committed transactions on p.
+func WithPublisher(p events.Publisher) Option {
+	return func(s *LedgerService) {
+		s.publisher = p
+	}
+}
+
+// WithPII makes recipient lookups find phones sealed by keyring.
+func WithPII(keyring *pii.Keyring) Option {
+	return func(s *LedgerService) {
+		s.pii = keyring
+	}
+}
+
+// WithKYCLimits caps withdrawals and bank payouts by KYC level where admins have not configured a
+// transaction limit for the currency and level.
+func WithKYCLimits(limits KYCLimits) Option {
+	return func(s *LedgerService) {
+		s.kycLimits = limits
+	}
 }
 
 // NewLedgerService constructs a LedgerService backed by the provided store.
-func NewLedgerService(store *db.Store) *LedgerService {
-	return &LedgerService{store: store}
+func NewLedgerService(store *db.Store, opts ...Option) *LedgerService {
+	s := &LedgerService{store: store}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// publish counts a committed event in the business metrics and forwards it when a publisher is
+// configured.
+func (s *LedgerService) publish(ctx context.Context, evt events.Event) {
+	recordOperation(evt)
+	if s.publisher == nil {
+		return
+	}
+	evt.OccurredAt = time.Now().UTC()
+	if evt.Currency != "" {
+		evt.Amount = currencies.FormatString(evt.Currency, evt.Amount)
+	}
+	s.publisher.Publish(ctx, evt)
 }
 
 // Deposit external money into user account
@@ -45,77 +122,71 @@ func (s *LedgerService) Deposit(ctx context.Context, accountID uuid.UUID, amount
 	if err != nil {
 		return err
 	}
+	sc, err := s.screen(ctx, "deposit", accountID, uuid.NullUUID{}, aml.Inbound, amount, true)
+	if err != nil {
+		return err
+	}
 
-	return s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
-		// Step 2: Lock settlement + target account rows for this transaction.
-		settlement, err := q.GetSettlementAccountForUpdate(ctx)
-		if err != nil {
-			return fmt.Errorf("settlement account not found: %w", err)
-		}
-
-		account, err := q.GetAccountForUpdate(ctx, accountID)
-		if err != nil {
-			return fmt.Errorf("account not found: %w", err)
-		}
-
-		if account.Currency != settlement.Currency {
-			return ErrCurrencyMismatch
-		}
-
-		// Step 3: Use one transaction ID to tie both ledger legs together.
-		txID := uuid.New()
-
-		// 1. Credit user account (entry)
-		_, err = q.CreateEntry(ctx, sqlc.CreateEntryParams{
-			AccountID:     accountID,
-			Debit:         decimal.Zero.StringFixed(4),
-			Credit:        amount.StringFixed(4),
-			TransactionID: txID,
-			OperationType: "deposit",
-			Description:   sql.NullString{String: "External deposit", Valid: true},
-		})
-		if err != nil {
+	var evt events.Event
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		if err := checkDepositLimit(ctx, q, accountID, amount); err != nil {
 			return err
 		}
+		var postErr error
+		evt, postErr = postDeposit(ctx, q, accountID, amount, "External deposit")
+		return postErr
+	})
+	if err != nil {
+		return err
+	}
 
-		// 2. Debit settlement (opposing entry)
-		_, err = q.CreateEntry(ctx, sqlc.CreateEntryParams{
-			AccountID:     settlement.ID,
-			Debit:         amount.StringFixed(4),
-			Credit:        decimal.Zero.StringFixed(4),
-			TransactionID: txID,
-			OperationType: "deposit",
-			Description:   sql.NullString{String: fmt.Sprintf("Deposit to account %s", accountID), Valid: true},
-		})
-		if err != nil {
-			return err
-		}
+	// Step 4: Announce only after commit so consumers never see rolled-back money.
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, evt.TransactionID)
+	return nil
+}
 
-		// 3. Update cached balances atomically in the same DB transaction.
-		err = q.UpdateAccountBalance(ctx, sqlc.UpdateAccountBalanceParams{
-			Balance: amount.StringFixed(4),
-			ID:      accountID,
-		})
-		if err != nil {
-			return err
-		}
+// postDeposit writes both deposit legs inside an open transaction and returns the event to publish.
+func postDeposit(ctx context.Context, q *sqlc.Queries, accountID uuid.UUID, amount decimal.Decimal, description string) (events.Event, error) {
+	// Step 2: Lock a settlement shard + target account rows for this transaction.
+	settlement, err := q.GetSettlementShardForUpdate(ctx)
+	if err != nil {
+		return events.Event{}, fmt.Errorf("settlement account not found: %w", err)
+	}
 
-		err = q.UpdateAccountBalance(ctx, sqlc.UpdateAccountBalanceParams{
-			Balance: amount.Neg().StringFixed(4),
-			ID:      settlement.ID,
-		})
-		if err != nil {
-			return err
-		}
+	account, err := q.GetAccountForUpdate(ctx, accountID)
+	if err != nil {
+		return events.Event{}, fmt.Errorf("account not found: %w", err)
+	}
 
-		log.Info().
-			Str("tx_id", txID.String()).
-			Str("account_id", accountID.String()).
-			Str("amount", amount.StringFixed(4)).
-			Msg("Deposit completed")
+	if account.Currency != settlement.Currency {
+		return events.Event{}, ErrCurrencyMismatch
+	}
 
-		return nil
-	})
+	// Step 3: Use one transaction ID to tie both ledger legs together: credit the user, debit settlement.
+	txID := uuid.New()
+	postings, balances, err := postLegs(ctx, q, txID, "deposit",
+		creditLeg(account, amount, description),
+		debitLeg(settlement, amount, fmt.Sprintf("Deposit to account %s", accountID)),
+	)
+	if err != nil {
+		return events.Event{}, err
+	}
+
+	logger(ctx).Info().
+		Str("tx_id", txID.String()).
+		Str("account_id", accountID.String()).
+		Str("amount", amount.StringFixed(4)).
+		Msg("Deposit completed")
+
+	return events.Event{
+		Type:          events.TypeDeposit,
+		TransactionID: txID,
+		Amount:        amount.StringFixed(4),
+		Currency:      account.Currency,
+		Entries:       postings,
+		Balances:      balances,
+	}, nil
 }
 
 // Withdraw external money from user account
@@ -125,86 +196,90 @@ func (s *LedgerService) Withdraw(ctx context.Context, accountID uuid.UUID, amoun
 	if err != nil {
 		return err
 	}
+	sc, err := s.screen(ctx, "withdrawal", accountID, uuid.NullUUID{}, aml.Outbound, amount, true)
+	if err != nil {
+		return err
+	}
+	ctx, err = s.assessRisk(ctx, "withdrawal", accountID, "", amount, true)
+	if err != nil {
+		return err
+	}
 
-	return s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
-		// Step 2: Lock settlement + user account to prevent concurrent balance races.
-		settlement, err := q.GetSettlementAccountForUpdate(ctx)
-		if err != nil {
-			return fmt.Errorf("settlement account not found: %w", err)
-		}
-
-		account, err := q.GetAccountForUpdate(ctx, accountID)
-		if err != nil {
-			return fmt.Errorf("account not found: %w", err)
-		}
-
-		if account.Currency != settlement.Currency {
-			return ErrCurrencyMismatch
-		}
+	var evt events.Event
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		evt, err = s.postWithdrawal(ctx, q, accountID, amount)
+		return err
+	})
+	if err != nil {
+		return err
+	}
 
-		balanceDec, err := decimal.NewFromString(account.Balance)
-		if err != nil {
-			return errors.New("invalid balance")
-		}
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, evt.TransactionID)
+	return nil
+}
 
-		if balanceDec.LessThan(amount) {
-			// Business invariant: withdrawals cannot overdraw user funds.
-			return ErrInsufficientFunds
-		}
+// postWithdrawal writes the withdrawal and fee legs inside an open transaction and returns the event to publish.
+func (s *LedgerService) postWithdrawal(ctx context.Context, q *sqlc.Queries, accountID uuid.UUID, amount decimal.Decimal) (events.Event, error) {
+	// Step 2: Lock a settlement shard + user account to prevent concurrent balance races.
+	settlement, err := q.GetSettlementShardForUpdate(ctx)
+	if err != nil {
+		return events.Event{}, fmt.Errorf("settlement account not found: %w", err)
+	}
 
-		txID := uuid.New()
-
-		// 1. Debit user
-		_, err = q.CreateEntry(ctx, sqlc.CreateEntryParams{
-			AccountID:     accountID,
-			Debit:         amount.StringFixed(4),
-			Credit:        decimal.Zero.StringFixed(4),
-			TransactionID: txID,
-			OperationType: "withdrawal",
-			Description:   sql.NullString{String: "External withdrawal", Valid: true},
-		})
-		if err != nil {
-			return err
-		}
+	account, err := q.GetAccountForUpdate(ctx, accountID)
+	if err != nil {
+		return events.Event{}, fmt.Errorf("account not found: %w", err)
+	}
 
-		// 2. Credit settlement
-		_, err = q.CreateEntry(ctx, sqlc.CreateEntryParams{
-			AccountID:     settlement.ID,
-			Debit:         decimal.Zero.StringFixed(4),
-			Credit:        amount.StringFixed(4),
-			TransactionID: txID,
-			OperationType: "withdrawal",
-			Description:   sql.NullString{String: fmt.Sprintf("Withdrawal from %s", accountID), Valid: true},
-		})
-		if err != nil {
-			return err
-		}
+	if account.Currency != settlement.Currency {
+		return events.Event{}, ErrCurrencyMismatch
+	}
 
-		// 3. Update cached balances after entries are written.
-		err = q.UpdateAccountBalance(ctx, sqlc.UpdateAccountBalanceParams{
-			Balance: amount.Neg().StringFixed(4),
-			ID:      accountID,
-		})
-		if err != nil {
-			return err
-		}
+	// Business invariant: withdrawals stay within the product's rules and overdraft.
+	fee, err := checkSpendable(ctx, q, account, amount, debitWithdrawal)
+	if err != nil {
+		return events.Event{}, err
+	}
+	if err := checkGoalLock(ctx, q, account); err != nil {
+		return events.Event{}, err
+	}
+	if err := checkLimit(ctx, q, account, LimitWithdrawal, amount, s.kycLimits); err != nil {
+		return events.Event{}, err
+	}
 
-		err = q.UpdateAccountBalance(ctx, sqlc.UpdateAccountBalanceParams{
-			Balance: amount.StringFixed(4),
-			ID:      settlement.ID,
-		})
-		if err != nil {
-			return err
-		}
+	// The product's withdrawal fee is charged under the same transaction.
+	legs := []leg{
+		debitLeg(account, amount, "External withdrawal"),
+		creditLeg(settlement, amount, fmt.Sprintf("Withdrawal from %s", accountID)),
+	}
+	fees, err := feeLegs(ctx, q, account, fee, "Withdrawal fee")
+	if err != nil {
+		return events.Event{}, err
+	}
 
-		log.Info().
-			Str("tx_id", txID.String()).
-			Str("account_id", accountID.String()).
-			Str("amount", amount.StringFixed(4)).
-			Msg("Withdrawal completed")
+	txID := uuid.New()
+	postings, balances, err := postLegs(ctx, q, txID, "withdrawal", append(legs, fees...)...)
+	if err != nil {
+		return events.Event{}, err
+	}
 
-		return nil
-	})
+	logger(ctx).Info().
+		Str("tx_id", txID.String()).
+		Str("account_id", accountID.String()).
+		Str("amount", amount.StringFixed(4)).
+		Str("fee", fee.StringFixed(4)).
+		Msg("Withdrawal completed")
+
+	return events.Event{
+		Type:          events.TypeWithdrawal,
+		TransactionID: txID,
+		Amount:        amount.StringFixed(4),
+		Currency:      account.Currency,
+		Entries:       postings,
+		Balances:      balances,
+	}, nil
 }
 
 // Transfer between two user accounts
@@ -218,88 +293,104 @@ func (s *LedgerService) Transfer(ctx context.Context, fromID, toID uuid.UUID, am
 	if fromID == toID {
 		return ErrSameAccountTransfer
 	}
+	sc, err := s.screen(ctx, "transfer", fromID, uuid.NullUUID{UUID: toID, Valid: true}, aml.Outbound, amount, true)
+	if err != nil {
+		return err
+	}
+	beneficiary := transferBeneficiary(toID)
+	ctx, err = s.assessRisk(ctx, "transfer", fromID, beneficiary, amount, true)
+	if err != nil {
+		return err
+	}
 
-	return s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
-		// Step 2: Lock both accounts in the same transaction.
-		fromAcc, err := q.GetAccountForUpdate(ctx, fromID)
-		if err != nil {
-			return err
-		}
-
-		toAcc, err := q.GetAccountForUpdate(ctx, toID)
+	var evt events.Event
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		evt, err = postTransfer(ctx, q, uuid.New(), fromID, toID, amount, "")
 		if err != nil {
 			return err
 		}
+		return s.rememberBeneficiary(ctx, q, fromID, beneficiary)
+	})
+	if err != nil {
+		return err
+	}
 
-		if fromAcc.Currency != toAcc.Currency {
-			return ErrCurrencyMismatch
-		}
-
-		fromBalance, err := decimal.NewFromString(fromAcc.Balance)
-		if err != nil {
-			return errors.New("invalid from balance")
-		}
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, evt.TransactionID)
+	return nil
+}
 
-		if fromBalance.LessThan(amount) {
-			// Sender must have enough balance to cover transfer amount.
-			return ErrInsufficientFunds
-		}
+// postTransfer writes both transfer legs under txID inside an open transaction and returns the event to publish.
+// A non-empty narration is appended to both leg descriptions.
+func postTransfer(ctx context.Context, q *sqlc.Queries, txID, fromID, toID uuid.UUID, amount decimal.Decimal, narration string) (events.Event, error) {
+	// Step 2: Lock both accounts in the same transaction.
+	fromAcc, err := q.GetAccountForUpdate(ctx, fromID)
+	if err != nil {
+		return events.Event{}, err
+	}
 
-		// Step 3: Single transaction ID links debit and credit entries.
-		txID := uuid.New()
-
-		// 1. Debit from
-		_, err = q.CreateEntry(ctx, sqlc.CreateEntryParams{
-			AccountID:     fromID,
-			Debit:         amount.StringFixed(4),
-			Credit:        decimal.Zero.StringFixed(4),
-			TransactionID: txID,
-			OperationType: "transfer",
-			Description:   sql.NullString{String: fmt.Sprintf("Transfer to %s", toID), Valid: true},
-		})
-		if err != nil {
-			return err
-		}
+	toAcc, err := q.GetAccountForUpdate(ctx, toID)
+	if err != nil {
+		return events.Event{}, err
+	}
 
-		// 2. Credit to
-		_, err = q.CreateEntry(ctx, sqlc.CreateEntryParams{
-			AccountID:     toID,
-			Debit:         decimal.Zero.StringFixed(4),
-			Credit:        amount.StringFixed(4),
-			TransactionID: txID,
-			OperationType: "transfer",
-			Description:   sql.NullString{String: fmt.Sprintf("Transfer from %s", fromID), Valid: true},
-		})
-		if err != nil {
-			return err
-		}
+	// Tenants are isolated: money only moves between accounts of the same organization.
+	if fromAcc.OrgID != toAcc.OrgID {
+		return events.Event{}, ErrCrossOrgTransfer
+	}
 
-		// 3. Update cached balances for both sides of the transfer.
-		err = q.UpdateAccountBalance(ctx, sqlc.UpdateAccountBalanceParams{
-			Balance: amount.Neg().StringFixed(4),
-			ID:      fromID,
-		})
-		if err != nil {
-			return err
-		}
+	if fromAcc.Currency != toAcc.Currency {
+		return events.Event{}, ErrCurrencyMismatch
+	}
 
-		err = q.UpdateAccountBalance(ctx, sqlc.UpdateAccountBalanceParams{
-			Balance: amount.StringFixed(4),
-			ID:      toID,
-		})
-		if err != nil {
-			return err
-		}
+	// Sender must stay within its product's rules and overdraft after the amount and fee.
+	fee, err := checkSpendable(ctx, q, fromAcc, amount, debitTransfer)
+	if err != nil {
+		return events.Event{}, err
+	}
+	if err := checkGoalLock(ctx, q, fromAcc); err != nil {
+		return events.Event{}, err
+	}
+	if err := checkLimit(ctx, q, fromAcc, LimitTransfer, amount, nil); err != nil {
+		return events.Event{}, err
+	}
 
-		log.Info().
-			Str("tx_id", txID.String()).
-			Str("from_id", fromID.String()).
-			Str("to_id", toID.String()).
-			Str("amount", amount.StringFixed(4)).
-			Msg("Transfer completed")
+	// Step 3: Single transaction ID links the debit, the credit and any fee legs.
+	debitDescription, creditDescription := fmt.Sprintf("Transfer to %s", toID), fmt.Sprintf("Transfer from %s", fromID)
+	if narration != "" {
+		debitDescription += ": " + narration
+		creditDescription += ": " + narration
+	}
+	legs := []leg{
+		debitLeg(fromAcc, amount, debitDescription),
+		creditLeg(toAcc, amount, creditDescription),
+	}
+	fees, err := feeLegs(ctx, q, fromAcc, fee, "Transfer fee")
+	if err != nil {
+		return events.Event{}, err
+	}
+	postings, balances, err := postLegs(ctx, q, txID, "transfer", append(legs, fees...)...)
+	if err != nil {
+		return events.Event{}, err
+	}
 
-		return nil
-	})
+	logger(ctx).Info().
+		Str("tx_id", txID.String()).
+		Str("from_id", fromID.String()).
+		Str("to_id", toID.String()).
+		Str("amount", amount.StringFixed(4)).
+		Str("fee", fee.StringFixed(4)).
+		Msg("Transfer completed")
+
+	return events.Event{
+		Type:          events.TypeTransfer,
+		TransactionID: txID,
+		Amount:        amount.StringFixed(4),
+		Currency:      fromAcc.Currency,
+		Entries:       postings,
+		Balances:      balances,
+	}, nil
 }
 
 // ReconcileAccount verifies stored balance == SUM(credits) - SUM(debits)
@@ -328,16 +419,18 @@ func (s *LedgerService) ReconcileAccount(ctx context.Context, accountID uuid.UUI
 
 	if !stored.Equal(calculated) {
 		// Mismatch means denormalized cache drifted from ledger truth.
-		log.Error().
+		ReconciliationMismatchesTotal.Inc("balance")
+		logger(ctx).Error().
 			Str("account_id", accountID.String()).
 			Str("stored_balance", account.Balance).
 			Str("calculated", calculated.StringFixed(4)).
 			Msg("Balance mismatch detected")
+		s.raiseAlerts(ctx, balanceAlert(alert.KindBalanceMismatch, accountID, account.Currency, stored, calculated))
 		return false, fmt.Errorf("balance mismatch: stored %s, calculated %s",
 			account.Balance, calculated.StringFixed(4))
 	}
 
-	log.Info().
+	logger(ctx).Info().
 		Str("account_id", accountID.String()).
 		Str("balance", account.Balance).
 		Msg("Account reconciled successfully")
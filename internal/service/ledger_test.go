@@ -20,7 +20,7 @@ import (
 // setupTestLedger and helpers would be implemented to provide a testable LedgerService and test DB.
 // For demonstration, these are placeholders. In a real repo, use test containers or a test DB.
 
-func setupTestLedger(t *testing.T) *LedgerService {
+func setupTestLedger(t testing.TB) *LedgerService {
 	// Reuse env DB when available to match CI/runtime behavior.
 	dbURL := os.Getenv("DB_URL")
 	if dbURL == "" {
@@ -33,7 +33,7 @@ func setupTestLedger(t *testing.T) *LedgerService {
 	return ledger
 }
 
-func createTestAccount(t *testing.T, ledger *LedgerService, balance string) uuid.UUID {
+func createTestAccount(t testing.TB, ledger *LedgerService, balance string) uuid.UUID {
 	// Use a unique account name for each test run
 	accName := "Test Account " + uuid.New().String()
 
@@ -42,10 +42,12 @@ func createTestAccount(t *testing.T, ledger *LedgerService, balance string) uuid
 	require.NoError(t, err)
 
 	account, err := ledger.store.Queries.CreateAccount(context.Background(), sqlc.CreateAccountParams{
-		OwnerID:  uuid.NullUUID{Valid: false}, // No owner for test accounts
-		Name:     accName,
-		Currency: settlement.Currency, // Match settlement account currency
-		IsSystem: false,
+		OwnerID:        uuid.NullUUID{Valid: false}, // No owner for test accounts
+		Name:           accName,
+		Currency:       settlement.Currency, // Match settlement account currency
+		IsSystem:       false,
+		Product:        DefaultProduct,
+		OverdraftLimit: "0",
 	})
 	require.NoError(t, err)
 	// Optionally pre-fund account for withdrawal/transfer scenarios.
@@ -99,3 +101,28 @@ func TestConcurrentDeposits(t *testing.T) {
 	balance := getAccountBalance(t, ledger, accountID)
 	assert.Equal(t, "200.0000", balance)
 }
+
+func TestExecTx_RejectsUnbalancedTransaction(t *testing.T) {
+	// A transaction missing its opposing leg must fail to commit and leave no entry behind.
+	ledger := setupTestLedger(t)
+	accountID := createTestAccount(t, ledger, "0.00")
+	txID := uuid.New()
+	err := ledger.store.ExecTx(context.Background(), func(q *sqlc.Queries) error {
+		if err := recordTransaction(context.Background(), q, txID, postedEvent{OperationType: "deposit", Status: TransactionPosted}); err != nil {
+			return err
+		}
+		_, err := q.CreateEntry(context.Background(), sqlc.CreateEntryParams{
+			ID:            uuid.New(),
+			AccountID:     accountID,
+			Debit:         "0.0000",
+			Credit:        "10.0000",
+			TransactionID: txID,
+			OperationType: "deposit",
+		})
+		return err
+	})
+	require.ErrorIs(t, err, db.ErrUnbalancedTransaction)
+	entries, err := ledger.store.ListEntriesByTransaction(context.Background(), txID)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
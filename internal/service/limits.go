@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// Operations a transaction limit can cap. Withdrawals, transfers and payouts are capped for the
+// sending account's owner, deposits for the receiving one.
+const (
+	LimitDeposit    = "deposit"
+	LimitWithdrawal = "withdrawal"
+	LimitTransfer   = "transfer"
+	LimitPayout     = "payout"
+)
+
+// LimitOperations are the operations transaction limits can apply to.
+var LimitOperations = []string{LimitDeposit, LimitWithdrawal, LimitTransfer, LimitPayout}
+
+// ErrInvalidTransactionLimit is returned for a malformed transaction limit.
+var ErrInvalidTransactionLimit = errors.New("invalid transaction limit")
+
+// ValidateTransactionLimit checks a limit's operation, KYC level and amount, returning the amount.
+func ValidateTransactionLimit(operation string, kycLevel int16, maxAmount string) (decimal.Decimal, error) {
+	known := false
+	for _, op := range LimitOperations {
+		known = known || op == operation
+	}
+	if !known {
+		return decimal.Zero, fmt.Errorf("%w: operation must be one of %v", ErrInvalidTransactionLimit, LimitOperations)
+	}
+	if kycLevel < 0 || kycLevel > 3 {
+		return decimal.Zero, fmt.Errorf("%w: kyc_level must be between 0 and 3", ErrInvalidTransactionLimit)
+	}
+	amount, err := decimal.NewFromString(maxAmount)
+	if err != nil || amount.IsNegative() || amount.Exponent() < -4 {
+		return decimal.Zero, fmt.Errorf("%w: max_amount must be zero or a positive amount with at most 4 decimal places", ErrInvalidTransactionLimit)
+	}
+	return amount, nil
+}
+
+// checkLimit applies the admin-configured cap on operation for account's currency and owner's KYC
+// level inside an open transaction. Without one, fallback applies (nil for none). System accounts
+// and accounts without an owner are not capped.
+func checkLimit(ctx context.Context, q *sqlc.Queries, account sqlc.Account, operation string, amount decimal.Decimal, fallback KYCLimits) error {
+	if account.IsSystem || !account.OwnerID.Valid {
+		return nil
+	}
+	level, err := q.GetKYCLevelByUser(ctx, account.OwnerID.UUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		level = 0
+	} else if err != nil {
+		return err
+	}
+
+	limit, err := q.GetTransactionLimit(ctx, sqlc.GetTransactionLimitParams{
+		Currency:  account.Currency,
+		Operation: operation,
+		KycLevel:  level,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return fallback.allow(level, amount)
+	}
+	if err != nil {
+		return err
+	}
+	maxAmount, err := decimal.NewFromString(limit.MaxAmount)
+	if err != nil {
+		return fmt.Errorf("invalid transaction limit: %w", err)
+	}
+	return KYCLimits{level: maxAmount}.allow(level, amount)
+}
+
+// checkDepositLimit caps a deposit into accountID. Missing accounts fail when posting as usual.
+func checkDepositLimit(ctx context.Context, q *sqlc.Queries, accountID uuid.UUID, amount decimal.Decimal) error {
+	account, err := q.GetAccount(ctx, accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return checkLimit(ctx, q, account, LimitDeposit, amount, nil)
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTransactionLimit(t *testing.T) {
+	// Known operations, levels 0 to 3 and non-negative amounts with ledger precision are accepted.
+	amount, err := ValidateTransactionLimit(LimitTransfer, 1, "50000.50")
+	require.NoError(t, err)
+	assert.Equal(t, "50000.5000", amount.StringFixed(4))
+	_, err = ValidateTransactionLimit(LimitPayout, 0, "0")
+	assert.NoError(t, err)
+
+	for _, c := range []struct {
+		op     string
+		level  int16
+		amount string
+	}{
+		{"refund", 1, "100"},
+		{LimitDeposit, 4, "100"},
+		{LimitDeposit, -1, "100"},
+		{LimitWithdrawal, 1, "-1"},
+		{LimitWithdrawal, 1, "1.00001"},
+		{LimitWithdrawal, 1, "lots"},
+	} {
+		_, err := ValidateTransactionLimit(c.op, c.level, c.amount)
+		assert.ErrorIs(t, err, ErrInvalidTransactionLimit, c)
+	}
+}
@@ -0,0 +1,540 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// KindLoanDelinquency is the background job kind that refreshes loan delinquency.
+const KindLoanDelinquency = "loans.delinquency"
+
+// MaxLoanTermMonths bounds a loan's term.
+const MaxLoanTermMonths = 360
+
+// System accounts loans post against. One of each exists per currency, created on first use.
+const (
+	loansReceivableAccount = "Loans Receivable"
+	interestIncomeAccount  = "Interest Income"
+)
+
+// Loan statuses stored on the loans table.
+const (
+	LoanActive  = "active"
+	LoanPaidOff = "paid_off"
+)
+
+// Loan delinquency stored on the loans table, by days the oldest unpaid installment is past due.
+const (
+	LoanCurrent    = "current"
+	LoanLate       = "late"
+	LoanDelinquent = "delinquent"
+	LoanDefaulted  = "defaulted"
+)
+
+var (
+	// ErrLoanNotFound is returned when a loan does not exist.
+	ErrLoanNotFound = errors.New("loan not found")
+	// ErrLoanPaidOff is returned when repaying a loan with nothing left owing.
+	ErrLoanPaidOff = errors.New("loan already paid off")
+	// ErrLoanOverpayment is returned when a repayment exceeds what is still owed.
+	ErrLoanOverpayment = errors.New("repayment exceeds the amount owed")
+	// ErrInvalidLoanTerms is returned for a rate or term outside the allowed range.
+	ErrInvalidLoanTerms = errors.New("annual rate must be 0-10000 bps and term 1-360 months")
+)
+
+// Installment is one month of an amortization schedule. Balance is the principal still owed after it.
+type Installment struct {
+	Number    int32
+	DueDate   time.Time
+	Principal decimal.Decimal
+	Interest  decimal.Decimal
+	Balance   decimal.Decimal
+}
+
+// AmortizationSchedule splits principal into termMonths equal monthly payments at annualRateBps,
+// the first due one month after start. Amounts are rounded to the decimal places of currency; the
+// last installment absorbs the rounding so principal is repaid exactly.
+func AmortizationSchedule(principal decimal.Decimal, annualRateBps, termMonths int32, start time.Time, currency string) ([]Installment, error) {
+	if annualRateBps < 0 || annualRateBps > 10000 || termMonths < 1 || termMonths > MaxLoanTermMonths {
+		return nil, ErrInvalidLoanTerms
+	}
+	if !principal.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	n := decimal.NewFromInt32(termMonths)
+	rate := decimal.New(int64(annualRateBps), -4).Div(decimal.NewFromInt(12))
+	payment := principal.Div(n)
+	if rate.IsPositive() {
+		// payment = P * r / (1 - (1 + r)^-n)
+		growth := decimal.NewFromInt(1).Add(rate).Pow(n)
+		payment = principal.Mul(rate).Mul(growth).Div(growth.Sub(decimal.NewFromInt(1)))
+	}
+	payment = currencies.Round(currency, payment)
+
+	y, m, d := start.UTC().Date()
+	schedule := make([]Installment, 0, termMonths)
+	balance := principal
+	for i := int32(1); i <= termMonths; i++ {
+		interest := currencies.Round(currency, balance.Mul(rate))
+		part := payment.Sub(interest)
+		if i == termMonths || part.GreaterThan(balance) {
+			part = balance
+		}
+		balance = balance.Sub(part)
+		schedule = append(schedule, Installment{
+			Number:    i,
+			DueDate:   addMonthsClamped(y, m, d, int(i)),
+			Principal: part,
+			Interest:  interest,
+			Balance:   balance,
+		})
+	}
+	return schedule, nil
+}
+
+// addMonthsClamped is the date months after y-m-d, moved back to the end of a shorter month
+// (31 January plus one month is 28 or 29 February).
+func addMonthsClamped(y int, m time.Month, d, months int) time.Time {
+	first := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(d, last)-1)
+}
+
+// loanAmounts reads an installment's due and paid amounts.
+func loanAmounts(inst sqlc.LoanInstallment) (principalDue, interestDue, principalPaid, interestPaid decimal.Decimal, err error) {
+	for _, f := range []struct {
+		dst *decimal.Decimal
+		src string
+	}{
+		{&principalDue, inst.PrincipalDue},
+		{&interestDue, inst.InterestDue},
+		{&principalPaid, inst.PrincipalPaid},
+		{&interestPaid, inst.InterestPaid},
+	} {
+		if *f.dst, err = decimal.NewFromString(f.src); err != nil {
+			return decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, fmt.Errorf("invalid amount on installment %d: %w", inst.Number, err)
+		}
+	}
+	return principalDue, interestDue, principalPaid, interestPaid, nil
+}
+
+// repaymentSplit is the part of a repayment applied to one installment.
+type repaymentSplit struct {
+	Number    int32
+	Principal decimal.Decimal
+	Interest  decimal.Decimal
+}
+
+// allocateRepayment applies amount to installments oldest first, interest before principal,
+// and returns the per-installment splits with the principal and interest totals.
+func allocateRepayment(installments []sqlc.LoanInstallment, amount decimal.Decimal) ([]repaymentSplit, decimal.Decimal, decimal.Decimal, error) {
+	var (
+		splits              []repaymentSplit
+		principal, interest decimal.Decimal
+		left                = amount
+	)
+	for _, inst := range installments {
+		if !left.IsPositive() {
+			break
+		}
+		principalDue, interestDue, principalPaid, interestPaid, err := loanAmounts(inst)
+		if err != nil {
+			return nil, decimal.Zero, decimal.Zero, err
+		}
+		split := repaymentSplit{Number: inst.Number}
+		split.Interest = decimal.Min(left, interestDue.Sub(interestPaid))
+		left = left.Sub(split.Interest)
+		split.Principal = decimal.Min(left, principalDue.Sub(principalPaid))
+		left = left.Sub(split.Principal)
+		if split.Interest.IsPositive() || split.Principal.IsPositive() {
+			splits = append(splits, split)
+			principal, interest = principal.Add(split.Principal), interest.Add(split.Interest)
+		}
+	}
+	if left.IsPositive() {
+		if len(splits) == 0 {
+			return nil, decimal.Zero, decimal.Zero, ErrLoanPaidOff
+		}
+		return nil, decimal.Zero, decimal.Zero, fmt.Errorf("%w (%s)", ErrLoanOverpayment, principal.Add(interest).StringFixed(2))
+	}
+	return splits, principal, interest, nil
+}
+
+// LoanStanding is a loan's status and delinquency as of one day.
+type LoanStanding struct {
+	Status      string
+	Delinquency string
+	DaysPastDue int32
+}
+
+// StandingOf derives a loan's standing from its installments: paid off when nothing is owed,
+// otherwise graded by how many days the oldest unpaid installment is past due on today (UTC).
+func StandingOf(installments []sqlc.LoanInstallment, today time.Time) (LoanStanding, error) {
+	y, m, d := today.UTC().Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	for _, inst := range installments {
+		principalDue, interestDue, principalPaid, interestPaid, err := loanAmounts(inst)
+		if err != nil {
+			return LoanStanding{}, err
+		}
+		if principalPaid.Equal(principalDue) && interestPaid.Equal(interestDue) {
+			continue
+		}
+		standing := LoanStanding{Status: LoanActive, Delinquency: LoanCurrent}
+		if day.After(inst.DueDate) {
+			standing.DaysPastDue = int32(day.Sub(inst.DueDate).Hours() / 24) // #nosec G115 -- bounded by the 30-year term
+		}
+		switch {
+		case standing.DaysPastDue >= 90:
+			standing.Delinquency = LoanDefaulted
+		case standing.DaysPastDue >= 30:
+			standing.Delinquency = LoanDelinquent
+		case standing.DaysPastDue >= 1:
+			standing.Delinquency = LoanLate
+		}
+		return standing, nil
+	}
+	return LoanStanding{Status: LoanPaidOff, Delinquency: LoanCurrent}, nil
+}
+
+// LoanRequest disburses Principal into AccountID on AnnualRateBps over TermMonths.
+type LoanRequest struct {
+	OrgID         uuid.UUID
+	AccountID     uuid.UUID
+	Principal     string
+	AnnualRateBps int32
+	TermMonths    int32
+	CreatedBy     uuid.UUID
+}
+
+// OriginateLoan disburses a loan into an account of the organization, debiting the Loans
+// Receivable account of its currency, and fixes the amortization schedule. The disbursement is
+// screened for AML as money coming into the account.
+func (s *LedgerService) OriginateLoan(ctx context.Context, req LoanRequest) (sqlc.Loan, []sqlc.LoanInstallment, error) {
+	principal, err := validatePositiveAmount(req.Principal)
+	if err != nil {
+		return sqlc.Loan{}, nil, err
+	}
+	// Screen the disbursement only for borrowers of the organization, so other tenants' accounts
+	// still look missing. A disbursement cannot wait for review, so a hold refuses it.
+	if borrower, err := s.store.GetAccount(ctx, req.AccountID); err == nil && (borrower.IsSystem || borrower.OrgID.UUID != req.OrgID) {
+		return sqlc.Loan{}, nil, ErrAccountNotFound
+	}
+	sc, err := s.screen(ctx, "loan", req.AccountID, uuid.NullUUID{}, aml.Inbound, principal, false)
+	if err != nil {
+		return sqlc.Loan{}, nil, err
+	}
+	now := time.Now().UTC()
+
+	var (
+		loan         sqlc.Loan
+		installments []sqlc.LoanInstallment
+		evt          events.Event
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the borrower; accounts of other tenants are indistinguishable from missing ones.
+		borrower, err := q.GetAccountForUpdate(ctx, req.AccountID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && (borrower.IsSystem || borrower.OrgID.UUID != req.OrgID)) {
+			return ErrAccountNotFound
+		}
+		if err != nil {
+			return err
+		}
+		schedule, err := AmortizationSchedule(principal, req.AnnualRateBps, req.TermMonths, now, borrower.Currency)
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Disburse from Loans Receivable, which carries the amount owed as a debit.
+		receivable, err := lockSystemAccount(ctx, q, loansReceivableAccount, borrower.Currency)
+		if err != nil {
+			return err
+		}
+		txID := uuid.New()
+		postings, balances, err := postLegs(ctx, q, txID, "loan",
+			debitLeg(receivable, principal, fmt.Sprintf("Loan disbursed to %s", borrower.ID)),
+			creditLeg(borrower, principal, fmt.Sprintf("Loan disbursement over %d months", req.TermMonths)),
+		)
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Record the loan and its schedule.
+		loan, err = q.CreateLoan(ctx, sqlc.CreateLoanParams{
+			OrgID:                     req.OrgID,
+			AccountID:                 borrower.ID,
+			Principal:                 principal.StringFixed(4),
+			Currency:                  borrower.Currency,
+			AnnualRateBps:             req.AnnualRateBps,
+			TermMonths:                req.TermMonths,
+			DisbursementTransactionID: txID,
+			CreatedBy:                 req.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		installments = make([]sqlc.LoanInstallment, 0, len(schedule))
+		for _, inst := range schedule {
+			params := sqlc.CreateLoanInstallmentParams{
+				LoanID:       loan.ID,
+				Number:       inst.Number,
+				DueDate:      inst.DueDate,
+				PrincipalDue: inst.Principal.StringFixed(4),
+				InterestDue:  inst.Interest.StringFixed(4),
+			}
+			if err := q.CreateLoanInstallment(ctx, params); err != nil {
+				return err
+			}
+			installments = append(installments, sqlc.LoanInstallment{
+				LoanID: loan.ID, Number: inst.Number, DueDate: inst.DueDate,
+				PrincipalDue: params.PrincipalDue, InterestDue: params.InterestDue,
+				PrincipalPaid: "0.0000", InterestPaid: "0.0000",
+			})
+		}
+		evt = events.Event{
+			Type:          events.TypeLoan,
+			TransactionID: txID,
+			Amount:        principal.StringFixed(4),
+			Currency:      borrower.Currency,
+			Entries:       postings,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.Loan{}, nil, err
+	}
+
+	logger(ctx).Info().Str("loan_id", loan.ID.String()).Str("account_id", loan.AccountID.String()).Str("principal", loan.Principal).Msg("Loan disbursed")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, loan.DisbursementTransactionID)
+	return loan, installments, nil
+}
+
+// LoanRepaymentRequest pays Amount towards LoanID from FromAccountID.
+type LoanRepaymentRequest struct {
+	LoanID        uuid.UUID
+	FromAccountID uuid.UUID
+	Amount        string
+	CreatedBy     uuid.UUID
+}
+
+// RepayLoan debits the payer and credits the principal part to Loans Receivable and the
+// interest part to Interest Income, filling installments oldest first, then refreshes the
+// loan's standing. The payer's product rules and AML screening apply; no fee is charged.
+func (s *LedgerService) RepayLoan(ctx context.Context, req LoanRepaymentRequest) (sqlc.LoanRepayment, sqlc.Loan, error) {
+	amount, err := validatePositiveAmount(req.Amount)
+	if err != nil {
+		return sqlc.LoanRepayment{}, sqlc.Loan{}, err
+	}
+	sc, err := s.screen(ctx, "loan_repayment", req.FromAccountID, uuid.NullUUID{}, aml.Outbound, amount, false)
+	if err != nil {
+		return sqlc.LoanRepayment{}, sqlc.Loan{}, err
+	}
+
+	var (
+		repayment sqlc.LoanRepayment
+		loan      sqlc.Loan
+		evt       events.Event
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the loan so concurrent repayments apply one after the other.
+		loan, err = q.GetLoanForUpdate(ctx, req.LoanID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrLoanNotFound
+			}
+			return err
+		}
+		if loan.Status == LoanPaidOff {
+			return ErrLoanPaidOff
+		}
+
+		// Step 2: Lock the payer and check it can pay.
+		payer, err := q.GetAccountForUpdate(ctx, req.FromAccountID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && payer.OrgID.UUID != loan.OrgID) {
+			return ErrAccountNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if payer.Currency != loan.Currency {
+			return ErrCurrencyMismatch
+		}
+		if _, err := checkSpendable(ctx, q, payer, amount, debitTransfer); err != nil {
+			return err
+		}
+		if err := checkGoalLock(ctx, q, payer); err != nil {
+			return err
+		}
+
+		// Step 3: Split the amount across what is owed.
+		installments, err := q.ListLoanInstallments(ctx, loan.ID)
+		if err != nil {
+			return err
+		}
+		splits, principal, interest, err := allocateRepayment(installments, amount)
+		if err != nil {
+			return err
+		}
+
+		// Step 4: Post one debit and a principal and an interest credit.
+		receivable, err := lockSystemAccount(ctx, q, loansReceivableAccount, loan.Currency)
+		if err != nil {
+			return err
+		}
+		income, err := lockSystemAccount(ctx, q, interestIncomeAccount, loan.Currency)
+		if err != nil {
+			return err
+		}
+		legs := []leg{debitLeg(payer, amount, fmt.Sprintf("Loan %s repayment", loan.ID))}
+		if principal.IsPositive() {
+			legs = append(legs, creditLeg(receivable, principal, fmt.Sprintf("Loan %s principal", loan.ID)))
+		}
+		if interest.IsPositive() {
+			legs = append(legs, creditLeg(income, interest, fmt.Sprintf("Loan %s interest", loan.ID)))
+		}
+		txID := uuid.New()
+		postings, balances, err := postLegs(ctx, q, txID, "loan", legs...)
+		if err != nil {
+			return err
+		}
+
+		// Step 5: Record the repayment against the installments and refresh the standing.
+		paid := make(map[int32]repaymentSplit, len(splits))
+		for _, split := range splits {
+			if err := q.PayLoanInstallment(ctx, sqlc.PayLoanInstallmentParams{
+				Principal: split.Principal.StringFixed(4),
+				Interest:  split.Interest.StringFixed(4),
+				LoanID:    loan.ID,
+				Number:    split.Number,
+			}); err != nil {
+				return err
+			}
+			paid[split.Number] = split
+		}
+		repayment, err = q.CreateLoanRepayment(ctx, sqlc.CreateLoanRepaymentParams{
+			LoanID:        loan.ID,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Principal:     principal.StringFixed(4),
+			Interest:      interest.StringFixed(4),
+			CreatedBy:     req.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		for i, inst := range installments {
+			split, ok := paid[inst.Number]
+			if !ok {
+				continue
+			}
+			_, _, principalPaid, interestPaid, err := loanAmounts(inst)
+			if err != nil {
+				return err
+			}
+			installments[i].PrincipalPaid = principalPaid.Add(split.Principal).StringFixed(4)
+			installments[i].InterestPaid = interestPaid.Add(split.Interest).StringFixed(4)
+		}
+		loan, err = s.updateStanding(ctx, q, loan, installments)
+		if err != nil {
+			return err
+		}
+
+		evt = events.Event{
+			Type:          events.TypeLoan,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      loan.Currency,
+			Entries:       postings,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.LoanRepayment{}, sqlc.Loan{}, err
+	}
+
+	logger(ctx).Info().Str("loan_id", loan.ID.String()).Str("amount", repayment.Amount).Str("status", loan.Status).Msg("Loan repayment posted")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, repayment.TransactionID)
+	return repayment, loan, nil
+}
+
+// updateStanding stores the standing the installments give today, if it changed.
+func (s *LedgerService) updateStanding(ctx context.Context, q *sqlc.Queries, loan sqlc.Loan, installments []sqlc.LoanInstallment) (sqlc.Loan, error) {
+	standing, err := StandingOf(installments, time.Now())
+	if err != nil {
+		return sqlc.Loan{}, err
+	}
+	if standing.Status == loan.Status && standing.Delinquency == loan.Delinquency && standing.DaysPastDue == loan.DaysPastDue {
+		return loan, nil
+	}
+	return q.UpdateLoanStanding(ctx, sqlc.UpdateLoanStandingParams{
+		Status:      standing.Status,
+		Delinquency: standing.Delinquency,
+		DaysPastDue: standing.DaysPastDue,
+		ID:          loan.ID,
+	})
+}
+
+// UpdateLoanDelinquency is a jobs.HandlerFunc that regrades every active loan by how far its
+// oldest unpaid installment is past due.
+func (s *LedgerService) UpdateLoanDelinquency(ctx context.Context, _ json.RawMessage) error {
+	const batch = 100
+	var (
+		errs    []error
+		after   uuid.UUID
+		changed int
+	)
+	for {
+		ids, err := s.store.ListActiveLoanIDs(ctx, sqlc.ListActiveLoanIDsParams{AfterID: after, RowLimit: batch})
+		if err != nil {
+			return errors.Join(append(errs, fmt.Errorf("list active loans: %w", err))...)
+		}
+		for _, id := range ids {
+			after = id
+			var before, now sqlc.Loan
+			err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+				loan, err := q.GetLoanForUpdate(ctx, id)
+				if err != nil {
+					return err
+				}
+				installments, err := q.ListLoanInstallments(ctx, id)
+				if err != nil {
+					return err
+				}
+				before = loan
+				now, err = s.updateStanding(ctx, q, loan, installments)
+				return err
+			})
+			if err != nil {
+				logger(ctx).Error().Err(err).Str("loan_id", id.String()).Msg("Failed to update loan delinquency")
+				errs = append(errs, err)
+				continue
+			}
+			if now.Delinquency != before.Delinquency {
+				changed++
+				logger(ctx).Info().Str("loan_id", id.String()).Str("from", before.Delinquency).Str("to", now.Delinquency).Int32("days_past_due", now.DaysPastDue).Msg("Loan delinquency changed")
+			}
+		}
+		if len(ids) < batch {
+			break
+		}
+	}
+
+	logger(ctx).Info().Int("changed", changed).Int("failed", len(errs)).Msg("Loan delinquency updated")
+	return errors.Join(errs...)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestAmortizationSchedule(t *testing.T) {
+	// Installments are equal to the cent (kobo), interest falls as principal is repaid, and the
+	// last one absorbs rounding so the principal is repaid exactly.
+	start := time.Date(2026, 1, 31, 15, 0, 0, 0, time.UTC)
+	schedule, err := AmortizationSchedule(decimal.NewFromInt(1000), 1200, 12, start, "NGN")
+	require.NoError(t, err)
+	require.Len(t, schedule, 12)
+
+	assert.Equal(t, "10.00", schedule[0].Interest.StringFixed(2))
+	assert.Equal(t, "88.85", schedule[0].Principal.Add(schedule[0].Interest).StringFixed(2))
+	assert.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), schedule[0].DueDate)
+	assert.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), schedule[1].DueDate)
+
+	total := decimal.Zero
+	for i, inst := range schedule {
+		total = total.Add(inst.Principal)
+		if i > 0 {
+			assert.True(t, inst.Interest.LessThan(schedule[i-1].Interest))
+		}
+	}
+	assert.Equal(t, "1000.00", total.StringFixed(2))
+	assert.True(t, schedule[11].Balance.IsZero())
+
+	flat, err := AmortizationSchedule(decimal.NewFromInt(100), 0, 3, start, "NGN")
+	require.NoError(t, err)
+	assert.Equal(t, "33.33", flat[0].Principal.StringFixed(2))
+	assert.Equal(t, "33.34", flat[2].Principal.StringFixed(2))
+	assert.True(t, flat[0].Interest.IsZero())
+
+	// Currencies without minor units are scheduled in whole units.
+	yen, err := AmortizationSchedule(decimal.NewFromInt(100000), 0, 3, start, "JPY")
+	require.NoError(t, err)
+	assert.Equal(t, "33333", yen[0].Principal.String())
+	assert.Equal(t, "33334", yen[2].Principal.String())
+
+	_, err = AmortizationSchedule(decimal.NewFromInt(100), 0, 0, start, "NGN")
+	assert.ErrorIs(t, err, ErrInvalidLoanTerms)
+	_, err = AmortizationSchedule(decimal.NewFromInt(100), 10001, 12, start, "NGN")
+	assert.ErrorIs(t, err, ErrInvalidLoanTerms)
+	_, err = AmortizationSchedule(decimal.Zero, 500, 12, start, "NGN")
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestAllocateRepayment(t *testing.T) {
+	// Repayments fill the oldest installment first, interest before principal.
+	installments := []sqlc.LoanInstallment{
+		{Number: 1, PrincipalDue: "90.00", InterestDue: "10.00", PrincipalPaid: "90.00", InterestPaid: "10.00"},
+		{Number: 2, PrincipalDue: "91.00", InterestDue: "9.00", PrincipalPaid: "20.00", InterestPaid: "9.00"},
+		{Number: 3, PrincipalDue: "92.00", InterestDue: "8.00", PrincipalPaid: "0", InterestPaid: "0"},
+	}
+
+	splits, principal, interest, err := allocateRepayment(installments, decimal.NewFromInt(80))
+	require.NoError(t, err)
+	require.Len(t, splits, 2)
+	assert.Equal(t, int32(2), splits[0].Number)
+	assert.Equal(t, "71.00", splits[0].Principal.StringFixed(2))
+	assert.True(t, splits[0].Interest.IsZero())
+	assert.Equal(t, int32(3), splits[1].Number)
+	assert.Equal(t, "8.00", splits[1].Interest.StringFixed(2))
+	assert.Equal(t, "1.00", splits[1].Principal.StringFixed(2))
+	assert.Equal(t, "72.00", principal.StringFixed(2))
+	assert.Equal(t, "8.00", interest.StringFixed(2))
+
+	_, _, _, err = allocateRepayment(installments, decimal.NewFromInt(200))
+	assert.ErrorIs(t, err, ErrLoanOverpayment)
+	_, _, _, err = allocateRepayment(installments[:1], decimal.NewFromInt(1))
+	assert.ErrorIs(t, err, ErrLoanPaidOff)
+}
+
+func TestStandingOf(t *testing.T) {
+	// Delinquency grades by days the oldest unpaid installment is overdue.
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	installments := []sqlc.LoanInstallment{
+		{Number: 1, DueDate: due, PrincipalDue: "50", InterestDue: "5", PrincipalPaid: "50", InterestPaid: "5"},
+		{Number: 2, DueDate: due.AddDate(0, 1, 0), PrincipalDue: "50", InterestDue: "5", PrincipalPaid: "0", InterestPaid: "5"},
+	}
+	next := installments[1].DueDate
+
+	for _, tc := range []struct {
+		days        int
+		delinquency string
+	}{
+		{0, LoanCurrent}, {1, LoanLate}, {29, LoanLate}, {30, LoanDelinquent}, {89, LoanDelinquent}, {90, LoanDefaulted},
+	} {
+		standing, err := StandingOf(installments, next.AddDate(0, 0, tc.days).Add(13*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, LoanActive, standing.Status)
+		assert.Equal(t, tc.delinquency, standing.Delinquency, "%d days", tc.days)
+		assert.Equal(t, int32(tc.days), standing.DaysPastDue)
+	}
+
+	installments[1].PrincipalPaid = "50"
+	standing, err := StandingOf(installments, next.AddDate(1, 0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, LoanPaidOff, standing.Status)
+	assert.Equal(t, LoanCurrent, standing.Delinquency)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/merkle"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// KindMerkleRoots is the background job kind that publishes Merkle roots over new entries.
+const KindMerkleRoots = "ledger.merkle_roots"
+
+// merkleRootMaxLeaves caps the entries under one root, so a backlog is split over several.
+const merkleRootMaxLeaves = 65536
+
+var (
+	// ErrMerkleRootNotFound is returned for a Merkle root that does not exist.
+	ErrMerkleRootNotFound = errors.New("merkle root not found")
+	// ErrEntryNotRooted is returned for an entry no published Merkle root covers yet.
+	ErrEntryNotRooted = errors.New("entry is not under a published merkle root yet")
+)
+
+// MerkleProof shows an entry is under a published root: the entry's leaf at LeafIndex, hashed up
+// through Path, gives Root.RootHash.
+type MerkleProof struct {
+	Root      sqlc.MerkleRoot
+	Leaf      []byte
+	Path      [][]byte
+	LeafIndex int
+}
+
+// PublishMerkleRoots is a jobs.HandlerFunc that publishes a Merkle root over the entries posted
+// since the last one, or several when more are waiting than one root holds.
+func (s *LedgerService) PublishMerkleRoots(ctx context.Context, _ json.RawMessage) error {
+	before := time.Now()
+	for {
+		root, ok, err := s.publishMerkleRoot(ctx, before)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		logger(ctx).Info().Str("root_id", root.ID.String()).Int32("leaves", root.LeafCount).Msg("Merkle root published")
+		if root.LeafCount < merkleRootMaxLeaves {
+			break
+		}
+	}
+	return nil
+}
+
+// publishMerkleRoot builds one root over the oldest entries posted before the cutoff that no
+// root covers. It reports false when there are none.
+func (s *LedgerService) publishMerkleRoot(ctx context.Context, before time.Time) (sqlc.MerkleRoot, bool, error) {
+	var root sqlc.MerkleRoot
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		root = sqlc.MerkleRoot{}
+		if err := q.LockMerkleRoots(ctx); err != nil {
+			return err
+		}
+		rows, err := q.ListUnrootedEntries(ctx, sqlc.ListUnrootedEntriesParams{Before: before, RowLimit: merkleRootMaxLeaves})
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+		ids := make([]uuid.UUID, 0, len(rows))
+		leaves := make([][]byte, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+			leaves = append(leaves, merkle.LeafHash(row.Hash))
+		}
+		if root, err = q.CreateMerkleRoot(ctx, sqlc.CreateMerkleRootParams{
+			RootHash:  merkle.Root(leaves),
+			LeafCount: int32(len(rows)), // #nosec G115 -- at most merkleRootMaxLeaves
+		}); err != nil {
+			return err
+		}
+		_, err = q.AddMerkleRootEntries(ctx, sqlc.AddMerkleRootEntriesParams{RootID: root.ID, EntryIds: ids})
+		return err
+	})
+	if err != nil {
+		return sqlc.MerkleRoot{}, false, err
+	}
+	return root, root.ID != uuid.Nil, nil
+}
+
+// GetMerkleRoot returns a published root.
+func (s *LedgerService) GetMerkleRoot(ctx context.Context, id uuid.UUID) (sqlc.MerkleRoot, error) {
+	root, err := s.store.GetMerkleRoot(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.MerkleRoot{}, ErrMerkleRootNotFound
+	}
+	return root, err
+}
+
+// MerkleProof returns the inclusion proof of an entry under the root that covers it. The proof is
+// built from the entries as they are now, so one changed since its root was published no longer
+// proves against that root.
+func (s *LedgerService) MerkleProof(ctx context.Context, entryID uuid.UUID) (MerkleProof, error) {
+	placed, err := s.store.GetMerkleRootEntry(ctx, entryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return MerkleProof{}, ErrEntryNotRooted
+	}
+	if err != nil {
+		return MerkleProof{}, err
+	}
+	root, err := s.GetMerkleRoot(ctx, placed.RootID)
+	if err != nil {
+		return MerkleProof{}, err
+	}
+	hashes, err := s.store.ListMerkleRootLeaves(ctx, root.ID)
+	if err != nil {
+		return MerkleProof{}, err
+	}
+	leaves := make([][]byte, 0, len(hashes))
+	for _, h := range hashes {
+		leaves = append(leaves, merkle.LeafHash(h))
+	}
+	index := int(placed.LeafIndex)
+	if index >= len(leaves) {
+		return MerkleProof{}, ErrEntryNotRooted
+	}
+	if len(leaves) != int(root.LeafCount) || !bytes.Equal(merkle.Root(leaves), root.RootHash) {
+		logger(ctx).Error().Str("root_id", root.ID.String()).Msg("Entries under a Merkle root changed since it was published")
+	}
+	return MerkleProof{Root: root, Leaf: leaves[index], Path: merkle.Proof(leaves, index), LeafIndex: index}, nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/metrics"
+)
+
+// Business metrics, served with the rest of metrics.Default.
+var (
+	operationsTotal = metrics.NewCounter("ledger_operations_total",
+		"Committed ledger operations (deposits, withdrawals, transfers, ...) by operation and currency.", "operation", "currency")
+	volumeTotal = metrics.NewCounter("ledger_volume_total",
+		"Amount moved by committed ledger operations, in units of the currency.", "operation", "currency")
+	insufficientFundsTotal = metrics.NewCounter("ledger_insufficient_funds_total",
+		"Operations refused because the debited account could not cover them.", "operation")
+	// ReconciliationMismatchesTotal counts mismatches found by reconciliations: kind "balance"
+	// for a cached balance that disagrees with the entries or the event log, "bank_statement" for
+	// statement lines and settlement entries left unmatched.
+	ReconciliationMismatchesTotal = metrics.NewCounter("ledger_reconciliation_mismatches_total",
+		"Mismatches found by reconciliations, by kind.", "kind")
+)
+
+// recordOperation counts a committed operation and its amount.
+func recordOperation(evt events.Event) {
+	operationsTotal.Inc(string(evt.Type), evt.Currency)
+	if amount, err := decimal.NewFromString(evt.Amount); err == nil {
+		volumeTotal.Add(amount.InexactFloat64(), string(evt.Type), evt.Currency)
+	}
+}
+
+// insufficientFunds counts a refused operation and returns ErrInsufficientFunds.
+func insufficientFunds(operation string) error {
+	insufficientFundsTotal.Inc(operation)
+	return ErrInsufficientFunds
+}
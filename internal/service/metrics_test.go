@@ -0,0 +1,26 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/metrics"
+)
+
+func TestRecordOperation(t *testing.T) {
+	// Each committed operation counts once and adds its amount to the currency's volume.
+	recordOperation(events.Event{Type: events.TypeDeposit, Amount: "100.5000", Currency: "XTS"})
+	recordOperation(events.Event{Type: events.TypeDeposit, Amount: "20.0000", Currency: "XTS"})
+	assert.ErrorIs(t, insufficientFunds("conversion"), ErrInsufficientFunds)
+
+	var b strings.Builder
+	_, err := metrics.Default.WriteTo(&b)
+	require.NoError(t, err)
+	assert.Contains(t, b.String(), `ledger_operations_total{operation="deposit",currency="XTS"} 2`)
+	assert.Contains(t, b.String(), `ledger_volume_total{operation="deposit",currency="XTS"} 120.5`)
+	assert.Contains(t, b.String(), `ledger_insufficient_funds_total{operation="conversion"}`)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrInvalidOwnershipTransfer is returned for an account that cannot move to the named user.
+	ErrInvalidOwnershipTransfer = errors.New("invalid ownership transfer")
+	// ErrOwnershipTransferPending is returned when the account already has an open request.
+	ErrOwnershipTransferPending = errors.New("account already has a pending ownership transfer")
+	// ErrOwnershipTransferNotFound is returned when an ownership transfer does not exist.
+	ErrOwnershipTransferNotFound = errors.New("ownership transfer not found")
+	// ErrOwnershipTransferNotPending is returned when deciding a request that was already approved or rejected.
+	ErrOwnershipTransferNotPending = errors.New("ownership transfer is not pending")
+	// ErrOwnershipTransferSelfApproval is returned when the requester of a transfer tries to decide it.
+	ErrOwnershipTransferSelfApproval = errors.New("an ownership transfer must be decided by an admin other than its requester")
+)
+
+// Ownership transfer statuses stored on the ownership_transfers table.
+const (
+	OwnershipTransferPending  = "pending"
+	OwnershipTransferApproved = "approved"
+	OwnershipTransferRejected = "rejected"
+)
+
+// accountRoleOwner is the account_owners role that moves money and manages co-owners.
+const accountRoleOwner = "owner"
+
+// AccessReader is the query subset needed to look up a user's role on an account.
+// *sqlc.Queries and *db.Store satisfy it.
+type AccessReader interface {
+	GetAccountAccessRole(ctx context.Context, arg sqlc.GetAccountAccessRoleParams) (string, error)
+}
+
+// OwnsAccount reports whether userID holds the owner role on acc. System and unowned accounts
+// belong to the ledger, so nobody owns them.
+func OwnsAccount(ctx context.Context, q AccessReader, userID uuid.UUID, acc sqlc.Account) (bool, error) {
+	if acc.IsSystem || !acc.OwnerID.Valid {
+		return false, nil
+	}
+	role, err := q.GetAccountAccessRole(ctx, sqlc.GetAccountAccessRoleParams{UserID: userID, AccountID: acc.ID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return role == accountRoleOwner, nil
+}
+
+// OwnershipTransferInput asks for an account to move from its primary owner to the user of the
+// account's organization with ToEmail.
+type OwnershipTransferInput struct {
+	AccountID   uuid.UUID
+	ToEmail     string
+	Reason      string
+	RequestedBy uuid.UUID
+}
+
+// RequestOwnershipTransfer queues a change of an account's primary owner. Nothing moves until
+// ApproveOwnershipTransfer runs for an admin other than the requester.
+func (s *LedgerService) RequestOwnershipTransfer(ctx context.Context, in OwnershipTransferInput) (sqlc.OwnershipTransfer, error) {
+	var transfer sqlc.OwnershipTransfer
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Only top-level customer accounts change hands; sub-wallets follow their parent.
+		acc, err := q.GetAccount(ctx, in.AccountID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+		if acc.IsSystem || !acc.OwnerID.Valid || !acc.OrgID.Valid {
+			return ErrAccountNotFound
+		}
+		if acc.ParentAccountID.Valid {
+			return fmt.Errorf("%w: sub-wallets move with their parent account", ErrInvalidOwnershipTransfer)
+		}
+		to, err := q.FindUserByEmail(ctx, sqlc.FindUserByEmailParams{OrgID: acc.OrgID.UUID, Email: in.ToEmail})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: no user with that email in the account's organization", ErrInvalidOwnershipTransfer)
+			}
+			return err
+		}
+		if to.ID == acc.OwnerID.UUID {
+			return fmt.Errorf("%w: the user already owns the account", ErrInvalidOwnershipTransfer)
+		}
+
+		// Step 2: Queue it, one open request per account.
+		pending, err := q.HasPendingOwnershipTransfer(ctx, acc.ID)
+		if err != nil {
+			return err
+		}
+		if pending {
+			return ErrOwnershipTransferPending
+		}
+		transfer, err = q.CreateOwnershipTransfer(ctx, sqlc.CreateOwnershipTransferParams{
+			AccountID:   acc.ID,
+			FromUserID:  acc.OwnerID.UUID,
+			ToUserID:    to.ID,
+			Reason:      in.Reason,
+			RequestedBy: in.RequestedBy,
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.OwnershipTransfer{}, err
+	}
+
+	logger(ctx).Info().Str("ownership_transfer_id", transfer.ID.String()).Str("account_id", transfer.AccountID.String()).Str("from_user_id", transfer.FromUserID.String()).Str("to_user_id", transfer.ToUserID.String()).Str("requested_by", in.RequestedBy.String()).Msg("Ownership transfer requested")
+	return transfer, nil
+}
+
+// ApproveOwnershipTransfer makes the new user the primary owner of the account and its
+// sub-wallets, replacing the previous owner's access, and records the approver in the same
+// transaction. Entries stay on the account, so its history moves with it.
+func (s *LedgerService) ApproveOwnershipTransfer(ctx context.Context, id, approverID uuid.UUID, note string) (sqlc.OwnershipTransfer, error) {
+	var transfer sqlc.OwnershipTransfer
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the request and the account; the account must not have changed hands since.
+		var err error
+		if transfer, err = lockPendingOwnershipTransfer(ctx, q, id, approverID); err != nil {
+			return err
+		}
+		acc, err := q.GetAccountForUpdate(ctx, transfer.AccountID)
+		if err != nil {
+			return err
+		}
+		if acc.OwnerID.UUID != transfer.FromUserID {
+			return fmt.Errorf("%w: the account's owner changed since the request", ErrInvalidOwnershipTransfer)
+		}
+		to, err := q.GetUser(ctx, transfer.ToUserID)
+		if err != nil {
+			return err
+		}
+		if to.OrgID != acc.OrgID.UUID {
+			return fmt.Errorf("%w: the new owner left the account's organization", ErrInvalidOwnershipTransfer)
+		}
+
+		// Step 2: Move the account, swap the owners' access and record the decision.
+		if err := q.SetAccountOwner(ctx, sqlc.SetAccountOwnerParams{
+			OwnerID:   uuid.NullUUID{UUID: to.ID, Valid: true},
+			AccountID: acc.ID,
+		}); err != nil {
+			return err
+		}
+		if _, err := q.RemoveAccountOwner(ctx, sqlc.RemoveAccountOwnerParams{AccountID: acc.ID, UserID: transfer.FromUserID}); err != nil {
+			return err
+		}
+		if _, err := q.AddAccountOwner(ctx, sqlc.AddAccountOwnerParams{
+			AccountID: acc.ID,
+			UserID:    to.ID,
+			Role:      accountRoleOwner,
+			AddedBy:   uuid.NullUUID{UUID: approverID, Valid: true},
+		}); err != nil {
+			return err
+		}
+		if err := q.ClearDefaultAccount(ctx, sqlc.ClearDefaultAccountParams{
+			ID:               transfer.FromUserID,
+			DefaultAccountID: uuid.NullUUID{UUID: acc.ID, Valid: true},
+		}); err != nil {
+			return err
+		}
+		transfer, err = q.DecideOwnershipTransfer(ctx, sqlc.DecideOwnershipTransferParams{
+			Status:       OwnershipTransferApproved,
+			DecidedBy:    uuid.NullUUID{UUID: approverID, Valid: true},
+			DecisionNote: note,
+			ID:           transfer.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.OwnershipTransfer{}, err
+	}
+
+	logger(ctx).Info().Str("ownership_transfer_id", transfer.ID.String()).Str("account_id", transfer.AccountID.String()).Str("from_user_id", transfer.FromUserID.String()).Str("to_user_id", transfer.ToUserID.String()).Str("approved_by", approverID.String()).Msg("Account ownership transferred")
+	return transfer, nil
+}
+
+// RejectOwnershipTransfer closes a pending request without changing the account.
+func (s *LedgerService) RejectOwnershipTransfer(ctx context.Context, id, approverID uuid.UUID, note string) (sqlc.OwnershipTransfer, error) {
+	var transfer sqlc.OwnershipTransfer
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		if transfer, err = lockPendingOwnershipTransfer(ctx, q, id, approverID); err != nil {
+			return err
+		}
+		transfer, err = q.DecideOwnershipTransfer(ctx, sqlc.DecideOwnershipTransferParams{
+			Status:       OwnershipTransferRejected,
+			DecidedBy:    uuid.NullUUID{UUID: approverID, Valid: true},
+			DecisionNote: note,
+			ID:           transfer.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.OwnershipTransfer{}, err
+	}
+
+	logger(ctx).Info().Str("ownership_transfer_id", transfer.ID.String()).Str("account_id", transfer.AccountID.String()).Str("rejected_by", approverID.String()).Msg("Ownership transfer rejected")
+	return transfer, nil
+}
+
+// lockPendingOwnershipTransfer locks a request approverID may still decide.
+func lockPendingOwnershipTransfer(ctx context.Context, q *sqlc.Queries, id, approverID uuid.UUID) (sqlc.OwnershipTransfer, error) {
+	transfer, err := q.GetOwnershipTransferForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.OwnershipTransfer{}, ErrOwnershipTransferNotFound
+		}
+		return sqlc.OwnershipTransfer{}, err
+	}
+	if transfer.Status != OwnershipTransferPending {
+		return sqlc.OwnershipTransfer{}, ErrOwnershipTransferNotPending
+	}
+	if transfer.RequestedBy == approverID {
+		return sqlc.OwnershipTransfer{}, ErrOwnershipTransferSelfApproval
+	}
+	return transfer, nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// accessRoles serves account roles keyed by user and account from memory.
+type accessRoles map[[2]uuid.UUID]string
+
+func (r accessRoles) GetAccountAccessRole(_ context.Context, arg sqlc.GetAccountAccessRoleParams) (string, error) {
+	role, ok := r[[2]uuid.UUID{arg.UserID, arg.AccountID}]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return role, nil
+}
+
+func TestOwnsAccount(t *testing.T) {
+	// Only the owner role counts; viewers, strangers and ledger accounts do not own.
+	owner, viewer, stranger := uuid.New(), uuid.New(), uuid.New()
+	acc := sqlc.Account{ID: uuid.New(), OwnerID: uuid.NullUUID{UUID: owner, Valid: true}}
+	roles := accessRoles{{owner, acc.ID}: "owner", {viewer, acc.ID}: "viewer"}
+	ctx := context.Background()
+
+	for user, want := range map[uuid.UUID]bool{owner: true, viewer: false, stranger: false} {
+		got, err := OwnsAccount(ctx, roles, user, acc)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	got, err := OwnsAccount(ctx, roles, owner, sqlc.Account{ID: acc.ID, IsSystem: true, OwnerID: acc.OwnerID})
+	require.NoError(t, err)
+	assert.False(t, got)
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrPaymentRequestNotFound is returned when no payment request has the given token.
+	ErrPaymentRequestNotFound = errors.New("payment request not found")
+	// ErrPaymentRequestClosed is returned when a payment request was already paid or cancelled.
+	ErrPaymentRequestClosed = errors.New("payment request already paid or cancelled")
+	// ErrPaymentRequestExpired is returned when paying a request after its expiry.
+	ErrPaymentRequestExpired = errors.New("payment request has expired")
+)
+
+// Payment request statuses stored on the payment_requests table. Expiry is not stored:
+// a pending request past expires_at simply can no longer be paid.
+const (
+	PaymentRequestPending   = "pending"
+	PaymentRequestPaid      = "paid"
+	PaymentRequestCancelled = "cancelled"
+	// PaymentRequestExpired is reported for pending requests past their expiry.
+	PaymentRequestExpired = "expired"
+)
+
+// PaymentRequestStatus reports the request's status at now, including expiry.
+func PaymentRequestStatus(pr sqlc.PaymentRequest, now time.Time) string {
+	if pr.Status == PaymentRequestPending && !now.Before(pr.ExpiresAt) {
+		return PaymentRequestExpired
+	}
+	return pr.Status
+}
+
+// PaymentRequestInput asks payers for amount into AccountID until ExpiresAt.
+type PaymentRequestInput struct {
+	ExpiresAt time.Time
+	Amount    string
+	Memo      string
+	AccountID uuid.UUID
+	CreatedBy uuid.UUID
+}
+
+// newPaymentRequestToken returns the unguessable handle shared in a payment link.
+func newPaymentRequestToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "pr_" + hex.EncodeToString(b), nil
+}
+
+// CreatePaymentRequest records a pending request for money into a customer account.
+func (s *LedgerService) CreatePaymentRequest(ctx context.Context, in PaymentRequestInput) (sqlc.PaymentRequest, error) {
+	amount, err := validatePositiveAmount(in.Amount)
+	if err != nil {
+		return sqlc.PaymentRequest{}, err
+	}
+	acc, err := s.store.GetAccount(ctx, in.AccountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.PaymentRequest{}, ErrAccountNotFound
+		}
+		return sqlc.PaymentRequest{}, err
+	}
+	if acc.IsSystem {
+		return sqlc.PaymentRequest{}, ErrAccountNotFound
+	}
+	token, err := newPaymentRequestToken()
+	if err != nil {
+		return sqlc.PaymentRequest{}, fmt.Errorf("generate payment request token: %w", err)
+	}
+
+	pr, err := s.store.CreatePaymentRequest(ctx, sqlc.CreatePaymentRequestParams{
+		Token:     token,
+		AccountID: acc.ID,
+		Amount:    amount.StringFixed(4),
+		Currency:  acc.Currency,
+		Memo:      in.Memo,
+		ExpiresAt: in.ExpiresAt,
+		CreatedBy: in.CreatedBy,
+	})
+	if err != nil {
+		return sqlc.PaymentRequest{}, err
+	}
+
+	logger(ctx).Info().Str("payment_request_id", pr.ID.String()).Str("account_id", acc.ID.String()).Str("amount", pr.Amount).Msg("Payment request created")
+	return pr, nil
+}
+
+// PayPaymentRequest transfers the requested amount from the payer's account and closes the
+// request in the same transaction. The transfer follows the payer's product rules and fees, and
+// is published as a payment_request event so both sides are alerted. The payment is screened for
+// AML before it posts.
+func (s *LedgerService) PayPaymentRequest(ctx context.Context, token string, payerAccountID, paidBy uuid.UUID) (sqlc.PaymentRequest, error) {
+	sc, err := s.screenPaymentRequest(ctx, token, payerAccountID)
+	if err != nil {
+		return sqlc.PaymentRequest{}, err
+	}
+
+	var (
+		pr  sqlc.PaymentRequest
+		evt events.Event
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the request so it cannot be paid twice.
+		var err error
+		pr, err = q.GetPaymentRequestByTokenForUpdate(ctx, token)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrPaymentRequestNotFound
+			}
+			return err
+		}
+		switch PaymentRequestStatus(pr, time.Now()) {
+		case PaymentRequestPending:
+		case PaymentRequestExpired:
+			return ErrPaymentRequestExpired
+		default:
+			return ErrPaymentRequestClosed
+		}
+		if payerAccountID == pr.AccountID {
+			return ErrSameAccountTransfer
+		}
+		amount, err := decimal.NewFromString(pr.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid payment request amount: %w", err)
+		}
+
+		// Step 2: Post the transfer under a new transaction and close the request.
+		txID := uuid.New()
+		evt, err = postTransfer(ctx, q, txID, payerAccountID, pr.AccountID, amount, pr.Memo)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+		evt.Type = events.TypePaymentRequest
+		pr, err = q.MarkPaymentRequestPaid(ctx, sqlc.MarkPaymentRequestPaidParams{
+			PayerAccountID: uuid.NullUUID{UUID: payerAccountID, Valid: true},
+			PaidBy:         uuid.NullUUID{UUID: paidBy, Valid: true},
+			TransactionID:  uuid.NullUUID{UUID: txID, Valid: true},
+			ID:             pr.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.PaymentRequest{}, err
+	}
+
+	logger(ctx).Info().Str("payment_request_id", pr.ID.String()).Str("payer_account_id", payerAccountID.String()).Str("tx_id", pr.TransactionID.UUID.String()).Msg("Payment request paid")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, pr.TransactionID.UUID)
+	return pr, nil
+}
+
+// screenPaymentRequest screens paying the pending request behind token from payerAccountID. The
+// request cannot wait for review, as its payer is waiting on it, so a hold refuses it. Requests
+// that are missing or no longer pending are left to PayPaymentRequest to refuse under lock.
+func (s *LedgerService) screenPaymentRequest(ctx context.Context, token string, payerAccountID uuid.UUID) (screening, error) {
+	pr, err := s.store.GetPaymentRequestByToken(ctx, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return screening{}, nil
+	}
+	if err != nil {
+		return screening{}, err
+	}
+	if PaymentRequestStatus(pr, time.Now()) != PaymentRequestPending {
+		return screening{}, nil
+	}
+	amount, err := decimal.NewFromString(pr.Amount)
+	if err != nil {
+		return screening{}, fmt.Errorf("invalid payment request amount: %w", err)
+	}
+	return s.screen(ctx, "payment_request", payerAccountID, uuid.NullUUID{UUID: pr.AccountID, Valid: true}, aml.Outbound, amount, false)
+}
+
+// CancelPaymentRequest withdraws a pending request so its link can no longer be paid.
+func (s *LedgerService) CancelPaymentRequest(ctx context.Context, id uuid.UUID) (sqlc.PaymentRequest, error) {
+	pr, err := s.store.CancelPaymentRequest(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The request exists (callers load it first), so it is no longer pending.
+		return sqlc.PaymentRequest{}, ErrPaymentRequestClosed
+	}
+	if err != nil {
+		return sqlc.PaymentRequest{}, err
+	}
+	logger(ctx).Info().Str("payment_request_id", pr.ID.String()).Msg("Payment request cancelled")
+	return pr, nil
+}
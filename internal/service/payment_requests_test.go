@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestPaymentRequestStatus(t *testing.T) {
+	// Pending requests expire at expires_at; paid and cancelled ones keep their status.
+	now := time.Now()
+	pending := sqlc.PaymentRequest{Status: PaymentRequestPending, ExpiresAt: now.Add(time.Hour)}
+	assert.Equal(t, PaymentRequestPending, PaymentRequestStatus(pending, now))
+	assert.Equal(t, PaymentRequestExpired, PaymentRequestStatus(pending, now.Add(time.Hour)))
+
+	paid := sqlc.PaymentRequest{Status: PaymentRequestPaid, ExpiresAt: now.Add(-time.Hour)}
+	assert.Equal(t, PaymentRequestPaid, PaymentRequestStatus(paid, now))
+}
+
+func TestNewPaymentRequestToken(t *testing.T) {
+	// Tokens are prefixed, fixed-length and not repeated.
+	first, err := newPaymentRequestToken()
+	require.NoError(t, err)
+	second, err := newPaymentRequestToken()
+	require.NoError(t, err)
+	assert.Len(t, first, 35)
+	assert.Regexp(t, `^pr_[0-9a-f]{32}$`, first)
+	assert.NotEqual(t, first, second)
+}
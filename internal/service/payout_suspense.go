@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	ErrSuspenseCaseNotFound = errors.New("payout suspense case not found")
+	ErrSuspenseCaseResolved = errors.New("payout suspense case already resolved")
+)
+
+// PayoutSuspenseAccount is the per-currency system account failed payouts wait in.
+const PayoutSuspenseAccount = "Payout Suspense"
+
+// Payout suspense case statuses.
+const (
+	SuspenseCaseOpen     = "open"
+	SuspenseCaseRefunded = "refunded"
+	SuspenseCaseRetried  = "retried"
+)
+
+// RefundSuspenseCase returns a failed payout's funds from suspense to the customer's account and
+// closes its case.
+func (s *LedgerService) RefundSuspenseCase(ctx context.Context, caseID, adminID uuid.UUID, note string) (sqlc.PayoutSuspenseCase, error) {
+	var (
+		sc  sqlc.PayoutSuspenseCase
+		evt events.Event
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the case so only one resolution posts.
+		var err error
+		sc, err = lockOpenSuspenseCase(ctx, q, caseID)
+		if err != nil {
+			return err
+		}
+		amount, err := decimal.NewFromString(sc.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid suspense amount: %w", err)
+		}
+
+		// Step 2: Credit the customer from suspense.
+		suspense, err := lockSystemAccount(ctx, q, PayoutSuspenseAccount, sc.Currency)
+		if err != nil {
+			return err
+		}
+		account, err := q.GetAccountForUpdate(ctx, sc.AccountID)
+		if err != nil {
+			return fmt.Errorf("account not found: %w", err)
+		}
+		payout, err := q.GetPayout(ctx, sc.PayoutID)
+		if err != nil {
+			return err
+		}
+		txID := uuid.New()
+		entries, balances, err := postLegs(ctx, q, txID, "reversal",
+			debitLeg(suspense, amount, "Payout refunded "+payout.Reference),
+			creditLeg(account, amount, "Reversal of failed bank payout "+payout.Reference),
+		)
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Close the case against the refund.
+		sc, err = q.ResolvePayoutSuspenseCase(ctx, sqlc.ResolvePayoutSuspenseCaseParams{
+			Status:                  SuspenseCaseRefunded,
+			ResolutionTransactionID: uuid.NullUUID{UUID: txID, Valid: true},
+			ResolvedBy:              uuid.NullUUID{UUID: adminID, Valid: true},
+			ResolutionNote:          note,
+			ID:                      sc.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		evt = events.Event{
+			Type:          events.TypeReversal,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      sc.Currency,
+			Entries:       entries,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.PayoutSuspenseCase{}, err
+	}
+
+	logger(ctx).Info().Str("case_id", sc.ID.String()).Str("admin_id", adminID.String()).Msg("Failed payout refunded from suspense")
+	s.publish(ctx, evt)
+	return sc, nil
+}
+
+// RetrySuspenseCase moves a failed payout's funds from suspense back into payouts clearing as the
+// hold of a new pending payout under reference, to the same beneficiary, and closes the case.
+// The caller sends the new payout on its rail; if it fails too, it opens a case of its own.
+func (s *LedgerService) RetrySuspenseCase(ctx context.Context, caseID, adminID uuid.UUID, note, reference string) (sqlc.PayoutSuspenseCase, sqlc.Payout, error) {
+	var (
+		sc    sqlc.PayoutSuspenseCase
+		retry sqlc.Payout
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the case so only one resolution posts.
+		var err error
+		sc, err = lockOpenSuspenseCase(ctx, q, caseID)
+		if err != nil {
+			return err
+		}
+		amount, err := decimal.NewFromString(sc.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid suspense amount: %w", err)
+		}
+		failed, err := q.GetPayout(ctx, sc.PayoutID)
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Hold the funds in clearing again, in the order CompletePayout locks them.
+		clearing, err := q.GetPayoutClearingAccountForUpdate(ctx)
+		if err != nil {
+			return fmt.Errorf("payout clearing account not found: %w", err)
+		}
+		suspense, err := lockSystemAccount(ctx, q, PayoutSuspenseAccount, sc.Currency)
+		if err != nil {
+			return err
+		}
+		txID := uuid.New()
+		if _, _, err := postLegsWithStatus(ctx, q, txID, "withdrawal", TransactionPending,
+			debitLeg(suspense, amount, "Payout retried "+failed.Reference),
+			creditLeg(clearing, amount, fmt.Sprintf("Payout hold for %s", sc.AccountID)),
+		); err != nil {
+			return err
+		}
+		retry, err = q.CreatePayout(ctx, sqlc.CreatePayoutParams{
+			Provider:          failed.Provider,
+			Reference:         reference,
+			AccountID:         failed.AccountID,
+			UserID:            failed.UserID,
+			Amount:            failed.Amount,
+			Currency:          failed.Currency,
+			BankCode:          failed.BankCode,
+			AccountNumber:     failed.AccountNumber,
+			Narration:         failed.Narration,
+			HoldTransactionID: txID,
+			BeneficiaryName:   failed.BeneficiaryName,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Close the case against the new payout.
+		sc, err = q.ResolvePayoutSuspenseCase(ctx, sqlc.ResolvePayoutSuspenseCaseParams{
+			Status:                  SuspenseCaseRetried,
+			ResolutionTransactionID: uuid.NullUUID{UUID: txID, Valid: true},
+			RetryPayoutID:           uuid.NullUUID{UUID: retry.ID, Valid: true},
+			ResolvedBy:              uuid.NullUUID{UUID: adminID, Valid: true},
+			ResolutionNote:          note,
+			ID:                      sc.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.PayoutSuspenseCase{}, sqlc.Payout{}, err
+	}
+
+	logger(ctx).Info().
+		Str("case_id", sc.ID.String()).
+		Str("admin_id", adminID.String()).
+		Str("reference", retry.Reference).
+		Msg("Failed payout retried from suspense")
+	return sc, retry, nil
+}
+
+// GetSuspenseCase returns a payout suspense case.
+func (s *LedgerService) GetSuspenseCase(ctx context.Context, caseID uuid.UUID) (sqlc.PayoutSuspenseCase, error) {
+	sc, err := s.store.GetPayoutSuspenseCase(ctx, caseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.PayoutSuspenseCase{}, ErrSuspenseCaseNotFound
+	}
+	return sc, err
+}
+
+// lockOpenSuspenseCase locks a case that has yet to be resolved.
+func lockOpenSuspenseCase(ctx context.Context, q *sqlc.Queries, caseID uuid.UUID) (sqlc.PayoutSuspenseCase, error) {
+	sc, err := q.GetPayoutSuspenseCaseForUpdate(ctx, caseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.PayoutSuspenseCase{}, ErrSuspenseCaseNotFound
+	}
+	if err != nil {
+		return sqlc.PayoutSuspenseCase{}, err
+	}
+	if sc.Status != SuspenseCaseOpen {
+		return sqlc.PayoutSuspenseCase{}, ErrSuspenseCaseResolved
+	}
+	return sc, nil
+}
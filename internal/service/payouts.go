@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// ErrPayoutNotFound is returned when a payout callback names an unknown reference.
+var ErrPayoutNotFound = errors.New("payout not found")
+
+// Payout statuses stored on the payouts table.
+const (
+	PayoutPending   = "pending"
+	PayoutSucceeded = "succeeded"
+	PayoutFailed    = "failed"
+)
+
+// PayoutRequest describes money leaving a user account for an external bank account.
+type PayoutRequest struct {
+	AccountID     uuid.UUID
+	UserID        uuid.UUID
+	Provider      string
+	Reference     string
+	Amount        string
+	BankCode      string
+	AccountNumber string
+	Narration     string
+	// BeneficiaryName is the destination account name confirmed by name enquiry, when the rail offers one.
+	BeneficiaryName string
+}
+
+// HoldPayout debits the user into the payouts clearing account and records a pending payout.
+// The money has left the customer's balance but not the bank until CompletePayout runs.
+func (s *LedgerService) HoldPayout(ctx context.Context, req PayoutRequest) (sqlc.Payout, error) {
+	amount, err := validatePositiveAmount(req.Amount)
+	if err != nil {
+		return sqlc.Payout{}, err
+	}
+	// Money sent to another bank cannot be recalled for review, so a hold blocks the payout.
+	sc, err := s.screen(ctx, "payout", req.AccountID, uuid.NullUUID{}, aml.Outbound, amount, false)
+	if err != nil {
+		return sqlc.Payout{}, err
+	}
+	beneficiary := payoutBeneficiary(req.BankCode, req.AccountNumber)
+	ctx, err = s.assessRisk(ctx, "payout", req.AccountID, beneficiary, amount, false)
+	if err != nil {
+		return sqlc.Payout{}, err
+	}
+
+	var (
+		payout sqlc.Payout
+		evt    events.Event
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock clearing + user account, then enforce the no-overdraft invariant.
+		clearing, err := q.GetPayoutClearingAccountForUpdate(ctx)
+		if err != nil {
+			return fmt.Errorf("payout clearing account not found: %w", err)
+		}
+		account, err := q.GetAccountForUpdate(ctx, req.AccountID)
+		if err != nil {
+			return fmt.Errorf("account not found: %w", err)
+		}
+		if account.Currency != clearing.Currency {
+			return ErrCurrencyMismatch
+		}
+		// Payouts follow the product's withdrawal rules; the hold reverses in full on failure,
+		// so no fee is charged and the check leaves room for one.
+		if _, err := checkSpendable(ctx, q, account, amount, debitWithdrawal); err != nil {
+			return err
+		}
+		if err := checkGoalLock(ctx, q, account); err != nil {
+			return err
+		}
+		if err := checkLimit(ctx, q, account, LimitPayout, amount, s.kycLimits); err != nil {
+			return err
+		}
+
+		// Step 2: Move funds into clearing and record the payout against that hold.
+		// The hold stays pending until the rail confirms or rejects the transfer.
+		txID := uuid.New()
+		entries, balances, err := postLegsWithStatus(ctx, q, txID, "withdrawal", TransactionPending,
+			debitLeg(account, amount, "Bank payout "+req.Reference),
+			creditLeg(clearing, amount, fmt.Sprintf("Payout hold for %s", account.ID)),
+		)
+		if err != nil {
+			return err
+		}
+		payout, err = q.CreatePayout(ctx, sqlc.CreatePayoutParams{
+			Provider:          req.Provider,
+			Reference:         req.Reference,
+			AccountID:         account.ID,
+			UserID:            req.UserID,
+			Amount:            amount.StringFixed(4),
+			Currency:          account.Currency,
+			BankCode:          req.BankCode,
+			AccountNumber:     req.AccountNumber,
+			Narration:         req.Narration,
+			HoldTransactionID: txID,
+			BeneficiaryName:   req.BeneficiaryName,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.rememberBeneficiary(ctx, q, account.ID, beneficiary); err != nil {
+			return err
+		}
+
+		evt = events.Event{
+			Type:          events.TypeWithdrawal,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      account.Currency,
+			Entries:       entries,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.Payout{}, err
+	}
+
+	logger(ctx).Info().Str("reference", payout.Reference).Str("account_id", payout.AccountID.String()).Str("amount", payout.Amount).Msg("Payout funds held")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, evt.TransactionID)
+	return payout, nil
+}
+
+// CompletePayout settles or suspends a held payout once the rail reports its outcome.
+// Success moves the hold to settlement; failure moves it to Payout Suspense and opens a suspense
+// case to refund or retry. Repeat callbacks are no-ops.
+func (s *LedgerService) CompletePayout(ctx context.Context, reference string, succeeded bool, reason string) (sqlc.Payout, error) {
+	var (
+		payout sqlc.Payout
+		evt    events.Event
+		posted bool
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the payout so duplicate callbacks serialize and only one posts.
+		var err error
+		payout, err = q.GetPayoutByReferenceForUpdate(ctx, reference)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrPayoutNotFound
+			}
+			return err
+		}
+		posted = false
+		if payout.Status != PayoutPending {
+			return nil
+		}
+		amount, err := decimal.NewFromString(payout.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid payout amount: %w", err)
+		}
+
+		// Step 2: Release the clearing hold to its final destination.
+		clearing, err := q.GetPayoutClearingAccountForUpdate(ctx)
+		if err != nil {
+			return fmt.Errorf("payout clearing account not found: %w", err)
+		}
+		txID := uuid.New()
+		var (
+			entries  []sqlc.Entry
+			balances map[uuid.UUID]string
+			status   = PayoutSucceeded
+		)
+		if succeeded {
+			settlement, err := q.GetSettlementShardForUpdate(ctx)
+			if err != nil {
+				return fmt.Errorf("settlement account not found: %w", err)
+			}
+			entries, balances, err = postLegs(ctx, q, txID, "withdrawal",
+				debitLeg(clearing, amount, "Payout settled "+reference),
+				creditLeg(settlement, amount, fmt.Sprintf("Withdrawal from %s", payout.AccountID)),
+			)
+			if err != nil {
+				return err
+			}
+			evt = events.Event{Type: events.TypeWithdrawal}
+		} else {
+			// The customer is not credited yet: the funds wait in suspense until an admin
+			// refunds them or retries the payout.
+			suspense, err := lockSystemAccount(ctx, q, PayoutSuspenseAccount, payout.Currency)
+			if err != nil {
+				return err
+			}
+			entries, balances, err = postLegs(ctx, q, txID, "reversal",
+				debitLeg(clearing, amount, "Payout failed "+reference),
+				creditLeg(suspense, amount, fmt.Sprintf("Failed payout %s from %s", reference, payout.AccountID)),
+			)
+			if err != nil {
+				return err
+			}
+			status = PayoutFailed
+		}
+
+		// The hold is now final either way: posted when settled, failed when reversed.
+		holdStatus := TransactionPosted
+		if !succeeded {
+			holdStatus = TransactionFailed
+		}
+		if err := transitionTransaction(ctx, q, payout.HoldTransactionID, TransactionPending, holdStatus); err != nil {
+			return err
+		}
+
+		payout, err = q.CompletePayout(ctx, sqlc.CompletePayoutParams{
+			Status:             status,
+			FinalTransactionID: uuid.NullUUID{UUID: txID, Valid: true},
+			FailureReason:      sql.NullString{String: reason, Valid: reason != ""},
+			Reference:          reference,
+		})
+		if err != nil {
+			return err
+		}
+		if !succeeded {
+			if _, err := q.CreatePayoutSuspenseCase(ctx, sqlc.CreatePayoutSuspenseCaseParams{
+				PayoutID:              payout.ID,
+				AccountID:             payout.AccountID,
+				Amount:                payout.Amount,
+				Currency:              payout.Currency,
+				Reason:                reason,
+				SuspenseTransactionID: txID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		evt.TransactionID = txID
+		evt.Amount = amount.StringFixed(4)
+		evt.Currency = payout.Currency
+		evt.Entries = entries
+		evt.Balances = balances
+		posted = true
+		return nil
+	})
+	if err != nil {
+		return sqlc.Payout{}, err
+	}
+
+	if posted {
+		logger(ctx).Info().Str("reference", reference).Str("status", payout.Status).Msg("Payout completed")
+		if succeeded {
+			s.publish(ctx, evt)
+		}
+	}
+	return payout, nil
+}
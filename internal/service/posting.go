@@ -0,0 +1,190 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/db"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// errUnbalancedPosting guards the double-entry invariant for multi-leg postings.
+var errUnbalancedPosting = errors.New("posting legs do not balance")
+
+// leg is one side of a posting. Exactly one of debit or credit is positive.
+type leg struct {
+	account     sqlc.Account
+	debit       decimal.Decimal
+	credit      decimal.Decimal
+	description string
+	// fee marks the legs of a fee charged on top of the operation, see feeLegs.
+	fee bool
+	// booked marks a leg whose amount the account's cached balance already holds, as when a
+	// balance repair writes the entries a drifted balance is missing; it leaves the balance alone.
+	booked bool
+}
+
+func debitLeg(acc sqlc.Account, amount decimal.Decimal, description string) leg {
+	return leg{account: acc, debit: amount, credit: decimal.Zero, description: description}
+}
+
+func creditLeg(acc sqlc.Account, amount decimal.Decimal, description string) leg {
+	return leg{account: acc, debit: decimal.Zero, credit: amount, description: description}
+}
+
+// postLegs logs balanced legs as one posted transaction and projects them: the transaction, its
+// entries and the cached balance movements.
+// Callers must already hold row locks on every account involved.
+// It returns the entries and each account's balance after the posting.
+func postLegs(ctx context.Context, q *sqlc.Queries, txID uuid.UUID, operationType string, legs ...leg) ([]sqlc.Entry, map[uuid.UUID]string, error) {
+	return postLegsWithStatus(ctx, q, txID, operationType, TransactionPosted, legs...)
+}
+
+// postLegsWithStatus is postLegs for transactions that start in a status other than posted,
+// such as a pending hold awaiting an external rail.
+func postLegsWithStatus(ctx context.Context, q *sqlc.Queries, txID uuid.UUID, operationType, status string, legs ...leg) ([]sqlc.Entry, map[uuid.UUID]string, error) {
+	// Debits equal credits within each currency; a conversion balances on both sides separately.
+	debits := make(map[string]decimal.Decimal)
+	credits := make(map[string]decimal.Decimal)
+	for _, l := range legs {
+		debits[l.account.Currency] = debits[l.account.Currency].Add(l.debit)
+		credits[l.account.Currency] = credits[l.account.Currency].Add(l.credit)
+	}
+	if len(legs) == 0 {
+		return nil, nil, errUnbalancedPosting
+	}
+	// Amounts are stored at their currency's exponent: no fractional yen, no tenths of a kobo. A
+	// balance repair books whatever drift it finds, so it is exempt.
+	if !slices.ContainsFunc(legs, func(l leg) bool { return l.booked }) {
+		for _, l := range legs {
+			if !currencies.Fits(l.account.Currency, l.debit) || !currencies.Fits(l.account.Currency, l.credit) {
+				return nil, nil, ErrAmountPrecision
+			}
+		}
+	}
+	for ccy, debit := range debits {
+		if !debit.Equal(credits[ccy]) || debit.IsZero() {
+			return nil, nil, errUnbalancedPosting
+		}
+	}
+	if err := checkCustomerBalances(legs); err != nil {
+		if errors.Is(err, ErrInsufficientFunds) {
+			insufficientFundsTotal.Inc(operationType)
+		}
+		return nil, nil, err
+	}
+
+	// Append the posting to the ledger's event log, then apply it to the projections.
+	posted := newPostedEvent(ctx, operationType, status, legs)
+	if err := appendLedgerEvent(ctx, q, LedgerEventPosted, txID, posted); err != nil {
+		return nil, nil, err
+	}
+	entries, err := projectPosting(ctx, q, txID, posted)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	balances := make(map[uuid.UUID]string, len(legs))
+	running := make(map[uuid.UUID]decimal.Decimal, len(legs))
+	for _, l := range legs {
+		// The same account may appear on several legs (e.g., fees); accumulate against its locked balance.
+		if _, seen := running[l.account.ID]; !seen {
+			start, err := decimal.NewFromString(l.account.Balance)
+			if err != nil {
+				return nil, nil, errors.New("invalid balance")
+			}
+			running[l.account.ID] = start
+		}
+		if !l.booked {
+			running[l.account.ID] = running[l.account.ID].Add(l.credit.Sub(l.debit))
+		}
+		balances[l.account.ID] = currencies.Format(l.account.Currency, running[l.account.ID])
+	}
+	return entries, balances, nil
+}
+
+// checkCustomerBalances refuses legs that would leave a customer account below zero, or below
+// minus its overdraft limit. System accounts (settlement, clearing, FX position) may go negative.
+func checkCustomerBalances(legs []leg) error {
+	after := make(map[uuid.UUID]decimal.Decimal, len(legs))
+	floor := make(map[uuid.UUID]decimal.Decimal, len(legs))
+	for _, l := range legs {
+		if l.account.IsSystem || l.booked {
+			continue
+		}
+		if _, seen := after[l.account.ID]; !seen {
+			start, err := decimal.NewFromString(l.account.Balance)
+			if err != nil {
+				return errors.New("invalid balance")
+			}
+			after[l.account.ID] = start
+			floor[l.account.ID] = decimal.Zero
+			if l.account.OverdraftLimit != "" {
+				overdraft, err := decimal.NewFromString(l.account.OverdraftLimit)
+				if err != nil {
+					return errors.New("invalid overdraft limit")
+				}
+				floor[l.account.ID] = overdraft.Neg()
+			}
+		}
+		after[l.account.ID] = after[l.account.ID].Add(l.credit).Sub(l.debit)
+	}
+	for id, balance := range after {
+		if balance.LessThan(floor[id]) {
+			return ErrInsufficientFunds
+		}
+	}
+	return nil
+}
+
+// updateBalances moves each account's cached balance by the sum of its deltas, accountIDs and
+// deltas running in parallel. The database refuses a customer balance below its overdraft even if
+// a caller skipped its own check; that surfaces as ErrInsufficientFunds.
+func updateBalances(ctx context.Context, q *sqlc.Queries, accountIDs []uuid.UUID, deltas []string) error {
+	err := q.UpdateAccountBalances(ctx, sqlc.UpdateAccountBalancesParams{
+		AccountIds: accountIDs,
+		Deltas:     deltas,
+	})
+	if db.IsCheckViolation(err, db.CustomerBalanceConstraint) {
+		return ErrInsufficientFunds
+	}
+	return err
+}
+
+// lockAccountPair locks two accounts in ID order, so postings between the same pair in
+// opposite directions cannot deadlock, and returns them in argument order.
+func lockAccountPair(ctx context.Context, q *sqlc.Queries, firstID, secondID uuid.UUID) (sqlc.Account, sqlc.Account, error) {
+	locked, err := lockAccounts(ctx, q, firstID, secondID)
+	if err != nil {
+		return sqlc.Account{}, sqlc.Account{}, err
+	}
+	return locked[firstID], locked[secondID], nil
+}
+
+// lockAccounts locks every account in ID order, like lockAccountPair, and returns them by ID.
+// Repeated IDs are locked once.
+func lockAccounts(ctx context.Context, q *sqlc.Queries, ids ...uuid.UUID) (map[uuid.UUID]sqlc.Account, error) {
+	sorted := slices.Clone(ids)
+	slices.SortFunc(sorted, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+	sorted = slices.Compact(sorted)
+
+	locked := make(map[uuid.UUID]sqlc.Account, len(sorted))
+	for _, id := range sorted {
+		acc, err := q.GetAccountForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrAccountNotFound
+			}
+			return nil, err
+		}
+		locked[id] = acc
+	}
+	return locked, nil
+}
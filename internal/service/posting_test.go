@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestPostLegs_RejectsUnbalanced(t *testing.T) {
+	// Unbalanced legs are refused before any entry is written.
+	acc := sqlc.Account{ID: uuid.New(), Balance: "10.0000"}
+	_, _, err := postLegs(context.Background(), nil, uuid.New(), "withdrawal",
+		debitLeg(acc, decimal.RequireFromString("5"), ""),
+		creditLeg(acc, decimal.RequireFromString("4"), ""),
+	)
+	assert.ErrorIs(t, err, errUnbalancedPosting)
+}
+
+func TestPostLegs_BalancesPerCurrency(t *testing.T) {
+	// Legs that only balance when currencies are mixed are refused.
+	usd := sqlc.Account{ID: uuid.New(), Balance: "10.0000", Currency: "USD"}
+	ngn := sqlc.Account{ID: uuid.New(), Balance: "10.0000", Currency: "NGN"}
+	_, _, err := postLegs(context.Background(), nil, uuid.New(), "conversion",
+		debitLeg(usd, decimal.RequireFromString("5"), ""),
+		creditLeg(ngn, decimal.RequireFromString("5"), ""),
+	)
+	assert.ErrorIs(t, err, errUnbalancedPosting)
+}
+
+func TestPostLegs_RefusesAmountsFinerThanTheCurrency(t *testing.T) {
+	// Yen have no minor unit and naira stop at the kobo.
+	cases := []struct {
+		currency, amount string
+	}{
+		{"JPY", "100.5"},
+		{"NGN", "0.005"},
+	}
+	for _, c := range cases {
+		customer := sqlc.Account{ID: uuid.New(), Balance: "1000.0000", Currency: c.currency}
+		other := sqlc.Account{ID: uuid.New(), Balance: "0.0000", Currency: c.currency}
+		_, _, err := postLegs(context.Background(), nil, uuid.New(), "transfer",
+			debitLeg(customer, decimal.RequireFromString(c.amount), ""),
+			creditLeg(other, decimal.RequireFromString(c.amount), ""),
+		)
+		assert.ErrorIs(t, err, ErrAmountPrecision, c.currency)
+	}
+}
+
+func TestPostLegs_RefusesNegativeCustomerBalance(t *testing.T) {
+	// A customer account can never be left below zero, even across several legs.
+	customer := sqlc.Account{ID: uuid.New(), Balance: "10.0000", Currency: "USD"}
+	other := sqlc.Account{ID: uuid.New(), Balance: "0.0000", Currency: "USD"}
+	_, _, err := postLegs(context.Background(), nil, uuid.New(), "transfer",
+		debitLeg(customer, decimal.RequireFromString("8"), ""),
+		debitLeg(customer, decimal.RequireFromString("3"), ""),
+		creditLeg(other, decimal.RequireFromString("11"), ""),
+	)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCheckCustomerBalances_SystemAccountsMayGoNegative(t *testing.T) {
+	// Settlement-style system accounts carry the other side of external flows.
+	settlement := sqlc.Account{ID: uuid.New(), Balance: "0.0000", IsSystem: true}
+	customer := sqlc.Account{ID: uuid.New(), Balance: "0.0000"}
+	assert.NoError(t, checkCustomerBalances([]leg{
+		debitLeg(settlement, decimal.RequireFromString("5"), ""),
+		creditLeg(customer, decimal.RequireFromString("5"), ""),
+	}))
+}
+
+func TestCheckCustomerBalances_AllowsOverdraft(t *testing.T) {
+	// An account with an overdraft may go that far below zero and no further.
+	customer := sqlc.Account{ID: uuid.New(), Balance: "10.0000", OverdraftLimit: "50.0000"}
+	other := sqlc.Account{ID: uuid.New(), Balance: "0.0000"}
+	assert.NoError(t, checkCustomerBalances([]leg{
+		debitLeg(customer, decimal.RequireFromString("60"), ""),
+		creditLeg(other, decimal.RequireFromString("60"), ""),
+	}))
+	assert.ErrorIs(t, checkCustomerBalances([]leg{
+		debitLeg(customer, decimal.RequireFromString("60.0001"), ""),
+		creditLeg(other, decimal.RequireFromString("60.0001"), ""),
+	}), ErrInsufficientFunds)
+}
+
+func TestCheckCustomerBalances_SkipsBookedLegs(t *testing.T) {
+	// A booked leg writes an entry the cached balance already holds, so it cannot overdraw it.
+	customer := sqlc.Account{ID: uuid.New(), Balance: "5.0000"}
+	suspense := sqlc.Account{ID: uuid.New(), Balance: "0.0000", IsSystem: true}
+	fix := debitLeg(customer, decimal.RequireFromString("20"), "")
+	fix.booked = true
+	assert.NoError(t, checkCustomerBalances([]leg{fix, creditLeg(suspense, decimal.RequireFromString("20"), "")}))
+}
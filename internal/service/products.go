@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// DefaultProduct is the product of accounts opened without one.
+const DefaultProduct = "current"
+
+// KindPayInterest is the background job kind that pays last month's interest.
+const KindPayInterest = "products.interest"
+
+// System accounts for product rules. One of each exists per currency, created on first use.
+const (
+	feeIncomeAccount       = "Fee Income"
+	interestExpenseAccount = "Interest Expense"
+)
+
+var (
+	// ErrMinimumBalance is returned when a debit would leave less than the product's minimum balance.
+	ErrMinimumBalance = errors.New("amount exceeds available balance: the account's minimum balance must remain")
+	// ErrInvalidProduct is returned for malformed product codes.
+	ErrInvalidProduct = errors.New("product must be 1-32 lowercase letters, digits or underscores, starting with a letter")
+	// ErrDebitLimitExceeded is returned when a single debit is above the product's per-transaction cap.
+	ErrDebitLimitExceeded = errors.New("amount exceeds the account's per-transaction limit")
+	// ErrOperationNotAllowed is returned when the account's product does not allow the operation.
+	ErrOperationNotAllowed = errors.New("operation not allowed for this account type")
+)
+
+var productPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// ValidateProduct reports whether product is a well-formed product code.
+func ValidateProduct(product string) error {
+	if !productPattern.MatchString(product) {
+		return ErrInvalidProduct
+	}
+	return nil
+}
+
+// debitKind selects the product rules that apply to money leaving an account.
+type debitKind int
+
+const (
+	debitWithdrawal debitKind = iota
+	debitTransfer
+)
+
+// ProductRules are the rules of an account's product in the account's currency.
+type ProductRules struct {
+	// MaxDebit caps a single withdrawal or transfer; invalid means no cap.
+	MaxDebit       decimal.NullDecimal
+	MinBalance     decimal.Decimal
+	OverdraftLimit decimal.Decimal
+	TransferFee    decimal.Decimal
+	WithdrawalFee  decimal.Decimal
+	Product        string
+	// InterestRateBps is the annual rate paid monthly on positive balances.
+	InterestRateBps    int32
+	WithdrawalsEnabled bool
+	TransfersEnabled   bool
+}
+
+// AvailableBalance is what can be spent from balance: down to the overdraft limit, or to the minimum.
+func (r ProductRules) AvailableBalance(balance decimal.Decimal) decimal.Decimal {
+	return decimal.Max(balance.Add(r.OverdraftLimit).Sub(r.MinBalance), decimal.Zero)
+}
+
+func (r ProductRules) fee(kind debitKind) decimal.Decimal {
+	if kind == debitTransfer {
+		return r.TransferFee
+	}
+	return r.WithdrawalFee
+}
+
+// ProductReader is the query subset needed to look up product rules.
+// *sqlc.Queries and *db.Store satisfy it.
+type ProductReader interface {
+	GetProduct(ctx context.Context, code string) (sqlc.Product, error)
+	GetAccountProduct(ctx context.Context, arg sqlc.GetAccountProductParams) (sqlc.AccountProduct, error)
+}
+
+// RulesFor returns the rules that apply to acc. System accounts and sub-wallets are unrestricted,
+// and a product without amounts for the account's currency has no minimum, overdraft, fees or cap.
+func RulesFor(ctx context.Context, q ProductReader, acc sqlc.Account) (ProductRules, error) {
+	if acc.IsSystem || acc.ParentAccountID.Valid {
+		return ProductRules{Product: acc.Product, WithdrawalsEnabled: true, TransfersEnabled: true}, nil
+	}
+	product, err := q.GetProduct(ctx, acc.Product)
+	if err != nil {
+		return ProductRules{}, fmt.Errorf("load product %s: %w", acc.Product, err)
+	}
+	rules := ProductRules{
+		Product:            product.Code,
+		InterestRateBps:    product.InterestRateBps,
+		WithdrawalsEnabled: product.WithdrawalsEnabled,
+		TransfersEnabled:   product.TransfersEnabled,
+	}
+
+	amounts, err := q.GetAccountProduct(ctx, sqlc.GetAccountProductParams{Product: acc.Product, Currency: acc.Currency})
+	if errors.Is(err, sql.ErrNoRows) {
+		return rules, nil
+	}
+	if err != nil {
+		return ProductRules{}, fmt.Errorf("load product %s rules for %s: %w", acc.Product, acc.Currency, err)
+	}
+	for _, f := range []struct {
+		dst *decimal.Decimal
+		src string
+	}{
+		{&rules.MinBalance, amounts.MinBalance},
+		{&rules.OverdraftLimit, amounts.OverdraftLimit},
+		{&rules.TransferFee, amounts.TransferFee},
+		{&rules.WithdrawalFee, amounts.WithdrawalFee},
+	} {
+		if *f.dst, err = decimal.NewFromString(f.src); err != nil {
+			return ProductRules{}, fmt.Errorf("invalid amount in product %s rules: %w", acc.Product, err)
+		}
+	}
+	if amounts.MaxDebit.Valid {
+		maxDebit, err := decimal.NewFromString(amounts.MaxDebit.String)
+		if err != nil {
+			return ProductRules{}, fmt.Errorf("invalid max debit in product %s rules: %w", acc.Product, err)
+		}
+		rules.MaxDebit = decimal.NewNullDecimal(maxDebit)
+	}
+	return rules, nil
+}
+
+// checkSpendable applies acc's product rules to a debit of amount from the locked account and
+// returns the fee to charge on top. The amount plus fee may use the overdraft but never the minimum.
+func checkSpendable(ctx context.Context, q ProductReader, acc sqlc.Account, amount decimal.Decimal, kind debitKind) (decimal.Decimal, error) {
+	balance, err := decimal.NewFromString(acc.Balance)
+	if err != nil {
+		return decimal.Zero, errors.New("invalid balance")
+	}
+	rules, err := RulesFor(ctx, q, acc)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if (kind == debitWithdrawal && !rules.WithdrawalsEnabled) || (kind == debitTransfer && !rules.TransfersEnabled) {
+		return decimal.Zero, ErrOperationNotAllowed
+	}
+	if rules.MaxDebit.Valid && amount.GreaterThan(rules.MaxDebit.Decimal) {
+		return decimal.Zero, ErrDebitLimitExceeded
+	}
+
+	fee := rules.fee(kind)
+	after := balance.Sub(amount).Sub(fee)
+	if after.LessThan(rules.OverdraftLimit.Neg()) {
+		operation := "withdrawal"
+		if kind == debitTransfer {
+			operation = "transfer"
+		}
+		return decimal.Zero, insufficientFunds(operation)
+	}
+	if rules.MinBalance.IsPositive() && after.LessThan(rules.MinBalance) {
+		return decimal.Zero, ErrMinimumBalance
+	}
+	return fee, nil
+}
+
+// feeLegs charges fee from acc to the Fee Income account of its currency, or returns nil for no fee.
+// The fee is posted under the same transaction as the operation it pays for, and its entries are
+// recorded as fee entries so a reversal can refund or keep them.
+func feeLegs(ctx context.Context, q *sqlc.Queries, acc sqlc.Account, fee decimal.Decimal, description string) ([]leg, error) {
+	if !fee.IsPositive() {
+		return nil, nil
+	}
+	income, err := lockSystemAccount(ctx, q, feeIncomeAccount, acc.Currency)
+	if err != nil {
+		return nil, err
+	}
+	charge := debitLeg(acc, fee, description)
+	earned := creditLeg(income, fee, fmt.Sprintf("%s from %s", description, acc.ID))
+	charge.fee, earned.fee = true, true
+	return []leg{charge, earned}, nil
+}
+
+// InterestBand is a balance band of a product's rate: the part of a balance from From up to
+// the next band's From earns RateBps a year.
+type InterestBand struct {
+	From    decimal.Decimal
+	RateBps int32
+}
+
+// InterestBands are the bands an account earns in: the product's base rate from zero, then
+// each tier of the account's currency from its minimum balance. Tiers must be sorted by
+// minimum balance, as ListInterestTiers returns them.
+func InterestBands(baseRateBps int32, tiers []sqlc.InterestTier) ([]InterestBand, error) {
+	bands := []InterestBand{{From: decimal.Zero, RateBps: baseRateBps}}
+	for _, t := range tiers {
+		from, err := decimal.NewFromString(t.MinBalance)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum balance in product %s interest tier: %w", t.Product, err)
+		}
+		bands = append(bands, InterestBand{From: from, RateBps: t.RateBps})
+	}
+	return bands, nil
+}
+
+// monthlyInterest is one month of simple interest on balance, each part at the rate of the
+// band it falls in, rounded down to the decimal places of currency. It also returns how many
+// bands the balance reached.
+func monthlyInterest(balance decimal.Decimal, bands []InterestBand, currency string) (decimal.Decimal, int) {
+	total := decimal.Zero
+	reached := 0
+	for i, band := range bands {
+		if !balance.GreaterThan(band.From) {
+			break
+		}
+		part := balance.Sub(band.From)
+		if i+1 < len(bands) {
+			part = decimal.Min(part, bands[i+1].From.Sub(band.From))
+		}
+		total = total.Add(part.Mul(decimal.New(int64(band.RateBps), -4)))
+		reached++
+	}
+	return currencies.RoundDown(currency, total.Div(decimal.NewFromInt(12))), reached
+}
+
+// effectiveRateBps is the single annual rate that pays interest on balance in a month,
+// rounded to a whole basis point.
+func effectiveRateBps(balance, interest decimal.Decimal) int32 {
+	if !balance.IsPositive() {
+		return 0
+	}
+	return int32(interest.Mul(decimal.NewFromInt(120000)).Div(balance).Round(0).IntPart()) // #nosec G115 -- at most the 10000 bps cap
+}
+
+// PayInterest is a jobs.HandlerFunc that pays one month of interest, at each product's rate or
+// balance bands, on the balance of every interest-earning account, funded by the Interest Expense
+// account and less any interest tax rules withhold. Each account is paid at most once per month,
+// so retries and reruns only pay what is missing.
+func (s *LedgerService) PayInterest(ctx context.Context, _ json.RawMessage) error {
+	now := time.Now().UTC()
+	period := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+
+	const batch = 100
+	var (
+		errs  []error
+		after uuid.UUID
+		paid  int
+	)
+	for {
+		rows, err := s.store.ListAccountsEarningInterest(ctx, sqlc.ListAccountsEarningInterestParams{AfterID: after, RowLimit: batch})
+		if err != nil {
+			return errors.Join(append(errs, fmt.Errorf("list accounts earning interest: %w", err))...)
+		}
+		for _, row := range rows {
+			after = row.ID
+			ok, err := s.payAccountInterest(ctx, row.ID, row.InterestRateBps, period)
+			if err != nil {
+				logger(ctx).Error().Err(err).Str("account_id", row.ID.String()).Msg("Failed to pay interest")
+				errs = append(errs, err)
+				continue
+			}
+			if ok {
+				paid++
+			}
+		}
+		if len(rows) < batch {
+			break
+		}
+	}
+
+	logger(ctx).Info().Str("period", period.Format("2006-01")).Int("paid", paid).Int("failed", len(errs)).Msg("Interest paid")
+	return errors.Join(errs...)
+}
+
+// payAccountInterest pays one account's interest for period unless it was already paid. rateBps is
+// the product's base rate; the posting records the effective rate across the account's bands.
+func (s *LedgerService) payAccountInterest(ctx context.Context, accountID uuid.UUID, rateBps int32, period time.Time) (bool, error) {
+	var (
+		evt  events.Event
+		paid bool
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the account so a concurrent run waits, then skip it if already paid.
+		paid = false
+		acc, err := q.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		done, err := q.HasInterestPosting(ctx, sqlc.HasInterestPostingParams{AccountID: accountID, PeriodStart: period})
+		if err != nil || done {
+			return err
+		}
+		balance, err := decimal.NewFromString(acc.Balance)
+		if err != nil {
+			return errors.New("invalid balance")
+		}
+		tiers, err := q.ListInterestTiers(ctx, sqlc.ListInterestTiersParams{Product: acc.Product, Currency: acc.Currency})
+		if err != nil {
+			return err
+		}
+		bands, err := InterestBands(rateBps, tiers)
+		if err != nil {
+			return err
+		}
+		interest, reached := monthlyInterest(balance, bands, acc.Currency)
+		if !interest.IsPositive() {
+			return nil
+		}
+		rate := effectiveRateBps(balance, interest)
+		description := fmt.Sprintf("Interest for %s at %s%% p.a.", period.Format("January 2006"), decimal.New(int64(bands[0].RateBps), -2).String())
+		if reached > 1 {
+			description = fmt.Sprintf("Interest for %s at tiered rates, %s%% p.a. overall", period.Format("January 2006"), decimal.New(int64(rate), -2).String())
+		}
+
+		// Step 2: Post from Interest Expense, withhold taxes on the credit, and record the payment
+		// under the same transaction.
+		expense, err := lockSystemAccount(ctx, q, interestExpenseAccount, acc.Currency)
+		if err != nil {
+			return err
+		}
+		taxes, applied, err := taxLegs(ctx, q, "interest", acc, interest)
+		if err != nil {
+			return err
+		}
+		txID := uuid.New()
+		legs := append([]leg{
+			debitLeg(expense, interest, fmt.Sprintf("Interest paid to %s", acc.ID)),
+			creditLeg(acc, interest, description),
+		}, taxes...)
+		postings, balances, err := postLegs(ctx, q, txID, "interest", legs...)
+		if err != nil {
+			return err
+		}
+		if err := recordTaxes(ctx, q, txID, acc, interest, applied); err != nil {
+			return err
+		}
+		if err := q.CreateInterestPosting(ctx, sqlc.CreateInterestPostingParams{
+			AccountID:     accountID,
+			PeriodStart:   period,
+			TransactionID: txID,
+			Balance:       balance.StringFixed(4),
+			RateBps:       rate,
+			Amount:        interest.StringFixed(4),
+		}); err != nil {
+			return err
+		}
+		paid = true
+		evt = events.Event{
+			Type:          events.TypeInterest,
+			TransactionID: txID,
+			Amount:        interest.StringFixed(4),
+			Currency:      acc.Currency,
+			Entries:       postings,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil || !paid {
+		return false, err
+	}
+	s.publish(ctx, evt)
+	return true, nil
+}
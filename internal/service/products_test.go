@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// productCatalog serves products and their per-currency rules from memory.
+type productCatalog struct {
+	products map[string]sqlc.Product
+	rules    map[string]sqlc.AccountProduct
+}
+
+func (c productCatalog) GetProduct(_ context.Context, code string) (sqlc.Product, error) {
+	p, ok := c.products[code]
+	if !ok {
+		return sqlc.Product{}, sql.ErrNoRows
+	}
+	return p, nil
+}
+
+func (c productCatalog) GetAccountProduct(_ context.Context, arg sqlc.GetAccountProductParams) (sqlc.AccountProduct, error) {
+	p, ok := c.rules[arg.Product+"/"+arg.Currency]
+	if !ok {
+		return sqlc.AccountProduct{}, sql.ErrNoRows
+	}
+	return p, nil
+}
+
+func testCatalog() productCatalog {
+	return productCatalog{
+		products: map[string]sqlc.Product{
+			"current": {Code: "current", WithdrawalsEnabled: true, TransfersEnabled: true},
+			"savings": {Code: "savings", InterestRateBps: 400, WithdrawalsEnabled: true, TransfersEnabled: true},
+			"escrow":  {Code: "escrow"},
+		},
+		rules: map[string]sqlc.AccountProduct{
+			"savings/NGN": {MinBalance: "500.0000", OverdraftLimit: "0.0000", TransferFee: "0.0000", WithdrawalFee: "0.0000"},
+			"current/NGN": {
+				MinBalance: "0.0000", OverdraftLimit: "200.0000", TransferFee: "10.0000", WithdrawalFee: "25.0000",
+				MaxDebit: sql.NullString{String: "5000.0000", Valid: true},
+			},
+		},
+	}
+}
+
+func TestCheckSpendable_EnforcesProductMinimum(t *testing.T) {
+	// NGN 500 must remain on a savings account; overdrawing is still reported as insufficient funds.
+	acc := sqlc.Account{ID: uuid.New(), Balance: "1000.0000", Currency: "NGN", Product: "savings"}
+	ctx := context.Background()
+
+	_, err := checkSpendable(ctx, testCatalog(), acc, decimal.RequireFromString("500"), debitTransfer)
+	assert.NoError(t, err)
+	_, err = checkSpendable(ctx, testCatalog(), acc, decimal.RequireFromString("500.0001"), debitTransfer)
+	assert.ErrorIs(t, err, ErrMinimumBalance)
+	_, err = checkSpendable(ctx, testCatalog(), acc, decimal.RequireFromString("1000.01"), debitTransfer)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCheckSpendable_OverdraftFeesAndCap(t *testing.T) {
+	// A current account may go 200 below zero including the fee, and no single debit may exceed 5000.
+	acc := sqlc.Account{ID: uuid.New(), Balance: "100.0000", Currency: "NGN", Product: "current"}
+	ctx := context.Background()
+
+	fee, err := checkSpendable(ctx, testCatalog(), acc, decimal.RequireFromString("275"), debitWithdrawal)
+	require.NoError(t, err)
+	assert.Equal(t, "25", fee.String())
+	_, err = checkSpendable(ctx, testCatalog(), acc, decimal.RequireFromString("275.0001"), debitWithdrawal)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	fee, err = checkSpendable(ctx, testCatalog(), acc, decimal.RequireFromString("290"), debitTransfer)
+	require.NoError(t, err)
+	assert.Equal(t, "10", fee.String())
+
+	acc.Balance = "10000.0000"
+	_, err = checkSpendable(ctx, testCatalog(), acc, decimal.RequireFromString("5000.0001"), debitTransfer)
+	assert.ErrorIs(t, err, ErrDebitLimitExceeded)
+}
+
+func TestCheckSpendable_OperationNotAllowed(t *testing.T) {
+	// Escrow accounts cannot be withdrawn from or transferred out of directly.
+	acc := sqlc.Account{ID: uuid.New(), Balance: "1000.0000", Currency: "NGN", Product: "escrow"}
+	ctx := context.Background()
+
+	_, err := checkSpendable(ctx, testCatalog(), acc, decimal.RequireFromString("1"), debitWithdrawal)
+	assert.ErrorIs(t, err, ErrOperationNotAllowed)
+	_, err = checkSpendable(ctx, testCatalog(), acc, decimal.RequireFromString("1"), debitTransfer)
+	assert.ErrorIs(t, err, ErrOperationNotAllowed)
+}
+
+func TestRulesFor_Defaults(t *testing.T) {
+	// Unconfigured currencies, system accounts and sub-wallets have no minimum, overdraft or fees.
+	ctx := context.Background()
+
+	for _, acc := range []sqlc.Account{
+		{Product: "savings", Currency: "USD"},
+		{Product: "current", Currency: "NGN", IsSystem: true},
+		{Product: "current", Currency: "NGN", ParentAccountID: uuid.NullUUID{UUID: uuid.New(), Valid: true}},
+	} {
+		rules, err := RulesFor(ctx, testCatalog(), acc)
+		require.NoError(t, err)
+		assert.True(t, rules.MinBalance.IsZero())
+		assert.True(t, rules.OverdraftLimit.IsZero())
+		assert.True(t, rules.TransferFee.IsZero())
+		assert.False(t, rules.MaxDebit.Valid)
+		assert.True(t, rules.TransfersEnabled)
+	}
+
+	_, err := RulesFor(ctx, testCatalog(), sqlc.Account{Product: "missing", Currency: "NGN"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestAvailableBalance(t *testing.T) {
+	// Available balance counts the overdraft, keeps the minimum back, and is never negative.
+	rules := ProductRules{OverdraftLimit: decimal.RequireFromString("200")}
+	assert.Equal(t, "150", rules.AvailableBalance(decimal.RequireFromString("-50")).String())
+
+	rules = ProductRules{MinBalance: decimal.RequireFromString("500")}
+	assert.Equal(t, "700", rules.AvailableBalance(decimal.RequireFromString("1200")).String())
+	assert.True(t, rules.AvailableBalance(decimal.RequireFromString("300")).IsZero())
+}
+
+func TestMonthlyInterest(t *testing.T) {
+	// 4% a year on 10,000 is 33.33 a month; the bank keeps the rounding.
+	flat := []InterestBand{{RateBps: 400}}
+	interest, reached := monthlyInterest(decimal.RequireFromString("10000"), flat, "NGN")
+	assert.Equal(t, "33.33", interest.StringFixed(2))
+	assert.Equal(t, 1, reached)
+	interest, _ = monthlyInterest(decimal.RequireFromString("0.01"), flat, "NGN")
+	assert.True(t, interest.IsZero())
+	interest, _ = monthlyInterest(decimal.RequireFromString("10000"), flat, "JPY")
+	assert.Equal(t, "33", interest.String())
+}
+
+func TestMonthlyInterest_Tiered(t *testing.T) {
+	// 2% up to 100,000 and 4% above: only the part above the band earns the higher rate.
+	bands, err := InterestBands(200, []sqlc.InterestTier{{Product: "savings", MinBalance: "100000.0000", RateBps: 400}})
+	require.NoError(t, err)
+
+	interest, reached := monthlyInterest(decimal.RequireFromString("50000"), bands, "NGN")
+	assert.Equal(t, "83.33", interest.StringFixed(2))
+	assert.Equal(t, 1, reached)
+
+	interest, reached = monthlyInterest(decimal.RequireFromString("150000"), bands, "NGN")
+	assert.Equal(t, "333.33", interest.StringFixed(2))
+	assert.Equal(t, 2, reached)
+	assert.Equal(t, int32(267), effectiveRateBps(decimal.RequireFromString("150000"), interest))
+
+	// A product without a base rate only pays from the first band up.
+	bands, err = InterestBands(0, []sqlc.InterestTier{{MinBalance: "1000", RateBps: 1200}})
+	require.NoError(t, err)
+	interest, _ = monthlyInterest(decimal.RequireFromString("900"), bands, "NGN")
+	assert.True(t, interest.IsZero())
+	interest, _ = monthlyInterest(decimal.RequireFromString("2000"), bands, "NGN")
+	assert.Equal(t, "10.00", interest.StringFixed(2))
+
+	_, err = InterestBands(0, []sqlc.InterestTier{{MinBalance: "lots"}})
+	assert.Error(t, err)
+}
+
+func TestValidateProduct(t *testing.T) {
+	// Product codes are short lowercase identifiers.
+	assert.NoError(t, ValidateProduct("current"))
+	assert.NoError(t, ValidateProduct("savings_ngn"))
+	assert.ErrorIs(t, ValidateProduct("Savings"), ErrInvalidProduct)
+	assert.ErrorIs(t, ValidateProduct("1savings"), ErrInvalidProduct)
+	assert.ErrorIs(t, ValidateProduct(""), ErrInvalidProduct)
+}
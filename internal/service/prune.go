@@ -0,0 +1,177 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/archive"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// KindPruneEntries is the background job kind that removes archived months of entries from
+// Postgres.
+const KindPruneEntries = "ledger.prune_entries"
+
+// errPruneBlocked is returned by pruneMonth when the month is not ready to prune yet. PruneEntries
+// stops there and tries again on its next run.
+var errPruneBlocked = errors.New("month cannot be pruned yet")
+
+// PruneEntries is a jobs.HandlerFunc that deletes entries older than the policy's
+// PruneAfterMonths from Postgres, a whole month of every account at a time and oldest first, once
+// each of the month's segments reads back as the entries it replaces. Balance snapshots are kept,
+// and a month is only pruned if every archived account still reconciles afterwards.
+func (s *LedgerService) PruneEntries(ctx context.Context, _ json.RawMessage) error {
+	if s.archive == nil || s.archivePolicy.PruneAfterMonths <= 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	before := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -s.archivePolicy.PruneAfterMonths, 0)
+
+	var pruned int64
+	for {
+		oldest, err := s.store.GetOldestEntryPeriod(ctx)
+		if err != nil {
+			return fmt.Errorf("find oldest entries: %w", err)
+		}
+		if !oldest.Valid {
+			break
+		}
+		period := time.Date(oldest.Time.Year(), oldest.Time.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if !period.Before(before) {
+			break
+		}
+		n, err := s.pruneMonth(ctx, period)
+		if errors.Is(err, errPruneBlocked) {
+			logger(ctx).Warn().Err(err).Str("period", period.Format("2006-01")).Msg("Entry pruning postponed")
+			break
+		}
+		if err != nil {
+			logger(ctx).Error().Err(err).Str("period", period.Format("2006-01")).Msg("Failed to prune entries")
+			return err
+		}
+		logger(ctx).Info().Str("period", period.Format("2006-01")).Int64("entries", n).Msg("Archived entries pruned")
+		pruned += n
+	}
+	logger(ctx).Info().Int64("entries", pruned).Msg("Entry pruning finished")
+	return nil
+}
+
+// pruneMonth deletes every entry posted in the month starting at period and returns how many it
+// deleted.
+func (s *LedgerService) pruneMonth(ctx context.Context, period time.Time) (int64, error) {
+	end := period.AddDate(0, 1, 0)
+	bounds := sqlc.CountUnarchivedAccountsInPeriodParams{PeriodStart: period, PeriodEnd: end}
+
+	// Step 1: Every account's entries for the month must be archived, and none of its chain may
+	// carry on past them from an entry left behind.
+	unarchived, err := s.store.CountUnarchivedAccountsInPeriod(ctx, bounds)
+	if err != nil {
+		return 0, err
+	}
+	if unarchived > 0 {
+		return 0, fmt.Errorf("%w: %d accounts have entries not archived", errPruneBlocked, unarchived)
+	}
+	overlaps, err := s.store.CountChainOverlapsAfterPeriod(ctx, sqlc.CountChainOverlapsAfterPeriodParams(bounds))
+	if err != nil {
+		return 0, err
+	}
+	if overlaps > 0 {
+		return 0, fmt.Errorf("%w: %d accounts chain entries of the next month before this month's", errPruneBlocked, overlaps)
+	}
+
+	// Step 2: Make sure a snapshot postdates the month, so balances replay without its entries.
+	snapshot, err := s.store.GetLatestBalanceSnapshot(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+	if err != nil || snapshot.CreatedAt.Before(end) {
+		if _, err := s.TakeBalanceSnapshot(ctx); err != nil {
+			return 0, fmt.Errorf("take balance snapshot: %w", err)
+		}
+	}
+
+	// Step 3: Each segment must read back as the entries it is about to replace.
+	segments, err := s.store.ListUnprunedEntryArchivesByPeriod(ctx, period)
+	if err != nil {
+		return 0, err
+	}
+	var archived int64
+	for _, seg := range segments {
+		records, err := s.readSegment(ctx, seg)
+		if err != nil {
+			return 0, err
+		}
+		rows, err := s.store.ListArchivableEntries(ctx, sqlc.ListArchivableEntriesParams{AccountID: seg.AccountID, PeriodStart: period, PeriodEnd: end})
+		if err != nil {
+			return 0, err
+		}
+		if err := matchSegment(seg, records, rows); err != nil {
+			return 0, err
+		}
+		archived += int64(seg.EntryCount)
+	}
+
+	// Step 4: Delete the month and check that every archived account still reconciles, carrying
+	// its pruned entries as their segments' net amounts.
+	var deleted int64
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		drift, err := q.ListArchivedAccountDrift(ctx, period)
+		if err != nil {
+			return err
+		}
+		if len(drift) > 0 {
+			return fmt.Errorf("%d archived accounts fail reconciliation before pruning, first %s", len(drift), drift[0].AccountID)
+		}
+		if _, err := q.KeepPrunedMerkleLeaves(ctx, sqlc.KeepPrunedMerkleLeavesParams(bounds)); err != nil {
+			return err
+		}
+		if deleted, err = q.DeleteEntriesInPeriod(ctx, sqlc.DeleteEntriesInPeriodParams(bounds)); err != nil {
+			return err
+		}
+		if deleted != archived {
+			return fmt.Errorf("month holds %d entries, %d archived", deleted, archived)
+		}
+		if _, err := q.MarkEntryArchivesPruned(ctx, period); err != nil {
+			return err
+		}
+		if drift, err = q.ListArchivedAccountDrift(ctx, period); err != nil {
+			return err
+		}
+		if len(drift) > 0 {
+			return fmt.Errorf("%d archived accounts fail reconciliation after pruning, first %s", len(drift), drift[0].AccountID)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// matchSegment checks that records, a segment's contents, are the account's entries for the
+// month in rows and that the segment records the chain link pruning leaves behind.
+func matchSegment(seg sqlc.EntryArchive, records []archive.Record, rows []sqlc.ListArchivableEntriesRow) error {
+	if len(records) != len(rows) {
+		return fmt.Errorf("archive segment %s holds %d entries, %d in Postgres", seg.ObjectKey, len(records), len(rows))
+	}
+	var link archive.Record
+	for i, rec := range records {
+		row := rows[i]
+		if rec.ID != row.ID || rec.Debit != row.Debit || rec.Credit != row.Credit ||
+			rec.ChainSeq != row.ChainSeq || !bytes.Equal(rec.Hash, row.Hash) {
+			return fmt.Errorf("archive segment %s does not match entry %s", seg.ObjectKey, row.ID)
+		}
+		if rec.ChainSeq > link.ChainSeq {
+			link = rec
+		}
+	}
+	if link.ChainSeq != seg.LastChainSeq || !bytes.Equal(link.Hash, seg.LastHash) {
+		return fmt.Errorf("archive segment %s records chain link %d, its entries end at %d", seg.ObjectKey, seg.LastChainSeq, link.ChainSeq)
+	}
+	return nil
+}
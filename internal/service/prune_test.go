@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/archive"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestMatchSegment(t *testing.T) {
+	// A segment matches the live rows entry for entry and records the highest chain link, which
+	// need not be the last entry posted.
+	records := []archive.Record{
+		{ID: uuid.New(), Debit: "0.0000", Credit: "10.0000", ChainSeq: 4, Hash: []byte{4}},
+		{ID: uuid.New(), Debit: "2.0000", Credit: "0.0000", ChainSeq: 3, Hash: []byte{3}},
+	}
+	rows := make([]sqlc.ListArchivableEntriesRow, 0, len(records))
+	for _, rec := range records {
+		rows = append(rows, sqlc.ListArchivableEntriesRow{ID: rec.ID, Debit: rec.Debit, Credit: rec.Credit, ChainSeq: rec.ChainSeq, Hash: rec.Hash})
+	}
+	seg := sqlc.EntryArchive{ObjectKey: "segment", LastChainSeq: 4, LastHash: []byte{4}}
+	assert.NoError(t, matchSegment(seg, records, rows))
+
+	assert.Error(t, matchSegment(sqlc.EntryArchive{ObjectKey: "segment", LastChainSeq: 3, LastHash: []byte{3}}, records, rows))
+	assert.Error(t, matchSegment(seg, records[:1], rows))
+
+	edited := append([]sqlc.ListArchivableEntriesRow(nil), rows...)
+	edited[1].Debit = "20.0000"
+	assert.Error(t, matchSegment(seg, records, edited))
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/qr"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// ErrQRAmountMismatch is returned when the payer's amount differs from the amount fixed in a QR code,
+// or a static code is paid without an amount.
+var ErrQRAmountMismatch = errors.New("amount must be given for a static QR code and match a dynamic one")
+
+// QRPayment is the committed result of PayQR.
+type QRPayment struct {
+	Amount        decimal.Decimal
+	Currency      string
+	TransactionID uuid.UUID
+	ToAccountID   uuid.UUID
+}
+
+// ReceivingQR builds the payload a customer account presents to receive money, returning its
+// fields and encoded form. amount may be empty for a reusable code where the payer chooses the amount.
+func ReceivingQR(acc sqlc.Account, amountStr, reference string) (qr.Payload, string, error) {
+	if acc.IsSystem {
+		return qr.Payload{}, "", ErrAccountNotFound
+	}
+	p := qr.Payload{AccountNumber: acc.VirtualAccountNumber, Currency: acc.Currency, MerchantName: acc.Name, Reference: reference}
+	if amountStr != "" {
+		amount, err := validatePositiveAmount(amountStr)
+		if err != nil {
+			return qr.Payload{}, "", err
+		}
+		// QR amounts carry the currency's decimals; refuse what would be rounded.
+		if !currencies.Fits(acc.Currency, amount) {
+			return qr.Payload{}, "", ErrAmountPrecision
+		}
+		p.Amount = currencies.Format(acc.Currency, amount)
+	}
+	encoded, err := qr.Encode(p)
+	return p, encoded, err
+}
+
+// ResolveQR returns the customer account a scanned payload pays into.
+func (s *LedgerService) ResolveQR(ctx context.Context, p qr.Payload) (sqlc.Account, error) {
+	acc, err := s.store.GetAccountByVirtualNumber(ctx, p.AccountNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.Account{}, ErrAccountNotFound
+		}
+		return sqlc.Account{}, err
+	}
+	// A code printed before the account changed currency must not be paid in the wrong one.
+	if acc.Currency != p.Currency {
+		return sqlc.Account{}, ErrCurrencyMismatch
+	}
+	return acc, nil
+}
+
+// PayQR transfers from fromID to the account in a scanned payload. A dynamic code fixes the
+// amount (amountStr may be empty or must match); a static code needs amountStr. The reference
+// is carried into the entry descriptions.
+func (s *LedgerService) PayQR(ctx context.Context, fromID uuid.UUID, p qr.Payload, amountStr string) (QRPayment, error) {
+	// Step 1: Settle the amount between the code and the payer.
+	amount, err := qrAmount(p, amountStr)
+	if err != nil {
+		return QRPayment{}, err
+	}
+	to, err := s.ResolveQR(ctx, p)
+	if err != nil {
+		return QRPayment{}, err
+	}
+	if to.ID == fromID {
+		return QRPayment{}, ErrSameAccountTransfer
+	}
+	// The payer is at the till, so a payment screened for review cannot wait for it.
+	sc, err := s.screen(ctx, "qr_payment", fromID, uuid.NullUUID{UUID: to.ID, Valid: true}, aml.Outbound, amount, false)
+	if err != nil {
+		return QRPayment{}, err
+	}
+
+	// Step 2: Post as an ordinary transfer under the payer's rules and fees.
+	narration := "QR payment"
+	if p.Reference != "" {
+		narration = fmt.Sprintf("QR payment %s", p.Reference)
+	}
+	txID := uuid.New()
+	var evt events.Event
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		evt, err = postTransfer(ctx, q, txID, fromID, to.ID, amount, narration)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return QRPayment{}, ErrAccountNotFound
+		}
+		return QRPayment{}, err
+	}
+
+	logger(ctx).Info().Str("tx_id", txID.String()).Str("from_id", fromID.String()).Str("to_id", to.ID.String()).Msg("QR payment completed")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, txID)
+	return QRPayment{TransactionID: txID, ToAccountID: to.ID, Amount: amount, Currency: to.Currency}, nil
+}
+
+// qrAmount returns the amount to pay for p given what the payer entered.
+func qrAmount(p qr.Payload, amountStr string) (decimal.Decimal, error) {
+	if p.Amount == "" {
+		if amountStr == "" {
+			return decimal.Zero, ErrQRAmountMismatch
+		}
+		return validatePositiveAmount(amountStr)
+	}
+	fixed, err := validatePositiveAmount(p.Amount)
+	if err != nil {
+		return decimal.Zero, qr.ErrInvalidPayload
+	}
+	if amountStr != "" {
+		entered, err := validatePositiveAmount(amountStr)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if !entered.Equal(fixed) {
+			return decimal.Zero, ErrQRAmountMismatch
+		}
+	}
+	return fixed, nil
+}
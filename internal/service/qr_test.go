@@ -0,0 +1,49 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/qr"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestReceivingQR(t *testing.T) {
+	// The code carries the account number and currency; amounts are fixed to the currency's decimals.
+	acc := sqlc.Account{Name: "Corner Shop", Currency: "USD", VirtualAccountNumber: "9000000042"}
+
+	fields, payload, err := ReceivingQR(acc, "12.5", "INV-1")
+	require.NoError(t, err)
+	assert.Equal(t, "12.50", fields.Amount)
+	decoded, err := qr.Decode(payload)
+	require.NoError(t, err)
+	assert.Equal(t, fields, decoded)
+
+	_, _, err = ReceivingQR(acc, "1.005", "")
+	assert.ErrorIs(t, err, ErrAmountPrecision)
+	_, _, err = ReceivingQR(sqlc.Account{Currency: "JPY", VirtualAccountNumber: "9000000043"}, "1500.5", "")
+	assert.ErrorIs(t, err, ErrAmountPrecision)
+	_, _, err = ReceivingQR(sqlc.Account{IsSystem: true, Currency: "USD"}, "", "")
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+}
+
+func TestQRAmount(t *testing.T) {
+	// Static codes need the payer's amount; dynamic codes fix it.
+	static := qr.Payload{}
+	_, err := qrAmount(static, "")
+	assert.ErrorIs(t, err, ErrQRAmountMismatch)
+	amount, err := qrAmount(static, "7")
+	require.NoError(t, err)
+	assert.Equal(t, "7", amount.String())
+
+	dynamic := qr.Payload{Amount: "12.50"}
+	amount, err = qrAmount(dynamic, "")
+	require.NoError(t, err)
+	assert.Equal(t, "12.5", amount.String())
+	_, err = qrAmount(dynamic, "12.5")
+	assert.NoError(t, err)
+	_, err = qrAmount(dynamic, "13")
+	assert.ErrorIs(t, err, ErrQRAmountMismatch)
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// ErrRecipientNotFound is returned when no single user in the organization has the given
+// email or phone, or that user has no account to receive into.
+var ErrRecipientNotFound = errors.New("recipient not found")
+
+// Recipient is a user addressed by email or phone and the account that receives for them.
+type Recipient struct {
+	User    sqlc.User
+	Account sqlc.Account
+}
+
+// FindRecipient resolves an email or phone (exactly one) to a user of orgID and their default
+// account. A phone shared by several users matches none of them.
+func (s *LedgerService) FindRecipient(ctx context.Context, orgID uuid.UUID, email, phone string) (Recipient, error) {
+	var (
+		user sqlc.User
+		err  error
+	)
+	switch {
+	case email != "" && phone == "":
+		user, err = s.store.FindUserByEmail(ctx, sqlc.FindUserByEmailParams{OrgID: orgID, Email: email})
+	case phone != "" && email == "":
+		var users []sqlc.User
+		index := s.pii.Index(phone)
+		users, err = s.store.ListUsersByPhone(ctx, sqlc.ListUsersByPhoneParams{
+			OrgID:      orgID,
+			PhoneIndex: sql.NullString{String: index, Valid: index != ""},
+			Phone:      phone,
+		})
+		if err == nil && len(users) != 1 {
+			err = sql.ErrNoRows
+		}
+		if err == nil {
+			user = users[0]
+		}
+	default:
+		return Recipient{}, ErrRecipientNotFound
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Recipient{}, ErrRecipientNotFound
+		}
+		return Recipient{}, err
+	}
+
+	acc, err := s.store.GetDefaultAccount(ctx, uuid.NullUUID{UUID: user.ID, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Recipient{}, ErrRecipientNotFound
+		}
+		return Recipient{}, err
+	}
+	return Recipient{User: user, Account: acc}, nil
+}
+
+// MaskedName lets a sender confirm who they are paying without learning the full name:
+// first name and last initial, or a masked email when the profile has no name.
+func MaskedName(u sqlc.User) string {
+	first, last := strings.TrimSpace(u.FirstName), strings.TrimSpace(u.LastName)
+	switch {
+	case first != "" && last != "":
+		return first + " " + string([]rune(last)[:1]) + "."
+	case first != "":
+		return first
+	}
+	local, domain, ok := strings.Cut(u.Email, "@")
+	if !ok || local == "" {
+		return "***"
+	}
+	return string([]rune(local)[:1]) + "***@" + domain
+}
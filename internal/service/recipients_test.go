@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestMaskedName(t *testing.T) {
+	// Senders see enough to confirm the recipient, never the full name or email.
+	assert.Equal(t, "Ada L.", MaskedName(sqlc.User{FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com"}))
+	assert.Equal(t, "Ada", MaskedName(sqlc.User{FirstName: "Ada", Email: "ada@example.com"}))
+	assert.Equal(t, "a***@example.com", MaskedName(sqlc.User{Email: "ada@example.com"}))
+	assert.Equal(t, "Émile Ž.", MaskedName(sqlc.User{FirstName: "Émile", LastName: "Žák"}))
+}
+
+func TestFindRecipient_NeedsExactlyOneAddress(t *testing.T) {
+	// Neither or both of email and phone match nobody without querying.
+	s := &LedgerService{}
+	_, err := s.FindRecipient(context.Background(), uuid.New(), "", "")
+	assert.ErrorIs(t, err, ErrRecipientNotFound)
+	_, err = s.FindRecipient(context.Background(), uuid.New(), "a@example.com", "+2348012345678")
+	assert.ErrorIs(t, err, ErrRecipientNotFound)
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/alert"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/jobs"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// KindReconcileAll is the background job kind that works through a reconciliation run.
+const KindReconcileAll = "ledger.reconcile_all"
+
+// reconcileBatchSize is how many accounts one transaction of a run checks.
+const reconcileBatchSize = 500
+
+// Reconciliation run statuses.
+const (
+	ReconciliationRunning   = "running"
+	ReconciliationCompleted = "completed"
+)
+
+var ErrReconciliationRunNotFound = errors.New("reconciliation run not found")
+
+// reconcileRunPayload is the payload of a KindReconcileAll job.
+type reconcileRunPayload struct {
+	RunID uuid.UUID `json:"run_id"`
+}
+
+// StartReconciliationRun queues a job that checks every account's stored balance against its
+// entries. Only one run goes at a time: while one is running it is returned, with resumed set,
+// and its job queued again so a run whose job gave up carries on from where it stopped.
+func (s *LedgerService) StartReconciliationRun(ctx context.Context, requestedBy uuid.UUID) (sqlc.BalanceReconciliationRun, bool, error) {
+	var (
+		run     sqlc.BalanceReconciliationRun
+		resumed bool
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Pick up the running run, or start one sized to the accounts there are now.
+		var err error
+		run, err = q.GetRunningReconciliationRun(ctx)
+		resumed = err == nil
+		if errors.Is(err, sql.ErrNoRows) {
+			run, err = q.CreateReconciliationRun(ctx, requestedBy)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Queue its job. A second job for the same run is harmless: batches take the
+		// run's row lock and carry on from its cursor.
+		payload, err := json.Marshal(reconcileRunPayload{RunID: run.ID})
+		if err != nil {
+			return err
+		}
+		_, err = q.EnqueueJob(ctx, sqlc.EnqueueJobParams{
+			Kind:        KindReconcileAll,
+			Payload:     payload,
+			RunAt:       time.Now(),
+			MaxAttempts: jobs.DefaultMaxAttempts,
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.BalanceReconciliationRun{}, false, fmt.Errorf("start reconciliation run: %w", err)
+	}
+	logger(ctx).Info().
+		Str("run_id", run.ID.String()).
+		Bool("resumed", resumed).
+		Int32("total_accounts", run.TotalAccounts).
+		Msg("Reconciliation run queued")
+	return run, resumed, nil
+}
+
+// GetReconciliationRun returns a run and its progress.
+func (s *LedgerService) GetReconciliationRun(ctx context.Context, id uuid.UUID) (sqlc.BalanceReconciliationRun, error) {
+	run, err := s.store.GetReconciliationRun(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.BalanceReconciliationRun{}, ErrReconciliationRunNotFound
+	}
+	return run, err
+}
+
+// ReconcileAll is a jobs.HandlerFunc that checks a run's accounts a batch at a time, in ID order,
+// until every account has a result. Each batch commits its results with the run's cursor, so a
+// job that fails or is interrupted resumes after the last batch it finished.
+func (s *LedgerService) ReconcileAll(ctx context.Context, payload json.RawMessage) error {
+	var p reconcileRunPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("decode reconciliation payload: %w", err)
+	}
+	for {
+		run, err := s.reconcileBatch(ctx, p.RunID)
+		if err != nil {
+			return err
+		}
+		if run.Status != ReconciliationRunning {
+			logger(ctx).Info().
+				Str("run_id", run.ID.String()).
+				Int32("checked_accounts", run.CheckedAccounts).
+				Int32("mismatched_accounts", run.MismatchedAccounts).
+				Msg("Reconciliation run completed")
+			return nil
+		}
+	}
+}
+
+// reconcileBatch checks the next batch of a run's accounts and advances the run past them,
+// completing it when the batch comes up short. A run that is no longer running is returned as
+// it is.
+func (s *LedgerService) reconcileBatch(ctx context.Context, runID uuid.UUID) (sqlc.BalanceReconciliationRun, error) {
+	var (
+		run        sqlc.BalanceReconciliationRun
+		mismatches []sqlc.BalanceReconciliationResult
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		mismatches = nil
+
+		// Step 1: Lock the run so no other job checks the same batch.
+		var err error
+		run, err = q.GetReconciliationRunForUpdate(ctx, runID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrReconciliationRunNotFound
+		}
+		if err != nil || run.Status != ReconciliationRunning {
+			return err
+		}
+
+		// Step 2: Check the accounts after the cursor and record each result.
+		results, err := q.ReconcileAccountBatch(ctx, sqlc.ReconcileAccountBatchParams{
+			AfterID:  run.LastAccountID.UUID,
+			RowLimit: reconcileBatchSize,
+			RunID:    run.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Move the cursor past the batch.
+		var last uuid.NullUUID
+		if len(results) > 0 {
+			last = uuid.NullUUID{UUID: results[len(results)-1].AccountID, Valid: true}
+		}
+		for _, r := range results {
+			if !r.Matched {
+				mismatches = append(mismatches, r)
+			}
+		}
+		run, err = q.AdvanceReconciliationRun(ctx, sqlc.AdvanceReconciliationRunParams{
+			LastAccountID: last,
+			Checked:       int32(len(results)),    // #nosec G115 -- at most reconcileBatchSize
+			Mismatched:    int32(len(mismatches)), // #nosec G115 -- at most reconcileBatchSize
+			Done:          len(results) < reconcileBatchSize,
+			ID:            run.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.BalanceReconciliationRun{}, fmt.Errorf("reconcile batch of run %s: %w", runID, err)
+	}
+
+	for _, m := range mismatches {
+		logger(ctx).Error().
+			Str("run_id", runID.String()).
+			Str("account_id", m.AccountID.String()).
+			Str("stored_balance", m.StoredBalance).
+			Str("calculated", m.CalculatedBalance).
+			Msg("Balance mismatch detected")
+	}
+	if len(mismatches) > 0 {
+		ReconciliationMismatchesTotal.Add(float64(len(mismatches)), "balance")
+		s.raiseAlerts(ctx, mismatchAlerts(mismatches)...)
+	}
+	return run, nil
+}
+
+// mismatchAlerts describes each mismatched account in a batch, up to maxDriftAlerts, against the
+// balance summed from its entries.
+func mismatchAlerts(results []sqlc.BalanceReconciliationResult) []alert.Alert {
+	alerts := make([]alert.Alert, 0, min(len(results), maxDriftAlerts))
+	for _, r := range results[:min(len(results), maxDriftAlerts)] {
+		stored, _ := decimal.NewFromString(r.StoredBalance)
+		calculated, _ := decimal.NewFromString(r.CalculatedBalance)
+		alerts = append(alerts, balanceAlert(alert.KindBalanceMismatch, r.AccountID, r.Currency, stored, calculated))
+	}
+	return alerts
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrTransactionNotRefundable is returned for transactions the partial refund path does not undo.
+	ErrTransactionNotRefundable = errors.New("only a posted two-leg transfer, deposit or withdrawal that was not disputed or paid out through a bank rail can be refunded")
+	// ErrRefundExceedsRemaining is returned when a refund would take the refunded total past the original amount.
+	ErrRefundExceedsRemaining = errors.New("refund exceeds the amount left to refund")
+	// ErrInvalidRefundAmount is returned for a refund amount that is not a positive ledger amount.
+	ErrInvalidRefundAmount = errors.New("refund amount must be a positive number with at most 4 decimal places")
+)
+
+// RefundRequest refunds Amount of TransactionID.
+type RefundRequest struct {
+	TransactionID uuid.UUID
+	Amount        string
+	RefundedBy    uuid.UUID
+	Reason        string
+}
+
+// RefundSummary is a transaction's amount, fees excluded, and the refunds made against it.
+type RefundSummary struct {
+	TransactionID uuid.UUID
+	Currency      string
+	Amount        decimal.Decimal
+	Refunded      decimal.Decimal
+	Refunds       []sqlc.TransactionRefund
+}
+
+// Remaining is what may still be refunded.
+func (s RefundSummary) Remaining() decimal.Decimal {
+	return s.Amount.Sub(s.Refunded)
+}
+
+// refundableEntries returns the debit and credit side of a refundable transaction and the amount
+// moved between them. Fee entries are left out: partial refunds never return fees.
+func refundableEntries(entries []sqlc.Entry, fees map[uuid.UUID]bool) (debit, credit sqlc.Entry, amount decimal.Decimal, err error) {
+	var n int
+	for _, e := range entries {
+		if fees[e.ID] {
+			continue
+		}
+		n++
+		if isPositive(e.Debit) {
+			debit = e
+		} else {
+			credit = e
+		}
+	}
+	if n != 2 || debit.ID == uuid.Nil || credit.ID == uuid.Nil {
+		return sqlc.Entry{}, sqlc.Entry{}, decimal.Zero, ErrTransactionNotRefundable
+	}
+	amount, err = decimal.NewFromString(debit.Debit)
+	if err != nil {
+		return sqlc.Entry{}, sqlc.Entry{}, decimal.Zero, fmt.Errorf("invalid debit on entry %s: %w", debit.ID, err)
+	}
+	credited, err := decimal.NewFromString(credit.Credit)
+	if err != nil {
+		return sqlc.Entry{}, sqlc.Entry{}, decimal.Zero, fmt.Errorf("invalid credit on entry %s: %w", credit.ID, err)
+	}
+	if !amount.Equal(credited) {
+		return sqlc.Entry{}, sqlc.Entry{}, decimal.Zero, ErrTransactionNotRefundable
+	}
+	return debit, credit, amount, nil
+}
+
+// RefundTransaction returns part of a posted transfer, deposit or withdrawal with a reversal
+// posting from the account it credited to the account it debited. Refunds of a transaction may
+// add up to its amount, fees excluded, and the one that reaches it marks the original reversed.
+func (s *LedgerService) RefundTransaction(ctx context.Context, req RefundRequest) (RefundSummary, error) {
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !amount.IsPositive() || amount.Exponent() < -4 {
+		return RefundSummary{}, ErrInvalidRefundAmount
+	}
+
+	var (
+		summary RefundSummary
+		refund  sqlc.TransactionRefund
+		evt     events.Event
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the original so refunds and reversals of it take turns.
+		tx, err := q.GetTransactionForUpdate(ctx, req.TransactionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrTransactionNotFound
+			}
+			return err
+		}
+		if tx.Status != TransactionPosted || !reversibleOperations[tx.OperationType] {
+			return ErrTransactionNotRefundable
+		}
+		if err := checkRefundable(ctx, q, tx.ID); err != nil {
+			return err
+		}
+
+		// Step 2: Work out what is left to refund.
+		var debit, credit sqlc.Entry
+		summary, debit, credit, err = refundSummary(ctx, q, tx.ID)
+		if err != nil {
+			return err
+		}
+		remaining := summary.Remaining()
+		if amount.GreaterThan(remaining) {
+			return ErrRefundExceedsRemaining
+		}
+
+		// Step 3: Move the amount back from the credited account to the debited one.
+		accounts, err := lockAccounts(ctx, q, debit.AccountID, credit.AccountID)
+		if err != nil {
+			return err
+		}
+		txID := uuid.New()
+		description := "Partial refund of " + tx.ID.String()
+		postings, balances, err := postLegs(ctx, q, txID, "reversal",
+			debitLeg(accounts[credit.AccountID], amount, description),
+			creditLeg(accounts[debit.AccountID], amount, description),
+		)
+		if err != nil {
+			return err
+		}
+
+		// Step 4: Record the refund against the original, and mark it reversed once fully refunded.
+		refund, err = q.CreateTransactionRefund(ctx, sqlc.CreateTransactionRefundParams{
+			TransactionID:       tx.ID,
+			RefundTransactionID: txID,
+			Amount:              amount.StringFixed(4),
+			Reason:              req.Reason,
+			RefundedBy:          req.RefundedBy,
+		})
+		if err != nil {
+			return err
+		}
+		summary.Refunded = summary.Refunded.Add(amount)
+		summary.Refunds = append(summary.Refunds, refund)
+		if amount.Equal(remaining) {
+			if err := transitionTransaction(ctx, q, tx.ID, TransactionPosted, TransactionReversed); err != nil {
+				return err
+			}
+		}
+
+		evt = events.Event{
+			Type:          events.TypeReversal,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      summary.Currency,
+			Entries:       postings,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return RefundSummary{}, err
+	}
+
+	logger(ctx).Info().
+		Str("transaction_id", refund.TransactionID.String()).
+		Str("refund_transaction_id", refund.RefundTransactionID.String()).
+		Str("amount", refund.Amount).
+		Str("remaining", summary.Remaining().StringFixed(4)).
+		Str("refunded_by", req.RefundedBy.String()).
+		Msg("Transaction partly refunded")
+	s.publish(ctx, evt)
+	return summary, nil
+}
+
+// TransactionRefunds returns a refundable transaction's amount and the refunds made against it.
+func (s *LedgerService) TransactionRefunds(ctx context.Context, transactionID uuid.UUID) (RefundSummary, error) {
+	tx, err := s.store.GetTransaction(ctx, transactionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return RefundSummary{}, ErrTransactionNotFound
+		}
+		return RefundSummary{}, err
+	}
+	if !reversibleOperations[tx.OperationType] {
+		return RefundSummary{}, ErrTransactionNotRefundable
+	}
+	var summary RefundSummary
+	err = s.store.ReadTx(ctx, func(q *sqlc.Queries) error {
+		summary, _, _, err = refundSummary(ctx, q, tx.ID)
+		return err
+	})
+	return summary, err
+}
+
+// refundSummary totals a transaction's amount and its refunds so far, returning the entries a
+// refund mirrors with it.
+func refundSummary(ctx context.Context, q *sqlc.Queries, transactionID uuid.UUID) (RefundSummary, sqlc.Entry, sqlc.Entry, error) {
+	entries, err := q.ListEntriesByTransaction(ctx, transactionID)
+	if err != nil {
+		return RefundSummary{}, sqlc.Entry{}, sqlc.Entry{}, err
+	}
+	fees, err := feeEntrySet(ctx, q, transactionID)
+	if err != nil {
+		return RefundSummary{}, sqlc.Entry{}, sqlc.Entry{}, err
+	}
+	debit, credit, amount, err := refundableEntries(entries, fees)
+	if err != nil {
+		return RefundSummary{}, sqlc.Entry{}, sqlc.Entry{}, err
+	}
+	account, err := q.GetAccount(ctx, debit.AccountID)
+	if err != nil {
+		return RefundSummary{}, sqlc.Entry{}, sqlc.Entry{}, err
+	}
+	refunds, err := q.ListTransactionRefunds(ctx, transactionID)
+	if err != nil {
+		return RefundSummary{}, sqlc.Entry{}, sqlc.Entry{}, err
+	}
+	refunded := decimal.Zero
+	for _, r := range refunds {
+		a, err := decimal.NewFromString(r.Amount)
+		if err != nil {
+			return RefundSummary{}, sqlc.Entry{}, sqlc.Entry{}, fmt.Errorf("invalid amount on refund %s: %w", r.ID, err)
+		}
+		refunded = refunded.Add(a)
+	}
+	summary := RefundSummary{
+		TransactionID: transactionID,
+		Currency:      account.Currency,
+		Amount:        amount,
+		Refunded:      refunded,
+		Refunds:       refunds,
+	}
+	return summary, debit, credit, nil
+}
+
+// checkRefundable refuses disputed transactions and rail payouts, which have their own way back.
+func checkRefundable(ctx context.Context, q *sqlc.Queries, transactionID uuid.UUID) error {
+	if _, err := q.GetDisputeByTransaction(ctx, transactionID); err == nil {
+		return ErrTransactionNotRefundable
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	payout, err := q.IsPayoutTransaction(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+	if payout {
+		return ErrTransactionNotRefundable
+	}
+	return nil
+}
+
+// feeEntrySet returns the IDs of a transaction's fee entries.
+func feeEntrySet(ctx context.Context, q *sqlc.Queries, transactionID uuid.UUID) (map[uuid.UUID]bool, error) {
+	ids, err := q.ListFeeEntryIDs(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	fees := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		fees[id] = true
+	}
+	return fees, nil
+}
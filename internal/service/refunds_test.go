@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestRefundableEntries(t *testing.T) {
+	// The operation's two legs are refundable; its fee legs are left out of the amount.
+	payer, payee, income := uuid.New(), uuid.New(), uuid.New()
+	entries := []sqlc.Entry{
+		{ID: uuid.New(), AccountID: payer, Debit: "10000.0000", Credit: "0.0000"},
+		{ID: uuid.New(), AccountID: payee, Debit: "0.0000", Credit: "10000.0000"},
+		{ID: uuid.New(), AccountID: payer, Debit: "25.0000", Credit: "0.0000"},
+		{ID: uuid.New(), AccountID: income, Debit: "0.0000", Credit: "25.0000"},
+	}
+	fees := map[uuid.UUID]bool{entries[2].ID: true, entries[3].ID: true}
+
+	debit, credit, amount, err := refundableEntries(entries, fees)
+	require.NoError(t, err)
+	assert.Equal(t, payer, debit.AccountID)
+	assert.Equal(t, payee, credit.AccountID)
+	assert.Equal(t, "10000", amount.String())
+
+	// Without the fee marks the posting has four legs and is not refundable.
+	_, _, _, err = refundableEntries(entries, nil)
+	assert.ErrorIs(t, err, ErrTransactionNotRefundable)
+
+	// Legs that do not match, as in a conversion, are not refundable either.
+	entries[1].Credit = "9000.0000"
+	_, _, _, err = refundableEntries(entries, fees)
+	assert.ErrorIs(t, err, ErrTransactionNotRefundable)
+}
+
+func TestRefundSummaryRemaining(t *testing.T) {
+	// Remaining is the original amount less everything refunded so far.
+	s := RefundSummary{Amount: decimal.RequireFromString("10000"), Refunded: decimal.RequireFromString("3000")}
+	assert.Equal(t, "7000", s.Remaining().String())
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrBalanceRepairNotFound is returned when a balance repair does not exist.
+	ErrBalanceRepairNotFound = errors.New("balance repair not found")
+	// ErrBalanceRepairNotPending is returned when deciding a repair that was already posted or rejected.
+	ErrBalanceRepairNotPending = errors.New("balance repair is not pending")
+	// ErrNoBalanceMismatch is returned when proposing a repair for an account whose stored balance
+	// matches its entries.
+	ErrNoBalanceMismatch = errors.New("account balance matches its entries; nothing to repair")
+	// ErrBalanceRepairPending is returned when proposing a repair for an account that already has
+	// one awaiting a decision.
+	ErrBalanceRepairPending = errors.New("account already has a balance repair awaiting a decision")
+	// ErrBalanceRepairStale is returned when approving a repair whose account no longer has the
+	// mismatch it was proposed for.
+	ErrBalanceRepairStale = errors.New("account balance changed since the repair was proposed; reject it and propose a new one")
+	// ErrEntriesDisagreeWithLog is returned when proposing a repair for an account whose entries,
+	// not its stored balance, differ from the ledger event log: an entry was lost or altered, which
+	// no new entry can explain.
+	ErrEntriesDisagreeWithLog = errors.New("account entries disagree with the ledger event log; verify the entry chain instead")
+)
+
+// RepairSuspenseAccount is the per-currency system account that takes the other side of every
+// balance repair, holding the unexplained differences until they are investigated.
+const RepairSuspenseAccount = "Reconciliation Suspense"
+
+// Balance repair statuses and the actions of its audit log.
+const (
+	BalanceRepairPending  = "pending"
+	BalanceRepairPosted   = "posted"
+	BalanceRepairRejected = "rejected"
+
+	repairActionProposed = "proposed"
+	repairActionApproved = "approved"
+	repairActionRejected = "rejected"
+	repairActionVerified = "verified"
+)
+
+// BalanceRepair is a repair with its proposed adjustment and its audit log, oldest first.
+type BalanceRepair struct {
+	sqlc.BalanceRepair
+	Adjustment Adjustment
+	Events     []sqlc.BalanceRepairEvent
+}
+
+// accountMismatch reads an account's stored balance and the balance its entries add up to.
+func accountMismatch(ctx context.Context, q *sqlc.Queries, acc sqlc.Account) (stored, calculated decimal.Decimal, err error) {
+	stored, err = decimal.NewFromString(acc.Balance)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("invalid stored balance: %w", err)
+	}
+	calculatedStr, err := q.GetAccountBalance(ctx, acc.ID)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("failed to calculate balance: %w", err)
+	}
+	calculated, err = decimal.NewFromString(calculatedStr)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("invalid calculated balance: %w", err)
+	}
+	return stored, calculated, nil
+}
+
+// appendRepairEvent adds an action to a repair's audit log.
+func appendRepairEvent(ctx context.Context, q *sqlc.Queries, repairID uuid.UUID, action string, actorID uuid.UUID, detail map[string]string) error {
+	raw, err := json.Marshal(detail)
+	if err != nil {
+		return fmt.Errorf("encode repair event: %w", err)
+	}
+	_, err = q.AppendBalanceRepairEvent(ctx, sqlc.AppendBalanceRepairEventParams{
+		RepairID: repairID,
+		Action:   action,
+		ActorID:  uuid.NullUUID{UUID: actorID, Valid: actorID != uuid.Nil},
+		Detail:   raw,
+	})
+	return err
+}
+
+// ProposeBalanceRepair checks an account's stored balance against its entries and, when they
+// differ, proposes an adjustment that books the delta: an entry on the account with no balance
+// movement, since its stored balance already holds the amount, against the Reconciliation
+// Suspense account in its currency. Once posted the stored balance, the entries and the event log
+// agree again. It keeps the stored balance, the one customers have been shown and spent against;
+// ledgerctl repair resets it to the entries instead. The adjustment stays pending until
+// ApproveBalanceRepair runs for a different admin, whatever the dual control setting.
+func (s *LedgerService) ProposeBalanceRepair(ctx context.Context, accountID, proposedBy uuid.UUID, memo string) (BalanceRepair, error) {
+	var repair BalanceRepair
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the account so no posting moves it while the delta is computed.
+		acc, err := q.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+		if n, err := q.CountPendingBalanceRepairs(ctx, accountID); err != nil {
+			return err
+		} else if n > 0 {
+			return ErrBalanceRepairPending
+		}
+
+		// Step 2: Compute the delta between the stored balance and the entries.
+		stored, calculated, err := accountMismatch(ctx, q, acc)
+		if err != nil {
+			return err
+		}
+		delta := stored.Sub(calculated)
+		if delta.IsZero() {
+			return ErrNoBalanceMismatch
+		}
+		replayedStr, err := q.GetReplayedAccountBalance(ctx, acc.ID)
+		if err != nil {
+			return err
+		}
+		if replayed, err := decimal.NewFromString(replayedStr); err != nil || !replayed.Equal(calculated) {
+			return ErrEntriesDisagreeWithLog
+		}
+
+		// Step 3: Propose the journal: the account's missing entry against suspense.
+		suspense, err := lockSystemAccount(ctx, q, RepairSuspenseAccount, acc.Currency)
+		if err != nil {
+			return err
+		}
+		fix := AdjustmentLine{AccountID: acc.ID, Description: "Balance repair: entries brought to the stored balance"}
+		offset := AdjustmentLine{AccountID: suspense.ID, Description: fmt.Sprintf("Balance repair offset for account %s", acc.ID)}
+		if delta.IsPositive() {
+			fix.Credit, offset.Debit = delta.StringFixed(4), delta.StringFixed(4)
+		} else {
+			fix.Debit, offset.Credit = delta.Neg().StringFixed(4), delta.Neg().StringFixed(4)
+		}
+		in := AdjustmentInput{ReasonCode: "reconciliation", Memo: memo, Lines: []AdjustmentLine{fix, offset}, RequestedBy: proposedBy}
+		if err := validateAdjustment(in); err != nil {
+			return err
+		}
+		if repair.Adjustment, err = recordAdjustment(ctx, q, in); err != nil {
+			return err
+		}
+		if _, err := adjustmentLegs(ctx, q, repair.Adjustment, acc.ID); err != nil {
+			return err
+		}
+
+		// Step 4: Record the repair and open its audit log.
+		repair.BalanceRepair, err = q.CreateBalanceRepair(ctx, sqlc.CreateBalanceRepairParams{
+			AccountID:         acc.ID,
+			AdjustmentID:      repair.Adjustment.ID,
+			StoredBalance:     stored.StringFixed(4),
+			CalculatedBalance: calculated.StringFixed(4),
+			Delta:             delta.StringFixed(4),
+		})
+		if err != nil {
+			return err
+		}
+		return appendRepairEvent(ctx, q, repair.ID, repairActionProposed, proposedBy, map[string]string{
+			"stored_balance":     stored.StringFixed(4),
+			"calculated_balance": calculated.StringFixed(4),
+			"delta":              delta.StringFixed(4),
+			"currency":           acc.Currency,
+			"adjustment_id":      repair.Adjustment.ID.String(),
+			"memo":               memo,
+		})
+	})
+	if err != nil {
+		return BalanceRepair{}, err
+	}
+
+	logger(ctx).Warn().
+		Str("repair_id", repair.ID.String()).
+		Str("account_id", accountID.String()).
+		Str("delta", repair.Delta).
+		Str("proposed_by", proposedBy.String()).
+		Msg("Balance repair proposed")
+	return s.GetBalanceRepair(ctx, repair.ID)
+}
+
+// lockPendingRepair locks a repair that is still awaiting a decision.
+func lockPendingRepair(ctx context.Context, q *sqlc.Queries, id uuid.UUID) (sqlc.BalanceRepair, error) {
+	repair, err := q.GetBalanceRepairForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.BalanceRepair{}, ErrBalanceRepairNotFound
+		}
+		return sqlc.BalanceRepair{}, err
+	}
+	if repair.Status != BalanceRepairPending {
+		return sqlc.BalanceRepair{}, ErrBalanceRepairNotPending
+	}
+	return repair, nil
+}
+
+// ApproveBalanceRepair posts a repair's adjustment as approverID, who may not be the admin who
+// proposed it. The account must still be off by exactly the proposed delta, and must match its
+// entries once the adjustment is posted; otherwise nothing is posted.
+func (s *LedgerService) ApproveBalanceRepair(ctx context.Context, id, approverID uuid.UUID, note string) (BalanceRepair, error) {
+	var (
+		repair sqlc.BalanceRepair
+		evt    events.Event
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the repair and its adjustment, refusing the proposer.
+		var err error
+		if repair, err = lockPendingRepair(ctx, q, id); err != nil {
+			return err
+		}
+		adj, err := lockPendingAdjustment(ctx, q, repair.AdjustmentID, approverID)
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Check the mismatch is still the one proposed.
+		acc, err := q.GetAccountForUpdate(ctx, repair.AccountID)
+		if err != nil {
+			return err
+		}
+		stored, calculated, err := accountMismatch(ctx, q, acc)
+		if err != nil {
+			return err
+		}
+		proposed, err := decimal.NewFromString(repair.Delta)
+		if err != nil {
+			return fmt.Errorf("invalid repair delta: %w", err)
+		}
+		if !stored.Sub(calculated).Equal(proposed) {
+			return ErrBalanceRepairStale
+		}
+
+		// Step 3: Post the adjustment, the account's leg writing its entry only.
+		if adj.Adjustment, evt, err = postAdjustment(ctx, q, adj, approverID, note, acc.ID); err != nil {
+			return err
+		}
+		if repair, err = q.DecideBalanceRepair(ctx, sqlc.DecideBalanceRepairParams{Status: BalanceRepairPosted, ID: repair.ID}); err != nil {
+			return err
+		}
+		if err := appendRepairEvent(ctx, q, repair.ID, repairActionApproved, approverID, map[string]string{
+			"note":           note,
+			"transaction_id": evt.TransactionID.String(),
+		}); err != nil {
+			return err
+		}
+
+		// Step 4: Verify the account now matches its entries before committing.
+		acc, err = q.GetAccount(ctx, repair.AccountID)
+		if err != nil {
+			return err
+		}
+		if stored, calculated, err = accountMismatch(ctx, q, acc); err != nil {
+			return err
+		}
+		if !stored.Equal(calculated) {
+			return fmt.Errorf("repair left stored balance %s and entries %s apart", stored.StringFixed(4), calculated.StringFixed(4))
+		}
+		return appendRepairEvent(ctx, q, repair.ID, repairActionVerified, uuid.Nil, map[string]string{
+			"balance":         stored.StringFixed(4),
+			"entries_balance": calculated.StringFixed(4),
+		})
+	})
+	if err != nil {
+		return BalanceRepair{}, err
+	}
+
+	logger(ctx).Warn().
+		Str("repair_id", repair.ID.String()).
+		Str("account_id", repair.AccountID.String()).
+		Str("delta", repair.Delta).
+		Str("approved_by", approverID.String()).
+		Str("tx_id", evt.TransactionID.String()).
+		Msg("Balance repair posted")
+	s.publish(ctx, evt)
+	return s.GetBalanceRepair(ctx, repair.ID)
+}
+
+// RejectBalanceRepair closes a repair and its adjustment without posting anything.
+func (s *LedgerService) RejectBalanceRepair(ctx context.Context, id, approverID uuid.UUID, note string) (BalanceRepair, error) {
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		repair, err := lockPendingRepair(ctx, q, id)
+		if err != nil {
+			return err
+		}
+		adj, err := lockPendingAdjustment(ctx, q, repair.AdjustmentID, approverID)
+		if err != nil {
+			return err
+		}
+		if _, err := q.DecideAdjustment(ctx, sqlc.DecideAdjustmentParams{
+			Status:       AdjustmentRejected,
+			DecidedBy:    uuid.NullUUID{UUID: approverID, Valid: true},
+			DecisionNote: note,
+			ID:           adj.ID,
+		}); err != nil {
+			return err
+		}
+		if _, err := q.DecideBalanceRepair(ctx, sqlc.DecideBalanceRepairParams{Status: BalanceRepairRejected, ID: repair.ID}); err != nil {
+			return err
+		}
+		return appendRepairEvent(ctx, q, repair.ID, repairActionRejected, approverID, map[string]string{"note": note})
+	})
+	if err != nil {
+		return BalanceRepair{}, err
+	}
+
+	logger(ctx).Info().Str("repair_id", id.String()).Str("rejected_by", approverID.String()).Msg("Balance repair rejected")
+	return s.GetBalanceRepair(ctx, id)
+}
+
+// GetBalanceRepair returns a repair with its adjustment and audit log, read as of one moment.
+func (s *LedgerService) GetBalanceRepair(ctx context.Context, id uuid.UUID) (BalanceRepair, error) {
+	var repair BalanceRepair
+	err := s.store.ReadTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		if repair.BalanceRepair, err = q.GetBalanceRepair(ctx, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrBalanceRepairNotFound
+			}
+			return err
+		}
+		if repair.Adjustment.Adjustment, err = q.GetAdjustment(ctx, repair.AdjustmentID); err != nil {
+			return err
+		}
+		if repair.Adjustment.Lines, err = q.ListAdjustmentLines(ctx, repair.AdjustmentID); err != nil {
+			return err
+		}
+		repair.Events, err = q.ListBalanceRepairEvents(ctx, id)
+		return err
+	})
+	if err != nil {
+		return BalanceRepair{}, err
+	}
+	return repair, nil
+}
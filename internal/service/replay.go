@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+var (
+	// ErrBalanceSnapshotNotFound is returned when replaying from a snapshot that does not exist.
+	ErrBalanceSnapshotNotFound = errors.New("balance snapshot not found")
+	// ErrBalanceSnapshotPruned is returned when replaying from a snapshot taken before the end of a
+	// month since pruned, whose entries after the snapshot are no longer there to sum.
+	ErrBalanceSnapshotPruned = errors.New("balance snapshot predates pruned entries")
+)
+
+// ReplayOptions selects how ReplayBalances rebuilds balances from the entries.
+type ReplayOptions struct {
+	// Snapshot starts from a snapshot's balances, so only the entries posted after it are summed.
+	// Without one every entry is summed.
+	Snapshot uuid.NullUUID
+	// All returns every account, not only those whose cached balance differs from the replay.
+	All bool
+	// Repair resets the cached balances that differ to the replayed ones.
+	Repair bool
+}
+
+// BalanceReplay is an account's cached balance next to the one rebuilt from its entries.
+type BalanceReplay struct {
+	AccountID uuid.UUID
+	Currency  string
+	Stored    decimal.Decimal
+	Replayed  decimal.Decimal
+}
+
+// Drift is how far the cached balance is from the replayed one.
+func (b BalanceReplay) Drift() decimal.Decimal {
+	return b.Replayed.Sub(b.Stored)
+}
+
+// ReplayResult reports a replay: the balances compared, how many had drifted and how many of
+// those were repaired.
+type ReplayResult struct {
+	Snapshot *sqlc.BalanceSnapshot
+	Balances []BalanceReplay
+	Drifted  int
+	Repaired int
+}
+
+// TakeBalanceSnapshot records every account's balance summed from its entries, as of the latest
+// ledger event. Postings wait while it runs, so the snapshot holds exactly the postings logged up
+// to its through_seq.
+func (s *LedgerService) TakeBalanceSnapshot(ctx context.Context) (sqlc.BalanceSnapshot, error) {
+	var snapshot sqlc.BalanceSnapshot
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		if err := q.LockLedgerEvents(ctx); err != nil {
+			return err
+		}
+		var err error
+		if snapshot, err = q.CreateBalanceSnapshot(ctx); err != nil {
+			return err
+		}
+		n, err := q.SnapshotBalances(ctx, snapshot.ID)
+		if err != nil {
+			return err
+		}
+		snapshot, err = q.SetBalanceSnapshotAccounts(ctx, sqlc.SetBalanceSnapshotAccountsParams{
+			ID:       snapshot.ID,
+			Accounts: int32(n), // #nosec G115 -- one row per account
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.BalanceSnapshot{}, err
+	}
+	logger(ctx).Info().Str("snapshot_id", snapshot.ID.String()).Int64("through_seq", snapshot.ThroughSeq).
+		Int32("accounts", snapshot.Accounts).Msg("Balance snapshot taken")
+	return snapshot, nil
+}
+
+// LatestBalanceSnapshot returns the most recent snapshot.
+func (s *LedgerService) LatestBalanceSnapshot(ctx context.Context) (sqlc.BalanceSnapshot, error) {
+	snapshot, err := s.store.GetLatestBalanceSnapshot(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.BalanceSnapshot{}, ErrBalanceSnapshotNotFound
+	}
+	return snapshot, err
+}
+
+// ListBalanceSnapshots returns up to limit snapshots, latest first.
+func (s *LedgerService) ListBalanceSnapshots(ctx context.Context, limit int32) ([]sqlc.BalanceSnapshot, error) {
+	return s.store.ListBalanceSnapshots(ctx, limit)
+}
+
+// PruneBalanceSnapshots deletes all but the latest keep snapshots and returns how many it deleted.
+func (s *LedgerService) PruneBalanceSnapshots(ctx context.Context, keep int32) (int64, error) {
+	return s.store.PruneBalanceSnapshots(ctx, keep)
+}
+
+// ReplayBalances rebuilds every account's balance from the raw entries, optionally from a snapshot
+// point, and compares it with the cached balance. With opts.Repair postings wait while it runs and
+// the cached balances that differ are reset to the replayed ones; otherwise nothing is written.
+func (s *LedgerService) ReplayBalances(ctx context.Context, opts ReplayOptions) (ReplayResult, error) {
+	var result ReplayResult
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		result = ReplayResult{}
+		// Step 1: Hold postings back while repairing, so none lands between replay and reset.
+		if opts.Repair {
+			if err := q.LockLedgerEvents(ctx); err != nil {
+				return err
+			}
+		}
+		if opts.Snapshot.Valid {
+			snapshot, err := q.GetBalanceSnapshot(ctx, opts.Snapshot.UUID)
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrBalanceSnapshotNotFound
+			}
+			if err != nil {
+				return err
+			}
+			pruned, err := q.GetLatestPrunedPeriod(ctx)
+			if err != nil {
+				return err
+			}
+			if pruned.Valid && snapshot.CreatedAt.Before(pruned.Time.AddDate(0, 1, 0)) {
+				return ErrBalanceSnapshotPruned
+			}
+			result.Snapshot = &snapshot
+		}
+
+		// Step 2: Replay and compare.
+		rows, err := q.ReplayBalances(ctx, sqlc.ReplayBalancesParams{
+			SnapshotID: opts.Snapshot,
+			DriftOnly:  !opts.All,
+		})
+		if err != nil {
+			return err
+		}
+		if result.Balances, result.Drifted, err = toBalanceReplays(rows); err != nil {
+			return err
+		}
+		if !opts.Repair {
+			return nil
+		}
+
+		// Step 3: Reset the drifted balances.
+		for _, b := range result.Balances {
+			if b.Drift().IsZero() {
+				continue
+			}
+			n, err := q.RepairAccountBalance(ctx, sqlc.RepairAccountBalanceParams{
+				ID:       b.AccountID,
+				Stored:   b.Stored.StringFixed(4),
+				Replayed: b.Replayed.StringFixed(4),
+			})
+			if err != nil {
+				return err
+			}
+			result.Repaired += int(n)
+		}
+		return nil
+	})
+	if err != nil {
+		return ReplayResult{}, err
+	}
+	if result.Drifted > 0 {
+		logger(ctx).Warn().Int("drifted", result.Drifted).Int("repaired", result.Repaired).
+			Bool("from_snapshot", opts.Snapshot.Valid).Msg("Cached balances differ from the entries")
+	}
+	return result, nil
+}
+
+// toBalanceReplays parses replayed rows and counts those that drifted.
+func toBalanceReplays(rows []sqlc.ReplayBalancesRow) ([]BalanceReplay, int, error) {
+	balances := make([]BalanceReplay, 0, len(rows))
+	drifted := 0
+	for _, row := range rows {
+		stored, err := decimal.NewFromString(row.StoredBalance)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid stored balance for account %s: %w", row.AccountID, err)
+		}
+		replayed, err := decimal.NewFromString(row.ReplayedBalance)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid replayed balance for account %s: %w", row.AccountID, err)
+		}
+		b := BalanceReplay{AccountID: row.AccountID, Currency: row.Currency, Stored: stored, Replayed: replayed}
+		if !b.Drift().IsZero() {
+			drifted++
+		}
+		balances = append(balances, b)
+	}
+	return balances, drifted, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestToBalanceReplays(t *testing.T) {
+	// Balances are compared as amounts, so scale alone is not drift.
+	rows := []sqlc.ReplayBalancesRow{
+		{AccountID: uuid.New(), Currency: "USD", StoredBalance: "100.0000", ReplayedBalance: "100"},
+		{AccountID: uuid.New(), Currency: "USD", StoredBalance: "90.0000", ReplayedBalance: "95.5000"},
+	}
+	balances, drifted, err := toBalanceReplays(rows)
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, 1, drifted)
+	assert.True(t, balances[0].Drift().IsZero())
+	assert.Equal(t, "5.5", balances[1].Drift().String())
+
+	_, _, err = toBalanceReplays([]sqlc.ReplayBalancesRow{{StoredBalance: "x", ReplayedBalance: "1"}})
+	assert.Error(t, err)
+}
+
+func TestReplayBalances_FromSnapshot(t *testing.T) {
+	// Replaying from a snapshot adds the later entries; repair resets a corrupted cached balance.
+	ledger := setupTestLedger(t)
+	ctx := context.Background()
+	accountID := createTestAccount(t, ledger, "100.00")
+	snapshot, err := ledger.TakeBalanceSnapshot(ctx)
+	require.NoError(t, err)
+	require.NoError(t, ledger.Deposit(ctx, accountID, "50.00"))
+
+	_, err = ledger.store.RepairAccountBalance(ctx, sqlc.RepairAccountBalanceParams{ID: accountID, Stored: "150.0000", Replayed: "175.0000"})
+	require.NoError(t, err)
+
+	find := func(r ReplayResult) *BalanceReplay {
+		for i := range r.Balances {
+			if r.Balances[i].AccountID == accountID {
+				return &r.Balances[i]
+			}
+		}
+		return nil
+	}
+	snap := uuid.NullUUID{UUID: snapshot.ID, Valid: true}
+	result, err := ledger.ReplayBalances(ctx, ReplayOptions{Snapshot: snap})
+	require.NoError(t, err)
+	b := find(result)
+	require.NotNil(t, b)
+	assert.Equal(t, "150.0000", b.Replayed.StringFixed(4))
+	assert.Equal(t, "-25.0000", b.Drift().StringFixed(4))
+
+	result, err = ledger.ReplayBalances(ctx, ReplayOptions{Snapshot: snap, Repair: true})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, result.Repaired, 1)
+	account, err := ledger.store.GetAccount(ctx, accountID)
+	require.NoError(t, err)
+	assert.Equal(t, "150.0000", account.Balance)
+
+	result, err = ledger.ReplayBalances(ctx, ReplayOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, find(result))
+}
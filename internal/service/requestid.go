@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// requestIDKey is the context key for the ID of the HTTP request the service is working for.
+type requestIDKey struct{}
+
+// WithRequestID returns ctx carrying the ID of the request that caused the work, so the
+// service's logs and the transactions it records can be traced back to it.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the ID set by WithRequestID, or "" for background work.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// logger returns the global logger, tagged with ctx's request ID when there is one.
+func logger(ctx context.Context) *zerolog.Logger {
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		return &log.Logger
+	}
+	l := log.With().Str("request_id", id).Logger()
+	return &l
+}
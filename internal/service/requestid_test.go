@@ -0,0 +1,34 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDFromContext(t *testing.T) {
+	// The ID set on a context is read back; background contexts and empty IDs carry none.
+	ctx := WithRequestID(context.Background(), "host/abc-000042")
+	assert.Equal(t, "host/abc-000042", RequestIDFromContext(ctx))
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+	assert.Empty(t, RequestIDFromContext(WithRequestID(context.Background(), "")))
+}
+
+func TestLogger_TagsRequestID(t *testing.T) {
+	// Service logs carry the request ID of the context they were written for.
+	var buf bytes.Buffer
+	prev := log.Logger
+	log.Logger = zerolog.New(&buf)
+	t.Cleanup(func() { log.Logger = prev })
+
+	logger(WithRequestID(context.Background(), "host/abc-000042")).Info().Msg("Transfer completed")
+	assert.Contains(t, buf.String(), `"request_id":"host/abc-000042"`)
+
+	buf.Reset()
+	logger(context.Background()).Info().Msg("Interest paid")
+	assert.NotContains(t, buf.String(), "request_id")
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// FeeReversalPolicy decides what happens to a transaction's fees when the transaction is reversed.
+type FeeReversalPolicy string
+
+const (
+	// FeesRefunded reverses fee entries together with the transaction. It is the default.
+	FeesRefunded FeeReversalPolicy = "refund"
+	// FeesRetained reverses only the operation and keeps the fees in Fee Income.
+	FeesRetained FeeReversalPolicy = "retain"
+)
+
+var (
+	// ErrTransactionNotFound is returned when a transaction does not exist.
+	ErrTransactionNotFound = errors.New("transaction not found")
+	// ErrTransactionNotReversible is returned for transactions the reversal path does not undo.
+	ErrTransactionNotReversible = errors.New("only a posted transfer, deposit or withdrawal that was not disputed, partly refunded or paid out through a bank rail can be reversed")
+	// ErrInvalidFeeReversalPolicy is returned for a policy other than refund or retain.
+	ErrInvalidFeeReversalPolicy = errors.New("fee reversal policy must be refund or retain")
+)
+
+// reversibleOperations are the operation types ReverseTransaction undoes. Rail payouts settle or
+// reverse through their rail, and the other flows have their own way back.
+var reversibleOperations = map[string]bool{"transfer": true, "deposit": true, "withdrawal": true}
+
+// ParseFeeReversalPolicy reads a policy name; empty means the default.
+func ParseFeeReversalPolicy(s string) (FeeReversalPolicy, error) {
+	switch p := FeeReversalPolicy(s); p {
+	case "":
+		return FeesRefunded, nil
+	case FeesRefunded, FeesRetained:
+		return p, nil
+	default:
+		return "", ErrInvalidFeeReversalPolicy
+	}
+}
+
+// WithFeeReversal sets whether reversals refund fees when the caller does not say.
+func WithFeeReversal(p FeeReversalPolicy) Option {
+	return func(s *LedgerService) {
+		s.feeReversal = p
+	}
+}
+
+// ReversalRequest undoes TransactionID. RefundFees overrides the service's fee reversal policy.
+type ReversalRequest struct {
+	TransactionID uuid.UUID
+	ReversedBy    uuid.UUID
+	Reason        string
+	RefundFees    *bool
+}
+
+// reversalLegs mirrors entries, swapping debit and credit. Fee entries are mirrored only when
+// refundFees; either way it returns the fee the transaction charged.
+func reversalLegs(entries []sqlc.Entry, fees map[uuid.UUID]bool, accounts map[uuid.UUID]sqlc.Account, refundFees bool) ([]leg, decimal.Decimal, error) {
+	legs := make([]leg, 0, len(entries))
+	feeTotal := decimal.Zero
+	for _, e := range entries {
+		debit, err := decimal.NewFromString(e.Debit)
+		if err != nil {
+			return nil, decimal.Zero, fmt.Errorf("invalid debit on entry %s: %w", e.ID, err)
+		}
+		credit, err := decimal.NewFromString(e.Credit)
+		if err != nil {
+			return nil, decimal.Zero, fmt.Errorf("invalid credit on entry %s: %w", e.ID, err)
+		}
+		if fees[e.ID] {
+			feeTotal = feeTotal.Add(debit)
+			if !refundFees {
+				continue
+			}
+		}
+		description := "Reversal of " + e.TransactionID.String()
+		if e.Description.Valid {
+			description = "Reversal: " + e.Description.String
+		}
+		legs = append(legs, leg{account: accounts[e.AccountID], debit: credit, credit: debit, description: description, fee: fees[e.ID]})
+	}
+	return legs, feeTotal, nil
+}
+
+// ReverseTransaction undoes a posted transfer, deposit or withdrawal with one reversal posting
+// that mirrors its entries, refunding its fees or keeping them as the policy says, and marks the
+// original reversed. The accounts it credited must still hold the money.
+func (s *LedgerService) ReverseTransaction(ctx context.Context, req ReversalRequest) (sqlc.TransactionReversal, error) {
+	refundFees := s.feeReversal != FeesRetained
+	if req.RefundFees != nil {
+		refundFees = *req.RefundFees
+	}
+
+	var (
+		reversal sqlc.TransactionReversal
+		evt      events.Event
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Only posted transactions of a reversible kind; marking it reversed first
+		// serializes concurrent attempts on the transaction row.
+		tx, err := q.GetTransaction(ctx, req.TransactionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrTransactionNotFound
+			}
+			return err
+		}
+		if !reversibleOperations[tx.OperationType] {
+			return ErrTransactionNotReversible
+		}
+		if err := transitionTransaction(ctx, q, tx.ID, TransactionPosted, TransactionReversed); err != nil {
+			if errors.Is(err, ErrInvalidTransactionTransition) {
+				return ErrTransactionNotReversible
+			}
+			return err
+		}
+		if _, err := q.GetDisputeByTransaction(ctx, tx.ID); err == nil {
+			return ErrTransactionNotReversible
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		payout, err := q.IsPayoutTransaction(ctx, tx.ID)
+		if err != nil {
+			return err
+		}
+		if payout {
+			return ErrTransactionNotReversible
+		}
+		// A partly refunded transaction is refunded the rest of the way instead.
+		refunded, err := q.SumTransactionRefunds(ctx, tx.ID)
+		if err != nil {
+			return err
+		}
+		if isPositive(refunded) {
+			return ErrTransactionNotReversible
+		}
+
+		// Step 2: Lock every account the transaction touched, then mirror its entries.
+		entries, err := q.ListEntriesByTransaction(ctx, tx.ID)
+		if err != nil {
+			return err
+		}
+		fees, err := feeEntrySet(ctx, q, tx.ID)
+		if err != nil {
+			return err
+		}
+		ids := make([]uuid.UUID, 0, len(entries))
+		for _, e := range entries {
+			ids = append(ids, e.AccountID)
+		}
+		accounts, err := lockAccounts(ctx, q, ids...)
+		if err != nil {
+			return err
+		}
+		legs, feeTotal, err := reversalLegs(entries, fees, accounts, refundFees)
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Post the reversal and link it to the original.
+		txID := uuid.New()
+		postings, balances, err := postLegs(ctx, q, txID, "reversal", legs...)
+		if err != nil {
+			return err
+		}
+		reversal, err = q.CreateTransactionReversal(ctx, sqlc.CreateTransactionReversalParams{
+			TransactionID:         tx.ID,
+			ReversalTransactionID: txID,
+			FeesRefunded:          refundFees && feeTotal.IsPositive(),
+			FeeAmount:             feeTotal.StringFixed(4),
+			Reason:                req.Reason,
+			ReversedBy:            req.ReversedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		var currency string
+		amount := decimal.Zero
+		for _, l := range legs {
+			amount, currency = amount.Add(l.debit), l.account.Currency
+		}
+		evt = events.Event{
+			Type:          events.TypeReversal,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      currency,
+			Entries:       postings,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.TransactionReversal{}, err
+	}
+
+	logger(ctx).Info().Str("transaction_id", reversal.TransactionID.String()).Str("reversal_transaction_id", reversal.ReversalTransactionID.String()).Bool("fees_refunded", reversal.FeesRefunded).Str("reversed_by", req.ReversedBy.String()).Msg("Transaction reversed")
+	s.publish(ctx, evt)
+	return reversal, nil
+}
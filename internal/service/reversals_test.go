@@ -0,0 +1,62 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestReversalLegs(t *testing.T) {
+	// A reversal mirrors every entry; fee entries only when fees are refunded.
+	txID := uuid.New()
+	payer := sqlc.Account{ID: uuid.New(), Currency: "NGN", Balance: "0"}
+	payee := sqlc.Account{ID: uuid.New(), Currency: "NGN", Balance: "100"}
+	income := sqlc.Account{ID: uuid.New(), Currency: "NGN", Balance: "1", IsSystem: true}
+	accounts := map[uuid.UUID]sqlc.Account{payer.ID: payer, payee.ID: payee, income.ID: income}
+	entries := []sqlc.Entry{
+		{ID: uuid.New(), TransactionID: txID, AccountID: payer.ID, Debit: "100.0000", Credit: "0.0000", Description: sql.NullString{String: "Rent", Valid: true}},
+		{ID: uuid.New(), TransactionID: txID, AccountID: payee.ID, Debit: "0.0000", Credit: "100.0000"},
+		{ID: uuid.New(), TransactionID: txID, AccountID: payer.ID, Debit: "1.5000", Credit: "0.0000", Description: sql.NullString{String: "Transfer fee", Valid: true}},
+		{ID: uuid.New(), TransactionID: txID, AccountID: income.ID, Debit: "0.0000", Credit: "1.5000"},
+	}
+	fees := map[uuid.UUID]bool{entries[2].ID: true, entries[3].ID: true}
+
+	legs, feeTotal, err := reversalLegs(entries, fees, accounts, true)
+	require.NoError(t, err)
+	require.Len(t, legs, 4)
+	assert.Equal(t, "1.5", feeTotal.String())
+	assert.Equal(t, payer.ID, legs[0].account.ID)
+	assert.Equal(t, "100", legs[0].credit.String())
+	assert.True(t, legs[0].debit.IsZero())
+	assert.Equal(t, "Reversal: Rent", legs[0].description)
+	assert.Equal(t, "Reversal of "+txID.String(), legs[1].description)
+	assert.Equal(t, "100", legs[1].debit.String())
+	assert.True(t, legs[3].fee)
+	assert.Equal(t, "1.5", legs[3].debit.String())
+	assert.NoError(t, checkCustomerBalances(legs))
+
+	legs, feeTotal, err = reversalLegs(entries, fees, accounts, false)
+	require.NoError(t, err)
+	require.Len(t, legs, 2)
+	assert.Equal(t, "1.5", feeTotal.String())
+	for _, l := range legs {
+		assert.False(t, l.fee)
+	}
+}
+
+func TestParseFeeReversalPolicy(t *testing.T) {
+	// Fees are refunded unless the policy says to retain them.
+	p, err := ParseFeeReversalPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, FeesRefunded, p)
+	p, err = ParseFeeReversalPolicy("retain")
+	require.NoError(t, err)
+	assert.Equal(t, FeesRetained, p)
+	_, err = ParseFeeReversalPolicy("sometimes")
+	assert.ErrorIs(t, err, ErrInvalidFeeReversalPolicy)
+}
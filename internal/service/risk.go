@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/risk"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// ErrStepUpRequired is returned when an operation scored high enough for fraud that the customer
+// must prove it is them with a second factor before it goes ahead.
+var ErrStepUpRequired = errors.New("additional verification required")
+
+// riskAlertRule names fraud reviews on the compliance review queue, alongside AML rule hits.
+const riskAlertRule = "fraud_risk"
+
+// WithRiskScoring scores withdrawals, transfers and bank payouts with scorer before they post:
+// challenged ones need a step-up (see WithStepUp) and reviewed ones wait on the review queue.
+func WithRiskScoring(scorer *risk.Scorer) Option {
+	return func(s *LedgerService) {
+		s.risk = scorer
+	}
+}
+
+// stepUpKey is the context key marking a request the customer confirmed with a second factor.
+type stepUpKey struct{}
+
+// WithStepUp returns ctx marked as confirmed with a second factor, e.g. a request signed with a
+// key registered on the account, so operations challenged for fraud risk go ahead.
+func WithStepUp(ctx context.Context) context.Context {
+	return context.WithValue(ctx, stepUpKey{}, true)
+}
+
+func steppedUp(ctx context.Context) bool {
+	ok, _ := ctx.Value(stepUpKey{}).(bool)
+	return ok
+}
+
+// geoAnomalyKey is the context key describing a sudden change of the requester's country.
+type geoAnomalyKey struct{}
+
+// WithGeoAnomaly returns ctx marked as coming from a country the customer was not in moments ago,
+// described by detail, so fraud risk scoring weighs it against the operations requested.
+func WithGeoAnomaly(ctx context.Context, detail string) context.Context {
+	return context.WithValue(ctx, geoAnomalyKey{}, detail)
+}
+
+func geoAnomaly(ctx context.Context) string {
+	detail, _ := ctx.Value(geoAnomalyKey{}).(string)
+	return detail
+}
+
+// riskKey is the context key carrying an operation's assessment to the transaction it records.
+type riskKey struct{}
+
+func riskFromContext(ctx context.Context) (risk.Assessment, bool) {
+	a, ok := ctx.Value(riskKey{}).(risk.Assessment)
+	return a, ok
+}
+
+// assessRisk scores an outbound movement of amount from accountID to beneficiary ("" for none)
+// before it is posted. A challenged operation is refused with ErrStepUpRequired unless ctx is
+// stepped up. One needing review is recorded on the review queue and refused with
+// ErrHeldForReview, or with ErrScreeningBlocked when it cannot wait (holdable false). Otherwise
+// the returned context carries the assessment to the transaction the caller posts with it.
+func (s *LedgerService) assessRisk(ctx context.Context, kind string, accountID uuid.UUID, beneficiary string, amount decimal.Decimal, holdable bool) (context.Context, error) {
+	if s.risk == nil {
+		return ctx, nil
+	}
+	// Step 1: Load the account, its recent outbound history and whether it paid beneficiary before.
+	account, err := s.store.GetAccount(ctx, accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ctx, nil
+	}
+	if err != nil {
+		return ctx, err
+	}
+	if account.IsSystem {
+		return ctx, nil
+	}
+	now := time.Now()
+	rows, err := s.store.ListAccountActivitySince(ctx, sqlc.ListAccountActivitySinceParams{
+		AccountID: accountID,
+		Since:     now.Add(-s.risk.Lookback()),
+	})
+	if err != nil {
+		return ctx, err
+	}
+	history := make([]risk.Activity, 0, len(rows))
+	for _, row := range rows {
+		debit, err := decimal.NewFromString(row.Debit)
+		if err != nil {
+			return ctx, fmt.Errorf("invalid entry debit: %w", err)
+		}
+		if debit.IsPositive() {
+			history = append(history, risk.Activity{Amount: debit, At: row.CreatedAt.Time})
+		}
+	}
+	op := risk.Operation{Kind: kind, Amount: amount, Beneficiary: beneficiary, GeoAnomaly: geoAnomaly(ctx), At: now}
+	if beneficiary != "" {
+		if op.KnownBeneficiary, err = s.store.IsKnownBeneficiary(ctx, sqlc.IsKnownBeneficiaryParams{AccountID: accountID, Beneficiary: beneficiary}); err != nil {
+			return ctx, err
+		}
+	}
+
+	// Step 2: Score; accounts outside an organization have nobody to review them, so they step up.
+	a := s.risk.Assess(op, history)
+	if a.Decision == risk.Review && !account.OrgID.Valid {
+		a.Decision = risk.Challenge
+	}
+	switch a.Decision {
+	case risk.Challenge:
+		if !steppedUp(ctx) {
+			logger(ctx).Warn().Str("account_id", accountID.String()).Str("operation", kind).Int("risk_score", a.Score).
+				Strs("factors", a.FactorNames()).Msg("Operation needs step-up - fraud risk")
+			return ctx, ErrStepUpRequired
+		}
+	case risk.Review:
+		return ctx, s.holdForRiskReview(ctx, account, op, a, holdable)
+	}
+	return context.WithValue(ctx, riskKey{}, a), nil
+}
+
+// holdForRiskReview records an operation scored for review on the compliance review queue, where it
+// can be released like an AML hold, and returns the error that refuses it for now.
+func (s *LedgerService) holdForRiskReview(ctx context.Context, account sqlc.Account, op risk.Operation, a risk.Assessment, holdable bool) error {
+	details := make([]string, 0, len(a.Factors))
+	for _, f := range a.Factors {
+		details = append(details, f.Detail)
+	}
+	action := aml.ActionHold
+	if !holdable {
+		action = aml.ActionBlock
+	}
+	sc := screening{
+		op:      aml.Operation{Kind: op.Kind, AccountID: account.ID, Direction: aml.Outbound, Amount: op.Amount, Currency: account.Currency, At: op.At},
+		account: account,
+		verdict: aml.Verdict{Action: action, Hits: []aml.Hit{{
+			Rule:   riskAlertRule,
+			Action: action,
+			Reason: fmt.Sprintf("fraud risk score %d: %s", a.Score, strings.Join(details, "; ")),
+		}}},
+	}
+	if rest, ok := strings.CutPrefix(op.Beneficiary, "account:"); ok {
+		if id, err := uuid.Parse(rest); err == nil {
+			sc.counterparty = uuid.NullUUID{UUID: id, Valid: true}
+		}
+	}
+	alert, err := s.recordAlert(ctx, sc, uuid.NullUUID{})
+	if err != nil {
+		return err
+	}
+	logger(ctx).Warn().Str("alert_id", alert.ID.String()).Str("account_id", account.ID.String()).Str("operation", op.Kind).
+		Int("risk_score", a.Score).Strs("factors", a.FactorNames()).Msg("Operation stopped for fraud review")
+	if action == aml.ActionHold {
+		return ErrHeldForReview
+	}
+	return ErrScreeningBlocked
+}
+
+// rememberBeneficiary records inside an open transaction that accountID paid beneficiary, so
+// later payments to it are not scored as a first one. It does nothing without risk scoring.
+func (s *LedgerService) rememberBeneficiary(ctx context.Context, q *sqlc.Queries, accountID uuid.UUID, beneficiary string) error {
+	if s.risk == nil || beneficiary == "" {
+		return nil
+	}
+	return q.RememberBeneficiary(ctx, sqlc.RememberBeneficiaryParams{AccountID: accountID, Beneficiary: beneficiary})
+}
+
+// transferBeneficiary and payoutBeneficiary key the beneficiaries risk scoring remembers.
+func transferBeneficiary(toID uuid.UUID) string { return "account:" + toID.String() }
+
+func payoutBeneficiary(bankCode, accountNumber string) string {
+	return "bank:" + bankCode + ":" + accountNumber
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStepUp(t *testing.T) {
+	// Only contexts marked by WithStepUp count as stepped up.
+	assert.False(t, steppedUp(context.Background()))
+	assert.True(t, steppedUp(WithStepUp(context.Background())))
+}
+
+func TestGeoAnomaly(t *testing.T) {
+	// The anomaly described by WithGeoAnomaly reaches scoring; other contexts have none.
+	assert.Equal(t, "", geoAnomaly(context.Background()))
+	assert.Equal(t, "request from GB", geoAnomaly(WithGeoAnomaly(context.Background(), "request from GB")))
+}
+
+func TestAssessRisk_Disabled(t *testing.T) {
+	// Without a scorer nothing is loaded and the context passes through unchanged.
+	s := &LedgerService{}
+	ctx := context.Background()
+	got, err := s.assessRisk(ctx, "transfer", uuid.New(), transferBeneficiary(uuid.New()), decimal.NewFromInt(1), true)
+	assert.NoError(t, err)
+	assert.Equal(t, ctx, got)
+	_, scored := riskFromContext(got)
+	assert.False(t, scored)
+}
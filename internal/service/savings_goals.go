@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// KindSavingsContributions is the background job kind that makes due savings contributions.
+const KindSavingsContributions = "savings.contributions"
+
+// Contribution intervals stored on savings_goals.
+const (
+	ContributionWeekly  = "weekly"
+	ContributionMonthly = "monthly"
+)
+
+// GoalDateLayout formats savings goal target and lock dates.
+const GoalDateLayout = "2006-01-02"
+
+var (
+	// ErrSavingsGoalNotFound is returned when a wallet has no savings goal.
+	ErrSavingsGoalNotFound = errors.New("savings goal not found")
+	// ErrSavingsGoalExists is returned when creating a second goal for a wallet.
+	ErrSavingsGoalExists = errors.New("wallet already has a savings goal")
+	// ErrSavingsGoalLocked is returned when debiting, unlocking or deleting a goal wallet before its lock date.
+	ErrSavingsGoalLocked = errors.New("savings goal is locked")
+	// ErrInvalidSavingsGoal is returned for a goal that is not on a sub-wallet or has invalid fields.
+	ErrInvalidSavingsGoal = errors.New("invalid savings goal")
+)
+
+// SavingsGoalRequest sets a goal on a sub-wallet. Zero dates and an empty contribution amount
+// leave those parts unset.
+type SavingsGoalRequest struct {
+	WalletID             uuid.UUID
+	Name                 string
+	TargetAmount         string
+	TargetDate           time.Time
+	LockedUntil          time.Time
+	ContributionAmount   string
+	ContributionInterval string
+	CreatedBy            uuid.UUID
+}
+
+// SavingsProgress is how far a goal wallet's balance is towards its target.
+type SavingsProgress struct {
+	Saved     decimal.Decimal
+	Remaining decimal.Decimal
+	// Percent is Saved over the target, capped at 100 and rounded down to two decimals.
+	Percent decimal.Decimal
+	Reached bool
+}
+
+// GoalProgress measures balance against the goal's target.
+func GoalProgress(goal sqlc.SavingsGoal, balance string) (SavingsProgress, error) {
+	target, err := decimal.NewFromString(goal.TargetAmount)
+	if err != nil {
+		return SavingsProgress{}, errors.New("invalid target amount")
+	}
+	saved, err := decimal.NewFromString(balance)
+	if err != nil {
+		return SavingsProgress{}, errors.New("invalid balance")
+	}
+	p := SavingsProgress{Saved: saved, Reached: !saved.LessThan(target)}
+	if !p.Reached {
+		p.Remaining = target.Sub(saved)
+		p.Percent = decimal.Max(saved, decimal.Zero).Mul(decimal.NewFromInt(100)).Div(target).RoundDown(2)
+	} else {
+		p.Percent = decimal.NewFromInt(100)
+	}
+	return p, nil
+}
+
+// GoalLocked reports whether the goal's wallet is still locked at now. A wallet unlocks at
+// the start (UTC) of its locked_until date.
+func GoalLocked(goal sqlc.SavingsGoal, now time.Time) bool {
+	return goal.LockedUntil.Valid && now.UTC().Before(goal.LockedUntil.Time)
+}
+
+// checkGoalLock refuses a debit of acc while it is the wallet of a locked savings goal.
+// Only sub-wallets carry goals, so other accounts cost no query.
+func checkGoalLock(ctx context.Context, q *sqlc.Queries, acc sqlc.Account) error {
+	if !acc.ParentAccountID.Valid {
+		return nil
+	}
+	goal, err := q.GetSavingsGoalByWallet(ctx, acc.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if GoalLocked(goal, time.Now()) {
+		return fmt.Errorf("%w until %s", ErrSavingsGoalLocked, goal.LockedUntil.Time.Format(GoalDateLayout))
+	}
+	return nil
+}
+
+// nextContribution is the first contribution date of interval after prev that is after now,
+// so a backlog after downtime is skipped rather than collected in a burst.
+func nextContribution(prev time.Time, interval string, now time.Time) time.Time {
+	next := prev
+	for !next.After(now) {
+		if interval == ContributionWeekly {
+			next = next.AddDate(0, 0, 7)
+		} else {
+			next = next.AddDate(0, 1, 0)
+		}
+	}
+	return next
+}
+
+// savingsGoalParams validates req into the columns it sets.
+func savingsGoalParams(req SavingsGoalRequest) (sqlc.CreateSavingsGoalParams, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return sqlc.CreateSavingsGoalParams{}, fmt.Errorf("%w: name required (at most 100 characters)", ErrInvalidSavingsGoal)
+	}
+	target, err := validatePositiveAmount(req.TargetAmount)
+	if err != nil {
+		return sqlc.CreateSavingsGoalParams{}, fmt.Errorf("%w: invalid target_amount", ErrInvalidSavingsGoal)
+	}
+	params := sqlc.CreateSavingsGoalParams{
+		WalletID:     req.WalletID,
+		Name:         name,
+		TargetAmount: target.StringFixed(4),
+		TargetDate:   sql.NullTime{Time: req.TargetDate, Valid: !req.TargetDate.IsZero()},
+		LockedUntil:  sql.NullTime{Time: req.LockedUntil, Valid: !req.LockedUntil.IsZero()},
+		CreatedBy:    req.CreatedBy,
+	}
+	switch {
+	case req.ContributionAmount == "" && req.ContributionInterval == "":
+	case req.ContributionInterval != ContributionWeekly && req.ContributionInterval != ContributionMonthly:
+		return sqlc.CreateSavingsGoalParams{}, fmt.Errorf("%w: contribution interval must be weekly or monthly", ErrInvalidSavingsGoal)
+	default:
+		amount, err := validatePositiveAmount(req.ContributionAmount)
+		if err != nil {
+			return sqlc.CreateSavingsGoalParams{}, fmt.Errorf("%w: invalid contribution amount", ErrInvalidSavingsGoal)
+		}
+		params.ContributionAmount = sql.NullString{String: amount.StringFixed(4), Valid: true}
+		params.ContributionInterval = sql.NullString{String: req.ContributionInterval, Valid: true}
+	}
+	return params, nil
+}
+
+// CreateSavingsGoal sets a goal on a sub-wallet. A contribution rule makes its first move on
+// the next contributions run.
+func (s *LedgerService) CreateSavingsGoal(ctx context.Context, req SavingsGoalRequest) (sqlc.SavingsGoal, error) {
+	params, err := savingsGoalParams(req)
+	if err != nil {
+		return sqlc.SavingsGoal{}, err
+	}
+	if params.ContributionAmount.Valid {
+		params.NextContributionAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	}
+
+	var goal sqlc.SavingsGoal
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the wallet so two goals cannot race onto it.
+		wallet, err := q.GetAccountForUpdate(ctx, req.WalletID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+		if wallet.IsSystem || !wallet.ParentAccountID.Valid {
+			return fmt.Errorf("%w: goals can only be set on a sub-wallet", ErrInvalidSavingsGoal)
+		}
+		if _, err := q.GetSavingsGoalByWallet(ctx, wallet.ID); err == nil {
+			return ErrSavingsGoalExists
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		// Step 2: Record the goal.
+		goal, err = q.CreateSavingsGoal(ctx, params)
+		return err
+	})
+	if err != nil {
+		return sqlc.SavingsGoal{}, err
+	}
+
+	logger(ctx).Info().Str("wallet_id", req.WalletID.String()).Str("goal_id", goal.ID.String()).Msg("Savings goal created")
+	return goal, nil
+}
+
+// UpdateSavingsGoal replaces a wallet's goal. While the goal is locked its lock date may be
+// extended but not brought forward or removed. Changing the contribution rule restarts it.
+func (s *LedgerService) UpdateSavingsGoal(ctx context.Context, req SavingsGoalRequest) (sqlc.SavingsGoal, error) {
+	params, err := savingsGoalParams(req)
+	if err != nil {
+		return sqlc.SavingsGoal{}, err
+	}
+
+	var goal sqlc.SavingsGoal
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		current, err := q.GetSavingsGoalByWalletForUpdate(ctx, req.WalletID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrSavingsGoalNotFound
+			}
+			return err
+		}
+		if GoalLocked(current, time.Now()) && (!params.LockedUntil.Valid || params.LockedUntil.Time.Before(current.LockedUntil.Time)) {
+			return fmt.Errorf("%w until %s", ErrSavingsGoalLocked, current.LockedUntil.Time.Format(GoalDateLayout))
+		}
+
+		next := current.NextContributionAt
+		if params.ContributionAmount != current.ContributionAmount || params.ContributionInterval != current.ContributionInterval {
+			next = sql.NullTime{Time: time.Now().UTC(), Valid: params.ContributionAmount.Valid}
+		}
+		goal, err = q.UpdateSavingsGoal(ctx, sqlc.UpdateSavingsGoalParams{
+			ID:                   current.ID,
+			Name:                 params.Name,
+			TargetAmount:         params.TargetAmount,
+			TargetDate:           params.TargetDate,
+			LockedUntil:          params.LockedUntil,
+			ContributionAmount:   params.ContributionAmount,
+			ContributionInterval: params.ContributionInterval,
+			NextContributionAt:   next,
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.SavingsGoal{}, err
+	}
+
+	logger(ctx).Info().Str("wallet_id", req.WalletID.String()).Str("goal_id", goal.ID.String()).Msg("Savings goal updated")
+	return goal, nil
+}
+
+// DeleteSavingsGoal removes a wallet's goal once it is no longer locked. The money stays in the wallet.
+func (s *LedgerService) DeleteSavingsGoal(ctx context.Context, walletID uuid.UUID) error {
+	return s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		goal, err := q.GetSavingsGoalByWalletForUpdate(ctx, walletID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrSavingsGoalNotFound
+			}
+			return err
+		}
+		if GoalLocked(goal, time.Now()) {
+			return fmt.Errorf("%w until %s", ErrSavingsGoalLocked, goal.LockedUntil.Time.Format(GoalDateLayout))
+		}
+		return q.DeleteSavingsGoal(ctx, goal.ID)
+	})
+}
+
+// ContributeSavings is a jobs.HandlerFunc that makes every due savings contribution. A
+// contribution the parent cannot cover is skipped until the next interval and recorded on the goal.
+func (s *LedgerService) ContributeSavings(ctx context.Context, _ json.RawMessage) error {
+	const batch = 100
+	var (
+		errs []error
+		made int
+	)
+	for {
+		due, err := s.store.ListDueSavingsContributions(ctx, sqlc.ListDueSavingsContributionsParams{Now: time.Now(), RowLimit: batch})
+		if err != nil {
+			return errors.Join(append(errs, fmt.Errorf("list due savings contributions: %w", err))...)
+		}
+		failed := 0
+		for _, walletID := range due {
+			ok, err := s.contributeToGoal(ctx, walletID, time.Now().UTC())
+			if err != nil {
+				logger(ctx).Error().Err(err).Str("wallet_id", walletID.String()).Msg("Failed to make savings contribution")
+				errs = append(errs, err)
+				failed++
+				continue
+			}
+			if ok {
+				made++
+			}
+		}
+		// Goals that errored stay due; stop rather than fetch them again.
+		if len(due) < batch || failed == len(due) {
+			break
+		}
+	}
+
+	logger(ctx).Info().Int("made", made).Int("failed", len(errs)).Msg("Savings contributions made")
+	return errors.Join(errs...)
+}
+
+// contributeToGoal moves one contribution from the wallet's parent into it if still due, and
+// schedules the next. It reports whether money moved.
+func (s *LedgerService) contributeToGoal(ctx context.Context, walletID uuid.UUID, now time.Time) (bool, error) {
+	var (
+		evt  events.Event
+		made bool
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the goal; a concurrent run finds it no longer due.
+		made = false
+		goal, err := q.GetSavingsGoalByWalletForUpdate(ctx, walletID)
+		if err != nil {
+			return err
+		}
+		if !goal.NextContributionAt.Valid || goal.NextContributionAt.Time.After(now) {
+			return nil
+		}
+		amount, err := decimal.NewFromString(goal.ContributionAmount.String)
+		if err != nil {
+			return errors.New("invalid contribution amount")
+		}
+		record := sqlc.RecordSavingsContributionParams{
+			ID:                 goal.ID,
+			NextContributionAt: sql.NullTime{Time: nextContribution(goal.NextContributionAt.Time, goal.ContributionInterval.String, now), Valid: true},
+		}
+
+		// Step 2: Lock parent and wallet in ID order, as wallet moves do.
+		wallet, err := q.GetAccount(ctx, walletID)
+		if err != nil {
+			return err
+		}
+		parent, wallet, err := lockAccountPair(ctx, q, wallet.ParentAccountID.UUID, walletID)
+		if err != nil {
+			return err
+		}
+		progress, err := GoalProgress(goal, wallet.Balance)
+		if err != nil {
+			return err
+		}
+		parentBalance, err := decimal.NewFromString(parent.Balance)
+		if err != nil {
+			return errors.New("invalid parent balance")
+		}
+		switch {
+		case progress.Reached:
+			return q.RecordSavingsContribution(ctx, record)
+		case parentBalance.LessThan(amount):
+			record.LastContributionError = fmt.Sprintf("%s: skipped %s contribution", ErrInsufficientFunds, now.Format(GoalDateLayout))
+			return q.RecordSavingsContribution(ctx, record)
+		}
+		// Never overshoot the target.
+		amount = decimal.Min(amount, progress.Remaining)
+
+		// Step 3: Post the move and schedule the next one.
+		txID := uuid.New()
+		entries, balances, err := postLegs(ctx, q, txID, "transfer",
+			debitLeg(parent, amount, fmt.Sprintf("Savings contribution to %s", goal.Name)),
+			creditLeg(wallet, amount, fmt.Sprintf("Savings contribution from %s", parent.Name)),
+		)
+		if err != nil {
+			return err
+		}
+		if err := q.RecordSavingsContribution(ctx, record); err != nil {
+			return err
+		}
+		made = true
+		evt = events.Event{
+			Type:          events.TypeTransfer,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      wallet.Currency,
+			Entries:       entries,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil || !made {
+		return false, err
+	}
+
+	logger(ctx).Info().Str("tx_id", evt.TransactionID.String()).Str("wallet_id", walletID.String()).Str("amount", evt.Amount).Msg("Savings contribution made")
+	s.publish(ctx, evt)
+	return true, nil
+}
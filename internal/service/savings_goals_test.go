@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestGoalProgress(t *testing.T) {
+	// Progress is the wallet balance against the target, capped at 100%.
+	goal := sqlc.SavingsGoal{TargetAmount: "300.0000"}
+
+	p, err := GoalProgress(goal, "100.0000")
+	require.NoError(t, err)
+	assert.Equal(t, "100.0000", p.Saved.StringFixed(4))
+	assert.Equal(t, "200.0000", p.Remaining.StringFixed(4))
+	assert.Equal(t, "33.33", p.Percent.StringFixed(2))
+	assert.False(t, p.Reached)
+
+	p, err = GoalProgress(goal, "450.0000")
+	require.NoError(t, err)
+	assert.True(t, p.Reached)
+	assert.True(t, p.Remaining.IsZero())
+	assert.Equal(t, "100.00", p.Percent.StringFixed(2))
+
+	_, err = GoalProgress(goal, "oops")
+	assert.Error(t, err)
+}
+
+func TestGoalLocked(t *testing.T) {
+	// The wallet unlocks at the start of locked_until in UTC.
+	until := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	goal := sqlc.SavingsGoal{LockedUntil: sql.NullTime{Time: until, Valid: true}}
+	assert.True(t, GoalLocked(goal, until.Add(-time.Second)))
+	assert.False(t, GoalLocked(goal, until))
+	assert.False(t, GoalLocked(sqlc.SavingsGoal{}, until))
+}
+
+func TestNextContribution(t *testing.T) {
+	// The next date follows the interval and skips any backlog after downtime.
+	start := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, start.AddDate(0, 0, 7), nextContribution(start, ContributionWeekly, start))
+	assert.Equal(t, time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC), nextContribution(start, ContributionMonthly, start))
+	assert.Equal(t, start.AddDate(0, 0, 35), nextContribution(start, ContributionWeekly, start.AddDate(0, 0, 30)))
+}
+
+func TestSavingsGoalParams_Validates(t *testing.T) {
+	// Contributions need both a positive amount and a known interval.
+	base := SavingsGoalRequest{WalletID: uuid.New(), Name: "Holiday", TargetAmount: "1000"}
+	params, err := savingsGoalParams(base)
+	require.NoError(t, err)
+	assert.Equal(t, "1000.0000", params.TargetAmount)
+	assert.False(t, params.ContributionAmount.Valid)
+
+	for _, req := range []SavingsGoalRequest{
+		{Name: "", TargetAmount: "1000"},
+		{Name: "Holiday", TargetAmount: "0"},
+		{Name: "Holiday", TargetAmount: "1000", ContributionAmount: "50"},
+		{Name: "Holiday", TargetAmount: "1000", ContributionAmount: "50", ContributionInterval: "daily"},
+		{Name: "Holiday", TargetAmount: "1000", ContributionAmount: "-5", ContributionInterval: ContributionWeekly},
+	} {
+		_, err := savingsGoalParams(req)
+		assert.ErrorIs(t, err, ErrInvalidSavingsGoal)
+	}
+
+	params, err = savingsGoalParams(SavingsGoalRequest{Name: "Holiday", TargetAmount: "1000", ContributionAmount: "50", ContributionInterval: ContributionMonthly})
+	require.NoError(t, err)
+	assert.Equal(t, "50.0000", params.ContributionAmount.String)
+}
+
+func TestCheckGoalLock_SkipsTopLevelAccounts(t *testing.T) {
+	// Only sub-wallets carry goals, so other accounts are never looked up.
+	assert.NoError(t, checkGoalLock(context.Background(), nil, sqlc.Account{ID: uuid.New()}))
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// MaxSettlementShards bounds how many shards the settlement account can be split into.
+const MaxSettlementShards = 64
+
+// EnsureSettlementShards splits the settlement account into n shards, the account itself and
+// n-1 system accounts beside it, creating whichever are missing. Deposits, withdrawals, payouts
+// and inbound credits each lock one shard at random, so they stop queuing on a single row; reads
+// of the settlement balance add the shards up. Shards are never removed, so with n below the
+// number that exist postings keep spreading across all of them.
+func (s *LedgerService) EnsureSettlementShards(ctx context.Context, n int) error {
+	if n < 1 || n > MaxSettlementShards {
+		return fmt.Errorf("settlement shards must be from 1 to %d", MaxSettlementShards)
+	}
+	return s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		settlement, err := q.GetSettlementAccount(ctx)
+		if err != nil {
+			return fmt.Errorf("settlement account not found: %w", err)
+		}
+		created, err := q.EnsureAccountShards(ctx, sqlc.EnsureAccountShardsParams{
+			AccountID:  settlement.ID,
+			ShardCount: int32(n), // #nosec G115 -- bounded by MaxSettlementShards
+		})
+		if err != nil {
+			return err
+		}
+		if created > 0 {
+			logger(ctx).Info().Int64("created", created).Int("shards", n).Msg("Settlement account shards created")
+		}
+		return nil
+	})
+}
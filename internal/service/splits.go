@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// MaxSplitDestinations caps the credits of one split payment.
+const MaxSplitDestinations = 20
+
+var (
+	// ErrInvalidSplit is returned when split shares are missing, repeated or do not add up to the total.
+	ErrInvalidSplit = errors.New("splits must name 1-20 distinct destinations whose amounts add up to the total")
+)
+
+// SplitShare is one destination of a split payment.
+type SplitShare struct {
+	Amount      string
+	Description string
+	AccountID   uuid.UUID
+}
+
+// SplitPayment is the committed result of PaySplit.
+type SplitPayment struct {
+	Total         decimal.Decimal
+	Fee           decimal.Decimal
+	Currency      string
+	Entries       []sqlc.Entry
+	TransactionID uuid.UUID
+}
+
+// parseSplit validates shares against total and returns each share's amount, in order.
+func parseSplit(fromID uuid.UUID, total decimal.Decimal, shares []SplitShare) ([]decimal.Decimal, error) {
+	if len(shares) == 0 || len(shares) > MaxSplitDestinations {
+		return nil, ErrInvalidSplit
+	}
+	amounts := make([]decimal.Decimal, len(shares))
+	seen := make(map[uuid.UUID]bool, len(shares))
+	sum := decimal.Zero
+	for i, share := range shares {
+		if share.AccountID == fromID {
+			return nil, ErrSameAccountTransfer
+		}
+		if seen[share.AccountID] {
+			return nil, ErrInvalidSplit
+		}
+		seen[share.AccountID] = true
+		amount, err := validatePositiveAmount(share.Amount)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = amount
+		sum = sum.Add(amount)
+	}
+	if !sum.Equal(total) {
+		return nil, ErrInvalidSplit
+	}
+	return amounts, nil
+}
+
+// PaySplit debits total from one account and credits each share to its destination under a
+// single transaction, e.g. a marketplace sale settled to the merchant, the platform fee and tax.
+// Every destination must be in the sender's organization and currency. The sender's transfer
+// rules, fee and transfer limit apply to the total, and the total is scored for fraud risk against
+// each destination; the total is screened for AML as it leaves. A split cannot wait for review, so
+// one scored or screened for review is refused.
+func (s *LedgerService) PaySplit(ctx context.Context, fromID uuid.UUID, totalStr, description string, shares []SplitShare) (SplitPayment, error) {
+	// Step 1: Validate the shares before opening expensive DB work.
+	total, err := validatePositiveAmount(totalStr)
+	if err != nil {
+		return SplitPayment{}, err
+	}
+	amounts, err := parseSplit(fromID, total, shares)
+	if err != nil {
+		return SplitPayment{}, err
+	}
+	// A split has several counterparties and no single transfer to release, so it cannot be held.
+	sc, err := s.screen(ctx, "split", fromID, uuid.NullUUID{}, aml.Outbound, total, false)
+	if err != nil {
+		return SplitPayment{}, err
+	}
+	beneficiaries := make([]string, 0, len(shares))
+	for _, share := range shares {
+		beneficiary := transferBeneficiary(share.AccountID)
+		if ctx, err = s.assessRisk(ctx, "transfer", fromID, beneficiary, total, false); err != nil {
+			return SplitPayment{}, err
+		}
+		beneficiaries = append(beneficiaries, beneficiary)
+	}
+
+	var (
+		payment SplitPayment
+		evt     events.Event
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 2: Lock every account in ID order and check the destinations.
+		ids := []uuid.UUID{fromID}
+		for _, share := range shares {
+			ids = append(ids, share.AccountID)
+		}
+		locked, err := lockAccounts(ctx, q, ids...)
+		if err != nil {
+			return err
+		}
+		from := locked[fromID]
+		for _, share := range shares {
+			to := locked[share.AccountID]
+			if to.OrgID != from.OrgID {
+				return ErrCrossOrgTransfer
+			}
+			if to.Currency != from.Currency {
+				return ErrCurrencyMismatch
+			}
+		}
+		fee, err := checkSpendable(ctx, q, from, total, debitTransfer)
+		if err != nil {
+			return err
+		}
+		if err := checkGoalLock(ctx, q, from); err != nil {
+			return err
+		}
+		if err := checkLimit(ctx, q, from, LimitTransfer, total, nil); err != nil {
+			return err
+		}
+
+		// Step 3: One debit, one credit per share, and any fee, under one transaction ID.
+		legs := []leg{debitLeg(from, total, description)}
+		for i, share := range shares {
+			shareDescription := share.Description
+			if shareDescription == "" {
+				shareDescription = fmt.Sprintf("Split payment from %s", fromID)
+			}
+			legs = append(legs, creditLeg(locked[share.AccountID], amounts[i], shareDescription))
+		}
+		fees, err := feeLegs(ctx, q, from, fee, "Transfer fee")
+		if err != nil {
+			return err
+		}
+		txID := uuid.New()
+		postings, balances, err := postLegs(ctx, q, txID, "transfer", append(legs, fees...)...)
+		if err != nil {
+			return err
+		}
+
+		for _, beneficiary := range beneficiaries {
+			if err := s.rememberBeneficiary(ctx, q, fromID, beneficiary); err != nil {
+				return err
+			}
+		}
+
+		payment = SplitPayment{TransactionID: txID, Total: total, Fee: fee, Currency: from.Currency, Entries: postings}
+		evt = events.Event{
+			Type:          events.TypeTransfer,
+			TransactionID: txID,
+			Amount:        total.StringFixed(4),
+			Currency:      from.Currency,
+			Entries:       postings,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return SplitPayment{}, err
+	}
+
+	logger(ctx).Info().
+		Str("tx_id", payment.TransactionID.String()).
+		Str("from_id", fromID.String()).
+		Str("amount", total.StringFixed(4)).
+		Int("destinations", len(shares)).
+		Msg("Split payment completed")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, payment.TransactionID)
+	return payment, nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSplit(t *testing.T) {
+	// Merchant, platform fee and tax shares must add up to the total exactly.
+	from := uuid.New()
+	merchant, platform, tax := uuid.New(), uuid.New(), uuid.New()
+	shares := []SplitShare{
+		{AccountID: merchant, Amount: "90.00"},
+		{AccountID: platform, Amount: "7.5"},
+		{AccountID: tax, Amount: "2.50"},
+	}
+
+	amounts, err := parseSplit(from, decimal.RequireFromString("100"), shares)
+	require.NoError(t, err)
+	assert.Equal(t, "7.5", amounts[1].String())
+
+	_, err = parseSplit(from, decimal.RequireFromString("100.01"), shares)
+	assert.ErrorIs(t, err, ErrInvalidSplit)
+}
+
+func TestParseSplit_RejectsBadShares(t *testing.T) {
+	// Repeated destinations, paying oneself, non-positive shares and empty splits are refused.
+	from, to := uuid.New(), uuid.New()
+	total := decimal.RequireFromString("10")
+
+	_, err := parseSplit(from, total, []SplitShare{{AccountID: to, Amount: "5"}, {AccountID: to, Amount: "5"}})
+	assert.ErrorIs(t, err, ErrInvalidSplit)
+	_, err = parseSplit(from, total, []SplitShare{{AccountID: from, Amount: "10"}})
+	assert.ErrorIs(t, err, ErrSameAccountTransfer)
+	_, err = parseSplit(from, total, []SplitShare{{AccountID: to, Amount: "0"}, {AccountID: uuid.New(), Amount: "10"}})
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = parseSplit(from, total, nil)
+	assert.ErrorIs(t, err, ErrInvalidSplit)
+}
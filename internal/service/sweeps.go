@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// KindSweeps is the background job kind that runs due sweep rules.
+const KindSweeps = "sweeps.run"
+
+// Sweep frequencies stored on sweep_rules. Rules run at midnight UTC.
+const (
+	SweepDaily  = "daily"
+	SweepWeekly = "weekly"
+)
+
+// Outcomes of a sweep run stored on sweep_executions.
+const (
+	SweepSwept   = "swept"
+	SweepSkipped = "skipped"
+	SweepFailed  = "failed"
+)
+
+var (
+	// ErrSweepRuleNotFound is returned when an account has no sweep rule with the given ID.
+	ErrSweepRuleNotFound = errors.New("sweep rule not found")
+	// ErrInvalidSweepRule is returned for a sweep rule with invalid fields or accounts.
+	ErrInvalidSweepRule = errors.New("invalid sweep rule")
+	// ErrSweepNotOwned is recorded when a rule's creator no longer owns both of its accounts.
+	ErrSweepNotOwned = errors.New("rule creator no longer owns both accounts")
+)
+
+// SweepRuleRequest asks for everything AccountID holds above Threshold to move to
+// TargetAccountID at Frequency.
+type SweepRuleRequest struct {
+	AccountID       uuid.UUID
+	TargetAccountID uuid.UUID
+	Threshold       string
+	Frequency       string
+	CreatedBy       uuid.UUID
+}
+
+// firstSweep is the midnight UTC after now, when a new rule first runs.
+func firstSweep(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+// nextSweep is the first run of frequency after prev that is after now, so runs missed during
+// downtime are skipped rather than made in a burst.
+func nextSweep(prev time.Time, frequency string, now time.Time) time.Time {
+	days := 1
+	if frequency == SweepWeekly {
+		days = 7
+	}
+	next := prev
+	for !next.After(now) {
+		next = next.AddDate(0, 0, days)
+	}
+	return next
+}
+
+// sweepAmount is what a balance holds above threshold, at the currency's exponent; zero or
+// negative when there is nothing to sweep.
+func sweepAmount(balance, threshold decimal.Decimal, currency string) decimal.Decimal {
+	return currencies.RoundDown(currency, balance.Sub(threshold))
+}
+
+// CreateSweepRule validates req and schedules its first run for the coming midnight UTC. Both
+// accounts must be customer accounts of one organization and currency.
+func (s *LedgerService) CreateSweepRule(ctx context.Context, req SweepRuleRequest) (sqlc.SweepRule, error) {
+	// Step 1: Validate the rule itself.
+	if req.Frequency != SweepDaily && req.Frequency != SweepWeekly {
+		return sqlc.SweepRule{}, fmt.Errorf("%w: frequency must be daily or weekly", ErrInvalidSweepRule)
+	}
+	threshold, err := decimal.NewFromString(req.Threshold)
+	if err != nil || threshold.IsNegative() {
+		return sqlc.SweepRule{}, fmt.Errorf("%w: threshold must be zero or more", ErrInvalidSweepRule)
+	}
+	if req.AccountID == req.TargetAccountID {
+		return sqlc.SweepRule{}, fmt.Errorf("%w: target must be another account", ErrInvalidSweepRule)
+	}
+
+	// Step 2: Sweeps post like transfers, so the accounts must allow one.
+	accounts := make([]sqlc.Account, 0, 2)
+	for _, id := range []uuid.UUID{req.AccountID, req.TargetAccountID} {
+		acc, err := s.store.GetAccount(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return sqlc.SweepRule{}, ErrAccountNotFound
+			}
+			return sqlc.SweepRule{}, err
+		}
+		accounts = append(accounts, acc)
+	}
+	from, to := accounts[0], accounts[1]
+	switch {
+	case from.IsSystem || to.IsSystem:
+		return sqlc.SweepRule{}, fmt.Errorf("%w: system accounts cannot be swept", ErrInvalidSweepRule)
+	case from.OrgID != to.OrgID:
+		return sqlc.SweepRule{}, ErrCrossOrgTransfer
+	case from.Currency != to.Currency:
+		return sqlc.SweepRule{}, ErrCurrencyMismatch
+	case !currencies.Fits(from.Currency, threshold):
+		return sqlc.SweepRule{}, ErrAmountPrecision
+	}
+
+	// Step 3: Record the rule.
+	rule, err := s.store.CreateSweepRule(ctx, sqlc.CreateSweepRuleParams{
+		AccountID:       req.AccountID,
+		TargetAccountID: req.TargetAccountID,
+		Threshold:       threshold.StringFixed(currencies.MaxExponent),
+		Frequency:       req.Frequency,
+		NextRunAt:       firstSweep(time.Now()),
+		CreatedBy:       req.CreatedBy,
+	})
+	if err != nil {
+		return sqlc.SweepRule{}, err
+	}
+
+	logger(ctx).Info().Str("account_id", req.AccountID.String()).Str("rule_id", rule.ID.String()).Msg("Sweep rule created")
+	return rule, nil
+}
+
+// DeleteSweepRule removes one of accountID's sweep rules and its execution history. Money
+// already swept stays where it went.
+func (s *LedgerService) DeleteSweepRule(ctx context.Context, accountID, ruleID uuid.UUID) error {
+	n, err := s.store.DeleteSweepRule(ctx, sqlc.DeleteSweepRuleParams{ID: ruleID, AccountID: accountID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSweepRuleNotFound
+	}
+	logger(ctx).Info().Str("account_id", accountID.String()).Str("rule_id", ruleID.String()).Msg("Sweep rule deleted")
+	return nil
+}
+
+// RunSweeps is a jobs.HandlerFunc that runs every due sweep rule. A sweep that cannot be made
+// is recorded as failed and tried again at the next run.
+func (s *LedgerService) RunSweeps(ctx context.Context, _ json.RawMessage) error {
+	const batch = 100
+	var (
+		errs  []error
+		swept int
+	)
+	for {
+		due, err := s.store.ListDueSweepRules(ctx, sqlc.ListDueSweepRulesParams{Now: time.Now(), RowLimit: batch})
+		if err != nil {
+			return errors.Join(append(errs, fmt.Errorf("list due sweep rules: %w", err))...)
+		}
+		failed := 0
+		for _, rule := range due {
+			ok, err := s.runSweep(ctx, rule, time.Now().UTC())
+			if err != nil {
+				logger(ctx).Error().Err(err).Str("rule_id", rule.ID.String()).Msg("Failed to run sweep rule")
+				errs = append(errs, err)
+				failed++
+				continue
+			}
+			if ok {
+				swept++
+			}
+		}
+		// Rules that errored stay due; stop rather than fetch them again.
+		if len(due) < batch || failed == len(due) {
+			break
+		}
+	}
+
+	logger(ctx).Info().Int("swept", swept).Int("failed", len(errs)).Msg("Sweep rules run")
+	return errors.Join(errs...)
+}
+
+// sweepRefusals are the reasons a sweep is not made this run, recorded as a failed run rather
+// than failing the job.
+var sweepRefusals = []error{
+	ErrInsufficientFunds, ErrMinimumBalance, ErrDebitLimitExceeded, ErrOperationNotAllowed,
+	ErrSavingsGoalLocked, ErrKYCRequired, ErrKYCLimitExceeded, ErrScreeningBlocked, ErrStepUpRequired,
+	ErrSweepNotOwned,
+}
+
+func sweepRefused(err error) bool {
+	for _, target := range sweepRefusals {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// sweepExcess is what acc holds above a rule's threshold; see sweepAmount.
+func sweepExcess(acc sqlc.Account, threshold string) (decimal.Decimal, error) {
+	balance, err := decimal.NewFromString(acc.Balance)
+	if err != nil {
+		return decimal.Zero, errors.New("invalid balance")
+	}
+	t, err := decimal.NewFromString(threshold)
+	if err != nil {
+		return decimal.Zero, errors.New("invalid sweep threshold")
+	}
+	return sweepAmount(balance, t, acc.Currency), nil
+}
+
+// runSweep makes due's sweep if the rule is still due, records the outcome and schedules the next
+// run. It reports whether money moved. Sweeps are screened and checked like transfers, and the
+// transfer fee comes out of the excess.
+func (s *LedgerService) runSweep(ctx context.Context, due sqlc.SweepRule, now time.Time) (bool, error) {
+	// Step 1: Screen and score the excess as listed. Nobody can review a sweep at midnight, so a
+	// hold blocks it; the sweep then moves no more than was screened.
+	var (
+		sc       screening
+		refusal  error
+		screened decimal.Decimal
+	)
+	beneficiary := transferBeneficiary(due.TargetAccountID)
+	listed, err := s.store.GetAccount(ctx, due.AccountID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	if err == nil {
+		if screened, err = sweepExcess(listed, due.Threshold); err != nil {
+			return false, err
+		}
+	}
+	if screened.IsPositive() {
+		sc, refusal = s.screen(ctx, "sweep", due.AccountID, uuid.NullUUID{UUID: due.TargetAccountID, Valid: true}, aml.Outbound, screened, false)
+		if refusal == nil {
+			ctx, refusal = s.assessRisk(ctx, "sweep", due.AccountID, beneficiary, screened, false)
+		}
+		if refusal != nil && !sweepRefused(refusal) {
+			return false, refusal
+		}
+	}
+
+	var (
+		evt   events.Event
+		swept bool
+	)
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 2: Lock the rule; a concurrent run finds it no longer due.
+		swept = false
+		rule, err := q.GetSweepRuleForUpdate(ctx, due.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if rule.NextRunAt.After(now) {
+			return nil
+		}
+		if err := q.ScheduleSweepRule(ctx, sqlc.ScheduleSweepRuleParams{
+			ID:        rule.ID,
+			NextRunAt: nextSweep(rule.NextRunAt, rule.Frequency, now),
+		}); err != nil {
+			return err
+		}
+		execution := sqlc.CreateSweepExecutionParams{RuleID: rule.ID, Status: SweepFailed, Amount: "0"}
+		fail := func(reason error) error {
+			execution.Reason = reason.Error()
+			_, err := q.CreateSweepExecution(ctx, execution)
+			return err
+		}
+
+		// Step 3: Lock both accounts in ID order, as transfers do, and work out what to move.
+		from, to, err := lockAccountPair(ctx, q, rule.AccountID, rule.TargetAccountID)
+		if err != nil {
+			return err
+		}
+		// The rule moves money on its creator's authority, which must still cover both accounts.
+		for _, acc := range []sqlc.Account{from, to} {
+			owned, err := OwnsAccount(ctx, q, rule.CreatedBy, acc)
+			if err != nil {
+				return err
+			}
+			if !owned {
+				return fail(ErrSweepNotOwned)
+			}
+		}
+		if from.Currency != to.Currency {
+			return fail(ErrCurrencyMismatch)
+		}
+		excess, err := sweepExcess(from, rule.Threshold)
+		if err != nil {
+			return err
+		}
+		amount := decimal.Min(excess, screened)
+		if !amount.IsPositive() {
+			execution.Status = SweepSkipped
+			execution.Reason = "balance at or below threshold"
+			_, err := q.CreateSweepExecution(ctx, execution)
+			return err
+		}
+		if refusal != nil {
+			return fail(refusal)
+		}
+		rules, err := RulesFor(ctx, q, from)
+		if err != nil {
+			return err
+		}
+		amount = currencies.RoundDown(from.Currency, amount.Sub(rules.fee(debitTransfer)))
+		if !amount.IsPositive() {
+			execution.Status = SweepSkipped
+			execution.Reason = "excess does not cover the transfer fee"
+			_, err := q.CreateSweepExecution(ctx, execution)
+			return err
+		}
+
+		// Step 4: Apply the checks a transfer makes; a refusal is recorded, not posted.
+		fee, err := checkSpendable(ctx, q, from, amount, debitTransfer)
+		if err == nil {
+			err = checkGoalLock(ctx, q, from)
+		}
+		if err == nil {
+			err = checkLimit(ctx, q, from, LimitTransfer, amount, nil)
+		}
+		if err != nil {
+			if !sweepRefused(err) {
+				return err
+			}
+			return fail(err)
+		}
+
+		// Step 5: Post the sweep with its fee and record it.
+		txID := uuid.New()
+		legs := []leg{
+			debitLeg(from, amount, fmt.Sprintf("Sweep to %s", to.Name)),
+			creditLeg(to, amount, fmt.Sprintf("Sweep from %s", from.Name)),
+		}
+		fees, err := feeLegs(ctx, q, from, fee, "Transfer fee")
+		if err != nil {
+			return err
+		}
+		entries, balances, err := postLegs(ctx, q, txID, "transfer", append(legs, fees...)...)
+		if err != nil {
+			return err
+		}
+		if err := s.rememberBeneficiary(ctx, q, from.ID, beneficiary); err != nil {
+			return err
+		}
+		execution.Status = SweepSwept
+		execution.Amount = amount.String()
+		execution.TransactionID = uuid.NullUUID{UUID: txID, Valid: true}
+		if _, err := q.CreateSweepExecution(ctx, execution); err != nil {
+			return err
+		}
+		swept = true
+		evt = events.Event{
+			Type:          events.TypeTransfer,
+			TransactionID: txID,
+			Amount:        amount.String(),
+			Currency:      from.Currency,
+			Entries:       entries,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil || !swept {
+		return false, err
+	}
+
+	logger(ctx).Info().Str("tx_id", evt.TransactionID.String()).Str("rule_id", due.ID.String()).Str("amount", evt.Amount).Msg("Sweep made")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, evt.TransactionID)
+	return true, nil
+}
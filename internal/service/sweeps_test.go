@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirstSweep(t *testing.T) {
+	// A new rule first runs at the coming midnight UTC, whatever the caller's zone.
+	lagos := time.FixedZone("WAT", 3600)
+	assert.Equal(t, time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC), firstSweep(time.Date(2026, 5, 1, 23, 30, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), firstSweep(time.Date(2026, 5, 1, 0, 30, 0, 0, lagos)))
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), firstSweep(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestNextSweep(t *testing.T) {
+	// Runs missed during downtime are skipped, not made in a burst.
+	prev := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC), nextSweep(prev, SweepDaily, prev.Add(time.Hour)))
+	assert.Equal(t, time.Date(2026, 5, 8, 0, 0, 0, 0, time.UTC), nextSweep(prev, SweepWeekly, prev.Add(time.Hour)))
+	assert.Equal(t, time.Date(2026, 5, 5, 0, 0, 0, 0, time.UTC), nextSweep(prev, SweepDaily, time.Date(2026, 5, 4, 1, 0, 0, 0, time.UTC)))
+}
+
+func TestSweepAmount(t *testing.T) {
+	// Only what sits above the threshold moves, at the currency's exponent.
+	d := decimal.RequireFromString
+	assert.Equal(t, "25000.5", sweepAmount(d("125000.5000"), d("100000"), "NGN").String())
+	assert.Equal(t, "1250", sweepAmount(d("1250.7500"), d("0"), "JPY").String())
+	assert.False(t, sweepAmount(d("90000"), d("100000"), "NGN").IsPositive())
+	assert.False(t, sweepAmount(d("100000"), d("100000"), "NGN").IsPositive())
+}
+
+func TestSweepRefused(t *testing.T) {
+	// Refusals are recorded as failed runs; anything else fails the job and leaves the rule due.
+	assert.True(t, sweepRefused(ErrMinimumBalance))
+	assert.True(t, sweepRefused(ErrKYCLimitExceeded))
+	assert.True(t, sweepRefused(ErrScreeningBlocked))
+	assert.True(t, sweepRefused(ErrSweepNotOwned))
+	assert.True(t, sweepRefused(fmt.Errorf("%w: until 2027-01-01", ErrSavingsGoalLocked)))
+	assert.False(t, sweepRefused(errors.New("connection reset")))
+}
+
+func TestCreateSweepRule_RejectsInvalidRules(t *testing.T) {
+	// Malformed rules are refused before any account is loaded.
+	s := &LedgerService{}
+	from, to := uuid.New(), uuid.New()
+	for name, req := range map[string]SweepRuleRequest{
+		"frequency": {AccountID: from, TargetAccountID: to, Threshold: "100", Frequency: "hourly"},
+		"threshold": {AccountID: from, TargetAccountID: to, Threshold: "-1", Frequency: SweepDaily},
+		"amount":    {AccountID: from, TargetAccountID: to, Threshold: "lots", Frequency: SweepDaily},
+		"same":      {AccountID: from, TargetAccountID: from, Threshold: "0", Frequency: SweepWeekly},
+	} {
+		_, err := s.CreateSweepRule(context.Background(), req)
+		assert.ErrorIs(t, err, ErrInvalidSweepRule, name)
+	}
+}
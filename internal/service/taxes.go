@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// taxPayableAccount collects withheld taxes until they are remitted. One exists per currency.
+const taxPayableAccount = "Tax Payable"
+
+// TaxableOperations are the operation types tax rules can apply to.
+var TaxableOperations = []string{"interest"}
+
+// ErrInvalidTaxRule is returned for a malformed tax rule, or rules of one operation that would
+// withhold more than the whole credit.
+var ErrInvalidTaxRule = errors.New("invalid tax rule")
+
+// ValidateTaxRule checks a rule's code, operation and rate.
+func ValidateTaxRule(code, operationType string, rateBps int32) error {
+	if !productPattern.MatchString(code) {
+		return fmt.Errorf("%w: code must be 1-32 lowercase letters, digits or underscores, starting with a letter", ErrInvalidTaxRule)
+	}
+	taxable := false
+	for _, op := range TaxableOperations {
+		taxable = taxable || op == operationType
+	}
+	if !taxable {
+		return fmt.Errorf("%w: operation_type must be one of %v", ErrInvalidTaxRule, TaxableOperations)
+	}
+	if rateBps < 1 || rateBps > 10000 {
+		return fmt.Errorf("%w: rate_bps must be between 1 and 10000", ErrInvalidTaxRule)
+	}
+	return nil
+}
+
+// withholding is rateBps of amount, rounded to the decimal places of currency.
+func withholding(amount decimal.Decimal, rateBps int32, currency string) decimal.Decimal {
+	return currencies.Round(currency, amount.Mul(decimal.New(int64(rateBps), -4)))
+}
+
+// appliedTax is one rule's share of a taxed credit.
+type appliedTax struct {
+	rule   sqlc.TaxRule
+	amount decimal.Decimal
+}
+
+// taxLegs withholds every active rule of operationType from a credit of amount to the customer
+// account acc, moving each share to the Tax Payable account of its currency. The legs are posted
+// with the credit, then recordTaxes links them to the transaction.
+func taxLegs(ctx context.Context, q *sqlc.Queries, operationType string, acc sqlc.Account, amount decimal.Decimal) ([]leg, []appliedTax, error) {
+	if acc.IsSystem || !amount.IsPositive() {
+		return nil, nil, nil
+	}
+	rules, err := q.ListActiveTaxRules(ctx, operationType)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		legs    []leg
+		applied []appliedTax
+		total   = decimal.Zero
+		payable sqlc.Account
+	)
+	for _, rule := range rules {
+		tax := withholding(amount, rule.RateBps, acc.Currency)
+		if !tax.IsPositive() {
+			continue
+		}
+		if payable.ID == uuid.Nil {
+			if payable, err = lockSystemAccount(ctx, q, taxPayableAccount, acc.Currency); err != nil {
+				return nil, nil, err
+			}
+		}
+		total = total.Add(tax)
+		legs = append(legs,
+			debitLeg(acc, tax, fmt.Sprintf("%s (%s%%)", rule.Name, decimal.New(int64(rule.RateBps), -2).String())),
+			creditLeg(payable, tax, fmt.Sprintf("%s withheld from %s", rule.Name, acc.ID)),
+		)
+		applied = append(applied, appliedTax{rule: rule, amount: tax})
+	}
+	if total.GreaterThan(amount) {
+		return nil, nil, fmt.Errorf("%w: %s rules withhold more than the credit", ErrInvalidTaxRule, operationType)
+	}
+	return legs, applied, nil
+}
+
+// recordTaxes stores what taxLegs withheld under transaction txID for the tax report.
+func recordTaxes(ctx context.Context, q *sqlc.Queries, txID uuid.UUID, acc sqlc.Account, base decimal.Decimal, applied []appliedTax) error {
+	for _, tax := range applied {
+		if err := q.CreateTaxPosting(ctx, sqlc.CreateTaxPostingParams{
+			TransactionID: txID,
+			RuleCode:      tax.rule.Code,
+			AccountID:     acc.ID,
+			Currency:      acc.Currency,
+			BaseAmount:    base.StringFixed(4),
+			RateBps:       tax.rule.RateBps,
+			Amount:        tax.amount.StringFixed(4),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
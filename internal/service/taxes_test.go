@@ -0,0 +1,26 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithholding(t *testing.T) {
+	// 10% withholding on 33.33 of interest is 3.33, rounded to the currency's decimal places.
+	assert.Equal(t, "3.33", withholding(decimal.RequireFromString("33.33"), 1000, "NGN").StringFixed(2))
+	assert.Equal(t, "0.01", withholding(decimal.RequireFromString("0.05"), 1000, "NGN").StringFixed(2))
+	assert.True(t, withholding(decimal.RequireFromString("0.04"), 1000, "NGN").IsZero())
+	assert.Equal(t, "12.50", withholding(decimal.RequireFromString("12.5"), 10000, "NGN").StringFixed(2))
+	assert.Equal(t, "101", withholding(decimal.RequireFromString("1005"), 1000, "JPY").String())
+}
+
+func TestValidateTaxRule(t *testing.T) {
+	// Rules need a product-style code, a taxable operation and a rate up to 100%.
+	assert.NoError(t, ValidateTaxRule("wht_interest", "interest", 1000))
+	assert.ErrorIs(t, ValidateTaxRule("WHT", "interest", 1000), ErrInvalidTaxRule)
+	assert.ErrorIs(t, ValidateTaxRule("wht", "transfer", 1000), ErrInvalidTaxRule)
+	assert.ErrorIs(t, ValidateTaxRule("wht", "interest", 0), ErrInvalidTaxRule)
+	assert.ErrorIs(t, ValidateTaxRule("wht", "interest", 10001), ErrInvalidTaxRule)
+}
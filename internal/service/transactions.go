@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// ErrInvalidTransactionTransition is returned when a transaction is not in the status a transition expects.
+var ErrInvalidTransactionTransition = errors.New("invalid transaction status transition")
+
+// Transaction statuses stored on the transactions table.
+const (
+	// TransactionPending has entries in a clearing account while an external rail decides.
+	TransactionPending = "pending"
+	// TransactionPosted is final; most postings are created in this status.
+	TransactionPosted = "posted"
+	// TransactionFailed was pending and the rail refused it; a separate reversal returned the funds.
+	TransactionFailed = "failed"
+	// TransactionReversed was posted and later undone by another transaction.
+	TransactionReversed = "reversed"
+)
+
+// transactionTransitions lists the only status changes allowed after creation.
+var transactionTransitions = map[string][]string{
+	TransactionPending: {TransactionPosted, TransactionFailed},
+	TransactionPosted:  {TransactionReversed},
+}
+
+// canTransition reports whether a transaction may move from one status to another.
+func canTransition(from, to string) bool {
+	for _, next := range transactionTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// recordTransaction creates the transaction row that a posting's entries reference, stamped with
+// the ID of the request that caused it and the operation's fraud risk assessment, if scored.
+func recordTransaction(ctx context.Context, q *sqlc.Queries, txID uuid.UUID, p postedEvent) error {
+	requestID := RequestIDFromContext(ctx)
+	params := sqlc.CreateTransactionParams{
+		ID:            txID,
+		OperationType: p.OperationType,
+		Status:        p.Status,
+		RequestID:     sql.NullString{String: requestID, Valid: requestID != ""},
+		RiskDecision:  sql.NullString{String: p.RiskDecision, Valid: p.RiskDecision != ""},
+		RiskFactors:   []string{},
+	}
+	if p.RiskScore != nil {
+		params.RiskScore = sql.NullInt16{Int16: *p.RiskScore, Valid: true}
+	}
+	if len(p.RiskFactors) > 0 {
+		params.RiskFactors = p.RiskFactors
+	}
+	_, err := q.CreateTransaction(ctx, params)
+	return err
+}
+
+// transitionTransaction moves a transaction from one status to the next inside an open transaction,
+// logging the change as a ledger event.
+func transitionTransaction(ctx context.Context, q *sqlc.Queries, txID uuid.UUID, from, to string) error {
+	if !canTransition(from, to) {
+		return ErrInvalidTransactionTransition
+	}
+	if err := appendLedgerEvent(ctx, q, LedgerEventStatusChanged, txID, statusChangedEvent{From: from, To: to}); err != nil {
+		return err
+	}
+	_, err := q.TransitionTransactionStatus(ctx, sqlc.TransitionTransactionStatusParams{
+		ToStatus:   to,
+		ID:         txID,
+		FromStatus: from,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidTransactionTransition
+	}
+	return err
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	// Pending settles or fails, posted can only be reversed, and final statuses never move.
+	assert.True(t, canTransition(TransactionPending, TransactionPosted))
+	assert.True(t, canTransition(TransactionPending, TransactionFailed))
+	assert.True(t, canTransition(TransactionPosted, TransactionReversed))
+	assert.False(t, canTransition(TransactionPending, TransactionReversed))
+	assert.False(t, canTransition(TransactionPosted, TransactionFailed))
+	assert.False(t, canTransition(TransactionFailed, TransactionPosted))
+	assert.False(t, canTransition(TransactionReversed, TransactionPosted))
+}
+
+func TestTransitionTransaction_RejectsDisallowed(t *testing.T) {
+	// Disallowed transitions fail before touching the database.
+	err := transitionTransaction(t.Context(), nil, uuid.Nil, TransactionFailed, TransactionPosted)
+	assert.ErrorIs(t, err, ErrInvalidTransactionTransition)
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// Transfer job statuses stored on the transfer_jobs table.
+const (
+	TransferJobPending = "pending"
+	TransferJobPosted  = "posted"
+	TransferJobFailed  = "failed"
+)
+
+// maxTransferJobAttempts bounds retries of transient failures (e.g., lost connections) before a job fails.
+const maxTransferJobAttempts = 5
+
+// EnqueueTransfer validates a transfer and queues it for the worker pool.
+// The returned job's ID is the transaction ID its entries will be posted under.
+func (s *LedgerService) EnqueueTransfer(ctx context.Context, fromID, toID, requestedBy uuid.UUID, amountStr string) (sqlc.TransferJob, error) {
+	// Step 1: Reject what can never succeed before accepting the job.
+	amount, err := validatePositiveAmount(amountStr)
+	if err != nil {
+		return sqlc.TransferJob{}, err
+	}
+	if fromID == toID {
+		return sqlc.TransferJob{}, ErrSameAccountTransfer
+	}
+	fromAcc, err := s.store.GetAccount(ctx, fromID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.TransferJob{}, ErrAccountNotFound
+		}
+		return sqlc.TransferJob{}, err
+	}
+	toAcc, err := s.store.GetAccount(ctx, toID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.TransferJob{}, ErrAccountNotFound
+		}
+		return sqlc.TransferJob{}, err
+	}
+	if fromAcc.OrgID != toAcc.OrgID {
+		return sqlc.TransferJob{}, ErrCrossOrgTransfer
+	}
+	if fromAcc.Currency != toAcc.Currency {
+		return sqlc.TransferJob{}, ErrCurrencyMismatch
+	}
+	sc, err := s.screen(ctx, "transfer", fromID, uuid.NullUUID{UUID: toID, Valid: true}, aml.Outbound, amount, true)
+	if err != nil {
+		return sqlc.TransferJob{}, err
+	}
+	// The job posts later without the request's context, so its transaction carries no assessment.
+	if _, err := s.assessRisk(ctx, "transfer", fromID, transferBeneficiary(toID), amount, true); err != nil {
+		return sqlc.TransferJob{}, err
+	}
+
+	// Step 2: Persist the job so a restart cannot lose it. Balances are checked by the worker.
+	job, err := s.store.CreateTransferJob(ctx, sqlc.CreateTransferJobParams{
+		ID:            uuid.New(),
+		FromAccountID: fromID,
+		ToAccountID:   toID,
+		Amount:        amount.StringFixed(4),
+		RequestedBy:   requestedBy,
+	})
+	if err != nil {
+		return sqlc.TransferJob{}, err
+	}
+
+	// The job's ID is its transaction ID, so a flag can point at the transfer before it posts.
+	s.recordFlag(ctx, sc, job.ID)
+	logger(ctx).Info().Str("tx_id", job.ID.String()).Str("from_id", fromID.String()).Str("to_id", toID.String()).Str("amount", job.Amount).Msg("Transfer queued")
+	return job, nil
+}
+
+// ProcessNextTransferJob posts the oldest pending transfer job.
+// It reports false when the queue is empty. Business failures fail the job immediately;
+// other errors leave it pending for another attempt until maxTransferJobAttempts.
+func (s *LedgerService) ProcessNextTransferJob(ctx context.Context) (bool, error) {
+	var (
+		job sqlc.TransferJob
+		evt events.Event
+	)
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Claim a job; its row lock is held until the posting commits.
+		var err error
+		job, err = q.ClaimNextTransferJob(ctx)
+		if err != nil {
+			return err
+		}
+		amount, err := decimal.NewFromString(job.Amount)
+		if err != nil {
+			return fmt.Errorf("invalid transfer job amount: %w", err)
+		}
+
+		// Step 2: Post under the job's ID and close the job in the same transaction.
+		evt, err = postTransfer(ctx, q, job.ID, job.FromAccountID, job.ToAccountID, amount, job.Narration)
+		if err != nil {
+			return err
+		}
+		if err := s.rememberBeneficiary(ctx, q, job.FromAccountID, transferBeneficiary(job.ToAccountID)); err != nil {
+			return err
+		}
+		job, err = q.MarkTransferJobPosted(ctx, job.ID)
+		return err
+	})
+	if err != nil {
+		if job.ID == uuid.Nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		// Step 3: Record the failed attempt outside the rolled-back transaction.
+		final, reason := transferJobFailure(err)
+		failed, markErr := s.store.FailTransferJobAttempt(ctx, sqlc.FailTransferJobAttemptParams{
+			Final:         final,
+			MaxAttempts:   maxTransferJobAttempts,
+			FailureReason: reason,
+			ID:            job.ID,
+		})
+		if markErr != nil && !errors.Is(markErr, sql.ErrNoRows) {
+			return true, markErr
+		}
+		logger(ctx).Warn().Err(err).Str("tx_id", job.ID.String()).Str("status", failed.Status).Msg("Queued transfer attempt failed")
+		return true, nil
+	}
+
+	logger(ctx).Info().Str("tx_id", job.ID.String()).Str("from_id", job.FromAccountID.String()).Str("to_id", job.ToAccountID.String()).Msg("Queued transfer posted")
+	s.publish(ctx, evt)
+	return true, nil
+}
+
+// transferJobFailure classifies a posting error: business rule failures are final and safe to show
+// the client; anything else is retried and reported generically.
+func transferJobFailure(err error) (final bool, reason string) {
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, ErrAccountNotFound.Error()
+	}
+	for _, target := range []error{ErrAccountNotFound, ErrInsufficientFunds, ErrMinimumBalance, ErrDebitLimitExceeded, ErrOperationNotAllowed, ErrSavingsGoalLocked, ErrCurrencyMismatch, ErrCrossOrgTransfer, ErrInvalidAmount, ErrSameAccountTransfer} {
+		if errors.Is(err, target) {
+			return true, target.Error()
+		}
+	}
+	return false, "temporary ledger failure"
+}
+
+// transferJobProcessor posts queued transfers. *LedgerService satisfies it.
+type transferJobProcessor interface {
+	ProcessNextTransferJob(ctx context.Context) (bool, error)
+}
+
+// TransferWorkers drains the async transfer queue with a fixed pool of goroutines.
+type TransferWorkers struct {
+	processor transferJobProcessor
+	size      int
+	wake      chan struct{}
+}
+
+// NewTransferWorkers constructs a pool of size workers (at least one) posting jobs through ledger.
+func NewTransferWorkers(ledger *LedgerService, size int) *TransferWorkers {
+	return newTransferWorkers(ledger, size)
+}
+
+func newTransferWorkers(processor transferJobProcessor, size int) *TransferWorkers {
+	if size < 1 {
+		size = 1
+	}
+	return &TransferWorkers{processor: processor, size: size, wake: make(chan struct{}, size)}
+}
+
+// Notify wakes an idle worker after a job is queued. It never blocks the caller.
+func (w *TransferWorkers) Notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run starts the workers and blocks until ctx is cancelled. Workers also poll every interval,
+// so jobs queued before a restart, or whose wake-up was dropped, are still processed.
+func (w *TransferWorkers) Run(ctx context.Context, interval time.Duration) {
+	var wg sync.WaitGroup
+	for i := 0; i < w.size; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx, interval)
+		}()
+	}
+	wg.Wait()
+}
+
+func (w *TransferWorkers) loop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		w.drain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain processes jobs until the queue is empty, an error occurs, or ctx is cancelled.
+func (w *TransferWorkers) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := w.processor.ProcessNextTransferJob(ctx)
+		if err != nil {
+			logger(ctx).Error().Err(err).Msg("Transfer worker failed")
+			return
+		}
+		if !processed {
+			return
+		}
+	}
+}
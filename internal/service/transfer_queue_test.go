@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTransferProcessor struct {
+	pending int
+	calls   int
+	err     error
+}
+
+func (f *fakeTransferProcessor) ProcessNextTransferJob(context.Context) (bool, error) {
+	f.calls++
+	if f.err != nil {
+		return false, f.err
+	}
+	if f.pending == 0 {
+		return false, nil
+	}
+	f.pending--
+	return true, nil
+}
+
+func TestTransferJobFailure(t *testing.T) {
+	// Business rule failures are final and shown as-is; other errors are retried with a generic reason.
+	final, reason := transferJobFailure(fmt.Errorf("post: %w", ErrInsufficientFunds))
+	assert.True(t, final)
+	assert.Equal(t, ErrInsufficientFunds.Error(), reason)
+
+	final, reason = transferJobFailure(sql.ErrNoRows)
+	assert.True(t, final)
+	assert.Equal(t, ErrAccountNotFound.Error(), reason)
+
+	final, reason = transferJobFailure(errors.New("connection reset by peer"))
+	assert.False(t, final)
+	assert.Equal(t, "temporary ledger failure", reason)
+}
+
+func TestTransferWorkers_DrainUntilEmpty(t *testing.T) {
+	// A worker keeps claiming jobs until the queue reports empty.
+	p := &fakeTransferProcessor{pending: 3}
+	newTransferWorkers(p, 2).drain(context.Background())
+	assert.Equal(t, 0, p.pending)
+	assert.Equal(t, 4, p.calls)
+}
+
+func TestTransferWorkers_DrainStopsOnError(t *testing.T) {
+	// Errors end the drain; the next tick or wake-up tries again.
+	p := &fakeTransferProcessor{pending: 3, err: errors.New("db down")}
+	newTransferWorkers(p, 1).drain(context.Background())
+	assert.Equal(t, 1, p.calls)
+}
+
+func TestTransferWorkers_NotifyNeverBlocks(t *testing.T) {
+	// Wake-ups beyond the pool size are dropped instead of blocking the request.
+	w := newTransferWorkers(&fakeTransferProcessor{}, 1)
+	w.Notify()
+	w.Notify()
+	assert.Len(t, w.wake, 1)
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// TreasuryMove is a movement between two customer accounts that a treasury module decides on
+// while LedgerService holds both locked. A zero Amount moves nothing.
+type TreasuryMove struct {
+	From, To    sqlc.Account
+	Amount      decimal.Decimal
+	Description string
+}
+
+// TreasuryPlan chooses the move between the locked accounts a and b.
+type TreasuryPlan func(a, b sqlc.Account) (TreasuryMove, error)
+
+// TreasuryRecord stores what a posted move was for, inside the posting's database transaction.
+type TreasuryRecord func(ctx context.Context, q *sqlc.Queries, txID uuid.UUID, move TreasuryMove) error
+
+// PostTreasuryMove locks aID and bID in ID order, lets plan choose a move between them, posts it
+// as a transfer and lets record store it, all in one database transaction. The accounts must be
+// customer accounts of one organization and currency. Moves are screened, risk-scored and checked
+// against the debited account's product rules and transfer limits like transfers. It reports
+// whether money moved.
+func (s *LedgerService) PostTreasuryMove(ctx context.Context, aID, bID uuid.UUID, plan TreasuryPlan, record TreasuryRecord) (bool, error) {
+	if aID == bID {
+		return false, ErrSameAccountTransfer
+	}
+
+	// Step 1: Plan the move on the balances as they stand, then screen and score it. Moves run
+	// with nobody to wait for a review, so a hold blocks them, and what posts is capped at what
+	// was screened.
+	accounts := make([]sqlc.Account, 0, 2)
+	for _, id := range []uuid.UUID{aID, bID} {
+		acc, err := s.store.GetAccount(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return false, ErrAccountNotFound
+			}
+			return false, err
+		}
+		accounts = append(accounts, acc)
+	}
+	if err := checkTreasuryPair(accounts[0], accounts[1]); err != nil {
+		return false, err
+	}
+	screened, err := plan(accounts[0], accounts[1])
+	if err != nil {
+		return false, err
+	}
+	if !screened.Amount.IsPositive() {
+		return false, nil
+	}
+	sc, err := s.screen(ctx, "cash_pool", screened.From.ID, uuid.NullUUID{UUID: screened.To.ID, Valid: true}, aml.Outbound, screened.Amount, false)
+	if err != nil {
+		return false, err
+	}
+	beneficiary := transferBeneficiary(screened.To.ID)
+	if ctx, err = s.assessRisk(ctx, "cash_pool", screened.From.ID, beneficiary, screened.Amount, false); err != nil {
+		return false, err
+	}
+
+	var evt events.Event
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 2: Lock both accounts in ID order, so moves either way cannot deadlock.
+		evt = events.Event{}
+		a, b, err := lockAccountPair(ctx, q, aID, bID)
+		if err != nil {
+			return err
+		}
+		if err := checkTreasuryPair(a, b); err != nil {
+			return err
+		}
+
+		// Step 3: Let the caller decide what moves now that balances are settled. A move that
+		// changed direction since it was screened waits for the next run.
+		move, err := plan(a, b)
+		if err != nil {
+			return err
+		}
+		if move.Amount.IsZero() {
+			return nil
+		}
+		if move.Amount.IsNegative() {
+			return ErrInvalidAmount
+		}
+		if move.From.ID != screened.From.ID {
+			return nil
+		}
+		move.Amount = decimal.Min(move.Amount, screened.Amount)
+
+		// Step 4: Apply the checks a transfer makes to the debited account.
+		if err := checkTreasuryMove(ctx, q, move); err != nil {
+			return err
+		}
+		if err := checkGoalLock(ctx, q, move.From); err != nil {
+			return err
+		}
+		if err := checkLimit(ctx, q, move.From, LimitTransfer, move.Amount, nil); err != nil {
+			return err
+		}
+
+		// Step 5: Post the move and let the caller record it.
+		txID := uuid.New()
+		entries, balances, err := postLegs(ctx, q, txID, "transfer",
+			debitLeg(move.From, move.Amount, fmt.Sprintf("%s to %s", move.Description, move.To.Name)),
+			creditLeg(move.To, move.Amount, fmt.Sprintf("%s from %s", move.Description, move.From.Name)),
+		)
+		if err != nil {
+			return err
+		}
+		if err := record(ctx, q, txID, move); err != nil {
+			return err
+		}
+		if err := s.rememberBeneficiary(ctx, q, move.From.ID, beneficiary); err != nil {
+			return err
+		}
+		evt = events.Event{
+			Type:          events.TypeTransfer,
+			TransactionID: txID,
+			Amount:        move.Amount.String(),
+			Currency:      move.From.Currency,
+			Entries:       entries,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil || evt.TransactionID == uuid.Nil {
+		return false, err
+	}
+
+	logger(ctx).Info().Str("tx_id", evt.TransactionID.String()).Str("amount", evt.Amount).Msg("Treasury move posted")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, evt.TransactionID)
+	return true, nil
+}
+
+// checkTreasuryPair refuses accounts a treasury move may not run between.
+func checkTreasuryPair(a, b sqlc.Account) error {
+	switch {
+	case a.IsSystem || b.IsSystem:
+		return ErrOperationNotAllowed
+	case a.OrgID != b.OrgID:
+		return ErrCrossOrgTransfer
+	case a.Currency != b.Currency:
+		return ErrCurrencyMismatch
+	}
+	return nil
+}
+
+// checkTreasuryMove applies the debited account's product rules to a positive move: its
+// transfers must be enabled and the move must fit the debit cap, overdraft and minimum balance.
+func checkTreasuryMove(ctx context.Context, q ProductReader, move TreasuryMove) error {
+	if !currencies.Fits(move.From.Currency, move.Amount) {
+		return ErrAmountPrecision
+	}
+	_, err := checkSpendable(ctx, q, move.From, move.Amount, debitTransfer)
+	return err
+}
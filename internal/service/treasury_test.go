@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestCheckTreasuryMove_StopsAtMinimumBalance(t *testing.T) {
+	// A pool sweep may take a savings member down to its NGN 500 minimum, but not below it.
+	member := sqlc.Account{ID: uuid.New(), Balance: "1200.0000", Currency: "NGN", Product: "savings"}
+	sweep := TreasuryMove{From: member, To: sqlc.Account{ID: uuid.New()}, Amount: decimal.RequireFromString("1200"), Description: "Cash pool sweep"}
+	ctx := context.Background()
+
+	assert.ErrorIs(t, checkTreasuryMove(ctx, testCatalog(), sweep), ErrMinimumBalance)
+	sweep.Amount = decimal.RequireFromString("700")
+	assert.NoError(t, checkTreasuryMove(ctx, testCatalog(), sweep))
+	sweep.Amount = decimal.RequireFromString("0.001")
+	assert.ErrorIs(t, checkTreasuryMove(ctx, testCatalog(), sweep), ErrAmountPrecision)
+
+	// Products that do not allow transfers cannot be pooled out of either.
+	sweep.From.Product, sweep.Amount = "escrow", decimal.RequireFromString("1")
+	assert.ErrorIs(t, checkTreasuryMove(ctx, testCatalog(), sweep), ErrOperationNotAllowed)
+}
+
+func TestCheckTreasuryPair(t *testing.T) {
+	org := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	a := sqlc.Account{ID: uuid.New(), OrgID: org, Currency: "NGN"}
+	b := sqlc.Account{ID: uuid.New(), OrgID: org, Currency: "NGN"}
+	assert.NoError(t, checkTreasuryPair(a, b))
+	assert.ErrorIs(t, checkTreasuryPair(a, sqlc.Account{OrgID: org, Currency: "NGN", IsSystem: true}), ErrOperationNotAllowed)
+	assert.ErrorIs(t, checkTreasuryPair(a, sqlc.Account{OrgID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, Currency: "NGN"}), ErrCrossOrgTransfer)
+	assert.ErrorIs(t, checkTreasuryPair(a, sqlc.Account{OrgID: org, Currency: "USD"}), ErrCurrencyMismatch)
+}
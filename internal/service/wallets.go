@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/aml"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// MaxSubWallets caps how many sub-wallets one parent account may hold.
+const MaxSubWallets = 20
+
+var (
+	// ErrSubWalletNesting is returned when a sub-wallet would be created under another sub-wallet or a system account.
+	ErrSubWalletNesting = errors.New("sub-wallets can only be created under a top-level customer account")
+	// ErrSubWalletLimit is returned when a parent already holds MaxSubWallets sub-wallets.
+	ErrSubWalletLimit = errors.New("sub-wallet limit reached")
+	// ErrNotSameWalletGroup is returned when a move involves accounts outside one parent and its sub-wallets.
+	ErrNotSameWalletGroup = errors.New("accounts are not wallets of the same parent")
+)
+
+// walletRoot returns the parent of a sub-wallet, or the account itself for a parent.
+func walletRoot(acc sqlc.Account) uuid.UUID {
+	if acc.ParentAccountID.Valid {
+		return acc.ParentAccountID.UUID
+	}
+	return acc.ID
+}
+
+// CreateSubWallet opens a named sub-wallet under parentID with the parent's owner, organization and currency.
+func (s *LedgerService) CreateSubWallet(ctx context.Context, parentID uuid.UUID, name string) (sqlc.Account, error) {
+	var wallet sqlc.Account
+	err := s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 1: Lock the parent so concurrent creations cannot exceed the cap.
+		parent, err := q.GetAccountForUpdate(ctx, parentID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+		if parent.IsSystem || parent.ParentAccountID.Valid {
+			return ErrSubWalletNesting
+		}
+
+		count, err := q.CountSubWallets(ctx, uuid.NullUUID{UUID: parentID, Valid: true})
+		if err != nil {
+			return err
+		}
+		if count >= MaxSubWallets {
+			return ErrSubWalletLimit
+		}
+
+		// Step 2: The wallet inherits everything that scopes the parent.
+		wallet, err = q.CreateSubWallet(ctx, sqlc.CreateSubWalletParams{
+			OwnerID:         parent.OwnerID,
+			Name:            name,
+			Currency:        parent.Currency,
+			OrgID:           parent.OrgID,
+			ParentAccountID: uuid.NullUUID{UUID: parentID, Valid: true},
+		})
+		return err
+	})
+	if err != nil {
+		return sqlc.Account{}, err
+	}
+
+	logger(ctx).Info().Str("parent_id", parentID.String()).Str("wallet_id", wallet.ID.String()).Msg("Sub-wallet created")
+	return wallet, nil
+}
+
+// MoveBetweenWallets moves money instantly between a parent account and its sub-wallets,
+// or between two sub-wallets of the same parent. The rolled-up parent balance is unchanged.
+func (s *LedgerService) MoveBetweenWallets(ctx context.Context, fromID, toID uuid.UUID, amountStr string) error {
+	// Step 1: Validate amount and reject self-moves immediately.
+	amount, err := validatePositiveAmount(amountStr)
+	if err != nil {
+		return err
+	}
+	if fromID == toID {
+		return ErrSameAccountTransfer
+	}
+	// Moves are instant, so one screened for review is refused rather than held.
+	sc, err := s.screen(ctx, "wallet_move", fromID, uuid.NullUUID{UUID: toID, Valid: true}, aml.Outbound, amount, false)
+	if err != nil {
+		return err
+	}
+
+	var evt events.Event
+	err = s.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		// Step 2: Lock both accounts in ID order so opposite moves cannot deadlock.
+		fromAcc, toAcc, err := lockAccountPair(ctx, q, fromID, toID)
+		if err != nil {
+			return err
+		}
+
+		// Step 3: Only wallets of one parent qualify; they share owner, organization and currency.
+		if fromAcc.IsSystem || toAcc.IsSystem || walletRoot(fromAcc) != walletRoot(toAcc) {
+			return ErrNotSameWalletGroup
+		}
+		fromBalance, err := decimal.NewFromString(fromAcc.Balance)
+		if err != nil {
+			return errors.New("invalid from balance")
+		}
+		if fromBalance.LessThan(amount) {
+			return insufficientFunds("transfer")
+		}
+		if err := checkGoalLock(ctx, q, fromAcc); err != nil {
+			return err
+		}
+
+		// Step 4: Post the move.
+		txID := uuid.New()
+		entries, balances, err := postLegs(ctx, q, txID, "transfer",
+			debitLeg(fromAcc, amount, fmt.Sprintf("Move to wallet %s", toAcc.Name)),
+			creditLeg(toAcc, amount, fmt.Sprintf("Move from wallet %s", fromAcc.Name)),
+		)
+		if err != nil {
+			return err
+		}
+		evt = events.Event{
+			Type:          events.TypeTransfer,
+			TransactionID: txID,
+			Amount:        amount.StringFixed(4),
+			Currency:      fromAcc.Currency,
+			Entries:       entries,
+			Balances:      balances,
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger(ctx).Info().Str("tx_id", evt.TransactionID.String()).Str("from_id", fromID.String()).Str("to_id", toID.String()).Str("amount", evt.Amount).Msg("Wallet move completed")
+	s.publish(ctx, evt)
+	s.recordFlag(ctx, sc, evt.TransactionID)
+	return nil
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestWalletRoot(t *testing.T) {
+	// A parent and each of its sub-wallets resolve to the same group; unrelated accounts do not.
+	parent := sqlc.Account{ID: uuid.New()}
+	pocket := sqlc.Account{ID: uuid.New(), ParentAccountID: uuid.NullUUID{UUID: parent.ID, Valid: true}}
+	sibling := sqlc.Account{ID: uuid.New(), ParentAccountID: uuid.NullUUID{UUID: parent.ID, Valid: true}}
+	other := sqlc.Account{ID: uuid.New()}
+
+	assert.Equal(t, parent.ID, walletRoot(parent))
+	assert.Equal(t, walletRoot(parent), walletRoot(pocket))
+	assert.Equal(t, walletRoot(pocket), walletRoot(sibling))
+	assert.NotEqual(t, walletRoot(parent), walletRoot(other))
+}
@@ -0,0 +1,284 @@
+package statement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// camt053Namespace is the 001.02 schema, the version most treasury systems still ingest.
+const camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+const (
+	isoDateTime = "2006-01-02T15:04:05Z"
+	isoDate     = "2006-01-02"
+)
+
+type camtDocument struct {
+	XMLName xml.Name      `xml:"Document"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	Body    camtBkToCstmr `xml:"BkToCstmrStmt"`
+}
+
+type camtBkToCstmr struct {
+	GrpHdr camtGroupHeader `xml:"GrpHdr"`
+	Stmt   camtStatement   `xml:"Stmt"`
+}
+
+type camtGroupHeader struct {
+	MsgID   string `xml:"MsgId"`
+	CreDtTm string `xml:"CreDtTm"`
+}
+
+type camtStatement struct {
+	ID        string        `xml:"Id"`
+	CreDtTm   string        `xml:"CreDtTm"`
+	FrToDt    camtPeriod    `xml:"FrToDt"`
+	Acct      camtAccount   `xml:"Acct"`
+	Bal       []camtBalance `xml:"Bal"`
+	TxsSummry camtTxSummary `xml:"TxsSummry"`
+	Ntry      []camtEntry   `xml:"Ntry"`
+}
+
+type camtPeriod struct {
+	FrDtTm string `xml:"FrDtTm"`
+	ToDtTm string `xml:"ToDtTm"`
+}
+
+type camtAccount struct {
+	ID   camtAccountID `xml:"Id"`
+	Ccy  string        `xml:"Ccy"`
+	Nm   string        `xml:"Nm,omitempty"`
+	Ownr *camtParty    `xml:"Ownr,omitempty"`
+}
+
+type camtParty struct {
+	Nm      string           `xml:"Nm"`
+	PstlAdr *camtPostalAddrs `xml:"PstlAdr,omitempty"`
+}
+
+// camtPostalAddrs uses the unstructured form: Ctry then up to seven AdrLine.
+type camtPostalAddrs struct {
+	Ctry    string   `xml:"Ctry,omitempty"`
+	AdrLine []string `xml:"AdrLine,omitempty"`
+}
+
+type camtAccountID struct {
+	Othr camtOther `xml:"Othr"`
+}
+
+type camtOther struct {
+	ID string `xml:"Id"`
+}
+
+type camtAmount struct {
+	Ccy   string `xml:"Ccy,attr"`
+	Value string `xml:",chardata"`
+}
+
+type camtBalance struct {
+	Tp        camtBalanceType `xml:"Tp"`
+	Amt       camtAmount      `xml:"Amt"`
+	CdtDbtInd string          `xml:"CdtDbtInd"`
+	Dt        camtDate        `xml:"Dt"`
+}
+
+type camtBalanceType struct {
+	CdOrPrtry camtCode `xml:"CdOrPrtry"`
+}
+
+type camtCode struct {
+	Cd string `xml:"Cd"`
+}
+
+type camtDate struct {
+	Dt string `xml:"Dt"`
+}
+
+type camtTxSummary struct {
+	TtlCdtNtries camtNumberSum `xml:"TtlCdtNtries"`
+	TtlDbtNtries camtNumberSum `xml:"TtlDbtNtries"`
+}
+
+type camtNumberSum struct {
+	NbOfNtries string `xml:"NbOfNtries"`
+	Sum        string `xml:"Sum"`
+}
+
+type camtEntry struct {
+	NtryRef      string           `xml:"NtryRef"`
+	Amt          camtAmount       `xml:"Amt"`
+	CdtDbtInd    string           `xml:"CdtDbtInd"`
+	Sts          string           `xml:"Sts"`
+	BookgDt      camtDateTime     `xml:"BookgDt"`
+	ValDt        camtDateTime     `xml:"ValDt"`
+	AcctSvcrRef  string           `xml:"AcctSvcrRef"`
+	BkTxCd       camtBankTxCode   `xml:"BkTxCd"`
+	NtryDtls     camtEntryDetails `xml:"NtryDtls"`
+	AddtlNtryInf string           `xml:"AddtlNtryInf,omitempty"`
+}
+
+type camtDateTime struct {
+	DtTm string `xml:"DtTm"`
+}
+
+type camtBankTxCode struct {
+	Prtry camtProprietary `xml:"Prtry"`
+}
+
+type camtProprietary struct {
+	Cd string `xml:"Cd"`
+}
+
+type camtEntryDetails struct {
+	TxDtls camtTxDetails `xml:"TxDtls"`
+}
+
+type camtTxDetails struct {
+	Refs camtRefs `xml:"Refs"`
+}
+
+type camtRefs struct {
+	EndToEndID string `xml:"EndToEndId"`
+	TxID       string `xml:"TxId"`
+}
+
+// WriteCAMT053 renders st as a camt.053.001.02 bank-to-customer statement.
+func WriteCAMT053(w io.Writer, st Statement) error {
+	acc := st.Account
+	ccy := acc.Currency
+	created := st.GeneratedAt.UTC().Format(isoDateTime)
+	stmtID := fmt.Sprintf("%s-%s", strings.ReplaceAll(acc.ID.String(), "-", "")[:16], st.From.UTC().Format("20060102"))
+
+	doc := camtDocument{
+		Xmlns: camt053Namespace,
+		Body: camtBkToCstmr{
+			GrpHdr: camtGroupHeader{MsgID: "STMT" + stmtID, CreDtTm: created},
+			Stmt: camtStatement{
+				ID:      stmtID,
+				CreDtTm: created,
+				FrToDt: camtPeriod{
+					FrDtTm: st.From.UTC().Format(isoDateTime),
+					ToDtTm: st.To.UTC().Format(isoDateTime),
+				},
+				Acct: camtAccount{
+					ID:   camtAccountID{Othr: camtOther{ID: acc.ID.String()}},
+					Ccy:  ccy,
+					Nm:   acc.Name,
+					Ownr: ownerParty(st.Owner),
+				},
+				Bal: []camtBalance{
+					balance("OPBD", st.OpeningBalance, ccy, st.From),
+					// Closing date is the last day covered, not the exclusive period end.
+					balance("CLBD", st.ClosingBalance, ccy, st.To.Add(-1)),
+				},
+			},
+		},
+	}
+
+	var credits, debits int
+	for _, e := range st.Entries {
+		credit, debit, err := entryAmounts(e)
+		if err != nil {
+			return err
+		}
+		if credit.IsPositive() {
+			credits++
+		} else {
+			debits++
+		}
+		doc.Body.Stmt.Ntry = append(doc.Body.Stmt.Ntry, entry(e, credit, debit, ccy))
+	}
+	doc.Body.Stmt.TxsSummry = camtTxSummary{
+		TtlCdtNtries: camtNumberSum{NbOfNtries: fmt.Sprint(credits), Sum: isoAmount(st.TotalCredits)},
+		TtlDbtNtries: camtNumberSum{NbOfNtries: fmt.Sprint(debits), Sum: isoAmount(st.TotalDebits)},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode camt.053: %w", err)
+	}
+	return enc.Close()
+}
+
+func balance(code string, amount decimal.Decimal, ccy string, at time.Time) camtBalance {
+	return camtBalance{
+		Tp:        camtBalanceType{CdOrPrtry: camtCode{Cd: code}},
+		Amt:       camtAmount{Ccy: ccy, Value: isoAmount(amount.Abs())},
+		CdtDbtInd: indicator(!amount.IsNegative()),
+		Dt:        camtDate{Dt: at.UTC().Format(isoDate)},
+	}
+}
+
+func entry(e sqlc.Entry, credit, debit decimal.Decimal, ccy string) camtEntry {
+	amount := debit
+	if credit.IsPositive() {
+		amount = credit
+	}
+	booked := e.CreatedAt.Time.UTC().Format(isoDateTime)
+	return camtEntry{
+		NtryRef:      e.ID.String(),
+		Amt:          camtAmount{Ccy: ccy, Value: isoAmount(amount)},
+		CdtDbtInd:    indicator(credit.IsPositive()),
+		Sts:          "BOOK",
+		BookgDt:      camtDateTime{DtTm: booked},
+		ValDt:        camtDateTime{DtTm: booked},
+		AcctSvcrRef:  e.TransactionID.String(),
+		BkTxCd:       camtBankTxCode{Prtry: camtProprietary{Cd: strings.ToUpper(e.OperationType)}},
+		NtryDtls:     camtEntryDetails{TxDtls: camtTxDetails{Refs: camtRefs{EndToEndID: e.TransactionID.String(), TxID: e.ID.String()}}},
+		AddtlNtryInf: e.Description.String,
+	}
+}
+
+func indicator(credit bool) string {
+	if credit {
+		return "CRDT"
+	}
+	return "DBIT"
+}
+
+// isoAmount drops ledger padding (100.0000 -> 100.00) without losing sub-cent precision.
+func isoAmount(d decimal.Decimal) string {
+	if d.Equal(d.Round(2)) {
+		return d.StringFixed(2)
+	}
+	return d.String()
+}
+
+// ownerParty renders the account holder, trimming to the schema's 70-character
+// lines and seven address lines.
+func ownerParty(p *Party) *camtParty {
+	if p == nil || p.Name == "" {
+		return nil
+	}
+	party := &camtParty{Nm: truncate(p.Name, 140)}
+	var lines []string
+	for _, l := range p.AddressLines {
+		if l = strings.TrimSpace(l); l != "" && len(lines) < 7 {
+			lines = append(lines, truncate(l, 70))
+		}
+	}
+	if len(lines) > 0 || p.Country != "" {
+		party.PstlAdr = &camtPostalAddrs{Ctry: p.Country, AdrLine: lines}
+	}
+	return party
+}
+
+// truncate limits s to n characters without splitting a multi-byte rune.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
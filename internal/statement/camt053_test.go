@@ -0,0 +1,60 @@
+package statement
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestWriteCAMT053(t *testing.T) {
+	// Daily statement renders balances, entries and CRDT/DBIT indicators.
+	from, to := DayBounds(time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC))
+	acc := sqlc.Account{ID: uuid.New(), Name: "Treasury", Currency: "USD"}
+	booked := sql.NullTime{Time: from.Add(2 * time.Hour), Valid: true}
+	st := Statement{
+		Account:        acc,
+		From:           from,
+		To:             to,
+		GeneratedAt:    to,
+		OpeningBalance: decimal.RequireFromString("100"),
+		ClosingBalance: decimal.RequireFromString("-20.5"),
+		TotalCredits:   decimal.RequireFromString("10"),
+		TotalDebits:    decimal.RequireFromString("130.5"),
+		Entries: []sqlc.Entry{
+			{ID: uuid.New(), TransactionID: uuid.New(), Credit: "10.0000", Debit: "0.0000", OperationType: "deposit", CreatedAt: booked},
+			{ID: uuid.New(), TransactionID: uuid.New(), Credit: "0.0000", Debit: "130.5000", OperationType: "transfer", CreatedAt: booked},
+		},
+	}
+
+	st.Owner = &Party{Name: "Ada Obi", AddressLines: []string{"12 Marina", "", "Lagos"}, Country: "NG"}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCAMT053(&buf, st))
+	out := buf.String()
+
+	assert.Contains(t, out, "<Nm>Ada Obi</Nm>")
+	assert.Contains(t, out, "<Ctry>NG</Ctry>")
+	assert.Equal(t, 2, strings.Count(out, "<AdrLine>"))
+	assert.Contains(t, out, `xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"`)
+	assert.Contains(t, out, "<Cd>OPBD</Cd>")
+	assert.Contains(t, out, `<Amt Ccy="USD">20.50</Amt>`)
+	assert.Contains(t, out, "<Dt>2026-03-14</Dt>")
+	assert.Contains(t, out, "<CdtDbtInd>DBIT</CdtDbtInd>")
+	assert.Contains(t, out, `<Amt Ccy="USD">130.50</Amt>`)
+	assert.Contains(t, out, "<Cd>DEPOSIT</Cd>")
+}
+
+func TestIsoAmount(t *testing.T) {
+	// Ledger padding is trimmed to cents unless sub-cent precision exists.
+	assert.Equal(t, "100.00", isoAmount(decimal.RequireFromString("100.0000")))
+	assert.Equal(t, "0.0125", isoAmount(decimal.RequireFromString("0.0125")))
+}
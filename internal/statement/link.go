@@ -0,0 +1,30 @@
+package statement
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PeriodLayout formats monthly statement periods in download links, e.g. 2026-09.
+const PeriodLayout = "2006-01"
+
+// LinkSignature authenticates a download link for accountID's statement of the month
+// starting at period, valid until the Unix time expires.
+func LinkSignature(secret []byte, accountID uuid.UUID, period time.Time, expires int64) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s|%s|%d", accountID, period.UTC().Format(PeriodLayout), expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyLink reports whether sig is valid for the link parameters and the link has not expired.
+func VerifyLink(secret []byte, accountID uuid.UUID, period time.Time, expires int64, sig string, now time.Time) bool {
+	if len(secret) == 0 || now.Unix() > expires {
+		return false
+	}
+	return hmac.Equal([]byte(sig), []byte(LinkSignature(secret, accountID, period, expires)))
+}
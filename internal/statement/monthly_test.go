@@ -0,0 +1,66 @@
+package statement
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestMonthBounds(t *testing.T) {
+	// Bounds cover the whole UTC month, including across year ends.
+	from, to := MonthBounds(time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), to)
+}
+
+func TestVerifyLink(t *testing.T) {
+	// Links verify only with the right secret, parameters and before expiry.
+	secret := []byte("secret")
+	accountID := uuid.New()
+	period := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	expires := now.Add(time.Hour).Unix()
+	sig := LinkSignature(secret, accountID, period, expires)
+
+	assert.True(t, VerifyLink(secret, accountID, period, expires, sig, now))
+	assert.False(t, VerifyLink(secret, accountID, period, expires, sig, now.Add(2*time.Hour)))
+	assert.False(t, VerifyLink(secret, accountID, period.AddDate(0, 1, 0), expires, sig, now))
+	assert.False(t, VerifyLink(secret, uuid.New(), period, expires, sig, now))
+	assert.False(t, VerifyLink(secret, accountID, period, expires+1, sig, now))
+	assert.False(t, VerifyLink(nil, accountID, period, expires, LinkSignature(nil, accountID, period, expires), now))
+}
+
+func TestWriteText(t *testing.T) {
+	// The text statement shows a running balance between opening and closing lines.
+	from, to := MonthBounds(time.Date(2026, 9, 10, 0, 0, 0, 0, time.UTC))
+	st := Statement{
+		Account:        sqlc.Account{ID: uuid.New(), Name: "Main", Currency: "USD"},
+		From:           from,
+		To:             to,
+		OpeningBalance: decimal.RequireFromString("100"),
+		ClosingBalance: decimal.RequireFromString("115"),
+		TotalCredits:   decimal.RequireFromString("25"),
+		TotalDebits:    decimal.RequireFromString("10"),
+		Entries: []sqlc.Entry{
+			{Credit: "25.0000", Debit: "0.0000", OperationType: "deposit", CreatedAt: sql.NullTime{Time: from.Add(time.Hour), Valid: true}},
+			{Credit: "0.0000", Debit: "10.0000", OperationType: "withdrawal", CreatedAt: sql.NullTime{Time: from.Add(48 * time.Hour), Valid: true}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteText(&buf, st))
+	out := buf.String()
+	assert.Contains(t, out, "Period: 2026-09-01 to 2026-09-30 (UTC)")
+	assert.Regexp(t, `deposit\s+25\.00\s+125\.00`, out)
+	assert.Regexp(t, `withdrawal\s+10\.00\s+115\.00`, out)
+	assert.Regexp(t, `Closing balance\s+10\.00\s+25\.00\s+115\.00`, out)
+	assert.Contains(t, out, "All amounts in USD. 2 entries.")
+}
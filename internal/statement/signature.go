@@ -0,0 +1,84 @@
+package statement
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jws"
+)
+
+// A signed statement is the rendered document followed by a processing instruction holding a
+// detached compact JWS (RFC 7515 appendix F) over every byte before it. XML readers skip
+// processing instructions, so a signed camt.053 still parses as before, and the file carries its
+// own signature wherever it is forwarded.
+const (
+	signatureStart = "\n<?statement-signature jws=\""
+	signatureClose = "\"?>"
+)
+
+var (
+	// ErrUnsigned is returned by VerifySigned for a document that carries no statement signature.
+	ErrUnsigned = errors.New("statement is not signed")
+	// ErrBadSignature is returned by VerifySigned when the signature does not match the document
+	// under any published key, as when the statement was edited after it was signed.
+	ErrBadSignature = errors.New("statement signature does not match")
+)
+
+// Sign appends a signature by key, a private JWK carrying its kid and alg, to doc.
+func Sign(doc []byte, key jwk.Key) ([]byte, error) {
+	alg, ok := key.Algorithm()
+	if !ok {
+		return nil, errors.New("statement signing key has no algorithm")
+	}
+	sigAlg, ok := alg.(jwa.SignatureAlgorithm)
+	if !ok {
+		return nil, fmt.Errorf("statement signing key algorithm %s cannot sign", alg)
+	}
+	hdrs := jws.NewHeaders()
+	if kid, ok := key.KeyID(); ok {
+		if err := hdrs.Set(jws.KeyIDKey, kid); err != nil {
+			return nil, err
+		}
+	}
+	if err := hdrs.Set(jws.ContentTypeKey, "application/xml"); err != nil {
+		return nil, err
+	}
+	sig, err := jws.Sign(nil, jws.WithKey(sigAlg, key, jws.WithProtectedHeaders(hdrs)), jws.WithDetachedPayload(doc))
+	if err != nil {
+		return nil, err
+	}
+
+	signed := make([]byte, 0, len(doc)+len(signatureStart)+len(sig)+len(signatureClose)+1)
+	signed = append(signed, doc...)
+	signed = append(signed, signatureStart...)
+	signed = append(signed, sig...)
+	signed = append(signed, signatureClose...)
+	return append(signed, '\n'), nil
+}
+
+// VerifySigned checks the signature a signed statement ends with against keys, the published
+// statement keys, and returns the signed document and the ID of the key that signed it.
+func VerifySigned(signed []byte, keys jwk.Set) ([]byte, string, error) {
+	i := bytes.LastIndex(signed, []byte(signatureStart))
+	if i < 0 {
+		return nil, "", ErrUnsigned
+	}
+	doc := signed[:i]
+	sig, ok := bytes.CutSuffix(bytes.TrimRight(signed[i+len(signatureStart):], "\r\n\t "), []byte(signatureClose))
+	if !ok || len(sig) == 0 {
+		return nil, "", ErrUnsigned
+	}
+
+	msg, err := jws.Parse(sig)
+	if err != nil || len(msg.Signatures()) != 1 {
+		return nil, "", ErrBadSignature
+	}
+	kid, _ := msg.Signatures()[0].ProtectedHeaders().KeyID()
+	if _, err := jws.Verify(sig, jws.WithKeySet(keys), jws.WithDetachedPayload(doc)); err != nil {
+		return nil, kid, ErrBadSignature
+	}
+	return doc, kid, nil
+}
@@ -0,0 +1,58 @@
+package statement
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/xml"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStatementKey(t *testing.T) (jwk.Key, jwk.Set) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := jwk.Import(priv)
+	require.NoError(t, err)
+	require.NoError(t, key.Set(jwk.KeyIDKey, "statement-key"))
+	require.NoError(t, key.Set(jwk.AlgorithmKey, jwa.EdDSA()))
+	pub, err := key.PublicKey()
+	require.NoError(t, err)
+	set := jwk.NewSet()
+	require.NoError(t, set.AddKey(pub))
+	return key, set
+}
+
+func TestSignVerifySigned(t *testing.T) {
+	// A signed statement still parses as XML, verifies under the published key and fails once edited.
+	key, keys := testStatementKey(t)
+	doc := []byte(`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<Document><Amt>10.00</Amt></Document>` + "\n")
+	signed, err := Sign(doc, key)
+	require.NoError(t, err)
+
+	var parsed struct {
+		Amt string `xml:"Amt"`
+	}
+	require.NoError(t, xml.Unmarshal(signed, &parsed))
+	assert.Equal(t, "10.00", parsed.Amt)
+
+	got, kid, err := VerifySigned(signed, keys)
+	require.NoError(t, err)
+	assert.Equal(t, doc, got)
+	assert.Equal(t, "statement-key", kid)
+
+	_, _, err = VerifySigned(bytes.Replace(signed, []byte("10.00"), []byte("90.00"), 1), keys)
+	assert.ErrorIs(t, err, ErrBadSignature)
+
+	_, otherKeys := testStatementKey(t)
+	_, _, err = VerifySigned(signed, otherKeys)
+	assert.ErrorIs(t, err, ErrBadSignature)
+
+	_, _, err = VerifySigned(doc, keys)
+	assert.ErrorIs(t, err, ErrUnsigned)
+}
@@ -0,0 +1,123 @@
+// Package statement builds account statements and renders them for external consumers.
+package statement
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// Source is the read access a statement needs. *db.Store satisfies it.
+type Source interface {
+	GetAccount(ctx context.Context, id uuid.UUID) (sqlc.Account, error)
+	GetAccountBalanceBefore(ctx context.Context, arg sqlc.GetAccountBalanceBeforeParams) (string, error)
+	ListEntriesByAccountBetween(ctx context.Context, arg sqlc.ListEntriesByAccountBetweenParams) ([]sqlc.Entry, error)
+}
+
+// Statement is an account's booked activity over [From, To) with bracketing balances.
+type Statement struct {
+	From           time.Time
+	To             time.Time
+	GeneratedAt    time.Time
+	OpeningBalance decimal.Decimal
+	ClosingBalance decimal.Decimal
+	TotalCredits   decimal.Decimal
+	TotalDebits    decimal.Decimal
+	Account        sqlc.Account
+	Entries        []sqlc.Entry
+	// Owner identifies the account holder on the statement; nil for system accounts.
+	Owner *Party
+}
+
+// Party is an account holder's name and postal address.
+type Party struct {
+	Name         string
+	AddressLines []string
+	Country      string
+}
+
+// Build loads the account and its entries for the half-open period [from, to).
+// Balances are derived from entries, never from the cached account balance.
+func Build(ctx context.Context, src Source, accountID uuid.UUID, from, to time.Time) (Statement, error) {
+	if !to.After(from) {
+		return Statement{}, errors.New("statement period end must be after start")
+	}
+
+	acc, err := src.GetAccount(ctx, accountID)
+	if err != nil {
+		return Statement{}, fmt.Errorf("load account: %w", err)
+	}
+
+	openingStr, err := src.GetAccountBalanceBefore(ctx, sqlc.GetAccountBalanceBeforeParams{
+		AccountID:  accountID,
+		BeforeTime: sql.NullTime{Time: from, Valid: true},
+	})
+	if err != nil {
+		return Statement{}, fmt.Errorf("compute opening balance: %w", err)
+	}
+	opening, err := decimal.NewFromString(openingStr)
+	if err != nil {
+		return Statement{}, fmt.Errorf("invalid opening balance: %w", err)
+	}
+
+	entries, err := src.ListEntriesByAccountBetween(ctx, sqlc.ListEntriesByAccountBetweenParams{
+		AccountID: accountID,
+		FromTime:  sql.NullTime{Time: from, Valid: true},
+		ToTime:    sql.NullTime{Time: to, Valid: true},
+	})
+	if err != nil {
+		return Statement{}, fmt.Errorf("load entries: %w", err)
+	}
+
+	st := Statement{
+		Account:        acc,
+		From:           from,
+		To:             to,
+		GeneratedAt:    time.Now().UTC(),
+		OpeningBalance: opening,
+		Entries:        entries,
+	}
+	for _, e := range entries {
+		credit, debit, err := entryAmounts(e)
+		if err != nil {
+			return Statement{}, err
+		}
+		st.TotalCredits = st.TotalCredits.Add(credit)
+		st.TotalDebits = st.TotalDebits.Add(debit)
+	}
+	st.ClosingBalance = opening.Add(st.TotalCredits).Sub(st.TotalDebits)
+	return st, nil
+}
+
+// DayBounds returns the UTC [start, end) bounds of the calendar day containing t.
+func DayBounds(t time.Time) (time.Time, time.Time) {
+	y, m, d := t.UTC().Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// MonthBounds returns the UTC [start, end) bounds of the calendar month containing t.
+func MonthBounds(t time.Time) (time.Time, time.Time) {
+	y, m, _ := t.UTC().Date()
+	start := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+func entryAmounts(e sqlc.Entry) (decimal.Decimal, decimal.Decimal, error) {
+	credit, err := decimal.NewFromString(e.Credit)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("invalid credit on entry %s: %w", e.ID, err)
+	}
+	debit, err := decimal.NewFromString(e.Debit)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("invalid debit on entry %s: %w", e.ID, err)
+	}
+	return credit, debit, nil
+}
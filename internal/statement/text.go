@@ -0,0 +1,56 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+)
+
+// WriteText renders st as a fixed-width plain-text statement suitable for an email body.
+func WriteText(w io.Writer, st Statement) error {
+	ccy := st.Account.Currency
+	last := st.To.AddDate(0, 0, -1)
+	header := fmt.Sprintf("Statement for %q (%s)\nPeriod: %s to %s (UTC)\n\n",
+		st.Account.Name, st.Account.ID, st.From.Format("2006-01-02"), last.Format("2006-01-02"))
+	if st.Owner != nil {
+		header = fmt.Sprintf("Account holder: %s\n", st.Owner.Name) + header
+	}
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "Date\tDescription\tDebit\tCredit\tBalance\t\n")
+	fmt.Fprintf(tw, "%s\t%s\t\t\t%s\t\n", st.From.Format("2006-01-02"), "Opening balance", currencies.Format(ccy, st.OpeningBalance))
+	running := st.OpeningBalance
+	for _, e := range st.Entries {
+		credit, debit, err := entryAmounts(e)
+		if err != nil {
+			return err
+		}
+		running = running.Add(credit).Sub(debit)
+		desc := e.OperationType
+		if e.Description.Valid && e.Description.String != "" {
+			desc = truncate(e.Description.String, 40)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n", e.CreatedAt.Time.UTC().Format("2006-01-02"), desc,
+			textAmount(debit.IsZero(), currencies.Format(ccy, debit)), textAmount(credit.IsZero(), currencies.Format(ccy, credit)), currencies.Format(ccy, running))
+	}
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n", last.Format("2006-01-02"), "Closing balance",
+		currencies.Format(ccy, st.TotalDebits), currencies.Format(ccy, st.TotalCredits), currencies.Format(ccy, st.ClosingBalance))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\nAll amounts in %s. %d entries.\n", ccy, len(st.Entries))
+	return err
+}
+
+func textAmount(zero bool, v string) string {
+	if zero {
+		return ""
+	}
+	return v
+}
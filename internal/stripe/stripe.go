@@ -0,0 +1,231 @@
+// Package stripe is a minimal client for Stripe PaymentIntents and webhook signatures.
+package stripe
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// SignatureHeader carries "t=<unix>,v1=<hex hmac>" for each webhook delivery.
+const SignatureHeader = "Stripe-Signature"
+
+// EventPaymentIntentSucceeded is sent once a PaymentIntent has captured funds.
+const EventPaymentIntentSucceeded = "payment_intent.succeeded"
+
+// DefaultTolerance bounds how old a signed webhook may be, limiting replay of captured requests.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	// ErrInvalidSignature is returned when no v1 signature matches the payload.
+	ErrInvalidSignature = errors.New("invalid stripe signature")
+	// ErrSignatureExpired is returned when the signed timestamp is outside the tolerance.
+	ErrSignatureExpired = errors.New("stripe signature timestamp outside tolerance")
+	// ErrMinorUnitPrecision is returned for amounts finer than the currency's minor unit.
+	ErrMinorUnitPrecision = errors.New("amount is more precise than the currency allows")
+)
+
+// zeroDecimal lists currencies Stripe charges in whole units.
+var zeroDecimal = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "JPY": true, "KMF": true, "KRW": true, "MGA": true,
+	"PYG": true, "RWF": true, "UGX": true, "VND": true, "VUV": true, "XAF": true, "XOF": true, "XPF": true,
+}
+
+// Client calls the Stripe API with a secret key and verifies webhooks with the endpoint secret.
+type Client struct {
+	httpClient    *http.Client
+	secretKey     string
+	webhookSecret string
+	baseURL       string
+	now           func() time.Time
+}
+
+// NewClient constructs a Client. webhookSecret is the endpoint's whsec_... signing secret.
+func NewClient(secretKey, webhookSecret string) (*Client, error) {
+	if secretKey == "" || webhookSecret == "" {
+		return nil, errors.New("stripe secret key and webhook signing secret are required")
+	}
+	return &Client{
+		httpClient:    &http.Client{Timeout: 15 * time.Second},
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		baseURL:       "https://api.stripe.com",
+		now:           time.Now,
+	}, nil
+}
+
+// PaymentIntentRequest describes a card payment. Amount is in the currency's minor unit.
+type PaymentIntentRequest struct {
+	Metadata    map[string]string
+	Currency    string
+	Description string
+	// IdempotencyKey makes retried creates return the same PaymentIntent.
+	IdempotencyKey string
+	Amount         int64
+}
+
+// PaymentIntent is the subset of Stripe's PaymentIntent the ledger uses.
+type PaymentIntent struct {
+	Metadata       map[string]string `json:"metadata"`
+	ID             string            `json:"id"`
+	ClientSecret   string            `json:"client_secret"`
+	Currency       string            `json:"currency"`
+	Status         string            `json:"status"`
+	Amount         int64             `json:"amount"`
+	AmountReceived int64             `json:"amount_received"`
+}
+
+// CreatePaymentIntent creates a PaymentIntent confirmed client-side with its client secret.
+func (c *Client) CreatePaymentIntent(ctx context.Context, in PaymentIntentRequest) (PaymentIntent, error) {
+	form := url.Values{
+		"amount":                             {strconv.FormatInt(in.Amount, 10)},
+		"currency":                           {strings.ToLower(in.Currency)},
+		"automatic_payment_methods[enabled]": {"true"},
+	}
+	if in.Description != "" {
+		form.Set("description", in.Description)
+	}
+	for k, v := range in.Metadata {
+		form.Set("metadata["+k+"]", v)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/payment_intents", strings.NewReader(form.Encode()))
+	if err != nil {
+		return PaymentIntent{}, err
+	}
+	req.SetBasicAuth(c.secretKey, "")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if in.IdempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", in.IdempotencyKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return PaymentIntent{}, fmt.Errorf("stripe request failed: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Warn().Err(closeErr).Msg("Failed to close stripe response body")
+		}
+	}()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return PaymentIntent{}, fmt.Errorf("read stripe response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(raw, &apiErr)
+		return PaymentIntent{}, fmt.Errorf("stripe returned %d: %s", resp.StatusCode, apiErr.Error.Message)
+	}
+	var pi PaymentIntent
+	if err := json.Unmarshal(raw, &pi); err != nil {
+		return PaymentIntent{}, fmt.Errorf("decode stripe payment intent: %w", err)
+	}
+	return pi, nil
+}
+
+// VerifySignature checks a Stripe-Signature header against payload and the tolerance window.
+func (c *Client) VerifySignature(payload []byte, header string, tolerance time.Duration) error {
+	var (
+		timestamp  int64
+		signatures [][]byte
+	)
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			ts, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return ErrInvalidSignature
+			}
+			timestamp = ts
+		case "v1":
+			if sig, err := hex.DecodeString(value); err == nil {
+				signatures = append(signatures, sig)
+			}
+		}
+	}
+	if timestamp == 0 || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(c.webhookSecret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	matched := false
+	for _, sig := range signatures {
+		if hmac.Equal(expected, sig) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return ErrInvalidSignature
+	}
+	if age := c.now().Sub(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+// Event is a Stripe webhook event carrying a PaymentIntent.
+type Event struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object PaymentIntent `json:"object"`
+	} `json:"data"`
+}
+
+// ParseEvent decodes a webhook body. Call VerifySignature first.
+func ParseEvent(payload []byte) (Event, error) {
+	var evt Event
+	if err := json.Unmarshal(payload, &evt); err != nil {
+		return Event{}, fmt.Errorf("decode stripe event: %w", err)
+	}
+	return evt, nil
+}
+
+// ToMinorUnits converts a major-unit amount to Stripe's integer minor units for currency.
+func ToMinorUnits(amount decimal.Decimal, currency string) (int64, error) {
+	exp := int32(2)
+	if zeroDecimal[strings.ToUpper(currency)] {
+		exp = 0
+	}
+	minor := amount.Shift(exp)
+	if !minor.Equal(minor.Truncate(0)) {
+		return 0, ErrMinorUnitPrecision
+	}
+	return minor.IntPart(), nil
+}
+
+// FromMinorUnits converts Stripe's integer minor units back to a major-unit amount.
+func FromMinorUnits(amount int64, currency string) decimal.Decimal {
+	if zeroDecimal[strings.ToUpper(currency)] {
+		return decimal.NewFromInt(amount)
+	}
+	return decimal.New(amount, -2)
+}
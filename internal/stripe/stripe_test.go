@@ -0,0 +1,85 @@
+package stripe
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signedHeader(secret string, ts int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", ts)
+	mac.Write(payload)
+	return fmt.Sprintf("t=%d,v1=%s", ts, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestVerifySignature(t *testing.T) {
+	// Signatures must match the endpoint secret and be fresh.
+	c, err := NewClient("sk_test_x", "whsec_test")
+	require.NoError(t, err)
+	now := time.Unix(1_800_000_000, 0)
+	c.now = func() time.Time { return now }
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded"}`)
+
+	assert.NoError(t, c.VerifySignature(payload, signedHeader("whsec_test", now.Unix(), payload), DefaultTolerance))
+	assert.ErrorIs(t, c.VerifySignature(payload, signedHeader("whsec_other", now.Unix(), payload), DefaultTolerance), ErrInvalidSignature)
+	assert.ErrorIs(t, c.VerifySignature(payload, signedHeader("whsec_test", now.Add(-10*time.Minute).Unix(), payload), DefaultTolerance), ErrSignatureExpired)
+	assert.ErrorIs(t, c.VerifySignature(payload, "garbage", DefaultTolerance), ErrInvalidSignature)
+
+	// Secret rotation: any matching v1 entry is accepted.
+	rotated := signedHeader("whsec_test", now.Unix(), payload) + ",v1=00ff"
+	assert.NoError(t, c.VerifySignature(payload, rotated, DefaultTolerance))
+}
+
+func TestMinorUnits(t *testing.T) {
+	// Two-decimal and zero-decimal currencies convert exactly.
+	n, err := ToMinorUnits(decimal.RequireFromString("12.34"), "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), n)
+
+	n, err = ToMinorUnits(decimal.RequireFromString("500"), "JPY")
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), n)
+
+	_, err = ToMinorUnits(decimal.RequireFromString("1.5"), "JPY")
+	assert.ErrorIs(t, err, ErrMinorUnitPrecision)
+
+	assert.True(t, FromMinorUnits(1234, "usd").Equal(decimal.RequireFromString("12.34")))
+}
+
+func TestCreatePaymentIntent(t *testing.T) {
+	// Requests are form-encoded with metadata and an idempotency key.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "/v1/payment_intents", r.URL.Path)
+		assert.Equal(t, "1234", r.Form.Get("amount"))
+		assert.Equal(t, "usd", r.Form.Get("currency"))
+		assert.Equal(t, "dep_1", r.Form.Get("metadata[reference]"))
+		assert.Equal(t, "dep_1", r.Header.Get("Idempotency-Key"))
+		user, _, _ := r.BasicAuth()
+		assert.Equal(t, "sk_test_x", user)
+		_, _ = w.Write([]byte(`{"id":"pi_1","client_secret":"pi_1_secret_abc","status":"requires_payment_method","amount":1234,"currency":"usd"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient("sk_test_x", "whsec_test")
+	require.NoError(t, err)
+	c.baseURL = srv.URL
+
+	pi, err := c.CreatePaymentIntent(context.Background(), PaymentIntentRequest{
+		Amount: 1234, Currency: "USD", IdempotencyKey: "dep_1", Metadata: map[string]string{"reference": "dep_1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pi_1", pi.ID)
+	assert.Equal(t, "pi_1_secret_abc", pi.ClientSecret)
+}
@@ -0,0 +1,421 @@
+// Package treasury runs cash pools for business customers: member accounts are zeroed into a
+// master account at end of day and funded from it on demand. The pooling relationships and the
+// movements they cause are kept here; postings go through LedgerService, so they take the same
+// locks and checks as transfers.
+package treasury
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/currencies"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+// KindZeroBalance is the background job kind that zeroes every pool's members into its master.
+const KindZeroBalance = "treasury.zero_balance"
+
+// Movement kinds stored on cash_pool_movements.
+const (
+	// MoveSweep takes a member's surplus above target up to the master at end of day.
+	MoveSweep = "sweep"
+	// MoveCover tops a member below target up from the master at end of day.
+	MoveCover = "cover"
+	// MoveFund moves money from the master to a member on request.
+	MoveFund = "fund"
+)
+
+// maxPoolName is the longest pool name accepted, in characters.
+const maxPoolName = 100
+
+var (
+	// ErrPoolNotFound is returned when no cash pool has the given ID.
+	ErrPoolNotFound = errors.New("cash pool not found")
+	// ErrMemberNotFound is returned when an account is not a member of the pool.
+	ErrMemberNotFound = errors.New("account is not a member of the cash pool")
+	// ErrInvalidPool is returned for a pool or membership with invalid fields or accounts.
+	ErrInvalidPool = errors.New("invalid cash pool")
+	// ErrAlreadyPooled is returned when an account already belongs to a pool, as master or member.
+	ErrAlreadyPooled = errors.New("account already belongs to a cash pool")
+	// ErrMemberNotOwned is returned when whoever added a member no longer owns it and the master.
+	ErrMemberNotOwned = errors.New("cash pool member and master are no longer owned by the user who pooled them")
+)
+
+// Store keeps pools, members and movements. *db.Store satisfies it.
+type Store interface {
+	GetAccount(ctx context.Context, id uuid.UUID) (sqlc.Account, error)
+	GetAccountAccessRole(ctx context.Context, arg sqlc.GetAccountAccessRoleParams) (string, error)
+	CreateCashPool(ctx context.Context, arg sqlc.CreateCashPoolParams) (sqlc.CashPool, error)
+	GetCashPool(ctx context.Context, id uuid.UUID) (sqlc.CashPool, error)
+	FindCashPoolByAccount(ctx context.Context, accountID uuid.UUID) (uuid.UUID, error)
+	AddCashPoolMember(ctx context.Context, arg sqlc.AddCashPoolMemberParams) (sqlc.CashPoolMember, error)
+	GetCashPoolMember(ctx context.Context, arg sqlc.GetCashPoolMemberParams) (sqlc.CashPoolMember, error)
+	DeleteCashPoolMember(ctx context.Context, arg sqlc.DeleteCashPoolMemberParams) (int64, error)
+	ListAllCashPoolMembers(ctx context.Context) ([]sqlc.ListAllCashPoolMembersRow, error)
+}
+
+// Ledger posts pool movements. *service.LedgerService satisfies it.
+type Ledger interface {
+	PostTreasuryMove(ctx context.Context, aID, bID uuid.UUID, plan service.TreasuryPlan, record service.TreasuryRecord) (bool, error)
+}
+
+// Service manages cash pools and makes their movements.
+type Service struct {
+	store  Store
+	ledger Ledger
+}
+
+// NewService constructs a Service.
+func NewService(store Store, ledger Ledger) *Service {
+	return &Service{store: store, ledger: ledger}
+}
+
+// MemberRequest asks for AccountID to join PoolID, kept at TargetBalance at end of day.
+type MemberRequest struct {
+	PoolID        uuid.UUID
+	AccountID     uuid.UUID
+	TargetBalance string
+	AddedBy       uuid.UUID
+}
+
+// CreatePool makes masterID the master of a new pool. The master must be a customer account that
+// is not already pooled.
+func (s *Service) CreatePool(ctx context.Context, masterID uuid.UUID, name string, createdBy uuid.UUID) (sqlc.CashPool, error) {
+	// Step 1: Validate the name and the master account.
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxPoolName {
+		return sqlc.CashPool{}, fmt.Errorf("%w: name must be 1-%d characters", ErrInvalidPool, maxPoolName)
+	}
+	master, err := s.account(ctx, masterID)
+	if err != nil {
+		return sqlc.CashPool{}, err
+	}
+	if master.IsSystem {
+		return sqlc.CashPool{}, fmt.Errorf("%w: system accounts cannot be pooled", ErrInvalidPool)
+	}
+	if err := s.checkUnpooled(ctx, masterID); err != nil {
+		return sqlc.CashPool{}, err
+	}
+
+	// Step 2: Record the pool.
+	pool, err := s.store.CreateCashPool(ctx, sqlc.CreateCashPoolParams{
+		MasterAccountID: masterID,
+		Name:            name,
+		CreatedBy:       createdBy,
+	})
+	if err != nil {
+		return sqlc.CashPool{}, err
+	}
+
+	log.Info().Str("pool_id", pool.ID.String()).Str("master_account_id", masterID.String()).Msg("Cash pool created")
+	return pool, nil
+}
+
+// AddMember adds an account to a pool. Members are customer accounts in the master's organization
+// and currency that are not already pooled; the target is the balance they are zeroed to. Money
+// moves for a member only while req.AddedBy owns both it and the master.
+func (s *Service) AddMember(ctx context.Context, req MemberRequest) (sqlc.CashPoolMember, error) {
+	// Step 1: Validate the target balance.
+	if req.TargetBalance == "" {
+		req.TargetBalance = "0"
+	}
+	target, err := decimal.NewFromString(req.TargetBalance)
+	if err != nil || target.IsNegative() {
+		return sqlc.CashPoolMember{}, fmt.Errorf("%w: target_balance must be zero or more", ErrInvalidPool)
+	}
+
+	// Step 2: Members post against the master, so the accounts must allow a transfer.
+	pool, err := s.pool(ctx, req.PoolID)
+	if err != nil {
+		return sqlc.CashPoolMember{}, err
+	}
+	master, err := s.account(ctx, pool.MasterAccountID)
+	if err != nil {
+		return sqlc.CashPoolMember{}, err
+	}
+	member, err := s.account(ctx, req.AccountID)
+	if err != nil {
+		return sqlc.CashPoolMember{}, err
+	}
+	switch {
+	case member.IsSystem:
+		return sqlc.CashPoolMember{}, fmt.Errorf("%w: system accounts cannot be pooled", ErrInvalidPool)
+	case member.OrgID != master.OrgID:
+		return sqlc.CashPoolMember{}, service.ErrCrossOrgTransfer
+	case member.Currency != master.Currency:
+		return sqlc.CashPoolMember{}, service.ErrCurrencyMismatch
+	case !currencies.Fits(member.Currency, target):
+		return sqlc.CashPoolMember{}, service.ErrAmountPrecision
+	}
+	if err := s.checkUnpooled(ctx, member.ID); err != nil {
+		return sqlc.CashPoolMember{}, err
+	}
+
+	// Step 3: Record the membership.
+	m, err := s.store.AddCashPoolMember(ctx, sqlc.AddCashPoolMemberParams{
+		PoolID:        pool.ID,
+		AccountID:     member.ID,
+		TargetBalance: target.StringFixed(currencies.MaxExponent),
+		AddedBy:       req.AddedBy,
+	})
+	if err != nil {
+		return sqlc.CashPoolMember{}, err
+	}
+
+	log.Info().Str("pool_id", pool.ID.String()).Str("account_id", member.ID.String()).Msg("Cash pool member added")
+	return m, nil
+}
+
+// RemoveMember takes an account out of a pool. Its balance and past movements stay as they are.
+func (s *Service) RemoveMember(ctx context.Context, poolID, accountID uuid.UUID) error {
+	n, err := s.store.DeleteCashPoolMember(ctx, sqlc.DeleteCashPoolMemberParams{PoolID: poolID, AccountID: accountID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrMemberNotFound
+	}
+	log.Info().Str("pool_id", poolID.String()).Str("account_id", accountID.String()).Msg("Cash pool member removed")
+	return nil
+}
+
+// Fund moves amount from the pool's master to one of its members now, rather than waiting for the
+// end-of-day cover. The master must hold the amount within its product's rules and transfer limits.
+func (s *Service) Fund(ctx context.Context, poolID, accountID uuid.UUID, amount string, requestedBy uuid.UUID) (sqlc.CashPoolMovement, error) {
+	// Step 1: Validate the amount and the membership.
+	amt, err := decimal.NewFromString(amount)
+	if err != nil || !amt.IsPositive() {
+		return sqlc.CashPoolMovement{}, service.ErrInvalidAmount
+	}
+	pool, err := s.pool(ctx, poolID)
+	if err != nil {
+		return sqlc.CashPoolMovement{}, err
+	}
+	m, err := s.member(ctx, poolID, accountID)
+	if err != nil {
+		return sqlc.CashPoolMovement{}, err
+	}
+	if err := s.checkOwned(ctx, m, pool.MasterAccountID); err != nil {
+		return sqlc.CashPoolMovement{}, err
+	}
+
+	// Step 2: Post the funding and record it.
+	var movement sqlc.CashPoolMovement
+	_, err = s.ledger.PostTreasuryMove(ctx, pool.MasterAccountID, accountID,
+		func(a, b sqlc.Account) (service.TreasuryMove, error) {
+			master, member := split(pool.MasterAccountID, a, b)
+			return service.TreasuryMove{From: master, To: member, Amount: amt, Description: "Cash pool funding"}, nil
+		},
+		recorder(poolID, accountID, MoveFund, uuid.NullUUID{UUID: requestedBy, Valid: true}, &movement),
+	)
+	if err != nil {
+		return sqlc.CashPoolMovement{}, err
+	}
+	return movement, nil
+}
+
+// zeroMove is what brings a member holding balance back to target: a sweep of the surplus up to
+// the master or a cover of the shortfall from it. Surpluses are rounded down and shortfalls up to
+// the currency's exponent, so a member never ends below its target.
+func zeroMove(balance, target decimal.Decimal, currency string) (string, decimal.Decimal) {
+	diff := balance.Sub(target)
+	switch {
+	case diff.IsPositive():
+		return MoveSweep, currencies.RoundDown(currency, diff)
+	case diff.IsNegative():
+		return MoveCover, diff.Neg().RoundCeil(currencies.Exponent(currency))
+	default:
+		return "", decimal.Zero
+	}
+}
+
+// ZeroBalances is a jobs.HandlerFunc that zeroes every pool member to its target. A movement the
+// ledger refuses, such as a cover the master cannot afford or a sweep below a member's minimum
+// balance, is logged and tried again next run.
+func (s *Service) ZeroBalances(ctx context.Context, _ json.RawMessage) error {
+	members, err := s.store.ListAllCashPoolMembers(ctx)
+	if err != nil {
+		return fmt.Errorf("list cash pool members: %w", err)
+	}
+
+	var (
+		errs  []error
+		moved int
+	)
+	// Sweeps go first, so covers can draw on the surpluses they bring to the master.
+	for _, kind := range []string{MoveSweep, MoveCover} {
+		for _, m := range members {
+			ok, err := s.zero(ctx, m, kind)
+			switch {
+			case err == nil:
+				if ok {
+					moved++
+				}
+			case errors.Is(err, ErrMemberNotFound):
+				// Removed from the pool since it was listed.
+			case refused(err):
+				log.Warn().Err(err).Str("pool_id", m.PoolID.String()).Str("account_id", m.AccountID.String()).
+					Str("kind", kind).Msg("Cash pool movement refused")
+			default:
+				log.Error().Err(err).Str("pool_id", m.PoolID.String()).Str("account_id", m.AccountID.String()).
+					Str("kind", kind).Msg("Failed to zero cash pool member")
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	log.Info().Int("members", len(members)).Int("moved", moved).Int("failed", len(errs)).Msg("Cash pools zeroed")
+	return errors.Join(errs...)
+}
+
+// zero makes one member's end-of-day movement of kind, if that is the direction it needs.
+func (s *Service) zero(ctx context.Context, m sqlc.ListAllCashPoolMembersRow, kind string) (bool, error) {
+	target, err := decimal.NewFromString(m.TargetBalance)
+	if err != nil {
+		return false, errors.New("invalid target balance")
+	}
+	member, err := s.member(ctx, m.PoolID, m.AccountID)
+	if err != nil {
+		return false, err
+	}
+	if err := s.checkOwned(ctx, member, m.MasterAccountID); err != nil {
+		return false, err
+	}
+	return s.ledger.PostTreasuryMove(ctx, m.MasterAccountID, m.AccountID,
+		func(a, b sqlc.Account) (service.TreasuryMove, error) {
+			master, member := split(m.MasterAccountID, a, b)
+			balance, err := decimal.NewFromString(member.Balance)
+			if err != nil {
+				return service.TreasuryMove{}, errors.New("invalid balance")
+			}
+			needed, amount := zeroMove(balance, target, member.Currency)
+			switch {
+			case needed != kind:
+				return service.TreasuryMove{}, nil
+			case kind == MoveSweep:
+				return service.TreasuryMove{From: member, To: master, Amount: amount, Description: "Cash pool sweep"}, nil
+			default:
+				return service.TreasuryMove{From: master, To: member, Amount: amount, Description: "Cash pool cover"}, nil
+			}
+		},
+		recorder(m.PoolID, m.AccountID, kind, uuid.NullUUID{}, nil),
+	)
+}
+
+// refused reports whether err is the ledger declining a movement rather than a failure.
+func refused(err error) bool {
+	for _, target := range []error{
+		service.ErrInsufficientFunds, service.ErrMinimumBalance, service.ErrDebitLimitExceeded,
+		service.ErrSavingsGoalLocked, service.ErrOperationNotAllowed, service.ErrCrossOrgTransfer,
+		service.ErrCurrencyMismatch, service.ErrAmountPrecision, service.ErrKYCRequired,
+		service.ErrKYCLimitExceeded, service.ErrScreeningBlocked, service.ErrStepUpRequired,
+		ErrMemberNotOwned,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// recorder stores a posted move as a movement of kind on memberID, once the account is confirmed
+// to still be in the pool within the posting's transaction. The movement is copied to out if set.
+func recorder(poolID, memberID uuid.UUID, kind string, createdBy uuid.NullUUID, out *sqlc.CashPoolMovement) service.TreasuryRecord {
+	return func(ctx context.Context, q *sqlc.Queries, txID uuid.UUID, move service.TreasuryMove) error {
+		if _, err := q.GetCashPoolMember(ctx, sqlc.GetCashPoolMemberParams{PoolID: poolID, AccountID: memberID}); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrMemberNotFound
+			}
+			return err
+		}
+		movement, err := q.CreateCashPoolMovement(ctx, sqlc.CreateCashPoolMovementParams{
+			PoolID:        poolID,
+			AccountID:     memberID,
+			Kind:          kind,
+			Amount:        move.Amount.String(),
+			TransactionID: txID,
+			CreatedBy:     createdBy,
+		})
+		if err != nil {
+			return err
+		}
+		if out != nil {
+			*out = movement
+		}
+		return nil
+	}
+}
+
+// split tells the master from the member among two accounts locked in ID order.
+func split(masterID uuid.UUID, a, b sqlc.Account) (master, member sqlc.Account) {
+	if a.ID == masterID {
+		return a, b
+	}
+	return b, a
+}
+
+func (s *Service) pool(ctx context.Context, id uuid.UUID) (sqlc.CashPool, error) {
+	pool, err := s.store.GetCashPool(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.CashPool{}, ErrPoolNotFound
+	}
+	return pool, err
+}
+
+func (s *Service) member(ctx context.Context, poolID, accountID uuid.UUID) (sqlc.CashPoolMember, error) {
+	m, err := s.store.GetCashPoolMember(ctx, sqlc.GetCashPoolMemberParams{PoolID: poolID, AccountID: accountID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.CashPoolMember{}, ErrMemberNotFound
+	}
+	return m, err
+}
+
+func (s *Service) account(ctx context.Context, id uuid.UUID) (sqlc.Account, error) {
+	acc, err := s.store.GetAccount(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlc.Account{}, service.ErrAccountNotFound
+	}
+	return acc, err
+}
+
+// checkUnpooled returns ErrAlreadyPooled when accountID is a master or member of any pool.
+func (s *Service) checkUnpooled(ctx context.Context, accountID uuid.UUID) error {
+	_, err := s.store.FindCashPoolByAccount(ctx, accountID)
+	switch {
+	case err == nil:
+		return ErrAlreadyPooled
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	default:
+		return err
+	}
+}
+
+// checkOwned returns ErrMemberNotOwned unless the user who added m still owns both its account and
+// the master, as they had to when adding it.
+func (s *Service) checkOwned(ctx context.Context, m sqlc.CashPoolMember, masterID uuid.UUID) error {
+	for _, id := range []uuid.UUID{masterID, m.AccountID} {
+		acc, err := s.account(ctx, id)
+		if err != nil {
+			return err
+		}
+		owned, err := service.OwnsAccount(ctx, s.store, m.AddedBy, acc)
+		if err != nil {
+			return err
+		}
+		if !owned {
+			return ErrMemberNotOwned
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package treasury
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/service"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+)
+
+func TestZeroMove(t *testing.T) {
+	cases := []struct {
+		balance, target, currency string
+		kind, amount              string
+	}{
+		// Surpluses sweep up, rounded down to the currency's exponent.
+		{"1500.25", "0", "NGN", MoveSweep, "1500.25"},
+		{"1500.2599", "500", "NGN", MoveSweep, "1000.25"},
+		{"100.9", "0", "JPY", MoveSweep, "100"},
+		// Shortfalls are covered, rounded up so the member reaches its target.
+		{"-250", "0", "NGN", MoveCover, "250"},
+		{"99.991", "100", "NGN", MoveCover, "0.01"},
+		{"19.5", "100", "JPY", MoveCover, "81"},
+		// A member at its target needs nothing.
+		{"100", "100", "NGN", "", "0"},
+	}
+	for _, c := range cases {
+		kind, amount := zeroMove(decimal.RequireFromString(c.balance), decimal.RequireFromString(c.target), c.currency)
+		assert.Equal(t, c.kind, kind, c.balance)
+		assert.Equal(t, c.amount, amount.String(), c.balance)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	a := sqlc.Account{ID: uuid.New()}
+	b := sqlc.Account{ID: uuid.New()}
+	master, member := split(b.ID, a, b)
+	assert.Equal(t, b.ID, master.ID)
+	assert.Equal(t, a.ID, member.ID)
+	master, member = split(a.ID, a, b)
+	assert.Equal(t, a.ID, master.ID)
+	assert.Equal(t, b.ID, member.ID)
+}
+
+func TestRefused(t *testing.T) {
+	// Ledger refusals are retried next run; anything else fails the job.
+	assert.True(t, refused(service.ErrInsufficientFunds))
+	assert.True(t, refused(service.ErrMinimumBalance))
+	assert.True(t, refused(service.ErrKYCLimitExceeded))
+	assert.True(t, refused(service.ErrScreeningBlocked))
+	assert.True(t, refused(fmt.Errorf("%w: until 2027-01-01", service.ErrSavingsGoalLocked)))
+	assert.False(t, refused(errors.New("connection reset")))
+}
+
+func TestValidation(t *testing.T) {
+	// Invalid fields are rejected before the store is touched.
+	s := NewService(nil, nil)
+	_, err := s.CreatePool(context.Background(), uuid.New(), "  ", uuid.New())
+	assert.ErrorIs(t, err, ErrInvalidPool)
+	_, err = s.CreatePool(context.Background(), uuid.New(), strings.Repeat("x", maxPoolName+1), uuid.New())
+	assert.ErrorIs(t, err, ErrInvalidPool)
+	_, err = s.AddMember(context.Background(), MemberRequest{PoolID: uuid.New(), AccountID: uuid.New(), TargetBalance: "-1"})
+	assert.ErrorIs(t, err, ErrInvalidPool)
+	_, err = s.Fund(context.Background(), uuid.New(), uuid.New(), "0", uuid.New())
+	assert.ErrorIs(t, err, service.ErrInvalidAmount)
+}
+
+// poolStore serves fixed memberships, all added by owner, and the accounts owner still owns.
+// Other Store methods are not used by movements.
+type poolStore struct {
+	Store
+	owner   uuid.UUID
+	owned   map[uuid.UUID]bool
+	members []sqlc.ListAllCashPoolMembersRow
+}
+
+func (s poolStore) ListAllCashPoolMembers(context.Context) ([]sqlc.ListAllCashPoolMembersRow, error) {
+	return s.members, nil
+}
+
+func (s poolStore) GetCashPool(_ context.Context, id uuid.UUID) (sqlc.CashPool, error) {
+	for _, m := range s.members {
+		if m.PoolID == id {
+			return sqlc.CashPool{ID: id, MasterAccountID: m.MasterAccountID}, nil
+		}
+	}
+	return sqlc.CashPool{}, sql.ErrNoRows
+}
+
+func (s poolStore) GetCashPoolMember(_ context.Context, arg sqlc.GetCashPoolMemberParams) (sqlc.CashPoolMember, error) {
+	for _, m := range s.members {
+		if m.PoolID == arg.PoolID && m.AccountID == arg.AccountID {
+			return sqlc.CashPoolMember{PoolID: m.PoolID, AccountID: m.AccountID, TargetBalance: m.TargetBalance, AddedBy: s.owner}, nil
+		}
+	}
+	return sqlc.CashPoolMember{}, sql.ErrNoRows
+}
+
+func (s poolStore) GetAccount(_ context.Context, id uuid.UUID) (sqlc.Account, error) {
+	return sqlc.Account{ID: id, OwnerID: uuid.NullUUID{UUID: s.owner, Valid: true}}, nil
+}
+
+func (s poolStore) GetAccountAccessRole(_ context.Context, arg sqlc.GetAccountAccessRoleParams) (string, error) {
+	if arg.UserID != s.owner || !s.owned[arg.AccountID] {
+		return "", sql.ErrNoRows
+	}
+	return "owner", nil
+}
+
+// poolLedger plans moves on in-memory balances and refuses debits from the accounts in refuse.
+type poolLedger struct {
+	balances map[uuid.UUID]string
+	refuse   map[uuid.UUID]error
+	moved    []service.TreasuryMove
+}
+
+func (l *poolLedger) PostTreasuryMove(_ context.Context, aID, bID uuid.UUID, plan service.TreasuryPlan, _ service.TreasuryRecord) (bool, error) {
+	a := sqlc.Account{ID: aID, Balance: l.balances[aID], Currency: "NGN"}
+	b := sqlc.Account{ID: bID, Balance: l.balances[bID], Currency: "NGN"}
+	move, err := plan(a, b)
+	if err != nil || move.Amount.IsZero() {
+		return false, err
+	}
+	if err := l.refuse[move.From.ID]; err != nil {
+		return false, err
+	}
+	l.moved = append(l.moved, move)
+	return true, nil
+}
+
+func TestZeroBalances_RefusedSweepDoesNotFailTheRun(t *testing.T) {
+	// A sweep stopped at a member's minimum balance is logged and retried next run, and the
+	// other members are still zeroed.
+	pool, master, savings, current := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	store := poolStore{owner: uuid.New(), owned: map[uuid.UUID]bool{master: true, savings: true, current: true}, members: []sqlc.ListAllCashPoolMembersRow{
+		{PoolID: pool, MasterAccountID: master, AccountID: savings, TargetBalance: "0"},
+		{PoolID: pool, MasterAccountID: master, AccountID: current, TargetBalance: "0"},
+	}}
+	ledger := &poolLedger{
+		balances: map[uuid.UUID]string{master: "0", savings: "1200", current: "300"},
+		refuse:   map[uuid.UUID]error{savings: service.ErrMinimumBalance},
+	}
+
+	assert.NoError(t, NewService(store, ledger).ZeroBalances(context.Background(), nil))
+	if assert.Len(t, ledger.moved, 1) {
+		assert.Equal(t, current, ledger.moved[0].From.ID)
+		assert.Equal(t, "300", ledger.moved[0].Amount.String())
+	}
+
+	// Anything other than a refusal fails the job.
+	ledger.refuse[savings] = errors.New("connection reset")
+	assert.Error(t, NewService(store, ledger).ZeroBalances(context.Background(), nil))
+}
+
+func TestZeroBalances_SkipsMembersNoLongerOwned(t *testing.T) {
+	// A member whose owner changed since it was pooled is skipped until someone fixes the pool,
+	// without failing the run.
+	pool, master, kept, sold := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	store := poolStore{owner: uuid.New(), owned: map[uuid.UUID]bool{master: true, kept: true}, members: []sqlc.ListAllCashPoolMembersRow{
+		{PoolID: pool, MasterAccountID: master, AccountID: sold, TargetBalance: "0"},
+		{PoolID: pool, MasterAccountID: master, AccountID: kept, TargetBalance: "0"},
+	}}
+	ledger := &poolLedger{balances: map[uuid.UUID]string{master: "0", sold: "500", kept: "250"}}
+
+	assert.NoError(t, NewService(store, ledger).ZeroBalances(context.Background(), nil))
+	if assert.Len(t, ledger.moved, 1) {
+		assert.Equal(t, kept, ledger.moved[0].From.ID)
+	}
+
+	_, err := NewService(store, ledger).Fund(context.Background(), pool, sold, "100", uuid.New())
+	assert.ErrorIs(t, err, ErrMemberNotOwned)
+}
@@ -0,0 +1,313 @@
+// Package webhooks delivers signed ledger events to organization endpoints, retrying failed
+// deliveries with backoff and parking them in a dead-letter state when retries run out.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/internal/events"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/postgres/sqlc"
+	"github.com/PaulBabatuyi/Double-Entry-Bank-Go/sdk/webhook"
+)
+
+// Delivery statuses stored on the webhook_deliveries table. Dead deliveries form the dead-letter queue.
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusDead      = "dead"
+)
+
+const (
+	// KindDispatch is the background job kind that sends due deliveries.
+	KindDispatch = "webhooks.dispatch"
+	// MaxAttempts is how many times a delivery is tried before it is parked as dead.
+	MaxAttempts = 8
+	// UnhealthyAfter is how many consecutive failed attempts mark an endpoint unhealthy.
+	UnhealthyAfter = 5
+
+	// SignatureHeader carries "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)), and
+	// during a secret rotation a second, comma-separated signature made with the old secret.
+	SignatureHeader = webhook.SignatureHeader
+	// TimestampHeader carries the Unix time the signature was made, so receivers can reject replays.
+	TimestampHeader = webhook.TimestampHeader
+	// EventHeader carries the event type.
+	EventHeader = webhook.EventHeader
+	// DeliveryHeader carries the delivery ID; it is stable across retries for deduplication.
+	DeliveryHeader = webhook.DeliveryHeader
+)
+
+// Store persists endpoints, deliveries and attempts. *db.Store satisfies it.
+type Store interface {
+	GetAccount(ctx context.Context, id uuid.UUID) (sqlc.Account, error)
+	ListWebhookEndpointsByOrg(ctx context.Context, orgID uuid.UUID) ([]sqlc.WebhookEndpoint, error)
+	GetWebhookEndpoint(ctx context.Context, id uuid.UUID) (sqlc.WebhookEndpoint, error)
+	CreateWebhookDelivery(ctx context.Context, arg sqlc.CreateWebhookDeliveryParams) (sqlc.WebhookDelivery, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, arg sqlc.ClaimDueWebhookDeliveriesParams) ([]sqlc.WebhookDelivery, error)
+	MarkWebhookDeliverySucceeded(ctx context.Context, id uuid.UUID) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg sqlc.MarkWebhookDeliveryFailedParams) (sqlc.WebhookDelivery, error)
+	CreateWebhookDeliveryAttempt(ctx context.Context, arg sqlc.CreateWebhookDeliveryAttemptParams) error
+	RecordWebhookEndpointSuccess(ctx context.Context, id uuid.UUID) error
+	RecordWebhookEndpointFailure(ctx context.Context, arg sqlc.RecordWebhookEndpointFailureParams) (sqlc.WebhookEndpoint, error)
+}
+
+// AccountBalance is an organization account touched by the event and its balance after commit.
+type AccountBalance struct {
+	AccountID string `json:"account_id"`
+	Balance   string `json:"balance"`
+}
+
+// Payload is the JSON body POSTed to endpoints.
+type Payload struct {
+	OccurredAt    time.Time        `json:"occurred_at"`
+	Type          string           `json:"type"`
+	TransactionID string           `json:"transaction_id"`
+	Amount        string           `json:"amount"`
+	Currency      string           `json:"currency"`
+	Accounts      []AccountBalance `json:"accounts"`
+}
+
+// Dispatcher queues deliveries for committed events and sends them.
+type Dispatcher struct {
+	store  Store
+	client *http.Client
+	now    func() time.Time
+	// lease is how long a claimed delivery stays invisible to other dispatchers.
+	lease time.Duration
+	batch int32
+}
+
+// NewDispatcher constructs a Dispatcher with a 10 second per-request timeout.
+func NewDispatcher(store Store) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		client: &http.Client{Timeout: 10 * time.Second},
+		now:    time.Now,
+		lease:  2 * time.Minute,
+		batch:  50,
+	}
+}
+
+// NewSecret returns a random signing secret for a new endpoint.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// ValidateURL accepts only absolute HTTPS URLs without embedded credentials.
+func ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.New("url must be an absolute https URL")
+	}
+	if u.User != nil {
+		return errors.New("url must not contain credentials")
+	}
+	return nil
+}
+
+// Sign computes the signature of body sent at timestamp with secret. Receivers verify it with
+// the sdk/webhook package.
+func Sign(secret string, timestamp int64, body []byte) string {
+	return webhook.Sign(secret, timestamp, body)
+}
+
+// signatures is the SignatureHeader value for a delivery to ep: a signature with its secret and,
+// while a rotation's grace period lasts, one with the secret it replaced.
+func signatures(ep sqlc.WebhookEndpoint, now time.Time, body []byte) string {
+	sig := Sign(ep.Secret, now.Unix(), body)
+	if ep.PreviousSecret != "" && ep.PreviousSecretExpiresAt.Valid && ep.PreviousSecretExpiresAt.Time.After(now) {
+		sig += "," + Sign(ep.PreviousSecret, now.Unix(), body)
+	}
+	return sig
+}
+
+// backoff returns the delay after a failed attempt (1-based): 1m, 2m, 4m ... capped at 6h.
+func backoff(attempt int32) time.Duration {
+	d := time.Minute
+	for i := int32(1); i < attempt; i++ {
+		d *= 2
+		if d >= 6*time.Hour {
+			return 6 * time.Hour
+		}
+	}
+	return d
+}
+
+// HandleEvent is an events.Handler that queues one delivery per endpoint of every
+// organization whose customer accounts evt touched. Each organization only sees its own accounts.
+func (d *Dispatcher) HandleEvent(ctx context.Context, evt events.Event) error {
+	byOrg := make(map[uuid.UUID][]AccountBalance)
+	seen := make(map[uuid.UUID]bool, len(evt.Entries))
+	for _, entry := range evt.Entries {
+		if seen[entry.AccountID] {
+			continue
+		}
+		seen[entry.AccountID] = true
+		acc, err := d.store.GetAccount(ctx, entry.AccountID)
+		if err != nil {
+			return fmt.Errorf("load account %s: %w", entry.AccountID, err)
+		}
+		if acc.IsSystem || !acc.OrgID.Valid {
+			continue
+		}
+		byOrg[acc.OrgID.UUID] = append(byOrg[acc.OrgID.UUID], AccountBalance{AccountID: acc.ID.String(), Balance: evt.Balances[acc.ID]})
+	}
+
+	var errs []error
+	for orgID, accounts := range byOrg {
+		endpoints, err := d.store.ListWebhookEndpointsByOrg(ctx, orgID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("list webhook endpoints of %s: %w", orgID, err))
+			continue
+		}
+		if len(endpoints) == 0 {
+			continue
+		}
+		body, err := json.Marshal(Payload{
+			OccurredAt:    evt.OccurredAt,
+			Type:          string(evt.Type),
+			TransactionID: evt.TransactionID.String(),
+			Amount:        evt.Amount,
+			Currency:      evt.Currency,
+			Accounts:      accounts,
+		})
+		if err != nil {
+			return err
+		}
+		for _, ep := range endpoints {
+			if _, err := d.store.CreateWebhookDelivery(ctx, sqlc.CreateWebhookDeliveryParams{
+				EndpointID:    ep.ID,
+				EventType:     string(evt.Type),
+				TransactionID: evt.TransactionID,
+				Payload:       body,
+			}); err != nil {
+				errs = append(errs, fmt.Errorf("queue webhook for %s: %w", ep.ID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Dispatch is a jobs.HandlerFunc that attempts every due delivery once.
+// Individual delivery failures are recorded on the delivery, not returned.
+func (d *Dispatcher) Dispatch(ctx context.Context, _ json.RawMessage) error {
+	deliveries, err := d.store.ClaimDueWebhookDeliveries(ctx, sqlc.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: d.now().Add(d.lease),
+		RowLimit:   d.batch,
+	})
+	if err != nil {
+		return err
+	}
+	for _, del := range deliveries {
+		if err := d.deliver(ctx, del); err != nil {
+			log.Error().Err(err).Str("delivery_id", del.ID.String()).Msg("Failed to record webhook delivery")
+		}
+	}
+	return nil
+}
+
+// deliver sends one delivery and records the attempt, the delivery outcome and endpoint health.
+func (d *Dispatcher) deliver(ctx context.Context, del sqlc.WebhookDelivery) error {
+	ep, err := d.store.GetWebhookEndpoint(ctx, del.EndpointID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Deleting an endpoint cascades to its deliveries; nothing left to do.
+			return nil
+		}
+		return err
+	}
+
+	start := d.now()
+	statusCode, sendErr := d.send(ctx, ep, del)
+	durationMs := d.now().Sub(start).Milliseconds()
+	attempt := sqlc.CreateWebhookDeliveryAttemptParams{
+		DeliveryID: del.ID,
+		StatusCode: sql.NullInt32{Int32: int32(statusCode), Valid: statusCode != 0}, // #nosec G115 -- HTTP status codes fit in int32
+		DurationMs: int32(min(durationMs, 1<<31-1)),                                 // #nosec G115 -- clamped by min
+	}
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	}
+	if err := d.store.CreateWebhookDeliveryAttempt(ctx, attempt); err != nil {
+		return err
+	}
+
+	if sendErr == nil {
+		if err := d.store.MarkWebhookDeliverySucceeded(ctx, del.ID); err != nil {
+			return err
+		}
+		if !ep.Healthy {
+			log.Info().Str("endpoint_id", ep.ID.String()).Msg("Webhook endpoint healthy again")
+		}
+		return d.store.RecordWebhookEndpointSuccess(ctx, ep.ID)
+	}
+
+	updated, err := d.store.MarkWebhookDeliveryFailed(ctx, sqlc.MarkWebhookDeliveryFailedParams{
+		MaxAttempts:   MaxAttempts,
+		NextAttemptAt: d.now().Add(backoff(del.Attempts + 1)),
+		LastError:     sendErr.Error(),
+		ID:            del.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if updated.Status == StatusDead {
+		log.Warn().Str("delivery_id", del.ID.String()).Str("endpoint_id", ep.ID.String()).Msg("Webhook delivery moved to dead-letter queue")
+	}
+	health, err := d.store.RecordWebhookEndpointFailure(ctx, sqlc.RecordWebhookEndpointFailureParams{
+		UnhealthyAfter: UnhealthyAfter,
+		ID:             ep.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if ep.Healthy && !health.Healthy {
+		log.Warn().Str("endpoint_id", ep.ID.String()).Int32("consecutive_failures", health.ConsecutiveFailures).Msg("Webhook endpoint marked unhealthy")
+	}
+	return nil
+}
+
+// send POSTs the signed payload. Any non-2xx status is a failure.
+func (d *Dispatcher) send(ctx context.Context, ep sqlc.WebhookEndpoint, del sqlc.WebhookDelivery) (int, error) {
+	now := d.now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.Url, bytes.NewReader(del.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, del.EventType)
+	req.Header.Set(DeliveryHeader, del.ID.String())
+	req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(SignatureHeader, signatures(ep, now, del.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	// Drain a bounded amount so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint returned %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,2 @@
+-- Supports chronological paging and resumable streams per account.
+CREATE INDEX IF NOT EXISTS idx_entries_account_created_id ON entries(account_id, created_at, id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bank_statement_imports_created_at;
+DROP TABLE IF EXISTS bank_statement_imports;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
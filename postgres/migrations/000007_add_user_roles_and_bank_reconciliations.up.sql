@@ -0,0 +1,20 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'customer'
+    CHECK (role IN ('customer', 'admin'));
+
+-- One row per imported settlement-bank statement, with the reconciliation report snapshot.
+CREATE TABLE IF NOT EXISTS bank_statement_imports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_format TEXT NOT NULL CHECK (source_format IN ('csv', 'ofx')),
+    filename TEXT NOT NULL DEFAULT '',
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    line_count INTEGER NOT NULL,
+    matched_count INTEGER NOT NULL,
+    missing_count INTEGER NOT NULL,
+    unexpected_count INTEGER NOT NULL,
+    report JSONB NOT NULL,
+    imported_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_statement_imports_created_at ON bank_statement_imports(created_at DESC);
@@ -0,0 +1,17 @@
+-- Card/bank charges initiated with a payment provider. The ledger deposit is posted
+-- only when the provider confirms the charge; transaction_id links to the resulting entries.
+CREATE TABLE IF NOT EXISTS payment_charges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    provider TEXT NOT NULL DEFAULT 'paystack',
+    reference TEXT NOT NULL UNIQUE,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    transaction_id UUID UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_charges_account_id ON payment_charges(account_id);
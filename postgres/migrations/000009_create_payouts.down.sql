@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS payouts;
+DELETE FROM accounts WHERE is_system = TRUE AND name = 'Payouts In Transit'
+    AND NOT EXISTS (SELECT 1 FROM entries WHERE entries.account_id = accounts.id);
+-- PostgreSQL cannot drop enum values; 'reversal' stays on operation_type.
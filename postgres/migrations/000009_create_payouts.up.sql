@@ -0,0 +1,34 @@
+-- Wrapped like the CREATE TYPE in 000003 (PostgreSQL 12+ allows ADD VALUE here).
+DO $$ BEGIN
+    ALTER TYPE operation_type ADD VALUE IF NOT EXISTS 'reversal';
+END $$;
+
+-- Clearing account that holds withdrawn funds until the payout rail confirms or rejects the transfer.
+INSERT INTO accounts (id, name, balance, currency, is_system)
+SELECT gen_random_uuid(), 'Payouts In Transit', 0.0000, 'USD', TRUE
+WHERE NOT EXISTS (
+    SELECT 1 FROM accounts WHERE is_system = TRUE AND name = 'Payouts In Transit'
+);
+
+CREATE TABLE IF NOT EXISTS payouts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    provider TEXT NOT NULL DEFAULT 'flutterwave',
+    reference TEXT NOT NULL UNIQUE,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    bank_code TEXT NOT NULL,
+    account_number TEXT NOT NULL,
+    narration TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    provider_transfer_id TEXT,
+    hold_transaction_id UUID NOT NULL,
+    final_transaction_id UUID,
+    failure_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_payouts_account_id ON payouts(account_id);
+CREATE INDEX IF NOT EXISTS idx_payouts_pending ON payouts(created_at) WHERE status = 'pending';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_payment_charges_provider_reference;
+ALTER TABLE payment_charges DROP COLUMN IF EXISTS provider_reference;
@@ -0,0 +1,4 @@
+-- Provider-side identifier (e.g., Stripe PaymentIntent ID) alongside our own reference.
+ALTER TABLE payment_charges ADD COLUMN IF NOT EXISTS provider_reference TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_charges_provider_reference
+    ON payment_charges(provider, provider_reference) WHERE provider_reference IS NOT NULL;
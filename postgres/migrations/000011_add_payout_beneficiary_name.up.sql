@@ -0,0 +1,2 @@
+-- Name returned by the destination bank's name enquiry, stored for receipts and disputes.
+ALTER TABLE payouts ADD COLUMN IF NOT EXISTS beneficiary_name TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS inbound_payments;
+DELETE FROM accounts WHERE is_system = TRUE AND name = 'Unapplied Receipts'
+    AND NOT EXISTS (SELECT 1 FROM entries WHERE entries.account_id = accounts.id);
+DROP INDEX IF EXISTS idx_accounts_virtual_account_number;
+ALTER TABLE accounts DROP COLUMN IF EXISTS virtual_account_number;
+DROP SEQUENCE IF EXISTS virtual_account_number_seq;
@@ -0,0 +1,37 @@
+-- Every customer account gets a stable 10-digit number that payers can send bank transfers to.
+CREATE SEQUENCE IF NOT EXISTS virtual_account_number_seq START WITH 1 MAXVALUE 999999999;
+
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS virtual_account_number TEXT NOT NULL
+        DEFAULT ('9' || lpad(nextval('virtual_account_number_seq')::TEXT, 9, '0'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_virtual_account_number ON accounts(virtual_account_number);
+
+-- Suspense account for inbound credits that could not be matched to a customer.
+INSERT INTO accounts (id, name, balance, currency, is_system)
+SELECT gen_random_uuid(), 'Unapplied Receipts', 0.0000, 'USD', TRUE
+WHERE NOT EXISTS (
+    SELECT 1 FROM accounts WHERE is_system = TRUE AND name = 'Unapplied Receipts'
+);
+
+CREATE TABLE IF NOT EXISTS inbound_payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    provider TEXT NOT NULL,
+    -- event_id is the provider's notification ID; the unique pair rejects replays.
+    event_id TEXT NOT NULL,
+    virtual_account_number TEXT NOT NULL,
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    payer_name TEXT NOT NULL DEFAULT '',
+    payer_reference TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('credited', 'unmatched', 'resolved')),
+    account_id UUID REFERENCES accounts(id),
+    transaction_id UUID NOT NULL,
+    resolution_transaction_id UUID,
+    resolved_by UUID REFERENCES users(id),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inbound_payments_unmatched ON inbound_payments(created_at) WHERE status = 'unmatched';
@@ -0,0 +1,18 @@
+-- One KYC record per user. level is the tier granted by the last approval and survives
+-- a pending resubmission; status tracks the latest submission.
+CREATE TABLE IF NOT EXISTS kyc_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    id_type TEXT NOT NULL CHECK (id_type IN ('passport', 'national_id', 'drivers_license', 'voters_card', 'nin', 'bvn')),
+    id_number TEXT NOT NULL,
+    document_reference TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    level SMALLINT NOT NULL DEFAULT 0 CHECK (level BETWEEN 0 AND 3),
+    rejection_reason TEXT,
+    reviewed_by UUID REFERENCES users(id),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_kyc_records_pending ON kyc_records(submitted_at) WHERE status = 'pending';
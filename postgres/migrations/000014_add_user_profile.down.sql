@@ -0,0 +1,10 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS first_name,
+    DROP COLUMN IF EXISTS last_name,
+    DROP COLUMN IF EXISTS date_of_birth,
+    DROP COLUMN IF EXISTS address_line1,
+    DROP COLUMN IF EXISTS address_line2,
+    DROP COLUMN IF EXISTS city,
+    DROP COLUMN IF EXISTS state,
+    DROP COLUMN IF EXISTS postal_code,
+    DROP COLUMN IF EXISTS country;
@@ -0,0 +1,12 @@
+-- Customer details needed for statements, KYC review and notifications.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS first_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS last_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS date_of_birth DATE,
+    ADD COLUMN IF NOT EXISTS address_line1 TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS address_line2 TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS city TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS state TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS postal_code TEXT NOT NULL DEFAULT '',
+    -- ISO 3166-1 alpha-2, e.g. NG or US.
+    ADD COLUMN IF NOT EXISTS country TEXT NOT NULL DEFAULT '';
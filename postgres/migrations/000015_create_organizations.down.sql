@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_accounts_org_id;
+ALTER TABLE accounts DROP COLUMN IF EXISTS org_id;
+
+UPDATE users SET role = 'customer' WHERE role = 'org_admin';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('customer', 'admin'));
+
+-- Fails if the same email now exists in two organizations; merge those users first.
+DROP INDEX IF EXISTS idx_users_org_email;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users DROP COLUMN IF EXISTS org_id;
+
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,27 @@
+-- Tenants: each fintech client served by this deployment is an organization.
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE CHECK (slug ~ '^[a-z0-9][a-z0-9-]{1,62}$'),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Existing users and accounts move into the default organization.
+INSERT INTO organizations (name, slug) VALUES ('Default', 'default')
+ON CONFLICT (slug) DO NOTHING;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id);
+UPDATE users SET org_id = (SELECT id FROM organizations WHERE slug = 'default') WHERE org_id IS NULL;
+ALTER TABLE users ALTER COLUMN org_id SET NOT NULL;
+
+-- The same email may sign up with two different organizations.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_org_email ON users(org_id, email);
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('customer', 'org_admin', 'admin'));
+
+-- Customer accounts carry their owner's organization; system accounts are platform-wide (NULL).
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id);
+UPDATE accounts SET org_id = users.org_id FROM users WHERE accounts.owner_id = users.id AND accounts.org_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_accounts_org_id ON accounts(org_id);
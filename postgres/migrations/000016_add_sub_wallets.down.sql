@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_accounts_parent_name;
+DROP INDEX IF EXISTS idx_accounts_parent_account_id;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_parent_not_self;
+ALTER TABLE accounts DROP COLUMN IF EXISTS parent_account_id;
@@ -0,0 +1,14 @@
+-- A sub-wallet ("pocket") is a customer account that belongs to a parent account.
+-- Nesting is one level deep; the service layer refuses wallets under a wallet.
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS parent_account_id UUID REFERENCES accounts(id) ON DELETE RESTRICT;
+
+ALTER TABLE accounts
+    ADD CONSTRAINT accounts_parent_not_self CHECK (parent_account_id IS NULL OR parent_account_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_accounts_parent_account_id ON accounts(parent_account_id)
+    WHERE parent_account_id IS NOT NULL;
+
+-- Wallet names are unique under their parent.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_parent_name ON accounts(parent_account_id, name)
+    WHERE parent_account_id IS NOT NULL;
@@ -0,0 +1,19 @@
+-- Joint accounts: every user with access to an account and what they may do with it.
+-- accounts.owner_id stays the primary owner (KYC, statements, notifications).
+CREATE TABLE IF NOT EXISTS account_owners (
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- owner moves money and manages co-owners; viewer only reads.
+    role TEXT NOT NULL CHECK (role IN ('owner', 'viewer')),
+    added_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_owners_user_id ON account_owners(user_id);
+
+-- Sub-wallets inherit access from their parent, so only top-level accounts get rows.
+INSERT INTO account_owners (account_id, user_id, role)
+SELECT id, owner_id, 'owner' FROM accounts
+WHERE owner_id IS NOT NULL AND parent_account_id IS NULL
+ON CONFLICT (account_id, user_id) DO NOTHING;
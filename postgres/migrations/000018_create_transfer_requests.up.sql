@@ -0,0 +1,26 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('customer', 'org_admin', 'approver', 'admin'));
+
+-- Maker-checker queue: staff-initiated transfers are posted only after a second user approves.
+CREATE TABLE IF NOT EXISTS transfer_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id),
+    from_account_id UUID NOT NULL REFERENCES accounts(id),
+    to_account_id UUID NOT NULL REFERENCES accounts(id),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    requested_by UUID NOT NULL REFERENCES users(id),
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    decided_by UUID REFERENCES users(id),
+    decided_at TIMESTAMP WITH TIME ZONE,
+    decision_note TEXT NOT NULL DEFAULT '',
+    -- Set when approval posted the transfer.
+    transaction_id UUID,
+    CHECK (from_account_id <> to_account_id),
+    -- The checker must never be the maker.
+    CHECK (decided_by IS NULL OR decided_by <> requested_by)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transfer_requests_org_status ON transfer_requests(org_id, status, requested_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS disputes;
+DELETE FROM accounts WHERE is_system = TRUE AND name = 'Disputes Holding'
+    AND NOT EXISTS (SELECT 1 FROM entries WHERE entries.account_id = accounts.id);
+-- PostgreSQL cannot drop enum values; 'dispute' stays on operation_type.
@@ -0,0 +1,34 @@
+-- Wrapped like the CREATE TYPE in 000003 (PostgreSQL 12+ allows ADD VALUE here).
+DO $$ BEGIN
+    ALTER TYPE operation_type ADD VALUE IF NOT EXISTS 'dispute';
+END $$;
+
+-- Holding account for disputed amounts until a dispute is refunded or rejected.
+INSERT INTO accounts (id, name, balance, currency, is_system)
+SELECT gen_random_uuid(), 'Disputes Holding', 0.0000, 'USD', TRUE
+WHERE NOT EXISTS (
+    SELECT 1 FROM accounts WHERE is_system = TRUE AND name = 'Disputes Holding'
+);
+
+CREATE TABLE IF NOT EXISTS disputes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- A transaction can be disputed once.
+    transaction_id UUID NOT NULL UNIQUE,
+    -- account_id was debited by the disputed transaction; counterparty_account_id was credited.
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    counterparty_account_id UUID NOT NULL REFERENCES accounts(id),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'refunded', 'rejected')),
+    opened_by UUID NOT NULL REFERENCES users(id),
+    opened_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    hold_transaction_id UUID NOT NULL,
+    resolved_by UUID REFERENCES users(id),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    resolution_note TEXT NOT NULL DEFAULT '',
+    resolution_transaction_id UUID
+);
+
+CREATE INDEX IF NOT EXISTS idx_disputes_status_opened ON disputes(status, opened_at);
+CREATE INDEX IF NOT EXISTS idx_disputes_account_id ON disputes(account_id);
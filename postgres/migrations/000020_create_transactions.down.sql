@@ -0,0 +1,2 @@
+ALTER TABLE entries DROP CONSTRAINT IF EXISTS fk_entries_transaction;
+DROP TABLE IF EXISTS transactions;
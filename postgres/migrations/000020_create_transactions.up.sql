@@ -0,0 +1,35 @@
+-- One row per ledger transaction, so rail operations that take time carry an explicit status
+-- instead of being inferred from entries. Every entry's transaction_id references a row here.
+CREATE TABLE IF NOT EXISTS transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    operation_type operation_type NOT NULL,
+    status TEXT NOT NULL DEFAULT 'posted' CHECK (status IN ('pending', 'posted', 'failed', 'reversed')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Backfill from existing entries; everything already written was posted.
+INSERT INTO transactions (id, operation_type, status, created_at, updated_at)
+SELECT transaction_id,
+       (array_agg(operation_type ORDER BY created_at))[1],
+       'posted',
+       COALESCE(MIN(created_at), CURRENT_TIMESTAMP),
+       COALESCE(MIN(created_at), CURRENT_TIMESTAMP)
+FROM entries
+GROUP BY transaction_id
+ON CONFLICT (id) DO NOTHING;
+
+-- Payout holds are pending until the rail answers; a failed payout's hold never completed.
+UPDATE transactions SET status = 'pending'
+FROM payouts WHERE payouts.hold_transaction_id = transactions.id AND payouts.status = 'pending';
+UPDATE transactions SET status = 'failed'
+FROM payouts WHERE payouts.hold_transaction_id = transactions.id AND payouts.status = 'failed';
+
+-- A refunded dispute undoes the transaction it disputed.
+UPDATE transactions SET status = 'reversed'
+FROM disputes WHERE disputes.transaction_id = transactions.id AND disputes.status = 'refunded';
+
+ALTER TABLE entries
+    ADD CONSTRAINT fk_entries_transaction FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_status_created ON transactions(status, created_at);
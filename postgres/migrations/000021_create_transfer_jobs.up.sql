@@ -0,0 +1,18 @@
+-- Transfers accepted in async mode. The job ID is the transaction ID its entries are posted under,
+-- so clients poll one identifier from 202 Accepted through to the ledger.
+CREATE TABLE IF NOT EXISTS transfer_jobs (
+    id UUID PRIMARY KEY,
+    from_account_id UUID NOT NULL REFERENCES accounts(id),
+    to_account_id UUID NOT NULL REFERENCES accounts(id),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    requested_by UUID NOT NULL REFERENCES users(id),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'posted', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    failure_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_account_id <> to_account_id)
+);
+
+-- Workers claim the oldest pending job first.
+CREATE INDEX IF NOT EXISTS idx_transfer_jobs_pending ON transfer_jobs(created_at) WHERE status = 'pending';
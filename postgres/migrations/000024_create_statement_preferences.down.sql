@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS monthly_statements;
+DROP TABLE IF EXISTS statement_preferences;
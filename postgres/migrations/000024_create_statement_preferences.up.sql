@@ -0,0 +1,19 @@
+-- Per-account monthly statement delivery. Accounts without a row get the statement by email.
+CREATE TABLE IF NOT EXISTS statement_preferences (
+    account_id UUID PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+    delivery TEXT NOT NULL DEFAULT 'email' CHECK (delivery IN ('email', 'link', 'off')),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per account and closed month once its statement was sent, so a retried or
+-- re-run job never sends the same statement twice.
+CREATE TABLE IF NOT EXISTS monthly_statements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    delivery TEXT NOT NULL CHECK (delivery IN ('email', 'link')),
+    sent_to TEXT NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (account_id, period_start)
+);
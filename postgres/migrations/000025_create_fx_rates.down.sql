@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS fx_rate_overrides;
+DROP TABLE IF EXISTS fx_rates;
@@ -0,0 +1,21 @@
+-- Latest provider rate per currency pair: 1 base = rate quote.
+CREATE TABLE IF NOT EXISTS fx_rates (
+    base_currency TEXT NOT NULL CHECK (base_currency ~ '^[A-Z]{3}$'),
+    quote_currency TEXT NOT NULL CHECK (quote_currency ~ '^[A-Z]{3}$'),
+    rate NUMERIC(24, 10) NOT NULL CHECK (rate > 0),
+    provider TEXT NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (base_currency, quote_currency)
+);
+
+-- Admin-set rates that take precedence over the provider until they expire or are cleared.
+CREATE TABLE IF NOT EXISTS fx_rate_overrides (
+    base_currency TEXT NOT NULL CHECK (base_currency ~ '^[A-Z]{3}$'),
+    quote_currency TEXT NOT NULL CHECK (quote_currency ~ '^[A-Z]{3}$'),
+    rate NUMERIC(24, 10) NOT NULL CHECK (rate > 0),
+    reason TEXT NOT NULL,
+    set_by UUID NOT NULL REFERENCES users(id),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (base_currency, quote_currency)
+);
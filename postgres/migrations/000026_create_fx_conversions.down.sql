@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS fx_conversions;
+DROP TABLE IF EXISTS fx_spreads;
+DROP INDEX IF EXISTS idx_accounts_system_name_currency;
+-- FX system accounts and the 'conversion' enum value are left in place: entries may reference them.
@@ -0,0 +1,51 @@
+-- Wrapped like the CREATE TYPE in 000003 (PostgreSQL 12+ allows ADD VALUE here).
+DO $$ BEGIN
+    ALTER TYPE operation_type ADD VALUE IF NOT EXISTS 'conversion';
+END $$;
+
+-- System accounts are now per currency; FX accounts for other currencies are created on first use.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_system_name_currency ON accounts(name, currency) WHERE is_system;
+
+-- FX Position holds the bank's currency inventory; FX Income collects the spread.
+INSERT INTO accounts (id, name, balance, currency, is_system)
+SELECT gen_random_uuid(), 'FX Position', 0.0000, 'USD', TRUE
+WHERE NOT EXISTS (
+    SELECT 1 FROM accounts WHERE is_system = TRUE AND name = 'FX Position' AND currency = 'USD'
+);
+INSERT INTO accounts (id, name, balance, currency, is_system)
+SELECT gen_random_uuid(), 'FX Income', 0.0000, 'USD', TRUE
+WHERE NOT EXISTS (
+    SELECT 1 FROM accounts WHERE is_system = TRUE AND name = 'FX Income' AND currency = 'USD'
+);
+
+-- Spread charged when selling base for quote, in basis points of the mid rate.
+-- Pairs without a row use the FX_SPREAD_BPS default.
+CREATE TABLE IF NOT EXISTS fx_spreads (
+    base_currency TEXT NOT NULL CHECK (base_currency ~ '^[A-Z]{3}$'),
+    quote_currency TEXT NOT NULL CHECK (quote_currency ~ '^[A-Z]{3}$'),
+    spread_bps INTEGER NOT NULL CHECK (spread_bps BETWEEN 0 AND 5000),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (base_currency, quote_currency)
+);
+
+-- Pricing of each conversion, keyed by its ledger transaction.
+CREATE TABLE IF NOT EXISTS fx_conversions (
+    transaction_id UUID PRIMARY KEY REFERENCES transactions(id),
+    from_account_id UUID NOT NULL REFERENCES accounts(id),
+    to_account_id UUID NOT NULL REFERENCES accounts(id),
+    sell_currency TEXT NOT NULL,
+    sell_amount NUMERIC(19,4) NOT NULL CHECK (sell_amount > 0),
+    buy_currency TEXT NOT NULL,
+    buy_amount NUMERIC(19,4) NOT NULL CHECK (buy_amount > 0),
+    mid_rate NUMERIC(24,10) NOT NULL,
+    customer_rate NUMERIC(24,10) NOT NULL,
+    spread_bps INTEGER NOT NULL,
+    margin NUMERIC(19,4) NOT NULL CHECK (margin >= 0),
+    rate_source TEXT NOT NULL,
+    rate_as_of TIMESTAMP WITH TIME ZONE NOT NULL,
+    requested_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_fx_conversions_from_account ON fx_conversions(from_account_id, created_at DESC);
@@ -0,0 +1 @@
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_customer_balance_non_negative;
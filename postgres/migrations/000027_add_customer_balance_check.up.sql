@@ -0,0 +1,5 @@
+-- Customer accounts (including sub-wallets) can never be overdrawn; system accounts such as
+-- settlement and FX position carry the other side of external flows and may go negative.
+-- Fails if a customer balance is already negative: reconcile that account before migrating.
+ALTER TABLE accounts
+    ADD CONSTRAINT accounts_customer_balance_non_negative CHECK (is_system OR balance >= 0);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS account_products;
+ALTER TABLE accounts DROP COLUMN IF EXISTS product;
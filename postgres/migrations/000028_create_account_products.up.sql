@@ -0,0 +1,13 @@
+-- Every account belongs to a product; products carry rules such as a minimum balance.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS product TEXT NOT NULL DEFAULT 'standard';
+
+-- Minimum balance that must remain after a withdrawal or transfer, per product and currency.
+-- A product without a row for the account's currency has no minimum.
+CREATE TABLE IF NOT EXISTS account_products (
+    product TEXT NOT NULL CHECK (product ~ '^[a-z][a-z0-9_]{0,31}$'),
+    currency TEXT NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    min_balance NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (min_balance >= 0),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product, currency)
+);
@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS interest_postings;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_customer_balance_within_overdraft;
+ALTER TABLE accounts
+    ADD CONSTRAINT accounts_customer_balance_non_negative CHECK (is_system OR balance >= 0);
+ALTER TABLE accounts DROP COLUMN IF EXISTS overdraft_limit;
+ALTER TABLE account_products
+    DROP CONSTRAINT IF EXISTS account_products_minimum_or_overdraft,
+    DROP COLUMN IF EXISTS max_debit,
+    DROP COLUMN IF EXISTS withdrawal_fee,
+    DROP COLUMN IF EXISTS transfer_fee,
+    DROP COLUMN IF EXISTS overdraft_limit,
+    DROP CONSTRAINT IF EXISTS account_products_product_fkey;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_product_fkey;
+ALTER TABLE accounts ALTER COLUMN product SET DEFAULT 'standard';
+UPDATE accounts SET product = 'standard' WHERE product = 'current';
+UPDATE account_products SET product = 'standard' WHERE product = 'current';
+DROP TABLE IF EXISTS products;
+-- The 'interest' enum value is left in place: entries may reference it.
@@ -0,0 +1,63 @@
+DO $$ BEGIN
+    ALTER TYPE operation_type ADD VALUE IF NOT EXISTS 'interest';
+END $$;
+
+-- Account types. Currency-independent rules live here; amounts are per currency in account_products.
+CREATE TABLE IF NOT EXISTS products (
+    code TEXT PRIMARY KEY CHECK (code ~ '^[a-z][a-z0-9_]{0,31}$'),
+    name TEXT NOT NULL,
+    -- Annual rate paid monthly on positive balances, in basis points.
+    interest_rate_bps INTEGER NOT NULL DEFAULT 0 CHECK (interest_rate_bps BETWEEN 0 AND 10000),
+    withdrawals_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    transfers_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by UUID REFERENCES users(id),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Escrow balances only move through the escrow flow, never by the holder directly.
+INSERT INTO products (code, name, withdrawals_enabled, transfers_enabled) VALUES
+    ('current', 'Current account', TRUE, TRUE),
+    ('savings', 'Savings account', TRUE, TRUE),
+    ('escrow', 'Escrow account', FALSE, FALSE),
+    ('merchant', 'Merchant account', TRUE, TRUE)
+ON CONFLICT (code) DO NOTHING;
+
+-- 000028 called the default product "standard".
+INSERT INTO products (code, name)
+SELECT DISTINCT product, product FROM account_products WHERE product <> 'standard'
+ON CONFLICT (code) DO NOTHING;
+UPDATE account_products SET product = 'current' WHERE product = 'standard';
+UPDATE accounts SET product = 'current' WHERE product = 'standard';
+ALTER TABLE accounts ALTER COLUMN product SET DEFAULT 'current';
+ALTER TABLE accounts
+    ADD CONSTRAINT accounts_product_fkey FOREIGN KEY (product) REFERENCES products(code);
+ALTER TABLE account_products
+    ADD CONSTRAINT account_products_product_fkey FOREIGN KEY (product) REFERENCES products(code);
+
+-- Per-currency amounts. Fees are posted as extra legs to the per-currency Fee Income system account;
+-- max_debit caps a single withdrawal or transfer (NULL means no cap).
+ALTER TABLE account_products
+    ADD COLUMN IF NOT EXISTS overdraft_limit NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (overdraft_limit >= 0),
+    ADD COLUMN IF NOT EXISTS transfer_fee NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (transfer_fee >= 0),
+    ADD COLUMN IF NOT EXISTS withdrawal_fee NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (withdrawal_fee >= 0),
+    ADD COLUMN IF NOT EXISTS max_debit NUMERIC(19,4) CHECK (max_debit > 0),
+    ADD CONSTRAINT account_products_minimum_or_overdraft CHECK (min_balance = 0 OR overdraft_limit = 0);
+
+-- The database enforces each account's overdraft, copied from its product rule, instead of zero.
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS overdraft_limit NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (overdraft_limit >= 0);
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_customer_balance_non_negative;
+ALTER TABLE accounts
+    ADD CONSTRAINT accounts_customer_balance_within_overdraft CHECK (is_system OR balance + overdraft_limit >= 0);
+
+-- One interest payment per account and month, so a retried run never pays twice.
+CREATE TABLE IF NOT EXISTS interest_postings (
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    balance NUMERIC(19,4) NOT NULL,
+    rate_bps INTEGER NOT NULL,
+    amount NUMERIC(19,4) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, period_start)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS escrows;
+-- PostgreSQL cannot drop enum values; 'escrow' stays on operation_type.
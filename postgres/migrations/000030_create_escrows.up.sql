@@ -0,0 +1,28 @@
+-- Wrapped like the CREATE TYPE in 000003 (PostgreSQL 12+ allows ADD VALUE here).
+DO $$ BEGIN
+    ALTER TYPE operation_type ADD VALUE IF NOT EXISTS 'escrow';
+END $$;
+
+-- Money a buyer has put aside for a seller. The funds sit in the Escrow Holding system account
+-- of the currency until the escrow is released to the seller or refunded to the buyer.
+CREATE TABLE IF NOT EXISTS escrows (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id),
+    buyer_account_id UUID NOT NULL REFERENCES accounts(id),
+    seller_account_id UUID NOT NULL REFERENCES accounts(id),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    description TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'funded' CHECK (status IN ('funded', 'released', 'refunded')),
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    funding_transaction_id UUID NOT NULL REFERENCES transactions(id),
+    settled_by UUID REFERENCES users(id),
+    settled_at TIMESTAMP WITH TIME ZONE,
+    settlement_note TEXT NOT NULL DEFAULT '',
+    settlement_transaction_id UUID REFERENCES transactions(id),
+    CHECK (buyer_account_id <> seller_account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_escrows_buyer ON escrows(buyer_account_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_escrows_seller ON escrows(seller_account_id, created_at DESC);
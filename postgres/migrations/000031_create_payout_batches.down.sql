@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_transfer_jobs_batch_row;
+ALTER TABLE transfer_jobs
+    DROP COLUMN IF EXISTS narration,
+    DROP COLUMN IF EXISTS batch_row,
+    DROP COLUMN IF EXISTS batch_id;
+DROP TABLE IF EXISTS payout_batches;
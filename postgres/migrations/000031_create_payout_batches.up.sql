@@ -0,0 +1,22 @@
+-- A CSV of payouts from one account. Each row runs as its own queued transfer, so rows
+-- succeed or fail independently and the batch's progress is read from its transfer jobs.
+CREATE TABLE IF NOT EXISTS payout_batches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_account_id UUID NOT NULL REFERENCES accounts(id),
+    created_by UUID NOT NULL REFERENCES users(id),
+    filename TEXT NOT NULL DEFAULT '',
+    row_count INTEGER NOT NULL CHECK (row_count > 0),
+    total_amount NUMERIC(19,4) NOT NULL CHECK (total_amount > 0),
+    currency TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_batches_source ON payout_batches(source_account_id, created_at DESC);
+
+-- Rows of a batch are transfer jobs tagged with the batch and their line in the file.
+ALTER TABLE transfer_jobs
+    ADD COLUMN IF NOT EXISTS batch_id UUID REFERENCES payout_batches(id),
+    ADD COLUMN IF NOT EXISTS batch_row INTEGER,
+    ADD COLUMN IF NOT EXISTS narration TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transfer_jobs_batch_row ON transfer_jobs(batch_id, batch_row) WHERE batch_id IS NOT NULL;
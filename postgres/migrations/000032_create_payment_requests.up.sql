@@ -0,0 +1,22 @@
+-- A request for money into an account, shared with the payer as a link carrying token.
+-- Paying it posts an ordinary transfer from the payer's account under transaction_id.
+CREATE TABLE IF NOT EXISTS payment_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token TEXT NOT NULL UNIQUE,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    memo TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid', 'cancelled')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    payer_account_id UUID REFERENCES accounts(id),
+    paid_by UUID REFERENCES users(id),
+    paid_at TIMESTAMP WITH TIME ZONE,
+    transaction_id UUID REFERENCES transactions(id),
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    CHECK (payer_account_id IS NULL OR payer_account_id <> account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_requests_account ON payment_requests(account_id, created_at DESC);
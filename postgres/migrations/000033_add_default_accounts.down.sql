@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_users_org_phone;
+ALTER TABLE users DROP COLUMN IF EXISTS default_account_id;
@@ -0,0 +1,7 @@
+-- The account that receives transfers addressed to a user by email or phone.
+-- When unset, the user's oldest primary-owned account is used.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS default_account_id UUID REFERENCES accounts(id) ON DELETE SET NULL;
+
+-- Phone lookups for transfers are scoped to an organization.
+CREATE INDEX IF NOT EXISTS idx_users_org_phone ON users(org_id, phone) WHERE phone IS NOT NULL;
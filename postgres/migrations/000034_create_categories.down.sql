@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_entries_account_debits;
+DROP TABLE IF EXISTS entry_categories;
+DROP TABLE IF EXISTS category_rules;
+DROP TABLE IF EXISTS categories;
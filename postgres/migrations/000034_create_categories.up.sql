@@ -0,0 +1,39 @@
+-- Spending categories are personal: each user labels the entries of the accounts they can see.
+CREATE TABLE IF NOT EXISTS categories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_user_name ON categories(user_id, LOWER(name));
+
+-- A rule files an entry under its category when the entry's description contains the pattern
+-- (case-insensitive) or the other side of its transaction is the counterparty account. Rules
+-- are tried in priority order; the first match wins.
+CREATE TABLE IF NOT EXISTS category_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    description_pattern TEXT,
+    counterparty_account_id UUID REFERENCES accounts(id) ON DELETE CASCADE,
+    priority INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((description_pattern IS NULL) <> (counterparty_account_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_rules_user ON category_rules(user_id, priority, created_at);
+
+-- Manual assignments override rules. Entries are immutable, so the label lives beside them.
+CREATE TABLE IF NOT EXISTS entry_categories (
+    entry_id UUID NOT NULL REFERENCES entries(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entry_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entry_categories_category ON entry_categories(category_id);
+
+-- Spending analytics scan an account's debits over a period.
+CREATE INDEX IF NOT EXISTS idx_entries_account_debits ON entries(account_id, created_at) WHERE debit > 0;
@@ -0,0 +1,23 @@
+-- A savings goal gives a sub-wallet a target. Money stays in the wallet, so progress is its
+-- balance. While locked_until is in the future the wallet cannot be debited. An optional
+-- contribution moves contribution_amount from the parent account every week or month.
+CREATE TABLE IF NOT EXISTS savings_goals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_id UUID NOT NULL UNIQUE REFERENCES accounts(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    target_amount NUMERIC(19,4) NOT NULL CHECK (target_amount > 0),
+    target_date DATE,
+    locked_until DATE,
+    contribution_amount NUMERIC(19,4) CHECK (contribution_amount > 0),
+    contribution_interval TEXT CHECK (contribution_interval IN ('weekly', 'monthly')),
+    next_contribution_at TIMESTAMP WITH TIME ZONE,
+    last_contribution_error TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((contribution_amount IS NULL) = (contribution_interval IS NULL)),
+    CHECK ((contribution_amount IS NULL) = (next_contribution_at IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_savings_goals_next_contribution ON savings_goals(next_contribution_at)
+    WHERE next_contribution_at IS NOT NULL;
@@ -0,0 +1,55 @@
+-- Wrapped like the CREATE TYPE in 000003 (PostgreSQL 12+ allows ADD VALUE here).
+DO $$ BEGIN
+    ALTER TYPE operation_type ADD VALUE IF NOT EXISTS 'loan';
+END $$;
+
+-- A loan disbursed into a borrower's account. The amount owed sits as a debit on the Loans
+-- Receivable system account of the currency until repayments credit it back.
+CREATE TABLE IF NOT EXISTS loans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    principal NUMERIC(19,4) NOT NULL CHECK (principal > 0),
+    currency TEXT NOT NULL,
+    annual_rate_bps INT NOT NULL CHECK (annual_rate_bps >= 0),
+    term_months INT NOT NULL CHECK (term_months > 0),
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paid_off')),
+    -- Delinquency follows the oldest unpaid installment: current, late (1-29 days past due),
+    -- delinquent (30-89) or defaulted (90+).
+    delinquency TEXT NOT NULL DEFAULT 'current' CHECK (delinquency IN ('current', 'late', 'delinquent', 'defaulted')),
+    days_past_due INT NOT NULL DEFAULT 0,
+    disbursement_transaction_id UUID NOT NULL REFERENCES transactions(id),
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    paid_off_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_loans_account ON loans(account_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_loans_active ON loans(id) WHERE status = 'active';
+
+-- The amortization schedule, fixed at origination. Repayments fill installments oldest first,
+-- interest before principal.
+CREATE TABLE IF NOT EXISTS loan_installments (
+    loan_id UUID NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    number INT NOT NULL CHECK (number > 0),
+    due_date DATE NOT NULL,
+    principal_due NUMERIC(19,4) NOT NULL CHECK (principal_due >= 0),
+    interest_due NUMERIC(19,4) NOT NULL CHECK (interest_due >= 0),
+    principal_paid NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (principal_paid >= 0 AND principal_paid <= principal_due),
+    interest_paid NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (interest_paid >= 0 AND interest_paid <= interest_due),
+    paid_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (loan_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS loan_repayments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    principal NUMERIC(19,4) NOT NULL,
+    interest NUMERIC(19,4) NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_loan_repayments_loan ON loan_repayments(loan_id, created_at DESC);
@@ -0,0 +1,12 @@
+-- Balance bands of a product's interest rate in one currency. A band starts at min_balance and
+-- runs up to the next band's; the part of a balance inside a band earns that band's rate, and
+-- the part below the lowest band earns the product's interest_rate_bps.
+CREATE TABLE IF NOT EXISTS interest_tiers (
+    product TEXT NOT NULL REFERENCES products(code) ON DELETE CASCADE,
+    currency TEXT NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    min_balance NUMERIC(19,4) NOT NULL CHECK (min_balance > 0),
+    rate_bps INTEGER NOT NULL CHECK (rate_bps BETWEEN 0 AND 10000),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product, currency, min_balance)
+);
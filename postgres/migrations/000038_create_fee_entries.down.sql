@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS transaction_reversals;
+DROP TABLE IF EXISTS fee_entries;
@@ -0,0 +1,37 @@
+-- Fee legs are posted under the transaction they pay for. Marking them lets a reversal tell the
+-- operation's own entries from its fees, and refund the fees or keep them.
+CREATE TABLE IF NOT EXISTS fee_entries (
+    entry_id UUID PRIMARY KEY REFERENCES entries(id) ON DELETE CASCADE,
+    transaction_id UUID NOT NULL REFERENCES transactions(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fee_entries_transaction ON fee_entries(transaction_id);
+
+-- Backfill: the Fee Income credit of each fee, and the matching "... fee" debit of the payer.
+INSERT INTO fee_entries (entry_id, transaction_id)
+SELECT e.id, e.transaction_id
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+WHERE a.is_system AND a.name = 'Fee Income' AND e.credit > 0
+ON CONFLICT (entry_id) DO NOTHING;
+
+INSERT INTO fee_entries (entry_id, transaction_id)
+SELECT e.id, e.transaction_id
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+WHERE NOT a.is_system
+  AND e.debit > 0
+  AND e.description IN ('Transfer fee', 'Withdrawal fee')
+  AND EXISTS (SELECT 1 FROM fee_entries f WHERE f.transaction_id = e.transaction_id)
+ON CONFLICT (entry_id) DO NOTHING;
+
+-- One reversal per transaction, recording whether its fees were refunded with it.
+CREATE TABLE IF NOT EXISTS transaction_reversals (
+    transaction_id UUID PRIMARY KEY REFERENCES transactions(id),
+    reversal_transaction_id UUID NOT NULL UNIQUE REFERENCES transactions(id),
+    fees_refunded BOOLEAN NOT NULL,
+    fee_amount NUMERIC(19,4) NOT NULL DEFAULT 0,
+    reason TEXT NOT NULL,
+    reversed_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tax_postings;
+DROP TABLE IF EXISTS tax_rules;
@@ -0,0 +1,27 @@
+-- Taxes withheld from customer credits of an operation, e.g. 10% withholding tax on interest.
+-- Each active rule posts its share of the credit from the customer to the per-currency
+-- Tax Payable system account under the same transaction.
+CREATE TABLE IF NOT EXISTS tax_rules (
+    code TEXT PRIMARY KEY CHECK (code ~ '^[a-z][a-z0-9_]{0,31}$'),
+    name TEXT NOT NULL,
+    operation_type TEXT NOT NULL CHECK (operation_type IN ('interest')),
+    rate_bps INTEGER NOT NULL CHECK (rate_bps BETWEEN 1 AND 10000),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by UUID NOT NULL REFERENCES users(id),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- What each rule withheld from each taxed transaction, for the tax report.
+CREATE TABLE IF NOT EXISTS tax_postings (
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    rule_code TEXT NOT NULL REFERENCES tax_rules(code),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    currency TEXT NOT NULL,
+    base_amount NUMERIC(19,4) NOT NULL CHECK (base_amount > 0),
+    rate_bps INTEGER NOT NULL,
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (transaction_id, rule_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tax_postings_created ON tax_postings(created_at);
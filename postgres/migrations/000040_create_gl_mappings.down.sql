@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS gl_customer_codes;
+DROP TABLE IF EXISTS gl_account_codes;
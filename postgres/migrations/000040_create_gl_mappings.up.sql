@@ -0,0 +1,17 @@
+-- External general ledger codes for the corporate books. Each system account maps to its own
+-- GL account; customer accounts are one liability per currency (customer deposits).
+CREATE TABLE IF NOT EXISTS gl_account_codes (
+    account_id UUID PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+    gl_code TEXT NOT NULL,
+    gl_name TEXT NOT NULL,
+    updated_by UUID NOT NULL REFERENCES users(id),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS gl_customer_codes (
+    currency TEXT PRIMARY KEY CHECK (currency ~ '^[A-Z]{3}$'),
+    gl_code TEXT NOT NULL,
+    gl_name TEXT NOT NULL,
+    updated_by UUID NOT NULL REFERENCES users(id),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS entries_transaction_balanced ON entries;
+DROP FUNCTION IF EXISTS check_transaction_balanced();
@@ -0,0 +1,36 @@
+-- Every transaction's entries must net to zero in each currency. The check is a deferred
+-- constraint trigger, so the legs of a posting can be inserted one by one and are judged
+-- together at commit; a code path that forgets a leg fails to commit instead of corrupting
+-- the ledger. Fails on commit with SQLSTATE 23514 and constraint entries_transaction_balanced.
+CREATE OR REPLACE FUNCTION check_transaction_balanced() RETURNS trigger AS $$
+DECLARE
+    tx_id UUID;
+    unbalanced TEXT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        tx_id := OLD.transaction_id;
+    ELSE
+        tx_id := NEW.transaction_id;
+    END IF;
+
+    SELECT a.currency INTO unbalanced
+    FROM entries e
+    JOIN accounts a ON a.id = e.account_id
+    WHERE e.transaction_id = tx_id
+    GROUP BY a.currency
+    HAVING SUM(e.debit) <> SUM(e.credit)
+    LIMIT 1;
+
+    IF unbalanced IS NOT NULL THEN
+        RAISE EXCEPTION 'transaction % does not balance in %', tx_id, unbalanced
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'entries_transaction_balanced';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS entries_transaction_balanced ON entries;
+CREATE CONSTRAINT TRIGGER entries_transaction_balanced
+    AFTER INSERT OR UPDATE OR DELETE ON entries
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_transaction_balanced();
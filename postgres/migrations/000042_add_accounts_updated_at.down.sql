@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS accounts_touch_updated_at ON accounts;
+DROP FUNCTION IF EXISTS touch_accounts_updated_at();
+ALTER TABLE accounts DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,16 @@
+-- updated_at moves on every change to an account row, including each balance update, so
+-- account reads can be validated with an ETag instead of being re-sent.
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE OR REPLACE FUNCTION touch_accounts_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at := clock_timestamp();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS accounts_touch_updated_at ON accounts;
+CREATE TRIGGER accounts_touch_updated_at
+    BEFORE UPDATE ON accounts
+    FOR EACH ROW EXECUTE FUNCTION touch_accounts_updated_at();
@@ -0,0 +1,6 @@
+-- The ID of the HTTP request that created a transaction (X-Request-Id), so a user's complaint can
+-- be traced from the request logs to the exact rows. Background jobs and rows written before this
+-- column existed have none.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS request_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_request_id ON transactions(request_id) WHERE request_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS adjustment_lines;
+DROP TABLE IF EXISTS adjustments;
+-- PostgreSQL cannot drop enum values; 'adjustment' stays on operation_type.
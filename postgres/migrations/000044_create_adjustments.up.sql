@@ -0,0 +1,36 @@
+-- Wrapped like the CREATE TYPE in 000003 (PostgreSQL 12+ allows ADD VALUE here).
+DO $$ BEGIN
+    ALTER TYPE operation_type ADD VALUE IF NOT EXISTS 'adjustment';
+END $$;
+
+-- Corrective journals posted by admins. Each row is the audit record of who asked for the
+-- adjustment and why, and, under dual control, which second admin approved or rejected it.
+CREATE TABLE IF NOT EXISTS adjustments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reason_code TEXT NOT NULL,
+    memo TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'posted', 'rejected')),
+    requested_by UUID NOT NULL REFERENCES users(id),
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Null when the adjustment was posted without dual control.
+    decided_by UUID REFERENCES users(id),
+    decided_at TIMESTAMP WITH TIME ZONE,
+    decision_note TEXT NOT NULL DEFAULT '',
+    -- Set once the journal is posted.
+    transaction_id UUID REFERENCES transactions(id),
+    CHECK (decided_by IS NULL OR decided_by <> requested_by)
+);
+
+CREATE INDEX IF NOT EXISTS idx_adjustments_status ON adjustments(status, requested_at);
+
+-- The journal's lines, posted as entries in line order once the adjustment is approved.
+CREATE TABLE IF NOT EXISTS adjustment_lines (
+    adjustment_id UUID NOT NULL REFERENCES adjustments(id) ON DELETE CASCADE,
+    line_no INTEGER NOT NULL,
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    debit NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (debit >= 0),
+    credit NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (credit >= 0),
+    description TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (adjustment_id, line_no),
+    CHECK ((debit > 0) <> (credit > 0))
+);
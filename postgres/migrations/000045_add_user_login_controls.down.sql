@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS password_reset_required,
+    DROP COLUMN IF EXISTS locked_reason,
+    DROP COLUMN IF EXISTS locked_at;
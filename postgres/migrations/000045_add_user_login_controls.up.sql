@@ -0,0 +1,6 @@
+-- Admin controls over a user's logins: a locked user cannot log in until unlocked, and a user
+-- whose password an admin reset must choose a new one before logging in again.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS locked_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS locked_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,22 @@
+-- Moving an account, with its sub-wallets and history, from its primary owner to another user of
+-- the organization, e.g. to an estate's executor or a company's new director. A request waits
+-- for an admin other than its requester, and the row stays as the audit record of the change.
+CREATE TABLE IF NOT EXISTS ownership_transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    from_user_id UUID NOT NULL REFERENCES users(id),
+    to_user_id UUID NOT NULL REFERENCES users(id),
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    requested_by UUID NOT NULL REFERENCES users(id),
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    decided_by UUID REFERENCES users(id),
+    decided_at TIMESTAMP WITH TIME ZONE,
+    decision_note TEXT NOT NULL DEFAULT '',
+    CHECK (from_user_id <> to_user_id),
+    CHECK (decided_by IS NULL OR decided_by <> requested_by)
+);
+
+-- At most one open request per account.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ownership_transfers_pending ON ownership_transfers(account_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_ownership_transfers_status ON ownership_transfers(status, requested_at);
@@ -0,0 +1,22 @@
+-- Right to erasure: a request waits out a grace period during which it can be cancelled, then a
+-- background job anonymizes the user's personal data. Accounts, transactions and entries stay
+-- for accounting retention, and the row stays as the audit record of the erasure.
+CREATE TABLE IF NOT EXISTS user_erasures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'cancelled', 'completed')),
+    reason TEXT NOT NULL DEFAULT '',
+    requested_by UUID NOT NULL REFERENCES users(id),
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    erase_after TIMESTAMP WITH TIME ZONE NOT NULL,
+    cancelled_by UUID REFERENCES users(id),
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- At most one open request per user.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_erasures_pending ON user_erasures(user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_user_erasures_due ON user_erasures(erase_after) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_user_erasures_status ON user_erasures(status, requested_at);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erased_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_users_org_phone_index;
+ALTER TABLE users DROP COLUMN IF EXISTS phone_index;
@@ -0,0 +1,6 @@
+-- Phones and KYC document details may now be sealed by the application (see internal/pii), so
+-- phones are found by a blind index instead of their value. Rows written before encryption was
+-- turned on keep a plaintext phone and no index until the pii.rekey job seals them.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone_index TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_users_org_phone_index ON users(org_id, phone_index) WHERE phone_index IS NOT NULL;
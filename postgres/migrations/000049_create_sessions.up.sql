@@ -0,0 +1,14 @@
+-- Server-side sessions: every token issued at login carries its session id as the jti claim, so
+-- logging out or an admin action can revoke it before it expires.
+CREATE TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_active ON sessions(user_id, created_at DESC) WHERE revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sessions_expires ON sessions(expires_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS login_attempts;
+ALTER TABLE users DROP COLUMN IF EXISTS login_locked_until;
+ALTER TABLE users DROP COLUMN IF EXISTS failed_logins;
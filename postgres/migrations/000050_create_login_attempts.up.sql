@@ -0,0 +1,20 @@
+-- Brute-force protection: consecutive failed logins lock a user out for a while, and every attempt
+-- is recorded so repeated failures from one address can be throttled and audited.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS failed_logins INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS login_locked_until TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID REFERENCES organizations(id),
+    email TEXT NOT NULL,
+    user_id UUID REFERENCES users(id),
+    ip_address TEXT NOT NULL,
+    succeeded BOOLEAN NOT NULL,
+    -- Why a failed attempt failed: invalid_credentials, locked or throttled.
+    failure_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip_failed ON login_attempts(ip_address, created_at) WHERE NOT succeeded;
+CREATE INDEX IF NOT EXISTS idx_login_attempts_user ON login_attempts(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_created ON login_attempts(created_at DESC);
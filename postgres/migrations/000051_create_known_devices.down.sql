@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS known_devices;
+ALTER TABLE login_attempts DROP COLUMN IF EXISTS new_device;
+ALTER TABLE login_attempts DROP COLUMN IF EXISTS device_id;
+ALTER TABLE login_attempts DROP COLUMN IF EXISTS user_agent;
@@ -0,0 +1,16 @@
+-- Login audit for users: every attempt records the client's user agent and a device fingerprint,
+-- and the devices a user has logged in from are remembered so a login from a new one can be
+-- reported to them.
+ALTER TABLE login_attempts ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE login_attempts ADD COLUMN IF NOT EXISTS device_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE login_attempts ADD COLUMN IF NOT EXISTS new_device BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS known_devices (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device_id TEXT NOT NULL,
+    user_agent TEXT NOT NULL,
+    ip_address TEXT NOT NULL,
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, device_id)
+);
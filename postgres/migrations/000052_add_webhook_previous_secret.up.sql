@@ -0,0 +1,4 @@
+-- Webhook secret rotation: the replaced secret keeps signing deliveries alongside the new one
+-- until previous_secret_expires_at, so receivers can switch secrets without dropping events.
+ALTER TABLE webhook_endpoints ADD COLUMN IF NOT EXISTS previous_secret TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhook_endpoints ADD COLUMN IF NOT EXISTS previous_secret_expires_at TIMESTAMP WITH TIME ZONE;
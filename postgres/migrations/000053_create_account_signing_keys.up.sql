@@ -0,0 +1,15 @@
+-- Request signing: owners register Ed25519 public keys on an account, and transfers from it above
+-- the configured amount must be signed with one of them as well as carry a bearer token.
+CREATE TABLE IF NOT EXISTS account_signing_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    name TEXT NOT NULL DEFAULT '',
+    -- Base64 of the 32-byte Ed25519 public key.
+    public_key TEXT NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_signing_keys_account ON account_signing_keys(account_id) WHERE revoked_at IS NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS aml_alerts;
+
+UPDATE users SET role = 'customer' WHERE role = 'compliance';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('customer', 'org_admin', 'approver', 'admin'));
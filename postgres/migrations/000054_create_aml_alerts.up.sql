@@ -0,0 +1,29 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('customer', 'org_admin', 'approver', 'compliance', 'admin'));
+
+-- AML screening: money movements that broke a rule, and compliance staff's review of them.
+-- Flagged operations were posted; held ones wait here until released or rejected; blocked ones
+-- were refused.
+CREATE TABLE IF NOT EXISTS aml_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    -- The other account of a transfer, which a held transfer is released to.
+    counterparty_id UUID REFERENCES accounts(id),
+    operation TEXT NOT NULL,
+    direction TEXT NOT NULL CHECK (direction IN ('in', 'out')),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('flag', 'hold', 'block')),
+    rules TEXT[] NOT NULL,
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'released', 'rejected', 'cleared', 'reported')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_by UUID REFERENCES users(id),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT NOT NULL DEFAULT '',
+    -- Set when the operation was posted: at once when flagged, on release when held.
+    transaction_id UUID
+);
+
+CREATE INDEX IF NOT EXISTS idx_aml_alerts_org_status ON aml_alerts(org_id, status, created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS sanctions_screenings;
+
+ALTER TABLE users DROP COLUMN IF EXISTS screening_status;
@@ -0,0 +1,28 @@
+-- Sanctions screening: users are screened when they register and beneficiaries of external transfers
+-- before money is sent. A hit leaves the subject pending review by compliance staff.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS screening_status TEXT NOT NULL DEFAULT 'clear'
+    CHECK (screening_status IN ('clear', 'pending_review', 'blocked'));
+
+CREATE TABLE IF NOT EXISTS sanctions_screenings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id),
+    subject_type TEXT NOT NULL CHECK (subject_type IN ('user', 'beneficiary')),
+    -- Identifies the subject for caching: the user, or the beneficiary's bank, account and name.
+    subject_key TEXT NOT NULL,
+    name TEXT NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    bank_code TEXT NOT NULL DEFAULT '',
+    account_number TEXT NOT NULL DEFAULT '',
+    provider TEXT NOT NULL,
+    matches JSONB NOT NULL DEFAULT '[]',
+    -- Set when the provider could not screen the name, which is reviewed like a hit.
+    error TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('clear', 'pending_review', 'cleared', 'confirmed')),
+    screened_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_by UUID REFERENCES users(id),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_sanctions_screenings_subject ON sanctions_screenings(org_id, subject_key, screened_at DESC);
+CREATE INDEX IF NOT EXISTS idx_sanctions_screenings_org_status ON sanctions_screenings(org_id, status, screened_at);
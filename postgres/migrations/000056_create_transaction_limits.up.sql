@@ -0,0 +1,12 @@
+-- Per-transaction caps set by admins, by currency, operation and the owner's KYC level. A zero
+-- cap blocks the operation at that level; combinations without a row fall back to the built-in
+-- KYC limits for withdrawals and payouts and are otherwise unlimited.
+CREATE TABLE IF NOT EXISTS transaction_limits (
+    currency TEXT NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    operation TEXT NOT NULL CHECK (operation IN ('deposit', 'withdrawal', 'transfer', 'payout')),
+    kyc_level SMALLINT NOT NULL CHECK (kyc_level BETWEEN 0 AND 3),
+    max_amount NUMERIC(19,4) NOT NULL CHECK (max_amount >= 0),
+    updated_by UUID NOT NULL REFERENCES users(id),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (currency, operation, kyc_level)
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS known_beneficiaries;
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS risk_factors,
+    DROP COLUMN IF EXISTS risk_decision,
+    DROP COLUMN IF EXISTS risk_score;
@@ -0,0 +1,16 @@
+-- Fraud risk assessment of the operation that created a transaction, for the admin transaction
+-- view. Operations posted without scoring leave risk_score NULL; a posted "challenge" passed step-up.
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS risk_score SMALLINT CHECK (risk_score BETWEEN 0 AND 100),
+    ADD COLUMN IF NOT EXISTS risk_decision TEXT CHECK (risk_decision IN ('allow', 'challenge', 'review')),
+    ADD COLUMN IF NOT EXISTS risk_factors TEXT[] NOT NULL DEFAULT '{}';
+
+-- Beneficiaries each account has paid, so a first payment to a new one can be scored. beneficiary
+-- is "account:<id>" for internal transfers and "bank:<code>:<number>" for bank payouts.
+CREATE TABLE IF NOT EXISTS known_beneficiaries (
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    beneficiary TEXT NOT NULL,
+    first_paid_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_paid_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, beneficiary)
+);
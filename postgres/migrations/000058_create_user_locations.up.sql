@@ -0,0 +1,10 @@
+-- Countries each user's authenticated requests came from, so a request from a new country shortly
+-- after activity elsewhere can be flagged and money operations from it stepped up.
+CREATE TABLE IF NOT EXISTS user_locations (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    country CHAR(2) NOT NULL,
+    ip_address TEXT NOT NULL DEFAULT '',
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, country)
+);
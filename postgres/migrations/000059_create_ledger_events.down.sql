@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS ledger_events_immutable ON ledger_events;
+DROP FUNCTION IF EXISTS refuse_ledger_event_change();
+DROP TABLE IF EXISTS ledger_events;
@@ -0,0 +1,52 @@
+-- The ledger's event log: every posting and every transaction status change is appended here
+-- first, in the transaction that applies it, and transactions, entries and cached account balances
+-- are projections of it. Events are immutable; updating or deleting one fails.
+CREATE TABLE IF NOT EXISTS ledger_events (
+    seq BIGSERIAL PRIMARY KEY,
+    event_type TEXT NOT NULL CHECK (event_type IN ('transaction_posted', 'transaction_status_changed')),
+    transaction_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    request_id TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_events_transaction ON ledger_events(transaction_id, seq);
+
+CREATE OR REPLACE FUNCTION refuse_ledger_event_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger events are immutable'
+        USING ERRCODE = 'check_violation', CONSTRAINT = 'ledger_events_immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ledger_events_immutable ON ledger_events;
+CREATE TRIGGER ledger_events_immutable
+    BEFORE UPDATE OR DELETE ON ledger_events
+    FOR EACH ROW EXECUTE FUNCTION refuse_ledger_event_change();
+
+-- Seed the log with the history posted before it existed: one transaction_posted event per
+-- transaction, in posting order, carrying its legs and its current status.
+INSERT INTO ledger_events (event_type, transaction_id, payload, request_id, created_at)
+SELECT 'transaction_posted',
+       t.id,
+       jsonb_build_object(
+           'operation_type', t.operation_type,
+           'status', t.status,
+           'legs', COALESCE((
+               SELECT jsonb_agg(jsonb_build_object(
+                          'entry_id', e.id,
+                          'account_id', e.account_id,
+                          'debit', e.debit::text,
+                          'credit', e.credit::text,
+                          'description', COALESCE(e.description, ''),
+                          'fee', EXISTS (SELECT 1 FROM fee_entries f WHERE f.entry_id = e.id)
+                      ) ORDER BY e.created_at, e.id)
+               FROM entries e
+               WHERE e.transaction_id = t.id
+           ), '[]'::jsonb)
+       ),
+       t.request_id,
+       t.created_at
+FROM transactions t
+WHERE NOT EXISTS (SELECT 1 FROM ledger_events le WHERE le.transaction_id = t.id)
+ORDER BY t.created_at, t.id;
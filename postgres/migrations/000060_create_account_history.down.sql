@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS account_history;
+DROP FUNCTION IF EXISTS entry_category(UUID, UUID);
+DROP FUNCTION IF EXISTS account_display_name(UUID);
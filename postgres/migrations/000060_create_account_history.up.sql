@@ -0,0 +1,84 @@
+-- Read model of account history: one row per entry with what a statement shows beside it, kept
+-- up to date by the posting path so GET /accounts/{id}/entries needs no joins or recomputation.
+-- balance_after is the account's balance once the entry posted, in (created_at, entry_id) order;
+-- the counterparty is the largest opposite leg of the transaction on another account; the
+-- category is the one the account's primary owner files the entry under.
+CREATE TABLE IF NOT EXISTS account_history (
+    entry_id UUID PRIMARY KEY REFERENCES entries(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    transaction_id UUID NOT NULL,
+    operation_type TEXT NOT NULL,
+    debit NUMERIC(19,4) NOT NULL,
+    credit NUMERIC(19,4) NOT NULL,
+    balance_after NUMERIC(19,4) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    counterparty_account_id UUID,
+    counterparty_name TEXT NOT NULL DEFAULT '',
+    category_id UUID,
+    category_name TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_history_account ON account_history(account_id, created_at DESC, entry_id DESC);
+CREATE INDEX IF NOT EXISTS idx_account_history_counterparty ON account_history(counterparty_account_id);
+
+-- The name a statement shows for an account: its owner's full name, else the account's name.
+CREATE OR REPLACE FUNCTION account_display_name(p_account_id UUID) RETURNS TEXT AS $$
+    SELECT COALESCE(NULLIF(btrim(COALESCE(u.first_name, '') || ' ' || COALESCE(u.last_name, '')), ''), a.name)
+    FROM accounts a
+    LEFT JOIN users u ON u.id = a.owner_id
+    WHERE a.id = p_account_id;
+$$ LANGUAGE sql STABLE;
+
+-- The category a user files an entry under: their manual assignment, else their first matching
+-- rule (see category_rules), else none.
+CREATE OR REPLACE FUNCTION entry_category(p_entry_id UUID, p_user_id UUID) RETURNS UUID AS $$
+    SELECT COALESCE(
+        (SELECT ec.category_id FROM entry_categories ec WHERE ec.entry_id = p_entry_id AND ec.user_id = p_user_id),
+        (SELECT r.category_id
+         FROM category_rules r, entries e
+         WHERE e.id = p_entry_id
+           AND r.user_id = p_user_id
+           AND (
+               e.description ILIKE '%' || replace(replace(replace(r.description_pattern, '\', '\\'), '%', '\%'), '_', '\_') || '%'
+               OR EXISTS (
+                   SELECT 1 FROM entries c
+                   WHERE c.transaction_id = e.transaction_id
+                     AND c.account_id = r.counterparty_account_id
+                     AND c.credit > 0
+               )
+           )
+         ORDER BY r.priority, r.created_at
+         LIMIT 1)
+    );
+$$ LANGUAGE sql STABLE;
+
+-- Seed the read model with the entries posted before it existed.
+INSERT INTO account_history (
+    entry_id, account_id, transaction_id, operation_type, debit, credit, balance_after,
+    description, counterparty_account_id, counterparty_name, category_id, created_at
+)
+SELECT e.id, e.account_id, e.transaction_id, e.operation_type::text, e.debit, e.credit,
+       SUM(e.credit - e.debit) OVER (PARTITION BY e.account_id ORDER BY e.created_at, e.id),
+       COALESCE(e.description, ''),
+       cp.account_id,
+       COALESCE(account_display_name(cp.account_id), ''),
+       CASE WHEN a.owner_id IS NOT NULL THEN entry_category(e.id, a.owner_id) END,
+       COALESCE(e.created_at, CURRENT_TIMESTAMP)
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+LEFT JOIN LATERAL (
+    SELECT c.account_id FROM entries c
+    WHERE c.transaction_id = e.transaction_id
+      AND c.account_id <> e.account_id
+      AND (c.credit > 0) = (e.debit > 0)
+      AND EXISTS (SELECT 1 FROM fee_entries f WHERE f.entry_id = c.id) = EXISTS (SELECT 1 FROM fee_entries f WHERE f.entry_id = e.id)
+    ORDER BY c.debit + c.credit DESC, c.id
+    LIMIT 1
+) cp ON TRUE
+ON CONFLICT (entry_id) DO NOTHING;
+
+UPDATE account_history h
+SET category_name = c.name
+FROM categories c
+WHERE c.id = h.category_id;
@@ -0,0 +1,18 @@
+-- Balance snapshots: every account's balance summed from its entries at a point in the ledger
+-- event log. Replaying from a snapshot only has to sum the entries posted after through_seq.
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    through_seq BIGINT NOT NULL,
+    accounts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS balance_snapshot_lines (
+    snapshot_id UUID NOT NULL REFERENCES balance_snapshots(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    balance NUMERIC(19,4) NOT NULL,
+    PRIMARY KEY (snapshot_id, account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_events_posted_transaction
+    ON ledger_events(transaction_id, seq) WHERE event_type = 'transaction_posted';
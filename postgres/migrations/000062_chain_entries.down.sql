@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS entries_chain ON entries;
+DROP FUNCTION IF EXISTS chain_entry();
+DROP INDEX IF EXISTS idx_entries_account_chain;
+DROP TABLE IF EXISTS entry_chain_heads;
+ALTER TABLE entries
+    DROP COLUMN IF EXISTS hash,
+    DROP COLUMN IF EXISTS prev_hash,
+    DROP COLUMN IF EXISTS chain_seq;
+DROP FUNCTION IF EXISTS entry_chain_hash(BYTEA, UUID, UUID, UUID, BIGINT, NUMERIC, NUMERIC, TEXT, TEXT, TIMESTAMP WITH TIME ZONE);
+DROP FUNCTION IF EXISTS chain_field(TEXT);
@@ -0,0 +1,101 @@
+-- Tamper evidence: each account's entries form a hash chain. An entry's hash covers its content,
+-- its position in the account's chain and the previous entry's hash, so changing, removing or
+-- reordering any entry after the fact breaks every hash after it. entry_chain_heads holds each
+-- account's latest position and hash, so dropping the newest entries is caught too.
+ALTER TABLE entries
+    ADD COLUMN IF NOT EXISTS chain_seq BIGINT,
+    ADD COLUMN IF NOT EXISTS prev_hash BYTEA,
+    ADD COLUMN IF NOT EXISTS hash BYTEA;
+
+CREATE TABLE IF NOT EXISTS entry_chain_heads (
+    account_id UUID PRIMARY KEY REFERENCES accounts(id) ON DELETE RESTRICT,
+    chain_seq BIGINT NOT NULL,
+    hash BYTEA NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- entry_chain_hash is SHA-256 over the previous hash (empty for an account's first entry), the
+-- entry, account and transaction IDs, the chain position, created_at in microseconds since the
+-- epoch, then debit, credit, operation type and description, each as a 4-byte length and its
+-- UTF-8 text (length -1 for NULL). Integers are big-endian. internal/hashchain computes the same.
+CREATE OR REPLACE FUNCTION chain_field(value TEXT) RETURNS BYTEA AS $$
+    SELECT CASE WHEN value IS NULL THEN int4send(-1)
+                ELSE int4send(octet_length(convert_to(value, 'UTF8'))) || convert_to(value, 'UTF8')
+           END;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION entry_chain_hash(
+    prev BYTEA, entry_id UUID, account UUID, tx UUID, seq BIGINT,
+    debit NUMERIC, credit NUMERIC, op TEXT, description TEXT, created TIMESTAMP WITH TIME ZONE
+) RETURNS BYTEA AS $$
+    SELECT sha256(
+        COALESCE(prev, ''::bytea)
+        || uuid_send(entry_id) || uuid_send(account) || uuid_send(tx)
+        || int8send(seq)
+        || int8send(COALESCE((EXTRACT(EPOCH FROM created) * 1000000)::bigint, 0))
+        || chain_field(debit::text) || chain_field(credit::text) || chain_field(op) || chain_field(description)
+    );
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Chain the entries posted before the chain existed, per account in posting order.
+ALTER TABLE entries DISABLE TRIGGER entries_transaction_balanced;
+DO $$
+DECLARE
+    r RECORD;
+    account UUID;
+    prev BYTEA;
+    seq BIGINT;
+BEGIN
+    FOR r IN SELECT * FROM entries ORDER BY account_id, created_at, id LOOP
+        IF account IS DISTINCT FROM r.account_id THEN
+            account := r.account_id;
+            prev := NULL;
+            seq := 0;
+        END IF;
+        seq := seq + 1;
+        UPDATE entries
+        SET chain_seq = seq,
+            prev_hash = prev,
+            hash = entry_chain_hash(prev, r.id, r.account_id, r.transaction_id, seq, r.debit, r.credit, r.operation_type::text, r.description, r.created_at)
+        WHERE id = r.id
+        RETURNING hash INTO prev;
+    END LOOP;
+END $$;
+ALTER TABLE entries ENABLE TRIGGER entries_transaction_balanced;
+
+INSERT INTO entry_chain_heads (account_id, chain_seq, hash)
+SELECT DISTINCT ON (account_id) account_id, chain_seq, hash
+FROM entries
+ORDER BY account_id, chain_seq DESC
+ON CONFLICT (account_id) DO NOTHING;
+
+ALTER TABLE entries
+    ALTER COLUMN chain_seq SET NOT NULL,
+    ALTER COLUMN hash SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_entries_account_chain ON entries(account_id, chain_seq);
+
+-- New entries are chained as they are inserted, under the account's row lock so concurrent
+-- postings to one account take consecutive positions.
+CREATE OR REPLACE FUNCTION chain_entry() RETURNS trigger AS $$
+DECLARE
+    head entry_chain_heads%ROWTYPE;
+BEGIN
+    PERFORM 1 FROM accounts WHERE id = NEW.account_id FOR NO KEY UPDATE;
+    SELECT * INTO head FROM entry_chain_heads WHERE account_id = NEW.account_id;
+    NEW.chain_seq := COALESCE(head.chain_seq, 0) + 1;
+    NEW.prev_hash := head.hash;
+    NEW.hash := entry_chain_hash(NEW.prev_hash, NEW.id, NEW.account_id, NEW.transaction_id, NEW.chain_seq,
+        NEW.debit, NEW.credit, NEW.operation_type::text, NEW.description, NEW.created_at);
+    INSERT INTO entry_chain_heads (account_id, chain_seq, hash)
+    VALUES (NEW.account_id, NEW.chain_seq, NEW.hash)
+    ON CONFLICT (account_id) DO UPDATE
+    SET chain_seq = EXCLUDED.chain_seq, hash = EXCLUDED.hash, updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS entries_chain ON entries;
+CREATE TRIGGER entries_chain
+    BEFORE INSERT ON entries
+    FOR EACH ROW EXECUTE FUNCTION chain_entry();
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS merkle_root_entries;
+DROP TABLE IF EXISTS merkle_roots;
@@ -0,0 +1,18 @@
+-- Merkle roots published over the entries. Each root covers the entries posted since the one
+-- before it, as a tree whose leaves are the entries' chain hashes in (created_at, id) order, so an
+-- inclusion proof shows an entry existed, unchanged, when its root was published.
+CREATE TABLE IF NOT EXISTS merkle_roots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    root_hash BYTEA NOT NULL,
+    leaf_count INTEGER NOT NULL CHECK (leaf_count > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_merkle_roots_created ON merkle_roots(created_at DESC, id);
+
+CREATE TABLE IF NOT EXISTS merkle_root_entries (
+    entry_id UUID PRIMARY KEY REFERENCES entries(id) ON DELETE RESTRICT,
+    root_id UUID NOT NULL REFERENCES merkle_roots(id) ON DELETE RESTRICT,
+    leaf_index INTEGER NOT NULL,
+    UNIQUE (root_id, leaf_index)
+);
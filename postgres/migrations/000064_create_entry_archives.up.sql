@@ -0,0 +1,20 @@
+-- Segments of aged entries copied to write-once object storage: one per account and calendar
+-- month (UTC), as written by internal/archive. sha256 is the segment object's digest, checked on
+-- every read. pruned_at is set once the segment's entries are removed from Postgres, after which
+-- account history is served from the segment.
+CREATE TABLE IF NOT EXISTS entry_archives (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT,
+    period DATE NOT NULL CHECK (EXTRACT(DAY FROM period) = 1),
+    object_key TEXT NOT NULL UNIQUE,
+    sha256 BYTEA NOT NULL,
+    entry_count INTEGER NOT NULL CHECK (entry_count > 0),
+    first_entry_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_entry_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    retain_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    pruned_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (account_id, period)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entry_archives_pruned ON entry_archives(account_id, period) WHERE pruned_at IS NOT NULL;
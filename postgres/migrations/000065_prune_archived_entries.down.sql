@@ -0,0 +1,10 @@
+ALTER TABLE merkle_root_entries DROP COLUMN IF EXISTS pruned_hash;
+ALTER TABLE merkle_root_entries
+    ADD CONSTRAINT merkle_root_entries_entry_id_fkey FOREIGN KEY (entry_id) REFERENCES entries(id) ON DELETE RESTRICT;
+
+DROP FUNCTION IF EXISTS pruned_balance(UUID);
+
+ALTER TABLE entry_archives
+    DROP COLUMN IF EXISTS last_hash,
+    DROP COLUMN IF EXISTS last_chain_seq,
+    DROP COLUMN IF EXISTS net_amount;
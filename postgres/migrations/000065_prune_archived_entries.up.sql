@@ -0,0 +1,43 @@
+-- Pruning removes whole archived months of entries from Postgres, marking their segments with
+-- entry_archives.pruned_at. Each segment keeps what the ledger checks need once its entries are
+-- gone: their net effect on the account's balance and the last link of the account's hash chain.
+ALTER TABLE entry_archives
+    ADD COLUMN IF NOT EXISTS net_amount NUMERIC(19,4),
+    ADD COLUMN IF NOT EXISTS last_chain_seq BIGINT,
+    ADD COLUMN IF NOT EXISTS last_hash BYTEA;
+
+-- Nothing is pruned yet, so every archived month is still in entries.
+UPDATE entry_archives a
+SET net_amount = s.net_amount,
+    last_chain_seq = s.last_chain_seq,
+    last_hash = s.last_hash
+FROM (
+    SELECT x.id,
+           SUM(e.credit - e.debit) AS net_amount,
+           MAX(e.chain_seq) AS last_chain_seq,
+           (array_agg(e.hash ORDER BY e.chain_seq DESC))[1] AS last_hash
+    FROM entry_archives x
+    JOIN entries e ON e.account_id = x.account_id
+                  AND e.created_at >= x.period::timestamp AT TIME ZONE 'UTC'
+                  AND e.created_at < (x.period + INTERVAL '1 month') AT TIME ZONE 'UTC'
+    GROUP BY x.id
+) s
+WHERE s.id = a.id;
+
+ALTER TABLE entry_archives
+    ALTER COLUMN net_amount SET NOT NULL,
+    ALTER COLUMN last_chain_seq SET NOT NULL,
+    ALTER COLUMN last_hash SET NOT NULL;
+
+-- The net of an account's pruned entries: the balance it carried into what is left in entries.
+-- Every check that sums entries into a balance adds it.
+CREATE OR REPLACE FUNCTION pruned_balance(p_account_id UUID) RETURNS NUMERIC AS $$
+    SELECT COALESCE(SUM(net_amount), 0)
+    FROM entry_archives
+    WHERE account_id = p_account_id AND pruned_at IS NOT NULL;
+$$ LANGUAGE sql STABLE;
+
+-- Merkle roots outlive the entries under them: a pruned entry's place in its tree keeps the hash
+-- it had, so the entries left under a root still prove against it.
+ALTER TABLE merkle_root_entries DROP CONSTRAINT IF EXISTS merkle_root_entries_entry_id_fkey;
+ALTER TABLE merkle_root_entries ADD COLUMN IF NOT EXISTS pruned_hash BYTEA;
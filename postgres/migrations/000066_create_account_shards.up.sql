@@ -0,0 +1,11 @@
+-- A hot system account, one nearly every posting touches, can be split into shards: system
+-- accounts of its currency whose balances add to its own. Postings lock one shard at random
+-- instead of the account's single row; the account itself is shard 0. Shards are never removed,
+-- so their entries and balances stay part of the account's total.
+CREATE TABLE IF NOT EXISTS account_shards (
+    account_id UUID PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+    shard_of UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    shard_index INT NOT NULL CHECK (shard_index > 0),
+    CONSTRAINT account_shards_not_self CHECK (account_id <> shard_of),
+    UNIQUE (shard_of, shard_index)
+);
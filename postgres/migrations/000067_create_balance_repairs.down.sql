@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS balance_repair_events;
+DROP FUNCTION IF EXISTS refuse_balance_repair_event_change();
+DROP TABLE IF EXISTS balance_repairs;
+DELETE FROM accounts WHERE is_system = TRUE AND name = 'Reconciliation Suspense'
+    AND NOT EXISTS (SELECT 1 FROM entries WHERE entries.account_id = accounts.id);
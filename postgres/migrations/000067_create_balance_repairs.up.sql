@@ -0,0 +1,42 @@
+-- Assisted repairs of accounts whose stored balance disagrees with their entries. Each proposes
+-- an adjustment booking the delta, which a second admin approves or rejects.
+CREATE TABLE IF NOT EXISTS balance_repairs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    adjustment_id UUID NOT NULL UNIQUE REFERENCES adjustments(id),
+    -- The mismatch as found when the repair was proposed; delta is stored less calculated.
+    stored_balance NUMERIC(19,4) NOT NULL,
+    calculated_balance NUMERIC(19,4) NOT NULL,
+    delta NUMERIC(19,4) NOT NULL CHECK (delta <> 0),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'posted', 'rejected')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- An account has at most one repair awaiting a decision.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_balance_repairs_pending ON balance_repairs(account_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_balance_repairs_status ON balance_repairs(status, created_at);
+
+-- The audit log of each repair: who found the mismatch and proposed the fix, who decided it, and
+-- the balances once it was posted. Rows are immutable; updating or deleting one fails.
+CREATE TABLE IF NOT EXISTS balance_repair_events (
+    id BIGSERIAL PRIMARY KEY,
+    repair_id UUID NOT NULL REFERENCES balance_repairs(id),
+    action TEXT NOT NULL CHECK (action IN ('proposed', 'approved', 'rejected', 'verified')),
+    actor_id UUID REFERENCES users(id),
+    detail JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_repair_events_repair ON balance_repair_events(repair_id, id);
+
+CREATE OR REPLACE FUNCTION refuse_balance_repair_event_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'balance repair events are immutable'
+        USING ERRCODE = 'check_violation', CONSTRAINT = 'balance_repair_events_immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS balance_repair_events_immutable ON balance_repair_events;
+CREATE TRIGGER balance_repair_events_immutable
+    BEFORE UPDATE OR DELETE ON balance_repair_events
+    FOR EACH ROW EXECUTE FUNCTION refuse_balance_repair_event_change();
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS balance_reconciliation_results;
+DROP TABLE IF EXISTS balance_reconciliation_runs;
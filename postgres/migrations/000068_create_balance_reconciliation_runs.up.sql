@@ -0,0 +1,34 @@
+-- Runs of the reconcile-all job: every account's stored balance checked against its entries, a
+-- batch at a time in account ID order. last_account_id is the last account checked, so a run that
+-- is interrupted resumes after it. At most one run is in progress.
+CREATE TABLE IF NOT EXISTS balance_reconciliation_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    status TEXT NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'completed')),
+    requested_by UUID NOT NULL REFERENCES users(id),
+    -- How many accounts existed when the run started; accounts opened since are checked too.
+    total_accounts INTEGER NOT NULL,
+    checked_accounts INTEGER NOT NULL DEFAULT 0,
+    mismatched_accounts INTEGER NOT NULL DEFAULT 0,
+    last_account_id UUID,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_balance_reconciliation_runs_running
+    ON balance_reconciliation_runs((status)) WHERE status = 'running';
+
+-- One row per account a run checked, with both balances as the run saw them.
+CREATE TABLE IF NOT EXISTS balance_reconciliation_results (
+    run_id UUID NOT NULL REFERENCES balance_reconciliation_runs(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    currency TEXT NOT NULL,
+    stored_balance NUMERIC(19,4) NOT NULL,
+    calculated_balance NUMERIC(19,4) NOT NULL,
+    matched BOOLEAN NOT NULL,
+    checked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (run_id, account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_reconciliation_results_mismatched
+    ON balance_reconciliation_results(run_id, account_id) WHERE NOT matched;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS payout_suspense_cases;
+DELETE FROM accounts WHERE is_system = TRUE AND name = 'Payout Suspense'
+    AND NOT EXISTS (SELECT 1 FROM entries WHERE entries.account_id = accounts.id);
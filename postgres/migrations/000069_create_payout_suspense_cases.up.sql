@@ -0,0 +1,23 @@
+-- Payouts the rail reported failed. Their held funds move from clearing to a per-currency
+-- Payout Suspense account, where they wait for an admin to refund the customer or retry the
+-- payout under a new reference.
+CREATE TABLE IF NOT EXISTS payout_suspense_cases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payout_id UUID NOT NULL UNIQUE REFERENCES payouts(id),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'refunded', 'retried')),
+    -- suspense_transaction_id moved the hold into suspense; resolution_transaction_id moved it out,
+    -- back to the customer or into clearing for retry_payout_id.
+    suspense_transaction_id UUID NOT NULL,
+    resolution_transaction_id UUID,
+    retry_payout_id UUID REFERENCES payouts(id),
+    resolved_by UUID REFERENCES users(id),
+    resolution_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_suspense_cases_status ON payout_suspense_cases(status, created_at);
@@ -0,0 +1,14 @@
+-- Partial refunds of a posted transfer, deposit or withdrawal. Each is a reversal posting of part
+-- of the original's amount; together they may not exceed it, and the refund that reaches it marks
+-- the original reversed.
+CREATE TABLE IF NOT EXISTS transaction_refunds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    refund_transaction_id UUID NOT NULL UNIQUE REFERENCES transactions(id),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL,
+    refunded_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_refunds_transaction ON transaction_refunds(transaction_id, created_at);
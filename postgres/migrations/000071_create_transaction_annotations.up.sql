@@ -0,0 +1,13 @@
+-- Notes users attach to a transaction after the fact ("this was for March rent"). Entries are
+-- immutable, so the note lives beside them. Notes are personal to their author and append-only:
+-- there is no way to edit one, only to add another. An erased user's notes are deleted.
+CREATE TABLE IF NOT EXISTS transaction_annotations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    note TEXT NOT NULL CHECK (note <> ''),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_annotations_transaction ON transaction_annotations(transaction_id, user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_transaction_annotations_user ON transaction_annotations(user_id);
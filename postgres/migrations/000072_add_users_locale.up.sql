@@ -0,0 +1,4 @@
+-- The language a user reads API errors and notifications in. Empty means no preference: requests
+-- follow Accept-Language and notifications are sent in English.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT ''
+    CHECK (locale IN ('', 'en', 'yo', 'ha', 'ig', 'fr'));
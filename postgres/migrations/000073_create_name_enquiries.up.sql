@@ -0,0 +1,14 @@
+-- Name enquiries confirm who owns an account number before paying it. Each is recorded so a user
+-- or address probing many numbers can be throttled, and the probing audited.
+CREATE TABLE IF NOT EXISTS name_enquiries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ip_address TEXT NOT NULL,
+    account_number TEXT NOT NULL,
+    found BOOLEAN NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_name_enquiries_user ON name_enquiries(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_name_enquiries_ip ON name_enquiries(ip_address, created_at);
+CREATE INDEX IF NOT EXISTS idx_name_enquiries_created ON name_enquiries(created_at);
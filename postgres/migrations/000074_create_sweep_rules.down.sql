@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sweep_executions;
+DROP TABLE IF EXISTS sweep_rules;
@@ -0,0 +1,31 @@
+-- A sweep rule moves whatever an account holds above threshold to a target account in the same
+-- currency, daily or weekly at midnight UTC. Each run is kept in sweep_executions: the amount
+-- swept and its transaction, or why nothing moved.
+CREATE TABLE IF NOT EXISTS sweep_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    target_account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    threshold NUMERIC(19,4) NOT NULL CHECK (threshold >= 0),
+    frequency TEXT NOT NULL CHECK (frequency IN ('daily', 'weekly')),
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (account_id <> target_account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sweep_rules_account ON sweep_rules(account_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_sweep_rules_next_run ON sweep_rules(next_run_at);
+
+CREATE TABLE IF NOT EXISTS sweep_executions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    rule_id UUID NOT NULL REFERENCES sweep_rules(id) ON DELETE CASCADE,
+    status TEXT NOT NULL CHECK (status IN ('swept', 'skipped', 'failed')),
+    amount NUMERIC(19,4) NOT NULL DEFAULT 0,
+    transaction_id UUID,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((status = 'swept') = (transaction_id IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_sweep_executions_rule ON sweep_executions(rule_id, created_at DESC);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS cash_pool_movements;
+DROP TABLE IF EXISTS cash_pool_members;
+DROP TABLE IF EXISTS cash_pools;
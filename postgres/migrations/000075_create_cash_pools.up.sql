@@ -0,0 +1,35 @@
+-- Cash pooling concentrates a business's liquidity in a master account. Members are zero-balance
+-- accounts: at end of day whatever a member holds above its target moves up to the master and any
+-- shortfall below it is covered from the master, and members can be funded from the master on
+-- demand. An account belongs to at most one pool, as master or member.
+CREATE TABLE IF NOT EXISTS cash_pools (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    master_account_id UUID NOT NULL UNIQUE REFERENCES accounts(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS cash_pool_members (
+    pool_id UUID NOT NULL REFERENCES cash_pools(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL UNIQUE REFERENCES accounts(id) ON DELETE CASCADE,
+    target_balance NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (target_balance >= 0),
+    added_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (pool_id, account_id)
+);
+
+-- Every posting a pool makes: sweep (member to master at end of day), cover (master to member at
+-- end of day) or fund (master to member on demand).
+CREATE TABLE IF NOT EXISTS cash_pool_movements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    pool_id UUID NOT NULL REFERENCES cash_pools(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('sweep', 'cover', 'fund')),
+    amount NUMERIC(19,4) NOT NULL CHECK (amount > 0),
+    transaction_id UUID NOT NULL,
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cash_pool_movements_pool ON cash_pool_movements(pool_id, created_at DESC);
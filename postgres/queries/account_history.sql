@@ -0,0 +1,74 @@
+-- name: ProjectAccountHistory :exec
+-- Adds the entries of a transaction just posted to the read model. The accounts are still locked
+-- and hold their balances after the posting, so an entry's balance_after is that balance less the
+-- account's legs that follow it in the transaction.
+INSERT INTO account_history (
+    entry_id, account_id, transaction_id, operation_type, debit, credit, balance_after,
+    description, counterparty_account_id, counterparty_name, category_id, category_name, created_at
+)
+SELECT e.id, e.account_id, e.transaction_id, e.operation_type::text, e.debit, e.credit,
+       a.balance - COALESCE((
+           SELECT SUM(l.credit - l.debit) FROM entries l
+           WHERE l.transaction_id = e.transaction_id
+             AND l.account_id = e.account_id
+             AND (l.created_at, l.id) > (e.created_at, e.id)
+       ), 0),
+       COALESCE(e.description, ''),
+       cp.account_id,
+       COALESCE(account_display_name(cp.account_id), '')::text,
+       cat.id,
+       COALESCE(cat.name, ''),
+       COALESCE(e.created_at, CURRENT_TIMESTAMP)
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+LEFT JOIN LATERAL (
+    SELECT c.account_id FROM entries c
+    WHERE c.transaction_id = e.transaction_id
+      AND c.account_id <> e.account_id
+      AND (c.credit > 0) = (e.debit > 0)
+      AND EXISTS (SELECT 1 FROM fee_entries f WHERE f.entry_id = c.id) = EXISTS (SELECT 1 FROM fee_entries f WHERE f.entry_id = e.id)
+    ORDER BY c.debit + c.credit DESC, c.id
+    LIMIT 1
+) cp ON TRUE
+LEFT JOIN categories cat ON a.owner_id IS NOT NULL AND cat.id = entry_category(e.id, a.owner_id)::uuid
+WHERE e.transaction_id = sqlc.arg(transaction_id);
+
+-- name: ListAccountHistory :many
+-- sort is one of created_at, -created_at (newest first), amount or -amount; any other value
+-- falls through to newest first.
+SELECT * FROM account_history
+WHERE account_id = sqlc.arg(account_id)
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::text = 'created_at' THEN created_at END ASC,
+    CASE WHEN sqlc.arg(sort)::text = 'created_at' THEN entry_id END ASC,
+    CASE WHEN sqlc.arg(sort)::text = 'amount' THEN debit + credit END ASC,
+    CASE WHEN sqlc.arg(sort)::text = '-amount' THEN debit + credit END DESC,
+    created_at DESC, entry_id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: RefreshEntryHistoryCategory :exec
+-- Re-files one entry in the read model after its primary owner categorized it by hand.
+UPDATE account_history h
+SET category_id = entry_category(h.entry_id, a.owner_id)::uuid,
+    category_name = COALESCE((SELECT c.name FROM categories c WHERE c.id = entry_category(h.entry_id, a.owner_id)), '')
+FROM accounts a
+WHERE h.entry_id = sqlc.arg(entry_id)
+  AND a.id = h.account_id
+  AND a.owner_id = sqlc.arg(user_id)::uuid;
+
+-- name: RefreshUserHistoryCategories :exec
+-- Re-files the history of every account the user is primary owner of after their rules or
+-- categories changed.
+UPDATE account_history h
+SET category_id = entry_category(h.entry_id, a.owner_id)::uuid,
+    category_name = COALESCE((SELECT c.name FROM categories c WHERE c.id = entry_category(h.entry_id, a.owner_id)), '')
+FROM accounts a
+WHERE a.id = h.account_id
+  AND a.owner_id = sqlc.arg(user_id)::uuid;
+
+-- name: RefreshCounterpartyNames :exec
+-- Re-reads the counterparty name of history rows naming one of the user's accounts, e.g. once
+-- the user was erased.
+UPDATE account_history h
+SET counterparty_name = COALESCE(account_display_name(h.counterparty_account_id), '')
+WHERE h.counterparty_account_id IN (SELECT id FROM accounts WHERE owner_id = sqlc.arg(user_id)::uuid);
@@ -0,0 +1,44 @@
+-- name: AddAccountOwner :one
+INSERT INTO account_owners (account_id, user_id, role, added_by)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (account_id, user_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING *;
+
+-- name: GetAccountAccessRole :one
+-- Sub-wallets resolve to their parent's owners.
+SELECT ao.role FROM account_owners ao
+WHERE ao.user_id = sqlc.arg(user_id)
+  AND ao.account_id = COALESCE(
+      (SELECT a.parent_account_id FROM accounts a WHERE a.id = sqlc.arg(account_id)),
+      sqlc.arg(account_id)
+  )
+LIMIT 1;
+
+-- name: ListAccountOwners :many
+SELECT ao.account_id, ao.user_id, ao.role, ao.added_by, ao.created_at, u.email, u.first_name, u.last_name
+FROM account_owners ao
+JOIN users u ON u.id = ao.user_id
+WHERE ao.account_id = $1
+ORDER BY ao.created_at;
+
+-- name: RemoveAccountOwner :execrows
+DELETE FROM account_owners
+WHERE account_id = $1 AND user_id = $2;
+
+-- name: ListAccountsForUser :many
+-- Accounts the user owns or co-owns, including sub-wallets of those accounts. sort is one of
+-- created_at, -created_at (newest first), name, -name, balance or -balance; any other value
+-- falls through to newest first.
+SELECT a.* FROM accounts a
+WHERE EXISTS (
+    SELECT 1 FROM account_owners ao
+    WHERE ao.user_id = sqlc.arg(user_id)
+      AND ao.account_id IN (a.id, a.parent_account_id)
+)
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::text = 'created_at' THEN a.created_at END ASC,
+    CASE WHEN sqlc.arg(sort)::text = 'name' THEN a.name END ASC,
+    CASE WHEN sqlc.arg(sort)::text = '-name' THEN a.name END DESC,
+    CASE WHEN sqlc.arg(sort)::text = 'balance' THEN a.balance END ASC,
+    CASE WHEN sqlc.arg(sort)::text = '-balance' THEN a.balance END DESC,
+    a.created_at DESC, a.id DESC;
@@ -0,0 +1,44 @@
+-- name: EnsureAccountShards :execrows
+-- Creates whichever of shards 1 to shard_count - 1 of a system account are missing, each a system
+-- account of its currency named after it, and returns how many it created.
+WITH parent AS (
+    SELECT id, name, currency FROM accounts
+    WHERE id = sqlc.arg(account_id) AND is_system
+), wanted AS (
+    SELECT p.id AS shard_of, n AS shard_index, p.name || ' (shard ' || n || ')' AS name, p.currency
+    FROM parent p, generate_series(1, sqlc.arg(shard_count)::int - 1) AS n
+    WHERE NOT EXISTS (SELECT 1 FROM account_shards s WHERE s.shard_of = p.id AND s.shard_index = n)
+), created AS (
+    INSERT INTO accounts (name, balance, currency, is_system)
+    SELECT name, 0.0000, currency, TRUE FROM wanted
+    ON CONFLICT (name, currency) WHERE is_system DO NOTHING
+    RETURNING id, name
+)
+INSERT INTO account_shards (account_id, shard_of, shard_index)
+SELECT c.id, w.shard_of, w.shard_index
+FROM created c
+JOIN wanted w ON w.name = c.name
+ON CONFLICT DO NOTHING;
+
+-- name: GetSettlementShardForUpdate :one
+-- Locks one shard of the settlement account, picked at random, to post against. Shard 0 is the
+-- account itself, so an account without shards is always the one picked.
+WITH settlement AS (
+    SELECT id FROM accounts
+    WHERE is_system = TRUE AND name = 'Settlement Account'
+    LIMIT 1
+), pick AS (
+    SELECT id FROM settlement
+    UNION ALL
+    SELECT s.account_id FROM account_shards s JOIN settlement ON s.shard_of = settlement.id
+    ORDER BY random()
+    LIMIT 1
+)
+SELECT a.* FROM accounts a
+JOIN pick ON pick.id = a.id
+FOR UPDATE OF a;
+
+-- name: ListAccountShards :many
+SELECT account_id FROM account_shards
+WHERE shard_of = $1
+ORDER BY shard_index;
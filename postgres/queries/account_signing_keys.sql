@@ -0,0 +1,25 @@
+-- name: CreateAccountSigningKey :one
+INSERT INTO account_signing_keys (account_id, name, public_key, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListAccountSigningKeys :many
+SELECT * FROM account_signing_keys
+WHERE account_id = $1 AND revoked_at IS NULL
+ORDER BY created_at;
+
+-- name: GetActiveAccountSigningKey :one
+SELECT * FROM account_signing_keys
+WHERE id = $1 AND account_id = $2 AND revoked_at IS NULL;
+
+-- name: TouchAccountSigningKey :exec
+UPDATE account_signing_keys
+SET last_used_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: RevokeAccountSigningKey :one
+-- Revoking an already revoked key keeps its original revoked_at.
+UPDATE account_signing_keys
+SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+WHERE id = $1 AND account_id = $2
+RETURNING *;
@@ -1,6 +1,6 @@
 -- name: CreateAccount :one
-INSERT INTO accounts (owner_id, name, currency, is_system)
-VALUES ($1, $2, $3, $4)
+INSERT INTO accounts (owner_id, name, currency, is_system, org_id, product, overdraft_limit)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: GetAccount :one
@@ -24,6 +24,17 @@ UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2;
 
+-- name: UpdateAccountBalances :exec
+-- Moves each account's cached balance by the sum of its deltas, in one statement.
+UPDATE accounts a
+SET balance = a.balance + d.delta
+FROM (
+    SELECT m.account_id, SUM(m.delta) AS delta
+    FROM unnest(sqlc.arg(account_ids)::uuid[], sqlc.arg(deltas)::numeric[]) AS m(account_id, delta)
+    GROUP BY m.account_id
+) d
+WHERE a.id = d.account_id;
+
 -- name: GetSettlementAccount :one
 SELECT * FROM accounts
 WHERE is_system = TRUE AND name = 'Settlement Account'
@@ -36,6 +47,151 @@ LIMIT 1
 FOR UPDATE; -- lock prevents concurrent transactions from reading a stale balance.
 
 -- name: GetAccountBalance :one
-SELECT CAST((COALESCE(SUM(credit), 0::NUMERIC) - COALESCE(SUM(debit), 0::NUMERIC)) AS NUMERIC(19,4)) AS calculated_balance
+-- The account's balance summed from its entries, carried forward from those pruned to the archive.
+SELECT CAST((pruned_balance($1) + COALESCE(SUM(credit), 0::NUMERIC) - COALESCE(SUM(debit), 0::NUMERIC)) AS NUMERIC(19,4)) AS calculated_balance
+FROM entries
+WHERE account_id = $1;
+
+-- name: GetAccountBalanceBefore :one
+-- Carries forward the pruned months that ended by before_time; a pruned month's own entries are
+-- only in the archive.
+SELECT CAST((
+    COALESCE((
+        SELECT SUM(x.net_amount) FROM entry_archives x
+        WHERE x.account_id = sqlc.arg(account_id)
+          AND x.pruned_at IS NOT NULL
+          AND (x.period + INTERVAL '1 month') AT TIME ZONE 'UTC' <= sqlc.arg(before_time)
+    ), 0::NUMERIC)
+    + COALESCE(SUM(credit), 0::NUMERIC) - COALESCE(SUM(debit), 0::NUMERIC)
+) AS NUMERIC(19,4)) AS balance
 FROM entries
-WHERE account_id = $1;
\ No newline at end of file
+WHERE account_id = sqlc.arg(account_id)
+  AND created_at < sqlc.arg(before_time);
+
+-- name: GetPayoutClearingAccountForUpdate :one
+SELECT * FROM accounts
+WHERE is_system = TRUE AND name = 'Payouts In Transit'
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetAccountByVirtualNumberForUpdate :one
+SELECT * FROM accounts
+WHERE virtual_account_number = $1 AND is_system = FALSE
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetSuspenseAccountForUpdate :one
+SELECT * FROM accounts
+WHERE is_system = TRUE AND name = 'Unapplied Receipts'
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListSettlementCurrencies :many
+-- Currencies customer money can enter and leave the ledger in: those of a settlement account with
+-- a payout clearing account beside it.
+SELECT DISTINCT s.currency FROM accounts s
+JOIN accounts c ON c.is_system = TRUE AND c.name = 'Payouts In Transit' AND c.currency = s.currency
+WHERE s.is_system = TRUE AND s.name = 'Settlement Account'
+ORDER BY s.currency;
+
+-- name: CreateSubWallet :one
+INSERT INTO accounts (owner_id, name, currency, is_system, org_id, parent_account_id)
+VALUES (sqlc.arg(owner_id), sqlc.arg(name), sqlc.arg(currency), FALSE, sqlc.arg(org_id), sqlc.arg(parent_account_id))
+RETURNING *;
+
+-- name: ListSubWallets :many
+SELECT * FROM accounts
+WHERE parent_account_id = $1
+ORDER BY created_at;
+
+-- name: CountSubWallets :one
+SELECT COUNT(*) FROM accounts
+WHERE parent_account_id = $1;
+
+-- name: GetRolledUpBalance :one
+-- Parent balance plus every sub-wallet balance.
+SELECT CAST(COALESCE(SUM(balance), 0::NUMERIC) AS NUMERIC(19,4)) AS total_balance
+FROM accounts
+WHERE id = $1 OR parent_account_id = $1;
+
+-- name: GetDisputesHoldingAccountForUpdate :one
+SELECT * FROM accounts
+WHERE is_system = TRUE AND name = 'Disputes Holding'
+LIMIT 1
+FOR UPDATE;
+
+-- name: EnsureSystemAccount :exec
+-- Creates a per-currency system account (e.g., FX Position in NGN) unless it already exists.
+INSERT INTO accounts (name, balance, currency, is_system)
+VALUES ($1, 0.0000, $2, TRUE)
+ON CONFLICT (name, currency) WHERE is_system DO NOTHING;
+
+-- name: GetSystemAccountForUpdate :one
+SELECT * FROM accounts
+WHERE is_system = TRUE AND name = $1 AND currency = $2
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetAccountByVirtualNumber :one
+SELECT * FROM accounts
+WHERE virtual_account_number = $1 AND is_system = FALSE
+LIMIT 1;
+
+-- name: GetDefaultAccount :one
+-- The user's chosen default account, else their oldest primary-owned top-level account.
+SELECT a.* FROM accounts a
+JOIN users u ON u.id = a.owner_id
+WHERE a.owner_id = $1 AND a.is_system = FALSE AND a.parent_account_id IS NULL
+ORDER BY (a.id = u.default_account_id) IS TRUE DESC, a.created_at
+LIMIT 1;
+
+-- name: SearchAccounts :many
+-- The admin account browser. Every filter is optional; owner_email matches the primary owner
+-- case-insensitively, and the balance bounds are inclusive. sort takes the same keys as
+-- ListAccountsForUser. Shards of a split system account are not listed; their balances are
+-- added to the account's total_balance, which the balance filters and sorts use.
+SELECT sqlc.embed(a), u.email AS owner_email,
+       CAST(a.balance + shards.balance AS NUMERIC(19,4)) AS total_balance
+FROM accounts a
+LEFT JOIN users u ON u.id = a.owner_id
+LEFT JOIN LATERAL (
+    SELECT COALESCE(SUM(s.balance), 0) AS balance
+    FROM account_shards sh
+    JOIN accounts s ON s.id = sh.account_id
+    WHERE sh.shard_of = a.id
+) shards ON TRUE
+WHERE NOT EXISTS (SELECT 1 FROM account_shards x WHERE x.account_id = a.id)
+  AND (sqlc.narg(owner_email)::text IS NULL OR lower(u.email) = lower(sqlc.narg(owner_email)::text))
+  AND (sqlc.narg(currency)::text IS NULL OR a.currency = sqlc.narg(currency)::text)
+  AND (sqlc.narg(min_balance)::numeric IS NULL OR a.balance + shards.balance >= sqlc.narg(min_balance)::numeric)
+  AND (sqlc.narg(max_balance)::numeric IS NULL OR a.balance + shards.balance <= sqlc.narg(max_balance)::numeric)
+  AND (sqlc.narg(is_system)::boolean IS NULL OR a.is_system = sqlc.narg(is_system)::boolean)
+  AND (sqlc.narg(product)::text IS NULL OR a.product = sqlc.narg(product)::text)
+  AND (sqlc.narg(org_id)::uuid IS NULL OR a.org_id = sqlc.narg(org_id)::uuid)
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::text = 'created_at' THEN a.created_at END ASC,
+    CASE WHEN sqlc.arg(sort)::text = 'name' THEN a.name END ASC,
+    CASE WHEN sqlc.arg(sort)::text = '-name' THEN a.name END DESC,
+    CASE WHEN sqlc.arg(sort)::text = 'balance' THEN a.balance + shards.balance END ASC,
+    CASE WHEN sqlc.arg(sort)::text = '-balance' THEN a.balance + shards.balance END DESC,
+    a.created_at DESC, a.id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountSearchAccounts :one
+SELECT COUNT(*)
+FROM accounts a
+LEFT JOIN users u ON u.id = a.owner_id
+LEFT JOIN LATERAL (
+    SELECT COALESCE(SUM(s.balance), 0) AS balance
+    FROM account_shards sh
+    JOIN accounts s ON s.id = sh.account_id
+    WHERE sh.shard_of = a.id
+) shards ON TRUE
+WHERE NOT EXISTS (SELECT 1 FROM account_shards x WHERE x.account_id = a.id)
+  AND (sqlc.narg(owner_email)::text IS NULL OR lower(u.email) = lower(sqlc.narg(owner_email)::text))
+  AND (sqlc.narg(currency)::text IS NULL OR a.currency = sqlc.narg(currency)::text)
+  AND (sqlc.narg(min_balance)::numeric IS NULL OR a.balance + shards.balance >= sqlc.narg(min_balance)::numeric)
+  AND (sqlc.narg(max_balance)::numeric IS NULL OR a.balance + shards.balance <= sqlc.narg(max_balance)::numeric)
+  AND (sqlc.narg(is_system)::boolean IS NULL OR a.is_system = sqlc.narg(is_system)::boolean)
+  AND (sqlc.narg(product)::text IS NULL OR a.product = sqlc.narg(product)::text)
+  AND (sqlc.narg(org_id)::uuid IS NULL OR a.org_id = sqlc.narg(org_id)::uuid);
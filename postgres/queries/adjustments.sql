@@ -0,0 +1,45 @@
+-- name: CreateAdjustment :one
+INSERT INTO adjustments (reason_code, memo, requested_by)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: CreateAdjustmentLine :one
+INSERT INTO adjustment_lines (adjustment_id, line_no, account_id, debit, credit, description)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetAdjustment :one
+SELECT * FROM adjustments
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetAdjustmentForUpdate :one
+SELECT * FROM adjustments
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListAdjustmentLines :many
+SELECT * FROM adjustment_lines
+WHERE adjustment_id = $1
+ORDER BY line_no;
+
+-- name: ListAdjustmentsByStatus :many
+SELECT * FROM adjustments
+WHERE status = $1
+ORDER BY requested_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: CountAdjustmentsByStatus :one
+SELECT COUNT(*) FROM adjustments
+WHERE status = $1;
+
+-- name: DecideAdjustment :one
+UPDATE adjustments
+SET status = sqlc.arg(status),
+    decided_by = sqlc.narg(decided_by),
+    decided_at = CURRENT_TIMESTAMP,
+    decision_note = sqlc.arg(decision_note),
+    transaction_id = sqlc.narg(transaction_id)
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
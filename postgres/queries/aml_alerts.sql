@@ -0,0 +1,34 @@
+-- name: ListAccountActivitySince :many
+-- An account's movements since a time, oldest first, for AML screening.
+SELECT debit, credit, created_at FROM entries
+WHERE account_id = sqlc.arg(account_id) AND created_at >= sqlc.arg(since)::timestamptz
+ORDER BY created_at
+LIMIT 1000;
+
+-- name: CreateAMLAlert :one
+INSERT INTO aml_alerts (org_id, account_id, counterparty_id, operation, direction, amount, currency, action, rules, reason, transaction_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetAMLAlertForUpdate :one
+-- Scoped by org so compliance staff only review their own tenant's alerts.
+SELECT * FROM aml_alerts
+WHERE id = $1 AND org_id = $2
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListAMLAlertsByStatus :many
+SELECT * FROM aml_alerts
+WHERE org_id = $1 AND status = $2
+ORDER BY created_at
+LIMIT $3 OFFSET $4;
+
+-- name: ReviewAMLAlert :one
+UPDATE aml_alerts
+SET status = sqlc.arg(status),
+    reviewed_by = sqlc.arg(reviewed_by),
+    reviewed_at = CURRENT_TIMESTAMP,
+    review_note = sqlc.arg(review_note),
+    transaction_id = COALESCE(sqlc.narg(transaction_id), transaction_id)
+WHERE id = sqlc.arg(id) AND status = 'open'
+RETURNING *;
@@ -0,0 +1,14 @@
+-- name: CreateTransactionAnnotation :one
+INSERT INTO transaction_annotations (transaction_id, user_id, note)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListTransactionAnnotations :many
+-- A user's notes on a transaction, oldest first.
+SELECT * FROM transaction_annotations
+WHERE transaction_id = $1 AND user_id = $2
+ORDER BY created_at, id;
+
+-- name: DeleteUserAnnotations :exec
+DELETE FROM transaction_annotations
+WHERE user_id = $1;
@@ -0,0 +1,195 @@
+-- Logical backups: each Dump query reads a page of a table's rows as JSON after the last key of
+-- the previous page, and each Restore query inserts a batch of dumped rows, a JSON array.
+
+-- name: GetLatestLedgerEventSeq :one
+SELECT COALESCE(MAX(seq), 0)::bigint AS seq FROM ledger_events;
+
+-- name: DumpOrganizations :many
+SELECT t.id, row_to_json(t)::text AS data FROM organizations t
+WHERE t.id > sqlc.arg(after)::uuid
+ORDER BY t.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: DumpUsers :many
+SELECT t.id, row_to_json(t)::text AS data FROM users t
+WHERE t.id > sqlc.arg(after)::uuid
+ORDER BY t.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: DumpProducts :many
+SELECT t.code, row_to_json(t)::text AS data FROM products t
+WHERE t.code > sqlc.arg(after)::text
+ORDER BY t.code
+LIMIT sqlc.arg(row_limit);
+
+-- name: DumpAccounts :many
+SELECT t.id, row_to_json(t)::text AS data FROM accounts t
+WHERE t.id > sqlc.arg(after)::uuid
+ORDER BY t.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: DumpAccountShards :many
+SELECT t.account_id, row_to_json(t)::text AS data FROM account_shards t
+WHERE t.account_id > sqlc.arg(after)::uuid
+ORDER BY t.account_id
+LIMIT sqlc.arg(row_limit);
+
+-- name: DumpTransactions :many
+SELECT t.id, row_to_json(t)::text AS data FROM transactions t
+WHERE t.id > sqlc.arg(after)::uuid
+ORDER BY t.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: DumpEntryArchives :many
+SELECT t.id, row_to_json(t)::text AS data FROM entry_archives t
+WHERE t.id > sqlc.arg(after)::uuid
+ORDER BY t.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: DumpEntries :many
+-- Entries in chain order, account by account, so a restore chains them afresh in the same order.
+SELECT t.account_id, t.chain_seq, row_to_json(t)::text AS data FROM entries t
+WHERE (t.account_id, t.chain_seq) > (sqlc.arg(after_account_id)::uuid, sqlc.arg(after_seq)::bigint)
+ORDER BY t.account_id, t.chain_seq
+LIMIT sqlc.arg(row_limit);
+
+-- name: DumpEntryChainHeads :many
+SELECT t.account_id, row_to_json(t)::text AS data FROM entry_chain_heads t
+WHERE t.account_id > sqlc.arg(after)::uuid
+ORDER BY t.account_id
+LIMIT sqlc.arg(row_limit);
+
+-- name: DumpAccountHistory :many
+SELECT t.entry_id, row_to_json(t)::text AS data FROM account_history t
+WHERE t.entry_id > sqlc.arg(after)::uuid
+ORDER BY t.entry_id
+LIMIT sqlc.arg(row_limit);
+
+-- name: DumpLedgerEvents :many
+SELECT t.seq, row_to_json(t)::text AS data FROM ledger_events t
+WHERE t.seq > sqlc.arg(after)::bigint
+ORDER BY t.seq
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetRestoreTargetUsage :one
+-- What a database holds that a restore would collide with: a restore needs one with neither.
+SELECT (SELECT COUNT(*) FROM users) AS users,
+       (SELECT COUNT(*) FROM entries) AS entries;
+
+-- name: DeleteSeedAccounts :execrows
+-- Removes the system accounts migrations seed, which a restore brings back with their dumped IDs.
+-- Call only on a ledger with no entries.
+DELETE FROM accounts;
+
+-- name: DeleteSeedOrganizations :execrows
+-- Removes the default organization migrations seed, as DeleteSeedAccounts does the accounts.
+DELETE FROM organizations;
+
+-- name: RestoreOrganizations :execrows
+INSERT INTO organizations
+SELECT * FROM jsonb_populate_recordset(NULL::organizations, sqlc.arg(batch)::jsonb);
+
+-- name: RestoreUsers :execrows
+-- Inserts the users without their default accounts, which SetRestoredDefaultAccounts sets once the
+-- accounts are in.
+INSERT INTO users
+SELECT u.*
+FROM jsonb_array_elements(sqlc.arg(batch)::jsonb) AS r(doc),
+     LATERAL jsonb_populate_record(NULL::users, r.doc - 'default_account_id') AS u;
+
+-- name: RestoreProducts :execrows
+-- Products are seeded by migrations too and referenced by their configuration, so the dumped ones
+-- replace the seeded ones in place.
+INSERT INTO products
+SELECT * FROM jsonb_populate_recordset(NULL::products, sqlc.arg(batch)::jsonb)
+ON CONFLICT (code) DO UPDATE
+SET name = EXCLUDED.name,
+    interest_rate_bps = EXCLUDED.interest_rate_bps,
+    withdrawals_enabled = EXCLUDED.withdrawals_enabled,
+    transfers_enabled = EXCLUDED.transfers_enabled,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: RestoreAccounts :execrows
+-- Inserts the accounts without their parents, which SetRestoredAccountParents sets once every
+-- account is in.
+INSERT INTO accounts
+SELECT a.*
+FROM jsonb_array_elements(sqlc.arg(batch)::jsonb) AS r(doc),
+     LATERAL jsonb_populate_record(NULL::accounts, r.doc - 'parent_account_id') AS a;
+
+-- name: SetRestoredDefaultAccounts :execrows
+UPDATE users u
+SET default_account_id = d.account_id
+FROM unnest(sqlc.arg(user_ids)::uuid[], sqlc.arg(account_ids)::uuid[]) AS d(user_id, account_id)
+WHERE u.id = d.user_id;
+
+-- name: SetRestoredAccountParents :execrows
+UPDATE accounts a
+SET parent_account_id = d.parent_id
+FROM unnest(sqlc.arg(account_ids)::uuid[], sqlc.arg(parent_ids)::uuid[]) AS d(account_id, parent_id)
+WHERE a.id = d.account_id;
+
+-- name: RestoreAccountShards :execrows
+INSERT INTO account_shards
+SELECT * FROM jsonb_populate_recordset(NULL::account_shards, sqlc.arg(batch)::jsonb);
+
+-- name: RestoreTransactions :execrows
+INSERT INTO transactions
+SELECT * FROM jsonb_populate_recordset(NULL::transactions, sqlc.arg(batch)::jsonb);
+
+-- name: RestoreEntryArchives :execrows
+INSERT INTO entry_archives
+SELECT * FROM jsonb_populate_recordset(NULL::entry_archives, sqlc.arg(batch)::jsonb);
+
+-- name: SeedRestoredEntryChainHeads :execrows
+-- Starts the chain of each account with pruned entries at its last pruned link, as pruning left
+-- it, so its restored entries chain on from there.
+INSERT INTO entry_chain_heads (account_id, chain_seq, hash)
+SELECT DISTINCT ON (account_id) account_id, last_chain_seq, last_hash
+FROM entry_archives
+WHERE pruned_at IS NOT NULL
+ORDER BY account_id, period DESC;
+
+-- name: RestoreEntries :many
+-- Inserts the entries in order, which chains each one afresh, and returns the IDs of those whose
+-- recomputed hash is not the one they were dumped with.
+WITH dumped AS (
+    SELECT e.*, r.ord
+    FROM jsonb_array_elements(sqlc.arg(batch)::jsonb) WITH ORDINALITY AS r(doc, ord),
+         LATERAL jsonb_populate_record(NULL::entries, r.doc) AS e
+), inserted AS (
+    INSERT INTO entries (id, account_id, debit, credit, transaction_id, operation_type, description, created_at, chain_seq, prev_hash, hash)
+    SELECT id, account_id, debit, credit, transaction_id, operation_type, description, created_at, chain_seq, prev_hash, hash
+    FROM dumped
+    ORDER BY ord
+    RETURNING id, hash
+)
+SELECT d.id FROM dumped d
+JOIN inserted i ON i.id = d.id
+WHERE i.hash IS DISTINCT FROM d.hash
+ORDER BY d.ord;
+
+-- name: ListRestoredChainHeadMismatches :many
+-- The accounts among the dumped chain heads whose restored head is missing or differs.
+SELECT d.account_id
+FROM jsonb_populate_recordset(NULL::entry_chain_heads, sqlc.arg(batch)::jsonb) AS d
+LEFT JOIN entry_chain_heads h ON h.account_id = d.account_id
+WHERE h.account_id IS NULL OR h.chain_seq <> d.chain_seq OR h.hash <> d.hash
+ORDER BY d.account_id;
+
+-- name: CountEntryChainHeads :one
+SELECT COUNT(*) FROM entry_chain_heads;
+
+-- name: RestoreAccountHistory :execrows
+INSERT INTO account_history
+SELECT * FROM jsonb_populate_recordset(NULL::account_history, sqlc.arg(batch)::jsonb);
+
+-- name: RestoreLedgerEvents :execrows
+INSERT INTO ledger_events
+SELECT * FROM jsonb_populate_recordset(NULL::ledger_events, sqlc.arg(batch)::jsonb);
+
+-- name: ResetLedgerEventSeq :exec
+-- Moves the event sequence past the restored events, so the next posting follows them.
+SELECT setval(pg_get_serial_sequence('ledger_events', 'seq'), GREATEST((SELECT MAX(seq) FROM ledger_events), 1),
+              (SELECT COUNT(*) > 0 FROM ledger_events));
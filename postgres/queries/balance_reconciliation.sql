@@ -0,0 +1,70 @@
+-- name: CreateReconciliationRun :one
+INSERT INTO balance_reconciliation_runs (requested_by, total_accounts)
+VALUES ($1, (SELECT COUNT(*) FROM accounts))
+RETURNING *;
+
+-- name: GetReconciliationRun :one
+SELECT * FROM balance_reconciliation_runs
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetReconciliationRunForUpdate :one
+SELECT * FROM balance_reconciliation_runs
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetRunningReconciliationRun :one
+SELECT * FROM balance_reconciliation_runs
+WHERE status = 'running'
+LIMIT 1;
+
+-- name: ListReconciliationRuns :many
+SELECT * FROM balance_reconciliation_runs
+ORDER BY started_at DESC, id
+LIMIT $1 OFFSET $2;
+
+-- name: CountReconciliationRuns :one
+SELECT COUNT(*) FROM balance_reconciliation_runs;
+
+-- name: ReconcileAccountBatch :many
+-- Checks the next batch of accounts after after_id, in ID order, and records each one's result.
+WITH batch AS (
+    SELECT a.id, a.currency, a.balance,
+           pruned_balance(a.id) + COALESCE((
+               SELECT SUM(e.credit - e.debit) FROM entries e WHERE e.account_id = a.id
+           ), 0) AS calculated
+    FROM accounts a
+    WHERE a.id > sqlc.arg(after_id)::uuid
+    ORDER BY a.id
+    LIMIT sqlc.arg(row_limit)
+)
+INSERT INTO balance_reconciliation_results (run_id, account_id, currency, stored_balance, calculated_balance, matched)
+SELECT sqlc.arg(run_id), id, currency, balance, calculated, balance = calculated
+FROM batch
+RETURNING *;
+
+-- name: AdvanceReconciliationRun :one
+-- Moves a run past a checked batch, completing it when the batch was the last.
+UPDATE balance_reconciliation_runs
+SET last_account_id = COALESCE(sqlc.narg(last_account_id), last_account_id),
+    checked_accounts = checked_accounts + sqlc.arg(checked),
+    mismatched_accounts = mismatched_accounts + sqlc.arg(mismatched),
+    status = CASE WHEN sqlc.arg(done)::boolean THEN 'completed' ELSE status END,
+    finished_at = CASE WHEN sqlc.arg(done)::boolean THEN CURRENT_TIMESTAMP ELSE finished_at END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListReconciliationResults :many
+-- A run's results in account ID order, or only the mismatches.
+SELECT * FROM balance_reconciliation_results
+WHERE run_id = sqlc.arg(run_id)
+  AND (NOT sqlc.arg(mismatched_only)::boolean OR NOT matched)
+ORDER BY account_id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountReconciliationResults :one
+SELECT COUNT(*) FROM balance_reconciliation_results
+WHERE run_id = sqlc.arg(run_id)
+  AND (NOT sqlc.arg(mismatched_only)::boolean OR NOT matched);
@@ -0,0 +1,49 @@
+-- name: CreateBalanceRepair :one
+INSERT INTO balance_repairs (account_id, adjustment_id, stored_balance, calculated_balance, delta)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetBalanceRepair :one
+SELECT * FROM balance_repairs
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetBalanceRepairForUpdate :one
+SELECT * FROM balance_repairs
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: CountBalanceRepairsForAdjustment :one
+SELECT COUNT(*) FROM balance_repairs
+WHERE adjustment_id = $1;
+
+-- name: CountPendingBalanceRepairs :one
+SELECT COUNT(*) FROM balance_repairs
+WHERE account_id = $1 AND status = 'pending';
+
+-- name: ListBalanceRepairsByStatus :many
+SELECT * FROM balance_repairs
+WHERE status = $1
+ORDER BY created_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: CountBalanceRepairsByStatus :one
+SELECT COUNT(*) FROM balance_repairs
+WHERE status = $1;
+
+-- name: DecideBalanceRepair :one
+UPDATE balance_repairs
+SET status = $1
+WHERE id = $2 AND status = 'pending'
+RETURNING *;
+
+-- name: AppendBalanceRepairEvent :one
+INSERT INTO balance_repair_events (repair_id, action, actor_id, detail)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListBalanceRepairEvents :many
+SELECT * FROM balance_repair_events
+WHERE repair_id = $1
+ORDER BY id;
@@ -0,0 +1,79 @@
+-- name: CreateBalanceSnapshot :one
+-- Call with the event log locked, so every posting up to through_seq has committed and none after it has.
+INSERT INTO balance_snapshots (through_seq)
+SELECT COALESCE(MAX(seq), 0) FROM ledger_events
+RETURNING *;
+
+-- name: SnapshotBalances :execrows
+INSERT INTO balance_snapshot_lines (snapshot_id, account_id, balance)
+SELECT sqlc.arg(snapshot_id)::uuid, a.id, pruned_balance(a.id) + COALESCE(SUM(e.credit - e.debit), 0)
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id;
+
+-- name: SetBalanceSnapshotAccounts :one
+UPDATE balance_snapshots
+SET accounts = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: GetBalanceSnapshot :one
+SELECT * FROM balance_snapshots
+WHERE id = $1;
+
+-- name: GetLatestBalanceSnapshot :one
+SELECT * FROM balance_snapshots
+ORDER BY through_seq DESC, created_at DESC
+LIMIT 1;
+
+-- name: ListBalanceSnapshots :many
+SELECT * FROM balance_snapshots
+ORDER BY through_seq DESC, created_at DESC
+LIMIT $1;
+
+-- name: PruneBalanceSnapshots :execrows
+-- Deletes all but the latest keep snapshots.
+DELETE FROM balance_snapshots
+WHERE id NOT IN (
+    SELECT id FROM balance_snapshots
+    ORDER BY through_seq DESC, created_at DESC
+    LIMIT sqlc.arg(keep)
+);
+
+-- name: ReplayBalances :many
+-- Every account's balance summed from its entries, starting from the snapshot's balances when one
+-- is given so only the entries posted after it are read, against the cached balance. Without a
+-- snapshot the sum starts from the balance carried by the account's pruned entries.
+WITH snapshot AS (
+    SELECT l.account_id, l.balance
+    FROM balance_snapshot_lines l
+    WHERE l.snapshot_id = sqlc.narg(snapshot_id)::uuid
+), since AS (
+    SELECT e.account_id, SUM(e.credit - e.debit) AS delta
+    FROM entries e
+    WHERE sqlc.narg(snapshot_id)::uuid IS NULL
+       OR EXISTS (
+            SELECT 1 FROM ledger_events le
+            WHERE le.transaction_id = e.transaction_id
+              AND le.event_type = 'transaction_posted'
+              AND le.seq > (SELECT s.through_seq FROM balance_snapshots s WHERE s.id = sqlc.narg(snapshot_id)::uuid)
+       )
+    GROUP BY e.account_id
+)
+SELECT a.id AS account_id,
+       a.currency,
+       a.balance::text AS stored_balance,
+       (COALESCE(sn.balance, pruned_balance(a.id)) + COALESCE(si.delta, 0))::text AS replayed_balance
+FROM accounts a
+LEFT JOIN snapshot sn ON sn.account_id = a.id
+LEFT JOIN since si ON si.account_id = a.id
+WHERE NOT sqlc.arg(drift_only)::boolean
+   OR a.balance <> COALESCE(sn.balance, pruned_balance(a.id)) + COALESCE(si.delta, 0)
+ORDER BY a.id;
+
+-- name: RepairAccountBalance :execrows
+-- Resets one cached balance to the replayed one, unless it moved since it was read.
+UPDATE accounts
+SET balance = sqlc.arg(replayed)::numeric
+WHERE id = sqlc.arg(id)
+  AND balance = sqlc.arg(stored)::numeric;
@@ -0,0 +1,66 @@
+-- name: CreateCashPool :one
+INSERT INTO cash_pools (master_account_id, name, created_by)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetCashPool :one
+SELECT * FROM cash_pools
+WHERE id = $1;
+
+-- name: ListCashPoolsForUser :many
+-- Pools whose master account the user can see.
+SELECT p.* FROM cash_pools p
+JOIN accounts a ON a.id = p.master_account_id
+WHERE EXISTS (
+    SELECT 1 FROM account_owners ao
+    WHERE ao.user_id = $1
+      AND ao.account_id IN (a.id, a.parent_account_id)
+)
+ORDER BY p.created_at;
+
+-- name: FindCashPoolByAccount :one
+-- The pool the account belongs to, as master or member.
+SELECT id FROM cash_pools WHERE master_account_id = sqlc.arg(account_id)
+UNION ALL
+SELECT pool_id FROM cash_pool_members WHERE account_id = sqlc.arg(account_id)
+LIMIT 1;
+
+-- name: AddCashPoolMember :one
+INSERT INTO cash_pool_members (pool_id, account_id, target_balance, added_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetCashPoolMember :one
+SELECT * FROM cash_pool_members
+WHERE pool_id = $1 AND account_id = $2;
+
+-- name: ListCashPoolMembers :many
+SELECT * FROM cash_pool_members
+WHERE pool_id = $1
+ORDER BY created_at;
+
+-- name: DeleteCashPoolMember :execrows
+DELETE FROM cash_pool_members
+WHERE pool_id = $1 AND account_id = $2;
+
+-- name: ListAllCashPoolMembers :many
+-- Every membership with its pool's master, for the end-of-day run.
+SELECT m.pool_id, p.master_account_id, m.account_id, m.target_balance
+FROM cash_pool_members m
+JOIN cash_pools p ON p.id = m.pool_id
+ORDER BY m.pool_id, m.created_at;
+
+-- name: CreateCashPoolMovement :one
+INSERT INTO cash_pool_movements (pool_id, account_id, kind, amount, transaction_id, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListCashPoolMovements :many
+SELECT * FROM cash_pool_movements
+WHERE pool_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountCashPoolMovements :one
+SELECT COUNT(*) FROM cash_pool_movements
+WHERE pool_id = $1;
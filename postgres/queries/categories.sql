@@ -0,0 +1,78 @@
+-- name: CreateCategory :one
+INSERT INTO categories (user_id, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: ListCategories :many
+SELECT * FROM categories
+WHERE user_id = $1
+ORDER BY LOWER(name);
+
+-- name: GetCategory :one
+SELECT * FROM categories
+WHERE id = $1 AND user_id = $2;
+
+-- name: DeleteCategory :execrows
+DELETE FROM categories
+WHERE id = $1 AND user_id = $2;
+
+-- name: CreateCategoryRule :one
+INSERT INTO category_rules (user_id, category_id, description_pattern, counterparty_account_id, priority)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListCategoryRules :many
+SELECT * FROM category_rules
+WHERE user_id = $1
+ORDER BY priority, created_at;
+
+-- name: DeleteCategoryRule :execrows
+DELETE FROM category_rules
+WHERE id = $1 AND user_id = $2;
+
+-- name: SetEntryCategory :one
+INSERT INTO entry_categories (entry_id, user_id, category_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (entry_id, user_id) DO UPDATE SET category_id = EXCLUDED.category_id, created_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ClearEntryCategory :execrows
+DELETE FROM entry_categories
+WHERE entry_id = $1 AND user_id = $2;
+
+-- name: SpendByCategory :many
+-- Debits of the account between from_time and to_time per UTC period (day, week or month) and
+-- category: the user's manual assignment, else their first matching rule, else uncategorized.
+WITH spend AS (
+    SELECT e.debit, e.created_at,
+           COALESCE(ec.category_id, (
+               SELECT r.category_id FROM category_rules r
+               WHERE r.user_id = sqlc.arg(user_id)
+                 AND (
+                     e.description ILIKE '%' || replace(replace(replace(r.description_pattern, '\', '\\'), '%', '\%'), '_', '\_') || '%'
+                     OR EXISTS (
+                         SELECT 1 FROM entries c
+                         WHERE c.transaction_id = e.transaction_id
+                           AND c.account_id = r.counterparty_account_id
+                           AND c.credit > 0
+                     )
+                 )
+               ORDER BY r.priority, r.created_at
+               LIMIT 1
+           )) AS category_id
+    FROM entries e
+    LEFT JOIN entry_categories ec ON ec.entry_id = e.id AND ec.user_id = sqlc.arg(user_id)
+    WHERE e.account_id = sqlc.arg(account_id)
+      AND e.debit > 0
+      AND e.created_at >= sqlc.arg(from_time)::timestamptz
+      AND e.created_at < sqlc.arg(to_time)::timestamptz
+)
+SELECT (date_trunc(sqlc.arg(period)::text, s.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC')::timestamptz AS period_start,
+       COALESCE(s.category_id::text, '')::text AS category_id,
+       COALESCE(c.name, '')::text AS category_name,
+       CAST(SUM(s.debit) AS NUMERIC(19,4)) AS total,
+       COUNT(*) AS entry_count
+FROM spend s
+LEFT JOIN categories c ON c.id = s.category_id
+GROUP BY 1, s.category_id, c.name
+ORDER BY 1, total DESC;
@@ -0,0 +1,41 @@
+-- name: CreateDispute :one
+INSERT INTO disputes (transaction_id, account_id, counterparty_account_id, amount, currency, reason, opened_by, hold_transaction_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetDispute :one
+SELECT * FROM disputes
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetDisputeForUpdate :one
+SELECT * FROM disputes
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListDisputesByStatus :many
+SELECT * FROM disputes
+WHERE status = $1
+ORDER BY opened_at
+LIMIT $2 OFFSET $3;
+
+-- name: ListDisputesByAccount :many
+SELECT * FROM disputes
+WHERE account_id = $1
+ORDER BY opened_at DESC;
+
+-- name: ResolveDispute :one
+UPDATE disputes
+SET status = sqlc.arg(status),
+    resolved_by = sqlc.arg(resolved_by),
+    resolved_at = CURRENT_TIMESTAMP,
+    resolution_note = sqlc.arg(resolution_note),
+    resolution_transaction_id = sqlc.arg(resolution_transaction_id)
+WHERE id = sqlc.arg(id) AND status = 'open'
+RETURNING *;
+
+-- name: GetDisputeByTransaction :one
+SELECT * FROM disputes
+WHERE transaction_id = $1
+LIMIT 1;
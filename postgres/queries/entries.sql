@@ -1,15 +1,101 @@
 -- name: CreateEntry :one
-INSERT INTO entries (account_id, debit, credit, transaction_id, operation_type, description)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO entries (id, account_id, debit, credit, transaction_id, operation_type, description)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
--- name: ListEntriesByAccount :many
-SELECT * FROM entries
-WHERE account_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+-- name: CreateEntries :many
+-- Inserts a posting's entries in one statement, chained in the order given.
+INSERT INTO entries (id, account_id, debit, credit, transaction_id, operation_type, description)
+SELECT e.id, e.account_id, e.debit, e.credit, sqlc.arg(transaction_id)::uuid, sqlc.arg(operation_type)::operation_type, NULLIF(e.description, '')
+FROM unnest(sqlc.arg(ids)::uuid[], sqlc.arg(account_ids)::uuid[], sqlc.arg(debits)::numeric[], sqlc.arg(credits)::numeric[], sqlc.arg(descriptions)::text[])
+     WITH ORDINALITY AS e(id, account_id, debit, credit, description, ord)
+ORDER BY e.ord
+RETURNING *;
+
+-- name: CountEntriesByAccount :one
+SELECT COUNT(*) FROM entries
+WHERE account_id = $1;
 
 -- name: ListEntriesByTransaction :many
 SELECT * FROM entries
 WHERE transaction_id = $1
-ORDER BY created_at;
\ No newline at end of file
+ORDER BY created_at;
+
+-- name: GetEntry :one
+SELECT * FROM entries
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListEntriesByAccountAfter :many
+-- Returns the account's entries after chain position after_seq; used to resume streams. Positions
+-- are taken while the posting holds the account's chain head, so they follow commit order.
+SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND chain_seq > sqlc.arg(after_seq)
+ORDER BY chain_seq
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListEntriesByAccountBetween :many
+SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND created_at >= sqlc.arg(from_time)
+  AND created_at < sqlc.arg(to_time)
+ORDER BY created_at, id;
+
+-- name: ListRecentRecipients :many
+-- Accounts credited by transfers debited from accounts the user owns or co-owns, most recent
+-- first. Fee legs (system accounts) and the user's own accounts are not recipients.
+SELECT a.id, a.name, a.currency, a.virtual_account_number,
+       COALESCE(u.first_name, '') AS first_name, COALESCE(u.last_name, '') AS last_name, COALESCE(u.email, '') AS email,
+       MAX(d.created_at)::timestamptz AS last_sent_at,
+       COUNT(DISTINCT d.transaction_id) AS transfer_count
+FROM entries d
+JOIN entries c ON c.transaction_id = d.transaction_id AND c.credit > 0 AND c.operation_type = 'transfer'
+JOIN accounts a ON a.id = c.account_id
+LEFT JOIN users u ON u.id = a.owner_id
+WHERE d.debit > 0
+  AND d.operation_type = 'transfer'
+  AND EXISTS (
+      SELECT 1 FROM account_owners ao
+      JOIN accounts da ON da.id = d.account_id
+      WHERE ao.user_id = sqlc.arg(user_id)
+        AND ao.account_id IN (da.id, da.parent_account_id)
+  )
+  AND NOT a.is_system
+  AND NOT EXISTS (
+      SELECT 1 FROM account_owners ao
+      WHERE ao.user_id = sqlc.arg(user_id)
+        AND ao.account_id IN (a.id, a.parent_account_id)
+  )
+GROUP BY a.id, u.id
+ORDER BY last_sent_at DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: DailyTotalsBetween :many
+-- One row per UTC day in [from_day, to_day), including days without entries.
+SELECT d.day::date AS day,
+       CAST(COALESCE(SUM(e.credit), 0::NUMERIC) AS NUMERIC(19,4)) AS credits,
+       CAST(COALESCE(SUM(e.debit), 0::NUMERIC) AS NUMERIC(19,4)) AS debits,
+       COUNT(e.id) AS entry_count
+FROM generate_series(sqlc.arg(from_day)::timestamp, sqlc.arg(to_day)::timestamp - INTERVAL '1 day', INTERVAL '1 day') AS d(day)
+LEFT JOIN entries e
+    ON e.account_id = sqlc.arg(account_id)
+   AND e.created_at >= d.day AT TIME ZONE 'UTC'
+   AND e.created_at < (d.day + INTERVAL '1 day') AT TIME ZONE 'UTC'
+GROUP BY d.day
+ORDER BY d.day;
+
+-- name: LargestTransactionsBetween :many
+-- The account's transactions in [from_time, to_time) by the size of their net effect on it.
+SELECT e.transaction_id,
+       CAST(SUM(e.credit) AS NUMERIC(19,4)) AS credits,
+       CAST(SUM(e.debit) AS NUMERIC(19,4)) AS debits,
+       COALESCE((array_agg(e.description ORDER BY GREATEST(e.debit, e.credit) DESC))[1], '')::text AS description,
+       MIN(e.created_at)::timestamptz AS created_at
+FROM entries e
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.created_at >= sqlc.arg(from_time)::timestamptz
+  AND e.created_at < sqlc.arg(to_time)::timestamptz
+GROUP BY e.transaction_id
+ORDER BY ABS(SUM(e.credit) - SUM(e.debit)) DESC, MIN(e.created_at)
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,128 @@
+-- name: ListUnarchivedPeriods :many
+-- Accounts and months (UTC) with entries posted before the cutoff that no segment holds yet,
+-- oldest month first. The cutoff must fall on a month boundary so every month listed is closed.
+SELECT e.account_id, date_trunc('month', e.created_at AT TIME ZONE 'UTC')::date AS period
+FROM entries e
+WHERE e.created_at < sqlc.arg(before)::timestamptz
+  AND NOT EXISTS (
+      SELECT 1 FROM entry_archives a
+      WHERE a.account_id = e.account_id
+        AND a.period = date_trunc('month', e.created_at AT TIME ZONE 'UTC')::date
+  )
+GROUP BY 1, 2
+ORDER BY 2, 1
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListArchivableEntries :many
+-- The account's entries in [period_start, period_end) with their history read model rows,
+-- oldest first. History columns are NULL for an entry the read model lacks.
+SELECT e.id, e.account_id, e.transaction_id, e.operation_type::text AS operation_type, e.debit, e.credit,
+       e.description, e.created_at, e.chain_seq, e.prev_hash, e.hash,
+       h.balance_after, h.counterparty_account_id, h.counterparty_name, h.category_id, h.category_name
+FROM entries e
+LEFT JOIN account_history h ON h.entry_id = e.id
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.created_at >= sqlc.arg(period_start)::timestamptz
+  AND e.created_at < sqlc.arg(period_end)::timestamptz
+ORDER BY e.created_at, e.id;
+
+-- name: CreateEntryArchive :execrows
+-- Records a written segment; a no-op when the account's month is already recorded.
+INSERT INTO entry_archives (
+    account_id, period, object_key, sha256, entry_count, first_entry_at, last_entry_at, retain_until,
+    net_amount, last_chain_seq, last_hash
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (account_id, period) DO NOTHING;
+
+-- name: ListPrunedEntryArchives :many
+-- The account's segments whose entries are no longer in Postgres, oldest month first.
+SELECT * FROM entry_archives
+WHERE account_id = $1
+  AND pruned_at IS NOT NULL
+ORDER BY period;
+
+-- name: GetOldestEntryPeriod :one
+-- The month (UTC) of the oldest entry still in Postgres; NULL when there are none.
+SELECT date_trunc('month', MIN(created_at) AT TIME ZONE 'UTC')::date AS period
+FROM entries;
+
+-- name: CountUnarchivedAccountsInPeriod :one
+-- Accounts with entries in the month [period_start, period_end) that have no segment for it.
+SELECT COUNT(DISTINCT e.account_id)
+FROM entries e
+WHERE e.created_at >= sqlc.arg(period_start)::timestamptz
+  AND e.created_at < sqlc.arg(period_end)::timestamptz
+  AND NOT EXISTS (
+      SELECT 1 FROM entry_archives a
+      WHERE a.account_id = e.account_id
+        AND a.period = sqlc.arg(period_start)::date
+  );
+
+-- name: CountChainOverlapsAfterPeriod :one
+-- Accounts with a segment for the month [period_start, period_end) and an entry posted after it
+-- that precedes one of the month's in the account's hash chain, so pruning the month would cut
+-- the chain short of a link still in Postgres.
+SELECT COUNT(*)
+FROM entry_archives x
+WHERE x.period = sqlc.arg(period_start)::date
+  AND EXISTS (
+      SELECT 1 FROM entries e
+      WHERE e.account_id = x.account_id
+        AND e.chain_seq < x.last_chain_seq
+        AND e.created_at >= sqlc.arg(period_end)::timestamptz
+  );
+
+-- name: ListUnprunedEntryArchivesByPeriod :many
+SELECT * FROM entry_archives
+WHERE period = sqlc.arg(period)::date
+  AND pruned_at IS NULL
+ORDER BY account_id;
+
+-- name: ListArchivedAccountDrift :many
+-- Accounts with a segment for the month whose cached balance is not the balance carried by their
+-- pruned entries plus their entries still in Postgres, i.e. that fail reconciliation.
+WITH ledger AS (
+    SELECT a.id, a.balance,
+           pruned_balance(a.id) + COALESCE((SELECT SUM(e.credit - e.debit) FROM entries e WHERE e.account_id = a.id), 0) AS ledger_balance
+    FROM accounts a
+    WHERE a.id IN (SELECT x.account_id FROM entry_archives x WHERE x.period = sqlc.arg(period)::date)
+)
+SELECT id AS account_id, balance::text AS stored_balance, ledger_balance::text AS ledger_balance
+FROM ledger
+WHERE balance <> ledger_balance
+ORDER BY id;
+
+-- name: KeepPrunedMerkleLeaves :execrows
+-- Copies the hashes of the month's entries into their places under Merkle roots before they go.
+UPDATE merkle_root_entries m
+SET pruned_hash = e.hash
+FROM entries e
+WHERE e.id = m.entry_id
+  AND e.created_at >= sqlc.arg(period_start)::timestamptz
+  AND e.created_at < sqlc.arg(period_end)::timestamptz;
+
+-- name: DeleteEntriesInPeriod :execrows
+-- Removes every entry posted in [period_start, period_end), of every account.
+DELETE FROM entries
+WHERE created_at >= sqlc.arg(period_start)::timestamptz
+  AND created_at < sqlc.arg(period_end)::timestamptz;
+
+-- name: MarkEntryArchivesPruned :execrows
+UPDATE entry_archives
+SET pruned_at = CURRENT_TIMESTAMP
+WHERE period = sqlc.arg(period)::date
+  AND pruned_at IS NULL;
+
+-- name: GetLatestPrunedPeriod :one
+-- The newest month pruned from Postgres; NULL when none is.
+SELECT MAX(period)::date AS period FROM entry_archives
+WHERE pruned_at IS NOT NULL;
+
+-- name: ListPrunedChainLinks :many
+-- Each account's last pruned link of its hash chain, which its oldest entry left in Postgres follows.
+SELECT DISTINCT ON (account_id) account_id, last_chain_seq, last_hash
+FROM entry_archives
+WHERE pruned_at IS NOT NULL
+  AND (sqlc.narg(account_id)::uuid IS NULL OR account_id = sqlc.narg(account_id)::uuid)
+ORDER BY account_id, period DESC;
@@ -0,0 +1,23 @@
+-- name: ListEntryChain :many
+-- Entries in chain order, a page at a time after (after_account_id, after_seq), with each
+-- account's recorded chain head.
+SELECT e.id, e.account_id, e.transaction_id, e.chain_seq, e.debit::text AS debit, e.credit::text AS credit,
+       e.operation_type::text AS operation_type, e.description, e.created_at, e.prev_hash, e.hash,
+       h.chain_seq AS head_seq, h.hash AS head_hash
+FROM entries e
+LEFT JOIN entry_chain_heads h ON h.account_id = e.account_id
+WHERE (sqlc.narg(account_id)::uuid IS NULL OR e.account_id = sqlc.narg(account_id)::uuid)
+  AND (e.account_id, e.chain_seq) > (sqlc.arg(after_account_id)::uuid, sqlc.arg(after_seq)::bigint)
+ORDER BY e.account_id, e.chain_seq
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListOrphanEntryChainHeads :many
+-- Chain heads of accounts that have no entries left.
+SELECT h.* FROM entry_chain_heads h
+WHERE (sqlc.narg(account_id)::uuid IS NULL OR h.account_id = sqlc.narg(account_id)::uuid)
+  AND NOT EXISTS (SELECT 1 FROM entries e WHERE e.account_id = h.account_id)
+ORDER BY h.account_id;
+
+-- name: GetEntryChainHead :one
+SELECT * FROM entry_chain_heads
+WHERE account_id = $1;
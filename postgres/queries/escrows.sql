@@ -0,0 +1,32 @@
+-- name: CreateEscrow :one
+INSERT INTO escrows (org_id, buyer_account_id, seller_account_id, amount, currency, description, created_by, funding_transaction_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetEscrow :one
+SELECT * FROM escrows
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetEscrowForUpdate :one
+SELECT * FROM escrows
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListEscrowsByAccount :many
+-- Escrows where the account is the buyer or the seller, newest first.
+SELECT * FROM escrows
+WHERE buyer_account_id = sqlc.arg(account_id) OR seller_account_id = sqlc.arg(account_id)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: SettleEscrow :one
+UPDATE escrows
+SET status = sqlc.arg(status),
+    settled_by = sqlc.arg(settled_by),
+    settled_at = CURRENT_TIMESTAMP,
+    settlement_note = sqlc.arg(settlement_note),
+    settlement_transaction_id = sqlc.arg(settlement_transaction_id)
+WHERE id = sqlc.arg(id) AND status = 'funded'
+RETURNING *;
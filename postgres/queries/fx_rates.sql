@@ -0,0 +1,63 @@
+-- name: UpsertFXRate :exec
+INSERT INTO fx_rates (base_currency, quote_currency, rate, provider, fetched_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (base_currency, quote_currency) DO UPDATE
+SET rate = EXCLUDED.rate,
+    provider = EXCLUDED.provider,
+    fetched_at = EXCLUDED.fetched_at
+WHERE fx_rates.fetched_at <= EXCLUDED.fetched_at;
+
+-- name: GetFXRate :one
+SELECT * FROM fx_rates
+WHERE base_currency = $1 AND quote_currency = $2
+LIMIT 1;
+
+-- name: GetActiveFXRateOverride :one
+SELECT * FROM fx_rate_overrides
+WHERE base_currency = $1 AND quote_currency = $2
+  AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+LIMIT 1;
+
+-- name: UpsertFXRateOverride :one
+INSERT INTO fx_rate_overrides (base_currency, quote_currency, rate, reason, set_by, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (base_currency, quote_currency) DO UPDATE
+SET rate = EXCLUDED.rate,
+    reason = EXCLUDED.reason,
+    set_by = EXCLUDED.set_by,
+    expires_at = EXCLUDED.expires_at,
+    created_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteFXRateOverride :execrows
+DELETE FROM fx_rate_overrides
+WHERE base_currency = $1 AND quote_currency = $2;
+
+-- name: ListFXRateOverrides :many
+SELECT * FROM fx_rate_overrides
+ORDER BY base_currency, quote_currency;
+
+-- name: GetFXSpread :one
+SELECT * FROM fx_spreads
+WHERE base_currency = $1 AND quote_currency = $2
+LIMIT 1;
+
+-- name: UpsertFXSpread :one
+INSERT INTO fx_spreads (base_currency, quote_currency, spread_bps, updated_by)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (base_currency, quote_currency) DO UPDATE
+SET spread_bps = EXCLUDED.spread_bps,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListFXSpreads :many
+SELECT * FROM fx_spreads
+ORDER BY base_currency, quote_currency;
+
+-- name: CreateFXConversion :one
+INSERT INTO fx_conversions (
+    transaction_id, from_account_id, to_account_id, sell_currency, sell_amount, buy_currency, buy_amount,
+    mid_rate, customer_rate, spread_bps, margin, rate_source, rate_as_of, requested_by
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING *;
@@ -0,0 +1,55 @@
+-- name: ListSystemAccountGLCodes :many
+-- Every system account with its GL mapping; gl_code is empty when unmapped. Shards are left out:
+-- their entries export under the account they split.
+SELECT a.id, a.name, a.currency,
+       COALESCE(m.gl_code, '')::text AS gl_code,
+       COALESCE(m.gl_name, '')::text AS gl_name
+FROM accounts a
+LEFT JOIN gl_account_codes m ON m.account_id = a.id
+WHERE a.is_system
+  AND NOT EXISTS (SELECT 1 FROM account_shards x WHERE x.account_id = a.id)
+ORDER BY a.currency, a.name;
+
+-- name: UpsertGLAccountCode :one
+INSERT INTO gl_account_codes (account_id, gl_code, gl_name, updated_by)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (account_id) DO UPDATE
+SET gl_code = EXCLUDED.gl_code,
+    gl_name = EXCLUDED.gl_name,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListGLCustomerCodes :many
+SELECT * FROM gl_customer_codes
+ORDER BY currency;
+
+-- name: UpsertGLCustomerCode :one
+INSERT INTO gl_customer_codes (currency, gl_code, gl_name, updated_by)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (currency) DO UPDATE
+SET gl_code = EXCLUDED.gl_code,
+    gl_name = EXCLUDED.gl_name,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GLDailyActivity :many
+-- Net debits per UTC day and GL code of every entry in currency in [from, to). Entries of
+-- accounts without a mapping come back with an empty gl_code and, for system accounts, their
+-- account name. A shard's entries count toward the account it splits.
+SELECT (e.created_at AT TIME ZONE 'UTC')::date AS day,
+       COALESCE(m.gl_code, c.gl_code, '')::text AS gl_code,
+       COALESCE(m.gl_name, c.gl_name, CASE WHEN a.is_system THEN COALESCE(p.name, a.name) ELSE 'Customer accounts' END)::text AS gl_name,
+       SUM(e.debit - e.credit)::text AS net
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+LEFT JOIN account_shards sh ON sh.account_id = a.id
+LEFT JOIN accounts p ON p.id = sh.shard_of
+LEFT JOIN gl_account_codes m ON m.account_id = COALESCE(sh.shard_of, a.id)
+LEFT JOIN gl_customer_codes c ON NOT a.is_system AND c.currency = a.currency
+WHERE a.currency = sqlc.arg(currency)
+  AND e.created_at >= sqlc.arg(from_time)::timestamptz
+  AND e.created_at < sqlc.arg(to_time)::timestamptz
+GROUP BY 1, 2, 3
+ORDER BY 1, 2, 3;
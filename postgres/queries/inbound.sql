@@ -0,0 +1,35 @@
+-- name: CreateInboundPayment :one
+-- Replays of an already-recorded event insert nothing and return no rows.
+INSERT INTO inbound_payments (
+    provider, event_id, virtual_account_number, amount, currency,
+    payer_name, payer_reference, status, account_id, transaction_id
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (provider, event_id) DO NOTHING
+RETURNING *;
+
+-- name: GetInboundPaymentByEvent :one
+SELECT * FROM inbound_payments
+WHERE provider = $1 AND event_id = $2
+LIMIT 1;
+
+-- name: GetInboundPaymentForUpdate :one
+SELECT * FROM inbound_payments
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListInboundPaymentsByStatus :many
+SELECT * FROM inbound_payments
+WHERE status = $1
+ORDER BY created_at
+LIMIT $2 OFFSET $3;
+
+-- name: ResolveInboundPayment :one
+UPDATE inbound_payments
+SET status = 'resolved',
+    account_id = sqlc.arg(account_id),
+    resolution_transaction_id = sqlc.arg(resolution_transaction_id),
+    resolved_by = sqlc.arg(resolved_by),
+    resolved_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND status = 'unmatched'
+RETURNING *;
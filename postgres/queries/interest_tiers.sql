@@ -0,0 +1,22 @@
+-- name: ListInterestTiers :many
+SELECT * FROM interest_tiers
+WHERE product = $1 AND currency = $2
+ORDER BY min_balance;
+
+-- name: ListProductInterestTiers :many
+SELECT * FROM interest_tiers
+WHERE product = $1
+ORDER BY currency, min_balance;
+
+-- name: ListAllInterestTiers :many
+SELECT * FROM interest_tiers
+ORDER BY product, currency, min_balance;
+
+-- name: DeleteInterestTiers :exec
+DELETE FROM interest_tiers
+WHERE product = $1 AND currency = $2;
+
+-- name: CreateInterestTier :one
+INSERT INTO interest_tiers (product, currency, min_balance, rate_bps, updated_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
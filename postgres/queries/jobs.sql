@@ -0,0 +1,80 @@
+-- name: EnqueueJob :one
+INSERT INTO jobs (kind, payload, run_at, max_attempts)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ClaimNextJob :one
+-- Marks the oldest due job running; SKIP LOCKED lets concurrent workers take different jobs.
+UPDATE jobs
+SET status = 'running',
+    attempts = attempts + 1,
+    locked_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = (
+    SELECT j.id FROM jobs j
+    WHERE j.status = 'pending' AND j.run_at <= CURRENT_TIMESTAMP
+    ORDER BY j.run_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteJob :exec
+UPDATE jobs
+SET status = 'succeeded',
+    locked_at = NULL,
+    last_error = '',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'running';
+
+-- name: RetryJob :exec
+UPDATE jobs
+SET status = 'pending',
+    run_at = sqlc.arg(run_at),
+    locked_at = NULL,
+    last_error = sqlc.arg(last_error),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND status = 'running';
+
+-- name: FailJob :exec
+UPDATE jobs
+SET status = 'failed',
+    locked_at = NULL,
+    last_error = sqlc.arg(last_error),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND status = 'running';
+
+-- name: RequeueStaleJobs :execrows
+-- Returns jobs whose worker died mid-run to the queue.
+UPDATE jobs
+SET status = 'pending',
+    locked_at = NULL,
+    last_error = 'worker lost',
+    updated_at = CURRENT_TIMESTAMP
+WHERE status = 'running' AND locked_at < sqlc.arg(stale_before)::timestamptz;
+
+-- name: DeleteSucceededJobsBefore :execrows
+DELETE FROM jobs
+WHERE status = 'succeeded' AND updated_at < $1;
+
+-- name: ListJobsByStatus :many
+SELECT * FROM jobs
+WHERE status = $1
+ORDER BY run_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: UpsertJobSchedule :exec
+-- Registers a schedule; next_run_at is only reset when the spec changed.
+INSERT INTO job_schedules (name, spec, next_run_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (name) DO UPDATE
+SET spec = EXCLUDED.spec, next_run_at = EXCLUDED.next_run_at
+WHERE job_schedules.spec <> EXCLUDED.spec;
+
+-- name: ClaimJobSchedule :one
+-- Advances a due schedule; only the instance that wins this update enqueues the occurrence.
+UPDATE job_schedules
+SET next_run_at = sqlc.arg(next_run_at),
+    last_run_at = CURRENT_TIMESTAMP
+WHERE name = sqlc.arg(name) AND next_run_at <= sqlc.arg(now)
+RETURNING *;
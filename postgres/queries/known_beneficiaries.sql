@@ -0,0 +1,11 @@
+-- name: IsKnownBeneficiary :one
+SELECT EXISTS (
+    SELECT 1 FROM known_beneficiaries
+    WHERE account_id = $1 AND beneficiary = $2
+);
+
+-- name: RememberBeneficiary :exec
+INSERT INTO known_beneficiaries (account_id, beneficiary)
+VALUES ($1, $2)
+ON CONFLICT (account_id, beneficiary) DO UPDATE
+SET last_paid_at = CURRENT_TIMESTAMP;
@@ -0,0 +1,22 @@
+-- name: RememberDevice :one
+-- Records that the user logged in from the device. new_device is true the first time it is seen,
+-- when both timestamps still hold the inserting transaction's time; other_devices says whether the
+-- user had logged in from any other device before.
+WITH inserted AS (
+    INSERT INTO known_devices (user_id, device_id, user_agent, ip_address)
+    VALUES (sqlc.arg(user_id), sqlc.arg(device_id), sqlc.arg(user_agent), sqlc.arg(ip_address))
+    ON CONFLICT (user_id, device_id) DO UPDATE
+    SET last_seen_at = CURRENT_TIMESTAMP,
+        ip_address = EXCLUDED.ip_address
+    RETURNING first_seen_at = last_seen_at AS new_device
+)
+SELECT inserted.new_device,
+       EXISTS (
+           SELECT 1 FROM known_devices d
+           WHERE d.user_id = sqlc.arg(user_id) AND d.device_id <> sqlc.arg(device_id)
+       ) AS other_devices
+FROM inserted;
+
+-- name: DeleteKnownDevices :exec
+DELETE FROM known_devices
+WHERE user_id = $1;
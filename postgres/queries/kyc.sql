@@ -0,0 +1,41 @@
+-- name: UpsertKYCSubmission :one
+-- A resubmission returns the record to review but keeps the previously approved level.
+INSERT INTO kyc_records (user_id, id_type, id_number, document_reference)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id) DO UPDATE
+SET id_type = EXCLUDED.id_type,
+    id_number = EXCLUDED.id_number,
+    document_reference = EXCLUDED.document_reference,
+    status = 'pending',
+    rejection_reason = NULL,
+    submitted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetKYCRecordByUser :one
+SELECT * FROM kyc_records
+WHERE user_id = $1
+LIMIT 1;
+
+-- name: GetKYCLevelByUser :one
+SELECT level FROM kyc_records
+WHERE user_id = $1
+LIMIT 1;
+
+-- name: ListKYCRecordsByStatus :many
+SELECT * FROM kyc_records
+WHERE status = $1
+ORDER BY submitted_at
+LIMIT $2 OFFSET $3;
+
+-- name: ReviewKYCRecord :one
+-- Only pending submissions can be reviewed; rejection keeps the previously approved level.
+UPDATE kyc_records
+SET status = sqlc.arg(status),
+    level = CASE WHEN sqlc.arg(status)::TEXT = 'approved' THEN sqlc.arg(level)::SMALLINT ELSE level END,
+    rejection_reason = sqlc.narg(rejection_reason),
+    reviewed_by = sqlc.arg(reviewed_by),
+    reviewed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = sqlc.arg(user_id) AND status = 'pending'
+RETURNING *;
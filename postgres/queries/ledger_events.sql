@@ -0,0 +1,62 @@
+-- name: AppendLedgerEvent :one
+INSERT INTO ledger_events (event_type, transaction_id, payload, request_id)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListLedgerEventsByTransaction :many
+SELECT * FROM ledger_events
+WHERE transaction_id = $1
+ORDER BY seq;
+
+-- name: GetReplayedAccountBalance :one
+-- One account's balance replayed from the log. It reads every posting, so it is for admin tools.
+SELECT COALESCE(SUM((leg->>'credit')::numeric - (leg->>'debit')::numeric), 0)::text AS balance
+FROM ledger_events le, jsonb_array_elements(le.payload->'legs') AS leg
+WHERE le.event_type = 'transaction_posted'
+  AND (leg->>'account_id')::uuid = $1;
+
+-- name: LockLedgerEvents :exec
+-- Waits for postings in flight to commit and holds new ones back until the transaction ends, so
+-- the log does not move while projections are rebuilt from it.
+LOCK TABLE ledger_events IN EXCLUSIVE MODE;
+
+-- name: ListLedgerProjectionDrift :many
+-- Accounts whose cached balance or entries disagree with the balance replayed from the log.
+WITH replayed AS (
+    SELECT (leg->>'account_id')::uuid AS account_id,
+           SUM((leg->>'credit')::numeric - (leg->>'debit')::numeric) AS balance
+    FROM ledger_events le, jsonb_array_elements(le.payload->'legs') AS leg
+    WHERE le.event_type = 'transaction_posted'
+    GROUP BY 1
+), journaled AS (
+    SELECT account_id, SUM(credit - debit) AS balance
+    FROM entries
+    GROUP BY account_id
+)
+SELECT a.id AS account_id,
+       a.currency,
+       a.balance::text AS stored_balance,
+       (pruned_balance(a.id) + COALESCE(j.balance, 0))::text AS entries_balance,
+       COALESCE(r.balance, 0)::text AS replayed_balance
+FROM accounts a
+LEFT JOIN replayed r ON r.account_id = a.id
+LEFT JOIN journaled j ON j.account_id = a.id
+WHERE a.balance <> COALESCE(r.balance, 0)
+   OR pruned_balance(a.id) + COALESCE(j.balance, 0) <> COALESCE(r.balance, 0)
+ORDER BY a.id;
+
+-- name: RebuildAccountBalances :execrows
+-- Resets every cached balance that differs from the balance replayed from the log.
+WITH replayed AS (
+    SELECT (leg->>'account_id')::uuid AS account_id,
+           SUM((leg->>'credit')::numeric - (leg->>'debit')::numeric) AS balance
+    FROM ledger_events le, jsonb_array_elements(le.payload->'legs') AS leg
+    WHERE le.event_type = 'transaction_posted'
+    GROUP BY 1
+)
+UPDATE accounts a
+SET balance = COALESCE(r.balance, 0)
+FROM accounts src
+LEFT JOIN replayed r ON r.account_id = src.id
+WHERE a.id = src.id
+  AND a.balance <> COALESCE(r.balance, 0);
@@ -0,0 +1,72 @@
+-- name: CreateLoan :one
+INSERT INTO loans (org_id, account_id, principal, currency, annual_rate_bps, term_months, disbursement_transaction_id, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetLoan :one
+SELECT * FROM loans
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetLoanForUpdate :one
+SELECT * FROM loans
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListLoansByAccount :many
+SELECT * FROM loans
+WHERE account_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListOrgLoans :many
+-- The organization's loans, optionally only those with the given delinquency, newest first.
+SELECT * FROM loans
+WHERE org_id = sqlc.arg(org_id)
+  AND (sqlc.arg(delinquency)::text = '' OR delinquency = sqlc.arg(delinquency)::text)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: ListActiveLoanIDs :many
+SELECT id FROM loans
+WHERE status = 'active' AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: UpdateLoanStanding :one
+UPDATE loans
+SET status = sqlc.arg(status),
+    delinquency = sqlc.arg(delinquency),
+    days_past_due = sqlc.arg(days_past_due),
+    paid_off_at = CASE WHEN sqlc.arg(status) = 'paid_off' THEN COALESCE(paid_off_at, CURRENT_TIMESTAMP) END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateLoanInstallment :exec
+INSERT INTO loan_installments (loan_id, number, due_date, principal_due, interest_due)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListLoanInstallments :many
+SELECT * FROM loan_installments
+WHERE loan_id = $1
+ORDER BY number;
+
+-- name: PayLoanInstallment :exec
+UPDATE loan_installments
+SET principal_paid = principal_paid + sqlc.arg(principal),
+    interest_paid = interest_paid + sqlc.arg(interest),
+    paid_at = CASE
+        WHEN principal_paid + sqlc.arg(principal) = principal_due AND interest_paid + sqlc.arg(interest) = interest_due
+        THEN CURRENT_TIMESTAMP
+    END
+WHERE loan_id = sqlc.arg(loan_id) AND number = sqlc.arg(number);
+
+-- name: CreateLoanRepayment :one
+INSERT INTO loan_repayments (loan_id, transaction_id, amount, principal, interest, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListLoanRepayments :many
+SELECT * FROM loan_repayments
+WHERE loan_id = $1
+ORDER BY created_at DESC;
@@ -0,0 +1,41 @@
+-- name: CreateLoginAttempt :exec
+INSERT INTO login_attempts (org_id, email, user_id, ip_address, succeeded, failure_reason, user_agent, device_id, new_device)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: CountFailedLoginsByIP :one
+-- Throttled attempts are not counted, so the window ends once the address stops failing.
+SELECT COUNT(*) FROM login_attempts
+WHERE ip_address = $1 AND NOT succeeded AND failure_reason <> 'throttled' AND created_at > $2;
+
+-- name: RecordFailedLogin :one
+-- Counts a failed password; the failure that reaches max_failures locks the login until
+-- locked_until and starts the count again.
+UPDATE users
+SET failed_logins = CASE WHEN failed_logins + 1 >= sqlc.arg(max_failures)::int THEN 0 ELSE failed_logins + 1 END,
+    login_locked_until = CASE WHEN failed_logins + 1 >= sqlc.arg(max_failures)::int THEN sqlc.arg(locked_until)::timestamptz ELSE login_locked_until END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ResetFailedLogins :exec
+UPDATE users
+SET failed_logins = 0,
+    login_locked_until = NULL
+WHERE id = $1 AND (failed_logins <> 0 OR login_locked_until IS NOT NULL);
+
+-- name: ListLoginAttempts :many
+SELECT * FROM login_attempts
+WHERE (sqlc.narg(user_id)::uuid IS NULL OR user_id = sqlc.narg(user_id)::uuid)
+  AND (sqlc.narg(ip_address)::text IS NULL OR ip_address = sqlc.narg(ip_address)::text)
+  AND (sqlc.narg(succeeded)::boolean IS NULL OR succeeded = sqlc.narg(succeeded)::boolean)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountLoginAttempts :one
+SELECT COUNT(*) FROM login_attempts
+WHERE (sqlc.narg(user_id)::uuid IS NULL OR user_id = sqlc.narg(user_id)::uuid)
+  AND (sqlc.narg(ip_address)::text IS NULL OR ip_address = sqlc.narg(ip_address)::text)
+  AND (sqlc.narg(succeeded)::boolean IS NULL OR succeeded = sqlc.narg(succeeded)::boolean);
+
+-- name: DeleteLoginAttemptsBefore :execrows
+DELETE FROM login_attempts
+WHERE created_at < $1;
@@ -0,0 +1,46 @@
+-- name: LockMerkleRoots :exec
+-- Serializes root building, so two builders never pick up the same entries.
+LOCK TABLE merkle_roots IN EXCLUSIVE MODE;
+
+-- name: ListUnrootedEntries :many
+-- Entries posted before the cutoff that no root covers yet, oldest first.
+SELECT e.id, e.hash FROM entries e
+WHERE e.created_at < sqlc.arg(before)::timestamptz
+  AND NOT EXISTS (SELECT 1 FROM merkle_root_entries m WHERE m.entry_id = e.id)
+ORDER BY e.created_at, e.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: CreateMerkleRoot :one
+INSERT INTO merkle_roots (root_hash, leaf_count)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: AddMerkleRootEntries :execrows
+-- Places the entries under the root, leaf_index following their order in entry_ids.
+INSERT INTO merkle_root_entries (entry_id, root_id, leaf_index)
+SELECT u.entry_id, sqlc.arg(root_id)::uuid, (u.ord - 1)::int
+FROM unnest(sqlc.arg(entry_ids)::uuid[]) WITH ORDINALITY AS u(entry_id, ord);
+
+-- name: GetMerkleRoot :one
+SELECT * FROM merkle_roots
+WHERE id = $1;
+
+-- name: ListMerkleRoots :many
+SELECT * FROM merkle_roots
+ORDER BY created_at DESC, id
+LIMIT $1 OFFSET $2;
+
+-- name: CountMerkleRoots :one
+SELECT COUNT(*) FROM merkle_roots;
+
+-- name: GetMerkleRootEntry :one
+SELECT * FROM merkle_root_entries
+WHERE entry_id = $1;
+
+-- name: ListMerkleRootLeaves :many
+-- The root's leaves in tree order, as the entries' current chain hashes; pruned entries keep the
+-- hash they had when pruned.
+SELECT COALESCE(e.hash, m.pruned_hash)::bytea AS hash FROM merkle_root_entries m
+LEFT JOIN entries e ON e.id = m.entry_id
+WHERE m.root_id = $1
+ORDER BY m.leaf_index;
@@ -0,0 +1,16 @@
+-- name: CreateNameEnquiry :exec
+INSERT INTO name_enquiries (user_id, ip_address, account_number, found)
+VALUES ($1, $2, $3, $4);
+
+-- name: CountRecentNameEnquiries :one
+-- How many enquiries the user, and separately the address, made since the given time.
+SELECT
+    COUNT(*) FILTER (WHERE user_id = sqlc.arg(user_id))::bigint AS by_user,
+    COUNT(*) FILTER (WHERE ip_address = sqlc.arg(ip_address))::bigint AS by_ip
+FROM name_enquiries
+WHERE (user_id = sqlc.arg(user_id) OR ip_address = sqlc.arg(ip_address))
+  AND created_at > sqlc.arg(since);
+
+-- name: DeleteNameEnquiriesBefore :execrows
+DELETE FROM name_enquiries
+WHERE created_at < $1;
@@ -0,0 +1,13 @@
+-- name: GetNotificationPreferences :one
+SELECT * FROM notification_preferences
+WHERE user_id = $1
+LIMIT 1;
+
+-- name: UpsertNotificationPreferences :one
+INSERT INTO notification_preferences (user_id, email_enabled, sms_enabled)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET email_enabled = EXCLUDED.email_enabled,
+    sms_enabled = EXCLUDED.sms_enabled,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
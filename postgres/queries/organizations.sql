@@ -0,0 +1,42 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (name, slug)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetOrganizationBySlug :one
+SELECT * FROM organizations
+WHERE slug = $1
+LIMIT 1;
+
+-- name: GetOrganization :one
+SELECT * FROM organizations
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListOrganizations :many
+SELECT * FROM organizations
+ORDER BY created_at
+LIMIT $1 OFFSET $2;
+
+-- name: ListUsersByOrg :many
+SELECT * FROM users
+WHERE org_id = $1
+ORDER BY created_at
+LIMIT $2 OFFSET $3;
+
+-- name: ListAccountsByOrg :many
+SELECT * FROM accounts
+WHERE org_id = $1
+ORDER BY created_at
+LIMIT $2 OFFSET $3;
+
+-- name: CountAccountsByOrg :one
+SELECT COUNT(*) FROM accounts
+WHERE org_id = $1;
+
+-- name: SetUserRoleInOrg :one
+-- Scoped by org so an org admin can never change users of another tenant.
+UPDATE users
+SET role = sqlc.arg(role)
+WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id)
+RETURNING *;
@@ -0,0 +1,47 @@
+-- name: CreateOwnershipTransfer :one
+INSERT INTO ownership_transfers (account_id, from_user_id, to_user_id, reason, requested_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: HasPendingOwnershipTransfer :one
+SELECT EXISTS (
+    SELECT 1 FROM ownership_transfers
+    WHERE account_id = $1 AND status = 'pending'
+);
+
+-- name: GetOwnershipTransferForUpdate :one
+SELECT * FROM ownership_transfers
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListOwnershipTransfersByStatus :many
+SELECT * FROM ownership_transfers
+WHERE status = $1
+ORDER BY requested_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: CountOwnershipTransfersByStatus :one
+SELECT COUNT(*) FROM ownership_transfers
+WHERE status = $1;
+
+-- name: DecideOwnershipTransfer :one
+UPDATE ownership_transfers
+SET status = sqlc.arg(status),
+    decided_by = sqlc.arg(decided_by),
+    decided_at = CURRENT_TIMESTAMP,
+    decision_note = sqlc.arg(decision_note)
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: SetAccountOwner :exec
+-- Moves an account and its sub-wallets to a new primary owner.
+UPDATE accounts
+SET owner_id = sqlc.arg(owner_id)
+WHERE id = sqlc.arg(account_id) OR parent_account_id = sqlc.arg(account_id);
+
+-- name: ClearDefaultAccount :exec
+-- Forgets a default receiving account the user no longer owns.
+UPDATE users
+SET default_account_id = NULL
+WHERE id = $1 AND default_account_id = $2;
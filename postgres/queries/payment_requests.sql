@@ -0,0 +1,38 @@
+-- name: CreatePaymentRequest :one
+INSERT INTO payment_requests (token, account_id, amount, currency, memo, expires_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetPaymentRequestByToken :one
+SELECT * FROM payment_requests
+WHERE token = $1
+LIMIT 1;
+
+-- name: GetPaymentRequestByTokenForUpdate :one
+SELECT * FROM payment_requests
+WHERE token = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListPaymentRequestsByAccount :many
+SELECT * FROM payment_requests
+WHERE account_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: MarkPaymentRequestPaid :one
+UPDATE payment_requests
+SET status = 'paid',
+    payer_account_id = sqlc.arg(payer_account_id),
+    paid_by = sqlc.arg(paid_by),
+    paid_at = CURRENT_TIMESTAMP,
+    transaction_id = sqlc.arg(transaction_id)
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: CancelPaymentRequest :one
+UPDATE payment_requests
+SET status = 'cancelled',
+    cancelled_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
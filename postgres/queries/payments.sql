@@ -0,0 +1,35 @@
+-- name: CreatePaymentCharge :one
+INSERT INTO payment_charges (provider, reference, account_id, user_id, amount, currency)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetPaymentChargeByReference :one
+SELECT * FROM payment_charges
+WHERE reference = $1
+LIMIT 1;
+
+-- name: GetPaymentChargeByReferenceForUpdate :one
+SELECT * FROM payment_charges
+WHERE reference = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: MarkPaymentChargeSucceeded :one
+UPDATE payment_charges
+SET status = 'succeeded',
+    transaction_id = sqlc.arg(transaction_id),
+    updated_at = CURRENT_TIMESTAMP
+WHERE reference = sqlc.arg(reference) AND status = 'pending'
+RETURNING *;
+
+-- name: MarkPaymentChargeFailed :exec
+UPDATE payment_charges
+SET status = 'failed',
+    updated_at = CURRENT_TIMESTAMP
+WHERE reference = $1 AND status = 'pending';
+
+-- name: SetPaymentChargeProviderReference :exec
+UPDATE payment_charges
+SET provider_reference = sqlc.arg(provider_reference),
+    updated_at = CURRENT_TIMESTAMP
+WHERE reference = sqlc.arg(reference);
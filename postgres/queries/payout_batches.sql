@@ -0,0 +1,19 @@
+-- name: CreatePayoutBatch :one
+INSERT INTO payout_batches (source_account_id, created_by, filename, row_count, total_amount, currency)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetPayoutBatch :one
+SELECT * FROM payout_batches
+WHERE id = $1
+LIMIT 1;
+
+-- name: CreateBatchTransferJob :one
+INSERT INTO transfer_jobs (id, from_account_id, to_account_id, amount, requested_by, batch_id, batch_row, narration)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: ListPayoutBatchRows :many
+SELECT * FROM transfer_jobs
+WHERE batch_id = $1
+ORDER BY batch_row;
@@ -0,0 +1,36 @@
+-- name: CreatePayoutSuspenseCase :one
+INSERT INTO payout_suspense_cases (payout_id, account_id, amount, currency, reason, suspense_transaction_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetPayoutSuspenseCase :one
+SELECT * FROM payout_suspense_cases
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetPayoutSuspenseCaseForUpdate :one
+SELECT * FROM payout_suspense_cases
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListPayoutSuspenseCasesByStatus :many
+SELECT * FROM payout_suspense_cases
+WHERE status = $1
+ORDER BY created_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: CountPayoutSuspenseCasesByStatus :one
+SELECT COUNT(*) FROM payout_suspense_cases
+WHERE status = $1;
+
+-- name: ResolvePayoutSuspenseCase :one
+UPDATE payout_suspense_cases
+SET status = $1,
+    resolution_transaction_id = $2,
+    retry_payout_id = $3,
+    resolved_by = $4,
+    resolution_note = $5,
+    resolved_at = CURRENT_TIMESTAMP
+WHERE id = $6 AND status = 'open'
+RETURNING *;
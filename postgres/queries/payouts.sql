@@ -0,0 +1,50 @@
+-- name: CreatePayout :one
+INSERT INTO payouts (
+    provider, reference, account_id, user_id, amount, currency,
+    bank_code, account_number, narration, hold_transaction_id, beneficiary_name
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetPayout :one
+SELECT * FROM payouts
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetPayoutByReference :one
+SELECT * FROM payouts
+WHERE reference = $1
+LIMIT 1;
+
+-- name: GetPayoutByReferenceForUpdate :one
+SELECT * FROM payouts
+WHERE reference = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: SetPayoutProviderTransferID :exec
+UPDATE payouts
+SET provider_transfer_id = sqlc.arg(provider_transfer_id),
+    updated_at = CURRENT_TIMESTAMP
+WHERE reference = sqlc.arg(reference);
+
+-- name: CompletePayout :one
+UPDATE payouts
+SET status = sqlc.arg(status),
+    final_transaction_id = sqlc.arg(final_transaction_id),
+    failure_reason = sqlc.arg(failure_reason),
+    updated_at = CURRENT_TIMESTAMP
+WHERE reference = sqlc.arg(reference) AND status = 'pending'
+RETURNING *;
+
+-- name: ListPendingPayoutsByProvider :many
+SELECT * FROM payouts
+WHERE provider = sqlc.arg(provider)
+  AND status = 'pending'
+  AND updated_at < sqlc.arg(updated_before)
+ORDER BY created_at
+LIMIT sqlc.arg(row_limit);
+
+-- name: TouchPayout :exec
+UPDATE payouts
+SET updated_at = CURRENT_TIMESTAMP
+WHERE reference = $1;
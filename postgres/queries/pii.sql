@@ -0,0 +1,28 @@
+-- name: ListUserPhones :many
+-- Keyset-paged by id so the pii.rekey job can walk every stored phone.
+SELECT id, phone FROM users
+WHERE phone IS NOT NULL AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ReplaceUserPhone :execrows
+-- Re-seals a phone unless it changed since it was read.
+UPDATE users
+SET phone = sqlc.arg(new_phone),
+    phone_index = sqlc.narg(phone_index)
+WHERE id = sqlc.arg(id) AND phone = sqlc.arg(phone);
+
+-- name: ListKYCDocuments :many
+SELECT id, id_number, document_reference FROM kyc_records
+WHERE id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ReplaceKYCDocument :execrows
+-- Re-seals a KYC record's document details unless they changed since they were read.
+UPDATE kyc_records
+SET id_number = sqlc.arg(new_id_number),
+    document_reference = sqlc.arg(new_document_reference)
+WHERE id = sqlc.arg(id)
+  AND id_number = sqlc.arg(id_number)
+  AND document_reference = sqlc.arg(document_reference);
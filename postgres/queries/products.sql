@@ -0,0 +1,79 @@
+-- name: GetProduct :one
+SELECT * FROM products
+WHERE code = $1
+LIMIT 1;
+
+-- name: ListProducts :many
+SELECT * FROM products
+ORDER BY code;
+
+-- name: UpsertProduct :one
+INSERT INTO products (code, name, interest_rate_bps, withdrawals_enabled, transfers_enabled, updated_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (code) DO UPDATE
+SET name = EXCLUDED.name,
+    interest_rate_bps = EXCLUDED.interest_rate_bps,
+    withdrawals_enabled = EXCLUDED.withdrawals_enabled,
+    transfers_enabled = EXCLUDED.transfers_enabled,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetAccountProduct :one
+SELECT * FROM account_products
+WHERE product = $1 AND currency = $2
+LIMIT 1;
+
+-- name: UpsertAccountProduct :one
+INSERT INTO account_products (product, currency, min_balance, overdraft_limit, transfer_fee, withdrawal_fee, max_debit, updated_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (product, currency) DO UPDATE
+SET min_balance = EXCLUDED.min_balance,
+    overdraft_limit = EXCLUDED.overdraft_limit,
+    transfer_fee = EXCLUDED.transfer_fee,
+    withdrawal_fee = EXCLUDED.withdrawal_fee,
+    max_debit = EXCLUDED.max_debit,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListAccountProducts :many
+SELECT * FROM account_products
+ORDER BY product, currency;
+
+-- name: SyncAccountOverdrafts :execrows
+-- Applies a product's overdraft to its customer top-level accounts in currency. Fails on the
+-- balance CHECK if an account is already overdrawn beyond the new limit.
+UPDATE accounts
+SET overdraft_limit = sqlc.arg(overdraft_limit)
+WHERE product = sqlc.arg(product)
+  AND currency = sqlc.arg(currency)
+  AND NOT is_system
+  AND parent_account_id IS NULL;
+
+-- name: ListAccountsEarningInterest :many
+-- Customer top-level accounts with a positive balance on a product that pays interest, at its
+-- base rate or in a balance band of the account's currency, keyset-paginated by account ID.
+SELECT a.id, p.interest_rate_bps
+FROM accounts a
+JOIN products p ON p.code = a.product
+WHERE NOT a.is_system
+  AND a.parent_account_id IS NULL
+  AND a.balance > 0
+  AND (p.interest_rate_bps > 0 OR EXISTS (
+      SELECT 1 FROM interest_tiers t
+      WHERE t.product = a.product AND t.currency = a.currency AND t.rate_bps > 0
+  ))
+  AND a.id > sqlc.arg(after_id)::uuid
+ORDER BY a.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: HasInterestPosting :one
+SELECT EXISTS (
+    SELECT 1 FROM interest_postings
+    WHERE account_id = $1 AND period_start = $2
+);
+
+-- name: CreateInterestPosting :exec
+INSERT INTO interest_postings (account_id, period_start, transaction_id, balance, rate_bps, amount)
+VALUES ($1, $2, $3, $4, $5, $6);
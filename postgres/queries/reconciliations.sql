@@ -0,0 +1,18 @@
+-- name: CreateBankStatementImport :one
+INSERT INTO bank_statement_imports (
+    source_format, filename, period_start, period_end,
+    line_count, matched_count, missing_count, unexpected_count, report, imported_by
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: GetBankStatementImport :one
+SELECT * FROM bank_statement_imports
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListBankStatementImports :many
+SELECT id, source_format, filename, period_start, period_end,
+       line_count, matched_count, missing_count, unexpected_count, imported_by, created_at
+FROM bank_statement_imports
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
@@ -0,0 +1,39 @@
+-- name: CreateFeeEntry :exec
+INSERT INTO fee_entries (entry_id, transaction_id)
+VALUES ($1, $2);
+
+-- name: ListFeeEntryIDs :many
+SELECT entry_id FROM fee_entries
+WHERE transaction_id = $1;
+
+-- name: IsPayoutTransaction :one
+SELECT EXISTS (
+    SELECT 1 FROM payouts
+    WHERE hold_transaction_id = sqlc.arg(transaction_id)::uuid
+       OR final_transaction_id = sqlc.arg(transaction_id)::uuid
+);
+
+-- name: CreateTransactionReversal :one
+INSERT INTO transaction_reversals (transaction_id, reversal_transaction_id, fees_refunded, fee_amount, reason, reversed_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetTransactionReversal :one
+SELECT * FROM transaction_reversals
+WHERE transaction_id = $1
+LIMIT 1;
+
+-- name: CreateTransactionRefund :one
+INSERT INTO transaction_refunds (transaction_id, refund_transaction_id, amount, reason, refunded_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListTransactionRefunds :many
+SELECT * FROM transaction_refunds
+WHERE transaction_id = $1
+ORDER BY created_at, id;
+
+-- name: SumTransactionRefunds :one
+SELECT COALESCE(SUM(amount), 0)::text AS refunded
+FROM transaction_refunds
+WHERE transaction_id = $1;
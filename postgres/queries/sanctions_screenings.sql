@@ -0,0 +1,38 @@
+-- name: GetCachedScreening :one
+-- The subject's latest screening that still applies: reviews and pending reviews always do,
+-- clean results only when newer than since.
+SELECT * FROM sanctions_screenings
+WHERE org_id = sqlc.arg(org_id) AND subject_key = sqlc.arg(subject_key)
+  AND (status <> 'clear' OR screened_at >= sqlc.arg(since)::timestamptz)
+ORDER BY screened_at DESC
+LIMIT 1;
+
+-- name: CreateSanctionsScreening :one
+INSERT INTO sanctions_screenings (org_id, subject_type, subject_key, name, user_id, bank_code, account_number, provider, matches, error, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetSanctionsScreeningForUpdate :one
+-- Scoped by org so compliance staff only review their own tenant's screenings.
+SELECT * FROM sanctions_screenings
+WHERE id = $1 AND org_id = $2
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListSanctionsScreeningsByStatus :many
+SELECT * FROM sanctions_screenings
+WHERE org_id = $1 AND status = $2
+ORDER BY screened_at
+LIMIT $3 OFFSET $4;
+
+-- name: ReviewSanctionsScreening :one
+UPDATE sanctions_screenings
+SET status = sqlc.arg(status),
+    reviewed_by = sqlc.arg(reviewed_by),
+    reviewed_at = CURRENT_TIMESTAMP,
+    review_note = sqlc.arg(review_note)
+WHERE id = sqlc.arg(id) AND status = 'pending_review'
+RETURNING *;
+
+-- name: SetUserScreeningStatus :exec
+UPDATE users SET screening_status = $2 WHERE id = $1;
@@ -0,0 +1,57 @@
+-- name: CreateSavingsGoal :one
+INSERT INTO savings_goals (
+    wallet_id, name, target_amount, target_date, locked_until,
+    contribution_amount, contribution_interval, next_contribution_at, created_by
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetSavingsGoalByWallet :one
+SELECT * FROM savings_goals
+WHERE wallet_id = $1;
+
+-- name: GetSavingsGoalByWalletForUpdate :one
+SELECT * FROM savings_goals
+WHERE wallet_id = $1
+FOR UPDATE;
+
+-- name: ListSavingsGoalsForUser :many
+-- Goals on wallets the user can see, with the wallet balance as progress.
+SELECT sqlc.embed(g), a.balance, a.currency
+FROM savings_goals g
+JOIN accounts a ON a.id = g.wallet_id
+WHERE EXISTS (
+    SELECT 1 FROM account_owners ao
+    WHERE ao.user_id = $1
+      AND ao.account_id IN (a.id, a.parent_account_id)
+)
+ORDER BY g.created_at;
+
+-- name: UpdateSavingsGoal :one
+UPDATE savings_goals
+SET name = $2,
+    target_amount = $3,
+    target_date = $4,
+    locked_until = $5,
+    contribution_amount = $6,
+    contribution_interval = $7,
+    next_contribution_at = $8,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteSavingsGoal :exec
+DELETE FROM savings_goals
+WHERE id = $1;
+
+-- name: ListDueSavingsContributions :many
+SELECT wallet_id FROM savings_goals
+WHERE next_contribution_at <= sqlc.arg(now)::timestamptz
+ORDER BY next_contribution_at
+LIMIT sqlc.arg(row_limit);
+
+-- name: RecordSavingsContribution :exec
+UPDATE savings_goals
+SET next_contribution_at = sqlc.arg(next_contribution_at),
+    last_contribution_error = sqlc.arg(last_contribution_error),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id);
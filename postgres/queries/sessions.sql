@@ -0,0 +1,30 @@
+-- name: CreateSession :one
+INSERT INTO sessions (id, user_id, user_agent, ip_address, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetSession :one
+SELECT * FROM sessions
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListActiveSessions :many
+SELECT * FROM sessions
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+ORDER BY created_at DESC, id DESC;
+
+-- name: RevokeSession :one
+-- Keeps the original revocation time when a session is revoked twice.
+UPDATE sessions
+SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: RevokeUserSessions :execrows
+UPDATE sessions
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP;
+
+-- name: DeleteSessionsExpiredBefore :execrows
+DELETE FROM sessions
+WHERE expires_at < $1;
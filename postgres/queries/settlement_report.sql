@@ -0,0 +1,51 @@
+-- name: GetSettlementPositionBefore :one
+-- The balance of the settlement account and its shards together from the entries posted before
+-- before_time, carrying forward the pruned months that ended by then.
+WITH settlement AS (
+    SELECT id, currency FROM accounts
+    WHERE is_system = TRUE AND name = 'Settlement Account'
+    LIMIT 1
+), members AS (
+    SELECT id FROM settlement
+    UNION ALL
+    SELECT s.account_id FROM account_shards s JOIN settlement ON s.shard_of = settlement.id
+)
+SELECT st.currency,
+       CAST((
+           COALESCE((
+               SELECT SUM(x.net_amount) FROM entry_archives x
+               WHERE x.account_id IN (SELECT id FROM members)
+                 AND x.pruned_at IS NOT NULL
+                 AND (x.period + INTERVAL '1 month') AT TIME ZONE 'UTC' <= sqlc.arg(before_time)::timestamptz
+           ), 0::NUMERIC)
+           + COALESCE((
+               SELECT SUM(e.credit - e.debit) FROM entries e
+               WHERE e.account_id IN (SELECT id FROM members)
+                 AND e.created_at < sqlc.arg(before_time)::timestamptz
+           ), 0::NUMERIC)
+       ) AS NUMERIC(19,4))::text AS balance
+FROM settlement st;
+
+-- name: SettlementActivity :many
+-- The settlement account and its shards' entries in [from, to) totalled per UTC day and operation
+-- type: debits are money arriving at the settlement bank, credits money leaving it.
+WITH settlement AS (
+    SELECT id FROM accounts
+    WHERE is_system = TRUE AND name = 'Settlement Account'
+    LIMIT 1
+), members AS (
+    SELECT id FROM settlement
+    UNION ALL
+    SELECT s.account_id FROM account_shards s JOIN settlement ON s.shard_of = settlement.id
+)
+SELECT to_char(e.created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day,
+       e.operation_type::text AS operation_type,
+       COUNT(*)::bigint AS entries,
+       SUM(e.debit)::text AS inflow,
+       SUM(e.credit)::text AS outflow
+FROM entries e
+WHERE e.account_id IN (SELECT id FROM members)
+  AND e.created_at >= sqlc.arg(from_time)::timestamptz
+  AND e.created_at < sqlc.arg(to_time)::timestamptz
+GROUP BY 1, 2
+ORDER BY 1, 2;
@@ -0,0 +1,37 @@
+-- name: GetStatementPreference :one
+SELECT * FROM statement_preferences
+WHERE account_id = $1
+LIMIT 1;
+
+-- name: UpsertStatementPreference :one
+INSERT INTO statement_preferences (account_id, delivery, updated_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (account_id) DO UPDATE
+SET delivery = EXCLUDED.delivery,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListAccountsDueStatement :many
+-- Customer top-level accounts opened before the period ended, with statements not turned off
+-- and not yet sent for the period. Keyset-paginated by account ID.
+SELECT a.id, a.owner_id, COALESCE(p.delivery, 'email')::text AS delivery
+FROM accounts a
+LEFT JOIN statement_preferences p ON p.account_id = a.id
+WHERE NOT a.is_system
+  AND a.owner_id IS NOT NULL
+  AND a.parent_account_id IS NULL
+  AND a.created_at < sqlc.arg(period_end)::timestamptz
+  AND COALESCE(p.delivery, 'email') <> 'off'
+  AND a.id > sqlc.arg(after_id)::uuid
+  AND NOT EXISTS (
+      SELECT 1 FROM monthly_statements s
+      WHERE s.account_id = a.id AND s.period_start = sqlc.arg(period_start)::timestamptz
+  )
+ORDER BY a.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: RecordMonthlyStatement :exec
+INSERT INTO monthly_statements (account_id, period_start, delivery, sent_to)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (account_id, period_start) DO NOTHING;
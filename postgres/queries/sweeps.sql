@@ -0,0 +1,49 @@
+-- name: CreateSweepRule :one
+INSERT INTO sweep_rules (account_id, target_account_id, threshold, frequency, next_run_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetSweepRuleForUpdate :one
+SELECT * FROM sweep_rules
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListSweepRulesByAccount :many
+SELECT * FROM sweep_rules
+WHERE account_id = $1
+ORDER BY created_at;
+
+-- name: GetAccountSweepRule :one
+SELECT * FROM sweep_rules
+WHERE id = $1 AND account_id = $2;
+
+-- name: DeleteSweepRule :execrows
+DELETE FROM sweep_rules
+WHERE id = $1 AND account_id = $2;
+
+-- name: ListDueSweepRules :many
+SELECT * FROM sweep_rules
+WHERE next_run_at <= sqlc.arg(now)::timestamptz
+ORDER BY next_run_at
+LIMIT sqlc.arg(row_limit);
+
+-- name: ScheduleSweepRule :exec
+UPDATE sweep_rules
+SET next_run_at = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: CreateSweepExecution :one
+INSERT INTO sweep_executions (rule_id, status, amount, transaction_id, reason)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListSweepExecutions :many
+SELECT * FROM sweep_executions
+WHERE rule_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountSweepExecutions :one
+SELECT COUNT(*) FROM sweep_executions
+WHERE rule_id = $1;
@@ -0,0 +1,39 @@
+-- name: UpsertTaxRule :one
+INSERT INTO tax_rules (code, name, operation_type, rate_bps, active, updated_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (code) DO UPDATE
+SET name = EXCLUDED.name,
+    operation_type = EXCLUDED.operation_type,
+    rate_bps = EXCLUDED.rate_bps,
+    active = EXCLUDED.active,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListTaxRules :many
+SELECT * FROM tax_rules
+ORDER BY code;
+
+-- name: ListActiveTaxRules :many
+SELECT * FROM tax_rules
+WHERE operation_type = $1 AND active
+ORDER BY code;
+
+-- name: CreateTaxPosting :exec
+INSERT INTO tax_postings (transaction_id, rule_code, account_id, currency, base_amount, rate_bps, amount)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: TaxReport :many
+-- Totals withheld per rule and currency in [from, to).
+SELECT p.rule_code,
+       r.name,
+       p.currency,
+       COUNT(*)::bigint AS postings,
+       SUM(p.base_amount)::text AS base_amount,
+       SUM(p.amount)::text AS amount
+FROM tax_postings p
+JOIN tax_rules r ON r.code = p.rule_code
+WHERE p.created_at >= sqlc.arg(from_time)::timestamptz
+  AND p.created_at < sqlc.arg(to_time)::timestamptz
+GROUP BY p.rule_code, r.name, p.currency
+ORDER BY p.rule_code, p.currency;
@@ -0,0 +1,21 @@
+-- name: GetTransactionLimit :one
+SELECT * FROM transaction_limits
+WHERE currency = $1 AND operation = $2 AND kyc_level = $3
+LIMIT 1;
+
+-- name: UpsertTransactionLimit :one
+INSERT INTO transaction_limits (currency, operation, kyc_level, max_amount, updated_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (currency, operation, kyc_level) DO UPDATE
+SET max_amount = EXCLUDED.max_amount,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteTransactionLimit :execrows
+DELETE FROM transaction_limits
+WHERE currency = $1 AND operation = $2 AND kyc_level = $3;
+
+-- name: ListTransactionLimits :many
+SELECT * FROM transaction_limits
+ORDER BY currency, operation, kyc_level;
@@ -0,0 +1,43 @@
+-- name: CreateTransaction :one
+INSERT INTO transactions (id, operation_type, status, request_id, risk_score, risk_decision, risk_factors)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetTransaction :one
+SELECT * FROM transactions
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetTransactionForUpdate :one
+SELECT * FROM transactions
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: TransitionTransactionStatus :one
+-- Moves a transaction between statuses only from the expected one, so concurrent updates cannot skip a step.
+UPDATE transactions
+SET status = sqlc.arg(to_status),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status)
+RETURNING *;
+
+-- name: ListTransactionsByStatus :many
+SELECT * FROM transactions
+WHERE status = $1
+ORDER BY created_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: CountTransactionsByStatus :one
+SELECT COUNT(*) FROM transactions
+WHERE status = $1;
+
+-- name: ListTransactionsByRequestID :many
+SELECT * FROM transactions
+WHERE request_id = $1
+ORDER BY created_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: CountTransactionsByRequestID :one
+SELECT COUNT(*) FROM transactions
+WHERE request_id = $1;
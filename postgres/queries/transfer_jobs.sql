@@ -0,0 +1,36 @@
+-- name: CreateTransferJob :one
+INSERT INTO transfer_jobs (id, from_account_id, to_account_id, amount, requested_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetTransferJob :one
+SELECT * FROM transfer_jobs
+WHERE id = $1
+LIMIT 1;
+
+-- name: ClaimNextTransferJob :one
+-- Locks the oldest pending job; SKIP LOCKED lets concurrent workers take different jobs.
+-- Rows of a payout batch share created_at and run in file order.
+SELECT * FROM transfer_jobs
+WHERE status = 'pending'
+ORDER BY created_at, batch_row
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkTransferJobPosted :one
+UPDATE transfer_jobs
+SET status = 'posted',
+    attempts = attempts + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: FailTransferJobAttempt :one
+-- Records a failed attempt; the job fails for good once it runs out of attempts or the failure is final.
+UPDATE transfer_jobs
+SET attempts = attempts + 1,
+    status = CASE WHEN sqlc.arg(final)::boolean OR attempts + 1 >= sqlc.arg(max_attempts)::integer THEN 'failed' ELSE 'pending' END,
+    failure_reason = sqlc.arg(failure_reason),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
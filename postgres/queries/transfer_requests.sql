@@ -0,0 +1,27 @@
+-- name: CreateTransferRequest :one
+INSERT INTO transfer_requests (org_id, from_account_id, to_account_id, amount, currency, reason, requested_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetTransferRequestForUpdate :one
+-- Scoped by org so a checker can only decide requests of their own tenant.
+SELECT * FROM transfer_requests
+WHERE id = $1 AND org_id = $2
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListTransferRequestsByStatus :many
+SELECT * FROM transfer_requests
+WHERE org_id = $1 AND status = $2
+ORDER BY requested_at
+LIMIT $3 OFFSET $4;
+
+-- name: DecideTransferRequest :one
+UPDATE transfer_requests
+SET status = sqlc.arg(status),
+    decided_by = sqlc.arg(decided_by),
+    decided_at = CURRENT_TIMESTAMP,
+    decision_note = sqlc.arg(decision_note),
+    transaction_id = sqlc.narg(transaction_id)
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
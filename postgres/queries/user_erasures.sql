@@ -0,0 +1,104 @@
+-- name: CreateUserErasure :one
+INSERT INTO user_erasures (user_id, reason, requested_by, erase_after)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetPendingUserErasure :one
+SELECT * FROM user_erasures
+WHERE user_id = $1 AND status = 'pending'
+LIMIT 1;
+
+-- name: GetLatestUserErasure :one
+SELECT * FROM user_erasures
+WHERE user_id = $1
+ORDER BY requested_at DESC, id DESC
+LIMIT 1;
+
+-- name: GetUserErasureForUpdate :one
+SELECT * FROM user_erasures
+WHERE id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: CancelUserErasure :one
+UPDATE user_erasures
+SET status = 'cancelled',
+    cancelled_by = sqlc.arg(cancelled_by),
+    cancelled_at = CURRENT_TIMESTAMP
+WHERE user_id = sqlc.arg(user_id) AND status = 'pending'
+RETURNING *;
+
+-- name: CompleteUserErasure :one
+UPDATE user_erasures
+SET status = 'completed',
+    completed_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: ListDueUserErasureIDs :many
+-- Erasures whose grace period has ended, oldest first, keyset-paged by id within a run.
+SELECT id FROM user_erasures
+WHERE status = 'pending' AND erase_after <= sqlc.arg(due_at) AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListUserErasuresByStatus :many
+SELECT * FROM user_erasures
+WHERE status = $1
+ORDER BY requested_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: CountUserErasuresByStatus :one
+SELECT COUNT(*) FROM user_erasures
+WHERE status = $1;
+
+-- name: HasOwnedBalance :one
+-- Whether any account the user is the primary owner of still holds money, either way.
+SELECT EXISTS (
+    SELECT 1 FROM accounts
+    WHERE owner_id = $1 AND balance <> 0
+);
+
+-- name: AnonymizeUser :one
+-- Replaces every piece of personal data on the user with a placeholder and locks them out. The
+-- email stays unique per organization by embedding the user ID.
+UPDATE users
+SET email = 'erased-' || id::text || '@erased.invalid',
+    hashed_password = '',
+    phone = NULL,
+    phone_index = NULL,
+    first_name = '',
+    last_name = '',
+    date_of_birth = NULL,
+    address_line1 = '',
+    address_line2 = '',
+    city = '',
+    state = '',
+    postal_code = '',
+    country = '',
+    default_account_id = NULL,
+    locked_at = COALESCE(locked_at, CURRENT_TIMESTAMP),
+    locked_reason = 'erased',
+    password_reset_required = FALSE,
+    erased_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND erased_at IS NULL
+RETURNING *;
+
+-- name: AnonymizeKYCRecord :exec
+-- Keeps the verification outcome and level, drops the identity document details.
+UPDATE kyc_records
+SET id_number = '',
+    document_reference = ''
+WHERE user_id = $1;
+
+-- name: AnonymizeStatementRecipients :exec
+-- The send log of the user's accounts keeps when statements went out, not to which address.
+UPDATE monthly_statements
+SET sent_to = ''
+WHERE account_id IN (SELECT id FROM accounts WHERE owner_id = $1);
+
+-- name: RemoveCoOwnerships :exec
+-- Drops the user's access to accounts other users own; their own accounts keep their owner row.
+DELETE FROM account_owners ao
+USING accounts a
+WHERE ao.account_id = a.id AND ao.user_id = $1 AND a.owner_id IS DISTINCT FROM $1;
@@ -0,0 +1,29 @@
+-- name: RememberLocation :one
+-- Records that the user made a request from the country. new_country is true the first time it is
+-- seen. other_country is the country the user was last active in, other than this one, if that
+-- country was known before this one and active within window_seconds before this one first
+-- appeared, and empty otherwise.
+WITH inserted AS (
+    INSERT INTO user_locations (user_id, country, ip_address)
+    VALUES (sqlc.arg(user_id), sqlc.arg(country), sqlc.arg(ip_address))
+    ON CONFLICT (user_id, country) DO UPDATE
+    SET last_seen_at = CURRENT_TIMESTAMP,
+        ip_address = EXCLUDED.ip_address
+    RETURNING first_seen_at, first_seen_at = last_seen_at AS new_country
+)
+SELECT inserted.new_country,
+       inserted.first_seen_at,
+       COALESCE((
+           SELECT l.country::text FROM user_locations l
+           WHERE l.user_id = sqlc.arg(user_id)
+             AND l.country <> sqlc.arg(country)
+             AND l.first_seen_at < inserted.first_seen_at
+             AND l.last_seen_at >= inserted.first_seen_at - sqlc.arg(window_seconds)::int * INTERVAL '1 second'
+           ORDER BY l.last_seen_at DESC
+           LIMIT 1
+       ), '')::text AS other_country
+FROM inserted;
+
+-- name: DeleteUserLocations :exec
+DELETE FROM user_locations
+WHERE user_id = $1;
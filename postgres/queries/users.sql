@@ -1,9 +1,119 @@
 -- name: CreateUser :one
-INSERT INTO users (email, hashed_password)
-VALUES ($1, $2)
-RETURNING id, email, created_at;
+INSERT INTO users (org_id, email, hashed_password, first_name, last_name)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, org_id, email, created_at;
 
 -- name: GetUserByEmail :one
+-- Emails are unique per organization, so login always names one.
 SELECT * FROM users
-WHERE email = $1
-LIMIT 1;
\ No newline at end of file
+WHERE org_id = $1 AND email = $2
+LIMIT 1;
+
+-- name: GetUser :one
+SELECT * FROM users
+WHERE id = $1
+LIMIT 1;
+
+-- name: UpdateUserPhone :exec
+UPDATE users
+SET phone = $2,
+    phone_index = $3
+WHERE id = $1;
+
+-- name: UpdateUserProfile :one
+UPDATE users
+SET first_name = sqlc.arg(first_name),
+    last_name = sqlc.arg(last_name),
+    phone = sqlc.narg(phone),
+    phone_index = sqlc.narg(phone_index),
+    date_of_birth = sqlc.narg(date_of_birth),
+    address_line1 = sqlc.arg(address_line1),
+    address_line2 = sqlc.arg(address_line2),
+    city = sqlc.arg(city),
+    state = sqlc.arg(state),
+    postal_code = sqlc.arg(postal_code),
+    country = sqlc.arg(country)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: FindUserByEmail :one
+-- Recipient lookup ignores case, unlike login.
+SELECT * FROM users
+WHERE org_id = $1 AND lower(email) = lower(sqlc.arg(email)::text)
+LIMIT 1;
+
+-- name: ListUsersByPhone :many
+-- Phones are not unique; callers treat more than one match as no match. Sealed phones are found
+-- by their blind index, and phones stored before encryption by their value.
+SELECT * FROM users
+WHERE org_id = sqlc.arg(org_id)
+  AND (phone_index = sqlc.narg(phone_index) OR (phone_index IS NULL AND phone = sqlc.arg(phone)::text))
+LIMIT 2;
+
+-- name: SetDefaultAccount :exec
+UPDATE users
+SET default_account_id = $2
+WHERE id = $1;
+
+-- name: ListUsers :many
+-- The admin user list across organizations. Every filter is optional; email matches any part of
+-- the address case-insensitively.
+SELECT * FROM users
+WHERE (sqlc.narg(org_id)::uuid IS NULL OR org_id = sqlc.narg(org_id)::uuid)
+  AND (sqlc.narg(email)::text IS NULL OR email ILIKE '%' || sqlc.narg(email)::text || '%')
+  AND (sqlc.narg(role)::text IS NULL OR role = sqlc.narg(role)::text)
+  AND (sqlc.narg(locked)::boolean IS NULL OR (locked_at IS NOT NULL) = sqlc.narg(locked)::boolean)
+ORDER BY created_at, id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE (sqlc.narg(org_id)::uuid IS NULL OR org_id = sqlc.narg(org_id)::uuid)
+  AND (sqlc.narg(email)::text IS NULL OR email ILIKE '%' || sqlc.narg(email)::text || '%')
+  AND (sqlc.narg(role)::text IS NULL OR role = sqlc.narg(role)::text)
+  AND (sqlc.narg(locked)::boolean IS NULL OR (locked_at IS NOT NULL) = sqlc.narg(locked)::boolean);
+
+-- name: LockUser :one
+-- Keeps the original lock time when an already locked user is locked again.
+UPDATE users
+SET locked_at = COALESCE(locked_at, CURRENT_TIMESTAMP),
+    locked_reason = sqlc.arg(locked_reason)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UnlockUser :one
+-- Also lifts a temporary lockout from failed logins.
+UPDATE users
+SET locked_at = NULL,
+    locked_reason = '',
+    failed_logins = 0,
+    login_locked_until = NULL
+WHERE id = $1
+RETURNING *;
+
+-- name: RehashUserPassword :execrows
+-- Replaces a hash with a stronger one for the same password, unless it changed since it was read.
+UPDATE users
+SET hashed_password = sqlc.arg(new_hash)
+WHERE id = sqlc.arg(id) AND hashed_password = sqlc.arg(old_hash);
+
+-- name: SetUserPassword :one
+-- An admin reset sets a temporary password with password_reset_required; the user's own change
+-- clears it.
+UPDATE users
+SET hashed_password = sqlc.arg(hashed_password),
+    password_reset_required = sqlc.arg(password_reset_required)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetUserRole :one
+UPDATE users
+SET role = sqlc.arg(role)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetUserLocale :one
+UPDATE users
+SET locale = sqlc.arg(locale)
+WHERE id = sqlc.arg(id)
+RETURNING *;
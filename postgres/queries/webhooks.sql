@@ -0,0 +1,103 @@
+-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (org_id, url, secret, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListWebhookEndpointsByOrg :many
+SELECT * FROM webhook_endpoints
+WHERE org_id = $1
+ORDER BY created_at;
+
+-- name: GetWebhookEndpoint :one
+SELECT * FROM webhook_endpoints
+WHERE id = $1
+LIMIT 1;
+
+-- name: RotateWebhookEndpointSecret :one
+-- Replaces the org's endpoint secret; the old one keeps signing deliveries until previous_expires_at.
+UPDATE webhook_endpoints
+SET previous_secret = secret,
+    previous_secret_expires_at = sqlc.arg(previous_expires_at)::timestamptz,
+    secret = sqlc.arg(secret),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND org_id = sqlc.arg(org_id)
+RETURNING *;
+
+-- name: RecordWebhookEndpointSuccess :exec
+UPDATE webhook_endpoints
+SET healthy = TRUE,
+    consecutive_failures = 0,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: RecordWebhookEndpointFailure :one
+-- Counts a failed attempt; the endpoint turns unhealthy once failures reach the threshold.
+UPDATE webhook_endpoints
+SET consecutive_failures = consecutive_failures + 1,
+    healthy = consecutive_failures + 1 < sqlc.arg(unhealthy_after)::integer,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (endpoint_id, event_type, transaction_id, payload)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ClaimDueWebhookDeliveries :many
+-- Leases due deliveries by pushing next_attempt_at to lease_until, so concurrent dispatchers take
+-- different rows and a dispatcher that dies mid-send is retried once the lease lapses.
+UPDATE webhook_deliveries
+SET next_attempt_at = sqlc.arg(lease_until),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id IN (
+    SELECT w.id FROM webhook_deliveries w
+    WHERE w.status = 'pending' AND w.next_attempt_at <= CURRENT_TIMESTAMP
+    ORDER BY w.next_attempt_at
+    LIMIT sqlc.arg(row_limit)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_deliveries
+SET status = 'succeeded',
+    attempts = attempts + 1,
+    last_error = '',
+    delivered_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: MarkWebhookDeliveryFailed :one
+-- Schedules the next attempt, or parks the delivery as dead once it runs out of attempts.
+UPDATE webhook_deliveries
+SET attempts = attempts + 1,
+    status = CASE WHEN attempts + 1 >= sqlc.arg(max_attempts)::integer THEN 'dead' ELSE 'pending' END,
+    next_attempt_at = sqlc.arg(next_attempt_at),
+    last_error = sqlc.arg(last_error),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateWebhookDeliveryAttempt :exec
+INSERT INTO webhook_delivery_attempts (delivery_id, status_code, error, duration_ms)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListWebhookDeliveriesByOrg :many
+SELECT d.* FROM webhook_deliveries d
+JOIN webhook_endpoints e ON e.id = d.endpoint_id
+WHERE e.org_id = $1 AND d.status = $2
+ORDER BY d.created_at DESC
+LIMIT $3 OFFSET $4;
+
+-- name: RedeliverWebhookDelivery :one
+-- Re-queues a dead (or delivered) delivery of the org for an immediate attempt with a fresh budget.
+UPDATE webhook_deliveries d
+SET status = 'pending',
+    attempts = 0,
+    next_attempt_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+FROM webhook_endpoints e
+WHERE d.id = sqlc.arg(id) AND e.id = d.endpoint_id AND e.org_id = sqlc.arg(org_id)
+  AND d.status IN ('dead', 'succeeded')
+RETURNING d.*;
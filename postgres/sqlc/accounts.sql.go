@@ -7,21 +7,83 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const countSearchAccounts = `-- name: CountSearchAccounts :one
+SELECT COUNT(*)
+FROM accounts a
+LEFT JOIN users u ON u.id = a.owner_id
+LEFT JOIN LATERAL (
+    SELECT COALESCE(SUM(s.balance), 0) AS balance
+    FROM account_shards sh
+    JOIN accounts s ON s.id = sh.account_id
+    WHERE sh.shard_of = a.id
+) shards ON TRUE
+WHERE NOT EXISTS (SELECT 1 FROM account_shards x WHERE x.account_id = a.id)
+  AND ($1::text IS NULL OR lower(u.email) = lower($1::text))
+  AND ($2::text IS NULL OR a.currency = $2::text)
+  AND ($3::numeric IS NULL OR a.balance + shards.balance >= $3::numeric)
+  AND ($4::numeric IS NULL OR a.balance + shards.balance <= $4::numeric)
+  AND ($5::boolean IS NULL OR a.is_system = $5::boolean)
+  AND ($6::text IS NULL OR a.product = $6::text)
+  AND ($7::uuid IS NULL OR a.org_id = $7::uuid)
+`
+
+type CountSearchAccountsParams struct {
+	OwnerEmail sql.NullString `json:"owner_email"`
+	Currency   sql.NullString `json:"currency"`
+	MinBalance sql.NullString `json:"min_balance"`
+	MaxBalance sql.NullString `json:"max_balance"`
+	IsSystem   sql.NullBool   `json:"is_system"`
+	Product    sql.NullString `json:"product"`
+	OrgID      uuid.NullUUID  `json:"org_id"`
+}
+
+func (q *Queries) CountSearchAccounts(ctx context.Context, arg CountSearchAccountsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchAccounts,
+		arg.OwnerEmail,
+		arg.Currency,
+		arg.MinBalance,
+		arg.MaxBalance,
+		arg.IsSystem,
+		arg.Product,
+		arg.OrgID,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSubWallets = `-- name: CountSubWallets :one
+SELECT COUNT(*) FROM accounts
+WHERE parent_account_id = $1
+`
+
+func (q *Queries) CountSubWallets(ctx context.Context, parentAccountID uuid.NullUUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSubWallets, parentAccountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAccount = `-- name: CreateAccount :one
-INSERT INTO accounts (owner_id, name, currency, is_system)
-VALUES ($1, $2, $3, $4)
-RETURNING id, owner_id, name, balance, currency, is_system, created_at
+INSERT INTO accounts (owner_id, name, currency, is_system, org_id, product, overdraft_limit)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at
 `
 
 type CreateAccountParams struct {
-	OwnerID  uuid.NullUUID `json:"owner_id"`
-	Name     string        `json:"name"`
-	Currency string        `json:"currency"`
-	IsSystem bool          `json:"is_system"`
+	OwnerID        uuid.NullUUID `json:"owner_id"`
+	Name           string        `json:"name"`
+	Currency       string        `json:"currency"`
+	IsSystem       bool          `json:"is_system"`
+	OrgID          uuid.NullUUID `json:"org_id"`
+	Product        string        `json:"product"`
+	OverdraftLimit string        `json:"overdraft_limit"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
@@ -30,6 +92,9 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		arg.Name,
 		arg.Currency,
 		arg.IsSystem,
+		arg.OrgID,
+		arg.Product,
+		arg.OverdraftLimit,
 	)
 	var i Account
 	err := row.Scan(
@@ -40,12 +105,76 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Currency,
 		&i.IsSystem,
 		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const createSubWallet = `-- name: CreateSubWallet :one
+INSERT INTO accounts (owner_id, name, currency, is_system, org_id, parent_account_id)
+VALUES ($1, $2, $3, FALSE, $4, $5)
+RETURNING id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at
+`
+
+type CreateSubWalletParams struct {
+	OwnerID         uuid.NullUUID `json:"owner_id"`
+	Name            string        `json:"name"`
+	Currency        string        `json:"currency"`
+	OrgID           uuid.NullUUID `json:"org_id"`
+	ParentAccountID uuid.NullUUID `json:"parent_account_id"`
+}
+
+func (q *Queries) CreateSubWallet(ctx context.Context, arg CreateSubWalletParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, createSubWallet,
+		arg.OwnerID,
+		arg.Name,
+		arg.Currency,
+		arg.OrgID,
+		arg.ParentAccountID,
+	)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Balance,
+		&i.Currency,
+		&i.IsSystem,
+		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const ensureSystemAccount = `-- name: EnsureSystemAccount :exec
+INSERT INTO accounts (name, balance, currency, is_system)
+VALUES ($1, 0.0000, $2, TRUE)
+ON CONFLICT (name, currency) WHERE is_system DO NOTHING
+`
+
+type EnsureSystemAccountParams struct {
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+}
+
+// Creates a per-currency system account (e.g., FX Position in NGN) unless it already exists.
+func (q *Queries) EnsureSystemAccount(ctx context.Context, arg EnsureSystemAccountParams) error {
+	_, err := q.db.ExecContext(ctx, ensureSystemAccount, arg.Name, arg.Currency)
+	return err
+}
+
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner_id, name, balance, currency, is_system, created_at FROM accounts
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
 WHERE id = $1
 LIMIT 1
 `
@@ -61,18 +190,25 @@ func (q *Queries) GetAccount(ctx context.Context, id uuid.UUID) (Account, error)
 		&i.Currency,
 		&i.IsSystem,
 		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getAccountBalance = `-- name: GetAccountBalance :one
 
-SELECT CAST((COALESCE(SUM(credit), 0::NUMERIC) - COALESCE(SUM(debit), 0::NUMERIC)) AS NUMERIC(19,4)) AS calculated_balance
+SELECT CAST((pruned_balance($1) + COALESCE(SUM(credit), 0::NUMERIC) - COALESCE(SUM(debit), 0::NUMERIC)) AS NUMERIC(19,4)) AS calculated_balance
 FROM entries
 WHERE account_id = $1
 `
 
 // lock prevents concurrent transactions from reading a stale balance.
+// The account's balance summed from its entries, carried forward from those pruned to the archive.
 func (q *Queries) GetAccountBalance(ctx context.Context, accountID uuid.UUID) (string, error) {
 	row := q.db.QueryRowContext(ctx, getAccountBalance, accountID)
 	var calculated_balance string
@@ -80,8 +216,92 @@ func (q *Queries) GetAccountBalance(ctx context.Context, accountID uuid.UUID) (s
 	return calculated_balance, err
 }
 
+const getAccountBalanceBefore = `-- name: GetAccountBalanceBefore :one
+SELECT CAST((
+    COALESCE((
+        SELECT SUM(x.net_amount) FROM entry_archives x
+        WHERE x.account_id = $1
+          AND x.pruned_at IS NOT NULL
+          AND (x.period + INTERVAL '1 month') AT TIME ZONE 'UTC' <= $2
+    ), 0::NUMERIC)
+    + COALESCE(SUM(credit), 0::NUMERIC) - COALESCE(SUM(debit), 0::NUMERIC)
+) AS NUMERIC(19,4)) AS balance
+FROM entries
+WHERE account_id = $1
+  AND created_at < $2
+`
+
+type GetAccountBalanceBeforeParams struct {
+	AccountID  uuid.UUID    `json:"account_id"`
+	BeforeTime sql.NullTime `json:"before_time"`
+}
+
+// Carries forward the pruned months that ended by before_time; a pruned month's own entries are
+// only in the archive.
+func (q *Queries) GetAccountBalanceBefore(ctx context.Context, arg GetAccountBalanceBeforeParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getAccountBalanceBefore, arg.AccountID, arg.BeforeTime)
+	var balance string
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const getAccountByVirtualNumber = `-- name: GetAccountByVirtualNumber :one
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
+WHERE virtual_account_number = $1 AND is_system = FALSE
+LIMIT 1
+`
+
+func (q *Queries) GetAccountByVirtualNumber(ctx context.Context, virtualAccountNumber string) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByVirtualNumber, virtualAccountNumber)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Balance,
+		&i.Currency,
+		&i.IsSystem,
+		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getAccountByVirtualNumberForUpdate = `-- name: GetAccountByVirtualNumberForUpdate :one
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
+WHERE virtual_account_number = $1 AND is_system = FALSE
+LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetAccountByVirtualNumberForUpdate(ctx context.Context, virtualAccountNumber string) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByVirtualNumberForUpdate, virtualAccountNumber)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Balance,
+		&i.Currency,
+		&i.IsSystem,
+		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner_id, name, balance, currency, is_system, created_at FROM accounts
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
 WHERE id = $1
 LIMIT 1
 FOR UPDATE
@@ -98,12 +318,118 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id uuid.UUID) (Accoun
 		&i.Currency,
 		&i.IsSystem,
 		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getDefaultAccount = `-- name: GetDefaultAccount :one
+SELECT a.id, a.owner_id, a.name, a.balance, a.currency, a.is_system, a.created_at, a.virtual_account_number, a.org_id, a.parent_account_id, a.product, a.overdraft_limit, a.updated_at FROM accounts a
+JOIN users u ON u.id = a.owner_id
+WHERE a.owner_id = $1 AND a.is_system = FALSE AND a.parent_account_id IS NULL
+ORDER BY (a.id = u.default_account_id) IS TRUE DESC, a.created_at
+LIMIT 1
+`
+
+// The user's chosen default account, else their oldest primary-owned top-level account.
+func (q *Queries) GetDefaultAccount(ctx context.Context, ownerID uuid.NullUUID) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getDefaultAccount, ownerID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Balance,
+		&i.Currency,
+		&i.IsSystem,
+		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getDisputesHoldingAccountForUpdate = `-- name: GetDisputesHoldingAccountForUpdate :one
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
+WHERE is_system = TRUE AND name = 'Disputes Holding'
+LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetDisputesHoldingAccountForUpdate(ctx context.Context) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getDisputesHoldingAccountForUpdate)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Balance,
+		&i.Currency,
+		&i.IsSystem,
+		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPayoutClearingAccountForUpdate = `-- name: GetPayoutClearingAccountForUpdate :one
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
+WHERE is_system = TRUE AND name = 'Payouts In Transit'
+LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetPayoutClearingAccountForUpdate(ctx context.Context) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getPayoutClearingAccountForUpdate)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Balance,
+		&i.Currency,
+		&i.IsSystem,
+		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const getRolledUpBalance = `-- name: GetRolledUpBalance :one
+SELECT CAST(COALESCE(SUM(balance), 0::NUMERIC) AS NUMERIC(19,4)) AS total_balance
+FROM accounts
+WHERE id = $1 OR parent_account_id = $1
+`
+
+// Parent balance plus every sub-wallet balance.
+func (q *Queries) GetRolledUpBalance(ctx context.Context, id uuid.UUID) (string, error) {
+	row := q.db.QueryRowContext(ctx, getRolledUpBalance, id)
+	var total_balance string
+	err := row.Scan(&total_balance)
+	return total_balance, err
+}
+
 const getSettlementAccount = `-- name: GetSettlementAccount :one
-SELECT id, owner_id, name, balance, currency, is_system, created_at FROM accounts
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
 WHERE is_system = TRUE AND name = 'Settlement Account'
 LIMIT 1
 `
@@ -119,12 +445,18 @@ func (q *Queries) GetSettlementAccount(ctx context.Context) (Account, error) {
 		&i.Currency,
 		&i.IsSystem,
 		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getSettlementAccountForUpdate = `-- name: GetSettlementAccountForUpdate :one
-SELECT id, owner_id, name, balance, currency, is_system, created_at FROM accounts
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
 WHERE is_system = TRUE AND name = 'Settlement Account'
 LIMIT 1
 FOR UPDATE
@@ -141,13 +473,80 @@ func (q *Queries) GetSettlementAccountForUpdate(ctx context.Context) (Account, e
 		&i.Currency,
 		&i.IsSystem,
 		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSuspenseAccountForUpdate = `-- name: GetSuspenseAccountForUpdate :one
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
+WHERE is_system = TRUE AND name = 'Unapplied Receipts'
+LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetSuspenseAccountForUpdate(ctx context.Context) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getSuspenseAccountForUpdate)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Balance,
+		&i.Currency,
+		&i.IsSystem,
+		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSystemAccountForUpdate = `-- name: GetSystemAccountForUpdate :one
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
+WHERE is_system = TRUE AND name = $1 AND currency = $2
+LIMIT 1
+FOR UPDATE
+`
+
+type GetSystemAccountForUpdateParams struct {
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) GetSystemAccountForUpdate(ctx context.Context, arg GetSystemAccountForUpdateParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getSystemAccountForUpdate, arg.Name, arg.Currency)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Balance,
+		&i.Currency,
+		&i.IsSystem,
+		&i.CreatedAt,
+		&i.VirtualAccountNumber,
+		&i.OrgID,
+		&i.ParentAccountID,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const listAccountsByOwner = `-- name: ListAccountsByOwner :many
 
-SELECT id, owner_id, name, balance, currency, is_system, created_at FROM accounts
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
 WHERE owner_id = $1
 ORDER BY created_at DESC
 `
@@ -170,6 +569,189 @@ func (q *Queries) ListAccountsByOwner(ctx context.Context, ownerID uuid.NullUUID
 			&i.Currency,
 			&i.IsSystem,
 			&i.CreatedAt,
+			&i.VirtualAccountNumber,
+			&i.OrgID,
+			&i.ParentAccountID,
+			&i.Product,
+			&i.OverdraftLimit,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSettlementCurrencies = `-- name: ListSettlementCurrencies :many
+SELECT DISTINCT s.currency FROM accounts s
+JOIN accounts c ON c.is_system = TRUE AND c.name = 'Payouts In Transit' AND c.currency = s.currency
+WHERE s.is_system = TRUE AND s.name = 'Settlement Account'
+ORDER BY s.currency
+`
+
+// Currencies customer money can enter and leave the ledger in: those of a settlement account with
+// a payout clearing account beside it.
+func (q *Queries) ListSettlementCurrencies(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listSettlementCurrencies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var currency string
+		if err := rows.Scan(&currency); err != nil {
+			return nil, err
+		}
+		items = append(items, currency)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubWallets = `-- name: ListSubWallets :many
+SELECT id, owner_id, name, balance, currency, is_system, created_at, virtual_account_number, org_id, parent_account_id, product, overdraft_limit, updated_at FROM accounts
+WHERE parent_account_id = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ListSubWallets(ctx context.Context, parentAccountID uuid.NullUUID) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listSubWallets, parentAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Account
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Name,
+			&i.Balance,
+			&i.Currency,
+			&i.IsSystem,
+			&i.CreatedAt,
+			&i.VirtualAccountNumber,
+			&i.OrgID,
+			&i.ParentAccountID,
+			&i.Product,
+			&i.OverdraftLimit,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchAccounts = `-- name: SearchAccounts :many
+SELECT a.id, a.owner_id, a.name, a.balance, a.currency, a.is_system, a.created_at, a.virtual_account_number, a.org_id, a.parent_account_id, a.product, a.overdraft_limit, a.updated_at, u.email AS owner_email,
+       CAST(a.balance + shards.balance AS NUMERIC(19,4)) AS total_balance
+FROM accounts a
+LEFT JOIN users u ON u.id = a.owner_id
+LEFT JOIN LATERAL (
+    SELECT COALESCE(SUM(s.balance), 0) AS balance
+    FROM account_shards sh
+    JOIN accounts s ON s.id = sh.account_id
+    WHERE sh.shard_of = a.id
+) shards ON TRUE
+WHERE NOT EXISTS (SELECT 1 FROM account_shards x WHERE x.account_id = a.id)
+  AND ($1::text IS NULL OR lower(u.email) = lower($1::text))
+  AND ($2::text IS NULL OR a.currency = $2::text)
+  AND ($3::numeric IS NULL OR a.balance + shards.balance >= $3::numeric)
+  AND ($4::numeric IS NULL OR a.balance + shards.balance <= $4::numeric)
+  AND ($5::boolean IS NULL OR a.is_system = $5::boolean)
+  AND ($6::text IS NULL OR a.product = $6::text)
+  AND ($7::uuid IS NULL OR a.org_id = $7::uuid)
+ORDER BY
+    CASE WHEN $8::text = 'created_at' THEN a.created_at END ASC,
+    CASE WHEN $8::text = 'name' THEN a.name END ASC,
+    CASE WHEN $8::text = '-name' THEN a.name END DESC,
+    CASE WHEN $8::text = 'balance' THEN a.balance + shards.balance END ASC,
+    CASE WHEN $8::text = '-balance' THEN a.balance + shards.balance END DESC,
+    a.created_at DESC, a.id DESC
+LIMIT $10 OFFSET $9
+`
+
+type SearchAccountsParams struct {
+	OwnerEmail sql.NullString `json:"owner_email"`
+	Currency   sql.NullString `json:"currency"`
+	MinBalance sql.NullString `json:"min_balance"`
+	MaxBalance sql.NullString `json:"max_balance"`
+	IsSystem   sql.NullBool   `json:"is_system"`
+	Product    sql.NullString `json:"product"`
+	OrgID      uuid.NullUUID  `json:"org_id"`
+	Sort       string         `json:"sort"`
+	RowOffset  int32          `json:"row_offset"`
+	RowLimit   int32          `json:"row_limit"`
+}
+
+type SearchAccountsRow struct {
+	Account      Account        `json:"account"`
+	OwnerEmail   sql.NullString `json:"owner_email"`
+	TotalBalance string         `json:"total_balance"`
+}
+
+// The admin account browser. Every filter is optional; owner_email matches the primary owner
+// case-insensitively, and the balance bounds are inclusive. sort takes the same keys as
+// ListAccountsForUser. Shards of a split system account are not listed; their balances are
+// added to the account's total_balance, which the balance filters and sorts use.
+func (q *Queries) SearchAccounts(ctx context.Context, arg SearchAccountsParams) ([]SearchAccountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchAccounts,
+		arg.OwnerEmail,
+		arg.Currency,
+		arg.MinBalance,
+		arg.MaxBalance,
+		arg.IsSystem,
+		arg.Product,
+		arg.OrgID,
+		arg.Sort,
+		arg.RowOffset,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchAccountsRow
+	for rows.Next() {
+		var i SearchAccountsRow
+		if err := rows.Scan(
+			&i.Account.ID,
+			&i.Account.OwnerID,
+			&i.Account.Name,
+			&i.Account.Balance,
+			&i.Account.Currency,
+			&i.Account.IsSystem,
+			&i.Account.CreatedAt,
+			&i.Account.VirtualAccountNumber,
+			&i.Account.OrgID,
+			&i.Account.ParentAccountID,
+			&i.Account.Product,
+			&i.Account.OverdraftLimit,
+			&i.Account.UpdatedAt,
+			&i.OwnerEmail,
+			&i.TotalBalance,
 		); err != nil {
 			return nil, err
 		}
@@ -199,3 +781,25 @@ func (q *Queries) UpdateAccountBalance(ctx context.Context, arg UpdateAccountBal
 	_, err := q.db.ExecContext(ctx, updateAccountBalance, arg.Balance, arg.ID)
 	return err
 }
+
+const updateAccountBalances = `-- name: UpdateAccountBalances :exec
+UPDATE accounts a
+SET balance = a.balance + d.delta
+FROM (
+    SELECT m.account_id, SUM(m.delta) AS delta
+    FROM unnest($1::uuid[], $2::numeric[]) AS m(account_id, delta)
+    GROUP BY m.account_id
+) d
+WHERE a.id = d.account_id
+`
+
+type UpdateAccountBalancesParams struct {
+	AccountIds []uuid.UUID `json:"account_ids"`
+	Deltas     []string    `json:"deltas"`
+}
+
+// Moves each account's cached balance by the sum of its deltas, in one statement.
+func (q *Queries) UpdateAccountBalances(ctx context.Context, arg UpdateAccountBalancesParams) error {
+	_, err := q.db.ExecContext(ctx, updateAccountBalances, pq.Array(arg.AccountIds), pq.Array(arg.Deltas))
+	return err
+}
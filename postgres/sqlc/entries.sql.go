@@ -8,17 +8,95 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const countEntriesByAccount = `-- name: CountEntriesByAccount :one
+SELECT COUNT(*) FROM entries
+WHERE account_id = $1
+`
+
+func (q *Queries) CountEntriesByAccount(ctx context.Context, accountID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countEntriesByAccount, accountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createEntries = `-- name: CreateEntries :many
+INSERT INTO entries (id, account_id, debit, credit, transaction_id, operation_type, description)
+SELECT e.id, e.account_id, e.debit, e.credit, $1::uuid, $2::operation_type, NULLIF(e.description, '')
+FROM unnest($3::uuid[], $4::uuid[], $5::numeric[], $6::numeric[], $7::text[])
+     WITH ORDINALITY AS e(id, account_id, debit, credit, description, ord)
+ORDER BY e.ord
+RETURNING id, account_id, debit, credit, transaction_id, operation_type, description, created_at, chain_seq, prev_hash, hash
+`
+
+type CreateEntriesParams struct {
+	TransactionID uuid.UUID   `json:"transaction_id"`
+	OperationType string      `json:"operation_type"`
+	Ids           []uuid.UUID `json:"ids"`
+	AccountIds    []uuid.UUID `json:"account_ids"`
+	Debits        []string    `json:"debits"`
+	Credits       []string    `json:"credits"`
+	Descriptions  []string    `json:"descriptions"`
+}
+
+// Inserts a posting's entries in one statement, chained in the order given.
+func (q *Queries) CreateEntries(ctx context.Context, arg CreateEntriesParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, createEntries,
+		arg.TransactionID,
+		arg.OperationType,
+		pq.Array(arg.Ids),
+		pq.Array(arg.AccountIds),
+		pq.Array(arg.Debits),
+		pq.Array(arg.Credits),
+		pq.Array(arg.Descriptions),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Entry
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Debit,
+			&i.Credit,
+			&i.TransactionID,
+			&i.OperationType,
+			&i.Description,
+			&i.CreatedAt,
+			&i.ChainSeq,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createEntry = `-- name: CreateEntry :one
-INSERT INTO entries (account_id, debit, credit, transaction_id, operation_type, description)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, account_id, debit, credit, transaction_id, operation_type, description, created_at
+INSERT INTO entries (id, account_id, debit, credit, transaction_id, operation_type, description)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, account_id, debit, credit, transaction_id, operation_type, description, created_at, chain_seq, prev_hash, hash
 `
 
 type CreateEntryParams struct {
+	ID            uuid.UUID      `json:"id"`
 	AccountID     uuid.UUID      `json:"account_id"`
 	Debit         string         `json:"debit"`
 	Credit        string         `json:"credit"`
@@ -29,6 +107,7 @@ type CreateEntryParams struct {
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
 	row := q.db.QueryRowContext(ctx, createEntry,
+		arg.ID,
 		arg.AccountID,
 		arg.Debit,
 		arg.Credit,
@@ -46,25 +125,177 @@ func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry
 		&i.OperationType,
 		&i.Description,
 		&i.CreatedAt,
+		&i.ChainSeq,
+		&i.PrevHash,
+		&i.Hash,
+	)
+	return i, err
+}
+
+const dailyTotalsBetween = `-- name: DailyTotalsBetween :many
+SELECT d.day::date AS day,
+       CAST(COALESCE(SUM(e.credit), 0::NUMERIC) AS NUMERIC(19,4)) AS credits,
+       CAST(COALESCE(SUM(e.debit), 0::NUMERIC) AS NUMERIC(19,4)) AS debits,
+       COUNT(e.id) AS entry_count
+FROM generate_series($1::timestamp, $2::timestamp - INTERVAL '1 day', INTERVAL '1 day') AS d(day)
+LEFT JOIN entries e
+    ON e.account_id = $3
+   AND e.created_at >= d.day AT TIME ZONE 'UTC'
+   AND e.created_at < (d.day + INTERVAL '1 day') AT TIME ZONE 'UTC'
+GROUP BY d.day
+ORDER BY d.day
+`
+
+type DailyTotalsBetweenParams struct {
+	FromDay   time.Time `json:"from_day"`
+	ToDay     time.Time `json:"to_day"`
+	AccountID uuid.UUID `json:"account_id"`
+}
+
+type DailyTotalsBetweenRow struct {
+	Day        time.Time `json:"day"`
+	Credits    string    `json:"credits"`
+	Debits     string    `json:"debits"`
+	EntryCount int64     `json:"entry_count"`
+}
+
+// One row per UTC day in [from_day, to_day), including days without entries.
+func (q *Queries) DailyTotalsBetween(ctx context.Context, arg DailyTotalsBetweenParams) ([]DailyTotalsBetweenRow, error) {
+	rows, err := q.db.QueryContext(ctx, dailyTotalsBetween, arg.FromDay, arg.ToDay, arg.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DailyTotalsBetweenRow
+	for rows.Next() {
+		var i DailyTotalsBetweenRow
+		if err := rows.Scan(
+			&i.Day,
+			&i.Credits,
+			&i.Debits,
+			&i.EntryCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEntry = `-- name: GetEntry :one
+SELECT id, account_id, debit, credit, transaction_id, operation_type, description, created_at, chain_seq, prev_hash, hash FROM entries
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetEntry(ctx context.Context, id uuid.UUID) (Entry, error) {
+	row := q.db.QueryRowContext(ctx, getEntry, id)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Debit,
+		&i.Credit,
+		&i.TransactionID,
+		&i.OperationType,
+		&i.Description,
+		&i.CreatedAt,
+		&i.ChainSeq,
+		&i.PrevHash,
+		&i.Hash,
 	)
 	return i, err
 }
 
-const listEntriesByAccount = `-- name: ListEntriesByAccount :many
-SELECT id, account_id, debit, credit, transaction_id, operation_type, description, created_at FROM entries
+const largestTransactionsBetween = `-- name: LargestTransactionsBetween :many
+SELECT e.transaction_id,
+       CAST(SUM(e.credit) AS NUMERIC(19,4)) AS credits,
+       CAST(SUM(e.debit) AS NUMERIC(19,4)) AS debits,
+       COALESCE((array_agg(e.description ORDER BY GREATEST(e.debit, e.credit) DESC))[1], '')::text AS description,
+       MIN(e.created_at)::timestamptz AS created_at
+FROM entries e
+WHERE e.account_id = $1
+  AND e.created_at >= $2::timestamptz
+  AND e.created_at < $3::timestamptz
+GROUP BY e.transaction_id
+ORDER BY ABS(SUM(e.credit) - SUM(e.debit)) DESC, MIN(e.created_at)
+LIMIT $4
+`
+
+type LargestTransactionsBetweenParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+	RowLimit  int32     `json:"row_limit"`
+}
+
+type LargestTransactionsBetweenRow struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Credits       string    `json:"credits"`
+	Debits        string    `json:"debits"`
+	Description   string    `json:"description"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// The account's transactions in [from_time, to_time) by the size of their net effect on it.
+func (q *Queries) LargestTransactionsBetween(ctx context.Context, arg LargestTransactionsBetweenParams) ([]LargestTransactionsBetweenRow, error) {
+	rows, err := q.db.QueryContext(ctx, largestTransactionsBetween,
+		arg.AccountID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LargestTransactionsBetweenRow
+	for rows.Next() {
+		var i LargestTransactionsBetweenRow
+		if err := rows.Scan(
+			&i.TransactionID,
+			&i.Credits,
+			&i.Debits,
+			&i.Description,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntriesByAccountAfter = `-- name: ListEntriesByAccountAfter :many
+SELECT id, account_id, debit, credit, transaction_id, operation_type, description, created_at, chain_seq, prev_hash, hash FROM entries
 WHERE account_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+  AND chain_seq > $2
+ORDER BY chain_seq
+LIMIT $3
 `
 
-type ListEntriesByAccountParams struct {
+type ListEntriesByAccountAfterParams struct {
 	AccountID uuid.UUID `json:"account_id"`
-	Limit     int32     `json:"limit"`
-	Offset    int32     `json:"offset"`
+	AfterSeq  int64     `json:"after_seq"`
+	RowLimit  int32     `json:"row_limit"`
 }
 
-func (q *Queries) ListEntriesByAccount(ctx context.Context, arg ListEntriesByAccountParams) ([]Entry, error) {
-	rows, err := q.db.QueryContext(ctx, listEntriesByAccount, arg.AccountID, arg.Limit, arg.Offset)
+// Returns the account's entries after chain position after_seq; used to resume streams. Positions
+// are taken while the posting holds the account's chain head, so they follow commit order.
+func (q *Queries) ListEntriesByAccountAfter(ctx context.Context, arg ListEntriesByAccountAfterParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listEntriesByAccountAfter, arg.AccountID, arg.AfterSeq, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +312,58 @@ func (q *Queries) ListEntriesByAccount(ctx context.Context, arg ListEntriesByAcc
 			&i.OperationType,
 			&i.Description,
 			&i.CreatedAt,
+			&i.ChainSeq,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntriesByAccountBetween = `-- name: ListEntriesByAccountBetween :many
+SELECT id, account_id, debit, credit, transaction_id, operation_type, description, created_at, chain_seq, prev_hash, hash FROM entries
+WHERE account_id = $1
+  AND created_at >= $2
+  AND created_at < $3
+ORDER BY created_at, id
+`
+
+type ListEntriesByAccountBetweenParams struct {
+	AccountID uuid.UUID    `json:"account_id"`
+	FromTime  sql.NullTime `json:"from_time"`
+	ToTime    sql.NullTime `json:"to_time"`
+}
+
+func (q *Queries) ListEntriesByAccountBetween(ctx context.Context, arg ListEntriesByAccountBetweenParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listEntriesByAccountBetween, arg.AccountID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Entry
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Debit,
+			&i.Credit,
+			&i.TransactionID,
+			&i.OperationType,
+			&i.Description,
+			&i.CreatedAt,
+			&i.ChainSeq,
+			&i.PrevHash,
+			&i.Hash,
 		); err != nil {
 			return nil, err
 		}
@@ -96,7 +379,7 @@ func (q *Queries) ListEntriesByAccount(ctx context.Context, arg ListEntriesByAcc
 }
 
 const listEntriesByTransaction = `-- name: ListEntriesByTransaction :many
-SELECT id, account_id, debit, credit, transaction_id, operation_type, description, created_at FROM entries
+SELECT id, account_id, debit, credit, transaction_id, operation_type, description, created_at, chain_seq, prev_hash, hash FROM entries
 WHERE transaction_id = $1
 ORDER BY created_at
 `
@@ -119,6 +402,89 @@ func (q *Queries) ListEntriesByTransaction(ctx context.Context, transactionID uu
 			&i.OperationType,
 			&i.Description,
 			&i.CreatedAt,
+			&i.ChainSeq,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentRecipients = `-- name: ListRecentRecipients :many
+SELECT a.id, a.name, a.currency, a.virtual_account_number,
+       COALESCE(u.first_name, '') AS first_name, COALESCE(u.last_name, '') AS last_name, COALESCE(u.email, '') AS email,
+       MAX(d.created_at)::timestamptz AS last_sent_at,
+       COUNT(DISTINCT d.transaction_id) AS transfer_count
+FROM entries d
+JOIN entries c ON c.transaction_id = d.transaction_id AND c.credit > 0 AND c.operation_type = 'transfer'
+JOIN accounts a ON a.id = c.account_id
+LEFT JOIN users u ON u.id = a.owner_id
+WHERE d.debit > 0
+  AND d.operation_type = 'transfer'
+  AND EXISTS (
+      SELECT 1 FROM account_owners ao
+      JOIN accounts da ON da.id = d.account_id
+      WHERE ao.user_id = $1
+        AND ao.account_id IN (da.id, da.parent_account_id)
+  )
+  AND NOT a.is_system
+  AND NOT EXISTS (
+      SELECT 1 FROM account_owners ao
+      WHERE ao.user_id = $1
+        AND ao.account_id IN (a.id, a.parent_account_id)
+  )
+GROUP BY a.id, u.id
+ORDER BY last_sent_at DESC
+LIMIT $2
+`
+
+type ListRecentRecipientsParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	RowLimit int32     `json:"row_limit"`
+}
+
+type ListRecentRecipientsRow struct {
+	ID                   uuid.UUID `json:"id"`
+	Name                 string    `json:"name"`
+	Currency             string    `json:"currency"`
+	VirtualAccountNumber string    `json:"virtual_account_number"`
+	FirstName            string    `json:"first_name"`
+	LastName             string    `json:"last_name"`
+	Email                string    `json:"email"`
+	LastSentAt           time.Time `json:"last_sent_at"`
+	TransferCount        int64     `json:"transfer_count"`
+}
+
+// Accounts credited by transfers debited from accounts the user owns or co-owns, most recent
+// first. Fee legs (system accounts) and the user's own accounts are not recipients.
+func (q *Queries) ListRecentRecipients(ctx context.Context, arg ListRecentRecipientsParams) ([]ListRecentRecipientsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRecentRecipients, arg.UserID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRecentRecipientsRow
+	for rows.Next() {
+		var i ListRecentRecipientsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Currency,
+			&i.VirtualAccountNumber,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.LastSentAt,
+			&i.TransferCount,
 		); err != nil {
 			return nil, err
 		}
@@ -6,18 +6,254 @@ package sqlc
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Account struct {
-	ID        uuid.UUID     `json:"id"`
-	OwnerID   uuid.NullUUID `json:"owner_id"`
-	Name      string        `json:"name"`
-	Balance   string        `json:"balance"`
-	Currency  string        `json:"currency"`
-	IsSystem  bool          `json:"is_system"`
-	CreatedAt sql.NullTime  `json:"created_at"`
+	ID                   uuid.UUID     `json:"id"`
+	OwnerID              uuid.NullUUID `json:"owner_id"`
+	Name                 string        `json:"name"`
+	Balance              string        `json:"balance"`
+	Currency             string        `json:"currency"`
+	IsSystem             bool          `json:"is_system"`
+	CreatedAt            sql.NullTime  `json:"created_at"`
+	VirtualAccountNumber string        `json:"virtual_account_number"`
+	OrgID                uuid.NullUUID `json:"org_id"`
+	ParentAccountID      uuid.NullUUID `json:"parent_account_id"`
+	Product              string        `json:"product"`
+	OverdraftLimit       string        `json:"overdraft_limit"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+}
+
+type AccountHistory struct {
+	EntryID               uuid.UUID     `json:"entry_id"`
+	AccountID             uuid.UUID     `json:"account_id"`
+	TransactionID         uuid.UUID     `json:"transaction_id"`
+	OperationType         string        `json:"operation_type"`
+	Debit                 string        `json:"debit"`
+	Credit                string        `json:"credit"`
+	BalanceAfter          string        `json:"balance_after"`
+	Description           string        `json:"description"`
+	CounterpartyAccountID uuid.NullUUID `json:"counterparty_account_id"`
+	CounterpartyName      string        `json:"counterparty_name"`
+	CategoryID            uuid.NullUUID `json:"category_id"`
+	CategoryName          string        `json:"category_name"`
+	CreatedAt             time.Time     `json:"created_at"`
+}
+
+type AccountOwner struct {
+	AccountID uuid.UUID     `json:"account_id"`
+	UserID    uuid.UUID     `json:"user_id"`
+	Role      string        `json:"role"`
+	AddedBy   uuid.NullUUID `json:"added_by"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type AccountProduct struct {
+	Product        string         `json:"product"`
+	Currency       string         `json:"currency"`
+	MinBalance     string         `json:"min_balance"`
+	UpdatedBy      uuid.UUID      `json:"updated_by"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	OverdraftLimit string         `json:"overdraft_limit"`
+	TransferFee    string         `json:"transfer_fee"`
+	WithdrawalFee  string         `json:"withdrawal_fee"`
+	MaxDebit       sql.NullString `json:"max_debit"`
+}
+
+type AccountShard struct {
+	AccountID  uuid.UUID `json:"account_id"`
+	ShardOf    uuid.UUID `json:"shard_of"`
+	ShardIndex int32     `json:"shard_index"`
+}
+
+type AccountSigningKey struct {
+	ID         uuid.UUID    `json:"id"`
+	AccountID  uuid.UUID    `json:"account_id"`
+	Name       string       `json:"name"`
+	PublicKey  string       `json:"public_key"`
+	CreatedBy  uuid.UUID    `json:"created_by"`
+	CreatedAt  time.Time    `json:"created_at"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	RevokedAt  sql.NullTime `json:"revoked_at"`
+}
+
+type Adjustment struct {
+	ID            uuid.UUID     `json:"id"`
+	ReasonCode    string        `json:"reason_code"`
+	Memo          string        `json:"memo"`
+	Status        string        `json:"status"`
+	RequestedBy   uuid.UUID     `json:"requested_by"`
+	RequestedAt   time.Time     `json:"requested_at"`
+	DecidedBy     uuid.NullUUID `json:"decided_by"`
+	DecidedAt     sql.NullTime  `json:"decided_at"`
+	DecisionNote  string        `json:"decision_note"`
+	TransactionID uuid.NullUUID `json:"transaction_id"`
+}
+
+type AdjustmentLine struct {
+	AdjustmentID uuid.UUID `json:"adjustment_id"`
+	LineNo       int32     `json:"line_no"`
+	AccountID    uuid.UUID `json:"account_id"`
+	Debit        string    `json:"debit"`
+	Credit       string    `json:"credit"`
+	Description  string    `json:"description"`
+}
+
+type AmlAlert struct {
+	ID             uuid.UUID     `json:"id"`
+	OrgID          uuid.UUID     `json:"org_id"`
+	AccountID      uuid.UUID     `json:"account_id"`
+	CounterpartyID uuid.NullUUID `json:"counterparty_id"`
+	Operation      string        `json:"operation"`
+	Direction      string        `json:"direction"`
+	Amount         string        `json:"amount"`
+	Currency       string        `json:"currency"`
+	Action         string        `json:"action"`
+	Rules          []string      `json:"rules"`
+	Reason         string        `json:"reason"`
+	Status         string        `json:"status"`
+	CreatedAt      time.Time     `json:"created_at"`
+	ReviewedBy     uuid.NullUUID `json:"reviewed_by"`
+	ReviewedAt     sql.NullTime  `json:"reviewed_at"`
+	ReviewNote     string        `json:"review_note"`
+	TransactionID  uuid.NullUUID `json:"transaction_id"`
+}
+
+type BalanceReconciliationResult struct {
+	RunID             uuid.UUID `json:"run_id"`
+	AccountID         uuid.UUID `json:"account_id"`
+	Currency          string    `json:"currency"`
+	StoredBalance     string    `json:"stored_balance"`
+	CalculatedBalance string    `json:"calculated_balance"`
+	Matched           bool      `json:"matched"`
+	CheckedAt         time.Time `json:"checked_at"`
+}
+
+type BalanceReconciliationRun struct {
+	ID                 uuid.UUID     `json:"id"`
+	Status             string        `json:"status"`
+	RequestedBy        uuid.UUID     `json:"requested_by"`
+	TotalAccounts      int32         `json:"total_accounts"`
+	CheckedAccounts    int32         `json:"checked_accounts"`
+	MismatchedAccounts int32         `json:"mismatched_accounts"`
+	LastAccountID      uuid.NullUUID `json:"last_account_id"`
+	StartedAt          time.Time     `json:"started_at"`
+	UpdatedAt          time.Time     `json:"updated_at"`
+	FinishedAt         sql.NullTime  `json:"finished_at"`
+}
+
+type BalanceRepair struct {
+	ID                uuid.UUID `json:"id"`
+	AccountID         uuid.UUID `json:"account_id"`
+	AdjustmentID      uuid.UUID `json:"adjustment_id"`
+	StoredBalance     string    `json:"stored_balance"`
+	CalculatedBalance string    `json:"calculated_balance"`
+	Delta             string    `json:"delta"`
+	Status            string    `json:"status"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type BalanceRepairEvent struct {
+	ID        int64           `json:"id"`
+	RepairID  uuid.UUID       `json:"repair_id"`
+	Action    string          `json:"action"`
+	ActorID   uuid.NullUUID   `json:"actor_id"`
+	Detail    json.RawMessage `json:"detail"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type BalanceSnapshot struct {
+	ID         uuid.UUID `json:"id"`
+	ThroughSeq int64     `json:"through_seq"`
+	Accounts   int32     `json:"accounts"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type BalanceSnapshotLine struct {
+	SnapshotID uuid.UUID `json:"snapshot_id"`
+	AccountID  uuid.UUID `json:"account_id"`
+	Balance    string    `json:"balance"`
+}
+
+type BankStatementImport struct {
+	ID              uuid.UUID       `json:"id"`
+	SourceFormat    string          `json:"source_format"`
+	Filename        string          `json:"filename"`
+	PeriodStart     time.Time       `json:"period_start"`
+	PeriodEnd       time.Time       `json:"period_end"`
+	LineCount       int32           `json:"line_count"`
+	MatchedCount    int32           `json:"matched_count"`
+	MissingCount    int32           `json:"missing_count"`
+	UnexpectedCount int32           `json:"unexpected_count"`
+	Report          json.RawMessage `json:"report"`
+	ImportedBy      uuid.NullUUID   `json:"imported_by"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+type CashPool struct {
+	ID              uuid.UUID `json:"id"`
+	MasterAccountID uuid.UUID `json:"master_account_id"`
+	Name            string    `json:"name"`
+	CreatedBy       uuid.UUID `json:"created_by"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type CashPoolMember struct {
+	PoolID        uuid.UUID `json:"pool_id"`
+	AccountID     uuid.UUID `json:"account_id"`
+	TargetBalance string    `json:"target_balance"`
+	AddedBy       uuid.UUID `json:"added_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type CashPoolMovement struct {
+	ID            uuid.UUID     `json:"id"`
+	PoolID        uuid.UUID     `json:"pool_id"`
+	AccountID     uuid.UUID     `json:"account_id"`
+	Kind          string        `json:"kind"`
+	Amount        string        `json:"amount"`
+	TransactionID uuid.UUID     `json:"transaction_id"`
+	CreatedBy     uuid.NullUUID `json:"created_by"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+type Category struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CategoryRule struct {
+	ID                    uuid.UUID      `json:"id"`
+	UserID                uuid.UUID      `json:"user_id"`
+	CategoryID            uuid.UUID      `json:"category_id"`
+	DescriptionPattern    sql.NullString `json:"description_pattern"`
+	CounterpartyAccountID uuid.NullUUID  `json:"counterparty_account_id"`
+	Priority              int32          `json:"priority"`
+	CreatedAt             time.Time      `json:"created_at"`
+}
+
+type Dispute struct {
+	ID                      uuid.UUID     `json:"id"`
+	TransactionID           uuid.UUID     `json:"transaction_id"`
+	AccountID               uuid.UUID     `json:"account_id"`
+	CounterpartyAccountID   uuid.UUID     `json:"counterparty_account_id"`
+	Amount                  string        `json:"amount"`
+	Currency                string        `json:"currency"`
+	Reason                  string        `json:"reason"`
+	Status                  string        `json:"status"`
+	OpenedBy                uuid.UUID     `json:"opened_by"`
+	OpenedAt                time.Time     `json:"opened_at"`
+	HoldTransactionID       uuid.UUID     `json:"hold_transaction_id"`
+	ResolvedBy              uuid.NullUUID `json:"resolved_by"`
+	ResolvedAt              sql.NullTime  `json:"resolved_at"`
+	ResolutionNote          string        `json:"resolution_note"`
+	ResolutionTransactionID uuid.NullUUID `json:"resolution_transaction_id"`
 }
 
 type Entry struct {
@@ -29,11 +265,689 @@ type Entry struct {
 	OperationType string         `json:"operation_type"`
 	Description   sql.NullString `json:"description"`
 	CreatedAt     sql.NullTime   `json:"created_at"`
+	ChainSeq      int64          `json:"chain_seq"`
+	PrevHash      []byte         `json:"prev_hash"`
+	Hash          []byte         `json:"hash"`
+}
+
+type EntryArchive struct {
+	ID           uuid.UUID    `json:"id"`
+	AccountID    uuid.UUID    `json:"account_id"`
+	Period       time.Time    `json:"period"`
+	ObjectKey    string       `json:"object_key"`
+	Sha256       []byte       `json:"sha256"`
+	EntryCount   int32        `json:"entry_count"`
+	FirstEntryAt time.Time    `json:"first_entry_at"`
+	LastEntryAt  time.Time    `json:"last_entry_at"`
+	RetainUntil  time.Time    `json:"retain_until"`
+	PrunedAt     sql.NullTime `json:"pruned_at"`
+	CreatedAt    time.Time    `json:"created_at"`
+	NetAmount    string       `json:"net_amount"`
+	LastChainSeq int64        `json:"last_chain_seq"`
+	LastHash     []byte       `json:"last_hash"`
+}
+
+type EntryCategory struct {
+	EntryID    uuid.UUID `json:"entry_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	CategoryID uuid.UUID `json:"category_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type EntryChainHead struct {
+	AccountID uuid.UUID `json:"account_id"`
+	ChainSeq  int64     `json:"chain_seq"`
+	Hash      []byte    `json:"hash"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Escrow struct {
+	ID                      uuid.UUID     `json:"id"`
+	OrgID                   uuid.UUID     `json:"org_id"`
+	BuyerAccountID          uuid.UUID     `json:"buyer_account_id"`
+	SellerAccountID         uuid.UUID     `json:"seller_account_id"`
+	Amount                  string        `json:"amount"`
+	Currency                string        `json:"currency"`
+	Description             string        `json:"description"`
+	Status                  string        `json:"status"`
+	CreatedBy               uuid.UUID     `json:"created_by"`
+	CreatedAt               time.Time     `json:"created_at"`
+	FundingTransactionID    uuid.UUID     `json:"funding_transaction_id"`
+	SettledBy               uuid.NullUUID `json:"settled_by"`
+	SettledAt               sql.NullTime  `json:"settled_at"`
+	SettlementNote          string        `json:"settlement_note"`
+	SettlementTransactionID uuid.NullUUID `json:"settlement_transaction_id"`
+}
+
+type FeeEntry struct {
+	EntryID       uuid.UUID `json:"entry_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+}
+
+type FxConversion struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	FromAccountID uuid.UUID `json:"from_account_id"`
+	ToAccountID   uuid.UUID `json:"to_account_id"`
+	SellCurrency  string    `json:"sell_currency"`
+	SellAmount    string    `json:"sell_amount"`
+	BuyCurrency   string    `json:"buy_currency"`
+	BuyAmount     string    `json:"buy_amount"`
+	MidRate       string    `json:"mid_rate"`
+	CustomerRate  string    `json:"customer_rate"`
+	SpreadBps     int32     `json:"spread_bps"`
+	Margin        string    `json:"margin"`
+	RateSource    string    `json:"rate_source"`
+	RateAsOf      time.Time `json:"rate_as_of"`
+	RequestedBy   uuid.UUID `json:"requested_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type FxRate struct {
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          string    `json:"rate"`
+	Provider      string    `json:"provider"`
+	FetchedAt     time.Time `json:"fetched_at"`
+}
+
+type FxRateOverride struct {
+	BaseCurrency  string       `json:"base_currency"`
+	QuoteCurrency string       `json:"quote_currency"`
+	Rate          string       `json:"rate"`
+	Reason        string       `json:"reason"`
+	SetBy         uuid.UUID    `json:"set_by"`
+	ExpiresAt     sql.NullTime `json:"expires_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type FxSpread struct {
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	SpreadBps     int32     `json:"spread_bps"`
+	UpdatedBy     uuid.UUID `json:"updated_by"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type GlAccountCode struct {
+	AccountID uuid.UUID `json:"account_id"`
+	GlCode    string    `json:"gl_code"`
+	GlName    string    `json:"gl_name"`
+	UpdatedBy uuid.UUID `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type GlCustomerCode struct {
+	Currency  string    `json:"currency"`
+	GlCode    string    `json:"gl_code"`
+	GlName    string    `json:"gl_name"`
+	UpdatedBy uuid.UUID `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type InboundPayment struct {
+	ID                      uuid.UUID     `json:"id"`
+	Provider                string        `json:"provider"`
+	EventID                 string        `json:"event_id"`
+	VirtualAccountNumber    string        `json:"virtual_account_number"`
+	Amount                  string        `json:"amount"`
+	Currency                string        `json:"currency"`
+	PayerName               string        `json:"payer_name"`
+	PayerReference          string        `json:"payer_reference"`
+	Status                  string        `json:"status"`
+	AccountID               uuid.NullUUID `json:"account_id"`
+	TransactionID           uuid.UUID     `json:"transaction_id"`
+	ResolutionTransactionID uuid.NullUUID `json:"resolution_transaction_id"`
+	ResolvedBy              uuid.NullUUID `json:"resolved_by"`
+	ResolvedAt              sql.NullTime  `json:"resolved_at"`
+	CreatedAt               time.Time     `json:"created_at"`
+}
+
+type InterestPosting struct {
+	AccountID     uuid.UUID `json:"account_id"`
+	PeriodStart   time.Time `json:"period_start"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Balance       string    `json:"balance"`
+	RateBps       int32     `json:"rate_bps"`
+	Amount        string    `json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type InterestTier struct {
+	Product    string    `json:"product"`
+	Currency   string    `json:"currency"`
+	MinBalance string    `json:"min_balance"`
+	RateBps    int32     `json:"rate_bps"`
+	UpdatedBy  uuid.UUID `json:"updated_by"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type Job struct {
+	ID          uuid.UUID       `json:"id"`
+	Kind        string          `json:"kind"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int32           `json:"attempts"`
+	MaxAttempts int32           `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LockedAt    sql.NullTime    `json:"locked_at"`
+	LastError   string          `json:"last_error"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+type JobSchedule struct {
+	Name      string       `json:"name"`
+	Spec      string       `json:"spec"`
+	NextRunAt time.Time    `json:"next_run_at"`
+	LastRunAt sql.NullTime `json:"last_run_at"`
+}
+
+type KnownBeneficiary struct {
+	AccountID   uuid.UUID `json:"account_id"`
+	Beneficiary string    `json:"beneficiary"`
+	FirstPaidAt time.Time `json:"first_paid_at"`
+	LastPaidAt  time.Time `json:"last_paid_at"`
+}
+
+type KnownDevice struct {
+	UserID      uuid.UUID `json:"user_id"`
+	DeviceID    string    `json:"device_id"`
+	UserAgent   string    `json:"user_agent"`
+	IpAddress   string    `json:"ip_address"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+type KycRecord struct {
+	ID                uuid.UUID      `json:"id"`
+	UserID            uuid.UUID      `json:"user_id"`
+	IDType            string         `json:"id_type"`
+	IDNumber          string         `json:"id_number"`
+	DocumentReference string         `json:"document_reference"`
+	Status            string         `json:"status"`
+	Level             int16          `json:"level"`
+	RejectionReason   sql.NullString `json:"rejection_reason"`
+	ReviewedBy        uuid.NullUUID  `json:"reviewed_by"`
+	ReviewedAt        sql.NullTime   `json:"reviewed_at"`
+	SubmittedAt       time.Time      `json:"submitted_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+type LedgerEvent struct {
+	Seq           int64           `json:"seq"`
+	EventType     string          `json:"event_type"`
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	Payload       json.RawMessage `json:"payload"`
+	RequestID     sql.NullString  `json:"request_id"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type Loan struct {
+	ID                        uuid.UUID    `json:"id"`
+	OrgID                     uuid.UUID    `json:"org_id"`
+	AccountID                 uuid.UUID    `json:"account_id"`
+	Principal                 string       `json:"principal"`
+	Currency                  string       `json:"currency"`
+	AnnualRateBps             int32        `json:"annual_rate_bps"`
+	TermMonths                int32        `json:"term_months"`
+	Status                    string       `json:"status"`
+	Delinquency               string       `json:"delinquency"`
+	DaysPastDue               int32        `json:"days_past_due"`
+	DisbursementTransactionID uuid.UUID    `json:"disbursement_transaction_id"`
+	CreatedBy                 uuid.UUID    `json:"created_by"`
+	CreatedAt                 time.Time    `json:"created_at"`
+	PaidOffAt                 sql.NullTime `json:"paid_off_at"`
+}
+
+type LoanInstallment struct {
+	LoanID        uuid.UUID    `json:"loan_id"`
+	Number        int32        `json:"number"`
+	DueDate       time.Time    `json:"due_date"`
+	PrincipalDue  string       `json:"principal_due"`
+	InterestDue   string       `json:"interest_due"`
+	PrincipalPaid string       `json:"principal_paid"`
+	InterestPaid  string       `json:"interest_paid"`
+	PaidAt        sql.NullTime `json:"paid_at"`
+}
+
+type LoanRepayment struct {
+	ID            uuid.UUID `json:"id"`
+	LoanID        uuid.UUID `json:"loan_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Amount        string    `json:"amount"`
+	Principal     string    `json:"principal"`
+	Interest      string    `json:"interest"`
+	CreatedBy     uuid.UUID `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type LoginAttempt struct {
+	ID            uuid.UUID     `json:"id"`
+	OrgID         uuid.NullUUID `json:"org_id"`
+	Email         string        `json:"email"`
+	UserID        uuid.NullUUID `json:"user_id"`
+	IpAddress     string        `json:"ip_address"`
+	Succeeded     bool          `json:"succeeded"`
+	FailureReason string        `json:"failure_reason"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UserAgent     string        `json:"user_agent"`
+	DeviceID      string        `json:"device_id"`
+	NewDevice     bool          `json:"new_device"`
+}
+
+type MerkleRoot struct {
+	ID        uuid.UUID `json:"id"`
+	RootHash  []byte    `json:"root_hash"`
+	LeafCount int32     `json:"leaf_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MerkleRootEntry struct {
+	EntryID    uuid.UUID `json:"entry_id"`
+	RootID     uuid.UUID `json:"root_id"`
+	LeafIndex  int32     `json:"leaf_index"`
+	PrunedHash []byte    `json:"pruned_hash"`
+}
+
+type MonthlyStatement struct {
+	ID          uuid.UUID `json:"id"`
+	AccountID   uuid.UUID `json:"account_id"`
+	PeriodStart time.Time `json:"period_start"`
+	Delivery    string    `json:"delivery"`
+	SentTo      string    `json:"sent_to"`
+	SentAt      time.Time `json:"sent_at"`
+}
+
+type NameEnquiry struct {
+	ID            uuid.UUID `json:"id"`
+	UserID        uuid.UUID `json:"user_id"`
+	IpAddress     string    `json:"ip_address"`
+	AccountNumber string    `json:"account_number"`
+	Found         bool      `json:"found"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type NotificationPreference struct {
+	UserID       uuid.UUID `json:"user_id"`
+	EmailEnabled bool      `json:"email_enabled"`
+	SmsEnabled   bool      `json:"sms_enabled"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type Organization struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OwnershipTransfer struct {
+	ID           uuid.UUID     `json:"id"`
+	AccountID    uuid.UUID     `json:"account_id"`
+	FromUserID   uuid.UUID     `json:"from_user_id"`
+	ToUserID     uuid.UUID     `json:"to_user_id"`
+	Reason       string        `json:"reason"`
+	Status       string        `json:"status"`
+	RequestedBy  uuid.UUID     `json:"requested_by"`
+	RequestedAt  time.Time     `json:"requested_at"`
+	DecidedBy    uuid.NullUUID `json:"decided_by"`
+	DecidedAt    sql.NullTime  `json:"decided_at"`
+	DecisionNote string        `json:"decision_note"`
+}
+
+type PaymentCharge struct {
+	ID                uuid.UUID      `json:"id"`
+	Provider          string         `json:"provider"`
+	Reference         string         `json:"reference"`
+	AccountID         uuid.UUID      `json:"account_id"`
+	UserID            uuid.UUID      `json:"user_id"`
+	Amount            string         `json:"amount"`
+	Currency          string         `json:"currency"`
+	Status            string         `json:"status"`
+	TransactionID     uuid.NullUUID  `json:"transaction_id"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	ProviderReference sql.NullString `json:"provider_reference"`
+}
+
+type PaymentRequest struct {
+	ID             uuid.UUID     `json:"id"`
+	Token          string        `json:"token"`
+	AccountID      uuid.UUID     `json:"account_id"`
+	Amount         string        `json:"amount"`
+	Currency       string        `json:"currency"`
+	Memo           string        `json:"memo"`
+	Status         string        `json:"status"`
+	ExpiresAt      time.Time     `json:"expires_at"`
+	CreatedBy      uuid.UUID     `json:"created_by"`
+	CreatedAt      time.Time     `json:"created_at"`
+	PayerAccountID uuid.NullUUID `json:"payer_account_id"`
+	PaidBy         uuid.NullUUID `json:"paid_by"`
+	PaidAt         sql.NullTime  `json:"paid_at"`
+	TransactionID  uuid.NullUUID `json:"transaction_id"`
+	CancelledAt    sql.NullTime  `json:"cancelled_at"`
+}
+
+type Payout struct {
+	ID                 uuid.UUID      `json:"id"`
+	Provider           string         `json:"provider"`
+	Reference          string         `json:"reference"`
+	AccountID          uuid.UUID      `json:"account_id"`
+	UserID             uuid.UUID      `json:"user_id"`
+	Amount             string         `json:"amount"`
+	Currency           string         `json:"currency"`
+	BankCode           string         `json:"bank_code"`
+	AccountNumber      string         `json:"account_number"`
+	Narration          string         `json:"narration"`
+	Status             string         `json:"status"`
+	ProviderTransferID sql.NullString `json:"provider_transfer_id"`
+	HoldTransactionID  uuid.UUID      `json:"hold_transaction_id"`
+	FinalTransactionID uuid.NullUUID  `json:"final_transaction_id"`
+	FailureReason      sql.NullString `json:"failure_reason"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	BeneficiaryName    string         `json:"beneficiary_name"`
+}
+
+type PayoutBatch struct {
+	ID              uuid.UUID `json:"id"`
+	SourceAccountID uuid.UUID `json:"source_account_id"`
+	CreatedBy       uuid.UUID `json:"created_by"`
+	Filename        string    `json:"filename"`
+	RowCount        int32     `json:"row_count"`
+	TotalAmount     string    `json:"total_amount"`
+	Currency        string    `json:"currency"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type PayoutSuspenseCase struct {
+	ID                      uuid.UUID     `json:"id"`
+	PayoutID                uuid.UUID     `json:"payout_id"`
+	AccountID               uuid.UUID     `json:"account_id"`
+	Amount                  string        `json:"amount"`
+	Currency                string        `json:"currency"`
+	Reason                  string        `json:"reason"`
+	Status                  string        `json:"status"`
+	SuspenseTransactionID   uuid.UUID     `json:"suspense_transaction_id"`
+	ResolutionTransactionID uuid.NullUUID `json:"resolution_transaction_id"`
+	RetryPayoutID           uuid.NullUUID `json:"retry_payout_id"`
+	ResolvedBy              uuid.NullUUID `json:"resolved_by"`
+	ResolutionNote          string        `json:"resolution_note"`
+	CreatedAt               time.Time     `json:"created_at"`
+	ResolvedAt              sql.NullTime  `json:"resolved_at"`
+}
+
+type Product struct {
+	Code               string        `json:"code"`
+	Name               string        `json:"name"`
+	InterestRateBps    int32         `json:"interest_rate_bps"`
+	WithdrawalsEnabled bool          `json:"withdrawals_enabled"`
+	TransfersEnabled   bool          `json:"transfers_enabled"`
+	UpdatedBy          uuid.NullUUID `json:"updated_by"`
+	UpdatedAt          time.Time     `json:"updated_at"`
+}
+
+type SanctionsScreening struct {
+	ID            uuid.UUID       `json:"id"`
+	OrgID         uuid.UUID       `json:"org_id"`
+	SubjectType   string          `json:"subject_type"`
+	SubjectKey    string          `json:"subject_key"`
+	Name          string          `json:"name"`
+	UserID        uuid.NullUUID   `json:"user_id"`
+	BankCode      string          `json:"bank_code"`
+	AccountNumber string          `json:"account_number"`
+	Provider      string          `json:"provider"`
+	Matches       json.RawMessage `json:"matches"`
+	Error         string          `json:"error"`
+	Status        string          `json:"status"`
+	ScreenedAt    time.Time       `json:"screened_at"`
+	ReviewedBy    uuid.NullUUID   `json:"reviewed_by"`
+	ReviewedAt    sql.NullTime    `json:"reviewed_at"`
+	ReviewNote    string          `json:"review_note"`
+}
+
+type SavingsGoal struct {
+	ID                    uuid.UUID      `json:"id"`
+	WalletID              uuid.UUID      `json:"wallet_id"`
+	Name                  string         `json:"name"`
+	TargetAmount          string         `json:"target_amount"`
+	TargetDate            sql.NullTime   `json:"target_date"`
+	LockedUntil           sql.NullTime   `json:"locked_until"`
+	ContributionAmount    sql.NullString `json:"contribution_amount"`
+	ContributionInterval  sql.NullString `json:"contribution_interval"`
+	NextContributionAt    sql.NullTime   `json:"next_contribution_at"`
+	LastContributionError string         `json:"last_contribution_error"`
+	CreatedBy             uuid.UUID      `json:"created_by"`
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+}
+
+type Session struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	UserAgent string       `json:"user_agent"`
+	IpAddress string       `json:"ip_address"`
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	RevokedAt sql.NullTime `json:"revoked_at"`
+}
+
+type StatementPreference struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Delivery  string    `json:"delivery"`
+	UpdatedBy uuid.UUID `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SweepExecution struct {
+	ID            uuid.UUID     `json:"id"`
+	RuleID        uuid.UUID     `json:"rule_id"`
+	Status        string        `json:"status"`
+	Amount        string        `json:"amount"`
+	TransactionID uuid.NullUUID `json:"transaction_id"`
+	Reason        string        `json:"reason"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+type SweepRule struct {
+	ID              uuid.UUID `json:"id"`
+	AccountID       uuid.UUID `json:"account_id"`
+	TargetAccountID uuid.UUID `json:"target_account_id"`
+	Threshold       string    `json:"threshold"`
+	Frequency       string    `json:"frequency"`
+	NextRunAt       time.Time `json:"next_run_at"`
+	CreatedBy       uuid.UUID `json:"created_by"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type TaxPosting struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	RuleCode      string    `json:"rule_code"`
+	AccountID     uuid.UUID `json:"account_id"`
+	Currency      string    `json:"currency"`
+	BaseAmount    string    `json:"base_amount"`
+	RateBps       int32     `json:"rate_bps"`
+	Amount        string    `json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type TaxRule struct {
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	OperationType string    `json:"operation_type"`
+	RateBps       int32     `json:"rate_bps"`
+	Active        bool      `json:"active"`
+	UpdatedBy     uuid.UUID `json:"updated_by"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type Transaction struct {
+	ID            uuid.UUID      `json:"id"`
+	OperationType string         `json:"operation_type"`
+	Status        string         `json:"status"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	RequestID     sql.NullString `json:"request_id"`
+	RiskScore     sql.NullInt16  `json:"risk_score"`
+	RiskDecision  sql.NullString `json:"risk_decision"`
+	RiskFactors   []string       `json:"risk_factors"`
+}
+
+type TransactionAnnotation struct {
+	ID            uuid.UUID `json:"id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type TransactionLimit struct {
+	Currency  string    `json:"currency"`
+	Operation string    `json:"operation"`
+	KycLevel  int16     `json:"kyc_level"`
+	MaxAmount string    `json:"max_amount"`
+	UpdatedBy uuid.UUID `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TransactionRefund struct {
+	ID                  uuid.UUID `json:"id"`
+	TransactionID       uuid.UUID `json:"transaction_id"`
+	RefundTransactionID uuid.UUID `json:"refund_transaction_id"`
+	Amount              string    `json:"amount"`
+	Reason              string    `json:"reason"`
+	RefundedBy          uuid.UUID `json:"refunded_by"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+type TransactionReversal struct {
+	TransactionID         uuid.UUID `json:"transaction_id"`
+	ReversalTransactionID uuid.UUID `json:"reversal_transaction_id"`
+	FeesRefunded          bool      `json:"fees_refunded"`
+	FeeAmount             string    `json:"fee_amount"`
+	Reason                string    `json:"reason"`
+	ReversedBy            uuid.UUID `json:"reversed_by"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+type TransferJob struct {
+	ID            uuid.UUID     `json:"id"`
+	FromAccountID uuid.UUID     `json:"from_account_id"`
+	ToAccountID   uuid.UUID     `json:"to_account_id"`
+	Amount        string        `json:"amount"`
+	RequestedBy   uuid.UUID     `json:"requested_by"`
+	Status        string        `json:"status"`
+	Attempts      int32         `json:"attempts"`
+	FailureReason string        `json:"failure_reason"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	BatchID       uuid.NullUUID `json:"batch_id"`
+	BatchRow      sql.NullInt32 `json:"batch_row"`
+	Narration     string        `json:"narration"`
+}
+
+type TransferRequest struct {
+	ID            uuid.UUID     `json:"id"`
+	OrgID         uuid.UUID     `json:"org_id"`
+	FromAccountID uuid.UUID     `json:"from_account_id"`
+	ToAccountID   uuid.UUID     `json:"to_account_id"`
+	Amount        string        `json:"amount"`
+	Currency      string        `json:"currency"`
+	Reason        string        `json:"reason"`
+	Status        string        `json:"status"`
+	RequestedBy   uuid.UUID     `json:"requested_by"`
+	RequestedAt   time.Time     `json:"requested_at"`
+	DecidedBy     uuid.NullUUID `json:"decided_by"`
+	DecidedAt     sql.NullTime  `json:"decided_at"`
+	DecisionNote  string        `json:"decision_note"`
+	TransactionID uuid.NullUUID `json:"transaction_id"`
 }
 
 type User struct {
-	ID             uuid.UUID    `json:"id"`
-	Email          string       `json:"email"`
-	HashedPassword string       `json:"hashed_password"`
-	CreatedAt      sql.NullTime `json:"created_at"`
+	ID                    uuid.UUID      `json:"id"`
+	Email                 string         `json:"email"`
+	HashedPassword        string         `json:"hashed_password"`
+	CreatedAt             sql.NullTime   `json:"created_at"`
+	Phone                 sql.NullString `json:"phone"`
+	Role                  string         `json:"role"`
+	FirstName             string         `json:"first_name"`
+	LastName              string         `json:"last_name"`
+	DateOfBirth           sql.NullTime   `json:"date_of_birth"`
+	AddressLine1          string         `json:"address_line1"`
+	AddressLine2          string         `json:"address_line2"`
+	City                  string         `json:"city"`
+	State                 string         `json:"state"`
+	PostalCode            string         `json:"postal_code"`
+	Country               string         `json:"country"`
+	OrgID                 uuid.UUID      `json:"org_id"`
+	DefaultAccountID      uuid.NullUUID  `json:"default_account_id"`
+	LockedAt              sql.NullTime   `json:"locked_at"`
+	LockedReason          string         `json:"locked_reason"`
+	PasswordResetRequired bool           `json:"password_reset_required"`
+	ErasedAt              sql.NullTime   `json:"erased_at"`
+	PhoneIndex            sql.NullString `json:"phone_index"`
+	FailedLogins          int32          `json:"failed_logins"`
+	LoginLockedUntil      sql.NullTime   `json:"login_locked_until"`
+	ScreeningStatus       string         `json:"screening_status"`
+	Locale                string         `json:"locale"`
+}
+
+type UserErasure struct {
+	ID          uuid.UUID     `json:"id"`
+	UserID      uuid.UUID     `json:"user_id"`
+	Status      string        `json:"status"`
+	Reason      string        `json:"reason"`
+	RequestedBy uuid.UUID     `json:"requested_by"`
+	RequestedAt time.Time     `json:"requested_at"`
+	EraseAfter  time.Time     `json:"erase_after"`
+	CancelledBy uuid.NullUUID `json:"cancelled_by"`
+	CancelledAt sql.NullTime  `json:"cancelled_at"`
+	CompletedAt sql.NullTime  `json:"completed_at"`
+}
+
+type UserLocation struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Country     string    `json:"country"`
+	IpAddress   string    `json:"ip_address"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+type WebhookDelivery struct {
+	ID            uuid.UUID       `json:"id"`
+	EndpointID    uuid.UUID       `json:"endpoint_id"`
+	EventType     string          `json:"event_type"`
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int32           `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     string          `json:"last_error"`
+	DeliveredAt   sql.NullTime    `json:"delivered_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+type WebhookDeliveryAttempt struct {
+	ID          uuid.UUID     `json:"id"`
+	DeliveryID  uuid.UUID     `json:"delivery_id"`
+	StatusCode  sql.NullInt32 `json:"status_code"`
+	Error       string        `json:"error"`
+	DurationMs  int32         `json:"duration_ms"`
+	AttemptedAt time.Time     `json:"attempted_at"`
+}
+
+type WebhookEndpoint struct {
+	ID                      uuid.UUID    `json:"id"`
+	OrgID                   uuid.UUID    `json:"org_id"`
+	Url                     string       `json:"url"`
+	Secret                  string       `json:"secret"`
+	Healthy                 bool         `json:"healthy"`
+	ConsecutiveFailures     int32        `json:"consecutive_failures"`
+	CreatedBy               uuid.UUID    `json:"created_by"`
+	CreatedAt               time.Time    `json:"created_at"`
+	UpdatedAt               time.Time    `json:"updated_at"`
+	PreviousSecret          string       `json:"previous_secret"`
+	PreviousSecretExpiresAt sql.NullTime `json:"previous_secret_expires_at"`
 }
@@ -6,26 +6,628 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddAccountOwner(ctx context.Context, arg AddAccountOwnerParams) (AccountOwner, error)
+	AddCashPoolMember(ctx context.Context, arg AddCashPoolMemberParams) (CashPoolMember, error)
+	// Places the entries under the root, leaf_index following their order in entry_ids.
+	AddMerkleRootEntries(ctx context.Context, arg AddMerkleRootEntriesParams) (int64, error)
+	// Moves a run past a checked batch, completing it when the batch was the last.
+	AdvanceReconciliationRun(ctx context.Context, arg AdvanceReconciliationRunParams) (BalanceReconciliationRun, error)
+	// Keeps the verification outcome and level, drops the identity document details.
+	AnonymizeKYCRecord(ctx context.Context, userID uuid.UUID) error
+	// The send log of the user's accounts keeps when statements went out, not to which address.
+	AnonymizeStatementRecipients(ctx context.Context, ownerID uuid.NullUUID) error
+	// Replaces every piece of personal data on the user with a placeholder and locks them out. The
+	// email stays unique per organization by embedding the user ID.
+	AnonymizeUser(ctx context.Context, id uuid.UUID) (User, error)
+	AppendBalanceRepairEvent(ctx context.Context, arg AppendBalanceRepairEventParams) (BalanceRepairEvent, error)
+	AppendLedgerEvent(ctx context.Context, arg AppendLedgerEventParams) (LedgerEvent, error)
+	CancelPaymentRequest(ctx context.Context, id uuid.UUID) (PaymentRequest, error)
+	CancelUserErasure(ctx context.Context, arg CancelUserErasureParams) (UserErasure, error)
+	// Leases due deliveries by pushing next_attempt_at to lease_until, so concurrent dispatchers take
+	// different rows and a dispatcher that dies mid-send is retried once the lease lapses.
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// Advances a due schedule; only the instance that wins this update enqueues the occurrence.
+	ClaimJobSchedule(ctx context.Context, arg ClaimJobScheduleParams) (JobSchedule, error)
+	// Marks the oldest due job running; SKIP LOCKED lets concurrent workers take different jobs.
+	ClaimNextJob(ctx context.Context) (Job, error)
+	// Locks the oldest pending job; SKIP LOCKED lets concurrent workers take different jobs.
+	// Rows of a payout batch share created_at and run in file order.
+	ClaimNextTransferJob(ctx context.Context) (TransferJob, error)
+	// Forgets a default receiving account the user no longer owns.
+	ClearDefaultAccount(ctx context.Context, arg ClearDefaultAccountParams) error
+	ClearEntryCategory(ctx context.Context, arg ClearEntryCategoryParams) (int64, error)
+	CompleteJob(ctx context.Context, id uuid.UUID) error
+	CompletePayout(ctx context.Context, arg CompletePayoutParams) (Payout, error)
+	CompleteUserErasure(ctx context.Context, id uuid.UUID) (UserErasure, error)
+	CountAccountsByOrg(ctx context.Context, orgID uuid.NullUUID) (int64, error)
+	CountAdjustmentsByStatus(ctx context.Context, status string) (int64, error)
+	CountBalanceRepairsByStatus(ctx context.Context, status string) (int64, error)
+	CountBalanceRepairsForAdjustment(ctx context.Context, adjustmentID uuid.UUID) (int64, error)
+	CountCashPoolMovements(ctx context.Context, poolID uuid.UUID) (int64, error)
+	// Accounts with a segment for the month [period_start, period_end) and an entry posted after it
+	// that precedes one of the month's in the account's hash chain, so pruning the month would cut
+	// the chain short of a link still in Postgres.
+	CountChainOverlapsAfterPeriod(ctx context.Context, arg CountChainOverlapsAfterPeriodParams) (int64, error)
+	CountEntriesByAccount(ctx context.Context, accountID uuid.UUID) (int64, error)
+	CountEntryChainHeads(ctx context.Context) (int64, error)
+	// Throttled attempts are not counted, so the window ends once the address stops failing.
+	CountFailedLoginsByIP(ctx context.Context, arg CountFailedLoginsByIPParams) (int64, error)
+	CountLoginAttempts(ctx context.Context, arg CountLoginAttemptsParams) (int64, error)
+	CountMerkleRoots(ctx context.Context) (int64, error)
+	CountOwnershipTransfersByStatus(ctx context.Context, status string) (int64, error)
+	CountPayoutSuspenseCasesByStatus(ctx context.Context, status string) (int64, error)
+	CountPendingBalanceRepairs(ctx context.Context, accountID uuid.UUID) (int64, error)
+	// How many enquiries the user, and separately the address, made since the given time.
+	CountRecentNameEnquiries(ctx context.Context, arg CountRecentNameEnquiriesParams) (CountRecentNameEnquiriesRow, error)
+	CountReconciliationResults(ctx context.Context, arg CountReconciliationResultsParams) (int64, error)
+	CountReconciliationRuns(ctx context.Context) (int64, error)
+	CountSearchAccounts(ctx context.Context, arg CountSearchAccountsParams) (int64, error)
+	CountSubWallets(ctx context.Context, parentAccountID uuid.NullUUID) (int64, error)
+	CountSweepExecutions(ctx context.Context, ruleID uuid.UUID) (int64, error)
+	CountTransactionsByRequestID(ctx context.Context, requestID sql.NullString) (int64, error)
+	CountTransactionsByStatus(ctx context.Context, status string) (int64, error)
+	// Accounts with entries in the month [period_start, period_end) that have no segment for it.
+	CountUnarchivedAccountsInPeriod(ctx context.Context, arg CountUnarchivedAccountsInPeriodParams) (int64, error)
+	CountUserErasuresByStatus(ctx context.Context, status string) (int64, error)
+	CountUsers(ctx context.Context, arg CountUsersParams) (int64, error)
+	CreateAMLAlert(ctx context.Context, arg CreateAMLAlertParams) (AmlAlert, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountSigningKey(ctx context.Context, arg CreateAccountSigningKeyParams) (AccountSigningKey, error)
+	CreateAdjustment(ctx context.Context, arg CreateAdjustmentParams) (Adjustment, error)
+	CreateAdjustmentLine(ctx context.Context, arg CreateAdjustmentLineParams) (AdjustmentLine, error)
+	CreateBalanceRepair(ctx context.Context, arg CreateBalanceRepairParams) (BalanceRepair, error)
+	// Call with the event log locked, so every posting up to through_seq has committed and none after it has.
+	CreateBalanceSnapshot(ctx context.Context) (BalanceSnapshot, error)
+	CreateBankStatementImport(ctx context.Context, arg CreateBankStatementImportParams) (BankStatementImport, error)
+	CreateBatchTransferJob(ctx context.Context, arg CreateBatchTransferJobParams) (TransferJob, error)
+	CreateCashPool(ctx context.Context, arg CreateCashPoolParams) (CashPool, error)
+	CreateCashPoolMovement(ctx context.Context, arg CreateCashPoolMovementParams) (CashPoolMovement, error)
+	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateCategoryRule(ctx context.Context, arg CreateCategoryRuleParams) (CategoryRule, error)
+	CreateDispute(ctx context.Context, arg CreateDisputeParams) (Dispute, error)
+	// Inserts a posting's entries in one statement, chained in the order given.
+	CreateEntries(ctx context.Context, arg CreateEntriesParams) ([]Entry, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	// Records a written segment; a no-op when the account's month is already recorded.
+	CreateEntryArchive(ctx context.Context, arg CreateEntryArchiveParams) (int64, error)
+	CreateEscrow(ctx context.Context, arg CreateEscrowParams) (Escrow, error)
+	CreateFXConversion(ctx context.Context, arg CreateFXConversionParams) (FxConversion, error)
+	CreateFeeEntry(ctx context.Context, arg CreateFeeEntryParams) error
+	// Replays of an already-recorded event insert nothing and return no rows.
+	CreateInboundPayment(ctx context.Context, arg CreateInboundPaymentParams) (InboundPayment, error)
+	CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) error
+	CreateInterestTier(ctx context.Context, arg CreateInterestTierParams) (InterestTier, error)
+	CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, error)
+	CreateLoanInstallment(ctx context.Context, arg CreateLoanInstallmentParams) error
+	CreateLoanRepayment(ctx context.Context, arg CreateLoanRepaymentParams) (LoanRepayment, error)
+	CreateLoginAttempt(ctx context.Context, arg CreateLoginAttemptParams) error
+	CreateMerkleRoot(ctx context.Context, arg CreateMerkleRootParams) (MerkleRoot, error)
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
+	CreateOwnershipTransfer(ctx context.Context, arg CreateOwnershipTransferParams) (OwnershipTransfer, error)
+	CreatePaymentCharge(ctx context.Context, arg CreatePaymentChargeParams) (PaymentCharge, error)
+	CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error)
+	CreatePayout(ctx context.Context, arg CreatePayoutParams) (Payout, error)
+	CreatePayoutBatch(ctx context.Context, arg CreatePayoutBatchParams) (PayoutBatch, error)
+	CreatePayoutSuspenseCase(ctx context.Context, arg CreatePayoutSuspenseCaseParams) (PayoutSuspenseCase, error)
+	CreateReconciliationRun(ctx context.Context, requestedBy uuid.UUID) (BalanceReconciliationRun, error)
+	CreateSanctionsScreening(ctx context.Context, arg CreateSanctionsScreeningParams) (SanctionsScreening, error)
+	CreateSavingsGoal(ctx context.Context, arg CreateSavingsGoalParams) (SavingsGoal, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateSubWallet(ctx context.Context, arg CreateSubWalletParams) (Account, error)
+	CreateSweepExecution(ctx context.Context, arg CreateSweepExecutionParams) (SweepExecution, error)
+	CreateSweepRule(ctx context.Context, arg CreateSweepRuleParams) (SweepRule, error)
+	CreateTaxPosting(ctx context.Context, arg CreateTaxPostingParams) error
+	CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error)
+	CreateTransactionAnnotation(ctx context.Context, arg CreateTransactionAnnotationParams) (TransactionAnnotation, error)
+	CreateTransactionRefund(ctx context.Context, arg CreateTransactionRefundParams) (TransactionRefund, error)
+	CreateTransactionReversal(ctx context.Context, arg CreateTransactionReversalParams) (TransactionReversal, error)
+	CreateTransferJob(ctx context.Context, arg CreateTransferJobParams) (TransferJob, error)
+	CreateTransferRequest(ctx context.Context, arg CreateTransferRequestParams) (TransferRequest, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error)
+	CreateUserErasure(ctx context.Context, arg CreateUserErasureParams) (UserErasure, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookDeliveryAttempt(ctx context.Context, arg CreateWebhookDeliveryAttemptParams) error
+	CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error)
+	// One row per UTC day in [from_day, to_day), including days without entries.
+	DailyTotalsBetween(ctx context.Context, arg DailyTotalsBetweenParams) ([]DailyTotalsBetweenRow, error)
+	DecideAdjustment(ctx context.Context, arg DecideAdjustmentParams) (Adjustment, error)
+	DecideBalanceRepair(ctx context.Context, arg DecideBalanceRepairParams) (BalanceRepair, error)
+	DecideOwnershipTransfer(ctx context.Context, arg DecideOwnershipTransferParams) (OwnershipTransfer, error)
+	DecideTransferRequest(ctx context.Context, arg DecideTransferRequestParams) (TransferRequest, error)
+	DeleteCashPoolMember(ctx context.Context, arg DeleteCashPoolMemberParams) (int64, error)
+	DeleteCategory(ctx context.Context, arg DeleteCategoryParams) (int64, error)
+	DeleteCategoryRule(ctx context.Context, arg DeleteCategoryRuleParams) (int64, error)
+	// Removes every entry posted in [period_start, period_end), of every account.
+	DeleteEntriesInPeriod(ctx context.Context, arg DeleteEntriesInPeriodParams) (int64, error)
+	DeleteFXRateOverride(ctx context.Context, arg DeleteFXRateOverrideParams) (int64, error)
+	DeleteInterestTiers(ctx context.Context, arg DeleteInterestTiersParams) error
+	DeleteKnownDevices(ctx context.Context, userID uuid.UUID) error
+	DeleteLoginAttemptsBefore(ctx context.Context, createdAt time.Time) (int64, error)
+	DeleteNameEnquiriesBefore(ctx context.Context, createdAt time.Time) (int64, error)
+	DeleteSavingsGoal(ctx context.Context, id uuid.UUID) error
+	// Removes the system accounts migrations seed, which a restore brings back with their dumped IDs.
+	// Call only on a ledger with no entries.
+	DeleteSeedAccounts(ctx context.Context) (int64, error)
+	// Removes the default organization migrations seed, as DeleteSeedAccounts does the accounts.
+	DeleteSeedOrganizations(ctx context.Context) (int64, error)
+	DeleteSessionsExpiredBefore(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteSucceededJobsBefore(ctx context.Context, updatedAt time.Time) (int64, error)
+	DeleteSweepRule(ctx context.Context, arg DeleteSweepRuleParams) (int64, error)
+	DeleteTransactionLimit(ctx context.Context, arg DeleteTransactionLimitParams) (int64, error)
+	DeleteUserLocations(ctx context.Context, userID uuid.UUID) error
+	DumpAccountHistory(ctx context.Context, arg DumpAccountHistoryParams) ([]DumpAccountHistoryRow, error)
+	DumpAccountShards(ctx context.Context, arg DumpAccountShardsParams) ([]DumpAccountShardsRow, error)
+	DumpAccounts(ctx context.Context, arg DumpAccountsParams) ([]DumpAccountsRow, error)
+	// Entries in chain order, account by account, so a restore chains them afresh in the same order.
+	DumpEntries(ctx context.Context, arg DumpEntriesParams) ([]DumpEntriesRow, error)
+	DumpEntryArchives(ctx context.Context, arg DumpEntryArchivesParams) ([]DumpEntryArchivesRow, error)
+	DumpEntryChainHeads(ctx context.Context, arg DumpEntryChainHeadsParams) ([]DumpEntryChainHeadsRow, error)
+	DumpLedgerEvents(ctx context.Context, arg DumpLedgerEventsParams) ([]DumpLedgerEventsRow, error)
+	DumpOrganizations(ctx context.Context, arg DumpOrganizationsParams) ([]DumpOrganizationsRow, error)
+	DumpProducts(ctx context.Context, arg DumpProductsParams) ([]DumpProductsRow, error)
+	DumpTransactions(ctx context.Context, arg DumpTransactionsParams) ([]DumpTransactionsRow, error)
+	DumpUsers(ctx context.Context, arg DumpUsersParams) ([]DumpUsersRow, error)
+	EnqueueJob(ctx context.Context, arg EnqueueJobParams) (Job, error)
+	// Creates whichever of shards 1 to shard_count - 1 of a system account are missing, each a system
+	// account of its currency named after it, and returns how many it created.
+	EnsureAccountShards(ctx context.Context, arg EnsureAccountShardsParams) (int64, error)
+	// Creates a per-currency system account (e.g., FX Position in NGN) unless it already exists.
+	EnsureSystemAccount(ctx context.Context, arg EnsureSystemAccountParams) error
+	FailJob(ctx context.Context, arg FailJobParams) error
+	// Records a failed attempt; the job fails for good once it runs out of attempts or the failure is final.
+	FailTransferJobAttempt(ctx context.Context, arg FailTransferJobAttemptParams) (TransferJob, error)
+	// The pool the account belongs to, as master or member.
+	FindCashPoolByAccount(ctx context.Context, accountID uuid.UUID) (uuid.UUID, error)
+	// Recipient lookup ignores case, unlike login.
+	FindUserByEmail(ctx context.Context, arg FindUserByEmailParams) (User, error)
+	// Net debits per UTC day and GL code of every entry in currency in [from, to). Entries of
+	// accounts without a mapping come back with an empty gl_code and, for system accounts, their
+	// account name. A shard's entries count toward the account it splits.
+	GLDailyActivity(ctx context.Context, arg GLDailyActivityParams) ([]GLDailyActivityRow, error)
+	// Scoped by org so compliance staff only review their own tenant's alerts.
+	GetAMLAlertForUpdate(ctx context.Context, arg GetAMLAlertForUpdateParams) (AmlAlert, error)
 	GetAccount(ctx context.Context, id uuid.UUID) (Account, error)
+	// Sub-wallets resolve to their parent's owners.
+	GetAccountAccessRole(ctx context.Context, arg GetAccountAccessRoleParams) (string, error)
 	// lock prevents concurrent transactions from reading a stale balance.
+	// The account's balance summed from its entries, carried forward from those pruned to the archive.
 	GetAccountBalance(ctx context.Context, accountID uuid.UUID) (string, error)
+	// Carries forward the pruned months that ended by before_time; a pruned month's own entries are
+	// only in the archive.
+	GetAccountBalanceBefore(ctx context.Context, arg GetAccountBalanceBeforeParams) (string, error)
+	GetAccountByVirtualNumber(ctx context.Context, virtualAccountNumber string) (Account, error)
+	GetAccountByVirtualNumberForUpdate(ctx context.Context, virtualAccountNumber string) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id uuid.UUID) (Account, error)
+	GetAccountProduct(ctx context.Context, arg GetAccountProductParams) (AccountProduct, error)
+	GetAccountSweepRule(ctx context.Context, arg GetAccountSweepRuleParams) (SweepRule, error)
+	GetActiveAccountSigningKey(ctx context.Context, arg GetActiveAccountSigningKeyParams) (AccountSigningKey, error)
+	GetActiveFXRateOverride(ctx context.Context, arg GetActiveFXRateOverrideParams) (FxRateOverride, error)
+	GetAdjustment(ctx context.Context, id uuid.UUID) (Adjustment, error)
+	GetAdjustmentForUpdate(ctx context.Context, id uuid.UUID) (Adjustment, error)
+	GetBalanceRepair(ctx context.Context, id uuid.UUID) (BalanceRepair, error)
+	GetBalanceRepairForUpdate(ctx context.Context, id uuid.UUID) (BalanceRepair, error)
+	GetBalanceSnapshot(ctx context.Context, id uuid.UUID) (BalanceSnapshot, error)
+	GetBankStatementImport(ctx context.Context, id uuid.UUID) (BankStatementImport, error)
+	// The subject's latest screening that still applies: reviews and pending reviews always do,
+	// clean results only when newer than since.
+	GetCachedScreening(ctx context.Context, arg GetCachedScreeningParams) (SanctionsScreening, error)
+	GetCashPool(ctx context.Context, id uuid.UUID) (CashPool, error)
+	GetCashPoolMember(ctx context.Context, arg GetCashPoolMemberParams) (CashPoolMember, error)
+	GetCategory(ctx context.Context, arg GetCategoryParams) (Category, error)
+	// The user's chosen default account, else their oldest primary-owned top-level account.
+	GetDefaultAccount(ctx context.Context, ownerID uuid.NullUUID) (Account, error)
+	GetDispute(ctx context.Context, id uuid.UUID) (Dispute, error)
+	GetDisputeByTransaction(ctx context.Context, transactionID uuid.UUID) (Dispute, error)
+	GetDisputeForUpdate(ctx context.Context, id uuid.UUID) (Dispute, error)
+	GetDisputesHoldingAccountForUpdate(ctx context.Context) (Account, error)
+	GetEntry(ctx context.Context, id uuid.UUID) (Entry, error)
+	GetEntryChainHead(ctx context.Context, accountID uuid.UUID) (EntryChainHead, error)
+	GetEscrow(ctx context.Context, id uuid.UUID) (Escrow, error)
+	GetEscrowForUpdate(ctx context.Context, id uuid.UUID) (Escrow, error)
+	GetFXRate(ctx context.Context, arg GetFXRateParams) (FxRate, error)
+	GetFXSpread(ctx context.Context, arg GetFXSpreadParams) (FxSpread, error)
+	GetInboundPaymentByEvent(ctx context.Context, arg GetInboundPaymentByEventParams) (InboundPayment, error)
+	GetInboundPaymentForUpdate(ctx context.Context, id uuid.UUID) (InboundPayment, error)
+	GetKYCLevelByUser(ctx context.Context, userID uuid.UUID) (int16, error)
+	GetKYCRecordByUser(ctx context.Context, userID uuid.UUID) (KycRecord, error)
+	GetLatestBalanceSnapshot(ctx context.Context) (BalanceSnapshot, error)
+	GetLatestLedgerEventSeq(ctx context.Context) (int64, error)
+	// The newest month pruned from Postgres; NULL when none is.
+	GetLatestPrunedPeriod(ctx context.Context) (sql.NullTime, error)
+	GetLatestUserErasure(ctx context.Context, userID uuid.UUID) (UserErasure, error)
+	GetLoan(ctx context.Context, id uuid.UUID) (Loan, error)
+	GetLoanForUpdate(ctx context.Context, id uuid.UUID) (Loan, error)
+	GetMerkleRoot(ctx context.Context, id uuid.UUID) (MerkleRoot, error)
+	GetMerkleRootEntry(ctx context.Context, entryID uuid.UUID) (MerkleRootEntry, error)
+	GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (NotificationPreference, error)
+	// The month (UTC) of the oldest entry still in Postgres; NULL when there are none.
+	GetOldestEntryPeriod(ctx context.Context) (sql.NullTime, error)
+	GetOrganization(ctx context.Context, id uuid.UUID) (Organization, error)
+	GetOrganizationBySlug(ctx context.Context, slug string) (Organization, error)
+	GetOwnershipTransferForUpdate(ctx context.Context, id uuid.UUID) (OwnershipTransfer, error)
+	GetPaymentChargeByReference(ctx context.Context, reference string) (PaymentCharge, error)
+	GetPaymentChargeByReferenceForUpdate(ctx context.Context, reference string) (PaymentCharge, error)
+	GetPaymentRequestByToken(ctx context.Context, token string) (PaymentRequest, error)
+	GetPaymentRequestByTokenForUpdate(ctx context.Context, token string) (PaymentRequest, error)
+	GetPayout(ctx context.Context, id uuid.UUID) (Payout, error)
+	GetPayoutBatch(ctx context.Context, id uuid.UUID) (PayoutBatch, error)
+	GetPayoutByReference(ctx context.Context, reference string) (Payout, error)
+	GetPayoutByReferenceForUpdate(ctx context.Context, reference string) (Payout, error)
+	GetPayoutClearingAccountForUpdate(ctx context.Context) (Account, error)
+	GetPayoutSuspenseCase(ctx context.Context, id uuid.UUID) (PayoutSuspenseCase, error)
+	GetPayoutSuspenseCaseForUpdate(ctx context.Context, id uuid.UUID) (PayoutSuspenseCase, error)
+	GetPendingUserErasure(ctx context.Context, userID uuid.UUID) (UserErasure, error)
+	GetProduct(ctx context.Context, code string) (Product, error)
+	GetReconciliationRun(ctx context.Context, id uuid.UUID) (BalanceReconciliationRun, error)
+	GetReconciliationRunForUpdate(ctx context.Context, id uuid.UUID) (BalanceReconciliationRun, error)
+	// One account's balance replayed from the log. It reads every posting, so it is for admin tools.
+	GetReplayedAccountBalance(ctx context.Context, accountID uuid.UUID) (string, error)
+	// What a database holds that a restore would collide with: a restore needs one with neither.
+	GetRestoreTargetUsage(ctx context.Context) (GetRestoreTargetUsageRow, error)
+	// Parent balance plus every sub-wallet balance.
+	GetRolledUpBalance(ctx context.Context, id uuid.UUID) (string, error)
+	GetRunningReconciliationRun(ctx context.Context) (BalanceReconciliationRun, error)
+	// Scoped by org so compliance staff only review their own tenant's screenings.
+	GetSanctionsScreeningForUpdate(ctx context.Context, arg GetSanctionsScreeningForUpdateParams) (SanctionsScreening, error)
+	GetSavingsGoalByWallet(ctx context.Context, walletID uuid.UUID) (SavingsGoal, error)
+	GetSavingsGoalByWalletForUpdate(ctx context.Context, walletID uuid.UUID) (SavingsGoal, error)
+	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetSettlementAccount(ctx context.Context) (Account, error)
 	GetSettlementAccountForUpdate(ctx context.Context) (Account, error)
-	GetUserByEmail(ctx context.Context, email string) (User, error)
+	// The balance of the settlement account and its shards together from the entries posted before
+	// before_time, carrying forward the pruned months that ended by then.
+	GetSettlementPositionBefore(ctx context.Context, beforeTime time.Time) (GetSettlementPositionBeforeRow, error)
+	// Locks one shard of the settlement account, picked at random, to post against. Shard 0 is the
+	// account itself, so an account without shards is always the one picked.
+	GetSettlementShardForUpdate(ctx context.Context) (Account, error)
+	GetStatementPreference(ctx context.Context, accountID uuid.UUID) (StatementPreference, error)
+	GetSuspenseAccountForUpdate(ctx context.Context) (Account, error)
+	GetSweepRuleForUpdate(ctx context.Context, id uuid.UUID) (SweepRule, error)
+	GetSystemAccountForUpdate(ctx context.Context, arg GetSystemAccountForUpdateParams) (Account, error)
+	GetTransaction(ctx context.Context, id uuid.UUID) (Transaction, error)
+	GetTransactionForUpdate(ctx context.Context, id uuid.UUID) (Transaction, error)
+	GetTransactionLimit(ctx context.Context, arg GetTransactionLimitParams) (TransactionLimit, error)
+	GetTransactionReversal(ctx context.Context, transactionID uuid.UUID) (TransactionReversal, error)
+	GetTransferJob(ctx context.Context, id uuid.UUID) (TransferJob, error)
+	// Scoped by org so a checker can only decide requests of their own tenant.
+	GetTransferRequestForUpdate(ctx context.Context, arg GetTransferRequestForUpdateParams) (TransferRequest, error)
+	GetUser(ctx context.Context, id uuid.UUID) (User, error)
+	// Emails are unique per organization, so login always names one.
+	GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error)
+	GetUserErasureForUpdate(ctx context.Context, id uuid.UUID) (UserErasure, error)
+	GetWebhookEndpoint(ctx context.Context, id uuid.UUID) (WebhookEndpoint, error)
+	HasInterestPosting(ctx context.Context, arg HasInterestPostingParams) (bool, error)
+	// Whether any account the user is the primary owner of still holds money, either way.
+	HasOwnedBalance(ctx context.Context, ownerID uuid.NullUUID) (bool, error)
+	HasPendingOwnershipTransfer(ctx context.Context, accountID uuid.UUID) (bool, error)
+	IsKnownBeneficiary(ctx context.Context, arg IsKnownBeneficiaryParams) (bool, error)
+	IsPayoutTransaction(ctx context.Context, transactionID uuid.UUID) (bool, error)
+	// Copies the hashes of the month's entries into their places under Merkle roots before they go.
+	KeepPrunedMerkleLeaves(ctx context.Context, arg KeepPrunedMerkleLeavesParams) (int64, error)
+	// The account's transactions in [from_time, to_time) by the size of their net effect on it.
+	LargestTransactionsBetween(ctx context.Context, arg LargestTransactionsBetweenParams) ([]LargestTransactionsBetweenRow, error)
+	ListAMLAlertsByStatus(ctx context.Context, arg ListAMLAlertsByStatusParams) ([]AmlAlert, error)
+	// An account's movements since a time, oldest first, for AML screening.
+	ListAccountActivitySince(ctx context.Context, arg ListAccountActivitySinceParams) ([]ListAccountActivitySinceRow, error)
+	// sort is one of created_at, -created_at (newest first), amount or -amount; any other value
+	// falls through to newest first.
+	ListAccountHistory(ctx context.Context, arg ListAccountHistoryParams) ([]AccountHistory, error)
+	ListAccountOwners(ctx context.Context, accountID uuid.UUID) ([]ListAccountOwnersRow, error)
+	ListAccountProducts(ctx context.Context) ([]AccountProduct, error)
+	ListAccountShards(ctx context.Context, shardOf uuid.UUID) ([]uuid.UUID, error)
+	ListAccountSigningKeys(ctx context.Context, accountID uuid.UUID) ([]AccountSigningKey, error)
+	ListAccountsByOrg(ctx context.Context, arg ListAccountsByOrgParams) ([]Account, error)
 	// locks row for update, prevents TOCTOU races
 	ListAccountsByOwner(ctx context.Context, ownerID uuid.NullUUID) ([]Account, error)
-	ListEntriesByAccount(ctx context.Context, arg ListEntriesByAccountParams) ([]Entry, error)
+	// Customer top-level accounts opened before the period ended, with statements not turned off
+	// and not yet sent for the period. Keyset-paginated by account ID.
+	ListAccountsDueStatement(ctx context.Context, arg ListAccountsDueStatementParams) ([]ListAccountsDueStatementRow, error)
+	// Customer top-level accounts with a positive balance on a product that pays interest, at its
+	// base rate or in a balance band of the account's currency, keyset-paginated by account ID.
+	ListAccountsEarningInterest(ctx context.Context, arg ListAccountsEarningInterestParams) ([]ListAccountsEarningInterestRow, error)
+	// Accounts the user owns or co-owns, including sub-wallets of those accounts. sort is one of
+	// created_at, -created_at (newest first), name, -name, balance or -balance; any other value
+	// falls through to newest first.
+	ListAccountsForUser(ctx context.Context, arg ListAccountsForUserParams) ([]Account, error)
+	ListActiveLoanIDs(ctx context.Context, arg ListActiveLoanIDsParams) ([]uuid.UUID, error)
+	ListActiveSessions(ctx context.Context, userID uuid.UUID) ([]Session, error)
+	ListActiveTaxRules(ctx context.Context, operationType string) ([]TaxRule, error)
+	ListAdjustmentLines(ctx context.Context, adjustmentID uuid.UUID) ([]AdjustmentLine, error)
+	ListAdjustmentsByStatus(ctx context.Context, arg ListAdjustmentsByStatusParams) ([]Adjustment, error)
+	// Every membership with its pool's master, for the end-of-day run.
+	ListAllCashPoolMembers(ctx context.Context) ([]ListAllCashPoolMembersRow, error)
+	ListAllInterestTiers(ctx context.Context) ([]InterestTier, error)
+	// The account's entries in [period_start, period_end) with their history read model rows,
+	// oldest first. History columns are NULL for an entry the read model lacks.
+	ListArchivableEntries(ctx context.Context, arg ListArchivableEntriesParams) ([]ListArchivableEntriesRow, error)
+	// Accounts with a segment for the month whose cached balance is not the balance carried by their
+	// pruned entries plus their entries still in Postgres, i.e. that fail reconciliation.
+	ListArchivedAccountDrift(ctx context.Context, period time.Time) ([]ListArchivedAccountDriftRow, error)
+	ListBalanceRepairEvents(ctx context.Context, repairID uuid.UUID) ([]BalanceRepairEvent, error)
+	ListBalanceRepairsByStatus(ctx context.Context, arg ListBalanceRepairsByStatusParams) ([]BalanceRepair, error)
+	ListBalanceSnapshots(ctx context.Context, limit int32) ([]BalanceSnapshot, error)
+	ListBankStatementImports(ctx context.Context, arg ListBankStatementImportsParams) ([]ListBankStatementImportsRow, error)
+	ListCashPoolMembers(ctx context.Context, poolID uuid.UUID) ([]CashPoolMember, error)
+	ListCashPoolMovements(ctx context.Context, arg ListCashPoolMovementsParams) ([]CashPoolMovement, error)
+	// Pools whose master account the user can see.
+	ListCashPoolsForUser(ctx context.Context, userID uuid.UUID) ([]CashPool, error)
+	ListCategories(ctx context.Context, userID uuid.UUID) ([]Category, error)
+	ListCategoryRules(ctx context.Context, userID uuid.UUID) ([]CategoryRule, error)
+	ListDisputesByAccount(ctx context.Context, accountID uuid.UUID) ([]Dispute, error)
+	ListDisputesByStatus(ctx context.Context, arg ListDisputesByStatusParams) ([]Dispute, error)
+	ListDueSavingsContributions(ctx context.Context, arg ListDueSavingsContributionsParams) ([]uuid.UUID, error)
+	ListDueSweepRules(ctx context.Context, arg ListDueSweepRulesParams) ([]SweepRule, error)
+	// Erasures whose grace period has ended, oldest first, keyset-paged by id within a run.
+	ListDueUserErasureIDs(ctx context.Context, arg ListDueUserErasureIDsParams) ([]uuid.UUID, error)
+	// Returns entries that follow the anchor entry in (created_at, id) order; used to resume streams.
+	ListEntriesByAccountAfter(ctx context.Context, arg ListEntriesByAccountAfterParams) ([]Entry, error)
+	ListEntriesByAccountBetween(ctx context.Context, arg ListEntriesByAccountBetweenParams) ([]Entry, error)
 	ListEntriesByTransaction(ctx context.Context, transactionID uuid.UUID) ([]Entry, error)
+	// Entries in chain order, a page at a time after (after_account_id, after_seq), with each
+	// account's recorded chain head.
+	ListEntryChain(ctx context.Context, arg ListEntryChainParams) ([]ListEntryChainRow, error)
+	// Escrows where the account is the buyer or the seller, newest first.
+	ListEscrowsByAccount(ctx context.Context, arg ListEscrowsByAccountParams) ([]Escrow, error)
+	ListFXRateOverrides(ctx context.Context) ([]FxRateOverride, error)
+	ListFXSpreads(ctx context.Context) ([]FxSpread, error)
+	ListFeeEntryIDs(ctx context.Context, transactionID uuid.UUID) ([]uuid.UUID, error)
+	ListGLCustomerCodes(ctx context.Context) ([]GlCustomerCode, error)
+	ListInboundPaymentsByStatus(ctx context.Context, arg ListInboundPaymentsByStatusParams) ([]InboundPayment, error)
+	ListInterestTiers(ctx context.Context, arg ListInterestTiersParams) ([]InterestTier, error)
+	ListJobsByStatus(ctx context.Context, arg ListJobsByStatusParams) ([]Job, error)
+	ListKYCDocuments(ctx context.Context, arg ListKYCDocumentsParams) ([]ListKYCDocumentsRow, error)
+	ListKYCRecordsByStatus(ctx context.Context, arg ListKYCRecordsByStatusParams) ([]KycRecord, error)
+	ListLedgerEventsByTransaction(ctx context.Context, transactionID uuid.UUID) ([]LedgerEvent, error)
+	// Accounts whose cached balance or entries disagree with the balance replayed from the log.
+	ListLedgerProjectionDrift(ctx context.Context) ([]ListLedgerProjectionDriftRow, error)
+	ListLoanInstallments(ctx context.Context, loanID uuid.UUID) ([]LoanInstallment, error)
+	ListLoanRepayments(ctx context.Context, loanID uuid.UUID) ([]LoanRepayment, error)
+	ListLoansByAccount(ctx context.Context, accountID uuid.UUID) ([]Loan, error)
+	ListLoginAttempts(ctx context.Context, arg ListLoginAttemptsParams) ([]LoginAttempt, error)
+	// The root's leaves in tree order, as the entries' current chain hashes; pruned entries keep the
+	// hash they had when pruned.
+	ListMerkleRootLeaves(ctx context.Context, rootID uuid.UUID) ([][]byte, error)
+	ListMerkleRoots(ctx context.Context, arg ListMerkleRootsParams) ([]MerkleRoot, error)
+	// The organization's loans, optionally only those with the given delinquency, newest first.
+	ListOrgLoans(ctx context.Context, arg ListOrgLoansParams) ([]Loan, error)
+	ListOrganizations(ctx context.Context, arg ListOrganizationsParams) ([]Organization, error)
+	// Chain heads of accounts that have no entries left.
+	ListOrphanEntryChainHeads(ctx context.Context, accountID uuid.NullUUID) ([]EntryChainHead, error)
+	ListOwnershipTransfersByStatus(ctx context.Context, arg ListOwnershipTransfersByStatusParams) ([]OwnershipTransfer, error)
+	ListPaymentRequestsByAccount(ctx context.Context, arg ListPaymentRequestsByAccountParams) ([]PaymentRequest, error)
+	ListPayoutBatchRows(ctx context.Context, batchID uuid.NullUUID) ([]TransferJob, error)
+	ListPayoutSuspenseCasesByStatus(ctx context.Context, arg ListPayoutSuspenseCasesByStatusParams) ([]PayoutSuspenseCase, error)
+	ListPendingPayoutsByProvider(ctx context.Context, arg ListPendingPayoutsByProviderParams) ([]Payout, error)
+	ListProductInterestTiers(ctx context.Context, product string) ([]InterestTier, error)
+	ListProducts(ctx context.Context) ([]Product, error)
+	// Each account's last pruned link of its hash chain, which its oldest entry left in Postgres follows.
+	ListPrunedChainLinks(ctx context.Context, accountID uuid.NullUUID) ([]ListPrunedChainLinksRow, error)
+	// The account's segments whose entries are no longer in Postgres, oldest month first.
+	ListPrunedEntryArchives(ctx context.Context, accountID uuid.UUID) ([]EntryArchive, error)
+	// Accounts credited by transfers debited from accounts the user owns or co-owns, most recent
+	// first. Fee legs (system accounts) and the user's own accounts are not recipients.
+	ListRecentRecipients(ctx context.Context, arg ListRecentRecipientsParams) ([]ListRecentRecipientsRow, error)
+	// A run's results in account ID order, or only the mismatches.
+	ListReconciliationResults(ctx context.Context, arg ListReconciliationResultsParams) ([]BalanceReconciliationResult, error)
+	ListReconciliationRuns(ctx context.Context, arg ListReconciliationRunsParams) ([]BalanceReconciliationRun, error)
+	// The accounts among the dumped chain heads whose restored head is missing or differs.
+	ListRestoredChainHeadMismatches(ctx context.Context, batch json.RawMessage) ([]uuid.UUID, error)
+	ListSanctionsScreeningsByStatus(ctx context.Context, arg ListSanctionsScreeningsByStatusParams) ([]SanctionsScreening, error)
+	// Goals on wallets the user can see, with the wallet balance as progress.
+	ListSavingsGoalsForUser(ctx context.Context, userID uuid.UUID) ([]ListSavingsGoalsForUserRow, error)
+	// Currencies customer money can enter and leave the ledger in: those of a settlement account with
+	// a payout clearing account beside it.
+	ListSettlementCurrencies(ctx context.Context) ([]string, error)
+	ListSubWallets(ctx context.Context, parentAccountID uuid.NullUUID) ([]Account, error)
+	ListSweepExecutions(ctx context.Context, arg ListSweepExecutionsParams) ([]SweepExecution, error)
+	ListSweepRulesByAccount(ctx context.Context, accountID uuid.UUID) ([]SweepRule, error)
+	// Every system account with its GL mapping; gl_code is empty when unmapped. Shards are left out:
+	// their entries export under the account they split.
+	ListSystemAccountGLCodes(ctx context.Context) ([]ListSystemAccountGLCodesRow, error)
+	ListTaxRules(ctx context.Context) ([]TaxRule, error)
+	// A user's notes on a transaction, oldest first.
+	ListTransactionAnnotations(ctx context.Context, arg ListTransactionAnnotationsParams) ([]TransactionAnnotation, error)
+	ListTransactionLimits(ctx context.Context) ([]TransactionLimit, error)
+	ListTransactionRefunds(ctx context.Context, transactionID uuid.UUID) ([]TransactionRefund, error)
+	ListTransactionsByRequestID(ctx context.Context, arg ListTransactionsByRequestIDParams) ([]Transaction, error)
+	ListTransactionsByStatus(ctx context.Context, arg ListTransactionsByStatusParams) ([]Transaction, error)
+	ListTransferRequestsByStatus(ctx context.Context, arg ListTransferRequestsByStatusParams) ([]TransferRequest, error)
+	// Accounts and months (UTC) with entries posted before the cutoff that no segment holds yet,
+	// oldest month first. The cutoff must fall on a month boundary so every month listed is closed.
+	ListUnarchivedPeriods(ctx context.Context, arg ListUnarchivedPeriodsParams) ([]ListUnarchivedPeriodsRow, error)
+	ListUnprunedEntryArchivesByPeriod(ctx context.Context, period time.Time) ([]EntryArchive, error)
+	// Entries posted before the cutoff that no root covers yet, oldest first.
+	ListUnrootedEntries(ctx context.Context, arg ListUnrootedEntriesParams) ([]ListUnrootedEntriesRow, error)
+	ListUserErasuresByStatus(ctx context.Context, arg ListUserErasuresByStatusParams) ([]UserErasure, error)
+	// Keyset-paged by id so the pii.rekey job can walk every stored phone.
+	ListUserPhones(ctx context.Context, arg ListUserPhonesParams) ([]ListUserPhonesRow, error)
+	// The admin user list across organizations. Every filter is optional; email matches any part of
+	// the address case-insensitively.
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListUsersByOrg(ctx context.Context, arg ListUsersByOrgParams) ([]User, error)
+	// Phones are not unique; callers treat more than one match as no match. Sealed phones are found
+	// by their blind index, and phones stored before encryption by their value.
+	ListUsersByPhone(ctx context.Context, arg ListUsersByPhoneParams) ([]User, error)
+	ListWebhookDeliveriesByOrg(ctx context.Context, arg ListWebhookDeliveriesByOrgParams) ([]WebhookDelivery, error)
+	ListWebhookEndpointsByOrg(ctx context.Context, orgID uuid.UUID) ([]WebhookEndpoint, error)
+	// Waits for postings in flight to commit and holds new ones back until the transaction ends, so
+	// the log does not move while projections are rebuilt from it.
+	LockLedgerEvents(ctx context.Context) error
+	// Serializes root building, so two builders never pick up the same entries.
+	LockMerkleRoots(ctx context.Context) error
+	// Keeps the original lock time when an already locked user is locked again.
+	LockUser(ctx context.Context, arg LockUserParams) (User, error)
+	MarkEntryArchivesPruned(ctx context.Context, period time.Time) (int64, error)
+	MarkPaymentChargeFailed(ctx context.Context, reference string) error
+	MarkPaymentChargeSucceeded(ctx context.Context, arg MarkPaymentChargeSucceededParams) (PaymentCharge, error)
+	MarkPaymentRequestPaid(ctx context.Context, arg MarkPaymentRequestPaidParams) (PaymentRequest, error)
+	MarkTransferJobPosted(ctx context.Context, id uuid.UUID) (TransferJob, error)
+	// Schedules the next attempt, or parks the delivery as dead once it runs out of attempts.
+	MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) (WebhookDelivery, error)
+	MarkWebhookDeliverySucceeded(ctx context.Context, id uuid.UUID) error
+	PayLoanInstallment(ctx context.Context, arg PayLoanInstallmentParams) error
+	// Adds the entries of a transaction just posted to the read model. The accounts are still locked
+	// and hold their balances after the posting, so an entry's balance_after is that balance less the
+	// account's legs that follow it in the transaction.
+	ProjectAccountHistory(ctx context.Context, transactionID uuid.UUID) error
+	// Deletes all but the latest keep snapshots.
+	PruneBalanceSnapshots(ctx context.Context, keep int32) (int64, error)
+	// Resets every cached balance that differs from the balance replayed from the log.
+	RebuildAccountBalances(ctx context.Context) (int64, error)
+	// Checks the next batch of accounts after after_id, in ID order, and records each one's result.
+	ReconcileAccountBatch(ctx context.Context, arg ReconcileAccountBatchParams) ([]BalanceReconciliationResult, error)
+	// Counts a failed password; the failure that reaches max_failures locks the login until
+	// locked_until and starts the count again.
+	RecordFailedLogin(ctx context.Context, arg RecordFailedLoginParams) (User, error)
+	RecordMonthlyStatement(ctx context.Context, arg RecordMonthlyStatementParams) error
+	RecordSavingsContribution(ctx context.Context, arg RecordSavingsContributionParams) error
+	// Counts a failed attempt; the endpoint turns unhealthy once failures reach the threshold.
+	RecordWebhookEndpointFailure(ctx context.Context, arg RecordWebhookEndpointFailureParams) (WebhookEndpoint, error)
+	RecordWebhookEndpointSuccess(ctx context.Context, id uuid.UUID) error
+	// Re-queues a dead (or delivered) delivery of the org for an immediate attempt with a fresh budget.
+	RedeliverWebhookDelivery(ctx context.Context, arg RedeliverWebhookDeliveryParams) (WebhookDelivery, error)
+	// Re-reads the counterparty name of history rows naming one of the user's accounts, e.g. once
+	// the user was erased.
+	RefreshCounterpartyNames(ctx context.Context, userID uuid.UUID) error
+	// Re-files one entry in the read model after its primary owner categorized it by hand.
+	RefreshEntryHistoryCategory(ctx context.Context, arg RefreshEntryHistoryCategoryParams) error
+	// Re-files the history of every account the user is primary owner of after their rules or
+	// categories changed.
+	RefreshUserHistoryCategories(ctx context.Context, userID uuid.UUID) error
+	// Replaces a hash with a stronger one for the same password, unless it changed since it was read.
+	RehashUserPassword(ctx context.Context, arg RehashUserPasswordParams) (int64, error)
+	RememberBeneficiary(ctx context.Context, arg RememberBeneficiaryParams) error
+	// Records that the user logged in from the device. new_device is true the first time it is seen,
+	// when both timestamps still hold the inserting transaction's time; other_devices says whether the
+	// user had logged in from any other device before.
+	RememberDevice(ctx context.Context, arg RememberDeviceParams) (RememberDeviceRow, error)
+	// Records that the user made a request from the country. new_country is true the first time it is
+	// seen. other_country is the country the user was last active in, other than this one, if that
+	// country was known before this one and active within window_seconds before this one first
+	// appeared, and empty otherwise.
+	RememberLocation(ctx context.Context, arg RememberLocationParams) (RememberLocationRow, error)
+	RemoveAccountOwner(ctx context.Context, arg RemoveAccountOwnerParams) (int64, error)
+	// Drops the user's access to accounts other users own; their own accounts keep their owner row.
+	RemoveCoOwnerships(ctx context.Context, userID uuid.UUID) error
+	// Resets one cached balance to the replayed one, unless it moved since it was read.
+	RepairAccountBalance(ctx context.Context, arg RepairAccountBalanceParams) (int64, error)
+	// Re-seals a KYC record's document details unless they changed since they were read.
+	ReplaceKYCDocument(ctx context.Context, arg ReplaceKYCDocumentParams) (int64, error)
+	// Re-seals a phone unless it changed since it was read.
+	ReplaceUserPhone(ctx context.Context, arg ReplaceUserPhoneParams) (int64, error)
+	// Every account's balance summed from its entries, starting from the snapshot's balances when one
+	// is given so only the entries posted after it are read, against the cached balance. Without a
+	// snapshot the sum starts from the balance carried by the account's pruned entries.
+	ReplayBalances(ctx context.Context, arg ReplayBalancesParams) ([]ReplayBalancesRow, error)
+	// Returns jobs whose worker died mid-run to the queue.
+	RequeueStaleJobs(ctx context.Context, staleBefore time.Time) (int64, error)
+	ResetFailedLogins(ctx context.Context, id uuid.UUID) error
+	ResolveDispute(ctx context.Context, arg ResolveDisputeParams) (Dispute, error)
+	ResolveInboundPayment(ctx context.Context, arg ResolveInboundPaymentParams) (InboundPayment, error)
+	ResolvePayoutSuspenseCase(ctx context.Context, arg ResolvePayoutSuspenseCaseParams) (PayoutSuspenseCase, error)
+	RestoreAccountHistory(ctx context.Context, batch json.RawMessage) (int64, error)
+	RestoreAccountShards(ctx context.Context, batch json.RawMessage) (int64, error)
+	// Inserts the accounts without their parents, which SetRestoredAccountParents sets once every
+	// account is in.
+	RestoreAccounts(ctx context.Context, batch json.RawMessage) (int64, error)
+	// Inserts the entries in order, which chains each one afresh, and returns the IDs of those whose
+	// recomputed hash is not the one they were dumped with.
+	RestoreEntries(ctx context.Context, batch json.RawMessage) ([]uuid.UUID, error)
+	RestoreEntryArchives(ctx context.Context, batch json.RawMessage) (int64, error)
+	RestoreLedgerEvents(ctx context.Context, batch json.RawMessage) (int64, error)
+	RestoreOrganizations(ctx context.Context, batch json.RawMessage) (int64, error)
+	// Products are seeded by migrations too and referenced by their configuration, so the dumped ones
+	// replace the seeded ones in place.
+	RestoreProducts(ctx context.Context, batch json.RawMessage) (int64, error)
+	RestoreTransactions(ctx context.Context, batch json.RawMessage) (int64, error)
+	// Inserts the users without their default accounts, which SetRestoredDefaultAccounts sets once the
+	// accounts are in.
+	RestoreUsers(ctx context.Context, batch json.RawMessage) (int64, error)
+	RetryJob(ctx context.Context, arg RetryJobParams) error
+	ReviewAMLAlert(ctx context.Context, arg ReviewAMLAlertParams) (AmlAlert, error)
+	// Only pending submissions can be reviewed; rejection keeps the previously approved level.
+	ReviewKYCRecord(ctx context.Context, arg ReviewKYCRecordParams) (KycRecord, error)
+	ReviewSanctionsScreening(ctx context.Context, arg ReviewSanctionsScreeningParams) (SanctionsScreening, error)
+	// Revoking an already revoked key keeps its original revoked_at.
+	RevokeAccountSigningKey(ctx context.Context, arg RevokeAccountSigningKeyParams) (AccountSigningKey, error)
+	// Keeps the original revocation time when a session is revoked twice.
+	RevokeSession(ctx context.Context, arg RevokeSessionParams) (Session, error)
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID) (int64, error)
+	// Replaces the org's endpoint secret; the old one keeps signing deliveries until previous_expires_at.
+	RotateWebhookEndpointSecret(ctx context.Context, arg RotateWebhookEndpointSecretParams) (WebhookEndpoint, error)
+	// The admin account browser. Every filter is optional; owner_email matches the primary owner
+	// case-insensitively, and the balance bounds are inclusive. sort takes the same keys as
+	// ListAccountsForUser. Shards of a split system account are not listed; their balances are
+	// added to the account's total_balance, which the balance filters and sorts use.
+	SearchAccounts(ctx context.Context, arg SearchAccountsParams) ([]SearchAccountsRow, error)
+	// Starts the chain of each account with pruned entries at its last pruned link, as pruning left
+	// it, so its restored entries chain on from there.
+	SeedRestoredEntryChainHeads(ctx context.Context) (int64, error)
+	// Moves an account and its sub-wallets to a new primary owner.
+	SetAccountOwner(ctx context.Context, arg SetAccountOwnerParams) error
+	SetBalanceSnapshotAccounts(ctx context.Context, arg SetBalanceSnapshotAccountsParams) (BalanceSnapshot, error)
+	SetDefaultAccount(ctx context.Context, arg SetDefaultAccountParams) error
+	SetEntryCategory(ctx context.Context, arg SetEntryCategoryParams) (EntryCategory, error)
+	SetPaymentChargeProviderReference(ctx context.Context, arg SetPaymentChargeProviderReferenceParams) error
+	SetPayoutProviderTransferID(ctx context.Context, arg SetPayoutProviderTransferIDParams) error
+	SetRestoredAccountParents(ctx context.Context, arg SetRestoredAccountParentsParams) (int64, error)
+	SetRestoredDefaultAccounts(ctx context.Context, arg SetRestoredDefaultAccountsParams) (int64, error)
+	SetUserLocale(ctx context.Context, arg SetUserLocaleParams) (User, error)
+	// An admin reset sets a temporary password with password_reset_required; the user's own change
+	// clears it.
+	SetUserPassword(ctx context.Context, arg SetUserPasswordParams) (User, error)
+	SetUserRole(ctx context.Context, arg SetUserRoleParams) (User, error)
+	// Scoped by org so an org admin can never change users of another tenant.
+	SetUserRoleInOrg(ctx context.Context, arg SetUserRoleInOrgParams) (User, error)
+	SetUserScreeningStatus(ctx context.Context, arg SetUserScreeningStatusParams) error
+	SettleEscrow(ctx context.Context, arg SettleEscrowParams) (Escrow, error)
+	// The settlement account and its shards' entries in [from, to) totalled per UTC day and operation
+	// type: debits are money arriving at the settlement bank, credits money leaving it.
+	SettlementActivity(ctx context.Context, arg SettlementActivityParams) ([]SettlementActivityRow, error)
+	SnapshotBalances(ctx context.Context, snapshotID uuid.UUID) (int64, error)
+	// Debits of the account between from_time and to_time per UTC period (day, week or month) and
+	// category: the user's manual assignment, else their first matching rule, else uncategorized.
+	SpendByCategory(ctx context.Context, arg SpendByCategoryParams) ([]SpendByCategoryRow, error)
+	SumTransactionRefunds(ctx context.Context, transactionID uuid.UUID) (string, error)
+	// Applies a product's overdraft to its customer top-level accounts in currency. Fails on the
+	// balance CHECK if an account is already overdrawn beyond the new limit.
+	SyncAccountOverdrafts(ctx context.Context, arg SyncAccountOverdraftsParams) (int64, error)
+	// Totals withheld per rule and currency in [from, to).
+	TaxReport(ctx context.Context, arg TaxReportParams) ([]TaxReportRow, error)
+	TouchAccountSigningKey(ctx context.Context, id uuid.UUID) error
+	TouchPayout(ctx context.Context, reference string) error
+	// Moves a transaction between statuses only from the expected one, so concurrent updates cannot skip a step.
+	TransitionTransactionStatus(ctx context.Context, arg TransitionTransactionStatusParams) (Transaction, error)
+	// Also lifts a temporary lockout from failed logins.
+	UnlockUser(ctx context.Context, id uuid.UUID) (User, error)
 	UpdateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) error
+	UpdateLoanStanding(ctx context.Context, arg UpdateLoanStandingParams) (Loan, error)
+	UpdateSavingsGoal(ctx context.Context, arg UpdateSavingsGoalParams) (SavingsGoal, error)
+	UpdateUserPhone(ctx context.Context, arg UpdateUserPhoneParams) error
+	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error)
+	UpsertAccountProduct(ctx context.Context, arg UpsertAccountProductParams) (AccountProduct, error)
+	UpsertFXRate(ctx context.Context, arg UpsertFXRateParams) error
+	UpsertFXRateOverride(ctx context.Context, arg UpsertFXRateOverrideParams) (FxRateOverride, error)
+	UpsertFXSpread(ctx context.Context, arg UpsertFXSpreadParams) (FxSpread, error)
+	UpsertGLAccountCode(ctx context.Context, arg UpsertGLAccountCodeParams) (GlAccountCode, error)
+	UpsertGLCustomerCode(ctx context.Context, arg UpsertGLCustomerCodeParams) (GlCustomerCode, error)
+	// Registers a schedule; next_run_at is only reset when the spec changed.
+	UpsertJobSchedule(ctx context.Context, arg UpsertJobScheduleParams) error
+	// A resubmission returns the record to review but keeps the previously approved level.
+	UpsertKYCSubmission(ctx context.Context, arg UpsertKYCSubmissionParams) (KycRecord, error)
+	UpsertNotificationPreferences(ctx context.Context, arg UpsertNotificationPreferencesParams) (NotificationPreference, error)
+	UpsertProduct(ctx context.Context, arg UpsertProductParams) (Product, error)
+	UpsertStatementPreference(ctx context.Context, arg UpsertStatementPreferenceParams) (StatementPreference, error)
+	UpsertTaxRule(ctx context.Context, arg UpsertTaxRuleParams) (TaxRule, error)
+	UpsertTransactionLimit(ctx context.Context, arg UpsertTransactionLimitParams) (TransactionLimit, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -12,44 +12,712 @@ import (
 	"github.com/google/uuid"
 )
 
+const countUsers = `-- name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE ($1::uuid IS NULL OR org_id = $1::uuid)
+  AND ($2::text IS NULL OR email ILIKE '%' || $2::text || '%')
+  AND ($3::text IS NULL OR role = $3::text)
+  AND ($4::boolean IS NULL OR (locked_at IS NOT NULL) = $4::boolean)
+`
+
+type CountUsersParams struct {
+	OrgID  uuid.NullUUID  `json:"org_id"`
+	Email  sql.NullString `json:"email"`
+	Role   sql.NullString `json:"role"`
+	Locked sql.NullBool   `json:"locked"`
+}
+
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUsers,
+		arg.OrgID,
+		arg.Email,
+		arg.Role,
+		arg.Locked,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (email, hashed_password)
-VALUES ($1, $2)
-RETURNING id, email, created_at
+INSERT INTO users (org_id, email, hashed_password, first_name, last_name)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, org_id, email, created_at
 `
 
 type CreateUserParams struct {
-	Email          string `json:"email"`
-	HashedPassword string `json:"hashed_password"`
+	OrgID          uuid.UUID `json:"org_id"`
+	Email          string    `json:"email"`
+	HashedPassword string    `json:"hashed_password"`
+	FirstName      string    `json:"first_name"`
+	LastName       string    `json:"last_name"`
 }
 
 type CreateUserRow struct {
 	ID        uuid.UUID    `json:"id"`
+	OrgID     uuid.UUID    `json:"org_id"`
 	Email     string       `json:"email"`
 	CreatedAt sql.NullTime `json:"created_at"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error) {
-	row := q.db.QueryRowContext(ctx, createUser, arg.Email, arg.HashedPassword)
+	row := q.db.QueryRowContext(ctx, createUser,
+		arg.OrgID,
+		arg.Email,
+		arg.HashedPassword,
+		arg.FirstName,
+		arg.LastName,
+	)
 	var i CreateUserRow
-	err := row.Scan(&i.ID, &i.Email, &i.CreatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.OrgID,
+		&i.Email,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const findUserByEmail = `-- name: FindUserByEmail :one
+SELECT id, email, hashed_password, created_at, phone, role, first_name, last_name, date_of_birth, address_line1, address_line2, city, state, postal_code, country, org_id, default_account_id, locked_at, locked_reason, password_reset_required, erased_at, phone_index, failed_logins, login_locked_until, screening_status, locale FROM users
+WHERE org_id = $1 AND lower(email) = lower($2::text)
+LIMIT 1
+`
+
+type FindUserByEmailParams struct {
+	OrgID uuid.UUID `json:"org_id"`
+	Email string    `json:"email"`
+}
+
+// Recipient lookup ignores case, unlike login.
+func (q *Queries) FindUserByEmail(ctx context.Context, arg FindUserByEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, findUserByEmail, arg.OrgID, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.CreatedAt,
+		&i.Phone,
+		&i.Role,
+		&i.FirstName,
+		&i.LastName,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.City,
+		&i.State,
+		&i.PostalCode,
+		&i.Country,
+		&i.OrgID,
+		&i.DefaultAccountID,
+		&i.LockedAt,
+		&i.LockedReason,
+		&i.PasswordResetRequired,
+		&i.ErasedAt,
+		&i.PhoneIndex,
+		&i.FailedLogins,
+		&i.LoginLockedUntil,
+		&i.ScreeningStatus,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, email, hashed_password, created_at, phone, role, first_name, last_name, date_of_birth, address_line1, address_line2, city, state, postal_code, country, org_id, default_account_id, locked_at, locked_reason, password_reset_required, erased_at, phone_index, failed_logins, login_locked_until, screening_status, locale FROM users
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.CreatedAt,
+		&i.Phone,
+		&i.Role,
+		&i.FirstName,
+		&i.LastName,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.City,
+		&i.State,
+		&i.PostalCode,
+		&i.Country,
+		&i.OrgID,
+		&i.DefaultAccountID,
+		&i.LockedAt,
+		&i.LockedReason,
+		&i.PasswordResetRequired,
+		&i.ErasedAt,
+		&i.PhoneIndex,
+		&i.FailedLogins,
+		&i.LoginLockedUntil,
+		&i.ScreeningStatus,
+		&i.Locale,
+	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, hashed_password, created_at FROM users
-WHERE email = $1
+SELECT id, email, hashed_password, created_at, phone, role, first_name, last_name, date_of_birth, address_line1, address_line2, city, state, postal_code, country, org_id, default_account_id, locked_at, locked_reason, password_reset_required, erased_at, phone_index, failed_logins, login_locked_until, screening_status, locale FROM users
+WHERE org_id = $1 AND email = $2
 LIMIT 1
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+type GetUserByEmailParams struct {
+	OrgID uuid.UUID `json:"org_id"`
+	Email string    `json:"email"`
+}
+
+// Emails are unique per organization, so login always names one.
+func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, arg.OrgID, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.CreatedAt,
+		&i.Phone,
+		&i.Role,
+		&i.FirstName,
+		&i.LastName,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.City,
+		&i.State,
+		&i.PostalCode,
+		&i.Country,
+		&i.OrgID,
+		&i.DefaultAccountID,
+		&i.LockedAt,
+		&i.LockedReason,
+		&i.PasswordResetRequired,
+		&i.ErasedAt,
+		&i.PhoneIndex,
+		&i.FailedLogins,
+		&i.LoginLockedUntil,
+		&i.ScreeningStatus,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, email, hashed_password, created_at, phone, role, first_name, last_name, date_of_birth, address_line1, address_line2, city, state, postal_code, country, org_id, default_account_id, locked_at, locked_reason, password_reset_required, erased_at, phone_index, failed_logins, login_locked_until, screening_status, locale FROM users
+WHERE ($1::uuid IS NULL OR org_id = $1::uuid)
+  AND ($2::text IS NULL OR email ILIKE '%' || $2::text || '%')
+  AND ($3::text IS NULL OR role = $3::text)
+  AND ($4::boolean IS NULL OR (locked_at IS NOT NULL) = $4::boolean)
+ORDER BY created_at, id
+LIMIT $6 OFFSET $5
+`
+
+type ListUsersParams struct {
+	OrgID     uuid.NullUUID  `json:"org_id"`
+	Email     sql.NullString `json:"email"`
+	Role      sql.NullString `json:"role"`
+	Locked    sql.NullBool   `json:"locked"`
+	RowOffset int32          `json:"row_offset"`
+	RowLimit  int32          `json:"row_limit"`
+}
+
+// The admin user list across organizations. Every filter is optional; email matches any part of
+// the address case-insensitively.
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers,
+		arg.OrgID,
+		arg.Email,
+		arg.Role,
+		arg.Locked,
+		arg.RowOffset,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.HashedPassword,
+			&i.CreatedAt,
+			&i.Phone,
+			&i.Role,
+			&i.FirstName,
+			&i.LastName,
+			&i.DateOfBirth,
+			&i.AddressLine1,
+			&i.AddressLine2,
+			&i.City,
+			&i.State,
+			&i.PostalCode,
+			&i.Country,
+			&i.OrgID,
+			&i.DefaultAccountID,
+			&i.LockedAt,
+			&i.LockedReason,
+			&i.PasswordResetRequired,
+			&i.ErasedAt,
+			&i.PhoneIndex,
+			&i.FailedLogins,
+			&i.LoginLockedUntil,
+			&i.ScreeningStatus,
+			&i.Locale,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByPhone = `-- name: ListUsersByPhone :many
+SELECT id, email, hashed_password, created_at, phone, role, first_name, last_name, date_of_birth, address_line1, address_line2, city, state, postal_code, country, org_id, default_account_id, locked_at, locked_reason, password_reset_required, erased_at, phone_index, failed_logins, login_locked_until, screening_status, locale FROM users
+WHERE org_id = $1
+  AND (phone_index = $2 OR (phone_index IS NULL AND phone = $3::text))
+LIMIT 2
+`
+
+type ListUsersByPhoneParams struct {
+	OrgID      uuid.UUID      `json:"org_id"`
+	PhoneIndex sql.NullString `json:"phone_index"`
+	Phone      string         `json:"phone"`
+}
+
+// Phones are not unique; callers treat more than one match as no match. Sealed phones are found
+// by their blind index, and phones stored before encryption by their value.
+func (q *Queries) ListUsersByPhone(ctx context.Context, arg ListUsersByPhoneParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersByPhone, arg.OrgID, arg.PhoneIndex, arg.Phone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.HashedPassword,
+			&i.CreatedAt,
+			&i.Phone,
+			&i.Role,
+			&i.FirstName,
+			&i.LastName,
+			&i.DateOfBirth,
+			&i.AddressLine1,
+			&i.AddressLine2,
+			&i.City,
+			&i.State,
+			&i.PostalCode,
+			&i.Country,
+			&i.OrgID,
+			&i.DefaultAccountID,
+			&i.LockedAt,
+			&i.LockedReason,
+			&i.PasswordResetRequired,
+			&i.ErasedAt,
+			&i.PhoneIndex,
+			&i.FailedLogins,
+			&i.LoginLockedUntil,
+			&i.ScreeningStatus,
+			&i.Locale,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockUser = `-- name: LockUser :one
+UPDATE users
+SET locked_at = COALESCE(locked_at, CURRENT_TIMESTAMP),
+    locked_reason = $1
+WHERE id = $2
+RETURNING id, email, hashed_password, created_at, phone, role, first_name, last_name, date_of_birth, address_line1, address_line2, city, state, postal_code, country, org_id, default_account_id, locked_at, locked_reason, password_reset_required, erased_at, phone_index, failed_logins, login_locked_until, screening_status, locale
+`
+
+type LockUserParams struct {
+	LockedReason string    `json:"locked_reason"`
+	ID           uuid.UUID `json:"id"`
+}
+
+// Keeps the original lock time when an already locked user is locked again.
+func (q *Queries) LockUser(ctx context.Context, arg LockUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, lockUser, arg.LockedReason, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.CreatedAt,
+		&i.Phone,
+		&i.Role,
+		&i.FirstName,
+		&i.LastName,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.City,
+		&i.State,
+		&i.PostalCode,
+		&i.Country,
+		&i.OrgID,
+		&i.DefaultAccountID,
+		&i.LockedAt,
+		&i.LockedReason,
+		&i.PasswordResetRequired,
+		&i.ErasedAt,
+		&i.PhoneIndex,
+		&i.FailedLogins,
+		&i.LoginLockedUntil,
+		&i.ScreeningStatus,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const rehashUserPassword = `-- name: RehashUserPassword :execrows
+UPDATE users
+SET hashed_password = $1
+WHERE id = $2 AND hashed_password = $3
+`
+
+type RehashUserPasswordParams struct {
+	NewHash string    `json:"new_hash"`
+	ID      uuid.UUID `json:"id"`
+	OldHash string    `json:"old_hash"`
+}
+
+// Replaces a hash with a stronger one for the same password, unless it changed since it was read.
+func (q *Queries) RehashUserPassword(ctx context.Context, arg RehashUserPasswordParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, rehashUserPassword, arg.NewHash, arg.ID, arg.OldHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setDefaultAccount = `-- name: SetDefaultAccount :exec
+UPDATE users
+SET default_account_id = $2
+WHERE id = $1
+`
+
+type SetDefaultAccountParams struct {
+	ID               uuid.UUID     `json:"id"`
+	DefaultAccountID uuid.NullUUID `json:"default_account_id"`
+}
+
+func (q *Queries) SetDefaultAccount(ctx context.Context, arg SetDefaultAccountParams) error {
+	_, err := q.db.ExecContext(ctx, setDefaultAccount, arg.ID, arg.DefaultAccountID)
+	return err
+}
+
+const setUserLocale = `-- name: SetUserLocale :one
+UPDATE users
+SET locale = $1
+WHERE id = $2
+RETURNING id, email, hashed_password, created_at, phone, role, first_name, last_name, date_of_birth, address_line1, address_line2, city, state, postal_code, country, org_id, default_account_id, locked_at, locked_reason, password_reset_required, erased_at, phone_index, failed_logins, login_locked_until, screening_status, locale
+`
+
+type SetUserLocaleParams struct {
+	Locale string    `json:"locale"`
+	ID     uuid.UUID `json:"id"`
+}
+
+func (q *Queries) SetUserLocale(ctx context.Context, arg SetUserLocaleParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, setUserLocale, arg.Locale, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.CreatedAt,
+		&i.Phone,
+		&i.Role,
+		&i.FirstName,
+		&i.LastName,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.City,
+		&i.State,
+		&i.PostalCode,
+		&i.Country,
+		&i.OrgID,
+		&i.DefaultAccountID,
+		&i.LockedAt,
+		&i.LockedReason,
+		&i.PasswordResetRequired,
+		&i.ErasedAt,
+		&i.PhoneIndex,
+		&i.FailedLogins,
+		&i.LoginLockedUntil,
+		&i.ScreeningStatus,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const setUserPassword = `-- name: SetUserPassword :one
+UPDATE users
+SET hashed_password = $1,
+    password_reset_required = $2
+WHERE id = $3
+RETURNING id, email, hashed_password, created_at, phone, role, first_name, last_name, date_of_birth, address_line1, address_line2, city, state, postal_code, country, org_id, default_account_id, locked_at, locked_reason, password_reset_required, erased_at, phone_index, failed_logins, login_locked_until, screening_status, locale
+`
+
+type SetUserPasswordParams struct {
+	HashedPassword        string    `json:"hashed_password"`
+	PasswordResetRequired bool      `json:"password_reset_required"`
+	ID                    uuid.UUID `json:"id"`
+}
+
+// An admin reset sets a temporary password with password_reset_required; the user's own change
+// clears it.
+func (q *Queries) SetUserPassword(ctx context.Context, arg SetUserPasswordParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, setUserPassword, arg.HashedPassword, arg.PasswordResetRequired, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.CreatedAt,
+		&i.Phone,
+		&i.Role,
+		&i.FirstName,
+		&i.LastName,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.City,
+		&i.State,
+		&i.PostalCode,
+		&i.Country,
+		&i.OrgID,
+		&i.DefaultAccountID,
+		&i.LockedAt,
+		&i.LockedReason,
+		&i.PasswordResetRequired,
+		&i.ErasedAt,
+		&i.PhoneIndex,
+		&i.FailedLogins,
+		&i.LoginLockedUntil,
+		&i.ScreeningStatus,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const setUserRole = `-- name: SetUserRole :one
+UPDATE users
+SET role = $1
+WHERE id = $2
+RETURNING id, email, hashed_password, created_at, phone, role, first_name, last_name, date_of_birth, address_line1, address_line2, city, state, postal_code, country, org_id, default_account_id, locked_at, locked_reason, password_reset_required, erased_at, phone_index, failed_logins, login_locked_until, screening_status, locale
+`
+
+type SetUserRoleParams struct {
+	Role string    `json:"role"`
+	ID   uuid.UUID `json:"id"`
+}
+
+func (q *Queries) SetUserRole(ctx context.Context, arg SetUserRoleParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, setUserRole, arg.Role, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.CreatedAt,
+		&i.Phone,
+		&i.Role,
+		&i.FirstName,
+		&i.LastName,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.City,
+		&i.State,
+		&i.PostalCode,
+		&i.Country,
+		&i.OrgID,
+		&i.DefaultAccountID,
+		&i.LockedAt,
+		&i.LockedReason,
+		&i.PasswordResetRequired,
+		&i.ErasedAt,
+		&i.PhoneIndex,
+		&i.FailedLogins,
+		&i.LoginLockedUntil,
+		&i.ScreeningStatus,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const unlockUser = `-- name: UnlockUser :one
+UPDATE users
+SET locked_at = NULL,
+    locked_reason = '',
+    failed_logins = 0,
+    login_locked_until = NULL
+WHERE id = $1
+RETURNING id, email, hashed_password, created_at, phone, role, first_name, last_name, date_of_birth, address_line1, address_line2, city, state, postal_code, country, org_id, default_account_id, locked_at, locked_reason, password_reset_required, erased_at, phone_index, failed_logins, login_locked_until, screening_status, locale
+`
+
+// Also lifts a temporary lockout from failed logins.
+func (q *Queries) UnlockUser(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, unlockUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.CreatedAt,
+		&i.Phone,
+		&i.Role,
+		&i.FirstName,
+		&i.LastName,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.City,
+		&i.State,
+		&i.PostalCode,
+		&i.Country,
+		&i.OrgID,
+		&i.DefaultAccountID,
+		&i.LockedAt,
+		&i.LockedReason,
+		&i.PasswordResetRequired,
+		&i.ErasedAt,
+		&i.PhoneIndex,
+		&i.FailedLogins,
+		&i.LoginLockedUntil,
+		&i.ScreeningStatus,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const updateUserPhone = `-- name: UpdateUserPhone :exec
+UPDATE users
+SET phone = $2,
+    phone_index = $3
+WHERE id = $1
+`
+
+type UpdateUserPhoneParams struct {
+	ID         uuid.UUID      `json:"id"`
+	Phone      sql.NullString `json:"phone"`
+	PhoneIndex sql.NullString `json:"phone_index"`
+}
+
+func (q *Queries) UpdateUserPhone(ctx context.Context, arg UpdateUserPhoneParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPhone, arg.ID, arg.Phone, arg.PhoneIndex)
+	return err
+}
+
+const updateUserProfile = `-- name: UpdateUserProfile :one
+UPDATE users
+SET first_name = $1,
+    last_name = $2,
+    phone = $3,
+    phone_index = $4,
+    date_of_birth = $5,
+    address_line1 = $6,
+    address_line2 = $7,
+    city = $8,
+    state = $9,
+    postal_code = $10,
+    country = $11
+WHERE id = $12
+RETURNING id, email, hashed_password, created_at, phone, role, first_name, last_name, date_of_birth, address_line1, address_line2, city, state, postal_code, country, org_id, default_account_id, locked_at, locked_reason, password_reset_required, erased_at, phone_index, failed_logins, login_locked_until, screening_status, locale
+`
+
+type UpdateUserProfileParams struct {
+	FirstName    string         `json:"first_name"`
+	LastName     string         `json:"last_name"`
+	Phone        sql.NullString `json:"phone"`
+	PhoneIndex   sql.NullString `json:"phone_index"`
+	DateOfBirth  sql.NullTime   `json:"date_of_birth"`
+	AddressLine1 string         `json:"address_line1"`
+	AddressLine2 string         `json:"address_line2"`
+	City         string         `json:"city"`
+	State        string         `json:"state"`
+	PostalCode   string         `json:"postal_code"`
+	Country      string         `json:"country"`
+	ID           uuid.UUID      `json:"id"`
+}
+
+func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserProfile,
+		arg.FirstName,
+		arg.LastName,
+		arg.Phone,
+		arg.PhoneIndex,
+		arg.DateOfBirth,
+		arg.AddressLine1,
+		arg.AddressLine2,
+		arg.City,
+		arg.State,
+		arg.PostalCode,
+		arg.Country,
+		arg.ID,
+	)
 	var i User
 	err := row.Scan(
 		&i.ID,
 		&i.Email,
 		&i.HashedPassword,
 		&i.CreatedAt,
+		&i.Phone,
+		&i.Role,
+		&i.FirstName,
+		&i.LastName,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.City,
+		&i.State,
+		&i.PostalCode,
+		&i.Country,
+		&i.OrgID,
+		&i.DefaultAccountID,
+		&i.LockedAt,
+		&i.LockedReason,
+		&i.PasswordResetRequired,
+		&i.ErasedAt,
+		&i.PhoneIndex,
+		&i.FailedLogins,
+		&i.LoginLockedUntil,
+		&i.ScreeningStatus,
+		&i.Locale,
 	)
 	return i, err
 }
@@ -0,0 +1,153 @@
+// Package httpsig signs and verifies HTTP requests with Ed25519 keys in the style of the
+// draft-cavage HTTP Signatures scheme. The ledger requires it for transfers above a configured
+// amount, on top of the bearer token, so a stolen token alone cannot move large sums. Clients
+// register the public key on the account and sign each such request:
+//
+//	req, _ := http.NewRequest(http.MethodPost, base+"/transfers", bytes.NewReader(body))
+//	req.Header.Set("Authorization", "Bearer "+token)
+//	if err := httpsig.Sign(req, body, keyID, privateKey); err != nil {
+//		return err
+//	}
+//
+// The signature covers the method and path, the Date header and a SHA-256 Digest of the body.
+package httpsig
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// Algorithm is the only signature algorithm supported.
+	Algorithm = "ed25519"
+	// MaxSkew is how far a request's Date may be from the server's clock.
+	MaxSkew = 5 * time.Minute
+)
+
+// RequiredHeaders must all be covered by a signature.
+var RequiredHeaders = []string{"(request-target)", "date", "digest"}
+
+// Verification errors.
+var (
+	ErrMissing   = errors.New("httpsig: request is not signed")
+	ErrMalformed = errors.New("httpsig: malformed Signature header")
+	ErrDigest    = errors.New("httpsig: Digest does not match the body")
+	ErrDate      = errors.New("httpsig: Date missing or too far from the server's clock")
+	ErrInvalid   = errors.New("httpsig: signature does not verify")
+)
+
+// Signature is a parsed Signature header.
+type Signature struct {
+	KeyID     string
+	Algorithm string
+	Headers   []string
+	Value     []byte
+}
+
+// Digest is the Digest header value for body.
+func Digest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// Sign sets the Date, Digest and Signature headers of r, whose body is body, signed by key.
+func Sign(r *http.Request, body []byte, keyID string, key ed25519.PrivateKey) error {
+	r.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	r.Header.Set("Digest", Digest(body))
+	signing, err := SigningString(r, RequiredHeaders)
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Signature", fmt.Sprintf(`keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		keyID, Algorithm, strings.Join(RequiredHeaders, " "), base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte(signing)))))
+	return nil
+}
+
+// Parse reads r's Signature header, returning ErrMissing when there is none.
+func Parse(r *http.Request) (Signature, error) {
+	raw := r.Header.Get("Signature")
+	if raw == "" {
+		return Signature{}, ErrMissing
+	}
+	params := map[string]string{}
+	for _, part := range strings.Split(raw, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+			return Signature{}, ErrMalformed
+		}
+		params[name] = value[1 : len(value)-1]
+	}
+	sig := Signature{KeyID: params["keyId"], Algorithm: params["algorithm"], Headers: strings.Fields(strings.ToLower(params["headers"]))}
+	value, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil || sig.KeyID == "" || len(value) == 0 {
+		return Signature{}, ErrMalformed
+	}
+	sig.Value = value
+	return sig, nil
+}
+
+// SigningString is what a signature covering headers signs: one "name: value" line per header,
+// where (request-target) is the lowercase method and the request URI.
+func SigningString(r *http.Request, headers []string) (string, error) {
+	lines := make([]string, 0, len(headers))
+	for _, h := range headers {
+		if h == "(request-target)" {
+			lines = append(lines, h+": "+strings.ToLower(r.Method)+" "+r.URL.RequestURI())
+			continue
+		}
+		v := r.Header.Get(h)
+		if v == "" {
+			return "", fmt.Errorf("httpsig: signed header %q is missing", h)
+		}
+		lines = append(lines, h+": "+v)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// Verify checks that sig, parsed from r, signs r with key: it must use Ed25519, cover
+// RequiredHeaders, carry a Digest matching body and a Date within MaxSkew of now.
+func Verify(r *http.Request, body []byte, sig Signature, key ed25519.PublicKey, now time.Time) error {
+	if sig.Algorithm != "" && sig.Algorithm != Algorithm {
+		return fmt.Errorf("%w: algorithm must be %s", ErrMalformed, Algorithm)
+	}
+	for _, want := range RequiredHeaders {
+		covered := false
+		for _, h := range sig.Headers {
+			covered = covered || h == want
+		}
+		if !covered {
+			return fmt.Errorf("%w: headers must include %s", ErrMalformed, strings.Join(RequiredHeaders, " "))
+		}
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("Digest")), []byte(Digest(body))) != 1 {
+		return ErrDigest
+	}
+	date, err := http.ParseTime(r.Header.Get("Date"))
+	if err != nil || date.Sub(now) > MaxSkew || now.Sub(date) > MaxSkew {
+		return ErrDate
+	}
+	signing, err := SigningString(r, sig.Headers)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if !ed25519.Verify(key, []byte(signing), sig.Value) {
+		return ErrInvalid
+	}
+	return nil
+}
+
+// ParsePublicKey reads an Ed25519 public key given as base64 of its 32 raw bytes.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, errors.New("public_key must be the base64 of a 32-byte Ed25519 public key")
+	}
+	return ed25519.PublicKey(raw), nil
+}
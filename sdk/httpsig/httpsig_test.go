@@ -0,0 +1,84 @@
+package httpsig
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedRequest returns a POST of body signed with a fresh key, and the key's public half.
+func signedRequest(t *testing.T, body []byte) (*http.Request, ed25519.PublicKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, "/transfers?async=true", bytes.NewReader(body))
+	require.NoError(t, Sign(r, body, "key-1", priv))
+	return r, pub
+}
+
+func TestSignVerify(t *testing.T) {
+	// A signed request verifies with the registered key and names it.
+	body := []byte(`{"amount":"5000"}`)
+	r, pub := signedRequest(t, body)
+
+	sig, err := Parse(r)
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", sig.KeyID)
+	assert.NoError(t, Verify(r, body, sig, pub, time.Now()))
+
+	other, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, Verify(r, body, sig, other, time.Now()), ErrInvalid)
+}
+
+func TestVerify_Tampering(t *testing.T) {
+	// Changing the body, the target or replaying it later fails verification.
+	body := []byte(`{"amount":"5000"}`)
+	r, pub := signedRequest(t, body)
+	sig, err := Parse(r)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, Verify(r, []byte(`{"amount":"9000"}`), sig, pub, time.Now()), ErrDigest)
+	assert.ErrorIs(t, Verify(r, body, sig, pub, time.Now().Add(time.Hour)), ErrDate)
+
+	r.URL.Path = "/accounts/x/transfers/external"
+	assert.ErrorIs(t, Verify(r, body, sig, pub, time.Now()), ErrInvalid)
+}
+
+func TestVerify_RequiresCoverage(t *testing.T) {
+	// Signatures that leave the body or date unsigned are refused.
+	body := []byte(`{}`)
+	r, pub := signedRequest(t, body)
+	sig, err := Parse(r)
+	require.NoError(t, err)
+	sig.Headers = []string{"(request-target)", "date"}
+	assert.ErrorIs(t, Verify(r, body, sig, pub, time.Now()), ErrMalformed)
+}
+
+func TestParse(t *testing.T) {
+	// Missing and garbled headers are told apart.
+	r := httptest.NewRequest(http.MethodPost, "/transfers", nil)
+	_, err := Parse(r)
+	assert.ErrorIs(t, err, ErrMissing)
+	r.Header.Set("Signature", `keyId=k1,signature="x"`)
+	_, err = Parse(r)
+	assert.ErrorIs(t, err, ErrMalformed)
+}
+
+func TestParsePublicKey(t *testing.T) {
+	// Only 32-byte base64 keys are accepted.
+	pub, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	got, err := ParsePublicKey(base64.StdEncoding.EncodeToString(pub))
+	require.NoError(t, err)
+	assert.Equal(t, pub, got)
+	_, err = ParsePublicKey("c2hvcnQ=")
+	assert.Error(t, err)
+}
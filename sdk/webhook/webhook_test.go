@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// signed builds the headers of a delivery of body signed at ts with each of secrets.
+func signed(ts time.Time, body []byte, secrets ...string) http.Header {
+	sigs := make([]string, 0, len(secrets))
+	for _, s := range secrets {
+		sigs = append(sigs, Sign(s, ts.Unix(), body))
+	}
+	h := http.Header{}
+	h.Set(TimestampHeader, strconv.FormatInt(ts.Unix(), 10))
+	h.Set(SignatureHeader, strings.Join(sigs, ","))
+	return h
+}
+
+func TestVerify(t *testing.T) {
+	// A delivery verifies with its secret and is refused when tampered with or unsigned.
+	body := []byte(`{"type":"transaction.posted"}`)
+	now := time.Now()
+
+	assert.NoError(t, Verify(signed(now, body, "whsec_a"), body, 0, "whsec_a"))
+	assert.ErrorIs(t, Verify(signed(now, body, "whsec_a"), []byte(`{}`), 0, "whsec_a"), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(signed(now, body, "whsec_a"), body, 0, "whsec_b"), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(http.Header{}, body, 0, "whsec_a"), ErrMissingSignature)
+}
+
+func TestVerify_Rotation(t *testing.T) {
+	// During a rotation either secret verifies a delivery signed with both.
+	body := []byte(`{}`)
+	h := signed(time.Now(), body, "whsec_new", "whsec_old")
+	assert.NoError(t, Verify(h, body, 0, "whsec_old"))
+	assert.NoError(t, Verify(h, body, 0, "whsec_new"))
+	assert.NoError(t, Verify(signed(time.Now(), body, "whsec_old"), body, 0, "whsec_new", "whsec_old"))
+}
+
+func TestVerify_Replay(t *testing.T) {
+	// Deliveries signed outside the tolerance are refused.
+	body := []byte(`{}`)
+	old := signed(time.Now().Add(-10*time.Minute), body, "whsec_a")
+	assert.ErrorIs(t, Verify(old, body, 0, "whsec_a"), ErrStaleTimestamp)
+	assert.NoError(t, Verify(old, body, time.Hour, "whsec_a"))
+}